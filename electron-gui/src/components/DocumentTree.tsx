@@ -118,7 +118,7 @@ function TreeNode({ node, selectedPath, onSelect, onNavigate, depth = 0 }: TreeN
             DOC_TYPE_COLORS[node.doc_type] || 'text-dark-400',
             'bg-dark-700/50'
           )}>
-            {node.doc_type}
+            {node.doc_type_name || node.doc_type}
           </span>
         )}
       </div>
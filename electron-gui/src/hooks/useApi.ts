@@ -726,6 +726,7 @@ export interface DocumentTreeNode {
   path: string
   type: 'file' | 'directory'
   doc_type?: string
+  doc_type_name?: string
   children?: DocumentTreeNode[]
 }
 
@@ -95,6 +95,8 @@ export interface BuildFailedData {
 
 export interface UseSSEOptions {
   filters?: EventType[]
+  topics?: string[] // server-side topic filter (e.g. ['ports', 'escalations'])
+  project?: string
   sessionId?: string
   maxEvents?: number
   autoReconnect?: boolean
@@ -133,6 +135,8 @@ function getSSEBaseUrl(baseUrl: string): string {
 export function useSSE(options: UseSSEOptions = {}): UseSSEResult {
   const {
     filters = [],
+    topics = [],
+    project,
     sessionId,
     maxEvents = DEFAULT_MAX_EVENTS,
     autoReconnect = true,
@@ -234,6 +238,12 @@ export function useSSE(options: UseSSEOptions = {}): UseSSEResult {
     if (filters.length > 0) {
       params.set('filter', filters.join(','))
     }
+    if (topics.length > 0) {
+      params.set('topics', topics.join(','))
+    }
+    if (project) {
+      params.set('project', project)
+    }
     if (sessionId) {
       params.set('session_id', sessionId)
     }
@@ -326,7 +336,7 @@ export function useSSE(options: UseSSEOptions = {}): UseSSEResult {
     } catch (err) {
       setError(err instanceof Error ? err : new Error('Failed to create EventSource'))
     }
-  }, [resolvedBaseUrl, filters, sessionId, maxEvents, autoReconnect, reconnectDelay])
+  }, [resolvedBaseUrl, filters, topics, project, sessionId, maxEvents, autoReconnect, reconnectDelay])
 
   const reconnect = useCallback(() => {
     isConnectedRef.current = false
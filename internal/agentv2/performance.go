@@ -0,0 +1,214 @@
+package agentv2
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+)
+
+// PerformanceUpdate holds the fields to correct on a performance record (nil = 변경 없음)
+type PerformanceUpdate struct {
+	AgentVersion *int
+	Outcome      *string
+	QualityScore *float64
+	AttentionAvg *float64
+	TokenUsed    *int
+	Feedback     *string
+}
+
+// ListPerformance lists performance records of an agent, newest first (version 0 = 전체)
+func (s *Store) ListPerformance(agentID string, version, limit int) ([]*AgentPerformance, error) {
+	query := `
+		SELECT id, agent_id, agent_version, session_id, COALESCE(attention_avg, 0), COALESCE(attention_min, 0),
+		       COALESCE(token_used, 0), COALESCE(compact_count, 0), COALESCE(completion_time_seconds, 0),
+		       COALESCE(outcome, ''), COALESCE(quality_score, 0), COALESCE(feedback, ''),
+		       COALESCE(improvement_suggestions, ''), created_at
+		FROM agent_performance
+		WHERE agent_id = ?
+	`
+	args := []interface{}{agentID}
+	if version > 0 {
+		query += ` AND agent_version = ?`
+		args = append(args, version)
+	}
+	query += ` ORDER BY created_at DESC`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("성능 기록 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*AgentPerformance
+	for rows.Next() {
+		perf, err := scanPerformance(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, perf)
+	}
+	return records, rows.Err()
+}
+
+// GetPerformance returns a single performance record
+func (s *Store) GetPerformance(id string) (*AgentPerformance, error) {
+	row := s.db.QueryRow(`
+		SELECT id, agent_id, agent_version, session_id, COALESCE(attention_avg, 0), COALESCE(attention_min, 0),
+		       COALESCE(token_used, 0), COALESCE(compact_count, 0), COALESCE(completion_time_seconds, 0),
+		       COALESCE(outcome, ''), COALESCE(quality_score, 0), COALESCE(feedback, ''),
+		       COALESCE(improvement_suggestions, ''), created_at
+		FROM agent_performance WHERE id = ?
+	`, id)
+	perf, err := scanPerformance(row)
+	if err == sql.ErrNoRows {
+		return nil, errcode.New(errcode.KindNotFound, "성능 기록 '%s'을(를) 찾을 수 없습니다", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("성능 기록 조회 실패: %w", err)
+	}
+	return perf, nil
+}
+
+// UpdatePerformance corrects a performance record and recomputes the affected version averages
+func (s *Store) UpdatePerformance(id string, u PerformanceUpdate) (*AgentPerformance, error) {
+	perf, err := s.GetPerformance(id)
+	if err != nil {
+		return nil, err
+	}
+	oldVersion := perf.AgentVersion
+
+	if u.AgentVersion != nil {
+		if *u.AgentVersion <= 0 {
+			return nil, errcode.New(errcode.KindValidation, "버전은 1 이상이어야 합니다")
+		}
+		perf.AgentVersion = *u.AgentVersion
+	}
+	if u.Outcome != nil {
+		switch *u.Outcome {
+		case "success", "partial", "failed":
+		default:
+			return nil, errcode.New(errcode.KindValidation, "알 수 없는 결과: %s (success|partial|failed)", *u.Outcome)
+		}
+		perf.Outcome = *u.Outcome
+	}
+	if u.QualityScore != nil {
+		if *u.QualityScore < 0 || *u.QualityScore > 1 {
+			return nil, errcode.New(errcode.KindValidation, "품질 점수는 0.0~1.0 사이여야 합니다")
+		}
+		perf.QualityScore = *u.QualityScore
+	}
+	if u.AttentionAvg != nil {
+		perf.AttentionAvg = *u.AttentionAvg
+	}
+	if u.TokenUsed != nil {
+		if *u.TokenUsed < 0 {
+			return nil, errcode.New(errcode.KindValidation, "토큰은 0 이상이어야 합니다")
+		}
+		perf.TokenUsed = *u.TokenUsed
+	}
+	if u.Feedback != nil {
+		perf.Feedback = *u.Feedback
+	}
+
+	_, err = s.db.Exec(`
+		UPDATE agent_performance SET
+			agent_version = ?, outcome = ?, quality_score = ?, attention_avg = ?, token_used = ?, feedback = ?
+		WHERE id = ?
+	`, perf.AgentVersion, perf.Outcome, perf.QualityScore, perf.AttentionAvg, perf.TokenUsed, perf.Feedback, id)
+	if err != nil {
+		return nil, fmt.Errorf("성능 기록 수정 실패: %w", err)
+	}
+
+	s.recomputeVersion(perf.AgentID, perf.AgentVersion)
+	if oldVersion != perf.AgentVersion {
+		s.recomputeVersion(perf.AgentID, oldVersion)
+	}
+	return perf, nil
+}
+
+// DeletePerformance removes a performance record and recomputes its version averages
+func (s *Store) DeletePerformance(id string) (*AgentPerformance, error) {
+	perf, err := s.GetPerformance(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.Exec(`DELETE FROM agent_performance WHERE id = ?`, id); err != nil {
+		return nil, fmt.Errorf("성능 기록 삭제 실패: %w", err)
+	}
+	s.recomputeVersion(perf.AgentID, perf.AgentVersion)
+	return perf, nil
+}
+
+// RecomputeVersionStats recomputes usage counts and averages of every version of an agent
+// from the remaining performance records ("" = 모든 에이전트). 갱신한 버전 수를 반환합니다.
+func (s *Store) RecomputeVersionStats(agentID string) (int, error) {
+	query := `SELECT agent_id, version FROM agent_versions`
+	var args []interface{}
+	if agentID != "" {
+		query += ` WHERE agent_id = ?`
+		args = append(args, agentID)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("버전 조회 실패: %w", err)
+	}
+	type agentVersion struct {
+		agentID string
+		version int
+	}
+	var versions []agentVersion
+	for rows.Next() {
+		var v agentVersion
+		if err := rows.Scan(&v.agentID, &v.version); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		versions = append(versions, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, v := range versions {
+		s.recomputeVersion(v.agentID, v.version)
+	}
+	return len(versions), nil
+}
+
+// recomputeVersion resets the usage count and averages of a version from its performance records
+func (s *Store) recomputeVersion(agentID string, version int) {
+	_, _ = s.db.Exec(`
+		UPDATE agent_versions SET usage_count = (
+			SELECT COUNT(*) FROM agent_performance WHERE agent_id = ? AND agent_version = ?
+		)
+		WHERE agent_id = ? AND version = ?
+	`, agentID, version, agentID, version)
+	s.updateVersionStats(agentID, version)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPerformance(row rowScanner) (*AgentPerformance, error) {
+	var perf AgentPerformance
+	var suggestions string
+	if err := row.Scan(&perf.ID, &perf.AgentID, &perf.AgentVersion, &perf.SessionID,
+		&perf.AttentionAvg, &perf.AttentionMin, &perf.TokenUsed, &perf.CompactCount,
+		&perf.CompletionTimeSeconds, &perf.Outcome, &perf.QualityScore, &perf.Feedback,
+		&suggestions, &perf.CreatedAt); err != nil {
+		return nil, err
+	}
+	if suggestions != "" {
+		json.Unmarshal([]byte(suggestions), &perf.ImprovementSuggestions)
+	}
+	return &perf, nil
+}
@@ -52,6 +52,13 @@ const (
 // DefaultTokenBudget is the default token budget
 const DefaultTokenBudget = 15000
 
+// Document injection budget at port-start
+const (
+	DefaultDocBudget = 50000 // attention 기록이 없을 때의 문서 예산 (상한)
+	MinDocBudget     = 1000  // 이보다 작으면 문서를 주입하지 않음
+	DocBudgetRatio   = 0.5   // 남은 토큰 중 문서에 쓸 비율 (나머지는 작업용)
+)
+
 // Store handles attention tracking persistence
 type Store struct {
 	db *sql.DB
@@ -312,6 +319,30 @@ func (att *SessionAttention) GetTokenUsagePercent() float64 {
 	return float64(att.LoadedTokens) / float64(att.AvailableTokens) * 100
 }
 
+// RemainingTokens returns the unused token budget
+func (att *SessionAttention) RemainingTokens() int {
+	if remaining := att.AvailableTokens - att.LoadedTokens; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// DocBudget returns the document injection budget scaled to the remaining attention budget.
+// 기록이 없으면 DefaultDocBudget, 남은 예산이 부족하면 0(주입 생략)을 반환합니다.
+func DocBudget(att *SessionAttention) int {
+	if att == nil || att.AvailableTokens <= 0 {
+		return DefaultDocBudget
+	}
+	budget := int(float64(att.RemainingTokens()) * DocBudgetRatio)
+	if budget > DefaultDocBudget {
+		budget = DefaultDocBudget
+	}
+	if budget < MinDocBudget {
+		return 0
+	}
+	return budget
+}
+
 // ShouldCheckpoint determines if a checkpoint should be created
 func (att *SessionAttention) ShouldCheckpoint() bool {
 	return att.GetTokenUsagePercent() >= 80 || att.DriftCount > 2
@@ -158,6 +158,27 @@ func TestDefaultTokenBudget(t *testing.T) {
 	}
 }
 
+func TestDocBudget(t *testing.T) {
+	tests := []struct {
+		name string
+		att  *SessionAttention
+		want int
+	}{
+		{"no record", nil, DefaultDocBudget},
+		{"no budget", &SessionAttention{}, DefaultDocBudget},
+		{"small worker", &SessionAttention{AvailableTokens: 15000, LoadedTokens: 5000}, 5000},
+		{"large budget capped", &SessionAttention{AvailableTokens: 200000}, DefaultDocBudget},
+		{"exhausted", &SessionAttention{AvailableTokens: 15000, LoadedTokens: 14500}, 0},
+		{"overflowed", &SessionAttention{AvailableTokens: 15000, LoadedTokens: 20000}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := DocBudget(tt.att); got != tt.want {
+			t.Errorf("%s: DocBudget = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
 func TestSessionAttentionStruct(t *testing.T) {
 	att := SessionAttention{
 		SessionID:       "test-session",
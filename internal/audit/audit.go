@@ -0,0 +1,340 @@
+// Package audit appends session events to per-day JSONL files under .pal/audit.
+// SQLite와 별도로 기록되므로 DB를 초기화해도 이벤트가 남고, 외부 로그 파이프라인에서 그대로 수집할 수 있습니다.
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dayLayout is the date part of audit file names (UTC)
+const dayLayout = "2006-01-02"
+
+// followInterval is how often Follow polls for new lines
+const followInterval = 500 * time.Millisecond
+
+// Record is one audited session event
+type Record struct {
+	Time      time.Time       `json:"ts"`
+	SessionID string          `json:"session_id"`
+	EventType string          `json:"event_type"`
+	Data      json.RawMessage `json:"event_data,omitempty"`
+}
+
+// Options controls rotation and retention of the audit log
+type Options struct {
+	MaxSize   int64         // 일별 파일 최대 크기 (0 = 회전 안 함)
+	Retention time.Duration // 보관 기간 (0 = 무제한)
+}
+
+// Log is the audit log of a project
+type Log struct {
+	dir  string
+	opts Options
+}
+
+// NewLog returns the audit log stored in <projectRoot>/.pal/audit
+func NewLog(projectRoot string, opts Options) *Log {
+	return &Log{dir: Dir(projectRoot), opts: opts}
+}
+
+// Dir returns the audit directory of a project
+func Dir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".pal", "audit")
+}
+
+// NewRecord builds a record, keeping eventData as JSON when it is valid and as a string otherwise
+func NewRecord(sessionID, eventType, eventData string, at time.Time) Record {
+	rec := Record{Time: at.UTC(), SessionID: sessionID, EventType: eventType}
+	switch {
+	case eventData == "":
+	case json.Valid([]byte(eventData)):
+		rec.Data = json.RawMessage(eventData)
+	default:
+		rec.Data, _ = json.Marshal(eventData)
+	}
+	return rec
+}
+
+// Append writes a record to the file of its day, rotating and pruning as configured
+func (l *Log) Append(rec Record) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if err := os.MkdirAll(l.dir, 0755); err != nil {
+		return fmt.Errorf("audit 디렉토리 생성 실패: %w", err)
+	}
+
+	day := rec.Time.UTC().Format(dayLayout)
+	path := filepath.Join(l.dir, day+".jsonl")
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		// 새 날짜의 첫 기록: 보관 기간이 지난 파일 정리
+		if err := l.Prune(rec.Time); err != nil {
+			return err
+		}
+	case err != nil:
+		return fmt.Errorf("audit 파일 확인 실패: %w", err)
+	case l.opts.MaxSize > 0 && info.Size()+int64(len(line)) > l.opts.MaxSize:
+		if err := l.rotate(day, path); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("audit 파일 열기 실패: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(line); err != nil {
+		return fmt.Errorf("audit 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// rotate renames the active file of a day to the next YYYY-MM-DD.N.jsonl
+func (l *Log) rotate(day, path string) error {
+	n := 1
+	for _, f := range l.files() {
+		if f.day == day && f.seq >= n {
+			n = f.seq + 1
+		}
+	}
+	rotated := filepath.Join(l.dir, fmt.Sprintf("%s.%d.jsonl", day, n))
+	if err := os.Rename(path, rotated); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("audit 파일 회전 실패: %w", err)
+	}
+	return nil
+}
+
+// Prune removes files whose day is older than the retention period
+func (l *Log) Prune(now time.Time) error {
+	if l.opts.Retention <= 0 {
+		return nil
+	}
+	cutoff := now.UTC().Add(-l.opts.Retention).Format(dayLayout)
+	for _, f := range l.files() {
+		if f.day < cutoff {
+			if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("audit 파일 삭제 실패: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Files returns the audit files from oldest to newest
+func (l *Log) Files() []string {
+	files := l.files()
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.path
+	}
+	return paths
+}
+
+// Tail returns the last n records (n <= 0 이면 전체)
+func (l *Log) Tail(n int) ([]Record, error) {
+	files := l.Files()
+	var records []Record
+	// 최신 파일부터 필요한 만큼만 읽습니다
+	for i := len(files) - 1; i >= 0; i-- {
+		recs, err := readFile(files[i])
+		if err != nil {
+			return nil, err
+		}
+		records = append(recs, records...)
+		if n > 0 && len(records) >= n {
+			break
+		}
+	}
+	if n > 0 && len(records) > n {
+		records = records[len(records)-n:]
+	}
+	return records, nil
+}
+
+// Follow calls fn for every record appended after the call until ctx is done.
+// 날짜가 바뀌거나 파일이 회전되어도 열어 둔 파일을 끝까지 읽은 뒤 새 파일로 넘어갑니다.
+func (l *Log) Follow(ctx context.Context, fn func(Record)) error {
+	var f *os.File
+	var reader *bufio.Reader
+	var partial string
+	defer func() {
+		if f != nil {
+			f.Close()
+		}
+	}()
+
+	open := func(path string, atEnd bool) error {
+		if f != nil {
+			f.Close()
+		}
+		var err error
+		if f, err = os.Open(path); err != nil {
+			f = nil
+			return err
+		}
+		if atEnd {
+			if _, err := f.Seek(0, io.SeekEnd); err != nil {
+				return err
+			}
+		}
+		reader = bufio.NewReader(f)
+		partial = ""
+		return nil
+	}
+
+	if files := l.Files(); len(files) > 0 {
+		if err := open(files[len(files)-1], true); err != nil {
+			return fmt.Errorf("audit 파일 열기 실패: %w", err)
+		}
+	}
+
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+
+	for {
+		if f != nil {
+			for {
+				chunk, err := reader.ReadString('\n')
+				partial += chunk
+				if err != nil {
+					break
+				}
+				if rec, ok := parseLine(partial); ok {
+					fn(rec)
+				}
+				partial = ""
+			}
+
+			// 활성 파일이 바뀌었으면(새 날짜, 회전) 새 파일을 처음부터 읽음
+			if files := l.Files(); len(files) > 0 {
+				latest := files[len(files)-1]
+				if !sameFile(f, latest) {
+					if err := open(latest, false); err != nil && !os.IsNotExist(err) {
+						return fmt.Errorf("audit 파일 열기 실패: %w", err)
+					}
+					continue
+				}
+			}
+		} else if files := l.Files(); len(files) > 0 {
+			if err := open(files[len(files)-1], false); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("audit 파일 열기 실패: %w", err)
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// auditFile is a parsed audit file name: YYYY-MM-DD.jsonl or YYYY-MM-DD.N.jsonl
+type auditFile struct {
+	path string
+	day  string
+	seq  int // 회전된 파일 번호 (활성 파일은 0)
+}
+
+// files lists audit files ordered by day, rotated files before the active file
+func (l *Log) files() []auditFile {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil
+	}
+	var files []auditFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".jsonl") {
+			continue
+		}
+		base := strings.TrimSuffix(name, ".jsonl")
+		day, seqStr, rotated := strings.Cut(base, ".")
+		if _, err := time.Parse(dayLayout, day); err != nil {
+			continue
+		}
+		f := auditFile{path: filepath.Join(l.dir, name), day: day}
+		if rotated {
+			seq, err := strconv.Atoi(seqStr)
+			if err != nil || seq <= 0 {
+				continue
+			}
+			f.seq = seq
+		}
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].day != files[j].day {
+			return files[i].day < files[j].day
+		}
+		// 활성 파일(0)이 같은 날짜의 회전 파일보다 최신
+		si, sj := files[i].seq, files[j].seq
+		if si == 0 || sj == 0 {
+			return sj == 0 && si != 0
+		}
+		return si < sj
+	})
+	return files
+}
+
+func readFile(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("audit 파일 읽기 실패: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if rec, ok := parseLine(scanner.Text()); ok {
+			records = append(records, rec)
+		}
+	}
+	return records, scanner.Err()
+}
+
+func parseLine(line string) (Record, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return Record{}, false
+	}
+	var rec Record
+	if err := json.Unmarshal([]byte(line), &rec); err != nil {
+		return Record{}, false
+	}
+	return rec, true
+}
+
+func sameFile(f *os.File, path string) bool {
+	a, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	b, err := os.Stat(path)
+	if err != nil {
+		return true // 아직 새 파일이 없으면 현재 파일 유지
+	}
+	return os.SameFile(a, b)
+}
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendRotateAndTail(t *testing.T) {
+	root := t.TempDir()
+	log := NewLog(root, Options{MaxSize: 200})
+	day := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 6; i++ {
+		if err := log.Append(NewRecord("s1", "file_edit", `{"file":"a.go"}`, day.Add(time.Duration(i)*time.Second))); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	if err := log.Append(NewRecord("s1", "decision", "plain text", day.Add(time.Minute))); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	files := log.Files()
+	if len(files) < 2 {
+		t.Fatalf("Expected rotated files, got %v", files)
+	}
+	if filepath.Base(files[len(files)-1]) != "2026-03-02.jsonl" || filepath.Base(files[0]) != "2026-03-02.1.jsonl" {
+		t.Errorf("Unexpected file order: %v", files)
+	}
+
+	all, err := log.Tail(0)
+	if err != nil {
+		t.Fatalf("Tail failed: %v", err)
+	}
+	if len(all) != 7 {
+		t.Fatalf("Expected 7 records across rotated files, got %d", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].Time.Before(all[i-1].Time) {
+			t.Fatalf("Records out of order at %d", i)
+		}
+	}
+
+	last, _ := log.Tail(2)
+	if len(last) != 2 || last[1].EventType != "decision" || string(last[1].Data) != `"plain text"` {
+		t.Errorf("Unexpected tail: %+v", last)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	root := t.TempDir()
+	log := NewLog(root, Options{Retention: 7 * 24 * time.Hour})
+	now := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	log.Append(NewRecord("s1", "session_start", "", now.AddDate(0, 0, -10)))
+	log.Append(NewRecord("s1", "session_start", "", now.AddDate(0, 0, -3)))
+	// 새 날짜의 첫 기록에서 오래된 파일 정리
+	log.Append(NewRecord("s1", "session_end", "", now))
+
+	files := log.Files()
+	if len(files) != 2 || filepath.Base(files[0]) != "2026-03-17.jsonl" {
+		t.Errorf("Expected files older than retention to be pruned, got %v", files)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	root := t.TempDir()
+	log := NewLog(root, Options{})
+	now := time.Now()
+	log.Append(NewRecord("s1", "old", "", now))
+
+	got := make(chan Record, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- log.Follow(ctx, func(r Record) { got <- r }) }()
+
+	time.Sleep(100 * time.Millisecond)
+	log.Append(NewRecord("s1", "new", "", now))
+	// 다음 날 파일로 넘어가도 계속 따라감
+	log.Append(NewRecord("s1", "tomorrow", "", now.Add(24*time.Hour)))
+
+	for _, want := range []string{"new", "tomorrow"} {
+		select {
+		case r := <-got:
+			if r.EventType != want {
+				t.Errorf("Expected %s, got %s", want, r.EventType)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("Timed out waiting for %s", want)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Follow returned error: %v", err)
+	}
+	if _, err := os.Stat(Dir(root)); err != nil {
+		t.Errorf("Audit dir missing: %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/agentv2"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/spf13/cobra"
+)
+
+var agPerfCmd = &cobra.Command{
+	Use:   "perf",
+	Short: "에이전트 성능 기록 관리",
+	Long: `에이전트 성능 기록을 조회하고, 잘못 기록되었거나 테스트 실행으로 남은 기록을 수정/삭제합니다.
+수정·삭제 시 해당 버전의 사용 횟수와 평균값이 남은 기록으로 다시 계산됩니다.`,
+}
+
+var agPerfListCmd = &cobra.Command{
+	Use:   "list [agent-id-or-name]",
+	Short: "성능 기록 목록",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		store := agentv2.NewStore(database.DB)
+		agentID, err := resolveAgentID(store, args[0])
+		if err != nil {
+			return err
+		}
+
+		version, _ := cmd.Flags().GetInt("version")
+		limit, _ := cmd.Flags().GetInt("limit")
+		records, err := store.ListPerformance(agentID, version, limit)
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(records, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(records) == 0 {
+			fmt.Println("성능 기록이 없습니다.")
+			return nil
+		}
+
+		fmt.Printf("%-36s %-5s %-8s %-9s %-7s %-8s %s\n", "ID", "Ver", "Outcome", "Attention", "Quality", "Tokens", "Created")
+		fmt.Println(strings.Repeat("-", 100))
+		for _, p := range records {
+			fmt.Printf("%-36s v%-4d %-8s %-9.2f %-7.2f %-8d %s\n",
+				truncate(p.ID, 36),
+				p.AgentVersion,
+				p.Outcome,
+				p.AttentionAvg,
+				p.QualityScore,
+				p.TokenUsed,
+				p.CreatedAt.Format("2006-01-02 15:04"))
+		}
+
+		return nil
+	},
+}
+
+var agPerfEditCmd = &cobra.Command{
+	Use:   "edit [record-id]",
+	Short: "성능 기록 수정",
+	Example: `  pal agent perf edit 3f2a... --outcome success --quality 0.8
+  pal agent perf edit 3f2a... --version 4`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var u agentv2.PerformanceUpdate
+		flags := cmd.Flags()
+		if flags.Changed("version") {
+			v, _ := flags.GetInt("version")
+			u.AgentVersion = &v
+		}
+		if flags.Changed("outcome") {
+			v, _ := flags.GetString("outcome")
+			u.Outcome = &v
+		}
+		if flags.Changed("quality") {
+			v, _ := flags.GetFloat64("quality")
+			u.QualityScore = &v
+		}
+		if flags.Changed("attention") {
+			v, _ := flags.GetFloat64("attention")
+			u.AttentionAvg = &v
+		}
+		if flags.Changed("tokens") {
+			v, _ := flags.GetInt("tokens")
+			u.TokenUsed = &v
+		}
+		if flags.Changed("feedback") {
+			v, _ := flags.GetString("feedback")
+			u.Feedback = &v
+		}
+		if u == (agentv2.PerformanceUpdate{}) {
+			return fmt.Errorf("수정할 항목이 없습니다 (--version, --outcome, --quality, --attention, --tokens, --feedback)")
+		}
+
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		store := agentv2.NewStore(database.DB)
+		perf, err := store.UpdatePerformance(args[0], u)
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(perf, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("✓ 성능 기록 수정됨: %s (v%d 통계 재계산)\n", perf.ID, perf.AgentVersion)
+		return nil
+	},
+}
+
+var agPerfDeleteCmd = &cobra.Command{
+	Use:   "delete [record-id]",
+	Short: "성능 기록 삭제",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		store := agentv2.NewStore(database.DB)
+		perf, err := store.DeletePerformance(args[0])
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(map[string]interface{}{
+				"status": "deleted",
+				"record": perf,
+			}, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("✓ 성능 기록 삭제됨: %s (%s v%d 통계 재계산)\n", perf.ID, perf.AgentID, perf.AgentVersion)
+		return nil
+	},
+}
+
+var agPerfRecomputeCmd = &cobra.Command{
+	Use:   "recompute [agent-id-or-name]",
+	Short: "버전 평균 재계산",
+	Long: `남아 있는 성능 기록으로 버전별 사용 횟수와 평균값을 다시 계산합니다.
+에이전트를 지정하지 않으면 모든 에이전트의 버전을 재계산합니다.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		store := agentv2.NewStore(database.DB)
+		agentID := ""
+		if len(args) > 0 {
+			if agentID, err = resolveAgentID(store, args[0]); err != nil {
+				return err
+			}
+		}
+
+		count, err := store.RecomputeVersionStats(agentID)
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(map[string]interface{}{
+				"agent_id":   agentID,
+				"recomputed": count,
+			}, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("✓ %d개 버전 통계 재계산됨\n", count)
+		return nil
+	},
+}
+
+// resolveAgentID accepts an agent ID or name
+func resolveAgentID(store *agentv2.Store, idOrName string) (string, error) {
+	if agent, err := store.GetAgent(idOrName); err == nil {
+		return agent.ID, nil
+	}
+	agent, err := store.GetAgentByName(idOrName)
+	if err != nil {
+		return "", fmt.Errorf("에이전트 '%s'을(를) 찾을 수 없습니다", idOrName)
+	}
+	return agent.ID, nil
+}
+
+func init() {
+	agentCmd.AddCommand(agPerfCmd)
+
+	agPerfCmd.AddCommand(agPerfListCmd)
+	agPerfListCmd.Flags().Int("version", 0, "버전 필터")
+	agPerfListCmd.Flags().Int("limit", 50, "최대 개수")
+
+	agPerfCmd.AddCommand(agPerfEditCmd)
+	agPerfEditCmd.Flags().Int("version", 0, "에이전트 버전 변경")
+	agPerfEditCmd.Flags().String("outcome", "", "결과 (success, partial, failed)")
+	agPerfEditCmd.Flags().Float64("quality", 0, "품질 점수 (0.0~1.0)")
+	agPerfEditCmd.Flags().Float64("attention", 0, "평균 Attention")
+	agPerfEditCmd.Flags().Int("tokens", 0, "사용 토큰")
+	agPerfEditCmd.Flags().String("feedback", "", "피드백")
+
+	agPerfCmd.AddCommand(agPerfDeleteCmd)
+	agPerfCmd.AddCommand(agPerfRecomputeCmd)
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/agentv2"
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+// captureStdout runs fn and returns what it printed to stdout
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldOut := os.Stdout
+	os.Stdout = w
+	runErr := fn()
+	os.Stdout = oldOut
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	return string(out)
+}
+
+// seedAgentPerf creates an agent with one version and two performance records
+func seedAgentPerf(t *testing.T) (string, []string) {
+	t.Helper()
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	store := agentv2.NewStore(database.DB)
+	agent := &agentv2.Agent{Name: "reviewer", Type: agentv2.TypeWorker}
+	if err := store.CreateAgent(agent); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateVersion(&agentv2.AgentVersion{AgentID: agent.ID, SpecContent: "spec v1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for i, outcome := range []string{"success", "failed"} {
+		perf := &agentv2.AgentPerformance{
+			AgentID:      agent.ID,
+			AgentVersion: 1,
+			SessionID:    fmt.Sprintf("session-%d", i),
+			AttentionAvg: 0.8,
+			TokenUsed:    1000,
+			Outcome:      outcome,
+			QualityScore: 0.9,
+		}
+		if err := store.RecordPerformance(perf); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, perf.ID)
+	}
+	return agent.ID, ids
+}
+
+// dumpAgentPerf returns the performance and version rows as comparable text
+func dumpAgentPerf(t *testing.T) string {
+	t.Helper()
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	var b strings.Builder
+	for _, query := range []string{
+		`SELECT id, agent_version, outcome, attention_avg, quality_score, token_used FROM agent_performance ORDER BY id`,
+		`SELECT id, version, usage_count, avg_attention_score, avg_completion_rate FROM agent_versions ORDER BY id`,
+	} {
+		rows, err := database.Query(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cols, _ := rows.Columns()
+		for rows.Next() {
+			vals := make([]interface{}, len(cols))
+			ptrs := make([]interface{}, len(cols))
+			for i := range vals {
+				ptrs[i] = &vals[i]
+			}
+			if err := rows.Scan(ptrs...); err != nil {
+				rows.Close()
+				t.Fatal(err)
+			}
+			fmt.Fprintln(&b, vals...)
+		}
+		rows.Close()
+	}
+	return b.String()
+}
+
+func TestAgentPerfListReadsWithoutWriting(t *testing.T) {
+	oldDB := dbPath
+	defer func() { dbPath = oldDB }()
+	dbPath = filepath.Join(t.TempDir(), "pal.db")
+
+	_, ids := seedAgentPerf(t)
+	before := dumpAgentPerf(t)
+
+	out := captureStdout(t, func() error {
+		return agPerfListCmd.RunE(agPerfListCmd, []string{"reviewer"})
+	})
+
+	if !strings.Contains(out, "Outcome") {
+		t.Errorf("header missing from output:\n%s", out)
+	}
+	for _, id := range ids {
+		if !strings.Contains(out, id) {
+			t.Errorf("record %s missing from output:\n%s", id, out)
+		}
+	}
+	if !strings.Contains(out, "success") || !strings.Contains(out, "failed") {
+		t.Errorf("outcomes missing from output:\n%s", out)
+	}
+
+	if after := dumpAgentPerf(t); after != before {
+		t.Errorf("list changed the DB:\nbefore:\n%s\nafter:\n%s", before, after)
+	}
+}
+
+func TestAgentPerfDeleteRecomputesVersionStats(t *testing.T) {
+	oldDB := dbPath
+	defer func() { dbPath = oldDB }()
+	dbPath = filepath.Join(t.TempDir(), "pal.db")
+
+	agentID, ids := seedAgentPerf(t)
+
+	out := captureStdout(t, func() error {
+		return agPerfDeleteCmd.RunE(agPerfDeleteCmd, []string{ids[1]})
+	})
+	if !strings.Contains(out, ids[1]) {
+		t.Errorf("deleted record missing from output:\n%s", out)
+	}
+
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	var count int
+	database.QueryRow(`SELECT COUNT(*) FROM agent_performance WHERE id = ?`, ids[1]).Scan(&count)
+	if count != 0 {
+		t.Errorf("record %s still present after delete", ids[1])
+	}
+	var usage int
+	var completion float64
+	if err := database.QueryRow(`SELECT usage_count, avg_completion_rate FROM agent_versions WHERE agent_id = ? AND version = 1`,
+		agentID).Scan(&usage, &completion); err != nil {
+		t.Fatal(err)
+	}
+	if usage != 1 || completion != 1 {
+		t.Errorf("version stats = usage %d, completion %.2f; want 1, 1.00", usage, completion)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/n0roo/pal-kit/internal/attention"
+	"github.com/n0roo/pal-kit/internal/compact"
 	"github.com/n0roo/pal-kit/internal/db"
 	"github.com/spf13/cobra"
 )
@@ -130,10 +131,13 @@ var attHistoryCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		store := attention.NewStore(database.DB)
 		limit, _ := cmd.Flags().GetInt("limit")
+		if limit <= 0 {
+			limit = 10
+		}
 
-		events, err := store.GetCompactHistory(args[0], limit)
+		// 레거시 compactions와 compact_events를 합친 이력
+		events, err := compact.NewService(database).History(args[0], limit)
 		if err != nil {
 			return err
 		}
@@ -151,17 +155,18 @@ var attHistoryCmd = &cobra.Command{
 
 		fmt.Printf("Compact History for %s\n", args[0])
 		fmt.Println(strings.Repeat("-", 70))
-		fmt.Printf("%-20s %-15s %-10s %-10s %s\n", "Time", "Reason", "Before", "After", "Preserved")
+		fmt.Printf("%-20s %-15s %-10s %-10s %-10s %s\n", "Time", "Reason", "Before", "After", "Preserved", "Source")
 		fmt.Println(strings.Repeat("-", 70))
 
 		for _, e := range events {
 			preserved := len(e.PreservedContext)
-			fmt.Printf("%-20s %-15s %-10d %-10d %d items\n",
-				e.CreatedAt.Format("01-02 15:04:05"),
+			fmt.Printf("%-20s %-15s %-10d %-10d %-10s %s\n",
+				e.CreatedAt.Local().Format("01-02 15:04:05"),
 				e.TriggerReason,
 				e.BeforeTokens,
 				e.AfterTokens,
-				preserved)
+				fmt.Sprintf("%d items", preserved),
+				e.Source)
 		}
 
 		return nil
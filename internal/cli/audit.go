@@ -0,0 +1,139 @@
+package cli
+
+import (
+	stdctx "context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/n0roo/pal-kit/internal/audit"
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditLines   int
+	auditFollow  bool
+	auditSession string
+	auditType    string
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "세션 이벤트 감사 로그",
+	Long: `모든 세션 이벤트는 SQLite와 함께 .pal/audit/YYYY-MM-DD.jsonl(UTC 날짜)에 한 줄씩 기록됩니다.
+DB를 초기화해도 남으며, 외부 로그 파이프라인에서 그대로 수집할 수 있습니다.
+
+설정 예시 (.pal/config.yaml):
+
+  settings:
+    audit:
+      disabled: false
+      max_size_mb: 50       # 넘으면 YYYY-MM-DD.N.jsonl로 회전 (-1 = 회전 안 함)
+      retention_days: 30    # 보관 기간 (-1 = 무제한)`,
+}
+
+var auditTailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "최근 감사 로그 출력",
+	Long: `최근 감사 로그를 출력합니다. -f를 지정하면 새로 기록되는 이벤트를 계속 출력합니다.
+
+예시:
+  pal audit tail
+  pal audit tail -n 100 --type file_edit
+  pal audit tail -f --session abc12345
+  pal audit tail -f --json | jq .`,
+	RunE: runAuditTail,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditTailCmd)
+
+	auditTailCmd.Flags().IntVarP(&auditLines, "lines", "n", 20, "출력할 최근 이벤트 수")
+	auditTailCmd.Flags().BoolVarP(&auditFollow, "follow", "f", false, "새 이벤트를 계속 출력")
+	auditTailCmd.Flags().StringVar(&auditSession, "session", "", "세션 ID로 필터")
+	auditTailCmd.Flags().StringVar(&auditType, "type", "", "이벤트 타입으로 필터")
+}
+
+func runAuditTail(cmd *cobra.Command, args []string) error {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	if projectRoot == "" {
+		return fmt.Errorf("프로젝트 루트를 찾을 수 없습니다")
+	}
+
+	var settings config.AuditSettings
+	if cfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+		settings = cfg.Settings.Audit
+	}
+	log := audit.NewLog(projectRoot, audit.Options{MaxSize: settings.MaxSizeBytes(), Retention: settings.Retention()})
+
+	records, err := log.Tail(0)
+	if err != nil {
+		return err
+	}
+	records = filterAuditRecords(records)
+	if auditLines > 0 && len(records) > auditLines {
+		records = records[len(records)-auditLines:]
+	}
+
+	if len(records) == 0 && !auditFollow && !IsJSON() {
+		if settings.Disabled {
+			fmt.Println("감사 로그가 비활성화되어 있습니다 (settings.audit.disabled).")
+		} else {
+			fmt.Println("기록된 감사 로그가 없습니다.")
+		}
+		return nil
+	}
+	for _, rec := range records {
+		printAuditRecord(rec)
+	}
+	if !auditFollow {
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(stdctx.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return log.Follow(ctx, func(rec audit.Record) {
+		if matchAuditRecord(rec) {
+			printAuditRecord(rec)
+		}
+	})
+}
+
+func filterAuditRecords(records []audit.Record) []audit.Record {
+	var filtered []audit.Record
+	for _, rec := range records {
+		if matchAuditRecord(rec) {
+			filtered = append(filtered, rec)
+		}
+	}
+	return filtered
+}
+
+func matchAuditRecord(rec audit.Record) bool {
+	return (auditSession == "" || rec.SessionID == auditSession) &&
+		(auditType == "" || rec.EventType == auditType)
+}
+
+// printAuditRecord prints a record as a JSONL line (--json) or a compact text line
+func printAuditRecord(rec audit.Record) {
+	if IsJSON() {
+		data, _ := json.Marshal(rec)
+		fmt.Println(string(data))
+		return
+	}
+	data := []rune(string(rec.Data))
+	if len(data) > 80 {
+		data = append(data[:77], []rune("...")...)
+	}
+	fmt.Printf("%s  %-12s  %-18s  %s\n",
+		rec.Time.Local().Format("01/02 15:04:05"),
+		truncate(rec.SessionID, 12),
+		rec.EventType,
+		string(data))
+}
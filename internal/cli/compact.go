@@ -17,6 +17,8 @@ var (
 	compactTriggerType string
 	compactTokens      int64
 	compactLimit       int
+	compactDryRun      bool
+	compactNoScan      bool
 )
 
 var compactCmd = &cobra.Command{
@@ -43,11 +45,28 @@ var compactSummaryCmd = &cobra.Command{
 	RunE:  runCompactSummary,
 }
 
+var compactBackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "컴팩션 기록 정합화 및 transcript 보충",
+	Long: `레거시 compactions 테이블과 compact_events를 맞춥니다.
+
+- 한쪽에만 있는 기록은 다른 쪽에 만들어 연결합니다
+- 같은 세션에서 시간이 가까운 미연결 기록끼리는 연결만 합니다
+- 세션 transcript의 컴팩션 경계(compact_boundary) 중 누락된 것을 두 테이블에 기록합니다
+- 세션의 compact_count가 기록 수보다 작으면 맞춥니다
+
+여러 번 실행해도 같은 결과입니다.`,
+	Example: `  pal compact backfill --dry-run
+  pal compact backfill --session abc123`,
+	RunE: runCompactBackfill,
+}
+
 func init() {
 	rootCmd.AddCommand(compactCmd)
 	compactCmd.AddCommand(compactRecordCmd)
 	compactCmd.AddCommand(compactListCmd)
 	compactCmd.AddCommand(compactSummaryCmd)
+	compactCmd.AddCommand(compactBackfillCmd)
 
 	compactRecordCmd.Flags().StringVar(&compactSessionID, "session", "", "세션 ID")
 	compactRecordCmd.Flags().StringVar(&compactSummary, "summary", "", "컨텍스트 요약")
@@ -56,6 +75,10 @@ func init() {
 
 	compactListCmd.Flags().StringVar(&compactSessionID, "session", "", "세션 ID 필터")
 	compactListCmd.Flags().IntVar(&compactLimit, "limit", 20, "결과 수 제한")
+
+	compactBackfillCmd.Flags().StringVar(&compactSessionID, "session", "", "세션 ID (기본: 전체)")
+	compactBackfillCmd.Flags().BoolVar(&compactDryRun, "dry-run", false, "변경 없이 결과만 표시")
+	compactBackfillCmd.Flags().BoolVar(&compactNoScan, "no-transcripts", false, "transcript를 읽지 않고 두 테이블만 맞춤")
 }
 
 func getCompactService() (*compact.Service, func(), error) {
@@ -173,3 +196,45 @@ func runCompactSummary(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runCompactBackfill(cmd *cobra.Command, args []string) error {
+	svc, cleanup, err := getCompactService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	result, err := svc.Reconcile(compact.ReconcileOptions{
+		SessionID:   compactSessionID,
+		Transcripts: !compactNoScan,
+		DryRun:      compactDryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(result)
+		return nil
+	}
+
+	if compactDryRun {
+		fmt.Println("🔍 컴팩션 정합화 (dry-run, 변경 없음)")
+	} else {
+		fmt.Println("📦 컴팩션 정합화 완료")
+	}
+	if !compactNoScan {
+		fmt.Printf("  transcript: %d개 (컴팩션 경계 %d개)\n", result.Transcripts, result.Markers)
+	}
+	if !result.Changed() {
+		fmt.Println("  두 기록이 이미 일치합니다.")
+		return nil
+	}
+	fmt.Printf("  연결: %d\n", result.Linked)
+	fmt.Printf("  compact_events 생성: %d\n", result.EventsCreated)
+	fmt.Printf("  compactions 생성: %d\n", result.CompactionsCreated)
+	fmt.Printf("  transcript 보충: %d\n", result.FromTranscripts)
+	fmt.Printf("  compact_count 갱신 세션: %d\n", result.SessionsUpdated)
+
+	return nil
+}
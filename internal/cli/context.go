@@ -4,17 +4,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/context"
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/operator"
+	"github.com/n0roo/pal-kit/internal/rules"
 	"github.com/n0roo/pal-kit/internal/session"
 	"github.com/spf13/cobra"
 )
 
 var (
-	ctxFile   string
-	ctxPortID string
+	ctxFile       string
+	ctxPortID     string
+	ctxEmitFormat string
+	ctxEmitOutput string
+	ctxEmitWrite  bool
 )
 
 var contextCmd = &cobra.Command{
@@ -104,6 +110,24 @@ var ctxCreateCheckpointCmd = &cobra.Command{
 	RunE:  runCtxCreateCheckpoint,
 }
 
+var ctxEmitCmd = &cobra.Command{
+	Use:   "emit",
+	Short: "다른 AI 도구용 컨텍스트 출력",
+	Long: `세션 브리핑과 .claude/rules 내용을 다른 AI 코딩 도구가 읽을 수 있는 형식으로 출력합니다.
+여러 어시스턴트를 함께 쓰는 팀도 하나의 워크플로우 소스를 유지할 수 있습니다.
+
+형식:
+  cursor    .cursor/rules/pal-kit.mdc
+  continue  .continue/rules/pal-kit.md
+  plain     frontmatter 없는 Markdown
+
+예시:
+  pal context emit --format plain
+  pal context emit --format cursor --write
+  pal context emit --format continue -o docs/ai-rules.md`,
+	RunE: runCtxEmit,
+}
+
 func init() {
 	rootCmd.AddCommand(contextCmd)
 	contextCmd.AddCommand(ctxShowCmd)
@@ -115,9 +139,14 @@ func init() {
 	contextCmd.AddCommand(ctxCheckpointsCmd)
 	contextCmd.AddCommand(ctxRestoreCmd)
 	contextCmd.AddCommand(ctxCreateCheckpointCmd)
+	contextCmd.AddCommand(ctxEmitCmd)
 
 	ctxInjectCmd.Flags().StringVar(&ctxFile, "file", "", "CLAUDE.md 파일 경로 (자동 탐색)")
 	ctxClaudeCmd.Flags().StringVar(&ctxPortID, "port", "", "포트 ID")
+
+	ctxEmitCmd.Flags().StringVar(&ctxEmitFormat, "format", rules.FormatPlain, "출력 형식 (cursor, continue, plain)")
+	ctxEmitCmd.Flags().StringVarP(&ctxEmitOutput, "output", "o", "", "출력 파일 경로")
+	ctxEmitCmd.Flags().BoolVar(&ctxEmitWrite, "write", false, "형식별 기본 경로에 저장")
 }
 
 func getContextService() (*context.Service, func(), error) {
@@ -512,3 +541,65 @@ func formatTimeAgoCLI(t time.Time) string {
 		return fmt.Sprintf("%d일 전", int(d.Hours()/24))
 	}
 }
+
+func runCtxEmit(cmd *cobra.Command, args []string) error {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+
+	// 브리핑: DB에서 새로 생성, 실패 시 만료되지 않은 마지막 session-briefing.md 사용
+	var briefing string
+	if database, err := db.Open(GetDBPath()); err == nil {
+		operatorSvc := operator.NewService(database, projectRoot)
+		if b, err := operatorSvc.GenerateBriefing(); err == nil {
+			briefing = operatorSvc.FormatBriefing(b)
+		}
+		database.Close()
+	}
+	if briefing == "" {
+		if data, err := os.ReadFile(filepath.Join(projectRoot, ".pal", "context", "session-briefing.md")); err == nil &&
+			!rules.IsExpired(string(data), time.Now()) {
+			briefing = string(data)
+		}
+	}
+
+	sections, err := rules.NewService(projectRoot).Sections()
+	if err != nil {
+		return err
+	}
+
+	content, err := rules.Render(ctxEmitFormat, briefing, sections)
+	if err != nil {
+		return err
+	}
+
+	output := ctxEmitOutput
+	if output == "" && ctxEmitWrite {
+		output = rules.EmitPath(projectRoot, ctxEmitFormat)
+		if output == "" {
+			return fmt.Errorf("%s 형식은 기본 경로가 없습니다. -o로 지정하세요", ctxEmitFormat)
+		}
+	}
+
+	if output == "" {
+		fmt.Print(content)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %w", err)
+	}
+	if err := os.WriteFile(output, []byte(content), 0644); err != nil {
+		return fmt.Errorf("파일 저장 실패: %w", err)
+	}
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"format":   ctxEmitFormat,
+			"output":   output,
+			"sections": len(sections),
+		})
+	} else {
+		fmt.Printf("✅ %s 컨텍스트 저장: %s (규칙 %d개)\n", ctxEmitFormat, output, len(sections))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/primer"
+	"github.com/spf13/cobra"
+)
+
+var ctxPrimerRefresh bool
+
+var ctxPrimerCmd = &cobra.Command{
+	Use:   "primer",
+	Short: "캐시된 프로젝트 프라이머 조회/갱신",
+	Long: `SessionStart에서 주입되는 프로젝트 프라이머(아키텍처 요약, 주요 컨벤션, 주요 도메인, 현재 상태)를 표시합니다.
+프라이머는 'pal serve' 데몬이 settings.primer_refresh 주기(기본 15m)로 .pal/context/primer.json에 갱신하며,
+캐시가 최신이면 SessionStart는 브리핑을 다시 계산하지 않고 캐시를 그대로 주입합니다.
+
+예시:
+  pal context primer
+  pal context primer --refresh`,
+	RunE: runCtxPrimer,
+}
+
+func init() {
+	contextCmd.AddCommand(ctxPrimerCmd)
+	ctxPrimerCmd.Flags().BoolVar(&ctxPrimerRefresh, "refresh", false, "지금 다시 생성하여 캐시 갱신")
+}
+
+func runCtxPrimer(cmd *cobra.Command, args []string) error {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	if projectRoot == "" {
+		return fmt.Errorf("프로젝트 루트를 찾을 수 없습니다")
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	svc := primer.NewService(database, projectRoot)
+	var p *primer.Primer
+	if ctxPrimerRefresh {
+		if p, err = svc.Generate(); err != nil {
+			return err
+		}
+		if err := svc.Save(p); err != nil {
+			return err
+		}
+	} else if p, err = svc.Load(); err != nil {
+		return err
+	}
+
+	if p == nil {
+		fmt.Println("캐시된 프라이머가 없습니다. 'pal context primer --refresh'로 생성하세요.")
+		return nil
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(p, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Print(p.Markdown())
+	if verbose {
+		fmt.Printf("\n📄 %s\n", svc.CachePath())
+	}
+	return nil
+}
@@ -20,7 +20,7 @@ var (
 
 var conventionCmd = &cobra.Command{
 	Use:     "convention",
-	Aliases: []string{"conv"},
+	Aliases: []string{"conv", "conventions"},
 	Short:   "컨벤션 관리",
 	Long:    `프로젝트 컨벤션을 관리합니다.`,
 }
@@ -72,6 +72,24 @@ var convCheckCmd = &cobra.Command{
 	RunE:  runConvCheck,
 }
 
+var convTestCmd = &cobra.Command{
+	Use:   "test [id...]",
+	Short: "컨벤션 규칙 테스트",
+	Long: `컨벤션 규칙을 good/bad 샘플에 실행하여 규칙이 의도대로 동작하는지 검증합니다.
+
+샘플 위치 (컨벤션 파일 기준):
+  fixtures/<id>/good/*   위반이 없어야 하는 샘플
+  fixtures/<id>/bad/*    위반이 하나 이상 나와야 하는 샘플
+
+컨벤션 YAML의 'fixtures' 필드로 경로를 지정할 수 있으며,
+인라인 examples.good / examples.bad 도 함께 검사합니다.
+
+예시:
+  pal conventions test
+  pal conventions test go-naming`,
+	RunE: runConvTest,
+}
+
 var convLearnCmd = &cobra.Command{
 	Use:   "learn [paths...]",
 	Short: "패턴 학습",
@@ -107,6 +125,7 @@ func init() {
 	conventionCmd.AddCommand(convEnableCmd)
 	conventionCmd.AddCommand(convDisableCmd)
 	conventionCmd.AddCommand(convCheckCmd)
+	conventionCmd.AddCommand(convTestCmd)
 	conventionCmd.AddCommand(convLearnCmd)
 	conventionCmd.AddCommand(convInitCmd)
 	conventionCmd.AddCommand(convTypesCmd)
@@ -427,6 +446,75 @@ func runConvCheck(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runConvTest(cmd *cobra.Command, args []string) error {
+	svc, err := getConventionService()
+	if err != nil {
+		return err
+	}
+
+	reports, err := svc.Test(args)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range reports {
+		failed += r.Failed
+	}
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"reports": reports,
+			"failed":  failed,
+		})
+	} else {
+		printConvTestReports(reports)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d개 샘플이 기대와 다르게 검사되었습니다", failed)
+	}
+	return nil
+}
+
+func printConvTestReports(reports []convention.TestReport) {
+	if len(reports) == 0 {
+		fmt.Println("테스트할 규칙이 있는 컨벤션이 없습니다.")
+		return
+	}
+
+	for _, r := range reports {
+		if len(r.Results) == 0 {
+			fmt.Printf("⚪ %s: 샘플 없음\n", r.ConventionID)
+			continue
+		}
+
+		icon := "✅"
+		if r.Failed > 0 {
+			icon = "❌"
+		}
+		fmt.Printf("%s %s: %d passed, %d failed\n", icon, r.ConventionID, r.Passed, r.Failed)
+
+		for _, res := range r.Results {
+			if res.Passed {
+				continue
+			}
+			if res.Expect == convention.ExpectGood {
+				fmt.Printf("   ✗ [good] %s: %d건 위반 발생\n", res.Sample, len(res.Violations))
+				for _, v := range res.Violations {
+					if v.Line > 0 {
+						fmt.Printf("       L%d [%s] %s\n", v.Line, v.RuleID, v.Message)
+					} else {
+						fmt.Printf("       [%s] %s\n", v.RuleID, v.Message)
+					}
+				}
+			} else {
+				fmt.Printf("   ✗ [bad] %s: 위반이 감지되지 않음\n", res.Sample)
+			}
+		}
+	}
+}
+
 func runConvLearn(cmd *cobra.Command, args []string) error {
 	svc, err := getConventionService()
 	if err != nil {
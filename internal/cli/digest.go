@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/digest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	digestHTML  bool
+	digestTo    []string
+	digestLimit int
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "주간 리포트 이메일 다이제스트",
+	Long: `주간 리포트와 마일스톤(파이프라인) 번다운을 이메일로 발송합니다.
+
+pal serve 데몬이 실행 중이면 notifications.digest.schedule(기본: 매주 월요일 09:00)에
+따라 자동으로 발송합니다. 설정 예시 (.pal/config.yaml):
+
+  notifications:
+    digest:
+      recipients: [team@example.com]
+      schedule: "0 9 * * 1"
+      provider: smtp            # smtp | sendgrid
+      from: pal@example.com
+      smtp:
+        host: smtp.example.com
+        port: 587
+        username: pal
+        password: ${SMTP_PASSWORD}
+      sendgrid:
+        api_key: ${SENDGRID_API_KEY}`,
+}
+
+var digestPreviewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "주간 리포트 미리보기",
+	Long:  `발송될 주간 리포트를 markdown(기본) 또는 HTML(--html)로 출력합니다.`,
+	RunE:  runDigestPreview,
+}
+
+var digestSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "주간 리포트 즉시 발송",
+	Long: `스케줄과 무관하게 주간 리포트를 즉시 발송합니다.
+--to를 지정하면 설정된 수신자 대신 해당 주소로 보냅니다.`,
+	RunE: runDigestSend,
+}
+
+var digestHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "다이제스트 발송 이력",
+	RunE:  runDigestHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(digestCmd)
+	digestCmd.AddCommand(digestPreviewCmd)
+	digestCmd.AddCommand(digestSendCmd)
+	digestCmd.AddCommand(digestHistoryCmd)
+
+	digestPreviewCmd.Flags().BoolVar(&digestHTML, "html", false, "HTML로 출력")
+	digestSendCmd.Flags().StringSliceVar(&digestTo, "to", nil, "수신자 (기본: 설정된 recipients)")
+	digestHistoryCmd.Flags().IntVar(&digestLimit, "limit", 10, "최대 표시 수")
+}
+
+// openDigest opens the DB and resolves the project root and digest config
+func openDigest() (*digest.Service, string, config.DigestConfig, func(), error) {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+
+	var cfg config.DigestConfig
+	if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+		cfg = projectCfg.Notifications.Digest
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return nil, "", cfg, nil, err
+	}
+	return digest.NewService(database), projectRoot, cfg, func() { database.Close() }, nil
+}
+
+func runDigestPreview(cmd *cobra.Command, args []string) error {
+	svc, projectRoot, _, cleanup, err := openDigest()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	report, err := svc.Weekly(projectRoot, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	md := report.Markdown()
+	if digestHTML {
+		fmt.Print(digest.RenderHTML(md))
+		return nil
+	}
+	fmt.Print(md)
+	return nil
+}
+
+func runDigestSend(cmd *cobra.Command, args []string) error {
+	svc, projectRoot, cfg, cleanup, err := openDigest()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if len(digestTo) > 0 {
+		cfg.Recipients = digestTo
+	}
+
+	run, err := svc.Send(cfg, projectRoot, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(run, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+	fmt.Printf("📧 주간 리포트 발송 완료: %s\n", strings.Join(run.Recipients, ", "))
+	return nil
+}
+
+func runDigestHistory(cmd *cobra.Command, args []string) error {
+	svc, projectRoot, _, cleanup, err := openDigest()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	runs, err := svc.History(projectRoot, digestLimit)
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(map[string]interface{}{"runs": runs}, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(runs) == 0 {
+		fmt.Println("발송 이력 없음")
+		return nil
+	}
+	for _, run := range runs {
+		icon := "✅"
+		if run.Status == digest.RunFailed {
+			icon = "❌"
+		}
+		fmt.Printf("%s %s  %s\n", icon, run.SentAt.Local().Format("2006-01-02 15:04"), strings.Join(run.Recipients, ", "))
+		if run.Error != "" {
+			fmt.Printf("    %s\n", run.Error)
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/n0roo/pal-kit/internal/config"
@@ -987,12 +988,23 @@ func runDocsStats(cmd *cobra.Command, args []string) error {
 			"session":    "💬",
 			"adr":        "📄",
 		}
-		for t, c := range stats.ByType {
+		types := svc.Types()
+		var typeIDs []string
+		for t := range stats.ByType {
+			typeIDs = append(typeIDs, t)
+		}
+		sort.Slice(typeIDs, func(i, j int) bool {
+			if oi, oj := types.OrderOf(typeIDs[i]), types.OrderOf(typeIDs[j]); oi != oj {
+				return oi < oj
+			}
+			return typeIDs[i] < typeIDs[j]
+		})
+		for _, t := range typeIDs {
 			emoji := typeEmoji[t]
 			if emoji == "" {
 				emoji = "📄"
 			}
-			fmt.Printf("  %s %-12s: %d\n", emoji, t, c)
+			fmt.Printf("  %s %-12s: %d\n", emoji, types.DisplayName(t), stats.ByType[t])
 		}
 		fmt.Println()
 	}
@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/document"
+	"github.com/n0roo/pal-kit/internal/kb"
+	"github.com/n0roo/pal-kit/internal/trash"
+	"github.com/spf13/cobra"
+)
+
+var (
+	docsTrashKind    string
+	docsTrashExpired bool
+)
+
+var docsRmCmd = &cobra.Command{
+	Use:   "rm <id>",
+	Short: "문서 삭제 (휴지통으로 이동)",
+	Long: `문서 파일을 휴지통(.pal/trash)으로 옮기고 인덱스에서 제거합니다.
+30일 이내에 'pal docs trash restore'로 복원할 수 있습니다.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDocsRm,
+}
+
+var docsTrashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "삭제된 문서/KB 노트 관리",
+	Long: `API 또는 CLI로 삭제된 문서와 KB 노트는 휴지통에 30일간 보관됩니다.
+
+예시:
+  pal docs trash list
+  pal docs trash list --kind kb
+  pal docs trash restore <trash-id>
+  pal docs trash purge --expired`,
+}
+
+var docsTrashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "휴지통 목록",
+	RunE:  runDocsTrashList,
+}
+
+var docsTrashRestoreCmd = &cobra.Command{
+	Use:   "restore <trash-id>",
+	Short: "휴지통 항목 복원",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDocsTrashRestore,
+}
+
+var docsTrashPurgeCmd = &cobra.Command{
+	Use:   "purge [trash-id]",
+	Short: "휴지통 항목 영구 삭제",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runDocsTrashPurge,
+}
+
+func init() {
+	docsCmd.AddCommand(docsRmCmd)
+	docsCmd.AddCommand(docsTrashCmd)
+
+	docsTrashCmd.AddCommand(docsTrashListCmd)
+	docsTrashCmd.AddCommand(docsTrashRestoreCmd)
+	docsTrashCmd.AddCommand(docsTrashPurgeCmd)
+
+	docsTrashListCmd.Flags().StringVar(&docsTrashKind, "kind", "", "종류 필터 (document, kb)")
+	docsTrashPurgeCmd.Flags().BoolVar(&docsTrashExpired, "expired", false, "보관 기간(30일)이 지난 항목만 삭제")
+}
+
+func runDocsRm(cmd *cobra.Command, args []string) error {
+	svc, err := getDocumentService()
+	if err != nil {
+		return err
+	}
+
+	item, err := svc.TrashDocument(args[0])
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(item)
+	}
+
+	fmt.Printf("🗑️  휴지통으로 이동: %s\n", item.Path)
+	fmt.Printf("   복원: pal docs trash restore %s\n", item.ID)
+	return nil
+}
+
+func runDocsTrashList(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(config.GlobalDBPath())
+	if err != nil {
+		return fmt.Errorf("DB 연결 실패: %w", err)
+	}
+	defer database.Close()
+
+	svc := trash.NewService(database)
+
+	// 보관 기간이 지난 항목은 목록 조회 전에 정리
+	svc.PurgeExpired(time.Now())
+
+	items, err := svc.List(trash.Kind(docsTrashKind))
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		if items == nil {
+			items = []*trash.Item{}
+		}
+		return json.NewEncoder(os.Stdout).Encode(items)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("휴지통이 비어 있습니다.")
+		return nil
+	}
+
+	fmt.Printf("🗑️  휴지통 (%d개)\n\n", len(items))
+	fmt.Printf("%-10s %-9s %-16s %-11s %s\n", "ID", "KIND", "DELETED", "EXPIRES", "PATH")
+	for _, item := range items {
+		fmt.Printf("%-10s %-9s %-16s %-11s %s\n",
+			item.ID,
+			item.Kind,
+			item.DeletedAt.Format("2006-01-02 15:04"),
+			item.ExpiresAt.Format("2006-01-02"),
+			item.Path,
+		)
+	}
+	return nil
+}
+
+func runDocsTrashRestore(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(config.GlobalDBPath())
+	if err != nil {
+		return fmt.Errorf("DB 연결 실패: %w", err)
+	}
+	defer database.Close()
+
+	item, err := trash.NewService(database).Restore(args[0])
+	if err != nil {
+		return err
+	}
+
+	// 문서는 원래 프로젝트, KB 노트는 원래 vault 기준으로 재인덱싱
+	switch item.Kind {
+	case trash.KindDocument:
+		if err := document.NewService(database, item.Root).RefreshDocument(item.Path); err != nil {
+			return fmt.Errorf("복원된 문서 인덱싱 실패: %w", err)
+		}
+	case trash.KindKB:
+		if err := kb.RefreshVaultDocument(item.Root, item.Path); err != nil {
+			return fmt.Errorf("복원된 노트 인덱싱 실패: %w", err)
+		}
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(item)
+	}
+
+	fmt.Printf("♻️  복원 완료: %s\n", item.Path)
+	return nil
+}
+
+func runDocsTrashPurge(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !docsTrashExpired {
+		return fmt.Errorf("삭제할 trash-id 또는 --expired 플래그가 필요합니다")
+	}
+
+	database, err := db.Open(config.GlobalDBPath())
+	if err != nil {
+		return fmt.Errorf("DB 연결 실패: %w", err)
+	}
+	defer database.Close()
+
+	svc := trash.NewService(database)
+
+	if len(args) == 1 {
+		if err := svc.Purge(args[0]); err != nil {
+			return err
+		}
+		if jsonOut {
+			return json.NewEncoder(os.Stdout).Encode(map[string]interface{}{"purged": 1})
+		}
+		fmt.Printf("✅ 영구 삭제: %s\n", args[0])
+		return nil
+	}
+
+	purged, err := svc.PurgeExpired(time.Now())
+	if err != nil {
+		return err
+	}
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(map[string]interface{}{"purged": purged})
+	}
+	fmt.Printf("✅ 만료 항목 %d개 영구 삭제\n", purged)
+	return nil
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/document"
+	"github.com/spf13/cobra"
+)
+
+var docsTypesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "문서 타입 목록",
+	Long: `인덱싱, 관련 문서 주입, 대시보드 트리에 사용되는 문서 타입을 표시합니다.
+
+기본 타입(l1/l2/lm/convention/template/port/agent/docs/session/adr)에 더해
+.pal/doc-types.yaml에서 표시 이름, 순서, glob 패턴, 주입 우선순위를 정의하거나 덮어쓸 수 있습니다.
+
+  types:
+    - id: runbook
+      name: 런북
+      order: 45
+      globs: ["ops/runbooks/**/*.md"]
+      priority: 30`,
+	RunE: runDocsTypes,
+}
+
+func init() {
+	docsCmd.AddCommand(docsTypesCmd)
+}
+
+func runDocsTypes(cmd *cobra.Command, args []string) error {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	if projectRoot == "" {
+		projectRoot = cwd
+	}
+
+	types, err := document.LoadTypeRegistry(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(types.Types())
+	}
+
+	fmt.Printf("%-12s %-16s %5s %8s  %s\n", "ID", "NAME", "ORDER", "PRIORITY", "GLOBS")
+	for _, t := range types.Types() {
+		priority := "-"
+		if t.Priority > 0 {
+			priority = fmt.Sprintf("%d", t.Priority)
+		}
+		globs := strings.Join(t.Globs, ", ")
+		if globs == "" {
+			globs = "(frontmatter type)"
+		}
+		if t.Source != "builtin" {
+			globs += "  *"
+		}
+		fmt.Printf("%-12s %-16s %5d %8s  %s\n", t.ID, t.Name, t.Order, priority, globs)
+	}
+	if hasCustomTypes(types) {
+		fmt.Printf("\n* %s에서 정의/수정된 타입\n", document.TypesFile)
+	}
+	return nil
+}
+
+func hasCustomTypes(types *document.TypeRegistry) bool {
+	for _, t := range types.Types() {
+		if t.Source != "builtin" {
+			return true
+		}
+	}
+	return false
+}
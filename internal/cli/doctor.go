@@ -8,9 +8,11 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/n0roo/pal-kit/internal/config"
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/rules"
 	"github.com/spf13/cobra"
 )
 
@@ -191,7 +193,12 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		})
 	}
 
-	// 6. Claude Code 설치 확인
+	// 6. 생성된 rules/브리핑 만료 확인
+	if _, err := os.Stat(claudeDir); err == nil {
+		checks = append(checks, checkContextExpiry(projectRoot))
+	}
+
+	// 7. Claude Code 설치 확인
 	claudePaths := []string{
 		"/usr/local/bin/claude",
 		os.ExpandEnv("$HOME/.local/bin/claude"),
@@ -275,6 +282,35 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// checkContextExpiry reports generated rules and the session briefing whose expiry has passed
+func checkContextExpiry(projectRoot string) CheckResult {
+	now := time.Now()
+	stale, err := rules.NewService(projectRoot).Expired(now)
+	if err != nil {
+		return CheckResult{Name: "Context Expiry", Status: "warning", Message: err.Error()}
+	}
+	for i, name := range stale {
+		stale[i] = "rules/" + name + ".md"
+	}
+	briefingPath := filepath.Join(projectRoot, ".pal", "context", "session-briefing.md")
+	if data, err := os.ReadFile(briefingPath); err == nil && rules.IsExpired(string(data), now) {
+		stale = append(stale, "session-briefing.md")
+	}
+
+	if len(stale) == 0 {
+		ttl := rules.ProjectTTL(projectRoot)
+		if ttl == 0 {
+			return CheckResult{Name: "Context Expiry", Status: "ok", Message: "만료 없음 (context_ttl: off)"}
+		}
+		return CheckResult{Name: "Context Expiry", Status: "ok", Message: fmt.Sprintf("만료된 파일 없음 (TTL %s)", ttl)}
+	}
+	return CheckResult{
+		Name:    "Context Expiry",
+		Status:  "warning",
+		Message: fmt.Sprintf("만료 %d개: %s - 'pal hook sync'로 재생성", len(stale), strings.Join(stale, ", ")),
+	}
+}
+
 // checkInstalledVersion checks the version of pal binary in PATH
 func checkInstalledVersion() string {
 	// Check common paths
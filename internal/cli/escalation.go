@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/n0roo/pal-kit/internal/context"
 	"github.com/n0roo/pal-kit/internal/db"
 	"github.com/n0roo/pal-kit/internal/escalation"
 	"github.com/spf13/cobra"
@@ -17,13 +19,21 @@ var (
 	escPortID    string
 	escStatus    string
 	escLimit     int
+	escType      string
+	escFields    []string
+	escSteps     []string
+	escNote      string
+	escSummary   string
 )
 
 var escalationCmd = &cobra.Command{
 	Use:     "escalation",
-	Aliases: []string{"esc"},
+	Aliases: []string{"esc", "escalate"},
 	Short:   "에스컬레이션 관리",
-	Long:    `상위 에스컬레이션을 관리합니다.`,
+	Long: `상위 에스컬레이션을 관리합니다.
+
+담당 지정(assign), 댓글(comment), 심각도 변경(severity), 해결(resolve --summary)은
+처리 기록(log)에 남고, 에스컬레이션을 올린 세션에 알림 메시지로 전달됩니다.`,
 }
 
 var escCreateCmd = &cobra.Command{
@@ -59,6 +69,27 @@ var escDismissCmd = &cobra.Command{
 	RunE:  runEscDismiss,
 }
 
+var escTypesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "에스컬레이션 타입 목록",
+	Long: `.pal/escalations.yaml에 정의된 에스컬레이션 타입을 표시합니다.
+
+타입별로 필수 필드와 권장 해결 단계를 정의할 수 있습니다:
+
+  types:
+    - id: blocked
+      name: 작업 차단
+      severity: high
+      fields:
+        - name: blocker
+          required: true
+      resolution_steps:
+        - id: identify-owner
+          description: 차단 요인의 담당자 확인
+          required: true`,
+	RunE: runEscTypes,
+}
+
 var escSummaryCmd = &cobra.Command{
 	Use:   "summary",
 	Short: "에스컬레이션 요약",
@@ -73,12 +104,20 @@ func init() {
 	escalationCmd.AddCommand(escResolveCmd)
 	escalationCmd.AddCommand(escDismissCmd)
 	escalationCmd.AddCommand(escSummaryCmd)
+	escalationCmd.AddCommand(escTypesCmd)
 
 	escCreateCmd.Flags().StringVar(&escIssue, "issue", "", "이슈 내용 (필수)")
 	escCreateCmd.Flags().StringVar(&escSessionID, "session", "", "발생 세션")
 	escCreateCmd.Flags().StringVar(&escPortID, "port", "", "발생 포트")
+	escCreateCmd.Flags().StringVar(&escType, "type", "", "에스컬레이션 타입 (.pal/escalations.yaml)")
+	escCreateCmd.Flags().StringArrayVar(&escFields, "field", nil, "타입 필드 (key=value, 여러 개 가능)")
 	escCreateCmd.MarkFlagRequired("issue")
 
+	escResolveCmd.Flags().StringArrayVar(&escSteps, "step", nil, "수행한 해결 단계 ID (여러 개 가능)")
+	escResolveCmd.Flags().StringVar(&escNote, "note", "", "해결 메모")
+	escResolveCmd.Flags().StringVar(&escSummary, "summary", "", "해결 요약 (차단된 워커에게 전달)")
+	escDismissCmd.Flags().StringVar(&escSummary, "reason", "", "무시 사유")
+
 	escListCmd.Flags().StringVar(&escStatus, "status", "", "상태 필터 (open|resolved|dismissed)")
 	escListCmd.Flags().IntVar(&escLimit, "limit", 20, "결과 수 제한")
 }
@@ -91,6 +130,25 @@ func getEscalationService() (*escalation.Service, func(), error) {
 	return escalation.NewService(database), func() { database.Close() }, nil
 }
 
+// loadEscalationTemplates loads escalation templates of the current project
+func loadEscalationTemplates() (*escalation.TemplateSet, error) {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	return escalation.LoadTemplates(projectRoot)
+}
+
+func parseEscFields(pairs []string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("잘못된 필드 형식: %s (key=value)", pair)
+		}
+		fields[strings.TrimSpace(key)] = value
+	}
+	return fields, nil
+}
+
 func runEscCreate(cmd *cobra.Command, args []string) error {
 	if escIssue == "" {
 		return fmt.Errorf("--issue 플래그가 필요합니다")
@@ -108,9 +166,37 @@ func runEscCreate(cmd *cobra.Command, args []string) error {
 	}
 	defer cleanup()
 
-	id, err := svc.Create(escIssue, sessionID, escPortID)
-	if err != nil {
-		return err
+	var id int64
+	if escType != "" {
+		templates, err := loadEscalationTemplates()
+		if err != nil {
+			return err
+		}
+		tmpl, ok := templates.Get(escType)
+		if !ok {
+			return fmt.Errorf("알 수 없는 에스컬레이션 타입: %s (pal esc types 참고)", escType)
+		}
+		fields, err := parseEscFields(escFields)
+		if err != nil {
+			return err
+		}
+		id, err = svc.CreateTyped(tmpl, escalation.TypedOptions{
+			Issue:     escIssue,
+			SessionID: sessionID,
+			PortID:    escPortID,
+			Fields:    fields,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		if len(escFields) > 0 {
+			return fmt.Errorf("--field는 --type과 함께 사용해야 합니다")
+		}
+		id, err = svc.Create(escIssue, sessionID, escPortID)
+		if err != nil {
+			return err
+		}
 	}
 
 	if jsonOut {
@@ -120,9 +206,13 @@ func runEscCreate(cmd *cobra.Command, args []string) error {
 			"issue":   escIssue,
 			"session": sessionID,
 			"port":    escPortID,
+			"type":    escType,
 		})
 	} else {
 		fmt.Printf("🚨 에스컬레이션 생성: #%d\n", id)
+		if escType != "" {
+			fmt.Printf("  타입: %s\n", escType)
+		}
 		fmt.Printf("  이슈: %s\n", escIssue)
 		if sessionID != "" {
 			fmt.Printf("  세션: %s\n", sessionID)
@@ -211,6 +301,12 @@ func runEscShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("에스컬레이션 #%d\n", e.ID)
 	fmt.Println(strings.Repeat("-", 40))
 	fmt.Printf("상태: %s\n", e.Status)
+	if e.Type.Valid && e.Type.String != "" {
+		fmt.Printf("타입: %s\n", e.Type.String)
+	}
+	if e.Severity.Valid && e.Severity.String != "" {
+		fmt.Printf("심각도: %s\n", e.Severity.String)
+	}
 	fmt.Printf("이슈: %s\n", e.Issue)
 	if e.FromSession.Valid {
 		fmt.Printf("세션: %s\n", e.FromSession.String)
@@ -218,11 +314,52 @@ func runEscShow(cmd *cobra.Command, args []string) error {
 	if e.FromPort.Valid {
 		fmt.Printf("포트: %s\n", e.FromPort.String)
 	}
+	if e.Assignee.Valid && e.Assignee.String != "" {
+		fmt.Printf("담당: %s\n", e.Assignee.String)
+	}
 	fmt.Printf("생성: %s\n", e.CreatedAt.Format("2006-01-02 15:04:05"))
 	if e.ResolvedAt.Valid {
 		fmt.Printf("해결: %s\n", e.ResolvedAt.Time.Format("2006-01-02 15:04:05"))
 	}
 
+	if fields := e.Fields(); len(fields) > 0 {
+		fmt.Println()
+		fmt.Println("필드:")
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("  %s: %s\n", k, fields[k])
+		}
+	}
+	if e.Suggestion.Valid && e.Suggestion.String != "" {
+		fmt.Println()
+		fmt.Println("권장 해결 단계:")
+		for _, line := range strings.Split(e.Suggestion.String, "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+	if record := e.ResolutionRecord(); record != nil {
+		fmt.Println()
+		if len(record.Steps) > 0 {
+			fmt.Printf("수행한 단계: %s\n", strings.Join(record.Steps, ", "))
+		}
+		if record.Summary != "" {
+			fmt.Printf("해결 요약: %s\n", record.Summary)
+		}
+		if record.Note != "" {
+			fmt.Printf("해결 메모: %s\n", record.Note)
+		}
+	}
+
+	if activities, err := svc.Activity(id); err == nil && len(activities) > 0 {
+		fmt.Println()
+		fmt.Println("처리 기록:")
+		printEscActivities(activities)
+	}
+
 	return nil
 }
 
@@ -236,17 +373,43 @@ func runEscResolve(cmd *cobra.Command, args []string) error {
 	}
 	defer cleanup()
 
-	if err := svc.Resolve(id); err != nil {
+	e, err := svc.Get(id)
+	if err != nil {
+		return err
+	}
+
+	// 타입이 있는 에스컬레이션은 해결 단계를 검증하고 기록
+	var tmpl *escalation.Template
+	if e.Type.Valid && e.Type.String != "" {
+		templates, err := loadEscalationTemplates()
+		if err != nil {
+			return err
+		}
+		if t, ok := templates.Get(e.Type.String); ok {
+			tmpl = t
+		}
+	}
+
+	record := escalation.ResolutionRecord{Steps: escSteps, Note: escNote, Summary: escSummary, By: escActor()}
+	if err := svc.ResolveWithSteps(id, tmpl, record); err != nil {
 		return err
 	}
 
 	if jsonOut {
 		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
-			"status": "resolved",
-			"id":     id,
+			"status":  "resolved",
+			"id":      id,
+			"steps":   escSteps,
+			"summary": escSummary,
 		})
 	} else {
 		fmt.Printf("✅ 에스컬레이션 해결: #%d\n", id)
+		if len(escSteps) > 0 {
+			fmt.Printf("  수행한 단계: %s\n", strings.Join(escSteps, ", "))
+		}
+		if escSummary != "" {
+			fmt.Printf("  해결 요약: %s\n", escSummary)
+		}
 	}
 
 	return nil
@@ -262,7 +425,7 @@ func runEscDismiss(cmd *cobra.Command, args []string) error {
 	}
 	defer cleanup()
 
-	if err := svc.Dismiss(id); err != nil {
+	if err := svc.DismissBy(id, escActor(), escSummary); err != nil {
 		return err
 	}
 
@@ -308,3 +471,52 @@ func runEscSummary(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runEscTypes(cmd *cobra.Command, args []string) error {
+	templates, err := loadEscalationTemplates()
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(templates)
+	}
+
+	if len(templates.Types) == 0 {
+		fmt.Printf("정의된 에스컬레이션 타입이 없습니다. (%s)\n", escalation.TemplatesFile)
+		return nil
+	}
+
+	for _, t := range templates.Types {
+		name := t.Name
+		if name == "" {
+			name = t.ID
+		}
+		fmt.Printf("📋 %s (%s)", t.ID, name)
+		if t.Severity != "" {
+			fmt.Printf(" [%s]", t.Severity)
+		}
+		fmt.Println()
+		if t.Description != "" {
+			fmt.Printf("  %s\n", t.Description)
+		}
+		for _, f := range t.Fields {
+			marker := " "
+			if f.Required {
+				marker = "*"
+			}
+			fmt.Printf("  %s --field %s=...  %s\n", marker, f.Name, f.Description)
+		}
+		for _, step := range t.ResolutionSteps {
+			marker := " "
+			if step.Required {
+				marker = "*"
+			}
+			fmt.Printf("  %s --step %s  %s\n", marker, step.ID, step.Description)
+		}
+		fmt.Println()
+	}
+	fmt.Println("(* 필수)")
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/spf13/cobra"
+)
+
+var (
+	escBy       string
+	escReplyTo  int64
+	escUnassign bool
+)
+
+var escAssignCmd = &cobra.Command{
+	Use:   "assign <id> [assignee]",
+	Short: "에스컬레이션 담당자 지정",
+	Long: `열린 에스컬레이션의 담당 사용자/에이전트를 지정합니다.
+에스컬레이션을 올린 세션에 알림 메시지가 전달됩니다.
+
+예시:
+  pal esc assign 12 alice
+  pal esc assign 12 --unassign`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runEscAssign,
+}
+
+var escCommentCmd = &cobra.Command{
+	Use:   "comment <id> <message>",
+	Short: "에스컬레이션 댓글",
+	Long: `에스컬레이션에 댓글을 남깁니다. --reply로 기존 댓글에 답글을 달 수 있습니다.
+
+예시:
+  pal esc comment 12 "스키마 변경 승인됨, 진행하세요"
+  pal esc comment 12 "확인했습니다" --reply 3`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runEscComment,
+}
+
+var escSeverityCmd = &cobra.Command{
+	Use:   "severity <id> <low|medium|high|critical>",
+	Short: "에스컬레이션 심각도 변경",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runEscSeverity,
+}
+
+var escLogCmd = &cobra.Command{
+	Use:   "log <id>",
+	Short: "에스컬레이션 처리 기록",
+	Long:  `담당 지정, 댓글(스레드), 심각도 변경, 해결/무시 기록을 시간순으로 표시합니다.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runEscLog,
+}
+
+func init() {
+	escalationCmd.AddCommand(escAssignCmd)
+	escalationCmd.AddCommand(escCommentCmd)
+	escalationCmd.AddCommand(escSeverityCmd)
+	escalationCmd.AddCommand(escLogCmd)
+
+	for _, c := range []*cobra.Command{escAssignCmd, escCommentCmd, escSeverityCmd, escResolveCmd, escDismissCmd} {
+		c.Flags().StringVar(&escBy, "by", "", "처리자 (기본: CLAUDE_SESSION_ID 또는 USER)")
+	}
+	escAssignCmd.Flags().BoolVar(&escUnassign, "unassign", false, "담당 해제")
+	escCommentCmd.Flags().Int64Var(&escReplyTo, "reply", 0, "답글 대상 댓글 ID")
+}
+
+// escActor returns who performs an escalation action
+func escActor() string {
+	if escBy != "" {
+		return escBy
+	}
+	if id := os.Getenv("CLAUDE_SESSION_ID"); id != "" {
+		return id
+	}
+	return os.Getenv("USER")
+}
+
+func parseEscID(arg string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("잘못된 에스컬레이션 ID: %s", arg)
+	}
+	return id, nil
+}
+
+func printEscActivity(a *escalation.Activity) {
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(a)
+		return
+	}
+	switch a.Kind {
+	case escalation.ActivityAssign:
+		if a.NewValue == "" {
+			fmt.Printf("👤 에스컬레이션 #%d 담당 해제\n", a.EscalationID)
+		} else {
+			fmt.Printf("👤 에스컬레이션 #%d 담당: %s\n", a.EscalationID, a.NewValue)
+		}
+	case escalation.ActivityComment:
+		fmt.Printf("💬 에스컬레이션 #%d 댓글 #%d 작성\n", a.EscalationID, a.ID)
+	case escalation.ActivitySeverity:
+		fmt.Printf("⚠️  에스컬레이션 #%d 심각도: %s → %s\n", a.EscalationID, orDash(a.OldValue), a.NewValue)
+	}
+}
+
+func runEscAssign(cmd *cobra.Command, args []string) error {
+	id, err := parseEscID(args[0])
+	if err != nil {
+		return err
+	}
+	assignee := ""
+	switch {
+	case len(args) == 2 && escUnassign:
+		return fmt.Errorf("담당자와 --unassign은 함께 사용할 수 없습니다")
+	case len(args) == 2:
+		assignee = args[1]
+	case !escUnassign:
+		return fmt.Errorf("담당자를 지정하거나 --unassign을 사용하세요")
+	}
+
+	svc, cleanup, err := getEscalationService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	a, err := svc.Assign(id, assignee, escActor())
+	if err != nil {
+		return err
+	}
+	printEscActivity(a)
+	return nil
+}
+
+func runEscComment(cmd *cobra.Command, args []string) error {
+	id, err := parseEscID(args[0])
+	if err != nil {
+		return err
+	}
+
+	svc, cleanup, err := getEscalationService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	a, err := svc.Comment(id, escActor(), strings.Join(args[1:], " "), escReplyTo)
+	if err != nil {
+		return err
+	}
+	printEscActivity(a)
+	return nil
+}
+
+func runEscSeverity(cmd *cobra.Command, args []string) error {
+	id, err := parseEscID(args[0])
+	if err != nil {
+		return err
+	}
+	severity, err := escalation.ParseSeverity(args[1])
+	if err != nil {
+		return err
+	}
+
+	svc, cleanup, err := getEscalationService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	a, err := svc.SetSeverity(id, severity, escActor())
+	if err != nil {
+		return err
+	}
+	printEscActivity(a)
+	return nil
+}
+
+func runEscLog(cmd *cobra.Command, args []string) error {
+	id, err := parseEscID(args[0])
+	if err != nil {
+		return err
+	}
+
+	svc, cleanup, err := getEscalationService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if _, err := svc.Get(id); err != nil {
+		return err
+	}
+	activities, err := svc.Activity(id)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		if activities == nil {
+			activities = []escalation.Activity{}
+		}
+		json.NewEncoder(os.Stdout).Encode(activities)
+		return nil
+	}
+	if len(activities) == 0 {
+		fmt.Printf("에스컬레이션 #%d 처리 기록이 없습니다\n", id)
+		return nil
+	}
+	printEscActivities(activities)
+	return nil
+}
+
+// printEscActivities prints the audit trail; 답글은 대상 댓글 아래에 들여씁니다
+func printEscActivities(activities []escalation.Activity) {
+	replies := make(map[int64][]escalation.Activity)
+	for _, a := range activities {
+		if a.ParentID > 0 {
+			replies[a.ParentID] = append(replies[a.ParentID], a)
+		}
+	}
+
+	var print func(a escalation.Activity, depth int)
+	print = func(a escalation.Activity, depth int) {
+		indent := strings.Repeat("    ", depth)
+		when := a.CreatedAt.Local().Format("01-02 15:04")
+		actor := orDash(a.Actor)
+		switch a.Kind {
+		case escalation.ActivityComment:
+			fmt.Printf("%s[%s] #%d %s: %s\n", indent, when, a.ID, actor, a.Body)
+		case escalation.ActivityAssign:
+			fmt.Printf("%s[%s] %s 담당 변경: %s → %s\n", indent, when, actor, orDash(a.OldValue), orDash(a.NewValue))
+		case escalation.ActivitySeverity:
+			fmt.Printf("%s[%s] %s 심각도 변경: %s → %s\n", indent, when, actor, orDash(a.OldValue), a.NewValue)
+		default:
+			line := fmt.Sprintf("%s[%s] %s %s", indent, when, actor, a.NewValue)
+			if a.Body != "" {
+				line += ": " + a.Body
+			}
+			fmt.Println(line)
+		}
+		for _, reply := range replies[a.ID] {
+			print(reply, depth+1)
+		}
+	}
+
+	for _, a := range activities {
+		if a.ParentID == 0 {
+			print(a, 0)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/feedback"
+	"github.com/spf13/cobra"
+)
+
+var (
+	feedbackOn      string
+	feedbackRef     string
+	feedbackUp      bool
+	feedbackDown    bool
+	feedbackAgent   bool
+	feedbackSession string
+	feedbackLimit   int
+	feedbackDays    int
+	feedbackAllProj bool
+)
+
+var feedbackCmd = &cobra.Command{
+	Use:   "feedback <comment...>",
+	Short: "주입 컨텍스트 품질 피드백 기록",
+	Long: `세션에 주입된 브리핑, 규칙 파일, 문서에 대한 의견을 기록합니다.
+템플릿/문서별로 모아 컨텍스트 구성과 관련 문서 순위를 조정하는 근거로 씁니다.
+
+대상 (--on):
+  briefing  - 세션 시작 브리핑
+  rules     - .claude/rules 규칙 파일
+  docs      - 주입된 관련 문서
+
+예시:
+  pal feedback "최근 세션 목록은 매번 불필요" --on briefing --down
+  pal feedback "API 문서가 오래됨" --on docs --ref docs/api.md --down
+  pal feedback "컨벤션 규칙 덕분에 리뷰가 줄었음" --on rules --ref port-auth.md --up
+  pal feedback list --on docs
+  pal feedback report`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runFeedback,
+}
+
+var feedbackListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "피드백 목록",
+	RunE:  runFeedbackList,
+}
+
+var feedbackReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "템플릿/문서별 피드백 집계",
+	Long: `대상과 템플릿/문서별로 피드백 수, 유용(+)/불필요(-) 평가와 점수를 집계합니다.
+점수가 낮은 항목(조정이 필요한 것)부터 표시합니다.`,
+	RunE: runFeedbackReport,
+}
+
+func init() {
+	rootCmd.AddCommand(feedbackCmd)
+	feedbackCmd.AddCommand(feedbackListCmd)
+	feedbackCmd.AddCommand(feedbackReportCmd)
+
+	feedbackCmd.PersistentFlags().StringVar(&feedbackOn, "on", "", "대상 (briefing|rules|docs)")
+	feedbackCmd.PersistentFlags().StringVar(&feedbackRef, "ref", "", "템플릿/문서 식별자 (예: docs/api.md)")
+	feedbackCmd.PersistentFlags().BoolVar(&feedbackAllProj, "all-projects", false, "모든 프로젝트의 피드백")
+	feedbackCmd.Flags().BoolVar(&feedbackUp, "up", false, "유용했음")
+	feedbackCmd.Flags().BoolVar(&feedbackDown, "down", false, "불필요하거나 부정확했음")
+	feedbackCmd.Flags().BoolVar(&feedbackAgent, "agent", false, "에이전트가 남긴 피드백")
+	feedbackCmd.Flags().StringVar(&feedbackSession, "session", "", "세션 ID (기본: CLAUDE_SESSION_ID)")
+	feedbackListCmd.Flags().IntVar(&feedbackLimit, "limit", 20, "결과 수 제한")
+	feedbackReportCmd.Flags().IntVar(&feedbackDays, "since", 0, "최근 N일만 집계 (0: 전체)")
+}
+
+func getFeedbackService() (*feedback.Service, func(), error) {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return nil, nil, err
+	}
+	return feedback.NewService(database), func() { database.Close() }, nil
+}
+
+// feedbackFilter builds the list/report filter from the shared flags
+func feedbackFilter() (feedback.Filter, error) {
+	var filter feedback.Filter
+	if feedbackOn != "" {
+		target, err := feedback.ParseTarget(feedbackOn)
+		if err != nil {
+			return filter, err
+		}
+		filter.Target = target
+	}
+	filter.Ref = feedbackRef
+	if !feedbackAllProj {
+		cwd, _ := os.Getwd()
+		filter.ProjectRoot = context.FindProjectRoot(cwd)
+	}
+	return filter, nil
+}
+
+func runFeedback(cmd *cobra.Command, args []string) error {
+	if feedbackOn == "" {
+		return fmt.Errorf("--on으로 대상을 지정하세요 (briefing|rules|docs)")
+	}
+	target, err := feedback.ParseTarget(feedbackOn)
+	if err != nil {
+		return err
+	}
+	if feedbackUp && feedbackDown {
+		return fmt.Errorf("--up과 --down은 함께 사용할 수 없습니다")
+	}
+
+	entry := feedback.Entry{
+		Target:    target,
+		Ref:       feedbackRef,
+		Comment:   strings.Join(args, " "),
+		Source:    feedback.SourceUser,
+		SessionID: feedbackSession,
+	}
+	switch {
+	case feedbackUp:
+		entry.Rating = 1
+	case feedbackDown:
+		entry.Rating = -1
+	}
+	if feedbackAgent {
+		entry.Source = feedback.SourceAgent
+	}
+	if entry.SessionID == "" {
+		entry.SessionID = os.Getenv("CLAUDE_SESSION_ID")
+	}
+	cwd, _ := os.Getwd()
+	entry.ProjectRoot = context.FindProjectRoot(cwd)
+
+	svc, cleanup, err := getFeedbackService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	recorded, err := svc.Record(entry)
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(recorded, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+	fmt.Printf("✓ 피드백 기록: [%s] %s %s\n", recorded.Target, recorded.Ref, ratingMark(recorded.Rating))
+	return nil
+}
+
+func runFeedbackList(cmd *cobra.Command, args []string) error {
+	filter, err := feedbackFilter()
+	if err != nil {
+		return err
+	}
+	filter.Limit = feedbackLimit
+
+	svc, cleanup, err := getFeedbackService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	entries, err := svc.List(filter)
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(entries, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+	if len(entries) == 0 {
+		fmt.Println("피드백이 없습니다.")
+		return nil
+	}
+	for _, e := range entries {
+		fmt.Printf("%s %-9s %-30s %s %s (%s)\n",
+			e.CreatedAt.Local().Format("01-02 15:04"), e.Target, truncate(e.Ref, 30),
+			ratingMark(e.Rating), e.Comment, e.Source)
+	}
+	return nil
+}
+
+func runFeedbackReport(cmd *cobra.Command, args []string) error {
+	filter, err := feedbackFilter()
+	if err != nil {
+		return err
+	}
+	if feedbackDays > 0 {
+		filter.Since = time.Now().AddDate(0, 0, -feedbackDays)
+	}
+
+	svc, cleanup, err := getFeedbackService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	aggs, err := svc.Aggregates(filter)
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(aggs, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+	if len(aggs) == 0 {
+		fmt.Println("피드백이 없습니다.")
+		return nil
+	}
+
+	fmt.Printf("%-9s %-30s %5s %4s %4s %6s  %s\n", "TARGET", "REF", "COUNT", "+", "-", "SCORE", "LAST")
+	fmt.Println(strings.Repeat("-", 90))
+	for _, a := range aggs {
+		fmt.Printf("%-9s %-30s %5d %4d %4d %+6.2f  %s\n",
+			a.Target, truncate(a.Ref, 30), a.Count, a.Up, a.Down, a.Score, a.LastComment)
+	}
+	return nil
+}
+
+func ratingMark(rating int) string {
+	switch {
+	case rating > 0:
+		return "👍"
+	case rating < 0:
+		return "👎"
+	}
+	return "💬"
+}
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/rules"
+	"github.com/n0roo/pal-kit/internal/template"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fixID      string
+	fixBranch  bool
+	fixNoStart bool
+)
+
+var fixCmd = &cobra.Command{
+	Use:   "fix <bug description>",
+	Short: "버그 수정 포트 생성 및 시작",
+	Long: `버그 설명으로부터 bugfix 템플릿 포트를 생성하고 바로 시작합니다.
+
+수행 단계:
+  1. bugfix 템플릿으로 포트 문서 생성 (ports/<id>.md)
+  2. (--branch) fix/<slug> 브랜치 생성
+  3. 포트 활성화 (running 상태 + rules 파일)
+  4. 디버깅 브리핑을 rules 파일에 주입
+
+예시:
+  pal fix "로그인 후 세션이 만료되지 않음"
+  pal fix "null pointer in order export" --branch
+  pal fix "결제 금액 반올림 오류" --id fix-payment-rounding`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runFix,
+}
+
+func init() {
+	rootCmd.AddCommand(fixCmd)
+	fixCmd.Flags().StringVar(&fixID, "id", "", "포트 ID (기본: 설명에서 생성)")
+	fixCmd.Flags().BoolVar(&fixBranch, "branch", false, "fix/<slug> git 브랜치 생성")
+	fixCmd.Flags().BoolVar(&fixNoStart, "no-start", false, "포트만 생성하고 시작하지 않음")
+}
+
+func runFix(cmd *cobra.Command, args []string) (err error) {
+	description := strings.Join(args, " ")
+
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	if projectRoot == "" {
+		return fmt.Errorf("PAL 프로젝트를 찾을 수 없습니다 (pal init 실행 필요)")
+	}
+
+	portID := fixID
+	if portID == "" {
+		portID = fixPortID(description, time.Now())
+	}
+	title := "Fix: " + description
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+	svc := port.NewService(database)
+
+	relPath := template.GetDefaultOutputPath(template.TypeBugfix, portID)
+	specPath := filepath.Join(projectRoot, relPath)
+	if _, statErr := os.Stat(specPath); statErr == nil {
+		return fmt.Errorf("포트 문서가 이미 있습니다: %s", relPath)
+	}
+
+	// 중간 단계가 실패하면 앞서 만든 포트, 문서, 브랜치, rules를 되돌림
+	var undo []func()
+	defer func() {
+		if err != nil {
+			for i := len(undo) - 1; i >= 0; i-- {
+				undo[i]()
+			}
+		}
+	}()
+
+	// 1. bugfix 템플릿으로 포트 문서 생성
+	if err := svc.Create(portID, title, relPath); err != nil {
+		return err
+	}
+	undo = append(undo, func() { svc.Delete(portID) })
+	tmplSvc := template.NewService(projectRoot)
+	undo = append(undo, func() { os.Remove(specPath) })
+	if err := tmplSvc.Create(template.TypeBugfix, specPath, template.TemplateData{
+		ID:          portID,
+		Title:       title,
+		Description: description,
+	}); err != nil {
+		return err
+	}
+
+	// 2. 브랜치 생성
+	branch := ""
+	if fixBranch {
+		branch = "fix/" + strings.TrimPrefix(portID, "fix-")
+		gitCmd := exec.Command("git", "checkout", "-b", branch)
+		gitCmd.Dir = projectRoot
+		if output, err := gitCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("브랜치 생성 실패: %s", strings.TrimSpace(string(output)))
+		}
+		undo = append(undo, func() {
+			for _, args := range [][]string{{"checkout", "-"}, {"branch", "-D", branch}} {
+				gitCmd := exec.Command("git", args...)
+				gitCmd.Dir = projectRoot
+				gitCmd.Run()
+			}
+		})
+	}
+
+	// 3-4. 포트 시작 (port-start Hook과 같은 경로: 세션 연결, port_start 이벤트) + 디버깅 브리핑 주입
+	rulesSvc := rules.NewService(projectRoot)
+	if !fixNoStart {
+		undo = append(undo, func() { rulesSvc.DeactivatePort(portID) })
+		if _, err := startPort(database, portID, "", cwd, projectRoot, []string{relPath}); err != nil {
+			return err
+		}
+		if err := rulesSvc.AppendToRule(portID, fixBriefing(description)); err != nil {
+			return err
+		}
+	}
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"status":    "created",
+			"id":        portID,
+			"title":     title,
+			"file_path": relPath,
+			"branch":    branch,
+			"started":   !fixNoStart,
+		})
+		return nil
+	}
+
+	fmt.Printf("🐛 버그 수정 포트 생성: %s\n", portID)
+	fmt.Printf("  문서: %s\n", relPath)
+	if branch != "" {
+		fmt.Printf("  브랜치: %s\n", branch)
+	}
+	if !fixNoStart {
+		fmt.Printf("  규칙 파일: %s\n", rulesSvc.GetRulePath(portID))
+		fmt.Println()
+		fmt.Println("🔄 포트가 시작되었습니다. 재현 → 실패 테스트 → 최소 수정 순서로 진행하세요.")
+		fmt.Printf("   완료: pal port status %s complete\n", portID)
+	}
+
+	return nil
+}
+
+var fixSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// fixPortID derives a port ID from the bug description.
+// 영문 단어가 없으면 타임스탬프 기반 ID를 사용합니다.
+func fixPortID(description string, now time.Time) string {
+	slug := strings.Trim(fixSlugPattern.ReplaceAllString(strings.ToLower(description), "-"), "-")
+
+	words := strings.Split(slug, "-")
+	if len(words) > 5 {
+		words = words[:5]
+	}
+	slug = strings.Join(words, "-")
+
+	if slug == "" {
+		return "fix-" + now.Format("20060102-150405")
+	}
+	return "fix-" + slug
+}
+
+// fixBriefing returns a focused debugging briefing for a bugfix port
+func fixBriefing(description string) string {
+	var sb strings.Builder
+
+	sb.WriteString("## 디버깅 브리핑\n\n")
+	sb.WriteString(fmt.Sprintf("> 버그: %s\n\n", description))
+	sb.WriteString("### 진행 순서\n")
+	sb.WriteString("1. 버그를 재현하고 재현 절차를 포트 문서에 기록합니다\n")
+	sb.WriteString("2. 버그를 재현하는 실패 테스트를 먼저 작성합니다\n")
+	sb.WriteString("3. 원인 가설을 세우고 로그/디버거로 검증합니다\n")
+	sb.WriteString("4. 원인을 해결하는 최소 범위의 수정만 적용합니다\n")
+	sb.WriteString("5. 실패 테스트와 기존 테스트가 모두 통과하는지 확인합니다\n\n")
+	sb.WriteString("### 주의\n")
+	sb.WriteString("- 관련 없는 리팩토링이나 기능 추가는 하지 않습니다\n")
+	sb.WriteString("- 원인이 포트 범위를 벗어나면 에스컬레이션합니다 (`pal escalation create`)\n")
+
+	return sb.String()
+}
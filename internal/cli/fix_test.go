@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/port"
+)
+
+func TestFixPortID(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"Null pointer in order export", "fix-null-pointer-in-order-export"},
+		{"  Login fails: session expired!! ", "fix-login-fails-session-expired"},
+		{"one two three four five six seven", "fix-one-two-three-four-five"},
+		{"로그인 후 세션이 만료되지 않음", "fix-20260310-140730"},
+	}
+
+	for _, tt := range tests {
+		if got := fixPortID(tt.description, now); got != tt.want {
+			t.Errorf("fixPortID(%q) = %q, want %q", tt.description, got, tt.want)
+		}
+	}
+}
+
+func TestFixBriefing(t *testing.T) {
+	briefing := fixBriefing("결제 금액 반올림 오류")
+
+	if !strings.Contains(briefing, "결제 금액 반올림 오류") {
+		t.Error("briefing should contain the bug description")
+	}
+	if !strings.Contains(briefing, "실패 테스트") {
+		t.Error("briefing should guide writing a failing test first")
+	}
+}
+
+func TestRunFixRollsBackOnFailure(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, ".claude"), 0755)
+	t.Chdir(root)
+
+	oldDB, oldID, oldBranch := dbPath, fixID, fixBranch
+	defer func() { dbPath, fixID, fixBranch = oldDB, oldID, oldBranch }()
+	dbPath = filepath.Join(t.TempDir(), "pal.db")
+	fixID, fixBranch = "fix-rollback", true
+
+	// git 저장소가 아니라 브랜치 생성이 실패
+	t.Setenv("GIT_DIR", filepath.Join(root, "no-git"))
+	if err := runFix(fixCmd, []string{"rollback"}); err == nil {
+		t.Fatal("expected branch creation to fail outside a git repository")
+	}
+
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	if _, err := port.NewService(database).Get("fix-rollback"); err == nil {
+		t.Error("port should be deleted after a failed fix")
+	}
+	matches, _ := filepath.Glob(filepath.Join(root, "ports", "*"))
+	if len(matches) != 0 {
+		t.Errorf("port spec should be removed after a failed fix: %v", matches)
+	}
+}
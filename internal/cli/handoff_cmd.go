@@ -3,8 +3,11 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/context"
 	"github.com/n0roo/pal-kit/internal/db"
 	"github.com/n0roo/pal-kit/internal/handoff"
 	"github.com/spf13/cobra"
@@ -17,6 +20,18 @@ var handoffCmd = &cobra.Command{
 	Long:    `포트 간 컨텍스트 전달(Handoff)을 관리합니다.`,
 }
 
+// newHandoffStore creates a handoff store with the project's token budgets applied
+func newHandoffStore(database *db.DB) *handoff.Store {
+	store := handoff.NewStore(database)
+	cwd, _ := os.Getwd()
+	if projectRoot := context.FindProjectRoot(cwd); projectRoot != "" {
+		if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+			store.SetBudgets(projectCfg.Settings.HandoffBudgets)
+		}
+	}
+	return store
+}
+
 var hoListCmd = &cobra.Command{
 	Use:   "list [port-id]",
 	Short: "포트의 Handoff 목록",
@@ -28,7 +43,7 @@ var hoListCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		store := handoff.NewStore(database)
+		store := newHandoffStore(database)
 		direction, _ := cmd.Flags().GetString("direction")
 
 		var handoffs []*handoff.Handoff
@@ -86,7 +101,7 @@ var hoShowCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		store := handoff.NewStore(database)
+		store := newHandoffStore(database)
 		h, err := store.Get(args[0])
 		if err != nil {
 			return err
@@ -114,7 +129,7 @@ var hoCreateCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		store := handoff.NewStore(database)
+		store := newHandoffStore(database)
 
 		hoType, _ := cmd.Flags().GetString("type")
 		contentStr, _ := cmd.Flags().GetString("content")
@@ -149,9 +164,14 @@ var hoCreateCmd = &cobra.Command{
 var hoEstimateCmd = &cobra.Command{
 	Use:   "estimate",
 	Short: "컨텐츠 토큰 추정",
+	Long: `Handoff 콘텐츠의 토큰 수를 추정하고 타입별 예산과 비교합니다.
+
+예산은 타입별 기본값(api_contract 1500, schema 3000 등)을 사용하며,
+.pal/config.yaml의 settings.handoff_budgets로 프로젝트별로 덮어쓸 수 있습니다.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		contentStr, _ := cmd.Flags().GetString("content")
 		filePath, _ := cmd.Flags().GetString("file")
+		hoType, _ := cmd.Flags().GetString("type")
 
 		var content interface{}
 		if filePath != "" {
@@ -171,14 +191,29 @@ var hoEstimateCmd = &cobra.Command{
 		}
 
 		budget := handoff.MaxTokenBudget
+		if hoType != "" {
+			database, err := db.Open(GetDBPath())
+			if err != nil {
+				return err
+			}
+			defer database.Close()
+			budget = newHandoffStore(database).BudgetFor(handoff.HandoffType(hoType))
+		}
 		percent := float64(tokens) / float64(budget) * 100
 
+		var suggestions []string
+		if tokens > budget {
+			suggestions = handoff.TrimSuggestions(content, tokens-budget)
+		}
+
 		if IsJSON() {
 			data, _ := json.Marshal(map[string]interface{}{
-				"tokens":  tokens,
-				"budget":  budget,
-				"percent": percent,
-				"valid":   tokens <= budget,
+				"type":        hoType,
+				"tokens":      tokens,
+				"budget":      budget,
+				"percent":     percent,
+				"valid":       tokens <= budget,
+				"suggestions": suggestions,
 			})
 			fmt.Println(string(data))
 			return nil
@@ -190,6 +225,9 @@ var hoEstimateCmd = &cobra.Command{
 		}
 
 		fmt.Printf("Token Estimate: %d / %d (%.1f%%) %s\n", tokens, budget, percent, status)
+		for _, sg := range suggestions {
+			fmt.Printf("  - %s\n", sg)
+		}
 		return nil
 	},
 }
@@ -205,7 +243,7 @@ var hoTotalCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		store := handoff.NewStore(database)
+		store := newHandoffStore(database)
 		total, err := store.GetTotalTokens(args[0])
 		if err != nil {
 			return err
@@ -240,6 +278,7 @@ func init() {
 	handoffCmd.AddCommand(hoEstimateCmd)
 	hoEstimateCmd.Flags().StringP("content", "c", "", "콘텐츠 (JSON)")
 	hoEstimateCmd.Flags().StringP("file", "f", "", "파일 경로")
+	hoEstimateCmd.Flags().StringP("type", "t", "", "Handoff 타입 (지정 시 타입별 예산 적용)")
 
 	handoffCmd.AddCommand(hoTotalCmd)
 }
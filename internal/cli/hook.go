@@ -6,20 +6,27 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/n0roo/pal-kit/internal/attention"
 	"github.com/n0roo/pal-kit/internal/config"
 	"github.com/n0roo/pal-kit/internal/context"
 	"github.com/n0roo/pal-kit/internal/db"
 	"github.com/n0roo/pal-kit/internal/document"
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/i18n"
 	"github.com/n0roo/pal-kit/internal/lock"
 	"github.com/n0roo/pal-kit/internal/manifest"
 	"github.com/n0roo/pal-kit/internal/message"
+	"github.com/n0roo/pal-kit/internal/metrics"
+	"github.com/n0roo/pal-kit/internal/notify"
 	"github.com/n0roo/pal-kit/internal/operator"
 	"github.com/n0roo/pal-kit/internal/orchestrator"
 	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/primer"
 	"github.com/n0roo/pal-kit/internal/recovery"
 	"github.com/n0roo/pal-kit/internal/rules"
 	"github.com/n0roo/pal-kit/internal/server/events"
@@ -67,14 +74,22 @@ type HookInput struct {
 	HookEventName  string `json:"hook_event_name"`
 
 	// SessionStart specific
-	Source string `json:"source,omitempty"` // "startup"
+	Source string `json:"source,omitempty"` // "startup", "resume", "clear", "compact"
 
 	// SessionEnd specific
 	Reason string `json:"reason,omitempty"` // "exit", "clear", "logout", "prompt_input_exit", "other"
 
+	// UserPromptSubmit specific
+	Prompt string `json:"prompt,omitempty"`
+
 	// Stop/SubagentStop specific
 	StopHookActive bool `json:"stop_hook_active,omitempty"`
 
+	// SubagentStart/SubagentStop specific
+	AgentID             string `json:"agent_id,omitempty"`
+	AgentType           string `json:"agent_type,omitempty"`
+	AgentTranscriptPath string `json:"agent_transcript_path,omitempty"`
+
 	// PreToolUse/PostToolUse specific
 	ToolName     string                 `json:"tool_name,omitempty"`
 	ToolInput    map[string]interface{} `json:"tool_input,omitempty"`
@@ -100,6 +115,7 @@ type HookOutput struct {
 	Reason     string                 `json:"reason,omitempty"`
 	Continue   bool                   `json:"continue,omitempty"`
 	StopReason string                 `json:"stopReason,omitempty"`
+	SystemMessage string              `json:"systemMessage,omitempty"` // 사용자에게 표시되는 메시지
 	HookOutput map[string]interface{} `json:"hookSpecificOutput,omitempty"`
 
 	// v11 확장 필드
@@ -158,7 +174,8 @@ type HookNotification struct {
 }
 
 var (
-	hookPortID string
+	hookPortID       string
+	hookPortEndForce bool
 )
 
 var hookCmd = &cobra.Command{
@@ -173,7 +190,7 @@ var hookSessionStartCmd = &cobra.Command{
 	Long: `세션 시작 시 호출됩니다.
 
 수행 작업:
-- 세션 등록
+- 세션 등록 (Claude 재시작 시 transcript 경로/fingerprint로 이전 세션 재개)
 - CLAUDE.md 컨텍스트 주입
 - 활성 포트 rules 확인`,
 	RunE: runHookSessionStart,
@@ -203,6 +220,16 @@ var hookPostToolUseCmd = &cobra.Command{
 	RunE:  runHookPostToolUse,
 }
 
+var hookUserPromptCmd = &cobra.Command{
+	Use:   "user-prompt",
+	Short: "UserPromptSubmit Hook",
+	Long: `사용자 프롬프트를 user_request 이벤트로 기록합니다.
+
+프롬프트 의도(bugfix/feature/question)를 간단히 분류하여 함께 저장하며,
+활성 포트 없이 작업 요청이 들어오면 포트 활성화 리마인더를 주입합니다.`,
+	RunE: runHookUserPrompt,
+}
+
 var hookStopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop Hook",
@@ -223,6 +250,7 @@ var hookPortStartCmd = &cobra.Command{
 수행 작업:
 - 포트 상태를 running으로 변경
 - rules 파일 생성
+- 관련 문서 참조 주입 (세션 attention의 남은 토큰 예산에 맞춰 조정)
 - Lock 획득 (리소스 지정 시)`,
 	Args: cobra.ExactArgs(1),
 	RunE: runHookPortStart,
@@ -234,9 +262,13 @@ var hookPortEndCmd = &cobra.Command{
 	Long: `포트 작업 완료 시 호출됩니다.
 
 수행 작업:
+- 포트 명세의 완료 기준(체크박스) 확인
 - 포트 상태를 complete로 변경
 - rules 파일 삭제
-- Lock 해제`,
+- Lock 해제
+
+완료 기준이 남아 있으면 settings.acceptance_mode에 따라 완료를 거부(block, 기본)하거나
+needs-review 상태로 표시(review)합니다. --force로 확인을 건너뜁니다.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runHookPortEnd,
 }
@@ -296,8 +328,9 @@ var hookNotificationCmd = &cobra.Command{
 
 수행 작업:
 - Compact 감지 및 복구 컨텍스트 생성
-- 에러 알림 처리
-- Claude에 복구 힌트 제공`,
+- Claude에 복구 힌트 제공
+- 그 외 알림은 세션 이벤트(notification)로 기록하고
+  .pal/config.yaml의 notifications 라우팅에 따라 desktop/webhook/file로 전달`,
 	RunE: runHookNotification,
 }
 
@@ -326,17 +359,42 @@ var hookSubagentCmd = &cobra.Command{
 	RunE: runHookSubagent,
 }
 
+var hookSubagentStartCmd = &cobra.Command{
+	Use:   "subagent-start",
+	Short: "SubagentStart Hook",
+	Long: `서브에이전트 시작 시 호출됩니다 (Claude Code SubagentStart).
+
+수행 작업:
+- 부모 PAL 세션 아래에 자식 세션(sub) 생성
+- 활성 포트 컨텍스트 전달`,
+	RunE: runHookSubagentStart,
+}
+
+var hookSubagentStopCmd = &cobra.Command{
+	Use:   "subagent-stop",
+	Short: "SubagentStop Hook",
+	Long: `서브에이전트 종료 시 호출됩니다 (Claude Code SubagentStop).
+
+수행 작업:
+- 자식 세션 종료 (사용 도구, 소요 시간 기록)
+- 서브에이전트 transcript 사용량을 자식 세션에 기록하고 부모 세션에 합산`,
+	RunE: runHookSubagentStop,
+}
+
 func init() {
 	rootCmd.AddCommand(hookCmd)
 	hookCmd.AddCommand(hookSessionStartCmd)
 	hookCmd.AddCommand(hookSessionEndCmd)
 	hookCmd.AddCommand(hookPreToolUseCmd)
 	hookCmd.AddCommand(hookPostToolUseCmd)
+	hookCmd.AddCommand(hookUserPromptCmd)
 	hookCmd.AddCommand(hookStopCmd)
 	hookCmd.AddCommand(hookPreCompactCmd)
 	hookCmd.AddCommand(hookNotificationCmd)
 	hookCmd.AddCommand(hookTestFeedbackCmd)
 	hookCmd.AddCommand(hookSubagentCmd)
+	hookCmd.AddCommand(hookSubagentStartCmd)
+	hookCmd.AddCommand(hookSubagentStopCmd)
 	hookCmd.AddCommand(hookPortStartCmd)
 	hookCmd.AddCommand(hookPortEndCmd)
 	hookCmd.AddCommand(hookSyncCmd)
@@ -344,11 +402,26 @@ func init() {
 	hookCmd.AddCommand(hookEventsCmd)
 
 	hookSessionStartCmd.Flags().StringVar(&hookPortID, "port", "", "시작할 포트 ID")
+	hookPortEndCmd.Flags().BoolVar(&hookPortEndForce, "force", false, "완료 기준 미충족이어도 완료 처리")
 	hookEventCmd.Flags().StringVar(&hookEventContext, "context", "", "추가 컨텍스트 (JSON)")
 	hookEventsCmd.Flags().IntVar(&hookEventsLimit, "limit", 20, "조회할 이벤트 수")
 	hookEventsCmd.Flags().StringVar(&hookEventsTypeFilter, "type", "", "이벤트 타입 필터")
 }
 
+// recordHookMetrics accumulates the invocation count and latency of pal hook <type> for /metrics.
+// 실패해도 hook 결과에 영향을 주지 않습니다.
+func recordHookMetrics(cmd *cobra.Command, cmdErr error, elapsed time.Duration) {
+	if cmd == nil || cmd.Parent() != hookCmd {
+		return
+	}
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return
+	}
+	defer database.Close()
+	metrics.RecordHook(database, cmd.Name(), elapsed, cmdErr != nil)
+}
+
 func readHookInput() (*HookInput, error) {
 	// stdin이 터미널이면 (파이프가 아니면) 빈 입력 반환
 	stat, _ := os.Stdin.Stat()
@@ -411,9 +484,15 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 		projectName = filepath.Base(projectRoot)
 	}
 
+	// 유휴 세션 일시정지 (Lock 해제). 지금 시작하는 Claude 세션은 제외해 Lock을 유지
+	if projectRoot != "" {
+		pauseIdleSessions(sessionSvc, projectRoot, input.SessionID)
+	}
+
 	// Claude 세션 ID로 기존 세션 확인 (FindActiveSession 사용)
 	var palSessionID string
 	if input.SessionID != "" {
+		sessionSvc.ResumeIdle(input.SessionID)
 		existingSession, err := sessionSvc.FindByClaudeSessionID(input.SessionID)
 		if err == nil && existingSession != nil {
 			// 기존 세션 재사용
@@ -424,6 +503,15 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Claude 재시작(--resume/--continue)이면 이전 세션을 재개
+	var resumed *session.Session
+	if palSessionID == "" && input.Source != "clear" {
+		resumed = resumeSession(sessionSvc, input, projectRoot)
+		if resumed != nil {
+			palSessionID = resumed.ID
+		}
+	}
+
 	// 기존 세션이 없으면 새로 생성
 	if palSessionID == "" {
 		palSessionID = uuid.New().String()[:8]
@@ -445,9 +533,6 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 			sessionType = session.TypeMain
 		} else {
 			sessionType = session.TypeSub
-			// 멀티 세션 경고
-			fmt.Fprintf(os.Stderr, "⚠️  [PAL Kit] 이 프로젝트에 %d개의 다른 세션이 실행 중입니다.\n", runningCount)
-			fmt.Fprintf(os.Stderr, "   현재 세션: %s (cwd: %s)\n", palSessionID, cwd)
 		}
 
 		// 세션 시작 (프로젝트 정보 포함)
@@ -462,6 +547,8 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 			Cwd:             cwd,
 			TTY:             tty,       // v11: 터미널 식별자
 			ParentPID:       parentPID, // v11: 부모 프로세스 ID
+			Sandbox:         os.Getenv("PAL_SANDBOX") != "",
+			WorkspaceRoot:   context.FindWorkspaceRoot(projectRoot),
 		}
 
 		if err := sessionSvc.StartWithFullOptions(opts); err != nil {
@@ -470,6 +557,14 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		// 멀티 세션 경고 (세션 생성 후 기록해야 log 채널에서도 남음)
+		if runningCount > 0 {
+			if peers, err := sessionSvc.Peers(projectRoot, palSessionID); err == nil && len(peers) > 0 {
+				newHookWarnings(projectRoot, sessionSvc, palSessionID).warn(config.WarningMultiSession,
+					"%s", peersBanner(peers, palSessionID, cwd, projectRoot))
+			}
+		}
+
 		// 메인 세션인 경우 명시적으로 표시
 		if sessionType == session.TypeMain && verbose {
 			fmt.Printf("🏠 Main session started: %s\n", palSessionID)
@@ -497,10 +592,28 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// 개발 환경 스냅샷 기록 (OS, 도구 버전, git 리비전)
+	if err := sessionSvc.SetEnvSnapshot(palSessionID, session.CaptureEnv(cwd)); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	}
+
+	// Safe mode: 이벤트 기록만 하고 컨텍스트 파일은 건드리지 않음
+	safeMode := hookSafeMode()
+	if safeMode && palSessionID != "" {
+		sessionSvc.LogEvent(palSessionID, "safe_mode", `{"hook":"session-start"}`)
+	}
+
+	// 동시 실행 세션 현황 갱신 (.pal/context/peers.md)
+	if !skipInSafeMode("peers.md 갱신") {
+		if err := writePeersContext(sessionSvc, projectRoot); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		}
+	}
+
 	// CLAUDE.md에 컨텍스트 주입
 	ctxSvc := context.NewService(database)
 	claudeMD := context.FindClaudeMD(cwd)
-	if claudeMD != "" {
+	if claudeMD != "" && !skipInSafeMode("CLAUDE.md 주입") {
 		ctxSvc.InjectToFile(claudeMD)
 		if verbose {
 			fmt.Printf("📝 Context injected: %s\n", claudeMD)
@@ -522,7 +635,9 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 				specPath = p.FilePath.String
 			}
 			
-			rulesSvc.ActivatePortWithSpec(hookPortID, title, specPath, nil)
+			if !safeMode {
+				rulesSvc.ActivatePortWithSpec(hookPortID, title, specPath, nil)
+			}
 			portSvc.UpdateStatus(hookPortID, "running")
 			
 			// 포트 시작 이벤트 로깅
@@ -534,6 +649,28 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// 재개된 세션: 작업 중이던 포트 rules 재주입
+	if resumed != nil && hookPortID == "" && resumed.PortID.Valid && projectRoot != "" && !skipInSafeMode("포트 rules 재주입") {
+		if p, err := portSvc.Get(resumed.PortID.String); err == nil && p.Status == "running" {
+			title := p.ID
+			if p.Title.Valid {
+				title = p.Title.String
+			}
+			specPath := ""
+			if p.FilePath.Valid {
+				specPath = p.FilePath.String
+			}
+			rules.NewService(projectRoot).ActivatePortWithSpec(p.ID, title, specPath, nil)
+		}
+	}
+	if resumed != nil {
+		fmt.Printf("♻️  [PAL Kit] 이전 세션 %s을(를) 이어서 진행합니다.", palSessionID)
+		if resumed.PortID.Valid {
+			fmt.Printf(" (포트: %s)", resumed.PortID.String)
+		}
+		fmt.Println()
+	}
+
 	// 현재 상태 요약
 	if verbose {
 		fmt.Printf("🚀 Session started: %s (claude: %s)\n", palSessionID, input.SessionID)
@@ -560,6 +697,7 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 	// 빌더 에이전트 자동 활성화
 	if projectRoot != "" {
 		claudeSvc := context.NewClaudeService(database, projectRoot)
+		claudeSvc.ReadOnly = safeMode
 		builderResult, err := claudeSvc.ProcessSessionStart()
 		if err == nil && builderResult.BuilderActive {
 			if verbose {
@@ -571,7 +709,7 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 	}
 
 	// 워크플로우 컨텍스트 주입 (rules 파일로)
-	if projectRoot != "" {
+	if projectRoot != "" && !skipInSafeMode("워크플로우 rules 작성") {
 		workflowSvc := workflow.NewService(projectRoot)
 		ctx, err := workflowSvc.GetContext()
 		if err == nil {
@@ -598,20 +736,46 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Operator 브리핑 생성
+	// 캐시된 프로젝트 프라이머가 최신이면 브리핑을 다시 계산하지 않고 그대로 주입
+	primerUsed := false
 	if projectRoot != "" {
+		maxAge := 2 * config.DefaultPrimerRefresh
+		if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+			maxAge = 2 * projectCfg.Settings.PrimerRefreshInterval()
+		}
+		if p := primer.NewService(database, projectRoot).LoadFresh(maxAge, time.Now()); p != nil {
+			fmt.Print(p.Markdown())
+			primerUsed = true
+		}
+	}
+
+	// Operator 브리핑 생성
+	if projectRoot != "" && !primerUsed {
+		warnings := newHookWarnings(projectRoot, sessionSvc, palSessionID)
 		operatorSvc := operator.NewService(database, projectRoot)
 		briefing, err := operatorSvc.GenerateBriefing()
 		if err == nil {
 			// .pal/context/session-briefing.md 저장
-			if err := operatorSvc.WriteBriefing(briefing); err != nil {
-				if verbose {
-					fmt.Fprintf(os.Stderr, "⚠️  브리핑 저장 실패: %v\n", err)
+			if !skipInSafeMode("브리핑 저장") {
+				if err := operatorSvc.WriteBriefing(briefing); err != nil {
+					warnings.warn(config.WarningHookError, "⚠️  브리핑 저장 실패: %v", err)
+				}
+			}
+
+			// 이전 브리핑 대비 변경 사항 (delta 모드에서는 변경분만 주입)
+			delta, state, err := operatorSvc.GenerateBriefingDelta(briefing)
+			if err != nil {
+				warnings.warn(config.WarningHookError, "⚠️  브리핑 delta 생성 실패: %v", err)
+			} else if !safeMode {
+				if err := operatorSvc.WriteBriefingState(state); err != nil {
+					warnings.warn(config.WarningHookError, "⚠️  브리핑 상태 저장 실패: %v", err)
 				}
 			}
 
 			// stdout으로 요약 출력 (Claude가 읽음)
-			if briefing.Summary != "" && briefing.Summary != "No active work items." {
+			if warnings.settings.BriefingMode == config.BriefingModeDelta && delta != nil {
+				fmt.Print(operator.FormatBriefingDelta(delta, config.ProjectLanguage(projectRoot)))
+			} else if briefing.Summary != "" && briefing.Summary != "No active work items." {
 				fmt.Printf("📋 %s\n", briefing.Summary)
 			}
 
@@ -623,8 +787,8 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 			if verbose {
 				fmt.Printf("📄 Briefing: %s\n", operatorSvc.GetBriefingPath())
 			}
-		} else if verbose {
-			fmt.Fprintf(os.Stderr, "⚠️  브리핑 생성 실패: %v\n", err)
+		} else {
+			warnings.warn(config.WarningHookError, "⚠️  브리핑 생성 실패: %v", err)
 		}
 	}
 
@@ -634,16 +798,7 @@ func runHookSessionStart(cmd *cobra.Command, args []string) error {
 	if len(runningPorts) == 0 {
 		fmt.Println("")
 		fmt.Println("<!-- pal:port-guidance")
-		fmt.Println("[PAL Kit 포트 사용 안내]")
-		fmt.Println("")
-		fmt.Println("⚠️ 현재 활성 포트가 없습니다.")
-		fmt.Println("코드 변경 작업을 시작하기 전에 포트를 활성화해야 작업이 추적됩니다.")
-		fmt.Println("")
-		fmt.Println("포트 활성화 방법:")
-		fmt.Println("1. 기존 포트 활성화: pal hook port-start <port-id>")
-		fmt.Println("2. 새 포트 생성: pal port create <id> --title \"작업명\" && pal hook port-start <id>")
-		fmt.Println("")
-		fmt.Println("포트 목록 확인: pal port list")
+		fmt.Println(i18n.T(config.ProjectLanguage(projectRoot), i18n.KeyPortGuidance))
 		fmt.Println("-->")
 	}
 
@@ -679,7 +834,7 @@ func runHookSessionEnd(cmd *cobra.Command, args []string) error {
 	projectRoot := context.FindProjectRoot(cwd)
 
 	// 워크플로우 rules 파일 정리
-	if projectRoot != "" {
+	if projectRoot != "" && !skipInSafeMode("워크플로우 rules 정리") {
 		workflowSvc := workflow.NewService(projectRoot)
 		workflowSvc.CleanupRulesFile()
 	}
@@ -687,6 +842,7 @@ func runHookSessionEnd(cmd *cobra.Command, args []string) error {
 	// 빌더 에이전트 정리
 	if projectRoot != "" {
 		claudeSvc := context.NewClaudeService(database, projectRoot)
+		claudeSvc.ReadOnly = hookSafeMode()
 		claudeSvc.ProcessSessionEnd()
 	}
 
@@ -707,27 +863,8 @@ func runHookSessionEnd(cmd *cobra.Command, args []string) error {
 	}
 
 	if transcriptPath != "" {
-		// 파일이 아직 쓰는 중일 수 있음 → 재시도 로직
-		var usage *transcript.Usage
-		for retry := 0; retry < 3; retry++ {
-			usage, err = transcript.ParseFile(transcriptPath)
-			if err == nil && usage != nil {
-				break
-			}
-			time.Sleep(100 * time.Millisecond)
-		}
-
+		usage, err := collectSessionUsage(sessionSvc, palSession.ID, transcriptPath)
 		if usage != nil {
-			// 세션 usage 업데이트
-			sessionSvc.UpdateUsage(
-				palSession.ID,
-				usage.InputTokens,
-				usage.OutputTokens,
-				usage.CacheReadTokens,
-				usage.CacheCreateTokens,
-				usage.CostUSD,
-			)
-
 			if verbose {
 				fmt.Printf("📊 Usage collected:\n")
 				fmt.Printf("   Input tokens: %d\n", usage.InputTokens)
@@ -736,21 +873,21 @@ func runHookSessionEnd(cmd *cobra.Command, args []string) error {
 				fmt.Printf("   Cache create: %d\n", usage.CacheCreateTokens)
 				fmt.Printf("   Cost: $%.4f\n", usage.CostUSD)
 			}
+			checkSessionBudget(sessionSvc, projectRoot, palSession.ID)
 		} else if verbose && err != nil {
 			fmt.Printf("⚠️  Usage 수집 실패: %v\n", err)
 		}
 	}
 
-	// Operator 세션 요약 생성 (세션 종료 전에)
-	if projectRoot != "" {
+	// Operator 세션 요약 생성 (세션 종료 전에, 샌드박스 세션은 이력에 남기지 않음)
+	if projectRoot != "" && !sessionSvc.IsSandbox(palSession.ID) {
+		warnings := newHookWarnings(projectRoot, sessionSvc, palSession.ID)
 		operatorSvc := operator.NewService(database, projectRoot)
 		summary, err := operatorSvc.GenerateSummary(palSession.ID)
 		if err == nil {
 			// .pal/sessions/{date}-{id}.md 저장
 			if err := operatorSvc.WriteSummary(summary); err != nil {
-				if verbose {
-					fmt.Fprintf(os.Stderr, "⚠️  세션 요약 저장 실패: %v\n", err)
-				}
+				warnings.warn(config.WarningHookError, "⚠️  세션 요약 저장 실패: %v", err)
 			} else if verbose {
 				fmt.Printf("📝 Session summary saved: %s-%s.md\n",
 					summary.GeneratedAt.Format("2006-01-02"), palSession.ID)
@@ -765,11 +902,16 @@ func runHookSessionEnd(cmd *cobra.Command, args []string) error {
 			if len(summary.ADRCandidates) > 0 && verbose {
 				fmt.Printf("📋 ADR candidates detected: %d\n", len(summary.ADRCandidates))
 			}
-		} else if verbose {
-			fmt.Fprintf(os.Stderr, "⚠️  세션 요약 생성 실패: %v\n", err)
+		} else {
+			warnings.warn(config.WarningHookError, "⚠️  세션 요약 생성 실패: %v", err)
 		}
 	}
 
+	// 추적되지 않은 수정이 많으면 회고 포트 생성 제안
+	if untracked, err := sessionSvc.GetEvents(palSession.ID, session.EventUntrackedEdit, 0); err == nil && len(untracked) >= backfillHintThreshold {
+		fmt.Printf("💡 추적되지 않은 파일 수정 %d건 → pal port backfill %s\n", len(untracked), palSession.ID)
+	}
+
 	// 종료 사유
 	reason := input.Reason
 	if reason == "" {
@@ -795,11 +937,20 @@ func runHookSessionEnd(cmd *cobra.Command, args []string) error {
 	publisher.PublishSessionEnd(palSession.ID, reason, "complete")
 
 	// Lock 해제
-	locks, _ := lockSvc.List()
 	releasedCount := 0
-	for _, l := range locks {
-		lockSvc.Release(l.Resource)
-		releasedCount++
+	if !skipInSafeMode("Lock 해제") {
+		locks, _ := lockSvc.List()
+		for _, l := range locks {
+			lockSvc.Release(l.Resource)
+			releasedCount++
+		}
+		lockSvc.ClearWaits(palSession.ID)
+	}
+
+	if !skipInSafeMode("peers.md 갱신") {
+		if err := writePeersContext(sessionSvc, projectRoot); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		}
 	}
 
 	if verbose {
@@ -818,9 +969,15 @@ func runHookPreToolUse(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Edit/Write 도구인 경우 활성 포트 확인
-	if input.ToolName == "Edit" || input.ToolName == "Write" {
-		filePath, ok := input.ToolInput["file_path"].(string)
+	// 예산 소진 시 모든 도구 사용 차단 (settings.budget.block_at_limit)
+	if blocked := preToolUseBudgetBlock(input); blocked != nil {
+		json.NewEncoder(os.Stdout).Encode(blocked)
+		return nil
+	}
+
+	// 파일 수정 도구인 경우 Lock과 활성 포트 확인
+	if writeTools[input.ToolName] {
+		filePath, ok := toolFilePath(input)
 		if !ok {
 			return nil
 		}
@@ -843,24 +1000,22 @@ func runHookPreToolUse(cmd *cobra.Command, args []string) error {
 
 		// v11: 프로젝트 설정에서 TrackingMode 로드
 		trackingMode := config.TrackingModeWarn // 기본값
+		lockMode := config.LockModeBlock
 		autoCreate := true
+		lang := ""
 		if projectRoot != "" {
 			if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+				lang = projectCfg.Project.Language
 				if projectCfg.Settings.TrackingMode != "" {
 					trackingMode = projectCfg.Settings.TrackingMode
 				}
+				if projectCfg.Settings.LockMode != "" {
+					lockMode = projectCfg.Settings.LockMode
+				}
 				autoCreate = projectCfg.Settings.TrackingAutoCreate
 			}
 		}
 
-		// TrackingMode가 off면 추적하지 않음
-		if trackingMode == config.TrackingModeOff {
-			return nil
-		}
-
-		// 활성 포트 확인
-		runningPorts, _ := portSvc.List("running", 10)
-
 		// 현재 세션 찾기
 		claudeSessionID := input.SessionID
 		if claudeSessionID == "" {
@@ -873,13 +1028,68 @@ func runHookPreToolUse(cmd *cobra.Command, args []string) error {
 			palSessionID = palSession.ID
 		}
 
+		// 다른 세션이 Lock한 파일인지 확인 (TrackingMode와 무관)
+		lockSvc := lock.NewService(database)
+		if conflict, err := lockSvc.FindFileConflict(filePath, projectRoot, palSessionID, claudeSessionID); err == nil && conflict != nil {
+			reason := fmt.Sprintf("'%s'은(는) 세션 %s의 Lock(%s)으로 보호되어 있습니다. 해당 작업이 끝날 때까지 기다리거나 다른 파일을 수정하세요.",
+				filePath, conflict.SessionID, conflict.Resource)
+
+			if palSessionID != "" {
+				eventData := fmt.Sprintf(`{"tool":"%s","file":"%s","resource":"%s","holder":"%s","mode":"%s"}`,
+					input.ToolName, escapeJSON(filePath), escapeJSON(conflict.Resource), conflict.SessionID, lockMode)
+				sessionSvc.LogEvent(palSessionID, "lock_conflict", eventData)
+				if !hookSafeMode() {
+					recordLockWait(database, sessionSvc, projectRoot, palSessionID, conflict)
+				}
+			}
+
+			if lockMode != config.LockModeWarn && !skipInSafeMode("Lock 차단") {
+				fmt.Fprintf(os.Stderr, "🔒 [PAL Kit] %s\n", reason)
+				output := HookOutput{
+					Decision: "deny",
+					Reason:   reason,
+					HookOutput: map[string]interface{}{
+						"hookEventName":            "PreToolUse",
+						"permissionDecision":       "deny",
+						"permissionDecisionReason": reason,
+					},
+					Context: &ContextInfo{
+						SessionID:    palSessionID,
+						SessionState: "running",
+					},
+					Notifications: []HookNotification{
+						{
+							Level:   "error",
+							Title:   "Lock 충돌",
+							Message: reason,
+							Action:  "pal lock list",
+						},
+					},
+				}
+				json.NewEncoder(os.Stdout).Encode(output)
+				return nil
+			}
+
+			if newHookWarnings(projectRoot, sessionSvc, palSessionID).toClaude(config.WarningLockConflict) {
+				fmt.Fprintf(os.Stderr, "⚠️  [PAL Kit] %s\n", reason)
+			}
+		}
+
+		// TrackingMode가 off면 추적하지 않음
+		if trackingMode == config.TrackingModeOff {
+			return nil
+		}
+
+		// 활성 포트 확인
+		runningPorts, _ := portSvc.List("running", 10)
+
 		// 활성 포트가 없을 때 처리 (TrackingMode에 따라 다름)
 		if len(runningPorts) == 0 {
 			// untracked_edit 이벤트 로깅
 			if palSessionID != "" {
 				eventData := fmt.Sprintf(`{"tool":"%s","file":"%s","warning":"no_active_port","mode":"%s"}`,
 					input.ToolName, filePath, trackingMode)
-				sessionSvc.LogEvent(palSessionID, "untracked_edit", eventData)
+				logSampledEvent(sessionSvc, projectRoot, palSessionID, session.EventUntrackedEdit, eventData)
 			}
 
 			// v11: TrackingMode에 따른 응답
@@ -887,25 +1097,20 @@ func runHookPreToolUse(cmd *cobra.Command, args []string) error {
 			case config.TrackingModeStrict:
 				// strict 모드: 포트 없으면 block
 				fmt.Fprintln(os.Stderr, "")
-				fmt.Fprintln(os.Stderr, "🚫 [PAL Kit] 포트 추적 필수 (strict 모드)")
-				fmt.Fprintln(os.Stderr, "   활성 포트가 없어 코드 변경이 차단되었습니다.")
-				fmt.Fprintln(os.Stderr, "")
-				fmt.Fprintln(os.Stderr, "   포트를 활성화하려면:")
-				fmt.Fprintln(os.Stderr, "   1. pal port create <id> --title \"작업명\"")
-				fmt.Fprintln(os.Stderr, "   2. pal hook port-start <id>")
+				fmt.Fprintln(os.Stderr, i18n.T(lang, i18n.KeyStrictStderr))
 				fmt.Fprintln(os.Stderr, "")
 
 				suggestions := []string{
-					"pal port create <id> --title \"작업명\" 으로 포트 생성",
-					"pal hook port-start <id> 로 포트 활성화",
+					i18n.T(lang, i18n.KeySuggestCreate),
+					i18n.T(lang, i18n.KeySuggestStart),
 				}
 				if autoCreate {
-					suggestions = append(suggestions, "또는 파일 경로 기반 포트 ID를 자동 생성할 수 있습니다")
+					suggestions = append(suggestions, i18n.T(lang, i18n.KeySuggestAutoCreate))
 				}
 
 				output := HookOutput{
 					Decision: "block",
-					Reason:   "활성 포트가 없습니다. 포트를 먼저 활성화하세요.",
+					Reason:   i18n.T(lang, i18n.KeyStrictReason),
 					Context: &ContextInfo{
 						SessionID:    palSessionID,
 						SessionState: "running",
@@ -913,8 +1118,8 @@ func runHookPreToolUse(cmd *cobra.Command, args []string) error {
 					Notifications: []HookNotification{
 						{
 							Level:   "error",
-							Title:   "포트 추적 필수",
-							Message: "strict 모드에서는 포트 없이 코드를 수정할 수 없습니다.",
+							Title:   i18n.T(lang, i18n.KeyStrictTitle),
+							Message: i18n.T(lang, i18n.KeyStrictMessage),
 							Action:  "pal hook port-start <id>",
 						},
 					},
@@ -924,14 +1129,12 @@ func runHookPreToolUse(cmd *cobra.Command, args []string) error {
 				return nil
 
 			case config.TrackingModeWarn:
-				// warn 모드: 경고만 하고 허용
-				fmt.Fprintln(os.Stderr, "")
-				fmt.Fprintln(os.Stderr, "⚠️  [PAL Kit] 활성 포트가 없습니다!")
-				fmt.Fprintln(os.Stderr, "   코드 변경이 추적되지 않습니다.")
+				// warn 모드: 경고만 하고 허용 (log 채널이면 이벤트만 남김)
+				if !newHookWarnings(projectRoot, sessionSvc, palSessionID).toClaude(config.WarningUntrackedEdit) {
+					return nil
+				}
 				fmt.Fprintln(os.Stderr, "")
-				fmt.Fprintln(os.Stderr, "   포트를 활성화하려면:")
-				fmt.Fprintln(os.Stderr, "   1. pal port create <id> --title \"작업명\"")
-				fmt.Fprintln(os.Stderr, "   2. pal hook port-start <id>")
+				fmt.Fprintln(os.Stderr, i18n.T(lang, i18n.KeyWarnStderr))
 				fmt.Fprintln(os.Stderr, "")
 
 				output := HookOutput{
@@ -943,14 +1146,14 @@ func runHookPreToolUse(cmd *cobra.Command, args []string) error {
 					Notifications: []HookNotification{
 						{
 							Level:   "warn",
-							Title:   "포트 미활성",
-							Message: "코드 변경이 추적되지 않습니다. 포트를 활성화하세요.",
+							Title:   i18n.T(lang, i18n.KeyWarnTitle),
+							Message: i18n.T(lang, i18n.KeyWarnMessage),
 							Action:  "pal hook port-start <id>",
 						},
 					},
 					Suggestions: []string{
-						"pal port create <id> --title \"작업명\" 으로 포트 생성",
-						"pal hook port-start <id> 로 포트 활성화",
+						i18n.T(lang, i18n.KeySuggestCreate),
+						i18n.T(lang, i18n.KeySuggestStart),
 					},
 				}
 				json.NewEncoder(os.Stdout).Encode(output)
@@ -969,7 +1172,14 @@ func runHookPreToolUse(cmd *cobra.Command, args []string) error {
 			if palSessionID != "" && len(runningPorts) > 0 {
 				eventData := fmt.Sprintf(`{"tool":"%s","file":"%s","port":"%s"}`,
 					input.ToolName, filePath, runningPorts[0].ID)
-				sessionSvc.LogEvent(palSessionID, "file_edit", eventData)
+				logSampledEvent(sessionSvc, projectRoot, palSessionID, session.EventFileEdit, eventData)
+
+				// 파일 출처 기록 (편집 전 파일이 없으면 이 세션/포트가 생성한 것)
+				if projectRoot != "" {
+					_, statErr := os.Stat(filePath)
+					manifest.NewService(database, projectRoot).RecordEdit(
+						filePath, palSessionID, runningPorts[0].ID, os.IsNotExist(statErr))
+				}
 			}
 
 			// v11: JSON 응답에 Context 추가 (활성 포트 정보 포함)
@@ -997,16 +1207,155 @@ func runHookPreToolUse(cmd *cobra.Command, args []string) error {
 			}
 			json.NewEncoder(os.Stdout).Encode(output)
 		}
-
-		// Lock 확인 (기존 로직 유지)
-		lockSvc := lock.NewService(database)
-		_ = lockSvc
-		// TODO: 파일 경로 기반 Lock 확인 로직
 	}
 
 	return nil
 }
 
+// toolDiffStat computes line additions/deletions of an Edit/MultiEdit/Write call.
+// tool_response의 structuredPatch가 있으면 우선 사용하고, 없으면 입력으로 추정합니다.
+func toolDiffStat(input *HookInput) (additions, deletions int) {
+	if hunks, ok := input.ToolResponse["structuredPatch"].([]interface{}); ok && len(hunks) > 0 {
+		for _, h := range hunks {
+			hunk, _ := h.(map[string]interface{})
+			lines, _ := hunk["lines"].([]interface{})
+			for _, l := range lines {
+				line, _ := l.(string)
+				switch {
+				case strings.HasPrefix(line, "+"):
+					additions++
+				case strings.HasPrefix(line, "-"):
+					deletions++
+				}
+			}
+		}
+		return additions, deletions
+	}
+
+	switch input.ToolName {
+	case "Edit":
+		oldStr, _ := input.ToolInput["old_string"].(string)
+		newStr, _ := input.ToolInput["new_string"].(string)
+		return port.DiffStat(oldStr, newStr)
+	case "MultiEdit":
+		edits, _ := input.ToolInput["edits"].([]interface{})
+		for _, e := range edits {
+			edit, _ := e.(map[string]interface{})
+			oldStr, _ := edit["old_string"].(string)
+			newStr, _ := edit["new_string"].(string)
+			a, d := port.DiffStat(oldStr, newStr)
+			additions += a
+			deletions += d
+		}
+		return additions, deletions
+	case "Write":
+		content, _ := input.ToolInput["content"].(string)
+		return port.DiffStat("", content)
+	}
+	return 0, 0
+}
+
+// writeTools are the Claude tools that modify a file (PreToolUse Lock 검사와 PostToolUse 변경 기록이 공유)
+var writeTools = map[string]bool{"Edit": true, "MultiEdit": true, "Write": true, "NotebookEdit": true}
+
+// toolFilePath returns the file a write tool modifies (NotebookEdit은 notebook_path)
+func toolFilePath(input *HookInput) (string, bool) {
+	if input.ToolName == "NotebookEdit" {
+		path, ok := input.ToolInput["notebook_path"].(string)
+		return path, ok
+	}
+	path, ok := input.ToolInput["file_path"].(string)
+	return path, ok
+}
+
+// hookWarnings routes hook warnings per category (settings.warnings).
+// claude 채널은 stderr로 출력해 Claude가 읽게 하고, log 채널은 hook_warning 이벤트로만 남깁니다.
+type hookWarnings struct {
+	settings   config.ProjectSettings
+	lang       string // 안내문 언어 (project.language)
+	sessionSvc *session.Service
+	sessionID  string
+}
+
+func newHookWarnings(projectRoot string, sessionSvc *session.Service, sessionID string) *hookWarnings {
+	w := &hookWarnings{sessionSvc: sessionSvc, sessionID: sessionID}
+	if projectRoot != "" {
+		if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+			w.settings = projectCfg.Settings
+			w.lang = projectCfg.Project.Language
+		}
+	}
+	return w
+}
+
+// toClaude reports whether warnings of the category are shown to Claude
+func (w *hookWarnings) toClaude(category string) bool {
+	return w.settings.WarningChannelFor(category) == config.WarningChannelClaude
+}
+
+// warn prints a warning to stderr, or records it as a hook_warning event in log mode
+func (w *hookWarnings) warn(category, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if w.toClaude(category) {
+		fmt.Fprintln(os.Stderr, msg)
+		return
+	}
+	w.log(category, msg)
+	if verbose {
+		fmt.Fprintln(os.Stderr, msg)
+	}
+}
+
+// log records a warning for logs/dashboard only
+func (w *hookWarnings) log(category, msg string) {
+	if w.sessionID == "" || w.sessionSvc == nil {
+		return
+	}
+	eventData := fmt.Sprintf(`{"category":"%s","message":"%s"}`, category, escapeJSON(strings.TrimSpace(msg)))
+	w.sessionSvc.LogEvent(w.sessionID, "hook_warning", eventData)
+}
+
+// logSampledEvent logs a high-volume hook event under the project's sampling rules
+// (settings.event_sampling). 한도를 넘는 이벤트는 events_collapsed 요약으로 합쳐집니다.
+func logSampledEvent(sessionSvc *session.Service, projectRoot, sessionID, eventType, eventData string) {
+	var settings config.ProjectSettings
+	if projectRoot != "" {
+		if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+			settings = projectCfg.Settings
+		}
+	}
+	sessionSvc.LogEventSampled(sessionID, eventType, eventData, settings.EventMaxPerMinute(eventType))
+}
+
+// sessionRunningPort returns the running port of a PAL session (없으면 빈 값)
+func sessionRunningPort(portSvc *port.Service, palSessionID string) string {
+	if palSessionID == "" {
+		return ""
+	}
+	ports, err := portSvc.ListBySession(palSessionID)
+	if err != nil {
+		return ""
+	}
+	for _, p := range ports {
+		if p.Status == port.StatusRunning {
+			return p.ID
+		}
+	}
+	return ""
+}
+
+// projectRelPath returns filePath relative to projectRoot when inside it
+func projectRelPath(filePath, projectRoot string) string {
+	if projectRoot == "" || !filepath.IsAbs(filePath) {
+		return filePath
+	}
+	rel, err := filepath.Rel(projectRoot, filePath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filePath
+	}
+	return filepath.ToSlash(rel)
+}
+
 func runHookPostToolUse(cmd *cobra.Command, args []string) error {
 	input, err := readHookInput()
 	if err != nil {
@@ -1042,20 +1391,31 @@ func runHookPostToolUse(cmd *cobra.Command, args []string) error {
 	}
 
 	// 1. 파일 변경 기록
-	if input.ToolName == "Edit" || input.ToolName == "Write" {
-		filePath, ok := input.ToolInput["file_path"].(string)
-		if ok && palSessionID != "" {
-			// 활성 포트 확인
-			runningPorts, _ := portSvc.List("running", 1)
-			portID := ""
-			if len(runningPorts) > 0 {
-				portID = runningPorts[0].ID
+	if writeTools[input.ToolName] {
+		filePath, ok := toolFilePath(input)
+		if ok {
+			// 이 세션의 활성 포트 (병렬 세션의 포트에 기록하지 않음)
+			portID := sessionRunningPort(portSvc, palSessionID)
+
+			// 포트별 파일 변경 (줄 단위 추가/삭제)
+			additions, deletions := toolDiffStat(input)
+			if portID != "" {
+				portSvc.RecordFileChange(port.FileChange{
+					PortID:    portID,
+					SessionID: palSessionID,
+					FilePath:  projectRelPath(filePath, projectRoot),
+					Tool:      input.ToolName,
+					Additions: additions,
+					Deletions: deletions,
+				})
 			}
 
 			// 파일 수정 이벤트 로깅
-			eventData := fmt.Sprintf(`{"tool":"%s","file":"%s","port":"%s","success":true}`,
-				input.ToolName, escapeJSON(filePath), portID)
-			sessionSvc.LogEvent(palSessionID, "file_change", eventData)
+			if palSessionID != "" {
+				eventData := fmt.Sprintf(`{"tool":"%s","file":"%s","port":"%s","success":true,"additions":%d,"deletions":%d}`,
+					input.ToolName, escapeJSON(filePath), portID, additions, deletions)
+				logSampledEvent(sessionSvc, projectRoot, palSessionID, "file_change", eventData)
+			}
 		}
 	}
 
@@ -1125,28 +1485,10 @@ func runHookPostToolUse(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runHookStop(cmd *cobra.Command, args []string) error {
-	input, err := readHookInput()
-	if err != nil {
-		input = &HookInput{}
-	}
-
-	sessionID := input.SessionID
-	if sessionID == "" {
-		sessionID = os.Getenv("CLAUDE_SESSION_ID")
-	}
-
-	if verbose && sessionID != "" {
-		fmt.Printf("🛑 Stop: session=%s\n", sessionID)
-	}
-
-	return nil
-}
-
-func runHookPreCompact(cmd *cobra.Command, args []string) error {
+func runHookUserPrompt(cmd *cobra.Command, args []string) error {
 	input, err := readHookInput()
-	if err != nil {
-		input = &HookInput{}
+	if err != nil || strings.TrimSpace(input.Prompt) == "" {
+		return nil
 	}
 
 	database, err := db.Open(GetDBPath())
@@ -1156,69 +1498,256 @@ func runHookPreCompact(cmd *cobra.Command, args []string) error {
 	defer database.Close()
 
 	sessionSvc := session.NewService(database)
+	portSvc := port.NewService(database)
+
+	cwd := input.Cwd
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+	projectRoot := context.FindProjectRoot(cwd)
 
-	// Claude 세션 ID로 PAL 세션 찾기
 	claudeSessionID := input.SessionID
 	if claudeSessionID == "" {
 		claudeSessionID = os.Getenv("CLAUDE_SESSION_ID")
 	}
 
-	if claudeSessionID != "" {
-		palSession, err := sessionSvc.FindByClaudeSessionID(claudeSessionID)
-		if err == nil && palSession != nil {
-			sessionSvc.IncrementCompact(palSession.ID)
-
-			// 컴팩트 이벤트 로깅
-			trigger := input.Trigger
-			if trigger == "" {
-				trigger = "auto"
-			}
-			sessionSvc.LogEvent(palSession.ID, "compact", fmt.Sprintf(`{"trigger":"%s"}`, trigger))
+	palSession, err := sessionSvc.FindActiveSession(claudeSessionID, cwd, projectRoot)
+	if err != nil || palSession == nil {
+		return nil
+	}
 
-			// v11: 체크포인트 생성
-			cwd, _ := os.Getwd()
-			projectRoot := context.FindProjectRoot(cwd)
-			if projectRoot != "" {
-				cpSvc := context.NewCheckpointService(database, projectRoot)
-				if cp, err := cpSvc.CreateCheckpoint(palSession.ID); err == nil {
-					if verbose {
-						fmt.Printf("📷 Checkpoint created: %s\n", cp.ID)
-					}
-				}
-			}
+	// 사용자 요구사항 기록 (세션 중간 요청 포함)
+	intent := session.ClassifyIntent(input.Prompt)
+	content := truncateString(input.Prompt, 500)
+	eventData := fmt.Sprintf(`{"message":"%s","full_length":%d,"intent":"%s","source":"user_prompt"}`,
+		escapeJSON(content), len(input.Prompt), intent)
+	sessionSvc.LogEvent(palSession.ID, session.EventUserRequest, eventData)
 
-			if verbose {
-				fmt.Printf("📦 PreCompact: session=%s, trigger=%s\n", palSession.ID, trigger)
-			}
-		}
+	if verbose {
+		fmt.Fprintf(os.Stderr, "💬 User prompt: intent=%s\n", intent)
 	}
 
-	return nil
-}
-
-func runHookPortStart(cmd *cobra.Command, args []string) error {
-	portID := args[0]
+	// 직전 응답에서 넘은 비용 알림 임계치 (한 줄)
+	costNote := costAlertNote(sessionSvc, palSession.ID)
 
-	// stdin에서 hook 입력 읽기
-	input, err := readHookInput()
-	if err != nil {
-		input = &HookInput{}
+	// 질문이 아닌 작업 요청인데 활성 포트가 없으면 리마인더 주입
+	if intent != session.IntentBugfix && intent != session.IntentFeature {
+		return emitCostNote(palSession.ID, costNote)
 	}
 
-	database, err := db.Open(GetDBPath())
-	if err != nil {
-		return err
+	warnings := newHookWarnings(projectRoot, sessionSvc, palSession.ID)
+	if warnings.settings.TrackingMode == config.TrackingModeOff {
+		return emitCostNote(palSession.ID, costNote)
 	}
-	defer database.Close()
 
-	portSvc := port.NewService(database)
+	runningPorts, _ := portSvc.List("running", 1)
+	if len(runningPorts) > 0 {
+		return emitCostNote(palSession.ID, costNote)
+	}
+
+	reminder := i18n.T(warnings.lang, i18n.KeyPortReminder, intent)
+	if !warnings.toClaude(config.WarningPortReminder) {
+		warnings.log(config.WarningPortReminder, reminder)
+		return emitCostNote(palSession.ID, costNote)
+	}
+
+	additional := reminder
+	if costNote != "" {
+		additional = costNote + "\n" + reminder
+	}
+	output := HookOutput{
+		SystemMessage: costNote,
+		HookOutput: map[string]interface{}{
+			"hookEventName":     "UserPromptSubmit",
+			"additionalContext": additional,
+		},
+		Context: &ContextInfo{
+			SessionID:    palSession.ID,
+			SessionState: "running",
+		},
+		Suggestions: []string{
+			"pal port create <id> --title \"작업명\" 으로 포트 생성",
+			"pal hook port-start <id> 로 포트 활성화",
+		},
+	}
+	json.NewEncoder(os.Stdout).Encode(output)
+	return nil
+}
+
+// emitCostNote prints a pending cost alert to Claude (additionalContext) and the user (systemMessage)
+func emitCostNote(sessionID, note string) error {
+	if note == "" {
+		return nil
+	}
+	json.NewEncoder(os.Stdout).Encode(HookOutput{
+		SystemMessage: note,
+		HookOutput: map[string]interface{}{
+			"hookEventName":     "UserPromptSubmit",
+			"additionalContext": note,
+		},
+		Context: &ContextInfo{
+			SessionID:    sessionID,
+			SessionState: "running",
+		},
+	})
+	return nil
+}
+
+func runHookStop(cmd *cobra.Command, args []string) error {
+	input, err := readHookInput()
+	if err != nil {
+		input = &HookInput{}
+	}
+
+	sessionID := input.SessionID
+	if sessionID == "" {
+		sessionID = os.Getenv("CLAUDE_SESSION_ID")
+	}
+
+	if verbose && sessionID != "" {
+		fmt.Printf("🛑 Stop: session=%s\n", sessionID)
+	}
+
+	// 응답마다 usage를 갱신하여 예산/비용 알림 임계치 확인 (settings.budget 설정 시)
+	cwd := input.Cwd
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+	projectRoot := context.FindProjectRoot(cwd)
+	budget := loadBudget(projectRoot)
+	if input.TranscriptPath == "" || (!budgetLimits(budget).Enabled() && len(budget.CostAlerts) == 0) {
+		return nil
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return nil
+	}
+	defer database.Close()
+
 	sessionSvc := session.NewService(database)
+	palSession, err := sessionSvc.FindActiveSession(sessionID, cwd, projectRoot)
+	if err != nil || palSession == nil {
+		return nil
+	}
+	if _, err := collectSessionUsage(sessionSvc, palSession.ID, input.TranscriptPath); err == nil {
+		checkSessionBudget(sessionSvc, projectRoot, palSession.ID)
+		checkCostAlerts(sessionSvc, projectRoot, palSession.ID)
+	}
 
-	// 포트 정보 조회
-	p, err := portSvc.Get(portID)
+	return nil
+}
+
+func runHookPreCompact(cmd *cobra.Command, args []string) error {
+	input, err := readHookInput()
+	if err != nil {
+		input = &HookInput{}
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return nil
+	}
+	defer database.Close()
+
+	sessionSvc := session.NewService(database)
+
+	// Claude 세션 ID로 PAL 세션 찾기
+	claudeSessionID := input.SessionID
+	if claudeSessionID == "" {
+		claudeSessionID = os.Getenv("CLAUDE_SESSION_ID")
+	}
+
+	if claudeSessionID != "" {
+		palSession, err := sessionSvc.FindByClaudeSessionID(claudeSessionID)
+		if err == nil && palSession != nil {
+			sessionSvc.IncrementCompact(palSession.ID)
+
+			// 컴팩트 이벤트 로깅
+			trigger := input.Trigger
+			if trigger == "" {
+				trigger = "auto"
+			}
+			sessionSvc.LogEvent(palSession.ID, "compact", fmt.Sprintf(`{"trigger":"%s"}`, trigger))
+
+			// v11: 체크포인트 생성
+			cwd, _ := os.Getwd()
+			projectRoot := context.FindProjectRoot(cwd)
+			if projectRoot != "" {
+				cpSvc := context.NewCheckpointService(database, projectRoot)
+				if cp, err := cpSvc.CreateCheckpoint(palSession.ID); err == nil {
+					if verbose {
+						fmt.Fprintf(os.Stderr, "📷 Checkpoint created: %s\n", cp.ID)
+					}
+				}
+
+				// 컴팩션 후에도 유지할 작업 상태 힌트 주입
+				if !skipInSafeMode("PreCompact 힌트 주입") {
+					writePreserveHint(database, projectRoot, palSession.ID)
+				}
+			}
+
+			if verbose {
+				fmt.Fprintf(os.Stderr, "📦 PreCompact: session=%s, trigger=%s\n", palSession.ID, trigger)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writePreserveHint writes the "must preserve" block (active port, open checklist, pending messages)
+// so the compaction summary keeps the working state. pre_compact 설정으로 섹션을 조정하거나 끌 수 있습니다.
+func writePreserveHint(database *db.DB, projectRoot, sessionID string) {
+	settings := config.PreCompactSettings{}
+	if cfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+		settings = cfg.Settings.PreCompact
+	}
+	if settings.Disabled {
+		return
+	}
+
+	hint, err := context.BuildPreserveHint(database, projectRoot, sessionID, settings)
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  PreCompact 힌트 생성 실패: %v\n", err)
+		}
+		return
+	}
+	if hint.IsEmpty() {
+		return
+	}
+
+	output := HookOutput{
+		HookOutput: map[string]interface{}{
+			"hookEventName":     "PreCompact",
+			"additionalContext": hint.Markdown(),
+		},
+		Context: &ContextInfo{
+			SessionID:    sessionID,
+			SessionState: "running",
+		},
+	}
+	if hint.PortID != "" {
+		output.Context.ActivePort = &PortSummary{ID: hint.PortID, Title: hint.PortTitle, Status: "running"}
+	}
+	json.NewEncoder(os.Stdout).Encode(output)
+}
+
+func runHookPortStart(cmd *cobra.Command, args []string) error {
+	portID := args[0]
+
+	// stdin에서 hook 입력 읽기
+	input, err := readHookInput()
+	if err != nil {
+		input = &HookInput{}
+	}
+
+	database, err := db.Open(GetDBPath())
 	if err != nil {
 		return err
 	}
+	defer database.Close()
 
 	// 프로젝트 루트 찾기
 	cwd, _ := os.Getwd()
@@ -1227,6 +1756,52 @@ func runHookPortStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("PAL 프로젝트를 찾을 수 없습니다")
 	}
 
+	result, err := startPort(database, portID, input.SessionID, cwd, projectRoot, nil)
+	if err != nil {
+		return err
+	}
+
+	rulesSvc := rules.NewService(projectRoot)
+	if jsonOut {
+		output := map[string]interface{}{
+			"status": "started",
+			"port":   portID,
+		}
+		if result != nil {
+			output["worker_id"] = result.WorkerID
+			output["worker_name"] = result.WorkerName
+			output["token_count"] = result.TokenCount
+			output["checklist"] = result.Checklist
+		}
+		json.NewEncoder(os.Stdout).Encode(output)
+	} else {
+		fmt.Printf("▶️  포트 시작: %s\n", portID)
+		fmt.Printf("   Rules: %s\n", rulesSvc.GetRulePath(portID))
+		if result != nil {
+			fmt.Printf("   워커: %s (%s)\n", result.WorkerName, result.WorkerID)
+			fmt.Printf("   토큰: ~%d\n", result.TokenCount)
+			if len(result.Checklist) > 0 {
+				fmt.Printf("   체크리스트: %d 항목\n", len(result.Checklist))
+			}
+		}
+	}
+
+	return nil
+}
+
+// startPort starts a port the way the port-start hook does: rules 활성화, 관련 문서와 선행 포트 요약 주입,
+// 워커 매핑, 세션 연결(RecordStart), port_start 이벤트와 SSE 발행. paths는 rules 파일의 적용 경로입니다.
+// 워커 매핑 결과를 반환하며, 매핑에 실패해도 포트는 시작됩니다.
+func startPort(database *db.DB, portID, claudeSessionID, cwd, projectRoot string, paths []string) (*context.PortStartResult, error) {
+	portSvc := port.NewService(database)
+	sessionSvc := session.NewService(database)
+
+	// 포트 정보 조회
+	p, err := portSvc.Get(portID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Rules 활성화
 	rulesSvc := rules.NewService(projectRoot)
 	title := portID
@@ -1238,41 +1813,71 @@ func runHookPortStart(cmd *cobra.Command, args []string) error {
 		specPath = p.FilePath.String
 	}
 
-	if err := rulesSvc.ActivatePortWithSpec(portID, title, specPath, nil); err != nil {
-		return err
+	safeMode := hookSafeMode()
+	if !skipInSafeMode("포트 rules 활성화") {
+		if err := rulesSvc.ActivatePortWithSpec(portID, title, specPath, paths); err != nil {
+			return nil, err
+		}
+	}
+
+	// 현재 세션 찾기 (FindActiveSession 사용)
+	if claudeSessionID == "" {
+		claudeSessionID = os.Getenv("CLAUDE_SESSION_ID")
+	}
+
+	var palSessionID string
+	palSession, err := sessionSvc.FindActiveSession(claudeSessionID, cwd, projectRoot)
+	if err == nil && palSession != nil {
+		palSessionID = palSession.ID
 	}
 
 	// 문서 컨텍스트 로딩 (P3: docs-management)
+	// 고정 예산 대신 세션 attention의 남은 토큰에 맞춰 예산 조정
 	docSvc := document.NewService(database, projectRoot)
 	if specPath != "" {
-		// 포트 명세에서 관련 문서 검색
-		relatedDocs, err := docSvc.GetRelatedDocs(specPath, 50000) // 50K 토큰 예산
-		if err == nil && len(relatedDocs) > 0 {
+		attStore := attention.NewStore(database.DB)
+		var att *attention.SessionAttention
+		if palSessionID != "" {
+			att, _ = attStore.Get(palSessionID)
+		}
+		docBudget := attention.DocBudget(att)
+
+		if docBudget == 0 {
+			if verbose {
+				fmt.Printf("📚 남은 토큰 예산이 부족하여 관련 문서 주입 생략 (%d/%d)\n", att.LoadedTokens, att.AvailableTokens)
+			}
+		} else if relatedDocs, err := docSvc.GetRelatedDocs(specPath, int64(docBudget)); err == nil && len(relatedDocs) > 0 {
 			// .claude/rules/<port-id>.md 파일에 문서 참조 추가
 			docContext := generateDocContext(relatedDocs, projectRoot)
-			if docContext != "" {
+			if docContext != "" && !safeMode {
 				rulesSvc.AppendToRule(portID, docContext)
 			}
+
+			// 주입한 문서 토큰을 attention에 반영
+			if att != nil {
+				var docTokens int64
+				for _, d := range relatedDocs {
+					docTokens += d.Tokens
+				}
+				attStore.UpdateTokens(palSessionID, att.LoadedTokens+int(docTokens))
+			}
 			if verbose {
-				fmt.Printf("📚 관련 문서 %d건 로드됨\n", len(relatedDocs))
+				fmt.Printf("📚 관련 문서 %d건 로드됨 (예산 %d 토큰)\n", len(relatedDocs), docBudget)
 			}
 		}
 	}
 
-	// 현재 세션 찾기 (FindActiveSession 사용)
-	claudeSessionID := input.SessionID
-	if claudeSessionID == "" {
-		claudeSessionID = os.Getenv("CLAUDE_SESSION_ID")
-	}
-
-	var palSessionID string
-	palSession, err := sessionSvc.FindActiveSession(claudeSessionID, cwd, projectRoot)
-	if err == nil && palSession != nil {
-		palSessionID = palSession.ID
+	// 선행 포트가 완료 시 남긴 변경 요약 주입
+	if summaries, err := portSvc.DependencySummaries(portID); err == nil && len(summaries) > 0 && !safeMode {
+		rulesSvc.AppendToRule(portID, port.DependencySummariesMarkdown(summaries))
+		if verbose {
+			fmt.Printf("📝 선행 포트 요약 %d건 주입됨\n", len(summaries))
+		}
 	}
 
 	// Claude 통합 서비스로 컨텍스트 처리 (먼저 워커 정보 얻기)
 	claudeSvc := context.NewClaudeService(database, projectRoot)
+	claudeSvc.ReadOnly = safeMode
 	result, err := claudeSvc.ProcessPortStart(portID)
 
 	// 워커 ID 추출
@@ -1310,36 +1915,12 @@ func runHookPortStart(cmd *cobra.Command, args []string) error {
 		// 기본 컨텍스트 주입
 		ctxSvc := context.NewService(database)
 		claudeMD := context.FindClaudeMD(cwd)
-		if claudeMD != "" {
+		if claudeMD != "" && !safeMode {
 			ctxSvc.InjectToFile(claudeMD)
 		}
 	}
 
-	if jsonOut {
-		output := map[string]interface{}{
-			"status": "started",
-			"port":   portID,
-		}
-		if result != nil {
-			output["worker_id"] = result.WorkerID
-			output["worker_name"] = result.WorkerName
-			output["token_count"] = result.TokenCount
-			output["checklist"] = result.Checklist
-		}
-		json.NewEncoder(os.Stdout).Encode(output)
-	} else {
-		fmt.Printf("▶️  포트 시작: %s\n", portID)
-		fmt.Printf("   Rules: %s\n", rulesSvc.GetRulePath(portID))
-		if result != nil {
-			fmt.Printf("   워커: %s (%s)\n", result.WorkerName, result.WorkerID)
-			fmt.Printf("   토큰: ~%d\n", result.TokenCount)
-			if len(result.Checklist) > 0 {
-				fmt.Printf("   체크리스트: %d 항목\n", len(result.Checklist))
-			}
-		}
-	}
-
-	return nil
+	return result, nil
 }
 
 func runHookPortEnd(cmd *cobra.Command, args []string) error {
@@ -1371,12 +1952,6 @@ func runHookPortEnd(cmd *cobra.Command, args []string) error {
 	cwd, _ := os.Getwd()
 	projectRoot := context.FindProjectRoot(cwd)
 
-	// Rules 비활성화
-	if projectRoot != "" {
-		rulesSvc := rules.NewService(projectRoot)
-		rulesSvc.DeactivatePort(portID)
-	}
-
 	// 세션 찾기 (FindActiveSession 사용)
 	claudeSessionID := input.SessionID
 	if claudeSessionID == "" {
@@ -1389,22 +1964,44 @@ func runHookPortEnd(cmd *cobra.Command, args []string) error {
 		palSessionID = palSession.ID
 	}
 
+	// 완료 기준 확인
+	if !hookPortEndForce && projectRoot != "" {
+		if done, err := checkPortAcceptance(portSvc, sessionSvc, projectRoot, palSessionID, portID); err != nil || !done {
+			return err
+		}
+	}
+
+	// Rules 비활성화
+	safeMode := hookSafeMode()
+	if projectRoot != "" && !skipInSafeMode("포트 rules 비활성화") {
+		rulesSvc := rules.NewService(projectRoot)
+		rulesSvc.DeactivatePort(portID)
+	}
+
 	// 포트 duration 계산 (시작 시간부터 현재까지)
 	var durationSecs int64
 	if p.StartedAt.Valid {
 		durationSecs = int64(time.Since(p.StartedAt.Time).Seconds())
 	}
 
-	// transcript에서 포트 작업 중 사용량 추정 (시작~종료 사이)
-	// 현재는 세션 전체 usage에서 추정하기 어려우므로 0으로 설정
-	// 향후 transcript 타임스탬프 기반 분할 수집 가능
-	var inputTokens, outputTokens int64
+	// transcript에서 포트 작업 구간(시작~종료)의 사용량을 포트에 귀속
+	var inputTokens, outputTokens, cacheReadTokens, cacheCreateTokens int64
 	var costUSD float64
 
-	// 세션에 usage가 있으면 이 포트에 할당 (단순화: 포트 작업 중 발생한 것으로 간주)
-	// 더 정확한 추적은 port-start/port-end 사이의 transcript 분석 필요
-	// TODO: transcript 시간 범위 기반 분석 구현
-	_ = palSession // 향후 구현 예정
+	transcriptPath := input.TranscriptPath
+	if transcriptPath == "" && palSession != nil && palSession.TranscriptPath.Valid {
+		transcriptPath = palSession.TranscriptPath.String
+	}
+	if transcriptPath != "" && p.StartedAt.Valid {
+		usage, err := transcript.ParseFileRange(transcriptPath, p.StartedAt.Time, time.Now())
+		if err == nil {
+			inputTokens, outputTokens = usage.InputTokens, usage.OutputTokens
+			cacheReadTokens, cacheCreateTokens = usage.CacheReadTokens, usage.CacheCreateTokens
+			costUSD = usage.CostUSD
+		} else if verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  포트 사용량 집계 실패: %v\n", err)
+		}
+	}
 
 	// RecordCompletion으로 포트 완료 기록 (상태, 시간, duration, usage)
 	if err := portSvc.RecordCompletion(portID, inputTokens, outputTokens, costUSD); err != nil {
@@ -1412,18 +2009,43 @@ func runHookPortEnd(cmd *cobra.Command, args []string) error {
 		portSvc.UpdateStatus(portID, "complete")
 		portSvc.SetDuration(portID, durationSecs)
 	}
+	portSvc.SetCacheUsage(portID, cacheReadTokens, cacheCreateTokens)
+
+	// 추정치 대비 실제값 비교
+	var estimateLine string
+	if est, err := portSvc.GetEstimate(portID); err == nil && !est.IsZero() {
+		actualHours := float64(durationSecs) / 3600
+		estimateLine = compareEstimate(est, inputTokens+outputTokens, actualHours)
+		if palSessionID != "" {
+			r := port.EstimateResult{Estimate: est, ActualTokens: inputTokens + outputTokens, ActualHours: actualHours}
+			sessionSvc.LogEvent(palSessionID, "port_estimate", fmt.Sprintf(
+				`{"port_id":"%s","estimate_tokens":%d,"actual_tokens":%d,"token_ratio":%.2f,"estimate_hours":%.2f,"actual_hours":%.2f,"hour_ratio":%.2f}`,
+				portID, est.Tokens, r.ActualTokens, r.TokenRatio(), est.Hours, actualHours, r.HourRatio()))
+		}
+	}
+
+	// 의존 포트에 전달할 변경 요약 생성
+	if summary, err := storePortSummary(portSvc, projectRoot, portID); err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  포트 요약 생성 실패: %v\n", err)
+		}
+	} else if summary != "" && palSessionID != "" {
+		sessionSvc.LogEvent(palSessionID, "port_summary", fmt.Sprintf(`{"port_id":"%s","tokens":%d}`, portID, len(summary)/4))
+	}
 
 	// Lock 해제
-	locks, _ := lockSvc.List()
-	for _, l := range locks {
-		lockSvc.Release(l.Resource)
+	if !safeMode {
+		locks, _ := lockSvc.List()
+		for _, l := range locks {
+			lockSvc.Release(l.Resource)
+		}
 	}
 
 	// 포트 완료 이벤트 로깅
 	if palSessionID != "" {
 		sessionSvc.LogEvent(palSessionID, "port_end", fmt.Sprintf(
-			`{"port_id":"%s","duration_secs":%d,"input_tokens":%d,"output_tokens":%d,"cost_usd":%.4f}`,
-			portID, durationSecs, inputTokens, outputTokens, costUSD))
+			`{"port_id":"%s","duration_secs":%d,"input_tokens":%d,"output_tokens":%d,"cache_read_tokens":%d,"cache_create_tokens":%d,"cost_usd":%.4f}`,
+			portID, durationSecs, inputTokens, outputTokens, cacheReadTokens, cacheCreateTokens, costUSD))
 	}
 
 	// SSE 이벤트 발행 (LM-sse-stream)
@@ -1434,13 +2056,14 @@ func runHookPortEnd(cmd *cobra.Command, args []string) error {
 	var result *context.PortEndResult
 	if projectRoot != "" {
 		claudeSvc := context.NewClaudeService(database, projectRoot)
+		claudeSvc.ReadOnly = safeMode
 		result, _ = claudeSvc.ProcessPortEnd(portID, "")
 	}
 
 	// 기본 컨텍스트 업데이트
 	ctxSvc := context.NewService(database)
 	claudeMD := context.FindClaudeMD(cwd)
-	if claudeMD != "" {
+	if claudeMD != "" && !safeMode {
 		ctxSvc.InjectToFile(claudeMD)
 	}
 
@@ -1453,17 +2076,80 @@ func runHookPortEnd(cmd *cobra.Command, args []string) error {
 		if result != nil {
 			output["message"] = result.Message
 		}
+		if estimateLine != "" {
+			output["estimate"] = estimateLine
+		}
 		json.NewEncoder(os.Stdout).Encode(output)
 	} else {
 		fmt.Printf("✅ 포트 완료: %s\n", portID)
 		if durationSecs > 0 {
 			fmt.Printf("   소요 시간: %s\n", formatPortDuration(durationSecs))
 		}
+		if estimateLine != "" {
+			fmt.Printf("   %s\n", estimateLine)
+		}
 	}
 
 	return nil
 }
 
+// checkPortAcceptance verifies the acceptance criteria of the port spec before completion.
+// 미충족 항목을 port_acceptance_unmet 이벤트로 남기고, review 모드면 needs-review로 표시한 뒤 false를,
+// block 모드면 에러를 반환합니다.
+func checkPortAcceptance(portSvc *port.Service, sessionSvc *session.Service, projectRoot, palSessionID, portID string) (bool, error) {
+	mode := config.AcceptanceModeBlock
+	if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil && projectCfg.Settings.AcceptanceMode != "" {
+		mode = projectCfg.Settings.AcceptanceMode
+	}
+	if mode == config.AcceptanceModeOff {
+		return true, nil
+	}
+
+	criteria, err := portSvc.AcceptanceCriteria(portID, projectRoot)
+	if err != nil {
+		return false, err
+	}
+	remaining := port.Remaining(criteria)
+	if len(remaining) == 0 {
+		return true, nil
+	}
+
+	items := make([]string, len(remaining))
+	for i, c := range remaining {
+		items[i] = c.Text
+	}
+	if palSessionID != "" {
+		itemsJSON, _ := json.Marshal(items)
+		sessionSvc.LogEvent(palSessionID, "port_acceptance_unmet", fmt.Sprintf(`{"port_id":"%s","mode":"%s","remaining":%s,"total":%d}`,
+			portID, mode, itemsJSON, len(criteria)))
+	}
+
+	if mode == config.AcceptanceModeReview {
+		if err := portSvc.UpdateStatus(portID, port.StatusNeedsReview); err != nil {
+			return false, err
+		}
+		if jsonOut {
+			json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+				"status":    port.StatusNeedsReview,
+				"port":      portID,
+				"remaining": items,
+			})
+			return false, nil
+		}
+		fmt.Printf("🔍 포트 검토 필요: %s (완료 기준 %d/%d 미충족)\n", portID, len(remaining), len(criteria))
+		for _, item := range items {
+			fmt.Printf("   - [ ] %s\n", item)
+		}
+		return false, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "🚫 완료 기준 %d/%d개가 체크되지 않았습니다:\n", len(remaining), len(criteria))
+	for _, item := range items {
+		fmt.Fprintf(os.Stderr, "   - [ ] %s\n", item)
+	}
+	return false, errcode.New(errcode.KindValidation, "포트 '%s'의 완료 기준이 충족되지 않았습니다 (명세에서 체크하거나 --force 사용)", portID)
+}
+
 // formatPortDuration formats seconds into human readable string
 func formatPortDuration(secs int64) string {
 	if secs < 60 {
@@ -1494,11 +2180,12 @@ func runHookSync(cmd *cobra.Command, args []string) error {
 	}
 
 	rulesSvc := rules.NewService(projectRoot)
+	safeMode := skipInSafeMode("rules/CLAUDE.md/브리핑 동기화")
 
 	// 워크플로우 rules 갱신
 	workflowSvc := workflow.NewService(projectRoot)
 	ctx, err := workflowSvc.GetContext()
-	if err == nil {
+	if err == nil && !safeMode {
 		if err := workflowSvc.WriteRulesFile(ctx); err != nil {
 			if verbose {
 				fmt.Fprintf(os.Stderr, "⚠️  워크플로우 rules 갱신 실패: %v\n", err)
@@ -1527,30 +2214,45 @@ func runHookSync(cmd *cobra.Command, args []string) error {
 		runningPortsMap[p.ID] = true
 	}
 
+	// 만료된 rules는 재사용하지 않고 재생성
+	now := time.Now()
+	expiredRules, _ := rulesSvc.Expired(now)
+	expiredMap := make(map[string]bool)
+	for _, r := range expiredRules {
+		expiredMap[r] = true
+	}
+
 	activated := 0
 	deactivated := 0
-
-	// running 포트에 rules가 없으면 생성
-	for _, p := range runningPorts {
-		if !activeRulesMap[p.ID] {
-			title := p.ID
-			if p.Title.Valid {
-				title = p.Title.String
-			}
-			specPath := ""
-			if p.FilePath.Valid {
-				specPath = p.FilePath.String
+	regenerated := 0
+
+	if !safeMode {
+		// running 포트에 rules가 없거나 만료되었으면 생성
+		for _, p := range runningPorts {
+			if !activeRulesMap[p.ID] || expiredMap[p.ID] {
+				title := p.ID
+				if p.Title.Valid {
+					title = p.Title.String
+				}
+				specPath := ""
+				if p.FilePath.Valid {
+					specPath = p.FilePath.String
+				}
+				rulesSvc.ActivatePortWithSpec(p.ID, title, specPath, nil)
+				if activeRulesMap[p.ID] {
+					regenerated++
+				} else {
+					activated++
+				}
 			}
-			rulesSvc.ActivatePortWithSpec(p.ID, title, specPath, nil)
-			activated++
 		}
-	}
 
-	// running이 아닌데 rules가 있으면 삭제
-	for _, ruleID := range activeRules {
-		if !runningPortsMap[ruleID] {
-			rulesSvc.DeactivatePort(ruleID)
-			deactivated++
+		// running이 아닌데 rules가 있으면 삭제
+		for _, ruleID := range activeRules {
+			if !runningPortsMap[ruleID] {
+				rulesSvc.DeactivatePort(ruleID)
+				deactivated++
+			}
 		}
 	}
 
@@ -1568,15 +2270,37 @@ func runHookSync(cmd *cobra.Command, args []string) error {
 	// 컨텍스트 업데이트
 	ctxSvc := context.NewService(database)
 	claudeMD := context.FindClaudeMD(cwd)
-	if claudeMD != "" {
+	if claudeMD != "" && !safeMode {
 		ctxSvc.InjectToFile(claudeMD)
 	}
 
+	// 동시 실행 세션 현황 갱신
+	if !safeMode {
+		if err := writePeersContext(session.NewService(database), projectRoot); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		}
+	}
+
+	// 만료된 세션 브리핑 재생성
+	briefingRegenerated := false
+	operatorSvc := operator.NewService(database, projectRoot)
+	if data, err := os.ReadFile(operatorSvc.GetBriefingPath()); err == nil && rules.IsExpired(string(data), now) && !safeMode {
+		if b, err := operatorSvc.GenerateBriefing(); err == nil {
+			if err := operatorSvc.WriteBriefing(b); err == nil {
+				briefingRegenerated = true
+			} else if verbose {
+				fmt.Fprintf(os.Stderr, "⚠️  브리핑 재생성 실패: %v\n", err)
+			}
+		}
+	}
+
 	if jsonOut {
 		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
-			"activated":   activated,
-			"deactivated": deactivated,
-			"running":     len(runningPorts),
+			"activated":            activated,
+			"deactivated":          deactivated,
+			"regenerated":          regenerated,
+			"briefing_regenerated": briefingRegenerated,
+			"running":              len(runningPorts),
 		})
 	} else {
 		fmt.Printf("🔄 Sync 완료\n")
@@ -1587,6 +2311,12 @@ func runHookSync(cmd *cobra.Command, args []string) error {
 		if deactivated > 0 {
 			fmt.Printf("   Deactivated: %d\n", deactivated)
 		}
+		if regenerated > 0 {
+			fmt.Printf("   Regenerated (만료): %d\n", regenerated)
+		}
+		if briefingRegenerated {
+			fmt.Printf("   Briefing: 만료되어 재생성\n")
+		}
 	}
 
 	return nil
@@ -1764,6 +2494,7 @@ func runHookEvents(cmd *cobra.Command, args []string) error {
 		session.EventFileEdit:      "📝",
 		session.EventUntrackedEdit: "⚠️",
 		session.EventCompact:       "📦",
+		session.EventCollapsed:     "🗜️",
 		// v11: 새로운 이벤트 타입
 		session.EventContextLoaded:      "📚",
 		session.EventContextOverflow:    "💥",
@@ -1815,34 +2546,26 @@ func generateDocContext(docs []document.Document, projectRoot string) string {
 	sb.WriteString("## 관련 문서\n\n")
 	sb.WriteString("> 이 포트와 관련된 문서입니다. 작업 시 참고하세요.\n\n")
 
-	// 타입별로 그룹화
+	// 타입별로 그룹화 (순서와 이름은 문서 타입 레지스트리 기준)
+	types, err := document.LoadTypeRegistry(projectRoot)
+	if err != nil {
+		types = document.DefaultTypeRegistry()
+	}
 	byType := make(map[string][]document.Document)
+	var typeOrder []string
 	for _, d := range docs {
+		if _, ok := byType[d.Type]; !ok {
+			typeOrder = append(typeOrder, d.Type)
+		}
 		byType[d.Type] = append(byType[d.Type], d)
 	}
-
-	typeOrder := []string{"l1", "l2", "lm", "convention", "template"}
-	typeNames := map[string]string{
-		"l1":         "L1 Domain",
-		"l2":         "L2 Feature",
-		"lm":         "LM Coordinator",
-		"convention": "컨벤션",
-		"template":   "템플릿",
-	}
+	sort.SliceStable(typeOrder, func(i, j int) bool {
+		return types.OrderOf(typeOrder[i]) < types.OrderOf(typeOrder[j])
+	})
 
 	for _, t := range typeOrder {
-		typeDocs, ok := byType[t]
-		if !ok || len(typeDocs) == 0 {
-			continue
-		}
-
-		name := typeNames[t]
-		if name == "" {
-			name = t
-		}
-
-		sb.WriteString(fmt.Sprintf("### %s\n\n", name))
-		for _, d := range typeDocs {
+		sb.WriteString(fmt.Sprintf("### %s\n\n", types.DisplayName(t)))
+		for _, d := range byType[t] {
 			sb.WriteString(fmt.Sprintf("- **%s** (`%s`)\n", d.ID, d.Path))
 			if d.Domain != "" {
 				sb.WriteString(fmt.Sprintf("  - 도메인: %s\n", d.Domain))
@@ -1852,27 +2575,43 @@ func generateDocContext(docs []document.Document, projectRoot string) string {
 		sb.WriteString("\n")
 	}
 
-	// 기타 타입
-	for t, typeDocs := range byType {
-		found := false
-		for _, order := range typeOrder {
-			if t == order {
-				found = true
-				break
-			}
-		}
-		if found || len(typeDocs) == 0 {
-			continue
-		}
+	return sb.String()
+}
 
-		sb.WriteString(fmt.Sprintf("### %s\n\n", t))
-		for _, d := range typeDocs {
-			sb.WriteString(fmt.Sprintf("- **%s** (`%s`)\n", d.ID, d.Path))
-		}
-		sb.WriteString("\n")
+// routeNotification logs a notification as a session event and forwards it
+// to the sinks configured in the notifications routing table
+func routeNotification(input *HookInput, sessionSvc *session.Service, palSessionID, projectRoot string) {
+	notificationType := input.NotificationType
+	if notificationType == "" {
+		notificationType = "general"
 	}
 
-	return sb.String()
+	if palSessionID != "" {
+		sessionSvc.LogEvent(palSessionID, session.EventNotification, fmt.Sprintf(`{"type":"%s","message":"%s"}`,
+			escapeJSON(notificationType), escapeJSON(truncateString(input.Message, 200))))
+	}
+
+	if projectRoot == "" {
+		return
+	}
+	projectCfg, err := config.LoadProjectConfig(projectRoot)
+	if err != nil || len(projectCfg.Notifications.Routes) == 0 {
+		return
+	}
+
+	router := notify.NewRouter(projectCfg.Notifications, projectRoot)
+	errs := router.Dispatch(notify.Notification{
+		Type:        notificationType,
+		Message:     input.Message,
+		SessionID:   palSessionID,
+		ProjectRoot: projectRoot,
+	})
+	if len(errs) > 0 {
+		warnings := newHookWarnings(projectRoot, sessionSvc, palSessionID)
+		for _, e := range errs {
+			warnings.warn(config.WarningHookError, "⚠️  알림 전달 실패: %v", e)
+		}
+	}
 }
 
 // runHookNotification handles notification events (compact, error, etc.)
@@ -1886,11 +2625,6 @@ func runHookNotification(cmd *cobra.Command, args []string) error {
 	isCompact := input.NotificationType == "compact" ||
 		recovery.DetectCompact(input.Message)
 
-	if !isCompact {
-		// Compact가 아니면 기본 처리
-		return nil
-	}
-
 	database, err := db.Open(GetDBPath())
 	if err != nil {
 		return nil
@@ -1917,6 +2651,12 @@ func runHookNotification(cmd *cobra.Command, args []string) error {
 		palSessionID = palSession.ID
 	}
 
+	if !isCompact {
+		// Compact가 아니면 이벤트 기록 + 라우팅 설정에 따라 전달
+		routeNotification(input, sessionSvc, palSessionID, projectRoot)
+		return nil
+	}
+
 	if palSessionID == "" {
 		return nil
 	}
@@ -2189,6 +2929,7 @@ func runHookSubagent(cmd *cobra.Command, args []string) error {
 		SessionType:     session.TypeSub,
 		ProjectRoot:     projectRoot,
 		Cwd:             cwd,
+		Sandbox:         sessionSvc.IsSandbox(parentSessionID),
 	}
 	sessionSvc.StartWithFullOptions(childOpts)
 
@@ -2236,3 +2977,206 @@ func runHookSubagent(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// pauseIdleSessions pauses idle sessions of a project using its idle_pause_after setting,
+// except the sessions of the Claude session that triggered the hook
+func pauseIdleSessions(sessionSvc *session.Service, projectRoot string, exceptClaudeSessions ...string) {
+	window := config.DefaultIdlePauseAfter
+	if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+		window = projectCfg.Settings.IdlePauseWindow()
+	}
+	paused, err := sessionSvc.PauseIdleSessions(projectRoot, window, exceptClaudeSessions...)
+	if err == nil && len(paused) > 0 && verbose {
+		fmt.Printf("💤 Paused %d idle session(s)\n", len(paused))
+	}
+}
+
+// resumeSession reopens the prior PAL session continued by a restarted Claude session.
+// transcript_path, Claude 세션 ID, transcript fingerprint 순으로 매칭합니다.
+func resumeSession(sessionSvc *session.Service, input *HookInput, projectRoot string) *session.Session {
+	q := session.ResumeQuery{
+		ClaudeSessionID: input.SessionID,
+		TranscriptPath:  input.TranscriptPath,
+		ProjectRoot:     projectRoot,
+	}
+	if input.TranscriptPath != "" {
+		q.Fingerprint = transcript.Fingerprint(input.TranscriptPath)
+		q.FingerprintOf = transcript.Fingerprint
+	}
+
+	prev, matchedBy, err := sessionSvc.FindResumable(q)
+	if err != nil || prev == nil {
+		return nil
+	}
+	if err := sessionSvc.Reopen(prev.ID, input.SessionID, input.TranscriptPath, matchedBy); err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "세션 재개 실패: %v\n", err)
+		}
+		return nil
+	}
+	if verbose {
+		fmt.Printf("♻️  Resumed session: %s (%s)\n", prev.ID, matchedBy)
+	}
+	return prev
+}
+
+// findSubagentParent resolves the parent PAL session of a subagent hook event
+func findSubagentParent(input *HookInput, sessionSvc *session.Service) (*session.Session, string, string) {
+	claudeSessionID := input.SessionID
+	if claudeSessionID == "" {
+		claudeSessionID = os.Getenv("CLAUDE_SESSION_ID")
+	}
+
+	cwd := input.Cwd
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+	projectRoot := context.FindProjectRoot(cwd)
+
+	parent, err := sessionSvc.FindActiveSession(claudeSessionID, cwd, projectRoot)
+	if err != nil {
+		return nil, cwd, projectRoot
+	}
+	return parent, cwd, projectRoot
+}
+
+// runHookSubagentStart creates a child session under the parent PAL session
+func runHookSubagentStart(cmd *cobra.Command, args []string) error {
+	input, err := readHookInput()
+	if err != nil {
+		input = &HookInput{}
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return nil
+	}
+	defer database.Close()
+
+	sessionSvc := session.NewService(database)
+	portSvc := port.NewService(database)
+
+	parent, cwd, projectRoot := findSubagentParent(input, sessionSvc)
+	if parent == nil {
+		return nil
+	}
+
+	// agent_id가 있으면 결정적 ID (SubagentStop에서 같은 세션을 찾음)
+	childSessionID := "sa-" + uuid.New().String()[:8]
+	if input.AgentID != "" {
+		childSessionID = session.SubagentSessionID(parent.ID, input.AgentID)
+		if _, err := sessionSvc.Get(childSessionID); err == nil {
+			return nil // 이미 시작됨
+		}
+	}
+
+	title := input.AgentType
+	if title == "" {
+		title = "subagent"
+	}
+	err = sessionSvc.StartWithFullOptions(session.StartOptions{
+		ID:            childSessionID,
+		Title:         title,
+		SessionType:   session.TypeSub,
+		ParentSession: parent.ID,
+		ProjectRoot:   projectRoot,
+		Cwd:           cwd,
+		Sandbox:       sessionSvc.IsSandbox(parent.ID),
+	})
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  서브에이전트 세션 생성 실패: %v\n", err)
+		}
+		return nil
+	}
+
+	sessionSvc.LogEvent(parent.ID, "subagent_start", fmt.Sprintf(`{"child_session":"%s","agent_id":"%s","agent_type":"%s"}`,
+		childSessionID, escapeJSON(input.AgentID), escapeJSON(input.AgentType)))
+
+	// 활성 포트가 있으면 서브에이전트에 컨텍스트 전달
+	if runningPorts, _ := portSvc.List("running", 1); len(runningPorts) > 0 {
+		p := runningPorts[0]
+		portTitle := p.ID
+		if p.Title.Valid {
+			portTitle = p.Title.String
+		}
+		output := HookOutput{
+			HookOutput: map[string]interface{}{
+				"hookEventName":     "SubagentStart",
+				"additionalContext": fmt.Sprintf("[PAL Kit] 활성 포트: %s (%s). 이 포트 범위 안에서 작업하세요.", p.ID, portTitle),
+			},
+		}
+		json.NewEncoder(os.Stdout).Encode(output)
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "👶 Subagent start: parent=%s, child=%s (%s)\n", parent.ID, childSessionID, title)
+	}
+	return nil
+}
+
+// runHookSubagentStop ends the child session and rolls its usage up into the parent
+func runHookSubagentStop(cmd *cobra.Command, args []string) error {
+	input, err := readHookInput()
+	if err != nil {
+		input = &HookInput{}
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return nil
+	}
+	defer database.Close()
+
+	sessionSvc := session.NewService(database)
+
+	parent, _, _ := findSubagentParent(input, sessionSvc)
+	if parent == nil {
+		return nil
+	}
+
+	child, err := sessionSvc.FindSubagentSession(parent.ID, input.AgentID)
+	if err != nil || child.Status != "running" {
+		return nil
+	}
+
+	duration := int64(time.Since(child.StartedAt).Seconds())
+	tools := map[string]int{}
+	var usage session.TokenUsage
+
+	// 서브에이전트 transcript에서 사용량/도구 집계
+	if input.AgentTranscriptPath != "" {
+		if u, err := transcript.ParseFile(input.AgentTranscriptPath); err == nil {
+			usage = session.TokenUsage{
+				InputTokens:       u.InputTokens,
+				OutputTokens:      u.OutputTokens,
+				CacheReadTokens:   u.CacheReadTokens,
+				CacheCreateTokens: u.CacheCreateTokens,
+				CostUSD:           u.CostUSD,
+			}
+			// 자식 세션은 summary에만 기록 (통계 중복 합산 방지), 부모에 합산
+			sessionSvc.AddUsage(parent.ID, usage)
+		}
+		if counts, err := transcript.CountToolUses(input.AgentTranscriptPath); err == nil {
+			tools = counts
+		}
+	}
+
+	summary := map[string]interface{}{
+		"agent_id":     input.AgentID,
+		"agent_type":   input.AgentType,
+		"duration_sec": duration,
+		"tools":        tools,
+		"usage":        usage,
+	}
+	sessionSvc.EndWithSummary(child.ID, "complete", summary)
+
+	toolsJSON, _ := json.Marshal(tools)
+	sessionSvc.LogEvent(parent.ID, "subagent_stop", fmt.Sprintf(`{"child_session":"%s","agent_type":"%s","duration_sec":%d,"tools":%s,"cost_usd":%.4f}`,
+		child.ID, escapeJSON(input.AgentType), duration, toolsJSON, usage.CostUSD))
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "🏁 Subagent stop: child=%s, %ds, $%.4f\n", child.ID, duration, usage.CostUSD)
+	}
+	return nil
+}
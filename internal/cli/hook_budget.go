@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/n0roo/pal-kit/internal/transcript"
+)
+
+// collectSessionUsage parses the transcript and stores the session usage.
+// 서브에이전트 transcript는 별도 파일이므로 자식 세션 사용량을 합산합니다.
+func collectSessionUsage(sessionSvc *session.Service, sessionID, transcriptPath string) (*transcript.Usage, error) {
+	// 파일이 아직 쓰는 중일 수 있음 → 재시도 로직
+	var usage *transcript.Usage
+	var err error
+	for retry := 0; retry < 3; retry++ {
+		usage, err = transcript.ParseFile(transcriptPath)
+		if err == nil && usage != nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if usage == nil {
+		return nil, err
+	}
+
+	if child, err := sessionSvc.ChildUsage(sessionID); err == nil {
+		usage.InputTokens += child.InputTokens
+		usage.OutputTokens += child.OutputTokens
+		usage.CacheReadTokens += child.CacheReadTokens
+		usage.CacheCreateTokens += child.CacheCreateTokens
+		usage.CostUSD += child.CostUSD
+	}
+
+	if err := sessionSvc.UpdateUsage(
+		sessionID,
+		usage.InputTokens,
+		usage.OutputTokens,
+		usage.CacheReadTokens,
+		usage.CacheCreateTokens,
+		usage.CostUSD,
+	); err != nil {
+		return usage, err
+	}
+	return usage, nil
+}
+
+// loadBudget returns the project's budget settings (settings.budget)
+func loadBudget(projectRoot string) config.BudgetSettings {
+	if projectRoot == "" {
+		return config.BudgetSettings{}
+	}
+	projectCfg, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return config.BudgetSettings{}
+	}
+	return projectCfg.Settings.Budget
+}
+
+// budgetLimits converts budget settings to session limits
+func budgetLimits(b config.BudgetSettings) session.BudgetLimits {
+	return session.BudgetLimits{
+		SessionUSD:    b.SessionUSD,
+		SessionTokens: b.SessionTokens,
+		DailyUSD:      b.DailyUSD,
+		DailyTokens:   b.DailyTokens,
+	}
+}
+
+// checkSessionBudget logs newly crossed budget thresholds and warns Claude
+func checkSessionBudget(sessionSvc *session.Service, projectRoot, sessionID string) {
+	budget := loadBudget(projectRoot)
+	limits := budgetLimits(budget)
+	if !limits.Enabled() {
+		return
+	}
+
+	status, err := sessionSvc.CheckBudget(sessionID, limits)
+	if err != nil || len(status.Alarms) == 0 {
+		return
+	}
+
+	warnings := newHookWarnings(projectRoot, sessionSvc, sessionID)
+	for _, alarm := range status.Alarms {
+		msg := fmt.Sprintf("💸 [PAL Kit] %s", alarm.Message())
+		if alarm.Threshold >= 100 && budget.BlockAtLimit {
+			msg += " - 이후 도구 사용이 차단됩니다"
+		}
+		warnings.warn(config.WarningBudget, "%s", msg)
+	}
+}
+
+// checkCostAlerts records a crossed cost alert threshold (settings.budget.cost_alerts).
+// 알림은 Notification 이벤트로 라우팅되고, 다음 user-prompt 훅 출력으로 Claude에 전달됩니다.
+func checkCostAlerts(sessionSvc *session.Service, projectRoot, sessionID string) {
+	thresholds := loadBudget(projectRoot).CostAlerts
+	if len(thresholds) == 0 {
+		return
+	}
+
+	alert, err := sessionSvc.CheckCostAlerts(sessionID, thresholds)
+	if err != nil || alert == nil {
+		return
+	}
+
+	routeNotification(&HookInput{
+		NotificationType: session.EventCostAlert,
+		Message:          "💸 [PAL Kit] " + alert.Message(),
+	}, sessionSvc, sessionID, projectRoot)
+	if verbose {
+		fmt.Fprintf(os.Stderr, "💸 [PAL Kit] %s\n", alert.Message())
+	}
+}
+
+// costAlertNote returns the one-line note of undelivered cost alerts and marks them delivered
+func costAlertNote(sessionSvc *session.Service, sessionID string) string {
+	alerts, err := sessionSvc.TakeCostAlerts(sessionID)
+	if err != nil || len(alerts) == 0 {
+		return ""
+	}
+	// 여러 개가 쌓였으면 가장 최근(가장 높은) 임계치만 알림
+	return "💸 [PAL Kit] " + alerts[len(alerts)-1].Message()
+}
+
+// budgetBlockOutput returns a deny output when the session has exhausted its budget
+// and settings.budget.block_at_limit is set. 차단하지 않으면 nil을 반환합니다.
+func budgetBlockOutput(sessionSvc *session.Service, projectRoot, sessionID string) *HookOutput {
+	budget := loadBudget(projectRoot)
+	limits := budgetLimits(budget)
+	if !budget.BlockAtLimit || !limits.Enabled() || sessionID == "" {
+		return nil
+	}
+
+	status, err := sessionSvc.BudgetUsage(sessionID, limits)
+	if err != nil || !status.Exceeded {
+		return nil
+	}
+
+	var over []string
+	for _, u := range status.Usage {
+		if u.Percent >= 100 {
+			over = append(over, u.Message())
+		}
+	}
+	reason := fmt.Sprintf("예산을 모두 사용하여 도구 사용이 차단되었습니다 (%s). 작업을 정리하고 사용자에게 예산 조정을 요청하세요.",
+		strings.Join(over, ", "))
+	fmt.Fprintf(os.Stderr, "💸 [PAL Kit] %s\n", reason)
+
+	return &HookOutput{
+		Decision: "deny",
+		Reason:   reason,
+		HookOutput: map[string]interface{}{
+			"hookEventName":            "PreToolUse",
+			"permissionDecision":       "deny",
+			"permissionDecisionReason": reason,
+		},
+		Context: &ContextInfo{
+			SessionID:    sessionID,
+			SessionState: "running",
+		},
+		Notifications: []HookNotification{
+			{
+				Level:   "error",
+				Title:   "예산 초과",
+				Message: reason,
+				Action:  "settings.budget",
+			},
+		},
+	}
+}
+
+// preToolUseBudgetBlock checks the budget of the session issuing a tool call.
+// block_at_limit이 꺼져 있으면 DB를 열지 않습니다.
+func preToolUseBudgetBlock(input *HookInput) *HookOutput {
+	cwd := input.Cwd
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+	projectRoot := context.FindProjectRoot(cwd)
+	if !loadBudget(projectRoot).BlockAtLimit {
+		return nil
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return nil
+	}
+	defer database.Close()
+
+	sessionSvc := session.NewService(database)
+	claudeSessionID := input.SessionID
+	if claudeSessionID == "" {
+		claudeSessionID = os.Getenv("CLAUDE_SESSION_ID")
+	}
+	palSession, err := sessionSvc.FindActiveSession(claudeSessionID, cwd, projectRoot)
+	if err != nil || palSession == nil {
+		return nil
+	}
+	return budgetBlockOutput(sessionSvc, projectRoot, palSession.ID)
+}
@@ -0,0 +1,453 @@
+package cli
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hookDryRun     bool
+	hookReplayHook string
+)
+
+var hookReplayCmd = &cobra.Command{
+	Use:   "replay <event.json>",
+	Short: "저장된 Hook 입력 재실행",
+	Long: `저장된 Hook 입력(JSON)으로 Hook을 다시 실행합니다.
+
+hook_event_name으로 실행할 Hook을 결정하며, --hook으로 직접 지정할 수 있습니다.
+--dry-run과 함께 사용하면 DB/파일을 변경하지 않고 발생할 변경 사항만 보여줍니다.
+
+예시:
+  pal hook replay event.json --dry-run
+  pal hook replay event.json --hook pre-tool-use`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHookReplay,
+}
+
+// hookEventCommands maps Claude Code hook event names to hook subcommands
+var hookEventCommands = map[string]string{
+	"SessionStart":     "session-start",
+	"SessionEnd":       "session-end",
+	"PreToolUse":       "pre-tool-use",
+	"PostToolUse":      "post-tool-use",
+	"PreCompact":       "pre-compact",
+	"Notification":     "notification",
+	"UserPromptSubmit": "user-prompt",
+	"Stop":             "stop",
+	"SubagentStart":    "subagent-start",
+	"SubagentStop":     "subagent-stop",
+}
+
+func init() {
+	hookCmd.PersistentFlags().BoolVar(&hookDryRun, "dry-run", false, "DB/파일을 변경하지 않고 변경 예정 사항만 출력")
+	hookReplayCmd.Flags().StringVar(&hookReplayHook, "hook", "", "실행할 Hook (기본: hook_event_name에서 결정)")
+	hookCmd.AddCommand(hookReplayCmd)
+
+	// 모든 Hook 서브커맨드에 --dry-run 적용
+	for _, sub := range hookCmd.Commands() {
+		if sub == hookReplayCmd || sub.RunE == nil {
+			continue
+		}
+		sub.RunE = withHookDryRun(sub.RunE)
+	}
+}
+
+func runHookReplay(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("이벤트 파일 읽기 실패: %w", err)
+	}
+
+	name := hookReplayHook
+	if name == "" {
+		var input HookInput
+		if err := json.Unmarshal(data, &input); err != nil {
+			return fmt.Errorf("이벤트 파싱 실패: %w", err)
+		}
+		name = hookEventCommands[input.HookEventName]
+		if name == "" {
+			return fmt.Errorf("Hook을 결정할 수 없습니다 (hook_event_name: %q). --hook으로 지정하세요", input.HookEventName)
+		}
+	}
+
+	var target *cobra.Command
+	for _, sub := range hookCmd.Commands() {
+		if sub.Name() == name && sub != cmd {
+			target = sub
+			break
+		}
+	}
+	if target == nil || target.RunE == nil {
+		return fmt.Errorf("알 수 없는 Hook: %s", name)
+	}
+
+	restore, err := replaceStdin(data)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "🔁 Replay: %s → pal hook %s\n", args[0], name)
+	}
+	return target.RunE(target, nil)
+}
+
+// withHookDryRun runs a hook against a DB copy and reports what it would change.
+// 프로젝트 파일(.pal, .claude, CLAUDE.md)은 실행 후 원래 상태로 복원됩니다.
+func withHookDryRun(run func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if !hookDryRun {
+			return run(cmd, args)
+		}
+
+		// stdin을 먼저 읽어 프로젝트 루트를 결정하고, Hook에는 다시 전달
+		var data []byte
+		if stat, _ := os.Stdin.Stat(); stat != nil && (stat.Mode()&os.ModeCharDevice) == 0 {
+			data, _ = io.ReadAll(os.Stdin)
+		}
+		restoreStdin, err := replaceStdin(data)
+		if err != nil {
+			return err
+		}
+		defer restoreStdin()
+
+		cwd, _ := os.Getwd()
+		var input HookInput
+		if json.Unmarshal(data, &input) == nil && input.Cwd != "" {
+			cwd = input.Cwd
+		}
+		projectRoot := context.FindProjectRoot(cwd)
+
+		// DB 복사본으로 실행
+		tmpDir, err := os.MkdirTemp("", "pal-dry-run-*")
+		if err != nil {
+			return fmt.Errorf("임시 디렉토리 생성 실패: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+
+		copyPath := filepath.Join(tmpDir, "pal.db")
+		if err := copyDatabase(GetDBPath(), copyPath); err != nil {
+			return err
+		}
+		origDBPath := dbPath
+		dbPath = copyPath
+		defer func() { dbPath = origDBPath }()
+
+		before, err := snapshotTables(copyPath)
+		if err != nil {
+			return err
+		}
+		files := snapshotProjectFiles(projectRoot)
+
+		// stdout 캡처 (Claude에게 주입될 내용)
+		captured, err := os.CreateTemp(tmpDir, "stdout-*")
+		if err != nil {
+			return fmt.Errorf("임시 파일 생성 실패: %w", err)
+		}
+		origStdout := os.Stdout
+		os.Stdout = captured
+		runErr := run(cmd, args)
+		os.Stdout = origStdout
+		captured.Close()
+		injected, _ := os.ReadFile(captured.Name())
+
+		after, err := snapshotTables(copyPath)
+		if err != nil {
+			return err
+		}
+		fileChanges := restoreProjectFiles(projectRoot, files)
+
+		printDryRunReport(cmd.Name(), injected, diffTables(before, after), fileChanges, runErr)
+		return nil
+	}
+}
+
+// replaceStdin swaps os.Stdin with a file holding data
+func replaceStdin(data []byte) (func(), error) {
+	f, err := os.CreateTemp("", "pal-hook-input-*")
+	if err != nil {
+		return nil, fmt.Errorf("임시 파일 생성 실패: %w", err)
+	}
+	f.Write(data)
+	f.Seek(0, io.SeekStart)
+
+	orig := os.Stdin
+	os.Stdin = f
+	return func() {
+		os.Stdin = orig
+		f.Close()
+		os.Remove(f.Name())
+	}, nil
+}
+
+// copyDatabase copies the DB with VACUUM INTO (WAL 내용 포함)
+func copyDatabase(src, dst string) error {
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	source, err := sql.Open("sqlite3", src+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("DB 열기 실패: %w", err)
+	}
+	defer source.Close()
+
+	if _, err := source.Exec(`VACUUM INTO ?`, dst); err != nil {
+		return fmt.Errorf("DB 복사 실패: %w", err)
+	}
+	return nil
+}
+
+// tableSnapshot maps table → rowid → row text
+type tableSnapshot map[string]map[int64]string
+
+func snapshotTables(path string) (tableSnapshot, error) {
+	database, err := db.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer database.Close()
+
+	// metadata는 DB를 열 때마다 갱신되므로 제외
+	rows, err := database.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'metadata'`)
+	if err != nil {
+		return nil, fmt.Errorf("테이블 목록 조회 실패: %w", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if rows.Scan(&name) == nil {
+			tables = append(tables, name)
+		}
+	}
+	rows.Close()
+
+	snap := make(tableSnapshot)
+	for _, table := range tables {
+		tableRows, err := database.Query(fmt.Sprintf(`SELECT rowid, * FROM "%s"`, table))
+		if err != nil {
+			continue
+		}
+		cols, _ := tableRows.Columns()
+		snap[table] = make(map[int64]string)
+		for tableRows.Next() {
+			values := make([]interface{}, len(cols))
+			ptrs := make([]interface{}, len(cols))
+			for i := range values {
+				ptrs[i] = &values[i]
+			}
+			if tableRows.Scan(ptrs...) != nil {
+				continue
+			}
+			rowid, _ := values[0].(int64)
+			var parts []string
+			for i := 1; i < len(cols); i++ {
+				if values[i] == nil {
+					continue
+				}
+				v := values[i]
+				if b, ok := v.([]byte); ok {
+					v = string(b)
+				}
+				parts = append(parts, fmt.Sprintf("%s=%v", cols[i], v))
+			}
+			snap[table][rowid] = strings.Join(parts, " ")
+		}
+		tableRows.Close()
+	}
+	return snap, nil
+}
+
+// dryRunChange is a DB row or project file change found during a dry-run
+type dryRunChange struct {
+	Target string
+	Op     string // insert, update, delete, create
+	Detail string
+}
+
+func diffTables(before, after tableSnapshot) []dryRunChange {
+	var changes []dryRunChange
+	tables := make([]string, 0, len(after))
+	for t := range after {
+		tables = append(tables, t)
+	}
+	sort.Strings(tables)
+
+	// INSERT OR REPLACE로 내용이 같은 행이 재삽입된 경우는 변경으로 보지 않음
+	inserted := make(map[string]map[string]bool)
+	deleted := make(map[string]map[string]bool)
+	for _, t := range tables {
+		inserted[t] = make(map[string]bool)
+		deleted[t] = make(map[string]bool)
+		for id, row := range after[t] {
+			if _, ok := before[t][id]; !ok {
+				inserted[t][row] = true
+			}
+		}
+		for id, row := range before[t] {
+			if _, ok := after[t][id]; !ok {
+				deleted[t][row] = true
+			}
+		}
+	}
+
+	for _, t := range tables {
+		var ids []int64
+		for id := range after[t] {
+			ids = append(ids, id)
+		}
+		for id := range before[t] {
+			if _, ok := after[t][id]; !ok {
+				ids = append(ids, id)
+			}
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		for _, id := range ids {
+			old, existed := before[t][id]
+			cur, exists := after[t][id]
+			switch {
+			case !existed:
+				if !deleted[t][cur] {
+					changes = append(changes, dryRunChange{t, "insert", cur})
+				}
+			case !exists:
+				if !inserted[t][old] {
+					changes = append(changes, dryRunChange{t, "delete", old})
+				}
+			case old != cur:
+				changes = append(changes, dryRunChange{t, "update", cur})
+			}
+		}
+	}
+	return changes
+}
+
+// projectSnapshot holds project files (and existing dirs) a hook may write
+type projectSnapshot struct {
+	files map[string][]byte
+	dirs  map[string]bool
+}
+
+// snapshotProjectFiles reads files a hook may write under the project
+func snapshotProjectFiles(projectRoot string) projectSnapshot {
+	snap := projectSnapshot{files: make(map[string][]byte), dirs: make(map[string]bool)}
+	if projectRoot == "" {
+		return snap
+	}
+	for _, path := range projectFileList(projectRoot) {
+		if data, err := os.ReadFile(path); err == nil {
+			snap.files[path] = data
+		}
+	}
+	for _, dir := range []string{".pal", ".claude"} {
+		filepath.Walk(filepath.Join(projectRoot, dir), func(path string, info os.FileInfo, err error) error {
+			if err == nil && info.IsDir() {
+				snap.dirs[path] = true
+			}
+			return nil
+		})
+	}
+	return snap
+}
+
+func projectFileList(projectRoot string) []string {
+	var paths []string
+	for _, dir := range []string{".pal", ".claude"} {
+		filepath.Walk(filepath.Join(projectRoot, dir), func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return nil
+			}
+			if strings.HasSuffix(path, ".db") || strings.Contains(path, ".db-") {
+				return nil
+			}
+			paths = append(paths, path)
+			return nil
+		})
+	}
+	if _, err := os.Stat(filepath.Join(projectRoot, "CLAUDE.md")); err == nil {
+		paths = append(paths, filepath.Join(projectRoot, "CLAUDE.md"))
+	}
+	return paths
+}
+
+// restoreProjectFiles restores project files to the snapshot and returns what the hook changed
+func restoreProjectFiles(projectRoot string, snap projectSnapshot) []dryRunChange {
+	var changes []dryRunChange
+	if projectRoot == "" {
+		return changes
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range projectFileList(projectRoot) {
+		seen[path] = true
+		rel := projectRelPath(path, projectRoot)
+		data, _ := os.ReadFile(path)
+		orig, existed := snap.files[path]
+		switch {
+		case !existed:
+			changes = append(changes, dryRunChange{"file", "create", rel})
+			os.Remove(path)
+			// Hook이 새로 만든 빈 디렉토리도 제거
+			for dir := filepath.Dir(path); dir != projectRoot && !snap.dirs[dir]; dir = filepath.Dir(dir) {
+				if os.Remove(dir) != nil {
+					break
+				}
+			}
+		case !bytes.Equal(orig, data):
+			changes = append(changes, dryRunChange{"file", "update", rel})
+			os.WriteFile(path, orig, 0644)
+		}
+	}
+	for path, orig := range snap.files {
+		if !seen[path] {
+			changes = append(changes, dryRunChange{"file", "delete", projectRelPath(path, projectRoot)})
+			os.MkdirAll(filepath.Dir(path), 0755)
+			os.WriteFile(path, orig, 0644)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Detail < changes[j].Detail })
+	return changes
+}
+
+func printDryRunReport(hookName string, injected []byte, dbChanges, fileChanges []dryRunChange, runErr error) {
+	fmt.Printf("🧪 Dry-run: pal hook %s\n", hookName)
+	if runErr != nil {
+		fmt.Printf("   ❌ Hook 오류: %v\n", runErr)
+	}
+
+	fmt.Println("\n── 컨텍스트 주입 (stdout) ──")
+	if out := strings.TrimSpace(string(injected)); out != "" {
+		fmt.Println(out)
+	} else {
+		fmt.Println("(없음)")
+	}
+
+	fmt.Printf("\n── DB 변경 (%d) ──\n", len(dbChanges))
+	for _, c := range dbChanges {
+		fmt.Printf("%-7s %-20s %s\n", c.Op, c.Target, truncate(c.Detail, 120))
+	}
+	if len(dbChanges) == 0 {
+		fmt.Println("(없음)")
+	}
+
+	fmt.Printf("\n── 파일 변경 (%d) ──\n", len(fileChanges))
+	for _, c := range fileChanges {
+		fmt.Printf("%-7s %s\n", c.Op, c.Detail)
+	}
+	if len(fileChanges) == 0 {
+		fmt.Println("(없음)")
+	}
+}
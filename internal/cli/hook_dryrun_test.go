@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"testing"
+)
+
+func TestDiffTables(t *testing.T) {
+	before := tableSnapshot{
+		"sessions": {1: "id=a status=running", 2: "id=b status=running"},
+		"locks":    {1: "resource=src/a.go"},
+		"settings": {7: "key=x value=1"},
+	}
+	after := tableSnapshot{
+		"sessions": {1: "id=a status=running", 2: "id=b status=complete", 3: "id=c status=running"},
+		"locks":    {},
+		"settings": {8: "key=x value=1"}, // INSERT OR REPLACE로 같은 내용 재삽입
+	}
+
+	changes := diffTables(before, after)
+	got := make(map[string]int)
+	for _, c := range changes {
+		got[c.Target+":"+c.Op]++
+	}
+
+	want := map[string]int{"sessions:update": 1, "sessions:insert": 1, "locks:delete": 1}
+	if len(got) != len(want) {
+		t.Fatalf("changes = %v, want %v", got, want)
+	}
+	for k, n := range want {
+		if got[k] != n {
+			t.Errorf("%s = %d, want %d", k, got[k], n)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/lock"
+	"github.com/n0roo/pal-kit/internal/notify"
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+// recordLockWait records the blocked session and escalates the contention
+// once it crosses the project's lock_escalation thresholds.
+func recordLockWait(database *db.DB, sessionSvc *session.Service, projectRoot, palSessionID string, conflict *lock.Lock) {
+	lockSvc := lock.NewService(database)
+	if err := lockSvc.RecordWait(conflict.Resource, palSessionID, conflict.SessionID); err != nil {
+		return
+	}
+
+	var settings config.LockEscalationSettings
+	var notifications config.NotificationConfig
+	if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+		settings = projectCfg.Settings.LockEscalation
+		notifications = projectCfg.Notifications
+	}
+	policy, ok := lock.PolicyFromSettings(settings)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	c, err := lockSvc.Contention(conflict.Resource)
+	if err != nil || c == nil || c.EscalationID != 0 || !policy.Exceeds(c, now) {
+		return
+	}
+	id, created, err := lockSvc.Escalate(c, now)
+	if err != nil {
+		newHookWarnings(projectRoot, sessionSvc, palSessionID).warn(config.WarningHookError, "⚠️  Lock 경합 에스컬레이션 실패: %v", err)
+		return
+	}
+	if !created {
+		return
+	}
+
+	sessionSvc.LogEvent(palSessionID, "lock_escalation", fmt.Sprintf(`{"resource":"%s","holder":"%s","waiters":%d,"escalation_id":%d}`,
+		escapeJSON(c.Resource), c.Holder, len(c.Waiters), id))
+
+	if len(notifications.Routes) == 0 {
+		return
+	}
+	errs := notify.NewRouter(notifications, projectRoot).Dispatch(notify.Notification{
+		Type:        notify.TypeLockContention,
+		Message:     fmt.Sprintf("#%d %s", id, c.Issue(now)),
+		SessionID:   palSessionID,
+		ProjectRoot: projectRoot,
+	})
+	if len(errs) > 0 {
+		warnings := newHookWarnings(projectRoot, sessionSvc, palSessionID)
+		for _, e := range errs {
+			warnings.warn(config.WarningHookError, "⚠️  알림 전달 실패: %v", e)
+		}
+	}
+}
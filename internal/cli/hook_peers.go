@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+// peersContextPath returns .pal/context/peers.md of the project
+func peersContextPath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".pal", "context", "peers.md")
+}
+
+// writePeersContext refreshes .pal/context/peers.md with the project's running sessions.
+// 모든 Claude 인스턴스가 같은 파일을 읽으므로 자기 자신을 포함한 전체 세션을 기록합니다.
+func writePeersContext(sessionSvc *session.Service, projectRoot string) error {
+	if projectRoot == "" {
+		return nil
+	}
+	peers, err := sessionSvc.Peers(projectRoot, "")
+	if err != nil {
+		return err
+	}
+
+	path := peersContextPath(projectRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("컨텍스트 디렉토리 생성 실패: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(session.PeersMarkdown(peers, time.Now())), 0644); err != nil {
+		return fmt.Errorf("peers.md 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// peersBanner builds the multi-session warning listing what each sibling session holds
+func peersBanner(peers []session.Peer, selfID, cwd, projectRoot string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("⚠️  [PAL Kit] 이 프로젝트에 %d개의 다른 세션이 실행 중입니다.\n", len(peers)))
+	for _, p := range peers {
+		sb.WriteString(p.Banner(projectRoot) + "\n")
+	}
+	sb.WriteString(fmt.Sprintf("   현재 세션: %s (cwd: %s)\n", selfID, cwd))
+	sb.WriteString("   다른 세션의 포트/Lock 대상은 피하세요. 최신 현황: .pal/context/peers.md")
+	return sb.String()
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// safeModeEnv enables safe mode without touching the hook commands in .claude/settings.json
+const safeModeEnv = "PAL_SAFE_MODE"
+
+var hookSafe bool
+
+func init() {
+	hookCmd.PersistentFlags().BoolVar(&hookSafe, "safe", false, "이벤트만 기록하고 CLAUDE.md/rules/Lock은 변경하지 않음 (PAL_SAFE_MODE=1과 동일)")
+}
+
+// hookSafeMode reports whether hooks must leave the Claude context untouched.
+// Safe mode에서는 세션/이벤트 기록만 수행하고 CLAUDE.md, .claude/rules, .pal/context 파일 쓰기와
+// Lock 획득/해제/차단을 모두 건너뜁니다. PAL이 컨텍스트를 오염시키는지 디버깅할 때 사용합니다.
+func hookSafeMode() bool {
+	if hookSafe {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(safeModeEnv))) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+// skipInSafeMode returns true when safe mode blocks the given context mutation
+func skipInSafeMode(what string) bool {
+	if !hookSafeMode() {
+		return false
+	}
+	if verbose {
+		fmt.Fprintf(os.Stderr, "🛡️  [PAL Kit] safe mode: %s 건너뜀\n", what)
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+package cli
+
+import "testing"
+
+func TestHookSafeMode(t *testing.T) {
+	cases := []struct {
+		flag bool
+		env  string
+		want bool
+	}{
+		{false, "", false},
+		{false, "0", false},
+		{false, "1", true},
+		{false, "TRUE", true},
+		{false, " on ", true},
+		{true, "", true},
+	}
+
+	for _, c := range cases {
+		hookSafe = c.flag
+		t.Setenv(safeModeEnv, c.env)
+		if got := hookSafeMode(); got != c.want {
+			t.Errorf("hookSafeMode(flag=%v, env=%q) = %v, want %v", c.flag, c.env, got, c.want)
+		}
+	}
+	hookSafe = false
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/lock"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+// runHookWithInput runs a hook handler with the given JSON on stdin and returns its stdout
+func runHookWithInput(t *testing.T, run func() error, input map[string]interface{}) string {
+	t.Helper()
+	data, _ := json.Marshal(input)
+	inPath := filepath.Join(t.TempDir(), "input.json")
+	if err := os.WriteFile(inPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	in, err := os.Open(inPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldIn, oldOut := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = in, w
+	runErr := run()
+	os.Stdin, os.Stdout = oldIn, oldOut
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	return string(out)
+}
+
+func TestPreToolUseDeniesLockedWrites(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, ".claude"), 0755)
+
+	oldDB := dbPath
+	defer func() { dbPath = oldDB }()
+	dbPath = filepath.Join(t.TempDir(), "pal.db")
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lock.NewProjectService(database, root).Acquire("src/cart.go", "other-session"); err != nil {
+		t.Fatal(err)
+	}
+	database.Close()
+
+	locked := filepath.Join(root, "src", "cart.go")
+	cases := map[string]map[string]interface{}{
+		"MultiEdit":    {"file_path": locked, "edits": []interface{}{map[string]interface{}{"old_string": "a", "new_string": "b"}}},
+		"NotebookEdit": {"notebook_path": locked, "new_source": "x"},
+		"Write":        {"file_path": locked, "content": "package cart\n"},
+	}
+	for tool, toolInput := range cases {
+		out := runHookWithInput(t, func() error { return runHookPreToolUse(hookPreToolUseCmd, nil) }, map[string]interface{}{
+			"session_id": "claude-me",
+			"cwd":        root,
+			"tool_name":  tool,
+			"tool_input": toolInput,
+		})
+		if !strings.Contains(out, `"permissionDecision":"deny"`) {
+			t.Errorf("%s on a locked file was not denied: %q", tool, out)
+		}
+	}
+}
+
+func TestPostToolUseRecordsOnSessionPort(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, ".claude"), 0755)
+
+	oldDB := dbPath
+	defer func() { dbPath = oldDB }()
+	dbPath = filepath.Join(t.TempDir(), "pal.db")
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	// 병렬 세션 두 개, 각자 running 포트 (idle 세션은 포트 없음)
+	sessionSvc := session.NewService(database)
+	portSvc := port.NewService(database)
+	for _, id := range []string{"alpha", "beta", "idle"} {
+		if err := sessionSvc.StartWithFullOptions(session.StartOptions{ID: "pal-" + id, ClaudeSessionID: "claude-" + id, ProjectRoot: root, Cwd: root}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []string{"alpha", "beta"} {
+		portSvc.Create("port-"+id, id, "")
+		if err := portSvc.RecordStart("port-"+id, "pal-"+id, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, id := range []string{"beta", "alpha", "idle"} {
+		runHookWithInput(t, func() error { return runHookPostToolUse(hookPostToolUseCmd, nil) }, map[string]interface{}{
+			"session_id": "claude-" + id,
+			"cwd":        root,
+			"tool_name":  "Edit",
+			"tool_input": map[string]interface{}{"file_path": filepath.Join(root, id+".go"), "old_string": "a", "new_string": "b"},
+		})
+	}
+
+	for _, id := range []string{"alpha", "beta"} {
+		changes, err := portSvc.GetFileChanges("port-" + id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changes) != 1 || changes[0].FilePath != id+".go" {
+			t.Errorf("port-%s changes = %+v", id, changes)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/inbox"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inboxKind     string
+	inboxSession  string
+	inboxPort     string
+	inboxLimit    int
+	inboxWatch    bool
+	inboxInterval time.Duration
+	inboxAll      bool
+)
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "처리 대기 항목 통합 조회",
+	Long: `실행 중인 세션의 처리 대기 항목을 한 곳에서 조회합니다.
+
+포함 항목:
+  - escalation: open 상태 에스컬레이션
+  - message:    읽지 않은 고우선순위 메시지 (priority <= 2)
+  - review:     대기 중인 리뷰 요청
+
+예시:
+  pal inbox
+  pal inbox --kind review --port port-001
+  pal inbox --watch
+  pal inbox resolve esc:3 msg:abc123
+  pal inbox ack --all --kind message`,
+	RunE: runInbox,
+}
+
+var inboxAckCmd = &cobra.Command{
+	Use:   "ack [key...]",
+	Short: "메시지 읽음 처리",
+	RunE:  runInboxAck,
+}
+
+var inboxResolveCmd = &cobra.Command{
+	Use:   "resolve [key...]",
+	Short: "항목 일괄 해결",
+	RunE:  runInboxResolve,
+}
+
+func init() {
+	rootCmd.AddCommand(inboxCmd)
+	inboxCmd.AddCommand(inboxAckCmd)
+	inboxCmd.AddCommand(inboxResolveCmd)
+
+	inboxCmd.PersistentFlags().StringVar(&inboxKind, "kind", "", "항목 종류 필터 (escalation|message|review)")
+	inboxCmd.PersistentFlags().StringVar(&inboxSession, "session", "", "세션 필터")
+	inboxCmd.PersistentFlags().StringVar(&inboxPort, "port", "", "포트 필터")
+	inboxCmd.Flags().IntVar(&inboxLimit, "limit", 50, "결과 수 제한")
+	inboxCmd.Flags().BoolVar(&inboxWatch, "watch", false, "주기적으로 갱신")
+	inboxCmd.Flags().DurationVar(&inboxInterval, "interval", 5*time.Second, "watch 갱신 주기")
+
+	inboxAckCmd.Flags().BoolVar(&inboxAll, "all", false, "필터에 해당하는 모든 항목")
+	inboxResolveCmd.Flags().BoolVar(&inboxAll, "all", false, "필터에 해당하는 모든 항목")
+}
+
+func getInboxService() (*inbox.Service, func(), error) {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return nil, nil, err
+	}
+	return inbox.NewService(database), func() { database.Close() }, nil
+}
+
+func inboxFilter() (inbox.Filter, error) {
+	kind := inbox.Kind(inboxKind)
+	switch kind {
+	case "", inbox.KindEscalation, inbox.KindMessage, inbox.KindReview:
+	default:
+		return inbox.Filter{}, fmt.Errorf("지원하지 않는 종류: %s (escalation|message|review)", inboxKind)
+	}
+
+	return inbox.Filter{
+		Kind:      kind,
+		SessionID: inboxSession,
+		PortID:    inboxPort,
+		Limit:     inboxLimit,
+	}, nil
+}
+
+func runInbox(cmd *cobra.Command, args []string) error {
+	filter, err := inboxFilter()
+	if err != nil {
+		return err
+	}
+
+	svc, cleanup, err := getInboxService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if !inboxWatch {
+		return printInbox(svc, filter)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	ticker := time.NewTicker(inboxInterval)
+	defer ticker.Stop()
+
+	for {
+		if !jsonOut {
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("📥 Inbox (갱신: %s, Ctrl+C 종료)\n\n", time.Now().Format("15:04:05"))
+		}
+		if err := printInbox(svc, filter); err != nil {
+			return err
+		}
+
+		select {
+		case <-sigCh:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func printInbox(svc *inbox.Service, filter inbox.Filter) error {
+	items, err := svc.List(filter)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"items": items,
+			"count": len(items),
+		})
+		return nil
+	}
+
+	if len(items) == 0 {
+		fmt.Println("처리 대기 항목이 없습니다.")
+		return nil
+	}
+
+	kindIcon := map[inbox.Kind]string{
+		inbox.KindEscalation: "🚨",
+		inbox.KindMessage:    "✉️ ",
+		inbox.KindReview:     "🔍",
+	}
+
+	fmt.Printf("%-13s %-10s %-12s %-40s %s\n", "KIND", "SESSION", "PORT", "SUMMARY", "KEY")
+	fmt.Println(strings.Repeat("-", 100))
+	counts := make(map[inbox.Kind]int)
+	for _, item := range items {
+		counts[item.Kind]++
+
+		session := item.SessionID
+		if session == "" {
+			session = "-"
+		}
+		portID := item.PortID
+		if portID == "" {
+			portID = "-"
+		}
+		summary := item.Summary
+		if item.Severity != "" {
+			summary = fmt.Sprintf("[%s] %s", item.Severity, summary)
+		}
+		if r := []rune(summary); len(r) > 40 {
+			summary = string(r[:37]) + "..."
+		}
+
+		fmt.Printf("%s %-10s %-10s %-12s %-40s %s\n",
+			kindIcon[item.Kind], item.Kind, session, portID, summary, item.Key)
+	}
+
+	fmt.Println()
+	fmt.Printf("🚨 %d  ✉️  %d  🔍 %d\n",
+		counts[inbox.KindEscalation], counts[inbox.KindMessage], counts[inbox.KindReview])
+
+	return nil
+}
+
+// inboxTargetKeys resolves target keys from args or --all with filters
+func inboxTargetKeys(svc *inbox.Service, args []string) ([]string, error) {
+	if inboxAll {
+		filter, err := inboxFilter()
+		if err != nil {
+			return nil, err
+		}
+		filter.Limit = 0
+		items, err := svc.List(filter)
+		if err != nil {
+			return nil, err
+		}
+		return inbox.Keys(items), nil
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("대상 키를 지정하거나 --all 플래그를 사용하세요")
+	}
+	return args, nil
+}
+
+func runInboxAck(cmd *cobra.Command, args []string) error {
+	svc, cleanup, err := getInboxService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	keys, err := inboxTargetKeys(svc, args)
+	if err != nil {
+		return err
+	}
+
+	result, err := svc.Ack(keys)
+	if err != nil {
+		return err
+	}
+
+	printInboxActionResult("읽음 처리", result)
+	return nil
+}
+
+func runInboxResolve(cmd *cobra.Command, args []string) error {
+	svc, cleanup, err := getInboxService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	keys, err := inboxTargetKeys(svc, args)
+	if err != nil {
+		return err
+	}
+
+	result, err := svc.Resolve(keys)
+	if err != nil {
+		return err
+	}
+
+	printInboxActionResult("해결", result)
+	return nil
+}
+
+func printInboxActionResult(action string, result *inbox.ActionResult) {
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(result)
+		return
+	}
+
+	fmt.Printf("✅ %s: %d건\n", action, len(result.Done))
+	for key, reason := range result.Skipped {
+		fmt.Printf("   ⚠️  %s: %s\n", key, reason)
+	}
+}
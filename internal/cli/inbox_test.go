@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/inbox"
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+func TestPrintInboxTruncatesOnRunes(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "pal.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	if err := session.NewService(database).Start("worker-1", "", "worker"); err != nil {
+		t.Fatal(err)
+	}
+	issue := strings.Repeat("빌드 실패 - 결제 모듈 ", 10)
+	if _, err := escalation.NewService(database).Create(issue, "worker-1", "port-001"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() error {
+		return printInbox(inbox.NewService(database), inbox.Filter{})
+	})
+	if !utf8.ValidString(out) {
+		t.Errorf("output is not valid UTF-8:\n%q", out)
+	}
+	if !strings.Contains(out, "...") || strings.Contains(out, issue) {
+		t.Errorf("summary was not truncated:\n%s", out)
+	}
+}
@@ -250,6 +250,17 @@ func createSettingsJSON(path string) error {
 					},
 				},
 			},
+			"UserPromptSubmit": []map[string]interface{}{
+				{
+					"matcher": "",
+					"hooks": []map[string]interface{}{
+						{
+							"type":    "command",
+							"command": "pal hook user-prompt",
+						},
+					},
+				},
+			},
 			"Stop": []map[string]interface{}{
 				{
 					"matcher": "",
@@ -261,6 +272,28 @@ func createSettingsJSON(path string) error {
 					},
 				},
 			},
+			"SubagentStart": []map[string]interface{}{
+				{
+					"matcher": "",
+					"hooks": []map[string]interface{}{
+						{
+							"type":    "command",
+							"command": "pal hook subagent-start",
+						},
+					},
+				},
+			},
+			"SubagentStop": []map[string]interface{}{
+				{
+					"matcher": "",
+					"hooks": []map[string]interface{}{
+						{
+							"type":    "command",
+							"command": "pal hook subagent-stop",
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -280,6 +313,7 @@ func updateGitignore(projectRoot string) error {
 # PAL Kit (project-level)
 .claude/state/
 .claude/rules/*.md
+.pal/audit/
 `
 
 	// 파일이 존재하면 추가, 없으면 새로 생성
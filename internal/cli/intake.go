@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/intake"
+	"github.com/spf13/cobra"
+)
+
+var (
+	intakeKind   string
+	intakeAs     string
+	intakePortID string
+	intakeDryRun bool
+)
+
+var intakeCmd = &cobra.Command{
+	Use:   "intake [text]",
+	Short: "버그 리포트/요청 원문으로 포트 초안 또는 에스컬레이션 생성",
+	Long: `Slack/이메일 등에서 붙여넣은 원문을 bug/feature/question으로 분류하고,
+bug/feature는 포트 초안(pending + 명세 문서), question은 에스컬레이션으로 등록합니다.
+
+제목, 재현 절차, 기대/실제 동작, 환경, 요청자를 추출하며,
+원문은 .pal/intake/에 저장되어 포트 문서와 에스컬레이션에서 참조됩니다.
+인자가 없거나 "-"이면 표준 입력에서 읽습니다.
+
+예시:
+  pal intake "로그인 시 500 에러가 발생합니다. 재현: 1. ..."
+  pbpaste | pal intake
+  pal intake --kind question --dry-run < mail.txt
+  pal intake --as escalation "결제 장애 ..."`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runIntake,
+}
+
+func init() {
+	rootCmd.AddCommand(intakeCmd)
+	intakeCmd.Flags().StringVar(&intakeKind, "kind", "", "분류 지정 (bug, feature, question; 기본: 자동 분류)")
+	intakeCmd.Flags().StringVar(&intakeAs, "as", "", "등록 대상 지정 (port, escalation; 기본: 분류에 따라)")
+	intakeCmd.Flags().StringVar(&intakePortID, "port", "", "생성할 포트 ID (기본: 제목에서 생성)")
+	intakeCmd.Flags().BoolVar(&intakeDryRun, "dry-run", false, "등록하지 않고 분류 결과만 표시")
+}
+
+func runIntake(cmd *cobra.Command, args []string) error {
+	var text string
+	if len(args) == 1 && args[0] != "-" {
+		text = args[0]
+	} else {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("표준 입력 읽기 실패: %w", err)
+		}
+		text = string(data)
+	}
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("intake 내용이 비어 있습니다")
+	}
+
+	opts := intake.Options{Target: intakeAs, PortID: intakePortID, SessionID: os.Getenv("CLAUDE_SESSION_ID")}
+	if intakeKind != "" {
+		kind, err := intake.ParseKind(intakeKind)
+		if err != nil {
+			return err
+		}
+		opts.Kind = kind
+	}
+
+	if intakeDryRun {
+		c := intake.Classify(text)
+		if opts.Kind != "" {
+			c.Kind = opts.Kind
+		}
+		if IsJSON() {
+			data, _ := json.MarshalIndent(c, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		printIntakeClassification(c)
+		return nil
+	}
+
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	if projectRoot == "" {
+		return fmt.Errorf("프로젝트 루트를 찾을 수 없습니다")
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	res, err := intake.NewService(database, projectRoot).Submit(text, opts)
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(res, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printIntakeClassification(res.Classification)
+	fmt.Println()
+	switch res.Target {
+	case intake.TargetPort:
+		fmt.Printf("✓ 포트 초안 생성: %s\n", res.PortID)
+		fmt.Printf("  문서: %s\n", res.DocPath)
+	case intake.TargetEscalation:
+		fmt.Printf("✓ 에스컬레이션 생성: #%d\n", res.EscalationID)
+	}
+	fmt.Printf("  원문: %s\n", res.ArtifactPath)
+	return nil
+}
+
+func printIntakeClassification(c intake.Classification) {
+	fmt.Printf("📥 분류: %s (신뢰도 %.0f%%), 심각도: %s\n", c.Kind, c.Confidence*100, c.Severity)
+	fmt.Printf("   제목: %s\n", c.Title)
+	for i, step := range c.Steps {
+		fmt.Printf("   %d. %s\n", i+1, step)
+	}
+	for _, name := range []string{"expected", "actual", "environment", "reporter"} {
+		if v := c.Fields[name]; v != "" {
+			fmt.Printf("   %s: %s\n", name, v)
+		}
+	}
+}
@@ -1,12 +1,15 @@
 package cli
 
 import (
+	stdctx "context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/kb"
@@ -104,11 +107,54 @@ var kbSearchCmd = &cobra.Command{
   --status    상태 (draft, active, archived)
   --tag       태그 (복수 지정 가능)
   --limit     결과 수 제한
-  --budget    토큰 예산`,
+  --budget    토큰 예산
+  --content   본문까지 검색 (일치 부분 표시)
+  --semantic  의미 검색 ('pal kb embed'로 만든 벡터 유사도와 키워드 점수를 섞음)
+
+결과는 BM25 관련도 순으로 정렬됩니다.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runKBSearch,
 }
 
+var kbEmbedCmd = &cobra.Command{
+	Use:   "embed [vault-path]",
+	Short: "의미 검색용 임베딩 생성",
+	Long: `색인된 문서의 임베딩 벡터를 계산해 색인 DB에 저장합니다.
+내용이 바뀐 문서만 다시 계산하며, 사용한 provider는 기록되어 'pal kb search --semantic'이 재사용합니다.
+
+Provider:
+  openai   OpenAI embeddings API (OPENAI_API_KEY, 기본 모델 text-embedding-3-small)
+  ollama   Ollama 서버 /api/embed (기본 http://localhost:11434, nomic-embed-text)
+  local    로컬 ONNX 모델을 실행하는 명령 (--command, --model에 모델 경로)
+           stdin {"model","texts"} → stdout {"embeddings"} JSON
+  hash     모델 없이 단어/글자 해시 벡터 (오프라인 테스트용)
+
+예시:
+  pal kb embed --provider ollama
+  pal kb embed --provider local --model ./models/e5-small.onnx --command "python3 embed.py"
+  pal kb search "세션 만료" --semantic`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runKBEmbed,
+}
+
+var kbWatchCmd = &cobra.Command{
+	Use:   "watch [vault-path]",
+	Short: "문서 변경 감시 (자동 색인/TOC/링크 검사)",
+	Long: `vault의 문서 변경을 감시하며 바뀐 문서만 재색인하고, 해당 섹션의 TOC를 갱신하고,
+바뀐 문서(와 삭제된 문서를 가리키던 문서)의 링크를 다시 검사합니다.
+index / toc update / link check를 손으로 반복하지 않아도 됩니다. Ctrl+C로 종료합니다.
+파일 시스템 알림으로 변경을 감지하며, 알림을 쓸 수 없으면 --interval 주기로 검사합니다.
+
+TOC는 이미 _toc.md가 있는 섹션만 갱신합니다.
+대시보드에 실시간으로 반영하려면 'pal serve --kb-watch'를 사용하세요 (SSE kb:updated 이벤트).
+
+예시:
+  pal kb watch
+  pal kb watch ~/mcp-docs --interval 3s --no-toc`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runKBWatch,
+}
+
 var kbStatsCmd = &cobra.Command{
 	Use:   "stats [vault-path]",
 	Short: "색인 통계",
@@ -126,17 +172,41 @@ var kbLinkCmd = &cobra.Command{
 var kbLinkCheckCmd = &cobra.Command{
 	Use:   "check [vault-path]",
 	Short: "깨진 링크 검사",
-	Long:  `모든 문서의 [[wikilink]]를 검사하여 깨진 링크를 찾습니다.`,
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  runKBLinkCheck,
+	Long: `모든 문서의 [[wikilink]]를 검사하여 깨진 링크를 찾습니다.
+
+--fix는 신뢰도가 높은 제안(대소문자/공백/구분자만 다른 유일한 문서)만 적용하고,
+나머지는 추천만 표시합니다.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runKBLinkCheck,
+}
+
+var kbMvCmd = &cobra.Command{
+	Use:   "mv <from> <to> [vault-path]",
+	Short: "문서 이동/이름 변경",
+	Long: `문서를 옮기거나 이름을 바꾸고, 이 문서를 가리키던 [[wikilink]]를 모두 고쳐 씁니다.
+색인이 있으면 문서 경로도 함께 바꿉니다. 경로는 vault 상대 경로이며 .md는 생략할 수 있습니다.
+
+예시:
+  pal kb mv 10-Domains/cache 10-Domains/infra/redis-cache
+  pal kb mv 20-Projects/old.md 40-Archive/old.md --dry-run`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runKBMv,
 }
 
 var kbLinkGraphCmd = &cobra.Command{
 	Use:   "graph [vault-path]",
 	Short: "링크 그래프 생성",
-	Long:  `문서 간 링크 그래프를 생성하여 .pal-kb/link-graph.json에 저장합니다.`,
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  runKBLinkGraph,
+	Long: `문서 간 링크 그래프를 생성하여 .pal-kb/link-graph.json에 저장합니다.
+
+--format을 지정하면 저장 대신 해당 형식으로 출력합니다 (json, graphml, dot, mermaid).
+--domain, --tag, --min-degree로 부분 그래프만 고를 수 있습니다.
+
+예시:
+  pal kb link graph --format dot | dot -Tsvg -o kb.svg
+  pal kb link graph --format mermaid --domain auth
+  pal kb link graph --format graphml --min-degree 3 -o kb.graphml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runKBLinkGraph,
 }
 
 var kbTagCmd = &cobra.Command{
@@ -172,9 +242,15 @@ var kbSyncCmd = &cobra.Command{
   - .pal/sessions/   → 20-Projects/{project}/sessions/
   - docs/            → 20-Projects/{project}/docs/
 
+기본은 프로젝트 → vault 단방향입니다. 디렉토리별로 방향을 바꾸면 Obsidian에서 고친 문서를
+프로젝트로 되돌려 보냅니다. 마지막 동기화 내용과 양쪽을 비교(3-way)해 양쪽이 모두 바뀐 파일은
+충돌로 보고하고 .pal-kb/sync-conflicts/{project}.md에 기록합니다.
+
 옵션:
-  --dry-run   실제 동기화 없이 변경 내용만 표시
-  --force     충돌 무시하고 강제 동기화`,
+  --dry-run         실제 동기화 없이 변경 내용만 표시
+  --force           충돌 무시하고 강제 동기화 (방향의 원본이 이김, both면 프로젝트)
+  --direction       이번 실행의 방향 (to-vault, from-vault, both)
+  --set-direction   디렉토리별 방향 저장 (예: docs=both, 복수 지정 가능)`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runKBSync,
 }
@@ -223,6 +299,8 @@ var kbLintCmd = &cobra.Command{
 
 var kbSyncDryRun bool
 var kbSyncForce bool
+var kbSyncDirection string
+var kbSyncSetDirection []string
 var lintStrict bool
 var lintCheckLinks bool
 
@@ -233,6 +311,27 @@ var searchStatus string
 var searchTags []string
 var searchLimit int
 var searchBudget int
+var searchContent bool
+var searchSemantic bool
+var searchSemanticWeight float64
+
+var watchInterval time.Duration
+var watchNoTOC bool
+var watchNoLinks bool
+
+var embedProvider string
+var embedModel string
+var embedEndpoint string
+var embedCommand string
+var embedForce bool
+var linkFix bool
+var linkFixDryRun bool
+var mvDryRun bool
+var graphFormat string
+var graphOutput string
+var graphDomain string
+var graphTag string
+var graphMinDegree int
 
 func init() {
 	rootCmd.AddCommand(kbCmd)
@@ -241,8 +340,11 @@ func init() {
 	kbCmd.AddCommand(kbTocCmd)
 	kbCmd.AddCommand(kbIndexCmd)
 	kbCmd.AddCommand(kbSearchCmd)
+	kbCmd.AddCommand(kbEmbedCmd)
+	kbCmd.AddCommand(kbWatchCmd)
 	kbCmd.AddCommand(kbStatsCmd)
 	kbCmd.AddCommand(kbLinkCmd)
+	kbCmd.AddCommand(kbMvCmd)
 	kbCmd.AddCommand(kbTagCmd)
 	kbCmd.AddCommand(kbSyncCmd)
 	kbCmd.AddCommand(kbSyncStatusCmd)
@@ -271,9 +373,34 @@ func init() {
 	kbSearchCmd.Flags().StringSliceVar(&searchTags, "tag", nil, "태그 필터")
 	kbSearchCmd.Flags().IntVar(&searchLimit, "limit", 10, "결과 수 제한")
 	kbSearchCmd.Flags().IntVar(&searchBudget, "budget", 0, "토큰 예산")
+	kbSearchCmd.Flags().BoolVar(&searchContent, "content", false, "본문까지 검색")
+	kbSearchCmd.Flags().BoolVar(&searchSemantic, "semantic", false, "의미 검색 (벡터 유사도 + 키워드)")
+	kbSearchCmd.Flags().Float64Var(&searchSemanticWeight, "semantic-weight", 0, "의미 검색에서 벡터 유사도 비중 0~1 (기본 0.6)")
+
+	kbWatchCmd.Flags().DurationVar(&watchInterval, "interval", time.Second, "알림을 쓸 수 없을 때의 검사 주기")
+	kbWatchCmd.Flags().BoolVar(&watchNoTOC, "no-toc", false, "TOC 갱신 안 함")
+	kbWatchCmd.Flags().BoolVar(&watchNoLinks, "no-links", false, "링크 검사 안 함")
+
+	kbEmbedCmd.Flags().StringVar(&embedProvider, "provider", "", "임베딩 provider (openai, ollama, local, hash; 기본: 마지막 사용)")
+	kbEmbedCmd.Flags().StringVar(&embedModel, "model", "", "모델 이름 (local이면 ONNX 모델 경로)")
+	kbEmbedCmd.Flags().StringVar(&embedEndpoint, "endpoint", "", "API 주소 (~/.pal/kb-embed.yaml에 저장)")
+	kbEmbedCmd.Flags().StringVar(&embedCommand, "command", "", "local provider 실행 명령 (~/.pal/kb-embed.yaml에 저장)")
+	kbEmbedCmd.Flags().BoolVar(&embedForce, "force", false, "모든 문서 다시 계산")
+
+	kbLinkCheckCmd.Flags().BoolVar(&linkFix, "fix", false, "신뢰도 높은 제안 자동 적용")
+	kbLinkCheckCmd.Flags().BoolVar(&linkFixDryRun, "dry-run", false, "--fix 적용 없이 변경 내용만 표시")
+	kbMvCmd.Flags().BoolVar(&mvDryRun, "dry-run", false, "실제 이동 없이 바뀔 링크만 표시")
+
+	kbLinkGraphCmd.Flags().StringVar(&graphFormat, "format", "", "출력 형식 (json, graphml, dot, mermaid)")
+	kbLinkGraphCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "출력 파일 (기본: stdout)")
+	kbLinkGraphCmd.Flags().StringVar(&graphDomain, "domain", "", "도메인 필터")
+	kbLinkGraphCmd.Flags().StringVar(&graphTag, "tag", "", "태그 필터")
+	kbLinkGraphCmd.Flags().IntVar(&graphMinDegree, "min-degree", 0, "최소 연결 수 (in + out)")
 
 	kbSyncCmd.Flags().BoolVar(&kbSyncDryRun, "dry-run", false, "실제 동기화 없이 변경 내용만 표시")
 	kbSyncCmd.Flags().BoolVar(&kbSyncForce, "force", false, "충돌 무시하고 강제 동기화")
+	kbSyncCmd.Flags().StringVar(&kbSyncDirection, "direction", "", "동기화 방향 (to-vault, from-vault, both; 기본: 디렉토리별 설정)")
+	kbSyncCmd.Flags().StringSliceVar(&kbSyncSetDirection, "set-direction", nil, "디렉토리별 방향 저장 (예: docs=both)")
 
 	kbLintCmd.Flags().BoolVar(&lintStrict, "strict", false, "엄격 모드 (오류 시 실패)")
 	kbLintCmd.Flags().BoolVar(&lintCheckLinks, "check-links", true, "링크 유효성 검사")
@@ -628,11 +755,36 @@ func runKBSearch(cmd *cobra.Command, args []string) error {
 		Tags:        searchTags,
 		Limit:       searchLimit,
 		TokenBudget: searchBudget,
+		Content:     searchContent,
 	}
 
-	results, err := indexSvc.Search(query, opts)
-	if err != nil {
-		return fmt.Errorf("검색 실패: %w", err)
+	var results []*kb.SearchResult
+	if searchSemantic {
+		cfg, err := indexSvc.EmbedConfig()
+		if err != nil {
+			return err
+		}
+		if cfg == nil {
+			return fmt.Errorf("임베딩이 없습니다. 'pal kb embed --provider <provider>'를 먼저 실행하세요")
+		}
+		resolved, err := kb.ResolveEmbedConfig(*cfg)
+		if err != nil {
+			return err
+		}
+		embedder, err := kb.NewEmbedder(resolved)
+		if err != nil {
+			return err
+		}
+		opts.SemanticWeight = searchSemanticWeight
+		results, err = indexSvc.SemanticSearch(cmd.Context(), embedder, query, opts)
+		if err != nil {
+			return fmt.Errorf("검색 실패: %w", err)
+		}
+	} else {
+		results, err = indexSvc.Search(query, opts)
+		if err != nil {
+			return fmt.Errorf("검색 실패: %w", err)
+		}
 	}
 
 	if jsonOut {
@@ -661,6 +813,9 @@ func runKBSearch(cmd *cobra.Command, args []string) error {
 		if doc.Status != "" {
 			meta = append(meta, fmt.Sprintf("상태:%s", doc.Status))
 		}
+		if r.Similarity > 0 {
+			meta = append(meta, fmt.Sprintf("유사도:%.2f", r.Similarity))
+		}
 		if len(meta) > 0 {
 			fmt.Printf("   %s\n", strings.Join(meta, " | "))
 		}
@@ -668,6 +823,9 @@ func runKBSearch(cmd *cobra.Command, args []string) error {
 		if doc.Summary != "" {
 			fmt.Printf("   %s\n", doc.Summary)
 		}
+		if r.Snippet != "" {
+			fmt.Printf("   » %s\n", r.Snippet)
+		}
 
 		if len(doc.Tags) > 0 {
 			fmt.Printf("   🏷️  %s\n", strings.Join(doc.Tags, ", "))
@@ -679,6 +837,137 @@ func runKBSearch(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runKBWatch(cmd *cobra.Command, args []string) error {
+	vaultPath := getVaultPath(args)
+
+	// Check if initialized
+	svc := kb.NewService(vaultPath)
+	status, err := svc.Status()
+	if err != nil {
+		return err
+	}
+	if !status.Initialized {
+		return fmt.Errorf("KB가 초기화되지 않았습니다. 'pal kb init' 실행하세요")
+	}
+
+	ctx, stop := signal.NotifyContext(stdctx.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !jsonOut {
+		fmt.Printf("👁️  KB 감시 중: %s (Ctrl+C로 종료)\n", vaultPath)
+	}
+	watcher := kb.NewWatcher(vaultPath, kb.WatchOptions{Interval: watchInterval, NoTOC: watchNoTOC, NoLinks: watchNoLinks})
+	return watcher.Run(ctx, func(event *kb.WatchEvent) {
+		if jsonOut {
+			json.NewEncoder(os.Stdout).Encode(event)
+			return
+		}
+		printWatchEvent(event)
+	})
+}
+
+func printWatchEvent(event *kb.WatchEvent) {
+	clock := time.Now().Format("15:04:05")
+	if event.CatchUp > 0 {
+		fmt.Printf("[%s] 감시 전 변경 %d건 색인\n", clock, event.CatchUp)
+		return
+	}
+	for _, path := range event.Added {
+		fmt.Printf("[%s] ➕ %s\n", clock, path)
+	}
+	for _, path := range event.Updated {
+		fmt.Printf("[%s] 📝 %s\n", clock, path)
+	}
+	for _, path := range event.Removed {
+		fmt.Printf("[%s] 🗑️  %s\n", clock, path)
+	}
+	if len(event.TOCs) > 0 {
+		fmt.Printf("[%s] 📑 TOC 갱신: %s\n", clock, strings.Join(event.TOCs, ", "))
+	}
+	for _, link := range event.BrokenLinks {
+		fmt.Printf("[%s] ⚠️  깨진 링크 %s:%d → [[%s]]", clock, link.Source, link.Line, link.Target)
+		if link.Suggestion != "" {
+			fmt.Printf(" (제안: [[%s]])", link.Suggestion)
+		}
+		fmt.Println()
+	}
+	for _, msg := range event.Errors {
+		fmt.Printf("[%s] ❌ %s\n", clock, msg)
+	}
+}
+
+func runKBEmbed(cmd *cobra.Command, args []string) error {
+	vaultPath := getVaultPath(args)
+
+	// Check if initialized
+	svc := kb.NewService(vaultPath)
+	status, err := svc.Status()
+	if err != nil {
+		return err
+	}
+	if !status.Initialized {
+		return fmt.Errorf("KB가 초기화되지 않았습니다. 'pal kb init' 실행하세요")
+	}
+
+	indexSvc := kb.NewIndexService(vaultPath)
+	if err := indexSvc.Open(); err != nil {
+		return err
+	}
+	defer indexSvc.Close()
+
+	// 플래그가 없으면 마지막으로 사용한 provider
+	cfg := kb.EmbedConfig{Provider: embedProvider, Model: embedModel, Endpoint: embedEndpoint, Command: embedCommand}
+	if cfg.Provider == "" {
+		saved, err := indexSvc.EmbedConfig()
+		if err != nil {
+			return err
+		}
+		if saved != nil {
+			cfg.Provider = saved.Provider
+			if cfg.Model == "" {
+				cfg.Model = saved.Model
+			}
+		}
+	}
+	cfg, err = kb.ResolveEmbedConfig(cfg)
+	if err != nil {
+		return err
+	}
+	embedder, err := kb.NewEmbedder(cfg)
+	if err != nil {
+		return err
+	}
+	// 주소와 실행 명령은 vault가 아닌 이 컴퓨터의 설정에 저장
+	if embedEndpoint != "" || embedCommand != "" {
+		if err := kb.SaveUserEmbedConfig(cfg); err != nil {
+			return err
+		}
+	}
+
+	stats, err := indexSvc.EmbedDocuments(cmd.Context(), embedder, embedForce)
+	if err != nil {
+		return fmt.Errorf("임베딩 실패: %w", err)
+	}
+	if err := indexSvc.SaveEmbedConfig(cfg); err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(stats)
+	}
+
+	fmt.Printf("✅ 임베딩 완료 (%s)\n", stats.Model)
+	fmt.Printf("   계산: %d / 문서 %d", stats.Embedded, stats.Total)
+	if stats.Skipped > 0 {
+		fmt.Printf(" (변경 없음 %d)", stats.Skipped)
+	}
+	fmt.Println()
+	if stats.Total == 0 {
+		fmt.Println("   색인된 문서가 없습니다. 'pal kb index'를 먼저 실행하세요")
+	}
+	return nil
+}
+
 func runKBStats(cmd *cobra.Command, args []string) error {
 	vaultPath := getVaultPath(args)
 
@@ -753,6 +1042,9 @@ func runKBLinkCheck(cmd *cobra.Command, args []string) error {
 	}
 
 	linkSvc := kb.NewLinkService(vaultPath)
+	if linkFix {
+		return runKBLinkFix(linkSvc)
+	}
 	result, err := linkSvc.CheckLinks()
 	if err != nil {
 		return err
@@ -783,6 +1075,80 @@ func runKBLinkCheck(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runKBLinkFix(linkSvc *kb.LinkService) error {
+	result, err := linkSvc.FixLinks(linkFixDryRun)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+
+	if result.DryRun {
+		fmt.Println("🔍 링크 수정 미리보기 (dry-run)")
+	} else {
+		fmt.Println("🔧 링크 수정 결과")
+	}
+	fmt.Printf("   수정: %d\n", len(result.Fixed))
+	fmt.Printf("   건너뜀: %d\n", len(result.Skipped))
+
+	for _, fix := range result.Fixed {
+		fmt.Printf("   ✅ %s:%d [[%s]] → [[%s]]\n", fix.Source, fix.Line, fix.From, fix.To)
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Println("\n⚠️  직접 확인 필요:")
+		for _, broken := range result.Skipped {
+			fmt.Printf("   %s:%d [[%s]]", broken.Source, broken.Line, broken.Target)
+			if broken.Suggestion != "" {
+				fmt.Printf(" (추천: [[%s]])", broken.Suggestion)
+			}
+			fmt.Println()
+		}
+	}
+
+	return nil
+}
+
+func runKBMv(cmd *cobra.Command, args []string) error {
+	vaultPath := getVaultPath(args[2:])
+
+	// Check if initialized
+	svc := kb.NewService(vaultPath)
+	status, err := svc.Status()
+	if err != nil {
+		return err
+	}
+	if !status.Initialized {
+		return fmt.Errorf("KB가 초기화되지 않았습니다. 'pal kb init' 실행하세요")
+	}
+
+	result, err := kb.NewLinkService(vaultPath).MoveDocument(args[0], args[1], mvDryRun)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+
+	if result.DryRun {
+		fmt.Println("🔍 이동 미리보기 (dry-run)")
+	} else {
+		fmt.Println("📦 문서 이동 완료")
+	}
+	fmt.Printf("   %s → %s\n", result.From, result.To)
+	fmt.Printf("   링크 갱신: %d\n", len(result.Relinked))
+	for _, fix := range result.Relinked {
+		fmt.Printf("   🔗 %s:%d [[%s]] → [[%s]]\n", fix.Source, fix.Line, fix.From, fix.To)
+	}
+	if result.Indexed {
+		fmt.Println("   색인 경로 갱신됨")
+	}
+
+	return nil
+}
+
 func runKBLinkGraph(cmd *cobra.Command, args []string) error {
 	vaultPath := getVaultPath(args)
 
@@ -801,6 +1167,23 @@ func runKBLinkGraph(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	graph = graph.Filter(kb.GraphFilter{Domain: graphDomain, Tag: graphTag, MinDegree: graphMinDegree})
+
+	if graphFormat != "" {
+		if graphOutput == "" {
+			return kb.WriteGraph(os.Stdout, graph, graphFormat)
+		}
+		f, err := os.Create(graphOutput)
+		if err != nil {
+			return fmt.Errorf("출력 파일 생성 실패: %w", err)
+		}
+		defer f.Close()
+		if err := kb.WriteGraph(f, graph, graphFormat); err != nil {
+			return err
+		}
+		fmt.Printf("📊 링크 그래프 내보내기 완료: %s (노드 %d, 엣지 %d)\n", graphOutput, len(graph.Nodes), len(graph.Edges))
+		return nil
+	}
 
 	if jsonOut {
 		return json.NewEncoder(os.Stdout).Encode(graph)
@@ -958,9 +1341,23 @@ func runKBSync(cmd *cobra.Command, args []string) error {
 
 	syncSvc := kb.NewSyncService(vaultPath, projectPath)
 
+	for _, setting := range kbSyncSetDirection {
+		target, direction, ok := strings.Cut(setting, "=")
+		if !ok {
+			return fmt.Errorf("--set-direction 형식은 <디렉토리>=<방향>입니다: %s", setting)
+		}
+		if err := syncSvc.SetDirection(strings.TrimSpace(target), strings.TrimSpace(direction)); err != nil {
+			return err
+		}
+		if !jsonOut {
+			fmt.Printf("⚙️  %s 동기화 방향: %s\n", target, direction)
+		}
+	}
+
 	opts := &kb.SyncOptions{
-		DryRun: kbSyncDryRun,
-		Force:  kbSyncForce,
+		DryRun:    kbSyncDryRun,
+		Force:     kbSyncForce,
+		Direction: kbSyncDirection,
 	}
 
 	result, err := syncSvc.Sync(opts)
@@ -1001,17 +1398,40 @@ func runKBSync(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(result.Pulled) > 0 {
+		fmt.Printf("⬅️  프로젝트로 반영: %d\n", len(result.Pulled))
+		for _, f := range result.Pulled {
+			fmt.Printf("   %s\n", f)
+		}
+	}
+
+	if len(result.PulledDeleted) > 0 {
+		fmt.Printf("🗑️  프로젝트에서 삭제: %d\n", len(result.PulledDeleted))
+		for _, f := range result.PulledDeleted {
+			fmt.Printf("   %s\n", f)
+		}
+	}
+
 	if len(result.Conflicts) > 0 {
 		fmt.Printf("\n⚠️  충돌: %d\n", len(result.Conflicts))
 		for _, c := range result.Conflicts {
-			fmt.Printf("   %s\n", c.Path)
+			if c.Resolution != "" {
+				fmt.Printf("   %s (%s → %s 우선)\n", c.ProjectPath, c.Reason, c.Resolution)
+				continue
+			}
+			fmt.Printf("   %s (%s)\n", c.ProjectPath, c.Reason)
 			fmt.Printf("     소스: %s\n", c.SourceTime)
 			fmt.Printf("     대상: %s\n", c.TargetTime)
 		}
-		fmt.Println("\n--force 옵션으로 강제 동기화할 수 있습니다.")
+		if result.ReportPath != "" {
+			fmt.Printf("\n📄 충돌 보고서: %s\n", result.ReportPath)
+		}
+		if !kbSyncForce {
+			fmt.Println("\n--force 옵션으로 강제 동기화할 수 있습니다.")
+		}
 	}
 
-	totalChanges := len(result.Added) + len(result.Updated) + len(result.Deleted)
+	totalChanges := len(result.Added) + len(result.Updated) + len(result.Deleted) + len(result.Pulled) + len(result.PulledDeleted)
 	if totalChanges == 0 && len(result.Conflicts) == 0 {
 		fmt.Println("변경 사항 없음")
 	} else if !kbSyncDryRun {
@@ -1068,6 +1488,14 @@ func runKBSyncStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("   경로: %s\n", p.SourcePath)
 		fmt.Printf("   마지막 동기화: %s\n", lastSync)
 		fmt.Printf("   동기화된 파일: %d개\n", len(p.Files))
+		if len(p.Directions) > 0 {
+			var dirs []string
+			for target, direction := range p.Directions {
+				dirs = append(dirs, target+"="+direction)
+			}
+			sort.Strings(dirs)
+			fmt.Printf("   동기화 방향: %s (나머지 to-vault)\n", strings.Join(dirs, ", "))
+		}
 		fmt.Println()
 	}
 
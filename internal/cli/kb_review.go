@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/kb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reviewWithin       string
+	reviewCriticalOnly bool
+	reviewSection      string
+	reviewEvery        string
+	reviewOwner        string
+	reviewAccess       string
+	reviewClear        bool
+)
+
+var kbReviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "문서 검토 주기 관리",
+	Long: `문서의 검토 주기를 관리합니다.
+
+문서 frontmatter:
+  review_every: 90d      # 검토 주기 (d, w, m=30일, y=365일)
+  reviewed: 2026-07-01   # 마지막 검토일 (없으면 updated, created)
+  critical: true         # 세션 브리핑에 기한 초과를 알림
+  owner: alice
+
+review_every가 없는 문서는 'pal kb review section'으로 지정한 섹션 기본값을 따릅니다.
+
+예시:
+  pal kb review due
+  pal kb review due --within 14d --critical
+  pal kb review section 00-System --every 180d --owner platform`,
+}
+
+var kbReviewDueCmd = &cobra.Command{
+	Use:   "due [vault-path]",
+	Short: "검토 기한이 지난 문서",
+	Long:  `검토 주기가 지난 문서를 기한 초과가 큰 순서로 표시합니다. 검토 기록이 없는 문서가 먼저 표시됩니다.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runKBReviewDue,
+}
+
+var kbReviewSectionCmd = &cobra.Command{
+	Use:   "section [section] [vault-path]",
+	Short: "섹션별 검토/접근 메타데이터",
+	Long: `섹션(vault 상대 디렉토리)의 기본 검토 주기, 담당자, 접근 수준을 설정합니다.
+플래그 없이 실행하면 현재 설정을 표시합니다.`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: runKBReviewSection,
+}
+
+func init() {
+	kbCmd.AddCommand(kbReviewCmd)
+	kbReviewCmd.AddCommand(kbReviewDueCmd)
+	kbReviewCmd.AddCommand(kbReviewSectionCmd)
+
+	kbReviewDueCmd.Flags().StringVar(&reviewWithin, "within", "", "이 기간 안에 기한이 오는 문서도 포함 (예: 14d)")
+	kbReviewDueCmd.Flags().BoolVar(&reviewCriticalOnly, "critical", false, "critical 문서만")
+	kbReviewDueCmd.Flags().StringVar(&reviewSection, "section", "", "섹션 경로로 필터")
+
+	kbReviewSectionCmd.Flags().StringVar(&reviewEvery, "every", "", "기본 검토 주기 (예: 90d)")
+	kbReviewSectionCmd.Flags().StringVar(&reviewOwner, "owner", "", "담당자")
+	kbReviewSectionCmd.Flags().StringVar(&reviewAccess, "access", "", "접근 수준 (예: public, internal, restricted)")
+	kbReviewSectionCmd.Flags().BoolVar(&reviewClear, "clear", false, "섹션 설정 삭제")
+}
+
+func runKBReviewDue(cmd *cobra.Command, args []string) error {
+	vaultPath := getVaultPath(args)
+	if err := requireKB(vaultPath); err != nil {
+		return err
+	}
+
+	opts := kb.ReviewOptions{CriticalOnly: reviewCriticalOnly, Section: reviewSection}
+	if reviewWithin != "" {
+		within, err := kb.ParseReviewInterval(reviewWithin)
+		if err != nil {
+			return err
+		}
+		opts.Within = within
+	}
+
+	indexSvc := kb.NewIndexService(vaultPath)
+	if err := indexSvc.Open(); err != nil {
+		return err
+	}
+	defer indexSvc.Close()
+
+	due, err := indexSvc.ReviewDue(opts)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"checked_at": time.Now().Format(time.RFC3339),
+			"due":        due,
+		})
+	}
+
+	if len(due) == 0 {
+		fmt.Println("✅ 검토 기한이 지난 문서 없음")
+		return nil
+	}
+
+	fmt.Printf("📅 검토 필요 문서 (%d):\n\n", len(due))
+	for _, r := range due {
+		mark := "  "
+		if r.Critical {
+			mark = "🔴"
+		}
+		var when string
+		switch {
+		case r.NeverReviewed:
+			when = "검토 기록 없음"
+		case r.OverdueDays > 0:
+			when = fmt.Sprintf("%d일 초과", r.OverdueDays)
+		case r.OverdueDays == 0:
+			when = "오늘 기한"
+		default:
+			when = fmt.Sprintf("%d일 남음", -r.OverdueDays)
+		}
+		fmt.Printf("%s %s (%s)\n", mark, r.Document.Path, when)
+
+		detail := fmt.Sprintf("주기: %s", r.ReviewEvery)
+		if r.Section != "" {
+			detail += fmt.Sprintf(" (섹션 %s)", r.Section)
+		}
+		if r.ReviewedAt != "" {
+			detail += fmt.Sprintf(", 마지막 검토: %s", r.ReviewedAt)
+		}
+		if r.Owner != "" {
+			detail += fmt.Sprintf(", 담당: %s", r.Owner)
+		}
+		fmt.Printf("     %s\n", detail)
+	}
+	return nil
+}
+
+func runKBReviewSection(cmd *cobra.Command, args []string) error {
+	var section string
+	var rest []string
+	if len(args) > 0 {
+		section, rest = strings.Trim(filepath.ToSlash(args[0]), "/"), args[1:]
+	}
+	vaultPath := getVaultPath(rest)
+	if err := requireKB(vaultPath); err != nil {
+		return err
+	}
+	svc := kb.NewService(vaultPath)
+
+	changed := cmd.Flags().Changed("every") || cmd.Flags().Changed("owner") || cmd.Flags().Changed("access")
+	if section != "" && (changed || reviewClear) {
+		sections, err := svc.SectionMeta()
+		if err != nil {
+			return err
+		}
+		meta := sections[section]
+		if reviewClear {
+			meta = kb.SectionMeta{}
+		}
+		if cmd.Flags().Changed("every") {
+			meta.ReviewEvery = reviewEvery
+		}
+		if cmd.Flags().Changed("owner") {
+			meta.Owner = reviewOwner
+		}
+		if cmd.Flags().Changed("access") {
+			meta.Access = reviewAccess
+		}
+		if err := svc.SetSectionMeta(section, meta); err != nil {
+			return err
+		}
+		if !jsonOut {
+			fmt.Printf("✅ 섹션 설정 저장: %s\n", section)
+		}
+	}
+
+	sections, err := svc.SectionMeta()
+	if err != nil {
+		return err
+	}
+	if section != "" {
+		if meta, ok := sections[section]; ok {
+			sections = map[string]kb.SectionMeta{section: meta}
+		} else {
+			sections = map[string]kb.SectionMeta{}
+		}
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(sections)
+	}
+	if len(sections) == 0 {
+		fmt.Println("섹션 설정 없음")
+		return nil
+	}
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		meta := sections[name]
+		fmt.Printf("📁 %s\n", name)
+		if meta.ReviewEvery != "" {
+			fmt.Printf("   검토 주기: %s\n", meta.ReviewEvery)
+		}
+		if meta.Owner != "" {
+			fmt.Printf("   담당: %s\n", meta.Owner)
+		}
+		if meta.Access != "" {
+			fmt.Printf("   접근: %s\n", meta.Access)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/n0roo/pal-kit/internal/kb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	taxonomyName        string
+	taxonomyDescription string
+	taxonomyOwner       string
+	taxonomyTags        []string
+	taxonomyGroup       string
+	taxonomyStrict      bool
+)
+
+var kbTaxonomyCmd = &cobra.Command{
+	Use:   "taxonomy",
+	Short: "분류체계 관리",
+	Long: `_taxonomy/의 도메인, 태그 정의를 편집하고 검증합니다.
+파일의 주석과 순서는 보존되며, 편집 결과가 올바른 YAML일 때만 저장합니다.
+
+예시:
+  pal kb taxonomy add-domain payment --name 결제 --description "결제 처리"
+  pal kb taxonomy add-tag idempotency --group concept
+  pal kb taxonomy validate --strict`,
+}
+
+var kbTaxonomyAddDomainCmd = &cobra.Command{
+	Use:   "add-domain <id> [vault-path]",
+	Short: "도메인 추가",
+	Long: `domains.yaml에 도메인을 추가하고 tags.yaml의 domain 그룹에 도메인 태그를 추가합니다.
+--tag로 지정한 태그는 tags.yaml에 이미 정의되어 있어야 합니다.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runKBTaxonomyAddDomain,
+}
+
+var kbTaxonomyAddTagCmd = &cobra.Command{
+	Use:   "add-tag <tag> [vault-path]",
+	Short: "태그 추가",
+	Long:  `tags.yaml의 그룹에 태그를 추가합니다. 그룹이 없으면 새로 만듭니다.`,
+	Args:  cobra.RangeArgs(1, 2),
+	RunE:  runKBTaxonomyAddTag,
+}
+
+var kbTaxonomyValidateCmd = &cobra.Command{
+	Use:   "validate [vault-path]",
+	Short: "분류체계 검증",
+	Long: `분류체계를 검증합니다.
+
+검사 항목:
+  - _taxonomy/*.yaml 파싱, 도메인 태그 정의 여부, 태그 중복
+  - 분류기/린트 규칙이 참조하는 문서 타입, 도메인, 태그가 정의되어 있는지
+  - 문서 frontmatter의 type, domain, tags와 타입별 필수 필드`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runKBTaxonomyValidate,
+}
+
+func init() {
+	kbCmd.AddCommand(kbTaxonomyCmd)
+	kbTaxonomyCmd.AddCommand(kbTaxonomyAddDomainCmd)
+	kbTaxonomyCmd.AddCommand(kbTaxonomyAddTagCmd)
+	kbTaxonomyCmd.AddCommand(kbTaxonomyValidateCmd)
+
+	kbTaxonomyAddDomainCmd.Flags().StringVar(&taxonomyName, "name", "", "표시 이름 (기본: ID)")
+	kbTaxonomyAddDomainCmd.Flags().StringVar(&taxonomyDescription, "description", "", "설명")
+	kbTaxonomyAddDomainCmd.Flags().StringVar(&taxonomyOwner, "owner", "", "담당자")
+	kbTaxonomyAddDomainCmd.Flags().StringSliceVar(&taxonomyTags, "tag", nil, "추가 태그 (정의된 태그만)")
+
+	kbTaxonomyAddTagCmd.Flags().StringVar(&taxonomyGroup, "group", "", "태그 그룹 (필수)")
+	kbTaxonomyAddTagCmd.Flags().StringVar(&taxonomyDescription, "description", "", "새 그룹의 설명")
+
+	kbTaxonomyValidateCmd.Flags().BoolVar(&taxonomyStrict, "strict", false, "엄격 모드 (문제가 있으면 실패)")
+}
+
+// requireKB returns an error when the vault has not been initialized
+func requireKB(vaultPath string) error {
+	status, err := kb.NewService(vaultPath).Status()
+	if err != nil {
+		return err
+	}
+	if !status.Initialized {
+		return fmt.Errorf("KB가 초기화되지 않았습니다. 'pal kb init' 실행하세요")
+	}
+	return nil
+}
+
+func runKBTaxonomyAddDomain(cmd *cobra.Command, args []string) error {
+	vaultPath := getVaultPath(args[1:])
+	if err := requireKB(vaultPath); err != nil {
+		return err
+	}
+
+	id := args[0]
+	if err := kb.NewTaxonomyService(vaultPath).AddDomain(id, kb.Domain{
+		Name:        taxonomyName,
+		Description: taxonomyDescription,
+		Owner:       taxonomyOwner,
+		Tags:        taxonomyTags,
+	}); err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(map[string]string{"status": "added", "domain": id})
+	}
+	fmt.Printf("✅ 도메인 추가: %s\n", id)
+	return nil
+}
+
+func runKBTaxonomyAddTag(cmd *cobra.Command, args []string) error {
+	vaultPath := getVaultPath(args[1:])
+	if err := requireKB(vaultPath); err != nil {
+		return err
+	}
+
+	tag := args[0]
+	if err := kb.NewTaxonomyService(vaultPath).AddTag(taxonomyGroup, tag, taxonomyDescription); err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(map[string]string{"status": "added", "tag": tag, "group": taxonomyGroup})
+	}
+	fmt.Printf("✅ 태그 추가: %s (그룹: %s)\n", tag, taxonomyGroup)
+	return nil
+}
+
+func runKBTaxonomyValidate(cmd *cobra.Command, args []string) error {
+	vaultPath := getVaultPath(args)
+	if err := requireKB(vaultPath); err != nil {
+		return err
+	}
+
+	report, err := kb.NewTaxonomyService(vaultPath).Validate()
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("🗂️  분류체계 검증 결과")
+		fmt.Printf("   도메인: %d, 문서 타입: %d, 태그: %d\n", report.Domains, report.DocTypes, report.Tags)
+		fmt.Printf("   검사한 문서: %d\n", report.Checked)
+
+		if len(report.Issues) > 0 {
+			fmt.Printf("\n⚠️  분류체계/규칙 문제 (%d):\n", len(report.Issues))
+			for _, issue := range report.Issues {
+				fmt.Printf("   [%s] %s: %s\n", issue.Kind, issue.File, issue.Message)
+			}
+		}
+		if len(report.Violations) > 0 {
+			fmt.Printf("\n❌ 분류체계 위반 문서 (%d):\n", len(report.Violations))
+			for _, v := range report.Violations {
+				if v.Value != "" {
+					fmt.Printf("   %s: %s '%s' - %s\n", v.Path, v.Field, v.Value, v.Message)
+				} else {
+					fmt.Printf("   %s: %s - %s\n", v.Path, v.Field, v.Message)
+				}
+			}
+		}
+		if report.OK() {
+			fmt.Println("\n✅ 분류체계 위반 없음")
+		}
+	}
+
+	if taxonomyStrict && !report.OK() {
+		return fmt.Errorf("분류체계 문제 %d개, 위반 %d개", len(report.Issues), len(report.Violations))
+	}
+	return nil
+}
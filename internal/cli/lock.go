@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
 	"github.com/n0roo/pal-kit/internal/lock"
@@ -63,7 +64,7 @@ func getLockService() (*lock.Service, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	return lock.NewService(database), func() { database.Close() }, nil
+	return lock.NewProjectService(database, GetProjectRoot()), func() { database.Close() }, nil
 }
 
 func runLockAcquire(cmd *cobra.Command, args []string) error {
@@ -137,9 +138,16 @@ func runLockList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	contentions, _ := svc.Contentions()
+	waiters := make(map[string]lock.Contention)
+	for _, c := range contentions {
+		waiters[c.Resource] = c
+	}
+
 	if jsonOut {
 		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
-			"locks": locks,
+			"locks":       locks,
+			"contentions": contentions,
 		})
 		return nil
 	}
@@ -149,10 +157,18 @@ func runLockList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Printf("%-20s %-20s %s\n", "RESOURCE", "SESSION", "ACQUIRED")
-	fmt.Println("------------------------------------------------------------")
+	fmt.Printf("%-20s %-20s %-20s %s\n", "RESOURCE", "SESSION", "ACQUIRED", "WAITERS")
+	fmt.Println("--------------------------------------------------------------------------------")
+	now := time.Now()
 	for _, l := range locks {
-		fmt.Printf("%-20s %-20s %s\n", l.Resource, l.SessionID, l.AcquiredAt.Format("2006-01-02 15:04:05"))
+		waiting := "-"
+		if c, ok := waiters[l.Resource]; ok {
+			waiting = fmt.Sprintf("%d (최대 %s)", len(c.Waiters), c.LongestWait(now).Round(time.Second))
+			if c.EscalationID != 0 {
+				waiting += fmt.Sprintf(" → #%d", c.EscalationID)
+			}
+		}
+		fmt.Printf("%-20s %-20s %-20s %s\n", l.Resource, l.SessionID, l.AcquiredAt.Format("2006-01-02 15:04:05"), waiting)
 	}
 
 	return nil
@@ -25,6 +25,7 @@ var manifestCmd = &cobra.Command{
 
 예시:
   pal manifest status     # 변경 상태 확인
+  pal manifest status -v  # 파일별 생성/수정 세션·포트 표시
   pal manifest sync       # 변경 사항 동기화
   pal manifest add <file> # 파일 추가
 `,
@@ -139,21 +140,25 @@ func runManifestStatus(cmd *cobra.Command, args []string) error {
 	// 동기화된 파일
 	for _, f := range synced {
 		fmt.Printf("  ✅ %-40s %s\n", f.Path, f.Type)
+		printManifestProvenance(f)
 	}
 
 	// 변경된 파일
 	for _, f := range modified {
 		fmt.Printf("  📝 %-40s %s (변경됨)\n", f.Path, f.Type)
+		printManifestProvenance(f)
 	}
 
 	// 새 파일
 	for _, f := range newFiles {
 		fmt.Printf("  ✨ %-40s %s (새 파일)\n", f.Path, f.Type)
+		printManifestProvenance(f)
 	}
 
 	// 삭제된 파일
 	for _, f := range deleted {
 		fmt.Printf("  ❌ %-40s %s (삭제됨)\n", f.Path, f.Type)
+		printManifestProvenance(f)
 	}
 
 	fmt.Println()
@@ -168,6 +173,36 @@ func runManifestStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printManifestProvenance prints which session/port created or last modified a file (--verbose)
+func printManifestProvenance(f manifest.TrackedFile) {
+	if !verbose {
+		return
+	}
+	if f.Provenance == nil {
+		fmt.Printf("       managed_by: %s, 출처 기록 없음\n", f.ManagedBy)
+		return
+	}
+
+	p := f.Provenance
+	if p.CreatedAt != nil {
+		fmt.Printf("       생성: %s (%s)\n", provenanceOwner(p.CreatedSession, p.CreatedPort), p.CreatedAt.Format("2006-01-02 15:04"))
+	}
+	if p.ModifiedAt != nil {
+		fmt.Printf("       수정: %s (%s, %d회 편집)\n", provenanceOwner(p.ModifiedSession, p.ModifiedPort),
+			p.ModifiedAt.Format("2006-01-02 15:04"), p.EditCount)
+	}
+}
+
+func provenanceOwner(sessionID, portID string) string {
+	if len(sessionID) > 8 {
+		sessionID = sessionID[:8]
+	}
+	if portID == "" {
+		return "session " + sessionID
+	}
+	return fmt.Sprintf("session %s / port %s", sessionID, portID)
+}
+
 func runManifestSync(cmd *cobra.Command, args []string) error {
 	svc, err := getManifestService()
 	if err != nil {
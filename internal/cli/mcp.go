@@ -1,8 +1,13 @@
 package cli
 
 import (
+	stdctx "context"
 	"fmt"
+	"net"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 
 	"github.com/n0roo/pal-kit/internal/mcp"
 	"github.com/spf13/cobra"
@@ -24,23 +29,101 @@ Claude Desktop 설정 (claude_desktop_config.json):
   }
 }`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		dbPath := GetDBPath()
-		projectRoot, _ := cmd.Flags().GetString("project")
+		server, err := newMCPServer(cmd)
+		if err != nil {
+			return err
+		}
+		defer server.Close()
 
-		if projectRoot == "" {
-			projectRoot = GetProjectRoot()
+		return server.Run()
+	},
+}
+
+var mcpServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "MCP Server를 HTTP로 실행",
+	Long: `MCP 서버를 Streamable HTTP 전송으로 실행합니다.
+다른 머신의 Claude 인스턴스와 MCP 클라이언트가 하나의 pal-kit 서버(DB, Lock, 에스컬레이션)를 공유합니다.
+
+엔드포인트: POST/GET/DELETE <addr>/mcp (세션은 Mcp-Session-Id 헤더)
+기본은 127.0.0.1에서만 받으며, 외부 주소(예: --http :8721)는 --token(또는 PAL_MCP_TOKEN)으로
+Bearer 인증을 켠 경우에만 열 수 있습니다.
+DNS rebinding을 막기 위해 Host 헤더는 localhost, 바인드 주소, --allow-host만 받습니다
+(예: pal mcp serve --http :8721 --token <token> --allow-host build-host).
+
+클라이언트 설정 예:
+{
+  "mcpServers": {
+    "pal-kit": {
+      "type": "http",
+      "url": "http://build-host:8721/mcp",
+      "headers": {"Authorization": "Bearer <token>"}
+    }
+  }
+}`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("http")
+		token, _ := cmd.Flags().GetString("token")
+		origins, _ := cmd.Flags().GetStringSlice("allow-origin")
+		hosts, _ := cmd.Flags().GetStringSlice("allow-host")
+		if token == "" {
+			token = os.Getenv("PAL_MCP_TOKEN")
 		}
 
-		server, err := mcp.NewServer(dbPath, projectRoot)
+		if token == "" && !isLoopbackAddr(addr) {
+			return fmt.Errorf("인증 없이 외부 주소(%s)에 열 수 없습니다. --token 또는 PAL_MCP_TOKEN을 설정하세요", addr)
+		}
+
+		server, err := newMCPServer(cmd)
 		if err != nil {
-			return fmt.Errorf("MCP 서버 생성 실패: %w", err)
+			return err
 		}
 		defer server.Close()
 
-		return server.Run()
+		ctx, stop := signal.NotifyContext(stdctx.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		transport := mcp.NewHTTPTransport(server, mcp.HTTPOptions{Token: token, AllowedOrigins: origins, AllowedHosts: hosts})
+		return transport.ListenAndServe(ctx, addr)
 	},
 }
 
+// newMCPServer creates the MCP server from the --project/--vault flags
+func newMCPServer(cmd *cobra.Command) (*mcp.Server, error) {
+	dbPath := GetDBPath()
+	projectRoot, _ := cmd.Flags().GetString("project")
+	vaultPath, _ := cmd.Flags().GetString("vault")
+
+	if projectRoot == "" {
+		projectRoot = GetProjectRoot()
+	}
+	if vaultPath == "" {
+		// 기본: ~/mcp-docs (pal serve와 동일)
+		home, _ := os.UserHomeDir()
+		vaultPath = filepath.Join(home, "mcp-docs")
+	}
+
+	server, err := mcp.NewServer(dbPath, projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("MCP 서버 생성 실패: %w", err)
+	}
+	server.SetVaultPath(vaultPath)
+	return server, nil
+}
+
+// isLoopbackAddr reports whether a listen address only accepts local connections
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil || host == "" {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 var mcpConfigCmd = &cobra.Command{
 	Use:   "config",
 	Short: "MCP 설정 출력",
@@ -84,6 +167,15 @@ var mcpConfigCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(mcpCmd)
 	mcpCmd.Flags().StringP("project", "p", "", "프로젝트 루트 경로")
+	mcpCmd.Flags().String("vault", "", "kb_search/doc_get이 사용할 Knowledge Base vault 경로 (기본: ~/mcp-docs)")
+
+	mcpCmd.AddCommand(mcpServeCmd)
+	mcpServeCmd.Flags().StringP("project", "p", "", "프로젝트 루트 경로")
+	mcpServeCmd.Flags().String("vault", "", "kb_search/doc_get이 사용할 Knowledge Base vault 경로 (기본: ~/mcp-docs)")
+	mcpServeCmd.Flags().String("http", "127.0.0.1:8721", "HTTP 수신 주소 (외부 주소는 --token 필요)")
+	mcpServeCmd.Flags().String("token", "", "Bearer 인증 토큰 (기본: PAL_MCP_TOKEN)")
+	mcpServeCmd.Flags().StringSlice("allow-origin", nil, "추가로 허용할 브라우저 Origin")
+	mcpServeCmd.Flags().StringSlice("allow-host", nil, "추가로 허용할 Host 헤더 (모든 인터페이스에 열 때 클라이언트가 접속하는 호스트 이름)")
 
 	mcpCmd.AddCommand(mcpConfigCmd)
 	mcpConfigCmd.Flags().StringP("project", "p", "", "프로젝트 루트 경로")
@@ -3,16 +3,21 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/context"
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/message"
 	"github.com/n0roo/pal-kit/internal/orchestrator"
 	"github.com/spf13/cobra"
 )
 
 var orchestrationCmd = &cobra.Command{
 	Use:     "orchestration",
-	Aliases: []string{"orch", "o"},
+	Aliases: []string{"orchestrate", "orch", "o"},
 	Short:   "Orchestration 관리",
 	Long:    `Orchestration 포트를 생성하고 관리합니다.`,
 }
@@ -32,31 +37,41 @@ var orchListCmd = &cobra.Command{
 		status, _ := cmd.Flags().GetString("status")
 		limit, _ := cmd.Flags().GetInt("limit")
 
-		orchestrations, err := svc.ListOrchestrations(orchestrator.OrchestrationStatus(status), limit)
+		// 이관되지 않은 레거시 파이프라인도 함께 표시
+		executions, err := svc.ListExecutions(status, limit)
 		if err != nil {
 			return err
 		}
 
 		if IsJSON() {
-			data, _ := json.MarshalIndent(orchestrations, "", "  ")
+			data, _ := json.MarshalIndent(executions, "", "  ")
 			fmt.Println(string(data))
 			return nil
 		}
 
-		if len(orchestrations) == 0 {
+		if len(executions) == 0 {
 			fmt.Println("Orchestration이 없습니다.")
 			return nil
 		}
 
+		legacy := 0
 		fmt.Printf("%-36s %-30s %-10s %5s %s\n", "ID", "Title", "Status", "Prog", "Ports")
 		fmt.Println(strings.Repeat("-", 100))
-		for _, o := range orchestrations {
+		for _, e := range executions {
+			title := e.Title
+			if e.Kind == orchestrator.ExecutionPipeline {
+				title = "[pipeline] " + title
+				legacy++
+			}
 			fmt.Printf("%-36s %-30s %-10s %4d%% %d\n",
-				truncate(o.ID, 36),
-				truncate(o.Title, 30),
-				o.Status,
-				o.ProgressPercent,
-				len(o.AtomicPorts))
+				truncate(e.ID, 36),
+				truncate(title, 30),
+				e.Status,
+				e.ProgressPercent,
+				e.Ports)
+		}
+		if legacy > 0 {
+			fmt.Printf("\n💡 레거시 파이프라인 %d개: pal pipeline migrate --all 로 Orchestration으로 이관하세요\n", legacy)
 		}
 
 		return nil
@@ -92,10 +107,23 @@ var orchCreateCmd = &cobra.Command{
 			}
 		}
 
+		policy, setPolicy := retryPolicyFromFlags(cmd, orchestrator.DefaultRetryPolicy())
+		if setPolicy {
+			if err := policy.Validate(); err != nil {
+				return err
+			}
+		}
+
 		orch, err := svc.CreateOrchestration(title, desc, atomicPorts)
 		if err != nil {
 			return err
 		}
+		if setPolicy {
+			if err := svc.SetRetryPolicy(orch.ID, policy); err != nil {
+				return err
+			}
+			orch.RetryPolicy = policy
+		}
 
 		if IsJSON() {
 			data, _ := json.MarshalIndent(orch, "", "  ")
@@ -106,6 +134,7 @@ var orchCreateCmd = &cobra.Command{
 		fmt.Printf("✓ Orchestration 생성됨: %s\n", orch.ID)
 		fmt.Printf("  Title: %s\n", orch.Title)
 		fmt.Printf("  Ports: %d\n", len(orch.AtomicPorts))
+		fmt.Printf("  Retry: %s\n", formatRetryPolicy(orch.RetryPolicy))
 		return nil
 	},
 }
@@ -122,7 +151,11 @@ var orchShowCmd = &cobra.Command{
 		defer database.Close()
 
 		svc := orchestrator.NewService(database, nil, nil)
-		orch, err := svc.GetOrchestration(args[0])
+		id, err := svc.ResolveExecutionID(args[0])
+		if err != nil {
+			return err
+		}
+		orch, err := svc.GetOrchestration(id)
 		if err != nil {
 			return err
 		}
@@ -140,6 +173,7 @@ var orchShowCmd = &cobra.Command{
 		}
 		fmt.Printf("Status: %s\n", orch.Status)
 		fmt.Printf("Progress: %d%%\n", orch.ProgressPercent)
+		fmt.Printf("Retry: %s\n", formatRetryPolicy(orch.RetryPolicy))
 		fmt.Printf("Created: %s\n", orch.CreatedAt.Format("2006-01-02 15:04:05"))
 
 		if len(orch.AtomicPorts) > 0 {
@@ -153,7 +187,18 @@ var orchShowCmd = &cobra.Command{
 				if len(p.DependsOn) > 0 {
 					deps = fmt.Sprintf(" (depends: %s)", strings.Join(p.DependsOn, ", "))
 				}
-				fmt.Printf("  %d. %s [%s]%s\n", p.Order, p.PortID, status, deps)
+				retry := ""
+				if p.Attempts > 0 {
+					retry = fmt.Sprintf(" (실패 %d회", p.Attempts)
+					if p.Status == "pending" && p.RetryAt != nil {
+						retry += ", 재시도 " + p.RetryAt.Local().Format("15:04:05")
+					}
+					retry += ")"
+				}
+				fmt.Printf("  %d. %s [%s]%s%s\n", p.Order, p.PortID, status, deps, retry)
+				if p.LastError != "" {
+					fmt.Printf("     마지막 오류: %s\n", truncate(p.LastError, 80))
+				}
 			}
 		}
 
@@ -172,7 +217,9 @@ var orchStatsCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		svc := orchestrator.NewService(database, nil, nil)
+		// 통계 전에 워커 heartbeat 갱신 (stalled 표시)
+		svc := orchestrator.NewService(database, nil, message.NewStore(database.DB))
+		svc.CheckWorkerHeartbeats(args[0], orchStallWindow(0))
 		stats, err := svc.GetOrchestrationStats(args[0])
 		if err != nil {
 			return err
@@ -191,7 +238,282 @@ var orchStatsCmd = &cobra.Command{
 		fmt.Printf("  Failed:    %d\n", stats.FailedPorts)
 		fmt.Printf("Progress: %d%%\n", stats.ProgressPercent)
 		fmt.Printf("\nWorkers: %d total, %d active\n", stats.TotalWorkers, stats.ActiveWorkers)
+		if stats.StalledWorkers > 0 {
+			fmt.Printf("  ⚠️  Stalled: %d (%s)\n", stats.StalledWorkers, strings.Join(stats.StalledPorts, ", "))
+		}
+
+		return nil
+	},
+}
+
+var orchHeartbeatCmd = &cobra.Command{
+	Use:   "heartbeat [id]",
+	Short: "워커 heartbeat (무응답 워커 감지)",
+	Long: `실행 중인 워커의 이벤트/메시지 주기로 생존 여부를 확인합니다.
+
+stall window 동안 활동이 없는 워커는 stalled로 표시되고 Operator 세션에 보고되며,
+활동이 재개되면 표시가 해제됩니다. window는 --window 또는
+.pal/config.yaml의 settings.worker_stall_window (기본 10m)로 지정합니다.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		window, _ := cmd.Flags().GetDuration("window")
+		svc := orchestrator.NewService(database, nil, message.NewStore(database.DB))
+		beats, err := svc.CheckWorkerHeartbeats(args[0], orchStallWindow(window))
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			if beats == nil {
+				beats = []*orchestrator.WorkerHeartbeat{}
+			}
+			data, _ := json.MarshalIndent(beats, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(beats) == 0 {
+			fmt.Println("실행 중인 워커가 없습니다.")
+			return nil
+		}
 
+		fmt.Printf("%-20s %-10s %-20s %10s %8s\n", "PORT", "STATE", "LAST ACTIVITY", "SILENCE", "EVENTS")
+		for _, hb := range beats {
+			state := "alive"
+			if hb.Stalled {
+				state = "stalled"
+			}
+			fmt.Printf("%-20s %-10s %-20s %9ds %8d\n",
+				truncate(hb.PortID, 20), state, hb.LastActivity.Local().Format("2006-01-02 15:04:05"),
+				hb.SilenceSeconds, hb.RecentEvents)
+		}
+		return nil
+	},
+}
+
+// orchStallWindow resolves the worker stall window (flag > project config > default)
+func orchStallWindow(flagValue time.Duration) time.Duration {
+	if flagValue > 0 {
+		return flagValue
+	}
+	cwd, _ := os.Getwd()
+	if projectRoot := context.FindProjectRoot(cwd); projectRoot != "" {
+		if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+			return projectCfg.Settings.StallWindow()
+		}
+	}
+	return 0
+}
+
+var orchScheduleCmd = &cobra.Command{
+	Use:   "schedule [plan-id]",
+	Short: "Orchestration 반복 실행 스케줄 등록",
+	Long: `기존 Orchestration을 plan으로 사용하여 cron 스케줄을 등록합니다.
+스케줄은 'pal serve' 데몬이 실행하며, 실행마다 plan을 복제한 새 Orchestration이 생성됩니다.
+
+예시:
+  pal orch schedule <plan-id> --cron "0 2 * * *"
+  pal orch schedule list
+  pal orch schedule history <schedule-id>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cronExpr, _ := cmd.Flags().GetString("cron")
+		if cronExpr == "" {
+			return fmt.Errorf("--cron 플래그가 필요합니다")
+		}
+
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := orchestrator.NewService(database, nil, nil)
+		sched, err := svc.CreateSchedule(args[0], cronExpr)
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(sched, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("✓ 스케줄 등록됨: %s\n", sched.ID)
+		fmt.Printf("  Plan: %s\n", sched.PlanID)
+		fmt.Printf("  Cron: %s\n", sched.Cron)
+		fmt.Printf("  Next: %s\n", sched.NextRunAt.Format("2006-01-02 15:04"))
+		fmt.Println("  (실행하려면 'pal serve' 데몬이 동작 중이어야 합니다)")
+		return nil
+	},
+}
+
+var orchScheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "스케줄 목록",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := orchestrator.NewService(database, nil, nil)
+		schedules, err := svc.ListSchedules()
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(schedules, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(schedules) == 0 {
+			fmt.Println("등록된 스케줄이 없습니다.")
+			return nil
+		}
+
+		fmt.Printf("%-36s %-36s %-15s %-8s %s\n", "ID", "Plan", "Cron", "Enabled", "Next Run")
+		fmt.Println(strings.Repeat("-", 120))
+		for _, sc := range schedules {
+			next := "-"
+			if sc.Enabled && sc.NextRunAt != nil {
+				next = sc.NextRunAt.Format("2006-01-02 15:04")
+			}
+			fmt.Printf("%-36s %-36s %-15s %-8v %s\n",
+				sc.ID, truncate(sc.PlanID, 36), sc.Cron, sc.Enabled, next)
+		}
+
+		return nil
+	},
+}
+
+var orchScheduleHistoryCmd = &cobra.Command{
+	Use:   "history [schedule-id]",
+	Short: "스케줄 실행 이력",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := orchestrator.NewService(database, nil, nil)
+		limit, _ := cmd.Flags().GetInt("limit")
+		runs, err := svc.ListScheduleRuns(args[0], limit)
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(runs, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(runs) == 0 {
+			fmt.Println("실행 이력이 없습니다.")
+			return nil
+		}
+
+		for _, run := range runs {
+			icon := "✓"
+			if run.Status == orchestrator.ScheduleRunFailed {
+				icon = "✗"
+			}
+			fmt.Printf("%s %s  %s", icon, run.StartedAt.Format("2006-01-02 15:04:05"), run.Status)
+			if run.OrchestrationID != "" {
+				fmt.Printf("  orch=%s", run.OrchestrationID)
+			}
+			if run.Error != "" {
+				fmt.Printf("  error=%s", run.Error)
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+var orchScheduleRunCmd = &cobra.Command{
+	Use:   "run [schedule-id]",
+	Short: "스케줄 즉시 실행",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := orchestrator.NewService(database, nil, nil)
+		run, err := svc.RunScheduleNow(args[0])
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(run, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if run.Status == orchestrator.ScheduleRunFailed {
+			return fmt.Errorf("스케줄 실행 실패: %s", run.Error)
+		}
+		fmt.Printf("✓ Orchestration 시작됨: %s\n", run.OrchestrationID)
+		return nil
+	},
+}
+
+func newOrchScheduleToggleCmd(use, short string, enabled bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " [schedule-id]",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			database, err := db.Open(GetDBPath())
+			if err != nil {
+				return err
+			}
+			defer database.Close()
+
+			svc := orchestrator.NewService(database, nil, nil)
+			if err := svc.SetScheduleEnabled(args[0], enabled); err != nil {
+				return err
+			}
+			fmt.Printf("✓ %s: %s\n", short, args[0])
+			return nil
+		},
+	}
+}
+
+var orchScheduleRemoveCmd = &cobra.Command{
+	Use:   "remove [schedule-id]",
+	Short: "스케줄 삭제",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := orchestrator.NewService(database, nil, nil)
+		if err := svc.DeleteSchedule(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("✓ 스케줄 삭제됨: %s\n", args[0])
 		return nil
 	},
 }
@@ -206,9 +528,22 @@ func init() {
 	orchestrationCmd.AddCommand(orchCreateCmd)
 	orchCreateCmd.Flags().StringP("description", "d", "", "설명")
 	orchCreateCmd.Flags().StringP("ports", "p", "", "포트 ID 목록 (쉼표 구분)")
+	addRetryPolicyFlags(orchCreateCmd)
 
 	orchestrationCmd.AddCommand(orchShowCmd)
 	orchestrationCmd.AddCommand(orchStatsCmd)
+	orchestrationCmd.AddCommand(orchHeartbeatCmd)
+	orchHeartbeatCmd.Flags().Duration("window", 0, "stall 판정 시간 (기본: 설정값 또는 10m)")
+
+	orchestrationCmd.AddCommand(orchScheduleCmd)
+	orchScheduleCmd.Flags().String("cron", "", "cron 표현식 (예: \"0 2 * * *\")")
+	orchScheduleCmd.AddCommand(orchScheduleListCmd)
+	orchScheduleCmd.AddCommand(orchScheduleHistoryCmd)
+	orchScheduleHistoryCmd.Flags().IntP("limit", "l", 20, "최대 개수")
+	orchScheduleCmd.AddCommand(orchScheduleRunCmd)
+	orchScheduleCmd.AddCommand(newOrchScheduleToggleCmd("enable", "스케줄 활성화", true))
+	orchScheduleCmd.AddCommand(newOrchScheduleToggleCmd("disable", "스케줄 비활성화", false))
+	orchScheduleCmd.AddCommand(orchScheduleRemoveCmd)
 }
 
 func truncate(s string, maxLen int) string {
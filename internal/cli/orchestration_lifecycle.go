@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/spf13/cobra"
+)
+
+// newOrchLifecycleCmd builds pause/resume/cancel commands sharing the same output
+func newOrchLifecycleCmd(action, short, long string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   action + " [id]",
+		Short: short,
+		Long:  long,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			database, err := db.Open(GetDBPath())
+			if err != nil {
+				return err
+			}
+			defer database.Close()
+
+			reason, _ := cmd.Flags().GetString("reason")
+			svc := orchestrator.NewService(database, session.NewService(database), nil)
+
+			var event *orchestrator.LifecycleEvent
+			switch action {
+			case orchestrator.ActionPause:
+				event, err = svc.PauseOrchestration(args[0], reason)
+			case orchestrator.ActionResume:
+				event, err = svc.ResumeOrchestration(args[0], reason)
+			case orchestrator.ActionCancel:
+				event, err = svc.CancelOrchestration(args[0], reason)
+			}
+			if err != nil {
+				return err
+			}
+
+			if IsJSON() {
+				data, _ := json.MarshalIndent(event, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			}
+
+			fmt.Printf("✓ Orchestration %s: %s → %s\n", event.OrchestrationID, event.FromStatus, event.ToStatus)
+			if event.Reason != "" {
+				fmt.Printf("  사유: %s\n", event.Reason)
+			}
+			if len(event.Workers) > 0 {
+				fmt.Printf("  워커: %d개 (%s)\n", len(event.Workers), strings.Join(event.Workers, ", "))
+			}
+			if len(event.ReleasedLocks) > 0 {
+				fmt.Printf("  해제한 Lock: %s\n", strings.Join(event.ReleasedLocks, ", "))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String("reason", "", "사유 (수명주기 이력에 기록)")
+	return cmd
+}
+
+var orchLifecycleCmd = &cobra.Command{
+	Use:   "lifecycle [id]",
+	Short: "일시정지/재개/취소 이력",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := orchestrator.NewService(database, nil, nil)
+		if _, err := svc.GetOrchestration(args[0]); err != nil {
+			return err
+		}
+		events, err := svc.LifecycleHistory(args[0])
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			if events == nil {
+				events = []*orchestrator.LifecycleEvent{}
+			}
+			data, _ := json.MarshalIndent(events, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(events) == 0 {
+			fmt.Println("수명주기 이력이 없습니다.")
+			return nil
+		}
+
+		fmt.Printf("%-20s %-8s %-22s %-8s %s\n", "TIME", "ACTION", "STATUS", "WORKERS", "REASON")
+		fmt.Println(strings.Repeat("-", 80))
+		for _, e := range events {
+			reason := e.Reason
+			if reason == "" {
+				reason = "-"
+			}
+			fmt.Printf("%-20s %-8s %-22s %-8d %s\n",
+				e.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+				e.Action,
+				fmt.Sprintf("%s → %s", e.FromStatus, e.ToStatus),
+				len(e.Workers),
+				reason)
+		}
+		return nil
+	},
+}
+
+func init() {
+	orchestrationCmd.AddCommand(newOrchLifecycleCmd(orchestrator.ActionPause, "Orchestration 일시정지",
+		`새 포트 배정을 멈추고, 진행 중인 워커를 paused로 표시하며 워커 세션의 Lock을 해제합니다.
+'resume'으로 재개하면 일시정지된 워커가 이전 상태로 돌아가고 준비된 포트 배정이 다시 시작됩니다.`))
+	orchestrationCmd.AddCommand(newOrchLifecycleCmd(orchestrator.ActionResume, "Orchestration 재개",
+		`일시정지된 Orchestration과 워커를 다시 실행 상태로 되돌립니다.`))
+	orchestrationCmd.AddCommand(newOrchLifecycleCmd(orchestrator.ActionCancel, "Orchestration 취소",
+		`새 포트 배정을 멈추고, 진행 중이거나 일시정지된 워커와 그 세션을 cancelled로 종료하며 Lock을 해제합니다.
+실행 중이던 포트는 cancelled로 표시되고, 대기 중인 포트는 그대로 남습니다.`))
+	orchestrationCmd.AddCommand(orchLifecycleCmd)
+}
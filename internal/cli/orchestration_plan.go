@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+	"github.com/spf13/cobra"
+)
+
+var orchPlanCmd = &cobra.Command{
+	Use:   "plan [id]",
+	Short: "크리티컬 패스와 예상 완료 시각",
+	Long: `포트 추정치와 의존성 그래프로 크리티컬 패스, 예상 완료 시각, 포트별 여유(slack)를 계산합니다.
+실행 중인 포트는 경과 시간을 뺀 나머지만 남은 것으로 보며, 추정치가 없는 포트는 기본값(1h)으로 계산합니다.
+병렬 워커 수 제한은 고려하지 않습니다.
+
+추정치 설정: pal port estimate <port-id> --hours 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		plan, err := orchestrator.NewService(database, nil, nil).ComputePlan(args[0])
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(plan, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("Orchestration: %s (%s)\n", plan.Title, plan.Status)
+		fmt.Printf("예상 완료: %s (남은 %.1fh)\n", plan.ProjectedCompletion.Local().Format("2006-01-02 15:04"), plan.RemainingHours)
+		if len(plan.CriticalPath) > 0 {
+			fmt.Printf("크리티컬 패스: %s\n", strings.Join(plan.CriticalPath, " → "))
+		}
+		if len(plan.Blocking) > 0 {
+			fmt.Printf("지연 요인: %s\n", strings.Join(plan.Blocking, ", "))
+		}
+		if len(plan.ExternallyBlocked) > 0 {
+			fmt.Printf("🚧 외부 차단: %s (pal port blockers)\n", strings.Join(plan.ExternallyBlocked, ", "))
+		}
+		if plan.MissingEstimates > 0 {
+			fmt.Printf("⚠️  추정치 없는 포트 %d개는 %.0fh로 계산했습니다\n", plan.MissingEstimates, orchestrator.DefaultPortHours)
+		}
+
+		if len(plan.Ports) == 0 {
+			return nil
+		}
+		fmt.Println()
+		fmt.Printf("%-2s %-24s %-10s %7s %7s %-11s %-11s %7s\n", "", "PORT", "STATUS", "EST", "REMAIN", "START", "FINISH", "SLACK")
+		fmt.Println(strings.Repeat("-", 88))
+		for _, p := range plan.Ports {
+			mark := ""
+			if p.Critical {
+				mark = "★"
+			}
+			if p.Blocked {
+				mark += "#"
+			}
+			est := fmt.Sprintf("%.1fh", p.EstimateHours)
+			if !p.Estimated {
+				est += "?"
+			}
+			remain := fmt.Sprintf("%.1fh", p.RemainingHours)
+			if p.Overrun {
+				remain += "!"
+			}
+			fmt.Printf("%-2s %-24s %-10s %7s %7s %-11s %-11s %6.1fh\n",
+				mark,
+				truncate(p.PortID, 24),
+				p.Status,
+				est,
+				remain,
+				p.ProjectedStart.Local().Format("01-02 15:04"),
+				p.ProjectedFinish.Local().Format("01-02 15:04"),
+				p.SlackHours)
+		}
+		fmt.Println("\n★ 크리티컬 패스, ? 추정치 없음(기본값), ! 추정 시간 초과, # 외부 차단")
+		return nil
+	},
+}
+
+func init() {
+	orchestrationCmd.AddCommand(orchPlanCmd)
+}
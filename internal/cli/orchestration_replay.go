@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/n0roo/pal-kit/internal/agentv2"
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	orchReplayAgentVersions []string
+	orchReplayBranch        string
+	orchReplayBase          string
+	orchReplayNoWorktree    bool
+	orchReplayListLimit     int
+)
+
+var orchReplayCmd = &cobra.Command{
+	Use:   "replay <orchestration-id>",
+	Short: "과거 Orchestration을 다른 에이전트 버전으로 재실행",
+	Long: `과거 Orchestration의 포트 구성을 복제하여 지정한 에이전트 버전으로 다시 실행합니다.
+재실행은 별도 git worktree/브랜치에서 진행되므로 작업 트리에 영향을 주지 않으며,
+완료 후 원본 실행과 결과를 비교하여 에이전트 명세 변경을 배포 전에 검증할 수 있습니다.
+
+예시:
+  pal orch replay <orch-id> --agent-version builder@5
+  pal orch replay <orch-id> --agent-version builder@5 --agent-version tester@2
+  pal orch replay compare <replay-id>
+  pal orch replay clean <replay-id>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOrchReplay,
+}
+
+var orchReplayListCmd = &cobra.Command{
+	Use:   "list [orchestration-id]",
+	Short: "재실행 목록",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		sourceID := ""
+		if len(args) > 0 {
+			sourceID = args[0]
+		}
+		svc := orchestrator.NewService(database, nil, nil)
+		replays, err := svc.ListReplays(sourceID, orchReplayListLimit)
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(replays, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(replays) == 0 {
+			fmt.Println("재실행 이력이 없습니다.")
+			return nil
+		}
+
+		fmt.Printf("%-36s %-36s %-8s %s\n", "ID", "Source", "Status", "Agents")
+		fmt.Println(strings.Repeat("-", 110))
+		for _, r := range replays {
+			fmt.Printf("%-36s %-36s %-8s %s\n", r.ID, truncate(r.SourceOrchestrationID, 36), r.Status, formatAgentPins(r.AgentVersions))
+		}
+		return nil
+	},
+}
+
+var orchReplayCompareCmd = &cobra.Command{
+	Use:   "compare <replay-id>",
+	Short: "재실행과 원본 실행 결과 비교",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := orchestrator.NewService(database, nil, nil)
+		cmp, err := svc.CompareReplay(args[0])
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(cmp, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("🔁 재실행 비교: %s\n", cmp.Replay.ID)
+		fmt.Printf("  에이전트: %s\n\n", formatAgentPins(cmp.Replay.AgentVersions))
+
+		fmt.Printf("%-12s %14s %14s\n", "", "Source", "Replay")
+		fmt.Println(strings.Repeat("-", 42))
+		fmt.Printf("%-12s %14s %14s\n", "Status", cmp.Source.Status, cmp.Candidate.Status)
+		fmt.Printf("%-12s %13d%% %13d%%\n", "Progress", cmp.Source.Stats.ProgressPercent, cmp.Candidate.Stats.ProgressPercent)
+		fmt.Printf("%-12s %14d %14d\n", "Completed", cmp.Source.Stats.CompletedPorts, cmp.Candidate.Stats.CompletedPorts)
+		fmt.Printf("%-12s %14d %14d\n", "Failed", cmp.Source.Stats.FailedPorts, cmp.Candidate.Stats.FailedPorts)
+		fmt.Printf("%-12s %14d %14d\n", "Tokens", cmp.Source.TokensUsed, cmp.Candidate.TokensUsed)
+		fmt.Printf("%-12s %14s %14s\n", "Cost", fmt.Sprintf("$%.2f", cmp.Source.CostUSD), fmt.Sprintf("$%.2f", cmp.Candidate.CostUSD))
+		fmt.Printf("%-12s %13ds %13ds\n", "Duration", cmp.Source.DurationSeconds, cmp.Candidate.DurationSeconds)
+
+		if len(cmp.PortDiffs) > 0 {
+			fmt.Println("\n포트 결과 차이:")
+			for _, d := range cmp.PortDiffs {
+				fmt.Printf("  %-30s %s → %s\n", truncate(d.PortID, 30), d.Source, d.Replay)
+			}
+		} else {
+			fmt.Println("\n포트 결과 차이 없음")
+		}
+		return nil
+	},
+}
+
+var orchReplayCleanCmd = &cobra.Command{
+	Use:   "clean <replay-id>",
+	Short: "재실행 worktree/브랜치 정리",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := orchestrator.NewService(database, nil, nil)
+		replay, err := svc.GetReplay(args[0])
+		if err != nil {
+			return err
+		}
+
+		cwd, _ := os.Getwd()
+		projectRoot := context.FindProjectRoot(cwd)
+		if replay.Worktree != "" {
+			if _, err := os.Stat(replay.Worktree); err == nil {
+				if err := runGit(projectRoot, "worktree", "remove", "--force", replay.Worktree); err != nil {
+					return fmt.Errorf("worktree 삭제 실패: %w", err)
+				}
+			}
+		}
+		if replay.Branch != "" {
+			if err := runGit(projectRoot, "branch", "-D", replay.Branch); err != nil && verbose {
+				fmt.Fprintf(os.Stderr, "⚠️  브랜치 삭제 실패: %v\n", err)
+			}
+		}
+
+		if err := svc.MarkReplayCleaned(replay.ID); err != nil {
+			return err
+		}
+		fmt.Printf("✓ 재실행 정리됨: %s\n", replay.ID)
+		return nil
+	},
+}
+
+func init() {
+	orchestrationCmd.AddCommand(orchReplayCmd)
+	orchReplayCmd.Flags().StringArrayVar(&orchReplayAgentVersions, "agent-version", nil, "고정할 에이전트 버전 (name@version, 반복 가능)")
+	orchReplayCmd.Flags().StringVar(&orchReplayBranch, "branch", "", "재실행 브랜치 이름 (기본: pal/replay-<id>)")
+	orchReplayCmd.Flags().StringVar(&orchReplayBase, "base", "HEAD", "worktree를 만들 기준 ref")
+	orchReplayCmd.Flags().BoolVar(&orchReplayNoWorktree, "no-worktree", false, "worktree 없이 기록만 생성")
+
+	orchReplayCmd.AddCommand(orchReplayListCmd)
+	orchReplayCmd.AddCommand(orchReplayCompareCmd)
+	orchReplayCmd.AddCommand(orchReplayCleanCmd)
+	orchReplayListCmd.Flags().IntVar(&orchReplayListLimit, "limit", 20, "최대 개수")
+}
+
+func runOrchReplay(cmd *cobra.Command, args []string) error {
+	if len(orchReplayAgentVersions) == 0 {
+		return fmt.Errorf("--agent-version을 하나 이상 지정하세요 (예: builder@5)")
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	// 에이전트 버전이 레지스트리에 있는지 확인
+	store := agentv2.NewStore(database.DB)
+	var pins []orchestrator.AgentPin
+	for _, spec := range orchReplayAgentVersions {
+		pin, err := orchestrator.ParseAgentPin(spec)
+		if err != nil {
+			return err
+		}
+		agent, err := store.GetAgentByName(pin.Agent)
+		if err != nil {
+			return fmt.Errorf("에이전트를 찾을 수 없습니다: %s", pin.Agent)
+		}
+		if _, err := store.GetVersion(agent.ID, pin.Version); err != nil {
+			return fmt.Errorf("에이전트 버전을 찾을 수 없습니다: %s", pin)
+		}
+		pins = append(pins, pin)
+	}
+
+	svc := orchestrator.NewService(database, nil, nil)
+	if _, err := svc.GetOrchestration(args[0]); err != nil {
+		return fmt.Errorf("원본 Orchestration 조회 실패: %w", err)
+	}
+
+	opts := orchestrator.ReplayOptions{
+		ID:                    uuid.New().String(),
+		SourceOrchestrationID: args[0],
+		AgentVersions:         pins,
+	}
+
+	// 작업 트리와 분리된 worktree에서 재실행
+	if !orchReplayNoWorktree {
+		cwd, _ := os.Getwd()
+		projectRoot := context.FindProjectRoot(cwd)
+		short := opts.ID[:8]
+		opts.Branch = orchReplayBranch
+		if opts.Branch == "" {
+			opts.Branch = "pal/replay-" + short
+		}
+		opts.Worktree = filepath.Join(projectRoot, ".pal", "replays", short)
+		if err := runGit(projectRoot, "worktree", "add", "-b", opts.Branch, opts.Worktree, orchReplayBase); err != nil {
+			return fmt.Errorf("worktree 생성 실패: %w", err)
+		}
+	}
+
+	replay, err := svc.CreateReplay(opts)
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(replay, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("✓ 재실행 시작됨: %s\n", replay.ID)
+	fmt.Printf("  Source:        %s\n", replay.SourceOrchestrationID)
+	fmt.Printf("  Orchestration: %s\n", replay.OrchestrationID)
+	fmt.Printf("  Agents:        %s\n", formatAgentPins(replay.AgentVersions))
+	if replay.Worktree != "" {
+		fmt.Printf("  Worktree:      %s (%s)\n", replay.Worktree, replay.Branch)
+	}
+	fmt.Printf("\n완료 후 비교: pal orch replay compare %s\n", replay.ID)
+	return nil
+}
+
+// runGit runs a git command in dir and returns its output as the error on failure
+func runGit(dir string, args ...string) error {
+	gitCmd := exec.Command("git", args...)
+	gitCmd.Dir = dir
+	if output, err := gitCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func formatAgentPins(pins []orchestrator.AgentPin) string {
+	if len(pins) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(pins))
+	for i, p := range pins {
+		parts[i] = p.String()
+	}
+	return strings.Join(parts, ", ")
+}
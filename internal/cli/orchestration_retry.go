@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/spf13/cobra"
+)
+
+// addRetryPolicyFlags adds the retry policy flags shared by create and retry-policy
+func addRetryPolicyFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("max-retries", 0, "포트당 재시도 횟수 (기본: 3)")
+	cmd.Flags().String("backoff", "", "첫 재시도 대기 시간, 재시도마다 2배 (기본: 5s)")
+	cmd.Flags().Int("escalate-after", 0, "이 횟수만큼 실패하면 재시도 중에도 에스컬레이션 (0: 소진 시에만)")
+}
+
+// retryPolicyFromFlags applies the changed retry flags to base and reports whether any was set
+func retryPolicyFromFlags(cmd *cobra.Command, base orchestrator.RetryPolicy) (orchestrator.RetryPolicy, bool) {
+	changed := false
+	if cmd.Flags().Changed("max-retries") {
+		base.MaxRetries, _ = cmd.Flags().GetInt("max-retries")
+		changed = true
+	}
+	if cmd.Flags().Changed("backoff") {
+		base.Backoff, _ = cmd.Flags().GetString("backoff")
+		changed = true
+	}
+	if cmd.Flags().Changed("escalate-after") {
+		base.EscalateAfter, _ = cmd.Flags().GetInt("escalate-after")
+		changed = true
+	}
+	return base, changed
+}
+
+func formatRetryPolicy(p orchestrator.RetryPolicy) string {
+	backoff := p.Backoff
+	if backoff == "" {
+		backoff = "0s"
+	}
+	escalate := "소진 시"
+	if p.EscalateAfter > 0 {
+		escalate = fmt.Sprintf("%d회 실패 시", p.EscalateAfter)
+	}
+	return fmt.Sprintf("최대 %d회 재시도, backoff %s (2배씩), 에스컬레이션 %s", p.MaxRetries, backoff, escalate)
+}
+
+var orchRetryPolicyCmd = &cobra.Command{
+	Use:   "retry-policy [id]",
+	Short: "재시도 정책 조회/변경",
+	Long: `워커 실패 시 적용할 Orchestration의 재시도 정책을 조회하거나 변경합니다.
+플래그를 주지 않으면 현재 정책을 출력합니다.
+
+예시:
+  pal orch retry-policy <id>
+  pal orch retry-policy <id> --max-retries 5 --backoff 1m --escalate-after 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := orchestrator.NewService(database, nil, nil)
+		orch, err := svc.GetOrchestration(args[0])
+		if err != nil {
+			return err
+		}
+
+		policy, changed := retryPolicyFromFlags(cmd, orch.RetryPolicy)
+		if changed {
+			if err := svc.SetRetryPolicy(orch.ID, policy); err != nil {
+				return err
+			}
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(policy, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if changed {
+			fmt.Printf("✓ 재시도 정책 변경됨: %s\n", formatRetryPolicy(policy))
+		} else {
+			fmt.Printf("재시도 정책: %s\n", formatRetryPolicy(policy))
+		}
+		return nil
+	},
+}
+
+var orchFailCmd = &cobra.Command{
+	Use:   "fail [worker-id]",
+	Short: "워커 실패 보고",
+	Long: `워커 세션을 실패로 종료하고 Orchestration의 재시도 정책을 적용합니다.
+재시도가 남아 있으면 포트가 backoff 후 다시 대기 상태가 되고,
+소진되면 포트가 failed로 표시되며 에스컬레이션이 기록됩니다.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		errMsg, _ := cmd.Flags().GetString("error")
+		svc := orchestrator.NewService(database, session.NewService(database), nil)
+
+		if _, err := svc.GetWorkerSession(args[0]); err != nil {
+			return err
+		}
+		if err := svc.CompleteWorkerSession(args[0], orchestrator.WorkerPairResult{
+			Success:      false,
+			ErrorMessage: errMsg,
+		}); err != nil {
+			return err
+		}
+		outcome, err := svc.HandleWorkerFailure(args[0], errMsg)
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(outcome, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("✗ 포트 %s 실패 (%d회째)\n", outcome.PortID, outcome.Attempt)
+		if outcome.Retrying {
+			fmt.Printf("  재시도 예정: %s (%d/%d)\n", outcome.RetryAt.Local().Format("2006-01-02 15:04:05"), outcome.Attempt, outcome.MaxRetries)
+		} else {
+			fmt.Println("  재시도 소진: 포트가 failed로 표시되었습니다")
+		}
+		if outcome.EscalationID > 0 {
+			fmt.Printf("  에스컬레이션 #%d 기록됨\n", outcome.EscalationID)
+		}
+		return nil
+	},
+}
+
+func init() {
+	addRetryPolicyFlags(orchRetryPolicyCmd)
+	orchFailCmd.Flags().String("error", "", "실패 사유")
+
+	orchestrationCmd.AddCommand(orchRetryPolicyCmd)
+	orchestrationCmd.AddCommand(orchFailCmd)
+}
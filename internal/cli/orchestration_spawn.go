@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var orchSpawnCmd = &cobra.Command{
+	Use:   "spawn [id] [port-id]",
+	Short: "포트 워커 생성",
+	Long: `Orchestration 포트의 워커 세션을 생성하고 포트를 running으로 표시합니다.
+포트 명세 frontmatter에 pair: true가 있으면 직접 채널과 피드백 루프를 공유하는
+Impl/Test 워커 쌍을, 없으면 단일 Impl 워커를 생성합니다.
+
+예시:
+  pal orch spawn <id> auth-login --operator <session-id>`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := orchestrator.NewService(database, session.NewService(database), nil)
+		orch, err := svc.GetOrchestration(args[0])
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, ap := range orch.AtomicPorts {
+			found = found || ap.PortID == args[1]
+		}
+		if !found {
+			return errcode.New(errcode.KindNotFound, "Orchestration에 포트 '%s'이(가) 없습니다", args[1])
+		}
+
+		operator, _ := cmd.Flags().GetString("operator")
+		cwd, _ := os.Getwd()
+		ws, err := svc.SpawnPortWorker(orchestrator.WorkerPairOptions{
+			OrchestrationID:   orch.ID,
+			OperatorSessionID: operator,
+			PortID:            args[1],
+			PortTitle:         args[1],
+			PortSpec:          fmt.Sprintf("Port ID: %s", args[1]),
+			ProjectRoot:       context.FindProjectRoot(cwd),
+		})
+		if err != nil {
+			return err
+		}
+		if err := svc.UpdatePortStatus(orch.ID, args[1], "running"); err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(ws, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("✓ 워커 생성됨: %s (%s)\n", ws.ID, ws.WorkerType)
+		if ws.TestSessionID != "" {
+			fmt.Printf("  Impl: %s\n", ws.ImplSessionID)
+			fmt.Printf("  Test: %s\n", ws.TestSessionID)
+			fmt.Printf("  피드백 루프: %s\n", ws.FeedbackLoopID)
+		} else {
+			fmt.Printf("  세션: %s\n", ws.ImplSessionID)
+		}
+		return nil
+	},
+}
+
+func init() {
+	orchSpawnCmd.Flags().String("operator", "", "상위 Operator 세션 ID")
+	orchestrationCmd.AddCommand(orchSpawnCmd)
+}
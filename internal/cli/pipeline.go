@@ -10,6 +10,7 @@ import (
 
 	"github.com/n0roo/pal-kit/internal/context"
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
 	"github.com/n0roo/pal-kit/internal/pipeline"
 	"github.com/n0roo/pal-kit/internal/port"
 	"github.com/spf13/cobra"
@@ -24,6 +25,8 @@ var (
 	pipelineOutFile  string
 	pipelineDryRun   bool
 	pipelineParallel bool
+
+	pipelineMigrateAll bool
 )
 
 var pipelineCmd = &cobra.Command{
@@ -125,6 +128,21 @@ var plExecCmd = &cobra.Command{
 	RunE: runPlExec,
 }
 
+var plMigrateCmd = &cobra.Command{
+	Use:   "migrate [id]",
+	Short: "파이프라인을 Orchestration으로 이관",
+	Long: `레거시 파이프라인을 Orchestration으로 옮깁니다.
+실행 그룹은 포트 순서로, 파이프라인 안의 포트 의존성은 depends_on으로 옮기며 포트 상태와 시각을 유지합니다.
+이관 후에는 pal orch show <pipeline-id>로도 조회할 수 있고, 목록에는 Orchestration으로만 표시됩니다.
+이미 이관된 파이프라인은 다시 만들지 않습니다.
+
+예시:
+  pal pipeline migrate my-pipeline
+  pal pipeline migrate --all`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPlMigrate,
+}
+
 var plResetCmd = &cobra.Command{
 	Use:   "reset <id>",
 	Short: "파이프라인 상태 초기화",
@@ -147,6 +165,7 @@ func init() {
 	pipelineCmd.AddCommand(plPortStatusCmd)
 	pipelineCmd.AddCommand(plExecCmd)
 	pipelineCmd.AddCommand(plResetCmd)
+	pipelineCmd.AddCommand(plMigrateCmd)
 
 	plListCmd.Flags().StringVar(&pipelineStatus, "status", "", "상태 필터")
 	plListCmd.Flags().IntVar(&pipelineLimit, "limit", 20, "결과 수 제한")
@@ -159,6 +178,8 @@ func init() {
 
 	plExecCmd.Flags().BoolVar(&pipelineDryRun, "dry-run", false, "실제 실행 없이 시뮬레이션")
 	plExecCmd.Flags().BoolVar(&pipelineParallel, "sequential", false, "순차 실행 (병렬 비활성화)")
+
+	plMigrateCmd.Flags().BoolVar(&pipelineMigrateAll, "all", false, "이관되지 않은 모든 파이프라인")
 }
 
 func getPipelineService() (*pipeline.Service, func(), error) {
@@ -823,3 +844,52 @@ func runPlPortStatus(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runPlMigrate(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !pipelineMigrateAll {
+		return fmt.Errorf("파이프라인 ID 또는 --all을 지정하세요")
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	svc := orchestrator.NewService(database, nil, nil)
+	var migrations []*orchestrator.PipelineMigration
+	if len(args) == 1 {
+		m, err := svc.MigratePipeline(args[0])
+		if err != nil {
+			return err
+		}
+		migrations = append(migrations, m)
+	} else {
+		migrations, err = svc.MigrateAllPipelines()
+		if err != nil {
+			return err
+		}
+	}
+
+	if IsJSON() {
+		if migrations == nil {
+			migrations = []*orchestrator.PipelineMigration{}
+		}
+		data, _ := json.MarshalIndent(migrations, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(migrations) == 0 {
+		fmt.Println("이관할 파이프라인이 없습니다.")
+		return nil
+	}
+	for _, m := range migrations {
+		if m.Created {
+			fmt.Printf("✓ %s → Orchestration %s (포트 %d개)\n", m.PipelineID, m.OrchestrationID, m.Ports)
+		} else {
+			fmt.Printf("- %s: 이미 이관됨 → %s\n", m.PipelineID, m.OrchestrationID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/n0roo/pal-kit/internal/plan"
+	"github.com/spf13/cobra"
+)
+
+var (
+	planOutput string
+	planPrefix string
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "작업 계획 도구",
+	Long:  `기능 스펙을 포트 단위 작업 계획으로 분해합니다.`,
+}
+
+var planFromSpecCmd = &cobra.Command{
+	Use:   "from-spec <spec.md>",
+	Short: "스펙 문서에서 포트 계획 생성",
+	Long: `기능 스펙 문서의 제목/수용 기준을 분석하여 원자 포트 후보와 의존성을 제안합니다.
+
+분해 규칙 (휴리스틱):
+  - # 제목       → 계획 제목
+  - ## 섹션      → 포트 후보 (본문 없이 ### 하위 섹션만 있으면 하위 섹션이 포트)
+  - 수용 기준/Acceptance Criteria 섹션, 체크박스 항목 → acceptance
+  - 개요/배경/목표 등 → 포트에서 제외
+  - "Depends on: <섹션>" / "의존: <섹션>" → 명시적 의존성
+  - 명시가 없으면 레이어(데이터 → 로직 → UI → 검증) 순서로 의존성 추론
+
+결과 YAML은 'pal port import'의 입력으로 사용할 수 있습니다.
+
+예시:
+  pal plan from-spec docs/feature.md
+  pal plan from-spec docs/feature.md -o plans/feature.yaml --prefix auth`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanFromSpec,
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	planCmd.AddCommand(planFromSpecCmd)
+
+	planFromSpecCmd.Flags().StringVarP(&planOutput, "output", "o", "", "YAML 출력 파일 (기본: stdout)")
+	planFromSpecCmd.Flags().StringVar(&planPrefix, "prefix", "", "포트 ID 접두사 (기본: 제목에서 생성)")
+}
+
+func runPlanFromSpec(cmd *cobra.Command, args []string) error {
+	p, err := plan.FromSpec(args[0], plan.Options{Prefix: planPrefix})
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(p)
+		return nil
+	}
+
+	data, err := p.YAML()
+	if err != nil {
+		return err
+	}
+
+	if planOutput == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(planOutput, data, 0644); err != nil {
+		return fmt.Errorf("계획 파일 저장 실패: %w", err)
+	}
+
+	fmt.Printf("✓ 포트 계획 생성: %s\n", planOutput)
+	fmt.Printf("  제목: %s\n", p.Title)
+	fmt.Printf("  포트: %d개\n", len(p.Ports))
+	for _, port := range p.Ports {
+		deps := ""
+		if len(port.DependsOn) > 0 {
+			deps = fmt.Sprintf(" ← %v", port.DependsOn)
+		}
+		fmt.Printf("    %s  %s%s\n", port.ID, port.Title, deps)
+	}
+	fmt.Printf("\n검토 후 가져오기: pal port import %s\n", planOutput)
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/n0roo/pal-kit/internal/context"
 	"github.com/n0roo/pal-kit/internal/db"
@@ -131,6 +132,13 @@ func getPortService() (*port.Service, func(), error) {
 func runPortCreate(cmd *cobra.Command, args []string) error {
 	portID := args[0]
 
+	if portTemplateName != "" {
+		return runPortCreateFromTemplate(portID)
+	}
+	if len(portDependsOn) > 0 || len(portConventions) > 0 || portDescription != "" {
+		return fmt.Errorf("--depends-on, --convention, --description은 --template과 함께 사용해야 합니다")
+	}
+
 	svc, cleanup, err := getPortService()
 	if err != nil {
 		return err
@@ -246,6 +254,12 @@ func runPortStatus(cmd *cobra.Command, args []string) error {
 	if err := svc.UpdateStatus(portID, newStatus); err != nil {
 		return err
 	}
+	if newStatus == port.StatusComplete {
+		cwd, _ := os.Getwd()
+		if _, err := storePortSummary(svc, context.FindProjectRoot(cwd), portID); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  포트 요약 생성 실패: %v\n", err)
+		}
+	}
 
 	if jsonOut {
 		json.NewEncoder(os.Stdout).Encode(map[string]string{
@@ -255,11 +269,12 @@ func runPortStatus(cmd *cobra.Command, args []string) error {
 		})
 	} else {
 		statusEmoji := map[string]string{
-			"pending":  "⏳",
-			"running":  "🔄",
-			"complete": "✅",
-			"failed":   "❌",
-			"blocked":  "🚫",
+			"pending":      "⏳",
+			"running":      "🔄",
+			"complete":     "✅",
+			"failed":       "❌",
+			"blocked":      "🚫",
+			"needs-review": "🔍",
 		}
 		emoji := statusEmoji[newStatus]
 		fmt.Printf("%s 포트 상태 변경: %s → %s\n", emoji, portID, newStatus)
@@ -352,6 +367,65 @@ func runPortShow(cmd *cobra.Command, args []string) error {
 		fmt.Printf("완료: %s\n", p.CompletedAt.Time.Format("2006-01-02 15:04:05"))
 	}
 
+	if p.InputTokens+p.OutputTokens > 0 {
+		fmt.Printf("토큰: 입력 %s / 출력 %s / 캐시 읽기 %s / 캐시 생성 %s\n",
+			formatNumber(p.InputTokens), formatNumber(p.OutputTokens),
+			formatNumber(p.CacheReadTokens), formatNumber(p.CacheCreateTokens))
+		fmt.Printf("비용: $%.4f\n", p.CostUSD)
+	}
+	if est, err := svc.GetEstimate(portID); err == nil && !est.IsZero() {
+		fmt.Printf("추정: %s\n", formatEstimate(est))
+		if p.Status == port.StatusComplete {
+			fmt.Printf("      %s\n", compareEstimate(est, p.InputTokens+p.OutputTokens, float64(p.DurationSecs)/3600))
+		}
+	}
+
+	// 외부 차단 요인
+	if blockers, err := svc.Blockers(portID, false); err == nil && len(blockers) > 0 {
+		fmt.Println()
+		fmt.Printf("차단 요인 (%d):\n", len(blockers))
+		now := time.Now()
+		for _, b := range blockers {
+			fmt.Printf("  %s\n", formatBlocker(b, now))
+		}
+	}
+
+	// 포트에서 수정된 파일
+	if changes, err := svc.GetFileChanges(portID); err == nil && len(changes) > 0 {
+		var totalAdd, totalDel int
+		fmt.Println()
+		fmt.Printf("변경 파일 (%d):\n", len(changes))
+		for _, c := range changes {
+			fmt.Printf("  %-50s +%-5d -%-5d (%d회)\n", c.FilePath, c.Additions, c.Deletions, c.Edits)
+			totalAdd += c.Additions
+			totalDel += c.Deletions
+		}
+		fmt.Printf("  합계: +%d -%d\n", totalAdd, totalDel)
+	}
+
+	// 명세의 완료 기준
+	cwd, _ := os.Getwd()
+	if projectRoot := context.FindProjectRoot(cwd); projectRoot != "" {
+		if criteria, err := svc.AcceptanceCriteria(portID, projectRoot); err == nil && len(criteria) > 0 {
+			fmt.Println()
+			fmt.Printf("완료 기준 (%d/%d):\n", len(criteria)-len(port.Remaining(criteria)), len(criteria))
+			for _, c := range criteria {
+				mark := " "
+				if c.Done {
+					mark = "x"
+				}
+				fmt.Printf("  [%s] %s\n", mark, c.Text)
+			}
+		}
+	}
+
+	// 의존 포트에 전달되는 변경 요약
+	if summary, err := svc.GetSummary(portID); err == nil && summary != "" {
+		fmt.Println()
+		fmt.Println("변경 요약:")
+		fmt.Println(summary)
+	}
+
 	return nil
 }
 
@@ -405,13 +479,15 @@ func runPortSummary(cmd *cobra.Command, args []string) error {
 	fmt.Printf("포트 요약 (총 %d개)\n", total)
 	fmt.Println(strings.Repeat("-", 30))
 
-	statusOrder := []string{"pending", "running", "complete", "failed", "blocked"}
+	statusOrder := []string{"pending", "running", "complete", "failed", "blocked", "needs-review", "archived"}
 	statusEmoji := map[string]string{
-		"pending":  "⏳",
-		"running":  "🔄",
-		"complete": "✅",
-		"failed":   "❌",
-		"blocked":  "🚫",
+		"pending":      "⏳",
+		"running":      "🔄",
+		"complete":     "✅",
+		"failed":       "❌",
+		"blocked":      "🚫",
+		"needs-review": "🔍",
+		"archived":     "📦",
 	}
 
 	for _, s := range statusOrder {
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portArchiveExpired bool
+	portArchiveDays    int
+)
+
+var portArchiveCmd = &cobra.Command{
+	Use:   "archive [port-id...]",
+	Short: "완료된 포트 아카이브",
+	Long: `완료/실패한 포트의 명세와 rules 파일을 .pal/archive/ports/<id>/로 옮기고
+DB에는 archived 상태의 포트 행만 남깁니다. pal port restore로 되돌릴 수 있습니다.
+
+--expired는 보관 정책(settings.port_archive_days, 기본 30일)보다 오래 전에 완료된
+포트를 모두 아카이브합니다. pal serve 데몬도 같은 정책을 주기적으로 적용합니다.`,
+	Example: `  pal port archive auth-login
+  pal port archive --expired
+  pal port archive --expired --older-than 7`,
+	RunE: runPortArchive,
+}
+
+var portRestoreCmd = &cobra.Command{
+	Use:   "restore <port-id>",
+	Short: "아카이브된 포트 복원",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPortRestore,
+}
+
+var portArchivedCmd = &cobra.Command{
+	Use:   "archived",
+	Short: "아카이브된 포트 목록",
+	RunE:  runPortArchived,
+}
+
+func init() {
+	portCmd.AddCommand(portArchiveCmd)
+	portCmd.AddCommand(portRestoreCmd)
+	portCmd.AddCommand(portArchivedCmd)
+
+	portArchiveCmd.Flags().BoolVar(&portArchiveExpired, "expired", false, "보관 기간이 지난 완료 포트 전체")
+	portArchiveCmd.Flags().IntVar(&portArchiveDays, "older-than", 0, "완료 후 경과 일수 (--expired, 기본: 설정값)")
+}
+
+func runPortArchive(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !portArchiveExpired {
+		return fmt.Errorf("포트 ID 또는 --expired가 필요합니다")
+	}
+
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	if projectRoot == "" {
+		return fmt.Errorf("프로젝트 루트를 찾을 수 없습니다")
+	}
+
+	svc, cleanup, err := getPortService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	var archived []port.Archive
+	for _, id := range args {
+		a, err := svc.Archive(id, projectRoot, port.ArchiveReasonManual)
+		if err != nil {
+			return err
+		}
+		archived = append(archived, *a)
+	}
+
+	if portArchiveExpired {
+		olderThan := time.Duration(portArchiveDays) * 24 * time.Hour
+		if portArchiveDays <= 0 {
+			olderThan = time.Duration(config.DefaultPortArchiveDays) * 24 * time.Hour
+			if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+				olderThan = projectCfg.Settings.PortArchiveAfter()
+			}
+		}
+		if olderThan == 0 {
+			return fmt.Errorf("포트 자동 보관이 비활성화되어 있습니다 (settings.port_archive_days: -1). --older-than을 지정하세요")
+		}
+		expired, err := svc.ArchiveExpired(projectRoot, olderThan, time.Now())
+		archived = append(archived, expired...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jsonOut {
+		if archived == nil {
+			archived = []port.Archive{}
+		}
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"archived": archived,
+		})
+		return nil
+	}
+
+	if len(archived) == 0 {
+		fmt.Println("아카이브할 포트가 없습니다.")
+		return nil
+	}
+	for _, a := range archived {
+		fmt.Printf("📦 포트 아카이브: %s → %s (파일 %d개)\n", a.PortID, a.ArchiveDir, len(a.Files))
+	}
+	return nil
+}
+
+func runPortRestore(cmd *cobra.Command, args []string) error {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	if projectRoot == "" {
+		return fmt.Errorf("프로젝트 루트를 찾을 수 없습니다")
+	}
+
+	svc, cleanup, err := getPortService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	a, err := svc.RestoreArchive(args[0], projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"status": "restored",
+			"port":   a.PortID,
+			"state":  a.PrevStatus,
+			"files":  a.Files,
+		})
+		return nil
+	}
+
+	fmt.Printf("✓ 포트 복원: %s (%s)\n", a.PortID, a.PrevStatus)
+	for _, f := range a.Files {
+		fmt.Printf("  %s\n", f.From)
+	}
+	return nil
+}
+
+func runPortArchived(cmd *cobra.Command, args []string) error {
+	svc, cleanup, err := getPortService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	archives, err := svc.ListArchives()
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		if archives == nil {
+			archives = []port.Archive{}
+		}
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"archives": archives,
+		})
+		return nil
+	}
+
+	if len(archives) == 0 {
+		fmt.Println("아카이브된 포트가 없습니다.")
+		return nil
+	}
+
+	fmt.Printf("%-24s %-10s %-10s %s\n", "PORT", "STATUS", "REASON", "ARCHIVED")
+	for _, a := range archives {
+		fmt.Printf("%-24s %-10s %-10s %s\n", a.PortID, a.PrevStatus, a.Reason, a.ArchivedAt.Local().Format("2006-01-02 15:04"))
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/spf13/cobra"
+)
+
+// backfillHintThreshold is the untracked edit count at which session-end suggests a backfill
+const backfillHintThreshold = 5
+
+var (
+	backfillPortID string
+	backfillTitle  string
+	backfillDryRun bool
+)
+
+var portBackfillCmd = &cobra.Command{
+	Use:   "backfill <session-id>",
+	Short: "추적되지 않은 작업으로 회고 포트 생성",
+	Long: `포트 없이 진행된 세션의 파일 수정(untracked_edit)을 모아
+완료된 회고 포트와 자동 초안 명세를 생성합니다.
+
+수정 파일은 디렉토리별로 묶여 명세에 기록되고,
+세션 이벤트는 file_edit으로 변경되어 포트에 귀속됩니다.
+
+예시:
+  pal port backfill abc123 --dry-run
+  pal port backfill abc123 --id auth-refactor --title "인증 리팩토링"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPortBackfill,
+}
+
+func init() {
+	portCmd.AddCommand(portBackfillCmd)
+
+	portBackfillCmd.Flags().StringVar(&backfillPortID, "id", "", "생성할 포트 ID (기본: backfill-<session-id>)")
+	portBackfillCmd.Flags().StringVar(&backfillTitle, "title", "", "포트 제목")
+	portBackfillCmd.Flags().BoolVar(&backfillDryRun, "dry-run", false, "생성하지 않고 초안만 출력")
+}
+
+// collectUntrackedEdits reads untracked_edit events of a session as backfill edits
+func collectUntrackedEdits(sessionSvc *session.Service, sessionID, projectRoot string) ([]port.BackfillEdit, error) {
+	events, err := sessionSvc.GetEvents(sessionID, session.EventUntrackedEdit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var edits []port.BackfillEdit
+	for i := len(events) - 1; i >= 0; i-- {
+		var data struct {
+			Tool string `json:"tool"`
+			File string `json:"file"`
+		}
+		if err := json.Unmarshal([]byte(events[i].EventData), &data); err != nil || data.File == "" {
+			continue
+		}
+		edits = append(edits, port.BackfillEdit{
+			FilePath: projectRelPath(data.File, projectRoot),
+			Tool:     data.Tool,
+			EditedAt: events[i].CreatedAt,
+		})
+	}
+	return edits, nil
+}
+
+func runPortBackfill(cmd *cobra.Command, args []string) error {
+	sessionID := args[0]
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	sessionSvc := session.NewService(database)
+	portSvc := port.NewService(database)
+
+	sess, err := sessionSvc.Get(sessionID)
+	if err != nil {
+		return err
+	}
+	projectRoot := ""
+	if sess.ProjectRoot.Valid {
+		projectRoot = sess.ProjectRoot.String
+	}
+	if projectRoot == "" {
+		cwd, _ := os.Getwd()
+		projectRoot = cwd
+	}
+
+	edits, err := collectUntrackedEdits(sessionSvc, sessionID, projectRoot)
+	if err != nil {
+		return err
+	}
+	if len(edits) == 0 {
+		return fmt.Errorf("세션 '%s'에 추적되지 않은 파일 수정이 없습니다", sessionID)
+	}
+
+	portID := backfillPortID
+	if portID == "" {
+		portID = "backfill-" + sessionID
+	}
+	title := backfillTitle
+	if title == "" && sess.Title.Valid && sess.Title.String != "" && sess.Title.String != "-" {
+		title = sess.Title.String
+	}
+	if title == "" {
+		title = fmt.Sprintf("Backfill: %s", sessionID)
+	}
+
+	opts := port.BackfillOptions{
+		PortID:    portID,
+		Title:     title,
+		FilePath:  fmt.Sprintf("ports/%s.md", portID),
+		SessionID: sessionID,
+		Edits:     edits,
+	}
+	spec := port.DraftBackfillSpec(opts)
+	groups := port.GroupEditedFiles(edits)
+
+	if backfillDryRun {
+		if jsonOut {
+			return json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+				"port_id": portID,
+				"title":   title,
+				"edits":   len(edits),
+				"groups":  groups,
+				"spec":    spec,
+			})
+		}
+		fmt.Printf("🔍 회고 포트 초안: %s (편집 %d회)\n\n", portID, len(edits))
+		fmt.Print(spec)
+		return nil
+	}
+
+	if err := portSvc.Backfill(opts); err != nil {
+		return err
+	}
+
+	specPath := filepath.Join(projectRoot, opts.FilePath)
+	if err := os.MkdirAll(filepath.Dir(specPath), 0755); err == nil {
+		if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  명세 저장 실패: %v\n", err)
+		}
+	}
+
+	retagged, _ := sessionSvc.RetagEvents(sessionID, session.EventUntrackedEdit, session.EventFileEdit)
+	sessionSvc.LogEvent(sessionID, "port_backfill",
+		fmt.Sprintf(`{"port":"%s","edits":%d}`, portID, len(edits)))
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"status":   "created",
+			"port_id":  portID,
+			"title":    title,
+			"spec":     specPath,
+			"edits":    len(edits),
+			"groups":   groups,
+			"retagged": retagged,
+		})
+	}
+
+	fmt.Printf("✓ 회고 포트 생성: %s\n", portID)
+	fmt.Printf("  제목: %s\n", title)
+	fmt.Printf("  명세: %s\n", specPath)
+	fmt.Printf("  파일: %d개 (%d개 디렉토리), 편집 %d회\n", countBackfillFiles(groups), len(groups), len(edits))
+	return nil
+}
+
+func countBackfillFiles(groups []port.BackfillGroup) int {
+	n := 0
+	for _, g := range groups {
+		n += len(g.Files)
+	}
+	return n
+}
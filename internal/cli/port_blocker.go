@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portBlockUntil    string
+	portUnblockID     int64
+	portBlockersAll   bool
+	portBlockersStale bool
+)
+
+var portBlockCmd = &cobra.Command{
+	Use:   "block <id> <reason...>",
+	Short: "포트에 외부 차단 요인 추가",
+	Long: `포트가 외부 상황(API 키 대기, 디자인 승인 등)으로 진행할 수 없음을 기록합니다.
+빌드 의존성과 별개이며, 차단 요인이 남아 있는 포트는 Orchestration이 워커를 배정하지 않고
+브리핑에 차단 사유와 해소 예정일이 표시됩니다.
+
+예시:
+  pal port block payment-api "결제사 API 키 발급 대기" --until 2025-06-10
+  pal port block onboarding-ui "디자인 승인 대기"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var until *time.Time
+		if portBlockUntil != "" {
+			t, err := time.ParseInLocation("2006-01-02", portBlockUntil, time.Local)
+			if err != nil {
+				return errcode.New(errcode.KindValidation, "잘못된 날짜입니다: %s (예: 2025-06-10)", portBlockUntil)
+			}
+			until = &t
+		}
+
+		svc, cleanup, err := getPortService()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		b, err := svc.AddBlocker(args[0], strings.Join(args[1:], " "), until)
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			data, _ := json.MarshalIndent(b, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		fmt.Printf("🚧 차단 요인 추가: %s #%d %s\n", b.PortID, b.ID, b.Reason)
+		if b.ExpectedAt != nil {
+			fmt.Printf("   해소 예정: %s\n", b.ExpectedAt.Format("2006-01-02"))
+		}
+		return nil
+	},
+}
+
+var portUnblockCmd = &cobra.Command{
+	Use:   "unblock <id> [note...]",
+	Short: "포트 차단 요인 해소",
+	Long: `포트의 차단 요인을 해소합니다. --blocker를 주지 않으면 모든 차단 요인을 해소합니다.
+
+예시:
+  pal port unblock payment-api "키 발급 완료"
+  pal port unblock onboarding-ui --blocker 3`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, cleanup, err := getPortService()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		resolved, err := svc.ResolveBlockers(args[0], portUnblockID, strings.Join(args[1:], " "))
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			data, _ := json.MarshalIndent(resolved, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		for _, b := range resolved {
+			fmt.Printf("✓ 차단 해소: %s #%d %s\n", b.PortID, b.ID, b.Reason)
+		}
+		return nil
+	},
+}
+
+var portBlockersCmd = &cobra.Command{
+	Use:   "blockers [id]",
+	Short: "포트 차단 요인 목록",
+	Long: `차단 중인 포트와 사유, 해소 예정일을 보여줍니다.
+포트 ID를 주면 해당 포트만, --all이면 해소된 이력까지 표시합니다.
+
+예시:
+  pal port blockers
+  pal port blockers --overdue
+  pal port blockers payment-api --all`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, cleanup, err := getPortService()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		portID := ""
+		if len(args) > 0 {
+			portID = args[0]
+		}
+		blockers, err := svc.Blockers(portID, portBlockersAll)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if portBlockersStale {
+			overdue := []port.Blocker{}
+			for _, b := range blockers {
+				if b.Overdue(now) {
+					overdue = append(overdue, b)
+				}
+			}
+			blockers = overdue
+		}
+
+		if jsonOut {
+			data, _ := json.MarshalIndent(blockers, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		if len(blockers) == 0 {
+			fmt.Println("차단 요인이 없습니다.")
+			return nil
+		}
+		for _, b := range blockers {
+			fmt.Println(formatBlocker(b, now))
+		}
+		return nil
+	},
+}
+
+// formatBlocker renders a blocker as one line
+func formatBlocker(b port.Blocker, now time.Time) string {
+	icon := "🚧"
+	switch {
+	case !b.Active():
+		icon = "✓"
+	case b.Overdue(now):
+		icon = "⏰"
+	}
+	line := fmt.Sprintf("%s %s #%d %s", icon, b.PortID, b.ID, b.Reason)
+	if b.ExpectedAt != nil {
+		line += fmt.Sprintf(" (예정 %s", b.ExpectedAt.Format("2006-01-02"))
+		if b.Overdue(now) {
+			line += ", 지연"
+		}
+		line += ")"
+	}
+	if b.ResolvedAt != nil {
+		line += fmt.Sprintf(" - 해소 %s", b.ResolvedAt.Local().Format("2006-01-02"))
+		if b.Resolution != "" {
+			line += ": " + b.Resolution
+		}
+	}
+	return line
+}
+
+func init() {
+	portBlockCmd.Flags().StringVar(&portBlockUntil, "until", "", "해소 예정일 (YYYY-MM-DD)")
+	portUnblockCmd.Flags().Int64Var(&portUnblockID, "blocker", 0, "해소할 차단 요인 번호 (기본: 전체)")
+	portBlockersCmd.Flags().BoolVar(&portBlockersAll, "all", false, "해소된 차단 요인 포함")
+	portBlockersCmd.Flags().BoolVar(&portBlockersStale, "overdue", false, "예정일이 지난 차단 요인만")
+	portCmd.AddCommand(portBlockCmd)
+	portCmd.AddCommand(portUnblockCmd)
+	portCmd.AddCommand(portBlockersCmd)
+}
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var portEnvExport bool
+
+var portEnvCmd = &cobra.Command{
+	Use:   "env <id>",
+	Short: "포트가 요구하는 환경 변수/시크릿 확인",
+	Long: `포트 명세의 "## 환경 변수" 섹션에 선언된 변수와 소스, 준비 여부를 표시합니다.
+값은 표시하지 않으며, --export를 지정하면 셸에서 eval 가능한 export 문을 출력합니다.
+
+선언 예시 (포트 명세):
+
+  ## 환경 변수
+
+  ` + "```yaml" + `
+  env:
+    - name: DATABASE_URL
+      from: env_file:.env.test        # .env 파일 (#KEY로 다른 키 지정 가능)
+    - name: STRIPE_KEY
+      from: keychain:stripe/test      # OS keychain (service/account)
+    - name: AWS_PROFILE               # from 생략: 현재 환경 변수
+      optional: true
+  ` + "```" + `
+
+예시:
+  pal port env auth-api
+  eval "$(pal port env auth-api --export)"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPortEnv,
+}
+
+var portRunCmd = &cobra.Command{
+	Use:   "run <id> -- <command> [args...]",
+	Short: "포트 환경 변수를 주입하여 워커 프로세스 실행",
+	Long: `포트가 선언한 환경 변수/시크릿을 주입한 상태로 명령을 실행합니다.
+필수 변수가 없으면 실행하지 않습니다.
+
+포트의 Worker 세션이 있으면 주입한 변수 이름(값 제외)을 Worker 결과에 기록하여
+나중에 동일한 환경을 재현할 수 있게 합니다.
+
+예시:
+  pal port run auth-api -- claude
+  pal port run auth-api -- go test ./...`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runPortRun,
+}
+
+func init() {
+	portCmd.AddCommand(portEnvCmd)
+	portCmd.AddCommand(portRunCmd)
+
+	portEnvCmd.Flags().BoolVar(&portEnvExport, "export", false, "export 문 출력 (값 포함)")
+}
+
+// resolvePortEnv loads and resolves a port's env declaration
+func resolvePortEnv(database *db.DB, portID string) ([]port.EnvVar, map[string]string, error) {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+
+	vars, err := port.NewService(database).EnvFor(portID, projectRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := port.ResolveEnv(vars, projectRoot)
+	return vars, values, err
+}
+
+func runPortEnv(cmd *cobra.Command, args []string) error {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	vars, values, resolveErr := resolvePortEnv(database, args[0])
+	if vars == nil && resolveErr != nil {
+		return resolveErr
+	}
+
+	if portEnvExport {
+		if resolveErr != nil {
+			return resolveErr
+		}
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("export %s=%s\n", name, shellQuote(values[name]))
+		}
+		return nil
+	}
+
+	if IsJSON() {
+		type envStatus struct {
+			port.EnvVar
+			Available bool `json:"available"`
+		}
+		items := make([]envStatus, 0, len(vars))
+		for _, v := range vars {
+			_, ok := values[v.Name]
+			items = append(items, envStatus{EnvVar: v, Available: ok})
+		}
+		data, _ := json.MarshalIndent(map[string]interface{}{
+			"port_id": args[0],
+			"env":     items,
+		}, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(vars) == 0 {
+		fmt.Printf("포트 %s에 선언된 환경 변수가 없습니다\n", args[0])
+		return nil
+	}
+
+	fmt.Printf("🔐 포트 %s 환경 변수\n", args[0])
+	for _, v := range vars {
+		icon := "✅"
+		if _, ok := values[v.Name]; !ok {
+			icon = "❌"
+			if v.Optional {
+				icon = "➖"
+			}
+		}
+		kind, ref := v.Source()
+		fmt.Printf("  %s %-24s %s:%s\n", icon, v.Name, kind, ref)
+	}
+	if resolveErr != nil {
+		fmt.Printf("\n⚠️  %v\n", resolveErr)
+	}
+	return nil
+}
+
+func runPortRun(cmd *cobra.Command, args []string) error {
+	portID, command := args[0], args[1:]
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+
+	vars, values, err := resolvePortEnv(database, portID)
+	if err != nil {
+		database.Close()
+		return err
+	}
+
+	// 주입한 변수 이름을 Worker 결과에 기록 (재현용)
+	if len(vars) > 0 {
+		orchSvc := orchestrator.NewService(database, session.NewService(database), nil)
+		if ws, err := orchSvc.GetWorkerSessionByPort(portID); err == nil {
+			orchSvc.RecordWorkerEnv(ws.ID, port.EnvNames(vars))
+		}
+	}
+	database.Close()
+
+	env := os.Environ()
+	for name, value := range values {
+		env = append(env, name+"="+value)
+	}
+
+	c := exec.Command(command[0], command[1:]...)
+	c.Env = env
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if verbose {
+		fmt.Fprintf(os.Stderr, "🔐 환경 변수 %d개 주입: %v\n", len(values), port.EnvNames(vars))
+	}
+	if err := c.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		return fmt.Errorf("명령 실행 실패: %w", err)
+	}
+	return nil
+}
+
+// shellQuote quotes a value for POSIX shells. eval로 실행되므로 $, `, \ 등이 해석되지 않도록
+// 작은따옴표로 감싸고 값 안의 작은따옴표는 '\” 로 바꿉니다.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
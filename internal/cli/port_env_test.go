@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestShellQuote(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	// eval "$(pal port env --export)"로 실행되어도 값이 그대로 전달되어야 함
+	for _, value := range []string{
+		"plain",
+		"$(echo injected) `echo injected` $HOME",
+		"it's \"quoted\"",
+		"back\\slash\nnewline\x01",
+		"",
+	} {
+		cmd := exec.Command(sh, "-c", `eval "$(cat)"; printf '%s' "$V"`)
+		cmd.Stdin = strings.NewReader("export V=" + shellQuote(value) + "\n")
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("sh failed for %q: %v", value, err)
+		}
+		if string(out) != value {
+			t.Errorf("shellQuote(%q) round trip = %q", value, out)
+		}
+	}
+}
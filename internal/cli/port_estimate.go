@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portEstimateTokens int64
+	portEstimateHours  float64
+	portEstimateSince  int
+	portEstimateBy     string
+)
+
+var portEstimateCmd = &cobra.Command{
+	Use:   "estimate <port-id>",
+	Short: "포트 추정치 기록/조회",
+	Long: `포트의 예상 토큰과 작업 시간을 기록합니다.
+port-end에서 실제 사용량과 비교되며, 플래그 없이 실행하면 추정치와 실제값을 표시합니다.`,
+	Example: `  pal port estimate auth-login --tokens 80000 --hours 1.5
+  pal port estimate auth-login`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPortEstimate,
+}
+
+var portEstimateReportCmd = &cobra.Command{
+	Use:   "estimate-report",
+	Short: "추정 정확도 리포트 (에이전트/주별)",
+	Long: `추정치가 있는 완료 포트의 실제/추정 비율을 에이전트(워커)별, 주별로 집계합니다.
+비율 1.0은 정확한 추정, 1보다 크면 과소 추정(포트가 예상보다 큼)입니다.
+적중은 실제값이 추정치의 ±25% 이내인 포트 수입니다.`,
+	RunE: runPortEstimateReport,
+}
+
+func init() {
+	portCmd.AddCommand(portEstimateCmd)
+	portCmd.AddCommand(portEstimateReportCmd)
+
+	portEstimateCmd.Flags().Int64Var(&portEstimateTokens, "tokens", 0, "예상 토큰 (input+output)")
+	portEstimateCmd.Flags().Float64Var(&portEstimateHours, "hours", 0, "예상 작업 시간")
+	portEstimateReportCmd.Flags().IntVar(&portEstimateSince, "since", 0, "최근 N일 (기본: 전체)")
+	portEstimateReportCmd.Flags().StringVar(&portEstimateBy, "by", "", "집계 기준 (agent|week, 기본: 둘 다)")
+}
+
+func runPortEstimate(cmd *cobra.Command, args []string) error {
+	portID := args[0]
+
+	svc, cleanup, err := getPortService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if cmd.Flags().Changed("tokens") || cmd.Flags().Changed("hours") {
+		est, err := svc.GetEstimate(portID)
+		if err != nil {
+			return err
+		}
+		if cmd.Flags().Changed("tokens") {
+			est.Tokens = portEstimateTokens
+		}
+		if cmd.Flags().Changed("hours") {
+			est.Hours = portEstimateHours
+		}
+		if err := svc.SetEstimate(portID, est); err != nil {
+			return err
+		}
+		if jsonOut {
+			json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+				"status":   "updated",
+				"port":     portID,
+				"estimate": est,
+			})
+			return nil
+		}
+		fmt.Printf("📏 추정치 기록: %s (%s)\n", portID, formatEstimate(est))
+		return nil
+	}
+
+	p, err := svc.Get(portID)
+	if err != nil {
+		return err
+	}
+	est, err := svc.GetEstimate(portID)
+	if err != nil {
+		return err
+	}
+	actualTokens := p.InputTokens + p.OutputTokens
+	actualHours := float64(p.DurationSecs) / 3600
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"port":          portID,
+			"status":        p.Status,
+			"estimate":      est,
+			"actual_tokens": actualTokens,
+			"actual_hours":  actualHours,
+		})
+		return nil
+	}
+
+	if est.IsZero() {
+		fmt.Printf("포트 '%s'에 추정치가 없습니다. (pal port estimate %s --tokens N --hours H)\n", portID, portID)
+		return nil
+	}
+	fmt.Printf("📏 %s 추정: %s\n", portID, formatEstimate(est))
+	if p.Status == port.StatusComplete {
+		fmt.Printf("   %s\n", compareEstimate(est, actualTokens, actualHours))
+	}
+	return nil
+}
+
+func runPortEstimateReport(cmd *cobra.Command, args []string) error {
+	if portEstimateBy != "" && portEstimateBy != "agent" && portEstimateBy != "week" {
+		return fmt.Errorf("알 수 없는 집계 기준: %s (agent|week)", portEstimateBy)
+	}
+
+	svc, cleanup, err := getPortService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	var since time.Time
+	if portEstimateSince > 0 {
+		since = time.Now().AddDate(0, 0, -portEstimateSince)
+	}
+	results, err := svc.EstimateResults(since)
+	if err != nil {
+		return err
+	}
+
+	var byAgent, byWeek []port.EstimateAccuracy
+	if portEstimateBy != "week" {
+		byAgent = port.AggregateEstimates(results, port.ByAgent)
+	}
+	if portEstimateBy != "agent" {
+		byWeek = port.AggregateEstimates(results, port.ByWeek)
+	}
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"ports":    len(results),
+			"by_agent": byAgent,
+			"by_week":  byWeek,
+		})
+		return nil
+	}
+
+	if len(results) == 0 {
+		fmt.Println("추정치가 있는 완료 포트가 없습니다.")
+		return nil
+	}
+
+	fmt.Printf("📏 추정 정확도 (완료 포트 %d개, 비율 = 실제/추정)\n", len(results))
+	if byAgent != nil {
+		fmt.Println()
+		printEstimateAccuracy("AGENT", byAgent)
+	}
+	if byWeek != nil {
+		fmt.Println()
+		printEstimateAccuracy("WEEK", byWeek)
+	}
+	return nil
+}
+
+func printEstimateAccuracy(label string, rows []port.EstimateAccuracy) {
+	fmt.Printf("%-20s %5s  %-16s %-16s\n", label, "PORTS", "TOKENS (적중)", "HOURS (적중)")
+	for _, a := range rows {
+		fmt.Printf("%-20s %5d  %-16s %-16s\n", a.Key, a.Ports,
+			formatRatio(a.TokenRatio, a.TokenHits, a.TokenPorts),
+			formatRatio(a.HourRatio, a.HourHits, a.HourPorts))
+	}
+}
+
+func formatRatio(ratio float64, hits, ports int) string {
+	if ports == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("x%.2f (%d/%d)", ratio, hits, ports)
+}
+
+func formatEstimate(est port.Estimate) string {
+	var parts []string
+	if est.Tokens > 0 {
+		parts = append(parts, fmt.Sprintf("토큰 %d", est.Tokens))
+	}
+	if est.Hours > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f시간", est.Hours))
+	}
+	if len(parts) == 0 {
+		return "없음"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// compareEstimate describes the actuals of a port against its estimate
+func compareEstimate(est port.Estimate, actualTokens int64, actualHours float64) string {
+	r := port.EstimateResult{Estimate: est, ActualTokens: actualTokens, ActualHours: actualHours}
+	var parts []string
+	if est.Tokens > 0 {
+		parts = append(parts, fmt.Sprintf("토큰 %d/%d (x%.2f)", actualTokens, est.Tokens, r.TokenRatio()))
+	}
+	if est.Hours > 0 {
+		parts = append(parts, fmt.Sprintf("시간 %.1f/%.1f (x%.2f)", actualHours, est.Hours, r.HourRatio()))
+	}
+	return "추정 대비: " + strings.Join(parts, ", ")
+}
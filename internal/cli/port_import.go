@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/plan"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/template"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portImportDryRun bool
+	portImportNoOrch bool
+)
+
+var portImportCmd = &cobra.Command{
+	Use:   "import <plan.yaml|plan.md>",
+	Short: "계획 파일에서 포트 일괄 생성",
+	Long: `플래너가 만든 계획 파일(YAML 또는 계획 마크다운)에서 포트, 의존성, 추정치, handoff를
+하나의 트랜잭션으로 생성하고, 의존성 순서대로 실행하는 Orchestration을 만듭니다.
+하나라도 실패하면 아무것도 생성되지 않습니다.
+
+마크다운은 YAML frontmatter 또는 첫 번째 ` + "```yaml" + ` 블록을 계획으로 읽습니다.
+명세 파일(spec, 기본 ports/<id>.md)이 없으면 template 또는 설명/완료 기준으로 생성합니다.
+
+계획 형식 ('pal plan from-spec' 출력과 호환):
+  title: 사용자 프로필
+  ports:
+    - id: profile-schema
+      title: 프로필 스키마
+      acceptance: ["profiles 테이블 생성"]
+      estimate: {tokens: 40000, hours: 1}
+      handoffs:
+        - to: profile-api
+          type: schema
+          content: {table_name: profiles}
+    - id: profile-api
+      depends_on: [profile-schema]
+      template: api`,
+	Example: `  pal port import plans/profile.yaml --dry-run
+  pal port import plans/profile.md --no-orch`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPortImport,
+}
+
+func init() {
+	portCmd.AddCommand(portImportCmd)
+	portImportCmd.Flags().BoolVar(&portImportDryRun, "dry-run", false, "검증과 생성 순서만 표시")
+	portImportCmd.Flags().BoolVar(&portImportNoOrch, "no-orch", false, "Orchestration을 만들지 않음")
+}
+
+func runPortImport(cmd *cobra.Command, args []string) error {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	if projectRoot == "" {
+		return fmt.Errorf("프로젝트 루트를 찾을 수 없습니다")
+	}
+
+	p, err := plan.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	// 명세는 DB 반영 전에 모두 렌더링 (템플릿 오류 시 아무것도 생성하지 않음)
+	specs := make(map[string]string)
+	for _, pp := range p.Ports {
+		fullPath := pp.Spec
+		if !filepath.IsAbs(fullPath) {
+			fullPath = filepath.Join(projectRoot, pp.Spec)
+		}
+		if _, err := os.Stat(fullPath); err == nil {
+			continue
+		}
+		content, err := renderPlannedSpec(projectRoot, pp)
+		if err != nil {
+			return fmt.Errorf("포트 '%s' 명세 생성 실패: %w", pp.ID, err)
+		}
+		specs[fullPath] = content
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if portImportDryRun {
+		portSvc := port.NewService(database)
+		if err := p.Validate(func(id string) bool {
+			_, err := portSvc.Get(id)
+			return err == nil
+		}); err != nil {
+			return err
+		}
+		ordered, _ := p.Order()
+		if IsJSON() {
+			json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+				"status": "valid",
+				"title":  p.Title,
+				"ports":  ordered,
+			})
+			return nil
+		}
+		fmt.Printf("✓ 계획 검증 완료: %s (포트 %d개)\n", p.Title, len(ordered))
+		for i, pp := range ordered {
+			fmt.Printf("  %d. %s%s\n", i+1, pp.ID, formatPlanDeps(pp.DependsOn))
+		}
+		return nil
+	}
+
+	result, err := plan.Import(database, p, plan.ImportOptions{
+		Handoffs:      newHandoffStore(database),
+		Orchestration: !portImportNoOrch,
+	})
+	if err != nil {
+		return err
+	}
+
+	var specErrors []string
+	for path, content := range specs {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			specErrors = append(specErrors, err.Error())
+			continue
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			specErrors = append(specErrors, err.Error())
+		}
+	}
+
+	if IsJSON() {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"status":        "imported",
+			"title":         p.Title,
+			"ports":         result.Ports,
+			"dependencies":  result.Dependencies,
+			"estimates":     result.Estimates,
+			"handoffs":      len(result.Handoffs),
+			"orchestration": result.Orchestration,
+			"specs_created": len(specs) - len(specErrors),
+			"spec_errors":   specErrors,
+		})
+		return nil
+	}
+
+	fmt.Printf("✓ 계획 가져오기 완료: %s\n", p.Title)
+	fmt.Printf("  포트: %d개, 의존성: %d개, 추정치: %d개, handoff: %d개\n",
+		len(result.Ports), result.Dependencies, result.Estimates, len(result.Handoffs))
+	if len(specs) > 0 {
+		fmt.Printf("  명세 생성: %d개\n", len(specs)-len(specErrors))
+	}
+	for _, e := range specErrors {
+		fmt.Printf("  ⚠️  명세 저장 실패: %s\n", e)
+	}
+	if result.Orchestration != nil {
+		fmt.Printf("  Orchestration: %s\n", result.Orchestration.ID)
+	}
+	return nil
+}
+
+// renderPlannedSpec renders the spec of a planned port from its template, or a minimal spec
+func renderPlannedSpec(projectRoot string, pp plan.PlannedPort) (string, error) {
+	if pp.Template == "" {
+		return plan.SpecDocument(pp), nil
+	}
+	tmpl, err := template.NewService(projectRoot).PortTemplate(pp.Template)
+	if err != nil {
+		return "", err
+	}
+	content, err := tmpl.Render(template.PortData{
+		TemplateData: template.TemplateData{ID: pp.ID, Title: pp.Title, Description: pp.Description},
+		DependsOn:    pp.DependsOn,
+	})
+	if err != nil {
+		return "", err
+	}
+	// 템플릿에 완료 기준이 없으면 계획의 완료 기준을 덧붙임
+	if len(pp.Acceptance) > 0 && len(port.ParseAcceptanceCriteria(content)) == 0 {
+		content += "\n## 완료 기준\n\n"
+		for _, a := range pp.Acceptance {
+			content += fmt.Sprintf("- [ ] %s\n", a)
+		}
+	}
+	return content, nil
+}
+
+func formatPlanDeps(deps []string) string {
+	if len(deps) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" ← %v", deps)
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/spf13/cobra"
+)
+
+var portScratchSession string
+
+var portScratchpadCmd = &cobra.Command{
+	Use:     "scratchpad <id> [note...]",
+	Aliases: []string{"scratch"},
+	Short:   "포트 스크래치패드 조회/메모 추가",
+	Long: `포트를 작업하는 세션들이 함께 쓰는 스크래치패드입니다.
+핸드오프처럼 형식을 갖추지 않아도 되는 주의사항("gotcha")을 남겨 다음 워커가 볼 수 있게 합니다.
+메모는 추가만 가능하며, 메모를 주지 않으면 전체 내용을 출력합니다.
+
+예시:
+  pal port scratchpad auth-api
+  pal port scratchpad auth-api "테스트 DB는 매 실행마다 migrate 필요" --session <session-id>`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		database, err := db.Open(GetDBPath())
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		svc := port.NewService(database)
+		portID := args[0]
+
+		if len(args) > 1 {
+			note, err := svc.AppendScratch(portID, portScratchSession, strings.Join(args[1:], " "))
+			if err != nil {
+				return err
+			}
+			if IsJSON() {
+				data, _ := json.MarshalIndent(note, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			}
+			fmt.Printf("✓ 스크래치패드에 메모 추가됨: %s #%d\n", portID, note.ID)
+			return nil
+		}
+
+		if _, err := svc.Get(portID); err != nil {
+			return err
+		}
+		notes, err := svc.Scratchpad(portID)
+		if err != nil {
+			return err
+		}
+		if IsJSON() {
+			data, _ := json.MarshalIndent(notes, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		fmt.Print(port.RenderScratchpad(portID, notes))
+		return nil
+	},
+}
+
+func init() {
+	portScratchpadCmd.Flags().StringVar(&portScratchSession, "session", "", "메모를 남기는 세션 ID")
+	portCmd.AddCommand(portScratchpadCmd)
+}
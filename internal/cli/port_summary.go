@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/spf13/cobra"
+)
+
+var portSummarizeCmd = &cobra.Command{
+	Use:   "summarize <port-id>",
+	Short: "완료된 포트의 변경 요약 생성",
+	Long: `포트에서 변경한 파일, 명세의 API/주의 사항 섹션, 미완료 완료 기준으로
+약 200토큰의 변경 요약을 만들어 포트에 저장합니다.
+
+요약은 포트 완료(pal hook port-end, pal port status <id> complete) 시 자동으로 생성되며,
+이 포트에 의존하는 포트가 시작될 때 rules에 함께 주입됩니다.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPortSummarize,
+}
+
+func init() {
+	portCmd.AddCommand(portSummarizeCmd)
+}
+
+func runPortSummarize(cmd *cobra.Command, args []string) error {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+
+	svc, cleanup, err := getPortService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	summary, err := storePortSummary(svc, projectRoot, args[0])
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"port":    args[0],
+			"summary": summary,
+		})
+		return nil
+	}
+
+	if summary == "" {
+		fmt.Printf("포트 '%s'에 요약할 변경 사항이 없습니다.\n", args[0])
+		return nil
+	}
+	fmt.Printf("📝 변경 요약: %s\n\n%s\n", args[0], summary)
+	return nil
+}
+
+// storePortSummary builds and stores the completion summary of a port.
+// 요약할 내용이 없으면 빈 문자열을 저장하지 않고 반환합니다.
+func storePortSummary(svc *port.Service, projectRoot, portID string) (string, error) {
+	sum, err := svc.BuildCompletionSummary(portID, projectRoot)
+	if err != nil {
+		return "", err
+	}
+	if sum.IsEmpty() {
+		return "", nil
+	}
+	text := sum.Text()
+	if err := svc.SetSummary(portID, text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/convention"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/document"
+	"github.com/n0roo/pal-kit/internal/pipeline"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/template"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portTemplateName string
+	portDescription  string
+	portDependsOn    []string
+	portConventions  []string
+)
+
+var portTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "포트 템플릿 목록",
+	Long: `pal port create --template에서 사용할 수 있는 포트 템플릿을 표시합니다.
+
+프로젝트 템플릿은 .claude/templates/ports/<name>.yaml에 정의하며 같은 이름의 기본 템플릿을 덮어씁니다:
+
+  description: gRPC 서비스 메서드 추가
+  conventions: [naming, error-handling]   # 미리 연결할 컨벤션 ID 또는 타입
+  checklist:
+    - proto 정의 리뷰
+  body: |
+    ## 컨텍스트
+    - 상위 요구사항: {{.Description}}`,
+	RunE: runPortTemplates,
+}
+
+func init() {
+	portCmd.AddCommand(portTemplatesCmd)
+
+	portCreateCmd.Flags().StringVar(&portTemplateName, "template", "", "포트 명세 템플릿 (pal port templates 참고)")
+	portCreateCmd.Flags().StringVar(&portDescription, "description", "", "상위 요구사항 설명 (--template)")
+	portCreateCmd.Flags().StringSliceVar(&portDependsOn, "depends-on", nil, "선행 포트 ID (--template, 여러 개 가능)")
+	portCreateCmd.Flags().StringSliceVar(&portConventions, "convention", nil, "추가로 연결할 컨벤션 ID 또는 타입 (--template)")
+}
+
+func runPortTemplates(cmd *cobra.Command, args []string) error {
+	cwd, _ := os.Getwd()
+	root := context.FindProjectRoot(cwd)
+	if root == "" {
+		root = cwd
+	}
+
+	templates, err := template.NewService(root).PortTemplates()
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(templates, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println("📐 포트 템플릿")
+	for _, t := range templates {
+		fmt.Printf("  %-14s  %s\n", t.Name, t.Description)
+		if verbose {
+			fmt.Printf("  %-14s  컨벤션: %v, 출처: %s\n", "", t.Conventions, t.Source)
+		}
+	}
+	return nil
+}
+
+// runPortCreateFromTemplate scaffolds the spec from a template, registers the port
+// with its dependencies and links the matching conventions to the spec document.
+func runPortCreateFromTemplate(portID string) error {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	if projectRoot == "" {
+		return fmt.Errorf("프로젝트 루트를 찾을 수 없습니다")
+	}
+
+	tmpl, err := template.NewService(projectRoot).PortTemplate(portTemplateName)
+	if err != nil {
+		return err
+	}
+	convs, err := resolvePortConventions(projectRoot, tmpl.Conventions, portConventions)
+	if err != nil {
+		return err
+	}
+
+	relPath := portFile
+	if relPath == "" {
+		relPath = filepath.ToSlash(filepath.Join("ports", portID+".md"))
+	}
+	fullPath := relPath
+	if !filepath.IsAbs(fullPath) {
+		fullPath = filepath.Join(projectRoot, relPath)
+	}
+	if _, err := os.Stat(fullPath); err == nil {
+		return fmt.Errorf("포트 문서가 이미 존재합니다: %s", relPath)
+	}
+
+	data := template.PortData{
+		TemplateData: template.TemplateData{ID: portID, Title: portTitle, Description: portDescription},
+		DependsOn:    portDependsOn,
+	}
+	for _, c := range convs {
+		data.Conventions = append(data.Conventions, template.PortConvention{ID: c.ID, Name: c.Name, Description: c.Description})
+	}
+	content, err := tmpl.Render(data)
+	if err != nil {
+		return err
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	portSvc := port.NewService(database)
+	for _, dep := range portDependsOn {
+		if _, err := portSvc.Get(dep); err != nil {
+			return fmt.Errorf("선행 포트 확인 실패: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %w", err)
+	}
+	if err := portSvc.Create(portID, portTitle, relPath); err != nil {
+		return err
+	}
+	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("포트 문서 생성 실패: %w", err)
+	}
+
+	pipelineSvc := pipeline.NewService(database)
+	for _, dep := range portDependsOn {
+		if err := pipelineSvc.AddDependency(portID, dep); err != nil {
+			return err
+		}
+	}
+
+	linked := linkPortConventions(document.NewService(database, projectRoot), projectRoot, fullPath, convs)
+
+	if IsJSON() {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"status":      "created",
+			"id":          portID,
+			"title":       portTitle,
+			"file_path":   relPath,
+			"template":    tmpl.Name,
+			"depends_on":  portDependsOn,
+			"conventions": linked,
+		})
+		return nil
+	}
+
+	fmt.Printf("✓ 포트 생성: %s (템플릿: %s)\n", portID, tmpl.Name)
+	if portTitle != "" {
+		fmt.Printf("  제목: %s\n", portTitle)
+	}
+	fmt.Printf("  문서: %s\n", relPath)
+	if len(portDependsOn) > 0 {
+		fmt.Printf("  선행 포트: %v\n", portDependsOn)
+	}
+	if len(linked) > 0 {
+		fmt.Printf("  컨벤션: %v\n", linked)
+	}
+	return nil
+}
+
+// resolvePortConventions matches template and explicit references against enabled conventions by ID or type.
+// 템플릿의 참조는 없으면 건너뛰지만, --convention으로 지정한 참조는 반드시 존재해야 합니다.
+func resolvePortConventions(projectRoot string, fromTemplate, explicit []string) ([]*convention.Convention, error) {
+	convSvc := convention.NewService(projectRoot)
+	if err := convSvc.Load(); err != nil {
+		return nil, err
+	}
+	enabled, err := convSvc.ListEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var matched []*convention.Convention
+	match := func(ref string) bool {
+		found := false
+		for _, c := range enabled {
+			if c.ID != ref && string(c.Type) != ref {
+				continue
+			}
+			found = true
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				matched = append(matched, c)
+			}
+		}
+		return found
+	}
+
+	for _, ref := range fromTemplate {
+		match(ref)
+	}
+	for _, ref := range explicit {
+		if !match(ref) {
+			return nil, fmt.Errorf("컨벤션을 찾을 수 없습니다: %s (pal convention list 참고)", ref)
+		}
+	}
+	return matched, nil
+}
+
+// linkPortConventions indexes the port spec and the conventions and links them in document_links.
+// 연결에 실패해도 포트 생성은 유지하고 경고만 출력합니다.
+func linkPortConventions(docSvc *document.Service, projectRoot, portPath string, convs []*convention.Convention) []string {
+	if len(convs) == 0 {
+		return nil
+	}
+	portRel, _ := filepath.Rel(projectRoot, portPath)
+	if err := docSvc.RefreshDocument(portRel); err != nil {
+		fmt.Fprintf(os.Stderr, "경고: 포트 문서 인덱싱 실패: %v\n", err)
+		return nil
+	}
+	portDoc, err := docSvc.GetByPath(portRel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "경고: %v\n", err)
+		return nil
+	}
+
+	var linked []string
+	for _, c := range convs {
+		convRel, err := filepath.Rel(projectRoot, c.FilePath)
+		if err != nil {
+			continue
+		}
+		if err := docSvc.RefreshDocument(convRel); err != nil {
+			fmt.Fprintf(os.Stderr, "경고: 컨벤션 '%s' 인덱싱 실패: %v\n", c.ID, err)
+			continue
+		}
+		convDoc, err := docSvc.GetByPath(convRel)
+		if err != nil {
+			continue
+		}
+		if err := docSvc.AddLink(portDoc.ID, convDoc.ID, "convention"); err != nil {
+			fmt.Fprintf(os.Stderr, "경고: 컨벤션 '%s' 연결 실패: %v\n", c.ID, err)
+			continue
+		}
+		linked = append(linked, c.ID)
+	}
+	return linked
+}
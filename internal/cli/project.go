@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/project"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectListAll      bool
+	projectListArchived bool
+	projectRegisterName string
+)
+
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "대시보드 프로젝트 관리",
+	Long: `대시보드에 등록된 프로젝트를 관리합니다.
+
+보관(archive)하거나 숨긴(hide) 프로젝트는 기본 목록과 통계에서 제외되며,
+--all 또는 --archived로 조회할 수 있습니다.`,
+}
+
+var projectListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "프로젝트 목록",
+	RunE:  runProjectList,
+}
+
+var projectRegisterCmd = &cobra.Command{
+	Use:   "register [path]",
+	Short: "프로젝트 등록 (기본: 현재 디렉토리)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runProjectRegister,
+}
+
+var projectRenameCmd = &cobra.Command{
+	Use:   "rename <root> <name>",
+	Short: "프로젝트 이름 변경",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runProjectRename,
+}
+
+var projectArchiveCmd = &cobra.Command{
+	Use:   "archive <root>",
+	Short: "프로젝트 보관 (목록/통계에서 제외)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  projectStateRunner("보관", func(svc *project.Service, root string) error { return svc.SetArchived(root, true) }),
+}
+
+var projectUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive <root>",
+	Short: "프로젝트 보관 해제",
+	Args:  cobra.ExactArgs(1),
+	RunE:  projectStateRunner("보관 해제", func(svc *project.Service, root string) error { return svc.SetArchived(root, false) }),
+}
+
+var projectHideCmd = &cobra.Command{
+	Use:   "hide <root>",
+	Short: "프로젝트 숨김 (목록에서만 제외)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  projectStateRunner("숨김", func(svc *project.Service, root string) error { return svc.SetHidden(root, true) }),
+}
+
+var projectUnhideCmd = &cobra.Command{
+	Use:   "unhide <root>",
+	Short: "프로젝트 숨김 해제",
+	Args:  cobra.ExactArgs(1),
+	RunE:  projectStateRunner("숨김 해제", func(svc *project.Service, root string) error { return svc.SetHidden(root, false) }),
+}
+
+func init() {
+	rootCmd.AddCommand(projectCmd)
+	projectCmd.AddCommand(projectListCmd)
+	projectCmd.AddCommand(projectRegisterCmd)
+	projectCmd.AddCommand(projectRenameCmd)
+	projectCmd.AddCommand(projectArchiveCmd)
+	projectCmd.AddCommand(projectUnarchiveCmd)
+	projectCmd.AddCommand(projectHideCmd)
+	projectCmd.AddCommand(projectUnhideCmd)
+
+	projectListCmd.Flags().BoolVar(&projectListAll, "all", false, "보관/숨김 프로젝트 포함")
+	projectListCmd.Flags().BoolVar(&projectListArchived, "archived", false, "보관된 프로젝트만")
+	projectRegisterCmd.Flags().StringVar(&projectRegisterName, "name", "", "프로젝트 이름 (기본: 디렉토리명)")
+}
+
+func getProjectService() (*project.Service, func(), error) {
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return nil, nil, err
+	}
+	return project.NewService(database), func() { database.Close() }, nil
+}
+
+// projectRootArg resolves a root argument to an absolute path
+func projectRootArg(arg string) string {
+	if abs, err := filepath.Abs(arg); err == nil {
+		return abs
+	}
+	return arg
+}
+
+func runProjectList(cmd *cobra.Command, args []string) error {
+	svc, cleanup, err := getProjectService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	projects, err := svc.List(project.ListOptions{
+		IncludeArchived: projectListAll,
+		IncludeHidden:   projectListAll,
+		OnlyArchived:    projectListArchived,
+	})
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		if projects == nil {
+			projects = []project.Project{}
+		}
+		return json.NewEncoder(os.Stdout).Encode(projects)
+	}
+
+	if len(projects) == 0 {
+		fmt.Println("등록된 프로젝트가 없습니다.")
+		return nil
+	}
+
+	fmt.Printf("%-20s %-10s %-16s %s\n", "NAME", "STATE", "LAST ACTIVE", "ROOT")
+	for _, p := range projects {
+		state := "active"
+		if p.Archived {
+			state = "archived"
+		} else if p.Hidden {
+			state = "hidden"
+		}
+		lastActive := "-"
+		if p.LastActive.Valid {
+			lastActive = p.LastActive.Time.Format("2006-01-02 15:04")
+		}
+		fmt.Printf("%-20s %-10s %-16s %s\n", truncate(p.Name, 20), state, lastActive, p.Root)
+	}
+
+	return nil
+}
+
+func runProjectRegister(cmd *cobra.Command, args []string) error {
+	root := "."
+	if len(args) > 0 {
+		root = args[0]
+	}
+	root = projectRootArg(root)
+
+	svc, cleanup, err := getProjectService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := svc.Register(root, projectRegisterName); err != nil {
+		return err
+	}
+	fmt.Printf("✓ 프로젝트 등록: %s\n", root)
+	return nil
+}
+
+func runProjectRename(cmd *cobra.Command, args []string) error {
+	root := projectRootArg(args[0])
+
+	svc, cleanup, err := getProjectService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := svc.Rename(root, args[1]); err != nil {
+		return err
+	}
+	fmt.Printf("✓ 프로젝트 이름 변경: %s → %s\n", root, args[1])
+	return nil
+}
+
+func projectStateRunner(label string, apply func(*project.Service, string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		root := projectRootArg(args[0])
+
+		svc, cleanup, err := getProjectService()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		if err := apply(svc, root); err != nil {
+			return err
+		}
+		fmt.Printf("✓ 프로젝트 %s: %s\n", label, root)
+		return nil
+	}
+}
@@ -1,7 +1,12 @@
 package cli
 
 import (
+	"encoding/json"
+	"os"
+	"time"
+
 	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/errcode"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +21,7 @@ var (
 	dbPath  string
 	verbose bool
 	jsonOut bool
+	quiet   bool
 )
 
 var rootCmd = &cobra.Command{
@@ -37,7 +43,31 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	// 기계 모드: usage/한국어 오류 대신 JSON 한 줄 오류만 stderr로 출력
+	for _, arg := range os.Args[1:] {
+		if arg == "--quiet" || arg == "-q" {
+			rootCmd.SilenceErrors = true
+			rootCmd.SilenceUsage = true
+		}
+	}
+
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	recordHookMetrics(cmd, err, time.Since(start))
+	recordTelemetry(cmd, err)
+	if err != nil && rootCmd.SilenceErrors {
+		json.NewEncoder(os.Stderr).Encode(map[string]interface{}{
+			"error":     errcode.KindOf(err),
+			"exit_code": errcode.ExitCode(err),
+			"message":   err.Error(),
+		})
+	}
+	return err
+}
+
+// ExitCode returns the process exit status for an error returned by Execute
+func ExitCode(err error) int {
+	return errcode.ExitCode(err)
 }
 
 func init() {
@@ -45,6 +75,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "", "SQLite DB 경로 (기본: ~/.pal/pal.db)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "상세 출력")
 	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "JSON 출력")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "기계 모드 (오류를 JSON으로 출력, 종료 코드로 실패 유형 구분)")
 }
 
 // GetDBPath returns the database path (global by default)
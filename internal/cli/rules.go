@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: ".claude/rules 관리",
+	Long:  `PAL Kit이 생성한 .claude/rules 파일을 조회합니다.`,
+}
+
+var rulesBudgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "활성 rules 토큰 합계",
+	Long: `.claude/rules의 모든 파일에 대한 예상 토큰 수를 합산합니다.
+
+생성된 rules 파일에는 다음 형식의 헤더가 포함됩니다:
+  <!-- pal-rules: {"tokens":420,"generated_at":"...","sources":["ports/a.md"]} -->`,
+	RunE: runRulesBudget,
+}
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesBudgetCmd)
+}
+
+func runRulesBudget(cmd *cobra.Command, args []string) error {
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+	if projectRoot == "" {
+		return fmt.Errorf("PAL 프로젝트를 찾을 수 없습니다")
+	}
+
+	budget, err := rules.NewService(projectRoot).Budget()
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		json.NewEncoder(os.Stdout).Encode(budget)
+		return nil
+	}
+
+	if len(budget.Rules) == 0 {
+		fmt.Println("활성 rules 파일이 없습니다.")
+		return nil
+	}
+
+	fmt.Printf("%-30s %8s  %-19s %s\n", "RULE", "TOKENS", "GENERATED", "SOURCES")
+	fmt.Println(strings.Repeat("-", 90))
+	for _, r := range budget.Rules {
+		generated := "-"
+		if r.GeneratedAt != nil {
+			generated = r.GeneratedAt.Format("2006-01-02 15:04:05")
+		}
+		sources := "-"
+		if len(r.Sources) > 0 {
+			sources = strings.Join(r.Sources, ", ")
+		} else if !r.Managed {
+			sources = "(PAL 외부 파일)"
+		}
+		fmt.Printf("%-30s %8d  %-19s %s\n", truncate(r.Name, 30), r.Tokens, generated, sources)
+	}
+	fmt.Println(strings.Repeat("-", 90))
+	fmt.Printf("%-30s %8d\n", "TOTAL", budget.TotalTokens)
+
+	return nil
+}
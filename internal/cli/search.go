@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/kb"
+	"github.com/n0roo/pal-kit/internal/search"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchKinds    []string
+	searchMaxHits  int
+	searchVaultDir string
+)
+
+var globalSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "PAL 데이터 통합 검색",
+	Long: `포트, 세션(제목/이벤트), 문서, KB 노트, 결정 사항, 에스컬레이션을 한 번에 검색합니다.
+각 결과에는 상세 조회 명령(pal <entity> show ...)이 함께 표시됩니다.
+
+KB는 --vault 경로 또는 프로젝트 루트가 KB로 초기화되어 있을 때만 검색합니다.
+
+예시:
+  pal search "결제"
+  pal search auth --kind port,session
+  pal search JWT --kind decision --limit 5`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGlobalSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(globalSearchCmd)
+
+	globalSearchCmd.Flags().StringSliceVar(&searchKinds, "kind", nil, "검색 대상 (port, session, event, document, kb, decision, escalation)")
+	globalSearchCmd.Flags().IntVar(&searchMaxHits, "limit", 10, "대상별 최대 결과 수")
+	globalSearchCmd.Flags().StringVar(&searchVaultDir, "vault", "", "KB vault 경로 (기본: 프로젝트 루트)")
+}
+
+func runGlobalSearch(cmd *cobra.Command, args []string) error {
+	for _, k := range searchKinds {
+		valid := false
+		for _, known := range search.AllKinds {
+			if k == known {
+				valid = true
+			}
+		}
+		if !valid {
+			return fmt.Errorf("알 수 없는 검색 대상: %s", k)
+		}
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+
+	vault := searchVaultDir
+	if vault == "" && projectRoot != "" {
+		if _, err := os.Stat(filepath.Join(projectRoot, kb.MetaDir)); err == nil {
+			vault = projectRoot
+		}
+	}
+
+	hits, err := search.NewService(database, projectRoot).Search(args[0], search.Options{
+		Kinds:     searchKinds,
+		Limit:     searchMaxHits,
+		VaultPath: vault,
+	})
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(map[string]interface{}{
+			"query": args[0],
+			"hits":  hits,
+		}, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(hits) == 0 {
+		fmt.Println("검색 결과 없음")
+		return nil
+	}
+
+	fmt.Printf("🔍 '%s' 검색 결과 (%d건)\n", args[0], len(hits))
+	lastKind := ""
+	for _, h := range hits {
+		if h.Kind != lastKind {
+			fmt.Printf("\n[%s]\n", h.Kind)
+			lastKind = h.Kind
+		}
+		title := truncate(h.Title, 60)
+		if h.Status != "" {
+			title = fmt.Sprintf("%s (%s)", title, h.Status)
+		}
+		fmt.Printf("  • %s\n", title)
+		if h.Snippet != "" {
+			fmt.Printf("    %s\n", h.Snippet)
+		}
+		fmt.Printf("    → %s\n", h.Link)
+	}
+
+	return nil
+}
@@ -14,6 +14,10 @@ import (
 
 var servePort int
 var serveVaultPath string
+var serveReadOnly bool
+var serveShare bool
+var serveHideCost bool
+var serveKBWatch bool
 
 var serveCmd = &cobra.Command{
 	Use:   "serve",
@@ -21,7 +25,16 @@ var serveCmd = &cobra.Command{
 	Long: `웹 기반 대시보드를 실행합니다.
 
 전역 DB (~/.pal/pal.db)를 사용하여 모든 프로젝트의
-세션, 포트, 히스토리를 통합 조회합니다.`,
+세션, 포트, 히스토리를 통합 조회합니다.
+
+--readonly는 모든 변경 요청(POST/PUT/PATCH/DELETE)과 Orchestration 스케줄러를 비활성화합니다.
+--share는 관계자가 Orchestration 진행 상황만 볼 수 있는 간이 상태 페이지를 read-only로 제공합니다.
+--hide-cost는 API 응답에서 비용 정보를 제거합니다.
+--kb-watch는 KB vault를 감시해 바뀐 문서를 재색인하고 대시보드에 kb:updated 이벤트를 보냅니다.
+
+예시:
+  pal serve --readonly
+  pal serve --share --hide-cost -p 9090`,
 	RunE: runServe,
 }
 
@@ -29,6 +42,10 @@ func init() {
 	rootCmd.AddCommand(serveCmd)
 	serveCmd.Flags().IntVarP(&servePort, "port", "p", 8080, "서버 포트")
 	serveCmd.Flags().StringVar(&serveVaultPath, "vault", "", "Knowledge Base vault 경로 (기본: ~/mcp-docs)")
+	serveCmd.Flags().BoolVar(&serveReadOnly, "readonly", false, "읽기 전용 모드 (변경 요청 차단)")
+	serveCmd.Flags().BoolVar(&serveShare, "share", false, "관계자용 간이 상태 페이지 제공 (읽기 전용)")
+	serveCmd.Flags().BoolVar(&serveHideCost, "hide-cost", false, "응답에서 비용 정보 제거")
+	serveCmd.Flags().BoolVar(&serveKBWatch, "kb-watch", false, "KB vault 감시 (자동 색인/TOC/링크 검사, SSE 알림)")
 }
 
 func runServe(cmd *cobra.Command, args []string) error {
@@ -51,23 +68,31 @@ func runServe(cmd *cobra.Command, args []string) error {
 		vaultPath = filepath.Join(home, "mcp-docs")
 	}
 
-	// Handle graceful shutdown
+	srv := server.NewServer(server.Config{
+		Port:        servePort,
+		ProjectRoot: projectRoot,
+		DBPath:      dbPath,
+		VaultPath:   vaultPath,
+		ReadOnly:    serveReadOnly || serveShare,
+		Share:       serveShare,
+		HideCost:    serveHideCost,
+		KBWatch:     serveKBWatch && !serveShare,
+	})
+
+	// Handle graceful shutdown: 처리 중인 요청과 스케줄러 작업을 마치고 DB를 닫은 뒤 종료
 	go func() {
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 		<-sigChan
 		fmt.Println("\nShutting down...")
-		os.Exit(0)
+		if err := srv.Stop(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  종료 중 오류: %v\n", err)
+		}
 	}()
 
 	fmt.Printf("📊 전역 DB: %s\n", dbPath)
 	fmt.Printf("📁 프로젝트: %s\n", projectRoot)
 	fmt.Printf("📚 KB Vault: %s\n", vaultPath)
 
-	return server.RunWithConfig(server.Config{
-		Port:        servePort,
-		ProjectRoot: projectRoot,
-		DBPath:      dbPath,
-		VaultPath:   vaultPath,
-	})
+	return srv.Start()
 }
@@ -8,19 +8,23 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/context"
 	"github.com/n0roo/pal-kit/internal/db"
 	"github.com/n0roo/pal-kit/internal/session"
 	"github.com/spf13/cobra"
 )
 
 var (
-	sessionPortID string
-	sessionTitle  string
-	sessionStatus string
-	sessionActive bool
-	sessionLimit  int
-	sessionType   string
-	sessionParent string
+	sessionPortID  string
+	sessionTitle   string
+	sessionStatus  string
+	sessionActive  bool
+	sessionLimit   int
+	sessionType    string
+	sessionParent  string
+	sessionSandbox bool
+	sessionCompare string
 )
 
 var sessionCmd = &cobra.Command{
@@ -38,7 +42,11 @@ var sessionStartCmd = &cobra.Command{
   single  - 단일 세션 (기본)
   multi   - 멀티 세션 (병렬 독립)
   sub     - 서브 세션 (상위에서 spawn)
-  builder - 빌더 세션 (파이프라인 관리)`,
+  builder - 빌더 세션 (파이프라인 관리)
+
+--sandbox로 시작한 세션은 이벤트/포트/사용량이 기록되지만
+통계, 브리핑, 세션 요약에서 제외됩니다 (데모, 프롬프트 실험용).
+Hook으로 시작되는 세션은 PAL_SANDBOX=1 환경변수로 지정합니다.`,
 	RunE: runSessionStart,
 }
 
@@ -107,6 +115,26 @@ var sessionChildrenCmd = &cobra.Command{
 
 var sessionCleanupHours int
 
+var (
+	sessionIdleAfter time.Duration
+	sessionIdlePause bool
+)
+
+var sessionIdleCmd = &cobra.Command{
+	Use:   "idle",
+	Short: "유휴 세션 조회/일시정지",
+	Long: `마지막 이벤트 이후 일정 시간 활동이 없는 running 세션을 조회합니다.
+--pause를 지정하면 paused 상태로 변경하고 보유한 Lock을 해제합니다.
+일시정지된 세션은 다음 Hook 이벤트에서 자동으로 재개됩니다.
+
+기준 시간은 --after > .pal/config.yaml의 settings.idle_pause_after > 기본값(30m) 순으로 결정됩니다.
+
+예시:
+  pal session idle
+  pal session idle --after 1h --pause`,
+	RunE: runSessionIdle,
+}
+
 func init() {
 	rootCmd.AddCommand(sessionCmd)
 	sessionCmd.AddCommand(sessionStartCmd)
@@ -118,11 +146,15 @@ func init() {
 	sessionCmd.AddCommand(sessionCleanupCmd)
 	sessionCmd.AddCommand(sessionRenameCmd)
 	sessionCmd.AddCommand(sessionChildrenCmd)
+	sessionCmd.AddCommand(sessionIdleCmd)
+
+	sessionShowCmd.Flags().StringVar(&sessionCompare, "compare", "", "환경 스냅샷을 비교할 세션 ID")
 
 	sessionStartCmd.Flags().StringVar(&sessionPortID, "port", "", "포트 ID")
 	sessionStartCmd.Flags().StringVar(&sessionTitle, "title", "", "세션 제목")
 	sessionStartCmd.Flags().StringVar(&sessionType, "type", "single", "세션 유형 (single|multi|sub|builder)")
 	sessionStartCmd.Flags().StringVar(&sessionParent, "parent", "", "상위 세션 ID")
+	sessionStartCmd.Flags().BoolVar(&sessionSandbox, "sandbox", false, "샌드박스 세션 (통계/브리핑 제외)")
 
 	sessionUpdateCmd.Flags().StringVar(&sessionStatus, "status", "", "상태 (running|complete|failed|cancelled)")
 
@@ -132,6 +164,9 @@ func init() {
 	sessionTreeCmd.Flags().IntVar(&sessionLimit, "limit", 10, "루트 세션 수 제한")
 
 	sessionCleanupCmd.Flags().IntVar(&sessionCleanupHours, "hours", 24, "정리 기준 시간 (시간)")
+
+	sessionIdleCmd.Flags().DurationVar(&sessionIdleAfter, "after", 0, "유휴 기준 시간 (기본: 프로젝트 설정)")
+	sessionIdleCmd.Flags().BoolVar(&sessionIdlePause, "pause", false, "유휴 세션 일시정지 및 Lock 해제")
 }
 
 func getSessionService() (*session.Service, func(), error) {
@@ -183,18 +218,26 @@ func runSessionStart(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if err := svc.StartWithOptions(sessionID, sessionPortID, sessionTitle, sessionType, sessionParent); err != nil {
+	if err := svc.StartWithFullOptions(session.StartOptions{
+		ID:            sessionID,
+		PortID:        sessionPortID,
+		Title:         sessionTitle,
+		SessionType:   sessionType,
+		ParentSession: sessionParent,
+		Sandbox:       sessionSandbox,
+	}); err != nil {
 		return err
 	}
 
 	if jsonOut {
-		json.NewEncoder(os.Stdout).Encode(map[string]string{
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
 			"status":  "started",
 			"id":      sessionID,
 			"port_id": sessionPortID,
 			"title":   sessionTitle,
 			"type":    sessionType,
 			"parent":  sessionParent,
+			"sandbox": sessionSandbox,
 		})
 	} else {
 		typeEmoji := map[string]string{
@@ -205,6 +248,9 @@ func runSessionStart(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Printf("%s 세션 시작: %s\n", typeEmoji[sessionType], sessionID)
 		fmt.Printf("  유형: %s\n", sessionType)
+		if sessionSandbox {
+			fmt.Println("  🧪 샌드박스 (통계/브리핑 제외)")
+		}
 		if sessionParent != "" {
 			fmt.Printf("  상위: %s\n", sessionParent)
 		}
@@ -357,7 +403,26 @@ func runSessionShow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	env, _ := svc.GetEnvSnapshot(sess.ID)
+	var compareEnv *session.EnvSnapshot
+	if sessionCompare != "" {
+		if compareEnv, err = svc.GetEnvSnapshot(sessionCompare); err != nil {
+			return err
+		}
+		if env == nil || compareEnv == nil {
+			return fmt.Errorf("환경 스냅샷이 없는 세션은 비교할 수 없습니다")
+		}
+	}
+
 	if jsonOut {
+		if compareEnv != nil {
+			json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+				"session":  sess.ID,
+				"compare":  sessionCompare,
+				"env_diff": env.Diff(compareEnv),
+			})
+			return nil
+		}
 		json.NewEncoder(os.Stdout).Encode(sess)
 		return nil
 	}
@@ -377,6 +442,9 @@ func runSessionShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%s 세션: %s\n", emoji, sess.ID)
 	fmt.Println(strings.Repeat("-", 50))
 	fmt.Printf("유형: %s\n", sess.SessionType)
+	if svc.IsSandbox(sess.ID) {
+		fmt.Println("샌드박스: 예 (통계/브리핑 제외)")
+	}
 	fmt.Printf("상태: %s\n", sess.Status)
 	if sess.ParentSession.Valid {
 		fmt.Printf("상위: %s\n", sess.ParentSession.String)
@@ -416,6 +484,20 @@ func runSessionShow(cmd *cobra.Command, args []string) error {
 		fmt.Println("  (사용량 없음)")
 	}
 
+	if env != nil {
+		fmt.Println()
+		if compareEnv != nil {
+			printEnvDiff(sess.ID, sessionCompare, env.Diff(compareEnv))
+		} else {
+			fmt.Printf("🖥️  환경 (%s 기록):\n", env.CapturedAt.Local().Format("2006-01-02 15:04:05"))
+			for _, f := range env.Fields() {
+				if f[1] != "" {
+					fmt.Printf("  %-12s %s\n", f[0]+":", f[1])
+				}
+			}
+		}
+	}
+
 	if sess.CompactCount > 0 {
 		fmt.Println()
 		fmt.Printf("📦 컴팩션: %d회\n", sess.CompactCount)
@@ -602,6 +684,67 @@ func runSessionCleanup(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runSessionIdle(cmd *cobra.Command, args []string) error {
+	svc, cleanup, err := getSessionService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cwd, _ := os.Getwd()
+	projectRoot := context.FindProjectRoot(cwd)
+
+	window := sessionIdleAfter
+	if window <= 0 {
+		window = config.DefaultIdlePauseAfter
+		if projectRoot != "" {
+			if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+				window = projectCfg.Settings.IdlePauseWindow()
+			}
+		}
+	}
+	if window <= 0 {
+		return fmt.Errorf("유휴 세션 감지가 비활성화되어 있습니다 (settings.idle_pause_after: off)")
+	}
+
+	var idles []session.IdleSession
+	if sessionIdlePause {
+		idles, err = svc.PauseIdleSessions(projectRoot, window)
+	} else {
+		idles, err = svc.FindIdleSessions(projectRoot, window)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"threshold": window.String(),
+			"paused":    sessionIdlePause,
+			"sessions":  idles,
+		})
+	}
+
+	if len(idles) == 0 {
+		fmt.Printf("유휴 세션이 없습니다 (기준: %s)\n", window)
+		return nil
+	}
+
+	for _, is := range idles {
+		fmt.Printf("  %s  %d분 유휴 (마지막 활동: %s)", is.ID, is.IdleMinutes, is.LastActivity)
+		if len(is.ReleasedLocks) > 0 {
+			fmt.Printf(" - Lock 해제: %s", strings.Join(is.ReleasedLocks, ", "))
+		}
+		fmt.Println()
+	}
+	if sessionIdlePause {
+		fmt.Printf("💤 %d개 세션을 일시정지했습니다 (기준: %s)\n", len(idles), window)
+	} else {
+		fmt.Printf("%d개 유휴 세션 (기준: %s). --pause로 일시정지할 수 있습니다.\n", len(idles), window)
+	}
+	return nil
+}
+
 func runSessionRename(cmd *cobra.Command, args []string) error {
 	sessionID := args[0]
 	newName := args[1]
@@ -717,3 +860,22 @@ func runSessionChildren(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// printEnvDiff prints the environment fields that differ between two sessions
+func printEnvDiff(a, b string, diffs []session.EnvDiff) {
+	fmt.Printf("🖥️  환경 비교 (%s ↔ %s):\n", a, b)
+	if len(diffs) == 0 {
+		fmt.Println("  차이 없음")
+		return
+	}
+	for _, d := range diffs {
+		fmt.Printf("  %-12s %s ↔ %s\n", d.Field+":", orDash(d.A), orDash(d.B))
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/operator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sessionExportFormat string
+	sessionExportOutput string
+)
+
+var sessionExportCmd = &cobra.Command{
+	Use:   "export <id>",
+	Short: "세션 기록을 하나의 파일로 내보내기",
+	Long: `세션 정보, 전체 이벤트, 포트 결과(변경 파일 포함), Operator 요약, transcript 사용량을
+하나의 결과물로 묶어 PR이나 티켓에 첨부할 수 있게 합니다.
+
+형식:
+  json      전체 번들 (기본)
+  markdown  공유용 리포트
+  zip       session.json + session.md + events.jsonl
+
+예시:
+  pal session export abc123
+  pal session export abc123 --format markdown > session.md
+  pal session export abc123 --format zip -o session-abc123.zip`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionExport,
+}
+
+func init() {
+	sessionCmd.AddCommand(sessionExportCmd)
+	sessionExportCmd.Flags().StringVar(&sessionExportFormat, "format", operator.ExportJSON, "형식 (json|markdown|zip)")
+	sessionExportCmd.Flags().StringVarP(&sessionExportOutput, "output", "o", "", "출력 파일 (기본: stdout, zip은 session-<id>.zip)")
+}
+
+func runSessionExport(cmd *cobra.Command, args []string) error {
+	var buf bytes.Buffer
+	format := sessionExportFormat
+	if format == "md" {
+		format = operator.ExportMarkdown
+	}
+	switch format {
+	case operator.ExportJSON, operator.ExportMarkdown, operator.ExportZip:
+	default:
+		return fmt.Errorf("지원하지 않는 형식입니다: %s (json|markdown|zip)", sessionExportFormat)
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	cwd, _ := os.Getwd()
+	bundle, err := operator.NewService(database, context.FindProjectRoot(cwd)).ExportSession(args[0])
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case operator.ExportJSON:
+		data, err := json.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return fmt.Errorf("번들 직렬화 실패: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	case operator.ExportMarkdown:
+		buf.WriteString(bundle.Markdown())
+	case operator.ExportZip:
+		if err := bundle.WriteZip(&buf); err != nil {
+			return err
+		}
+	}
+
+	output := sessionExportOutput
+	if output == "" && format == operator.ExportZip {
+		output = fmt.Sprintf("session-%s.zip", bundle.Session.ID)
+	}
+	if output == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+
+	if err := os.WriteFile(output, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("내보내기 파일 저장 실패: %w", err)
+	}
+	if IsJSON() {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"session_id": bundle.Session.ID,
+			"format":     format,
+			"output":     output,
+			"events":     len(bundle.Events),
+			"ports":      len(bundle.Ports),
+		})
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "✓ 세션 내보내기: %s (이벤트 %d, 포트 %d)\n", output, len(bundle.Events), len(bundle.Ports))
+	return nil
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var stateAllProjects bool
+
+var stateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "프로젝트 상태 조회",
+	Long:  `이벤트 로그로 재구성한 프로젝트 상태를 조회합니다.`,
+}
+
+var stateAsOfCmd = &cobra.Command{
+	Use:   "as-of <date>",
+	Short: "특정 시점의 프로젝트 상태",
+	Long: `세션 이벤트 로그(port_start/port_end)와 세션·포트 타임스탬프로
+지정한 시점에 실행 중이던 포트, 활성 세션, 누적 비용을 재구성합니다.
+회고나 비용 정산 확인에 사용합니다.
+
+시점은 날짜(그날이 끝나는 시점), "2025-06-01 18:00" 또는 RFC3339로 지정합니다.
+누적 비용은 시점 이전에 종료된 세션의 확정 비용과, 진행 중이던 세션 비용을
+경과 시간 비율로 나눈 추정치의 합입니다.
+
+예시:
+  pal state as-of 2025-06-01
+  pal state as-of "2025-06-01 18:00" --all
+  pal state as-of 2025-06-01T09:00:00+09:00 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStateAsOf,
+}
+
+func init() {
+	rootCmd.AddCommand(stateCmd)
+	stateCmd.AddCommand(stateAsOfCmd)
+
+	stateAsOfCmd.Flags().BoolVar(&stateAllProjects, "all", false, "모든 프로젝트 대상 (기본: 현재 프로젝트)")
+}
+
+func runStateAsOf(cmd *cobra.Command, args []string) error {
+	at, err := history.ParseAsOf(args[0])
+	if err != nil {
+		return err
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	projectRoot := ""
+	if !stateAllProjects {
+		cwd, _ := os.Getwd()
+		projectRoot = context.FindProjectRoot(cwd)
+	}
+
+	snap, err := history.NewService(database).StateAsOf(at, projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if IsJSON() {
+		data, _ := json.MarshalIndent(snap, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	scope := "전체 프로젝트"
+	if snap.ProjectRoot != "" {
+		scope = snap.ProjectRoot
+	}
+	fmt.Printf("🕰  %s 시점 상태 (%s)\n\n", at.Local().Format("2006-01-02 15:04:05"), scope)
+
+	fmt.Printf("실행 중 포트 (%d개, 완료 %d개):\n", len(snap.RunningPorts), snap.CompletedPorts)
+	if len(snap.RunningPorts) == 0 {
+		fmt.Println("  (없음)")
+	}
+	for _, p := range snap.RunningPorts {
+		title := ""
+		if p.Title != "" {
+			title = " - " + truncate(p.Title, 40)
+		}
+		source := ""
+		if p.Source == history.SourceTimestamps {
+			source = " (타임스탬프 기준)"
+		}
+		fmt.Printf("  🔄 %s%s  since %s%s\n", p.ID, title, p.StartedAt.Local().Format("01-02 15:04"), source)
+	}
+
+	fmt.Printf("\n활성 세션 (%d개, 시작된 세션 %d개):\n", len(snap.ActiveSessions), snap.SessionsStarted)
+	if len(snap.ActiveSessions) == 0 {
+		fmt.Println("  (없음)")
+	}
+	for _, sess := range snap.ActiveSessions {
+		title := sess.Title
+		if title == "" {
+			title = "-"
+		}
+		fmt.Printf("  ▶ %s  %s  since %s  ~$%.2f\n", shortSessionID(sess.ID), truncate(title, 40),
+			sess.StartedAt.Local().Format("01-02 15:04"), sess.CostUSD)
+	}
+
+	fmt.Printf("\n누적 비용: $%.2f (확정 $%.2f + 진행 중 추정 $%.2f)\n",
+		snap.Cost.TotalUSD, snap.Cost.SettledUSD, snap.Cost.EstimatedUSD)
+	fmt.Printf("기록된 이벤트: %d개\n", snap.Events)
+	return nil
+}
+
+func shortSessionID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
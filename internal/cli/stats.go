@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsDays    int
+	statsProject string
+	statsBy      string
+	statsMetric  string
+	statsWindow  int
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "사용 통계",
+	Long:  `세션/에이전트/프로젝트 사용 통계를 조회합니다.`,
+}
+
+var statsHeatmapCmd = &cobra.Command{
+	Use:   "heatmap",
+	Short: "시간대별 사용량 히트맵",
+	Long: `세션이 언제 실행되고 어떤 에이전트/프로젝트가 예산을 쓰는지
+요일 × 시간대 히트맵으로 표시합니다.
+무거운 Orchestration을 오프피크 시간대에 스케줄링할 때 참고하세요.
+
+예시:
+  pal stats heatmap
+  pal stats heatmap --days 7 --metric cost
+  pal stats heatmap --by project
+  pal stats heatmap --project my-app`,
+	RunE: runStatsHeatmap,
+}
+
+var statsCostsCmd = &cobra.Command{
+	Use:   "costs",
+	Short: "토큰/비용 분석",
+	Long: `기간 내 토큰과 비용을 일별(이동 평균 포함)/주별/프로젝트/세션 타입/에이전트/포트별로 집계합니다.
+웹 대시보드의 /api/v2/analytics/costs와 같은 결과입니다.
+
+예시:
+  pal stats costs
+  pal stats costs --days 90 --window 14
+  pal stats costs --project my-app --json`,
+	RunE: runStatsCosts,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.AddCommand(statsHeatmapCmd)
+	statsCmd.AddCommand(statsCostsCmd)
+
+	statsHeatmapCmd.Flags().IntVar(&statsDays, "days", 30, "조회 기간 (일, 0 = 전체)")
+	statsHeatmapCmd.Flags().StringVar(&statsProject, "project", "", "프로젝트 필터 (이름 또는 경로)")
+	statsHeatmapCmd.Flags().StringVar(&statsBy, "by", usage.GroupByAgent, "그룹 기준 (agent, project)")
+	statsHeatmapCmd.Flags().StringVar(&statsMetric, "metric", "tokens", "표시 지표 (sessions, tokens, cost)")
+
+	statsCostsCmd.Flags().IntVar(&statsDays, "days", 30, "조회 기간 (일)")
+	statsCostsCmd.Flags().StringVar(&statsProject, "project", "", "프로젝트 필터 (이름 또는 경로)")
+	statsCostsCmd.Flags().IntVar(&statsWindow, "window", usage.DefaultCostWindow, "이동 평균 기간 (일)")
+}
+
+func runStatsHeatmap(cmd *cobra.Command, args []string) error {
+	switch statsMetric {
+	case "sessions", "tokens", "cost":
+	default:
+		return fmt.Errorf("알 수 없는 지표: %s (sessions, tokens, cost)", statsMetric)
+	}
+
+	svc, cleanup, err := getUsageService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	opts := usage.HeatmapOptions{
+		Project: statsProject,
+		GroupBy: statsBy,
+	}
+	if statsDays > 0 {
+		opts.Since = time.Now().AddDate(0, 0, -statsDays)
+	}
+
+	hm, err := svc.GetHeatmap(opts)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(hm)
+	}
+
+	period := "전체"
+	if statsDays > 0 {
+		period = fmt.Sprintf("최근 %d일", statsDays)
+	}
+	fmt.Printf("🔥 사용량 히트맵 (%s, %s, %s)\n\n", period, statsMetric, hm.Timezone)
+
+	if hm.Total.Sessions == 0 {
+		fmt.Println("기간 내 세션이 없습니다.")
+		return nil
+	}
+
+	// 요일 × 시간 히트맵
+	var max float64
+	for d := 0; d < 7; d++ {
+		for h := 0; h < 24; h++ {
+			if v := heatValue(hm.Cells[d][h], statsMetric); v > max {
+				max = v
+			}
+		}
+	}
+
+	fmt.Print("     ")
+	for h := 0; h < 24; h++ {
+		if h%3 == 0 {
+			fmt.Printf("%-3d", h)
+		}
+	}
+	fmt.Println()
+
+	weekdays := []string{"일", "월", "화", "수", "목", "금", "토"}
+	for _, d := range []int{1, 2, 3, 4, 5, 6, 0} {
+		fmt.Printf("  %s ", weekdays[d])
+		for h := 0; h < 24; h++ {
+			fmt.Print(heatShade(heatValue(hm.Cells[d][h], statsMetric), max))
+		}
+		fmt.Println()
+	}
+	fmt.Println("     (░ 낮음 ▒ 중간 ▓ 높음 █ 최대)")
+	fmt.Println()
+
+	if len(hm.PeakHours) > 0 {
+		fmt.Printf("피크 시간대: %s\n", formatHours(hm.PeakHours))
+		fmt.Printf("한산한 시간대: %s\n", formatHours(hm.QuietHours))
+		fmt.Println()
+	}
+
+	// 그룹별 사용량
+	label := "에이전트"
+	if hm.GroupBy == usage.GroupByProject {
+		label = "프로젝트"
+	}
+	fmt.Printf("%s별 사용량:\n", label)
+	for _, g := range hm.Groups {
+		peak := 0
+		for h := 1; h < 24; h++ {
+			if g.Hours[h].Tokens > g.Hours[peak].Tokens {
+				peak = h
+			}
+		}
+		fmt.Printf("  %-24s 세션 %-4d 토큰 %-8s $%-8.2f 주 사용 %02d시\n",
+			truncate(g.Name, 24), g.Total.Sessions, formatNumber(g.Total.Tokens), g.Total.CostUSD, peak)
+	}
+
+	return nil
+}
+
+func heatValue(c usage.HeatCell, metric string) float64 {
+	switch metric {
+	case "sessions":
+		return float64(c.Sessions)
+	case "cost":
+		return c.CostUSD
+	default:
+		return float64(c.Tokens)
+	}
+}
+
+func heatShade(v, max float64) string {
+	if v <= 0 || max <= 0 {
+		return "·"
+	}
+	ratio := v / max
+	switch {
+	case ratio >= 0.9:
+		return "█"
+	case ratio >= 0.5:
+		return "▓"
+	case ratio >= 0.2:
+		return "▒"
+	default:
+		return "░"
+	}
+}
+
+func formatHours(hours []int) string {
+	parts := make([]string, len(hours))
+	for i, h := range hours {
+		parts[i] = fmt.Sprintf("%02d시", h)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func runStatsCosts(cmd *cobra.Command, args []string) error {
+	if statsDays < 1 {
+		return fmt.Errorf("조회 기간은 1일 이상이어야 합니다")
+	}
+
+	svc, cleanup, err := getUsageService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	now := time.Now()
+	report, err := svc.GetCosts(usage.CostOptions{
+		Since:   now.AddDate(0, 0, -(statsDays - 1)),
+		Until:   now,
+		Project: statsProject,
+		Window:  min(statsWindow, statsDays),
+	})
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(report)
+	}
+
+	fmt.Printf("💰 토큰/비용 분석 (최근 %d일, 이동 평균 %d일)\n\n", statsDays, report.Window)
+	if report.Total.Sessions == 0 {
+		fmt.Println("기간 내 세션이 없습니다.")
+		return nil
+	}
+	fmt.Printf("합계: 세션 %d, 토큰 %s, $%.2f\n\n", report.Total.Sessions, formatNumber(report.Total.Tokens), report.Total.CostUSD)
+
+	// 일별: 사용이 있는 날만 표시
+	fmt.Println("일별:")
+	for _, d := range report.Daily {
+		if d.Sessions == 0 {
+			continue
+		}
+		fmt.Printf("  %s  토큰 %-8s $%-8.2f 평균 $%.2f\n", d.Key, formatNumber(d.Tokens), d.CostUSD, d.CostMA)
+	}
+
+	printCostBuckets("주별 (월요일 시작)", report.Weekly)
+	printCostBuckets("프로젝트별", report.ByProject)
+	printCostBuckets("세션 타입별", report.BySessionType)
+	printCostBuckets("에이전트별", report.ByAgent)
+	printCostBuckets("포트별", report.ByPort)
+	return nil
+}
+
+func printCostBuckets(title string, buckets []usage.CostBucket) {
+	if len(buckets) == 0 {
+		return
+	}
+	fmt.Printf("\n%s:\n", title)
+	for _, b := range buckets {
+		sessions := "" // 포트별 집계는 세션 수가 없음
+		if b.Sessions > 0 {
+			sessions = fmt.Sprintf("세션 %d", b.Sessions)
+		}
+		fmt.Printf("  %-24s %-9s 토큰 %-8s $%.2f\n", truncate(b.Key, 24), sessions, formatNumber(b.Tokens), b.CostUSD)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/n0roo/pal-kit/internal/context"
 	"github.com/n0roo/pal-kit/internal/db"
 	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/health"
 	"github.com/n0roo/pal-kit/internal/lock"
 	"github.com/n0roo/pal-kit/internal/pipeline"
 	"github.com/n0roo/pal-kit/internal/port"
@@ -23,7 +24,9 @@ var statusCmd = &cobra.Command{
 	Short: "통합 상태 조회",
 	Long: `프로젝트의 현재 상태를 한눈에 조회합니다.
 
-세션, 포트, 파이프라인, Lock, 에스컬레이션 현황을 보여줍니다.`,
+세션, 포트, 파이프라인, Lock, 에스컬레이션 현황과 프로젝트 건강 점수를 보여줍니다.
+건강 점수는 오래된 문서 비율, 에스컬레이션 적체, 추적 안 된 수정 비율, 재작업률,
+예산 소진율(settings.budget)을 합산하며, -d로 요인별 원인을 볼 수 있습니다.`,
 	RunE: runStatus,
 }
 
@@ -31,7 +34,7 @@ var statusDetailedFlag bool
 
 func init() {
 	rootCmd.AddCommand(statusCmd)
-	statusCmd.Flags().BoolVarP(&statusDetailedFlag, "detailed", "d", false, "상세 정보 표시 (토큰, 시간)")
+	statusCmd.Flags().BoolVarP(&statusDetailedFlag, "detailed", "d", false, "상세 정보 표시 (토큰, 시간, 건강 요인별 원인)")
 }
 
 // StatusSummary holds all status information
@@ -43,6 +46,7 @@ type StatusSummary struct {
 	Escalations EscalationStatus `json:"escalations"`
 	Agents      AgentStatus      `json:"agents"`
 	TotalUsage  UsageSummary     `json:"total_usage"`
+	Health      *health.Report   `json:"health,omitempty"`
 }
 
 type SessionStatus struct {
@@ -186,6 +190,11 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		Types: types,
 	}
 
+	// 프로젝트 건강 점수
+	if report, err := health.NewService(database).Compute(projectRoot, 0, time.Now()); err == nil {
+		summary.Health = report
+	}
+
 	if jsonOut {
 		json.NewEncoder(os.Stdout).Encode(summary)
 		return nil
@@ -198,6 +207,10 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	fmt.Println("╚══════════════════════════════════════════════════════════════╝")
 	fmt.Println()
 
+	if summary.Health != nil {
+		printHealth(summary.Health, statusDetailedFlag)
+	}
+
 	// 세션 섹션
 	fmt.Printf("📍 Sessions: %d active / %d total\n", summary.Sessions.Active, summary.Sessions.Total)
 	if len(summary.Sessions.List) > 0 {
@@ -337,6 +350,32 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printHealth prints the health score; detailed mode drills down into every factor
+func printHealth(report *health.Report, detailed bool) {
+	gradeEmoji := map[string]string{
+		health.GradeHealthy: "🟢", health.GradeWarning: "🟡", health.GradeCritical: "🔴",
+	}
+	fmt.Printf("%s Health: %d/100 (%s, 최근 %s)\n", gradeEmoji[report.Grade], report.Score, report.Grade, report.Window)
+
+	factors := report.Weakest()
+	if detailed {
+		factors = report.Factors
+	}
+	for _, f := range factors {
+		if !f.Applicable {
+			fmt.Printf("   ⚪   -  %s: %s\n", f.Label, f.Detail)
+			continue
+		}
+		fmt.Printf("   %s %3d  %s: %s\n", gradeEmoji[health.GradeOf(f.Score)], f.Score, f.Label, f.Detail)
+		if detailed {
+			for _, item := range f.Items {
+				fmt.Printf("      └─ %s\n", item)
+			}
+		}
+	}
+	fmt.Println()
+}
+
 // formatDuration formats duration in human readable format
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
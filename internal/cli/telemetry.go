@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+// telemetryNoSend are top-level commands that never send reports (Claude Code가 자주 호출하거나 장시간 실행)
+var telemetryNoSend = map[string]bool{"hook": true, "mcp": true, "serve": true}
+
+// recordTelemetry counts the executed command when telemetry is enabled and sends the report once a day.
+// 실패해도 명령 결과에 영향을 주지 않습니다.
+func recordTelemetry(cmd *cobra.Command, cmdErr error) {
+	if cmd == nil || cmd == rootCmd {
+		return
+	}
+	name := strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+	if name == "help" || strings.HasPrefix(name, "__complete") || strings.HasPrefix(name, "completion") {
+		return
+	}
+
+	errKind := ""
+	if cmdErr != nil {
+		errKind = string(errcode.KindOf(cmdErr))
+	}
+
+	store := telemetry.NewStore(config.GlobalDir())
+	if err := store.Record(name, errKind); err != nil {
+		return
+	}
+	if !telemetryNoSend[strings.Fields(name)[0]] {
+		store.SendIfDue(Version, GetDBPath(), time.Now())
+	}
+}
+
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "익명 사용 통계 (opt-in)",
+	Long: `익명 사용 통계 전송을 관리합니다. 기본은 꺼져 있습니다.
+
+켜면 명령 이름별 실행 횟수, DB 크기 구간, 오류 유형만 집계하여 하루 한 번 전송합니다.
+명령 인자, 파일 경로, 프로젝트/세션 내용은 기록하지 않으며, 전송 내용은 pal telemetry preview로 확인할 수 있습니다.
+DO_NOT_TRACK=1이 설정되어 있으면 켜져 있어도 집계하지 않습니다.`,
+}
+
+var telemetryStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "텔레메트리 상태",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := telemetry.NewStore(config.GlobalDir())
+		st, err := store.Load()
+		if err != nil {
+			return err
+		}
+
+		if IsJSON() {
+			data, _ := json.MarshalIndent(map[string]interface{}{
+				"enabled":    st.Enabled,
+				"active":     st.Active(),
+				"install_id": st.InstallID,
+				"endpoint":   st.ReportEndpoint(),
+				"since":      st.Since,
+				"last_sent":  st.LastSent,
+				"file":       store.Path(),
+			}, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if !st.Enabled {
+			fmt.Println("텔레메트리: 꺼짐 (pal telemetry enable 로 켤 수 있습니다)")
+			return nil
+		}
+		state := "켜짐"
+		if !st.Active() {
+			state = "켜짐 (DO_NOT_TRACK 설정으로 집계 중지)"
+		}
+		fmt.Printf("텔레메트리: %s\n", state)
+		fmt.Printf("  설치 ID: %s\n", st.InstallID)
+		endpoint := st.ReportEndpoint()
+		if endpoint == "" {
+			endpoint = "(없음 - 로컬 집계만)"
+		}
+		fmt.Printf("  전송 주소: %s\n", endpoint)
+		total := 0
+		for _, n := range st.Commands {
+			total += n
+		}
+		fmt.Printf("  집계: %s부터 명령 %d회\n", st.Since.Local().Format("2006-01-02 15:04"), total)
+		if st.LastSent != nil {
+			fmt.Printf("  마지막 전송: %s\n", st.LastSent.Local().Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+}
+
+var telemetryEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "텔레메트리 켜기",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endpoint, _ := cmd.Flags().GetString("endpoint")
+		st, err := telemetry.NewStore(config.GlobalDir()).Enable(endpoint, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Println("✓ 텔레메트리를 켰습니다.")
+		fmt.Printf("  설치 ID: %s (무작위, 사용자 정보와 무관)\n", st.InstallID)
+		if st.ReportEndpoint() == "" {
+			fmt.Println("  전송 주소가 없어 로컬에만 집계됩니다 (--endpoint 또는 PAL_TELEMETRY_ENDPOINT)")
+		}
+		fmt.Println("  전송 내용 확인: pal telemetry preview")
+		return nil
+	},
+}
+
+var telemetryDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "텔레메트리 끄기",
+	Long:  `텔레메트리를 끄고 설치 ID와 전송하지 않은 집계를 삭제합니다.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := telemetry.NewStore(config.GlobalDir()).Disable(); err != nil {
+			return err
+		}
+		fmt.Println("✓ 텔레메트리를 껐습니다. 집계한 내용은 삭제되었습니다.")
+		return nil
+	},
+}
+
+var telemetryPreviewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "전송될 내용 미리보기",
+	Long:  `다음 전송에 보낼 내용을 그대로 출력합니다. 전송하지는 않습니다.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := telemetry.NewStore(config.GlobalDir()).Load()
+		if err != nil {
+			return err
+		}
+		if !st.Enabled && !IsJSON() {
+			fmt.Println("# 텔레메트리가 꺼져 있어 아무것도 전송하지 않습니다. 켰을 때의 형식:")
+		}
+		data, _ := json.MarshalIndent(telemetry.BuildReport(st, Version, GetDBPath(), time.Now()), "", "  ")
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(telemetryCmd)
+	telemetryCmd.AddCommand(telemetryStatusCmd)
+	telemetryCmd.AddCommand(telemetryEnableCmd)
+	telemetryCmd.AddCommand(telemetryDisableCmd)
+	telemetryCmd.AddCommand(telemetryPreviewCmd)
+
+	telemetryEnableCmd.Flags().String("endpoint", "", "전송 주소 (기본: 배포 빌드 설정)")
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var workspaceDepth int
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace [path]",
+	Short: "워크스페이스(모노레포) 프로젝트 목록 및 통계",
+	Long: `모노레포 워크스페이스의 프로젝트 루트 목록과 통합 통계를 보여줍니다.
+
+워크스페이스 루트는 현재 프로젝트를 감싸는 최상위 .claude 루트입니다.
+하위 디렉토리의 프로젝트 루트와 세션에 기록된 프로젝트 루트를 함께 집계합니다.
+
+예시:
+  pal workspace
+  pal workspace ~/repo --depth 6`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWorkspace,
+}
+
+func init() {
+	rootCmd.AddCommand(workspaceCmd)
+	workspaceCmd.Flags().IntVar(&workspaceDepth, "depth", 4, "프로젝트 루트 탐색 깊이")
+}
+
+func runWorkspace(cmd *cobra.Command, args []string) error {
+	var workspaceRoot string
+	if len(args) > 0 {
+		abs, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("경로 확인 실패: %w", err)
+		}
+		workspaceRoot = abs
+	} else {
+		cwd, _ := os.Getwd()
+		projectRoot := context.FindProjectRoot(cwd)
+		workspaceRoot = context.FindWorkspaceRoot(projectRoot)
+		if workspaceRoot == "" {
+			workspaceRoot = projectRoot
+		}
+		if workspaceRoot == "" {
+			workspaceRoot = cwd
+		}
+	}
+
+	database, err := db.Open(GetDBPath())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	sessionSvc := session.NewService(database)
+
+	// 디렉토리 탐색 + 세션 기록 병합
+	seen := make(map[string]bool)
+	var roots []string
+	for _, root := range context.DiscoverProjectRoots(workspaceRoot, workspaceDepth) {
+		seen[root] = true
+		roots = append(roots, root)
+	}
+	recorded, err := sessionSvc.ListWorkspaceProjects(workspaceRoot)
+	if err != nil {
+		return err
+	}
+	for _, root := range recorded {
+		if !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+	sort.Strings(roots)
+
+	total := session.ProjectStats{ProjectRoot: workspaceRoot}
+	var projects []*session.ProjectStats
+	for _, root := range roots {
+		stats, err := sessionSvc.GetProjectStats(root)
+		if err != nil {
+			return err
+		}
+		projects = append(projects, stats)
+		total.TotalSessions += stats.TotalSessions
+		total.ActiveSessions += stats.ActiveSessions
+		total.TotalPorts += stats.TotalPorts
+		total.RunningPorts += stats.RunningPorts
+		total.InputTokens += stats.InputTokens
+		total.OutputTokens += stats.OutputTokens
+		total.CostUSD += stats.CostUSD
+	}
+
+	if jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"workspace_root": workspaceRoot,
+			"projects":       projects,
+			"total":          total,
+		})
+	}
+
+	fmt.Printf("🗂️  Workspace: %s\n\n", workspaceRoot)
+	if len(projects) == 0 {
+		fmt.Println("프로젝트 루트가 없습니다.")
+		return nil
+	}
+
+	fmt.Printf("%-40s %10s %10s %12s %10s\n", "PROJECT", "SESSIONS", "PORTS", "TOKENS", "COST")
+	for _, p := range projects {
+		name, err := filepath.Rel(workspaceRoot, p.ProjectRoot)
+		if err != nil || name == "." {
+			name = filepath.Base(p.ProjectRoot)
+		}
+		fmt.Printf("%-40s %6d/%-3d %6d/%-3d %12s %10s\n",
+			truncate(name, 40),
+			p.ActiveSessions, p.TotalSessions,
+			p.RunningPorts, p.TotalPorts,
+			formatNumber(p.InputTokens+p.OutputTokens),
+			fmt.Sprintf("$%.2f", p.CostUSD))
+	}
+	fmt.Printf("\n합계: 프로젝트 %d개, 세션 %d (실행 중 %d), 포트 %d (진행 중 %d), 토큰 %s, 비용 $%.2f\n",
+		len(projects), total.TotalSessions, total.ActiveSessions,
+		total.TotalPorts, total.RunningPorts,
+		formatNumber(total.InputTokens+total.OutputTokens), total.CostUSD)
+
+	return nil
+}
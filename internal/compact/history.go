@@ -0,0 +1,402 @@
+package compact
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/transcript"
+)
+
+// Event is a compaction as seen through both the legacy compactions table and compact_events.
+// JSON 필드는 attention.CompactEvent와 호환됩니다.
+type Event struct {
+	ID               string    `json:"id"`                      // compact_events ID (레거시 전용 기록은 "legacy-<id>")
+	CompactionID     int64     `json:"compaction_id,omitempty"` // compactions ID
+	SessionID        string    `json:"session_id"`
+	TriggerReason    string    `json:"trigger_reason"` // token_limit, user_request, auto
+	BeforeTokens     int64     `json:"before_tokens"`
+	AfterTokens      int64     `json:"after_tokens"`
+	Summary          string    `json:"summary,omitempty"`
+	PreservedContext []string  `json:"preserved_context,omitempty"`
+	DiscardedContext []string  `json:"discarded_context,omitempty"`
+	CheckpointBefore string    `json:"checkpoint_before,omitempty"`
+	RecoveryHint     string    `json:"recovery_hint,omitempty"`
+	Source           string    `json:"source"` // both, compactions, compact_events
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Event sources
+const (
+	SourceBoth        = "both"
+	SourceLegacy      = "compactions"
+	SourceEvents      = "compact_events"
+	legacyEventPrefix = "legacy-"
+	transcriptPrefix  = "transcript-"
+)
+
+// MatchWindow is how close two records of one session must be to count as the same compaction.
+// PreCompact 훅은 요약 전에, transcript 경계는 요약 후에 기록되므로 여유를 둡니다.
+const MatchWindow = 5 * time.Minute
+
+// History returns the compactions of a session from both stores, newest first.
+// 연결된 기록(compactions.event_id)은 하나로 합쳐지고, 한쪽에만 있는 기록도 포함됩니다.
+// sessionID가 비어 있으면 전체, limit <= 0이면 제한 없이 조회합니다.
+func (s *Service) History(sessionID string, limit int) ([]Event, error) {
+	var args []interface{}
+	eventFilter, legacyFilter := "", ""
+	if sessionID != "" {
+		eventFilter = ` WHERE e.session_id = ?`
+		legacyFilter = ` AND c.session_id = ?`
+		args = append(args, sessionID)
+	}
+	limitClause := ""
+	if limit > 0 {
+		limitClause = fmt.Sprintf(` LIMIT %d`, limit)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT e.id, COALESCE(c.id, 0), e.session_id, COALESCE(e.trigger_reason, ''),
+		       COALESCE(e.before_tokens, 0), COALESCE(e.after_tokens, 0), COALESCE(c.context_summary, ''),
+		       e.preserved_context, e.discarded_context, COALESCE(e.checkpoint_before, ''),
+		       COALESCE(e.recovery_hint, ''), e.created_at
+		FROM compact_events e
+		LEFT JOIN compactions c ON c.event_id = e.id`+eventFilter+`
+		ORDER BY e.created_at DESC`+limitClause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("컴팩션 이력 조회 실패: %w", err)
+	}
+	var events []Event
+	for rows.Next() {
+		var e Event
+		var preserved, discarded sql.NullString
+		if err := rows.Scan(&e.ID, &e.CompactionID, &e.SessionID, &e.TriggerReason,
+			&e.BeforeTokens, &e.AfterTokens, &e.Summary, &preserved, &discarded,
+			&e.CheckpointBefore, &e.RecoveryHint, &e.CreatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("컴팩션 이력 조회 실패: %w", err)
+		}
+		if preserved.Valid {
+			json.Unmarshal([]byte(preserved.String), &e.PreservedContext)
+		}
+		if discarded.Valid {
+			json.Unmarshal([]byte(discarded.String), &e.DiscardedContext)
+		}
+		e.Source = SourceEvents
+		if e.CompactionID > 0 {
+			e.Source = SourceBoth
+		}
+		events = append(events, e)
+	}
+	rows.Close()
+
+	// compact_events에 없는 레거시 기록
+	rows, err = s.db.Query(`
+		SELECT c.id, c.session_id, COALESCE(c.trigger_type, 'auto'), COALESCE(c.tokens_before, 0),
+		       COALESCE(c.context_summary, ''), c.triggered_at
+		FROM compactions c
+		WHERE (c.event_id IS NULL OR c.event_id NOT IN (SELECT id FROM compact_events))`+legacyFilter+`
+		ORDER BY c.triggered_at DESC`+limitClause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("컴팩션 이력 조회 실패: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e Event
+		var trigger string
+		if err := rows.Scan(&e.CompactionID, &e.SessionID, &trigger, &e.BeforeTokens, &e.Summary, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("컴팩션 이력 조회 실패: %w", err)
+		}
+		e.ID = legacyEventPrefix + strconv.FormatInt(e.CompactionID, 10)
+		e.TriggerReason = TriggerReason(trigger)
+		e.Source = SourceLegacy
+		events = append(events, e)
+	}
+
+	// 두 테이블의 타임스탬프 형식이 달라 정렬은 Go에서 수행
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.After(events[j].CreatedAt)
+	})
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+// TriggerReason maps a legacy trigger type to a compact_events trigger reason
+func TriggerReason(triggerType string) string {
+	if triggerType == "manual" {
+		return "user_request"
+	}
+	return "auto"
+}
+
+// TriggerType maps a compact_events trigger reason to a legacy trigger type
+func TriggerType(reason string) string {
+	if reason == "user_request" || reason == "manual" {
+		return "manual"
+	}
+	return "auto"
+}
+
+// ReconcileOptions controls a reconciliation run
+type ReconcileOptions struct {
+	SessionID   string // 비어 있으면 전체 세션
+	Transcripts bool   // 세션 transcript의 컴팩션 경계로 누락 기록 보충
+	DryRun      bool   // 변경 없이 결과만 계산
+}
+
+// ReconcileResult is what a reconciliation run found and changed
+type ReconcileResult struct {
+	Linked             int  `json:"linked"`              // 같은 컴팩션의 두 기록을 연결
+	EventsCreated      int  `json:"events_created"`      // 레거시 기록으로 만든 compact_events
+	CompactionsCreated int  `json:"compactions_created"` // compact_events로 만든 레거시 기록
+	Transcripts        int  `json:"transcripts"`         // 읽은 transcript 수
+	Markers            int  `json:"markers"`             // 발견한 컴팩션 경계 수
+	FromTranscripts    int  `json:"from_transcripts"`    // transcript로 보충한 컴팩션 수
+	SessionsUpdated    int  `json:"sessions_updated"`    // compact_count를 맞춘 세션 수
+	DryRun             bool `json:"dry_run"`
+}
+
+// Changed reports whether the run created or linked anything
+func (r *ReconcileResult) Changed() bool {
+	return r.Linked+r.EventsCreated+r.CompactionsCreated+r.FromTranscripts+r.SessionsUpdated > 0
+}
+
+// record is one side of a compaction while reconciling
+type record struct {
+	legacyID int64
+	eventID  string
+	session  string
+	at       time.Time
+	matched  bool
+}
+
+// Reconcile makes the legacy compactions table and compact_events describe the same compactions.
+// 한쪽에만 있는 기록은 다른 쪽에 만들어 연결하고, 시간이 가까운 미연결 기록끼리는 연결만 합니다.
+// Transcripts 옵션이면 transcript의 compact_boundary 중 어느 쪽에도 없는 컴팩션을 두 테이블에 모두 기록하고,
+// 마지막으로 sessions.compact_count가 기록 수보다 작으면 맞춥니다. 모든 변경은 하나의 트랜잭션입니다.
+func (s *Service) Reconcile(opts ReconcileOptions) (*ReconcileResult, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &ReconcileResult{DryRun: opts.DryRun}
+	filter, args := "", []interface{}{}
+	if opts.SessionID != "" {
+		filter = ` AND session_id = ?`
+		args = append(args, opts.SessionID)
+	}
+
+	// 미연결 compact_events
+	events, err := queryRecords(tx, `
+		SELECT 0, id, session_id, created_at FROM compact_events
+		WHERE id NOT IN (SELECT event_id FROM compactions WHERE event_id IS NOT NULL)`+filter, args...)
+	if err != nil {
+		return nil, err
+	}
+	// 미연결 레거시 기록
+	legacy, err := queryRecords(tx, `
+		SELECT id, '', session_id, triggered_at FROM compactions
+		WHERE (event_id IS NULL OR event_id NOT IN (SELECT id FROM compact_events))`+filter, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range legacy {
+		if e := closest(events, l.session, l.at); e != nil {
+			e.matched = true
+			if _, err := tx.Exec(`UPDATE compactions SET event_id = ? WHERE id = ?`, e.eventID, l.legacyID); err != nil {
+				return nil, fmt.Errorf("컴팩션 연결 실패: %w", err)
+			}
+			result.Linked++
+			continue
+		}
+
+		eventID := legacyEventPrefix + strconv.FormatInt(l.legacyID, 10)
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO compact_events (id, session_id, trigger_reason, before_tokens, after_tokens, recovery_hint, created_at)
+			SELECT ?, session_id, CASE WHEN trigger_type = 'manual' THEN 'user_request' ELSE 'auto' END,
+			       COALESCE(tokens_before, 0), 0, context_summary, ?
+			FROM compactions WHERE id = ?
+		`, eventID, l.at.In(time.Local), l.legacyID); err != nil {
+			return nil, fmt.Errorf("compact 이벤트 생성 실패: %w", err)
+		}
+		if _, err := tx.Exec(`UPDATE compactions SET event_id = ? WHERE id = ?`, eventID, l.legacyID); err != nil {
+			return nil, fmt.Errorf("컴팩션 연결 실패: %w", err)
+		}
+		result.EventsCreated++
+	}
+
+	for _, e := range events {
+		if e.matched {
+			continue
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO compactions (session_id, triggered_at, trigger_type, context_summary, tokens_before, event_id)
+			SELECT session_id, ?, CASE WHEN trigger_reason = 'user_request' THEN 'manual' ELSE 'auto' END,
+			       recovery_hint, before_tokens, id
+			FROM compact_events WHERE id = ?
+		`, e.at.UTC().Format("2006-01-02 15:04:05"), e.eventID); err != nil {
+			return nil, fmt.Errorf("컴팩션 생성 실패: %w", err)
+		}
+		result.CompactionsCreated++
+	}
+
+	if opts.Transcripts {
+		if err := backfillTranscripts(tx, opts.SessionID, result); err != nil {
+			return nil, err
+		}
+	}
+
+	// 기록 수보다 작은 compact_count 보정 (PreCompact 훅 카운트가 더 클 수 있으므로 줄이지 않음)
+	countFilter := ""
+	if opts.SessionID != "" {
+		countFilter = ` AND id = ?`
+	}
+	res, err := tx.Exec(`
+		UPDATE sessions SET
+			compact_count = (SELECT COUNT(*) FROM compactions c WHERE c.session_id = sessions.id),
+			last_compact_at = (SELECT MAX(triggered_at) FROM compactions c WHERE c.session_id = sessions.id)
+		WHERE COALESCE(compact_count, 0) < (SELECT COUNT(*) FROM compactions c WHERE c.session_id = sessions.id)`+countFilter, args...)
+	if err != nil {
+		return nil, fmt.Errorf("세션 컴팩션 수 갱신 실패: %w", err)
+	}
+	updated, _ := res.RowsAffected()
+	result.SessionsUpdated = int(updated)
+
+	if opts.DryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("컴팩션 정합화 실패: %w", err)
+	}
+	return result, nil
+}
+
+// backfillTranscripts records transcript compaction boundaries missing from both stores
+func backfillTranscripts(tx *sql.Tx, sessionID string, result *ReconcileResult) error {
+	query := `SELECT id, transcript_path FROM sessions WHERE transcript_path IS NOT NULL AND transcript_path != ''`
+	var args []interface{}
+	if sessionID != "" {
+		query += ` AND id = ?`
+		args = append(args, sessionID)
+	}
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("세션 조회 실패: %w", err)
+	}
+	paths := make(map[string]string)
+	var sessions []string
+	for rows.Next() {
+		var id, path string
+		if err := rows.Scan(&id, &path); err != nil {
+			rows.Close()
+			return fmt.Errorf("세션 조회 실패: %w", err)
+		}
+		paths[id] = path
+		sessions = append(sessions, id)
+	}
+	rows.Close()
+
+	for _, sid := range sessions {
+		markers, err := transcript.ParseCompactions(paths[sid])
+		if err != nil {
+			// transcript가 정리된 세션은 건너뜀
+			continue
+		}
+		result.Transcripts++
+		result.Markers += len(markers)
+		if len(markers) == 0 {
+			continue
+		}
+
+		// 이 시점에는 두 테이블이 연결되어 있으므로 compactions만 보면 됨
+		existing, err := queryRecords(tx, `SELECT id, COALESCE(event_id, ''), session_id, triggered_at FROM compactions WHERE session_id = ?`, sid)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range markers {
+			eventID := transcriptPrefix + m.UUID
+			if m.UUID == "" {
+				eventID = fmt.Sprintf("%s%s-%d", transcriptPrefix, sid, m.Timestamp.Unix())
+			}
+			if hasRecord(existing, eventID) {
+				continue
+			}
+			if r := closest(existing, sid, m.Timestamp); r != nil {
+				r.matched = true
+				continue
+			}
+
+			reason := TriggerReason(m.Trigger)
+			if _, err := tx.Exec(`
+				INSERT INTO compact_events (id, session_id, trigger_reason, before_tokens, after_tokens, recovery_hint, created_at)
+				VALUES (?, ?, ?, ?, 0, ?, ?)
+			`, eventID, sid, reason, m.PreTokens, m.Summary, m.Timestamp.In(time.Local)); err != nil {
+				return fmt.Errorf("compact 이벤트 생성 실패: %w", err)
+			}
+			if _, err := tx.Exec(`
+				INSERT INTO compactions (session_id, triggered_at, trigger_type, context_summary, tokens_before, event_id)
+				VALUES (?, ?, ?, ?, ?, ?)
+			`, sid, m.Timestamp.UTC().Format("2006-01-02 15:04:05"), TriggerType(m.Trigger), m.Summary, m.PreTokens, eventID); err != nil {
+				return fmt.Errorf("컴팩션 생성 실패: %w", err)
+			}
+			existing = append(existing, &record{eventID: eventID, session: sid, at: m.Timestamp, matched: true})
+			result.FromTranscripts++
+		}
+	}
+	return nil
+}
+
+func queryRecords(tx *sql.Tx, query string, args ...interface{}) ([]*record, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("컴팩션 기록 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*record
+	for rows.Next() {
+		r := &record{}
+		if err := rows.Scan(&r.legacyID, &r.eventID, &r.session, &r.at); err != nil {
+			return nil, fmt.Errorf("컴팩션 기록 조회 실패: %w", err)
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// closest returns the unmatched record of the session nearest to at within MatchWindow
+func closest(records []*record, session string, at time.Time) *record {
+	var best *record
+	var bestDiff time.Duration
+	for _, r := range records {
+		if r.matched || r.session != session {
+			continue
+		}
+		diff := r.at.Sub(at)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= MatchWindow && (best == nil || diff < bestDiff) {
+			best, bestDiff = r, diff
+		}
+	}
+	return best
+}
+
+func hasRecord(records []*record, eventID string) bool {
+	for _, r := range records {
+		if r.eventID == eventID {
+			return true
+		}
+	}
+	return false
+}
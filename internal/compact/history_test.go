@@ -0,0 +1,95 @@
+package compact
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func setupTestDB(t *testing.T) *db.DB {
+	t.Helper()
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	if err := database.Init(); err != nil {
+		t.Fatalf("DB 초기화 실패: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func TestReconcile(t *testing.T) {
+	database := setupTestDB(t)
+	svc := NewService(database)
+
+	transcriptPath := filepath.Join(t.TempDir(), "s1.jsonl")
+	os.WriteFile(transcriptPath, []byte(
+		`{"type":"system","subtype":"compact_boundary","uuid":"b-1","timestamp":"2026-01-05T10:01:00.000Z","compactMetadata":{"trigger":"auto","preTokens":150000}}
+{"type":"system","subtype":"compact_boundary","uuid":"b-2","timestamp":"2026-01-05T14:00:00.000Z","compactMetadata":{"trigger":"manual","preTokens":80000}}
+{"type":"user","isCompactSummary":true,"message":{"content":"요약"}}
+`), 0644)
+	database.Exec(`INSERT INTO sessions (id, transcript_path, compact_count) VALUES ('s1', ?, 1)`, transcriptPath)
+
+	// 레거시 기록 하나 (10:00), 같은 컴팩션의 이벤트 하나 (10:02), 이벤트에만 있는 기록 하나 (12:00)
+	database.Exec(`INSERT INTO compactions (session_id, triggered_at, trigger_type, tokens_before) VALUES ('s1', '2026-01-05 10:00:00', 'auto', 150000)`)
+	database.Exec(`INSERT INTO compact_events (id, session_id, trigger_reason, before_tokens, after_tokens, created_at) VALUES ('e-1', 's1', 'token_limit', 150000, 20000, ?)`,
+		time.Date(2026, 1, 5, 10, 2, 0, 0, time.UTC))
+	database.Exec(`INSERT INTO compact_events (id, session_id, trigger_reason, before_tokens, after_tokens, created_at) VALUES ('e-2', 's1', 'user_request', 90000, 10000, ?)`,
+		time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC))
+
+	before, err := svc.History("s1", 0)
+	if err != nil {
+		t.Fatalf("History 실패: %v", err)
+	}
+	if len(before) != 3 {
+		t.Fatalf("Expected 3 unlinked records before reconcile, got %d", len(before))
+	}
+
+	dry, err := svc.Reconcile(ReconcileOptions{Transcripts: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("Reconcile dry-run 실패: %v", err)
+	}
+	if !dry.Changed() {
+		t.Error("Dry run should report changes")
+	}
+	if after, _ := svc.History("s1", 0); len(after) != 3 || after[0].Source == SourceBoth {
+		t.Error("Dry run should not change anything")
+	}
+
+	result, err := svc.Reconcile(ReconcileOptions{Transcripts: true})
+	if err != nil {
+		t.Fatalf("Reconcile 실패: %v", err)
+	}
+	if result.Linked != 1 || result.CompactionsCreated != 1 || result.Markers != 2 || result.FromTranscripts != 1 || result.SessionsUpdated != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	events, _ := svc.History("s1", 0)
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 compactions after reconcile, got %d", len(events))
+	}
+	for _, e := range events {
+		if e.Source != SourceBoth {
+			t.Errorf("Expected every compaction in both stores: %+v", e)
+		}
+	}
+	if events[0].ID != "transcript-b-2" || events[0].TriggerReason != "user_request" || events[0].Summary != "요약" {
+		t.Errorf("Unexpected newest compaction: %+v", events[0])
+	}
+
+	var count int
+	database.QueryRow(`SELECT compact_count FROM sessions WHERE id = 's1'`).Scan(&count)
+	if count != 3 {
+		t.Errorf("Expected compact_count 3, got %d", count)
+	}
+
+	// 두 번째 실행은 변경 없음
+	again, _ := svc.Reconcile(ReconcileOptions{Transcripts: true})
+	if again.Changed() {
+		t.Errorf("Reconcile should be idempotent: %+v", again)
+	}
+}
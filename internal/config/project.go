@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/n0roo/pal-kit/internal/errcode"
 	"gopkg.in/yaml.v3"
 )
 
@@ -27,6 +29,73 @@ const (
 	TrackingModeOff    PortTrackingMode = "off"    // 추적 안 함
 )
 
+// LockMode represents how PreToolUse handles files locked by another session
+type LockMode string
+
+const (
+	LockModeBlock LockMode = "block" // 다른 세션이 Lock한 파일 수정 차단
+	LockModeWarn  LockMode = "warn"  // 경고만
+)
+
+// AcceptanceMode represents how port-end handles unchecked acceptance criteria
+type AcceptanceMode string
+
+const (
+	AcceptanceModeBlock  AcceptanceMode = "block"  // 완료 거부 (기본)
+	AcceptanceModeReview AcceptanceMode = "review" // needs-review 상태로 표시
+	AcceptanceModeOff    AcceptanceMode = "off"    // 확인하지 않음
+)
+
+// BriefingMode represents how the session-start briefing is injected
+type BriefingMode string
+
+const (
+	BriefingModeFull  BriefingMode = "full"  // 매 세션 전체 브리핑 요약
+	BriefingModeDelta BriefingMode = "delta" // 이전 브리핑 이후 변경 사항만
+)
+
+// WarningChannel represents where a hook warning is delivered
+type WarningChannel string
+
+const (
+	WarningChannelClaude WarningChannel = "claude" // stderr + hookSpecificOutput (Claude가 읽음)
+	WarningChannelLog    WarningChannel = "log"    // 세션 이벤트(로그/대시보드)에만 기록
+)
+
+// Hook warning categories
+const (
+	WarningUntrackedEdit = "untracked_edit" // 활성 포트 없이 코드 수정
+	WarningLockConflict  = "lock_conflict"  // 다른 세션의 Lock 파일 수정 (warn 모드)
+	WarningPortReminder  = "port_reminder"  // 작업 요청 시 포트 활성화 리마인더
+	WarningMultiSession  = "multi_session"  // 같은 프로젝트의 다른 세션 실행 중
+	WarningHookError     = "hook_error"     // 브리핑/요약 생성 실패 등 내부 오류
+	WarningBudget        = "budget"         // 세션/일일 예산 임계치(50/80/100%) 도달
+)
+
+// DefaultWarningChannels holds the default channel per warning category.
+// 작업 흐름에 직접 영향을 주는 경고만 Claude에게 전달하고, 내부 오류는 로그로만 남깁니다.
+var DefaultWarningChannels = map[string]WarningChannel{
+	WarningUntrackedEdit: WarningChannelClaude,
+	WarningLockConflict:  WarningChannelClaude,
+	WarningPortReminder:  WarningChannelClaude,
+	WarningMultiSession:  WarningChannelLog,
+	WarningHookError:     WarningChannelLog,
+	WarningBudget:        WarningChannelClaude,
+}
+
+// EventSamplingRule controls how often an event type is recorded.
+// 1분 동안 MaxPerMinute를 넘는 이벤트는 개별 기록 대신 events_collapsed 요약 이벤트로 합쳐집니다.
+type EventSamplingRule struct {
+	MaxPerMinute int `yaml:"max_per_minute"` // 0 = 제한 없음
+}
+
+// DefaultEventSampling holds the default sampling rule per event type.
+// untracked_edit은 port backfill에서 개별 이벤트를 사용하므로 기본 제한이 없습니다.
+var DefaultEventSampling = map[string]EventSamplingRule{
+	"file_edit":   {MaxPerMinute: 50},
+	"file_change": {MaxPerMinute: 50},
+}
+
 // ProjectConfig represents .pal/config.yaml
 type ProjectConfig struct {
 	Version  string          `yaml:"version"`
@@ -35,6 +104,62 @@ type ProjectConfig struct {
 	Agents   AgentsConfig    `yaml:"agents"`
 	Settings ProjectSettings `yaml:"settings"`
 	Context  ContextConfig   `yaml:"context"` // v11: 컨텍스트 설정
+
+	Notifications NotificationConfig `yaml:"notifications,omitempty"` // Notification Hook 라우팅
+}
+
+// NotificationConfig holds routing rules for Claude Code notifications
+type NotificationConfig struct {
+	Routes  []NotificationRoute `yaml:"routes,omitempty"`
+	Webhook WebhookSinkConfig   `yaml:"webhook,omitempty"`
+	File    FileSinkConfig      `yaml:"file,omitempty"`
+	Digest  DigestConfig        `yaml:"digest,omitempty"`
+}
+
+// NotificationRoute maps a notification type to sinks.
+// Match는 notification_type (예: permission_prompt, idle_prompt)이며 "*"는 전체입니다.
+type NotificationRoute struct {
+	Match string   `yaml:"match"`
+	Sinks []string `yaml:"sinks"` // desktop, webhook, file
+}
+
+// WebhookSinkConfig configures the webhook sink
+type WebhookSinkConfig struct {
+	URL     string            `yaml:"url,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// FileSinkConfig configures the file sink (상대 경로는 프로젝트 루트 기준)
+type FileSinkConfig struct {
+	Path string `yaml:"path,omitempty"`
+}
+
+// DigestConfig configures the weekly report email digest (pal serve 데몬이 스케줄에 따라 발송)
+type DigestConfig struct {
+	Recipients []string       `yaml:"recipients,omitempty"`
+	Schedule   string         `yaml:"schedule,omitempty"` // 5필드 cron (기본: 월요일 09:00)
+	Provider   string         `yaml:"provider,omitempty"` // smtp, sendgrid
+	From       string         `yaml:"from,omitempty"`
+	SMTP       SMTPConfig     `yaml:"smtp,omitempty"`
+	SendGrid   SendGridConfig `yaml:"sendgrid,omitempty"`
+}
+
+// SMTPConfig configures the SMTP provider (password는 ${ENV} 확장 지원)
+type SMTPConfig struct {
+	Host     string `yaml:"host,omitempty"`
+	Port     int    `yaml:"port,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// SendGridConfig configures the SendGrid provider (api_key는 ${ENV} 확장 지원)
+type SendGridConfig struct {
+	APIKey string `yaml:"api_key,omitempty"`
+}
+
+// Enabled reports whether the digest has recipients to send to
+func (d DigestConfig) Enabled() bool {
+	return len(d.Recipients) > 0
 }
 
 // ContextConfig holds context management settings
@@ -72,6 +197,7 @@ type ProjectInfo struct {
 	Name        string `yaml:"name"`
 	Description string `yaml:"description,omitempty"`
 	Root        string `yaml:"root,omitempty"`
+	Language    string `yaml:"language,omitempty"` // 생성 안내문(rules, 브리핑, 훅 경고) 언어: ko, en (기본: ko)
 }
 
 // WorkflowConfig holds workflow settings
@@ -95,6 +221,316 @@ type ProjectSettings struct {
 	// v11: 포트 추적 강제화
 	TrackingMode       PortTrackingMode `yaml:"tracking_mode"`       // strict, warn, off
 	TrackingAutoCreate bool             `yaml:"tracking_auto_create"` // 자동 포트 생성 제안
+
+	// 파일 Lock 강제 (기본: block)
+	LockMode LockMode `yaml:"lock_mode,omitempty"` // block, warn
+
+	// port-end 시 완료 기준 미충족 처리 (기본: block)
+	AcceptanceMode AcceptanceMode `yaml:"acceptance_mode,omitempty"` // block, review, off
+
+	// 세션 시작 브리핑 주입 방식 (기본: full)
+	BriefingMode BriefingMode `yaml:"briefing_mode,omitempty"` // full, delta
+
+	// 경고 카테고리별 출력 채널 (claude, log). 미지정 카테고리는 기본값 사용
+	Warnings map[string]WarningChannel `yaml:"warnings,omitempty"`
+
+	// 이벤트 타입별 샘플링 규칙. 미지정 타입은 기본값 사용
+	EventSampling map[string]EventSamplingRule `yaml:"event_sampling,omitempty"`
+
+	// 워커 무응답(stalled) 판정 시간 (예: "10m"). 비어 있으면 기본값 사용
+	WorkerStallWindow string `yaml:"worker_stall_window,omitempty"`
+
+	// Handoff 타입별 토큰 예산 (예: api_contract: 1500). 미지정 타입은 기본값 사용
+	HandoffBudgets map[string]int `yaml:"handoff_budgets,omitempty"`
+
+	// 세션 유휴(paused) 판정 시간 (예: "30m", "off"). 비어 있으면 기본값 사용
+	IdlePauseAfter string `yaml:"idle_pause_after,omitempty"`
+
+	// 세션/일일 비용·토큰 예산
+	Budget BudgetSettings `yaml:"budget,omitempty"`
+
+	// 프로젝트 프라이머 캐시 갱신 주기 (예: "15m", "off"). 비어 있으면 기본값 사용
+	PrimerRefresh string `yaml:"primer_refresh,omitempty"`
+
+	// 세션 이벤트 JSONL 감사 로그 (.pal/audit/YYYY-MM-DD.jsonl)
+	Audit AuditSettings `yaml:"audit,omitempty"`
+
+	// PreCompact 시 컴팩션 후에도 유지할 작업 상태 힌트
+	PreCompact PreCompactSettings `yaml:"pre_compact,omitempty"`
+
+	// 완료 후 이 기간이 지난 포트를 .pal/archive로 자동 보관 (기본 30, -1 = 사용 안 함)
+	PortArchiveDays int `yaml:"port_archive_days,omitempty"`
+
+	// Lock 경합 자동 에스컬레이션
+	LockEscalation LockEscalationSettings `yaml:"lock_escalation,omitempty"`
+
+	// 생성된 rules/브리핑 파일의 유효 기간 (예: "24h", "off"). 비어 있으면 기본값 사용
+	ContextTTL string `yaml:"context_ttl,omitempty"`
+
+	// MCP 서버 도구 실행 시간 제한
+	MCP MCPSettings `yaml:"mcp,omitempty"`
+}
+
+// MCPSettings controls the MCP server (pal mcp)
+type MCPSettings struct {
+	ToolTimeout  string            `yaml:"tool_timeout,omitempty"`  // 읽기 전용 도구의 기본 제한 (기본 60s, "off" = 제한 없음)
+	ToolTimeouts map[string]string `yaml:"tool_timeouts,omitempty"` // 도구별 제한 (예: pal_port_end: 10m)
+}
+
+// DefaultMCPToolTimeout is the default time limit of one MCP tool call
+const DefaultMCPToolTimeout = 60 * time.Second
+
+// TimeoutFor returns the time limit of a tool call (0 = 제한 없음)
+func (m MCPSettings) TimeoutFor(tool string) time.Duration {
+	if v, ok := m.ToolTimeouts[tool]; ok {
+		if d, ok := parseTimeout(v); ok {
+			return d
+		}
+	}
+	if d, ok := parseTimeout(m.ToolTimeout); ok {
+		return d
+	}
+	return DefaultMCPToolTimeout
+}
+
+// parseTimeout parses a duration setting; "off"/"0" means no limit, "" or invalid values are not set
+func parseTimeout(v string) (time.Duration, bool) {
+	switch v {
+	case "":
+		return 0, false
+	case "off", "0":
+		return 0, true
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// LockEscalationSettings controls when lock contention is escalated to a human
+type LockEscalationSettings struct {
+	Disabled   bool   `yaml:"disabled,omitempty"`
+	WaitAfter  string `yaml:"wait_after,omitempty"`  // 대기 시간 임계치 (기본 10m, "off" = 사용 안 함)
+	MaxWaiters int    `yaml:"max_waiters,omitempty"` // 대기 세션 수가 이 값을 넘으면 에스컬레이션 (기본 2, -1 = 사용 안 함)
+}
+
+// Lock escalation defaults
+const (
+	DefaultLockWaitAfter  = 10 * time.Minute
+	DefaultLockMaxWaiters = 2
+)
+
+// WaitThreshold returns the lock wait after which contention is escalated (0 = 사용 안 함)
+func (l LockEscalationSettings) WaitThreshold() time.Duration {
+	switch l.WaitAfter {
+	case "":
+		return DefaultLockWaitAfter
+	case "off", "0":
+		return 0
+	}
+	d, err := time.ParseDuration(l.WaitAfter)
+	if err != nil || d < 0 {
+		return DefaultLockWaitAfter
+	}
+	return d
+}
+
+// WaiterThreshold returns the number of waiters above which contention is escalated (0 = 사용 안 함)
+func (l LockEscalationSettings) WaiterThreshold() int {
+	switch {
+	case l.MaxWaiters == 0:
+		return DefaultLockMaxWaiters
+	case l.MaxWaiters < 0:
+		return 0
+	}
+	return l.MaxWaiters
+}
+
+// PreCompactSettings controls the "must preserve" block written on PreCompact
+type PreCompactSettings struct {
+	Disabled bool     `yaml:"disabled,omitempty"`
+	Sections []string `yaml:"sections,omitempty"`  // port, checklist, messages (비어 있으면 전체)
+	MaxItems int      `yaml:"max_items,omitempty"` // 섹션별 최대 항목 수 (기본 10)
+	Notes    []string `yaml:"notes,omitempty"`     // 항상 유지할 프로젝트 고유 지침
+}
+
+// PreCompact hint sections
+const (
+	PreserveSectionPort      = "port"
+	PreserveSectionChecklist = "checklist"
+	PreserveSectionMessages  = "messages"
+)
+
+// DefaultPreCompactMaxItems is the default number of items per preserve section
+const DefaultPreCompactMaxItems = 10
+
+// SectionEnabled reports whether a preserve section is included in the hint
+func (p PreCompactSettings) SectionEnabled(section string) bool {
+	if len(p.Sections) == 0 {
+		return true
+	}
+	for _, s := range p.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// Limit returns the maximum number of items per preserve section
+func (p PreCompactSettings) Limit() int {
+	if p.MaxItems <= 0 {
+		return DefaultPreCompactMaxItems
+	}
+	return p.MaxItems
+}
+
+// AuditSettings controls the JSONL audit log of session events
+type AuditSettings struct {
+	Disabled      bool `yaml:"disabled,omitempty"`
+	MaxSizeMB     int  `yaml:"max_size_mb,omitempty"`    // 일별 파일 최대 크기, 넘으면 YYYY-MM-DD.N.jsonl로 회전 (기본 50, -1 = 회전 안 함)
+	RetentionDays int  `yaml:"retention_days,omitempty"` // 보관 기간 (기본 30, -1 = 무제한)
+}
+
+// Audit log defaults
+const (
+	DefaultAuditMaxSizeMB     = 50
+	DefaultAuditRetentionDays = 30
+)
+
+// MaxSizeBytes returns the size at which the day's audit file is rotated (0 = 회전 안 함)
+func (a AuditSettings) MaxSizeBytes() int64 {
+	switch {
+	case a.MaxSizeMB == 0:
+		return DefaultAuditMaxSizeMB << 20
+	case a.MaxSizeMB < 0:
+		return 0
+	}
+	return int64(a.MaxSizeMB) << 20
+}
+
+// Retention returns how long audit files are kept (0 = 무제한)
+func (a AuditSettings) Retention() time.Duration {
+	switch {
+	case a.RetentionDays == 0:
+		return DefaultAuditRetentionDays * 24 * time.Hour
+	case a.RetentionDays < 0:
+		return 0
+	}
+	return time.Duration(a.RetentionDays) * 24 * time.Hour
+}
+
+// BudgetSettings holds per-session and per-day usage budgets (0 = 제한 없음).
+// 50/80/100% 도달 시 budget_warning 이벤트와 경고를 남기며, BlockAtLimit이면 100%에서 도구 사용을 차단합니다.
+// CostAlerts는 예산과 별개로 세션 비용이 넘으면 다음 프롬프트에 한 줄 알림을 남기는 금액(USD)입니다.
+type BudgetSettings struct {
+	SessionUSD    float64   `yaml:"session_usd,omitempty"`
+	SessionTokens int64     `yaml:"session_tokens,omitempty"`
+	DailyUSD      float64   `yaml:"daily_usd,omitempty"`
+	DailyTokens   int64     `yaml:"daily_tokens,omitempty"`
+	BlockAtLimit  bool      `yaml:"block_at_limit,omitempty"`
+	CostAlerts    []float64 `yaml:"cost_alerts,omitempty"` // 예: [2, 5]
+}
+
+// WarningChannelFor returns the output channel of a warning category
+func (s ProjectSettings) WarningChannelFor(category string) WarningChannel {
+	if ch, ok := s.Warnings[category]; ok && (ch == WarningChannelClaude || ch == WarningChannelLog) {
+		return ch
+	}
+	if ch, ok := DefaultWarningChannels[category]; ok {
+		return ch
+	}
+	return WarningChannelClaude
+}
+
+// EventMaxPerMinute returns the per-minute recording limit of an event type (0 = 제한 없음)
+func (s ProjectSettings) EventMaxPerMinute(eventType string) int {
+	if rule, ok := s.EventSampling[eventType]; ok {
+		return rule.MaxPerMinute
+	}
+	return DefaultEventSampling[eventType].MaxPerMinute
+}
+
+// StallWindow returns the configured worker stall window (미설정/잘못된 값이면 0)
+func (s ProjectSettings) StallWindow() time.Duration {
+	if s.WorkerStallWindow == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(s.WorkerStallWindow)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// DefaultIdlePauseAfter is the default inactivity before a session is paused
+const DefaultIdlePauseAfter = 30 * time.Minute
+
+// IdlePauseWindow returns the session idle threshold (0 = 비활성화)
+func (s ProjectSettings) IdlePauseWindow() time.Duration {
+	switch s.IdlePauseAfter {
+	case "":
+		return DefaultIdlePauseAfter
+	case "off", "0":
+		return 0
+	}
+	d, err := time.ParseDuration(s.IdlePauseAfter)
+	if err != nil || d < 0 {
+		return DefaultIdlePauseAfter
+	}
+	return d
+}
+
+// DefaultPortArchiveDays is the default number of days after completion before a port is archived
+const DefaultPortArchiveDays = 30
+
+// PortArchiveAfter returns how long completed ports are kept before archival (0 = 사용 안 함)
+func (s ProjectSettings) PortArchiveAfter() time.Duration {
+	switch {
+	case s.PortArchiveDays == 0:
+		return DefaultPortArchiveDays * 24 * time.Hour
+	case s.PortArchiveDays < 0:
+		return 0
+	}
+	return time.Duration(s.PortArchiveDays) * 24 * time.Hour
+}
+
+// DefaultPrimerRefresh is the default interval at which the daemon refreshes the project primer
+const DefaultPrimerRefresh = 15 * time.Minute
+
+// PrimerRefreshInterval returns the primer refresh interval (0 = 비활성화).
+// SessionStart는 이 주기의 2배보다 오래된 캐시는 사용하지 않습니다.
+func (s ProjectSettings) PrimerRefreshInterval() time.Duration {
+	switch s.PrimerRefresh {
+	case "":
+		return DefaultPrimerRefresh
+	case "off", "0":
+		return 0
+	}
+	d, err := time.ParseDuration(s.PrimerRefresh)
+	if err != nil || d < 0 {
+		return DefaultPrimerRefresh
+	}
+	return d
+}
+
+// DefaultContextTTL is the default lifetime of generated rules and briefing files
+const DefaultContextTTL = 24 * time.Hour
+
+// ContextExpiry returns how long generated rules/briefings stay valid (0 = 만료 없음)
+func (s ProjectSettings) ContextExpiry() time.Duration {
+	switch s.ContextTTL {
+	case "":
+		return DefaultContextTTL
+	case "off", "0":
+		return 0
+	}
+	d, err := time.ParseDuration(s.ContextTTL)
+	if err != nil || d < 0 {
+		return DefaultContextTTL
+	}
+	return d
 }
 
 // DefaultProjectConfig returns a default config
@@ -118,6 +554,7 @@ func DefaultProjectConfig(projectName string) *ProjectConfig {
 			AutoTestOnComplete: true,
 			TrackingMode:       TrackingModeWarn, // 기본: 경고만
 			TrackingAutoCreate: true,             // 자동 포트 생성 제안 활성화
+			LockMode:           LockModeBlock,
 		},
 		Context: ContextConfig{
 			TokenBudget: 15000, // 기본 15K 토큰
@@ -149,19 +586,31 @@ func LoadProjectConfig(projectRoot string) (*ProjectConfig, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("프로젝트 설정이 없습니다. Claude에게 'PAL Kit 환경을 설정해줘'라고 요청하세요")
+			return nil, errcode.New(errcode.KindConfig, "프로젝트 설정이 없습니다. Claude에게 'PAL Kit 환경을 설정해줘'라고 요청하세요")
 		}
-		return nil, fmt.Errorf("설정 파일 읽기 실패: %w", err)
+		return nil, errcode.New(errcode.KindConfig, "설정 파일 읽기 실패: %w", err)
 	}
 
 	var config ProjectConfig
 	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("설정 파일 파싱 실패: %w", err)
+		return nil, errcode.New(errcode.KindConfig, "설정 파일 파싱 실패: %w", err)
 	}
 
 	return &config, nil
 }
 
+// ProjectLanguage returns the configured guidance language of a project ("" if not set)
+func ProjectLanguage(projectRoot string) string {
+	if projectRoot == "" {
+		return ""
+	}
+	cfg, err := LoadProjectConfig(projectRoot)
+	if err != nil {
+		return ""
+	}
+	return cfg.Project.Language
+}
+
 // SaveProjectConfig saves config to .pal/config.yaml
 func SaveProjectConfig(projectRoot string, config *ProjectConfig) error {
 	configPath := ProjectConfigPath(projectRoot)
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestDefaultProjectConfig(t *testing.T) {
@@ -184,3 +185,110 @@ func TestProjectConfigPath(t *testing.T) {
 		t.Errorf("expected %s, got %s", expected, path)
 	}
 }
+
+func TestWarningChannelFor(t *testing.T) {
+	var settings ProjectSettings
+
+	if ch := settings.WarningChannelFor(WarningUntrackedEdit); ch != WarningChannelClaude {
+		t.Errorf("untracked_edit default = %s, want claude", ch)
+	}
+	if ch := settings.WarningChannelFor(WarningHookError); ch != WarningChannelLog {
+		t.Errorf("hook_error default = %s, want log", ch)
+	}
+
+	settings.Warnings = map[string]WarningChannel{
+		WarningUntrackedEdit: WarningChannelLog,
+		WarningHookError:     "unknown",
+	}
+	if ch := settings.WarningChannelFor(WarningUntrackedEdit); ch != WarningChannelLog {
+		t.Errorf("override = %s, want log", ch)
+	}
+	if ch := settings.WarningChannelFor(WarningHookError); ch != WarningChannelLog {
+		t.Errorf("invalid override should fall back to default, got %s", ch)
+	}
+	if ch := settings.WarningChannelFor("custom"); ch != WarningChannelClaude {
+		t.Errorf("unknown category = %s, want claude", ch)
+	}
+}
+
+func TestEventMaxPerMinute(t *testing.T) {
+	var settings ProjectSettings
+
+	if n := settings.EventMaxPerMinute("file_edit"); n != 50 {
+		t.Errorf("file_edit default = %d, want 50", n)
+	}
+	if n := settings.EventMaxPerMinute("untracked_edit"); n != 0 {
+		t.Errorf("untracked_edit default = %d, want 0", n)
+	}
+
+	settings.EventSampling = map[string]EventSamplingRule{
+		"file_edit":      {MaxPerMinute: 0},
+		"untracked_edit": {MaxPerMinute: 20},
+	}
+	if n := settings.EventMaxPerMinute("file_edit"); n != 0 {
+		t.Errorf("file_edit override = %d, want 0 (disabled)", n)
+	}
+	if n := settings.EventMaxPerMinute("untracked_edit"); n != 20 {
+		t.Errorf("untracked_edit override = %d, want 20", n)
+	}
+}
+
+func TestStallWindow(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"15m", 15 * time.Minute},
+		{"invalid", 0},
+		{"-5m", 0},
+	}
+	for _, tt := range tests {
+		s := ProjectSettings{WorkerStallWindow: tt.value}
+		if got := s.StallWindow(); got != tt.want {
+			t.Errorf("StallWindow(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIdlePauseWindow(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", DefaultIdlePauseAfter},
+		{"45m", 45 * time.Minute},
+		{"off", 0},
+		{"0", 0},
+		{"invalid", DefaultIdlePauseAfter},
+	}
+	for _, tt := range tests {
+		s := ProjectSettings{IdlePauseAfter: tt.value}
+		if got := s.IdlePauseWindow(); got != tt.want {
+			t.Errorf("IdlePauseWindow(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMCPToolTimeout(t *testing.T) {
+	var m MCPSettings
+	if got := m.TimeoutFor("pal_status"); got != DefaultMCPToolTimeout {
+		t.Errorf("기본 제한 = %v", got)
+	}
+
+	m = MCPSettings{
+		ToolTimeout:  "30s",
+		ToolTimeouts: map[string]string{"pal_port_end": "10m", "kb_search": "off", "doc_get": "bogus"},
+	}
+	cases := map[string]time.Duration{
+		"pal_status":   30 * time.Second,
+		"pal_port_end": 10 * time.Minute,
+		"kb_search":    0,
+		"doc_get":      30 * time.Second, // 잘못된 값은 기본 제한
+	}
+	for tool, want := range cases {
+		if got := m.TimeoutFor(tool); got != want {
+			t.Errorf("TimeoutFor(%s) = %v, want %v", tool, got, want)
+		}
+	}
+}
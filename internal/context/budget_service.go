@@ -3,6 +3,7 @@ package context
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/n0roo/pal-kit/internal/config"
 	"github.com/n0roo/pal-kit/internal/db"
@@ -183,9 +184,9 @@ func (s *BudgetService) collectConventions() {
 
 // collectSessionInfo collects session information
 func (s *BudgetService) collectSessionInfo() {
-	// 세션 브리핑 파일
+	// 세션 브리핑 파일 (만료된 브리핑은 제외)
 	briefingPath := filepath.Join(s.projectRoot, ".pal", "context", "session-briefing.md")
-	if content, err := os.ReadFile(briefingPath); err == nil {
+	if content, err := os.ReadFile(briefingPath); err == nil && !rules.IsExpired(string(content), time.Now()) {
 		s.manager.AddItem(BudgetItem{
 			ID:       "session-briefing",
 			Category: CategorySessionInfo,
@@ -25,6 +25,9 @@ type ClaudeService struct {
 	workerMapper  *worker.Mapper
 	promptBuilder *prompt.Builder
 	agentSvc      *agent.Service
+
+	// ReadOnly skips writes to CLAUDE.md and .claude/rules (PAL safe mode)
+	ReadOnly bool
 }
 
 // NewClaudeService creates a new Claude integration service
@@ -72,6 +75,9 @@ func (s *ClaudeService) ProcessSessionStart() (*SessionStartResult, error) {
 	result.TokenCount = len(builderPrompt) / 4 // rough estimate
 
 	// 3. Create builder rules file
+	if s.ReadOnly {
+		return result, nil
+	}
 	rulesDir := filepath.Join(s.projectRoot, ".claude", "rules")
 	if err := os.MkdirAll(rulesDir, 0755); err != nil {
 		return result, nil
@@ -92,6 +98,9 @@ func (s *ClaudeService) ProcessSessionStart() (*SessionStartResult, error) {
 // ProcessSessionEnd handles session-end cleanup for builder
 func (s *ClaudeService) ProcessSessionEnd() error {
 	// Remove builder rules file
+	if s.ReadOnly {
+		return nil
+	}
 	rulesFile := filepath.Join(s.projectRoot, ".claude", "rules", "builder.md")
 	if err := os.Remove(rulesFile); err != nil && !os.IsNotExist(err) {
 		return err
@@ -177,7 +186,7 @@ func (s *ClaudeService) ProcessPortStart(portID string) (*PortStartResult, error
 
 	// 5. Update CLAUDE.md with active worker info
 	claudeMD := FindClaudeMD(s.projectRoot)
-	if claudeMD != "" {
+	if claudeMD != "" && !s.ReadOnly {
 		if err := s.updateActiveWorkerSection(claudeMD, workerID, portID, buildResult); err == nil {
 			result.UpdatedFiles = append(result.UpdatedFiles, claudeMD)
 		}
@@ -204,7 +213,7 @@ func (s *ClaudeService) ProcessPortEnd(portID, workerID string) (*PortEndResult,
 
 	// Clear active worker section in CLAUDE.md
 	claudeMD := FindClaudeMD(s.projectRoot)
-	if claudeMD != "" {
+	if claudeMD != "" && !s.ReadOnly {
 		s.clearActiveWorkerSection(claudeMD)
 	}
 
@@ -2,6 +2,9 @@ package context
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,6 +12,7 @@ import (
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
 	"github.com/n0roo/pal-kit/internal/escalation"
 	"github.com/n0roo/pal-kit/internal/port"
 	"github.com/n0roo/pal-kit/internal/session"
@@ -128,38 +132,79 @@ func (s *Service) GenerateContext() (string, error) {
 	return sb.String(), nil
 }
 
-// InjectToFile injects context into CLAUDE.md file
-// Creates the file with default template if it doesn't exist
+// InjectToFile injects context into CLAUDE.md file.
+// 사용자와 다른 PAL 프로세스가 동시에 쓸 수 있으므로 낙관적 동시성으로 씁니다:
+// 읽은 내용의 해시를 쓰기 직전에 다시 확인하고, 그 사이 파일이 바뀌었으면
+// claude_md_conflict 이벤트를 남기고 최신 내용에 컨텍스트 블록을 다시 합쳐 재시도합니다.
 func (s *Service) InjectToFile(filePath string) error {
-	var content []byte
-	var err error
+	// 컨텍스트 생성
+	ctx, err := s.GenerateContext()
+	if err != nil {
+		return fmt.Errorf("컨텍스트 생성 실패: %w", err)
+	}
+
+	for attempt := 1; attempt <= InjectRetries; attempt++ {
+		content, hash, err := readClaudeMD(filePath)
+		if err != nil {
+			return err
+		}
+		merged := mergeContext(content, ctx)
+		if injectHook != nil {
+			injectHook(attempt)
+		}
+
+		written, err := writeIfUnchanged(filePath, hash, merged)
+		if err != nil {
+			return err
+		}
+		if written {
+			return nil
+		}
+		s.logInjectConflict(filePath, attempt)
+	}
+
+	return errcode.New(errcode.KindConflict, "CLAUDE.md가 계속 변경되어 컨텍스트를 주입하지 못했습니다 (%d회 시도): %s", InjectRetries, filePath)
+}
+
+// InjectRetries is how many times InjectToFile re-merges after a conflicting edit
+const InjectRetries = 3
 
-	// 파일 읽기 시도
-	content, err = os.ReadFile(filePath)
+// EventClaudeMDConflict is logged when CLAUDE.md changed while context was being injected
+const EventClaudeMDConflict = "claude_md_conflict"
+
+// injectLockStale is how old a CLAUDE.md lock file may be before it is treated as abandoned
+const injectLockStale = 10 * time.Second
+
+// injectHook runs between merging and writing (테스트에서 동시 수정을 재현할 때 사용)
+var injectHook func(attempt int)
+
+// readClaudeMD reads CLAUDE.md with the hash of what was read ("" when the file does not exist)
+func readClaudeMD(filePath string) (string, string, error) {
+	content, err := os.ReadFile(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// 파일이 없으면 기본 템플릿으로 생성
-			content = []byte(defaultClaudeMDTemplate)
-		} else {
-			return fmt.Errorf("파일 읽기 실패: %w", err)
+			return defaultClaudeMDTemplate, "", nil
 		}
+		return "", "", fmt.Errorf("파일 읽기 실패: %w", err)
 	}
+	return string(content), contentHash(content), nil
+}
 
-	// 컨텍스트 생성
-	ctx, err := s.GenerateContext()
-	if err != nil {
-		return fmt.Errorf("컨텍스트 생성 실패: %w", err)
-	}
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
 
+// mergeContext replaces the PAL context block of content, adding the markers when missing
+func mergeContext(content, ctx string) string {
 	// 마커가 없으면 추가
-	contentStr := string(content)
-	if !strings.Contains(contentStr, contextStartMarker) {
-		contentStr += "\n\n" + contextStartMarker + "\n" + contextEndMarker + "\n"
-		content = []byte(contentStr)
+	if !strings.Contains(content, contextStartMarker) {
+		content += "\n\n" + contextStartMarker + "\n" + contextEndMarker + "\n"
 	}
 
 	// 마커 찾기 및 교체
-	lines := strings.Split(string(content), "\n")
+	lines := strings.Split(content, "\n")
 	var result []string
 	inContext := false
 
@@ -180,8 +225,116 @@ func (s *Service) InjectToFile(filePath string) error {
 		}
 	}
 
-	// 파일 쓰기
-	return os.WriteFile(filePath, []byte(strings.Join(result, "\n")), 0644)
+	return strings.Join(result, "\n")
+}
+
+// writeIfUnchanged writes content only if the file still has the expected hash.
+// PAL 프로세스끼리는 잠금 파일(<file>.pal-lock)로 확인과 쓰기를 직렬화하고,
+// 임시 파일에 쓴 뒤 rename하여 읽는 쪽이 절반만 쓰인 파일을 보지 않게 합니다.
+func writeIfUnchanged(filePath, expectedHash, content string) (bool, error) {
+	// 심볼릭 링크면 링크가 아닌 대상 파일을 교체
+	filePath = resolveSymlink(filePath)
+
+	unlock, err := lockClaudeMD(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	current, err := os.ReadFile(filePath)
+	switch {
+	case err == nil:
+		if contentHash(current) != expectedHash {
+			return false, nil
+		}
+	case os.IsNotExist(err):
+		if expectedHash != "" {
+			return false, nil
+		}
+	default:
+		return false, fmt.Errorf("파일 읽기 실패: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".claude-md-*")
+	if err != nil {
+		return false, fmt.Errorf("임시 파일 생성 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return false, fmt.Errorf("파일 쓰기 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return false, fmt.Errorf("파일 쓰기 실패: %w", err)
+	}
+	os.Chmod(tmp.Name(), 0644)
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return false, fmt.Errorf("파일 쓰기 실패: %w", err)
+	}
+	return true, nil
+}
+
+// resolveSymlink returns the file a symlinked path points to (대상이 아직 없어도 링크를 따라감)
+func resolveSymlink(filePath string) string {
+	if resolved, err := filepath.EvalSymlinks(filePath); err == nil {
+		return resolved
+	}
+	info, err := os.Lstat(filePath)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return filePath
+	}
+	target, err := os.Readlink(filePath)
+	if err != nil {
+		return filePath
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(filePath), target)
+	}
+	return target
+}
+
+// lockClaudeMD takes the PAL writer lock of a CLAUDE.md file, waiting briefly for other writers
+func lockClaudeMD(filePath string) (func(), error) {
+	lockPath := filePath + ".pal-lock"
+	deadline := time.Now().Add(injectLockStale)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("CLAUDE.md 잠금 실패: %w", err)
+		}
+
+		// 비정상 종료로 남은 잠금은 제거
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > injectLockStale {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, errcode.New(errcode.KindConflict, "CLAUDE.md를 다른 프로세스가 쓰고 있습니다: %s", lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// logInjectConflict records a conflicting CLAUDE.md edit on the most recent running session of the file's project
+func (s *Service) logInjectConflict(filePath string, attempt int) {
+	projectRoot := FindProjectRoot(filepath.Dir(filePath))
+	if projectRoot == "" {
+		projectRoot = filepath.Dir(filePath)
+	}
+	var sessionID string
+	s.db.QueryRow(`SELECT id FROM sessions WHERE status = 'running' AND project_root = ?
+		ORDER BY started_at DESC LIMIT 1`, projectRoot).Scan(&sessionID)
+	data, _ := json.Marshal(map[string]interface{}{
+		"file":    filePath,
+		"attempt": attempt,
+		"action":  "merge_retry",
+	})
+	s.sessionSvc.LogEvent(sessionID, EventClaudeMDConflict, string(data))
 }
 
 // 기본 CLAUDE.md 템플릿
@@ -1,11 +1,13 @@
 package context
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/n0roo/pal-kit/internal/config"
 	"github.com/n0roo/pal-kit/internal/db"
 )
 
@@ -218,6 +220,89 @@ Some content here.
 	}
 }
 
+func TestInjectToFile_ConcurrentEdit(t *testing.T) {
+	projectRoot, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	database, dbCleanup := setupTestDB(t)
+	defer dbCleanup()
+
+	claudeMD := filepath.Join(projectRoot, "CLAUDE.md")
+	os.WriteFile(claudeMD, []byte("# Project\n\n<!-- pal:context:start -->\nOld\n<!-- pal:context:end -->\n"), 0644)
+	database.Exec(`INSERT INTO sessions (id, status, project_root) VALUES (?, ?, ?)`, "session-1", "running", projectRoot)
+	// 다른 프로젝트에서 더 최근에 시작한 세션에는 기록하지 않음
+	database.Exec(`INSERT INTO sessions (id, status, project_root, started_at) VALUES (?, ?, ?, datetime('now', '+1 minute'))`,
+		"session-other", "running", "/elsewhere")
+
+	// 첫 번째 시도의 읽기와 쓰기 사이에 사용자가 파일을 수정
+	injectHook = func(attempt int) {
+		if attempt == 1 {
+			os.WriteFile(claudeMD, []byte("# Project\n\n사용자 메모\n\n<!-- pal:context:start -->\nOld\n<!-- pal:context:end -->\n"), 0644)
+		}
+	}
+	defer func() { injectHook = nil }()
+
+	svc := NewService(database)
+	if err := svc.InjectToFile(claudeMD); err != nil {
+		t.Fatalf("주입 실패: %v", err)
+	}
+
+	content, _ := os.ReadFile(claudeMD)
+	if !strings.Contains(string(content), "사용자 메모") {
+		t.Error("사용자 수정이 유지되어야 함")
+	}
+	if strings.Contains(string(content), "Old") {
+		t.Error("컨텍스트 블록이 갱신되어야 함")
+	}
+	if _, err := os.Stat(claudeMD + ".pal-lock"); !os.IsNotExist(err) {
+		t.Error("잠금 파일이 남아 있음")
+	}
+
+	var conflicts int
+	database.QueryRow(`SELECT COUNT(*) FROM session_events WHERE session_id = 'session-1' AND event_type = ?`, EventClaudeMDConflict).Scan(&conflicts)
+	if conflicts != 1 {
+		t.Errorf("충돌 이벤트 1개 기대, got %d", conflicts)
+	}
+
+	// 계속 수정되면 재시도 후 충돌 오류
+	injectHook = func(attempt int) {
+		os.WriteFile(claudeMD, []byte(fmt.Sprintf("# edit %d\n", attempt)), 0644)
+	}
+	if err := svc.InjectToFile(claudeMD); err == nil {
+		t.Error("반복 충돌 시 오류가 나야 함")
+	}
+}
+
+func TestInjectToFile_Symlink(t *testing.T) {
+	projectRoot, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	database, dbCleanup := setupTestDB(t)
+	defer dbCleanup()
+
+	// 공유 문서를 가리키는 CLAUDE.md 링크
+	target := filepath.Join(projectRoot, "docs", "AGENTS.md")
+	os.MkdirAll(filepath.Dir(target), 0755)
+	os.WriteFile(target, []byte("# Shared\n\n<!-- pal:context:start -->\nOld\n<!-- pal:context:end -->\n"), 0644)
+	claudeMD := filepath.Join(projectRoot, "CLAUDE.md")
+	if err := os.Symlink(filepath.Join("docs", "AGENTS.md"), claudeMD); err != nil {
+		t.Skipf("심볼릭 링크를 만들 수 없음: %v", err)
+	}
+
+	if err := NewService(database).InjectToFile(claudeMD); err != nil {
+		t.Fatalf("주입 실패: %v", err)
+	}
+
+	info, err := os.Lstat(claudeMD)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatal("CLAUDE.md 심볼릭 링크가 유지되어야 함")
+	}
+	content, _ := os.ReadFile(target)
+	if !strings.Contains(string(content), "# Shared") || strings.Contains(string(content), "Old") {
+		t.Errorf("링크 대상 파일에 주입되어야 함:\n%s", content)
+	}
+}
+
 func TestNewService(t *testing.T) {
 	database, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -260,3 +345,83 @@ func TestFindProjectRoot_DeepNested(t *testing.T) {
 		t.Errorf("프로젝트 루트 = %s, want %s", found, projectRoot)
 	}
 }
+
+func TestWorkspaceRoots(t *testing.T) {
+	ws := t.TempDir()
+	for _, dir := range []string{
+		".claude",
+		"services/api/.claude",
+		"services/web/.claude",
+		"node_modules/pkg/.claude",
+	} {
+		if err := os.MkdirAll(filepath.Join(ws, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	api := filepath.Join(ws, "services", "api")
+	if got := FindWorkspaceRoot(api); got != ws {
+		t.Errorf("FindWorkspaceRoot = %s, want %s", got, ws)
+	}
+	if got := FindWorkspaceRoot(ws); got != "" {
+		t.Errorf("최상위 루트는 워크스페이스가 없어야 함: %s", got)
+	}
+
+	roots := DiscoverProjectRoots(ws, 4)
+	want := []string{ws, api, filepath.Join(ws, "services", "web")}
+	if len(roots) != len(want) {
+		t.Fatalf("DiscoverProjectRoots = %v, want %v", roots, want)
+	}
+	for i := range want {
+		if roots[i] != want[i] {
+			t.Errorf("roots[%d] = %s, want %s", i, roots[i], want[i])
+		}
+	}
+}
+
+func TestBuildPreserveHint(t *testing.T) {
+	projectRoot, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	database, dbCleanup := setupTestDB(t)
+	defer dbCleanup()
+
+	os.MkdirAll(filepath.Join(projectRoot, "ports"), 0755)
+	os.WriteFile(filepath.Join(projectRoot, "ports", "port-001.md"), []byte(`# Entity
+
+## 완료 체크리스트
+
+- [x] 엔티티 정의
+- [ ] 리포지토리 구현
+- [ ] 테스트 통과
+`), 0644)
+
+	database.Exec(`INSERT INTO sessions (id, status) VALUES (?, ?)`, "session-1", "running")
+	database.Exec(`INSERT INTO ports (id, title, status, session_id, file_path) VALUES (?, ?, ?, ?, ?)`,
+		"port-001", "Entity", "running", "session-1", "ports/port-001.md")
+	database.Exec(`INSERT INTO messages (id, conversation_id, from_session, to_session, type, subtype, payload, status, port_id)
+		VALUES ('m1', 'c1', 'builder-1', 'session-1', 'request', 'fix_request', '{}', 'pending', 'port-001')`)
+
+	hint, err := BuildPreserveHint(database, projectRoot, "session-1", config.PreCompactSettings{Notes: []string{"API 호환성 유지"}})
+	if err != nil {
+		t.Fatalf("힌트 생성 실패: %v", err)
+	}
+	if hint.PortID != "port-001" || strings.Join(hint.OpenItems, "|") != "리포지토리 구현|테스트 통과" {
+		t.Errorf("포트/체크리스트 불일치: %+v", hint)
+	}
+	if len(hint.Messages) != 1 || !strings.Contains(hint.Messages[0], "fix_request") {
+		t.Errorf("대기 메시지 불일치: %v", hint.Messages)
+	}
+	md := hint.Markdown()
+	for _, want := range []string{"활성 포트: port-001 (Entity)", "- [ ] 리포지토리 구현", "- API 호환성 유지"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("힌트에 %q 없음:\n%s", want, md)
+		}
+	}
+
+	// 섹션 제한
+	hint, _ = BuildPreserveHint(database, projectRoot, "session-1", config.PreCompactSettings{Sections: []string{"checklist"}, MaxItems: 1})
+	if hint.PortID != "" || len(hint.Messages) != 0 || len(hint.OpenItems) != 1 {
+		t.Errorf("섹션 설정이 반영되지 않음: %+v", hint)
+	}
+}
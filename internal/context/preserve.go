@@ -0,0 +1,163 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/message"
+	"github.com/n0roo/pal-kit/internal/port"
+)
+
+// PreserveHint is the working state that must survive a compaction
+type PreserveHint struct {
+	PortID    string   `json:"port_id,omitempty"`
+	PortTitle string   `json:"port_title,omitempty"`
+	SpecPath  string   `json:"spec_path,omitempty"`
+	OpenItems []string `json:"open_items,omitempty"`
+	Messages  []string `json:"messages,omitempty"`
+	Notes     []string `json:"notes,omitempty"`
+}
+
+// BuildPreserveHint collects the active port, its open checklist items and pending messages of a session.
+// 설정에서 제외한 섹션은 수집하지 않습니다.
+func BuildPreserveHint(database *db.DB, projectRoot, sessionID string, settings config.PreCompactSettings) (*PreserveHint, error) {
+	hint := &PreserveHint{Notes: settings.Notes}
+	limit := settings.Limit()
+
+	wantPort := settings.SectionEnabled(config.PreserveSectionPort)
+	wantChecklist := settings.SectionEnabled(config.PreserveSectionChecklist)
+	if wantPort || wantChecklist {
+		p, err := activePort(database, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			if wantPort {
+				hint.PortID = p.ID
+				hint.PortTitle = p.ID
+				if p.Title.Valid && p.Title.String != "" {
+					hint.PortTitle = p.Title.String
+				}
+			}
+			if p.FilePath.Valid && p.FilePath.String != "" {
+				if wantPort {
+					hint.SpecPath = p.FilePath.String
+				}
+				if wantChecklist {
+					specPath := p.FilePath.String
+					if !filepath.IsAbs(specPath) {
+						specPath = filepath.Join(projectRoot, specPath)
+					}
+					if content, err := os.ReadFile(specPath); err == nil {
+						hint.OpenItems = limitItems(openChecklistItems(string(content)), limit)
+					}
+				}
+			}
+		}
+	}
+
+	if settings.SectionEnabled(config.PreserveSectionMessages) {
+		msgs, err := message.NewStore(database.DB).Receive(sessionID, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range msgs {
+			hint.Messages = append(hint.Messages, describeMessage(m))
+		}
+	}
+
+	return hint, nil
+}
+
+// activePort returns the running port of the session, falling back to any running port
+func activePort(database *db.DB, sessionID string) (*port.Port, error) {
+	portSvc := port.NewService(database)
+	ports, err := portSvc.ListBySession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ports {
+		if ports[i].Status == port.StatusRunning {
+			return &ports[i], nil
+		}
+	}
+	running, err := portSvc.List(port.StatusRunning, 1)
+	if err != nil || len(running) == 0 {
+		return nil, err
+	}
+	return &running[0], nil
+}
+
+// openChecklistItems returns the unchecked "- [ ]" items of a port spec
+func openChecklistItems(spec string) []string {
+	var items []string
+	for _, line := range strings.Split(spec, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "- [ ]") {
+			continue
+		}
+		if item := strings.TrimSpace(strings.TrimPrefix(line, "- [ ]")); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func describeMessage(m *message.Message) string {
+	kind := string(m.Type)
+	if m.Subtype != "" {
+		kind = string(m.Subtype)
+	}
+	desc := fmt.Sprintf("%s (from %s", kind, m.FromSession)
+	if m.PortID != "" {
+		desc += ", port " + m.PortID
+	}
+	return desc + ")"
+}
+
+func limitItems(items []string, limit int) []string {
+	if limit > 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+// IsEmpty reports whether the hint has nothing worth preserving
+func (h *PreserveHint) IsEmpty() bool {
+	return h.PortID == "" && len(h.OpenItems) == 0 && len(h.Messages) == 0 && len(h.Notes) == 0
+}
+
+// Markdown renders the hint as a block for the compaction prompt
+func (h *PreserveHint) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("## [PAL Kit] 컴팩션 후에도 반드시 유지할 작업 상태\n")
+	if h.PortID != "" {
+		sb.WriteString(fmt.Sprintf("\n- 활성 포트: %s (%s)\n", h.PortID, h.PortTitle))
+		if h.SpecPath != "" {
+			sb.WriteString(fmt.Sprintf("- 포트 명세: %s\n", h.SpecPath))
+		}
+	}
+	if len(h.OpenItems) > 0 {
+		sb.WriteString("\n### 남은 체크리스트\n")
+		for _, item := range h.OpenItems {
+			sb.WriteString(fmt.Sprintf("- [ ] %s\n", item))
+		}
+	}
+	if len(h.Messages) > 0 {
+		sb.WriteString("\n### 처리 대기 메시지\n")
+		for _, m := range h.Messages {
+			sb.WriteString(fmt.Sprintf("- %s\n", m))
+		}
+	}
+	if len(h.Notes) > 0 {
+		sb.WriteString("\n### 프로젝트 지침\n")
+		for _, n := range h.Notes {
+			sb.WriteString(fmt.Sprintf("- %s\n", n))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// workspaceSkipDirs are directories never scanned for nested project roots
+var workspaceSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+}
+
+// FindWorkspaceRoot returns the outermost project root enclosing projectRoot.
+// 모노레포에서 여러 .claude 루트가 중첩된 경우 최상위 루트를 워크스페이스 루트로 봅니다.
+// 중첩이 없으면 빈 문자열을 반환하며, 홈 디렉토리(~/.claude)는 제외합니다.
+func FindWorkspaceRoot(projectRoot string) string {
+	if projectRoot == "" {
+		return ""
+	}
+	home, _ := os.UserHomeDir()
+
+	workspace := ""
+	dir := filepath.Dir(projectRoot)
+	for {
+		if dir == home {
+			break
+		}
+		if info, err := os.Stat(filepath.Join(dir, ".claude")); err == nil && info.IsDir() {
+			workspace = dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return workspace
+}
+
+// DiscoverProjectRoots finds project roots (.claude 디렉토리) under a workspace root
+func DiscoverProjectRoots(workspaceRoot string, maxDepth int) []string {
+	var roots []string
+	base := strings.Count(filepath.Clean(workspaceRoot), string(filepath.Separator))
+
+	filepath.Walk(workspaceRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		if path != workspaceRoot && (strings.HasPrefix(name, ".") || workspaceSkipDirs[name]) {
+			return filepath.SkipDir
+		}
+		if strings.Count(path, string(filepath.Separator))-base > maxDepth {
+			return filepath.SkipDir
+		}
+		if _, err := os.Stat(filepath.Join(path, ".claude")); err == nil {
+			roots = append(roots, path)
+		}
+		return nil
+	})
+
+	sort.Strings(roots)
+	return roots
+}
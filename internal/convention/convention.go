@@ -33,6 +33,7 @@ type Convention struct {
 	Description string         `yaml:"description" json:"description"`
 	Rules       []Rule         `yaml:"rules" json:"rules"`
 	Examples    Examples       `yaml:"examples,omitempty" json:"examples,omitempty"`
+	Fixtures    string         `yaml:"fixtures,omitempty" json:"fixtures,omitempty"` // good/bad 샘플 디렉토리 (컨벤션 파일 기준 상대 경로)
 	Enabled     bool           `yaml:"enabled" json:"enabled"`
 	Priority    int            `yaml:"priority" json:"priority"` // 1-10, 높을수록 중요
 	FilePath    string         `yaml:"-" json:"file_path,omitempty"`
@@ -108,6 +109,10 @@ func (s *Service) Load() error {
 		}
 
 		if d.IsDir() {
+			// 테스트 샘플 디렉토리는 컨벤션으로 로드하지 않음
+			if d.Name() == fixturesDirName {
+				return filepath.SkipDir
+			}
 			return nil // 디렉토리는 계속 탐색
 		}
 
@@ -365,20 +370,25 @@ func (s *Service) Check(paths []string) ([]CheckResult, error) {
 		}
 
 		for _, conv := range conventions {
-			for _, rule := range conv.Rules {
-				if !s.isApplicable(path, rule.FileTypes) {
-					continue
-				}
-
-				violations := s.checkRule(string(content), path, conv, &rule)
-				results = append(results, violations...)
-			}
+			results = append(results, s.checkContent(string(content), path, conv)...)
 		}
 	}
 
 	return results, nil
 }
 
+// checkContent checks content against all applicable rules of a convention
+func (s *Service) checkContent(content, filePath string, conv *Convention) []CheckResult {
+	var results []CheckResult
+	for _, rule := range conv.Rules {
+		if !s.isApplicable(filePath, rule.FileTypes) {
+			continue
+		}
+		results = append(results, s.checkRule(content, filePath, conv, &rule)...)
+	}
+	return results
+}
+
 // isApplicable checks if a rule applies to a file
 func (s *Service) isApplicable(filePath string, fileTypes []string) bool {
 	if len(fileTypes) == 0 {
@@ -310,3 +310,63 @@ func TestGetConventionTypes(t *testing.T) {
 		t.Error("컨벤션 타입이 없음")
 	}
 }
+
+func TestConventionFixtures(t *testing.T) {
+	projectRoot, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	convDir := filepath.Join(projectRoot, "conventions")
+	files := map[string]string{
+		"no-println.yaml": `id: no-println
+name: No Println
+enabled: true
+rules:
+  - id: no-fmt-println
+    description: fmt.Println 사용 금지
+    anti_pattern: 'fmt\.Println'
+    file_types: [".go"]
+    severity: warning
+examples:
+  good:
+    - code: 'log.Printf("ok")'
+  bad:
+    - code: 'fmt.Println("x")'
+`,
+		"fixtures/no-println/good/ok.go":        "package main\n\nfunc main() { log.Print(1) }\n",
+		"fixtures/no-println/bad/println.go":    "package main\n\nfunc main() { fmt.Println(1) }\n",
+		"fixtures/no-println/bad/undetected.go": "package main\n\nfunc main() {}\n",
+	}
+	for path, content := range files {
+		full := filepath.Join(convDir, path)
+		os.MkdirAll(filepath.Dir(full), 0755)
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatalf("파일 생성 실패: %v", err)
+		}
+	}
+
+	svc := NewService(projectRoot)
+
+	// fixtures 디렉토리의 파일은 컨벤션으로 로드되지 않아야 함
+	convs, _ := svc.List()
+	if len(convs) != 1 {
+		t.Fatalf("Expected 1 convention, got %d", len(convs))
+	}
+
+	reports, err := svc.Test(nil)
+	if err != nil {
+		t.Fatalf("테스트 실행 실패: %v", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report, got %d", len(reports))
+	}
+
+	r := reports[0]
+	if r.Passed != 4 || r.Failed != 1 {
+		t.Errorf("Expected 4 passed / 1 failed, got %d / %d", r.Passed, r.Failed)
+	}
+	for _, res := range r.Results {
+		if !res.Passed && filepath.Base(res.Sample) != "undetected.go" {
+			t.Errorf("Unexpected failure: %s", res.Sample)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package convention
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// fixturesDirName is the directory holding good/bad samples next to conventions
+const fixturesDirName = "fixtures"
+
+// Fixture expectations
+const (
+	ExpectGood = "good" // 위반이 없어야 함
+	ExpectBad  = "bad"  // 위반이 하나 이상 있어야 함
+)
+
+// FixtureResult is the outcome of running a convention against one sample
+type FixtureResult struct {
+	Sample     string        `json:"sample"`
+	Expect     string        `json:"expect"`
+	Passed     bool          `json:"passed"`
+	Violations []CheckResult `json:"violations,omitempty"`
+}
+
+// TestReport summarizes fixture results for a convention
+type TestReport struct {
+	ConventionID string          `json:"convention_id"`
+	FixturesDir  string          `json:"fixtures_dir,omitempty"`
+	Results      []FixtureResult `json:"results"`
+	Passed       int             `json:"passed"`
+	Failed       int             `json:"failed"`
+}
+
+// FixturesDir returns the sample directory of a convention.
+// 기본값: <컨벤션 파일 디렉토리>/fixtures/<id>
+func (s *Service) FixturesDir(conv *Convention) string {
+	baseDir := s.conventionsDir
+	if conv.FilePath != "" {
+		baseDir = filepath.Dir(conv.FilePath)
+	}
+
+	if conv.Fixtures != "" {
+		if filepath.IsAbs(conv.Fixtures) {
+			return conv.Fixtures
+		}
+		return filepath.Join(baseDir, conv.Fixtures)
+	}
+	return filepath.Join(baseDir, fixturesDirName, conv.ID)
+}
+
+// Test runs enabled conventions against their fixtures and inline examples.
+// ids가 비어있으면 활성화된 모든 컨벤션을 테스트합니다.
+func (s *Service) Test(ids []string) ([]TestReport, error) {
+	var conventions []*Convention
+	if len(ids) == 0 {
+		enabled, err := s.ListEnabled()
+		if err != nil {
+			return nil, err
+		}
+		conventions = enabled
+	} else {
+		for _, id := range ids {
+			conv, err := s.Get(id)
+			if err != nil {
+				return nil, err
+			}
+			conventions = append(conventions, conv)
+		}
+	}
+
+	var reports []TestReport
+	for _, conv := range conventions {
+		// 규칙이 없는 컨벤션 (markdown 문서형)은 검사 대상 아님
+		if len(conv.Rules) == 0 {
+			continue
+		}
+		report, err := s.TestConvention(conv)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, *report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].ConventionID < reports[j].ConventionID
+	})
+
+	return reports, nil
+}
+
+// TestConvention runs a single convention against its samples
+func (s *Service) TestConvention(conv *Convention) (*TestReport, error) {
+	report := &TestReport{ConventionID: conv.ID}
+
+	// 1. fixtures/<id>/good, fixtures/<id>/bad 디렉토리
+	dir := s.FixturesDir(conv)
+	if _, err := os.Stat(dir); err == nil {
+		report.FixturesDir = dir
+		for _, expect := range []string{ExpectGood, ExpectBad} {
+			samples, err := collectFixtureFiles(filepath.Join(dir, expect))
+			if err != nil {
+				return nil, fmt.Errorf("샘플 탐색 실패 (%s): %w", conv.ID, err)
+			}
+			for _, sample := range samples {
+				content, err := os.ReadFile(sample)
+				if err != nil {
+					return nil, fmt.Errorf("샘플 읽기 실패: %w", err)
+				}
+				violations := s.checkContent(string(content), sample, conv)
+				report.add(sample, expect, violations)
+			}
+		}
+	}
+
+	// 2. 컨벤션에 인라인으로 선언된 examples (파일 타입 무관하게 모든 규칙 적용)
+	for i, ex := range conv.Examples.Good {
+		report.add(fmt.Sprintf("examples.good[%d]", i), ExpectGood, s.checkExample(ex.Code, conv))
+	}
+	for i, ex := range conv.Examples.Bad {
+		report.add(fmt.Sprintf("examples.bad[%d]", i), ExpectBad, s.checkExample(ex.Code, conv))
+	}
+
+	return report, nil
+}
+
+func (s *Service) checkExample(code string, conv *Convention) []CheckResult {
+	var results []CheckResult
+	for _, rule := range conv.Rules {
+		results = append(results, s.checkRule(code, "", conv, &rule)...)
+	}
+	return results
+}
+
+func (r *TestReport) add(sample, expect string, violations []CheckResult) {
+	passed := len(violations) == 0
+	if expect == ExpectBad {
+		passed = len(violations) > 0
+	}
+
+	r.Results = append(r.Results, FixtureResult{
+		Sample:     sample,
+		Expect:     expect,
+		Passed:     passed,
+		Violations: violations,
+	})
+	if passed {
+		r.Passed++
+	} else {
+		r.Failed++
+	}
+}
+
+func collectFixtureFiles(dir string) ([]string, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name()[0] == '.' {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
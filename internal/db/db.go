@@ -9,7 +9,7 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
-const schemaVersion = 11
+const schemaVersion = 39
 
 // 기본 테이블 (v1 호환)
 const schemaBase = `
@@ -17,7 +17,9 @@ const schemaBase = `
 CREATE TABLE IF NOT EXISTS locks (
     resource TEXT PRIMARY KEY,
     session_id TEXT NOT NULL,
-    acquired_at DATETIME DEFAULT CURRENT_TIMESTAMP
+    acquired_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME,
+    project_root TEXT
 );
 
 -- 포트 관리
@@ -104,7 +106,8 @@ CREATE TABLE IF NOT EXISTS pipelines (
     status TEXT DEFAULT 'pending',
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     started_at DATETIME,
-    completed_at DATETIME
+    completed_at DATETIME,
+    migrated_to TEXT                           -- 이관된 Orchestration ID
 );
 
 CREATE INDEX IF NOT EXISTS idx_pipelines_status ON pipelines(status);
@@ -486,6 +489,7 @@ CREATE TABLE IF NOT EXISTS worker_sessions (
     status TEXT DEFAULT 'pending',
     substatus TEXT,                            -- coding, building, testing, reviewing
     result TEXT,                               -- JSON: {output, metrics, errors}
+    paused_from TEXT,                          -- Orchestration 일시정지 전 상태 (재개 시 복원)
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
@@ -544,7 +548,8 @@ CREATE TABLE IF NOT EXISTS orchestration_ports (
     progress_percent INTEGER DEFAULT 0,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     started_at DATETIME,
-    completed_at DATETIME
+    completed_at DATETIME,
+    retry_policy TEXT                          -- JSON: {max_retries, backoff, escalate_after}
 );
 
 CREATE INDEX IF NOT EXISTS idx_orchestration_ports_status ON orchestration_ports(status);
@@ -617,21 +622,329 @@ CREATE INDEX IF NOT EXISTS idx_feedback_loops_status ON feedback_loops(status);
 CREATE INDEX IF NOT EXISTS idx_feedback_loops_port ON feedback_loops(port_id);
 `
 
+// v12 추가 테이블 (Orchestration 스케줄)
+const schemaV12 = `
+-- ============================================================
+-- Orchestration 스케줄 (cron)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS orchestration_schedules (
+    id TEXT PRIMARY KEY,
+    plan_id TEXT NOT NULL,                     -- 템플릿으로 사용할 Orchestration
+    cron TEXT NOT NULL,                        -- 5필드 cron 표현식
+    enabled INTEGER DEFAULT 1,
+    last_run_at DATETIME,
+    next_run_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (plan_id) REFERENCES orchestration_ports(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_orchestration_schedules_next ON orchestration_schedules(enabled, next_run_at);
+
+CREATE TABLE IF NOT EXISTS orchestration_schedule_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    schedule_id TEXT NOT NULL,
+    orchestration_id TEXT,                     -- 생성된 Orchestration
+    status TEXT NOT NULL,                      -- started, failed
+    error TEXT,
+    scheduled_at DATETIME,
+    started_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (schedule_id) REFERENCES orchestration_schedules(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_orchestration_schedule_runs_schedule ON orchestration_schedule_runs(schedule_id, started_at);
+`
+
+// v13 추가 테이블 (Orchestration 멱등성 키)
+const schemaV13 = `
+-- ============================================================
+-- Orchestration 생성 멱등성 키 (재시도 중복 방지)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS orchestration_idempotency_keys (
+    key TEXT PRIMARY KEY,                      -- 클라이언트가 보낸 Idempotency-Key
+    orchestration_id TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+// v14 추가 테이블 (문서/KB 휴지통)
+const schemaV14 = `
+-- ============================================================
+-- 휴지통 (soft-delete, 30일 후 영구 삭제)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS trash_items (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,                        -- document, kb
+    root TEXT NOT NULL,                        -- 프로젝트 루트 또는 vault 경로
+    path TEXT NOT NULL,                        -- root 기준 원래 경로
+    trash_path TEXT NOT NULL,                  -- 보관 중인 파일 경로
+    metadata TEXT,                             -- JSON: 삭제 시점의 인덱스 정보
+    deleted_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_trash_items_kind ON trash_items(kind, deleted_at);
+`
+
+// v15 추가 테이블 (파일 출처 추적)
+const schemaV15 = `
+-- ============================================================
+-- 파일 출처 (file_edit 이벤트 기반, file_manifests 보조)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS file_provenance (
+    project_root TEXT NOT NULL,
+    file_path TEXT NOT NULL,                   -- 프로젝트 기준 상대 경로
+    created_session TEXT,                      -- 파일을 생성한 세션
+    created_port TEXT,
+    created_at DATETIME,
+    modified_session TEXT,                     -- 마지막으로 수정한 세션
+    modified_port TEXT,
+    modified_at DATETIME,
+    edit_count INTEGER DEFAULT 0,
+    PRIMARY KEY (project_root, file_path)
+);
+`
+
+const schemaV16 = `
+-- ============================================================
+-- 포트별 파일 변경 (PostToolUse Edit/Write)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS port_file_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    port_id TEXT NOT NULL,
+    session_id TEXT,
+    file_path TEXT NOT NULL,
+    tool TEXT,                                 -- Edit, MultiEdit, Write
+    additions INTEGER DEFAULT 0,
+    deletions INTEGER DEFAULT 0,
+    changed_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_port_file_changes_port ON port_file_changes(port_id);
+`
+
+const schemaV17 = `
+-- ============================================================
+-- 프로젝트 보관/숨김 상태 (대시보드 프로젝트 관리)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS project_states (
+    root TEXT PRIMARY KEY,
+    archived INTEGER DEFAULT 0,
+    hidden INTEGER DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+const schemaV18 = `
+-- ============================================================
+-- 주간 리포트 이메일 다이제스트 발송 이력
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS digest_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_root TEXT NOT NULL,
+    period_start DATETIME,
+    period_end DATETIME,
+    recipients TEXT,                           -- 콤마 구분
+    status TEXT NOT NULL,                      -- sent, failed
+    error TEXT,
+    sent_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_digest_runs_project ON digest_runs(project_root, sent_at);
+`
+
+const schemaV19 = `
+-- ============================================================
+-- Orchestration 재실행 (에이전트 버전 검증)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS orchestration_replays (
+    id TEXT PRIMARY KEY,
+    source_orchestration_id TEXT NOT NULL,     -- 원본 실행
+    orchestration_id TEXT NOT NULL,            -- 재실행으로 생성된 Orchestration
+    agent_versions TEXT,                       -- JSON: [{"agent":"builder","version":5}]
+    branch TEXT,
+    worktree TEXT,
+    status TEXT DEFAULT 'active',              -- active, cleaned
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    cleaned_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_orch_replays_source ON orchestration_replays(source_orchestration_id);
+CREATE INDEX IF NOT EXISTS idx_orch_replays_orch ON orchestration_replays(orchestration_id);
+`
+
+const schemaV20 = `
+-- ============================================================
+-- Lock 대기 (경합 에스컬레이션)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS lock_waits (
+    resource TEXT NOT NULL,
+    session_id TEXT NOT NULL,                  -- 대기 중인 세션
+    holder TEXT NOT NULL,                      -- 대기 시작 시점의 Lock 보유 세션
+    first_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    escalation_id INTEGER,                     -- 자동 생성된 에스컬레이션
+    PRIMARY KEY (resource, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lock_waits_session ON lock_waits(session_id);
+`
+
+const schemaV21 = `
+-- ============================================================
+-- 포트 아카이브 (명세/규칙 파일을 .pal/archive로 이동)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS port_archives (
+    port_id TEXT PRIMARY KEY,
+    prev_status TEXT NOT NULL,                 -- 복원 시 되돌릴 상태
+    spec_path TEXT,                            -- 원래 명세 경로 (프로젝트 루트 기준)
+    archive_dir TEXT NOT NULL,                 -- 보관 디렉토리 (프로젝트 루트 기준)
+    files TEXT,                                -- JSON: 이동한 파일 [{"from":"ports/a.md","to":".pal/archive/ports/a/a.md"}]
+    reason TEXT DEFAULT 'manual',              -- manual, retention
+    archived_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+const schemaV22 = `
+-- ============================================================
+-- Orchestration 수명주기 (일시정지/재개/취소 기록)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS orchestration_lifecycle (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    orchestration_id TEXT NOT NULL,
+    action TEXT NOT NULL,                      -- pause, resume, cancel
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    reason TEXT,
+    workers TEXT,                              -- JSON: 표시한 워커 세션 ID
+    released_locks TEXT,                       -- JSON: 해제한 Lock 리소스
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_orch_lifecycle_orch ON orchestration_lifecycle(orchestration_id);
+`
+
+const schemaV23 = `
+-- ============================================================
+-- 포트 스크래치패드 (세션 간 공유 메모, 추가 전용)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS port_scratchpad (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    port_id TEXT NOT NULL,
+    session_id TEXT,                           -- 메모를 남긴 세션 (없으면 사용자)
+    content TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_port_scratchpad_port ON port_scratchpad(port_id);
+`
+
+const schemaV24 = `
+-- ============================================================
+-- 포트 외부 차단 요인 (의존성과 별개: API 키 대기, 디자인 승인 등)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS port_blockers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    port_id TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    expected_at DATETIME,                      -- 해소 예정일 (없으면 미정)
+    resolution TEXT,                           -- 해소 메모
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    resolved_at DATETIME                       -- NULL이면 차단 중
+);
+
+CREATE INDEX IF NOT EXISTS idx_port_blockers_port ON port_blockers(port_id);
+`
+
+const schemaV25 = `
+-- ============================================================
+-- 주입 컨텍스트 피드백 (브리핑/규칙/문서 품질)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS context_feedback (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    target TEXT NOT NULL,                      -- briefing, rules, docs
+    ref TEXT NOT NULL,                         -- 템플릿/문서 식별자 (없으면 target)
+    comment TEXT NOT NULL,
+    rating INTEGER DEFAULT 0,                  -- 1: 유용, -1: 불필요/부정확, 0: 의견만
+    source TEXT DEFAULT 'user',                -- user, agent
+    session_id TEXT,
+    project_root TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_context_feedback_ref ON context_feedback(target, ref);
+`
+
+const schemaV26 = `
+-- ============================================================
+-- Hook 실행 집계 (hook 종류별 누적 호출 수, 오류 수, 소요 시간 버킷)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS hook_metrics (
+    hook TEXT PRIMARY KEY,                     -- session-start, pre-tool-use, ...
+    invocations INTEGER DEFAULT 0,
+    errors INTEGER DEFAULT 0,
+    duration_sum REAL DEFAULT 0,               -- 초
+    buckets TEXT DEFAULT '[]',                 -- 버킷별 호출 수 (JSON, metrics.DefaultBuckets + Inf)
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+const schemaV27 = `
+-- ============================================================
+-- 에스컬레이션 처리 기록 (담당자 지정, 댓글, 심각도 변경, 해결/무시)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS escalation_activity (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    escalation_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,                        -- assign, comment, severity, resolve, dismiss
+    actor TEXT,                                -- 사용자 또는 세션/에이전트
+    body TEXT,                                 -- 댓글 본문, 해결 요약
+    old_value TEXT,
+    new_value TEXT,
+    parent_id INTEGER,                         -- 답글 대상 댓글
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_escalation_activity_esc ON escalation_activity(escalation_id, id);
+`
+
 // DB wraps sql.DB with helper methods
 type DB struct {
 	*sql.DB
 	path string
 }
 
+// dsnParams are the connection options shared by every connection
+const dsnParams = "_foreign_keys=on&_journal_mode=WAL"
+
 // Open opens or creates the database
 func Open(path string) (*DB, error) {
+	return open(path, dsnParams)
+}
+
+func open(path, params string) (*DB, error) {
 	// 디렉토리 생성
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("디렉토리 생성 실패: %w", err)
 	}
 
-	db, err := sql.Open("sqlite3", path+"?_foreign_keys=on&_journal_mode=WAL")
+	db, err := sql.Open("sqlite3", path+"?"+params)
 	if err != nil {
 		return nil, fmt.Errorf("DB 열기 실패: %w", err)
 	}
@@ -714,7 +1027,87 @@ func (d *DB) Init() error {
 		return fmt.Errorf("v11 스키마 적용 실패: %w", err)
 	}
 
-	// 13. 버전 저장
+	// 13. v12 적용 (Orchestration 스케줄)
+	if _, err := d.Exec(schemaV12); err != nil {
+		return fmt.Errorf("v12 스키마 적용 실패: %w", err)
+	}
+
+	// 14. v13 적용 (Orchestration 멱등성 키)
+	if _, err := d.Exec(schemaV13); err != nil {
+		return fmt.Errorf("v13 스키마 적용 실패: %w", err)
+	}
+
+	// 15. v14 적용 (문서/KB 휴지통)
+	if _, err := d.Exec(schemaV14); err != nil {
+		return fmt.Errorf("v14 스키마 적용 실패: %w", err)
+	}
+
+	// 16. v15 적용 (파일 출처 추적)
+	if _, err := d.Exec(schemaV15); err != nil {
+		return fmt.Errorf("v15 스키마 적용 실패: %w", err)
+	}
+
+	// 17. v16 적용 (포트별 파일 변경)
+	if _, err := d.Exec(schemaV16); err != nil {
+		return fmt.Errorf("v16 스키마 적용 실패: %w", err)
+	}
+
+	// 18. v17 적용 (프로젝트 보관/숨김)
+	if _, err := d.Exec(schemaV17); err != nil {
+		return fmt.Errorf("v17 스키마 적용 실패: %w", err)
+	}
+
+	// 19. v18 적용 (이메일 다이제스트)
+	if _, err := d.Exec(schemaV18); err != nil {
+		return fmt.Errorf("v18 스키마 적용 실패: %w", err)
+	}
+
+	// 20. v19 적용 (Orchestration 재실행)
+	if _, err := d.Exec(schemaV19); err != nil {
+		return fmt.Errorf("v19 스키마 적용 실패: %w", err)
+	}
+
+	// 21. v20 적용 (Lock 대기)
+	if _, err := d.Exec(schemaV20); err != nil {
+		return fmt.Errorf("v20 스키마 적용 실패: %w", err)
+	}
+
+	// 22. v21 적용 (포트 아카이브)
+	if _, err := d.Exec(schemaV21); err != nil {
+		return fmt.Errorf("v21 스키마 적용 실패: %w", err)
+	}
+
+	// 23. v22 적용 (Orchestration 수명주기)
+	if _, err := d.Exec(schemaV22); err != nil {
+		return fmt.Errorf("v22 스키마 적용 실패: %w", err)
+	}
+
+	// 24. v23 적용 (포트 스크래치패드)
+	if _, err := d.Exec(schemaV23); err != nil {
+		return fmt.Errorf("v23 스키마 적용 실패: %w", err)
+	}
+
+	// 25. v24 적용 (포트 외부 차단 요인)
+	if _, err := d.Exec(schemaV24); err != nil {
+		return fmt.Errorf("v24 스키마 적용 실패: %w", err)
+	}
+
+	// 26. v25 적용 (주입 컨텍스트 피드백)
+	if _, err := d.Exec(schemaV25); err != nil {
+		return fmt.Errorf("v25 스키마 적용 실패: %w", err)
+	}
+
+	// 27. v26 적용 (Hook 실행 메트릭)
+	if _, err := d.Exec(schemaV26); err != nil {
+		return fmt.Errorf("v26 스키마 적용 실패: %w", err)
+	}
+
+	// 28. v27 적용 (에스컬레이션 처리 기록)
+	if _, err := d.Exec(schemaV27); err != nil {
+		return fmt.Errorf("v27 스키마 적용 실패: %w", err)
+	}
+
+	// 29. 버전 저장
 	_, err := d.Exec(`INSERT OR REPLACE INTO metadata (key, value, updated_at) VALUES ('schema_version', ?, CURRENT_TIMESTAMP)`, schemaVersion)
 	if err != nil {
 		return fmt.Errorf("버전 저장 실패: %w", err)
@@ -818,6 +1211,74 @@ func (d *DB) migrate() error {
 		d.Exec(`CREATE INDEX IF NOT EXISTS idx_sessions_claude_id ON sessions(claude_session_id)`)
 	}
 
+	// v16 -> v17: 샌드박스 세션 (통계/브리핑 제외)
+	if currentVersion < 17 {
+		d.Exec(`ALTER TABLE sessions ADD COLUMN sandbox INTEGER DEFAULT 0`)
+	}
+
+	// v17 -> v18: 포트별 캐시 토큰 (transcript 시간 구간 귀속)
+	if currentVersion < 18 {
+		d.Exec(`ALTER TABLE ports ADD COLUMN cache_read_tokens INTEGER DEFAULT 0`)
+		d.Exec(`ALTER TABLE ports ADD COLUMN cache_create_tokens INTEGER DEFAULT 0`)
+	}
+
+	// v19: 모노레포 워크스페이스 루트
+	if currentVersion < 19 {
+		d.Exec(`ALTER TABLE sessions ADD COLUMN workspace_root TEXT`)
+	}
+
+	// v23: 세션 시작 시 개발 환경 스냅샷 (OS, 도구 버전, git 리비전)
+	if currentVersion < 23 {
+		d.Exec(`ALTER TABLE sessions ADD COLUMN env_snapshot TEXT`)
+	}
+
+	// v26: 완료된 포트의 변경 요약 (의존 포트 시작 시 주입)
+	if currentVersion < 26 {
+		d.Exec(`ALTER TABLE ports ADD COLUMN summary TEXT`)
+	}
+
+	// v27: 포트 추정치 (토큰, 시간) - port-end에서 실제값과 비교
+	if currentVersion < 27 {
+		d.Exec(`ALTER TABLE ports ADD COLUMN estimate_tokens INTEGER`)
+		d.Exec(`ALTER TABLE ports ADD COLUMN estimate_hours REAL`)
+	}
+
+	// v28: 레거시 compactions와 compact_events 연결 (같은 컴팩션의 두 기록)
+	if currentVersion < 28 {
+		d.Exec(`ALTER TABLE compactions ADD COLUMN event_id TEXT`)
+		d.Exec(`CREATE INDEX IF NOT EXISTS idx_compactions_event ON compactions(event_id)`)
+	}
+
+	// v30: Orchestration 재시도 정책 (워커 실패 시 재시도/에스컬레이션)
+	if currentVersion < 30 {
+		d.Exec(`ALTER TABLE orchestration_ports ADD COLUMN retry_policy TEXT`)
+	}
+
+	// v32: 파이프라인 → Orchestration 이관 기록
+	if currentVersion < 32 {
+		d.Exec(`ALTER TABLE pipelines ADD COLUMN migrated_to TEXT`)
+	}
+
+	// v36: 에스컬레이션 담당자
+	if currentVersion < 36 {
+		d.Exec(`ALTER TABLE escalations ADD COLUMN assignee TEXT`)
+	}
+
+	// v37: Lock TTL
+	if currentVersion < 37 {
+		d.Exec(`ALTER TABLE locks ADD COLUMN expires_at DATETIME`)
+	}
+
+	// v38: Lock을 잡은 프로젝트 (상대 경로 리소스는 같은 프로젝트에서만 파일 Lock)
+	if currentVersion < 38 {
+		d.Exec(`ALTER TABLE locks ADD COLUMN project_root TEXT`)
+	}
+
+	// v39: 일시정지 전 워커 상태 (substatus와 분리)
+	if currentVersion < 39 {
+		d.Exec(`ALTER TABLE worker_sessions ADD COLUMN paused_from TEXT`)
+	}
+
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -214,3 +215,51 @@ func TestClose(t *testing.T) {
 		t.Error("Close 후에도 쿼리가 실행됨")
 	}
 }
+
+func TestOpenPoolConcurrentWrites(t *testing.T) {
+	db, err := OpenPool(filepath.Join(t.TempDir(), "pool.db"), PoolOptions{MaxOpenConns: 4})
+	if err != nil {
+		t.Fatalf("OpenPool 실패: %v", err)
+	}
+	defer db.Close()
+
+	if got := db.Stats().MaxOpenConnections; got != 4 {
+		t.Errorf("MaxOpenConnections = %d, want 4", got)
+	}
+
+	// 여러 goroutine이 동시에 트랜잭션으로 쓰더라도 SQLITE_BUSY 없이 직렬화되어야 함
+	const writers, perWriter = 8, 20
+	errs := make(chan error, writers)
+	for w := 0; w < writers; w++ {
+		go func(w int) {
+			for i := 0; i < perWriter; i++ {
+				tx, err := db.Begin()
+				if err != nil {
+					errs <- err
+					return
+				}
+				if _, err := tx.Exec(`INSERT INTO locks (resource, session_id) VALUES (?, 's')`, fmt.Sprintf("r-%d-%d", w, i)); err != nil {
+					tx.Rollback()
+					errs <- err
+					return
+				}
+				if err := tx.Commit(); err != nil {
+					errs <- err
+					return
+				}
+			}
+			errs <- nil
+		}(w)
+	}
+	for w := 0; w < writers; w++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("동시 쓰기 실패: %v", err)
+		}
+	}
+
+	var count int
+	db.QueryRow(`SELECT COUNT(*) FROM locks`).Scan(&count)
+	if count != writers*perWriter {
+		t.Errorf("기록 수 = %d, want %d", count, writers*perWriter)
+	}
+}
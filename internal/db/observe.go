@@ -0,0 +1,46 @@
+package db
+
+import (
+	"database/sql"
+	"sync/atomic"
+	"time"
+)
+
+// QueryObserver receives the latency of each Exec/Query/QueryRow call (op: exec, query)
+type QueryObserver func(op string, elapsed time.Duration)
+
+var queryObserver atomic.Pointer[QueryObserver]
+
+// SetQueryObserver installs a process-wide observer for query latency (nil이면 해제).
+// 서버의 /metrics가 DB 쿼리 지연 히스토그램을 수집할 때 사용합니다.
+func SetQueryObserver(fn QueryObserver) {
+	if fn == nil {
+		queryObserver.Store(nil)
+		return
+	}
+	queryObserver.Store(&fn)
+}
+
+func observe(op string, start time.Time) {
+	if fn := queryObserver.Load(); fn != nil {
+		(*fn)(op, time.Since(start))
+	}
+}
+
+// Exec executes a statement, reporting its latency to the query observer
+func (d *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	defer observe("exec", time.Now())
+	return d.DB.Exec(query, args...)
+}
+
+// Query runs a query, reporting its latency to the query observer
+func (d *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	defer observe("query", time.Now())
+	return d.DB.Query(query, args...)
+}
+
+// QueryRow runs a single-row query, reporting its latency to the query observer
+func (d *DB) QueryRow(query string, args ...interface{}) *sql.Row {
+	defer observe("query", time.Now())
+	return d.DB.QueryRow(query, args...)
+}
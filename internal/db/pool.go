@@ -0,0 +1,37 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// PoolOptions configures a long-lived shared connection pool (서버처럼 오래 실행되는 프로세스용)
+type PoolOptions struct {
+	MaxOpenConns int           // 동시 연결 수 (기본 8, WAL이라 읽기는 병렬, 쓰기는 직렬화)
+	BusyTimeout  time.Duration // 쓰기 잠금 대기 시간 (기본 5초)
+}
+
+// DefaultPoolOptions are used for zero-valued fields
+var DefaultPoolOptions = PoolOptions{MaxOpenConns: 8, BusyTimeout: 5 * time.Second}
+
+// OpenPool opens the database for sharing across goroutines.
+// 요청마다 Open/Close하는 대신 연결을 재사용하며, 잠금 경합 시 SQLITE_BUSY 대신 BusyTimeout만큼 기다립니다.
+// 트랜잭션은 BEGIN IMMEDIATE로 시작해 읽기→쓰기 잠금 승격 중의 교착을 피합니다.
+func OpenPool(path string, opts PoolOptions) (*DB, error) {
+	if opts.MaxOpenConns <= 0 {
+		opts.MaxOpenConns = DefaultPoolOptions.MaxOpenConns
+	}
+	if opts.BusyTimeout <= 0 {
+		opts.BusyTimeout = DefaultPoolOptions.BusyTimeout
+	}
+
+	params := fmt.Sprintf("%s&_busy_timeout=%d&_txlock=immediate", dsnParams, opts.BusyTimeout.Milliseconds())
+	d, err := open(path, params)
+	if err != nil {
+		return nil, err
+	}
+	d.SetMaxOpenConns(opts.MaxOpenConns)
+	d.SetMaxIdleConns(opts.MaxOpenConns)
+	d.SetConnMaxIdleTime(5 * time.Minute)
+	return d, nil
+}
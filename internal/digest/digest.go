@@ -0,0 +1,409 @@
+// Package digest builds the weekly project report and emails it to a configured list.
+// 발송 설정은 .pal/config.yaml의 notifications.digest 섹션에서 관리하며,
+// pal serve 데몬이 스케줄에 따라 발송합니다.
+package digest
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+)
+
+// DefaultSchedule sends the digest every Monday at 09:00
+const DefaultSchedule = "0 9 * * 1"
+
+// ReportPeriod is the span covered by a weekly report
+const ReportPeriod = 7 * 24 * time.Hour
+
+// firstRunGrace limits the first send to shortly after a scheduled time,
+// so enabling the digest does not immediately email last week's slot.
+const firstRunGrace = time.Hour
+
+// Run statuses
+const (
+	RunSent   = "sent"
+	RunFailed = "failed"
+)
+
+const sqlTime = "2006-01-02 15:04:05"
+
+// Report is the weekly project report
+type Report struct {
+	ProjectRoot     string          `json:"project_root"`
+	ProjectName     string          `json:"project_name"`
+	PeriodStart     time.Time       `json:"period_start"`
+	PeriodEnd       time.Time       `json:"period_end"`
+	Sessions        int             `json:"sessions"`
+	Tokens          int64           `json:"tokens"`
+	CostUSD         float64         `json:"cost_usd"`
+	CompletedPorts  []CompletedPort `json:"completed_ports"`
+	NewEscalations  int             `json:"new_escalations"`
+	OpenEscalations int             `json:"open_escalations"`
+	Milestones      []Milestone     `json:"milestones"`
+}
+
+// CompletedPort is a port completed during the report period
+type CompletedPort struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	CompletedAt string `json:"completed_at"`
+}
+
+// Milestone is a pipeline tracked as a milestone with its burndown
+type Milestone struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Status    string          `json:"status"`
+	Total     int             `json:"total"`
+	Completed int             `json:"completed"`
+	Burndown  []BurndownPoint `json:"burndown"`
+}
+
+// Remaining returns the number of ports left in the milestone
+func (m Milestone) Remaining() int {
+	return m.Total - m.Completed
+}
+
+// BurndownPoint is the remaining port count at the end of a day
+type BurndownPoint struct {
+	Date      string `json:"date"`
+	Remaining int    `json:"remaining"`
+}
+
+// Run is a recorded digest send attempt
+type Run struct {
+	ID          int64     `json:"id"`
+	ProjectRoot string    `json:"project_root"`
+	Recipients  []string  `json:"recipients"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	SentAt      time.Time `json:"sent_at"`
+}
+
+// Service generates and sends digests
+type Service struct {
+	db *db.DB
+}
+
+// NewService creates a new digest service
+func NewService(database *db.DB) *Service {
+	return &Service{db: database}
+}
+
+// Weekly builds the report for the 7 days ending at end.
+// 포트/파이프라인은 연결된 세션의 project_root로 프로젝트를 판별합니다.
+func (s *Service) Weekly(projectRoot string, end time.Time) (*Report, error) {
+	start := end.Add(-ReportPeriod)
+	from, to := start.UTC().Format(sqlTime), end.UTC().Format(sqlTime)
+
+	r := &Report{
+		ProjectRoot: projectRoot,
+		ProjectName: filepath.Base(projectRoot),
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}
+
+	if err := s.db.QueryRow(`
+		SELECT COUNT(*),
+		       COALESCE(SUM(input_tokens + output_tokens + cache_read_tokens + cache_create_tokens), 0),
+		       COALESCE(SUM(cost_usd), 0)
+		FROM sessions
+		WHERE project_root = ? AND COALESCE(sandbox, 0) = 0
+		  AND started_at >= ? AND started_at < ?
+	`, projectRoot, from, to).Scan(&r.Sessions, &r.Tokens, &r.CostUSD); err != nil {
+		return nil, fmt.Errorf("세션 집계 실패: %w", err)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT p.id, COALESCE(p.title, ''), p.completed_at
+		FROM ports p
+		JOIN sessions s ON s.id = p.session_id
+		WHERE s.project_root = ? AND p.status = 'complete'
+		  AND p.completed_at >= ? AND p.completed_at < ?
+		ORDER BY p.completed_at
+	`, projectRoot, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("완료 포트 조회 실패: %w", err)
+	}
+	for rows.Next() {
+		var cp CompletedPort
+		var completedAt time.Time
+		if err := rows.Scan(&cp.ID, &cp.Title, &completedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		cp.CompletedAt = completedAt.Local().Format("2006-01-02")
+		r.CompletedPorts = append(r.CompletedPorts, cp)
+	}
+	rows.Close()
+
+	s.db.QueryRow(`
+		SELECT COUNT(*) FROM escalations e
+		JOIN sessions s ON s.id = e.from_session
+		WHERE s.project_root = ? AND e.created_at >= ? AND e.created_at < ?
+	`, projectRoot, from, to).Scan(&r.NewEscalations)
+	s.db.QueryRow(`
+		SELECT COUNT(*) FROM escalations e
+		JOIN sessions s ON s.id = e.from_session
+		WHERE s.project_root = ? AND e.status = 'open'
+	`, projectRoot).Scan(&r.OpenEscalations)
+
+	milestones, err := s.milestones(projectRoot, start, end)
+	if err != nil {
+		return nil, err
+	}
+	r.Milestones = milestones
+
+	return r, nil
+}
+
+// milestones returns pipelines active during the period with their daily burndown
+func (s *Service) milestones(projectRoot string, start, end time.Time) ([]Milestone, error) {
+	rows, err := s.db.Query(`
+		SELECT p.id, p.name, COALESCE(p.status, ''),
+		       (SELECT COUNT(*) FROM pipeline_ports pp WHERE pp.pipeline_id = p.id)
+		FROM pipelines p
+		JOIN sessions s ON s.id = p.session_id
+		WHERE s.project_root = ?
+		  AND (p.completed_at IS NULL OR p.completed_at >= ?)
+		ORDER BY p.created_at
+	`, projectRoot, start.UTC().Format(sqlTime))
+	if err != nil {
+		return nil, fmt.Errorf("마일스톤 조회 실패: %w", err)
+	}
+	var milestones []Milestone
+	for rows.Next() {
+		var m Milestone
+		if err := rows.Scan(&m.ID, &m.Name, &m.Status, &m.Total); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		milestones = append(milestones, m)
+	}
+	rows.Close()
+
+	for i := range milestones {
+		m := &milestones[i]
+		s.db.QueryRow(`
+			SELECT COUNT(*) FROM pipeline_ports WHERE pipeline_id = ? AND status = 'complete'
+		`, m.ID).Scan(&m.Completed)
+
+		for day := start; day.Before(end); day = day.Add(24 * time.Hour) {
+			dayEnd := day.Add(24 * time.Hour)
+			if dayEnd.After(end) {
+				dayEnd = end
+			}
+			var done int
+			s.db.QueryRow(`
+				SELECT COUNT(*) FROM pipeline_ports pp
+				JOIN ports p ON p.id = pp.port_id
+				WHERE pp.pipeline_id = ? AND p.status = 'complete' AND p.completed_at < ?
+			`, m.ID, dayEnd.UTC().Format(sqlTime)).Scan(&done)
+			m.Burndown = append(m.Burndown, BurndownPoint{
+				Date:      day.Local().Format("01-02"),
+				Remaining: m.Total - done,
+			})
+		}
+	}
+
+	return milestones, nil
+}
+
+// Markdown renders the report as markdown
+func (r *Report) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# 주간 리포트: %s\n\n", r.ProjectName)
+	fmt.Fprintf(&b, "기간: %s ~ %s\n\n", r.PeriodStart.Local().Format("2006-01-02"), r.PeriodEnd.Local().Format("2006-01-02"))
+
+	b.WriteString("## 요약\n\n")
+	b.WriteString("| 항목 | 값 |\n|---|---|\n")
+	fmt.Fprintf(&b, "| 세션 | %d |\n", r.Sessions)
+	fmt.Fprintf(&b, "| 토큰 | %d |\n", r.Tokens)
+	fmt.Fprintf(&b, "| 비용 | $%.2f |\n", r.CostUSD)
+	fmt.Fprintf(&b, "| 완료 포트 | %d |\n", len(r.CompletedPorts))
+	fmt.Fprintf(&b, "| 신규 에스컬레이션 | %d (미해결 %d) |\n\n", r.NewEscalations, r.OpenEscalations)
+
+	b.WriteString("## 완료된 포트\n\n")
+	if len(r.CompletedPorts) == 0 {
+		b.WriteString("이번 주에 완료된 포트가 없습니다.\n\n")
+	}
+	for _, p := range r.CompletedPorts {
+		title := p.Title
+		if title == "" {
+			title = p.ID
+		}
+		fmt.Fprintf(&b, "- `%s` %s (%s)\n", p.ID, title, p.CompletedAt)
+	}
+	if len(r.CompletedPorts) > 0 {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## 마일스톤 번다운\n\n")
+	if len(r.Milestones) == 0 {
+		b.WriteString("진행 중인 마일스톤(파이프라인)이 없습니다.\n")
+	}
+	for _, m := range r.Milestones {
+		percent := 0
+		if m.Total > 0 {
+			percent = m.Completed * 100 / m.Total
+		}
+		fmt.Fprintf(&b, "### %s\n\n", m.Name)
+		fmt.Fprintf(&b, "진행률 **%d%%** (%d/%d 완료, 남은 포트 %d)\n\n", percent, m.Completed, m.Total, m.Remaining())
+		b.WriteString("| 날짜 | 남은 포트 |\n|---|---|\n")
+		for _, p := range m.Burndown {
+			fmt.Fprintf(&b, "| %s | %d |\n", p.Date, p.Remaining)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// Subject returns the email subject for the report
+func (r *Report) Subject() string {
+	return fmt.Sprintf("[PAL] %s 주간 리포트 (%s)", r.ProjectName, r.PeriodEnd.Local().Format("2006-01-02"))
+}
+
+// Due reports whether a scheduled send time has passed since the last send.
+// 발송 이력이 없으면 직전 스케줄 시각이 firstRunGrace 이내일 때만 발송합니다.
+func Due(schedule string, lastSent *time.Time, now time.Time) (bool, error) {
+	if schedule == "" {
+		schedule = DefaultSchedule
+	}
+	cron, err := orchestrator.ParseCron(schedule)
+	if err != nil {
+		return false, fmt.Errorf("다이제스트 스케줄 오류: %w", err)
+	}
+
+	base := now.Add(-firstRunGrace)
+	if lastSent != nil {
+		base = *lastSent
+	}
+	next := cron.Next(base)
+	return !next.IsZero() && !next.After(now), nil
+}
+
+// LastAttempt returns the time of the last send attempt for a project.
+// 실패한 발송도 포함하여 데몬이 매 tick마다 재시도하지 않도록 합니다.
+func (s *Service) LastAttempt(projectRoot string) (*time.Time, error) {
+	var sentAt sql.NullTime
+	err := s.db.QueryRow(`
+		SELECT sent_at FROM digest_runs
+		WHERE project_root = ?
+		ORDER BY sent_at DESC, id DESC LIMIT 1
+	`, projectRoot).Scan(&sentAt)
+	if err == sql.ErrNoRows || !sentAt.Valid {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("다이제스트 이력 조회 실패: %w", err)
+	}
+	return &sentAt.Time, nil
+}
+
+// Send builds the weekly report ending at now and emails it to the configured recipients.
+// 발송 결과는 성공/실패 모두 digest_runs에 기록됩니다.
+func (s *Service) Send(cfg config.DigestConfig, projectRoot string, now time.Time) (*Run, error) {
+	if !cfg.Enabled() {
+		return nil, fmt.Errorf("다이제스트 수신자가 설정되지 않았습니다 (notifications.digest.recipients)")
+	}
+
+	report, err := s.Weekly(projectRoot, now)
+	if err != nil {
+		return nil, err
+	}
+
+	sendErr := func() error {
+		sender, err := NewSender(cfg)
+		if err != nil {
+			return err
+		}
+		md := report.Markdown()
+		return sender.Send(Message{
+			From:    cfg.From,
+			To:      cfg.Recipients,
+			Subject: report.Subject(),
+			Text:    md,
+			HTML:    RenderHTML(md),
+		})
+	}()
+
+	run := &Run{
+		ProjectRoot: projectRoot,
+		Recipients:  cfg.Recipients,
+		Status:      RunSent,
+		SentAt:      now,
+	}
+	if sendErr != nil {
+		run.Status = RunFailed
+		run.Error = sendErr.Error()
+	}
+
+	result, err := s.db.Exec(`
+		INSERT INTO digest_runs (project_root, period_start, period_end, recipients, status, error, sent_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, projectRoot, report.PeriodStart.UTC(), report.PeriodEnd.UTC(), strings.Join(cfg.Recipients, ","), run.Status, run.Error, now.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("다이제스트 이력 저장 실패: %w", err)
+	}
+	run.ID, _ = result.LastInsertId()
+
+	if sendErr != nil {
+		return run, fmt.Errorf("다이제스트 발송 실패: %w", sendErr)
+	}
+	return run, nil
+}
+
+// RunDue sends the digest when the schedule is due; 발송하지 않았으면 nil을 반환합니다.
+func (s *Service) RunDue(cfg config.DigestConfig, projectRoot string, now time.Time) (*Run, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	last, err := s.LastAttempt(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	due, err := Due(cfg.Schedule, last, now)
+	if err != nil || !due {
+		return nil, err
+	}
+	return s.Send(cfg, projectRoot, now)
+}
+
+// History returns recent digest runs for a project
+func (s *Service) History(projectRoot string, limit int) ([]Run, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	rows, err := s.db.Query(`
+		SELECT id, project_root, COALESCE(recipients, ''), status, COALESCE(error, ''), sent_at
+		FROM digest_runs WHERE project_root = ?
+		ORDER BY sent_at DESC, id DESC LIMIT ?
+	`, projectRoot, limit)
+	if err != nil {
+		return nil, fmt.Errorf("다이제스트 이력 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []Run
+	for rows.Next() {
+		var run Run
+		var recipients string
+		if err := rows.Scan(&run.ID, &run.ProjectRoot, &recipients, &run.Status, &run.Error, &run.SentAt); err != nil {
+			return nil, err
+		}
+		if recipients != "" {
+			run.Recipients = strings.Split(recipients, ",")
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
@@ -0,0 +1,163 @@
+package digest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func setupTestDB(t *testing.T) *db.DB {
+	t.Helper()
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func TestRenderHTML(t *testing.T) {
+	md := "# 제목 <x>\n\n진행률 **50%** `port-1`\n\n- 항목 A\n- 항목 B\n\n| 날짜 | 남은 포트 |\n|---|---|\n| 10-01 | 3 |\n"
+	out := RenderHTML(md)
+
+	for _, want := range []string{
+		"<h1>제목 &lt;x&gt;</h1>",
+		"<p>진행률 <strong>50%</strong> <code>port-1</code></p>",
+		"<ul>\n<li>항목 A</li>\n<li>항목 B</li>\n</ul>",
+		"<tr><th>날짜</th><th>남은 포트</th></tr>",
+		"<tr><td>10-01</td><td>3</td></tr>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("RenderHTML missing %q\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "---") {
+		t.Errorf("separator row should not be rendered:\n%s", out)
+	}
+}
+
+func TestDue(t *testing.T) {
+	// 2026-10-12는 월요일
+	monday9 := time.Date(2026, 10, 12, 9, 0, 0, 0, time.Local)
+	lastWeek := monday9.Add(-7 * 24 * time.Hour)
+
+	tests := []struct {
+		name string
+		last *time.Time
+		now  time.Time
+		want bool
+	}{
+		{"before slot", &lastWeek, monday9.Add(-time.Minute), false},
+		{"slot passed", &lastWeek, monday9.Add(time.Minute), true},
+		{"already sent", &monday9, monday9.Add(time.Hour), false},
+		{"first run within grace", nil, monday9.Add(10 * time.Minute), true},
+		{"first run after grace", nil, monday9.Add(3 * time.Hour), false},
+	}
+	for _, tt := range tests {
+		got, err := Due("", tt.last, tt.now)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: Due = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := Due("bad", nil, monday9); err == nil {
+		t.Error("invalid schedule should fail")
+	}
+}
+
+func TestWeeklyReportAndSend(t *testing.T) {
+	database := setupTestDB(t)
+	root := "/tmp/digest-project"
+	now := time.Now()
+	recent := now.Add(-48 * time.Hour).UTC().Format(sqlTime)
+	old := now.Add(-10 * 24 * time.Hour).UTC().Format(sqlTime)
+
+	for _, q := range []string{
+		`INSERT INTO sessions (id, title, status, project_root, started_at, input_tokens, output_tokens, cost_usd) VALUES ('s1', 'a', 'complete', '` + root + `', '` + recent + `', 100, 50, 1.5)`,
+		`INSERT INTO sessions (id, title, status, project_root, started_at, cost_usd) VALUES ('s0', 'old', 'complete', '` + root + `', '` + old + `', 9)`,
+		`INSERT INTO ports (id, title, status, session_id, completed_at) VALUES ('p1', '로그인', 'complete', 's1', '` + recent + `')`,
+		`INSERT INTO ports (id, title, status, session_id) VALUES ('p2', '결제', 'pending', 's1')`,
+		`INSERT INTO pipelines (id, name, session_id, status) VALUES ('pl1', 'M1', 's1', 'running')`,
+		`INSERT INTO pipeline_ports (pipeline_id, port_id, status) VALUES ('pl1', 'p1', 'complete'), ('pl1', 'p2', 'pending')`,
+		`INSERT INTO escalations (from_session, issue, status) VALUES ('s1', '막힘', 'open')`,
+	} {
+		if _, err := database.Exec(q); err != nil {
+			t.Fatalf("seed 실패: %v\n%s", err, q)
+		}
+	}
+
+	svc := NewService(database)
+	report, err := svc.Weekly(root, now)
+	if err != nil {
+		t.Fatalf("Weekly 실패: %v", err)
+	}
+	if report.Sessions != 1 || report.CostUSD != 1.5 || report.Tokens != 150 {
+		t.Errorf("sessions/cost/tokens = %d/%v/%d, want 1/1.5/150", report.Sessions, report.CostUSD, report.Tokens)
+	}
+	if len(report.CompletedPorts) != 1 || report.CompletedPorts[0].ID != "p1" {
+		t.Errorf("completed ports = %+v", report.CompletedPorts)
+	}
+	if report.OpenEscalations != 1 {
+		t.Errorf("open escalations = %d, want 1", report.OpenEscalations)
+	}
+	if len(report.Milestones) != 1 {
+		t.Fatalf("milestones = %+v", report.Milestones)
+	}
+	m := report.Milestones[0]
+	if m.Total != 2 || m.Completed != 1 || len(m.Burndown) != 7 {
+		t.Errorf("milestone = %+v", m)
+	}
+	if first, last := m.Burndown[0].Remaining, m.Burndown[len(m.Burndown)-1].Remaining; first != 2 || last != 1 {
+		t.Errorf("burndown %d -> %d, want 2 -> 1", first, last)
+	}
+
+	var got map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sg-key" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+	sendGridEndpoint = ts.URL
+
+	os.Setenv("PAL_TEST_SENDGRID_KEY", "sg-key")
+	defer os.Unsetenv("PAL_TEST_SENDGRID_KEY")
+
+	cfg := config.DigestConfig{
+		Recipients: []string{"team@example.com"},
+		Provider:   ProviderSendGrid,
+		From:       "pal@example.com",
+		SendGrid:   config.SendGridConfig{APIKey: "${PAL_TEST_SENDGRID_KEY}"},
+	}
+	run, err := svc.Send(cfg, root, now)
+	if err != nil {
+		t.Fatalf("Send 실패: %v", err)
+	}
+	if run.Status != RunSent {
+		t.Errorf("run status = %s", run.Status)
+	}
+	if got["subject"] != report.Subject() {
+		t.Errorf("subject = %v", got["subject"])
+	}
+
+	last, err := svc.LastAttempt(root)
+	if err != nil || last == nil {
+		t.Fatalf("LastAttempt = %v, %v", last, err)
+	}
+	if run, err := svc.RunDue(cfg, root, now.Add(time.Minute)); err != nil || run != nil {
+		t.Errorf("RunDue right after send = %+v, %v; want no send", run, err)
+	}
+}
@@ -0,0 +1,168 @@
+package digest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+)
+
+// Providers
+const (
+	ProviderSMTP     = "smtp"
+	ProviderSendGrid = "sendgrid"
+)
+
+// defaultSMTPPort is the submission port used when none is configured
+const defaultSMTPPort = 587
+
+// sendGridEndpoint is the SendGrid v3 mail send API (테스트에서 교체)
+var sendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
+// sendTimeout bounds a single provider request
+const sendTimeout = 15 * time.Second
+
+// Message is an email with plain text and HTML bodies
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Sender delivers a message through a mail provider
+type Sender interface {
+	Send(msg Message) error
+}
+
+// NewSender creates the sender for the configured provider (기본: smtp)
+func NewSender(cfg config.DigestConfig) (Sender, error) {
+	if cfg.From == "" {
+		return nil, fmt.Errorf("발신 주소가 설정되지 않았습니다 (notifications.digest.from)")
+	}
+
+	switch cfg.Provider {
+	case "", ProviderSMTP:
+		if cfg.SMTP.Host == "" {
+			return nil, fmt.Errorf("SMTP 호스트가 설정되지 않았습니다 (notifications.digest.smtp.host)")
+		}
+		return &SMTPSender{cfg: cfg.SMTP}, nil
+	case ProviderSendGrid:
+		apiKey := os.ExpandEnv(cfg.SendGrid.APIKey)
+		if apiKey == "" {
+			return nil, fmt.Errorf("SendGrid API 키가 설정되지 않았습니다 (notifications.digest.sendgrid.api_key)")
+		}
+		return &SendGridSender{apiKey: apiKey, client: &http.Client{Timeout: sendTimeout}}, nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 메일 provider: %s", cfg.Provider)
+	}
+}
+
+// SMTPSender sends mail through an SMTP server
+type SMTPSender struct {
+	cfg config.SMTPConfig
+}
+
+// Send sends a multipart/alternative message via SMTP
+func (s *SMTPSender) Send(msg Message) error {
+	port := s.cfg.Port
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	addr := s.cfg.Host + ":" + strconv.Itoa(port)
+
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, os.ExpandEnv(s.cfg.Password), s.cfg.Host)
+	}
+
+	if err := smtp.SendMail(addr, auth, msg.From, msg.To, buildMIME(msg)); err != nil {
+		return fmt.Errorf("SMTP 전송 실패: %w", err)
+	}
+	return nil
+}
+
+// buildMIME renders a message as a multipart/alternative MIME document
+func buildMIME(msg Message) []byte {
+	const boundary = "pal-digest-boundary"
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", msg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain", msg.Text},
+		{"text/html", msg.HTML},
+	} {
+		fmt.Fprintf(&b, "--%s\r\n", boundary)
+		fmt.Fprintf(&b, "Content-Type: %s; charset=UTF-8\r\n", part.contentType)
+		b.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+		encoded := base64.StdEncoding.EncodeToString([]byte(part.body))
+		for len(encoded) > 76 {
+			b.WriteString(encoded[:76] + "\r\n")
+			encoded = encoded[76:]
+		}
+		b.WriteString(encoded + "\r\n")
+	}
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+
+	return b.Bytes()
+}
+
+// SendGridSender sends mail through the SendGrid v3 API
+type SendGridSender struct {
+	apiKey string
+	client *http.Client
+}
+
+// Send posts the message to SendGrid
+func (s *SendGridSender) Send(msg Message) error {
+	to := make([]map[string]string, 0, len(msg.To))
+	for _, addr := range msg.To {
+		to = append(to, map[string]string{"email": addr})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"personalizations": []map[string]interface{}{{"to": to}},
+		"from":             map[string]string{"email": msg.From},
+		"subject":          msg.Subject,
+		"content": []map[string]string{
+			{"type": "text/plain", "value": msg.Text},
+			{"type": "text/html", "value": msg.HTML},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sendGridEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("SendGrid 요청 생성 실패: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("SendGrid 전송 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("SendGrid 응답 오류: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package digest
+
+import (
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/markdown"
+)
+
+// htmlStyle keeps the email readable in clients that ignore external stylesheets
+const htmlStyle = `body{font-family:-apple-system,Segoe UI,sans-serif;color:#222;line-height:1.5}` +
+	`table{border-collapse:collapse;margin:8px 0}th,td{border:1px solid #ddd;padding:4px 10px;text-align:left}` +
+	`th{background:#f5f5f5}code{background:#f2f2f2;padding:1px 4px;border-radius:3px}`
+
+// RenderHTML converts a report into a standalone HTML document (본문은 markdown.Render)
+func RenderHTML(md string) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html><html><head><meta charset=\"UTF-8\"><style>")
+	b.WriteString(htmlStyle)
+	b.WriteString("</style></head><body>\n")
+	b.WriteString(markdown.Render(md, markdown.Options{}))
+	b.WriteString("</body></html>\n")
+	return b.String()
+}
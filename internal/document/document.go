@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/trash"
 )
 
 // Document represents an indexed document
@@ -42,6 +43,7 @@ type Link struct {
 type Service struct {
 	db          *db.DB
 	projectRoot string
+	types       *TypeRegistry
 }
 
 // NewService creates a new document service
@@ -52,6 +54,19 @@ func NewService(database *db.DB, projectRoot string) *Service {
 	}
 }
 
+// Types returns the document type registry of the project.
+// 정의 파일을 읽을 수 없으면 기본 타입을 사용합니다.
+func (s *Service) Types() *TypeRegistry {
+	if s.types == nil {
+		types, err := LoadTypeRegistry(s.projectRoot)
+		if err != nil {
+			types = DefaultTypeRegistry()
+		}
+		s.types = types
+	}
+	return s.types
+}
+
 // IndexResult contains results from indexing operation
 type IndexResult struct {
 	Added   int
@@ -64,76 +79,59 @@ type IndexResult struct {
 func (s *Service) Index() (*IndexResult, error) {
 	result := &IndexResult{}
 
-	// 1. 스캔할 디렉토리 목록
-	scanPaths := []struct {
-		dir      string
-		docType  string
-		patterns []string
-	}{
-		{"ports", "port", []string{"*.md"}},
-		{"conventions", "convention", []string{"*.md"}},
-		{"agents", "agent", []string{"*.yaml", "*.yml"}},
-		{"docs", "docs", []string{"*.md"}},
-		{".pal/sessions", "session", []string{"*.md"}},
-		{".pal/decisions", "adr", []string{"*.md"}},
+	// 1. 문서 타입 레지스트리 (기본 타입 + .pal/doc-types.yaml)
+	types, err := LoadTypeRegistry(s.projectRoot)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		types = DefaultTypeRegistry()
 	}
+	s.types = types
 
 	existingDocs := make(map[string]bool)
 
-	// 2. 각 경로 스캔
-	for _, sp := range scanPaths {
-		dir := filepath.Join(s.projectRoot, sp.dir)
+	// 2. 타입 glob의 기준 디렉토리 스캔
+	for _, root := range types.ScanRoots() {
+		dir := filepath.Join(s.projectRoot, root)
 		if _, err := os.Stat(dir); os.IsNotExist(err) {
 			continue
 		}
 
-		for _, pattern := range sp.patterns {
-			files, err := filepath.Glob(filepath.Join(dir, "**", pattern))
+		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
-				result.Errors = append(result.Errors, fmt.Sprintf("glob error: %v", err))
-				continue
+				return nil
 			}
-
-			// 직접 스캔 (Glob이 ** 지원 안 할 수 있음)
-			filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-				if err != nil || info.IsDir() {
-					return nil
-				}
-
-				matched, _ := filepath.Match(pattern, info.Name())
-				if !matched {
-					return nil
+			if info.IsDir() {
+				if path != dir && (info.Name() == ".git" || info.Name() == "node_modules") {
+					return filepath.SkipDir
 				}
-
-				files = append(files, path)
 				return nil
-			})
-
-			// 중복 제거
-			seen := make(map[string]bool)
-			for _, f := range files {
-				if seen[f] {
-					continue
-				}
-				seen[f] = true
+			}
 
-				relPath, _ := filepath.Rel(s.projectRoot, f)
-				existingDocs[relPath] = true
+			relPath, _ := filepath.Rel(s.projectRoot, path)
+			// 여러 기준 디렉토리에 걸친 파일은 한 번만
+			if existingDocs[relPath] {
+				return nil
+			}
+			t, ok := types.Match(relPath)
+			if !ok {
+				return nil
+			}
+			existingDocs[relPath] = true
 
-				added, updated, err := s.indexFile(f, sp.docType)
-				if err != nil {
-					result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", relPath, err))
-					continue
-				}
+			added, updated, err := s.indexFile(path, t.ID)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", relPath, err))
+				return nil
+			}
 
-				if added {
-					result.Added++
-				}
-				if updated {
-					result.Updated++
-				}
+			if added {
+				result.Added++
 			}
-		}
+			if updated {
+				result.Updated++
+			}
+			return nil
+		})
 	}
 
 	// 3. 삭제된 문서 정리
@@ -610,10 +608,17 @@ func (s *Service) GetRelatedDocs(portPath string, tokenBudget int64) ([]Document
 	var docs []Document
 	var totalTokens int64
 
-	// 2. 같은 도메인의 L1 문서 검색
-	if port.Domain != "" {
-		l1Docs, _ := s.Search("", SearchFilters{Type: "l1", Domain: port.Domain, Limit: 5})
-		for _, d := range l1Docs {
+	// 2. 주입 우선순위가 높은 타입부터 예산 안에서 추가
+	for _, t := range s.Types().Injectable() {
+		filters := SearchFilters{Type: t.ID, Limit: t.InjectLimit()}
+		if t.DomainScoped {
+			if port.Domain == "" {
+				continue
+			}
+			filters.Domain = port.Domain
+		}
+		candidates, _ := s.Search("", filters)
+		for _, d := range candidates {
 			if totalTokens+d.Tokens <= tokenBudget {
 				docs = append(docs, d)
 				totalTokens += d.Tokens
@@ -621,15 +626,6 @@ func (s *Service) GetRelatedDocs(portPath string, tokenBudget int64) ([]Document
 		}
 	}
 
-	// 3. 관련 컨벤션 검색
-	conventions, _ := s.Search("", SearchFilters{Type: "convention", Limit: 10})
-	for _, d := range conventions {
-		if totalTokens+d.Tokens <= tokenBudget {
-			docs = append(docs, d)
-			totalTokens += d.Tokens
-		}
-	}
-
 	return docs, nil
 }
 
@@ -716,14 +712,8 @@ func (s *Service) RefreshDocument(path string) error {
 
 	// 타입 추론
 	docType := "unknown"
-	if strings.HasPrefix(path, "ports/") {
-		docType = "port"
-	} else if strings.HasPrefix(path, "conventions/") {
-		docType = "convention"
-	} else if strings.HasPrefix(path, "agents/") {
-		docType = "agent"
-	} else if strings.HasPrefix(path, "docs/") {
-		docType = "docs"
+	if t, ok := s.Types().Match(path); ok {
+		docType = t.ID
 	}
 
 	_, _, err := s.indexFile(fullPath, docType)
@@ -909,23 +899,36 @@ func (s *Service) CopyDocument(id, newPath string) error {
 	return s.CreateDocument(newPath, content)
 }
 
-// DeleteDocument deletes a document
+// DeleteDocument soft-deletes a document (moves it to the trash)
 func (s *Service) DeleteDocument(id string) error {
+	_, err := s.TrashDocument(id)
+	return err
+}
+
+// TrashDocument moves a document file to the trash and removes its index entry.
+// 'pal docs trash restore'로 30일 이내 복원할 수 있습니다.
+func (s *Service) TrashDocument(id string) (*trash.Item, error) {
 	doc, err := s.Get(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	
-	fullPath := filepath.Join(s.projectRoot, doc.Path)
-	
-	// Delete file
-	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
-		return err
+
+	item, err := trash.NewService(s.db).Move(trash.KindDocument, s.projectRoot, doc.Path, map[string]string{
+		"document_id": doc.ID,
+		"type":        doc.Type,
+		"domain":      doc.Domain,
+		"status":      doc.Status,
+		"tags":        strings.Join(doc.Tags, ","),
+	})
+	if err != nil {
+		return nil, err
 	}
-	
+
 	// Delete from index
-	_, err = s.db.Exec(`DELETE FROM documents WHERE id = ?`, id)
-	return err
+	if _, err := s.db.Exec(`DELETE FROM documents WHERE id = ?`, id); err != nil {
+		return nil, err
+	}
+	return item, nil
 }
 
 // unused but keeping io import for potential future use
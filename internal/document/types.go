@@ -0,0 +1,308 @@
+package document
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TypesFile is the project file defining custom document types (<root>/.pal/doc-types.yaml)
+var TypesFile = filepath.Join(".pal", "doc-types.yaml")
+
+// defaultInjectLimit is the number of documents injected per type when limit is omitted
+const defaultInjectLimit = 10
+
+// TypeDef defines a document type: how it is displayed, ordered, discovered and injected.
+//
+// 프로젝트 타입은 .pal/doc-types.yaml에 정의하며 같은 ID의 기본 타입은 지정한 필드만 덮어씁니다.
+//
+//	types:
+//	  - id: runbook
+//	    name: 런북
+//	    order: 45
+//	    globs: ["ops/runbooks/**/*.md"]
+//	    priority: 30      # 관련 문서 주입 우선순위 (높을수록 먼저, 0 = 주입 안 함)
+//	    limit: 3
+type TypeDef struct {
+	ID           string   `yaml:"id" json:"id"`
+	Name         string   `yaml:"name,omitempty" json:"name"`
+	Order        int      `yaml:"order,omitempty" json:"order"`
+	Globs        []string `yaml:"globs,omitempty" json:"globs,omitempty"`
+	Priority     int      `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Limit        int      `yaml:"limit,omitempty" json:"limit,omitempty"`
+	DomainScoped bool     `yaml:"domain_scoped,omitempty" json:"domain_scoped,omitempty"` // 포트와 같은 도메인의 문서만 주입
+	Source       string   `yaml:"-" json:"source"`                                        // builtin 또는 파일 경로
+}
+
+// InjectLimit returns how many documents of this type are injected at most
+func (t TypeDef) InjectLimit() int {
+	if t.Limit > 0 {
+		return t.Limit
+	}
+	return defaultInjectLimit
+}
+
+// builtinTypes are the document types shipped with PAL Kit
+var builtinTypes = []TypeDef{
+	{ID: "l1", Name: "L1 Domain", Order: 10, Priority: 100, Limit: 5, DomainScoped: true},
+	{ID: "l2", Name: "L2 Feature", Order: 20},
+	{ID: "lm", Name: "LM Coordinator", Order: 30},
+	{ID: "convention", Name: "컨벤션", Order: 40, Globs: []string{"conventions/**/*.md"}, Priority: 50, Limit: 10},
+	{ID: "template", Name: "템플릿", Order: 50},
+	{ID: "port", Name: "포트", Order: 60, Globs: []string{"ports/**/*.md"}},
+	{ID: "agent", Name: "에이전트", Order: 70, Globs: []string{"agents/**/*.yaml", "agents/**/*.yml"}},
+	{ID: "docs", Name: "문서", Order: 80, Globs: []string{"docs/**/*.md"}},
+	{ID: "session", Name: "세션", Order: 90, Globs: []string{".pal/sessions/**/*.md"}},
+	{ID: "adr", Name: "ADR", Order: 100, Globs: []string{".pal/decisions/**/*.md"}},
+}
+
+// TypeRegistry holds the document types of a project
+type TypeRegistry struct {
+	types []TypeDef // Order, ID 순
+}
+
+// DefaultTypeRegistry returns a registry with only the builtin types
+func DefaultTypeRegistry() *TypeRegistry {
+	r, _ := newTypeRegistry(nil)
+	return r
+}
+
+// LoadTypeRegistry loads the builtin types merged with the project's .pal/doc-types.yaml
+func LoadTypeRegistry(projectRoot string) (*TypeRegistry, error) {
+	file := filepath.Join(projectRoot, TypesFile)
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return DefaultTypeRegistry(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("문서 타입 정의 읽기 실패: %w", err)
+	}
+
+	var spec struct {
+		Types []TypeDef `yaml:"types"`
+	}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("문서 타입 정의 파싱 실패 (%s): %w", TypesFile, err)
+	}
+	for i := range spec.Types {
+		spec.Types[i].Source = file
+	}
+	return newTypeRegistry(spec.Types)
+}
+
+func newTypeRegistry(custom []TypeDef) (*TypeRegistry, error) {
+	byID := make(map[string]TypeDef)
+	for _, t := range builtinTypes {
+		t.Source = "builtin"
+		byID[t.ID] = t
+	}
+
+	for _, c := range custom {
+		c.ID = strings.TrimSpace(c.ID)
+		if c.ID == "" {
+			return nil, fmt.Errorf("문서 타입 ID가 비어 있습니다 (%s)", TypesFile)
+		}
+		for _, g := range c.Globs {
+			if err := validateGlob(g); err != nil {
+				return nil, fmt.Errorf("문서 타입 '%s'의 glob 패턴 오류: %w", c.ID, err)
+			}
+		}
+
+		t, ok := byID[c.ID]
+		if !ok {
+			t = TypeDef{ID: c.ID, Order: 1000}
+		}
+		if c.Name != "" {
+			t.Name = c.Name
+		}
+		if c.Order != 0 {
+			t.Order = c.Order
+		}
+		if len(c.Globs) > 0 {
+			t.Globs = c.Globs
+		}
+		if c.Priority != 0 {
+			t.Priority = c.Priority
+		}
+		if c.Limit != 0 {
+			t.Limit = c.Limit
+		}
+		if c.DomainScoped {
+			t.DomainScoped = true
+		}
+		t.Source = c.Source
+		byID[c.ID] = t
+	}
+
+	r := &TypeRegistry{}
+	for _, t := range byID {
+		if t.Name == "" {
+			t.Name = t.ID
+		}
+		r.types = append(r.types, t)
+	}
+	sort.Slice(r.types, func(i, j int) bool {
+		if r.types[i].Order != r.types[j].Order {
+			return r.types[i].Order < r.types[j].Order
+		}
+		return r.types[i].ID < r.types[j].ID
+	})
+	return r, nil
+}
+
+// Types returns the registered types in display order
+func (r *TypeRegistry) Types() []TypeDef {
+	return r.types
+}
+
+// Get returns a type by ID
+func (r *TypeRegistry) Get(id string) (TypeDef, bool) {
+	for _, t := range r.types {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return TypeDef{}, false
+}
+
+// DisplayName returns the display name of a type (미등록 타입은 ID 그대로)
+func (r *TypeRegistry) DisplayName(id string) string {
+	if t, ok := r.Get(id); ok {
+		return t.Name
+	}
+	return id
+}
+
+// OrderOf returns the display order of a type (미등록 타입은 등록된 타입 뒤)
+func (r *TypeRegistry) OrderOf(id string) int {
+	if t, ok := r.Get(id); ok {
+		return t.Order
+	}
+	return 1 << 30
+}
+
+// Match returns the type whose glob matches a project-relative path.
+// 프로젝트에서 정의한 타입이 기본 타입보다 먼저 검사되므로 기본 디렉토리의 하위 경로를 가져갈 수 있습니다.
+func (r *TypeRegistry) Match(relPath string) (TypeDef, bool) {
+	relPath = filepath.ToSlash(relPath)
+	for _, builtin := range []bool{false, true} {
+		for _, t := range r.types {
+			if (t.Source == "builtin") != builtin {
+				continue
+			}
+			for _, g := range t.Globs {
+				if matchGlob(g, relPath) {
+					return t, true
+				}
+			}
+		}
+	}
+	return TypeDef{}, false
+}
+
+// Injectable returns the types injected as related documents, highest priority first
+func (r *TypeRegistry) Injectable() []TypeDef {
+	var types []TypeDef
+	for _, t := range r.types {
+		if t.Priority > 0 {
+			types = append(types, t)
+		}
+	}
+	sort.SliceStable(types, func(i, j int) bool { return types[i].Priority > types[j].Priority })
+	return types
+}
+
+// ScanRoots returns the directories to walk for indexing.
+// glob의 고정 접두 경로이며, 다른 경로 하위에 있는 경로는 제외합니다.
+func (r *TypeRegistry) ScanRoots() []string {
+	var bases []string
+	for _, t := range r.types {
+		for _, g := range t.Globs {
+			bases = append(bases, globBase(g))
+		}
+	}
+
+	var roots []string
+	for i, base := range bases {
+		covered := false
+		for j, other := range bases {
+			if j == i {
+				continue
+			}
+			// 하위 경로이거나, 같은 경로의 첫 항목이 아닌 경우
+			if (other != base && (other == "." || strings.HasPrefix(base, other+"/"))) || (other == base && j < i) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			roots = append(roots, base)
+		}
+	}
+	return roots
+}
+
+// globBase returns the leading path segments of a glob that contain no wildcard
+func globBase(glob string) string {
+	var base []string
+	for _, seg := range strings.Split(filepath.ToSlash(glob), "/") {
+		if strings.ContainsAny(seg, "*?[") {
+			break
+		}
+		base = append(base, seg)
+	}
+	if len(base) == 0 {
+		return "."
+	}
+	// 마지막 세그먼트가 파일명이면 디렉토리만
+	if len(base) == len(strings.Split(filepath.ToSlash(glob), "/")) {
+		base = base[:len(base)-1]
+		if len(base) == 0 {
+			return "."
+		}
+	}
+	return strings.Join(base, "/")
+}
+
+// matchGlob matches a slash-separated path against a glob where ** spans any number of directories
+func matchGlob(glob, relPath string) bool {
+	return matchSegments(strings.Split(filepath.ToSlash(glob), "/"), strings.Split(relPath, "/"))
+}
+
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(pattern[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
+
+func validateGlob(glob string) error {
+	if glob == "" || filepath.IsAbs(glob) || strings.HasPrefix(glob, "../") {
+		return fmt.Errorf("프로젝트 루트 기준 상대 경로여야 합니다: %q", glob)
+	}
+	for _, seg := range strings.Split(filepath.ToSlash(glob), "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("%q: %w", glob, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("디렉토리 생성 실패: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("파일 쓰기 실패: %v", err)
+	}
+}
+
+func TestTypeRegistry(t *testing.T) {
+	root := t.TempDir()
+
+	types, err := LoadTypeRegistry(root)
+	if err != nil {
+		t.Fatalf("LoadTypeRegistry 실패: %v", err)
+	}
+	if got, _ := types.Match("ports/auth/login.md"); got.ID != "port" {
+		t.Errorf("ports/auth/login.md 타입 = %q, want port", got.ID)
+	}
+	if _, ok := types.Match("README.md"); ok {
+		t.Error("README.md는 어떤 타입에도 매칭되지 않아야 합니다")
+	}
+	if types.DisplayName("l1") != "L1 Domain" {
+		t.Errorf("l1 표시 이름 = %q", types.DisplayName("l1"))
+	}
+
+	writeFile(t, root, TypesFile, `types:
+  - id: runbook
+    name: 런북
+    order: 45
+    globs: ["docs/runbooks/**/*.md"]
+    priority: 200
+  - id: convention
+    name: 코딩 규칙
+`)
+	types, err = LoadTypeRegistry(root)
+	if err != nil {
+		t.Fatalf("LoadTypeRegistry 실패: %v", err)
+	}
+
+	// 프로젝트 타입이 기본 docs 타입의 하위 경로를 가져감
+	if got, _ := types.Match("docs/runbooks/deploy.md"); got.ID != "runbook" {
+		t.Errorf("docs/runbooks/deploy.md 타입 = %q, want runbook", got.ID)
+	}
+	if got, _ := types.Match("docs/guide.md"); got.ID != "docs" {
+		t.Errorf("docs/guide.md 타입 = %q, want docs", got.ID)
+	}
+
+	// 덮어쓴 필드만 바뀌고 나머지는 기본값 유지
+	conv, _ := types.Get("convention")
+	if conv.Name != "코딩 규칙" || conv.Priority != 50 || len(conv.Globs) == 0 {
+		t.Errorf("convention 병합 결과 = %+v", conv)
+	}
+
+	injectable := types.Injectable()
+	if len(injectable) != 3 || injectable[0].ID != "runbook" || injectable[1].ID != "l1" {
+		t.Errorf("주입 순서 = %+v", injectable)
+	}
+
+	for _, r := range types.ScanRoots() {
+		if r == "docs/runbooks" {
+			t.Error("docs 하위 경로는 별도로 스캔하지 않아야 합니다")
+		}
+	}
+
+	writeFile(t, root, TypesFile, "types:\n  - id: bad\n    globs: [\"/abs/*.md\"]\n")
+	if _, err := LoadTypeRegistry(root); err == nil {
+		t.Error("절대 경로 glob은 오류여야 합니다")
+	}
+}
+
+func TestIndexWithCustomTypes(t *testing.T) {
+	root := t.TempDir()
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	defer database.Close()
+	if err := database.Init(); err != nil {
+		t.Fatalf("DB 초기화 실패: %v", err)
+	}
+
+	writeFile(t, root, TypesFile, `types:
+  - id: runbook
+    globs: ["ops/**/*.md"]
+    priority: 10
+`)
+	writeFile(t, root, "ops/deploy/rollback.md", "# Rollback\n")
+	writeFile(t, root, "ports/auth.md", "# Auth\n")
+	writeFile(t, root, "ops/notes.txt", "skip\n")
+
+	svc := NewService(database, root)
+	result, err := svc.Index()
+	if err != nil {
+		t.Fatalf("Index 실패: %v", err)
+	}
+	if result.Added != 2 || len(result.Errors) != 0 {
+		t.Fatalf("Index 결과 = %+v, want 2 added", result)
+	}
+
+	doc, err := svc.GetByPath(filepath.Join("ops", "deploy", "rollback.md"))
+	if err != nil {
+		t.Fatalf("GetByPath 실패: %v", err)
+	}
+	if doc.Type != "runbook" {
+		t.Errorf("문서 타입 = %q, want runbook", doc.Type)
+	}
+
+	related, err := svc.GetRelatedDocs(filepath.Join("ports", "auth.md"), 1000)
+	if err != nil {
+		t.Fatalf("GetRelatedDocs 실패: %v", err)
+	}
+	if len(related) != 1 || related[0].Type != "runbook" {
+		t.Errorf("관련 문서 = %+v, want runbook 1건", related)
+	}
+}
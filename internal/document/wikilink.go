@@ -0,0 +1,59 @@
+package document
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// LinkIndex resolves wikilink targets against the document index
+type LinkIndex struct {
+	byKey map[string]string // 확장자 없는 경로, 파일 이름, 문서 ID → 문서 ID
+}
+
+// LinkIndex loads the document index for wikilink resolution
+func (s *Service) LinkIndex() (*LinkIndex, error) {
+	rows, err := s.db.Query(`SELECT id, path FROM documents ORDER BY path`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	idx := &LinkIndex{byKey: make(map[string]string)}
+	var names []struct{ name, id string }
+	for rows.Next() {
+		var id, path string
+		if err := rows.Scan(&id, &path); err != nil {
+			return nil, err
+		}
+		idx.byKey[id] = id
+		idx.byKey[strings.TrimSuffix(path, filepath.Ext(path))] = id
+		names = append(names, struct{ name, id string }{strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), id})
+	}
+	// 파일 이름은 경로/ID와 겹치지 않을 때만 (같은 이름이 여러 개면 경로순 첫 문서)
+	for _, n := range names {
+		if _, exists := idx.byKey[n.name]; !exists {
+			idx.byKey[n.name] = n.id
+		}
+	}
+	return idx, rows.Err()
+}
+
+// Resolve returns the ID of the document [[target]] points to, written in the document at fromPath
+func (idx *LinkIndex) Resolve(target, fromPath string) (string, bool) {
+	target = strings.TrimSpace(strings.Split(target, "#")[0])
+	target = strings.TrimSuffix(target, ".md")
+	if target == "" {
+		return "", false
+	}
+	candidates := []string{
+		target,
+		filepath.Join(filepath.Dir(fromPath), target),
+		target + "/_index",
+	}
+	for _, key := range candidates {
+		if id, ok := idx.byKey[key]; ok {
+			return id, true
+		}
+	}
+	return "", false
+}
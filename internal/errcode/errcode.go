@@ -0,0 +1,104 @@
+// Package errcode defines the error taxonomy shared by services and the CLI.
+// 각 분류는 고유한 종료 코드로 매핑되어, 래퍼 스크립트와 Hook 실행기가
+// 한국어 오류 메시지를 파싱하지 않고 실패 유형으로 분기할 수 있습니다.
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Kind is an error category
+type Kind string
+
+const (
+	KindInternal   Kind = "internal"   // 분류되지 않은 오류
+	KindConflict   Kind = "conflict"   // Lock 충돌, 중복 등
+	KindConfig     Kind = "config"     // 설정 없음/파싱 실패
+	KindNotFound   Kind = "not_found"  // 세션/포트 등 대상 없음
+	KindValidation Kind = "validation" // 입력값 검증 실패
+	KindDBBusy     Kind = "db_busy"    // SQLite busy/locked (재시도 가능)
+)
+
+// Exit codes per kind.
+// 2는 Claude Code Hook에서 차단(blocking)으로 처리되므로 충돌에만 사용합니다.
+const (
+	ExitOK         = 0
+	ExitInternal   = 1
+	ExitConflict   = 2
+	ExitConfig     = 3
+	ExitNotFound   = 4
+	ExitValidation = 5
+	ExitDBBusy     = 6
+)
+
+var exitCodes = map[Kind]int{
+	KindInternal:   ExitInternal,
+	KindConflict:   ExitConflict,
+	KindConfig:     ExitConfig,
+	KindNotFound:   ExitNotFound,
+	KindValidation: ExitValidation,
+	KindDBBusy:     ExitDBBusy,
+}
+
+// Error is an error tagged with a Kind. Error()는 원래 메시지를 그대로 반환합니다.
+type Error struct {
+	Kind Kind
+	Err  error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// New creates a tagged error with a formatted message (%w 지원)
+func New(kind Kind, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// Wrap tags an existing error with a Kind (nil이면 nil)
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// KindOf returns the category of an error.
+// 태그가 없으면 SQLite busy/locked 여부를 확인하고, 그 외에는 internal입니다.
+func KindOf(err error) Kind {
+	if err == nil {
+		return ""
+	}
+
+	var tagged *Error
+	if errors.As(err, &tagged) {
+		return tagged.Kind
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked) {
+		return KindDBBusy
+	}
+	if strings.Contains(err.Error(), "database is locked") {
+		return KindDBBusy
+	}
+
+	return KindInternal
+}
+
+// ExitCode returns the process exit status of an error
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	return exitCodes[KindOf(err)]
+}
+
+// Is reports whether err is of the given kind
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
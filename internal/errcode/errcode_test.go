@@ -0,0 +1,40 @@
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestExitCode(t *testing.T) {
+	notFound := New(KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", "p1")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"untagged", errors.New("실패"), ExitInternal},
+		{"not found", notFound, ExitNotFound},
+		{"wrapped", fmt.Errorf("조회 실패: %w", notFound), ExitNotFound},
+		{"conflict", New(KindConflict, "잠겨있습니다"), ExitConflict},
+		{"config", Wrap(KindConfig, errors.New("파싱 실패")), ExitConfig},
+		{"sqlite busy", fmt.Errorf("저장 실패: %w", sqlite3.Error{Code: sqlite3.ErrBusy}), ExitDBBusy},
+	}
+
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("%s: ExitCode = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	if notFound.Error() != "포트 'p1'을(를) 찾을 수 없습니다" {
+		t.Errorf("메시지가 보존되어야 함: %s", notFound.Error())
+	}
+	if Wrap(KindConfig, nil) != nil {
+		t.Error("Wrap(nil)은 nil이어야 함")
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
 )
 
 // Escalation represents an escalation request
@@ -19,6 +20,12 @@ type Escalation struct {
 	Status      string
 	CreatedAt   time.Time
 	ResolvedAt  sql.NullTime
+	Type        sql.NullString // 템플릿 타입 (.pal/escalations.yaml)
+	Severity    sql.NullString
+	Context     sql.NullString // JSON: {"fields": {...}}
+	Suggestion  sql.NullString
+	Resolution  sql.NullString // JSON: ResolutionRecord
+	Assignee    sql.NullString // 담당 사용자/에이전트
 }
 
 // Status constants
@@ -78,11 +85,16 @@ func (s *Service) Resolve(id int64) error {
 		return fmt.Errorf("에스컬레이션 #%d을(를) 찾을 수 없거나 이미 처리됨", id)
 	}
 
-	return nil
+	return s.logActivity(&Activity{EscalationID: id, Kind: ActivityResolve, NewValue: StatusResolved})
 }
 
 // Dismiss marks an escalation as dismissed
 func (s *Service) Dismiss(id int64) error {
+	return s.DismissBy(id, "", "")
+}
+
+// DismissBy dismisses an escalation, recording who dismissed it and why
+func (s *Service) DismissBy(id int64, actor, reason string) error {
 	result, err := s.db.Exec(`
 		UPDATE escalations 
 		SET status = 'dismissed', resolved_at = CURRENT_TIMESTAMP
@@ -98,19 +110,21 @@ func (s *Service) Dismiss(id int64) error {
 		return fmt.Errorf("에스컬레이션 #%d을(를) 찾을 수 없거나 이미 처리됨", id)
 	}
 
-	return nil
+	return s.logActivity(&Activity{EscalationID: id, Kind: ActivityDismiss, Actor: actor, Body: reason, NewValue: StatusDismissed})
 }
 
 // Get retrieves an escalation by ID
 func (s *Service) Get(id int64) (*Escalation, error) {
 	var e Escalation
 	err := s.db.QueryRow(`
-		SELECT id, from_session, from_port, issue, status, created_at, resolved_at
+		SELECT id, from_session, from_port, issue, status, created_at, resolved_at,
+		       type, severity, context, suggestion, resolution, assignee
 		FROM escalations WHERE id = ?
-	`, id).Scan(&e.ID, &e.FromSession, &e.FromPort, &e.Issue, &e.Status, &e.CreatedAt, &e.ResolvedAt)
+	`, id).Scan(&e.ID, &e.FromSession, &e.FromPort, &e.Issue, &e.Status, &e.CreatedAt, &e.ResolvedAt,
+		&e.Type, &e.Severity, &e.Context, &e.Suggestion, &e.Resolution, &e.Assignee)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("에스컬레이션 #%d을(를) 찾을 수 없습니다", id)
+		return nil, errcode.New(errcode.KindNotFound, "에스컬레이션 #%d을(를) 찾을 수 없습니다", id)
 	}
 	if err != nil {
 		return nil, err
@@ -121,21 +135,53 @@ func (s *Service) Get(id int64) (*Escalation, error) {
 
 // List returns escalations with optional filters
 func (s *Service) List(status string, limit int) ([]Escalation, error) {
+	return s.ListFiltered(ListFilter{Status: status, Limit: limit})
+}
+
+// ListFilter narrows ListFiltered (빈 값은 조건 없음)
+type ListFilter struct {
+	Status   string
+	Session  string // from_session
+	Port     string
+	Assignee string
+	AfterID  int64 // 이 ID 이후에 생성된 것만 (폴링용, 오래된 순으로 반환)
+	Limit    int
+}
+
+// ListFiltered returns escalations matching the filter, newest first (AfterID가 있으면 오래된 순)
+func (s *Service) ListFiltered(f ListFilter) ([]Escalation, error) {
 	query := `
-		SELECT id, from_session, from_port, issue, status, created_at, resolved_at
-		FROM escalations
+		SELECT id, from_session, from_port, issue, status, created_at, resolved_at,
+		       type, severity, context, suggestion, resolution, assignee
+		FROM escalations WHERE 1 = 1
 	`
 
 	var args []interface{}
-	if status != "" {
-		query += ` WHERE status = ?`
-		args = append(args, status)
+	if f.Status != "" {
+		query += ` AND status = ?`
+		args = append(args, f.Status)
+	}
+	if f.Session != "" {
+		query += ` AND from_session = ?`
+		args = append(args, f.Session)
+	}
+	if f.Port != "" {
+		query += ` AND from_port = ?`
+		args = append(args, f.Port)
+	}
+	if f.Assignee != "" {
+		query += ` AND assignee = ?`
+		args = append(args, f.Assignee)
+	}
+	if f.AfterID > 0 {
+		query += ` AND id > ? ORDER BY id`
+		args = append(args, f.AfterID)
+	} else {
+		query += ` ORDER BY created_at DESC`
 	}
 
-	query += ` ORDER BY created_at DESC`
-
-	if limit > 0 {
-		query += fmt.Sprintf(` LIMIT %d`, limit)
+	if f.Limit > 0 {
+		query += fmt.Sprintf(` LIMIT %d`, f.Limit)
 	}
 
 	rows, err := s.db.Query(query, args...)
@@ -147,7 +193,8 @@ func (s *Service) List(status string, limit int) ([]Escalation, error) {
 	var escalations []Escalation
 	for rows.Next() {
 		var e Escalation
-		if err := rows.Scan(&e.ID, &e.FromSession, &e.FromPort, &e.Issue, &e.Status, &e.CreatedAt, &e.ResolvedAt); err != nil {
+		if err := rows.Scan(&e.ID, &e.FromSession, &e.FromPort, &e.Issue, &e.Status, &e.CreatedAt, &e.ResolvedAt,
+			&e.Type, &e.Severity, &e.Context, &e.Suggestion, &e.Resolution, &e.Assignee); err != nil {
 			return nil, err
 		}
 		escalations = append(escalations, e)
@@ -206,6 +253,18 @@ const (
 	TypeDependency     EscalationType = "dependency"       // 의존성 해결 불가
 )
 
+// builtinTypes are the escalation types usable without a project template
+var builtinTypes = map[EscalationType]bool{
+	TypeBuildFail: true, TypeTestFail: true, TypeBlocked: true, TypeQuestion: true,
+	TypeTokenExceeded: true, TypeCompactWarning: true, TypeDependencyLoop: true, TypeManualReview: true,
+	TypeTimeout: true, TypeConflict: true, TypeQuality: true, TypeDependency: true,
+}
+
+// IsBuiltinType reports whether t is one of the predefined escalation types
+func IsBuiltinType(t string) bool {
+	return builtinTypes[EscalationType(t)]
+}
+
 // Severity defines the severity of an escalation
 type Severity string
 
@@ -279,6 +338,31 @@ type EnhancedEscalationOptions struct {
 	Suggestion  string
 }
 
+// CreateRecord creates a typed escalation in the escalations table and returns its row ID.
+// CreateEnhanced와 같은 옵션을 받지만 정수 ID(AUTOINCREMENT)를 사용하므로 'pal esc' 목록에 함께 나타납니다.
+func (s *Service) CreateRecord(opts EnhancedEscalationOptions) (int64, error) {
+	severity := opts.Severity
+	if severity == "" {
+		severity = SeverityMedium
+	}
+	var contextJSON string
+	if opts.Context != nil {
+		data, _ := json.Marshal(opts.Context)
+		contextJSON = string(data)
+	}
+
+	result, err := s.db.Exec(`
+		INSERT INTO escalations (issue, from_session, to_session, from_port, status, type, severity, context, suggestion)
+		VALUES (?, ?, ?, ?, 'open', ?, ?, ?, ?)
+	`, opts.Issue, nullableString(opts.FromSession), nullableString(opts.ToSession), nullableString(opts.FromPort),
+		string(opts.Type), severity, nullableString(contextJSON), nullableString(opts.Suggestion))
+	if err != nil {
+		return 0, fmt.Errorf("에스컬레이션 생성 실패: %w", err)
+	}
+
+	return result.LastInsertId()
+}
+
 // GetEnhanced retrieves an enhanced escalation by ID
 func (s *Service) GetEnhanced(id string) (*EnhancedEscalation, error) {
 	var e EnhancedEscalation
@@ -295,7 +379,7 @@ func (s *Service) GetEnhanced(id string) (*EnhancedEscalation, error) {
 		&e.Issue, &contextJSON, &suggestion, &e.Status, &resolution, &e.CreatedAt, &resolvedAt)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("에스컬레이션 '%s'을(를) 찾을 수 없습니다", id)
+		return nil, errcode.New(errcode.KindNotFound, "에스컬레이션 '%s'을(를) 찾을 수 없습니다", id)
 	}
 	if err != nil {
 		return nil, err
@@ -346,7 +430,12 @@ func (s *Service) ResolveEnhanced(id, resolution string) error {
 		return fmt.Errorf("에스컬레이션 '%s'을(를) 찾을 수 없거나 이미 처리됨", id)
 	}
 
-	return nil
+	// 갱신된 행은 정수 ID를 가지므로 일반 해결과 같은 기록에 남김
+	var rowID int64
+	if err := s.db.QueryRow(`SELECT id FROM escalations WHERE id = ?`, id).Scan(&rowID); err != nil {
+		return fmt.Errorf("에스컬레이션 조회 실패: %w", err)
+	}
+	return s.logActivity(&Activity{EscalationID: rowID, Kind: ActivityResolve, Body: resolution, NewValue: StatusResolved})
 }
 
 // ListBySession returns escalations for a session
@@ -227,3 +227,88 @@ func TestDismiss_AlreadyDismissed(t *testing.T) {
 		t.Error("이미 무시된 에스컬레이션 재무시가 성공함")
 	}
 }
+
+func TestTemplates_CreateAndResolveWithSteps(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	projectRoot := t.TempDir()
+	os.MkdirAll(filepath.Join(projectRoot, ".pal"), 0755)
+	yamlContent := `types:
+  - id: blocked
+    severity: high
+    fields:
+      - name: blocker
+        required: true
+      - name: eta
+    resolution_steps:
+      - id: identify-owner
+        description: 담당자 확인
+        required: true
+      - id: workaround
+        description: 우회 방안 적용
+`
+	if err := os.WriteFile(filepath.Join(projectRoot, TemplatesFile), []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("템플릿 작성 실패: %v", err)
+	}
+
+	templates, err := LoadTemplates(projectRoot)
+	if err != nil {
+		t.Fatalf("LoadTemplates 실패: %v", err)
+	}
+	tmpl, ok := templates.Get("blocked")
+	if !ok {
+		t.Fatal("blocked 타입이 로드되지 않음")
+	}
+
+	svc := NewService(database)
+
+	if _, err := svc.CreateTyped(tmpl, TypedOptions{Issue: "막힘", Fields: map[string]string{"eta": "1d"}}); err == nil {
+		t.Error("필수 필드 누락 시 에러가 발생해야 함")
+	}
+	if _, err := svc.CreateTyped(tmpl, TypedOptions{Issue: "막힘", Fields: map[string]string{"blocker": "API", "owner": "x"}}); err == nil {
+		t.Error("정의되지 않은 필드에 에러가 발생해야 함")
+	}
+
+	id, err := svc.CreateTyped(tmpl, TypedOptions{Issue: "막힘", PortID: "port-1", Fields: map[string]string{"blocker": "API"}})
+	if err != nil {
+		t.Fatalf("CreateTyped 실패: %v", err)
+	}
+
+	e, _ := svc.Get(id)
+	if e.Type.String != "blocked" || e.Severity.String != "high" {
+		t.Errorf("타입/심각도 불일치: %s/%s", e.Type.String, e.Severity.String)
+	}
+	if e.Fields()["blocker"] != "API" {
+		t.Errorf("필드가 저장되지 않음: %v", e.Fields())
+	}
+	if e.Suggestion.String == "" {
+		t.Error("권장 해결 단계가 저장되어야 함")
+	}
+
+	if err := svc.ResolveWithSteps(id, tmpl, ResolutionRecord{Steps: []string{"workaround"}}); err == nil {
+		t.Error("필수 단계 미완료 시 에러가 발생해야 함")
+	}
+	if err := svc.ResolveWithSteps(id, tmpl, ResolutionRecord{Steps: []string{"reboot"}}); err == nil {
+		t.Error("정의되지 않은 단계에 에러가 발생해야 함")
+	}
+	if err := svc.ResolveWithSteps(id, tmpl, ResolutionRecord{Steps: []string{"identify-owner"}, Note: "팀 A 처리"}); err != nil {
+		t.Fatalf("ResolveWithSteps 실패: %v", err)
+	}
+
+	e, _ = svc.Get(id)
+	record := e.ResolutionRecord()
+	if e.Status != "resolved" || record == nil || len(record.Steps) != 1 || record.Note != "팀 A 처리" {
+		t.Errorf("해결 기록 불일치: status=%s record=%+v", e.Status, record)
+	}
+}
+
+func TestLoadTemplates_Missing(t *testing.T) {
+	templates, err := LoadTemplates(t.TempDir())
+	if err != nil {
+		t.Fatalf("파일이 없으면 에러 없이 빈 세트를 반환해야 함: %v", err)
+	}
+	if len(templates.Types) != 0 {
+		t.Errorf("빈 세트를 기대함: %d", len(templates.Types))
+	}
+}
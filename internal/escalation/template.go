@@ -0,0 +1,288 @@
+package escalation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TemplatesFile is the project-level escalation type definition file
+const TemplatesFile = ".pal/escalations.yaml"
+
+// Template defines an escalation type with required fields and resolution steps.
+//
+//	types:
+//	  - id: blocked
+//	    name: 작업 차단
+//	    severity: high
+//	    fields:
+//	      - name: blocker
+//	        description: 무엇이 작업을 막고 있는지
+//	        required: true
+//	    resolution_steps:
+//	      - id: identify-owner
+//	        description: 차단 요인의 담당자 확인
+//	        required: true
+type Template struct {
+	ID              string           `yaml:"id" json:"id"`
+	Name            string           `yaml:"name,omitempty" json:"name,omitempty"`
+	Description     string           `yaml:"description,omitempty" json:"description,omitempty"`
+	Severity        Severity         `yaml:"severity,omitempty" json:"severity,omitempty"`
+	Fields          []TemplateField  `yaml:"fields,omitempty" json:"fields,omitempty"`
+	ResolutionSteps []ResolutionStep `yaml:"resolution_steps,omitempty" json:"resolution_steps,omitempty"`
+}
+
+// TemplateField is a structured field collected when an escalation is created
+type TemplateField struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty" json:"required,omitempty"`
+}
+
+// ResolutionStep is a suggested step of the guided resolution flow
+type ResolutionStep struct {
+	ID          string `yaml:"id" json:"id"`
+	Description string `yaml:"description" json:"description"`
+	Required    bool   `yaml:"required,omitempty" json:"required,omitempty"`
+}
+
+// TemplateSet holds the escalation templates of a project
+type TemplateSet struct {
+	Types []Template `yaml:"types" json:"types"`
+}
+
+// LoadTemplates reads <projectRoot>/.pal/escalations.yaml.
+// 파일이 없으면 빈 TemplateSet을 반환합니다 (자유 형식 에스컬레이션).
+func LoadTemplates(projectRoot string) (*TemplateSet, error) {
+	path := filepath.Join(projectRoot, TemplatesFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &TemplateSet{}, nil
+		}
+		return nil, fmt.Errorf("에스컬레이션 템플릿 읽기 실패: %w", err)
+	}
+
+	var set TemplateSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("에스컬레이션 템플릿 파싱 실패 (%s): %w", TemplatesFile, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, t := range set.Types {
+		if t.ID == "" {
+			return nil, fmt.Errorf("%s: id가 없는 에스컬레이션 타입이 있습니다", TemplatesFile)
+		}
+		if seen[t.ID] {
+			return nil, fmt.Errorf("%s: 중복된 에스컬레이션 타입 '%s'", TemplatesFile, t.ID)
+		}
+		seen[t.ID] = true
+	}
+
+	return &set, nil
+}
+
+// Get returns the template for an escalation type
+func (ts *TemplateSet) Get(id string) (*Template, bool) {
+	for i := range ts.Types {
+		if ts.Types[i].ID == id {
+			return &ts.Types[i], true
+		}
+	}
+	return nil, false
+}
+
+// ValidateFields checks that all required fields are present and no unknown fields are given
+func (t *Template) ValidateFields(fields map[string]string) error {
+	known := make(map[string]bool)
+	var missing []string
+	for _, f := range t.Fields {
+		known[f.Name] = true
+		if f.Required && strings.TrimSpace(fields[f.Name]) == "" {
+			missing = append(missing, f.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("'%s' 에스컬레이션 필수 필드 누락: %s", t.ID, strings.Join(missing, ", "))
+	}
+
+	var unknown []string
+	for name := range fields {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("'%s' 에스컬레이션에 정의되지 않은 필드: %s", t.ID, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// ValidateSteps checks that taken steps exist and all required steps were taken
+func (t *Template) ValidateSteps(taken []string) error {
+	done := make(map[string]bool)
+	for _, id := range taken {
+		done[id] = true
+	}
+
+	known := make(map[string]bool)
+	var missing []string
+	for _, step := range t.ResolutionSteps {
+		known[step.ID] = true
+		if step.Required && !done[step.ID] {
+			missing = append(missing, step.ID)
+		}
+	}
+
+	for _, id := range taken {
+		if !known[id] {
+			return fmt.Errorf("'%s' 해결 절차에 없는 단계: %s", t.ID, id)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("'%s' 필수 해결 단계 미완료: %s", t.ID, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Suggestion renders the resolution steps as a suggestion text
+func (t *Template) Suggestion() string {
+	if len(t.ResolutionSteps) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for i, step := range t.ResolutionSteps {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("%d. [%s] %s", i+1, step.ID, step.Description))
+	}
+	return sb.String()
+}
+
+// ResolutionRecord records which resolution steps were taken
+type ResolutionRecord struct {
+	Steps   []string `json:"steps,omitempty"`
+	Note    string   `json:"note,omitempty"`
+	Summary string   `json:"summary,omitempty"` // 해결 요약 (차단된 워커에게 전달)
+	By      string   `json:"by,omitempty"`
+}
+
+// TypedOptions holds options for creating a templated escalation
+type TypedOptions struct {
+	Issue      string
+	SessionID  string
+	PortID     string
+	Fields     map[string]string
+	Severity   Severity // 비어 있으면 템플릿 severity
+	Note       string   // 자유 형식 상황 설명 (context.note)
+	Suggestion string   // 템플릿 해결 절차 앞에 붙는 제안
+}
+
+// CreateTyped creates an escalation validated against its template.
+// 템플릿의 severity와 해결 절차(suggestion)가 함께 저장됩니다.
+func (s *Service) CreateTyped(tmpl *Template, opts TypedOptions) (int64, error) {
+	if err := tmpl.ValidateFields(opts.Fields); err != nil {
+		return 0, err
+	}
+
+	severity := opts.Severity
+	if severity == "" {
+		severity = tmpl.Severity
+	}
+	if severity == "" {
+		severity = SeverityMedium
+	}
+	ctx := map[string]interface{}{"fields": opts.Fields}
+	if opts.Note != "" {
+		ctx["note"] = opts.Note
+	}
+	contextJSON, _ := json.Marshal(ctx)
+	suggestion := tmpl.Suggestion()
+	if opts.Suggestion != "" {
+		suggestion = strings.TrimSpace(opts.Suggestion + "\n\n" + suggestion)
+	}
+
+	result, err := s.db.Exec(`
+		INSERT INTO escalations (issue, from_session, from_port, status, type, severity, context, suggestion)
+		VALUES (?, ?, ?, 'open', ?, ?, ?, ?)
+	`, opts.Issue, nullableString(opts.SessionID), nullableString(opts.PortID),
+		tmpl.ID, severity, string(contextJSON), nullableString(suggestion))
+	if err != nil {
+		return 0, fmt.Errorf("에스컬레이션 생성 실패: %w", err)
+	}
+
+	return result.LastInsertId()
+}
+
+// ResolveWithSteps resolves an escalation and records the resolution steps taken.
+// tmpl이 주어지면 단계 ID와 필수 단계 완료 여부를 검증합니다.
+func (s *Service) ResolveWithSteps(id int64, tmpl *Template, record ResolutionRecord) error {
+	if tmpl != nil {
+		if err := tmpl.ValidateSteps(record.Steps); err != nil {
+			return err
+		}
+	}
+
+	data, _ := json.Marshal(record)
+	result, err := s.db.Exec(`
+		UPDATE escalations
+		SET status = 'resolved', resolution = ?, resolved_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = 'open'
+	`, string(data), id)
+	if err != nil {
+		return fmt.Errorf("에스컬레이션 해결 실패: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("에스컬레이션 #%d을(를) 찾을 수 없거나 이미 처리됨", id)
+	}
+
+	body := record.Summary
+	if body == "" {
+		body = record.Note
+	}
+	return s.logActivity(&Activity{EscalationID: id, Kind: ActivityResolve, Actor: record.By, Body: body, NewValue: StatusResolved})
+}
+
+// Fields returns the structured fields recorded at creation
+func (e *Escalation) Fields() map[string]string {
+	var ctx struct {
+		Fields map[string]string `json:"fields"`
+	}
+	if e.Context.Valid {
+		json.Unmarshal([]byte(e.Context.String), &ctx)
+	}
+	return ctx.Fields
+}
+
+// Note returns the free-form context recorded at creation (context.note)
+func (e *Escalation) Note() string {
+	var ctx struct {
+		Note string `json:"note"`
+	}
+	if e.Context.Valid {
+		json.Unmarshal([]byte(e.Context.String), &ctx)
+	}
+	return ctx.Note
+}
+
+// ResolutionRecord returns the recorded resolution steps, if any
+func (e *Escalation) ResolutionRecord() *ResolutionRecord {
+	if !e.Resolution.Valid || e.Resolution.String == "" {
+		return nil
+	}
+	var record ResolutionRecord
+	if err := json.Unmarshal([]byte(e.Resolution.String), &record); err != nil {
+		// 자유 형식 resolution 텍스트
+		return &ResolutionRecord{Note: e.Resolution.String}
+	}
+	return &record
+}
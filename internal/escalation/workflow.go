@@ -0,0 +1,185 @@
+package escalation
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/message"
+)
+
+// Activity kinds recorded in the escalation audit trail
+const (
+	ActivityAssign   = "assign"
+	ActivityComment  = "comment"
+	ActivitySeverity = "severity"
+	ActivityResolve  = "resolve"
+	ActivityDismiss  = "dismiss"
+)
+
+// Activity is one entry of an escalation's audit trail
+type Activity struct {
+	ID           int64     `json:"id"`
+	EscalationID int64     `json:"escalation_id"`
+	Kind         string    `json:"kind"`
+	Actor        string    `json:"actor,omitempty"`
+	Body         string    `json:"body,omitempty"`
+	OldValue     string    `json:"old_value,omitempty"`
+	NewValue     string    `json:"new_value,omitempty"`
+	ParentID     int64     `json:"parent_id,omitempty"` // 답글 대상 댓글
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ParseSeverity validates a severity name
+func ParseSeverity(v string) (Severity, error) {
+	switch sev := Severity(strings.ToLower(strings.TrimSpace(v))); sev {
+	case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return sev, nil
+	}
+	return "", errcode.New(errcode.KindValidation, "알 수 없는 심각도: %s (low, medium, high, critical)", v)
+}
+
+// Assign sets the user or agent responsible for an open escalation ("" = 담당 해제)
+func (s *Service) Assign(id int64, assignee, actor string) (*Activity, error) {
+	e, err := s.openEscalation(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.Exec(`UPDATE escalations SET assignee = ? WHERE id = ?`, nullableString(assignee), id); err != nil {
+		return nil, fmt.Errorf("담당자 지정 실패: %w", err)
+	}
+
+	a := &Activity{EscalationID: id, Kind: ActivityAssign, Actor: actor, OldValue: e.Assignee.String, NewValue: assignee}
+	return a, s.logActivity(a)
+}
+
+// Comment adds a comment (or a reply to parentID) to an escalation. 해결된 뒤에도 남길 수 있습니다.
+func (s *Service) Comment(id int64, actor, body string, parentID int64) (*Activity, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, errcode.New(errcode.KindValidation, "댓글 내용이 필요합니다")
+	}
+	if _, err := s.Get(id); err != nil {
+		return nil, err
+	}
+	if parentID > 0 {
+		var escID int64
+		err := s.db.QueryRow(`SELECT escalation_id FROM escalation_activity WHERE id = ? AND kind = ?`, parentID, ActivityComment).Scan(&escID)
+		if err == sql.ErrNoRows || escID != id {
+			return nil, errcode.New(errcode.KindNotFound, "에스컬레이션 #%d에 댓글 #%d이(가) 없습니다", id, parentID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	a := &Activity{EscalationID: id, Kind: ActivityComment, Actor: actor, Body: body, ParentID: parentID}
+	return a, s.logActivity(a)
+}
+
+// SetSeverity changes the severity of an open escalation
+func (s *Service) SetSeverity(id int64, severity Severity, actor string) (*Activity, error) {
+	e, err := s.openEscalation(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.Exec(`UPDATE escalations SET severity = ? WHERE id = ?`, string(severity), id); err != nil {
+		return nil, fmt.Errorf("심각도 변경 실패: %w", err)
+	}
+
+	a := &Activity{EscalationID: id, Kind: ActivitySeverity, Actor: actor, OldValue: e.Severity.String, NewValue: string(severity)}
+	return a, s.logActivity(a)
+}
+
+// Activity returns the audit trail of an escalation in chronological order
+func (s *Service) Activity(id int64) ([]Activity, error) {
+	rows, err := s.db.Query(`
+		SELECT id, escalation_id, kind, COALESCE(actor, ''), COALESCE(body, ''),
+		       COALESCE(old_value, ''), COALESCE(new_value, ''), COALESCE(parent_id, 0), created_at
+		FROM escalation_activity
+		WHERE escalation_id = ?
+		ORDER BY id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("에스컬레이션 기록 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var activities []Activity
+	for rows.Next() {
+		var a Activity
+		if err := rows.Scan(&a.ID, &a.EscalationID, &a.Kind, &a.Actor, &a.Body,
+			&a.OldValue, &a.NewValue, &a.ParentID, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		activities = append(activities, a)
+	}
+	return activities, rows.Err()
+}
+
+func (s *Service) openEscalation(id int64) (*Escalation, error) {
+	e, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != StatusOpen {
+		return nil, errcode.New(errcode.KindConflict, "에스컬레이션 #%d은(는) 이미 %s 상태입니다", id, e.Status)
+	}
+	return e, nil
+}
+
+// logActivity appends to the audit trail and notifies the session that raised the escalation.
+// 차단된 워커가 MCP receive/컨텍스트 보존으로 담당 지정, 댓글, 해결 내용을 받아볼 수 있도록 메시지를 보냅니다.
+func (s *Service) logActivity(a *Activity) error {
+	var parent sql.NullInt64
+	if a.ParentID > 0 {
+		parent = sql.NullInt64{Int64: a.ParentID, Valid: true}
+	}
+	a.CreatedAt = time.Now()
+	result, err := s.db.Exec(`
+		INSERT INTO escalation_activity (escalation_id, kind, actor, body, old_value, new_value, parent_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, a.EscalationID, a.Kind, nullableString(a.Actor), nullableString(a.Body),
+		nullableString(a.OldValue), nullableString(a.NewValue), parent, a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("에스컬레이션 기록 실패: %w", err)
+	}
+	a.ID, _ = result.LastInsertId()
+
+	if a.Kind == ActivitySeverity {
+		return nil
+	}
+	var fromSession, fromPort, status string
+	err = s.db.QueryRow(`
+		SELECT COALESCE(from_session, ''), COALESCE(from_port, ''), status FROM escalations WHERE id = ?
+	`, a.EscalationID).Scan(&fromSession, &fromPort, &status)
+	if err != nil || fromSession == "" || fromSession == a.Actor {
+		return nil
+	}
+
+	from := a.Actor
+	if from == "" {
+		from = "pal"
+	}
+	// 기본 우선순위(5)라 운영자 inbox에는 다시 올라오지 않음. 알림 실패는 기록 자체를 실패시키지 않음
+	message.NewStore(s.db.DB).Send(&message.Message{
+		ConversationID: "escalation-" + strconv.FormatInt(a.EscalationID, 10),
+		FromSession:    from,
+		ToSession:      fromSession,
+		Type:           message.TypeResponse,
+		Subtype:        message.SubtypeEscalationUpdate,
+		PortID:         fromPort,
+		Payload: map[string]interface{}{
+			"escalation_id": a.EscalationID,
+			"kind":          a.Kind,
+			"actor":         a.Actor,
+			"body":          a.Body,
+			"value":         a.NewValue,
+			"status":        status,
+		},
+	})
+	return nil
+}
@@ -0,0 +1,116 @@
+package escalation
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/message"
+)
+
+func TestWorkflow(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	id, err := svc.Create("스키마 변경 승인 필요", "worker-1", "port-001")
+	if err != nil {
+		t.Fatalf("Create 실패: %v", err)
+	}
+
+	if _, err := svc.Assign(id, "alice", "op"); err != nil {
+		t.Fatalf("Assign 실패: %v", err)
+	}
+	comment, err := svc.Comment(id, "op", "검토 중입니다", 0)
+	if err != nil {
+		t.Fatalf("Comment 실패: %v", err)
+	}
+	if _, err := svc.Comment(id, "worker-1", "감사합니다", comment.ID); err != nil {
+		t.Fatalf("답글 실패: %v", err)
+	}
+	if _, err := svc.Comment(id, "op", "없는 댓글", 999); errcode.KindOf(err) != errcode.KindNotFound {
+		t.Errorf("없는 댓글에 답글 = %v, want not_found", err)
+	}
+	if _, err := svc.Comment(id, "op", "  ", 0); errcode.KindOf(err) != errcode.KindValidation {
+		t.Errorf("빈 댓글 = %v, want validation", err)
+	}
+	if _, err := ParseSeverity("urgent"); err == nil {
+		t.Error("알 수 없는 심각도는 에러여야 함")
+	}
+	if _, err := svc.SetSeverity(id, SeverityHigh, "op"); err != nil {
+		t.Fatalf("SetSeverity 실패: %v", err)
+	}
+	if err := svc.ResolveWithSteps(id, nil, ResolutionRecord{Summary: "승인됨, 진행", By: "op"}); err != nil {
+		t.Fatalf("Resolve 실패: %v", err)
+	}
+
+	e, _ := svc.Get(id)
+	if e.Assignee.String != "alice" || e.Severity.String != "high" || e.ResolutionRecord().Summary != "승인됨, 진행" {
+		t.Errorf("에스컬레이션 = %+v", e)
+	}
+	if _, err := svc.Assign(id, "bob", "op"); errcode.KindOf(err) != errcode.KindConflict {
+		t.Errorf("해결된 에스컬레이션 담당 지정 = %v, want conflict", err)
+	}
+
+	activities, err := svc.Activity(id)
+	if err != nil {
+		t.Fatalf("Activity 실패: %v", err)
+	}
+	kinds := []string{ActivityAssign, ActivityComment, ActivityComment, ActivitySeverity, ActivityResolve}
+	if len(activities) != len(kinds) {
+		t.Fatalf("기록 수 = %d, want %d", len(activities), len(kinds))
+	}
+	for i, k := range kinds {
+		if activities[i].Kind != k {
+			t.Errorf("activities[%d].Kind = %s, want %s", i, activities[i].Kind, k)
+		}
+	}
+	if activities[2].ParentID != comment.ID || activities[4].Body != "승인됨, 진행" {
+		t.Errorf("답글/해결 기록 = %+v", activities)
+	}
+
+	// 워커 본인의 답글과 심각도 변경을 제외한 담당/댓글/해결이 워커에게 전달됨
+	msgs, err := message.NewStore(database.DB).Receive("worker-1", 10)
+	if err != nil {
+		t.Fatalf("Receive 실패: %v", err)
+	}
+	if len(msgs) != 3 {
+		t.Fatalf("워커 알림 수 = %d, want 3", len(msgs))
+	}
+	for _, m := range msgs {
+		if m.Subtype != message.SubtypeEscalationUpdate || m.PortID != "port-001" {
+			t.Errorf("알림 메시지 = %+v", m)
+		}
+	}
+}
+
+func TestResolveEnhancedLogsActivity(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	id, err := svc.CreateRecord(EnhancedEscalationOptions{
+		FromSession: "worker-1",
+		FromPort:    "port-001",
+		Type:        TypeBlocked,
+		Issue:       "외부 API 키 필요",
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord 실패: %v", err)
+	}
+	if err := svc.ResolveEnhanced(strconv.FormatInt(id, 10), "키 발급 완료"); err != nil {
+		t.Fatalf("ResolveEnhanced 실패: %v", err)
+	}
+
+	activities, err := svc.Activity(id)
+	if err != nil {
+		t.Fatalf("Activity 실패: %v", err)
+	}
+	if len(activities) != 1 {
+		t.Fatalf("기록 수 = %d, want 1", len(activities))
+	}
+	a := activities[0]
+	if a.Kind != ActivityResolve || a.Body != "키 발급 완료" || a.NewValue != StatusResolved {
+		t.Errorf("해결 기록 = %+v", a)
+	}
+}
@@ -0,0 +1,332 @@
+// Package feed merges session events, messages, escalations and lock activity into one timeline.
+// 대시보드 첫 화면의 "최근 활동"을 한 번의 조회로 그리기 위한 읽기 전용 집계입니다.
+package feed
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+// Kind defines the source of a feed item
+type Kind string
+
+const (
+	KindEvent      Kind = "event"
+	KindMessage    Kind = "message"
+	KindEscalation Kind = "escalation"
+	KindLock       Kind = "lock"
+)
+
+// Kinds lists every feed kind in display order
+var Kinds = []Kind{KindEvent, KindMessage, KindEscalation, KindLock}
+
+// DefaultWindow is how far back the feed looks when no start time is given
+const DefaultWindow = time.Hour
+
+// summaryMax bounds the length of event payloads copied into summaries
+const summaryMax = 120
+
+// Item is one entry of the activity feed
+type Item struct {
+	Key       string    `json:"key"` // <kind>:<id>[:<type>]
+	Kind      Kind      `json:"kind"`
+	ID        string    `json:"id"`
+	Type      string    `json:"type"` // event_type, 메시지 subtype, opened/resolved, acquired/wait/conflict
+	SessionID string    `json:"session_id,omitempty"`
+	PortID    string    `json:"port_id,omitempty"`
+	Summary   string    `json:"summary"`
+	Time      time.Time `json:"time"`
+}
+
+// Filter holds feed filter options
+type Filter struct {
+	Since   time.Time // 기본: Until - DefaultWindow
+	Until   time.Time // 기본: 현재
+	Project string    // project_root (세션을 통해 필터)
+	Kinds   []Kind    // 비어 있으면 전체
+	Limit   int       // 소스별 최대 건수이자 전체 최대 건수 (0 = 무제한)
+}
+
+// Service builds the activity feed
+type Service struct {
+	db *db.DB
+}
+
+// NewService creates a new feed service
+func NewService(database *db.DB) *Service {
+	return &Service{db: database}
+}
+
+// ParseKind validates a kind name
+func ParseKind(s string) (Kind, error) {
+	for _, k := range Kinds {
+		if string(k) == s {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("알 수 없는 피드 종류입니다: %s (event, message, escalation, lock)", s)
+}
+
+// List returns feed items in [Since, Until], newest first.
+// Lock 해제는 기록이 남지 않으므로 현재 보유 중인 Lock의 획득, 대기, 충돌 이벤트만 포함됩니다.
+func (s *Service) List(filter Filter) ([]Item, error) {
+	if filter.Until.IsZero() {
+		filter.Until = time.Now()
+	}
+	if filter.Since.IsZero() {
+		filter.Since = filter.Until.Add(-DefaultWindow)
+	}
+
+	sources := map[Kind]func(Filter) ([]Item, error){
+		KindEvent:      s.listEvents,
+		KindMessage:    s.listMessages,
+		KindEscalation: s.listEscalations,
+		KindLock:       s.listLocks,
+	}
+
+	var items []Item
+	for _, kind := range Kinds {
+		if !wants(filter.Kinds, kind) {
+			continue
+		}
+		kindItems, err := sources[kind](filter)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, kindItems...)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].Time.Equal(items[j].Time) {
+			return items[i].Time.After(items[j].Time)
+		}
+		return items[i].Key > items[j].Key
+	})
+
+	if filter.Limit > 0 && len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+	return items, nil
+}
+
+func wants(kinds []Kind, kind Kind) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// scope appends the time range, project and limit clauses for a source
+func scope(query, timeCol, sessionExpr string, filter Filter) (string, []interface{}) {
+	query += ` AND ` + timeCol + ` >= ? AND ` + timeCol + ` <= ?`
+	args := []interface{}{filter.Since.UTC(), filter.Until.UTC()}
+	if filter.Project != "" {
+		query += ` AND ` + sessionExpr + ` IN (SELECT id FROM sessions WHERE project_root = ?)`
+		args = append(args, filter.Project)
+	}
+	query += ` ORDER BY ` + timeCol + ` DESC`
+	if filter.Limit > 0 {
+		query += ` LIMIT ` + strconv.Itoa(filter.Limit)
+	}
+	return query, args
+}
+
+func (s *Service) listEvents(filter Filter) ([]Item, error) {
+	// lock_conflict는 lock 종류로 분류
+	query, args := scope(`
+		SELECT id, session_id, event_type, COALESCE(event_data, ''), created_at
+		FROM session_events
+		WHERE event_type != 'lock_conflict'
+	`, "created_at", "session_id", filter)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("세션 이벤트 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var items []Item
+	for rows.Next() {
+		var id int64
+		var item Item
+		var data string
+		if err := rows.Scan(&id, &item.SessionID, &item.Type, &data, &item.Time); err != nil {
+			return nil, err
+		}
+		item.Kind = KindEvent
+		item.ID = strconv.FormatInt(id, 10)
+		item.Key = "event:" + item.ID
+		item.Summary = item.Type
+		if data != "" {
+			item.Summary += " " + truncate(data, summaryMax)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (s *Service) listMessages(filter Filter) ([]Item, error) {
+	query, args := scope(`
+		SELECT id, from_session, COALESCE(to_session, ''), type, COALESCE(subtype, ''),
+		       COALESCE(port_id, ''), created_at
+		FROM messages
+		WHERE 1 = 1
+	`, "created_at", "from_session", filter)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("메시지 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var items []Item
+	for rows.Next() {
+		var item Item
+		var from, to, msgType, subtype string
+		if err := rows.Scan(&item.ID, &from, &to, &msgType, &subtype, &item.PortID, &item.Time); err != nil {
+			return nil, err
+		}
+		item.Kind = KindMessage
+		item.Key = "message:" + item.ID
+		item.SessionID = from
+		item.Type = subtype
+		if item.Type == "" {
+			item.Type = msgType
+		}
+		if to == "" {
+			to = "broadcast"
+		}
+		item.Summary = fmt.Sprintf("%s %s → %s", item.Type, from, to)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// listEscalations returns one item per creation and one per resolution
+func (s *Service) listEscalations(filter Filter) ([]Item, error) {
+	var items []Item
+	for _, typ := range []string{"opened", "resolved"} {
+		timeCol := "created_at"
+		where := "1 = 1"
+		if typ == "resolved" {
+			timeCol = "resolved_at"
+			where = "resolved_at IS NOT NULL"
+		}
+		query, args := scope(`
+			SELECT id, COALESCE(from_session, ''), COALESCE(from_port, ''), issue, `+timeCol+`
+			FROM escalations
+			WHERE `+where, timeCol, "from_session", filter)
+
+		rows, err := s.db.Query(query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("에스컬레이션 조회 실패: %w", err)
+		}
+		for rows.Next() {
+			var id int64
+			var item Item
+			var issue string
+			if err := rows.Scan(&id, &item.SessionID, &item.PortID, &issue, &item.Time); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			item.Kind = KindEscalation
+			item.ID = strconv.FormatInt(id, 10)
+			item.Key = "escalation:" + item.ID + ":" + typ
+			item.Type = typ
+			item.Summary = fmt.Sprintf("[%s] %s", typ, truncate(issue, summaryMax))
+			items = append(items, item)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// listLocks merges current lock acquisitions, lock waits and lock_conflict events
+func (s *Service) listLocks(filter Filter) ([]Item, error) {
+	var items []Item
+
+	query, args := scope(`
+		SELECT resource, session_id, acquired_at FROM locks WHERE 1 = 1
+	`, "acquired_at", "session_id", filter)
+	if err := s.scanLocks(query, args, "acquired", &items); err != nil {
+		return nil, err
+	}
+
+	query, args = scope(`
+		SELECT resource, session_id, first_at FROM lock_waits WHERE 1 = 1
+	`, "first_at", "session_id", filter)
+	if err := s.scanLocks(query, args, "wait", &items); err != nil {
+		return nil, err
+	}
+
+	query, args = scope(`
+		SELECT id, session_id, COALESCE(event_data, ''), created_at
+		FROM session_events
+		WHERE event_type = 'lock_conflict'
+	`, "created_at", "session_id", filter)
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Lock 충돌 이벤트 조회 실패: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var data string
+		item := Item{Kind: KindLock, Type: "conflict"}
+		if err := rows.Scan(&id, &item.SessionID, &data, &item.Time); err != nil {
+			return nil, err
+		}
+		item.ID = strconv.FormatInt(id, 10)
+		item.Key = "lock:event:" + item.ID
+		item.Summary = "conflict " + truncate(data, summaryMax)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (s *Service) scanLocks(query string, args []interface{}, typ string, items *[]Item) error {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("Lock 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var resource string
+		var at sql.NullTime
+		item := Item{Kind: KindLock, Type: typ}
+		if err := rows.Scan(&resource, &item.SessionID, &at); err != nil {
+			return err
+		}
+		item.ID = resource
+		item.Key = "lock:" + typ + ":" + resource + ":" + item.SessionID
+		item.Time = at.Time
+		item.Summary = fmt.Sprintf("%s %s (%s)", typ, resource, item.SessionID)
+		*items = append(*items, item)
+	}
+	return rows.Err()
+}
+
+func truncate(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max]) + "…"
+}
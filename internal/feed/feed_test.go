@@ -0,0 +1,86 @@
+package feed
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func setupTestDB(t *testing.T) *db.DB {
+	t.Helper()
+
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func TestList(t *testing.T) {
+	database := setupTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(ago time.Duration) string { return now.Add(-ago).Format("2006-01-02 15:04:05") }
+
+	stmts := []struct {
+		query string
+		args  []interface{}
+	}{
+		{`INSERT INTO sessions (id, status, project_root) VALUES ('s-a', 'running', '/proj/a'), ('s-b', 'running', '/proj/b')`, nil},
+		{`INSERT INTO session_events (session_id, event_type, event_data, created_at) VALUES ('s-a', 'tool_use', '{"tool":"Edit"}', ?)`, []interface{}{at(50 * time.Minute)}},
+		{`INSERT INTO session_events (session_id, event_type, event_data, created_at) VALUES ('s-a', 'lock_conflict', '{"resource":"api"}', ?)`, []interface{}{at(40 * time.Minute)}},
+		{`INSERT INTO session_events (session_id, event_type, created_at) VALUES ('s-b', 'old', ?)`, []interface{}{at(3 * time.Hour)}},
+		{`INSERT INTO messages (id, conversation_id, from_session, to_session, type, subtype, payload, created_at) VALUES ('m1', 'c', 's-b', 's-a', 'request', 'task_assign', '{}', ?)`, []interface{}{at(30 * time.Minute)}},
+		{`INSERT INTO escalations (from_session, from_port, issue, status, created_at, resolved_at) VALUES ('s-a', 'p1', '빌드 실패', 'resolved', ?, ?)`, []interface{}{at(20 * time.Minute), at(10 * time.Minute)}},
+		{`INSERT INTO locks (resource, session_id, acquired_at) VALUES ('api', 's-b', ?)`, []interface{}{at(5 * time.Minute)}},
+	}
+	for _, st := range stmts {
+		if _, err := database.Exec(st.query, st.args...); err != nil {
+			t.Fatalf("seed 실패: %v\n%s", err, st.query)
+		}
+	}
+
+	svc := NewService(database)
+
+	items, err := svc.List(Filter{Until: now})
+	if err != nil {
+		t.Fatalf("List 실패: %v", err)
+	}
+	want := []string{"lock:acquired", "escalation:resolved", "escalation:opened", "message:task_assign", "lock:conflict", "event:tool_use"}
+	if len(items) != len(want) {
+		t.Fatalf("항목 수 = %d, want %d: %+v", len(items), len(want), items)
+	}
+	for i, w := range want {
+		if got := string(items[i].Kind) + ":" + items[i].Type; got != w {
+			t.Errorf("items[%d] = %s, want %s", i, got, w)
+		}
+	}
+
+	items, err = svc.List(Filter{Until: now, Project: "/proj/a", Kinds: []Kind{KindLock, KindEscalation}})
+	if err != nil {
+		t.Fatalf("List 실패: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("프로젝트/종류 필터 항목 수 = %d, want 3: %+v", len(items), items)
+	}
+	for _, item := range items {
+		if item.SessionID != "s-a" {
+			t.Errorf("다른 프로젝트 항목 포함: %+v", item)
+		}
+	}
+
+	items, err = svc.List(Filter{Since: now.Add(-4 * time.Hour), Until: now, Limit: 2})
+	if err != nil {
+		t.Fatalf("List 실패: %v", err)
+	}
+	if len(items) != 2 || items[0].Kind != KindLock {
+		t.Errorf("Limit 적용 결과가 올바르지 않음: %+v", items)
+	}
+
+	if _, err := ParseKind("bogus"); err == nil {
+		t.Error("알 수 없는 종류는 에러여야 함")
+	}
+}
@@ -0,0 +1,222 @@
+// Package feedback records comments on the quality of injected context.
+// 브리핑, 규칙 파일, 주입 문서에 대한 사용자/에이전트 의견을 템플릿·문서별로 모아
+// 컨텍스트 구성과 관련 문서 순위를 조정하는 근거로 씁니다.
+package feedback
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
+)
+
+// Target is the kind of injected context the feedback is about
+type Target string
+
+const (
+	TargetBriefing Target = "briefing"
+	TargetRules    Target = "rules"
+	TargetDocs     Target = "docs"
+)
+
+// Targets lists the valid targets
+var Targets = []Target{TargetBriefing, TargetRules, TargetDocs}
+
+// Source is who left the feedback
+const (
+	SourceUser  = "user"
+	SourceAgent = "agent"
+)
+
+// Entry is a single feedback record
+type Entry struct {
+	ID          int64     `json:"id"`
+	Target      Target    `json:"target"`
+	Ref         string    `json:"ref"` // 템플릿/문서 식별자 (없으면 target 이름)
+	Comment     string    `json:"comment"`
+	Rating      int       `json:"rating"` // 1 유용, -1 불필요/부정확, 0 의견만
+	Source      string    `json:"source"`
+	SessionID   string    `json:"session_id,omitempty"`
+	ProjectRoot string    `json:"project_root,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Aggregate summarizes the feedback of one template/doc
+type Aggregate struct {
+	Target      Target    `json:"target"`
+	Ref         string    `json:"ref"`
+	Count       int       `json:"count"`
+	Up          int       `json:"up"`
+	Down        int       `json:"down"`
+	Score       float64   `json:"score"` // (up - down) / count, -1 ~ 1
+	LastComment string    `json:"last_comment"`
+	LastAt      time.Time `json:"last_at"`
+}
+
+// Filter holds list/aggregate options
+type Filter struct {
+	Target      Target
+	Ref         string
+	ProjectRoot string
+	Since       time.Time
+	Limit       int
+}
+
+// Service stores and aggregates context feedback
+type Service struct {
+	db *db.DB
+}
+
+// NewService creates a new feedback service
+func NewService(database *db.DB) *Service {
+	return &Service{db: database}
+}
+
+// ParseTarget validates a target name
+func ParseTarget(s string) (Target, error) {
+	for _, t := range Targets {
+		if string(t) == s {
+			return t, nil
+		}
+	}
+	return "", errcode.New(errcode.KindValidation, "알 수 없는 피드백 대상입니다: %s (briefing|rules|docs)", s)
+}
+
+// Record stores a feedback entry; Ref defaults to the target name
+func (s *Service) Record(e Entry) (*Entry, error) {
+	if _, err := ParseTarget(string(e.Target)); err != nil {
+		return nil, err
+	}
+	e.Comment = strings.TrimSpace(e.Comment)
+	if e.Comment == "" {
+		return nil, errcode.New(errcode.KindValidation, "피드백 내용이 비어 있습니다")
+	}
+	if e.Rating < -1 || e.Rating > 1 {
+		return nil, errcode.New(errcode.KindValidation, "평가는 -1, 0, 1 중 하나여야 합니다")
+	}
+	e.Ref = strings.TrimSpace(e.Ref)
+	if e.Ref == "" {
+		e.Ref = string(e.Target)
+	}
+	if e.Source == "" {
+		e.Source = SourceUser
+	}
+	e.CreatedAt = time.Now()
+
+	res, err := s.db.Exec(`
+		INSERT INTO context_feedback (target, ref, comment, rating, source, session_id, project_root, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, e.Target, e.Ref, e.Comment, e.Rating, e.Source, nullString(e.SessionID), nullString(e.ProjectRoot), e.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("피드백 기록 실패: %w", err)
+	}
+	e.ID, _ = res.LastInsertId()
+	return &e, nil
+}
+
+// List returns feedback entries, newest first
+func (s *Service) List(filter Filter) ([]Entry, error) {
+	where, args := filter.where()
+	query := `
+		SELECT id, target, ref, comment, rating, source, COALESCE(session_id, ''), COALESCE(project_root, ''), created_at
+		FROM context_feedback` + where + ` ORDER BY created_at DESC, id DESC`
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("피드백 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		var e Entry
+		if err := rows.Scan(&e.ID, &e.Target, &e.Ref, &e.Comment, &e.Rating, &e.Source, &e.SessionID, &e.ProjectRoot, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Aggregates groups feedback per target/ref, lowest score first (조정이 필요한 것부터)
+func (s *Service) Aggregates(filter Filter) ([]Aggregate, error) {
+	limit := filter.Limit
+	filter.Limit = 0
+	entries, err := s.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*Aggregate)
+	var order []string
+	for _, e := range entries {
+		key := string(e.Target) + "\x00" + e.Ref
+		agg, ok := byKey[key]
+		if !ok {
+			// entries는 최신순이므로 처음 본 항목이 마지막 의견
+			agg = &Aggregate{Target: e.Target, Ref: e.Ref, LastComment: e.Comment, LastAt: e.CreatedAt}
+			byKey[key] = agg
+			order = append(order, key)
+		}
+		agg.Count++
+		switch {
+		case e.Rating > 0:
+			agg.Up++
+		case e.Rating < 0:
+			agg.Down++
+		}
+	}
+
+	aggs := make([]Aggregate, 0, len(order))
+	for _, key := range order {
+		agg := byKey[key]
+		agg.Score = float64(agg.Up-agg.Down) / float64(agg.Count)
+		aggs = append(aggs, *agg)
+	}
+	sort.SliceStable(aggs, func(i, j int) bool {
+		if aggs[i].Score != aggs[j].Score {
+			return aggs[i].Score < aggs[j].Score
+		}
+		return aggs[i].Count > aggs[j].Count
+	})
+	if limit > 0 && len(aggs) > limit {
+		aggs = aggs[:limit]
+	}
+	return aggs, nil
+}
+
+func (f Filter) where() (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if f.Target != "" {
+		conds = append(conds, "target = ?")
+		args = append(args, f.Target)
+	}
+	if f.Ref != "" {
+		conds = append(conds, "ref = ?")
+		args = append(args, f.Ref)
+	}
+	if f.ProjectRoot != "" {
+		conds = append(conds, "project_root = ?")
+		args = append(args, f.ProjectRoot)
+	}
+	if !f.Since.IsZero() {
+		conds = append(conds, "created_at >= ?")
+		args = append(args, f.Since)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
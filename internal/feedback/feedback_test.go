@@ -0,0 +1,75 @@
+package feedback
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func setupTestDB(t *testing.T) (*db.DB, func()) {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "pal-test-*")
+	if err != nil {
+		t.Fatalf("임시 디렉토리 생성 실패: %v", err)
+	}
+
+	database, err := db.Open(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+
+	cleanup := func() {
+		database.Close()
+		os.RemoveAll(tmpDir)
+	}
+
+	return database, cleanup
+}
+
+func TestRecordAndAggregate(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+
+	if _, err := svc.Record(Entry{Target: "slides", Comment: "x"}); err == nil {
+		t.Error("알 수 없는 대상은 오류여야 함")
+	}
+	if _, err := svc.Record(Entry{Target: TargetBriefing, Comment: "  "}); err == nil {
+		t.Error("빈 내용은 오류여야 함")
+	}
+
+	e, err := svc.Record(Entry{Target: TargetBriefing, Comment: "최근 세션 목록은 불필요", Rating: -1})
+	if err != nil {
+		t.Fatalf("Record 실패: %v", err)
+	}
+	if e.Ref != "briefing" || e.Source != SourceUser || e.ID == 0 {
+		t.Errorf("기본값 미적용: %+v", e)
+	}
+	svc.Record(Entry{Target: TargetDocs, Ref: "docs/api.md", Comment: "정확함", Rating: 1, Source: SourceAgent})
+	svc.Record(Entry{Target: TargetDocs, Ref: "docs/api.md", Comment: "엔드포인트 목록이 오래됨", Rating: -1})
+	svc.Record(Entry{Target: TargetDocs, Ref: "docs/api.md", Comment: "도움됨", Rating: 1})
+
+	docs, err := svc.List(Filter{Target: TargetDocs})
+	if err != nil || len(docs) != 3 || docs[0].Comment != "도움됨" {
+		t.Fatalf("List = %+v, %v", docs, err)
+	}
+
+	aggs, err := svc.Aggregates(Filter{})
+	if err != nil {
+		t.Fatalf("Aggregates 실패: %v", err)
+	}
+	if len(aggs) != 2 {
+		t.Fatalf("집계 = %+v", aggs)
+	}
+	if aggs[0].Ref != "briefing" || aggs[0].Score != -1 {
+		t.Errorf("점수가 낮은 항목이 먼저여야 함: %+v", aggs[0])
+	}
+	if a := aggs[1]; a.Ref != "docs/api.md" || a.Count != 3 || a.Up != 2 || a.Down != 1 || a.LastComment != "도움됨" {
+		t.Errorf("문서 집계 = %+v", a)
+	}
+}
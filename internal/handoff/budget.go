@@ -0,0 +1,109 @@
+package handoff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultBudgets holds the default token budget per handoff type.
+// 미지정 타입은 MaxTokenBudget을 사용합니다.
+var DefaultBudgets = map[HandoffType]int{
+	TypeAPIContract: 1500,
+	TypeFileList:    1000,
+	TypeTypeDef:     2000,
+	TypeSchema:      3000,
+	TypeConfig:      1000,
+	TypeCustom:      MaxTokenBudget,
+}
+
+// BudgetFor returns the default token budget of a handoff type
+func BudgetFor(t HandoffType) int {
+	if budget, ok := DefaultBudgets[t]; ok {
+		return budget
+	}
+	return MaxTokenBudget
+}
+
+// BudgetError is returned when handoff content exceeds its token budget
+type BudgetError struct {
+	Type        HandoffType `json:"type,omitempty"`
+	Tokens      int         `json:"tokens"`
+	Budget      int         `json:"budget"`
+	Suggestions []string    `json:"suggestions,omitempty"`
+}
+
+func (e *BudgetError) Error() string {
+	msg := fmt.Sprintf("토큰 제한 초과: %d > %d", e.Tokens, e.Budget)
+	if e.Type != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Type)
+	}
+	if len(e.Suggestions) > 0 {
+		msg += "\n  - " + strings.Join(e.Suggestions, "\n  - ")
+	}
+	return msg
+}
+
+// Over returns how many tokens must be trimmed
+func (e *BudgetError) Over() int {
+	return e.Tokens - e.Budget
+}
+
+// newBudgetError builds a BudgetError with trimming suggestions for the content
+func newBudgetError(t HandoffType, content interface{}, tokens, budget int) *BudgetError {
+	return &BudgetError{
+		Type:        t,
+		Tokens:      tokens,
+		Budget:      budget,
+		Suggestions: TrimSuggestions(content, tokens-budget),
+	}
+}
+
+// TrimSuggestions suggests which top-level fields to trim to save `over` tokens.
+// 큰 필드부터 최대 3개까지 제안합니다.
+func TrimSuggestions(content interface{}, over int) []string {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return nil
+	}
+
+	suggestions := []string{fmt.Sprintf("약 %d 토큰을 줄여야 합니다", over)}
+
+	var fields map[string]interface{}
+	if json.Unmarshal(data, &fields) != nil {
+		return append(suggestions, "콘텐츠를 요약하거나 여러 Handoff로 나누세요")
+	}
+
+	type fieldSize struct {
+		name   string
+		tokens int
+		value  interface{}
+	}
+	var sizes []fieldSize
+	for name, value := range fields {
+		v, _ := json.Marshal(value)
+		sizes = append(sizes, fieldSize{name, len(v) / 4, value})
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		if sizes[i].tokens != sizes[j].tokens {
+			return sizes[i].tokens > sizes[j].tokens
+		}
+		return sizes[i].name < sizes[j].name
+	})
+
+	for i, f := range sizes {
+		if i >= 3 || f.tokens == 0 {
+			break
+		}
+		switch v := f.value.(type) {
+		case []interface{}:
+			suggestions = append(suggestions, fmt.Sprintf("'%s' (%d개 항목, 약 %d 토큰): 필수 항목만 남기세요", f.name, len(v), f.tokens))
+		case string:
+			suggestions = append(suggestions, fmt.Sprintf("'%s' (약 %d 토큰): 핵심만 요약하세요", f.name, f.tokens))
+		default:
+			suggestions = append(suggestions, fmt.Sprintf("'%s' (약 %d 토큰): 하위 필드를 줄이세요", f.name, f.tokens))
+		}
+	}
+	return suggestions
+}
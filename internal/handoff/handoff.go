@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
 )
 
 // MaxTokenBudget is the default maximum token budget for handoffs
@@ -105,7 +106,8 @@ type ColumnDef struct {
 
 // Store handles handoff persistence
 type Store struct {
-	db *db.DB
+	db      *db.DB
+	budgets map[HandoffType]int // 프로젝트별 예산 (DefaultBudgets 덮어쓰기)
 }
 
 // NewStore creates a new handoff store
@@ -113,8 +115,40 @@ func NewStore(database *db.DB) *Store {
 	return &Store{db: database}
 }
 
+// SetBudgets overrides token budgets per handoff type (예: 프로젝트 설정의 handoff_budgets)
+func (s *Store) SetBudgets(budgets map[string]int) {
+	s.budgets = make(map[HandoffType]int)
+	for t, budget := range budgets {
+		if budget > 0 {
+			s.budgets[HandoffType(t)] = budget
+		}
+	}
+}
+
+// BudgetFor returns the token budget of a handoff type for this store
+func (s *Store) BudgetFor(t HandoffType) int {
+	if budget, ok := s.budgets[t]; ok {
+		return budget
+	}
+	return BudgetFor(t)
+}
+
 // Create creates a new handoff
 func (s *Store) Create(fromPortID, toPortID string, handoffType HandoffType, content interface{}) (*Handoff, error) {
+	return s.create(s.db, fromPortID, toPortID, handoffType, content)
+}
+
+// CreateTx creates a new handoff within the caller's transaction
+func (s *Store) CreateTx(tx *sql.Tx, fromPortID, toPortID string, handoffType HandoffType, content interface{}) (*Handoff, error) {
+	return s.create(tx, fromPortID, toPortID, handoffType, content)
+}
+
+// execer is satisfied by both *db.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func (s *Store) create(ex execer, fromPortID, toPortID string, handoffType HandoffType, content interface{}) (*Handoff, error) {
 	// Serialize content
 	contentJSON, err := json.Marshal(content)
 	if err != nil {
@@ -124,18 +158,19 @@ func (s *Store) Create(fromPortID, toPortID string, handoffType HandoffType, con
 	// Estimate token count (rough: 4 chars = 1 token)
 	tokenCount := len(string(contentJSON)) / 4
 
-	// Check budget
-	if tokenCount > MaxTokenBudget {
-		return nil, fmt.Errorf("토큰 제한 초과: %d > %d (최대)", tokenCount, MaxTokenBudget)
+	// Check budget (타입별 예산)
+	budget := s.BudgetFor(handoffType)
+	if tokenCount > budget {
+		return nil, errcode.Wrap(errcode.KindValidation, newBudgetError(handoffType, content, tokenCount, budget))
 	}
 
 	id := uuid.New().String()
 	now := time.Now()
 
-	_, err = s.db.Exec(`
+	_, err = ex.Exec(`
 		INSERT INTO port_handoffs (id, from_port_id, to_port_id, handoff_type, content, token_count, max_token_budget, created_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-	`, id, fromPortID, toPortID, handoffType, string(contentJSON), tokenCount, MaxTokenBudget, now)
+	`, id, fromPortID, toPortID, handoffType, string(contentJSON), tokenCount, budget, now)
 
 	if err != nil {
 		return nil, fmt.Errorf("Handoff 생성 실패: %w", err)
@@ -148,7 +183,7 @@ func (s *Store) Create(fromPortID, toPortID string, handoffType HandoffType, con
 		Type:           handoffType,
 		Content:        content,
 		TokenCount:     tokenCount,
-		MaxTokenBudget: MaxTokenBudget,
+		MaxTokenBudget: budget,
 		CreatedAt:      now,
 	}, nil
 }
@@ -302,7 +337,7 @@ func Validate(content interface{}, maxBudget int) error {
 
 	tokenCount := len(string(contentJSON)) / 4
 	if tokenCount > maxBudget {
-		return fmt.Errorf("토큰 제한 초과: %d > %d", tokenCount, maxBudget)
+		return errcode.Wrap(errcode.KindValidation, newBudgetError("", content, tokenCount, maxBudget))
 	}
 
 	return nil
@@ -382,8 +417,8 @@ func (b *Builder) Build() (*APIContractContent, error) {
 		return nil, err
 	}
 
-	if tokens > MaxTokenBudget {
-		return nil, fmt.Errorf("토큰 제한 초과: %d > %d. 콘텐츠를 줄이세요", tokens, MaxTokenBudget)
+	if budget := BudgetFor(TypeAPIContract); tokens > budget {
+		return nil, errcode.Wrap(errcode.KindValidation, newBudgetError(TypeAPIContract, b.content, tokens, budget))
 	}
 
 	content := &APIContractContent{}
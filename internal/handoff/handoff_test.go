@@ -1,7 +1,9 @@
 package handoff
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/n0roo/pal-kit/internal/db"
@@ -233,6 +235,55 @@ func TestMaxTokenBudget(t *testing.T) {
 	}
 }
 
+func TestBudgetPerType(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	store := NewStore(database)
+	if got := store.BudgetFor(TypeAPIContract); got != 1500 {
+		t.Errorf("Expected api_contract budget 1500, got %d", got)
+	}
+	if got := store.BudgetFor(TypeSchema); got != 3000 {
+		t.Errorf("Expected schema budget 3000, got %d", got)
+	}
+	if got := store.BudgetFor("unknown"); got != MaxTokenBudget {
+		t.Errorf("Expected unknown type budget %d, got %d", MaxTokenBudget, got)
+	}
+
+	store.SetBudgets(map[string]int{"api_contract": 10, "schema": 0})
+	if got := store.BudgetFor(TypeAPIContract); got != 10 {
+		t.Errorf("Expected overridden budget 10, got %d", got)
+	}
+	if got := store.BudgetFor(TypeSchema); got != 3000 {
+		t.Errorf("Expected non-positive override to be ignored, got %d", got)
+	}
+
+	content := map[string]interface{}{
+		"entity": "User",
+		"fields": []string{"id", "name", "email", "created_at", "updated_at"},
+	}
+	_, err := store.Create("port-001", "port-002", TypeAPIContract, content)
+	var budgetErr *BudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("Expected BudgetError, got %v", err)
+	}
+	if budgetErr.Budget != 10 || budgetErr.Type != TypeAPIContract {
+		t.Errorf("Unexpected budget error: %+v", budgetErr)
+	}
+	if len(budgetErr.Suggestions) < 2 || !strings.Contains(budgetErr.Suggestions[1], "'fields'") {
+		t.Errorf("Expected largest field 'fields' to be suggested first, got %v", budgetErr.Suggestions)
+	}
+
+	// 같은 콘텐츠라도 다른 타입 예산 안에서는 생성됨
+	h, err := store.Create("port-001", "port-002", TypeSchema, content)
+	if err != nil {
+		t.Fatalf("Failed to create handoff: %v", err)
+	}
+	if h.MaxTokenBudget != 3000 {
+		t.Errorf("Expected stored budget 3000, got %d", h.MaxTokenBudget)
+	}
+}
+
 func TestTotalTokensForPort(t *testing.T) {
 	database, cleanup := setupTestDB(t)
 	defer cleanup()
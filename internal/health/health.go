@@ -0,0 +1,425 @@
+// Package health computes a composite project health score from several signals.
+// 오래된 문서 비율, 에스컬레이션 적체, 추적 안 된 수정 비율, 재작업률, 예산 소진율을
+// 0~100 점수로 환산해 가중 평균하고, 요인별로 원인 항목을 함께 돌려줍니다.
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/docs"
+)
+
+// Factor names
+const (
+	FactorStaleDocs      = "stale_docs"
+	FactorEscalations    = "escalation_backlog"
+	FactorUntrackedEdits = "untracked_edits"
+	FactorRework         = "rework"
+	FactorBudgetBurn     = "budget_burn"
+)
+
+// Grades
+const (
+	GradeHealthy  = "healthy"
+	GradeWarning  = "warning"
+	GradeCritical = "critical"
+)
+
+// DefaultWindow is the lookback period of event-based signals
+const DefaultWindow = 14 * 24 * time.Hour
+
+// maxItems limits the drill-down items per factor
+const maxItems = 10
+
+// weights of each factor in the composite score (적용 불가 요인은 제외 후 재정규화)
+var weights = map[string]float64{
+	FactorStaleDocs:      0.20,
+	FactorEscalations:    0.25,
+	FactorUntrackedEdits: 0.20,
+	FactorRework:         0.20,
+	FactorBudgetBurn:     0.15,
+}
+
+// Factor is one contributing signal
+type Factor struct {
+	Name       string   `json:"name"`
+	Label      string   `json:"label"`
+	Score      int      `json:"score"`      // 0~100 (높을수록 건강)
+	Weight     float64  `json:"weight"`     // 적용된 가중치 (재정규화 후)
+	Value      float64  `json:"value"`      // 원시 지표 (비율 또는 건수)
+	Detail     string   `json:"detail"`     // 사람이 읽는 요약
+	Items      []string `json:"items"`      // 원인 항목 (드릴다운, 최대 10개)
+	Applicable bool     `json:"applicable"` // 데이터/설정이 없어 계산하지 않았으면 false
+}
+
+// Report is the composite health of a project
+type Report struct {
+	ProjectRoot string    `json:"project_root"`
+	Score       int       `json:"score"`
+	Grade       string    `json:"grade"`
+	Window      string    `json:"window"`
+	Factors     []Factor  `json:"factors"`
+	ComputedAt  time.Time `json:"computed_at"`
+}
+
+// Service computes project health
+type Service struct {
+	db *db.DB
+}
+
+// NewService creates a new health service
+func NewService(database *db.DB) *Service {
+	return &Service{db: database}
+}
+
+// Compute builds the health report of a project over the given window (0이면 DefaultWindow)
+func (s *Service) Compute(projectRoot string, window time.Duration, now time.Time) (*Report, error) {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	since := now.Add(-window)
+
+	factors := []Factor{staleDocsFactor(projectRoot)}
+	collectors := []func(string, time.Time, time.Time) (Factor, error){
+		s.escalationFactor, s.untrackedFactor, s.reworkFactor, s.budgetFactor,
+	}
+	for _, collect := range collectors {
+		f, err := collect(projectRoot, since, now)
+		if err != nil {
+			return nil, err
+		}
+		factors = append(factors, f)
+	}
+
+	report := &Report{
+		ProjectRoot: projectRoot,
+		Window:      fmt.Sprintf("%dd", int(window.Hours()/24)),
+		Factors:     factors,
+		ComputedAt:  now,
+	}
+	report.Score = combine(report.Factors)
+	report.Grade = GradeOf(report.Score)
+	return report, nil
+}
+
+// combine computes the weighted average of applicable factors and stores the effective weights
+func combine(factors []Factor) int {
+	total := 0.0
+	for _, f := range factors {
+		if f.Applicable {
+			total += weights[f.Name]
+		}
+	}
+	if total == 0 {
+		return 100
+	}
+	score := 0.0
+	for i := range factors {
+		if !factors[i].Applicable {
+			factors[i].Weight = 0
+			continue
+		}
+		factors[i].Weight = math.Round(weights[factors[i].Name]/total*100) / 100
+		score += float64(factors[i].Score) * weights[factors[i].Name] / total
+	}
+	return int(math.Round(score))
+}
+
+// GradeOf maps a score to a grade (80 이상 healthy, 50 이상 warning)
+func GradeOf(score int) string {
+	switch {
+	case score >= 80:
+		return GradeHealthy
+	case score >= 50:
+		return GradeWarning
+	}
+	return GradeCritical
+}
+
+// Weakest returns applicable factors below 80, lowest score first
+func (r *Report) Weakest() []Factor {
+	var weak []Factor
+	for _, f := range r.Factors {
+		if f.Applicable && f.Score < 80 {
+			weak = append(weak, f)
+		}
+	}
+	sort.SliceStable(weak, func(i, j int) bool { return weak[i].Score < weak[j].Score })
+	return weak
+}
+
+// staleDocsFactor: outdated/invalid 상태 관리 문서 비율
+func staleDocsFactor(projectRoot string) Factor {
+	f := Factor{Name: FactorStaleDocs, Label: "오래된 문서", Items: []string{}}
+	list, err := docs.NewService(projectRoot).List()
+	if err != nil || len(list) == 0 {
+		f.Score, f.Detail = 100, "관리 문서 없음"
+		return f
+	}
+
+	stale := 0
+	for _, d := range list {
+		if d.Status == docs.StatusOutdated || d.Status == docs.StatusInvalid {
+			stale++
+			f.Items = appendItem(f.Items, fmt.Sprintf("%s (%s)", d.RelativePath, d.Status))
+		}
+	}
+	f.Applicable = true
+	f.Value = ratio(stale, len(list))
+	f.Score = ratioScore(f.Value)
+	f.Detail = fmt.Sprintf("%d/%d개 문서가 오래되었거나 검증 실패", stale, len(list))
+	return f
+}
+
+// escalationFactor: 미해결 에스컬레이션 수 (건당 -15, 3일 넘은 건은 추가 -10)
+func (s *Service) escalationFactor(projectRoot string, since, now time.Time) (Factor, error) {
+	f := Factor{Name: FactorEscalations, Label: "에스컬레이션 적체", Items: []string{}, Applicable: true}
+
+	rows, err := s.db.Query(`
+		SELECT id, issue, created_at FROM escalations
+		WHERE status = 'open' AND (
+			from_session IN (SELECT id FROM sessions WHERE project_root = ?)
+			OR from_port IN (SELECT port_id FROM sessions WHERE project_root = ? AND port_id IS NOT NULL)
+		)
+		ORDER BY created_at
+	`, projectRoot, projectRoot)
+	if err != nil {
+		return f, fmt.Errorf("에스컬레이션 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	open, aged := 0, 0
+	for rows.Next() {
+		var id int64
+		var issue string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &issue, &createdAt); err != nil {
+			return f, err
+		}
+		open++
+		age := now.Sub(createdAt)
+		if age > 3*24*time.Hour {
+			aged++
+		}
+		f.Items = appendItem(f.Items, fmt.Sprintf("#%d %s (%d일)", id, issue, int(age.Hours()/24)))
+	}
+	if err := rows.Err(); err != nil {
+		return f, err
+	}
+
+	f.Value = float64(open)
+	f.Score = clamp(100 - 15*open - 10*aged)
+	f.Detail = fmt.Sprintf("미해결 %d건 (3일 초과 %d건)", open, aged)
+	return f, nil
+}
+
+// untrackedFactor: 기간 내 파일 수정 중 활성 포트 없이 이루어진 비율
+func (s *Service) untrackedFactor(projectRoot string, since, now time.Time) (Factor, error) {
+	f := Factor{Name: FactorUntrackedEdits, Label: "추적 안 된 수정", Items: []string{}}
+
+	rows, err := s.db.Query(`
+		SELECT event_type, COALESCE(event_data, '') FROM session_events
+		WHERE event_type IN ('file_edit', 'untracked_edit') AND created_at >= ?
+			AND session_id IN (SELECT id FROM sessions WHERE project_root = ?)
+	`, sqlTime(since), projectRoot)
+	if err != nil {
+		return f, fmt.Errorf("수정 이벤트 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	tracked, untracked := 0, 0
+	files := make(map[string]int)
+	for rows.Next() {
+		var eventType, data string
+		if err := rows.Scan(&eventType, &data); err != nil {
+			return f, err
+		}
+		if eventType == "file_edit" {
+			tracked++
+			continue
+		}
+		untracked++
+		var ev struct {
+			File string `json:"file"`
+		}
+		if json.Unmarshal([]byte(data), &ev) == nil && ev.File != "" {
+			files[ev.File]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return f, err
+	}
+
+	if tracked+untracked == 0 {
+		f.Score, f.Detail = 100, "기간 내 파일 수정 없음"
+		return f, nil
+	}
+	f.Applicable = true
+	f.Value = ratio(untracked, tracked+untracked)
+	f.Score = ratioScore(f.Value)
+	f.Detail = fmt.Sprintf("수정 %d건 중 %d건이 포트 없이 이루어짐", tracked+untracked, untracked)
+	f.Items = topCounts(files, "회")
+	return f, nil
+}
+
+// reworkFactor: 기간 내 시작된 포트 중 완료 후 다시 시작된 비율
+func (s *Service) reworkFactor(projectRoot string, since, now time.Time) (Factor, error) {
+	f := Factor{Name: FactorRework, Label: "재작업", Items: []string{}}
+
+	rows, err := s.db.Query(`
+		SELECT event_type, COALESCE(event_data, ''), created_at FROM session_events
+		WHERE event_type IN ('port_start', 'port_end')
+			AND session_id IN (SELECT id FROM sessions WHERE project_root = ?)
+		ORDER BY id
+	`, projectRoot)
+	if err != nil {
+		return f, fmt.Errorf("포트 이벤트 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	ended := make(map[string]bool)
+	started := make(map[string]bool) // 기간 내 시작된 포트
+	reworked := make(map[string]int) // 기간 내 완료 후 재시작 횟수
+	for rows.Next() {
+		var eventType, data string
+		var createdAt time.Time
+		if err := rows.Scan(&eventType, &data, &createdAt); err != nil {
+			return f, err
+		}
+		var ev struct {
+			PortID string `json:"port_id"`
+		}
+		if json.Unmarshal([]byte(data), &ev) != nil || ev.PortID == "" {
+			continue
+		}
+		if eventType == "port_end" {
+			ended[ev.PortID] = true
+			continue
+		}
+		if createdAt.Before(since) {
+			continue
+		}
+		started[ev.PortID] = true
+		if ended[ev.PortID] {
+			reworked[ev.PortID]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return f, err
+	}
+
+	if len(started) == 0 {
+		f.Score, f.Detail = 100, "기간 내 시작된 포트 없음"
+		return f, nil
+	}
+	f.Applicable = true
+	f.Value = ratio(len(reworked), len(started))
+	// 재작업은 20%만 되어도 심각하므로 비율의 2배로 감점
+	f.Score = clamp(int(math.Round(100 - f.Value*200)))
+	f.Detail = fmt.Sprintf("시작된 포트 %d개 중 %d개가 완료 후 다시 시작됨", len(started), len(reworked))
+	f.Items = topCounts(reworked, "회 재시작")
+	return f, nil
+}
+
+// budgetFactor: 일일 예산(settings.budget) 대비 기간 내 사용량
+func (s *Service) budgetFactor(projectRoot string, since, now time.Time) (Factor, error) {
+	f := Factor{Name: FactorBudgetBurn, Label: "예산 소진", Items: []string{}}
+
+	var budget config.BudgetSettings
+	if cfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+		budget = cfg.Settings.Budget
+	}
+	if budget.DailyUSD <= 0 && budget.DailyTokens <= 0 {
+		f.Score, f.Detail = 100, "일일 예산 미설정 (settings.budget)"
+		return f, nil
+	}
+
+	var cost float64
+	var tokens int64
+	err := s.db.QueryRow(`
+		SELECT COALESCE(SUM(cost_usd), 0), COALESCE(SUM(input_tokens + output_tokens), 0)
+		FROM sessions WHERE project_root = ? AND started_at >= ?
+	`, projectRoot, sqlTime(since)).Scan(&cost, &tokens)
+	if err != nil {
+		return f, fmt.Errorf("사용량 조회 실패: %w", err)
+	}
+
+	days := now.Sub(since).Hours() / 24
+	f.Applicable = true
+	if budget.DailyUSD > 0 {
+		plan := budget.DailyUSD * days
+		f.Value = cost / plan
+		f.Items = append(f.Items, fmt.Sprintf("비용 $%.2f / 계획 $%.2f", cost, plan))
+	}
+	if budget.DailyTokens > 0 {
+		plan := float64(budget.DailyTokens) * days
+		burn := float64(tokens) / plan
+		if burn > f.Value {
+			f.Value = burn
+		}
+		f.Items = append(f.Items, fmt.Sprintf("토큰 %d / 계획 %.0f", tokens, plan))
+	}
+	f.Value = math.Round(f.Value*100) / 100
+	// 계획 이내면 100점, 초과분만큼 감점 (2배면 0점)
+	f.Score = clamp(int(math.Round(100 - math.Max(0, f.Value-1)*100)))
+	f.Detail = fmt.Sprintf("계획 대비 %.0f%% 사용", f.Value*100)
+	return f, nil
+}
+
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)/float64(total)*100) / 100
+}
+
+func ratioScore(r float64) int {
+	return clamp(int(math.Round(100 * (1 - r))))
+}
+
+func clamp(score int) int {
+	if score < 0 {
+		return 0
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
+
+func appendItem(items []string, item string) []string {
+	if len(items) >= maxItems {
+		return items
+	}
+	return append(items, item)
+}
+
+// topCounts renders the most frequent keys first
+func topCounts(counts map[string]int, unit string) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	items := []string{}
+	for _, k := range keys {
+		items = appendItem(items, fmt.Sprintf("%s (%d%s)", k, counts[k], unit))
+	}
+	return items
+}
+
+// sqlTime formats a time like CURRENT_TIMESTAMP (UTC) for comparisons with stored timestamps
+func sqlTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
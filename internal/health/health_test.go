@@ -0,0 +1,90 @@
+package health
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func TestCompute(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	defer database.Close()
+
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, ".pal"), 0755)
+	os.WriteFile(filepath.Join(root, ".pal", "config.yaml"), []byte("settings:\n  budget:\n    daily_usd: 1\n"), 0644)
+
+	for _, q := range []string{
+		`INSERT INTO sessions (id, project_root, cost_usd) VALUES ('s1', '` + root + `', 21)`,
+		`INSERT INTO sessions (id, project_root) VALUES ('other', '/elsewhere')`,
+		`INSERT INTO session_events (session_id, event_type, event_data) VALUES
+			('s1', 'port_start', '{"port_id":"p1"}'),
+			('s1', 'port_end', '{"port_id":"p1"}'),
+			('s1', 'port_start', '{"port_id":"p1"}'),
+			('s1', 'port_start', '{"port_id":"p2"}'),
+			('s1', 'file_edit', '{"file":"a.go"}'),
+			('s1', 'untracked_edit', '{"file":"b.go"}'),
+			('other', 'untracked_edit', '{"file":"x.go"}')`,
+		`INSERT INTO escalations (from_session, issue, created_at) VALUES ('s1', '빌드 실패', datetime('now', '-5 days'))`,
+		`INSERT INTO escalations (from_session, issue) VALUES ('other', '다른 프로젝트')`,
+	} {
+		if _, err := database.Exec(q); err != nil {
+			t.Fatalf("데이터 준비 실패: %v", err)
+		}
+	}
+
+	report, err := NewService(database).Compute(root, 0, time.Now())
+	if err != nil {
+		t.Fatalf("Compute 실패: %v", err)
+	}
+
+	byName := make(map[string]Factor)
+	for _, f := range report.Factors {
+		byName[f.Name] = f
+	}
+	if f := byName[FactorStaleDocs]; f.Applicable {
+		t.Errorf("문서가 없으면 적용되지 않아야 함: %+v", f)
+	}
+	if f := byName[FactorEscalations]; f.Value != 1 || f.Score != 75 || len(f.Items) != 1 {
+		t.Errorf("에스컬레이션 = %+v", f)
+	}
+	if f := byName[FactorUntrackedEdits]; f.Value != 0.5 || f.Score != 50 || f.Items[0] != "b.go (1회)" {
+		t.Errorf("추적 안 된 수정 = %+v", f)
+	}
+	if f := byName[FactorRework]; f.Value != 0.5 || f.Score != 0 {
+		t.Errorf("재작업 = %+v", f)
+	}
+	if f := byName[FactorBudgetBurn]; !f.Applicable || f.Value != 1.5 || f.Score != 50 {
+		t.Errorf("예산 소진 = %+v", f)
+	}
+
+	// (75*0.25 + 50*0.2 + 0*0.2 + 50*0.15) / 0.8 = 45.3
+	if report.Score != 45 || report.Grade != GradeCritical {
+		t.Errorf("종합 점수 = %d (%s)", report.Score, report.Grade)
+	}
+	if weak := report.Weakest(); len(weak) != 4 || weak[0].Name != FactorRework {
+		t.Errorf("Weakest = %+v", weak)
+	}
+}
+
+func TestComputeEmptyProject(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	defer database.Close()
+
+	report, err := NewService(database).Compute(t.TempDir(), 0, time.Now())
+	if err != nil {
+		t.Fatalf("Compute 실패: %v", err)
+	}
+	if report.Score != 100 || report.Grade != GradeHealthy {
+		t.Errorf("빈 프로젝트 = %d (%s)", report.Score, report.Grade)
+	}
+}
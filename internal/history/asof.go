@@ -0,0 +1,295 @@
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Port state sources
+const (
+	SourceEvents     = "events"     // port_start/port_end 이벤트로 재구성
+	SourceTimestamps = "timestamps" // 이벤트가 없어 ports.started_at/completed_at 사용
+)
+
+// PortAsOf is a port that was running at the snapshot time
+type PortAsOf struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title,omitempty"`
+	SessionID string    `json:"session_id,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	Source    string    `json:"source"`
+}
+
+// SessionAsOf is a session that was active at the snapshot time
+type SessionAsOf struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title,omitempty"`
+	Type        string    `json:"type,omitempty"`
+	ProjectRoot string    `json:"project_root,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	CostUSD     float64   `json:"cost_usd"` // 스냅샷 시점까지의 추정 비용
+}
+
+// CostAsOf is the cumulative cost up to the snapshot time
+type CostAsOf struct {
+	SettledUSD    float64 `json:"settled_usd"`   // 시점 이전에 종료된 세션 비용 (확정)
+	EstimatedUSD  float64 `json:"estimated_usd"` // 시점에 진행 중이던 세션의 경과 시간 비례 추정
+	TotalUSD      float64 `json:"total_usd"`
+	SettledTokens int64   `json:"settled_tokens"`
+}
+
+// Snapshot is the project state reconstructed at a point in time
+type Snapshot struct {
+	AsOf            time.Time     `json:"as_of"`
+	ProjectRoot     string        `json:"project_root,omitempty"`
+	RunningPorts    []PortAsOf    `json:"running_ports"`
+	CompletedPorts  int           `json:"completed_ports"` // 시점까지 완료된 포트 수
+	ActiveSessions  []SessionAsOf `json:"active_sessions"`
+	SessionsStarted int           `json:"sessions_started"` // 시점까지 시작된 세션 수
+	Cost            CostAsOf      `json:"cost"`
+	Events          int           `json:"events"` // 시점까지 기록된 이벤트 수
+}
+
+// ParseAsOf parses a snapshot time.
+// 날짜만 주면(2025-06-01) 그날이 끝나는 시점(로컬 23:59:59)의 상태를 조회합니다.
+func ParseAsOf(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("잘못된 시점입니다: %s (예: 2025-06-01, 2025-06-01 18:00, RFC3339)", value)
+}
+
+// StateAsOf reconstructs which ports were running, which sessions were active and the
+// cumulative cost at a point in time. projectRoot가 비어 있으면 전체 프로젝트를 대상으로 합니다.
+func (s *Service) StateAsOf(at time.Time, projectRoot string) (*Snapshot, error) {
+	snap := &Snapshot{
+		AsOf:           at,
+		ProjectRoot:    projectRoot,
+		RunningPorts:   []PortAsOf{},
+		ActiveSessions: []SessionAsOf{},
+	}
+
+	sessionIDs, err := s.sessionsAsOf(snap, at, projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.portsAsOf(snap, at, projectRoot, sessionIDs); err != nil {
+		return nil, err
+	}
+
+	snap.Cost.TotalUSD = snap.Cost.SettledUSD + snap.Cost.EstimatedUSD
+	return snap, nil
+}
+
+// sessionsAsOf fills sessions and costs, and returns the IDs of the sessions in scope
+func (s *Service) sessionsAsOf(snap *Snapshot, at time.Time, projectRoot string) (map[string]bool, error) {
+	query := `
+		SELECT id, COALESCE(title, ''), COALESCE(session_type, ''), COALESCE(project_root, ''),
+		       started_at, ended_at, COALESCE(cost_usd, 0),
+		       COALESCE(input_tokens, 0) + COALESCE(output_tokens, 0)
+		FROM sessions
+	`
+	var args []interface{}
+	if projectRoot != "" {
+		query += ` WHERE project_root = ?`
+		args = append(args, projectRoot)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("세션 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	ids := make(map[string]bool)
+	for rows.Next() {
+		var sess SessionAsOf
+		var endedAt sql.NullTime
+		var cost float64
+		var tokens int64
+		if err := rows.Scan(&sess.ID, &sess.Title, &sess.Type, &sess.ProjectRoot,
+			&sess.StartedAt, &endedAt, &cost, &tokens); err != nil {
+			return nil, fmt.Errorf("세션 조회 실패: %w", err)
+		}
+		ids[sess.ID] = true
+		if sess.StartedAt.After(at) {
+			continue
+		}
+		snap.SessionsStarted++
+
+		if endedAt.Valid && !endedAt.Time.After(at) {
+			snap.Cost.SettledUSD += cost
+			snap.Cost.SettledTokens += tokens
+			continue
+		}
+
+		// 진행 중이던 세션: 전체 비용을 경과 시간 비율로 나눔
+		end := now
+		if endedAt.Valid {
+			end = endedAt.Time
+		}
+		if span := end.Sub(sess.StartedAt); span > 0 {
+			sess.CostUSD = cost * float64(at.Sub(sess.StartedAt)) / float64(span)
+		}
+		snap.Cost.EstimatedUSD += sess.CostUSD
+		snap.ActiveSessions = append(snap.ActiveSessions, sess)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(snap.ActiveSessions, func(i, j int) bool {
+		return snap.ActiveSessions[i].StartedAt.Before(snap.ActiveSessions[j].StartedAt)
+	})
+	return ids, nil
+}
+
+// portsAsOf replays port_start/port_end events up to `at`, falling back to port timestamps
+func (s *Service) portsAsOf(snap *Snapshot, at time.Time, projectRoot string, sessionIDs map[string]bool) error {
+	// session_events.created_at은 CURRENT_TIMESTAMP(UTC) 형식으로 저장됨
+	countQuery := `SELECT COUNT(*) FROM session_events e WHERE e.created_at <= ?`
+	countArgs := []interface{}{at.UTC().Format("2006-01-02 15:04:05")}
+	if projectRoot != "" {
+		countQuery += ` AND e.session_id IN (SELECT id FROM sessions WHERE project_root = ?)`
+		countArgs = append(countArgs, projectRoot)
+	}
+	if err := s.db.QueryRow(countQuery, countArgs...).Scan(&snap.Events); err != nil {
+		return fmt.Errorf("이벤트 조회 실패: %w", err)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT session_id, event_type, COALESCE(event_data, ''), created_at
+		FROM session_events
+		WHERE event_type IN ('port_start', 'port_end')
+		ORDER BY id
+	`)
+	if err != nil {
+		return fmt.Errorf("이벤트 조회 실패: %w", err)
+	}
+
+	type portEvent struct {
+		sessionID string
+		start     bool
+		at        time.Time
+	}
+	last := make(map[string]portEvent) // 포트별 시점 이전 마지막 이벤트
+	seen := make(map[string]bool)      // 이벤트가 있는 포트 (시점 이후 포함)
+	completed := make(map[string]bool)
+	for rows.Next() {
+		var sessionID, eventType, data string
+		var createdAt time.Time
+		if err := rows.Scan(&sessionID, &eventType, &data, &createdAt); err != nil {
+			rows.Close()
+			return fmt.Errorf("이벤트 조회 실패: %w", err)
+		}
+		if projectRoot != "" && !sessionIDs[sessionID] {
+			continue
+		}
+		id := eventPortID(data)
+		if id == "" {
+			continue
+		}
+		seen[id] = true
+		if createdAt.After(at) {
+			continue
+		}
+		last[id] = portEvent{sessionID: sessionID, start: eventType == "port_start", at: createdAt}
+		if eventType == "port_end" {
+			completed[id] = true
+		}
+	}
+	rows.Close()
+
+	titles, fallback, err := s.portTimestamps(at, projectRoot, sessionIDs)
+	if err != nil {
+		return err
+	}
+
+	for id, e := range last {
+		if e.start {
+			snap.RunningPorts = append(snap.RunningPorts, PortAsOf{
+				ID: id, Title: titles[id], SessionID: e.sessionID, StartedAt: e.at, Source: SourceEvents,
+			})
+		}
+	}
+	for _, p := range fallback {
+		if seen[p.port.ID] {
+			continue
+		}
+		if p.completed {
+			completed[p.port.ID] = true
+		} else {
+			snap.RunningPorts = append(snap.RunningPorts, p.port)
+		}
+	}
+	snap.CompletedPorts = len(completed)
+
+	sort.Slice(snap.RunningPorts, func(i, j int) bool {
+		return snap.RunningPorts[i].StartedAt.Before(snap.RunningPorts[j].StartedAt)
+	})
+	return nil
+}
+
+type portTimestamp struct {
+	port      PortAsOf
+	completed bool
+}
+
+// portTimestamps returns port titles and the started ports reconstructed from ports.started_at/completed_at
+func (s *Service) portTimestamps(at time.Time, projectRoot string, sessionIDs map[string]bool) (map[string]string, []portTimestamp, error) {
+	rows, err := s.db.Query(`
+		SELECT id, COALESCE(title, ''), COALESCE(session_id, ''), started_at, completed_at
+		FROM ports
+	`)
+	if err != nil {
+		return nil, nil, fmt.Errorf("포트 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	titles := make(map[string]string)
+	var result []portTimestamp
+	for rows.Next() {
+		var p PortAsOf
+		var startedAt, completedAt sql.NullTime
+		if err := rows.Scan(&p.ID, &p.Title, &p.SessionID, &startedAt, &completedAt); err != nil {
+			return nil, nil, fmt.Errorf("포트 조회 실패: %w", err)
+		}
+		titles[p.ID] = p.Title
+		if !startedAt.Valid || startedAt.Time.After(at) {
+			continue
+		}
+		if projectRoot != "" && !sessionIDs[p.SessionID] {
+			continue
+		}
+		p.StartedAt = startedAt.Time
+		p.Source = SourceTimestamps
+		result = append(result, portTimestamp{
+			port:      p,
+			completed: completedAt.Valid && !completedAt.Time.After(at),
+		})
+	}
+	return titles, result, rows.Err()
+}
+
+func eventPortID(data string) string {
+	var payload struct {
+		PortID string `json:"port_id"`
+	}
+	if json.Unmarshal([]byte(data), &payload) != nil {
+		return ""
+	}
+	return payload.PortID
+}
@@ -0,0 +1,127 @@
+package history
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func setupTestDB(t *testing.T) (*db.DB, func()) {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "pal-test-*")
+	if err != nil {
+		t.Fatalf("임시 디렉토리 생성 실패: %v", err)
+	}
+
+	database, err := db.Open(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+
+	if err := database.Init(); err != nil {
+		database.Close()
+		os.RemoveAll(tmpDir)
+		t.Fatalf("DB 초기화 실패: %v", err)
+	}
+
+	return database, func() {
+		database.Close()
+		os.RemoveAll(tmpDir)
+	}
+}
+
+func TestStateAsOf(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	stmts := []string{
+		`INSERT INTO sessions (id, title, project_root, started_at, ended_at, cost_usd)
+		 VALUES ('s1', 'done', '/p', '2025-06-01 08:00:00', '2025-06-01 09:00:00', 2.0)`,
+		`INSERT INTO sessions (id, title, project_root, started_at, ended_at, cost_usd)
+		 VALUES ('s2', 'active', '/p', '2025-06-01 10:00:00', '2025-06-01 14:00:00', 4.0)`,
+		`INSERT INTO sessions (id, title, project_root, started_at, cost_usd)
+		 VALUES ('s3', 'other', '/other', '2025-06-01 10:00:00', 1.0)`,
+		`INSERT INTO session_events (session_id, event_type, event_data, created_at)
+		 VALUES ('s1', 'port_start', '{"port_id":"p-done"}', '2025-06-01 08:10:00')`,
+		`INSERT INTO session_events (session_id, event_type, event_data, created_at)
+		 VALUES ('s1', 'port_end', '{"port_id":"p-done"}', '2025-06-01 08:50:00')`,
+		`INSERT INTO session_events (session_id, event_type, event_data, created_at)
+		 VALUES ('s2', 'port_start', '{"port_id":"p-run"}', '2025-06-01 10:30:00')`,
+		`INSERT INTO session_events (session_id, event_type, event_data, created_at)
+		 VALUES ('s2', 'port_end', '{"port_id":"p-run"}', '2025-06-01 13:00:00')`,
+		`INSERT INTO ports (id, title, status, session_id, started_at)
+		 VALUES ('p-legacy', 'legacy', 'running', 's2', '2025-06-01 11:00:00')`,
+	}
+	for _, stmt := range stmts {
+		if _, err := database.Exec(stmt); err != nil {
+			t.Fatalf("테스트 데이터 생성 실패: %v", err)
+		}
+	}
+
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	snap, err := NewService(database).StateAsOf(at, "/p")
+	if err != nil {
+		t.Fatalf("StateAsOf 실패: %v", err)
+	}
+
+	if snap.SessionsStarted != 2 {
+		t.Errorf("SessionsStarted = %d, want 2", snap.SessionsStarted)
+	}
+	if len(snap.ActiveSessions) != 1 || snap.ActiveSessions[0].ID != "s2" {
+		t.Fatalf("ActiveSessions = %+v, want [s2]", snap.ActiveSessions)
+	}
+	// s2는 4시간 중 2시간 경과 → $2 추정
+	if math.Abs(snap.Cost.EstimatedUSD-2.0) > 1e-9 || math.Abs(snap.Cost.SettledUSD-2.0) > 1e-9 {
+		t.Errorf("Cost = %+v, want settled 2, estimated 2", snap.Cost)
+	}
+	if math.Abs(snap.Cost.TotalUSD-4.0) > 1e-9 {
+		t.Errorf("TotalUSD = %v, want 4", snap.Cost.TotalUSD)
+	}
+
+	running := make(map[string]string)
+	for _, p := range snap.RunningPorts {
+		running[p.ID] = p.Source
+	}
+	if len(running) != 2 || running["p-run"] != SourceEvents || running["p-legacy"] != SourceTimestamps {
+		t.Errorf("RunningPorts = %+v, want p-run(events), p-legacy(timestamps)", snap.RunningPorts)
+	}
+	if snap.CompletedPorts != 1 {
+		t.Errorf("CompletedPorts = %d, want 1", snap.CompletedPorts)
+	}
+	if snap.Events != 3 {
+		t.Errorf("Events = %d, want 3", snap.Events)
+	}
+
+	// 전체 프로젝트 대상이면 다른 프로젝트 세션도 포함
+	all, err := NewService(database).StateAsOf(at, "")
+	if err != nil {
+		t.Fatalf("StateAsOf 실패: %v", err)
+	}
+	if len(all.ActiveSessions) != 2 {
+		t.Errorf("ActiveSessions (all) = %d, want 2", len(all.ActiveSessions))
+	}
+}
+
+func TestParseAsOf(t *testing.T) {
+	got, err := ParseAsOf("2025-06-01")
+	if err != nil {
+		t.Fatalf("ParseAsOf 실패: %v", err)
+	}
+	want := time.Date(2025, 6, 1, 23, 59, 59, 0, time.Local)
+	if !got.Equal(want) {
+		t.Errorf("ParseAsOf(date) = %v, want %v", got, want)
+	}
+
+	if _, err := ParseAsOf("2025-06-01T09:00:00+09:00"); err != nil {
+		t.Errorf("RFC3339 파싱 실패: %v", err)
+	}
+	if _, err := ParseAsOf("yesterday"); err == nil {
+		t.Error("잘못된 값에 에러가 없음")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/project"
 )
 
 // Event represents a history event
@@ -201,7 +202,7 @@ func (s *Service) GetProjects() ([]string, error) {
 	rows, err := s.db.Query(`
 		SELECT DISTINCT project_name
 		FROM sessions
-		WHERE project_name IS NOT NULL AND project_name != ''
+		WHERE project_name IS NOT NULL AND project_name != '' AND `+project.NotArchived+`
 		ORDER BY project_name
 	`)
 	if err != nil {
@@ -0,0 +1,186 @@
+package i18n
+
+// Message keys
+const (
+	// 포트 rules 파일 (.claude/rules/<port>.md)
+	KeyRuleInstructions    = "rule.instructions"
+	KeyRuleCommands        = "rule.commands"
+	KeyRulePalCommands     = "rule.pal_commands"
+	KeyRuleCmdStatus       = "rule.cmd_status"
+	KeyRuleCmdPortStatus   = "rule.cmd_port_status"
+	KeyRuleCmdComplete     = "rule.cmd_complete"
+	KeyRuleCmdWorkComplete = "rule.cmd_work_complete"
+	KeyRuleDependencies    = "rule.dependencies"
+	KeyRulePortTitle       = "rule.port_title"
+	KeyRuleChecklist       = "rule.checklist"
+	KeyRuleNotes           = "rule.notes"
+
+	// 세션 시작 포트 사용 안내
+	KeyPortGuidance = "guidance.port"
+
+	// 세션 시작 브리핑 delta
+	KeyDeltaNoChanges  = "delta.no_changes"
+	KeyDeltaCompleted  = "delta.completed"
+	KeyDeltaBlocked    = "delta.blocked"
+	KeyDeltaStarted    = "delta.started"
+	KeyDeltaNew        = "delta.new"
+	KeyDeltaEscalation = "delta.escalation"
+	KeyDeltaResolved   = "delta.resolved"
+
+	// 활성 포트 없음 경고 (PreToolUse / UserPromptSubmit)
+	KeyStrictStderr      = "no_port.strict_stderr"
+	KeyStrictReason      = "no_port.strict_reason"
+	KeyStrictTitle       = "no_port.strict_title"
+	KeyStrictMessage     = "no_port.strict_message"
+	KeyWarnStderr        = "no_port.warn_stderr"
+	KeyWarnTitle         = "no_port.warn_title"
+	KeyWarnMessage       = "no_port.warn_message"
+	KeySuggestCreate     = "no_port.suggest_create"
+	KeySuggestStart      = "no_port.suggest_start"
+	KeySuggestAutoCreate = "no_port.suggest_auto_create"
+	KeyPortReminder      = "no_port.reminder"
+)
+
+var catalogs = map[string]map[string]string{
+	Korean: {
+		KeyRuleInstructions: `## 작업 지침
+
+이 포트에서 작업 시 다음 사항을 준수하세요:
+
+1. 포트 명세에 정의된 파일만 수정
+2. 작업 시작 전 Lock 획득 확인
+3. 완료 시 검증 명령 실행`,
+		KeyRulePortTitle: "# 포트: %s",
+		KeyRuleChecklist: "## 완료 체크리스트",
+		KeyRuleNotes: `## 주의사항
+
+- 작업 완료 시 ` + "`pal_port_end`" + ` 호출
+- 빌드/테스트 실패 시 자동으로 블록 처리됨
+- 문제 발생 시 ` + "`pal_escalate`" + ` 사용`,
+		KeyRuleCommands:        "## 실행 명령",
+		KeyRulePalCommands:     "## PAL 명령",
+		KeyRuleCmdStatus:       "# 상태 확인",
+		KeyRuleCmdPortStatus:   "# 포트 상태 확인",
+		KeyRuleCmdComplete:     "# 완료 처리",
+		KeyRuleCmdWorkComplete: "# 작업 완료",
+		KeyRuleDependencies: `## 의존성
+
+이 포트는 다음 포트에 의존합니다:
+
+%s
+의존 포트가 완료된 후에 작업을 진행하세요.`,
+
+		KeyPortGuidance: `[PAL Kit 포트 사용 안내]
+
+⚠️ 현재 활성 포트가 없습니다.
+코드 변경 작업을 시작하기 전에 포트를 활성화해야 작업이 추적됩니다.
+
+포트 활성화 방법:
+1. 기존 포트 활성화: pal hook port-start <port-id>
+2. 새 포트 생성: pal port create <id> --title "작업명" && pal hook port-start <id>
+
+포트 목록 확인: pal port list`,
+
+		KeyDeltaNoChanges:  "변경 없음",
+		KeyDeltaCompleted:  "✅ 완료",
+		KeyDeltaBlocked:    "⛔ 차단",
+		KeyDeltaStarted:    "🔄 시작",
+		KeyDeltaNew:        "🆕 신규",
+		KeyDeltaEscalation: "🚨 에스컬레이션",
+		KeyDeltaResolved:   "✔️ 해결된 에스컬레이션",
+
+		KeyStrictStderr: `🚫 [PAL Kit] 포트 추적 필수 (strict 모드)
+   활성 포트가 없어 코드 변경이 차단되었습니다.
+
+   포트를 활성화하려면:
+   1. pal port create <id> --title "작업명"
+   2. pal hook port-start <id>`,
+		KeyStrictReason:  "활성 포트가 없습니다. 포트를 먼저 활성화하세요.",
+		KeyStrictTitle:   "포트 추적 필수",
+		KeyStrictMessage: "strict 모드에서는 포트 없이 코드를 수정할 수 없습니다.",
+		KeyWarnStderr: `⚠️  [PAL Kit] 활성 포트가 없습니다!
+   코드 변경이 추적되지 않습니다.
+
+   포트를 활성화하려면:
+   1. pal port create <id> --title "작업명"
+   2. pal hook port-start <id>`,
+		KeyWarnTitle:         "포트 미활성",
+		KeyWarnMessage:       "코드 변경이 추적되지 않습니다. 포트를 활성화하세요.",
+		KeySuggestCreate:     `pal port create <id> --title "작업명" 으로 포트 생성`,
+		KeySuggestStart:      "pal hook port-start <id> 로 포트 활성화",
+		KeySuggestAutoCreate: "또는 파일 경로 기반 포트 ID를 자동 생성할 수 있습니다",
+		KeyPortReminder: "[PAL Kit] %s 요청으로 보입니다. 현재 활성 포트가 없어 변경 사항이 추적되지 않습니다. " +
+			"코드를 수정하기 전에 `pal port create <id> --title \"작업명\"` 후 `pal hook port-start <id>`로 포트를 활성화하세요.",
+	},
+
+	English: {
+		KeyRuleInstructions: `## Guidelines
+
+Follow these rules while working on this port:
+
+1. Only modify files defined in the port specification
+2. Make sure you hold the lock before starting work
+3. Run the verification commands when done`,
+		KeyRulePortTitle: "# Port: %s",
+		KeyRuleChecklist: "## Completion Checklist",
+		KeyRuleNotes: `## Notes
+
+- Call ` + "`pal_port_end`" + ` when the work is done
+- Build/test failures block the port automatically
+- Use ` + "`pal_escalate`" + ` when you run into a problem`,
+		KeyRuleCommands:        "## Commands",
+		KeyRulePalCommands:     "## PAL Commands",
+		KeyRuleCmdStatus:       "# Check status",
+		KeyRuleCmdPortStatus:   "# Check port status",
+		KeyRuleCmdComplete:     "# Mark complete",
+		KeyRuleCmdWorkComplete: "# Finish work",
+		KeyRuleDependencies: `## Dependencies
+
+This port depends on the following ports:
+
+%s
+Wait until the dependencies are complete before starting work.`,
+
+		KeyPortGuidance: `[PAL Kit port guidance]
+
+⚠️ There is no active port.
+Activate a port before changing code so the work is tracked.
+
+How to activate a port:
+1. Activate an existing port: pal hook port-start <port-id>
+2. Create a new port: pal port create <id> --title "Task name" && pal hook port-start <id>
+
+List ports: pal port list`,
+
+		KeyDeltaNoChanges:  "No changes",
+		KeyDeltaCompleted:  "✅ Completed",
+		KeyDeltaBlocked:    "⛔ Blocked",
+		KeyDeltaStarted:    "🔄 Started",
+		KeyDeltaNew:        "🆕 New",
+		KeyDeltaEscalation: "🚨 Escalation",
+		KeyDeltaResolved:   "✔️ Resolved escalations",
+
+		KeyStrictStderr: `🚫 [PAL Kit] Port tracking required (strict mode)
+   Code changes are blocked because there is no active port.
+
+   To activate a port:
+   1. pal port create <id> --title "Task name"
+   2. pal hook port-start <id>`,
+		KeyStrictReason:  "There is no active port. Activate a port first.",
+		KeyStrictTitle:   "Port tracking required",
+		KeyStrictMessage: "In strict mode code cannot be modified without a port.",
+		KeyWarnStderr: `⚠️  [PAL Kit] There is no active port!
+   Code changes are not being tracked.
+
+   To activate a port:
+   1. pal port create <id> --title "Task name"
+   2. pal hook port-start <id>`,
+		KeyWarnTitle:         "No active port",
+		KeyWarnMessage:       "Code changes are not being tracked. Activate a port.",
+		KeySuggestCreate:     `Create a port with pal port create <id> --title "Task name"`,
+		KeySuggestStart:      "Activate it with pal hook port-start <id>",
+		KeySuggestAutoCreate: "Or let PAL Kit generate a port ID from the file path",
+		KeyPortReminder: "[PAL Kit] This looks like a %s request. There is no active port, so changes are not tracked. " +
+			"Before modifying code, run `pal port create <id> --title \"Task name\"` and then `pal hook port-start <id>` to activate a port.",
+	},
+}
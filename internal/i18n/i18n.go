@@ -0,0 +1,48 @@
+// Package i18n renders the guidance text PAL Kit injects into Claude's context
+// (rules, briefing guidance, hook warnings) in the project's configured language.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Supported languages
+const (
+	Korean  = "ko"
+	English = "en"
+
+	// Default is used when a project has no language configured
+	Default = Korean
+)
+
+// Normalize maps a configured language (예: "en-US", "English") to a supported catalog.
+// 지원하지 않는 언어는 기본 언어(ko)로 처리합니다.
+func Normalize(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		lang = lang[:i]
+	}
+	switch lang {
+	case English, "english":
+		return English
+	case Korean, "korean", "한국어":
+		return Korean
+	}
+	return Default
+}
+
+// T returns the message for key in lang, formatted with args.
+// 언어 카탈로그에 없는 키는 기본 언어로, 그래도 없으면 키 자체를 반환합니다.
+func T(lang, key string, args ...interface{}) string {
+	msg, ok := catalogs[Normalize(lang)][key]
+	if !ok {
+		if msg, ok = catalogs[Default][key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
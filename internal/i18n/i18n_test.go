@@ -0,0 +1,41 @@
+package i18n
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"":        Korean,
+		"ko":      Korean,
+		"en":      English,
+		"en-US":   English,
+		"English": English,
+		"ja":      Korean,
+	}
+	for in, want := range tests {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestCatalogsComplete(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key := range catalogs[Default] {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("%s 카탈로그에 %s 키가 없습니다", lang, key)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T("en", KeyPortReminder, "feature"); got[:32] != "[PAL Kit] This looks like a feat" {
+		t.Errorf("T(en) = %s", got)
+	}
+	if got := T("fr", KeyWarnTitle); got != "포트 미활성" {
+		t.Errorf("지원하지 않는 언어는 기본 언어로: %s", got)
+	}
+	if got := T("en", "unknown.key"); got != "unknown.key" {
+		t.Errorf("없는 키는 키 자체를 반환해야 함: %s", got)
+	}
+}
@@ -0,0 +1,313 @@
+package inbox
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/message"
+)
+
+// Kind defines the kind of inbox item
+type Kind string
+
+const (
+	KindEscalation Kind = "escalation"
+	KindMessage    Kind = "message"
+	KindReview     Kind = "review"
+)
+
+// HighPriorityThreshold is the lowest message priority shown in the inbox
+// (1 = highest, 10 = lowest)
+const HighPriorityThreshold = 2
+
+// Item represents a single actionable inbox entry
+type Item struct {
+	Key       string    `json:"key"` // esc:<id> | msg:<id>
+	Kind      Kind      `json:"kind"`
+	ID        string    `json:"id"`
+	SessionID string    `json:"session_id,omitempty"`
+	PortID    string    `json:"port_id,omitempty"`
+	Summary   string    `json:"summary"`
+	Severity  string    `json:"severity,omitempty"`
+	Priority  int       `json:"priority,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Filter holds inbox filter options
+type Filter struct {
+	Kind      Kind
+	SessionID string
+	PortID    string
+	Limit     int
+}
+
+// ActionResult holds the result of a bulk action
+type ActionResult struct {
+	Done    []string          `json:"done"`
+	Skipped map[string]string `json:"skipped,omitempty"`
+}
+
+// Service aggregates escalations, messages and reviews
+type Service struct {
+	db *db.DB
+}
+
+// NewService creates a new inbox service
+func NewService(database *db.DB) *Service {
+	return &Service{db: database}
+}
+
+// List returns open inbox items across running sessions
+func (s *Service) List(filter Filter) ([]Item, error) {
+	var items []Item
+
+	if filter.Kind == "" || filter.Kind == KindEscalation {
+		escItems, err := s.listEscalations(filter)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, escItems...)
+	}
+
+	if filter.Kind == "" || filter.Kind == KindMessage || filter.Kind == KindReview {
+		msgItems, err := s.listMessages(filter)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, msgItems...)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreatedAt.After(items[j].CreatedAt)
+	})
+
+	if filter.Limit > 0 && len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+
+	return items, nil
+}
+
+func (s *Service) listEscalations(filter Filter) ([]Item, error) {
+	// 실행 중인 세션이 올린 에스컬레이션 (또는 세션 없이 생성된 것)
+	query := `
+		SELECT id, from_session, from_port, issue, COALESCE(severity, 'medium'), created_at
+		FROM escalations
+		WHERE status = 'open'
+		  AND (COALESCE(from_session, '') = ''
+		       OR from_session IN (SELECT id FROM sessions WHERE status = 'running'))
+	`
+	var args []interface{}
+	if filter.SessionID != "" {
+		query += ` AND (from_session = ? OR to_session = ?)`
+		args = append(args, filter.SessionID, filter.SessionID)
+	}
+	if filter.PortID != "" {
+		query += ` AND from_port = ?`
+		args = append(args, filter.PortID)
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("에스컬레이션 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var items []Item
+	for rows.Next() {
+		var id int64
+		var sessionID, portID sql.NullString
+		var item Item
+		if err := rows.Scan(&id, &sessionID, &portID, &item.Summary, &item.Severity, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("에스컬레이션 읽기 실패: %w", err)
+		}
+		item.Kind = KindEscalation
+		item.ID = strconv.FormatInt(id, 10)
+		item.Key = "esc:" + item.ID
+		item.SessionID = sessionID.String
+		item.PortID = portID.String
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (s *Service) listMessages(filter Filter) ([]Item, error) {
+	// 실행 중인 세션 대상 메시지 (또는 broadcast)
+	query := `
+		SELECT id, from_session, COALESCE(to_session, ''), COALESCE(subtype, ''),
+		       type, COALESCE(port_id, ''), priority, created_at
+		FROM messages
+		WHERE status = ?
+		  AND (COALESCE(to_session, '') = ''
+		       OR to_session IN (SELECT id FROM sessions WHERE status = 'running'))
+	`
+	args := []interface{}{message.StatusPending}
+
+	switch filter.Kind {
+	case KindReview:
+		query += ` AND subtype = ?`
+		args = append(args, message.SubtypeReviewRequest)
+	case KindMessage:
+		query += ` AND priority <= ? AND COALESCE(subtype, '') != ?`
+		args = append(args, HighPriorityThreshold, message.SubtypeReviewRequest)
+	default:
+		query += ` AND (priority <= ? OR subtype = ?)`
+		args = append(args, HighPriorityThreshold, message.SubtypeReviewRequest)
+	}
+	if filter.SessionID != "" {
+		query += ` AND (to_session = ? OR from_session = ?)`
+		args = append(args, filter.SessionID, filter.SessionID)
+	}
+	if filter.PortID != "" {
+		query += ` AND port_id = ?`
+		args = append(args, filter.PortID)
+	}
+	query += ` ORDER BY priority ASC, created_at DESC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("메시지 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var items []Item
+	for rows.Next() {
+		var item Item
+		var fromSession, toSession, subtype, msgType string
+		if err := rows.Scan(&item.ID, &fromSession, &toSession, &subtype, &msgType,
+			&item.PortID, &item.Priority, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("메시지 읽기 실패: %w", err)
+		}
+
+		item.Key = "msg:" + item.ID
+		item.Kind = KindMessage
+		if subtype == string(message.SubtypeReviewRequest) {
+			item.Kind = KindReview
+		}
+		item.SessionID = toSession
+		if item.SessionID == "" {
+			item.SessionID = fromSession
+		}
+
+		label := subtype
+		if label == "" {
+			label = msgType
+		}
+		item.Summary = fmt.Sprintf("%s from %s", label, fromSession)
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// Ack marks message items as read (delivered). Escalations cannot be acked.
+func (s *Service) Ack(keys []string) (*ActionResult, error) {
+	msgStore := message.NewStore(s.db.DB)
+	result := &ActionResult{Skipped: make(map[string]string)}
+
+	for _, key := range keys {
+		kind, id, err := ParseKey(key)
+		if err != nil {
+			result.Skipped[key] = err.Error()
+			continue
+		}
+		if kind == KindEscalation {
+			result.Skipped[key] = "에스컬레이션은 ack 대상이 아닙니다 (resolve 사용)"
+			continue
+		}
+		if err := s.requireMessage(id); err != nil {
+			result.Skipped[key] = err.Error()
+			continue
+		}
+		if err := msgStore.MarkDelivered(id); err != nil {
+			result.Skipped[key] = err.Error()
+			continue
+		}
+		result.Done = append(result.Done, key)
+	}
+
+	return result, nil
+}
+
+// Resolve resolves escalations and marks messages as processed
+func (s *Service) Resolve(keys []string) (*ActionResult, error) {
+	escSvc := escalation.NewService(s.db)
+	msgStore := message.NewStore(s.db.DB)
+	result := &ActionResult{Skipped: make(map[string]string)}
+
+	for _, key := range keys {
+		kind, id, err := ParseKey(key)
+		if err != nil {
+			result.Skipped[key] = err.Error()
+			continue
+		}
+
+		switch kind {
+		case KindEscalation:
+			var escID int64
+			if escID, err = strconv.ParseInt(id, 10, 64); err == nil {
+				err = escSvc.Resolve(escID)
+			}
+		default:
+			if err = s.requireMessage(id); err == nil {
+				err = msgStore.MarkProcessed(id)
+			}
+		}
+		if err != nil {
+			result.Skipped[key] = err.Error()
+			continue
+		}
+		result.Done = append(result.Done, key)
+	}
+
+	return result, nil
+}
+
+// requireMessage fails when no message has the given ID
+func (s *Service) requireMessage(id string) error {
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM messages WHERE id = ?`, id).Scan(&count); err != nil {
+		return fmt.Errorf("메시지 조회 실패: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("메시지를 찾을 수 없습니다: %s", id)
+	}
+	return nil
+}
+
+// Keys returns the keys of the given items
+func Keys(items []Item) []string {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = item.Key
+	}
+	return keys
+}
+
+// ParseKey parses an inbox key (esc:<id> | msg:<id>)
+func ParseKey(key string) (Kind, string, error) {
+	parts := strings.SplitN(key, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("잘못된 inbox 키: %s (형식: esc:<id> | msg:<id>)", key)
+	}
+
+	switch parts[0] {
+	case "esc":
+		if _, err := strconv.ParseInt(parts[1], 10, 64); err != nil {
+			return "", "", fmt.Errorf("잘못된 에스컬레이션 ID: %s", parts[1])
+		}
+		return KindEscalation, parts[1], nil
+	case "msg":
+		return KindMessage, parts[1], nil
+	default:
+		return "", "", fmt.Errorf("알 수 없는 inbox 키 종류: %s", parts[0])
+	}
+}
@@ -0,0 +1,199 @@
+package inbox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/message"
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+func setupTestDB(t *testing.T) (*db.DB, func()) {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "pal-test-*")
+	if err != nil {
+		t.Fatalf("임시 디렉토리 생성 실패: %v", err)
+	}
+
+	database, err := db.Open(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+
+	cleanup := func() {
+		database.Close()
+		os.RemoveAll(tmpDir)
+	}
+
+	return database, cleanup
+}
+
+func seedInbox(t *testing.T, database *db.DB) {
+	t.Helper()
+
+	sessionSvc := session.NewService(database)
+	if err := sessionSvc.Start("worker-1", "", "worker"); err != nil {
+		t.Fatalf("세션 시작 실패: %v", err)
+	}
+	if err := sessionSvc.Start("worker-2", "", "finished"); err != nil {
+		t.Fatalf("세션 시작 실패: %v", err)
+	}
+	sessionSvc.End("worker-2")
+
+	escSvc := escalation.NewService(database)
+	if _, err := escSvc.Create("빌드 실패", "worker-1", "port-001"); err != nil {
+		t.Fatalf("에스컬레이션 생성 실패: %v", err)
+	}
+	if _, err := escSvc.Create("종료된 세션의 문의", "worker-2", "port-003"); err != nil {
+		t.Fatalf("에스컬레이션 생성 실패: %v", err)
+	}
+
+	store := message.NewStore(database.DB)
+	msgs := []*message.Message{
+		{ID: "m-high", ConversationID: "port-001", FromSession: "op", ToSession: "worker-1",
+			Type: message.TypeRequest, Subtype: message.SubtypeTaskAssign, Priority: 1, PortID: "port-001"},
+		{ID: "m-low", ConversationID: "port-001", FromSession: "op", ToSession: "worker-1",
+			Type: message.TypeReport, Subtype: message.SubtypeProgress, Priority: 7, PortID: "port-001"},
+		{ID: "m-review", ConversationID: "port-002", FromSession: "worker-1", ToSession: "worker-1",
+			Type: message.TypeRequest, Subtype: message.SubtypeReviewRequest, Priority: 5, PortID: "port-002"},
+		{ID: "m-ended", ConversationID: "port-003", FromSession: "op", ToSession: "worker-2",
+			Type: message.TypeRequest, Subtype: message.SubtypeTaskAssign, Priority: 1},
+	}
+	for _, m := range msgs {
+		if err := store.Send(m); err != nil {
+			t.Fatalf("메시지 전송 실패: %v", err)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+	seedInbox(t, database)
+
+	svc := NewService(database)
+	items, err := svc.List(Filter{})
+	if err != nil {
+		t.Fatalf("inbox 조회 실패: %v", err)
+	}
+
+	keys := make(map[string]Kind)
+	for _, item := range items {
+		keys[item.Key] = item.Kind
+	}
+
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 items, got %d (%v)", len(items), keys)
+	}
+	if keys["msg:m-high"] != KindMessage {
+		t.Error("high priority message should be listed")
+	}
+	if keys["msg:m-review"] != KindReview {
+		t.Error("review request should be listed as review")
+	}
+	if _, ok := keys["msg:m-low"]; ok {
+		t.Error("low priority message should not be listed")
+	}
+	if _, ok := keys["msg:m-ended"]; ok {
+		t.Error("message to ended session should not be listed")
+	}
+	if _, ok := keys["esc:2"]; ok {
+		t.Error("escalation from ended session should not be listed")
+	}
+}
+
+func TestListFilter(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+	seedInbox(t, database)
+
+	svc := NewService(database)
+
+	reviews, _ := svc.List(Filter{Kind: KindReview})
+	if len(reviews) != 1 {
+		t.Errorf("Expected 1 review, got %d", len(reviews))
+	}
+
+	byPort, _ := svc.List(Filter{PortID: "port-001"})
+	if len(byPort) != 2 {
+		t.Errorf("Expected 2 items for port-001, got %d", len(byPort))
+	}
+}
+
+func TestAckAndResolve(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+	seedInbox(t, database)
+
+	svc := NewService(database)
+	items, _ := svc.List(Filter{})
+
+	ackResult, err := svc.Ack([]string{"msg:m-high", "esc:1"})
+	if err != nil {
+		t.Fatalf("ack 실패: %v", err)
+	}
+	if len(ackResult.Done) != 1 || ackResult.Skipped["esc:1"] == "" {
+		t.Errorf("Unexpected ack result: %+v", ackResult)
+	}
+
+	// 없는 메시지는 처리 완료로 보고하지 않음
+	missing, err := svc.Resolve([]string{"msg:m-unknown"})
+	if err != nil {
+		t.Fatalf("resolve 실패: %v", err)
+	}
+	if len(missing.Done) != 0 || missing.Skipped["msg:m-unknown"] == "" {
+		t.Errorf("Unexpected resolve result: %+v", missing)
+	}
+
+	if _, err := svc.Resolve(Keys(items)); err != nil {
+		t.Fatalf("resolve 실패: %v", err)
+	}
+
+	remaining, _ := svc.List(Filter{})
+	if len(remaining) != 0 {
+		t.Errorf("Expected empty inbox, got %d items", len(remaining))
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		kind    Kind
+		wantErr bool
+	}{
+		{"esc:12", KindEscalation, false},
+		{"msg:abc-123", KindMessage, false},
+		{"esc:abc", "", true},
+		{"foo:1", "", true},
+		{"msg:", "", true},
+	}
+
+	for _, tt := range tests {
+		kind, _, err := ParseKey(tt.key)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseKey(%s) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+		}
+		if kind != tt.kind {
+			t.Errorf("ParseKey(%s) kind = %s, want %s", tt.key, kind, tt.kind)
+		}
+	}
+}
+
+func TestListScanError(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+	seedInbox(t, database)
+
+	// 읽을 수 없는 행은 건너뛰지 않고 오류로 보고
+	if _, err := database.Exec(`UPDATE messages SET priority = 'urgent' WHERE id = 'm-review'`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewService(database).List(Filter{Kind: KindReview}); err == nil {
+		t.Error("expected error for an unreadable message row")
+	}
+}
@@ -0,0 +1,437 @@
+// Package intake turns pasted bug reports and requests into port drafts or escalations.
+// 원문은 .pal/intake/에 그대로 보관되고, 생성된 포트 문서와 에스컬레이션이 원문 경로를 참조합니다.
+package intake
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/port"
+)
+
+// Kind is the classified type of an intake text
+type Kind string
+
+// Intake kinds
+const (
+	KindBug      Kind = "bug"
+	KindFeature  Kind = "feature"
+	KindQuestion Kind = "question"
+)
+
+// Targets an intake is filed as
+const (
+	TargetPort       = "port"
+	TargetEscalation = "escalation"
+)
+
+// maxTitle limits the extracted title length
+const maxTitle = 80
+
+// ParseKind validates a kind name
+func ParseKind(s string) (Kind, error) {
+	switch k := Kind(strings.ToLower(strings.TrimSpace(s))); k {
+	case KindBug, KindFeature, KindQuestion:
+		return k, nil
+	}
+	return "", fmt.Errorf("알 수 없는 분류: %s (bug, feature, question)", s)
+}
+
+// Target returns where a kind is filed by default: questions become escalations, the rest port drafts
+func (k Kind) Target() string {
+	if k == KindQuestion {
+		return TargetEscalation
+	}
+	return TargetPort
+}
+
+// keywords scores each kind by keyword hits (lowercase match)
+var keywords = map[Kind][]string{
+	KindBug: {
+		"bug", "error", "crash", "exception", "fail", "broken", "regression", "not working", "doesn't work",
+		"stack trace", "traceback", "panic", "500", "버그", "오류", "에러", "장애", "실패", "안 됨", "안됨", "안돼",
+		"깨짐", "깨져", "크래시", "예외", "재현",
+	},
+	KindFeature: {
+		"feature", "request", "add ", "support", "would like", "would be nice", "enhancement", "allow", "ability to",
+		"기능", "추가", "지원", "요청", "개선", "했으면", "원합니다", "필요합니다",
+	},
+	KindQuestion: {
+		"how do", "how to", "how can", "why", "what is", "is there", "is it possible", "can i", "does it",
+		"어떻게", "왜 ", "궁금", "문의", "가능한가요", "인가요", "나요", "까요",
+	},
+}
+
+var (
+	numberedLine = regexp.MustCompile(`^\s*(?:\d+[.)]|-|\*)\s+(.+)$`)
+	fieldLine    = regexp.MustCompile(`^\s*([^:：]{1,30})\s*[:：]\s*(.*)$`)
+	titlePrefix  = regexp.MustCompile(`(?i)^\s*(?:subject|title|제목|re|fwd?)\s*[:：]\s*|^\s*\[[^\]]{1,20}\]\s*`)
+	slugInvalid  = regexp.MustCompile(`[^a-z0-9]+`)
+	prodWord     = regexp.MustCompile(`\bprod\b`)
+)
+
+// fieldAliases maps field labels found in reports to extracted field names
+var fieldAliases = map[string]string{
+	"expected": "expected", "expected behavior": "expected", "expected result": "expected", "기대": "expected", "기대 동작": "expected", "기대 결과": "expected",
+	"actual": "actual", "actual behavior": "actual", "actual result": "actual", "실제": "actual", "실제 동작": "actual", "실제 결과": "actual",
+	"environment": "environment", "env": "environment", "version": "environment", "환경": "environment", "버전": "environment",
+	"reporter": "reporter", "from": "reporter", "보고자": "reporter", "요청자": "reporter",
+}
+
+// stepHeadings mark the start of a reproduction step list
+var stepHeadings = []string{"steps", "to reproduce", "reproduce", "재현", "재현 절차", "재현 방법"}
+
+// Classification is the result of classifying an intake text
+type Classification struct {
+	Kind       Kind                `json:"kind"`
+	Confidence float64             `json:"confidence"`
+	Title      string              `json:"title"`
+	Severity   escalation.Severity `json:"severity"`
+	Steps      []string            `json:"steps,omitempty"`
+	Fields     map[string]string   `json:"fields,omitempty"` // expected, actual, environment, reporter
+}
+
+// Classify classifies a text as bug/feature/question and extracts its fields
+func Classify(text string) Classification {
+	lower := strings.ToLower(text)
+	scores := map[Kind]int{}
+	for kind, words := range keywords {
+		for _, w := range words {
+			if strings.Contains(lower, w) {
+				scores[kind]++
+			}
+		}
+	}
+	if strings.HasSuffix(strings.TrimSpace(text), "?") {
+		scores[KindQuestion] += 2
+	}
+	if strings.Contains(text, "\tat ") || strings.Contains(lower, "goroutine ") || strings.Contains(text, "Traceback") {
+		scores[KindBug] += 2
+	}
+
+	c := Classification{Kind: KindFeature, Fields: map[string]string{}}
+	total, best := 0, 0
+	// 동점이면 bug > feature > question 순으로 선택
+	for _, kind := range []Kind{KindBug, KindFeature, KindQuestion} {
+		total += scores[kind]
+		if scores[kind] > best {
+			best = scores[kind]
+			c.Kind = kind
+		}
+	}
+	if total > 0 {
+		c.Confidence = float64(best) / float64(total)
+	}
+
+	c.Title = extractTitle(text)
+	c.Steps, c.Fields = extractFields(text)
+	c.Severity = severity(c.Kind, lower)
+	return c
+}
+
+// Result is a filed intake
+type Result struct {
+	Classification
+	Target       string `json:"target"`
+	PortID       string `json:"port_id,omitempty"`
+	DocPath      string `json:"doc_path,omitempty"`
+	EscalationID int64  `json:"escalation_id,omitempty"`
+	ArtifactPath string `json:"artifact_path"`
+}
+
+// Options controls how an intake is filed
+type Options struct {
+	Kind      Kind   // 비어 있으면 자동 분류
+	Target    string // 비어 있으면 분류에 따라 결정
+	PortID    string // 비어 있으면 제목에서 생성
+	SessionID string
+}
+
+// Service files intakes into ports and escalations
+type Service struct {
+	db          *db.DB
+	projectRoot string
+}
+
+// NewService creates a new intake service
+func NewService(database *db.DB, projectRoot string) *Service {
+	return &Service{db: database, projectRoot: projectRoot}
+}
+
+// Submit classifies the text, saves it as an artifact and creates a port draft or an escalation
+func (s *Service) Submit(text string, opts Options) (*Result, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("intake 내용이 비어 있습니다")
+	}
+
+	c := Classify(text)
+	if opts.Kind != "" {
+		c.Kind = opts.Kind
+		c.Confidence = 1
+		c.Severity = severity(c.Kind, strings.ToLower(text))
+	}
+	res := &Result{Classification: c, Target: opts.Target}
+	if res.Target == "" {
+		res.Target = c.Kind.Target()
+	}
+
+	now := time.Now()
+	artifact, err := s.saveArtifact(text, now)
+	if err != nil {
+		return nil, err
+	}
+	res.ArtifactPath = artifact
+
+	switch res.Target {
+	case TargetPort:
+		if err := s.createPort(res, opts.PortID, now); err != nil {
+			return nil, err
+		}
+	case TargetEscalation:
+		fields := map[string]string{"kind": string(c.Kind), "artifact": artifact}
+		var tmplFields []escalation.TemplateField
+		for name, value := range c.Fields {
+			fields[name] = value
+		}
+		for name := range fields {
+			tmplFields = append(tmplFields, escalation.TemplateField{Name: name})
+		}
+		tmpl := &escalation.Template{ID: string(escalationType(c.Kind)), Severity: c.Severity, Fields: tmplFields}
+		id, err := escalation.NewService(s.db).CreateTyped(tmpl, escalation.TypedOptions{
+			Issue:     c.Title,
+			SessionID: opts.SessionID,
+			Fields:    fields,
+		})
+		if err != nil {
+			return nil, err
+		}
+		res.EscalationID = id
+	default:
+		return nil, fmt.Errorf("알 수 없는 대상: %s (port, escalation)", res.Target)
+	}
+	return res, nil
+}
+
+// saveArtifact stores the original text under .pal/intake and returns its project-relative path
+func (s *Service) saveArtifact(text string, now time.Time) (string, error) {
+	dir := filepath.Join(s.projectRoot, ".pal", "intake")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("intake 디렉토리 생성 실패: %w", err)
+	}
+	name := now.Format("20060102-150405") + ".md"
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, name)); os.IsNotExist(err) {
+			break
+		}
+		name = fmt.Sprintf("%s-%d.md", now.Format("20060102-150405"), i)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(text+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("intake 원문 저장 실패: %w", err)
+	}
+	return filepath.ToSlash(filepath.Join(".pal", "intake", name)), nil
+}
+
+// createPort creates a pending port with a spec document prefilled from the intake
+func (s *Service) createPort(res *Result, portID string, now time.Time) error {
+	portSvc := port.NewService(s.db)
+	if portID == "" {
+		portID = s.uniquePortID(portSvc, res.Kind, res.Title, now)
+	}
+	res.PortID = portID
+	res.DocPath = filepath.ToSlash(filepath.Join("ports", portID+".md"))
+
+	if err := portSvc.Create(portID, res.Title, res.DocPath); err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.projectRoot, res.DocPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("포트 문서 디렉토리 생성 실패: %w", err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil // 기존 문서는 덮어쓰지 않음
+	}
+	if err := os.WriteFile(path, []byte(portDocument(res)), 0644); err != nil {
+		return fmt.Errorf("포트 문서 생성 실패: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) uniquePortID(portSvc *port.Service, kind Kind, title string, now time.Time) string {
+	prefix := "feat"
+	if kind == KindBug {
+		prefix = "bug"
+	}
+	base := slug(title)
+	if base == "" {
+		base = now.Format("20060102-150405")
+	}
+	id := prefix + "-" + base
+	for i := 2; ; i++ {
+		if p, _ := portSvc.Get(id); p == nil {
+			return id
+		}
+		id = fmt.Sprintf("%s-%s-%d", prefix, base, i)
+	}
+}
+
+// portDocument renders the port draft spec
+func portDocument(res *Result) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s\n\n", res.Title))
+	sb.WriteString(fmt.Sprintf("> intake: %s (신뢰도 %.0f%%) · 원문: %s\n\n", res.Kind, res.Confidence*100, res.ArtifactPath))
+
+	purpose := "기능 추가"
+	if res.Kind == KindBug {
+		purpose = "버그 수정"
+	}
+	sb.WriteString("## 컨텍스트\n\n")
+	sb.WriteString(fmt.Sprintf("- 상위 요구사항: %s\n", res.ArtifactPath))
+	sb.WriteString(fmt.Sprintf("- 작업 목적: %s\n", purpose))
+	if r := res.Fields["reporter"]; r != "" {
+		sb.WriteString(fmt.Sprintf("- 요청자: %s\n", r))
+	}
+	if res.Kind == KindBug {
+		sb.WriteString(fmt.Sprintf("- 심각도: %s\n", res.Severity))
+	}
+	sb.WriteString("\n")
+
+	if len(res.Steps) > 0 {
+		sb.WriteString("## 재현 절차\n\n")
+		for i, step := range res.Steps {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, step))
+		}
+		sb.WriteString("\n")
+	}
+	if res.Fields["expected"] != "" || res.Fields["actual"] != "" {
+		sb.WriteString("## 기대 동작 / 실제 동작\n\n")
+		sb.WriteString(fmt.Sprintf("- 기대: %s\n", res.Fields["expected"]))
+		sb.WriteString(fmt.Sprintf("- 실제: %s\n\n", res.Fields["actual"]))
+	}
+	if env := res.Fields["environment"]; env != "" {
+		sb.WriteString(fmt.Sprintf("## 환경\n\n- %s\n\n", env))
+	}
+
+	sb.WriteString("## 작업 범위 (배타적 소유권)\n\n### 생성/수정할 파일\n- \n\n### 구현할 기능\n- \n\n")
+	sb.WriteString("## 검증\n\n- [ ] ")
+	if res.Kind == KindBug {
+		sb.WriteString("재현 절차로 문제가 재현되지 않음\n")
+	} else {
+		sb.WriteString("요청된 동작 확인\n")
+	}
+	return sb.String()
+}
+
+func extractTitle(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		for stripped := titlePrefix.ReplaceAllString(line, ""); stripped != line; stripped = titlePrefix.ReplaceAllString(line, "") {
+			line = stripped
+		}
+		line = strings.TrimSpace(line)
+		line = strings.TrimLeft(line, "# ")
+		if line == "" {
+			continue
+		}
+		r := []rune(line)
+		if len(r) > maxTitle {
+			line = string(r[:maxTitle-3]) + "..."
+		}
+		return line
+	}
+	return ""
+}
+
+// extractFields collects numbered reproduction steps and labelled fields
+func extractFields(text string) ([]string, map[string]string) {
+	fields := map[string]string{}
+	var steps []string
+	inSteps := false
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		label := strings.ToLower(strings.TrimRight(strings.TrimLeft(trimmed, "# "), ":："))
+		if isStepHeading(label) {
+			inSteps = true
+			continue
+		}
+		if m := fieldLine.FindStringSubmatch(trimmed); m != nil {
+			key := strings.ToLower(strings.TrimLeft(strings.TrimSpace(m[1]), "#-* "))
+			if name, ok := fieldAliases[key]; ok {
+				if v := strings.TrimSpace(m[2]); v != "" && fields[name] == "" {
+					fields[name] = v
+				}
+				inSteps = false
+				continue
+			}
+			if isStepHeading(key) {
+				inSteps = true
+				continue
+			}
+		}
+		if m := numberedLine.FindStringSubmatch(line); m != nil && (inSteps || startsNumbered(trimmed)) {
+			steps = append(steps, strings.TrimSpace(m[1]))
+			continue
+		}
+		inSteps = false
+	}
+	return steps, fields
+}
+
+func isStepHeading(label string) bool {
+	for _, h := range stepHeadings {
+		if label == h || strings.HasPrefix(label, "steps to") {
+			return true
+		}
+	}
+	return false
+}
+
+func startsNumbered(line string) bool {
+	return line != "" && line[0] >= '0' && line[0] <= '9'
+}
+
+// severity estimates the severity from impact keywords
+func severity(kind Kind, lower string) escalation.Severity {
+	if prodWord.MatchString(lower) {
+		return escalation.SeverityCritical
+	}
+	for _, w := range []string{"production", "outage", "data loss", "security", "urgent", "critical", "장애", "긴급", "데이터 유실", "보안"} {
+		if strings.Contains(lower, w) {
+			return escalation.SeverityCritical
+		}
+	}
+	if kind != KindBug {
+		return escalation.SeverityLow
+	}
+	for _, w := range []string{"crash", "panic", "500", "크래시", "blocked", "막힘"} {
+		if strings.Contains(lower, w) {
+			return escalation.SeverityHigh
+		}
+	}
+	return escalation.SeverityMedium
+}
+
+func escalationType(kind Kind) escalation.EscalationType {
+	if kind == KindQuestion {
+		return escalation.TypeQuestion
+	}
+	return escalation.TypeManualReview
+}
+
+func slug(title string) string {
+	s := strings.Trim(slugInvalid.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(s) > 40 {
+		s = strings.TrimRight(s[:40], "-")
+	}
+	return s
+}
@@ -0,0 +1,104 @@
+package intake
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/port"
+)
+
+const bugReport = `Subject: [Bug] Login fails with 500 on Safari
+From: kim@example.com
+
+Steps to reproduce:
+1. Open /login
+2. Submit valid credentials
+
+Expected: dashboard is shown
+Actual: HTTP 500 error
+`
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		text string
+		kind Kind
+	}{
+		{bugReport, KindBug},
+		{"PDF 내보내기 기능을 추가했으면 합니다", KindFeature},
+		{"How do I configure the lock mode?", KindQuestion},
+		{"세션 목록 정렬 기준이 어떻게 되나요?", KindQuestion},
+		{"결제 페이지에서 에러가 납니다", KindBug},
+	}
+	for _, tt := range tests {
+		if c := Classify(tt.text); c.Kind != tt.kind {
+			t.Errorf("Classify(%q) = %s, want %s", tt.text, c.Kind, tt.kind)
+		}
+	}
+
+	c := Classify(bugReport)
+	if c.Title != "Login fails with 500 on Safari" {
+		t.Errorf("Title = %q", c.Title)
+	}
+	if strings.Join(c.Steps, "|") != "Open /login|Submit valid credentials" {
+		t.Errorf("Steps = %v", c.Steps)
+	}
+	if c.Fields["expected"] != "dashboard is shown" || c.Fields["actual"] != "HTTP 500 error" || c.Fields["reporter"] != "kim@example.com" {
+		t.Errorf("Fields = %v", c.Fields)
+	}
+	if c.Severity != escalation.SeverityHigh {
+		t.Errorf("Severity = %s", c.Severity)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	defer database.Close()
+
+	root := t.TempDir()
+	svc := NewService(database, root)
+
+	res, err := svc.Submit(bugReport, Options{})
+	if err != nil {
+		t.Fatalf("Submit 실패: %v", err)
+	}
+	if res.Target != TargetPort || res.PortID != "bug-login-fails-with-500-on-safari" {
+		t.Fatalf("포트 초안이 생성되어야 함: %+v", res)
+	}
+	p, err := port.NewService(database).Get(res.PortID)
+	if err != nil || p.Status != port.StatusPending {
+		t.Fatalf("포트 조회 실패: %v", err)
+	}
+	doc, _ := os.ReadFile(filepath.Join(root, res.DocPath))
+	if !strings.Contains(string(doc), res.ArtifactPath) || !strings.Contains(string(doc), "1. Open /login") {
+		t.Errorf("포트 문서에 원문 경로와 재현 절차가 있어야 함:\n%s", doc)
+	}
+	original, _ := os.ReadFile(filepath.Join(root, res.ArtifactPath))
+	if strings.TrimSpace(string(original)) != strings.TrimSpace(bugReport) {
+		t.Error("원문이 그대로 보관되어야 함")
+	}
+
+	// 같은 제목은 다른 ID로 생성
+	again, err := svc.Submit(bugReport, Options{})
+	if err != nil || again.PortID != res.PortID+"-2" {
+		t.Errorf("중복 ID 처리 실패: %v %+v", err, again)
+	}
+
+	q, err := svc.Submit("How do I configure the lock mode?", Options{})
+	if err != nil {
+		t.Fatalf("Submit 실패: %v", err)
+	}
+	if q.Target != TargetEscalation || q.EscalationID == 0 {
+		t.Fatalf("에스컬레이션이 생성되어야 함: %+v", q)
+	}
+	e, _ := escalation.NewService(database).Get(q.EscalationID)
+	if e.Type.String != string(escalation.TypeQuestion) || e.Fields()["artifact"] != q.ArtifactPath {
+		t.Errorf("에스컬레이션 필드 불일치: type=%s fields=%v", e.Type.String, e.Fields())
+	}
+}
@@ -0,0 +1,365 @@
+package kb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// 임베딩 provider: 문서와 검색어를 벡터로 바꿔 의미 검색에 사용합니다.
+// openai/ollama는 HTTP API를, local은 ONNX 모델을 실행하는 외부 명령을 호출합니다
+// (go 빌드에 ONNX 런타임이 없어 명령으로 분리). hash는 모델 없이 동작하는 오프라인/테스트용입니다.
+
+// Embedding providers
+const (
+	EmbedProviderOpenAI = "openai"
+	EmbedProviderOllama = "ollama"
+	EmbedProviderLocal  = "local"
+	EmbedProviderHash   = "hash"
+)
+
+// Provider defaults
+const (
+	defaultOpenAIEndpoint = "https://api.openai.com/v1/embeddings"
+	defaultOpenAIModel    = "text-embedding-3-small"
+	defaultOllamaEndpoint = "http://localhost:11434"
+	defaultOllamaModel    = "nomic-embed-text"
+	hashEmbedDim          = 256
+)
+
+// embedTimeout bounds a single provider request
+const embedTimeout = 60 * time.Second
+
+// EmbedConfig selects and configures an embedding provider.
+// Endpoint와 Command는 이 컴퓨터의 설정(~/.pal/kb-embed.yaml)에서만 읽고 vault 색인에는 저장하지 않습니다.
+type EmbedConfig struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model,omitempty"`    // openai/ollama 모델 이름, local이면 ONNX 모델 경로
+	Endpoint string `json:"endpoint,omitempty"` // API 주소 (기본: provider별 기본값)
+	Command  string `json:"command,omitempty"`  // local: 실행할 명령 (stdin JSON {"texts"} → stdout JSON {"embeddings"})
+}
+
+// userEmbedSettings is the machine-local part of an EmbedConfig for one provider
+type userEmbedSettings struct {
+	Endpoint string `yaml:"endpoint,omitempty"`
+	Command  string `yaml:"command,omitempty"`
+}
+
+// UserEmbedConfigPath returns the machine-local embedding settings file (~/.pal/kb-embed.yaml).
+// 복제한 vault가 임의 명령을 실행하거나 API 키를 다른 주소로 보내지 못하도록 실행 명령과 주소는 여기에만 둡니다.
+func UserEmbedConfigPath() string {
+	return filepath.Join(config.GlobalDir(), "kb-embed.yaml")
+}
+
+func loadUserEmbedSettings() (map[string]userEmbedSettings, error) {
+	data, err := os.ReadFile(UserEmbedConfigPath())
+	if os.IsNotExist(err) {
+		return map[string]userEmbedSettings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("임베딩 설정 읽기 실패: %w", err)
+	}
+	settings := make(map[string]userEmbedSettings)
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("임베딩 설정 파싱 실패: %w", err)
+	}
+	return settings, nil
+}
+
+// SaveUserEmbedConfig stores the endpoint and command of cfg's provider in ~/.pal/kb-embed.yaml
+func SaveUserEmbedConfig(cfg EmbedConfig) error {
+	settings, err := loadUserEmbedSettings()
+	if err != nil {
+		return err
+	}
+	settings[cfg.Provider] = userEmbedSettings{Endpoint: cfg.Endpoint, Command: cfg.Command}
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(UserEmbedConfigPath()), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(UserEmbedConfigPath(), data, 0600)
+}
+
+// ResolveEmbedConfig fills the endpoint and command missing from cfg with this machine's settings
+func ResolveEmbedConfig(cfg EmbedConfig) (EmbedConfig, error) {
+	settings, err := loadUserEmbedSettings()
+	if err != nil {
+		return cfg, err
+	}
+	local := settings[cfg.Provider]
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = local.Endpoint
+	}
+	if cfg.Command == "" {
+		cfg.Command = local.Command
+	}
+	return cfg, nil
+}
+
+// Embedder turns texts into vectors
+type Embedder interface {
+	// Name identifies the provider and model; 이름이 다른 벡터끼리는 비교하지 않습니다
+	Name() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewEmbedder creates the embedder for a provider config.
+// API 키는 설정에 저장하지 않고 환경 변수(OPENAI_API_KEY)에서 읽습니다.
+func NewEmbedder(cfg EmbedConfig) (Embedder, error) {
+	client := &http.Client{Timeout: embedTimeout}
+
+	switch cfg.Provider {
+	case EmbedProviderOpenAI:
+		apiKey := os.Getenv("OPENAI_API_KEY")
+		if apiKey == "" {
+			return nil, fmt.Errorf("OPENAI_API_KEY 환경 변수가 설정되지 않았습니다")
+		}
+		return &openAIEmbedder{
+			endpoint: orDefault(cfg.Endpoint, defaultOpenAIEndpoint),
+			model:    orDefault(cfg.Model, defaultOpenAIModel),
+			apiKey:   apiKey,
+			client:   client,
+		}, nil
+	case EmbedProviderOllama:
+		return &ollamaEmbedder{
+			endpoint: strings.TrimSuffix(orDefault(cfg.Endpoint, defaultOllamaEndpoint), "/"),
+			model:    orDefault(cfg.Model, defaultOllamaModel),
+			client:   client,
+		}, nil
+	case EmbedProviderLocal:
+		if cfg.Command == "" {
+			return nil, fmt.Errorf("local provider에는 ONNX 모델을 실행할 명령이 필요합니다 (pal kb embed --command, %s)", UserEmbedConfigPath())
+		}
+		return &localEmbedder{command: cfg.Command, model: cfg.Model}, nil
+	case EmbedProviderHash:
+		return hashEmbedder{}, nil
+	case "":
+		return nil, fmt.Errorf("임베딩 provider가 지정되지 않았습니다 (openai, ollama, local, hash)")
+	default:
+		return nil, fmt.Errorf("알 수 없는 임베딩 provider: %s", cfg.Provider)
+	}
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// openAIEmbedder calls the OpenAI embeddings API
+type openAIEmbedder struct {
+	endpoint string
+	model    string
+	apiKey   string
+	client   *http.Client
+}
+
+func (e *openAIEmbedder) Name() string { return EmbedProviderOpenAI + ":" + e.model }
+
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	var resp struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	headers := map[string]string{"Authorization": "Bearer " + e.apiKey}
+	if err := postJSON(ctx, e.client, e.endpoint, headers, map[string]interface{}{
+		"model": e.model,
+		"input": texts,
+	}, &resp); err != nil {
+		return nil, fmt.Errorf("OpenAI 임베딩 실패: %w", err)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index >= 0 && d.Index < len(vectors) {
+			vectors[d.Index] = d.Embedding
+		}
+	}
+	return vectors, checkVectors(vectors)
+}
+
+// ollamaEmbedder calls an Ollama server (/api/embed)
+type ollamaEmbedder struct {
+	endpoint string
+	model    string
+	client   *http.Client
+}
+
+func (e *ollamaEmbedder) Name() string { return EmbedProviderOllama + ":" + e.model }
+
+func (e *ollamaEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	var resp struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	if err := postJSON(ctx, e.client, e.endpoint+"/api/embed", nil, map[string]interface{}{
+		"model": e.model,
+		"input": texts,
+	}, &resp); err != nil {
+		return nil, fmt.Errorf("Ollama 임베딩 실패: %w", err)
+	}
+	if len(resp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("Ollama 임베딩 실패: 벡터 %d개를 기대했지만 %d개를 받았습니다", len(texts), len(resp.Embeddings))
+	}
+	return resp.Embeddings, checkVectors(resp.Embeddings)
+}
+
+// localEmbedder runs a command that evaluates a local ONNX model.
+// 명령은 stdin으로 {"model": ..., "texts": [...]}를 받아 stdout에 {"embeddings": [[...], ...]}를 씁니다.
+type localEmbedder struct {
+	command string
+	model   string
+}
+
+func (e *localEmbedder) Name() string {
+	if e.model == "" {
+		return EmbedProviderLocal + ":" + e.command
+	}
+	return EmbedProviderLocal + ":" + e.model
+}
+
+func (e *localEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	input, _ := json.Marshal(map[string]interface{}{"model": e.model, "texts": texts})
+
+	cmd := exec.CommandContext(ctx, "bash", "-c", e.command)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Env = append(os.Environ(), "PAL_EMBED_MODEL="+e.model)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("로컬 임베딩 명령 실패: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var resp struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("로컬 임베딩 출력 파싱 실패: %w", err)
+	}
+	if len(resp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("로컬 임베딩 명령이 벡터 %d개 대신 %d개를 반환했습니다", len(texts), len(resp.Embeddings))
+	}
+	return resp.Embeddings, checkVectors(resp.Embeddings)
+}
+
+// hashEmbedder hashes words and character trigrams into a fixed-size vector.
+// 개념 수준 유사도는 약하지만 모델 없이 철자/형태가 비슷한 문서를 찾습니다.
+type hashEmbedder struct{}
+
+func (hashEmbedder) Name() string { return EmbedProviderHash }
+
+func (hashEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vec := make([]float32, hashEmbedDim)
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			addHashed(vec, "w:"+word, 1)
+			runes := []rune(" " + word + " ")
+			for j := 0; j+3 <= len(runes); j++ {
+				addHashed(vec, string(runes[j:j+3]), 0.5)
+			}
+		}
+		normalize(vec)
+		vectors[i] = vec
+	}
+	return vectors, nil
+}
+
+func addHashed(vec []float32, feature string, weight float32) {
+	h := fnv.New32a()
+	h.Write([]byte(feature))
+	sum := h.Sum32()
+	if sum&1 == 1 {
+		weight = -weight
+	}
+	vec[(sum>>1)%uint32(len(vec))] += weight
+}
+
+func normalize(vec []float32) {
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return
+	}
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vec {
+		vec[i] *= scale
+	}
+}
+
+// cosine returns the cosine similarity of two vectors (차원이 다르면 0)
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+func checkVectors(vectors [][]float32) error {
+	for i, v := range vectors {
+		if len(v) == 0 {
+			return fmt.Errorf("%d번째 텍스트의 벡터가 비어 있습니다", i)
+		}
+	}
+	return nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return json.Unmarshal(respBody, out)
+}
@@ -0,0 +1,83 @@
+package kb
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+)
+
+// 본문 전문 검색: documents_body(FTS4, unicode61)에 경로/제목/요약/본문을 색인하고
+// matchinfo('pcnalx')로 BM25 점수를 계산합니다. go-sqlite3 기본 빌드에는 FTS5(bm25())가 없어
+// 같은 공식을 Go에서 계산합니다.
+
+// bodyIndexVersion is the index.db user_version that has documents_body filled
+const bodyIndexVersion = 1
+
+// BM25 parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Column weights for BM25 (제목 일치를 본문 일치보다 높게)
+var (
+	bodyColumnWeights = []float64{0.5, 4, 2, 1} // path, title, summary, body
+	metaColumnWeights = []float64{0.5, 4, 2}    // path, title, summary
+)
+
+// snippetTokens is the size of a body snippet in tokens
+const snippetTokens = 16
+
+// bm25 computes the BM25 score of a row from FTS4 matchinfo('pcnalx').
+// 형식: p(구문 수), c(컬럼 수), n(행 수), a[c](평균 토큰), l[c](행 토큰), x[3*c*p](행 일치, 전체 일치, 일치 행 수)
+func bm25(matchinfo []byte, weights []float64) float64 {
+	if len(matchinfo) < 12 || len(matchinfo)%4 != 0 {
+		return 0
+	}
+	vals := make([]uint32, len(matchinfo)/4)
+	for i := range vals {
+		vals[i] = binary.NativeEndian.Uint32(matchinfo[i*4:])
+	}
+
+	p, c, n := int(vals[0]), int(vals[1]), float64(vals[2])
+	if len(vals) < 3+2*c+3*c*p {
+		return 0
+	}
+	avg := vals[3 : 3+c]
+	length := vals[3+c : 3+2*c]
+	x := vals[3+2*c:]
+
+	score := 0.0
+	for phrase := 0; phrase < p; phrase++ {
+		for col := 0; col < c; col++ {
+			weight := 1.0
+			if col < len(weights) {
+				weight = weights[col]
+			}
+			base := 3 * (phrase*c + col)
+			tf := float64(x[base])
+			docs := float64(x[base+2])
+			if tf == 0 || weight == 0 {
+				continue
+			}
+			idf := math.Log(1 + (n-docs+0.5)/(docs+0.5))
+			norm := 1.0
+			if avg[col] > 0 {
+				norm = 1 - bm25B + bm25B*float64(length[col])/float64(avg[col])
+			}
+			score += weight * idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+	return score
+}
+
+// documentBody returns the indexed body text of a markdown document (frontmatter 제외)
+func documentBody(content string) string {
+	if strings.HasPrefix(content, "---") {
+		parts := strings.SplitN(content, "---", 3)
+		if len(parts) == 3 {
+			return strings.TrimSpace(parts[2])
+		}
+	}
+	return content
+}
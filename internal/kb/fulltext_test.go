@@ -0,0 +1,165 @@
+package kb
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeVaultDoc(t *testing.T, vault, rel, content string) {
+	t.Helper()
+	path := filepath.Join(vault, rel)
+	os.MkdirAll(filepath.Dir(path), 0755)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearchBM25(t *testing.T) {
+	vault := t.TempDir()
+	writeVaultDoc(t, vault, filepath.Join(DomainsDir, "auth", "session.md"), `---
+title: Session handling
+summary: 로그인 이후 세션 관리
+---
+# Session handling
+
+세션 만료는 30분이며 refresh token으로 갱신한다.
+`)
+	writeVaultDoc(t, vault, filepath.Join(DomainsDir, "auth", "token.md"), `---
+title: Refresh token
+summary: 토큰 갱신 절차
+---
+# Refresh token
+
+refresh token은 세션마다 하나만 발급한다. 만료된 refresh token은 즉시 폐기하고 다시 로그인한다.
+`)
+	writeVaultDoc(t, vault, filepath.Join(DomainsDir, "billing", "invoice.md"), `---
+title: Invoice
+summary: 청구서 발행
+---
+# Invoice
+
+청구서 발행 규칙. 결제 실패 시 재시도한다.
+`)
+
+	svc := NewIndexService(vault)
+	if err := svc.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer svc.Close()
+	if _, err := svc.BuildIndex(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 메타데이터 검색: 본문에만 있는 단어는 찾지 않음
+	results, err := svc.Search("재시도", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Errorf("메타데이터 검색이 본문과 일치함: %+v", results)
+	}
+
+	results, err = svc.Search("재시도", &SearchOptions{Content: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || !strings.Contains(results[0].Snippet, "**재시도한다**") {
+		t.Fatalf("본문 검색 결과 = %+v", results)
+	}
+
+	// 제목 일치 문서가 본문에만 나오는 문서보다 앞
+	results, _ = svc.Search("refresh", &SearchOptions{Content: true})
+	if len(results) != 2 || results[0].Document.Title != "Refresh token" || results[0].Score <= results[1].Score {
+		t.Errorf("BM25 순위 = %+v", results)
+	}
+
+	// 토큰 예산을 넘는 결과는 잘림
+	first := svc.estimateTokens(results[0].Document) + len(results[0].Snippet)/4
+	results, _ = svc.Search("refresh", &SearchOptions{Content: true, TokenBudget: first + 1})
+	if len(results) != 1 {
+		t.Errorf("예산 적용 결과 = %d건", len(results))
+	}
+
+	// 삭제된 문서는 본문 색인에서도 제거
+	os.Remove(filepath.Join(vault, DomainsDir, "billing", "invoice.md"))
+	svc.UpdateIndex()
+	if results, _ = svc.Search("재시도", &SearchOptions{Content: true}); len(results) != 0 {
+		t.Errorf("삭제된 문서가 검색됨: %+v", results)
+	}
+}
+
+func TestBM25(t *testing.T) {
+	// p=1, c=1, n=10, a=[10], l=[5], x=[hits 2, total 4, docs 2]
+	info := func(hits uint32, length uint32) []byte {
+		vals := []uint32{1, 1, 10, 10, length, hits, 4, 2}
+		buf := make([]byte, len(vals)*4)
+		for i, v := range vals {
+			buf[i*4] = byte(v)
+		}
+		return buf
+	}
+	if bm25(info(2, 5), nil) <= bm25(info(1, 5), nil) {
+		t.Error("일치 횟수가 많을수록 점수가 높아야 함")
+	}
+	if bm25(info(1, 5), nil) <= bm25(info(1, 20), nil) {
+		t.Error("짧은 문서의 점수가 높아야 함")
+	}
+	if bm25(info(0, 5), nil) != 0 || bm25(nil, nil) != 0 {
+		t.Error("일치가 없으면 0")
+	}
+}
+
+func TestRefreshDocument(t *testing.T) {
+	vault := t.TempDir()
+	rel := filepath.Join(DomainsDir, "auth", "session.md")
+	writeVaultDoc(t, vault, rel, "---\ntitle: Session\ntags: [auth]\nreview_every: 30d\n---\n# Session\n\n세션 만료 규칙\n")
+
+	// 색인 전 vault는 건드리지 않음
+	if err := RefreshVaultDocument(vault, rel); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(vault, MetaDir, "index.db")); !os.IsNotExist(err) {
+		t.Fatalf("index.db가 생성됨: %v", err)
+	}
+
+	svc := NewIndexService(vault)
+	if err := svc.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer svc.Close()
+	if _, err := svc.BuildIndex(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 휴지통 이동처럼 파일이 사라지면 문서와 딸린 행을 모두 제거
+	trashed := filepath.Join(t.TempDir(), "session.md")
+	if err := os.Rename(filepath.Join(vault, rel), trashed); err != nil {
+		t.Fatal(err)
+	}
+	if err := RefreshVaultDocument(vault, rel); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range []string{"documents", "documents_body", "document_tags", "document_reviews"} {
+		var count int
+		svc.db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
+		if count != 0 {
+			t.Errorf("%s: %d rows left", table, count)
+		}
+	}
+	if results, _ := svc.Search("만료", &SearchOptions{Content: true}); len(results) != 0 {
+		t.Errorf("results = %+v", results)
+	}
+
+	// 복원하면 다시 검색됨
+	if err := os.Rename(trashed, filepath.Join(vault, rel)); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefreshDocument(rel); err != nil {
+		t.Fatal(err)
+	}
+	if doc, err := svc.GetByPath(rel); err != nil || doc.Title != "Session" {
+		t.Errorf("GetByPath = %+v, %v", doc, err)
+	}
+}
@@ -0,0 +1,186 @@
+package kb
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 링크 그래프 내보내기: JSON 외에 GraphML(yEd/Gephi), DOT(Graphviz), Mermaid로 쓰고,
+// 도메인/태그/연결 수로 부분 그래프를 골라 대시보드나 문서에 바로 그릴 수 있게 합니다.
+
+// Graph export formats
+const (
+	GraphFormatJSON    = "json"
+	GraphFormatGraphML = "graphml"
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+)
+
+// GraphFormats lists the supported export formats
+var GraphFormats = []string{GraphFormatJSON, GraphFormatGraphML, GraphFormatDOT, GraphFormatMermaid}
+
+// GraphFilter selects a subgraph (빈 값은 조건 없음)
+type GraphFilter struct {
+	Domain    string `json:"domain,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+	MinDegree int    `json:"min_degree,omitempty"` // in + out 링크 수 하한
+}
+
+// Filter returns the subgraph of nodes matching f and the edges between them.
+// 노드의 in/out 수는 전체 그래프 기준 값을 유지합니다.
+func (g *LinkGraph) Filter(f GraphFilter) *LinkGraph {
+	out := &LinkGraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	keep := make(map[string]bool)
+	for _, node := range g.Nodes {
+		if f.Domain != "" && !strings.EqualFold(node.Domain, f.Domain) {
+			continue
+		}
+		if f.Tag != "" && !slices.Contains(node.Tags, strings.TrimPrefix(f.Tag, "#")) {
+			continue
+		}
+		if node.InLinks+node.OutLinks < f.MinDegree {
+			continue
+		}
+		keep[node.ID] = true
+		out.Nodes = append(out.Nodes, node)
+	}
+	for _, edge := range g.Edges {
+		if keep[edge.Source] && keep[edge.Target] {
+			out.Edges = append(out.Edges, edge)
+		}
+	}
+	return out
+}
+
+// WriteGraph writes the graph in one of GraphFormats
+func WriteGraph(w io.Writer, g *LinkGraph, format string) error {
+	switch strings.ToLower(format) {
+	case "", GraphFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(g)
+	case GraphFormatGraphML:
+		return writeGraphML(w, g)
+	case GraphFormatDOT:
+		return writeDOT(w, g)
+	case GraphFormatMermaid:
+		return writeMermaid(w, g)
+	default:
+		return fmt.Errorf("지원하지 않는 그래프 형식: %s (%s)", format, strings.Join(GraphFormats, ", "))
+	}
+}
+
+func writeGraphML(w io.Writer, g *LinkGraph) error {
+	esc := func(s string) string {
+		var b strings.Builder
+		xml.EscapeText(&b, []byte(s))
+		return b.String()
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	for _, key := range []string{"label", "type", "domain", "tags"} {
+		fmt.Fprintf(&b, "  <key id=%q for=\"node\" attr.name=%q attr.type=\"string\"/>\n", key, key)
+	}
+	for _, key := range []string{"in_links", "out_links"} {
+		fmt.Fprintf(&b, "  <key id=%q for=\"node\" attr.name=%q attr.type=\"int\"/>\n", key, key)
+	}
+	b.WriteString(`  <graph id="kb" edgedefault="directed">` + "\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "    <node id=\"%s\">\n", esc(n.ID))
+		fmt.Fprintf(&b, "      <data key=\"label\">%s</data>\n", esc(n.Label))
+		if n.Type != "" {
+			fmt.Fprintf(&b, "      <data key=\"type\">%s</data>\n", esc(n.Type))
+		}
+		if n.Domain != "" {
+			fmt.Fprintf(&b, "      <data key=\"domain\">%s</data>\n", esc(n.Domain))
+		}
+		if len(n.Tags) > 0 {
+			fmt.Fprintf(&b, "      <data key=\"tags\">%s</data>\n", esc(strings.Join(n.Tags, ",")))
+		}
+		fmt.Fprintf(&b, "      <data key=\"in_links\">%d</data>\n      <data key=\"out_links\">%d</data>\n", n.InLinks, n.OutLinks)
+		b.WriteString("    </node>\n")
+	}
+	for i, e := range g.Edges {
+		fmt.Fprintf(&b, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\"/>\n", i, esc(e.Source), esc(e.Target))
+	}
+	b.WriteString("  </graph>\n</graphml>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeDOT(w io.Writer, g *LinkGraph) error {
+	quote := func(s string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(s) + `"`
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph kb {\n  rankdir=LR;\n  node [shape=box, style=rounded];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s", quote(n.ID), quote(n.Label))
+		if n.Type != "" {
+			fmt.Fprintf(&b, ", group=%s", quote(n.Type))
+		}
+		b.WriteString("];\n")
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", quote(e.Source), quote(e.Target))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMermaid(w io.Writer, g *LinkGraph) error {
+	// Mermaid ID에는 경로 문자를 쓸 수 없어 순번으로 바꿈
+	ids := make(map[string]string, len(g.Nodes))
+	label := strings.NewReplacer(`"`, "#quot;", "\n", " ")
+
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "  n%d[\"%s\"]\n", i, label.Replace(n.Label))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s --> %s\n", ids[e.Source], ids[e.Target])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// nodeMeta reads the domain and tags of a document for graph filtering
+func (s *LinkService) nodeMeta(relPath string) (string, []string) {
+	path := filepath.Join(s.vaultPath, relPath)
+	tags, _ := s.extractTags(path)
+	sort.Strings(tags)
+
+	var domain string
+	if data, err := os.ReadFile(path); err == nil && strings.HasPrefix(string(data), "---") {
+		if parts := strings.SplitN(string(data), "---", 3); len(parts) == 3 {
+			var fm struct {
+				Domain string `yaml:"domain"`
+			}
+			if yaml.Unmarshal([]byte(parts[1]), &fm) == nil {
+				domain = fm.Domain
+			}
+		}
+	}
+	// 프론트매터가 없으면 10-Domains/{domain}/ 경로에서
+	if domain == "" {
+		if rest, ok := strings.CutPrefix(filepath.ToSlash(relPath), DomainsDir+"/"); ok && strings.Contains(rest, "/") {
+			domain = strings.SplitN(rest, "/", 2)[0]
+		}
+	}
+	return domain, tags
+}
@@ -0,0 +1,88 @@
+package kb
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestLinkGraphFilterAndExport(t *testing.T) {
+	vault := t.TempDir()
+	writeVaultDoc(t, vault, "10-Domains/auth/login.md", "---\ntags: [security]\n---\n# Login \"flow\"\n[[session]] [[token]]\n")
+	writeVaultDoc(t, vault, "10-Domains/auth/session.md", "# Session\n#security [[token]]\n")
+	writeVaultDoc(t, vault, "10-Domains/auth/token.md", "# Token\n")
+	writeVaultDoc(t, vault, "20-Projects/web/app.md", "---\ndomain: web\n---\n# App\n[[login]]\n")
+
+	graph, err := NewLinkService(vault).BuildLinkGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graph.Nodes) != 4 || len(graph.Edges) != 4 {
+		t.Fatalf("nodes=%d edges=%d", len(graph.Nodes), len(graph.Edges))
+	}
+	byID := make(map[string]GraphNode)
+	for _, n := range graph.Nodes {
+		byID[n.ID] = n
+	}
+	if byID["10-Domains/auth/login"].Domain != "auth" || byID["20-Projects/web/app"].Domain != "web" {
+		t.Errorf("domains: %+v", byID)
+	}
+	if tags := byID["10-Domains/auth/session"].Tags; len(tags) != 1 || tags[0] != "security" {
+		t.Errorf("session tags = %v", tags)
+	}
+
+	// 도메인 필터: 양 끝이 남은 엣지만
+	auth := graph.Filter(GraphFilter{Domain: "auth"})
+	if len(auth.Nodes) != 3 || len(auth.Edges) != 3 {
+		t.Errorf("auth: nodes=%d edges=%d", len(auth.Nodes), len(auth.Edges))
+	}
+	if sec := graph.Filter(GraphFilter{Tag: "#security"}); len(sec.Nodes) != 2 || len(sec.Edges) != 1 {
+		t.Errorf("tag: nodes=%d edges=%d", len(sec.Nodes), len(sec.Edges))
+	}
+	if hub := graph.Filter(GraphFilter{MinDegree: 3}); len(hub.Nodes) != 1 || hub.Nodes[0].ID != "10-Domains/auth/login" {
+		t.Errorf("min degree: %+v", hub.Nodes)
+	}
+
+	var dot strings.Builder
+	if err := WriteGraph(&dot, graph, "dot"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(dot.String(), "digraph kb {") ||
+		!strings.Contains(dot.String(), `"10-Domains/auth/login" -> "10-Domains/auth/session";`) ||
+		!strings.Contains(dot.String(), `label="Login \"flow\""`) {
+		t.Errorf("dot:\n%s", dot.String())
+	}
+
+	var mermaid strings.Builder
+	if err := WriteGraph(&mermaid, graph, "mermaid"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(mermaid.String(), `n0["Login #quot;flow#quot;"]`) || !strings.Contains(mermaid.String(), "n3 --> n0") {
+		t.Errorf("mermaid:\n%s", mermaid.String())
+	}
+
+	var graphml strings.Builder
+	if err := WriteGraph(&graphml, graph, "graphml"); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Graph struct {
+			Nodes []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal([]byte(graphml.String()), &doc); err != nil {
+		t.Fatalf("graphml: %v", err)
+	}
+	if len(doc.Graph.Nodes) != 4 || len(doc.Graph.Edges) != 4 {
+		t.Errorf("graphml nodes=%d edges=%d", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+
+	if err := WriteGraph(&dot, graph, "png"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -3,9 +3,11 @@ package kb
 import (
 	"database/sql"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -39,8 +41,10 @@ type DocumentIndex struct {
 // SearchResult represents a search result
 type SearchResult struct {
 	Document   *DocumentIndex `json:"document"`
-	Score      float64        `json:"score"`
+	Score      float64        `json:"score"` // BM25 (높을수록 관련도 높음)
 	Highlights []string       `json:"highlights,omitempty"`
+	Snippet    string         `json:"snippet,omitempty"`    // 본문 검색 시 일치 부분 (**강조**)
+	Similarity float64        `json:"similarity,omitempty"` // 의미 검색 시 코사인 유사도
 }
 
 // SearchOptions represents search options
@@ -51,6 +55,9 @@ type SearchOptions struct {
 	Tags        []string `json:"tags,omitempty"`
 	Limit       int      `json:"limit,omitempty"`
 	TokenBudget int      `json:"token_budget,omitempty"`
+	Content     bool     `json:"content,omitempty"` // 제목/요약뿐 아니라 본문까지 검색
+	// SemanticWeight is the share of vector similarity in a semantic search score (0 = 기본 0.6)
+	SemanticWeight float64 `json:"semantic_weight,omitempty"`
 }
 
 // IndexStats represents indexing statistics
@@ -85,7 +92,37 @@ func (s *IndexService) Open() error {
 	s.db = db
 
 	// Initialize schema
-	return s.initSchema()
+	if err := s.initSchema(); err != nil {
+		return err
+	}
+	return s.backfillBody()
+}
+
+// backfillBody re-indexes documents indexed before full-text search or review metadata existed
+func (s *IndexService) backfillBody() error {
+	var version int
+	s.db.QueryRow("PRAGMA user_version").Scan(&version)
+	if version >= reviewIndexVersion {
+		return nil
+	}
+
+	rows, err := s.db.Query("SELECT path FROM documents")
+	if err != nil {
+		return err
+	}
+	var paths []string
+	for rows.Next() {
+		var path string
+		rows.Scan(&path)
+		paths = append(paths, path)
+	}
+	rows.Close()
+
+	for _, path := range paths {
+		s.indexDocument(filepath.Join(s.vaultPath, path))
+	}
+	_, err = s.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", reviewIndexVersion))
+	return err
 }
 
 // Close closes the database connection
@@ -148,6 +185,48 @@ func (s *IndexService) initSchema() error {
 		DELETE FROM documents_fts WHERE docid = old.id;
 	END;
 
+	CREATE VIRTUAL TABLE IF NOT EXISTS documents_body USING fts4(
+		path,
+		title,
+		summary,
+		body,
+		tokenize=unicode61
+	);
+
+	CREATE TRIGGER IF NOT EXISTS documents_body_ad AFTER DELETE ON documents BEGIN
+		DELETE FROM documents_body WHERE docid = old.id;
+	END;
+
+	CREATE TABLE IF NOT EXISTS document_embeddings (
+		doc_id INTEGER PRIMARY KEY,
+		model TEXT NOT NULL,
+		dim INTEGER NOT NULL,
+		vector BLOB NOT NULL,
+		content_hash TEXT NOT NULL,
+		embedded_at TEXT NOT NULL
+	);
+
+	CREATE TRIGGER IF NOT EXISTS documents_embed_ad AFTER DELETE ON documents BEGIN
+		DELETE FROM document_embeddings WHERE doc_id = old.id;
+	END;
+
+	CREATE TABLE IF NOT EXISTS document_reviews (
+		doc_id INTEGER PRIMARY KEY,
+		review_every TEXT,
+		reviewed_at TEXT,
+		critical INTEGER NOT NULL DEFAULT 0,
+		owner TEXT
+	);
+
+	CREATE TRIGGER IF NOT EXISTS documents_review_ad AFTER DELETE ON documents BEGIN
+		DELETE FROM document_reviews WHERE doc_id = old.id;
+	END;
+
+	CREATE TABLE IF NOT EXISTS index_meta (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);
+
 	CREATE TRIGGER IF NOT EXISTS documents_au AFTER UPDATE ON documents BEGIN
 		DELETE FROM documents_fts WHERE docid = old.id;
 		INSERT INTO documents_fts(docid, path, title, summary)
@@ -295,6 +374,44 @@ func (s *IndexService) UpdateIndex() (int, int, error) {
 	return added, updated, nil
 }
 
+// RefreshDocument re-indexes a vault-relative document, or removes it from the index when the file is gone
+// (휴지통 이동/복원 등 UpdateIndex를 기다리지 않고 바로 반영할 때 사용)
+func (s *IndexService) RefreshDocument(relPath string) error {
+	relPath = filepath.Clean(relPath)
+	if _, err := os.Stat(filepath.Join(s.vaultPath, relPath)); os.IsNotExist(err) {
+		// 본문, 임베딩, 검토 행은 트리거가 정리 (태그/별칭은 외래 키가 꺼져 있어 직접 삭제)
+		var docID int64
+		if err := s.db.QueryRow("SELECT id FROM documents WHERE path = ?", relPath).Scan(&docID); err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+		s.db.Exec("DELETE FROM document_tags WHERE doc_id = ?", docID)
+		s.db.Exec("DELETE FROM document_aliases WHERE doc_id = ?", docID)
+		_, err := s.db.Exec("DELETE FROM documents WHERE id = ?", docID)
+		return err
+	}
+	_, err := s.indexDocument(filepath.Join(s.vaultPath, relPath))
+	return err
+}
+
+// RefreshVaultDocument applies RefreshDocument to the vault index; 아직 색인하지 않은 vault는 건너뜀
+func RefreshVaultDocument(vaultPath, relPath string) error {
+	svc := NewIndexService(vaultPath)
+	if _, err := os.Stat(svc.dbPath); os.IsNotExist(err) {
+		return nil
+	}
+	if err := svc.Open(); err != nil {
+		return err
+	}
+	defer svc.Close()
+	if err := svc.RefreshDocument(relPath); err != nil {
+		return fmt.Errorf("KB 인덱스 갱신 실패: %w", err)
+	}
+	return nil
+}
+
 func (s *IndexService) indexDocument(path string) (*DocumentIndex, error) {
 	relPath, _ := filepath.Rel(s.vaultPath, path)
 
@@ -370,7 +487,7 @@ func (s *IndexService) indexDocument(path string) (*DocumentIndex, error) {
 	}
 
 	// Upsert document
-	result, err := s.db.Exec(`
+	_, err = s.db.Exec(`
 		INSERT INTO documents (path, title, type, status, domain, summary, created_at, updated_at, indexed_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(path) DO UPDATE SET
@@ -389,10 +506,10 @@ func (s *IndexService) indexDocument(path string) (*DocumentIndex, error) {
 		return nil, err
 	}
 
-	// Get document ID
-	docID, _ := result.LastInsertId()
-	if docID == 0 {
-		s.db.QueryRow("SELECT id FROM documents WHERE path = ?", doc.Path).Scan(&docID)
+	// Get document ID (upsert가 UPDATE로 끝나면 LastInsertId는 이전 INSERT의 rowid를 돌려줌)
+	var docID int64
+	if err := s.db.QueryRow("SELECT id FROM documents WHERE path = ?", doc.Path).Scan(&docID); err != nil {
+		return nil, err
 	}
 	doc.ID = docID
 
@@ -408,6 +525,21 @@ func (s *IndexService) indexDocument(path string) (*DocumentIndex, error) {
 		s.db.Exec("INSERT OR IGNORE INTO document_aliases (doc_id, alias) VALUES (?, ?)", docID, alias)
 	}
 
+	// Update review metadata
+	s.db.Exec("DELETE FROM document_reviews WHERE doc_id = ?", docID)
+	if review := reviewMetaOf(content); review != nil {
+		s.db.Exec(`INSERT INTO document_reviews (doc_id, review_every, reviewed_at, critical, owner) VALUES (?, ?, ?, ?, ?)`,
+			docID, review.ReviewEvery, review.ReviewedAt, review.Critical, review.Owner)
+	}
+
+	// Update full-text body
+	s.db.Exec("DELETE FROM documents_body WHERE docid = ?", docID)
+	if _, err := s.db.Exec(`
+		INSERT INTO documents_body (docid, path, title, summary, body) VALUES (?, ?, ?, ?, ?)
+	`, docID, doc.Path, doc.Title, doc.Summary, documentBody(content)); err != nil {
+		return nil, err
+	}
+
 	return doc, nil
 }
 
@@ -487,7 +619,8 @@ func (s *IndexService) extractSummary(content string) string {
 	return result
 }
 
-// Search searches documents
+// Search searches documents ranked by BM25.
+// Content가 true면 본문까지 검색하고 일치 부분을 Snippet으로 반환합니다.
 func (s *IndexService) Search(query string, opts *SearchOptions) ([]*SearchResult, error) {
 	if opts == nil {
 		opts = &SearchOptions{}
@@ -496,57 +629,43 @@ func (s *IndexService) Search(query string, opts *SearchOptions) ([]*SearchResul
 		opts.Limit = 20
 	}
 
-	var results []*SearchResult
 	var args []interface{}
 
 	// Build query
-	sqlQuery := `
+	table, weights, snippetExpr := "documents_fts", metaColumnWeights, "''"
+	if opts.Content {
+		table, weights = "documents_body", bodyColumnWeights
+		snippetExpr = fmt.Sprintf("snippet(documents_body, '**', '**', '…', 3, %d)", snippetTokens)
+	}
+	sqlQuery := fmt.Sprintf(`
 		SELECT d.id, d.path, d.title, d.type, d.status, d.domain, d.summary,
-		       d.created_at, d.updated_at, d.indexed_at
+		       d.created_at, d.updated_at, d.indexed_at,
+		       matchinfo(%[1]s, 'pcnalx'), %[2]s
 		FROM documents d
-		JOIN documents_fts fts ON d.id = fts.docid
-		WHERE documents_fts MATCH ?
-	`
+		JOIN %[1]s ON d.id = %[1]s.docid
+		WHERE %[1]s MATCH ?
+	`, table, snippetExpr)
 	args = append(args, s.buildFTSQuery(query))
 
-	// Add filters
-	if opts.Type != "" {
-		sqlQuery += " AND d.type = ?"
-		args = append(args, opts.Type)
-	}
-	if opts.Domain != "" {
-		sqlQuery += " AND d.domain = ?"
-		args = append(args, opts.Domain)
-	}
-	if opts.Status != "" {
-		sqlQuery += " AND d.status = ?"
-		args = append(args, opts.Status)
-	}
-	if len(opts.Tags) > 0 {
-		sqlQuery += " AND d.id IN (SELECT doc_id FROM document_tags WHERE tag IN (" +
-			strings.Repeat("?,", len(opts.Tags)-1) + "?))"
-		for _, tag := range opts.Tags {
-			args = append(args, tag)
-		}
-	}
-
-	sqlQuery += " LIMIT ?"
-	args = append(args, opts.Limit)
+	filter, filterArgs := searchFilter(opts)
+	sqlQuery += filter
+	args = append(args, filterArgs...)
 
 	rows, err := s.db.Query(sqlQuery, args...)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	totalTokens := 0
-	resultIdx := 0
+	// 점수는 모든 일치 행을 읽은 뒤 계산해 정렬
+	var ranked []*SearchResult
 	for rows.Next() {
 		var doc DocumentIndex
 		var createdAt, updatedAt sql.NullString
+		var matchinfo []byte
+		var snippet string
 
 		err := rows.Scan(&doc.ID, &doc.Path, &doc.Title, &doc.Type, &doc.Status,
-			&doc.Domain, &doc.Summary, &createdAt, &updatedAt, &doc.IndexedAt)
+			&doc.Domain, &doc.Summary, &createdAt, &updatedAt, &doc.IndexedAt, &matchinfo, &snippet)
 		if err != nil {
 			continue
 		}
@@ -558,6 +677,55 @@ func (s *IndexService) Search(query string, opts *SearchOptions) ([]*SearchResul
 			doc.UpdatedAt = updatedAt.String
 		}
 
+		ranked = append(ranked, &SearchResult{
+			Document: &doc,
+			Score:    bm25(matchinfo, weights),
+			Snippet:  strings.Join(strings.Fields(snippet), " "),
+		})
+	}
+	rows.Close()
+
+	return s.finishResults(query, ranked, opts), nil
+}
+
+// searchFilter builds the " AND ..." clause for the type/domain/status/tag filters on documents d
+func searchFilter(opts *SearchOptions) (string, []interface{}) {
+	var clause string
+	var args []interface{}
+	if opts.Type != "" {
+		clause += " AND d.type = ?"
+		args = append(args, opts.Type)
+	}
+	if opts.Domain != "" {
+		clause += " AND d.domain = ?"
+		args = append(args, opts.Domain)
+	}
+	if opts.Status != "" {
+		clause += " AND d.status = ?"
+		args = append(args, opts.Status)
+	}
+	if len(opts.Tags) > 0 {
+		clause += " AND d.id IN (SELECT doc_id FROM document_tags WHERE tag IN (" +
+			strings.Repeat("?,", len(opts.Tags)-1) + "?))"
+		for _, tag := range opts.Tags {
+			args = append(args, tag)
+		}
+	}
+	return clause, args
+}
+
+// finishResults sorts scored results, applies the limit, loads tags/aliases and trims to the token budget
+func (s *IndexService) finishResults(query string, ranked []*SearchResult, opts *SearchOptions) []*SearchResult {
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+	if len(ranked) > opts.Limit {
+		ranked = ranked[:opts.Limit]
+	}
+
+	var results []*SearchResult
+	totalTokens := 0
+	for _, result := range ranked {
+		doc := result.Document
+
 		// Load tags
 		tagRows, _ := s.db.Query("SELECT tag FROM document_tags WHERE doc_id = ?", doc.ID)
 		if tagRows != nil {
@@ -580,16 +748,12 @@ func (s *IndexService) Search(query string, opts *SearchOptions) ([]*SearchResul
 			aliasRows.Close()
 		}
 
-		result := &SearchResult{
-			Document:   &doc,
-			Score:      float64(100 - resultIdx), // Simple ranking by order
-			Highlights: s.generateHighlights(query, &doc),
-		}
-		resultIdx++
+		result.Score = math.Round(result.Score*1000) / 1000
+		result.Highlights = s.generateHighlights(query, doc)
 
 		// Check token budget
 		if opts.TokenBudget > 0 {
-			docTokens := s.estimateTokens(&doc)
+			docTokens := s.estimateTokens(doc) + len(result.Snippet)/4
 			if totalTokens+docTokens > opts.TokenBudget {
 				break
 			}
@@ -599,7 +763,7 @@ func (s *IndexService) Search(query string, opts *SearchOptions) ([]*SearchResul
 		results = append(results, result)
 	}
 
-	return results, nil
+	return results
 }
 
 func (s *IndexService) buildFTSQuery(query string) string {
@@ -758,6 +922,17 @@ func (s *IndexService) SearchByTag(tag string, limit int) ([]*DocumentIndex, err
 	return docs, nil
 }
 
+// GetByPath returns the indexed document at a vault-relative path
+func (s *IndexService) GetByPath(path string) (*DocumentIndex, error) {
+	var docID int64
+	err := s.db.QueryRow("SELECT id FROM documents WHERE path = ?", path).Scan(&docID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getDocumentByID(docID)
+}
+
 func (s *IndexService) getDocumentByID(id int64) (*DocumentIndex, error) {
 	doc := &DocumentIndex{}
 	var createdAt, updatedAt sql.NullString
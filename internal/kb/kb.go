@@ -11,9 +11,10 @@ import (
 
 // Config represents KB configuration
 type Config struct {
-	VaultPath string `yaml:"vault_path"`
-	Version   string `yaml:"version"`
-	CreatedAt string `yaml:"created_at"`
+	VaultPath string                 `yaml:"vault_path"`
+	Version   string                 `yaml:"version"`
+	CreatedAt string                 `yaml:"created_at"`
+	Sections  map[string]SectionMeta `yaml:"sections,omitempty"` // 섹션(디렉토리)별 기본 검토 주기/담당/접근 수준
 }
 
 // Service handles Knowledge Base operations
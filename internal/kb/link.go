@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -23,10 +24,11 @@ type Link struct {
 
 // BrokenLink represents a broken link
 type BrokenLink struct {
-	Source     string `json:"source"`
-	Target     string `json:"target"`
-	Line       int    `json:"line"`
-	Suggestion string `json:"suggestion,omitempty"`
+	Source     string  `json:"source"`
+	Target     string  `json:"target"`
+	Line       int     `json:"line"`
+	Suggestion string  `json:"suggestion,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"` // 제안 신뢰도 (fixConfidence 이상이면 --fix 대상)
 }
 
 // LinkCheckResult represents link check results
@@ -44,11 +46,13 @@ type LinkGraph struct {
 
 // GraphNode represents a node in the link graph
 type GraphNode struct {
-	ID       string `json:"id"`
-	Label    string `json:"label"`
-	Type     string `json:"type,omitempty"`
-	InLinks  int    `json:"in_links"`
-	OutLinks int    `json:"out_links"`
+	ID       string   `json:"id"`
+	Label    string   `json:"label"`
+	Type     string   `json:"type,omitempty"`
+	Domain   string   `json:"domain,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	InLinks  int      `json:"in_links"`
+	OutLinks int      `json:"out_links"`
 }
 
 // GraphEdge represents an edge in the link graph
@@ -121,12 +125,7 @@ func (s *LinkService) CheckLinks() (*LinkCheckResult, error) {
 				// Resolve link target
 				targetPath := s.resolveLink(link.Target, relPath, fileIndex)
 				if targetPath == "" {
-					result.BrokenLinks = append(result.BrokenLinks, &BrokenLink{
-						Source:     relPath,
-						Target:     link.Target,
-						Line:       link.Line,
-						Suggestion: s.suggestTarget(link.Target, fileIndex),
-					})
+					result.BrokenLinks = append(result.BrokenLinks, s.brokenLink(relPath, link, fileIndex))
 				} else {
 					result.ValidLinks++
 				}
@@ -140,8 +139,12 @@ func (s *LinkService) CheckLinks() (*LinkCheckResult, error) {
 }
 
 func (s *LinkService) buildFileIndex() map[string]string {
-	index := make(map[string]string)
+	return fileIndexOf(s.documentPaths())
+}
 
+// documentPaths lists the vault-relative paths of all markdown files in walk order
+func (s *LinkService) documentPaths() []string {
+	var paths []string
 	filepath.Walk(s.vaultPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil || info.IsDir() {
 			return nil
@@ -151,20 +154,26 @@ func (s *LinkService) buildFileIndex() map[string]string {
 		}
 
 		relPath, _ := filepath.Rel(s.vaultPath, path)
+		paths = append(paths, relPath)
+		return nil
+	})
+	return paths
+}
 
+// fileIndexOf maps link targets (path without .md, and file name) to vault-relative paths
+func fileIndexOf(paths []string) map[string]string {
+	index := make(map[string]string)
+	for _, relPath := range paths {
 		// Index by full path (without .md)
 		pathNoExt := strings.TrimSuffix(relPath, ".md")
 		index[pathNoExt] = relPath
 
 		// Index by filename only
-		name := strings.TrimSuffix(info.Name(), ".md")
+		name := strings.TrimSuffix(filepath.Base(relPath), ".md")
 		if _, exists := index[name]; !exists {
 			index[name] = relPath
 		}
-
-		return nil
-	})
-
+	}
 	return index
 }
 
@@ -238,6 +247,27 @@ func (s *LinkService) resolveLink(target, sourcePath string, fileIndex map[strin
 	return ""
 }
 
+// Resolver returns a function resolving wikilink targets written in sourcePath to vault-relative paths
+func (s *LinkService) Resolver(sourcePath string) func(target string) (string, bool) {
+	fileIndex := s.buildFileIndex()
+	return func(target string) (string, bool) {
+		path := s.resolveLink(target, sourcePath, fileIndex)
+		return path, path != ""
+	}
+}
+
+// brokenLink describes a link that does not resolve, with the best replacement target
+func (s *LinkService) brokenLink(source string, link *Link, fileIndex map[string]string) *BrokenLink {
+	broken := &BrokenLink{Source: source, Target: link.Target, Line: link.Line}
+	base, _ := splitAnchor(link.Target)
+	if target := confidentTarget(base, fileIndex); target != "" {
+		broken.Suggestion, broken.Confidence = target, 1
+	} else if broken.Suggestion = s.suggestTarget(base, fileIndex); broken.Suggestion != "" {
+		broken.Confidence = 0.5
+	}
+	return broken
+}
+
 func (s *LinkService) suggestTarget(target string, fileIndex map[string]string) string {
 	targetLower := strings.ToLower(target)
 	var bestMatch string
@@ -271,6 +301,56 @@ func (s *LinkService) suggestTarget(target string, fileIndex map[string]string)
 	return ""
 }
 
+// RecheckLinks validates the links of the given documents and finds links broken by removed documents.
+// 전체 vault를 검사하지 않고 변경된 문서만 다시 확인합니다 (vault 상대 경로).
+func (s *LinkService) RecheckLinks(touched, removed []string) (*LinkCheckResult, error) {
+	result := &LinkCheckResult{}
+	fileIndex := s.buildFileIndex()
+	checked := make(map[string]bool)
+
+	check := func(relPath string, onlyTargets map[string]bool) {
+		links, err := s.extractLinks(filepath.Join(s.vaultPath, relPath))
+		if err != nil {
+			return
+		}
+		for _, link := range links {
+			target := strings.Split(link.Target, "#")[0]
+			if onlyTargets != nil && !onlyTargets[target] && !onlyTargets[filepath.Base(target)] {
+				continue
+			}
+			result.TotalLinks++
+			if s.resolveLink(link.Target, relPath, fileIndex) != "" {
+				result.ValidLinks++
+				continue
+			}
+			result.BrokenLinks = append(result.BrokenLinks, s.brokenLink(relPath, link, fileIndex))
+		}
+	}
+
+	for _, relPath := range touched {
+		checked[relPath] = true
+		check(relPath, nil)
+	}
+	if len(removed) == 0 {
+		return result, nil
+	}
+
+	// 삭제된 문서를 가리키던 링크 (경로 또는 파일 이름)
+	targets := make(map[string]bool)
+	for _, relPath := range removed {
+		name := strings.TrimSuffix(relPath, ".md")
+		targets[name] = true
+		targets[filepath.Base(name)] = true
+	}
+	for _, relPath := range fileIndex {
+		if !checked[relPath] {
+			checked[relPath] = true
+			check(relPath, targets)
+		}
+	}
+	return result, nil
+}
+
 // BuildLinkGraph builds the link graph
 func (s *LinkService) BuildLinkGraph() (*LinkGraph, error) {
 	graph := &LinkGraph{}
@@ -350,14 +430,18 @@ func (s *LinkService) BuildLinkGraph() (*LinkGraph, error) {
 			label = title
 		}
 
+		domain, tags := s.nodeMeta(nodeID + ".md")
 		graph.Nodes = append(graph.Nodes, GraphNode{
 			ID:       nodeID,
 			Label:    label,
 			Type:     nodeType,
+			Domain:   domain,
+			Tags:     tags,
 			InLinks:  inLinks[nodeID],
 			OutLinks: outLinks[nodeID],
 		})
 	}
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
 
 	return graph, nil
 }
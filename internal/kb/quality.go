@@ -45,6 +45,15 @@ type QualityOptions struct {
 	RequiredFields []string `json:"required_fields,omitempty"`
 }
 
+// lintTypeRequiredFields are the type-specific required fields checked by lint
+var lintTypeRequiredFields = map[string][]string{
+	"port":    {"status", "priority"},
+	"adr":     {"status", "decision_date"},
+	"concept": {"domain"},
+	"guide":   {},
+	"session": {"date"},
+}
+
 // NewQualityService creates a new quality service
 func NewQualityService(vaultPath string) *QualityService {
 	return &QualityService{
@@ -188,17 +197,8 @@ func (q *QualityService) checkRequiredFields(result *QualityResult, meta map[str
 		result.addWarning("frontmatter", "warning", "type 필드가 없습니다", "문서 타입을 지정하세요 (예: type: concept)")
 	}
 
-	// Type-specific required fields
-	typeRequired := map[string][]string{
-		"port":      {"status", "priority"},
-		"adr":       {"status", "decision_date"},
-		"concept":   {"domain"},
-		"guide":     {},
-		"session":   {"date"},
-	}
-
 	requiredFields := append(baseRequired, opts.RequiredFields...)
-	if typeReq, ok := typeRequired[docType]; ok {
+	if typeReq, ok := lintTypeRequiredFields[docType]; ok {
 		requiredFields = append(requiredFields, typeReq...)
 	}
 
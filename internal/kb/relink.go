@@ -0,0 +1,353 @@
+package kb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 링크 자동 수정: 신뢰도 높은 제안(파일 이름이 대소문자/공백/구분자만 다른 유일한 문서)을 적용하고,
+// 문서를 옮길 때 그 문서를 가리키던 위키링크를 모두 고쳐 씁니다.
+// 파일은 임시 파일에 먼저 쓴 뒤 한 번에 교체하고, 색인의 경로 변경은 같은 트랜잭션 안에서 처리해
+// 실패하면 원래 상태로 되돌립니다.
+
+// fixConfidence is the minimum suggestion confidence applied by FixLinks
+const fixConfidence = 0.9
+
+// relinkTempSuffix is the suffix of files staged before replacing
+const relinkTempSuffix = ".pal-tmp"
+
+// LinkFix is a wikilink rewritten by FixLinks or MoveDocument
+type LinkFix struct {
+	Source string `json:"source"`
+	Line   int    `json:"line"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// LinkFixResult reports the result of FixLinks
+type LinkFixResult struct {
+	Fixed   []*LinkFix    `json:"fixed"`
+	Skipped []*BrokenLink `json:"skipped,omitempty"` // 제안이 없거나 신뢰도가 낮음
+	DryRun  bool          `json:"dry_run,omitempty"`
+}
+
+// MoveResult reports the result of MoveDocument
+type MoveResult struct {
+	From     string     `json:"from"`
+	To       string     `json:"to"`
+	Relinked []*LinkFix `json:"relinked"`
+	Indexed  bool       `json:"indexed"` // 색인의 경로도 바꿈
+	DryRun   bool       `json:"dry_run,omitempty"`
+}
+
+// FixLinks rewrites broken links whose suggestion is confident enough (dryRun이면 계획만 반환)
+func (s *LinkService) FixLinks(dryRun bool) (*LinkFixResult, error) {
+	check, err := s.CheckLinks()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LinkFixResult{Fixed: []*LinkFix{}, DryRun: dryRun}
+	fixes := make(map[string]map[string]string) // source → 줄/대상 → 새 대상
+	for _, broken := range check.BrokenLinks {
+		if broken.Suggestion == "" || broken.Confidence < fixConfidence {
+			result.Skipped = append(result.Skipped, broken)
+			continue
+		}
+		_, anchor := splitAnchor(broken.Target)
+		to := broken.Suggestion + anchor
+		if fixes[broken.Source] == nil {
+			fixes[broken.Source] = make(map[string]string)
+		}
+		fixes[broken.Source][linkKey(broken.Line, broken.Target)] = to
+		result.Fixed = append(result.Fixed, &LinkFix{Source: broken.Source, Line: broken.Line, From: broken.Target, To: to})
+	}
+	if dryRun || len(fixes) == 0 {
+		return result, nil
+	}
+
+	updates := make(map[string]string)
+	originals := make(map[string]string)
+	for source, byKey := range fixes {
+		data, err := os.ReadFile(filepath.Join(s.vaultPath, source))
+		if err != nil {
+			return nil, fmt.Errorf("문서 읽기 실패: %w", err)
+		}
+		content, _ := rewriteLinks(string(data), func(line int, target string) (string, bool) {
+			to, ok := byKey[linkKey(line, target)]
+			return to, ok
+		})
+		updates[source] = content
+		originals[source] = string(data)
+	}
+
+	if err := s.replaceFiles(updates, originals, nil); err != nil {
+		return nil, err
+	}
+	s.reindexFiles(sortedMapKeys(updates))
+	return result, nil
+}
+
+// MoveDocument moves a document within the vault and rewrites every wikilink whose target changes.
+// 경로는 vault 상대 경로이며 .md는 생략할 수 있습니다.
+func (s *LinkService) MoveDocument(from, to string, dryRun bool) (*MoveResult, error) {
+	from, err := s.docPath(from)
+	if err != nil {
+		return nil, err
+	}
+	to, err = s.docPath(to)
+	if err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, fmt.Errorf("원래 경로와 같습니다: %s", from)
+	}
+	if _, err := os.Stat(filepath.Join(s.vaultPath, from)); err != nil {
+		return nil, fmt.Errorf("문서를 찾을 수 없습니다: %s", from)
+	}
+	if _, err := os.Stat(filepath.Join(s.vaultPath, to)); err == nil {
+		return nil, fmt.Errorf("대상 문서가 이미 있습니다: %s", to)
+	}
+
+	paths := s.documentPaths()
+	before := fileIndexOf(paths)
+	moved := make([]string, len(paths))
+	for i, p := range paths {
+		if p == from {
+			p = to
+		}
+		moved[i] = p
+	}
+	after := fileIndexOf(moved)
+
+	result := &MoveResult{From: from, To: to, Relinked: []*LinkFix{}, DryRun: dryRun}
+	updates := make(map[string]string)
+	originals := make(map[string]string)
+	for _, relPath := range paths {
+		data, err := os.ReadFile(filepath.Join(s.vaultPath, relPath))
+		if err != nil {
+			continue
+		}
+		source := relPath
+		if relPath == from {
+			source = to
+		}
+		content, n := rewriteLinks(string(data), func(line int, target string) (string, bool) {
+			base, anchor := splitAnchor(target)
+			if base == "" {
+				return "", false
+			}
+			old := s.resolveLink(base, relPath, before)
+			if old == "" {
+				return "", false // 이미 깨진 링크는 link check --fix 대상
+			}
+			want := old
+			if old == from {
+				want = to
+			}
+			if s.resolveLink(base, source, after) == want {
+				return "", false
+			}
+			text := linkTarget(want, after, strings.Contains(base, "/")) + anchor
+			result.Relinked = append(result.Relinked, &LinkFix{Source: source, Line: line, From: target, To: text})
+			return text, true
+		})
+		if n > 0 {
+			updates[source] = content
+			originals[source] = string(data)
+		}
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(s.vaultPath, to)), 0755); err != nil {
+		return nil, fmt.Errorf("디렉토리 생성 실패: %w", err)
+	}
+
+	// 색인 경로 변경은 파일 교체가 끝난 뒤 커밋 (실패하면 파일도 되돌림)
+	var commit func() error
+	index := NewIndexService(s.vaultPath)
+	if _, err := os.Stat(index.dbPath); err == nil {
+		if err := index.Open(); err != nil {
+			return nil, err
+		}
+		defer index.Close()
+		tx, err := index.db.Begin()
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+		if _, err := tx.Exec("UPDATE documents SET path = ? WHERE path = ?", to, from); err != nil {
+			return nil, fmt.Errorf("색인 경로 변경 실패: %w", err)
+		}
+		if _, err := tx.Exec("UPDATE documents_body SET path = ? WHERE path = ?", to, from); err != nil {
+			return nil, fmt.Errorf("색인 경로 변경 실패: %w", err)
+		}
+		commit = tx.Commit
+	}
+
+	if err := s.replaceFiles(updates, originals, &[2]string{from, to}); err != nil {
+		return nil, err
+	}
+	if commit != nil {
+		if err := commit(); err != nil {
+			s.restoreFiles(updates, originals, &[2]string{from, to})
+			return nil, fmt.Errorf("색인 경로 변경 실패: %w", err)
+		}
+		result.Indexed = true
+		// 링크를 고친 문서의 본문 색인 갱신
+		for _, relPath := range append(sortedMapKeys(updates), to) {
+			index.indexDocument(filepath.Join(s.vaultPath, relPath))
+		}
+	}
+	return result, nil
+}
+
+// replaceFiles writes updated contents (moving move[0] to move[1] first) so that either all or none change
+func (s *LinkService) replaceFiles(updates, originals map[string]string, move *[2]string) error {
+	staged := make([]string, 0, len(updates))
+	cleanup := func() {
+		for _, relPath := range staged {
+			os.Remove(filepath.Join(s.vaultPath, relPath) + relinkTempSuffix)
+		}
+	}
+	for _, relPath := range sortedMapKeys(updates) {
+		path := filepath.Join(s.vaultPath, relPath)
+		if err := os.WriteFile(path+relinkTempSuffix, []byte(updates[relPath]), 0644); err != nil {
+			cleanup()
+			return fmt.Errorf("문서 쓰기 실패: %w", err)
+		}
+		staged = append(staged, relPath)
+	}
+
+	if move != nil {
+		if err := os.Rename(filepath.Join(s.vaultPath, move[0]), filepath.Join(s.vaultPath, move[1])); err != nil {
+			cleanup()
+			return fmt.Errorf("문서 이동 실패: %w", err)
+		}
+	}
+	for _, relPath := range staged {
+		path := filepath.Join(s.vaultPath, relPath)
+		if err := os.Rename(path+relinkTempSuffix, path); err != nil {
+			cleanup()
+			s.restoreFiles(updates, originals, move)
+			return fmt.Errorf("문서 쓰기 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+// restoreFiles puts back the original contents and location after a failed replaceFiles
+func (s *LinkService) restoreFiles(updates, originals map[string]string, move *[2]string) {
+	for relPath := range updates {
+		os.WriteFile(filepath.Join(s.vaultPath, relPath), []byte(originals[relPath]), 0644)
+	}
+	if move != nil {
+		os.Rename(filepath.Join(s.vaultPath, move[1]), filepath.Join(s.vaultPath, move[0]))
+	}
+}
+
+// reindexFiles refreshes the index rows of rewritten documents when the vault has an index
+func (s *LinkService) reindexFiles(paths []string) {
+	index := NewIndexService(s.vaultPath)
+	if _, err := os.Stat(index.dbPath); err != nil {
+		return
+	}
+	if err := index.Open(); err != nil {
+		return
+	}
+	defer index.Close()
+	for _, relPath := range paths {
+		index.indexDocument(filepath.Join(s.vaultPath, relPath))
+	}
+}
+
+// docPath cleans a vault-relative document path and adds .md
+func (s *LinkService) docPath(path string) (string, error) {
+	clean := filepath.Clean(strings.TrimSpace(path))
+	if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("vault 상대 경로가 아닙니다: %s", path)
+	}
+	if filepath.Ext(clean) != ".md" {
+		clean += ".md"
+	}
+	return clean, nil
+}
+
+// rewriteLinks replaces wikilink targets outside code blocks; fn receives the 1-based line and the target
+func rewriteLinks(content string, fn func(line int, target string) (string, bool)) (string, int) {
+	lines := strings.Split(content, "\n")
+	changed := 0
+	inCodeBlock := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+		matches := WikilinkRegex.FindAllStringSubmatchIndex(line, -1)
+		// 뒤에서부터 바꿔야 앞쪽 인덱스가 유지됨
+		for j := len(matches) - 1; j >= 0; j-- {
+			m := matches[j]
+			target := line[m[2]:m[3]]
+			if to, ok := fn(i+1, target); ok && to != target {
+				line = line[:m[2]] + to + line[m[3]:]
+				changed++
+			}
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n"), changed
+}
+
+// confidentTarget returns the only document whose name matches target ignoring case and separators
+func confidentTarget(target string, fileIndex map[string]string) string {
+	key := normalizeLinkName(filepath.Base(target))
+	if key == "" {
+		return ""
+	}
+	var match string
+	for _, relPath := range fileIndex {
+		if normalizeLinkName(strings.TrimSuffix(filepath.Base(relPath), ".md")) != key {
+			continue
+		}
+		if match != "" && match != relPath {
+			return "" // 후보가 여럿이면 확신할 수 없음
+		}
+		match = relPath
+	}
+	if match == "" {
+		return ""
+	}
+	return linkTarget(match, fileIndex, strings.Contains(target, "/"))
+}
+
+// linkTarget is the wikilink text for a document: 파일 이름으로 찾아지면 이름만, 아니면 전체 경로
+func linkTarget(relPath string, fileIndex map[string]string, fullPath bool) string {
+	noExt := strings.TrimSuffix(filepath.ToSlash(relPath), ".md")
+	if name := filepath.Base(noExt); !fullPath && fileIndex[name] == relPath {
+		return name
+	}
+	return noExt
+}
+
+func normalizeLinkName(name string) string {
+	return strings.NewReplacer(" ", "-", "_", "-").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// splitAnchor splits "target#heading" into the target and "#heading"
+func splitAnchor(target string) (string, string) {
+	if i := strings.Index(target, "#"); i >= 0 {
+		return target[:i], target[i:]
+	}
+	return target, ""
+}
+
+func linkKey(line int, target string) string {
+	return fmt.Sprintf("%d\x00%s", line, target)
+}
@@ -0,0 +1,138 @@
+package kb
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readVaultDoc(t *testing.T, vault, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(vault, rel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFixLinks(t *testing.T) {
+	vault := t.TempDir()
+	writeVaultDoc(t, vault, "10-Domains/auth/session-store.md", "# Session Store\n")
+	writeVaultDoc(t, vault, "10-Domains/auth/token.md", "# Token\n")
+	writeVaultDoc(t, vault, "10-Domains/billing/token.md", "# Billing Token\n")
+	writeVaultDoc(t, vault, "20-Projects/app.md", "# App\n[[Session Store#TTL|세션]] [[Token]]\n```\n[[Session Store]]\n```\n[[missing]]\n")
+
+	svc := NewLinkService(vault)
+	check, err := svc.CheckLinks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	confidence := make(map[string]float64)
+	for _, b := range check.BrokenLinks {
+		confidence[b.Target] = b.Confidence
+	}
+	// 이름이 같은 문서가 둘이면 확신할 수 없음
+	if confidence["Session Store#TTL"] != 1 || confidence["Token"] >= fixConfidence {
+		t.Fatalf("confidence = %v", confidence)
+	}
+
+	preview, err := svc.FixLinks(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preview.Fixed) != 1 || len(preview.Skipped) != 2 {
+		t.Fatalf("preview fixed=%d skipped=%d", len(preview.Fixed), len(preview.Skipped))
+	}
+	if strings.Contains(readVaultDoc(t, vault, "20-Projects/app.md"), "session-store") {
+		t.Fatal("dry run modified the document")
+	}
+
+	result, err := svc.FixLinks(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fix := result.Fixed[0]; fix.Line != 2 || fix.To != "session-store#TTL" {
+		t.Errorf("fix = %+v", fix)
+	}
+	content := readVaultDoc(t, vault, "20-Projects/app.md")
+	if !strings.Contains(content, "[[session-store#TTL|세션]] [[Token]]") || !strings.Contains(content, "```\n[[Session Store]]\n```") {
+		t.Errorf("content:\n%s", content)
+	}
+}
+
+func TestMoveDocument(t *testing.T) {
+	vault := t.TempDir()
+	writeVaultDoc(t, vault, "10-Domains/cache.md", "# Cache\n[[./ttl]]\n")
+	writeVaultDoc(t, vault, "10-Domains/ttl.md", "# TTL\n[[cache#정책]] [[10-Domains/cache|캐시]]\n")
+	writeVaultDoc(t, vault, "20-Projects/app.md", "# App\n[[cache]]\n")
+
+	index := NewIndexService(vault)
+	if err := index.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := index.BuildIndex(); err != nil {
+		t.Fatal(err)
+	}
+	index.Close()
+
+	svc := NewLinkService(vault)
+	if _, err := svc.MoveDocument("10-Domains/cache", "20-Projects/app", false); err == nil {
+		t.Error("expected error when the target exists")
+	}
+	if _, err := svc.MoveDocument("../cache", "10-Domains/x", false); err == nil {
+		t.Error("expected error for a path outside the vault")
+	}
+
+	// 이름이 그대로면 [[cache]]는 고치지 않음
+	result, err := svc.MoveDocument("10-Domains/cache.md", "10-Domains/infra/cache", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.To != filepath.Join("10-Domains", "infra", "cache.md") || !result.Indexed {
+		t.Errorf("result = %+v", result)
+	}
+	if got := readVaultDoc(t, vault, "10-Domains/ttl.md"); !strings.Contains(got, "[[cache#정책]] [[10-Domains/infra/cache|캐시]]") {
+		t.Errorf("ttl.md:\n%s", got)
+	}
+	if got := readVaultDoc(t, vault, "10-Domains/infra/cache.md"); !strings.Contains(got, "[[10-Domains/ttl]]") {
+		t.Errorf("moved doc:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(vault, "10-Domains", "cache.md")); !os.IsNotExist(err) {
+		t.Error("old file still exists")
+	}
+
+	// 이름이 바뀌면 이름으로 걸린 링크도 갱신
+	result, err = svc.MoveDocument("10-Domains/infra/cache", "10-Domains/infra/redis-cache", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Relinked) != 3 {
+		t.Errorf("relinked = %d", len(result.Relinked))
+	}
+	if got := readVaultDoc(t, vault, "20-Projects/app.md"); !strings.Contains(got, "[[redis-cache]]") {
+		t.Errorf("app.md:\n%s", got)
+	}
+	check, _ := svc.CheckLinks()
+	if len(check.BrokenLinks) != 0 {
+		t.Errorf("broken links after move: %+v", check.BrokenLinks[0])
+	}
+
+	if err := index.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close()
+	doc, err := index.GetByPath(filepath.Join("10-Domains", "infra", "redis-cache.md"))
+	if err != nil || doc.Title != "Cache" {
+		t.Errorf("index doc = %+v, %v", doc, err)
+	}
+	if _, err := index.GetByPath(filepath.Join("10-Domains", "cache.md")); err == nil {
+		t.Error("old path still indexed")
+	}
+	results, _ := index.Search("Cache", &SearchOptions{Content: true})
+	for _, r := range results {
+		if r.Document.Path == filepath.Join("10-Domains", "cache.md") {
+			t.Error("search returned the old path")
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package kb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 검토 주기: 문서 frontmatter의 review_every(예: 90d)와 reviewed(마지막 검토일)를 색인하고,
+// 주기가 지난 문서를 찾아 조용히 낡아 가는 지식을 드러냅니다. review_every가 없는 문서는
+// .pal-kb/config.yaml의 섹션 기본값(sections)을 따릅니다.
+
+// reviewIndexVersion is the index.db user_version that has document_reviews filled (bodyIndexVersion 다음)
+const reviewIndexVersion = 2
+
+// SectionMeta is the per-section review and access metadata in .pal-kb/config.yaml
+type SectionMeta struct {
+	ReviewEvery string `yaml:"review_every,omitempty" json:"review_every,omitempty"`
+	Owner       string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Access      string `yaml:"access,omitempty" json:"access,omitempty"` // 예: public, internal, restricted
+}
+
+// ReviewStatus is a document whose review is due
+type ReviewStatus struct {
+	Document      *DocumentIndex `json:"document"`
+	ReviewEvery   string         `json:"review_every"`
+	ReviewedAt    string         `json:"reviewed_at,omitempty"` // reviewed, 없으면 updated/created
+	DueAt         string         `json:"due_at,omitempty"`
+	OverdueDays   int            `json:"overdue_days"` // 음수면 남은 일수 (Within으로 포함된 경우)
+	NeverReviewed bool           `json:"never_reviewed,omitempty"`
+	Critical      bool           `json:"critical,omitempty"`
+	Owner         string         `json:"owner,omitempty"`
+	Access        string         `json:"access,omitempty"`
+	Section       string         `json:"section,omitempty"` // 주기를 물려준 섹션 (문서에 직접 지정했으면 빈 값)
+}
+
+// ReviewOptions filters ReviewDue
+type ReviewOptions struct {
+	Now          time.Time     // 기준 시각 (기본: 현재)
+	Within       time.Duration // 이 기간 안에 도래하는 문서도 포함
+	CriticalOnly bool
+	Section      string // 경로 접두사
+}
+
+// reviewMeta is the review frontmatter of a document as stored in document_reviews
+type reviewMeta struct {
+	ReviewEvery string
+	ReviewedAt  string
+	Critical    bool
+	Owner       string
+}
+
+// ParseReviewInterval parses a review cadence: Nd, Nw, Nm(30일), Ny(365일) 또는 Go duration (예: 720h)
+func ParseReviewInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return 0, fmt.Errorf("검토 주기가 비어 있습니다")
+	}
+	days := map[byte]int{'d': 1, 'w': 7, 'm': 30, 'y': 365}
+	if unit, ok := days[s[len(s)-1]]; ok {
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n > 0 {
+			return time.Duration(n*unit) * 24 * time.Hour, nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("잘못된 검토 주기: %s (예: 90d, 12w, 6m, 1y)", s)
+}
+
+// SectionMeta returns the per-section metadata from the KB config
+func (s *Service) SectionMeta() (map[string]SectionMeta, error) {
+	cfg, err := s.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Sections == nil {
+		return map[string]SectionMeta{}, nil
+	}
+	return cfg.Sections, nil
+}
+
+// SetSectionMeta stores the metadata of a section (빈 값이면 설정 삭제)
+func (s *Service) SetSectionMeta(section string, meta SectionMeta) error {
+	section = strings.Trim(filepath.ToSlash(filepath.Clean(section)), "/")
+	if section == "" || section == "." || strings.HasPrefix(section, "..") {
+		return fmt.Errorf("vault 상대 경로가 아닙니다: %s", section)
+	}
+	if meta.ReviewEvery != "" {
+		if _, err := ParseReviewInterval(meta.ReviewEvery); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := s.loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Sections == nil {
+		cfg.Sections = make(map[string]SectionMeta)
+	}
+	if meta == (SectionMeta{}) {
+		delete(cfg.Sections, section)
+	} else {
+		cfg.Sections[section] = meta
+	}
+	return s.writeYAML(filepath.Join(MetaDir, "config.yaml"), cfg)
+}
+
+func (s *Service) loadConfig() (*Config, error) {
+	data, err := os.ReadFile(filepath.Join(s.vaultPath, MetaDir, "config.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("KB 설정 읽기 실패: %w", err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("KB 설정 파싱 실패: %w", err)
+	}
+	return &cfg, nil
+}
+
+// ReviewDue lists documents whose review cadence has passed, most overdue first
+func (s *IndexService) ReviewDue(opts ReviewOptions) ([]*ReviewStatus, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	sections, _ := NewService(s.vaultPath).SectionMeta()
+
+	rows, err := s.db.Query(`
+		SELECT d.id, d.path, d.title, COALESCE(d.type, ''), COALESCE(d.status, ''), COALESCE(d.domain, ''),
+		       COALESCE(r.review_every, ''), COALESCE(r.reviewed_at, ''), COALESCE(r.critical, 0), COALESCE(r.owner, '')
+		FROM documents d LEFT JOIN document_reviews r ON r.doc_id = d.id
+		ORDER BY d.path
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []*ReviewStatus
+	for rows.Next() {
+		var doc DocumentIndex
+		var meta reviewMeta
+		if err := rows.Scan(&doc.ID, &doc.Path, &doc.Title, &doc.Type, &doc.Status, &doc.Domain,
+			&meta.ReviewEvery, &meta.ReviewedAt, &meta.Critical, &meta.Owner); err != nil {
+			continue
+		}
+		if opts.Section != "" && !strings.HasPrefix(filepath.ToSlash(doc.Path), strings.TrimSuffix(opts.Section, "/")+"/") {
+			continue
+		}
+		if opts.CriticalOnly && !meta.Critical {
+			continue
+		}
+		if strings.HasPrefix(filepath.Base(doc.Path), "_") || doc.Status == "archived" {
+			continue
+		}
+
+		status := &ReviewStatus{Document: &doc, ReviewEvery: meta.ReviewEvery, ReviewedAt: meta.ReviewedAt,
+			Critical: meta.Critical, Owner: meta.Owner}
+		if name, section, ok := sectionFor(doc.Path, sections); ok {
+			if status.ReviewEvery == "" && section.ReviewEvery != "" {
+				status.ReviewEvery, status.Section = section.ReviewEvery, name
+			}
+			if status.Owner == "" {
+				status.Owner = section.Owner
+			}
+			status.Access = section.Access
+		}
+		if status.ReviewEvery == "" {
+			continue
+		}
+		interval, err := ParseReviewInterval(status.ReviewEvery)
+		if err != nil {
+			continue
+		}
+
+		reviewed, ok := parseReviewDate(status.ReviewedAt)
+		if !ok {
+			status.NeverReviewed = true
+			due = append(due, status)
+			continue
+		}
+		dueAt := reviewed.Add(interval)
+		if dueAt.After(opts.Now.Add(opts.Within)) {
+			continue
+		}
+		status.DueAt = dueAt.Format("2006-01-02")
+		status.OverdueDays = int(opts.Now.Sub(dueAt).Hours() / 24)
+		due = append(due, status)
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		if due[i].NeverReviewed != due[j].NeverReviewed {
+			return due[i].NeverReviewed
+		}
+		return due[i].OverdueDays > due[j].OverdueDays
+	})
+	return due, nil
+}
+
+// sectionFor returns the most specific section metadata whose path contains the document
+func sectionFor(docPath string, sections map[string]SectionMeta) (string, SectionMeta, bool) {
+	docPath = filepath.ToSlash(docPath)
+	best := ""
+	for name := range sections {
+		if strings.HasPrefix(docPath, name+"/") && len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return "", SectionMeta{}, false
+	}
+	return best, sections[best], true
+}
+
+// reviewMetaOf extracts the review frontmatter of a document; 관련 필드가 하나도 없으면 nil
+func reviewMetaOf(content string) *reviewMeta {
+	fm := parseFrontmatterMap(content)
+	if fm == nil {
+		return nil
+	}
+	meta := &reviewMeta{
+		ReviewEvery: frontmatterString(fm["review_every"]),
+		Owner:       frontmatterString(fm["owner"]),
+	}
+	switch v := fm["critical"].(type) {
+	case bool:
+		meta.Critical = v
+	case string:
+		meta.Critical = v == "true" || v == "yes"
+	}
+	if p := frontmatterString(fm["priority"]); p == "critical" {
+		meta.Critical = true
+	}
+	// 마지막 검토일: reviewed, 없으면 updated/created (섹션 기본 주기에도 쓰이므로 항상 기록)
+	for _, key := range []string{"reviewed", "last_reviewed", "updated", "created"} {
+		if v := frontmatterString(fm[key]); v != "" {
+			meta.ReviewedAt = v
+			break
+		}
+	}
+	if *meta == (reviewMeta{}) {
+		return nil
+	}
+	return meta
+}
+
+// frontmatterString formats a frontmatter scalar (YAML 날짜는 time.Time으로 읽힘)
+func frontmatterString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case time.Time:
+		return v.Format("2006-01-02")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func parseReviewDate(s string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339, "2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,128 @@
+package kb
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseReviewInterval(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"90d":  90 * 24 * time.Hour,
+		"2w":   14 * 24 * time.Hour,
+		"6M":   180 * 24 * time.Hour,
+		"1y":   365 * 24 * time.Hour,
+		"720h": 720 * time.Hour,
+	} {
+		if got, err := ParseReviewInterval(in); err != nil || got != want {
+			t.Errorf("ParseReviewInterval(%q) = %v, %v", in, got, err)
+		}
+	}
+	for _, in := range []string{"", "0d", "-3d", "soon"} {
+		if _, err := ParseReviewInterval(in); err == nil {
+			t.Errorf("ParseReviewInterval(%q): expected error", in)
+		}
+	}
+}
+
+func TestReviewDue(t *testing.T) {
+	vault := t.TempDir()
+	if err := NewService(vault).Init(); err != nil {
+		t.Fatal(err)
+	}
+	writeVaultDoc(t, vault, "10-Domains/auth/session.md", "---\ntitle: 세션\nreview_every: 90d\nreviewed: 2026-01-01\ncritical: true\nowner: alice\n---\n# 세션\n")
+	writeVaultDoc(t, vault, "10-Domains/auth/token.md", "---\ntitle: 토큰\nreview_every: 30d\nreviewed: 2026-02-20\n---\n# 토큰\n")
+	writeVaultDoc(t, vault, "10-Domains/auth/fresh.md", "---\ntitle: 최신\nreview_every: 1y\nreviewed: 2026-02-01\ncritical: true\n---\n# 최신\n")
+	writeVaultDoc(t, vault, "00-System/policy.md", "---\ntitle: 정책\nupdated: \"2025-12-01\"\n---\n# 정책\n")
+	writeVaultDoc(t, vault, "00-System/new.md", "# 검토 기록 없음\n")
+	writeVaultDoc(t, vault, "20-Projects/plan.md", "---\ntitle: 계획\ncreated: 2025-01-01\n---\n# 계획\n")
+
+	svc := NewService(vault)
+	if err := svc.SetSectionMeta("00-System/", SectionMeta{ReviewEvery: "60d", Owner: "platform", Access: "internal"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SetSectionMeta("20-Projects", SectionMeta{ReviewEvery: "someday"}); err == nil {
+		t.Error("expected error for an invalid review interval")
+	}
+
+	idx := NewIndexService(vault)
+	if err := idx.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+	if _, err := idx.BuildIndex(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	due, err := idx.ReviewDue(ReviewOptions{Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range due {
+		got = append(got, fmt.Sprintf("%s:%d:%s:%s", r.Document.Path, r.OverdueDays, r.Section, r.Owner))
+	}
+	// new.md는 검토 기록이 없어 맨 앞, policy.md는 updated 기준 (12-01 + 60d = 01-30)
+	want := []string{
+		"00-System/new.md:0:00-System:platform",
+		"00-System/policy.md:61:00-System:platform",
+		"10-Domains/auth/token.md:10::",
+		"10-Domains/auth/session.md:0::alice",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("due = %v, want %v", got, want)
+	}
+	if !due[0].NeverReviewed || due[1].Access != "internal" {
+		t.Errorf("due[0] = %+v, due[1] = %+v", due[0], due[1])
+	}
+
+	due, _ = idx.ReviewDue(ReviewOptions{Now: now, CriticalOnly: true})
+	if len(due) != 1 || due[0].Document.Path != "10-Domains/auth/session.md" {
+		t.Errorf("critical due = %+v", due)
+	}
+	due, _ = idx.ReviewDue(ReviewOptions{Now: now, Within: 14 * 24 * time.Hour, Section: "10-Domains"})
+	if len(due) != 2 {
+		t.Errorf("section due = %+v", due)
+	}
+
+	// 검토일을 갱신하면 목록에서 빠짐
+	writeVaultDoc(t, vault, "10-Domains/auth/session.md", "---\ntitle: 세션\nreview_every: 90d\nreviewed: 2026-03-30\ncritical: true\n---\n# 세션\n")
+	if _, err := idx.indexDocument(filepath.Join(vault, "10-Domains/auth/session.md")); err != nil {
+		t.Fatal(err)
+	}
+	if due, _ = idx.ReviewDue(ReviewOptions{Now: now, CriticalOnly: true}); len(due) != 0 {
+		t.Errorf("critical due after review = %+v", due)
+	}
+}
+
+func TestReviewBackfill(t *testing.T) {
+	vault := t.TempDir()
+	writeVaultDoc(t, vault, "10-Domains/auth/session.md", "---\ntitle: 세션\nreview_every: 30d\nreviewed: 2026-01-01\n---\n# 세션\n")
+
+	idx := NewIndexService(vault)
+	if err := idx.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := idx.BuildIndex(); err != nil {
+		t.Fatal(err)
+	}
+	// 검토 메타데이터 이전의 색인 흉내
+	idx.db.Exec("DELETE FROM document_reviews")
+	idx.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", bodyIndexVersion))
+	idx.Close()
+
+	idx = NewIndexService(vault)
+	if err := idx.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+	due, err := idx.ReviewDue(ReviewOptions{Now: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0].DueAt != "2026-01-31" {
+		t.Errorf("due = %+v", due)
+	}
+}
@@ -0,0 +1,241 @@
+package kb
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// 의미 검색: 문서 벡터를 document_embeddings에 저장하고, 검색어 벡터와의 코사인 유사도를
+// BM25 점수(최고 점수로 정규화)와 섞어 순위를 매깁니다. 키워드가 겹치지 않아도 개념이 가까운 문서를 찾습니다.
+
+const (
+	// embedBatchSize is the number of documents sent to a provider at once
+	embedBatchSize = 32
+	// maxEmbedChars bounds the text embedded per document (runes)
+	maxEmbedChars = 8000
+	// defaultSemanticWeight is the share of vector similarity in the blended score
+	defaultSemanticWeight = 0.6
+	// embedConfigKey is the index_meta key of the provider used by 'pal kb embed'
+	embedConfigKey = "embed_config"
+)
+
+// EmbedStats reports the result of EmbedDocuments
+type EmbedStats struct {
+	Model    string `json:"model"`
+	Total    int    `json:"total"`
+	Embedded int    `json:"embedded"`
+	Skipped  int    `json:"skipped"` // 내용과 모델이 그대로라 건너뜀
+}
+
+// SaveEmbedConfig records the provider used for the stored vectors (검색 시 같은 provider 사용).
+// provider와 모델 이름만 저장하고 주소와 실행 명령은 버립니다.
+func (s *IndexService) SaveEmbedConfig(cfg EmbedConfig) error {
+	data, _ := json.Marshal(EmbedConfig{Provider: cfg.Provider, Model: cfg.Model})
+	_, err := s.db.Exec(`INSERT INTO index_meta (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value`, embedConfigKey, string(data))
+	return err
+}
+
+// EmbedConfig returns the recorded provider and model, or nil when 'pal kb embed' has not run.
+// 이전 버전이 저장한 주소와 실행 명령은 무시하므로 ResolveEmbedConfig로 이 컴퓨터의 설정을 채워 사용합니다.
+func (s *IndexService) EmbedConfig() (*EmbedConfig, error) {
+	var value string
+	err := s.db.QueryRow("SELECT value FROM index_meta WHERE key = ?", embedConfigKey).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cfg EmbedConfig
+	if err := json.Unmarshal([]byte(value), &cfg); err != nil {
+		return nil, err
+	}
+	return &EmbedConfig{Provider: cfg.Provider, Model: cfg.Model}, nil
+}
+
+// EmbedDocuments computes vectors for indexed documents whose content or model changed (force면 전체)
+func (s *IndexService) EmbedDocuments(ctx context.Context, e Embedder, force bool) (*EmbedStats, error) {
+	stats := &EmbedStats{Model: e.Name()}
+
+	existing := make(map[int64]string)
+	rows, err := s.db.Query("SELECT doc_id, model, content_hash FROM document_embeddings")
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id int64
+		var model, hash string
+		rows.Scan(&id, &model, &hash)
+		existing[id] = model + "\x00" + hash
+	}
+	rows.Close()
+
+	type pending struct {
+		id   int64
+		text string
+		hash string
+	}
+	var todo []pending
+	rows, err = s.db.Query(`
+		SELECT d.id, d.title, d.summary, COALESCE(b.body, '')
+		FROM documents d LEFT JOIN documents_body b ON b.docid = d.id
+		ORDER BY d.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id int64
+		var title, summary, body string
+		if err := rows.Scan(&id, &title, &summary, &body); err != nil {
+			continue
+		}
+		stats.Total++
+		text := embedText(title, summary, body)
+		sum := sha256.Sum256([]byte(text))
+		hash := hex.EncodeToString(sum[:8])
+		if !force && existing[id] == stats.Model+"\x00"+hash {
+			stats.Skipped++
+			continue
+		}
+		todo = append(todo, pending{id: id, text: text, hash: hash})
+	}
+	rows.Close()
+
+	now := time.Now().Format(time.RFC3339)
+	for start := 0; start < len(todo); start += embedBatchSize {
+		batch := todo[start:min(start+embedBatchSize, len(todo))]
+		texts := make([]string, len(batch))
+		for i, p := range batch {
+			texts[i] = p.text
+		}
+		vectors, err := e.Embed(ctx, texts)
+		if err != nil {
+			return stats, err
+		}
+		for i, p := range batch {
+			if _, err := s.db.Exec(`
+				INSERT INTO document_embeddings (doc_id, model, dim, vector, content_hash, embedded_at)
+				VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT(doc_id) DO UPDATE SET
+					model = excluded.model, dim = excluded.dim, vector = excluded.vector,
+					content_hash = excluded.content_hash, embedded_at = excluded.embedded_at
+			`, p.id, stats.Model, len(vectors[i]), encodeVector(vectors[i]), p.hash, now); err != nil {
+				return stats, err
+			}
+			stats.Embedded++
+		}
+	}
+	return stats, nil
+}
+
+// SemanticSearch ranks documents by vector similarity blended with the BM25 keyword score.
+// 다른 모델로 만든 벡터는 무시하므로 provider를 바꾸면 'pal kb embed'를 다시 실행해야 합니다.
+func (s *IndexService) SemanticSearch(ctx context.Context, e Embedder, query string, opts *SearchOptions) ([]*SearchResult, error) {
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+	if opts.Limit == 0 {
+		opts.Limit = 20
+	}
+	weight := opts.SemanticWeight
+	if weight <= 0 || weight > 1 {
+		weight = defaultSemanticWeight
+	}
+
+	var count int
+	s.db.QueryRow("SELECT COUNT(*) FROM document_embeddings WHERE model = ?", e.Name()).Scan(&count)
+	if count == 0 {
+		return nil, fmt.Errorf("'%s' 임베딩이 없습니다. 'pal kb embed'를 먼저 실행하세요", e.Name())
+	}
+
+	queryVec, err := e.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+
+	// 키워드 점수: 본문 검색 결과 전체를 최고 점수로 정규화
+	keywordOpts := *opts
+	keywordOpts.Content, keywordOpts.Limit, keywordOpts.TokenBudget = true, math.MaxInt32, 0
+	keyword, err := s.Search(query, &keywordOpts)
+	if err != nil {
+		keyword = nil // 검색어가 FTS 문법에 맞지 않아도 의미 검색은 계속
+	}
+	maxScore := 0.0
+	for _, r := range keyword {
+		maxScore = math.Max(maxScore, r.Score)
+	}
+	keywordByID := make(map[int64]*SearchResult, len(keyword))
+	for _, r := range keyword {
+		keywordByID[r.Document.ID] = r
+	}
+
+	filter, args := searchFilter(opts)
+	rows, err := s.db.Query(`
+		SELECT d.id, d.path, d.title, d.type, d.status, d.domain, d.summary,
+		       COALESCE(d.created_at, ''), COALESCE(d.updated_at, ''), d.indexed_at, e.vector
+		FROM documents d
+		JOIN document_embeddings e ON e.doc_id = d.id
+		WHERE e.model = ?`+filter, append([]interface{}{e.Name()}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var ranked []*SearchResult
+	for rows.Next() {
+		var doc DocumentIndex
+		var blob []byte
+		if err := rows.Scan(&doc.ID, &doc.Path, &doc.Title, &doc.Type, &doc.Status, &doc.Domain,
+			&doc.Summary, &doc.CreatedAt, &doc.UpdatedAt, &doc.IndexedAt, &blob); err != nil {
+			continue
+		}
+
+		similarity := math.Max(cosine(queryVec[0], decodeVector(blob)), 0)
+		result := &SearchResult{Document: &doc, Similarity: math.Round(similarity*1000) / 1000}
+		keywordScore := 0.0
+		if kw, ok := keywordByID[doc.ID]; ok && maxScore > 0 {
+			keywordScore = kw.Score / maxScore
+			result.Snippet = kw.Snippet
+		}
+		result.Score = weight*similarity + (1-weight)*keywordScore
+		if result.Score > 0 {
+			ranked = append(ranked, result)
+		}
+	}
+	rows.Close()
+
+	return s.finishResults(query, ranked, opts), nil
+}
+
+// embedText is the text embedded for a document (제목과 요약을 앞에 둬 잘려도 남도록)
+func embedText(title, summary, body string) string {
+	text := title + "\n" + summary + "\n" + body
+	if runes := []rune(text); len(runes) > maxEmbedChars {
+		text = string(runes[:maxEmbedChars])
+	}
+	return text
+}
+
+func encodeVector(vec []float32) []byte {
+	buf := make([]byte, 4*len(vec))
+	for i, v := range vec {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	return buf
+}
+
+func decodeVector(buf []byte) []float32 {
+	vec := make([]float32, len(buf)/4)
+	for i := range vec {
+		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:]))
+	}
+	return vec
+}
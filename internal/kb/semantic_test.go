@@ -0,0 +1,222 @@
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// conceptEmbedder maps texts to fixed concept axes so tests can check concept-level ranking
+type conceptEmbedder struct{ calls int }
+
+func (e *conceptEmbedder) Name() string { return "test:concept" }
+
+func (e *conceptEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	e.calls++
+	axes := [][]string{{"만료", "expire", "ttl", "timeout"}, {"청구", "invoice", "결제"}}
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vec := make([]float32, len(axes)+1)
+		vec[len(axes)] = 0.1
+		for a, words := range axes {
+			for _, w := range words {
+				if strings.Contains(strings.ToLower(text), w) {
+					vec[a]++
+				}
+			}
+		}
+		vectors[i] = vec
+	}
+	return vectors, nil
+}
+
+func newSemanticVault(t *testing.T) *IndexService {
+	t.Helper()
+	vault := t.TempDir()
+	writeVaultDoc(t, vault, filepath.Join(DomainsDir, "auth", "session.md"), `---
+title: Session lifetime
+summary: 세션 수명
+---
+# Session lifetime
+
+Idle sessions expire after a TTL of 30 minutes.
+`)
+	writeVaultDoc(t, vault, filepath.Join(DomainsDir, "billing", "invoice.md"), `---
+title: Invoice
+summary: 청구서 발행
+---
+# Invoice
+
+결제 실패 시 재시도한다.
+`)
+	svc := NewIndexService(vault)
+	if err := svc.Open(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { svc.Close() })
+	if _, err := svc.BuildIndex(); err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestSemanticSearch(t *testing.T) {
+	svc := newSemanticVault(t)
+	e := &conceptEmbedder{}
+
+	if _, err := svc.SemanticSearch(context.Background(), e, "만료", nil); err == nil {
+		t.Error("임베딩 전 의미 검색은 실패해야 함")
+	}
+
+	stats, err := svc.EmbedDocuments(context.Background(), e, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Total != 2 || stats.Embedded != 2 || stats.Model != "test:concept" {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	// 내용이 같으면 다시 계산하지 않음
+	stats, _ = svc.EmbedDocuments(context.Background(), e, false)
+	if stats.Embedded != 0 || stats.Skipped != 2 {
+		t.Errorf("재실행 stats = %+v", stats)
+	}
+	if stats, _ = svc.EmbedDocuments(context.Background(), e, true); stats.Embedded != 2 {
+		t.Errorf("force stats = %+v", stats)
+	}
+
+	// 본문에 없는 한국어 검색어로도 개념이 같은 영어 문서를 찾음
+	results, err := svc.SemanticSearch(context.Background(), e, "세션 만료", &SearchOptions{Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) == 0 || results[0].Document.Title != "Session lifetime" || results[0].Similarity <= 0 {
+		t.Fatalf("의미 검색 결과 = %+v", results)
+	}
+	if len(results) > 1 && results[1].Score >= results[0].Score {
+		t.Errorf("순위가 정렬되지 않음: %+v", results)
+	}
+
+	// 필터 적용
+	results, _ = svc.SemanticSearch(context.Background(), e, "세션 만료", &SearchOptions{Domain: "billing"})
+	for _, r := range results {
+		if r.Document.Domain != "billing" {
+			t.Errorf("도메인 필터 무시: %+v", r.Document)
+		}
+	}
+
+	// 다른 모델의 벡터는 사용하지 않음
+	if _, err := svc.SemanticSearch(context.Background(), hashEmbedder{}, "만료", nil); err == nil {
+		t.Error("다른 모델로 검색하면 실패해야 함")
+	}
+}
+
+func TestEmbedConfigRoundTrip(t *testing.T) {
+	svc := newSemanticVault(t)
+	if cfg, err := svc.EmbedConfig(); err != nil || cfg != nil {
+		t.Fatalf("초기 설정 = %+v, %v", cfg, err)
+	}
+	want := EmbedConfig{Provider: EmbedProviderOllama, Model: "nomic-embed-text"}
+	if err := svc.SaveEmbedConfig(want); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, _ := svc.EmbedConfig(); cfg == nil || *cfg != want {
+		t.Errorf("설정 = %+v", cfg)
+	}
+}
+
+func TestEmbedConfigKeepsCommandOutOfVault(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	svc := newSemanticVault(t)
+
+	// 이전 버전이 색인에 남긴 명령은 읽지 않음
+	svc.db.Exec("INSERT INTO index_meta (key, value) VALUES (?, ?)", embedConfigKey,
+		`{"provider":"local","model":"m","command":"touch pwned"}`)
+	cfg, err := svc.EmbedConfig()
+	if err != nil || cfg == nil || *cfg != (EmbedConfig{Provider: EmbedProviderLocal, Model: "m"}) {
+		t.Fatalf("설정 = %+v, %v", cfg, err)
+	}
+	if _, err := NewEmbedder(*cfg); err == nil {
+		t.Error("명령 없이 local provider를 만들면 실패해야 함")
+	}
+
+	local := EmbedConfig{Provider: EmbedProviderLocal, Model: "m", Command: "embed-onnx"}
+	if err := svc.SaveEmbedConfig(local); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, _ := svc.EmbedConfig(); cfg.Command != "" {
+		t.Errorf("색인에 명령이 저장됨: %+v", cfg)
+	}
+	if err := SaveUserEmbedConfig(local); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ = svc.EmbedConfig()
+	if resolved, err := ResolveEmbedConfig(*cfg); err != nil || resolved != local {
+		t.Errorf("resolved = %+v, %v", resolved, err)
+	}
+	if resolved, _ := ResolveEmbedConfig(EmbedConfig{Provider: EmbedProviderOllama}); resolved.Command != "" {
+		t.Errorf("다른 provider에 명령이 적용됨: %+v", resolved)
+	}
+}
+
+func TestOllamaEmbedder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/api/embed" || req.Model != "m" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		resp := struct {
+			Embeddings [][]float32 `json:"embeddings"`
+		}{}
+		for range req.Input {
+			resp.Embeddings = append(resp.Embeddings, []float32{1, 0})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	e, err := NewEmbedder(EmbedConfig{Provider: EmbedProviderOllama, Model: "m", Endpoint: srv.URL + "/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Name() != "ollama:m" {
+		t.Errorf("Name = %s", e.Name())
+	}
+	vectors, err := e.Embed(context.Background(), []string{"a", "b"})
+	if err != nil || len(vectors) != 2 {
+		t.Fatalf("Embed = %v, %v", vectors, err)
+	}
+
+	bad, _ := NewEmbedder(EmbedConfig{Provider: EmbedProviderOllama, Model: "other", Endpoint: srv.URL})
+	if _, err := bad.Embed(context.Background(), []string{"a"}); err == nil {
+		t.Error("HTTP 오류가 전달되어야 함")
+	}
+}
+
+func TestNewEmbedderErrors(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	for _, cfg := range []EmbedConfig{{}, {Provider: "nope"}, {Provider: EmbedProviderOpenAI}, {Provider: EmbedProviderLocal}} {
+		if _, err := NewEmbedder(cfg); err == nil {
+			t.Errorf("NewEmbedder(%+v) 오류 없음", cfg)
+		}
+	}
+}
+
+func TestHashEmbedder(t *testing.T) {
+	vectors, _ := hashEmbedder{}.Embed(context.Background(), []string{"session expiry", "session expired", "invoice payment"})
+	if cosine(vectors[0], vectors[1]) <= cosine(vectors[0], vectors[2]) {
+		t.Error("형태가 비슷한 텍스트의 유사도가 높아야 함")
+	}
+	if got := decodeVector(encodeVector(vectors[0])); cosine(got, vectors[0]) < 0.999 {
+		t.Error("벡터 인코딩 왕복 실패")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -33,33 +34,53 @@ type ProjectState struct {
 	Name       string            `yaml:"name"`
 	SourcePath string            `yaml:"source_path"`
 	LastSync   string            `yaml:"last_sync"`
-	Files      map[string]string `yaml:"files"` // path -> hash
+	Files      map[string]string `yaml:"files"` // path -> hash (마지막 동기화 시점, 3-way 비교의 기준)
+	// Directions overrides the sync direction per mapping target (기본: to-vault)
+	Directions map[string]string `yaml:"directions,omitempty"`
 }
 
 // SyncResult represents sync operation result
 type SyncResult struct {
-	ProjectName string        `json:"project_name"`
-	Added       []string      `json:"added,omitempty"`
-	Updated     []string      `json:"updated,omitempty"`
-	Deleted     []string      `json:"deleted,omitempty"`
-	Skipped     []string      `json:"skipped,omitempty"`
-	Conflicts   []SyncConflict `json:"conflicts,omitempty"`
-	SyncTime    string        `json:"sync_time"`
+	ProjectName   string         `json:"project_name"`
+	Added         []string       `json:"added,omitempty"`
+	Updated       []string       `json:"updated,omitempty"`
+	Deleted       []string       `json:"deleted,omitempty"`
+	Pulled        []string       `json:"pulled,omitempty"`         // vault 편집을 프로젝트로 반영 (매핑/상대 경로)
+	PulledDeleted []string       `json:"pulled_deleted,omitempty"` // vault에서 삭제되어 프로젝트에서도 삭제
+	Skipped       []string       `json:"skipped,omitempty"`
+	Conflicts     []SyncConflict `json:"conflicts,omitempty"`
+	ReportPath    string         `json:"report_path,omitempty"` // 충돌 보고서 (vault 상대 경로)
+	SyncTime      string         `json:"sync_time"`
 }
 
 // SyncConflict represents a sync conflict
 type SyncConflict struct {
-	Path       string `json:"path"`
-	SourceTime string `json:"source_time"`
-	TargetTime string `json:"target_time"`
-	Resolution string `json:"resolution,omitempty"`
+	Path        string `json:"path"`
+	ProjectPath string `json:"project_path"` // 매핑 기준 경로 (예: docs/api.md)
+	VaultPath   string `json:"vault_path"`   // vault 상대 경로
+	SourceTime  string `json:"source_time"`
+	TargetTime  string `json:"target_time"`
+	Reason      string `json:"reason"`               // both-modified, both-added, deleted-in-project, deleted-in-vault
+	Resolution  string `json:"resolution,omitempty"` // --force로 이긴 쪽 (project, vault)
 }
 
 // SyncOptions represents sync options
 type SyncOptions struct {
 	DryRun    bool   `json:"dry_run"`
 	Force     bool   `json:"force"`
-	Direction string `json:"direction"` // "to-vault", "from-vault", "both"
+	Direction string `json:"direction"` // "to-vault", "from-vault", "both" (비우면 디렉토리별 설정)
+}
+
+// Sync directions
+const (
+	SyncToVault   = "to-vault"
+	SyncFromVault = "from-vault"
+	SyncBoth      = "both"
+)
+
+// ValidSyncDirection reports whether d is a sync direction
+func ValidSyncDirection(d string) bool {
+	return d == SyncToVault || d == SyncFromVault || d == SyncBoth
 }
 
 // NewSyncService creates a new sync service
@@ -87,7 +108,7 @@ var SyncMappings = []struct {
 // Sync synchronizes project to vault
 func (s *SyncService) Sync(opts *SyncOptions) (*SyncResult, error) {
 	if opts == nil {
-		opts = &SyncOptions{Direction: "to-vault"}
+		opts = &SyncOptions{}
 	}
 
 	result := &SyncResult{
@@ -114,6 +135,12 @@ func (s *SyncService) Sync(opts *SyncOptions) (*SyncResult, error) {
 			Files:      make(map[string]string),
 		}
 	}
+	if projectState.Files == nil {
+		projectState.Files = make(map[string]string)
+	}
+	if opts.Direction != "" && !ValidSyncDirection(opts.Direction) {
+		return nil, fmt.Errorf("알 수 없는 동기화 방향: %s (to-vault, from-vault, both)", opts.Direction)
+	}
 
 	// Ensure target directory exists
 	targetDir := filepath.Join(s.vaultPath, ProjectsDir, s.projectName)
@@ -128,13 +155,23 @@ func (s *SyncService) Sync(opts *SyncOptions) (*SyncResult, error) {
 		sourcePath := filepath.Join(s.projectPath, mapping.Source)
 		targetPath := filepath.Join(targetDir, mapping.Target)
 
-		// Check if source exists
+		direction := opts.Direction
+		if direction == "" {
+			direction = projectState.direction(mapping.Target)
+		}
+
+		// Check if source exists (양방향이면 vault에만 있는 디렉토리도 가져옴)
 		if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
-			continue
+			if direction == SyncToVault {
+				continue
+			}
+			if _, err := os.Stat(targetPath); os.IsNotExist(err) {
+				continue
+			}
 		}
 
 		// Sync directory (use mapping.Target as prefix for unique file keys)
-		syncResult, err := s.syncDirectory(sourcePath, targetPath, mapping.Target, &projectState, opts)
+		syncResult, err := s.syncDirectory(sourcePath, targetPath, mapping.Target, &projectState, opts, direction)
 		if err != nil {
 			return result, fmt.Errorf("%s 동기화 실패: %w", mapping.Desc, err)
 		}
@@ -142,6 +179,8 @@ func (s *SyncService) Sync(opts *SyncOptions) (*SyncResult, error) {
 		result.Added = append(result.Added, syncResult.Added...)
 		result.Updated = append(result.Updated, syncResult.Updated...)
 		result.Deleted = append(result.Deleted, syncResult.Deleted...)
+		result.Pulled = append(result.Pulled, syncResult.Pulled...)
+		result.PulledDeleted = append(result.PulledDeleted, syncResult.PulledDeleted...)
 		result.Skipped = append(result.Skipped, syncResult.Skipped...)
 		result.Conflicts = append(result.Conflicts, syncResult.Conflicts...)
 	}
@@ -154,6 +193,15 @@ func (s *SyncService) Sync(opts *SyncOptions) (*SyncResult, error) {
 		}
 	}
 
+	// 충돌 보고서 (해결되면 제거)
+	if !opts.DryRun {
+		report, err := s.writeConflictReport(result)
+		if err != nil {
+			return result, fmt.Errorf("충돌 보고서 작성 실패: %w", err)
+		}
+		result.ReportPath = report
+	}
+
 	// Save sync state
 	if !opts.DryRun {
 		projectState.LastSync = result.SyncTime
@@ -168,115 +216,248 @@ func (s *SyncService) Sync(opts *SyncOptions) (*SyncResult, error) {
 	return result, nil
 }
 
-func (s *SyncService) syncDirectory(sourcePath, targetPath, keyPrefix string, projectState *ProjectState, opts *SyncOptions) (*SyncResult, error) {
+func (s *SyncService) syncDirectory(sourcePath, targetPath, keyPrefix string, projectState *ProjectState, opts *SyncOptions, direction string) (*SyncResult, error) {
 	result := &SyncResult{}
+	toVault := direction == SyncToVault || direction == SyncBoth
+	fromVault := direction == SyncFromVault || direction == SyncBoth
 
 	// Ensure target directory exists
-	if !opts.DryRun {
+	if !opts.DryRun && toVault {
 		if err := os.MkdirAll(targetPath, 0755); err != nil {
 			return nil, err
 		}
 	}
 
-	// Track files in source (using prefixed keys)
-	sourceFiles := make(map[string]bool)
+	sourceFiles, err := s.hashTree(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	targetFiles, err := s.hashTree(targetPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// Walk source directory
-	err := filepath.Walk(sourcePath, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
-			return nil
+	// 양쪽 파일과 마지막 동기화 기록의 합집합을 비교 (기록 = 공통 조상)
+	paths := make(map[string]bool)
+	for relPath := range sourceFiles {
+		paths[relPath] = true
+	}
+	for relPath := range targetFiles {
+		paths[relPath] = true
+	}
+	for fileKey := range projectState.Files {
+		if relPath, ok := strings.CutPrefix(fileKey, keyPrefix+"/"); ok {
+			paths[relPath] = true
 		}
+	}
+	sorted := make([]string, 0, len(paths))
+	for relPath := range paths {
+		sorted = append(sorted, relPath)
+	}
+	sort.Strings(sorted)
 
-		// Only sync markdown files
-		if filepath.Ext(path) != ".md" && filepath.Ext(path) != ".yaml" {
-			return nil
+	record := func(fileKey, hash string) {
+		if opts.DryRun {
+			return
 		}
-
-		// Get relative path and create prefixed key for unique identification
-		relPath, _ := filepath.Rel(sourcePath, path)
-		fileKey := filepath.Join(keyPrefix, relPath)
-		sourceFiles[fileKey] = true
-
-		// Calculate hash
-		hash, err := s.fileHash(path)
-		if err != nil {
-			return nil
+		if hash == "" {
+			delete(projectState.Files, fileKey)
+		} else {
+			projectState.Files[fileKey] = hash
 		}
+	}
 
-		// Check if file changed
+	for _, relPath := range sorted {
+		fileKey := filepath.Join(keyPrefix, relPath)
+		sourceFile := filepath.Join(sourcePath, relPath)
 		targetFile := filepath.Join(targetPath, relPath)
-		prevHash := projectState.Files[fileKey]
+		src, tgt, base := sourceFiles[relPath], targetFiles[relPath], projectState.Files[fileKey]
 
-		if prevHash == hash {
-			// No change
-			result.Skipped = append(result.Skipped, relPath)
-			return nil
+		change := classifySync(src, tgt, base)
+		if !fromVault && change != syncTargetChanged && change != ConflictBothChanged && change != ConflictDeletedProj {
+			// 단방향에서 vault는 사본이므로 프로젝트 쪽이 이김
+			// (양쪽 수정과, vault에서 수정한 문서를 프로젝트에서 삭제한 경우는 vault 편집을 잃지 않게 충돌)
+			change = syncSourceChanged
 		}
 
-		// Check for conflicts (target modified independently)
-		if prevHash != "" && !opts.Force {
-			if targetInfo, err := os.Stat(targetFile); err == nil {
-				targetHash, _ := s.fileHash(targetFile)
-				if targetHash != prevHash {
-					// Both source and target changed
-					result.Conflicts = append(result.Conflicts, SyncConflict{
-						Path:       relPath,
-						SourceTime: info.ModTime().Format(time.RFC3339),
-						TargetTime: targetInfo.ModTime().Format(time.RFC3339),
-					})
-					return nil
+		switch change {
+		case syncUnchanged:
+			if src != "" {
+				result.Skipped = append(result.Skipped, relPath)
+			}
+			record(fileKey, src)
+
+		case syncSourceChanged:
+			if !toVault {
+				result.Skipped = append(result.Skipped, relPath)
+				continue
+			}
+			if src == "" {
+				if !opts.DryRun {
+					if err := os.Remove(targetFile); err != nil && !os.IsNotExist(err) {
+						return nil, err
+					}
 				}
+				result.Deleted = append(result.Deleted, relPath)
+				record(fileKey, "")
+				continue
 			}
-		}
+			if err := s.syncCopy(sourceFile, targetFile, opts); err != nil {
+				return nil, err
+			}
+			if tgt == "" {
+				result.Added = append(result.Added, relPath)
+			} else {
+				result.Updated = append(result.Updated, relPath)
+			}
+			record(fileKey, src)
 
-		// Copy file
-		if !opts.DryRun {
-			// Ensure parent directory exists
-			parentDir := filepath.Dir(targetFile)
-			if err := os.MkdirAll(parentDir, 0755); err != nil {
-				return err
+		case syncTargetChanged:
+			// 단방향(to-vault)에서는 vault 편집을 덮어쓰지 않고 그대로 둠, vault가 만든 _toc.md 등도 가져오지 않음
+			if !fromVault || (tgt != "" && base == "" && strings.HasPrefix(filepath.Base(relPath), "_")) {
+				if src != "" {
+					result.Skipped = append(result.Skipped, relPath)
+				}
+				continue
+			}
+			if tgt == "" {
+				if !opts.DryRun {
+					if err := os.Remove(sourceFile); err != nil && !os.IsNotExist(err) {
+						return nil, err
+					}
+				}
+				result.PulledDeleted = append(result.PulledDeleted, fileKey)
+				record(fileKey, "")
+				continue
+			}
+			if err := s.syncCopy(targetFile, sourceFile, opts); err != nil {
+				return nil, err
+			}
+			result.Pulled = append(result.Pulled, fileKey)
+			record(fileKey, tgt)
+
+		default:
+			conflict := SyncConflict{
+				Path:        relPath,
+				ProjectPath: fileKey,
+				VaultPath:   filepath.Join(ProjectsDir, s.projectName, fileKey),
+				SourceTime:  modTime(sourceFile),
+				TargetTime:  modTime(targetFile),
+				Reason:      change,
+			}
+			if !opts.Force {
+				result.Conflicts = append(result.Conflicts, conflict)
+				continue
 			}
 
-			if err := s.copyFile(path, targetFile); err != nil {
-				return err
+			// --force: 방향의 원본이 이김 (both면 프로젝트)
+			if toVault {
+				conflict.Resolution = "project"
+				if src == "" {
+					if !opts.DryRun {
+						os.Remove(targetFile)
+					}
+					result.Deleted = append(result.Deleted, relPath)
+				} else {
+					if err := s.syncCopy(sourceFile, targetFile, opts); err != nil {
+						return nil, err
+					}
+					result.Updated = append(result.Updated, relPath)
+				}
+				record(fileKey, src)
+			} else {
+				conflict.Resolution = "vault"
+				if tgt == "" {
+					if !opts.DryRun {
+						os.Remove(sourceFile)
+					}
+					result.PulledDeleted = append(result.PulledDeleted, fileKey)
+				} else {
+					if err := s.syncCopy(targetFile, sourceFile, opts); err != nil {
+						return nil, err
+					}
+					result.Pulled = append(result.Pulled, fileKey)
+				}
+				record(fileKey, tgt)
 			}
-			projectState.Files[fileKey] = hash
+			result.Conflicts = append(result.Conflicts, conflict)
 		}
+	}
 
-		if prevHash == "" {
-			result.Added = append(result.Added, relPath)
-		} else {
-			result.Updated = append(result.Updated, relPath)
-		}
+	return result, nil
+}
 
-		return nil
-	})
+// Sync change kinds (충돌이면 SyncConflict.Reason 값)
+const (
+	syncUnchanged        = "unchanged"
+	syncSourceChanged    = "project-changed"
+	syncTargetChanged    = "vault-changed"
+	ConflictBothChanged  = "both-modified"
+	ConflictBothAdded    = "both-added"
+	ConflictDeletedProj  = "deleted-in-project" // 프로젝트에서 삭제, vault에서 수정
+	ConflictDeletedVault = "deleted-in-vault"   // vault에서 삭제, 프로젝트에서 수정
+)
 
-	if err != nil {
-		return nil, err
+// classifySync compares the project and vault hashes of a file with the hash at the last sync.
+// 빈 문자열은 파일이 없음(기록이 없으면 동기화된 적 없음)을 뜻합니다.
+func classifySync(src, tgt, base string) string {
+	switch {
+	case src == tgt:
+		return syncUnchanged
+	case tgt == base:
+		return syncSourceChanged
+	case src == base:
+		return syncTargetChanged
+	case base == "":
+		return ConflictBothAdded
+	case src == "":
+		return ConflictDeletedProj
+	case tgt == "":
+		return ConflictDeletedVault
+	default:
+		return ConflictBothChanged
 	}
+}
 
-	// Check for deleted files (only within this keyPrefix scope)
-	for fileKey := range projectState.Files {
-		// Only check files belonging to this mapping
-		if !strings.HasPrefix(fileKey, keyPrefix+"/") && fileKey != keyPrefix {
-			continue
+// hashTree hashes the synced files (.md, .yaml) under dir by relative path
+func (s *SyncService) hashTree(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return files, nil
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
 		}
-		if !sourceFiles[fileKey] {
-			// Extract relative path from prefixed key
-			relPath := strings.TrimPrefix(fileKey, keyPrefix+"/")
-			targetFile := filepath.Join(targetPath, relPath)
-			if _, err := os.Stat(targetFile); err == nil {
-				if !opts.DryRun {
-					os.Remove(targetFile)
-					delete(projectState.Files, fileKey)
-				}
-				result.Deleted = append(result.Deleted, relPath)
-			}
+		if filepath.Ext(path) != ".md" && filepath.Ext(path) != ".yaml" {
+			return nil
 		}
+		hash, err := s.fileHash(path)
+		if err != nil {
+			return nil
+		}
+		relPath, _ := filepath.Rel(dir, path)
+		files[relPath] = hash
+		return nil
+	})
+	return files, err
+}
+
+func (s *SyncService) syncCopy(src, dst string, opts *SyncOptions) error {
+	if opts.DryRun {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
 	}
+	return s.copyFile(src, dst)
+}
 
-	return result, nil
+func modTime(path string) string {
+	if info, err := os.Stat(path); err == nil {
+		return info.ModTime().Format(time.RFC3339)
+	}
+	return ""
 }
 
 func (s *SyncService) fileHash(path string) (string, error) {
@@ -362,6 +543,95 @@ func (s *SyncService) saveSyncState(state *SyncState) error {
 	return os.WriteFile(statePath, data, 0644)
 }
 
+// direction returns the sync direction of a mapping target
+func (p *ProjectState) direction(target string) string {
+	if d := p.Directions[target]; ValidSyncDirection(d) {
+		return d
+	}
+	return SyncToVault
+}
+
+// SetDirection stores the sync direction of a mapping target (예: docs=both)
+func (s *SyncService) SetDirection(target, direction string) error {
+	if !ValidSyncDirection(direction) {
+		return fmt.Errorf("알 수 없는 동기화 방향: %s (to-vault, from-vault, both)", direction)
+	}
+	known := false
+	for _, mapping := range SyncMappings {
+		if mapping.Target == target {
+			known = true
+		}
+	}
+	if !known {
+		return fmt.Errorf("알 수 없는 동기화 디렉토리: %s (ports, decisions, sessions, docs)", target)
+	}
+
+	state, err := s.loadSyncState()
+	if err != nil {
+		state = &SyncState{Version: "1", Projects: make(map[string]ProjectState), SyncMode: "one-way"}
+	}
+	projectState, ok := state.Projects[s.projectName]
+	if !ok {
+		projectState = ProjectState{Name: s.projectName, SourcePath: s.projectPath, Files: make(map[string]string)}
+	}
+	if projectState.Directions == nil {
+		projectState.Directions = make(map[string]string)
+	}
+	if direction == SyncToVault {
+		delete(projectState.Directions, target)
+	} else {
+		projectState.Directions[target] = direction
+	}
+	state.Projects[s.projectName] = projectState
+
+	state.SyncMode = "one-way"
+	for _, p := range state.Projects {
+		if len(p.Directions) > 0 {
+			state.SyncMode = "two-way"
+		}
+	}
+	state.LastModified = time.Now().Format(time.RFC3339)
+	return s.saveSyncState(state)
+}
+
+// writeConflictReport writes the project's conflicts to {MetaDir}/sync-conflicts/{project}.md,
+// or removes the report when there are none. vault 상대 경로를 반환합니다.
+func (s *SyncService) writeConflictReport(result *SyncResult) (string, error) {
+	rel := filepath.Join(MetaDir, "sync-conflicts", s.projectName+".md")
+	path := filepath.Join(s.vaultPath, rel)
+
+	var open []SyncConflict
+	for _, c := range result.Conflicts {
+		if c.Resolution == "" {
+			open = append(open, c)
+		}
+	}
+	if len(open) == 0 {
+		os.Remove(path)
+		return "", nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# 동기화 충돌: %s\n\n", s.projectName)
+	fmt.Fprintf(&b, "> %s 기준. 한쪽을 정리한 뒤 다시 `pal kb sync`를 실행하거나 `--force`로 덮어쓰세요.\n\n", result.SyncTime)
+	b.WriteString("| 파일 | 사유 | 프로젝트 수정 | vault 수정 |\n|---|---|---|---|\n")
+	for _, c := range open {
+		fmt.Fprintf(&b, "| %s<br>vault: %s | %s | %s | %s |\n", c.ProjectPath, c.VaultPath, c.Reason, orDash(c.SourceTime), orDash(c.TargetTime))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	return rel, os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+func orDash(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
+
 // GetSyncStatus returns the sync status for a project
 func (s *SyncService) GetSyncStatus() (*ProjectState, error) {
 	state, err := s.loadSyncState()
@@ -0,0 +1,169 @@
+package kb
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSyncBidirectional(t *testing.T) {
+	vault := t.TempDir()
+	project := filepath.Join(t.TempDir(), "shop")
+	writeVaultDoc(t, project, "docs/api.md", "# API v1\n")
+	writeVaultDoc(t, project, "docs/guide.md", "# Guide\n")
+	writeVaultDoc(t, project, "ports/cart.md", "# Cart\n")
+	os.MkdirAll(filepath.Join(vault, MetaDir), 0755)
+
+	svc := NewSyncService(vault, project)
+	if err := svc.SetDirection("docs", SyncBoth); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SetDirection("nope", SyncBoth); err == nil {
+		t.Error("알 수 없는 디렉토리는 거부해야 함")
+	}
+
+	result, err := svc.Sync(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added) != 3 {
+		t.Fatalf("첫 동기화 = %+v", result)
+	}
+
+	vaultDocs := filepath.Join(vault, ProjectsDir, "shop", "docs")
+	vaultPorts := filepath.Join(vault, ProjectsDir, "shop", "ports")
+
+	// vault 편집 → 프로젝트로 (docs는 양방향)
+	writeVaultDoc(t, vaultDocs, "api.md", "# API v2 (Obsidian)\n")
+	// vault에서 새 문서 → 프로젝트로, vault가 만든 _toc.md는 제외
+	writeVaultDoc(t, vaultDocs, "faq.md", "# FAQ\n")
+	writeVaultDoc(t, vaultDocs, "_toc.md", "# TOC\n")
+	// ports는 단방향: vault 편집은 프로젝트로 가지 않음
+	writeVaultDoc(t, vaultPorts, "cart.md", "# Cart (vault)\n")
+
+	result, err = svc.Sync(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(result.Pulled)
+	if !slices.Equal(result.Pulled, []string{"docs/api.md", "docs/faq.md"}) {
+		t.Errorf("Pulled = %v", result.Pulled)
+	}
+	if got := readFile(t, filepath.Join(project, "docs/api.md")); got != "# API v2 (Obsidian)\n" {
+		t.Errorf("프로젝트 api.md = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(project, "docs/_toc.md")); err == nil {
+		t.Error("_toc.md를 프로젝트로 가져오면 안 됨")
+	}
+	if got := readFile(t, filepath.Join(project, "ports/cart.md")); got != "# Cart\n" {
+		t.Errorf("단방향 포트가 덮어써짐: %q", got)
+	}
+
+	// 양쪽 수정 → 충돌 보고
+	writeVaultDoc(t, project, "docs/guide.md", "# Guide (repo)\n")
+	writeVaultDoc(t, vaultDocs, "guide.md", "# Guide (vault)\n")
+	result, _ = svc.Sync(nil)
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Reason != ConflictBothChanged || result.Conflicts[0].ProjectPath != "docs/guide.md" {
+		t.Fatalf("Conflicts = %+v", result.Conflicts)
+	}
+	if result.ReportPath == "" {
+		t.Fatal("충돌 보고서 경로가 없음")
+	}
+	if _, err := os.Stat(filepath.Join(vault, result.ReportPath)); err != nil {
+		t.Errorf("충돌 보고서 없음: %v", err)
+	}
+
+	// 미리보기는 아무것도 바꾸지 않음
+	result, _ = svc.Sync(&SyncOptions{DryRun: true, Force: true, Direction: SyncFromVault})
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Resolution != "vault" {
+		t.Errorf("dry-run force = %+v", result.Conflicts)
+	}
+	if got := readFile(t, filepath.Join(project, "docs/guide.md")); got != "# Guide (repo)\n" {
+		t.Errorf("dry-run이 파일을 바꿈: %q", got)
+	}
+
+	// --force (both): 프로젝트가 이기고 보고서는 제거
+	result, _ = svc.Sync(&SyncOptions{Force: true})
+	if got := readFile(t, filepath.Join(vaultDocs, "guide.md")); got != "# Guide (repo)\n" {
+		t.Errorf("force 후 vault guide.md = %q", got)
+	}
+	if result.ReportPath != "" {
+		t.Errorf("충돌이 해결됐는데 보고서가 남음: %s", result.ReportPath)
+	}
+
+	// vault 삭제 → 프로젝트에서도 삭제
+	os.Remove(filepath.Join(vaultDocs, "faq.md"))
+	result, _ = svc.Sync(nil)
+	if !slices.Equal(result.PulledDeleted, []string{"docs/faq.md"}) {
+		t.Errorf("PulledDeleted = %v", result.PulledDeleted)
+	}
+	if _, err := os.Stat(filepath.Join(project, "docs/faq.md")); !os.IsNotExist(err) {
+		t.Error("vault에서 삭제한 문서가 프로젝트에 남음")
+	}
+}
+
+func TestSyncOneWayKeepsVaultEdits(t *testing.T) {
+	vault := t.TempDir()
+	project := filepath.Join(t.TempDir(), "shop")
+	writeVaultDoc(t, project, "ports/cart.md", "# Cart\n")
+	writeVaultDoc(t, project, "ports/order.md", "# Order\n")
+	os.MkdirAll(filepath.Join(vault, MetaDir), 0755)
+
+	svc := NewSyncService(vault, project)
+	if _, err := svc.Sync(nil); err != nil {
+		t.Fatal(err)
+	}
+	vaultPorts := filepath.Join(vault, ProjectsDir, "shop", "ports")
+
+	// Obsidian에서 수정한 문서를 프로젝트에서 삭제하면 vault 편집을 지우지 않고 충돌
+	writeVaultDoc(t, vaultPorts, "cart.md", "# Cart (Obsidian 메모)\n")
+	os.Remove(filepath.Join(project, "ports/cart.md"))
+	// 수정 없는 문서의 삭제는 그대로 반영
+	os.Remove(filepath.Join(project, "ports/order.md"))
+
+	result, err := svc.Sync(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Reason != ConflictDeletedProj || result.Conflicts[0].ProjectPath != "ports/cart.md" {
+		t.Fatalf("Conflicts = %+v", result.Conflicts)
+	}
+	if got := readFile(t, filepath.Join(vaultPorts, "cart.md")); got != "# Cart (Obsidian 메모)\n" {
+		t.Errorf("vault 편집이 사라짐: %q", got)
+	}
+	if !slices.Equal(result.Deleted, []string{"order.md"}) {
+		t.Errorf("Deleted = %v", result.Deleted)
+	}
+}
+
+func TestClassifySync(t *testing.T) {
+	tests := []struct {
+		src, tgt, base, want string
+	}{
+		{"a", "a", "a", syncUnchanged},
+		{"b", "a", "a", syncSourceChanged},
+		{"a", "b", "a", syncTargetChanged},
+		{"b", "c", "a", ConflictBothChanged},
+		{"a", "", "", syncSourceChanged},
+		{"", "a", "", syncTargetChanged},
+		{"a", "b", "", ConflictBothAdded},
+		{"", "b", "a", ConflictDeletedProj},
+		{"b", "", "a", ConflictDeletedVault},
+		{"", "", "a", syncUnchanged},
+	}
+	for _, tt := range tests {
+		if got := classifySync(tt.src, tt.tgt, tt.base); got != tt.want {
+			t.Errorf("classifySync(%q, %q, %q) = %s, want %s", tt.src, tt.tgt, tt.base, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,419 @@
+package kb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 분류체계 편집/검증: _taxonomy/*.yaml을 yaml.Node로 고쳐 써 주석과 순서를 보존하고,
+// 쓰기 전에 다시 파싱해 깨진 파일이 남지 않게 합니다. 검증은 분류체계 자체, 분류기/린트 규칙이
+// 참조하는 값, 문서의 frontmatter를 차례로 확인합니다.
+
+// Taxonomy files
+const (
+	DomainsFile  = "domains.yaml"
+	DocTypesFile = "doc-types.yaml"
+	TagsFile     = "tags.yaml"
+)
+
+// domainTagGroup is the tags.yaml group that lists domain tags
+const domainTagGroup = "domain"
+
+var (
+	taxonomyIDRegex  = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	taxonomyTagRegex = regexp.MustCompile(`^[a-z0-9가-힣][a-z0-9가-힣_/-]*$`)
+)
+
+// TaxonomyService edits and validates the vault taxonomy
+type TaxonomyService struct {
+	vaultPath string
+}
+
+// TaxonomyIssue is a problem in the taxonomy files or in a rule referencing them
+type TaxonomyIssue struct {
+	File    string `json:"file"`
+	Kind    string `json:"kind"` // parse, reference, classifier, lint
+	Message string `json:"message"`
+}
+
+// TaxonomyViolation is a document frontmatter value outside the taxonomy
+type TaxonomyViolation struct {
+	Path    string `json:"path"`
+	Field   string `json:"field"` // type, domain, tags 또는 빠진 필수 필드
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+// TaxonomyReport is the result of Validate
+type TaxonomyReport struct {
+	Domains    int                  `json:"domains"`
+	DocTypes   int                  `json:"doc_types"`
+	Tags       int                  `json:"tags"`
+	Checked    int                  `json:"checked"` // 검사한 문서 수
+	Issues     []*TaxonomyIssue     `json:"issues"`
+	Violations []*TaxonomyViolation `json:"violations"`
+}
+
+// OK reports whether the taxonomy and all documents are valid
+func (r *TaxonomyReport) OK() bool {
+	return len(r.Issues) == 0 && len(r.Violations) == 0
+}
+
+// NewTaxonomyService creates a new taxonomy service
+func NewTaxonomyService(vaultPath string) *TaxonomyService {
+	return &TaxonomyService{vaultPath: vaultPath}
+}
+
+// AddDomain adds a domain to domains.yaml and its tag to the domain tag group.
+// d.Tags의 태그는 도메인 ID를 빼고 tags.yaml에 이미 정의되어 있어야 합니다.
+func (t *TaxonomyService) AddDomain(id string, d Domain) error {
+	if !taxonomyIDRegex.MatchString(id) {
+		return fmt.Errorf("도메인 ID는 소문자, 숫자, 하이픈만 사용할 수 있습니다: %s", id)
+	}
+	if d.Name == "" {
+		d.Name = id
+	}
+	if !containsString(d.Tags, id) {
+		d.Tags = append([]string{id}, d.Tags...)
+	}
+
+	tags, err := t.loadTags()
+	if err != nil {
+		return err
+	}
+	defined := definedTags(tags)
+	for _, tag := range d.Tags {
+		if tag != id && !defined[tag] {
+			return fmt.Errorf("정의되지 않은 태그입니다: %s ('pal kb taxonomy add-tag'로 먼저 추가하세요)", tag)
+		}
+	}
+
+	doc, err := t.loadNode(DomainsFile)
+	if err != nil {
+		return err
+	}
+	domains := mappingEntry(doc.Content[0], "domains")
+	if findEntry(domains, id) != nil {
+		return fmt.Errorf("이미 정의된 도메인입니다: %s", id)
+	}
+	if err := appendMappingEntry(domains, id, d); err != nil {
+		return err
+	}
+	if err := t.writeNode(DomainsFile, doc, &DomainsConfig{}); err != nil {
+		return err
+	}
+
+	if defined[id] {
+		return nil
+	}
+	return t.AddTag(domainTagGroup, id, "도메인 태그")
+}
+
+// AddTag adds a tag to a tags.yaml group, creating the group when missing (description은 새 그룹에만 사용)
+func (t *TaxonomyService) AddTag(group, tag, description string) error {
+	tag = strings.TrimPrefix(tag, "#")
+	if group == "" {
+		return fmt.Errorf("태그 그룹이 필요합니다 (--group)")
+	}
+	if !taxonomyTagRegex.MatchString(tag) {
+		return fmt.Errorf("태그는 공백 없는 소문자여야 합니다: %s", tag)
+	}
+
+	tags, err := t.loadTags()
+	if err != nil {
+		return err
+	}
+	for name, g := range tags.Hierarchy {
+		if containsString(g.Tags, tag) {
+			return fmt.Errorf("이미 '%s' 그룹에 정의된 태그입니다: %s", name, tag)
+		}
+	}
+
+	doc, err := t.loadNode(TagsFile)
+	if err != nil {
+		return err
+	}
+	hierarchy := mappingEntry(doc.Content[0], "hierarchy")
+	groupNode := findEntry(hierarchy, group)
+	if groupNode == nil {
+		if err := appendMappingEntry(hierarchy, group, TagGroup{Description: description, Tags: []string{tag}}); err != nil {
+			return err
+		}
+	} else {
+		list := mappingEntry(groupNode, "tags")
+		list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag})
+	}
+	return t.writeNode(TagsFile, doc, &TagsConfig{})
+}
+
+// Validate checks the taxonomy files, the classifier/lint rules and every document's frontmatter
+func (t *TaxonomyService) Validate() (*TaxonomyReport, error) {
+	report := &TaxonomyReport{Issues: []*TaxonomyIssue{}, Violations: []*TaxonomyViolation{}}
+	issue := func(file, kind, format string, args ...interface{}) {
+		report.Issues = append(report.Issues, &TaxonomyIssue{File: file, Kind: kind, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var domains DomainsConfig
+	var docTypes DocTypesConfig
+	var tags TagsConfig
+	for _, f := range []struct {
+		name string
+		out  interface{}
+	}{{DomainsFile, &domains}, {DocTypesFile, &docTypes}, {TagsFile, &tags}} {
+		if err := t.readYAML(f.name, f.out); err != nil {
+			issue(f.name, "parse", "%v", err)
+		}
+	}
+	report.Domains, report.DocTypes = len(domains.Domains), len(docTypes.Types)
+	defined := definedTags(&tags)
+	report.Tags = len(defined)
+
+	// 1. 분류체계 내부 참조
+	seen := make(map[string]string)
+	for _, group := range sortedMapKeys(tags.Hierarchy) {
+		for _, tag := range tags.Hierarchy[group].Tags {
+			if prev, ok := seen[tag]; ok {
+				issue(TagsFile, "reference", "태그가 여러 그룹에 중복 정의되어 있습니다: %s (%s, %s)", tag, prev, group)
+			}
+			seen[tag] = group
+		}
+	}
+	for _, id := range sortedMapKeys(domains.Domains) {
+		for _, tag := range domains.Domains[id].Tags {
+			if !defined[tag] {
+				issue(DomainsFile, "reference", "도메인 %s의 태그가 %s에 없습니다: %s", id, TagsFile, tag)
+			}
+		}
+	}
+
+	// 2. 분류기/린트 규칙이 참조하는 값
+	classifier := NewClassifierService(t.vaultPath).GetTaxonomy()
+	for _, id := range sortedMapKeys(classifier.DocTypes) {
+		if _, ok := docTypes.Types[id]; !ok {
+			issue(DocTypesFile, "classifier", "분류기가 제안하는 문서 타입이 정의되어 있지 않습니다: %s", id)
+		}
+	}
+	for _, id := range sortedMapKeys(classifier.Domains) {
+		if _, ok := domains.Domains[id]; !ok {
+			issue(DomainsFile, "classifier", "분류기가 제안하는 도메인이 정의되어 있지 않습니다: %s", id)
+		}
+	}
+	for _, id := range sortedMapKeys(classifier.Tags) {
+		if !defined[id] {
+			issue(TagsFile, "classifier", "분류기가 제안하는 태그가 정의되어 있지 않습니다: %s", id)
+		}
+	}
+	for _, id := range sortedMapKeys(lintTypeRequiredFields) {
+		if _, ok := docTypes.Types[id]; !ok {
+			issue(DocTypesFile, "lint", "린트 규칙의 문서 타입이 정의되어 있지 않습니다: %s", id)
+		}
+	}
+
+	// 3. 문서 frontmatter
+	for _, section := range []string{SystemDir, DomainsDir, ProjectsDir, ReferencesDir, ArchiveDir} {
+		filepath.Walk(filepath.Join(t.vaultPath, section), func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || filepath.Ext(path) != ".md" || strings.HasPrefix(info.Name(), "_") {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			fm := parseFrontmatterMap(string(data))
+			if fm == nil {
+				return nil
+			}
+			relPath, _ := filepath.Rel(t.vaultPath, path)
+			report.Checked++
+			report.Violations = append(report.Violations, checkDocumentTaxonomy(relPath, fm, &domains, &docTypes, defined)...)
+			return nil
+		})
+	}
+	return report, nil
+}
+
+// checkDocumentTaxonomy returns the taxonomy violations of one document's frontmatter
+func checkDocumentTaxonomy(relPath string, fm map[string]interface{}, domains *DomainsConfig, docTypes *DocTypesConfig, defined map[string]bool) []*TaxonomyViolation {
+	var violations []*TaxonomyViolation
+	add := func(field, value, format string, args ...interface{}) {
+		violations = append(violations, &TaxonomyViolation{Path: relPath, Field: field, Value: value, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if docType, ok := fm["type"].(string); ok && docType != "" {
+		if def, ok := docTypes.Types[docType]; !ok {
+			add("type", docType, "정의되지 않은 문서 타입입니다")
+		} else {
+			for _, field := range def.RequiredFields {
+				if _, ok := fm[field]; !ok {
+					add(field, "", "%s 타입의 필수 필드가 없습니다", docType)
+				}
+			}
+		}
+	}
+	if domain, ok := fm["domain"].(string); ok && domain != "" {
+		if _, ok := domains.Domains[domain]; !ok {
+			add("domain", domain, "정의되지 않은 도메인입니다")
+		}
+	}
+	if list, ok := fm["tags"].([]interface{}); ok {
+		for _, v := range list {
+			if tag, ok := v.(string); ok && !defined[strings.TrimPrefix(tag, "#")] {
+				add("tags", tag, "정의되지 않은 태그입니다")
+			}
+		}
+	}
+	return violations
+}
+
+func (t *TaxonomyService) loadTags() (*TagsConfig, error) {
+	var tags TagsConfig
+	if err := t.readYAML(TagsFile, &tags); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return &tags, nil
+}
+
+func (t *TaxonomyService) readYAML(name string, out interface{}) error {
+	data, err := os.ReadFile(filepath.Join(t.vaultPath, TaxonomyDir, name))
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%s 파싱 실패: %w", name, err)
+	}
+	return nil
+}
+
+// loadNode parses a taxonomy file as a YAML document (없으면 version만 있는 새 문서)
+func (t *TaxonomyService) loadNode(name string) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	data, err := os.ReadFile(filepath.Join(t.vaultPath, TaxonomyDir, name))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		data = []byte("version: \"1\"\n")
+	}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("%s 파싱 실패: %w", name, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s의 최상위가 맵이 아닙니다", name)
+	}
+	return doc, nil
+}
+
+// writeNode re-validates the edited document against its config type and replaces the file atomically
+func (t *TaxonomyService) writeNode(name string, doc *yaml.Node, check interface{}) error {
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, check); err != nil {
+		return fmt.Errorf("%s 편집 결과가 올바르지 않습니다: %w", name, err)
+	}
+
+	path := filepath.Join(t.vaultPath, TaxonomyDir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("%s 쓰기 실패: %w", name, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("%s 쓰기 실패: %w", name, err)
+	}
+	return nil
+}
+
+// findEntry returns the value node of key in a mapping, or nil
+func findEntry(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// mappingEntry returns the value node of key, creating an empty one when missing or null
+// (tags는 목록, 나머지는 맵)
+func mappingEntry(m *yaml.Node, key string) *yaml.Node {
+	kind := yaml.MappingNode
+	if key == "tags" {
+		kind = yaml.SequenceNode
+	}
+	if value := findEntry(m, key); value != nil {
+		if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+			*value = yaml.Node{Kind: kind}
+		}
+		return value
+	}
+	if m.Kind != yaml.MappingNode {
+		return nil
+	}
+	value := &yaml.Node{Kind: kind}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
+
+func appendMappingEntry(m *yaml.Node, key string, value interface{}) error {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return err
+	}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &node)
+	return nil
+}
+
+func definedTags(tags *TagsConfig) map[string]bool {
+	defined := make(map[string]bool)
+	for _, g := range tags.Hierarchy {
+		for _, tag := range g.Tags {
+			defined[tag] = true
+		}
+	}
+	return defined
+}
+
+// parseFrontmatterMap parses the YAML frontmatter of a document; 없거나 깨졌으면 nil
+func parseFrontmatterMap(content string) map[string]interface{} {
+	if !strings.HasPrefix(content, "---") {
+		return nil
+	}
+	parts := strings.SplitN(content, "---", 3)
+	if len(parts) < 3 {
+		return nil
+	}
+	var fm map[string]interface{}
+	if yaml.Unmarshal([]byte(parts[1]), &fm) != nil {
+		return nil
+	}
+	return fm
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
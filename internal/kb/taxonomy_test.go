@@ -0,0 +1,103 @@
+package kb
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaxonomyEditAndValidate(t *testing.T) {
+	vault := t.TempDir()
+	if err := NewService(vault).Init(); err != nil {
+		t.Fatal(err)
+	}
+	// 사람이 단 주석은 편집 후에도 남아야 함
+	writeVaultDoc(t, vault, "_taxonomy/tags.yaml", `version: "1"
+# 팀 공용 태그
+hierarchy:
+    domain:
+        description: 도메인 태그
+        tags: [example]
+    status:
+        tags:
+            - draft
+            - active
+`)
+
+	svc := NewTaxonomyService(vault)
+	if err := svc.AddDomain("payment", Domain{Name: "결제", Tags: []string{"undefined"}}); err == nil {
+		t.Error("expected error for an undefined domain tag")
+	}
+	if err := svc.AddDomain("Payment", Domain{}); err == nil {
+		t.Error("expected error for an invalid domain id")
+	}
+	if err := svc.AddDomain("payment", Domain{Name: "결제", Tags: []string{"active"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.AddDomain("payment", Domain{}); err == nil {
+		t.Error("expected error for a duplicate domain")
+	}
+	if err := svc.AddTag("status", "draft", ""); err == nil {
+		t.Error("expected error for a duplicate tag")
+	}
+	if err := svc.AddTag("topic", "멱등성", "주제"); err != nil {
+		t.Fatal(err)
+	}
+
+	tags := readVaultDoc(t, vault, "_taxonomy/tags.yaml")
+	if !strings.Contains(tags, "# 팀 공용 태그") || !strings.Contains(tags, "tags: [example, payment]") || !strings.Contains(tags, "description: 주제") {
+		t.Errorf("tags.yaml:\n%s", tags)
+	}
+	var domains DomainsConfig
+	if err := svc.readYAML(DomainsFile, &domains); err != nil {
+		t.Fatal(err)
+	}
+	if d := domains.Domains["payment"]; d.Name != "결제" || strings.Join(d.Tags, ",") != "payment,active" {
+		t.Errorf("payment = %+v", d)
+	}
+
+	writeVaultDoc(t, vault, "10-Domains/payment/refund.md", "---\ntitle: 환불\ntype: concept\ndomain: payment\ntags: [멱등성]\n---\n# 환불\n")
+	writeVaultDoc(t, vault, "10-Domains/payment/legacy.md", "---\ntitle: 레거시\ntype: memo\ndomain: billing\ntags: [old]\n---\n")
+	writeVaultDoc(t, vault, "10-Domains/payment/port.md", "---\ntitle: 포트\ntype: port\n---\n")
+	writeVaultDoc(t, vault, "10-Domains/payment/plain.md", "# frontmatter 없음\n")
+
+	report, err := svc.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Checked != 3 || report.Domains != 2 || report.DocTypes != 4 {
+		t.Errorf("report = %+v", report)
+	}
+	got := make(map[string]bool)
+	for _, v := range report.Violations {
+		got[v.Path[strings.LastIndex(v.Path, "/")+1:]+":"+v.Field+"="+v.Value] = true
+	}
+	for _, want := range []string{"legacy.md:type=memo", "legacy.md:domain=billing", "legacy.md:tags=old", "port.md:id=", "port.md:status="} {
+		if !got[want] {
+			t.Errorf("missing violation %s in %v", want, got)
+		}
+	}
+	if len(report.Violations) != 5 {
+		t.Errorf("violations = %v", got)
+	}
+
+	// 분류기/린트 기본 규칙의 session 타입은 doc-types.yaml에 없음
+	kinds := make(map[string]bool)
+	for _, issue := range report.Issues {
+		kinds[issue.Kind] = true
+		if issue.Kind == "reference" {
+			t.Errorf("unexpected issue: %+v", issue)
+		}
+	}
+	if !kinds["classifier"] || !kinds["lint"] {
+		t.Errorf("issues = %+v", report.Issues)
+	}
+
+	writeVaultDoc(t, vault, "_taxonomy/domains.yaml", "domains: [broken\n")
+	report, _ = svc.Validate()
+	if report.Issues[0].Kind != "parse" {
+		t.Errorf("issues = %+v", report.Issues)
+	}
+	if err := svc.AddDomain("search", Domain{}); err == nil {
+		t.Error("expected error when domains.yaml is broken")
+	}
+}
@@ -449,6 +449,25 @@ func (s *Service) UpdateTOC(section string) (*TOCStats, bool, error) {
 	return stats, true, err
 }
 
+// RefreshTOC regenerates an existing TOC with its saved depth/sort, even when no file is newer
+// (문서 추가/삭제 반영). TOC가 없으면 만들지 않고 false를 반환합니다.
+func (s *Service) RefreshTOC(section string) (*TOCStats, bool, error) {
+	config, _, err := s.readTOCConfig(filepath.Join(s.vaultPath, section, "_toc.md"))
+	if err != nil {
+		return nil, false, nil
+	}
+	depth := config.Depth
+	if depth == 0 {
+		depth = 2
+	}
+	sortBy := config.Sort
+	if sortBy == "" {
+		sortBy = "alphabetical"
+	}
+	stats, err := s.GenerateTOC(section, depth, sortBy)
+	return stats, true, err
+}
+
 func (s *Service) readTOCConfig(tocPath string) (*TOCConfig, time.Time, error) {
 	data, err := os.ReadFile(tocPath)
 	if err != nil {
@@ -0,0 +1,306 @@
+package kb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// 감시 모드: vault의 문서 변경을 감지해 바뀐 문서만 재색인하고,
+// 해당 섹션의 TOC를 갱신하고, 바뀐 문서의 링크를 다시 검사합니다 (index/toc update/link check 반복 대체).
+// 파일 시스템 알림(fsnotify)을 받으면 mtime/크기 비교로 바뀐 문서를 가려내고,
+// 알림을 쓸 수 없는 환경(inotify 한도 초과, 네트워크 드라이브 등)에서는 주기 검사로 대체합니다.
+
+// defaultWatchInterval is how often the vault is scanned when notifications are unavailable
+const defaultWatchInterval = time.Second
+
+// watchDebounce groups the notifications of one save (임시 파일 쓰기 + rename 등) into a single scan
+const watchDebounce = 100 * time.Millisecond
+
+// newNotifier creates the file system notifier (테스트에서 주기 검사 대체 경로를 강제할 때 교체)
+var newNotifier = fsnotify.NewWatcher
+
+// watchSections are the vault sections watched (UpdateIndex와 같은 범위)
+var watchSections = []string{SystemDir, DomainsDir, ProjectsDir, ReferencesDir, ArchiveDir}
+
+// WatchOptions configures a Watcher
+type WatchOptions struct {
+	Interval time.Duration // 알림을 쓸 수 없을 때의 검사 주기 (기본 1초)
+	NoTOC    bool          // TOC 갱신 안 함
+	NoLinks  bool          // 링크 검사 안 함
+}
+
+// WatchEvent reports the changes handled in one scan
+type WatchEvent struct {
+	Time        string        `json:"time"`
+	Added       []string      `json:"added,omitempty"`
+	Updated     []string      `json:"updated,omitempty"`
+	Removed     []string      `json:"removed,omitempty"`
+	TOCs        []string      `json:"tocs,omitempty"` // 갱신된 TOC 섹션
+	BrokenLinks []*BrokenLink `json:"broken_links,omitempty"`
+	Errors      []string      `json:"errors,omitempty"`
+	CatchUp     int           `json:"catch_up,omitempty"` // 감시 시작 시 따라잡은 문서 수
+}
+
+// Changed returns the number of documents added, updated or removed
+func (e *WatchEvent) Changed() int {
+	return len(e.Added) + len(e.Updated) + len(e.Removed)
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher keeps the index, TOCs and link checks of a vault up to date
+type Watcher struct {
+	vaultPath string
+	opts      WatchOptions
+	index     *IndexService
+	toc       *Service
+	links     *LinkService
+	files     map[string]fileStamp
+}
+
+// NewWatcher creates a watcher for a vault; 색인 DB는 Start(Run)에서 엽니다
+func NewWatcher(vaultPath string, opts WatchOptions) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultWatchInterval
+	}
+	return &Watcher{
+		vaultPath: vaultPath,
+		opts:      opts,
+		index:     NewIndexService(vaultPath),
+		toc:       NewService(vaultPath),
+		links:     NewLinkService(vaultPath),
+	}
+}
+
+// Start opens the index, catches up on changes made while not watching and takes the baseline scan.
+// 따라잡은 변경이 있으면 CatchUp이 채워진 이벤트를 반환합니다.
+func (w *Watcher) Start() (*WatchEvent, error) {
+	if err := w.index.Open(); err != nil {
+		return nil, err
+	}
+	added, updated, err := w.index.UpdateIndex()
+	if err != nil {
+		w.index.Close()
+		return nil, err
+	}
+	w.files = w.scan()
+	if added+updated == 0 {
+		return nil, nil
+	}
+	return &WatchEvent{Time: time.Now().Format(time.RFC3339), CatchUp: added + updated}, nil
+}
+
+// Close closes the index
+func (w *Watcher) Close() error {
+	return w.index.Close()
+}
+
+// Run starts the watcher and handles changes until ctx is cancelled (onChange는 변경마다 호출).
+// 파일 시스템 알림으로 변경을 기다리고, 알림을 쓸 수 없으면 Interval 주기 검사로 대체합니다.
+func (w *Watcher) Run(ctx context.Context, onChange func(*WatchEvent)) error {
+	event, err := w.Start()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	if event != nil && onChange != nil {
+		onChange(event)
+	}
+
+	notifier, err := w.startNotifier()
+	if err != nil {
+		return w.pollLoop(ctx, onChange)
+	}
+	defer notifier.Close()
+	return w.notifyLoop(ctx, notifier, onChange)
+}
+
+// pollLoop scans the vault every Interval
+func (w *Watcher) pollLoop(ctx context.Context, onChange func(*WatchEvent)) error {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		w.handle(onChange)
+	}
+}
+
+// notifyLoop scans the vault shortly after each burst of notifications
+func (w *Watcher) notifyLoop(ctx context.Context, notifier *fsnotify.Watcher, onChange func(*WatchEvent)) error {
+	var pending <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-notifier.Events:
+			if !ok {
+				return w.pollLoop(ctx, onChange)
+			}
+			if ev.Has(fsnotify.Create) {
+				if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+					w.watchTree(notifier, ev.Name)
+				}
+			}
+			if pending == nil {
+				pending = time.After(watchDebounce)
+			}
+		case _, ok := <-notifier.Errors:
+			if !ok {
+				return w.pollLoop(ctx, onChange)
+			}
+			// 큐 넘침 등으로 알림을 놓쳤을 수 있으니 전체 검사로 보정
+			if pending == nil {
+				pending = time.After(watchDebounce)
+			}
+		case <-pending:
+			pending = nil
+			w.handle(onChange)
+		}
+	}
+}
+
+func (w *Watcher) handle(onChange func(*WatchEvent)) {
+	if event := w.Poll(); event != nil && onChange != nil {
+		onChange(event)
+	}
+}
+
+// startNotifier watches the vault root (섹션 생성 감지) and every directory under the watched sections
+func (w *Watcher) startNotifier() (*fsnotify.Watcher, error) {
+	notifier, err := newNotifier()
+	if err != nil {
+		return nil, err
+	}
+	if err := notifier.Add(w.vaultPath); err != nil {
+		notifier.Close()
+		return nil, err
+	}
+	for _, section := range watchSections {
+		if err := w.watchTree(notifier, filepath.Join(w.vaultPath, section)); err != nil {
+			notifier.Close()
+			return nil, err
+		}
+	}
+	return notifier, nil
+}
+
+// watchTree adds a directory inside a watched section and its subdirectories (숨김 디렉토리 제외)
+func (w *Watcher) watchTree(notifier *fsnotify.Watcher, dir string) error {
+	rel, err := filepath.Rel(w.vaultPath, dir)
+	if err != nil || !slices.Contains(watchSections, strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]) {
+		return nil
+	}
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+		if strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return notifier.Add(path)
+	})
+}
+
+// Poll scans the vault once and handles the changes since the last scan; 변경이 없으면 nil
+func (w *Watcher) Poll() *WatchEvent {
+	current := w.scan()
+
+	event := &WatchEvent{Time: time.Now().Format(time.RFC3339)}
+	for path, stamp := range current {
+		prev, ok := w.files[path]
+		switch {
+		case !ok:
+			event.Added = append(event.Added, path)
+		case !prev.modTime.Equal(stamp.modTime) || prev.size != stamp.size:
+			event.Updated = append(event.Updated, path)
+		}
+	}
+	for path := range w.files {
+		if _, ok := current[path]; !ok {
+			event.Removed = append(event.Removed, path)
+		}
+	}
+	w.files = current
+	if event.Changed() == 0 {
+		return nil
+	}
+	sort.Strings(event.Added)
+	sort.Strings(event.Updated)
+	sort.Strings(event.Removed)
+
+	// 1. 바뀐 문서만 재색인
+	touched := append(append([]string{}, event.Added...), event.Updated...)
+	for _, path := range touched {
+		if _, err := w.index.indexDocument(filepath.Join(w.vaultPath, path)); err != nil {
+			event.Errors = append(event.Errors, path+": "+err.Error())
+		}
+	}
+	for _, path := range event.Removed {
+		w.index.db.Exec("DELETE FROM documents WHERE path = ?", path)
+	}
+
+	// 2. 영향받은 섹션의 TOC (이미 TOC가 있는 섹션만)
+	if !w.opts.NoTOC {
+		sections := make(map[string]bool)
+		for _, path := range append(append([]string{}, touched...), event.Removed...) {
+			sections[strings.SplitN(filepath.ToSlash(path), "/", 2)[0]] = true
+		}
+		for section := range sections {
+			_, refreshed, err := w.toc.RefreshTOC(section)
+			if err != nil {
+				event.Errors = append(event.Errors, section+" TOC: "+err.Error())
+			} else if refreshed {
+				event.TOCs = append(event.TOCs, section)
+			}
+		}
+		sort.Strings(event.TOCs) // _toc.md는 scan 대상이 아니므로 TOC 쓰기는 다음 검사에서 변경이 아님
+	}
+
+	// 3. 바뀐 문서와 삭제된 문서를 가리키던 링크
+	if !w.opts.NoLinks {
+		if result, err := w.links.RecheckLinks(touched, event.Removed); err == nil {
+			event.BrokenLinks = result.BrokenLinks
+		}
+	}
+	return event
+}
+
+// scan stamps the watched markdown documents by vault-relative path
+func (w *Watcher) scan() map[string]fileStamp {
+	files := make(map[string]fileStamp)
+	for _, section := range watchSections {
+		filepath.Walk(filepath.Join(w.vaultPath, section), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			name := info.Name()
+			if info.IsDir() {
+				if strings.HasPrefix(name, ".") && path != filepath.Join(w.vaultPath, section) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(name) != ".md" || name == "_toc.md" || strings.HasPrefix(name, ".") {
+				return nil
+			}
+			relPath, _ := filepath.Rel(w.vaultPath, path)
+			files[relPath] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+	}
+	return files
+}
@@ -0,0 +1,141 @@
+package kb
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	vault := t.TempDir()
+	svc := NewService(vault)
+	if err := svc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	writeVaultDoc(t, vault, filepath.Join(DomainsDir, "auth", "login.md"), "# Login\n\n로그인 절차. [[session]] 참고.\n")
+	writeVaultDoc(t, vault, filepath.Join(DomainsDir, "auth", "session.md"), "# Session\n\n세션 관리.\n")
+	if _, err := svc.GenerateTOC(DomainsDir, 2, "alphabetical"); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewWatcher(vault, WatchOptions{})
+	event, err := w.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if event == nil || event.CatchUp != 2 {
+		t.Errorf("catch-up = %+v", event)
+	}
+	if event := w.Poll(); event != nil {
+		t.Errorf("변경이 없는데 이벤트: %+v", event)
+	}
+
+	// 추가 + 수정
+	writeVaultDoc(t, vault, filepath.Join(DomainsDir, "auth", "token.md"), "# Token\n\n토큰 갱신. [[missing-doc]]\n")
+	writeVaultDoc(t, vault, filepath.Join(DomainsDir, "auth", "session.md"), "# Session v2\n\n세션 만료 정책.\n")
+	event = w.Poll()
+	if event == nil {
+		t.Fatal("변경을 감지하지 못함")
+	}
+	tokenPath := filepath.Join(DomainsDir, "auth", "token.md")
+	sessionPath := filepath.Join(DomainsDir, "auth", "session.md")
+	if !slices.Equal(event.Added, []string{tokenPath}) || !slices.Equal(event.Updated, []string{sessionPath}) {
+		t.Errorf("Added=%v Updated=%v", event.Added, event.Updated)
+	}
+	if !slices.Equal(event.TOCs, []string{DomainsDir}) {
+		t.Errorf("TOCs = %v", event.TOCs)
+	}
+	if len(event.BrokenLinks) != 1 || event.BrokenLinks[0].Target != "missing-doc" {
+		t.Errorf("BrokenLinks = %+v", event.BrokenLinks)
+	}
+	toc, _ := os.ReadFile(filepath.Join(vault, DomainsDir, "_toc.md"))
+	if !strings.Contains(string(toc), "token") {
+		t.Error("새 문서가 TOC에 반영되지 않음")
+	}
+	if doc, err := w.index.GetByPath(sessionPath); err != nil || doc.Title != "Session v2" {
+		t.Errorf("재색인 안 됨: %+v, %v", doc, err)
+	}
+
+	// TOC 쓰기는 다음 검사에서 변경이 아님
+	if event := w.Poll(); event != nil {
+		t.Errorf("TOC 갱신이 변경으로 감지됨: %+v", event)
+	}
+
+	// 삭제: 색인에서 제거하고 삭제된 문서를 가리키던 링크를 보고
+	os.Remove(filepath.Join(vault, sessionPath))
+	event = w.Poll()
+	if event == nil || !slices.Equal(event.Removed, []string{sessionPath}) {
+		t.Fatalf("삭제 이벤트 = %+v", event)
+	}
+	if len(event.BrokenLinks) != 1 || event.BrokenLinks[0].Source != filepath.Join(DomainsDir, "auth", "login.md") {
+		t.Errorf("삭제 후 BrokenLinks = %+v", event.BrokenLinks)
+	}
+	if _, err := w.index.GetByPath(sessionPath); err == nil {
+		t.Error("삭제된 문서가 색인에 남음")
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		notify   bool
+		interval time.Duration
+	}{
+		{"notify", true, time.Hour}, // 주기 검사로는 잡히지 않아야 알림 경로를 검증
+		{"poll-fallback", false, 20 * time.Millisecond},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.notify {
+				orig := newNotifier
+				newNotifier = func() (*fsnotify.Watcher, error) { return nil, errors.New("inotify 한도 초과") }
+				t.Cleanup(func() { newNotifier = orig })
+			}
+			vault := t.TempDir()
+			if err := NewService(vault).Init(); err != nil {
+				t.Fatal(err)
+			}
+
+			events := make(chan *WatchEvent, 8)
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+			go func() {
+				done <- NewWatcher(vault, WatchOptions{Interval: tc.interval, NoLinks: true}).Run(ctx, func(e *WatchEvent) { events <- e })
+			}()
+			defer func() {
+				cancel()
+				if err := <-done; err != nil {
+					t.Error(err)
+				}
+			}()
+
+			// 감시 시작 후 새로 만든 하위 디렉토리의 문서도 감지
+			// (Run이 기준 검사를 마치기 전에 쓰면 따라잡기로 처리되므로 감지될 때까지 다시 씀)
+			doc := filepath.Join(DomainsDir, "auth", "login.md")
+			deadline := time.After(5 * time.Second)
+			for i := 1; ; i++ {
+				writeVaultDoc(t, vault, doc, "# Login\n"+strings.Repeat("로그인 ", i))
+				select {
+				case e := <-events:
+					if e.CatchUp > 0 {
+						continue
+					}
+					if !slices.Contains(e.Added, doc) && !slices.Contains(e.Updated, doc) {
+						t.Fatalf("event = %+v", e)
+					}
+					return
+				case <-time.After(200 * time.Millisecond):
+				case <-deadline:
+					t.Fatal("변경을 감지하지 못함")
+				}
+			}
+		})
+	}
+}
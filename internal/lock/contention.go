@@ -0,0 +1,231 @@
+package lock
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/escalation"
+)
+
+// Wait is a session blocked by a lock held by another session
+type Wait struct {
+	Resource  string
+	SessionID string
+	Holder    string
+	FirstAt   time.Time
+	LastAt    time.Time
+}
+
+// Contention is a held lock together with the sessions waiting on it
+type Contention struct {
+	Resource     string
+	Holder       string
+	Project      string // 보유 세션의 프로젝트 루트
+	Waiters      []Wait
+	EscalationID int64 // 0이면 아직 에스컬레이션되지 않음
+}
+
+// EscalationPolicy decides when contention is escalated to a human (0 = 해당 조건 비활성화)
+type EscalationPolicy struct {
+	WaitAfter  time.Duration // 가장 오래 기다린 세션의 대기 시간
+	MaxWaiters int           // 이 수를 넘는 세션이 대기하면 에스컬레이션
+}
+
+// PolicyFromSettings builds the escalation policy of a project (비활성화되었으면 false)
+func PolicyFromSettings(settings config.LockEscalationSettings) (EscalationPolicy, bool) {
+	policy := EscalationPolicy{WaitAfter: settings.WaitThreshold(), MaxWaiters: settings.WaiterThreshold()}
+	if settings.Disabled || (policy.WaitAfter == 0 && policy.MaxWaiters == 0) {
+		return policy, false
+	}
+	return policy, true
+}
+
+// RecordWait records that waiter is blocked on resource held by holder.
+// 보유 세션이 바뀌면 대기 시작 시각을 초기화합니다.
+func (s *Service) RecordWait(resource, waiter, holder string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO lock_waits (resource, session_id, holder, first_at, last_at)
+		VALUES (?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT(resource, session_id) DO UPDATE SET
+			first_at = CASE WHEN holder = excluded.holder THEN first_at ELSE excluded.first_at END,
+			escalation_id = CASE WHEN holder = excluded.holder THEN escalation_id ELSE NULL END,
+			holder = excluded.holder,
+			last_at = excluded.last_at
+	`, resource, waiter, holder)
+	if err != nil {
+		return fmt.Errorf("Lock 대기 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// ClearWaits removes every wait of a session (세션 종료 시)
+func (s *Service) ClearWaits(sessionID string) error {
+	if _, err := s.db.Exec(`DELETE FROM lock_waits WHERE session_id = ?`, sessionID); err != nil {
+		return fmt.Errorf("Lock 대기 정리 실패: %w", err)
+	}
+	return nil
+}
+
+// Contentions returns the held locks that have waiting sessions.
+// Lock이 해제되었거나 보유 세션이 바뀐 대기는 제외합니다.
+func (s *Service) Contentions() ([]Contention, error) {
+	rows, err := s.db.Query(`
+		SELECT w.resource, w.session_id, w.holder, w.first_at, w.last_at, COALESCE(w.escalation_id, 0),
+		       COALESCE(s.project_root, '')
+		FROM lock_waits w
+		JOIN locks l ON l.resource = w.resource AND l.session_id = w.holder
+		LEFT JOIN sessions s ON s.id = w.holder
+		ORDER BY w.resource, w.first_at, w.session_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("Lock 대기 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var contentions []Contention
+	for rows.Next() {
+		var w Wait
+		var escID int64
+		var project string
+		if err := rows.Scan(&w.Resource, &w.SessionID, &w.Holder, &w.FirstAt, &w.LastAt, &escID, &project); err != nil {
+			return nil, err
+		}
+		if n := len(contentions); n == 0 || contentions[n-1].Resource != w.Resource {
+			contentions = append(contentions, Contention{Resource: w.Resource, Holder: w.Holder, Project: project})
+		}
+		c := &contentions[len(contentions)-1]
+		c.Waiters = append(c.Waiters, w)
+		if escID > c.EscalationID {
+			c.EscalationID = escID
+		}
+	}
+	return contentions, rows.Err()
+}
+
+// Contention returns the contention of a single resource (대기 세션이 없으면 nil)
+func (s *Service) Contention(resource string) (*Contention, error) {
+	all, err := s.Contentions()
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		if all[i].Resource == resource {
+			return &all[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// LongestWait returns how long the earliest waiter has been blocked
+func (c *Contention) LongestWait(now time.Time) time.Duration {
+	var longest time.Duration
+	for _, w := range c.Waiters {
+		if d := now.Sub(w.FirstAt); d > longest {
+			longest = d
+		}
+	}
+	return longest
+}
+
+// WaiterIDs returns the waiting session IDs
+func (c *Contention) WaiterIDs() []string {
+	ids := make([]string, len(c.Waiters))
+	for i, w := range c.Waiters {
+		ids[i] = w.SessionID
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Issue describes the contention naming the holder and the waiters
+func (c *Contention) Issue(now time.Time) string {
+	return fmt.Sprintf("Lock 경합: '%s'을(를) 세션 %s이(가) 보유 중이며 %d개 세션(%s)이 최대 %s 대기 중입니다",
+		c.Resource, c.Holder, len(c.Waiters), strings.Join(c.WaiterIDs(), ", "), c.LongestWait(now).Round(time.Second))
+}
+
+// Exceeds reports whether the contention crosses the policy thresholds
+func (p EscalationPolicy) Exceeds(c *Contention, now time.Time) bool {
+	if p.MaxWaiters > 0 && len(c.Waiters) > p.MaxWaiters {
+		return true
+	}
+	return p.WaitAfter > 0 && c.LongestWait(now) >= p.WaitAfter
+}
+
+// Escalate opens a conflict escalation naming the holder and the waiters, once per contention.
+// 이미 에스컬레이션된 경합이면 기존 ID를 반환합니다.
+func (s *Service) Escalate(c *Contention, now time.Time) (int64, bool, error) {
+	if c.EscalationID != 0 {
+		return c.EscalationID, false, nil
+	}
+
+	waiters := strings.Join(c.WaiterIDs(), ", ")
+	wait := c.LongestWait(now).Round(time.Second)
+
+	tmpl := &escalation.Template{
+		ID:       string(escalation.TypeConflict),
+		Severity: escalation.SeverityHigh,
+		Fields: []escalation.TemplateField{
+			{Name: "resource", Description: "경합 중인 Lock 리소스"},
+			{Name: "holder", Description: "Lock 보유 세션"},
+			{Name: "waiters", Description: "대기 중인 세션"},
+			{Name: "longest_wait", Description: "최대 대기 시간"},
+		},
+		ResolutionSteps: []escalation.ResolutionStep{
+			{ID: "contact-holder", Description: "보유 세션의 작업 상태 확인"},
+			{ID: "release-or-reassign", Description: "Lock 해제(pal lock release) 또는 대기 세션 작업 재배치"},
+		},
+	}
+	id, err := escalation.NewService(s.db).CreateTyped(tmpl, escalation.TypedOptions{
+		Issue:     c.Issue(now),
+		SessionID: c.Holder,
+		Fields: map[string]string{
+			"resource":     c.Resource,
+			"holder":       c.Holder,
+			"waiters":      waiters,
+			"longest_wait": wait.String(),
+		},
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	if _, err := s.db.Exec(`UPDATE lock_waits SET escalation_id = ? WHERE resource = ? AND holder = ?`,
+		id, c.Resource, c.Holder); err != nil {
+		return id, true, fmt.Errorf("Lock 대기 갱신 실패: %w", err)
+	}
+	c.EscalationID = id
+	return id, true, nil
+}
+
+// EscalateExceeded escalates every contention crossing its policy that has not been escalated yet.
+// policyFor가 false를 반환하면 (예: 프로젝트에서 비활성화) 건너뜁니다.
+func (s *Service) EscalateExceeded(policyFor func(c *Contention) (EscalationPolicy, bool), now time.Time) ([]Contention, error) {
+	all, err := s.Contentions()
+	if err != nil {
+		return nil, err
+	}
+	var escalated []Contention
+	for i := range all {
+		c := &all[i]
+		if c.EscalationID != 0 {
+			continue
+		}
+		if policy, ok := policyFor(c); !ok || !policy.Exceeds(c, now) {
+			continue
+		}
+		if _, created, err := s.Escalate(c, now); err != nil {
+			return escalated, err
+		} else if created {
+			escalated = append(escalated, *c)
+		}
+	}
+	return escalated, nil
+}
+
+// deleteWaits removes the waits of a released resource
+func (s *Service) deleteWaits(resource string) {
+	s.db.Exec(`DELETE FROM lock_waits WHERE resource = ?`, resource)
+}
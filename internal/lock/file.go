@@ -0,0 +1,121 @@
+package lock
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IsFileResource reports whether a lock resource names files rather than a logical resource.
+// 경로 구분자, glob 문자(*, ?), 확장자가 있으면 경로로 봅니다. db-migration, api 같은 이름은
+// 논리 Lock이며, 한 단계 디렉토리는 "src/"처럼 끝에 /를 붙여 지정합니다.
+func IsFileResource(resource string) bool {
+	resource = strings.TrimSpace(resource)
+	if resource == "" {
+		return false
+	}
+	if strings.ContainsAny(resource, `/\*?`) || filepath.IsAbs(resource) {
+		return true
+	}
+	return filepath.Ext(resource) != ""
+}
+
+// MatchesFile reports whether a lock resource covers a file.
+// 리소스는 파일 경로, 디렉토리 경로, 또는 glob 패턴(*, ?, **)일 수 있으며
+// 상대 경로는 projectRoot 기준으로 해석됩니다. 논리 Lock(IsFileResource가 false)은 파일과 맞지 않습니다.
+func MatchesFile(resource, filePath, projectRoot string) bool {
+	resource = strings.TrimSpace(resource)
+	if !IsFileResource(resource) || filePath == "" {
+		return false
+	}
+
+	file := normalizePath(filePath, projectRoot)
+	pattern := normalizePath(resource, projectRoot)
+
+	if strings.ContainsAny(resource, "*?") {
+		return globToRegexp(pattern).MatchString(file)
+	}
+
+	pattern = strings.TrimSuffix(pattern, "/")
+	return file == pattern || strings.HasPrefix(file, pattern+"/")
+}
+
+// Covers reports whether the lock protects filePath when working in projectRoot.
+// 상대 경로 Lock은 Lock을 잡은 프로젝트에서만, 절대 경로 Lock은 어느 프로젝트에서나 적용됩니다.
+func (l *Lock) Covers(filePath, projectRoot string) bool {
+	if !filepath.IsAbs(strings.TrimSpace(l.Resource)) && !sameProject(l.ProjectRoot, projectRoot) {
+		return false
+	}
+	return MatchesFile(l.Resource, filePath, projectRoot)
+}
+
+// FindFileConflict returns a lock covering filePath held by another session.
+// ownSessions에 포함된 세션의 Lock은 충돌로 보지 않습니다.
+func (s *Service) FindFileConflict(filePath, projectRoot string, ownSessions ...string) (*Lock, error) {
+	locks, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	own := make(map[string]bool)
+	for _, id := range ownSessions {
+		if id != "" {
+			own[id] = true
+		}
+	}
+
+	for i := range locks {
+		if own[locks[i].SessionID] {
+			continue
+		}
+		if locks[i].Covers(filePath, projectRoot) {
+			return &locks[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// sameProject reports whether two project roots name the same directory
+func sameProject(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// normalizePath converts a path to an absolute, slash-separated form
+func normalizePath(p, projectRoot string) string {
+	if !filepath.IsAbs(p) && projectRoot != "" {
+		p = filepath.Join(projectRoot, p)
+	}
+	return filepath.ToSlash(filepath.Clean(p))
+}
+
+// globToRegexp compiles a glob pattern where ** matches across directories
+func globToRegexp(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
@@ -6,18 +6,22 @@ import (
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
 )
 
 // Lock represents a resource lock
 type Lock struct {
-	Resource   string
-	SessionID  string
-	AcquiredAt time.Time
+	Resource    string
+	SessionID   string
+	AcquiredAt  time.Time
+	ExpiresAt   *time.Time // nil이면 만료 없음 (세션 종료/명시적 해제까지 유지)
+	ProjectRoot string     // Lock을 잡은 프로젝트 (모르면 빈 값)
 }
 
 // Service handles lock operations
 type Service struct {
-	db *db.DB
+	db          *db.DB
+	projectRoot string
 }
 
 // NewService creates a new lock service
@@ -25,16 +29,38 @@ func NewService(database *db.DB) *Service {
 	return &Service{db: database}
 }
 
+// NewProjectService creates a lock service that records projectRoot on acquired locks.
+// 지정하지 않으면 Lock을 잡는 세션의 프로젝트를 기록합니다.
+func NewProjectService(database *db.DB, projectRoot string) *Service {
+	return &Service{db: database, projectRoot: projectRoot}
+}
+
+// lockProject returns the project root to record for a lock held by sessionID
+func (s *Service) lockProject(sessionID string) interface{} {
+	if s.projectRoot != "" {
+		return s.projectRoot
+	}
+	var root sql.NullString
+	s.db.QueryRow(`SELECT project_root FROM sessions WHERE (id = ? OR claude_session_id = ?) AND project_root != ''
+		ORDER BY started_at DESC LIMIT 1`, sessionID, sessionID).Scan(&root)
+	if !root.Valid {
+		return nil
+	}
+	return root.String
+}
+
 // Acquire attempts to acquire a lock on a resource
 // Returns nil if successful, error if already locked or failed
 func (s *Service) Acquire(resource, sessionID string) error {
+	s.purgeExpired()
+
 	// 이미 잠겨있는지 확인
 	var existing string
 	err := s.db.QueryRow(`SELECT session_id FROM locks WHERE resource = ?`, resource).Scan(&existing)
 	
 	if err == nil {
 		// 이미 잠김
-		return fmt.Errorf("리소스 '%s'는 세션 '%s'에 의해 잠겨있습니다", resource, existing)
+		return errcode.New(errcode.KindConflict, "리소스 '%s'는 세션 '%s'에 의해 잠겨있습니다", resource, existing)
 	}
 	
 	if err != sql.ErrNoRows {
@@ -42,7 +68,7 @@ func (s *Service) Acquire(resource, sessionID string) error {
 	}
 
 	// Lock 획득
-	_, err = s.db.Exec(`INSERT INTO locks (resource, session_id) VALUES (?, ?)`, resource, sessionID)
+	_, err = s.db.Exec(`INSERT INTO locks (resource, session_id, project_root) VALUES (?, ?, ?)`, resource, sessionID, s.lockProject(sessionID))
 	if err != nil {
 		return fmt.Errorf("Lock 획득 실패: %w", err)
 	}
@@ -50,6 +76,94 @@ func (s *Service) Acquire(resource, sessionID string) error {
 	return nil
 }
 
+// AcquireTTL acquires a lock that expires after ttl (0 = no expiry).
+// 같은 세션이 이미 보유한 Lock을 다시 획득하면 만료 시각을 갱신합니다 (heartbeat).
+func (s *Service) AcquireTTL(resource, sessionID string, ttl time.Duration) (*Lock, error) {
+	if ttl < 0 {
+		return nil, errcode.New(errcode.KindValidation, "TTL은 0 이상이어야 합니다: %s", ttl)
+	}
+	s.purgeExpired()
+
+	var expiresAt *time.Time
+	if ttl > 0 {
+		t := time.Now().UTC().Add(ttl)
+		expiresAt = &t
+	}
+
+	var existing string
+	err := s.db.QueryRow(`SELECT session_id FROM locks WHERE resource = ?`, resource).Scan(&existing)
+	switch {
+	case err == nil && existing != sessionID:
+		return nil, errcode.New(errcode.KindConflict, "리소스 '%s'는 세션 '%s'에 의해 잠겨있습니다", resource, existing)
+	case err == nil:
+		if _, err := s.db.Exec(`UPDATE locks SET expires_at = ? WHERE resource = ?`, expiresAt, resource); err != nil {
+			return nil, fmt.Errorf("Lock 갱신 실패: %w", err)
+		}
+	case err == sql.ErrNoRows:
+		if _, err := s.db.Exec(`INSERT INTO locks (resource, session_id, expires_at, project_root) VALUES (?, ?, ?, ?)`,
+			resource, sessionID, expiresAt, s.lockProject(sessionID)); err != nil {
+			return nil, fmt.Errorf("Lock 획득 실패: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("Lock 확인 실패: %w", err)
+	}
+
+	return s.Get(resource)
+}
+
+// Get returns the active lock on a resource
+func (s *Service) Get(resource string) (*Lock, error) {
+	s.purgeExpired()
+
+	var l Lock
+	var expiresAt sql.NullTime
+	err := s.db.QueryRow(`SELECT resource, session_id, acquired_at, expires_at, COALESCE(project_root, '') FROM locks WHERE resource = ?`, resource).
+		Scan(&l.Resource, &l.SessionID, &l.AcquiredAt, &expiresAt, &l.ProjectRoot)
+	if err == sql.ErrNoRows {
+		return nil, errcode.New(errcode.KindNotFound, "리소스 '%s'에 대한 Lock이 없습니다", resource)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Lock 조회 실패: %w", err)
+	}
+	if expiresAt.Valid {
+		l.ExpiresAt = &expiresAt.Time
+	}
+	return &l, nil
+}
+
+// ReleaseBy releases a lock only when it is held by sessionID
+func (s *Service) ReleaseBy(resource, sessionID string) error {
+	l, err := s.Get(resource)
+	if err != nil {
+		return err
+	}
+	if l.SessionID != sessionID {
+		return errcode.New(errcode.KindConflict, "리소스 '%s'는 다른 세션 '%s'이 보유 중이라 해제할 수 없습니다", resource, l.SessionID)
+	}
+	return s.Release(resource)
+}
+
+// purgeExpired drops locks whose TTL has passed
+func (s *Service) purgeExpired() {
+	rows, err := s.db.Query(`SELECT resource FROM locks WHERE expires_at IS NOT NULL AND expires_at <= ?`, time.Now().UTC())
+	if err != nil {
+		return
+	}
+	var expired []string
+	for rows.Next() {
+		var resource string
+		if rows.Scan(&resource) == nil {
+			expired = append(expired, resource)
+		}
+	}
+	rows.Close()
+
+	for _, resource := range expired {
+		s.db.Exec(`DELETE FROM locks WHERE resource = ?`, resource)
+		s.deleteWaits(resource)
+	}
+}
+
 // Release releases a lock on a resource
 func (s *Service) Release(resource string) error {
 	result, err := s.db.Exec(`DELETE FROM locks WHERE resource = ?`, resource)
@@ -59,15 +173,18 @@ func (s *Service) Release(resource string) error {
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("리소스 '%s'에 대한 Lock이 없습니다", resource)
+		return errcode.New(errcode.KindNotFound, "리소스 '%s'에 대한 Lock이 없습니다", resource)
 	}
 
+	s.deleteWaits(resource)
 	return nil
 }
 
 // List returns all active locks
 func (s *Service) List() ([]Lock, error) {
-	rows, err := s.db.Query(`SELECT resource, session_id, acquired_at FROM locks ORDER BY acquired_at`)
+	s.purgeExpired()
+
+	rows, err := s.db.Query(`SELECT resource, session_id, acquired_at, expires_at, COALESCE(project_root, '') FROM locks ORDER BY acquired_at`)
 	if err != nil {
 		return nil, fmt.Errorf("Lock 목록 조회 실패: %w", err)
 	}
@@ -76,9 +193,13 @@ func (s *Service) List() ([]Lock, error) {
 	var locks []Lock
 	for rows.Next() {
 		var l Lock
-		if err := rows.Scan(&l.Resource, &l.SessionID, &l.AcquiredAt); err != nil {
+		var expiresAt sql.NullTime
+		if err := rows.Scan(&l.Resource, &l.SessionID, &l.AcquiredAt, &expiresAt, &l.ProjectRoot); err != nil {
 			return nil, err
 		}
+		if expiresAt.Valid {
+			l.ExpiresAt = &expiresAt.Time
+		}
 		locks = append(locks, l)
 	}
 
@@ -91,11 +212,14 @@ func (s *Service) Clear() (int64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("Lock 정리 실패: %w", err)
 	}
+	s.db.Exec(`DELETE FROM lock_waits`)
 	return result.RowsAffected()
 }
 
 // IsLocked checks if a resource is locked
 func (s *Service) IsLocked(resource string) (bool, string, error) {
+	s.purgeExpired()
+
 	var sessionID string
 	err := s.db.QueryRow(`SELECT session_id FROM locks WHERE resource = ?`, resource).Scan(&sessionID)
 	
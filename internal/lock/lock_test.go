@@ -4,8 +4,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/escalation"
 )
 
 func setupTestDB(t *testing.T) (*db.DB, func()) {
@@ -178,3 +180,171 @@ func TestLockStruct(t *testing.T) {
 		t.Error("AcquiredAt이 설정되지 않음")
 	}
 }
+
+func TestMatchesFile(t *testing.T) {
+	root := "/work/app"
+	tests := []struct {
+		resource string
+		file     string
+		want     bool
+	}{
+		{"src/main.go", "/work/app/src/main.go", true},
+		{"/work/app/src/main.go", "/work/app/src/main.go", true},
+		{"src/order", "/work/app/src/order/entity.go", true},
+		{"src/order/", "/work/app/src/orders.go", false},
+		{"src/*.go", "/work/app/src/main.go", true},
+		{"src/*.go", "/work/app/src/sub/main.go", false},
+		{"src/**/*.go", "/work/app/src/sub/deep/main.go", true},
+		{"src/**/*.go", "/work/app/src/main.go", true},
+		{"db-migration", "/work/app/src/main.go", false},
+		{"api", "/work/app/api/handler.go", false},
+		{"api/", "/work/app/api/handler.go", true},
+		{"main.go", "/work/app/main.go", true},
+	}
+	for _, tt := range tests {
+		if got := MatchesFile(tt.resource, tt.file, root); got != tt.want {
+			t.Errorf("MatchesFile(%q, %q) = %v, want %v", tt.resource, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestFindFileConflict(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewProjectService(database, "/work/app")
+	svc.Acquire("src/order/**", "session-a")
+
+	conflict, err := svc.FindFileConflict("/work/app/src/order/entity.go", "/work/app", "session-b")
+	if err != nil {
+		t.Fatalf("FindFileConflict 실패: %v", err)
+	}
+	if conflict == nil || conflict.SessionID != "session-a" {
+		t.Errorf("session-a의 Lock이 충돌로 감지되어야 함: %+v", conflict)
+	}
+
+	conflict, _ = svc.FindFileConflict("/work/app/src/order/entity.go", "/work/app", "session-a")
+	if conflict != nil {
+		t.Error("자신의 Lock은 충돌이 아니어야 함")
+	}
+}
+
+func TestFindFileConflictScopedToProject(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database.Exec(`INSERT INTO sessions (id, project_root, status) VALUES ('session-a', '/work/app', 'running')`)
+	svc := NewService(database)
+	if err := svc.Acquire("src/", "session-a"); err != nil {
+		t.Fatal(err)
+	}
+	if l, _ := svc.Get("src/"); l == nil || l.ProjectRoot != "/work/app" {
+		t.Fatalf("세션의 프로젝트가 기록되어야 함: %+v", l)
+	}
+	NewProjectService(database, "/work/app").Acquire("/shared/schema.sql", "session-a")
+
+	if conflict, _ := svc.FindFileConflict("/work/other/src/main.go", "/work/other", "session-b"); conflict != nil {
+		t.Errorf("다른 프로젝트의 상대 경로 Lock은 충돌이 아니어야 함: %+v", conflict)
+	}
+	if conflict, _ := svc.FindFileConflict("/work/app/src/main.go", "/work/app", "session-b"); conflict == nil || conflict.Resource != "src/" {
+		t.Errorf("같은 프로젝트의 Lock은 충돌이어야 함: %+v", conflict)
+	}
+	if conflict, _ := svc.FindFileConflict("/shared/schema.sql", "/work/other", "session-b"); conflict == nil {
+		t.Error("절대 경로 Lock은 프로젝트와 무관하게 충돌이어야 함")
+	}
+}
+
+func TestContentionEscalation(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	svc.Acquire("src/api/**", "holder-1")
+	svc.RecordWait("src/api/**", "waiter-b", "holder-1")
+	svc.RecordWait("src/api/**", "waiter-a", "holder-1")
+	svc.RecordWait("src/api/**", "waiter-a", "holder-1") // 재시도는 대기 시작 시각 유지
+
+	c, err := svc.Contention("src/api/**")
+	if err != nil || c == nil {
+		t.Fatalf("경합 조회 실패: %v", err)
+	}
+	if c.Holder != "holder-1" || len(c.Waiters) != 2 {
+		t.Fatalf("경합 정보 불일치: %+v", c)
+	}
+
+	now := time.Now()
+	policy := EscalationPolicy{WaitAfter: 10 * time.Minute, MaxWaiters: 2}
+	if policy.Exceeds(c, now) {
+		t.Error("임계치 전에는 에스컬레이션하지 않아야 함")
+	}
+	if !policy.Exceeds(c, now.Add(11*time.Minute)) {
+		t.Error("대기 시간 초과 시 에스컬레이션해야 함")
+	}
+
+	escalated, err := svc.EscalateExceeded(func(*Contention) (EscalationPolicy, bool) { return policy, true }, now.Add(11*time.Minute))
+	if err != nil || len(escalated) != 1 {
+		t.Fatalf("에스컬레이션 실패: %v %+v", err, escalated)
+	}
+	e, err := escalation.NewService(database).Get(escalated[0].EscalationID)
+	if err != nil {
+		t.Fatalf("에스컬레이션 조회 실패: %v", err)
+	}
+	if fields := e.Fields(); fields["holder"] != "holder-1" || fields["waiters"] != "waiter-a, waiter-b" {
+		t.Errorf("보유/대기 세션이 기록되어야 함: %v", fields)
+	}
+
+	// 같은 경합은 한 번만 에스컬레이션
+	again, _ := svc.EscalateExceeded(func(*Contention) (EscalationPolicy, bool) { return policy, true }, now.Add(20*time.Minute))
+	if len(again) != 0 {
+		t.Errorf("중복 에스컬레이션: %+v", again)
+	}
+
+	// 해제 시 대기 정리
+	svc.Release("src/api/**")
+	if c, _ := svc.Contention("src/api/**"); c != nil {
+		t.Errorf("Lock 해제 후 대기가 남아 있음: %+v", c)
+	}
+}
+
+func TestAcquireTTL(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+
+	l, err := svc.AcquireTTL("src/**/*.go", "session-a", time.Hour)
+	if err != nil {
+		t.Fatalf("Lock 획득 실패: %v", err)
+	}
+	if l.ExpiresAt == nil || time.Until(*l.ExpiresAt) < 59*time.Minute {
+		t.Fatalf("만료 시각이 설정되지 않음: %+v", l.ExpiresAt)
+	}
+
+	// 같은 세션의 재획득은 TTL 갱신
+	if _, err := svc.AcquireTTL("src/**/*.go", "session-a", 0); err != nil {
+		t.Fatalf("재획득 실패: %v", err)
+	}
+	if l, _ := svc.Get("src/**/*.go"); l.ExpiresAt != nil {
+		t.Errorf("TTL 0으로 갱신 후 만료 시각이 남아있음: %v", l.ExpiresAt)
+	}
+
+	if _, err := svc.AcquireTTL("src/**/*.go", "session-b", time.Minute); err == nil {
+		t.Error("다른 세션의 Lock 획득이 성공함")
+	}
+	if err := svc.ReleaseBy("src/**/*.go", "session-b"); err == nil {
+		t.Error("다른 세션이 Lock을 해제함")
+	}
+	if err := svc.ReleaseBy("src/**/*.go", "session-a"); err != nil {
+		t.Fatalf("해제 실패: %v", err)
+	}
+
+	// 만료된 Lock은 조회/획득 시 정리
+	database.Exec(`INSERT INTO locks (resource, session_id, expires_at) VALUES (?, ?, ?)`,
+		"docs/", "session-a", time.Now().UTC().Add(-time.Second))
+	if locked, _, _ := svc.IsLocked("docs/"); locked {
+		t.Error("만료된 Lock이 남아있음")
+	}
+	if err := svc.Acquire("docs/", "session-b"); err != nil {
+		t.Errorf("만료된 Lock 리소스 획득 실패: %v", err)
+	}
+}
@@ -54,6 +54,9 @@ type TrackedFile struct {
 	MTime     time.Time  `yaml:"mtime" json:"mtime"`
 	ManagedBy ManagedBy  `yaml:"managed_by" json:"managed_by"`
 	Status    FileStatus `yaml:"status,omitempty" json:"status,omitempty"`
+
+	// Provenance는 DB(file_provenance)에서 채워지며 manifest.yaml에는 저장하지 않음
+	Provenance *Provenance `yaml:"-" json:"provenance,omitempty"`
 }
 
 // Manifest represents the manifest file structure
@@ -366,6 +369,13 @@ func (s *Service) Status() ([]TrackedFile, error) {
 		}
 	}
 
+	// 출처 정보 연결
+	if provenance, err := s.GetProvenance(); err == nil {
+		for i := range results {
+			results[i].Provenance = provenance[results[i].Path]
+		}
+	}
+
 	return results, nil
 }
 
@@ -481,6 +491,16 @@ func (s *Service) Sync() ([]ChangeRecord, error) {
 		}
 	}
 
+	// 마지막 수정 세션을 변경 기록에 연결
+	for i := range changes {
+		for _, file := range statuses {
+			if file.Path == changes[i].FilePath && file.Provenance != nil {
+				changes[i].SessionID = file.Provenance.ModifiedSession
+				break
+			}
+		}
+	}
+
 	// manifest 저장
 	if err := s.SaveManifest(manifest); err != nil {
 		return nil, err
@@ -600,3 +600,73 @@ func TestNewFileDetection(t *testing.T) {
 		t.Error("CLAUDE.md should be detected as new file")
 	}
 }
+
+func TestRecordEditProvenance(t *testing.T) {
+	database, cleanupDB := setupTestDB(t)
+	defer cleanupDB()
+
+	projectRoot, cleanupProject := setupTestProject(t)
+	defer cleanupProject()
+
+	svc := NewService(database, projectRoot)
+	if err := svc.Init(); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	docPath := filepath.Join(projectRoot, "docs", "guide.md")
+
+	// session-a가 Write로 생성, session-b가 이후 수정
+	if err := svc.RecordEdit(docPath, "session-a", "port-001", true); err != nil {
+		t.Fatalf("RecordEdit failed: %v", err)
+	}
+	if err := svc.RecordEdit(docPath, "session-b", "port-002", false); err != nil {
+		t.Fatalf("RecordEdit failed: %v", err)
+	}
+	// 프로젝트 밖의 파일은 무시
+	if err := svc.RecordEdit("/outside/file.md", "session-a", "", true); err != nil {
+		t.Fatalf("RecordEdit failed: %v", err)
+	}
+
+	provenance, err := svc.GetProvenance()
+	if err != nil {
+		t.Fatalf("GetProvenance failed: %v", err)
+	}
+	if len(provenance) != 1 {
+		t.Fatalf("expected 1 provenance record, got %d", len(provenance))
+	}
+
+	p := provenance["docs/guide.md"]
+	if p == nil {
+		t.Fatal("expected provenance for docs/guide.md")
+	}
+	if p.CreatedSession != "session-a" || p.CreatedPort != "port-001" {
+		t.Errorf("unexpected creator: %s / %s", p.CreatedSession, p.CreatedPort)
+	}
+	if p.ModifiedSession != "session-b" || p.ModifiedPort != "port-002" {
+		t.Errorf("unexpected modifier: %s / %s", p.ModifiedSession, p.ModifiedPort)
+	}
+	if p.EditCount != 2 {
+		t.Errorf("expected 2 edits, got %d", p.EditCount)
+	}
+
+	// Status에 출처가 연결되어야 함
+	os.MkdirAll(filepath.Dir(docPath), 0755)
+	os.WriteFile(docPath, []byte("# Guide"), 0644)
+
+	statuses, err := svc.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	found := false
+	for _, f := range statuses {
+		if f.Path == "docs/guide.md" {
+			found = true
+			if f.Provenance == nil || f.Provenance.CreatedSession != "session-a" {
+				t.Errorf("expected provenance on status, got %+v", f.Provenance)
+			}
+		}
+	}
+	if !found {
+		t.Error("docs/guide.md should appear in status")
+	}
+}
@@ -0,0 +1,110 @@
+package manifest
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Provenance records which session/port created or last modified a file
+type Provenance struct {
+	CreatedSession  string     `json:"created_session,omitempty"`
+	CreatedPort     string     `json:"created_port,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	ModifiedSession string     `json:"modified_session,omitempty"`
+	ModifiedPort    string     `json:"modified_port,omitempty"`
+	ModifiedAt      *time.Time `json:"modified_at,omitempty"`
+	EditCount       int        `json:"edit_count"`
+}
+
+// RecordEdit records a file_edit event for provenance tracking.
+// created는 편집 전 파일이 존재하지 않았는지 여부 (Write로 새로 생성).
+func (s *Service) RecordEdit(filePath, sessionID, portID string, created bool) error {
+	relPath := s.relPath(filePath)
+	if relPath == "" {
+		return nil
+	}
+
+	now := time.Now()
+	var createdSession, createdPort interface{}
+	var createdAt interface{}
+	if created {
+		createdSession, createdPort, createdAt = sessionID, portID, now
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO file_provenance (
+			project_root, file_path, created_session, created_port, created_at,
+			modified_session, modified_port, modified_at, edit_count
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1)
+		ON CONFLICT(project_root, file_path) DO UPDATE SET
+			created_session = COALESCE(excluded.created_session, file_provenance.created_session),
+			created_port = COALESCE(excluded.created_port, file_provenance.created_port),
+			created_at = COALESCE(excluded.created_at, file_provenance.created_at),
+			modified_session = excluded.modified_session,
+			modified_port = excluded.modified_port,
+			modified_at = excluded.modified_at,
+			edit_count = file_provenance.edit_count + 1
+	`, s.projectRoot, relPath, createdSession, createdPort, createdAt,
+		sessionID, portID, now)
+	if err != nil {
+		return fmt.Errorf("파일 출처 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// GetProvenance returns provenance of all edited files in the project
+func (s *Service) GetProvenance() (map[string]*Provenance, error) {
+	rows, err := s.db.Query(`
+		SELECT file_path, created_session, created_port, created_at,
+		       modified_session, modified_port, modified_at, edit_count
+		FROM file_provenance
+		WHERE project_root = ?
+	`, s.projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("파일 출처 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*Provenance)
+	for rows.Next() {
+		var path string
+		var p Provenance
+		var createdSession, createdPort, modifiedSession, modifiedPort sql.NullString
+		var createdAt, modifiedAt sql.NullTime
+		if err := rows.Scan(&path, &createdSession, &createdPort, &createdAt,
+			&modifiedSession, &modifiedPort, &modifiedAt, &p.EditCount); err != nil {
+			continue
+		}
+
+		p.CreatedSession = createdSession.String
+		p.CreatedPort = createdPort.String
+		p.ModifiedSession = modifiedSession.String
+		p.ModifiedPort = modifiedPort.String
+		if createdAt.Valid {
+			p.CreatedAt = &createdAt.Time
+		}
+		if modifiedAt.Valid {
+			p.ModifiedAt = &modifiedAt.Time
+		}
+		result[path] = &p
+	}
+
+	return result, nil
+}
+
+// relPath converts an absolute path into a project-relative path.
+// 프로젝트 밖의 파일은 빈 문자열을 반환합니다.
+func (s *Service) relPath(filePath string) string {
+	if !filepath.IsAbs(filePath) {
+		return filepath.ToSlash(filepath.Clean(filePath))
+	}
+
+	rel, err := filepath.Rel(s.projectRoot, filePath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
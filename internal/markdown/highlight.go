@@ -0,0 +1,173 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// syntax describes the lexical rules needed to highlight one language
+type syntax struct {
+	keywords     map[string]bool
+	lineComments []string
+	blockComment [2]string
+	quotes       string
+}
+
+func words(s string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
+
+var (
+	goSyntax = &syntax{
+		keywords: words(`break case chan const continue default defer else fallthrough for func go goto if import
+			interface map package range return select struct switch type var nil true false iota`),
+		lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"}, quotes: "\"'`",
+	}
+	jsSyntax = &syntax{
+		keywords: words(`async await break case catch class const continue default delete do else export extends
+			false finally for from function if import in instanceof interface let new null of return static super
+			switch this throw true try type typeof undefined var void while yield`),
+		lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"}, quotes: "\"'`",
+	}
+	pySyntax = &syntax{
+		keywords: words(`and as assert async await break class continue def del elif else except False finally for
+			from global if import in is lambda None nonlocal not or pass raise return True try while with yield`),
+		lineComments: []string{"#"}, quotes: "\"'",
+	}
+	shSyntax = &syntax{
+		keywords:     words(`if then else elif fi for in do done while until case esac function return export local echo`),
+		lineComments: []string{"#"}, quotes: "\"'",
+	}
+	sqlSyntax = &syntax{
+		keywords: words(`select from where and or not insert into values update set delete create table index
+			alter add drop join left right inner outer on group by order having limit offset as distinct
+			null is in like between case when then else end primary key default exists union
+			SELECT FROM WHERE AND OR NOT INSERT INTO VALUES UPDATE SET DELETE CREATE TABLE INDEX
+			ALTER ADD DROP JOIN LEFT RIGHT INNER OUTER ON GROUP BY ORDER HAVING LIMIT OFFSET AS DISTINCT
+			NULL IS IN LIKE BETWEEN CASE WHEN THEN ELSE END PRIMARY KEY DEFAULT EXISTS UNION`),
+		lineComments: []string{"--"}, blockComment: [2]string{"/*", "*/"}, quotes: "'\"",
+	}
+	yamlSyntax = &syntax{keywords: words(`true false null yes no`), lineComments: []string{"#"}, quotes: "\"'"}
+	jsonSyntax = &syntax{keywords: words(`true false null`), quotes: "\""}
+)
+
+var syntaxes = map[string]*syntax{
+	"go": goSyntax, "golang": goSyntax,
+	"js": jsSyntax, "javascript": jsSyntax, "ts": jsSyntax, "typescript": jsSyntax, "tsx": jsSyntax, "jsx": jsSyntax,
+	"py": pySyntax, "python": pySyntax,
+	"sh": shSyntax, "bash": shSyntax, "shell": shSyntax, "zsh": shSyntax,
+	"sql":  sqlSyntax,
+	"yaml": yamlSyntax, "yml": yamlSyntax,
+	"json": jsonSyntax,
+}
+
+// Highlight escapes code and wraps keywords, strings, numbers and comments in
+// <span class="hl-kw|hl-str|hl-num|hl-com">. 모르는 언어는 이스케이프만 합니다.
+func Highlight(code, lang string) string {
+	syn, ok := syntaxes[lang]
+	if !ok {
+		return html.EscapeString(code)
+	}
+
+	var b strings.Builder
+	emit := func(class, text string) {
+		if class == "" {
+			b.WriteString(html.EscapeString(text))
+			return
+		}
+		b.WriteString(`<span class="hl-` + class + `">` + html.EscapeString(text) + "</span>")
+	}
+
+	for i := 0; i < len(code); {
+		rest := code[i:]
+		c := code[i]
+
+		if end := syn.comment(rest); end > 0 {
+			emit("com", rest[:end])
+			i += end
+			continue
+		}
+
+		if strings.IndexByte(syn.quotes, c) >= 0 {
+			end := stringEnd(rest)
+			emit("str", rest[:end])
+			i += end
+			continue
+		}
+
+		if c >= '0' && c <= '9' && (i == 0 || !isIdent(rune(code[i-1]))) {
+			end := 1
+			for end < len(rest) && (isIdent(rune(rest[end])) || rest[end] == '.') {
+				end++
+			}
+			emit("num", rest[:end])
+			i += end
+			continue
+		}
+
+		if isIdent(rune(c)) {
+			end := 1
+			for end < len(rest) && isIdent(rune(rest[end])) {
+				end++
+			}
+			word := rest[:end]
+			if syn.keywords[word] {
+				emit("kw", word)
+			} else {
+				emit("", word)
+			}
+			i += end
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(rest)
+		emit("", rest[:size])
+		i += size
+	}
+	return b.String()
+}
+
+// comment returns the length of the comment starting at s, or 0
+func (syn *syntax) comment(s string) int {
+	for _, p := range syn.lineComments {
+		if strings.HasPrefix(s, p) {
+			if end := strings.IndexByte(s, '\n'); end >= 0 {
+				return end
+			}
+			return len(s)
+		}
+	}
+	if open := syn.blockComment[0]; open != "" && strings.HasPrefix(s, open) {
+		if end := strings.Index(s[len(open):], syn.blockComment[1]); end >= 0 {
+			return len(open) + end + len(syn.blockComment[1])
+		}
+		return len(s)
+	}
+	return 0
+}
+
+// stringEnd returns the length of the quoted string at s (escape 처리, 닫히지 않으면 줄 끝까지)
+func stringEnd(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote != '`':
+			i++
+		case s[i] == quote:
+			return i + 1
+		case s[i] == '\n' && quote != '`':
+			return i
+		}
+	}
+	return len(s)
+}
+
+func isIdent(r rune) bool {
+	return r == '_' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
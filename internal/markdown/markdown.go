@@ -0,0 +1,278 @@
+// Package markdown renders the markdown used by port specs, KB notes and reports into HTML fragments.
+// 외부 렌더러 없이 PAL 문서에서 쓰는 문법만 지원합니다:
+// 제목, 목록(체크박스 포함), 인용, 구분선, 표, 코드 블록(구문 강조), 인라인 코드/굵게/기울임, 링크, 위키링크.
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Options controls rendering
+type Options struct {
+	// ResolveLink maps a wikilink target ([[target]] / [[target|alias]]) to an href.
+	// nil이거나 ok=false면 깨진 링크(<span class="wikilink broken">)로 렌더링합니다.
+	ResolveLink func(target string) (href string, ok bool)
+}
+
+var (
+	headingLine  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	orderedItem  = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	taskItem     = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	inlineCode   = regexp.MustCompile("`([^`]+)`")
+	inlineBold   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	inlineItalic = regexp.MustCompile(`(^|[^*\w])\*([^*\s][^*]*)\*`)
+	inlineLink   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	wikilink     = regexp.MustCompile(`\[\[([^\]|]+)(?:\|([^\]]+))?\]\]`)
+)
+
+// Render converts markdown into an HTML fragment. 앞머리의 YAML frontmatter는 제외합니다.
+func Render(md string, opts Options) string {
+	r := &renderer{opts: opts}
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	lines = skipFrontmatter(lines)
+
+	var para []string
+	flushPara := func() {
+		if len(para) > 0 {
+			r.b.WriteString("<p>" + r.inline(strings.Join(para, " ")) + "</p>\n")
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		switch {
+		case trimmed == "":
+			flushPara()
+
+		case strings.HasPrefix(trimmed, "```"):
+			flushPara()
+			lang := strings.ToLower(strings.TrimSpace(strings.TrimLeft(trimmed, "`")))
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			r.codeBlock(strings.Join(code, "\n"), lang)
+
+		case headingLine.MatchString(trimmed):
+			flushPara()
+			m := headingLine.FindStringSubmatch(trimmed)
+			tag := "h" + strconv.Itoa(len(m[1]))
+			r.b.WriteString("<" + tag + ">" + r.inline(strings.TrimRight(m[2], " #")) + "</" + tag + ">\n")
+
+		case isRule(trimmed):
+			flushPara()
+			r.b.WriteString("<hr>\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			flushPara()
+			var quote []string
+			for ; i < len(lines); i++ {
+				q := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(q, ">") {
+					i--
+					break
+				}
+				quote = append(quote, strings.TrimSpace(strings.TrimPrefix(q, ">")))
+			}
+			r.b.WriteString("<blockquote>\n" + Render(strings.Join(quote, "\n"), r.opts) + "</blockquote>\n")
+
+		case isBullet(trimmed):
+			flushPara()
+			r.b.WriteString("<ul>\n")
+			for ; i < len(lines); i++ {
+				item := strings.TrimSpace(lines[i])
+				if !isBullet(item) {
+					i--
+					break
+				}
+				r.listItem(item[2:])
+			}
+			r.b.WriteString("</ul>\n")
+
+		case orderedItem.MatchString(trimmed):
+			flushPara()
+			r.b.WriteString("<ol>\n")
+			for ; i < len(lines); i++ {
+				m := orderedItem.FindStringSubmatch(strings.TrimSpace(lines[i]))
+				if m == nil {
+					i--
+					break
+				}
+				r.listItem(m[1])
+			}
+			r.b.WriteString("</ol>\n")
+
+		case strings.HasPrefix(trimmed, "|"):
+			flushPara()
+			var rows []string
+			for ; i < len(lines); i++ {
+				row := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(row, "|") {
+					i--
+					break
+				}
+				rows = append(rows, row)
+			}
+			r.table(rows)
+
+		default:
+			para = append(para, trimmed)
+		}
+	}
+	flushPara()
+
+	return r.b.String()
+}
+
+type renderer struct {
+	b    strings.Builder
+	opts Options
+}
+
+func (r *renderer) listItem(text string) {
+	if m := taskItem.FindStringSubmatch(text); m != nil {
+		checked := ""
+		if m[1] != " " {
+			checked = " checked"
+		}
+		r.b.WriteString(`<li class="task"><input type="checkbox" disabled` + checked + `> ` + r.inline(m[2]) + "</li>\n")
+		return
+	}
+	r.b.WriteString("<li>" + r.inline(text) + "</li>\n")
+}
+
+func (r *renderer) table(rows []string) {
+	r.b.WriteString("<table>\n")
+	header := true
+	for _, row := range rows {
+		cells := tableCells(row)
+		if isSeparatorRow(cells) {
+			continue
+		}
+		cellTag := "td"
+		if header {
+			cellTag = "th"
+		}
+		r.b.WriteString("<tr>")
+		for _, c := range cells {
+			r.b.WriteString("<" + cellTag + ">" + r.inline(c) + "</" + cellTag + ">")
+		}
+		r.b.WriteString("</tr>\n")
+		header = false
+	}
+	r.b.WriteString("</table>\n")
+}
+
+func (r *renderer) codeBlock(code, lang string) {
+	if lang == "" {
+		r.b.WriteString("<pre><code>" + html.EscapeString(code) + "\n</code></pre>\n")
+		return
+	}
+	r.b.WriteString(`<pre><code class="language-` + html.EscapeString(lang) + `">` + Highlight(code, lang) + "\n</code></pre>\n")
+}
+
+// inline escapes text and applies inline markup; 인라인 코드 안은 다른 문법을 적용하지 않습니다
+func (r *renderer) inline(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range inlineCode.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(r.span(s[last:m[0]]))
+		b.WriteString("<code>" + html.EscapeString(s[m[2]:m[3]]) + "</code>")
+		last = m[1]
+	}
+	b.WriteString(r.span(s[last:]))
+	return b.String()
+}
+
+func (r *renderer) span(s string) string {
+	s = html.EscapeString(s)
+	s = wikilink.ReplaceAllStringFunc(s, r.wikilink)
+	s = inlineLink.ReplaceAllStringFunc(s, func(m string) string {
+		parts := inlineLink.FindStringSubmatch(m)
+		href := html.UnescapeString(parts[2])
+		if !safeHref(href) {
+			return parts[1]
+		}
+		return `<a href="` + html.EscapeString(href) + `">` + parts[1] + "</a>"
+	})
+	s = inlineBold.ReplaceAllString(s, "<strong>$1</strong>")
+	s = inlineItalic.ReplaceAllString(s, "$1<em>$2</em>")
+	return s
+}
+
+func (r *renderer) wikilink(m string) string {
+	parts := wikilink.FindStringSubmatch(m)
+	target := strings.TrimSpace(html.UnescapeString(parts[1]))
+	label := parts[2]
+	if label == "" {
+		label = parts[1]
+	}
+	if r.opts.ResolveLink != nil {
+		if href, ok := r.opts.ResolveLink(target); ok {
+			return `<a class="wikilink" href="` + html.EscapeString(href) + `">` + label + "</a>"
+		}
+	}
+	return `<span class="wikilink broken" title="` + html.EscapeString(target) + `">` + label + "</span>"
+}
+
+// safeHref rejects script URLs; 상대 경로, 앵커, http(s), mailto만 허용
+func safeHref(href string) bool {
+	lower := strings.ToLower(href)
+	if i := strings.Index(lower, ":"); i >= 0 && !strings.ContainsAny(lower[:i], "/?#") {
+		scheme := lower[:i]
+		return scheme == "http" || scheme == "https" || scheme == "mailto"
+	}
+	return true
+}
+
+func skipFrontmatter(lines []string) []string {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return lines
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return lines[i+1:]
+		}
+	}
+	return lines
+}
+
+func isBullet(s string) bool {
+	return strings.HasPrefix(s, "- ") || strings.HasPrefix(s, "* ") || strings.HasPrefix(s, "+ ")
+}
+
+func isRule(s string) bool {
+	if len(s) < 3 {
+		return false
+	}
+	compact := strings.ReplaceAll(s, " ", "")
+	for _, c := range []string{"-", "*", "_"} {
+		if strings.Trim(compact, c) == "" && len(compact) >= 3 {
+			return true
+		}
+	}
+	return false
+}
+
+func tableCells(row string) []string {
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	cells := strings.Split(row, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+func isSeparatorRow(cells []string) bool {
+	for _, c := range cells {
+		if strings.Trim(c, "-: ") != "" || c == "" {
+			return false
+		}
+	}
+	return true
+}
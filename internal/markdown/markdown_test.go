@@ -0,0 +1,59 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	md := "---\ntype: port\n---\n# 포트 <x>\n\n" +
+		"참고: [[auth/login|로그인]], [[missing]], [문서](https://example.com), [xss](javascript:alert(1))\n\n" +
+		"**굵게** *기울임* `[[code]] **raw**`\n\n" +
+		"- [x] 완료\n- [ ] 진행\n\n1. 첫째\n2. 둘째\n\n> 인용 **문장**\n\n---\n\n" +
+		"```go\nfunc main() { return \"s\" // c\n}\n```\n"
+
+	out := Render(md, Options{ResolveLink: func(target string) (string, bool) {
+		if target == "auth/login" {
+			return "/docs/auth-login", true
+		}
+		return "", false
+	}})
+
+	for _, want := range []string{
+		"<h1>포트 &lt;x&gt;</h1>",
+		`<a class="wikilink" href="/docs/auth-login">로그인</a>`,
+		`<span class="wikilink broken" title="missing">missing</span>`,
+		`<a href="https://example.com">문서</a>`,
+		"<strong>굵게</strong> <em>기울임</em> <code>[[code]] **raw**</code>",
+		`<li class="task"><input type="checkbox" disabled checked> 완료</li>`,
+		"<ol>\n<li>첫째</li>\n<li>둘째</li>\n</ol>",
+		"<blockquote>\n<p>인용 <strong>문장</strong></p>\n</blockquote>",
+		"<hr>",
+		`<pre><code class="language-go"><span class="hl-kw">func</span> main() { <span class="hl-kw">return</span> <span class="hl-str">&#34;s&#34;</span> <span class="hl-com">// c</span>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Render missing %q\n%s", want, out)
+		}
+	}
+	for _, bad := range []string{"type: port", "javascript:"} {
+		if strings.Contains(out, bad) {
+			t.Errorf("Render should not contain %q\n%s", bad, out)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		lang, code, want string
+	}{
+		{"sql", "SELECT 1 -- 주석", `<span class="hl-kw">SELECT</span> <span class="hl-num">1</span> <span class="hl-com">-- 주석</span>`},
+		{"python", "def f(): pass  # 한글", `<span class="hl-kw">def</span> f(): <span class="hl-kw">pass</span>  <span class="hl-com"># 한글</span>`},
+		{"text", "<b>한글</b>", "&lt;b&gt;한글&lt;/b&gt;"},
+		{"js", "x = '미완성", `x = <span class="hl-str">&#39;미완성</span>`},
+	}
+	for _, tt := range tests {
+		if got := Highlight(tt.code, tt.lang); got != tt.want {
+			t.Errorf("Highlight(%q, %s) = %q, want %q", tt.code, tt.lang, got, tt.want)
+		}
+	}
+}
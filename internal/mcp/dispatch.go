@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// 요청 동시 처리: 각 요청은 자체 context를 가진 goroutine에서 처리되며
+// $/cancelRequest 또는 notifications/cancelled로 취소하고, 도구 호출은 도구별 제한 시간을 넘으면 중단합니다.
+// 도구 핸들러는 context를 받지 않아 실행을 멈출 수 없으므로, 취소·시간 초과는 읽기 전용 도구에만 적용합니다.
+// 상태를 바꾸는 도구는 끝까지 기다려 실제 결과를 응답합니다 (실패라고 알렸는데 변경이 커밋되는 일 방지).
+
+// JSON-RPC error code of a cancelled request (LSP와 동일)
+const codeRequestCancelled = -32800
+
+var (
+	// errCancelled: 클라이언트가 $/cancelRequest로 취소 (오류 응답을 보냄)
+	errCancelled = errors.New("request cancelled")
+	// errCancelledSilently: notifications/cancelled로 취소 (MCP 규약상 응답하지 않음)
+	errCancelledSilently = errors.New("request cancelled by notification")
+)
+
+// toolFunc is the signature of every tool handler
+type toolFunc func(args json.RawMessage) (interface{}, error)
+
+// readOnlyTools are the tools that change no state and may be abandoned when cancelled
+var readOnlyTools = map[string]bool{
+	"pal_status":           true,
+	"lock_status":          true,
+	"kb_search":            true,
+	"doc_get":              true,
+	"escalation_list":      true,
+	"session_hierarchy":    true,
+	"attention_status":     true,
+	"orchestration_status": true,
+	"execution_list":       true,
+	"handoff_get":          true,
+	"agent_list":           true,
+	"agent_version":        true,
+}
+
+// requestKey normalizes a JSON-RPC id (숫자는 float64로 디코딩됨) for the in-flight table
+func requestKey(id interface{}) string {
+	return fmt.Sprintf("%v", id)
+}
+
+// dispatch handles a request on its own goroutine; initialize, notifications and cancellations run inline
+// (initialize가 뒤따르는 요청보다 먼저 프로토콜을 협상하도록)
+func (s *Server) dispatch(req *JSONRPCRequest) {
+	switch req.Method {
+	case "$/cancelRequest":
+		var params struct {
+			ID interface{} `json:"id"`
+		}
+		if json.Unmarshal(req.Params, &params) == nil {
+			s.cancelRequest(params.ID, errCancelled)
+		}
+		return
+	case "notifications/cancelled":
+		var params struct {
+			RequestID interface{} `json:"requestId"`
+		}
+		if json.Unmarshal(req.Params, &params) == nil {
+			s.cancelRequest(params.RequestID, errCancelledSilently)
+		}
+		return
+	}
+
+	if req.ID == nil || req.Method == "initialize" {
+		s.handleRequest(context.Background(), req)
+		return
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	key := requestKey(req.ID)
+	s.inflightMu.Lock()
+	s.inflight[key] = cancel
+	s.inflightMu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.inflightMu.Lock()
+			delete(s.inflight, key)
+			s.inflightMu.Unlock()
+			cancel(nil)
+		}()
+		s.handleRequest(ctx, req)
+	}()
+}
+
+// cancelRequest cancels an in-flight request (이미 끝난 요청이면 무시)
+func (s *Server) cancelRequest(id interface{}, cause error) {
+	if id == nil {
+		return
+	}
+	s.inflightMu.Lock()
+	cancel, ok := s.inflight[requestKey(id)]
+	s.inflightMu.Unlock()
+	if ok {
+		cancel(cause)
+	}
+}
+
+// cancelAll cancels every in-flight request (세션 종료 시)
+func (s *Server) cancelAll(cause error) {
+	s.inflightMu.Lock()
+	defer s.inflightMu.Unlock()
+	for _, cancel := range s.inflight {
+		cancel(cause)
+	}
+}
+
+// runTool runs a tool handler until it returns, the request is cancelled, or the tool's time limit passes.
+// 핸들러는 context를 받지 않으므로 중단된 읽기 전용 호출은 백그라운드에서 끝까지 실행되지만 응답은 버려지고
+// 다른 요청은 계속 처리됩니다. 상태를 바꾸는 도구는 취소되어도 결과를 기다립니다.
+func (s *Server) runTool(ctx context.Context, name string, fn toolFunc, args json.RawMessage) (interface{}, error) {
+	if !readOnlyTools[name] {
+		ctx = context.Background()
+	} else if timeout := s.mcpSettings.TimeoutFor(name); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, fmt.Errorf("도구 '%s' 실행 시간 초과 (%s)", name, timeout))
+		defer cancel()
+	}
+
+	type outcome struct {
+		result interface{}
+		err    error
+	}
+	ch := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				ch <- outcome{err: fmt.Errorf("도구 '%s' 실행 중 패닉: %v", name, r)}
+			}
+		}()
+		result, err := fn(args)
+		ch <- outcome{result, err}
+	}()
+
+	select {
+	case o := <-ch:
+		return o.result, o.err
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
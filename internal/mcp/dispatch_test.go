@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func slowTool(d time.Duration) toolFunc {
+	return func(json.RawMessage) (interface{}, error) {
+		time.Sleep(d)
+		return "done", nil
+	}
+}
+
+func TestRunToolTimeout(t *testing.T) {
+	s := &Server{mcpSettings: config.MCPSettings{ToolTimeouts: map[string]string{"kb_search": "50ms", "lock_acquire": "50ms"}}}
+
+	start := time.Now()
+	_, err := s.runTool(context.Background(), "kb_search", slowTool(2*time.Second), nil)
+	if err == nil || !strings.Contains(err.Error(), "시간 초과") {
+		t.Fatalf("제한 시간 초과 오류가 아님: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("제한 시간이 지나도 반환되지 않음")
+	}
+
+	if got, err := s.runTool(context.Background(), "doc_get", slowTool(0), nil); err != nil || got != "done" {
+		t.Errorf("runTool = %v, %v", got, err)
+	}
+
+	// 상태를 바꾸는 도구는 제한 시간이 지나도 실제 결과를 응답
+	if got, err := s.runTool(context.Background(), "lock_acquire", slowTool(100*time.Millisecond), nil); err != nil || got != "done" {
+		t.Errorf("runTool(lock_acquire) = %v, %v", got, err)
+	}
+}
+
+func TestCancelRequest(t *testing.T) {
+	s := &Server{inflight: make(map[string]context.CancelCauseFunc)}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	s.inflight[requestKey(float64(7))] = cancel
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := s.runTool(ctx, "kb_search", slowTool(2*time.Second), nil)
+		errCh <- err
+	}()
+
+	s.cancelRequest(float64(7), errCancelled)
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, errCancelled) {
+			t.Errorf("취소 원인 = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("취소된 요청이 반환되지 않음")
+	}
+
+	// 모르는 요청 취소는 무시
+	s.cancelRequest("unknown", errCancelled)
+
+	// 상태를 바꾸는 도구는 취소해도 끝까지 실행하고 결과를 응답
+	ctx, cancel = context.WithCancelCause(context.Background())
+	cancel(errCancelled)
+	if got, err := s.runTool(ctx, "lock_acquire", slowTool(50*time.Millisecond), nil); err != nil || got != "done" {
+		t.Errorf("runTool(lock_acquire) = %v, %v", got, err)
+	}
+}
+
+func TestDispatchInitializeInline(t *testing.T) {
+	s := newTestServer(t, "")
+	var out bytes.Buffer
+	s.writer = &out
+
+	// dispatch가 반환되면 협상이 끝나 있어야 뒤따르는 요청이 올바른 프로토콜로 처리됨
+	s.dispatch(&JSONRPCRequest{JSONRPC: "2.0", ID: float64(1), Method: "initialize",
+		Params: json.RawMessage(`{"protocolVersion":"2025-06-18"}`)})
+	if !s.structured.Load() || !strings.Contains(out.String(), `"protocolVersion"`) {
+		t.Errorf("initialize가 dispatch 안에서 끝나지 않음: structured=%v, out=%q", s.structured.Load(), out.String())
+	}
+}
+
+// newTestServer returns a server on an initialized temporary DB
+func newTestServer(t *testing.T, projectRoot string) *Server {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "pal.db")
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.Init(); err != nil {
+		t.Fatal(err)
+	}
+	database.Close()
+
+	s, err := NewServer(dbPath, projectRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestRunConcurrent(t *testing.T) {
+	s := newTestServer(t, "")
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"lock_status","arguments":{}}}`,
+		`{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":99}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"nope"}}`,
+	}, "\n") + "\n"
+	var out bytes.Buffer
+	s.reader = bufio.NewReader(strings.NewReader(input))
+	s.writer = &out
+
+	if err := s.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[float64]JSONRPCResponse)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var resp JSONRPCResponse
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("잘못된 응답 %q: %v", line, err)
+		}
+		if id, ok := resp.ID.(float64); ok {
+			got[id] = resp
+		}
+	}
+	if len(got) != 3 {
+		t.Fatalf("응답 수 = %d, 출력:\n%s", len(got), out.String())
+	}
+	if got[1].Error != nil || got[2].Error != nil {
+		t.Errorf("정상 요청이 실패함: %+v %+v", got[1].Error, got[2].Error)
+	}
+	if got[3].Error == nil || got[3].Error.Code != -32601 {
+		t.Errorf("알 수 없는 도구 오류 = %+v", got[3].Error)
+	}
+}
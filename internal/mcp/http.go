@@ -0,0 +1,463 @@
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HTTP 전송 (Streamable HTTP): 원격 클라이언트가 한 pal-kit 서버를 공유합니다.
+// POST /mcp로 JSON-RPC 메시지를 보내고 응답은 JSON 본문으로 받으며,
+// GET /mcp(SSE)로 서버 알림을 받고 DELETE /mcp로 세션을 끝냅니다.
+// initialize 응답의 Mcp-Session-Id 헤더가 세션을 식별하고, 세션마다 협상된 프로토콜과 처리 중인 요청을 따로 가집니다.
+
+const (
+	// mcpSessionHeader carries the session ID assigned on initialize
+	mcpSessionHeader = "Mcp-Session-Id"
+	// httpSessionIdleTimeout is how long an unused session is kept
+	httpSessionIdleTimeout = 30 * time.Minute
+	// httpMaxBody bounds the size of one JSON-RPC message
+	httpMaxBody = 4 << 20
+)
+
+// HTTPOptions configures the HTTP transport
+type HTTPOptions struct {
+	Token          string   // 설정하면 Authorization: Bearer <token> 필요
+	AllowedOrigins []string // 브라우저 Origin 허용 목록 (기본: localhost만)
+	AllowedHosts   []string // Host 헤더 허용 목록 (기본: localhost와 바인드 주소만)
+}
+
+// HTTPTransport serves one Server to many HTTP clients
+type HTTPTransport struct {
+	base     *Server
+	opts     HTTPOptions
+	bindHost string // ListenAndServe 주소의 호스트 (모든 인터페이스면 빈 값)
+
+	mu       sync.Mutex
+	sessions map[string]*httpSession
+
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+type httpSession struct {
+	id  string
+	srv *Server
+	out *sessionWriter
+
+	mu       sync.Mutex
+	lastSeen time.Time
+}
+
+// sessionWriter routes the lines written by a session server:
+// 요청을 기다리는 POST가 있으면 응답을 그쪽으로, 나머지(알림)는 SSE 스트림으로 보냅니다.
+type sessionWriter struct {
+	mu      sync.Mutex
+	waiters map[string]chan []byte
+	stream  chan []byte // 열린 GET 스트림 (없으면 nil, 알림은 버림)
+}
+
+func newSessionWriter() *sessionWriter {
+	return &sessionWriter{waiters: make(map[string]chan []byte)}
+}
+
+func (w *sessionWriter) Write(p []byte) (int, error) {
+	line := append([]byte(nil), strings.TrimSpace(string(p))...)
+
+	var msg struct {
+		ID interface{} `json:"id"`
+	}
+	json.Unmarshal(line, &msg)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if msg.ID != nil {
+		if ch, ok := w.waiters[requestKey(msg.ID)]; ok {
+			delete(w.waiters, requestKey(msg.ID))
+			ch <- line
+		}
+		return len(p), nil
+	}
+	if w.stream != nil {
+		select {
+		case w.stream <- line:
+		default: // 느린 스트림은 알림을 놓칠 수 있음 (list_changed는 다시 조회하면 됨)
+		}
+	}
+	return len(p), nil
+}
+
+// wait registers a POST waiting for the response to a request id
+func (w *sessionWriter) wait(id interface{}) chan []byte {
+	ch := make(chan []byte, 1)
+	w.mu.Lock()
+	w.waiters[requestKey(id)] = ch
+	w.mu.Unlock()
+	return ch
+}
+
+func (w *sessionWriter) forget(id interface{}) {
+	w.mu.Lock()
+	delete(w.waiters, requestKey(id))
+	w.mu.Unlock()
+}
+
+// openStream replaces the session's notification stream (세션당 하나)
+func (w *sessionWriter) openStream() chan []byte {
+	ch := make(chan []byte, 32)
+	w.mu.Lock()
+	if w.stream != nil {
+		close(w.stream)
+	}
+	w.stream = ch
+	w.mu.Unlock()
+	return ch
+}
+
+// closeAll ends the waiting POSTs and the notification stream of a terminated session
+func (w *sessionWriter) closeAll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for key, ch := range w.waiters {
+		close(ch)
+		delete(w.waiters, key)
+	}
+	if w.stream != nil {
+		close(w.stream)
+		w.stream = nil
+	}
+}
+
+func (w *sessionWriter) closeStream(ch chan []byte) {
+	w.mu.Lock()
+	if w.stream == ch {
+		close(w.stream)
+		w.stream = nil
+	}
+	w.mu.Unlock()
+}
+
+// NewHTTPTransport wraps a server for the HTTP transport
+func NewHTTPTransport(s *Server, opts HTTPOptions) *HTTPTransport {
+	return &HTTPTransport{
+		base:     s,
+		opts:     opts,
+		sessions: make(map[string]*httpSession),
+		done:     make(chan struct{}),
+	}
+}
+
+// Handler returns the HTTP handler serving /mcp
+func (t *HTTPTransport) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mcp", t.handleMCP)
+	return mux
+}
+
+// ListenAndServe serves the transport on addr until ctx is cancelled, then drains in-flight requests
+func (t *HTTPTransport) ListenAndServe(ctx context.Context, addr string) error {
+	if host, _, err := net.SplitHostPort(addr); err == nil && !net.ParseIP(host).IsUnspecified() {
+		t.bindHost = host
+	}
+	srv := &http.Server{Addr: addr, Handler: t.Handler()}
+
+	go t.watch()
+	defer t.stop()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+	log.Printf("PAL Kit MCP Server (HTTP) listening on %s/mcp", addr)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// SSE 스트림을 먼저 닫아야 Shutdown이 기다리지 않음
+	t.stop()
+	err := srv.Shutdown(shutdownCtx)
+	t.mu.Lock()
+	for _, sess := range t.sessions {
+		sess.srv.wg.Wait()
+	}
+	t.mu.Unlock()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (t *HTTPTransport) stop() {
+	t.stopOnce.Do(func() { close(t.done) })
+}
+
+// watch expires idle sessions and broadcasts prompt changes to every session
+func (t *HTTPTransport) watch() {
+	ticker := time.NewTicker(promptReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+		}
+
+		changed := t.base.prompts.reload()
+		t.mu.Lock()
+		for id, sess := range t.sessions {
+			if sess.idle() > httpSessionIdleTimeout {
+				delete(t.sessions, id)
+				sess.end()
+				continue
+			}
+			if changed {
+				sess.srv.notify("notifications/prompts/list_changed")
+			}
+		}
+		t.mu.Unlock()
+	}
+}
+
+// end cancels the session's in-flight requests and releases the requests waiting on them
+func (s *httpSession) end() {
+	s.srv.cancelAll(errCancelledSilently)
+	s.out.closeAll()
+}
+
+func (s *httpSession) touch() {
+	s.mu.Lock()
+	s.lastSeen = time.Now()
+	s.mu.Unlock()
+}
+
+func (s *httpSession) idle() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Since(s.lastSeen)
+}
+
+func (t *HTTPTransport) handleMCP(w http.ResponseWriter, r *http.Request) {
+	if !t.hostAllowed(r) {
+		http.Error(w, "host not allowed", http.StatusForbidden)
+		return
+	}
+	if !t.originAllowed(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	if !t.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		t.handlePost(w, r)
+	case http.MethodGet:
+		t.handleStream(w, r)
+	case http.MethodDelete:
+		if sess := t.session(w, r); sess != nil {
+			t.mu.Lock()
+			delete(t.sessions, sess.id)
+			t.mu.Unlock()
+			sess.end()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (t *HTTPTransport) handlePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, httpMaxBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req JSONRPCRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSON(w, http.StatusBadRequest, JSONRPCResponse{
+			JSONRPC: "2.0",
+			Error:   &RPCError{Code: -32700, Message: "Parse error", Data: err.Error()},
+		})
+		return
+	}
+
+	var sess *httpSession
+	if req.Method == "initialize" {
+		sess = t.newSession()
+		w.Header().Set(mcpSessionHeader, sess.id)
+	} else if sess = t.session(w, r); sess == nil {
+		return
+	}
+	sess.touch()
+
+	// 알림과 취소는 응답이 없음
+	if req.ID == nil {
+		sess.srv.dispatch(&req)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	ch := sess.out.wait(req.ID)
+	sess.srv.dispatch(&req)
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			http.Error(w, "session terminated", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(resp)
+	case <-r.Context().Done():
+		// 클라이언트가 연결을 끊으면 요청도 취소
+		sess.out.forget(req.ID)
+		sess.srv.cancelRequest(req.ID, errCancelledSilently)
+	}
+}
+
+func (t *HTTPTransport) handleStream(w http.ResponseWriter, r *http.Request) {
+	sess := t.session(w, r)
+	if sess == nil {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	stream := sess.out.openStream()
+	defer sess.out.closeStream(stream)
+	for {
+		select {
+		case msg, ok := <-stream:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", msg)
+			flusher.Flush()
+			sess.touch()
+		case <-r.Context().Done():
+			return
+		case <-t.done:
+			return
+		}
+	}
+}
+
+func (t *HTTPTransport) newSession() *httpSession {
+	out := newSessionWriter()
+	sess := &httpSession{
+		id:       uuid.New().String(),
+		srv:      t.base.newSession(out),
+		out:      out,
+		lastSeen: time.Now(),
+	}
+	t.mu.Lock()
+	t.sessions[sess.id] = sess
+	t.mu.Unlock()
+	return sess
+}
+
+// session looks up the request's session, writing the error response when there is none
+func (t *HTTPTransport) session(w http.ResponseWriter, r *http.Request) *httpSession {
+	id := r.Header.Get(mcpSessionHeader)
+	if id == "" {
+		http.Error(w, "missing "+mcpSessionHeader+" header (initialize first)", http.StatusBadRequest)
+		return nil
+	}
+	t.mu.Lock()
+	sess, ok := t.sessions[id]
+	t.mu.Unlock()
+	if !ok {
+		// 404를 받은 클라이언트는 다시 initialize해야 함
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return nil
+	}
+	return sess
+}
+
+func (t *HTTPTransport) authorized(r *http.Request) bool {
+	if t.opts.Token == "" {
+		return true
+	}
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(t.opts.Token)) == 1
+}
+
+// hostAllowed guards against DNS rebinding: 공격자 도메인을 127.0.0.1로 돌려도 Host 헤더는 그 도메인이므로
+// localhost, 바인드 주소, 허용 목록의 호스트만 받습니다
+func (t *HTTPTransport) hostAllowed(r *http.Request) bool {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if isLoopbackHost(host) || (t.bindHost != "" && strings.EqualFold(host, t.bindHost)) {
+		return true
+	}
+	for _, allowed := range t.opts.AllowedHosts {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// originAllowed rejects browser requests from other sites: localhost와 허용 목록의 Origin만 받습니다
+// (요청 Host와 같다는 이유로 허용하지 않음 - DNS rebinding에서는 Origin과 Host가 모두 공격자 도메인)
+func (t *HTTPTransport) originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range t.opts.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return isLoopbackHost(u.Hostname())
+}
+
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,253 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func postMCP(t *testing.T, url, session, token, body string) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if session != "" {
+		req.Header.Set(mcpSessionHeader, session)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestHTTPTransport(t *testing.T) {
+	s := newTestServer(t, "")
+	ts := httptest.NewServer(NewHTTPTransport(s, HTTPOptions{Token: "secret"}).Handler())
+	defer ts.Close()
+	url := ts.URL + "/mcp"
+
+	resp := postMCP(t, url, "", "", `{"jsonrpc":"2.0","id":1,"method":"initialize"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("토큰 없이 status = %d", resp.StatusCode)
+	}
+
+	// 세션마다 프로토콜을 따로 협상
+	resp = postMCP(t, url, "", "secret", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`)
+	structured := resp.Header.Get(mcpSessionHeader)
+	resp.Body.Close()
+	resp = postMCP(t, url, "", "secret", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`)
+	legacy := resp.Header.Get(mcpSessionHeader)
+	resp.Body.Close()
+	if structured == "" || legacy == "" || structured == legacy {
+		t.Fatalf("세션 ID = %q, %q", structured, legacy)
+	}
+
+	resp = postMCP(t, url, structured, "secret", `{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("알림 status = %d", resp.StatusCode)
+	}
+
+	call := `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"lock_status","arguments":{}}}`
+	for session, wantStructured := range map[string]bool{structured: true, legacy: false} {
+		resp = postMCP(t, url, session, "secret", call)
+		var out JSONRPCResponse
+		json.NewDecoder(resp.Body).Decode(&out)
+		resp.Body.Close()
+		result, _ := out.Result.(map[string]interface{})
+		if out.ID != float64(7) || result == nil {
+			t.Fatalf("tools/call 응답 = %+v", out)
+		}
+		if _, ok := result["structuredContent"]; ok != wantStructured {
+			t.Errorf("세션 %s structuredContent = %v, want %v", session, ok, wantStructured)
+		}
+	}
+
+	resp = postMCP(t, url, "", "secret", call)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("세션 헤더 없이 status = %d", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, url, nil)
+	req.Header.Set(mcpSessionHeader, legacy)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, _ = http.DefaultClient.Do(req)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("DELETE status = %d", resp.StatusCode)
+	}
+	resp = postMCP(t, url, legacy, "secret", call)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("종료된 세션 status = %d", resp.StatusCode)
+	}
+}
+
+func TestHTTPDeleteCancelsInflight(t *testing.T) {
+	s := newTestServer(t, "")
+	transport := NewHTTPTransport(s, HTTPOptions{})
+	ts := httptest.NewServer(transport.Handler())
+	defer ts.Close()
+	url := ts.URL + "/mcp"
+
+	resp := postMCP(t, url, "", "", `{"jsonrpc":"2.0","id":1,"method":"initialize"}`)
+	session := resp.Header.Get(mcpSessionHeader)
+	resp.Body.Close()
+
+	// 응답을 기다리는 요청 흉내
+	transport.mu.Lock()
+	sess := transport.sessions[session]
+	transport.mu.Unlock()
+	ctx, cancel := context.WithCancelCause(context.Background())
+	sess.srv.inflightMu.Lock()
+	sess.srv.inflight["9"] = cancel
+	sess.srv.inflightMu.Unlock()
+	waiting := sess.out.wait(float64(9))
+
+	req, _ := http.NewRequest(http.MethodDelete, url, nil)
+	req.Header.Set(mcpSessionHeader, session)
+	resp, _ = http.DefaultClient.Do(req)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d", resp.StatusCode)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("세션을 끝내도 처리 중인 요청이 취소되지 않음")
+	}
+	if _, ok := <-waiting; ok {
+		t.Error("응답을 기다리던 POST가 풀려야 함")
+	}
+}
+
+func TestHTTPTransportStream(t *testing.T) {
+	s := newTestServer(t, "")
+	transport := NewHTTPTransport(s, HTTPOptions{})
+	ts := httptest.NewServer(transport.Handler())
+	defer ts.Close()
+	url := ts.URL + "/mcp"
+
+	resp := postMCP(t, url, "", "", `{"jsonrpc":"2.0","id":1,"method":"initialize"}`)
+	session := resp.Header.Get(mcpSessionHeader)
+	resp.Body.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set(mcpSessionHeader, session)
+	req.Header.Set("Accept", "text/event-stream")
+	stream, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Body.Close()
+
+	// 스트림이 등록될 때까지 알림을 다시 보냄
+	lines := make(chan string)
+	go func() {
+		r := bufio.NewReader(stream.Body)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				close(lines)
+				return
+			}
+			lines <- line
+		}
+	}()
+	transport.mu.Lock()
+	sess := transport.sessions[session]
+	transport.mu.Unlock()
+	deadline := time.After(2 * time.Second)
+	for {
+		sess.srv.notify("notifications/prompts/list_changed")
+		select {
+		case line := <-lines:
+			if strings.HasPrefix(line, "data: ") && strings.Contains(line, "list_changed") {
+				return
+			}
+		case <-time.After(50 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("SSE 알림을 받지 못함")
+		}
+	}
+}
+
+func TestHTTPOriginCheck(t *testing.T) {
+	transport := NewHTTPTransport(nil, HTTPOptions{AllowedOrigins: []string{"https://app.example.com"}})
+	tests := map[string]bool{
+		"":                         true,
+		"http://localhost:3000":    true,
+		"http://127.0.0.1:8721":    true,
+		"https://app.example.com":  true,
+		"http://build-host:8721":   false, // 요청 Host와 같아도 허용 목록에 없으면 거부
+		"https://evil.example.com": false,
+	}
+	for origin, want := range tests {
+		req := httptest.NewRequest(http.MethodPost, "http://build-host:8721/mcp", io.NopCloser(strings.NewReader("")))
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if got := transport.originAllowed(req); got != want {
+			t.Errorf("originAllowed(%q) = %v, want %v", origin, got, want)
+		}
+	}
+}
+
+func TestHTTPHostCheck(t *testing.T) {
+	transport := NewHTTPTransport(nil, HTTPOptions{AllowedHosts: []string{"build-host"}})
+	transport.bindHost = "10.0.0.5"
+	tests := map[string]bool{
+		"localhost:8721":        true,
+		"127.0.0.1:8721":        true,
+		"[::1]:8721":            true,
+		"10.0.0.5:8721":         true, // 바인드 주소
+		"build-host:8721":       true, // 허용 목록
+		"evil.example.com:8721": false,
+		"evil.example.com":      false,
+	}
+	for host, want := range tests {
+		req := httptest.NewRequest(http.MethodPost, "http://"+host+"/mcp", nil)
+		if got := transport.hostAllowed(req); got != want {
+			t.Errorf("hostAllowed(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestHTTPRejectsDNSRebinding(t *testing.T) {
+	s := newTestServer(t, "")
+	ts := httptest.NewServer(NewHTTPTransport(s, HTTPOptions{}).Handler())
+	defer ts.Close()
+
+	// 공격자 도메인을 127.0.0.1로 돌린 페이지: Origin과 Host가 모두 공격자 도메인이고 토큰은 없음
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"initialize"}`))
+	req.Host = "rebind.attacker.example:8721"
+	req.Header.Set("Origin", "http://rebind.attacker.example:8721")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden || resp.Header.Get(mcpSessionHeader) != "" {
+		t.Errorf("rebinding status = %d, session = %q", resp.StatusCode, resp.Header.Get(mcpSessionHeader))
+	}
+
+	// 같은 서버에 localhost로 접근하면 허용
+	resp = postMCP(t, ts.URL+"/mcp", "", "", `{"jsonrpc":"2.0","id":1,"method":"initialize"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("loopback status = %d", resp.StatusCode)
+	}
+}
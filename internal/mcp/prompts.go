@@ -0,0 +1,214 @@
+package mcp
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 프로젝트 프롬프트: .pal/prompts/*.yaml에 정의한 프롬프트를 내장 프롬프트와 함께 제공합니다.
+// 파일이 바뀌면 다시 읽고 클라이언트에 notifications/prompts/list_changed를 보냅니다.
+
+// promptReloadInterval is how often the prompts directory is checked for changes
+const promptReloadInterval = 2 * time.Second
+
+// builtinPrompts are the prompt names handled in handlePromptsGet (프로젝트 파일로 덮어쓸 수 없음)
+var builtinPrompts = map[string]bool{
+	"start_build":    true,
+	"worker_context": true,
+	"test_feedback":  true,
+}
+
+// PromptFile is one .pal/prompts/*.yaml definition
+type PromptFile struct {
+	Name        string               `yaml:"name"`
+	Description string               `yaml:"description"`
+	Arguments   []PromptFileArgument `yaml:"arguments"`
+	Template    string               `yaml:"template"` // Go text/template, 인자는 {{.name}}으로 참조
+}
+
+// PromptFileArgument is an argument of a project prompt
+type PromptFileArgument struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
+type projectPrompt struct {
+	def  PromptFile
+	tmpl *template.Template
+	path string
+}
+
+// promptLoader keeps the parsed project prompts and reloads them when the directory changes
+type promptLoader struct {
+	dir string
+
+	mu      sync.Mutex
+	sig     string
+	prompts map[string]*projectPrompt
+	names   []string
+}
+
+func newPromptLoader(projectRoot string) *promptLoader {
+	if projectRoot == "" {
+		return &promptLoader{}
+	}
+	return &promptLoader{dir: filepath.Join(projectRoot, ".pal", "prompts")}
+}
+
+// reload re-reads the prompt files when any of them was added, removed or modified.
+// 변경이 있었으면 true를 반환합니다. 잘못된 파일은 로그만 남기고 건너뜁니다.
+func (l *promptLoader) reload() bool {
+	if l.dir == "" {
+		return false
+	}
+	files, sig := l.scan()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if sig == l.sig {
+		return false
+	}
+	l.sig = sig
+
+	prompts := make(map[string]*projectPrompt)
+	for _, path := range files {
+		p, err := loadPromptFile(path)
+		if err != nil {
+			log.Printf("프롬프트 로드 실패 (%s): %v", path, err)
+			continue
+		}
+		if builtinPrompts[p.def.Name] {
+			log.Printf("프롬프트 '%s'는 내장 프롬프트와 이름이 같아 무시합니다 (%s)", p.def.Name, path)
+			continue
+		}
+		if prev, ok := prompts[p.def.Name]; ok {
+			log.Printf("프롬프트 '%s'가 중복 정의되어 %s를 사용합니다", p.def.Name, prev.path)
+			continue
+		}
+		prompts[p.def.Name] = p
+	}
+
+	names := make([]string, 0, len(prompts))
+	for name := range prompts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	l.prompts = prompts
+	l.names = names
+	return true
+}
+
+// scan lists the prompt files and a signature of their names, sizes and modification times
+func (l *promptLoader) scan() ([]string, string) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, "none"
+	}
+
+	var files []string
+	var sig strings.Builder
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(l.dir, e.Name())
+		files = append(files, path)
+		fmt.Fprintf(&sig, "%s:%d:%d;", e.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+	if sig.Len() == 0 {
+		return nil, "empty"
+	}
+	return files, sig.String()
+}
+
+// list returns the project prompts in name order
+func (l *promptLoader) list() []Prompt {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	prompts := make([]Prompt, 0, len(l.names))
+	for _, name := range l.names {
+		def := l.prompts[name].def
+		p := Prompt{Name: def.Name, Description: def.Description}
+		for _, a := range def.Arguments {
+			p.Arguments = append(p.Arguments, PromptArgument{Name: a.Name, Description: a.Description, Required: a.Required})
+		}
+		prompts = append(prompts, p)
+	}
+	return prompts
+}
+
+// render executes a project prompt; ok is false when no prompt has that name
+func (l *promptLoader) render(name string, args map[string]string) (text string, ok bool, err error) {
+	l.mu.Lock()
+	p, ok := l.prompts[name]
+	l.mu.Unlock()
+	if !ok {
+		return "", false, nil
+	}
+
+	data := make(map[string]string, len(args))
+	for k, v := range args {
+		data[k] = v
+	}
+	for _, a := range p.def.Arguments {
+		if _, ok := data[a.Name]; !ok {
+			if a.Required {
+				return "", true, fmt.Errorf("필수 인자 '%s'가 없습니다", a.Name)
+			}
+			data[a.Name] = ""
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := p.tmpl.Execute(&buf, data); err != nil {
+		return "", true, fmt.Errorf("프롬프트 '%s' 렌더링 실패: %w", name, err)
+	}
+	return buf.String(), true, nil
+}
+
+// loadPromptFile parses and validates one prompt definition
+func loadPromptFile(path string) (*projectPrompt, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var def PromptFile
+	if err := yaml.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("YAML 파싱 실패: %w", err)
+	}
+	if def.Name == "" {
+		def.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if strings.TrimSpace(def.Template) == "" {
+		return nil, fmt.Errorf("template이 비어 있습니다")
+	}
+	for _, a := range def.Arguments {
+		if a.Name == "" {
+			return nil, fmt.Errorf("이름이 없는 인자가 있습니다")
+		}
+	}
+
+	tmpl, err := template.New(def.Name).Option("missingkey=zero").Parse(def.Template)
+	if err != nil {
+		return nil, fmt.Errorf("템플릿 파싱 실패: %w", err)
+	}
+	return &projectPrompt{def: def, tmpl: tmpl, path: path}, nil
+}
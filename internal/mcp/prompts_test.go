@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPromptLoader(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, ".pal", "prompts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	l := newPromptLoader(root)
+	l.reload()
+	if got := l.list(); len(got) != 0 {
+		t.Fatalf("빈 디렉토리에서 프롬프트가 로드됨: %v", got)
+	}
+
+	review := `name: review_port
+description: 포트 리뷰
+arguments:
+  - name: port_id
+    required: true
+  - name: focus
+template: |
+  포트 {{.port_id}}를 리뷰하세요.{{if .focus}} 중점: {{.focus}}{{end}}
+`
+	os.WriteFile(filepath.Join(dir, "review.yaml"), []byte(review), 0644)
+	os.WriteFile(filepath.Join(dir, "builtin.yaml"), []byte("name: start_build\ntemplate: x\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("name: broken\ntemplate: '{{.x'\n"), 0644)
+
+	if !l.reload() {
+		t.Fatal("파일 추가가 변경으로 감지되지 않음")
+	}
+	if l.reload() {
+		t.Error("변경 없이 다시 로드됨")
+	}
+
+	list := l.list()
+	if len(list) != 1 || list[0].Name != "review_port" || len(list[0].Arguments) != 2 || !list[0].Arguments[0].Required {
+		t.Fatalf("list = %+v", list)
+	}
+
+	text, ok, err := l.render("review_port", map[string]string{"port_id": "P-1"})
+	if !ok || err != nil || strings.TrimSpace(text) != "포트 P-1를 리뷰하세요." {
+		t.Errorf("render = %q, %v, %v", text, ok, err)
+	}
+	if _, _, err := l.render("review_port", nil); err == nil {
+		t.Error("필수 인자 없이 렌더링됨")
+	}
+	if _, ok, _ := l.render("start_build", nil); ok {
+		t.Error("내장 프롬프트 이름이 프로젝트 파일로 덮어써짐")
+	}
+
+	// 수정 감지 (같은 크기라도 수정 시각으로 판별)
+	later := time.Now().Add(time.Minute)
+	os.WriteFile(filepath.Join(dir, "review.yaml"), []byte(strings.Replace(review, "리뷰하세요", "검토하세요", 1)), 0644)
+	os.Chtimes(filepath.Join(dir, "review.yaml"), later, later)
+	if !l.reload() {
+		t.Fatal("파일 수정이 감지되지 않음")
+	}
+	if text, _, _ := l.render("review_port", map[string]string{"port_id": "P-1", "focus": "보안"}); !strings.Contains(text, "검토하세요. 중점: 보안") {
+		t.Errorf("수정된 템플릿이 반영되지 않음: %q", text)
+	}
+
+	os.Remove(filepath.Join(dir, "review.yaml"))
+	if !l.reload() || len(l.list()) != 0 {
+		t.Error("삭제된 프롬프트가 남아있음")
+	}
+}
@@ -0,0 +1,222 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/document"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+// 구조화된 도구 결과: 2025-06-18 이후 프로토콜을 협상한 클라이언트에는 structuredContent와
+// 짧은 요약 + pal:// resource_link를 돌려주고, 이전 클라이언트에는 기존처럼 들여쓴 JSON 텍스트를 돌려줍니다.
+// 큰 계층/문서 목록의 전체 내용은 링크된 리소스를 resources/read로 필요할 때만 읽습니다.
+
+// protocolStructured is the first protocol version with structuredContent and resource_link
+const protocolStructured = "2025-06-18"
+
+// supportedProtocolVersions lists the protocol versions this server speaks, newest first
+var supportedProtocolVersions = []string{protocolStructured, "2025-03-26", "2024-11-05"}
+
+// negotiateProtocol returns the requested version when supported, otherwise the newest one
+func negotiateProtocol(requested string) string {
+	for _, v := range supportedProtocolVersions {
+		if v == requested {
+			return v
+		}
+	}
+	return supportedProtocolVersions[0]
+}
+
+// ResourceLink points to a pal:// resource holding the full data of a tool result
+type ResourceLink struct {
+	URI         string
+	Name        string
+	Description string
+	MimeType    string
+}
+
+// ToolResult is a tool result with a compact summary and links to the full data.
+// Data는 structuredContent로 전달되며 JSON 객체여야 합니다.
+type ToolResult struct {
+	Summary string
+	Data    interface{}
+	Links   []ResourceLink
+	Legacy  interface{} // 이전 프로토콜 클라이언트에 보낼 값 (nil이면 Data)
+}
+
+// ResourceTemplate describes a parameterized resource (resources/templates/list)
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+var resourceTemplates = []ResourceTemplate{
+	{URITemplate: "pal://sessions/{id}/hierarchy", Name: "Session Hierarchy", Description: "세션 계층 전체 (모든 필드)", MimeType: "application/json"},
+	{URITemplate: "pal://ports/{status}", Name: "Ports", Description: "상태별 포트 목록 (all이면 전체)", MimeType: "application/json"},
+	{URITemplate: "pal://docs/{id}", Name: "Document", Description: "프로젝트 문서 본문", MimeType: "text/markdown"},
+	{URITemplate: "pal://kb/{path}", Name: "KB Document", Description: "KB 문서 본문 (vault 상대 경로)", MimeType: "text/markdown"},
+}
+
+// toolCallResult builds the tools/call result for a handler's return value
+func (s *Server) toolCallResult(result interface{}) map[string]interface{} {
+	tr, isToolResult := result.(*ToolResult)
+
+	if !s.structured.Load() {
+		// 이전 프로토콜: 전체 데이터를 텍스트로
+		if isToolResult {
+			result = tr.Data
+			if tr.Legacy != nil {
+				result = tr.Legacy
+			}
+		}
+		resultText, _ := json.MarshalIndent(result, "", "  ")
+		return map[string]interface{}{
+			"content": []ContentItem{{Type: "text", Text: string(resultText)}},
+		}
+	}
+
+	if !isToolResult {
+		data, _ := json.Marshal(result)
+		out := map[string]interface{}{
+			"content": []ContentItem{{Type: "text", Text: string(data)}},
+		}
+		if bytes.HasPrefix(data, []byte("{")) {
+			out["structuredContent"] = json.RawMessage(data)
+		}
+		return out
+	}
+
+	content := []ContentItem{{Type: "text", Text: tr.Summary}}
+	for _, l := range tr.Links {
+		content = append(content, ContentItem{
+			Type:        "resource_link",
+			URI:         l.URI,
+			Name:        l.Name,
+			Description: l.Description,
+			MimeType:    l.MimeType,
+		})
+	}
+	return map[string]interface{}{
+		"content":           content,
+		"structuredContent": tr.Data,
+	}
+}
+
+// readResourceTemplate reads a parameterized pal:// resource; ok is false for unknown URIs
+func (s *Server) readResourceTemplate(uri string) (mimeType, text string, ok bool, err error) {
+	rest, found := strings.CutPrefix(uri, "pal://")
+	if !found {
+		return "", "", false, nil
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "sessions/") && strings.HasSuffix(rest, "/hierarchy"):
+		id := strings.TrimSuffix(strings.TrimPrefix(rest, "sessions/"), "/hierarchy")
+		node, err := s.sessionSvc.GetSessionHierarchy(id)
+		if err != nil {
+			return "", "", true, err
+		}
+		data, _ := json.MarshalIndent(node, "", "  ")
+		return "application/json", string(data), true, nil
+
+	case strings.HasPrefix(rest, "ports/"):
+		status := strings.TrimPrefix(rest, "ports/")
+		if status == "all" {
+			status = ""
+		}
+		ports, err := port.NewService(s.database).List(status, 0)
+		if err != nil {
+			return "", "", true, err
+		}
+		items := make([]PortInfo, 0, len(ports))
+		for _, p := range ports {
+			items = append(items, toPortInfo(p))
+		}
+		data, _ := json.MarshalIndent(items, "", "  ")
+		return "application/json", string(data), true, nil
+
+	case strings.HasPrefix(rest, "docs/"):
+		content, err := document.NewService(s.database, s.projectRoot).GetContent(strings.TrimPrefix(rest, "docs/"))
+		if err != nil {
+			return "", "", true, err
+		}
+		return "text/markdown", content, true, nil
+
+	case strings.HasPrefix(rest, "kb/"):
+		full, err := s.kbFilePath(strings.TrimPrefix(rest, "kb/"))
+		if err != nil {
+			return "", "", true, err
+		}
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return "", "", true, fmt.Errorf("KB 문서를 찾을 수 없습니다: %s", strings.TrimPrefix(rest, "kb/"))
+		}
+		return "text/markdown", string(data), true, nil
+	}
+	return "", "", false, nil
+}
+
+// HierarchyNode is a compact session hierarchy node (전체 필드는 pal://sessions/{id}/hierarchy)
+type HierarchyNode struct {
+	ID       string           `json:"id"`
+	Title    string           `json:"title,omitempty"`
+	Type     string           `json:"type,omitempty"`
+	Status   string           `json:"status"`
+	PortID   string           `json:"port_id,omitempty"`
+	Children []*HierarchyNode `json:"children,omitempty"`
+}
+
+func compactHierarchy(node *session.SessionHierarchyNode) *HierarchyNode {
+	sess := node.Session
+	out := &HierarchyNode{ID: sess.ID, Type: sess.Type, Status: sess.Status}
+	if sess.Title.Valid {
+		out.Title = sess.Title.String
+	}
+	if sess.PortID.Valid {
+		out.PortID = sess.PortID.String
+	}
+	for _, child := range node.Children {
+		out.Children = append(out.Children, compactHierarchy(child))
+	}
+	return out
+}
+
+// countByStatus counts the nodes of a hierarchy by status
+func countByStatus(node *HierarchyNode, counts map[string]int) int {
+	counts[node.Status]++
+	total := 1
+	for _, child := range node.Children {
+		total += countByStatus(child, counts)
+	}
+	return total
+}
+
+// formatCounts renders counts as "running 2, complete 5" in key order
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s %d", k, counts[k]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func toPortInfo(p port.Port) PortInfo {
+	title := p.ID
+	if p.Title.Valid {
+		title = p.Title.String
+	}
+	return PortInfo{ID: p.ID, Title: title, Status: p.Status}
+}
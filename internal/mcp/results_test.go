@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateProtocol(t *testing.T) {
+	tests := map[string]string{
+		"2024-11-05": "2024-11-05",
+		"2025-06-18": "2025-06-18",
+		"1999-01-01": protocolStructured,
+		"":           protocolStructured,
+	}
+	for requested, want := range tests {
+		if got := negotiateProtocol(requested); got != want {
+			t.Errorf("negotiateProtocol(%q) = %q, want %q", requested, got, want)
+		}
+	}
+}
+
+func TestToolCallResult(t *testing.T) {
+	s := &Server{}
+	tr := &ToolResult{
+		Summary: "세션 3개",
+		Data:    map[string]int{"count": 3},
+		Links:   []ResourceLink{{URI: "pal://sessions/a/hierarchy", Name: "Session Hierarchy"}},
+		Legacy:  map[string]string{"full": "tree"},
+	}
+
+	// 이전 프로토콜: Legacy 값을 들여쓴 JSON 텍스트로
+	out := s.toolCallResult(tr)
+	content := out["content"].([]ContentItem)
+	if len(content) != 1 || !strings.Contains(content[0].Text, `"full": "tree"`) || out["structuredContent"] != nil {
+		t.Errorf("legacy result = %+v", out)
+	}
+
+	s.structured.Store(true)
+	out = s.toolCallResult(tr)
+	content = out["content"].([]ContentItem)
+	if len(content) != 2 || content[0].Text != "세션 3개" ||
+		content[1].Type != "resource_link" || content[1].URI != "pal://sessions/a/hierarchy" {
+		t.Errorf("structured content = %+v", content)
+	}
+	data, _ := json.Marshal(out["structuredContent"])
+	if string(data) != `{"count":3}` {
+		t.Errorf("structuredContent = %s", data)
+	}
+
+	// 일반 결과: 객체면 structuredContent, 배열은 텍스트만
+	out = s.toolCallResult(map[string]string{"status": "ok"})
+	if out["content"].([]ContentItem)[0].Text != `{"status":"ok"}` || out["structuredContent"] == nil {
+		t.Errorf("plain object result = %+v", out)
+	}
+	out = s.toolCallResult([]string{"a"})
+	if _, ok := out["structuredContent"]; ok {
+		t.Errorf("array result has structuredContent: %+v", out)
+	}
+}
@@ -2,19 +2,27 @@ package mcp
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/n0roo/pal-kit/internal/agentv2"
 	"github.com/n0roo/pal-kit/internal/attention"
+	"github.com/n0roo/pal-kit/internal/config"
 	"github.com/n0roo/pal-kit/internal/db"
 	"github.com/n0roo/pal-kit/internal/handoff"
+	"github.com/n0roo/pal-kit/internal/lock"
 	"github.com/n0roo/pal-kit/internal/message"
 	"github.com/n0roo/pal-kit/internal/orchestrator"
+	"github.com/n0roo/pal-kit/internal/port"
 	"github.com/n0roo/pal-kit/internal/session"
 )
 
@@ -89,12 +97,18 @@ type ContentItem struct {
 	Text     string `json:"text,omitempty"`
 	MimeType string `json:"mimeType,omitempty"`
 	Data     string `json:"data,omitempty"`
+
+	// resource_link
+	URI         string `json:"uri,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 // Server represents the MCP server
 type Server struct {
 	database    *db.DB
 	projectRoot string
+	vaultPath   string
 
 	// Services
 	sessionSvc *session.Service
@@ -103,10 +117,23 @@ type Server struct {
 	agentStore *agentv2.Store
 	attStore   *attention.Store
 	hoStore    *handoff.Store
+	lockSvc    *lock.Service
+	prompts    *promptLoader
+
+	mcpSettings config.MCPSettings
+
+	// 클라이언트가 structuredContent/resource_link를 지원하는 프로토콜을 협상했는지
+	structured atomic.Bool
+
+	// 처리 중인 요청 (취소용)
+	inflightMu sync.Mutex
+	inflight   map[string]context.CancelCauseFunc
+	wg         sync.WaitGroup
 
 	// I/O
-	reader *bufio.Reader
-	writer io.Writer
+	reader  *bufio.Reader
+	writer  io.Writer
+	writeMu sync.Mutex // 요청별 goroutine과 프롬프트 변경 알림이 동시에 쓰므로 직렬화
 }
 
 // NewServer creates a new MCP server
@@ -119,6 +146,18 @@ func NewServer(dbPath, projectRoot string) (*Server, error) {
 	sessionSvc := session.NewService(database)
 	msgStore := message.NewStore(database.DB)
 
+	hoStore := handoff.NewStore(database)
+	var mcpSettings config.MCPSettings
+	if projectRoot != "" {
+		if projectCfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+			hoStore.SetBudgets(projectCfg.Settings.HandoffBudgets)
+			mcpSettings = projectCfg.Settings.MCP
+		}
+	}
+
+	prompts := newPromptLoader(projectRoot)
+	prompts.reload()
+
 	return &Server{
 		database:    database,
 		projectRoot: projectRoot,
@@ -127,12 +166,37 @@ func NewServer(dbPath, projectRoot string) (*Server, error) {
 		msgStore:    msgStore,
 		agentStore:  agentv2.NewStore(database.DB),
 		attStore:    attention.NewStore(database.DB),
-		hoStore:     handoff.NewStore(database),
+		hoStore:     hoStore,
+		lockSvc:     lock.NewProjectService(database, projectRoot),
+		prompts:     prompts,
+		mcpSettings: mcpSettings,
+		inflight:    make(map[string]context.CancelCauseFunc),
 		reader:      bufio.NewReader(os.Stdin),
 		writer:      os.Stdout,
 	}, nil
 }
 
+// newSession returns a server for one client connection that shares the DB and services
+// but has its own output, in-flight requests and negotiated protocol (HTTP 전송의 세션별 상태)
+func (s *Server) newSession(w io.Writer) *Server {
+	return &Server{
+		database:    s.database,
+		projectRoot: s.projectRoot,
+		vaultPath:   s.vaultPath,
+		sessionSvc:  s.sessionSvc,
+		orchSvc:     s.orchSvc,
+		msgStore:    s.msgStore,
+		agentStore:  s.agentStore,
+		attStore:    s.attStore,
+		hoStore:     s.hoStore,
+		lockSvc:     s.lockSvc,
+		prompts:     s.prompts,
+		mcpSettings: s.mcpSettings,
+		inflight:    make(map[string]context.CancelCauseFunc),
+		writer:      w,
+	}
+}
+
 // Close closes the server
 func (s *Server) Close() error {
 	return s.database.Close()
@@ -142,6 +206,13 @@ func (s *Server) Close() error {
 func (s *Server) Run() error {
 	log.Println("PAL Kit MCP Server started")
 
+	done := make(chan struct{})
+	defer close(done)
+	go s.watchPrompts(done)
+
+	// 입력이 끝나도 처리 중인 요청의 응답은 마저 보냄
+	defer s.wg.Wait()
+
 	for {
 		line, err := s.reader.ReadString('\n')
 		if err == io.EOF {
@@ -162,11 +233,27 @@ func (s *Server) Run() error {
 			continue
 		}
 
-		s.handleRequest(&req)
+		s.dispatch(&req)
+	}
+}
+
+// watchPrompts reloads .pal/prompts periodically and tells the client when the list changed
+func (s *Server) watchPrompts(done <-chan struct{}) {
+	ticker := time.NewTicker(promptReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if s.prompts.reload() {
+				s.notify("notifications/prompts/list_changed")
+			}
+		}
 	}
 }
 
-func (s *Server) handleRequest(req *JSONRPCRequest) {
+func (s *Server) handleRequest(ctx context.Context, req *JSONRPCRequest) {
 	switch req.Method {
 	case "initialize":
 		s.handleInitialize(req)
@@ -175,7 +262,7 @@ func (s *Server) handleRequest(req *JSONRPCRequest) {
 	case "tools/list":
 		s.handleToolsList(req)
 	case "tools/call":
-		s.handleToolsCall(req)
+		s.handleToolsCall(ctx, req)
 	case "prompts/list":
 		s.handlePromptsList(req)
 	case "prompts/get":
@@ -184,14 +271,24 @@ func (s *Server) handleRequest(req *JSONRPCRequest) {
 		s.handleResourcesList(req)
 	case "resources/read":
 		s.handleResourcesRead(req)
+	case "resources/templates/list":
+		s.sendResult(req.ID, map[string]interface{}{"resourceTemplates": resourceTemplates})
 	default:
 		s.sendError(req.ID, -32601, "Method not found", req.Method)
 	}
 }
 
 func (s *Server) handleInitialize(req *JSONRPCRequest) {
+	var params struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	json.Unmarshal(req.Params, &params)
+
+	version := negotiateProtocol(params.ProtocolVersion)
+	s.structured.Store(version >= protocolStructured)
+
 	result := map[string]interface{}{
-		"protocolVersion": "2024-11-05",
+		"protocolVersion": version,
 		"serverInfo": map[string]string{
 			"name":    "pal-kit",
 			"version": "1.0.0",
@@ -209,6 +306,9 @@ func (s *Server) handleToolsList(req *JSONRPCRequest) {
 	// Claude 친화적 도구들 먼저 추가
 	tools := GetClaudeTools()
 
+	tools = append(tools, toolLockAcquire, toolLockRelease, toolLockStatus, toolKBSearch, toolDocGet,
+		toolEscalationCreate, toolEscalationList, toolEscalationResolve, toolPortPlan)
+
 	// 기존 도구들 추가
 	tools = append(tools, []Tool{
 		{
@@ -312,6 +412,17 @@ func (s *Server) handleToolsList(req *JSONRPCRequest) {
 				"required": ["orchestration_id"]
 			}`),
 		},
+		{
+			Name:        "execution_list",
+			Description: "Orchestration과 이관되지 않은 레거시 파이프라인을 하나의 실행 목록으로 조회합니다",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"status": {"type": "string", "description": "상태 필터 (optional)"},
+					"limit": {"type": "integer", "description": "최대 개수 (default: 20)"}
+				}
+			}`),
+		},
 		{
 			Name:        "message_send",
 			Description: "다른 세션에 메시지를 전송합니다",
@@ -410,7 +521,7 @@ func (s *Server) handleToolsList(req *JSONRPCRequest) {
 	s.sendResult(req.ID, map[string]interface{}{"tools": tools})
 }
 
-func (s *Server) handleToolsCall(req *JSONRPCRequest) {
+func (s *Server) handleToolsCall(ctx context.Context, req *JSONRPCRequest) {
 	var params struct {
 		Name      string          `json:"name"`
 		Arguments json.RawMessage `json:"arguments"`
@@ -421,74 +532,112 @@ func (s *Server) handleToolsCall(req *JSONRPCRequest) {
 		return
 	}
 
-	var result interface{}
-	var err error
+	fn := s.toolHandler(params.Name)
+	if fn == nil {
+		s.sendError(req.ID, -32601, "Unknown tool", params.Name)
+		return
+	}
 
-	switch params.Name {
+	result, err := s.runTool(ctx, params.Name, fn, params.Arguments)
+	switch {
+	case errors.Is(err, errCancelledSilently):
+		return
+	case errors.Is(err, errCancelled):
+		s.sendError(req.ID, codeRequestCancelled, "Request cancelled", params.Name)
+		return
+	}
+
+	if err != nil {
+		s.sendResult(req.ID, map[string]interface{}{
+			"content": []ContentItem{{Type: "text", Text: fmt.Sprintf("오류: %s", err.Error())}},
+			"isError": true,
+		})
+		return
+	}
+
+	s.sendResult(req.ID, s.toolCallResult(result))
+}
+
+// toolHandler returns the handler of a tool (없는 도구면 nil)
+func (s *Server) toolHandler(name string) toolFunc {
+	switch name {
 	// Claude 친화적 도구들
 	case "pal_status":
-		result, err = s.toolPalStatusHandler(params.Arguments)
+		return s.toolPalStatusHandler
 	case "pal_port_start":
-		result, err = s.toolPalPortStartHandler(params.Arguments)
+		return s.toolPalPortStartHandler
 	case "pal_port_end":
-		result, err = s.toolPalPortEndHandler(params.Arguments)
+		return s.toolPalPortEndHandler
 	case "pal_checkpoint":
-		result, err = s.toolPalCheckpointHandler(params.Arguments)
+		return s.toolPalCheckpointHandler
 	case "pal_escalate":
-		result, err = s.toolPalEscalateHandler(params.Arguments)
+		return s.toolPalEscalateHandler
 	case "pal_context":
-		result, err = s.toolPalContextHandler(params.Arguments)
+		return s.toolPalContextHandler
 	case "pal_session":
-		result, err = s.toolPalSessionHandler(params.Arguments)
+		return s.toolPalSessionHandler
 	case "pal_hierarchy":
-		result, err = s.toolPalHierarchyHandler(params.Arguments)
+		return s.toolPalHierarchyHandler
+	case "pal_scratchpad":
+		return s.toolPalScratchpadHandler
+	case "pal_feedback":
+		return s.toolPalFeedbackHandler
+	// Lock 도구들
+	case "lock_acquire":
+		return s.toolLockAcquireHandler
+	case "lock_release":
+		return s.toolLockReleaseHandler
+	case "lock_status":
+		return s.toolLockStatusHandler
+	// KB/문서 도구들
+	case "kb_search":
+		return s.toolKBSearchHandler
+	case "doc_get":
+		return s.toolDocGetHandler
+	// 에스컬레이션 도구들
+	case "escalation_create":
+		return s.toolEscalationCreateHandler
+	case "escalation_list":
+		return s.toolEscalationListHandler
+	case "escalation_resolve":
+		return s.toolEscalationResolveHandler
+	// 계획 도구
+	case "port_plan":
+		return s.toolPortPlanHandler
+
 	// 기존 도구들
 	case "session_start":
-		result, err = s.toolSessionStart(params.Arguments)
+		return s.toolSessionStart
 	case "session_end":
-		result, err = s.toolSessionEnd(params.Arguments)
+		return s.toolSessionEnd
 	case "session_hierarchy":
-		result, err = s.toolSessionHierarchy(params.Arguments)
+		return s.toolSessionHierarchy
 	case "attention_status":
-		result, err = s.toolAttentionStatus(params.Arguments)
+		return s.toolAttentionStatus
 	case "attention_update":
-		result, err = s.toolAttentionUpdate(params.Arguments)
+		return s.toolAttentionUpdate
 	case "orchestration_create":
-		result, err = s.toolOrchestrationCreate(params.Arguments)
+		return s.toolOrchestrationCreate
 	case "orchestration_status":
-		result, err = s.toolOrchestrationStatus(params.Arguments)
+		return s.toolOrchestrationStatus
+	case "execution_list":
+		return s.toolExecutionList
 	case "message_send":
-		result, err = s.toolMessageSend(params.Arguments)
+		return s.toolMessageSend
 	case "message_receive":
-		result, err = s.toolMessageReceive(params.Arguments)
+		return s.toolMessageReceive
 	case "handoff_create":
-		result, err = s.toolHandoffCreate(params.Arguments)
+		return s.toolHandoffCreate
 	case "handoff_get":
-		result, err = s.toolHandoffGet(params.Arguments)
+		return s.toolHandoffGet
 	case "agent_list":
-		result, err = s.toolAgentList(params.Arguments)
+		return s.toolAgentList
 	case "agent_version":
-		result, err = s.toolAgentVersion(params.Arguments)
+		return s.toolAgentVersion
 	case "compact_record":
-		result, err = s.toolCompactRecord(params.Arguments)
-	default:
-		s.sendError(req.ID, -32601, "Unknown tool", params.Name)
-		return
+		return s.toolCompactRecord
 	}
-
-	if err != nil {
-		s.sendResult(req.ID, map[string]interface{}{
-			"content": []ContentItem{{Type: "text", Text: fmt.Sprintf("오류: %s", err.Error())}},
-			"isError": true,
-		})
-		return
-	}
-
-	// Format result as text
-	resultText, _ := json.MarshalIndent(result, "", "  ")
-	s.sendResult(req.ID, map[string]interface{}{
-		"content": []ContentItem{{Type: "text", Text: string(resultText)}},
-	})
+	return nil
 }
 
 func (s *Server) handlePromptsList(req *JSONRPCRequest) {
@@ -518,6 +667,8 @@ func (s *Server) handlePromptsList(req *JSONRPCRequest) {
 			},
 		},
 	}
+	s.prompts.reload()
+	prompts = append(prompts, s.prompts.list()...)
 
 	s.sendResult(req.ID, map[string]interface{}{"prompts": prompts})
 }
@@ -560,6 +711,8 @@ func (s *Server) handlePromptsGet(req *JSONRPCRequest) {
 		// Load handoffs for the port
 		handoffs, _ := s.hoStore.GetForPort(params.Arguments["port_id"])
 		handoffsJSON, _ := json.MarshalIndent(handoffs, "", "  ")
+		notes, _ := port.NewService(s.database).Scratchpad(params.Arguments["port_id"])
+		scratchpad := port.RenderScratchpad(params.Arguments["port_id"], notes)
 
 		messages = []map[string]interface{}{
 			{
@@ -574,10 +727,14 @@ func (s *Server) handlePromptsGet(req *JSONRPCRequest) {
 핸드오프 정보:
 %s
 
+이전 세션의 메모:
+%s
+
 다음 단계:
 1. attention_status로 현재 Attention 상태를 확인하세요
-2. 핸드오프 정보를 기반으로 구현을 시작하세요
-3. 구현 완료 시 message_send로 impl_ready를 전송하세요`, params.Arguments["port_id"], params.Arguments["session_id"], string(handoffsJSON)),
+2. 핸드오프 정보와 스크래치패드 메모를 기반으로 구현을 시작하세요
+3. 다음 워커가 알아야 할 주의사항은 pal_scratchpad로 남기세요
+4. 구현 완료 시 message_send로 impl_ready를 전송하세요`, params.Arguments["port_id"], params.Arguments["session_id"], string(handoffsJSON), scratchpad),
 				},
 			},
 		}
@@ -602,8 +759,22 @@ func (s *Server) handlePromptsGet(req *JSONRPCRequest) {
 		}
 
 	default:
-		s.sendError(req.ID, -32602, "Unknown prompt", params.Name)
-		return
+		s.prompts.reload()
+		text, ok, err := s.prompts.render(params.Name, params.Arguments)
+		if !ok {
+			s.sendError(req.ID, -32602, "Unknown prompt", params.Name)
+			return
+		}
+		if err != nil {
+			s.sendError(req.ID, -32602, "Invalid arguments", err.Error())
+			return
+		}
+		messages = []map[string]interface{}{
+			{
+				"role":    "user",
+				"content": ContentItem{Type: "text", Text: text},
+			},
+		}
 	}
 
 	s.sendResult(req.ID, map[string]interface{}{"messages": messages})
@@ -660,7 +831,20 @@ func (s *Server) handleResourcesRead(req *JSONRPCRequest) {
 		content = agents
 
 	default:
-		s.sendError(req.ID, -32602, "Unknown resource", params.URI)
+		mimeType, text, ok, err := s.readResourceTemplate(params.URI)
+		if !ok {
+			s.sendError(req.ID, -32602, "Unknown resource", params.URI)
+			return
+		}
+		if err != nil {
+			s.sendError(req.ID, -32002, "Resource not found", err.Error())
+			return
+		}
+		s.sendResult(req.ID, map[string]interface{}{
+			"contents": []map[string]interface{}{
+				{"uri": params.URI, "mimeType": mimeType, "text": text},
+			},
+		})
 		return
 	}
 
@@ -742,7 +926,25 @@ func (s *Server) toolSessionHierarchy(args json.RawMessage) (interface{}, error)
 		return nil, err
 	}
 
-	return s.sessionSvc.GetSessionHierarchy(params.RootID)
+	node, err := s.sessionSvc.GetSessionHierarchy(params.RootID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := compactHierarchy(node)
+	counts := map[string]int{}
+	total := countByStatus(tree, counts)
+	return &ToolResult{
+		Summary: fmt.Sprintf("세션 계층 %s: %d개 세션 (%s)", tree.ID, total, formatCounts(counts)),
+		Data:    tree,
+		Links: []ResourceLink{{
+			URI:         fmt.Sprintf("pal://sessions/%s/hierarchy", tree.ID),
+			Name:        "Session Hierarchy",
+			Description: "모든 필드를 포함한 세션 계층",
+			MimeType:    "application/json",
+		}},
+		Legacy: node,
+	}, nil
 }
 
 func (s *Server) toolAttentionStatus(args json.RawMessage) (interface{}, error) {
@@ -814,12 +1016,17 @@ func (s *Server) toolOrchestrationStatus(args json.RawMessage) (interface{}, err
 		return nil, err
 	}
 
-	orch, err := s.orchSvc.GetOrchestration(params.OrchestrationID)
+	// 이관된 파이프라인 ID로도 조회 가능
+	id, err := s.orchSvc.ResolveExecutionID(params.OrchestrationID)
+	if err != nil {
+		return nil, err
+	}
+	orch, err := s.orchSvc.GetOrchestration(id)
 	if err != nil {
 		return nil, err
 	}
 
-	stats, _ := s.orchSvc.GetOrchestrationStats(params.OrchestrationID)
+	stats, _ := s.orchSvc.GetOrchestrationStats(id)
 
 	return map[string]interface{}{
 		"orchestration": orch,
@@ -827,6 +1034,24 @@ func (s *Server) toolOrchestrationStatus(args json.RawMessage) (interface{}, err
 	}, nil
 }
 
+func (s *Server) toolExecutionList(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		Status string `json:"status"`
+		Limit  int    `json:"limit"`
+	}
+
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, err
+		}
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+
+	return s.orchSvc.ListExecutions(params.Status, params.Limit)
+}
+
 func (s *Server) toolMessageSend(args json.RawMessage) (interface{}, error) {
 	var params struct {
 		FromSession string      `json:"from_session"`
@@ -995,7 +1220,19 @@ func (s *Server) sendError(id interface{}, code int, message string, data interf
 	s.send(resp)
 }
 
+// notify sends a JSON-RPC notification (id 없음)
+func (s *Server) notify(method string) {
+	data, _ := json.Marshal(map[string]string{"jsonrpc": "2.0", "method": method})
+	s.writeLine(data)
+}
+
 func (s *Server) send(resp JSONRPCResponse) {
 	data, _ := json.Marshal(resp)
+	s.writeLine(data)
+}
+
+func (s *Server) writeLine(data []byte) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
 	fmt.Fprintf(s.writer, "%s\n", data)
 }
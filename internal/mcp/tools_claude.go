@@ -10,6 +10,7 @@ import (
 	"github.com/n0roo/pal-kit/internal/checklist"
 	"github.com/n0roo/pal-kit/internal/checkpoint"
 	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/feedback"
 	"github.com/n0roo/pal-kit/internal/port"
 	"github.com/n0roo/pal-kit/internal/rules"
 )
@@ -129,6 +130,39 @@ var toolPalHierarchy = Tool{
 	}`),
 }
 
+// pal_scratchpad 도구 스키마
+var toolPalScratchpad = Tool{
+	Name:        "pal_scratchpad",
+	Description: "포트의 공유 스크래치패드를 읽거나 메모를 추가합니다. 다음 워커가 알아야 할 주의사항(gotcha)을 남기세요. 메모는 추가만 가능합니다.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"action": {"type": "string", "enum": ["read", "append"], "description": "동작 (default: read)"},
+			"port_id": {"type": "string", "description": "포트 ID"},
+			"note": {"type": "string", "description": "추가할 메모 (append)"},
+			"session_id": {"type": "string", "description": "메모를 남기는 세션 ID (optional)"}
+		},
+		"required": ["port_id"]
+	}`),
+}
+
+// pal_feedback 도구 스키마
+var toolPalFeedback = Tool{
+	Name:        "pal_feedback",
+	Description: "주입된 브리핑/규칙/문서가 작업에 도움이 됐는지, 불필요하거나 부정확했는지 피드백을 남깁니다. 템플릿·문서별로 집계되어 컨텍스트 구성 조정에 쓰입니다.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"on": {"type": "string", "enum": ["briefing", "rules", "docs"], "description": "피드백 대상"},
+			"ref": {"type": "string", "description": "규칙 파일/문서 경로 등 식별자 (optional)"},
+			"comment": {"type": "string", "description": "피드백 내용"},
+			"rating": {"type": "integer", "enum": [-1, 0, 1], "description": "1: 유용, -1: 불필요/부정확, 0: 의견만 (default: 0)"},
+			"session_id": {"type": "string", "description": "세션 ID (optional)"}
+		},
+		"required": ["on", "comment"]
+	}`),
+}
+
 // GetClaudeTools returns Claude-friendly tools
 func GetClaudeTools() []Tool {
 	return []Tool{
@@ -140,6 +174,8 @@ func GetClaudeTools() []Tool {
 		toolPalContext,
 		toolPalSession,
 		toolPalHierarchy,
+		toolPalScratchpad,
+		toolPalFeedback,
 	}
 }
 
@@ -594,18 +630,20 @@ func (s *Server) toolPalEscalateHandler(args json.RawMessage) (interface{}, erro
 		return nil, err
 	}
 
-	// 에스컬레이션 이벤트 기록
-	escID := fmt.Sprintf("esc-%d", time.Now().Unix())
-
-	// pal hook event escalation 호출
-	cmd := exec.Command("pal", "hook", "event", "escalation", params.Issue)
-	cmd.Dir = s.projectRoot
-	cmd.Run()
+	e, err := s.createEscalation(escalationCreateParams{
+		Issue:      params.Issue,
+		Type:       params.Type,
+		Context:    params.Context,
+		Suggestion: params.Suggestion,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
 
 	return &EscalateResult{
-		EscalationID: escID,
+		EscalationID: fmt.Sprintf("%d", e.ID),
 		Status:       "created",
-		Message:      fmt.Sprintf("에스컬레이션 생성됨: %s", params.Type),
+		Message:      fmt.Sprintf("에스컬레이션 생성됨: %s (#%d)", params.Type, e.ID),
 	}, nil
 }
 
@@ -676,11 +714,19 @@ func (s *Server) toolPalContextHandler(args json.RawMessage) (interface{}, error
 	}
 
 	// 토큰 합계 계산
+	var links []ResourceLink
 	for _, item := range result.Items {
 		result.TotalTokens += item.Tokens
+		if params.Type == "document" && item.ID != "" {
+			links = append(links, ResourceLink{URI: "pal://docs/" + item.ID, Name: item.Path, MimeType: "text/markdown"})
+		}
 	}
 
-	return result, nil
+	return &ToolResult{
+		Summary: fmt.Sprintf("%s %d개 (%d 토큰)", params.Type, len(result.Items), result.TotalTokens),
+		Data:    result,
+		Links:   links,
+	}, nil
 }
 
 // _truncateOutput truncates output to maxLen
@@ -832,5 +878,95 @@ func (s *Server) toolPalHierarchyHandler(args json.RawMessage) (interface{}, err
 		"blocked_ports":  len(portsByStatus["blocked"]),
 	}
 
+	counts := map[string]int{}
+	for status, ports := range portsByStatus {
+		counts[status] = len(ports)
+	}
+	return &ToolResult{
+		Summary: fmt.Sprintf("메인 세션 %d개, 포트 %s", len(result["hierarchy"].([]interface{})), formatCounts(counts)),
+		Data:    result,
+		Links: []ResourceLink{{
+			URI:         "pal://ports/all",
+			Name:        "Ports",
+			Description: "전체 포트 목록",
+			MimeType:    "application/json",
+		}},
+	}, nil
+}
+
+// ScratchpadResult represents pal_scratchpad result
+type ScratchpadResult struct {
+	PortID   string             `json:"port_id"`
+	Notes    []port.ScratchNote `json:"notes"`
+	Document string             `json:"document"`
+	Added    *port.ScratchNote  `json:"added,omitempty"`
+}
+
+// toolPalScratchpadHandler handles pal_scratchpad tool call
+func (s *Server) toolPalScratchpadHandler(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		Action    string `json:"action"`
+		PortID    string `json:"port_id"`
+		Note      string `json:"note"`
+		SessionID string `json:"session_id"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	}
+
+	portSvc := port.NewService(s.database)
+	result := &ScratchpadResult{PortID: params.PortID}
+
+	switch params.Action {
+	case "append":
+		note, err := portSvc.AppendScratch(params.PortID, params.SessionID, params.Note)
+		if err != nil {
+			return nil, err
+		}
+		result.Added = note
+	case "", "read":
+		if _, err := portSvc.Get(params.PortID); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("알 수 없는 동작입니다: %s", params.Action)
+	}
+
+	notes, err := portSvc.Scratchpad(params.PortID)
+	if err != nil {
+		return nil, err
+	}
+	result.Notes = notes
+	result.Document = port.RenderScratchpad(params.PortID, notes)
 	return result, nil
 }
+
+// toolPalFeedbackHandler handles pal_feedback tool call
+func (s *Server) toolPalFeedbackHandler(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		On        string `json:"on"`
+		Ref       string `json:"ref"`
+		Comment   string `json:"comment"`
+		Rating    int    `json:"rating"`
+		SessionID string `json:"session_id"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	}
+
+	target, err := feedback.ParseTarget(params.On)
+	if err != nil {
+		return nil, err
+	}
+	return feedback.NewService(s.database).Record(feedback.Entry{
+		Target:      target,
+		Ref:         params.Ref,
+		Comment:     params.Comment,
+		Rating:      params.Rating,
+		Source:      feedback.SourceAgent,
+		SessionID:   params.SessionID,
+		ProjectRoot: s.projectRoot,
+	})
+}
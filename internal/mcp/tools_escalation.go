@@ -0,0 +1,331 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/port"
+)
+
+// 에스컬레이션 도구: 워커는 구조화된 차단 요인을 올리고, 오퍼레이터 세션은 폴링해서 처리합니다.
+
+var toolEscalationCreate = Tool{
+	Name:        "escalation_create",
+	Description: "구조화된 에스컬레이션을 생성합니다. type이 .pal/escalations.yaml 템플릿이면 필수 fields를 검증하고 해결 절차를 붙입니다.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"issue": {"type": "string", "description": "문제 요약"},
+			"type": {"type": "string", "description": "에스컬레이션 타입 (템플릿 ID 또는 blocked, question, build_fail, test_fail, conflict...)"},
+			"severity": {"type": "string", "enum": ["low", "medium", "high", "critical"], "description": "심각도 (default: 템플릿 또는 medium)"},
+			"context": {"type": "string", "description": "상황 설명"},
+			"suggestion": {"type": "string", "description": "제안하는 해결 방법"},
+			"fields": {"type": "object", "additionalProperties": {"type": "string"}, "description": "템플릿 필드 값"},
+			"session_id": {"type": "string", "description": "요청 세션 ID (해결 알림을 받을 세션)"},
+			"port_id": {"type": "string", "description": "관련 포트 ID"}
+		},
+		"required": ["issue"]
+	}`),
+}
+
+var toolEscalationList = Tool{
+	Name:        "escalation_list",
+	Description: "에스컬레이션을 조회합니다. after_id를 주면 그 이후 생성된 것만 오래된 순으로 반환하므로 오퍼레이터가 폴링할 때 사용하세요.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"status": {"type": "string", "enum": ["open", "resolved", "dismissed", "all"], "description": "상태 (default: open)"},
+			"session_id": {"type": "string", "description": "요청 세션 필터"},
+			"port_id": {"type": "string", "description": "포트 필터"},
+			"assignee": {"type": "string", "description": "담당자 필터"},
+			"after_id": {"type": "integer", "description": "이 ID 이후만 (폴링)"},
+			"limit": {"type": "integer", "description": "최대 개수 (default: 20)"}
+		}
+	}`),
+}
+
+var toolEscalationResolve = Tool{
+	Name:        "escalation_resolve",
+	Description: "에스컬레이션을 해결하거나 기각합니다. 해결 요약은 요청 세션에 메시지로 전달됩니다.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "description": "에스컬레이션 ID"},
+			"status": {"type": "string", "enum": ["resolved", "dismissed"], "description": "처리 결과 (default: resolved)"},
+			"summary": {"type": "string", "description": "해결 요약 또는 기각 사유"},
+			"steps": {"type": "array", "items": {"type": "string"}, "description": "수행한 해결 단계 ID (템플릿 타입)"},
+			"by": {"type": "string", "description": "처리자 (오퍼레이터 세션 ID 등)"}
+		},
+		"required": ["id"]
+	}`),
+}
+
+// EscalationInfo is an escalation as returned by the escalation tools
+type EscalationInfo struct {
+	ID         int64             `json:"id"`
+	Issue      string            `json:"issue"`
+	Status     string            `json:"status"`
+	Type       string            `json:"type,omitempty"`
+	Severity   string            `json:"severity,omitempty"`
+	Context    string            `json:"context,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	Suggestion string            `json:"suggestion,omitempty"`
+	SessionID  string            `json:"session_id,omitempty"`
+	PortID     string            `json:"port_id,omitempty"`
+	Assignee   string            `json:"assignee,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	ResolvedAt *time.Time        `json:"resolved_at,omitempty"`
+	Resolution string            `json:"resolution,omitempty"`
+	ResolvedBy string            `json:"resolved_by,omitempty"`
+	StepsTaken []string          `json:"steps_taken,omitempty"`
+}
+
+func toEscalationInfo(e escalation.Escalation) EscalationInfo {
+	info := EscalationInfo{
+		ID:         e.ID,
+		Issue:      e.Issue,
+		Status:     e.Status,
+		Type:       e.Type.String,
+		Severity:   e.Severity.String,
+		Context:    e.Note(),
+		Fields:     e.Fields(),
+		Suggestion: e.Suggestion.String,
+		SessionID:  e.FromSession.String,
+		PortID:     e.FromPort.String,
+		Assignee:   e.Assignee.String,
+		CreatedAt:  e.CreatedAt,
+	}
+	if e.ResolvedAt.Valid {
+		info.ResolvedAt = &e.ResolvedAt.Time
+	}
+	if r := e.ResolutionRecord(); r != nil {
+		info.Resolution = r.Summary
+		if info.Resolution == "" {
+			info.Resolution = r.Note
+		}
+		info.ResolvedBy = r.By
+		info.StepsTaken = r.Steps
+	}
+	return info
+}
+
+// escalationCreateParams is shared by escalation_create and pal_escalate
+type escalationCreateParams struct {
+	Issue      string            `json:"issue"`
+	Type       string            `json:"type"`
+	Severity   string            `json:"severity"`
+	Context    string            `json:"context"`
+	Suggestion string            `json:"suggestion"`
+	Fields     map[string]string `json:"fields"`
+	SessionID  string            `json:"session_id"`
+	PortID     string            `json:"port_id"`
+}
+
+// toolEscalationCreateHandler handles escalation_create tool call
+func (s *Server) toolEscalationCreateHandler(args json.RawMessage) (interface{}, error) {
+	var params escalationCreateParams
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	}
+
+	e, err := s.createEscalation(params, true)
+	if err != nil {
+		return nil, err
+	}
+	return toEscalationInfo(*e), nil
+}
+
+// createEscalation creates an escalation from tool parameters.
+// strictType이면 템플릿에도 내장 타입에도 없는 type을 거부합니다 (pal esc create와 동일).
+func (s *Server) createEscalation(p escalationCreateParams, strictType bool) (*escalation.Escalation, error) {
+	p.Issue = strings.TrimSpace(p.Issue)
+	if p.Issue == "" {
+		return nil, fmt.Errorf("issue가 필요합니다")
+	}
+	var severity escalation.Severity
+	if p.Severity != "" {
+		sev, err := escalation.ParseSeverity(p.Severity)
+		if err != nil {
+			return nil, err
+		}
+		severity = sev
+	}
+	if p.PortID != "" {
+		if _, err := port.NewService(s.database).Get(p.PortID); err != nil {
+			return nil, fmt.Errorf("포트를 찾을 수 없습니다: %s", p.PortID)
+		}
+	}
+
+	svc := escalation.NewService(s.database)
+	var id int64
+
+	var tmpl *escalation.Template
+	if p.Type != "" {
+		templates, err := escalation.LoadTemplates(s.projectRoot)
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := templates.Get(p.Type); ok {
+			tmpl = t
+		} else if strictType && !escalation.IsBuiltinType(p.Type) {
+			return nil, fmt.Errorf("알 수 없는 에스컬레이션 타입: %s (pal esc types 참고)", p.Type)
+		}
+	}
+
+	if tmpl != nil {
+		created, err := svc.CreateTyped(tmpl, escalation.TypedOptions{
+			Issue:      p.Issue,
+			SessionID:  p.SessionID,
+			PortID:     p.PortID,
+			Fields:     p.Fields,
+			Severity:   severity,
+			Note:       p.Context,
+			Suggestion: p.Suggestion,
+		})
+		if err != nil {
+			return nil, err
+		}
+		id = created
+	} else {
+		ctx := map[string]interface{}{}
+		if len(p.Fields) > 0 {
+			ctx["fields"] = p.Fields
+		}
+		if p.Context != "" {
+			ctx["note"] = p.Context
+		}
+		var ctxValue interface{}
+		if len(ctx) > 0 {
+			ctxValue = ctx
+		}
+		created, err := svc.CreateRecord(escalation.EnhancedEscalationOptions{
+			FromSession: p.SessionID,
+			FromPort:    p.PortID,
+			Type:        escalation.EscalationType(p.Type),
+			Severity:    severity,
+			Issue:       p.Issue,
+			Context:     ctxValue,
+			Suggestion:  p.Suggestion,
+		})
+		if err != nil {
+			return nil, err
+		}
+		id = created
+	}
+
+	return svc.Get(id)
+}
+
+// toolEscalationListHandler handles escalation_list tool call
+func (s *Server) toolEscalationListHandler(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		Status    string `json:"status"`
+		SessionID string `json:"session_id"`
+		PortID    string `json:"port_id"`
+		Assignee  string `json:"assignee"`
+		AfterID   int64  `json:"after_id"`
+		Limit     int    `json:"limit"`
+	}
+
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, err
+		}
+	}
+	switch params.Status {
+	case "":
+		params.Status = escalation.StatusOpen
+	case "all":
+		params.Status = ""
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+
+	list, err := escalation.NewService(s.database).ListFiltered(escalation.ListFilter{
+		Status:   params.Status,
+		Session:  params.SessionID,
+		Port:     params.PortID,
+		Assignee: params.Assignee,
+		AfterID:  params.AfterID,
+		Limit:    params.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]EscalationInfo, 0, len(list))
+	var lastID int64
+	for _, e := range list {
+		items = append(items, toEscalationInfo(e))
+		if e.ID > lastID {
+			lastID = e.ID
+		}
+	}
+	if lastID == 0 {
+		lastID = params.AfterID
+	}
+
+	return map[string]interface{}{
+		"escalations": items,
+		"count":       len(items),
+		"last_id":     lastID, // 다음 폴링의 after_id
+	}, nil
+}
+
+// toolEscalationResolveHandler handles escalation_resolve tool call
+func (s *Server) toolEscalationResolveHandler(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		ID      int64    `json:"id"`
+		Status  string   `json:"status"`
+		Summary string   `json:"summary"`
+		Steps   []string `json:"steps"`
+		By      string   `json:"by"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	}
+	if params.ID <= 0 {
+		return nil, fmt.Errorf("id가 필요합니다")
+	}
+
+	svc := escalation.NewService(s.database)
+	e, err := svc.Get(params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch params.Status {
+	case "", escalation.StatusResolved:
+		var tmpl *escalation.Template
+		if e.Type.Valid && e.Type.String != "" {
+			templates, err := escalation.LoadTemplates(s.projectRoot)
+			if err != nil {
+				return nil, err
+			}
+			if t, ok := templates.Get(e.Type.String); ok {
+				tmpl = t
+			}
+		}
+		record := escalation.ResolutionRecord{Steps: params.Steps, Summary: params.Summary, By: params.By}
+		if err := svc.ResolveWithSteps(params.ID, tmpl, record); err != nil {
+			return nil, err
+		}
+	case escalation.StatusDismissed:
+		if err := svc.DismissBy(params.ID, params.By, params.Summary); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("잘못된 status: %s (resolved, dismissed)", params.Status)
+	}
+
+	e, err = svc.Get(params.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toEscalationInfo(*e), nil
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func callTool(t *testing.T, fn toolFunc, args string) (interface{}, error) {
+	t.Helper()
+	return fn(json.RawMessage(args))
+}
+
+func TestEscalationTools(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, ".pal"), 0755)
+	os.WriteFile(filepath.Join(root, ".pal", "escalations.yaml"), []byte(`types:
+  - id: api_blocked
+    severity: high
+    fields:
+      - name: endpoint
+        required: true
+    resolution_steps:
+      - id: contact-owner
+        description: API 담당자 연락
+        required: true
+`), 0644)
+	s := newTestServer(t, root)
+
+	if _, err := callTool(t, s.toolEscalationCreateHandler, `{"issue":"x","type":"nonsense"}`); err == nil {
+		t.Error("알 수 없는 타입이 허용됨")
+	}
+	if _, err := callTool(t, s.toolEscalationCreateHandler, `{"issue":"x","type":"api_blocked"}`); err == nil {
+		t.Error("템플릿 필수 필드 없이 생성됨")
+	}
+
+	res, err := callTool(t, s.toolEscalationCreateHandler, `{"issue":"결제 API 응답 없음","type":"api_blocked","severity":"critical",
+		"context":"sandbox에서만 재현","suggestion":"mock으로 우회","fields":{"endpoint":"/pay"},"session_id":"worker-1"}`)
+	if err != nil {
+		t.Fatalf("템플릿 에스컬레이션 생성 실패: %v", err)
+	}
+	typed := res.(EscalationInfo)
+	if typed.Severity != "critical" || typed.Context != "sandbox에서만 재현" || typed.Fields["endpoint"] != "/pay" ||
+		!strings.HasPrefix(typed.Suggestion, "mock으로 우회") || !strings.Contains(typed.Suggestion, "contact-owner") {
+		t.Errorf("생성된 에스컬레이션 = %+v", typed)
+	}
+
+	res, err = callTool(t, s.toolEscalationCreateHandler, `{"issue":"스펙 질문","type":"question","context":"필드명 확인 필요"}`)
+	if err != nil {
+		t.Fatalf("내장 타입 에스컬레이션 생성 실패: %v", err)
+	}
+	question := res.(EscalationInfo)
+	if question.Severity != "medium" || question.Context != "필드명 확인 필요" {
+		t.Errorf("내장 타입 에스컬레이션 = %+v", question)
+	}
+
+	// 폴링: after_id 이후만 오래된 순
+	res, _ = callTool(t, s.toolEscalationListHandler, `{}`)
+	if res.(map[string]interface{})["count"] != 2 {
+		t.Errorf("열린 에스컬레이션 수 = %v", res)
+	}
+	res, _ = callTool(t, s.toolEscalationListHandler, `{"after_id":`+jsonInt(typed.ID)+`}`)
+	polled := res.(map[string]interface{})
+	if items := polled["escalations"].([]EscalationInfo); len(items) != 1 || items[0].ID != question.ID || polled["last_id"] != question.ID {
+		t.Errorf("폴링 결과 = %+v", polled)
+	}
+
+	if _, err := callTool(t, s.toolEscalationResolveHandler, `{"id":`+jsonInt(typed.ID)+`,"summary":"mock 적용"}`); err == nil {
+		t.Error("필수 해결 단계 없이 해결됨")
+	}
+	res, err = callTool(t, s.toolEscalationResolveHandler, `{"id":`+jsonInt(typed.ID)+`,"summary":"mock 적용","steps":["contact-owner"],"by":"operator"}`)
+	if err != nil {
+		t.Fatalf("해결 실패: %v", err)
+	}
+	if r := res.(EscalationInfo); r.Status != "resolved" || r.Resolution != "mock 적용" || r.ResolvedBy != "operator" {
+		t.Errorf("해결 결과 = %+v", r)
+	}
+
+	res, err = callTool(t, s.toolEscalationResolveHandler, `{"id":`+jsonInt(question.ID)+`,"status":"dismissed","summary":"중복"}`)
+	if err != nil || res.(EscalationInfo).Status != "dismissed" {
+		t.Errorf("기각 결과 = %+v, %v", res, err)
+	}
+
+	res, _ = callTool(t, s.toolEscalationListHandler, `{"status":"all","session_id":"worker-1"}`)
+	if res.(map[string]interface{})["count"] != 1 {
+		t.Errorf("세션 필터 결과 = %v", res)
+	}
+}
+
+func jsonInt(v int64) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
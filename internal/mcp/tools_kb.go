@@ -0,0 +1,413 @@
+package mcp
+
+import (
+	stdctx "context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/context"
+	"github.com/n0roo/pal-kit/internal/document"
+	"github.com/n0roo/pal-kit/internal/kb"
+)
+
+// KB/문서 도구: 에이전트가 `pal kb search`를 호출하지 않고 참고 문서를 토큰 예산 안에서 가져옵니다.
+
+const (
+	defaultKBSearchBudget  = 4000
+	defaultExcerptTokens   = 300
+	defaultDocGetMaxTokens = 4000
+)
+
+var toolKBSearch = Tool{
+	Name:        "kb_search",
+	Description: "Knowledge Base를 BM25 관련도 순으로 검색합니다. 결과마다 요약과 본문 발췌가 포함되며 전체가 token_budget을 넘지 않도록 잘라서 반환합니다. 전체 본문은 doc_get으로 가져오세요.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"query": {"type": "string", "description": "검색어"},
+			"type": {"type": "string", "description": "문서 타입 필터 (port, adr, concept, guide...)"},
+			"domain": {"type": "string", "description": "도메인 필터"},
+			"status": {"type": "string", "description": "상태 필터 (draft, active, archived)"},
+			"tags": {"type": "array", "items": {"type": "string"}, "description": "태그 필터"},
+			"limit": {"type": "integer", "description": "최대 결과 수 (default: 10)"},
+			"token_budget": {"type": "integer", "description": "전체 결과 토큰 예산 (default: 4000)"},
+			"excerpt_tokens": {"type": "integer", "description": "문서당 본문 발췌 토큰 (default: 300, 0이면 발췌 없음)"},
+			"content": {"type": "boolean", "description": "제목/요약뿐 아니라 본문까지 검색하고 일치 부분(snippet)을 반환"},
+			"semantic": {"type": "boolean", "description": "의미 검색: 'pal kb embed'로 만든 벡터 유사도와 키워드 점수를 섞어 순위를 매김"}
+		},
+		"required": ["query"]
+	}`),
+}
+
+var toolDocGet = Tool{
+	Name:        "doc_get",
+	Description: "문서를 가져옵니다. id는 프로젝트 문서 ID, path는 kb_search 결과의 KB 경로입니다. 기본은 요약과 목차만 반환하며 mode=full이면 max_tokens 안에서 본문을 반환합니다.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"id": {"type": "string", "description": "프로젝트 문서 ID (pal docs)"},
+			"path": {"type": "string", "description": "KB 문서 경로 (vault 기준 상대 경로)"},
+			"mode": {"type": "string", "enum": ["summary", "full"], "description": "반환 형태 (default: summary)"},
+			"max_tokens": {"type": "integer", "description": "본문 최대 토큰 (default: 4000)"}
+		}
+	}`),
+}
+
+// KBSearchItem is one kb_search hit
+type KBSearchItem struct {
+	Path       string   `json:"path"`
+	Title      string   `json:"title"`
+	Type       string   `json:"type,omitempty"`
+	Domain     string   `json:"domain,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+	Snippet    string   `json:"snippet,omitempty"`
+	Excerpt    string   `json:"excerpt,omitempty"`
+	Tokens     int      `json:"tokens"`
+}
+
+// KBSearchResult represents kb_search result
+type KBSearchResult struct {
+	Query       string         `json:"query"`
+	Items       []KBSearchItem `json:"items"`
+	TotalTokens int            `json:"total_tokens"`
+	Truncated   bool           `json:"truncated"` // 예산 때문에 결과/발췌가 잘렸는지
+}
+
+// DocGetResult represents doc_get result
+type DocGetResult struct {
+	Source    string   `json:"source"` // docs, kb
+	ID        string   `json:"id,omitempty"`
+	Path      string   `json:"path"`
+	Title     string   `json:"title,omitempty"`
+	Type      string   `json:"type,omitempty"`
+	Domain    string   `json:"domain,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Summary   string   `json:"summary,omitempty"`
+	Outline   []string `json:"outline,omitempty"`
+	Content   string   `json:"content,omitempty"`
+	Tokens    int      `json:"tokens"`       // 반환한 본문 토큰
+	Total     int      `json:"total_tokens"` // 원문 전체 토큰
+	Truncated bool     `json:"truncated"`
+}
+
+// SetVaultPath sets the Knowledge Base vault used by kb_search/doc_get
+func (s *Server) SetVaultPath(path string) {
+	s.vaultPath = path
+}
+
+// toolKBSearchHandler handles kb_search tool call
+func (s *Server) toolKBSearchHandler(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		Query         string   `json:"query"`
+		Type          string   `json:"type"`
+		Domain        string   `json:"domain"`
+		Status        string   `json:"status"`
+		Tags          []string `json:"tags"`
+		Limit         int      `json:"limit"`
+		TokenBudget   int      `json:"token_budget"`
+		ExcerptTokens *int     `json:"excerpt_tokens"`
+		Content       bool     `json:"content"`
+		Semantic      bool     `json:"semantic"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(params.Query) == "" {
+		return nil, fmt.Errorf("query가 필요합니다")
+	}
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.TokenBudget <= 0 {
+		params.TokenBudget = defaultKBSearchBudget
+	}
+	excerptTokens := defaultExcerptTokens
+	if params.ExcerptTokens != nil {
+		excerptTokens = *params.ExcerptTokens
+	}
+
+	indexSvc, err := s.openKBIndex()
+	if err != nil {
+		return nil, err
+	}
+	defer indexSvc.Close()
+
+	opts := &kb.SearchOptions{
+		Type:    params.Type,
+		Domain:  params.Domain,
+		Status:  params.Status,
+		Tags:    params.Tags,
+		Limit:   params.Limit,
+		Content: params.Content,
+	}
+	var hits []*kb.SearchResult
+	if params.Semantic {
+		cfg, err := indexSvc.EmbedConfig()
+		if err != nil {
+			return nil, err
+		}
+		if cfg == nil {
+			return nil, fmt.Errorf("KB 임베딩이 없습니다. 'pal kb embed --provider <provider>'를 먼저 실행하세요")
+		}
+		resolved, err := kb.ResolveEmbedConfig(*cfg)
+		if err != nil {
+			return nil, err
+		}
+		embedder, err := kb.NewEmbedder(resolved)
+		if err != nil {
+			return nil, err
+		}
+		hits, err = indexSvc.SemanticSearch(stdctx.Background(), embedder, params.Query, opts)
+	} else {
+		hits, err = indexSvc.Search(params.Query, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("검색 실패: %w", err)
+	}
+
+	result := &KBSearchResult{Query: params.Query, Items: []KBSearchItem{}}
+	remaining := params.TokenBudget
+	for _, hit := range hits {
+		doc := hit.Document
+		item := KBSearchItem{
+			Path:       doc.Path,
+			Title:      doc.Title,
+			Type:       doc.Type,
+			Domain:     doc.Domain,
+			Status:     doc.Status,
+			Tags:       doc.Tags,
+			Summary:    doc.Summary,
+			Highlights: hit.Highlights,
+			Snippet:    hit.Snippet,
+		}
+		item.Tokens = context.EstimateTokens(item.Path + item.Title + item.Summary + item.Snippet + strings.Join(item.Tags, " "))
+		if item.Tokens > remaining {
+			result.Truncated = true
+			break
+		}
+		remaining -= item.Tokens
+
+		if excerptTokens > 0 && remaining > 0 {
+			if content, err := os.ReadFile(filepath.Join(s.vaultPath, doc.Path)); err == nil {
+				limit := excerptTokens
+				if limit > remaining {
+					limit = remaining
+				}
+				excerpt, cut := trimToTokens(stripFrontmatter(string(content)), limit)
+				n := context.EstimateTokens(excerpt)
+				item.Excerpt = excerpt
+				item.Tokens += n
+				remaining -= n
+				if cut && limit < excerptTokens {
+					result.Truncated = true
+				}
+			}
+		}
+
+		result.Items = append(result.Items, item)
+		result.TotalTokens += item.Tokens
+	}
+
+	summary := []string{fmt.Sprintf("'%s' 검색 결과 %d건 (%d 토큰)", params.Query, len(result.Items), result.TotalTokens)}
+	links := make([]ResourceLink, 0, len(result.Items))
+	for _, item := range result.Items {
+		summary = append(summary, fmt.Sprintf("- %s (%s)", item.Title, item.Path))
+		links = append(links, ResourceLink{URI: "pal://kb/" + item.Path, Name: item.Title, Description: item.Summary, MimeType: "text/markdown"})
+	}
+	return &ToolResult{Summary: strings.Join(summary, "\n"), Data: result, Links: links}, nil
+}
+
+// toolDocGetHandler handles doc_get tool call
+func (s *Server) toolDocGetHandler(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		ID        string `json:"id"`
+		Path      string `json:"path"`
+		Mode      string `json:"mode"`
+		MaxTokens int    `json:"max_tokens"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	}
+	if params.ID == "" && params.Path == "" {
+		return nil, fmt.Errorf("id 또는 path가 필요합니다")
+	}
+	switch params.Mode {
+	case "":
+		params.Mode = "summary"
+	case "summary", "full":
+	default:
+		return nil, fmt.Errorf("잘못된 mode: %s (summary, full)", params.Mode)
+	}
+	if params.MaxTokens <= 0 {
+		params.MaxTokens = defaultDocGetMaxTokens
+	}
+
+	var result *DocGetResult
+	var content string
+	if params.ID != "" {
+		docSvc := document.NewService(s.database, s.projectRoot)
+		doc, err := docSvc.Get(params.ID)
+		if err != nil {
+			return nil, err
+		}
+		content, err = docSvc.GetContent(params.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = &DocGetResult{
+			Source: "docs",
+			ID:     doc.ID,
+			Path:   doc.Path,
+			Type:   doc.Type,
+			Domain: doc.Domain,
+			Status: doc.Status,
+			Tags:   doc.Tags,
+		}
+		if doc.Summary.Valid {
+			result.Summary = doc.Summary.String
+		}
+	} else {
+		full, err := s.kbFilePath(params.Path)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return nil, fmt.Errorf("KB 문서를 찾을 수 없습니다: %s", params.Path)
+		}
+		content = string(data)
+		result = &DocGetResult{Source: "kb", Path: filepath.ToSlash(params.Path)}
+
+		if indexSvc, err := s.openKBIndex(); err == nil {
+			if doc, err := indexSvc.GetByPath(result.Path); err == nil {
+				result.Title = doc.Title
+				result.Type = doc.Type
+				result.Domain = doc.Domain
+				result.Status = doc.Status
+				result.Tags = doc.Tags
+				result.Summary = doc.Summary
+			}
+			indexSvc.Close()
+		}
+	}
+
+	body := stripFrontmatter(content)
+	result.Total = context.EstimateTokens(body)
+	result.Outline = markdownOutline(body)
+	if result.Title == "" && len(result.Outline) > 0 {
+		result.Title = strings.TrimLeft(result.Outline[0], "# ")
+	}
+
+	if params.Mode == "full" {
+		result.Content, result.Truncated = trimToTokens(body, params.MaxTokens)
+		result.Tokens = context.EstimateTokens(result.Content)
+	} else {
+		result.Truncated = result.Total > 0
+	}
+
+	return result, nil
+}
+
+// openKBIndex opens the vault index (KB가 초기화되지 않았으면 오류)
+func (s *Server) openKBIndex() (*kb.IndexService, error) {
+	if s.vaultPath == "" {
+		return nil, fmt.Errorf("KB vault 경로가 설정되지 않았습니다 (pal mcp --vault)")
+	}
+	status, err := kb.NewService(s.vaultPath).Status()
+	if err != nil {
+		return nil, err
+	}
+	if !status.Initialized {
+		return nil, fmt.Errorf("KB가 초기화되지 않았습니다: %s ('pal kb init' 실행)", s.vaultPath)
+	}
+
+	indexSvc := kb.NewIndexService(s.vaultPath)
+	if err := indexSvc.Open(); err != nil {
+		return nil, err
+	}
+	return indexSvc, nil
+}
+
+// kbFilePath resolves a vault-relative path, rejecting paths that escape the vault
+func (s *Server) kbFilePath(rel string) (string, error) {
+	if s.vaultPath == "" {
+		return "", fmt.Errorf("KB vault 경로가 설정되지 않았습니다 (pal mcp --vault)")
+	}
+	clean := filepath.Clean(filepath.FromSlash(rel))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("잘못된 KB 경로: %s", rel)
+	}
+	return filepath.Join(s.vaultPath, clean), nil
+}
+
+// stripFrontmatter drops the leading YAML frontmatter of a markdown document
+func stripFrontmatter(content string) string {
+	if !strings.HasPrefix(content, "---") {
+		return content
+	}
+	parts := strings.SplitN(content, "---", 3)
+	if len(parts) < 3 {
+		return content
+	}
+	return strings.TrimLeft(parts[2], "\r\n")
+}
+
+// markdownOutline returns the headings of a markdown document (코드 블록 안은 제외)
+func markdownOutline(content string) []string {
+	var outline []string
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence && strings.HasPrefix(trimmed, "#") {
+			outline = append(outline, trimmed)
+		}
+	}
+	return outline
+}
+
+// trimToTokens cuts content at a line boundary so it fits maxTokens.
+// 첫 줄조차 넘치면 룬 단위로 자릅니다. 잘렸으면 true를 반환합니다.
+func trimToTokens(content string, maxTokens int) (string, bool) {
+	if context.EstimateTokens(content) <= maxTokens {
+		return content, false
+	}
+
+	// 줄 단위 추정치를 더하면 전체 추정치보다 약간 크게 잡혀 예산을 넘지 않습니다
+	var sb strings.Builder
+	used := 0
+	for _, line := range strings.SplitAfter(content, "\n") {
+		n := context.EstimateTokens(line) + 1
+		if used+n > maxTokens {
+			break
+		}
+		used += n
+		sb.WriteString(line)
+	}
+	if sb.Len() == 0 {
+		runes := []rune(content)
+		lo, hi := 0, len(runes)
+		for lo < hi {
+			mid := (lo + hi + 1) / 2
+			if context.EstimateTokens(string(runes[:mid])) <= maxTokens {
+				lo = mid
+			} else {
+				hi = mid - 1
+			}
+		}
+		return string(runes[:lo]), true
+	}
+	return strings.TrimRight(sb.String(), "\n"), true
+}
@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/context"
+)
+
+func TestTrimToTokens(t *testing.T) {
+	content := strings.Repeat("이 줄은 토큰 예산 테스트용 문장입니다.\n", 200)
+
+	trimmed, cut := trimToTokens(content, 100)
+	if !cut {
+		t.Fatal("예산을 넘는 본문이 잘리지 않음")
+	}
+	if n := context.EstimateTokens(trimmed); n > 100 {
+		t.Errorf("잘린 본문이 예산을 넘음: %d", n)
+	}
+	if strings.HasSuffix(trimmed, "\n") || !strings.HasSuffix(trimmed, ".") {
+		t.Errorf("줄 단위로 자르지 않음: %q", trimmed[len(trimmed)-10:])
+	}
+
+	if got, cut := trimToTokens("짧은 본문", 100); cut || got != "짧은 본문" {
+		t.Errorf("예산 안의 본문이 변경됨: %q", got)
+	}
+
+	// 한 줄이 예산보다 길면 룬 단위로 자름
+	long := strings.Repeat("가", 1000)
+	if got, cut := trimToTokens(long, 10); !cut || got == "" || context.EstimateTokens(got) > 10 {
+		t.Errorf("긴 줄 자르기 실패: %d runes", len([]rune(got)))
+	}
+}
+
+func TestKBFilePath(t *testing.T) {
+	s := &Server{vaultPath: "/vault"}
+
+	if p, err := s.kbFilePath("concepts/auth.md"); err != nil || p != "/vault/concepts/auth.md" {
+		t.Errorf("kbFilePath = %q, %v", p, err)
+	}
+	for _, bad := range []string{"../secret.md", "/etc/passwd", "a/../../b.md"} {
+		if _, err := s.kbFilePath(bad); err == nil {
+			t.Errorf("vault 밖 경로가 허용됨: %s", bad)
+		}
+	}
+}
+
+func TestMarkdownOutline(t *testing.T) {
+	md := "---\ntitle: x\n---\n# 제목\n본문\n```sh\n# 주석\n```\n## 소제목\n"
+	outline := markdownOutline(stripFrontmatter(md))
+	if len(outline) != 2 || outline[0] != "# 제목" || outline[1] != "## 소제목" {
+		t.Errorf("outline = %v", outline)
+	}
+}
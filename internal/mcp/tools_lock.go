@@ -0,0 +1,200 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/lock"
+)
+
+// Lock 도구: 워커 세션이 hook을 거치지 않고 MCP 채널로 파일 소유권을 조율합니다.
+
+var toolLockAcquire = Tool{
+	Name:        "lock_acquire",
+	Description: "리소스(파일 경로, 디렉토리, glob 패턴)에 Lock을 획득합니다. 이미 보유 중이면 TTL을 갱신하고, 다른 세션이 보유 중이면 acquired=false와 보유 세션을 반환합니다.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"resource": {"type": "string", "description": "리소스 (예: internal/api/handler.go, internal/api/, src/**/*.ts)"},
+			"session_id": {"type": "string", "description": "Lock을 보유할 세션 ID"},
+			"ttl": {"type": "string", "description": "만료 시간 (예: 10m, 1h). 생략하면 해제 또는 세션 종료까지 유지"}
+		},
+		"required": ["resource", "session_id"]
+	}`),
+}
+
+var toolLockRelease = Tool{
+	Name:        "lock_release",
+	Description: "보유 중인 Lock을 해제합니다. 다른 세션의 Lock은 해제할 수 없습니다.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"resource": {"type": "string", "description": "리소스"},
+			"session_id": {"type": "string", "description": "Lock을 보유한 세션 ID"}
+		},
+		"required": ["resource", "session_id"]
+	}`),
+}
+
+var toolLockStatus = Tool{
+	Name:        "lock_status",
+	Description: "활성 Lock과 대기 세션을 조회합니다. path를 주면 해당 파일을 덮는 Lock만 반환합니다.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"resource": {"type": "string", "description": "리소스 필터 (정확히 일치)"},
+			"path": {"type": "string", "description": "파일 경로 (이 파일을 덮는 Lock 조회)"},
+			"session_id": {"type": "string", "description": "보유 세션 필터"}
+		}
+	}`),
+}
+
+// LockInfo is a held lock as returned by the lock tools
+type LockInfo struct {
+	Resource   string     `json:"resource"`
+	SessionID  string     `json:"session_id"`
+	AcquiredAt time.Time  `json:"acquired_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Waiters    []string   `json:"waiters,omitempty"`
+}
+
+// LockAcquireResult represents lock_acquire result
+type LockAcquireResult struct {
+	Acquired bool      `json:"acquired"`
+	Lock     *LockInfo `json:"lock,omitempty"`
+	Holder   string    `json:"holder,omitempty"` // acquired=false일 때 보유 세션
+	Message  string    `json:"message"`
+}
+
+func toLockInfo(l lock.Lock) LockInfo {
+	return LockInfo{
+		Resource:   l.Resource,
+		SessionID:  l.SessionID,
+		AcquiredAt: l.AcquiredAt,
+		ExpiresAt:  l.ExpiresAt,
+	}
+}
+
+// toolLockAcquireHandler handles lock_acquire tool call
+func (s *Server) toolLockAcquireHandler(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		Resource  string `json:"resource"`
+		SessionID string `json:"session_id"`
+		TTL       string `json:"ttl"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	}
+	if params.Resource == "" || params.SessionID == "" {
+		return nil, fmt.Errorf("resource와 session_id가 필요합니다")
+	}
+
+	var ttl time.Duration
+	if params.TTL != "" {
+		d, err := time.ParseDuration(params.TTL)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("잘못된 TTL: %s (예: 10m, 1h)", params.TTL)
+		}
+		ttl = d
+	}
+
+	if _, err := s.sessionSvc.Get(params.SessionID); err != nil {
+		return nil, fmt.Errorf("세션을 찾을 수 없습니다: %s", params.SessionID)
+	}
+
+	l, err := s.lockSvc.AcquireTTL(params.Resource, params.SessionID, ttl)
+	if errcode.Is(err, errcode.KindConflict) {
+		holder, herr := s.lockSvc.Get(params.Resource)
+		if herr != nil {
+			return nil, err
+		}
+		// hook과 동일하게 대기를 기록해 경합 에스컬레이션 대상이 되도록 합니다
+		s.lockSvc.RecordWait(params.Resource, params.SessionID, holder.SessionID)
+		info := toLockInfo(*holder)
+		return &LockAcquireResult{
+			Acquired: false,
+			Lock:     &info,
+			Holder:   holder.SessionID,
+			Message:  fmt.Sprintf("'%s'는 세션 '%s'이 보유 중입니다", params.Resource, holder.SessionID),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info := toLockInfo(*l)
+	msg := fmt.Sprintf("Lock 획득: %s", params.Resource)
+	if l.ExpiresAt != nil {
+		msg += fmt.Sprintf(" (만료: %s)", l.ExpiresAt.Local().Format("15:04:05"))
+	}
+	return &LockAcquireResult{Acquired: true, Lock: &info, Message: msg}, nil
+}
+
+// toolLockReleaseHandler handles lock_release tool call
+func (s *Server) toolLockReleaseHandler(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		Resource  string `json:"resource"`
+		SessionID string `json:"session_id"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	}
+	if params.Resource == "" || params.SessionID == "" {
+		return nil, fmt.Errorf("resource와 session_id가 필요합니다")
+	}
+
+	if err := s.lockSvc.ReleaseBy(params.Resource, params.SessionID); err != nil {
+		return nil, err
+	}
+	return map[string]string{"status": "released", "resource": params.Resource}, nil
+}
+
+// toolLockStatusHandler handles lock_status tool call
+func (s *Server) toolLockStatusHandler(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		Resource  string `json:"resource"`
+		Path      string `json:"path"`
+		SessionID string `json:"session_id"`
+	}
+
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	locks, err := s.lockSvc.List()
+	if err != nil {
+		return nil, err
+	}
+	contentions, _ := s.lockSvc.Contentions()
+	waiters := make(map[string][]string)
+	for i := range contentions {
+		waiters[contentions[i].Resource] = contentions[i].WaiterIDs()
+	}
+
+	result := []LockInfo{}
+	for _, l := range locks {
+		if params.Resource != "" && l.Resource != params.Resource {
+			continue
+		}
+		if params.SessionID != "" && l.SessionID != params.SessionID {
+			continue
+		}
+		if params.Path != "" && !l.Covers(params.Path, s.projectRoot) {
+			continue
+		}
+		info := toLockInfo(l)
+		info.Waiters = waiters[l.Resource]
+		result = append(result, info)
+	}
+
+	return map[string]interface{}{
+		"locks": result,
+		"count": len(result),
+	}, nil
+}
@@ -0,0 +1,195 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/plan"
+	"github.com/n0roo/pal-kit/internal/port"
+)
+
+// 계획 도구: 목표를 포트로 분해한 계획을 검증하고 초안 Orchestration으로 저장합니다.
+// 분해는 호출한 에이전트가 ports로 넘기는 것이 기본이고, 생략하면 목표 텍스트의 섹션/목록으로 추정합니다.
+
+var toolPortPlan = Tool{
+	Name:        "port_plan",
+	Description: "목표를 Atomic Port로 분해한 계획을 검증하고 포트와 초안(pending) Orchestration으로 저장합니다. ports를 생략하면 목표의 섹션이나 목록 항목으로 분해합니다. dry_run이면 검증만 합니다.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"goal": {"type": "string", "description": "목표 (자유 텍스트 또는 마크다운)"},
+			"title": {"type": "string", "description": "계획 제목 (default: 목표 첫 줄)"},
+			"prefix": {"type": "string", "description": "자동 분해 시 포트 ID 접두사"},
+			"ports": {
+				"type": "array",
+				"description": "에이전트가 분해한 포트 (생략하면 자동 분해)",
+				"items": {
+					"type": "object",
+					"properties": {
+						"id": {"type": "string"},
+						"title": {"type": "string"},
+						"description": {"type": "string"},
+						"acceptance": {"type": "array", "items": {"type": "string"}},
+						"depends_on": {"type": "array", "items": {"type": "string"}},
+						"estimate": {"type": "object", "properties": {"tokens": {"type": "integer"}, "hours": {"type": "number"}}}
+					},
+					"required": ["id", "title"]
+				}
+			},
+			"constraints": {
+				"type": "object",
+				"description": "계획 제약 (위반하면 저장하지 않음)",
+				"properties": {
+					"max_ports": {"type": "integer", "description": "최대 포트 수"},
+					"max_port_tokens": {"type": "integer", "description": "포트당 추정 토큰 상한"},
+					"max_port_hours": {"type": "number", "description": "포트당 추정 시간 상한"},
+					"max_hours": {"type": "number", "description": "전체 추정 시간 상한"}
+				}
+			},
+			"default_estimate": {"type": "object", "description": "추정치가 없는 포트에 적용", "properties": {"tokens": {"type": "integer"}, "hours": {"type": "number"}}},
+			"dry_run": {"type": "boolean", "description": "검증만 하고 저장하지 않음"}
+		},
+		"required": ["goal"]
+	}`),
+}
+
+// PlannedPortInfo is a port of a port_plan result in execution order
+type PlannedPortInfo struct {
+	Order       int            `json:"order"`
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Acceptance  []string       `json:"acceptance,omitempty"`
+	DependsOn   []string       `json:"depends_on,omitempty"`
+	Estimate    *plan.Estimate `json:"estimate,omitempty"`
+	Spec        string         `json:"spec"`
+}
+
+// PortPlanResult represents port_plan result
+type PortPlanResult struct {
+	Status          string            `json:"status"` // valid (dry_run), draft
+	Title           string            `json:"title"`
+	Ports           []PlannedPortInfo `json:"ports"`
+	Total           plan.Estimate     `json:"total_estimate"`
+	Decomposed      bool              `json:"decomposed"` // ports를 생략해 목표에서 자동 분해했는지
+	OrchestrationID string            `json:"orchestration_id,omitempty"`
+	SpecsCreated    int               `json:"specs_created,omitempty"`
+	Message         string            `json:"message"`
+}
+
+// toolPortPlanHandler handles port_plan tool call
+func (s *Server) toolPortPlanHandler(args json.RawMessage) (interface{}, error) {
+	var params struct {
+		Goal            string             `json:"goal"`
+		Title           string             `json:"title"`
+		Prefix          string             `json:"prefix"`
+		Ports           []plan.PlannedPort `json:"ports"`
+		Constraints     plan.Constraints   `json:"constraints"`
+		DefaultEstimate plan.Estimate      `json:"default_estimate"`
+		DryRun          bool               `json:"dry_run"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(params.Goal) == "" {
+		return nil, fmt.Errorf("goal이 필요합니다")
+	}
+
+	var p *plan.Plan
+	if len(params.Ports) > 0 {
+		p = &plan.Plan{Title: params.Title, Ports: params.Ports}
+		if p.Title == "" {
+			p.Title = strings.TrimSpace(strings.SplitN(strings.TrimSpace(params.Goal), "\n", 2)[0])
+		}
+	} else {
+		decomposed, err := plan.FromGoal(params.Goal, plan.Options{Prefix: params.Prefix})
+		if err != nil {
+			return nil, err
+		}
+		p = decomposed
+		if params.Title != "" {
+			p.Title = params.Title
+		}
+	}
+	p.Source = strings.TrimSpace(params.Goal)
+	p.Normalize()
+	p.FillEstimates(params.DefaultEstimate)
+
+	if err := p.CheckConstraints(params.Constraints); err != nil {
+		return nil, err
+	}
+	portSvc := port.NewService(s.database)
+	if err := p.Validate(func(id string) bool {
+		_, err := portSvc.Get(id)
+		return err == nil
+	}); err != nil {
+		return nil, err
+	}
+	ordered, err := p.Order()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PortPlanResult{
+		Status:     "valid",
+		Title:      p.Title,
+		Total:      p.TotalEstimate(),
+		Decomposed: len(params.Ports) == 0,
+	}
+	for i, pp := range ordered {
+		result.Ports = append(result.Ports, PlannedPortInfo{
+			Order:       i + 1,
+			ID:          pp.ID,
+			Title:       pp.Title,
+			Description: pp.Description,
+			Acceptance:  pp.Acceptance,
+			DependsOn:   pp.DependsOn,
+			Estimate:    pp.Estimate,
+			Spec:        pp.Spec,
+		})
+	}
+
+	if params.DryRun {
+		result.Message = fmt.Sprintf("계획 검증 완료: 포트 %d개 (저장하지 않음)", len(ordered))
+		return result, nil
+	}
+
+	imported, err := plan.Import(s.database, p, plan.ImportOptions{Handoffs: s.hoStore, Orchestration: true})
+	if err != nil {
+		return nil, err
+	}
+	result.Status = "draft"
+	result.OrchestrationID = imported.Orchestration.ID
+	result.SpecsCreated = s.writePlannedSpecs(ordered)
+	result.Message = fmt.Sprintf("포트 %d개와 초안 Orchestration '%s'을(를) 생성했습니다 (orchestration_status로 확인)",
+		len(imported.Ports), imported.Orchestration.ID)
+	return result, nil
+}
+
+// writePlannedSpecs writes a minimal spec for planned ports whose spec file does not exist yet
+func (s *Server) writePlannedSpecs(ports []plan.PlannedPort) int {
+	if s.projectRoot == "" {
+		return 0
+	}
+	created := 0
+	for _, pp := range ports {
+		path := pp.Spec
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(s.projectRoot, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			continue
+		}
+		if os.WriteFile(path, []byte(plan.SpecDocument(pp)), 0644) == nil {
+			created++
+		}
+	}
+	return created
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+)
+
+func TestPortPlan(t *testing.T) {
+	root := t.TempDir()
+	s := newTestServer(t, root)
+
+	explicit := `{"goal":"결제 기능","ports":[
+		{"id":"pay-api","title":"Payment API","depends_on":["pay-schema"],"estimate":{"hours":3}},
+		{"id":"pay-schema","title":"Payment schema"}],
+		"default_estimate":{"hours":1},"constraints":{"max_port_hours":2}}`
+	if _, err := callTool(t, s.toolPortPlanHandler, explicit); err == nil {
+		t.Error("포트당 시간 제약 위반이 허용됨")
+	}
+
+	res, err := callTool(t, s.toolPortPlanHandler, `{"goal":"결제 기능","dry_run":true,"ports":[
+		{"id":"pay-api","title":"Payment API","depends_on":["pay-schema"]},
+		{"id":"pay-schema","title":"Payment schema"}],"default_estimate":{"hours":1}}`)
+	if err != nil {
+		t.Fatalf("dry_run 실패: %v", err)
+	}
+	dry := res.(*PortPlanResult)
+	if dry.Status != "valid" || dry.OrchestrationID != "" || dry.Ports[0].ID != "pay-schema" || dry.Total.Hours != 2 {
+		t.Errorf("dry_run 결과 = %+v", dry)
+	}
+	if _, err := s.toolPortPlanHandler([]byte(`{"goal":"x","ports":[{"id":"a","title":"A","depends_on":["b"]},{"id":"b","title":"B","depends_on":["a"]}]}`)); err == nil {
+		t.Error("순환 의존성이 허용됨")
+	}
+
+	res, err = callTool(t, s.toolPortPlanHandler, "{\"goal\":\"Team billing\\n\\n- Billing schema\\n- Billing API\",\"prefix\":\"bill\"}")
+	if err != nil {
+		t.Fatalf("자동 분해 실패: %v", err)
+	}
+	draft := res.(*PortPlanResult)
+	if draft.Status != "draft" || !draft.Decomposed || len(draft.Ports) != 2 || draft.Ports[1].DependsOn[0] != "bill-01" {
+		t.Fatalf("계획 결과 = %+v", draft)
+	}
+
+	op, err := s.orchSvc.GetOrchestration(draft.OrchestrationID)
+	if err != nil {
+		t.Fatalf("Orchestration 조회 실패: %v", err)
+	}
+	if op.Status != orchestrator.StatusPending || len(op.AtomicPorts) != 2 || op.AtomicPorts[0].PortID != "bill-01" {
+		t.Errorf("초안 Orchestration = %+v", op)
+	}
+	if _, err := os.Stat(filepath.Join(root, "ports", "bill-02.md")); err != nil || draft.SpecsCreated != 2 {
+		t.Errorf("명세 생성 = %d, %v", draft.SpecsCreated, err)
+	}
+
+	// 같은 ID로 다시 계획하면 거부
+	if _, err := callTool(t, s.toolPortPlanHandler, "{\"goal\":\"Team billing\\n\\n- Billing schema\",\"prefix\":\"bill\"}"); err == nil {
+		t.Error("기존 포트 ID가 허용됨")
+	}
+}
@@ -32,6 +32,8 @@ const (
 	SubtypeTestFail     MessageSubtype = "test_fail"
 	SubtypeFixRequest   MessageSubtype = "fix_request"
 	SubtypeProgress     MessageSubtype = "progress"
+	SubtypeWorkerStalled MessageSubtype = "worker_stalled" // 워커 무응답 (heartbeat)
+	SubtypeEscalationUpdate MessageSubtype = "escalation_update" // 에스컬레이션 담당/댓글/해결 알림
 
 	// Review message subtypes (L2-agent-reviewer)
 	SubtypeReviewRequest  MessageSubtype = "review_request"
@@ -77,6 +79,7 @@ type TaskAssignPayload struct {
 	PortSpec    string   `json:"port_spec"`
 	Conventions []string `json:"conventions,omitempty"`
 	Context     string   `json:"context,omitempty"`
+	ChannelID   string   `json:"channel_id,omitempty"` // Impl/Test 워커 쌍의 직접 채널
 }
 
 // TaskReportPayload is the payload for task reports
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+// HookStat is the accumulated invocation count and latency of one hook type.
+// hook은 매번 별도 프로세스로 실행되므로 서버 메모리가 아닌 DB에 누적합니다.
+type HookStat struct {
+	Hook        string   `json:"hook"`
+	Invocations int64    `json:"invocations"`
+	Errors      int64    `json:"errors"`
+	DurationSum float64  `json:"duration_sum"` // 초
+	Buckets     []uint64 `json:"buckets"`      // DefaultBuckets 순서, 마지막은 +Inf
+}
+
+// RecordHook adds one hook invocation to hook_metrics
+func RecordHook(database *db.DB, hook string, elapsed time.Duration, failed bool) error {
+	tx, err := database.Begin()
+	if err != nil {
+		return fmt.Errorf("hook 메트릭 기록 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	var raw string
+	err = tx.QueryRow(`SELECT buckets FROM hook_metrics WHERE hook = ?`, hook).Scan(&raw)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("hook 메트릭 조회 실패: %w", err)
+	}
+	buckets := decodeBuckets(raw)
+
+	seconds := elapsed.Seconds()
+	buckets[BucketIndex(seconds)]++
+	encoded, _ := json.Marshal(buckets)
+
+	errInc := 0
+	if failed {
+		errInc = 1
+	}
+	_, err = tx.Exec(`
+		INSERT INTO hook_metrics (hook, invocations, errors, duration_sum, buckets, updated_at)
+		VALUES (?, 1, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(hook) DO UPDATE SET
+			invocations = invocations + 1,
+			errors = errors + excluded.errors,
+			duration_sum = duration_sum + excluded.duration_sum,
+			buckets = excluded.buckets,
+			updated_at = CURRENT_TIMESTAMP
+	`, hook, errInc, seconds, string(encoded))
+	if err != nil {
+		return fmt.Errorf("hook 메트릭 기록 실패: %w", err)
+	}
+	return tx.Commit()
+}
+
+// HookStats returns the accumulated stats of every recorded hook type
+func HookStats(database *db.DB) ([]HookStat, error) {
+	rows, err := database.Query(`
+		SELECT hook, invocations, errors, duration_sum, COALESCE(buckets, '[]')
+		FROM hook_metrics ORDER BY hook
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("hook 메트릭 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []HookStat{}
+	for rows.Next() {
+		var st HookStat
+		var raw string
+		if err := rows.Scan(&st.Hook, &st.Invocations, &st.Errors, &st.DurationSum, &raw); err != nil {
+			return nil, err
+		}
+		st.Buckets = decodeBuckets(raw)
+		stats = append(stats, st)
+	}
+	return stats, rows.Err()
+}
+
+// decodeBuckets parses stored bucket counts, padding to DefaultBuckets + Inf
+func decodeBuckets(raw string) []uint64 {
+	buckets := make([]uint64, len(DefaultBuckets)+1)
+	var stored []uint64
+	if raw != "" && json.Unmarshal([]byte(raw), &stored) == nil {
+		copy(buckets, stored)
+	}
+	return buckets
+}
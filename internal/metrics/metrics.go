@@ -0,0 +1,260 @@
+// Package metrics renders counters, gauges and histograms in the Prometheus text exposition format.
+// 외부 클라이언트 라이브러리 없이 /metrics 엔드포인트에 필요한 최소 기능만 제공합니다.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are latency buckets in seconds (1ms ~ 10s)
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Labels are metric label name/value pairs
+type Labels map[string]string
+
+// key renders labels in a stable order (이름순)
+func (l Labels) key() string {
+	if len(l) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(l))
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + `="` + escapeLabel(l[name]) + `"`
+	}
+	return strings.Join(parts, ",")
+}
+
+func escapeLabel(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	return strings.ReplaceAll(v, "\n", `\n`)
+}
+
+type metricType string
+
+const (
+	typeCounter   metricType = "counter"
+	typeGauge     metricType = "gauge"
+	typeHistogram metricType = "histogram"
+)
+
+// family is one named metric with its labeled series
+type family struct {
+	name    string
+	help    string
+	typ     metricType
+	buckets []float64
+
+	values map[string]float64    // counter/gauge: label key -> value
+	hists  map[string]*histogram // histogram: label key -> series
+}
+
+type histogram struct {
+	counts []uint64 // 버킷별 누적 아님 (렌더링 시 누적)
+	sum    float64
+	count  uint64
+}
+
+// Registry holds metric families
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+	order    []string
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+func (r *Registry) family(name, help string, typ metricType, buckets []float64) *family {
+	if f, ok := r.families[name]; ok {
+		return f
+	}
+	f := &family{name: name, help: help, typ: typ, buckets: buckets,
+		values: make(map[string]float64), hists: make(map[string]*histogram)}
+	r.families[name] = f
+	r.order = append(r.order, name)
+	return f
+}
+
+// Describe registers a family ahead of time so it is exported even without samples
+func (r *Registry) Describe(name, help, typ string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var buckets []float64
+	if metricType(typ) == typeHistogram {
+		buckets = DefaultBuckets
+	}
+	r.family(name, help, metricType(typ), buckets)
+}
+
+// Add increments a counter
+func (r *Registry) Add(name, help string, labels Labels, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.family(name, help, typeCounter, nil).values[labels.key()] += delta
+}
+
+// Set sets a gauge
+func (r *Registry) Set(name, help string, labels Labels, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.family(name, help, typeGauge, nil).values[labels.key()] = value
+}
+
+// SetCounter sets a counter to an absolute value read from storage
+func (r *Registry) SetCounter(name, help string, labels Labels, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.family(name, help, typeCounter, nil).values[labels.key()] = value
+}
+
+// Observe records a histogram sample using DefaultBuckets
+func (r *Registry) Observe(name, help string, labels Labels, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f := r.family(name, help, typeHistogram, DefaultBuckets)
+	key := labels.key()
+	h, ok := f.hists[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(f.buckets))}
+		f.hists[key] = h
+	}
+	h.observe(f.buckets, value, 1)
+}
+
+// SetHistogram replaces a histogram series with stored bucket counts (버킷별, 누적 아님)
+func (r *Registry) SetHistogram(name, help string, labels Labels, counts []uint64, sum float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f := r.family(name, help, typeHistogram, DefaultBuckets)
+	h := &histogram{counts: make([]uint64, len(f.buckets)), sum: sum}
+	for i := range h.counts {
+		if i < len(counts) {
+			h.counts[i] = counts[i]
+			h.count += counts[i]
+		}
+	}
+	// +Inf 버킷 (DefaultBuckets 범위를 넘는 샘플)
+	if len(counts) > len(f.buckets) {
+		h.count += counts[len(f.buckets)]
+	}
+	f.hists[labels.key()] = h
+}
+
+// Reset drops all series of a family (스크레이프마다 다시 채우는 gauge용)
+func (r *Registry) Reset(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.families[name]; ok {
+		f.values = make(map[string]float64)
+		f.hists = make(map[string]*histogram)
+	}
+}
+
+func (h *histogram) observe(buckets []float64, value float64, n uint64) {
+	h.sum += value * float64(n)
+	h.count += n
+	for i, le := range buckets {
+		if value <= le {
+			h.counts[i] += n
+			return
+		}
+	}
+}
+
+// BucketIndex returns the bucket a value falls into; len(DefaultBuckets) means +Inf
+func BucketIndex(value float64) int {
+	for i, le := range DefaultBuckets {
+		if value <= le {
+			return i
+		}
+	}
+	return len(DefaultBuckets)
+}
+
+// WriteText writes all families in the Prometheus text exposition format (version 0.0.4)
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range r.order {
+		f := r.families[name]
+		if f.help != "" {
+			if _, err := fmt.Fprintf(w, "# HELP %s %s\n", f.name, f.help); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.typ)
+
+		if f.typ == typeHistogram {
+			for _, key := range sortedKeys(f.hists) {
+				f.writeHistogram(w, key, f.hists[key])
+			}
+			continue
+		}
+		for _, key := range sortedKeys(f.values) {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, braces(key), formatFloat(f.values[key]))
+		}
+	}
+	return nil
+}
+
+func (f *family) writeHistogram(w io.Writer, key string, h *histogram) {
+	var cumulative uint64
+	for i, le := range f.buckets {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, braces(joinLabels(key, `le="`+formatFloat(le)+`"`)), cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, braces(joinLabels(key, `le="+Inf"`)), h.count)
+	fmt.Fprintf(w, "%s_sum%s %s\n", f.name, braces(key), formatFloat(h.sum))
+	fmt.Fprintf(w, "%s_count%s %d\n", f.name, braces(key), h.count)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinLabels(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + "," + b
+}
+
+func braces(key string) string {
+	if key == "" {
+		return ""
+	}
+	return "{" + key + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func TestWriteText(t *testing.T) {
+	reg := NewRegistry()
+	reg.Describe("pal_empty_total", "Declared without samples", "counter")
+	reg.Add("pal_calls_total", "Calls", Labels{"hook": "stop"}, 1)
+	reg.Add("pal_calls_total", "", Labels{"hook": "stop"}, 2)
+	reg.Set("pal_active", "Active", nil, 3)
+	reg.Set("pal_label", "", Labels{"path": `C:\a "b"`}, 1)
+	reg.Observe("pal_latency_seconds", "Latency", Labels{"op": "query"}, 0.003)
+	reg.Observe("pal_latency_seconds", "", Labels{"op": "query"}, 20)
+
+	var b strings.Builder
+	if err := reg.WriteText(&b); err != nil {
+		t.Fatalf("WriteText 실패: %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"# TYPE pal_empty_total counter\n",
+		"# HELP pal_calls_total Calls\n",
+		`pal_calls_total{hook="stop"} 3` + "\n",
+		"pal_active 3\n",
+		`pal_label{path="C:\\a \"b\""} 1` + "\n",
+		`pal_latency_seconds_bucket{op="query",le="0.001"} 0` + "\n",
+		`pal_latency_seconds_bucket{op="query",le="0.005"} 1` + "\n",
+		`pal_latency_seconds_bucket{op="query",le="10"} 1` + "\n",
+		`pal_latency_seconds_bucket{op="query",le="+Inf"} 2` + "\n",
+		`pal_latency_seconds_sum{op="query"} 20.003` + "\n",
+		`pal_latency_seconds_count{op="query"} 2` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("출력에 %q 없음:\n%s", want, out)
+		}
+	}
+}
+
+func TestRecordHook(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	defer database.Close()
+
+	RecordHook(database, "pre-tool-use", 2*time.Millisecond, false)
+	RecordHook(database, "pre-tool-use", 30*time.Second, true)
+	RecordHook(database, "stop", 40*time.Millisecond, false)
+
+	stats, err := HookStats(database)
+	if err != nil {
+		t.Fatalf("HookStats 실패: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("hook 종류 = %d, want 2", len(stats))
+	}
+	pre := stats[0]
+	if pre.Hook != "pre-tool-use" || pre.Invocations != 2 || pre.Errors != 1 {
+		t.Errorf("pre-tool-use 집계 = %+v", pre)
+	}
+	if pre.Buckets[BucketIndex(0.002)] != 1 || pre.Buckets[len(DefaultBuckets)] != 1 {
+		t.Errorf("버킷 = %v", pre.Buckets)
+	}
+
+	reg := NewRegistry()
+	reg.SetHistogram("h", "", nil, pre.Buckets, pre.DurationSum)
+	var b strings.Builder
+	reg.WriteText(&b)
+	if !strings.Contains(b.String(), `h_bucket{le="+Inf"} 2`) || !strings.Contains(b.String(), `h_bucket{le="10"} 1`) {
+		t.Errorf("저장된 히스토그램 출력:\n%s", b.String())
+	}
+}
@@ -0,0 +1,206 @@
+// Package notify forwards Claude Code notifications to external sinks.
+// 라우팅 규칙은 .pal/config.yaml의 notifications 섹션에서 설정합니다.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+)
+
+// Sink names
+const (
+	SinkDesktop = "desktop"
+	SinkWebhook = "webhook"
+	SinkFile    = "file"
+)
+
+// MatchAll matches every notification type
+const MatchAll = "*"
+
+// TypeLockContention is sent when lock contention is escalated to a human
+const TypeLockContention = "lock_contention"
+
+// DefaultFilePath is the file sink path when none is configured
+const DefaultFilePath = ".pal/notifications.log"
+
+// webhookTimeout keeps hooks from blocking on slow endpoints
+const webhookTimeout = 3 * time.Second
+
+// Notification is a single notification forwarded to sinks
+type Notification struct {
+	Type        string    `json:"type"`
+	Message     string    `json:"message"`
+	SessionID   string    `json:"session_id,omitempty"`
+	ProjectRoot string    `json:"project_root,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Router dispatches notifications according to a routing table
+type Router struct {
+	cfg         config.NotificationConfig
+	projectRoot string
+	client      *http.Client
+}
+
+// NewRouter creates a router for a project
+func NewRouter(cfg config.NotificationConfig, projectRoot string) *Router {
+	return &Router{
+		cfg:         cfg,
+		projectRoot: projectRoot,
+		client:      &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// SinksFor returns the sinks matching a notification type (중복 제거, 설정 순서 유지)
+func (r *Router) SinksFor(notificationType string) []string {
+	seen := make(map[string]bool)
+	var sinks []string
+	for _, route := range r.cfg.Routes {
+		if route.Match != "" && route.Match != MatchAll && route.Match != notificationType {
+			continue
+		}
+		for _, sink := range route.Sinks {
+			if !seen[sink] {
+				seen[sink] = true
+				sinks = append(sinks, sink)
+			}
+		}
+	}
+	return sinks
+}
+
+// Dispatch sends a notification to every matching sink.
+// 한 싱크의 실패가 다른 싱크 전송을 막지 않도록 오류를 모아서 반환합니다.
+func (r *Router) Dispatch(n Notification) []error {
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+
+	var errs []error
+	for _, sink := range r.SinksFor(n.Type) {
+		var err error
+		switch sink {
+		case SinkDesktop:
+			err = sendDesktop(n)
+		case SinkWebhook:
+			err = r.sendWebhook(n)
+		case SinkFile:
+			err = r.appendFile(n)
+		default:
+			err = fmt.Errorf("알 수 없는 알림 싱크: %s", sink)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink, err))
+		}
+	}
+	return errs
+}
+
+// FilePath returns the resolved file sink path
+func (r *Router) FilePath() string {
+	path := r.cfg.File.Path
+	if path == "" {
+		path = DefaultFilePath
+	}
+	if !filepath.IsAbs(path) && r.projectRoot != "" {
+		path = filepath.Join(r.projectRoot, path)
+	}
+	return path
+}
+
+func (r *Router) appendFile(n Notification) error {
+	path := r.FilePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("알림 파일 열기 실패: %w", err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+func (r *Router) sendWebhook(n Notification) error {
+	if r.cfg.Webhook.URL == "" {
+		return fmt.Errorf("webhook url이 설정되지 않았습니다")
+	}
+
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, r.cfg.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook 요청 생성 실패: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range r.cfg.Webhook.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook 전송 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook 응답 오류: %s", resp.Status)
+	}
+	return nil
+}
+
+// sendDesktop shows an OS notification (macOS: osascript, Linux: notify-send)
+func sendDesktop(n Notification) error {
+	title := "Claude Code"
+	if n.Type != "" {
+		title = fmt.Sprintf("Claude Code (%s)", n.Type)
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(n.Message), appleScriptString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux":
+		cmd = exec.Command("notify-send", title, n.Message)
+	default:
+		return fmt.Errorf("데스크톱 알림을 지원하지 않는 OS: %s", runtime.GOOS)
+	}
+	return cmd.Run()
+}
+
+// appleScriptString quotes a string literal for AppleScript
+func appleScriptString(s string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case '\n':
+			buf.WriteByte(' ')
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
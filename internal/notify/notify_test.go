@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/config"
+)
+
+func TestSinksFor(t *testing.T) {
+	r := NewRouter(config.NotificationConfig{
+		Routes: []config.NotificationRoute{
+			{Match: "permission_prompt", Sinks: []string{SinkDesktop, SinkWebhook}},
+			{Match: MatchAll, Sinks: []string{SinkFile, SinkDesktop}},
+		},
+	}, "")
+
+	tests := []struct {
+		typ  string
+		want []string
+	}{
+		{"permission_prompt", []string{SinkDesktop, SinkWebhook, SinkFile}},
+		{"idle_prompt", []string{SinkFile, SinkDesktop}},
+	}
+	for _, tt := range tests {
+		if got := r.SinksFor(tt.typ); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SinksFor(%q) = %v, want %v", tt.typ, got, tt.want)
+		}
+	}
+
+	empty := NewRouter(config.NotificationConfig{}, "")
+	if got := empty.SinksFor("idle_prompt"); len(got) != 0 {
+		t.Errorf("SinksFor without routes = %v, want none", got)
+	}
+}
+
+func TestDispatchFileAndWebhook(t *testing.T) {
+	root := t.TempDir()
+
+	var received Notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewDecoder(req.Body).Decode(&received)
+	}))
+	defer srv.Close()
+
+	r := NewRouter(config.NotificationConfig{
+		Routes: []config.NotificationRoute{
+			{Match: "idle_prompt", Sinks: []string{SinkFile, SinkWebhook}},
+		},
+		Webhook: config.WebhookSinkConfig{URL: srv.URL, Headers: map[string]string{"X-Token": "secret"}},
+	}, root)
+
+	errs := r.Dispatch(Notification{Type: "idle_prompt", Message: "입력 대기 중"})
+	if len(errs) != 0 {
+		t.Fatalf("Dispatch errors: %v", errs)
+	}
+
+	if received.Message != "입력 대기 중" {
+		t.Errorf("webhook message = %q", received.Message)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, DefaultFilePath))
+	if err != nil {
+		t.Fatalf("read file sink: %v", err)
+	}
+	if !strings.Contains(string(data), `"type":"idle_prompt"`) {
+		t.Errorf("file sink content = %s", data)
+	}
+
+	// 매칭되지 않는 타입은 전송하지 않음
+	if errs := r.Dispatch(Notification{Type: "permission_prompt", Message: "x"}); len(errs) != 0 {
+		t.Errorf("unmatched Dispatch errors: %v", errs)
+	}
+	if data2, _ := os.ReadFile(filepath.Join(root, DefaultFilePath)); len(data2) != len(data) {
+		t.Error("unmatched notification was written to file sink")
+	}
+}
+
+func TestDispatchWebhookWithoutURL(t *testing.T) {
+	r := NewRouter(config.NotificationConfig{
+		Routes: []config.NotificationRoute{{Match: MatchAll, Sinks: []string{SinkWebhook, "pager"}}},
+	}, "")
+	if errs := r.Dispatch(Notification{Type: "idle_prompt"}); len(errs) != 2 {
+		t.Errorf("Dispatch errors = %v, want 2", errs)
+	}
+}
@@ -0,0 +1,269 @@
+package operator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/i18n"
+	"github.com/n0roo/pal-kit/internal/port"
+)
+
+// BriefingState is a compact snapshot of the last briefing, used to compute deltas.
+// Hash는 이전 Hash와 현재 상태를 함께 해싱한 체인 값입니다.
+type BriefingState struct {
+	Hash        string            `json:"hash"`
+	PrevHash    string            `json:"prev_hash,omitempty"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Ports       map[string]string `json:"ports"`       // port id → status (running, pending, blocked)
+	Escalations []int64           `json:"escalations"` // open escalation ids
+}
+
+// BriefingDelta holds what changed since the previous briefing
+type BriefingDelta struct {
+	Since               time.Time     `json:"since"`
+	PrevHash            string        `json:"prev_hash"`
+	Hash                string        `json:"hash"`
+	NewEscalations      []Escalation  `json:"new_escalations,omitempty"`
+	ResolvedEscalations []int64       `json:"resolved_escalations,omitempty"`
+	CompletedPorts      []PortSummary `json:"completed_ports,omitempty"`
+	BlockedPorts        []PortSummary `json:"blocked_ports,omitempty"`
+	StartedPorts        []PortSummary `json:"started_ports,omitempty"`
+	NewPorts            []PortSummary `json:"new_ports,omitempty"`
+}
+
+// IsEmpty reports whether nothing changed since the previous briefing
+func (d *BriefingDelta) IsEmpty() bool {
+	return len(d.NewEscalations) == 0 && len(d.ResolvedEscalations) == 0 &&
+		len(d.CompletedPorts) == 0 && len(d.BlockedPorts) == 0 &&
+		len(d.StartedPorts) == 0 && len(d.NewPorts) == 0
+}
+
+// GetBriefingStatePath returns the path to the briefing state file
+func (s *Service) GetBriefingStatePath() string {
+	return filepath.Join(s.projectRoot, ".pal", "context", "briefing-state.json")
+}
+
+// LoadBriefingState loads the previous briefing state (없으면 nil)
+func (s *Service) LoadBriefingState() (*BriefingState, error) {
+	data, err := os.ReadFile(s.GetBriefingStatePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("브리핑 상태 읽기 실패: %w", err)
+	}
+
+	var st BriefingState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("브리핑 상태 파싱 실패: %w", err)
+	}
+	return &st, nil
+}
+
+// WriteBriefingState writes the briefing state to .pal/context/briefing-state.json
+func (s *Service) WriteBriefingState(st *BriefingState) error {
+	path := s.GetBriefingStatePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// GenerateBriefingDelta compares a briefing with the previous state.
+// 이전 상태가 없으면 delta는 nil이며, 변경이 없으면 이전 상태를 그대로 반환합니다(체인 유지).
+func (s *Service) GenerateBriefingDelta(b *Briefing) (*BriefingDelta, *BriefingState, error) {
+	prev, err := s.LoadBriefingState()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	portSvc := port.NewService(s.db)
+	blocked, _ := portSvc.List(port.StatusBlocked, 20)
+
+	current := &BriefingState{
+		GeneratedAt: b.GeneratedAt,
+		Ports:       make(map[string]string),
+	}
+	titles := make(map[string]string)
+	for _, p := range b.RunningPorts {
+		current.Ports[p.ID] = p.Status
+		titles[p.ID] = p.Title
+	}
+	for _, p := range b.PendingPorts {
+		current.Ports[p.ID] = p.Status
+		titles[p.ID] = p.Title
+	}
+	for _, p := range blocked {
+		current.Ports[p.ID] = p.Status
+		titles[p.ID] = p.ID
+		if p.Title.Valid {
+			titles[p.ID] = p.Title.String
+		}
+	}
+	for _, e := range b.Escalations {
+		current.Escalations = append(current.Escalations, e.ID)
+	}
+	sort.Slice(current.Escalations, func(i, j int) bool { return current.Escalations[i] < current.Escalations[j] })
+
+	if prev == nil {
+		current.Hash = chainHash("", current)
+		return nil, current, nil
+	}
+
+	current.PrevHash = prev.Hash
+	current.Hash = chainHash(prev.Hash, current)
+
+	delta := &BriefingDelta{
+		Since:    prev.GeneratedAt,
+		PrevHash: prev.Hash,
+		Hash:     current.Hash,
+	}
+
+	// 에스컬레이션 변화
+	prevEsc := make(map[int64]bool)
+	for _, id := range prev.Escalations {
+		prevEsc[id] = true
+	}
+	curEsc := make(map[int64]bool)
+	for _, e := range b.Escalations {
+		curEsc[e.ID] = true
+		if !prevEsc[e.ID] {
+			delta.NewEscalations = append(delta.NewEscalations, e)
+		}
+	}
+	for _, id := range prev.Escalations {
+		if !curEsc[id] {
+			delta.ResolvedEscalations = append(delta.ResolvedEscalations, id)
+		}
+	}
+
+	// 포트 상태 변화
+	for _, id := range sortedKeys(current.Ports) {
+		status := current.Ports[id]
+		prevStatus, known := prev.Ports[id]
+		summary := PortSummary{ID: id, Title: titles[id], Status: status}
+		switch {
+		case !known && status == port.StatusPending:
+			delta.NewPorts = append(delta.NewPorts, summary)
+		case status == port.StatusBlocked && prevStatus != port.StatusBlocked:
+			delta.BlockedPorts = append(delta.BlockedPorts, summary)
+		case status == port.StatusRunning && prevStatus != port.StatusRunning:
+			delta.StartedPorts = append(delta.StartedPorts, summary)
+		}
+	}
+	for _, id := range sortedKeys(prev.Ports) {
+		if _, ok := current.Ports[id]; ok {
+			continue
+		}
+		p, err := portSvc.Get(id)
+		if err != nil || p == nil || p.Status != port.StatusComplete {
+			continue
+		}
+		title := p.ID
+		if p.Title.Valid {
+			title = p.Title.String
+		}
+		delta.CompletedPorts = append(delta.CompletedPorts, PortSummary{ID: p.ID, Title: title, Status: p.Status})
+	}
+
+	if delta.IsEmpty() && sameState(prev, current) {
+		delta.Hash = prev.Hash
+		return delta, prev, nil
+	}
+	return delta, current, nil
+}
+
+// FormatBriefingDelta renders a delta as a short text block for injection in the given language
+func FormatBriefingDelta(d *BriefingDelta, lang string) string {
+	var sb strings.Builder
+
+	chain := fmt.Sprintf("#%s", d.Hash)
+	if d.PrevHash != "" && d.PrevHash != d.Hash {
+		chain = fmt.Sprintf("#%s → #%s", d.PrevHash, d.Hash)
+	}
+	sb.WriteString(fmt.Sprintf("📋 Briefing Δ since %s [%s]\n", d.Since.Format("01/02 15:04"), chain))
+
+	if d.IsEmpty() {
+		sb.WriteString("   " + i18n.T(lang, i18n.KeyDeltaNoChanges) + "\n")
+		return sb.String()
+	}
+
+	writePorts := func(label string, ports []PortSummary) {
+		if len(ports) == 0 {
+			return
+		}
+		items := make([]string, len(ports))
+		for i, p := range ports {
+			items[i] = p.ID
+		}
+		sb.WriteString(fmt.Sprintf("   %s: %s\n", label, strings.Join(items, ", ")))
+	}
+	writePorts(i18n.T(lang, i18n.KeyDeltaCompleted), d.CompletedPorts)
+	writePorts(i18n.T(lang, i18n.KeyDeltaBlocked), d.BlockedPorts)
+	writePorts(i18n.T(lang, i18n.KeyDeltaStarted), d.StartedPorts)
+	writePorts(i18n.T(lang, i18n.KeyDeltaNew), d.NewPorts)
+
+	for _, e := range d.NewEscalations {
+		sb.WriteString(fmt.Sprintf("   %s #%d [%s] %s\n", i18n.T(lang, i18n.KeyDeltaEscalation), e.ID, e.Type, truncate(e.Message, 60)))
+	}
+	if len(d.ResolvedEscalations) > 0 {
+		ids := make([]string, len(d.ResolvedEscalations))
+		for i, id := range d.ResolvedEscalations {
+			ids[i] = fmt.Sprintf("#%d", id)
+		}
+		sb.WriteString(fmt.Sprintf("   %s: %s\n", i18n.T(lang, i18n.KeyDeltaResolved), strings.Join(ids, ", ")))
+	}
+
+	return sb.String()
+}
+
+// chainHash hashes the previous hash together with the state content
+func chainHash(prevHash string, st *BriefingState) string {
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	for _, id := range sortedKeys(st.Ports) {
+		fmt.Fprintf(h, "|p:%s=%s", id, st.Ports[id])
+	}
+	for _, id := range st.Escalations {
+		fmt.Fprintf(h, "|e:%d", id)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:8]
+}
+
+func sameState(a, b *BriefingState) bool {
+	if len(a.Ports) != len(b.Ports) || len(a.Escalations) != len(b.Escalations) {
+		return false
+	}
+	for id, status := range a.Ports {
+		if b.Ports[id] != status {
+			return false
+		}
+	}
+	for i := range a.Escalations {
+		if a.Escalations[i] != b.Escalations[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,199 @@
+package operator
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+func setupTestDB(t *testing.T) (*db.DB, func()) {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "pal-test-*")
+	if err != nil {
+		t.Fatalf("임시 디렉토리 생성 실패: %v", err)
+	}
+
+	database, err := db.Open(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+
+	if err := database.Init(); err != nil {
+		database.Close()
+		os.RemoveAll(tmpDir)
+		t.Fatalf("DB 초기화 실패: %v", err)
+	}
+
+	cleanup := func() {
+		database.Close()
+		os.RemoveAll(tmpDir)
+	}
+
+	return database, cleanup
+}
+
+func TestBriefingDelta(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database, t.TempDir())
+	portSvc := port.NewService(database)
+	escSvc := escalation.NewService(database)
+
+	portSvc.Create("p-done", "완료될 포트", "")
+	portSvc.Create("p-block", "차단될 포트", "")
+	portSvc.UpdateStatus("p-done", port.StatusRunning)
+
+	// 첫 브리핑: 이전 상태가 없으므로 delta 없음
+	b, _ := svc.GenerateBriefing()
+	delta, state, err := svc.GenerateBriefingDelta(b)
+	if err != nil {
+		t.Fatalf("GenerateBriefingDelta 실패: %v", err)
+	}
+	if delta != nil {
+		t.Error("첫 브리핑은 delta가 없어야 함")
+	}
+	if err := svc.WriteBriefingState(state); err != nil {
+		t.Fatalf("WriteBriefingState 실패: %v", err)
+	}
+	firstHash := state.Hash
+
+	// 변경 없이 재생성하면 체인이 유지됨
+	b, _ = svc.GenerateBriefing()
+	delta, state, _ = svc.GenerateBriefingDelta(b)
+	if delta == nil || !delta.IsEmpty() || state.Hash != firstHash {
+		t.Errorf("변경이 없으면 빈 delta와 동일 hash여야 함: %+v", delta)
+	}
+
+	// 포트 완료/차단 + 에스컬레이션 생성
+	portSvc.UpdateStatus("p-done", port.StatusComplete)
+	portSvc.UpdateStatus("p-block", port.StatusBlocked)
+	escSvc.Create("API 스펙 불일치", "", "p-block")
+
+	b, _ = svc.GenerateBriefing()
+	delta, state, _ = svc.GenerateBriefingDelta(b)
+	if len(delta.CompletedPorts) != 1 || delta.CompletedPorts[0].ID != "p-done" {
+		t.Errorf("완료 포트 감지 실패: %+v", delta.CompletedPorts)
+	}
+	if len(delta.BlockedPorts) != 1 || delta.BlockedPorts[0].ID != "p-block" {
+		t.Errorf("차단 포트 감지 실패: %+v", delta.BlockedPorts)
+	}
+	if len(delta.NewEscalations) != 1 {
+		t.Errorf("신규 에스컬레이션 감지 실패: %+v", delta.NewEscalations)
+	}
+	if state.PrevHash != firstHash || state.Hash == firstHash {
+		t.Errorf("hash 체인 불일치: prev=%s hash=%s", state.PrevHash, state.Hash)
+	}
+
+	text := FormatBriefingDelta(delta, "")
+	if !strings.Contains(text, "p-done") || !strings.Contains(text, "#"+firstHash) {
+		t.Errorf("delta 출력 누락:\n%s", text)
+	}
+}
+
+func TestBriefingBlockedPorts(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database, t.TempDir())
+	portSvc := port.NewService(database)
+
+	portSvc.Create("p-key", "API 키 필요", "")
+	portSvc.Create("p-free", "바로 가능", "")
+	past := time.Now().Add(-48 * time.Hour)
+	if _, err := portSvc.AddBlocker("p-key", "결제사 API 키 발급 대기", &past); err != nil {
+		t.Fatalf("AddBlocker 실패: %v", err)
+	}
+
+	b, err := svc.GenerateBriefing()
+	if err != nil {
+		t.Fatalf("GenerateBriefing 실패: %v", err)
+	}
+	if len(b.BlockedPorts) != 1 || b.BlockedPorts[0].ID != "p-key" || !b.BlockedPorts[0].Overdue {
+		t.Fatalf("차단 포트 = %+v", b.BlockedPorts)
+	}
+	recs := strings.Join(b.Recommendations, "\n")
+	if !strings.Contains(recs, "Follow up on overdue blocker: p-key") || !strings.Contains(recs, "Start pending port: p-free") {
+		t.Errorf("추천 = %v", b.Recommendations)
+	}
+
+	md := svc.FormatBriefing(b)
+	if !strings.Contains(md, "## Blocked Ports") || !strings.Contains(md, "결제사 API 키 발급 대기") {
+		t.Errorf("브리핑에 차단 요인 누락:\n%s", md)
+	}
+
+	portSvc.ResolveBlockers("p-key", 0, "발급 완료")
+	b, _ = svc.GenerateBriefing()
+	if len(b.BlockedPorts) != 0 {
+		t.Errorf("해소된 차단 요인이 남음: %+v", b.BlockedPorts)
+	}
+}
+
+func TestExportSession(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	sessionSvc := session.NewService(database)
+	portSvc := port.NewService(database)
+
+	if err := sessionSvc.Start("s-export", "", "내보내기 테스트"); err != nil {
+		t.Fatalf("세션 시작 실패: %v", err)
+	}
+	portSvc.Create("p-export", "내보낼 포트", "")
+	sessionSvc.LogEvent("s-export", "port_start", `{"port_id":"p-export"}`)
+	sessionSvc.LogEvent("s-export", "port_end", `{"port_id":"p-export"}`)
+	portSvc.RecordFileChange(port.FileChange{PortID: "p-export", SessionID: "s-export", FilePath: "main.go", Additions: 3})
+
+	svc := NewService(database, t.TempDir())
+	bundle, err := svc.ExportSession("s-export")
+	if err != nil {
+		t.Fatalf("ExportSession 실패: %v", err)
+	}
+	if bundle.Session.Title != "내보내기 테스트" || len(bundle.Events) < 2 {
+		t.Fatalf("번들 = %+v, 이벤트 %d개", bundle.Session, len(bundle.Events))
+	}
+	if last := bundle.Events[len(bundle.Events)-1]; last.Type != "port_end" {
+		t.Errorf("이벤트가 시간순이 아님: 마지막 %s", last.Type)
+	}
+	if len(bundle.Ports) != 1 || len(bundle.Ports[0].Files) != 1 {
+		t.Fatalf("포트 결과 = %+v", bundle.Ports)
+	}
+
+	md := bundle.Markdown()
+	for _, want := range []string{"# Session Export", "## Ports", "`main.go` +3", "## Event Timeline"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown에 %q 없음", want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := bundle.WriteZip(&buf); err != nil {
+		t.Fatalf("WriteZip 실패: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip 읽기 실패: %v", err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if strings.Join(names, ",") != "session.json,session.md,events.jsonl" {
+		t.Errorf("zip 항목 = %v", names)
+	}
+
+	if _, err := svc.ExportSession("missing"); err == nil {
+		t.Error("없는 세션은 오류여야 함")
+	}
+}
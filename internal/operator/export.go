@@ -0,0 +1,303 @@
+package operator
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/n0roo/pal-kit/internal/transcript"
+)
+
+// Export formats
+const (
+	ExportJSON     = "json"
+	ExportMarkdown = "markdown"
+	ExportZip      = "zip"
+)
+
+// SessionBundle packages everything recorded about a session for sharing (PR, 티켓 첨부)
+type SessionBundle struct {
+	ExportedAt time.Time         `json:"exported_at"`
+	Session    SessionRecord     `json:"session"`
+	Summary    *Summary          `json:"summary"`
+	Ports      []PortOutcome     `json:"ports"`
+	Events     []EventSummary    `json:"events"` // 시간순 전체
+	Usage      *transcript.Usage `json:"parsed_usage,omitempty"`
+	Children   []SessionSummary  `json:"children,omitempty"`
+}
+
+// SessionRecord is the exported session row
+type SessionRecord struct {
+	ID              string     `json:"id"`
+	Title           string     `json:"title,omitempty"`
+	Status          string     `json:"status"`
+	Type            string     `json:"type"`
+	ParentSession   string     `json:"parent_session,omitempty"`
+	ProjectRoot     string     `json:"project_root,omitempty"`
+	ClaudeSessionID string     `json:"claude_session_id,omitempty"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	CompactCount    int        `json:"compact_count"`
+}
+
+// PortOutcome is the result of a port worked on in the session
+type PortOutcome struct {
+	ID           string                   `json:"id"`
+	Title        string                   `json:"title"`
+	Status       string                   `json:"status"`
+	DurationSecs int64                    `json:"duration_secs,omitempty"`
+	InputTokens  int64                    `json:"input_tokens"`
+	OutputTokens int64                    `json:"output_tokens"`
+	CostUSD      float64                  `json:"cost_usd"`
+	Files        []port.FileChangeSummary `json:"files,omitempty"`
+}
+
+// ExportSession collects the session record, all events, port outcomes, the operator
+// summary and the usage parsed from the transcript into a bundle.
+// transcript를 읽을 수 없으면 parsed_usage는 생략되고 DB에 기록된 사용량만 포함됩니다.
+func (s *Service) ExportSession(sessionID string) (*SessionBundle, error) {
+	sessionSvc := session.NewService(s.db)
+	portSvc := port.NewService(s.db)
+
+	sess, err := sessionSvc.Get(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("세션을 찾을 수 없습니다: %s", sessionID)
+	}
+
+	summary, err := s.GenerateSummary(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("세션 요약 생성 실패: %w", err)
+	}
+
+	bundle := &SessionBundle{
+		ExportedAt: time.Now(),
+		Session: SessionRecord{
+			ID:              sess.ID,
+			Title:           sess.Title.String,
+			Status:          sess.Status,
+			Type:            sess.SessionType,
+			ParentSession:   sess.ParentSession.String,
+			ProjectRoot:     sess.ProjectRoot.String,
+			ClaudeSessionID: sess.ClaudeSessionID.String,
+			StartedAt:       sess.StartedAt,
+			CompactCount:    sess.CompactCount,
+		},
+		Summary: summary,
+	}
+	if sess.EndedAt.Valid {
+		bundle.Session.EndedAt = &sess.EndedAt.Time
+	}
+
+	// 전체 이벤트 (요약은 최근 50개만 포함하므로 별도 조회)
+	events, err := sessionSvc.GetEvents(sessionID, "", 0)
+	if err != nil {
+		return nil, fmt.Errorf("세션 이벤트 조회 실패: %w", err)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		}
+		return events[i].ID < events[j].ID
+	})
+	for _, e := range events {
+		bundle.Events = append(bundle.Events, EventSummary{Type: e.EventType, Data: e.EventData, CreatedAt: e.CreatedAt})
+	}
+
+	// 세션에 귀속된 포트 + 이벤트로 시작/종료된 포트
+	portIDs := map[string]bool{}
+	if ports, err := portSvc.ListBySession(sessionID); err == nil {
+		for _, p := range ports {
+			portIDs[p.ID] = true
+		}
+	}
+	for _, e := range events {
+		if e.EventType == "port_start" || e.EventType == "port_end" {
+			if id := extractPortIDFromEvent(e.EventData); id != "" {
+				portIDs[id] = true
+			}
+		}
+	}
+	ids := make([]string, 0, len(portIDs))
+	for id := range portIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		p, err := portSvc.Get(id)
+		if err != nil {
+			continue
+		}
+		outcome := PortOutcome{
+			ID:           p.ID,
+			Title:        p.ID,
+			Status:       p.Status,
+			DurationSecs: p.DurationSecs,
+			InputTokens:  p.InputTokens,
+			OutputTokens: p.OutputTokens,
+			CostUSD:      p.CostUSD,
+		}
+		if p.Title.Valid && p.Title.String != "" {
+			outcome.Title = p.Title.String
+		}
+		outcome.Files, _ = portSvc.GetFileChanges(p.ID)
+		bundle.Ports = append(bundle.Ports, outcome)
+	}
+
+	if sess.TranscriptPath.Valid && sess.TranscriptPath.String != "" {
+		if usage, err := transcript.ParseFile(sess.TranscriptPath.String); err == nil {
+			bundle.Usage = usage
+		}
+	}
+
+	if children, err := sessionSvc.GetChildren(sessionID); err == nil {
+		for _, c := range children {
+			bundle.Children = append(bundle.Children, SessionSummary{
+				ID:        c.ID,
+				Title:     c.Title.String,
+				Status:    c.Status,
+				StartedAt: c.StartedAt,
+			})
+		}
+	}
+
+	return bundle, nil
+}
+
+// Markdown renders the bundle as a shareable Markdown report
+func (b *SessionBundle) Markdown() string {
+	var sb strings.Builder
+
+	title := b.Session.ID
+	if b.Session.Title != "" {
+		title = fmt.Sprintf("%s (%s)", b.Session.Title, b.Session.ID)
+	}
+	sb.WriteString(fmt.Sprintf("# Session Export: %s\n\n", title))
+	sb.WriteString(fmt.Sprintf("- **Status**: %s\n", b.Session.Status))
+	sb.WriteString(fmt.Sprintf("- **Type**: %s\n", b.Session.Type))
+	sb.WriteString(fmt.Sprintf("- **Started**: %s\n", b.Session.StartedAt.Local().Format("2006-01-02 15:04:05")))
+	if b.Session.EndedAt != nil {
+		sb.WriteString(fmt.Sprintf("- **Ended**: %s\n", b.Session.EndedAt.Local().Format("2006-01-02 15:04:05")))
+	}
+	if b.Summary != nil {
+		sb.WriteString(fmt.Sprintf("- **Duration**: %s\n", b.Summary.DurationStr))
+	}
+	if b.Session.ParentSession != "" {
+		sb.WriteString(fmt.Sprintf("- **Parent**: %s\n", b.Session.ParentSession))
+	}
+	sb.WriteString(fmt.Sprintf("- **Compactions**: %d\n", b.Session.CompactCount))
+	sb.WriteString(fmt.Sprintf("- **Exported**: %s\n\n", b.ExportedAt.Format("2006-01-02 15:04:05")))
+
+	// Usage
+	sb.WriteString("## Usage\n\n")
+	sb.WriteString("| | Input | Output | Cache Read | Cache Create | Cost |\n")
+	sb.WriteString("|---|---|---|---|---|---|\n")
+	if b.Summary != nil {
+		u := b.Summary.Usage
+		sb.WriteString(fmt.Sprintf("| Recorded | %d | %d | %d | %d | $%.4f |\n",
+			u.InputTokens, u.OutputTokens, u.CacheRead, u.CacheCreate, u.CostUSD))
+	}
+	if b.Usage != nil {
+		sb.WriteString(fmt.Sprintf("| Transcript (%d messages) | %d | %d | %d | %d | $%.4f |\n",
+			b.Usage.MessageCount, b.Usage.InputTokens, b.Usage.OutputTokens,
+			b.Usage.CacheReadTokens, b.Usage.CacheCreateTokens, b.Usage.CostUSD))
+	}
+	sb.WriteString("\n")
+
+	// Ports
+	if len(b.Ports) > 0 {
+		sb.WriteString("## Ports\n\n")
+		sb.WriteString("| Port | Status | Duration | Tokens | Cost | Files |\n")
+		sb.WriteString("|---|---|---|---|---|---|\n")
+		for _, p := range b.Ports {
+			sb.WriteString(fmt.Sprintf("| %s (%s) | %s | %s | %d | $%.4f | %d |\n",
+				p.Title, p.ID, p.Status, formatDuration(time.Duration(p.DurationSecs)*time.Second),
+				p.InputTokens+p.OutputTokens, p.CostUSD, len(p.Files)))
+		}
+		sb.WriteString("\n")
+
+		for _, p := range b.Ports {
+			if len(p.Files) == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("### %s\n\n", p.ID))
+			for _, f := range p.Files {
+				sb.WriteString(fmt.Sprintf("- `%s` +%d -%d (%d edits)\n", f.FilePath, f.Additions, f.Deletions, f.Edits))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	// Sub-sessions
+	if len(b.Children) > 0 {
+		sb.WriteString("## Sub-sessions\n\n")
+		for _, c := range b.Children {
+			sb.WriteString(fmt.Sprintf("- %s %s (%s)\n", c.ID, c.Title, c.Status))
+		}
+		sb.WriteString("\n")
+	}
+
+	// ADR candidates
+	if b.Summary != nil && len(b.Summary.ADRCandidates) > 0 {
+		sb.WriteString("## ADR Candidates\n\n")
+		for _, adr := range b.Summary.ADRCandidates {
+			sb.WriteString(fmt.Sprintf("- %s\n", adr.Title))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Event timeline
+	if len(b.Events) > 0 {
+		sb.WriteString("## Event Timeline\n\n")
+		for _, e := range b.Events {
+			sb.WriteString(fmt.Sprintf("- `%s` [%s] %s\n",
+				e.CreatedAt.Local().Format("01/02 15:04:05"), e.Type, truncate(e.Data, 120)))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// WriteZip writes the bundle as a zip archive:
+// session.json (전체 번들), session.md (Markdown 리포트), events.jsonl (이벤트 원본)
+func (b *SessionBundle) WriteZip(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	add := func(name string, data []byte) error {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: b.ExportedAt})
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(data)
+		return err
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("번들 직렬화 실패: %w", err)
+	}
+	if err := add("session.json", data); err != nil {
+		return fmt.Errorf("zip 작성 실패: %w", err)
+	}
+	if err := add("session.md", []byte(b.Markdown())); err != nil {
+		return fmt.Errorf("zip 작성 실패: %w", err)
+	}
+
+	var events strings.Builder
+	for _, e := range b.Events {
+		line, _ := json.Marshal(e)
+		events.Write(line)
+		events.WriteByte('\n')
+	}
+	if err := add("events.jsonl", []byte(events.String())); err != nil {
+		return fmt.Errorf("zip 작성 실패: %w", err)
+	}
+
+	return zw.Close()
+}
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/kb"
 	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/rules"
 	"github.com/n0roo/pal-kit/internal/session"
 )
 
@@ -27,7 +30,9 @@ type Briefing struct {
 	RecentSessions []SessionSummary `json:"recent_sessions"`
 	RunningPorts   []PortSummary    `json:"running_ports"`
 	PendingPorts   []PortSummary    `json:"pending_ports"`
+	BlockedPorts   []BlockedPort    `json:"blocked_ports,omitempty"`
 	Escalations    []Escalation     `json:"escalations"`
+	OverdueDocs    []OverdueDoc     `json:"overdue_docs,omitempty"`
 	Recommendations []string        `json:"recommendations"`
 }
 
@@ -62,6 +67,24 @@ type PortSummary struct {
 	StartedAt time.Time `json:"started_at,omitempty"`
 }
 
+// BlockedPort is a port waiting on external blockers (API 키, 디자인 승인 등)
+type BlockedPort struct {
+	ID       string         `json:"id"`
+	Title    string         `json:"title"`
+	Status   string         `json:"status"`
+	Blockers []port.Blocker `json:"blockers"`
+	Overdue  bool           `json:"overdue,omitempty"` // 해소 예정일이 지난 차단 요인이 있음
+}
+
+// OverdueDoc is a critical KB document past its review cadence
+type OverdueDoc struct {
+	Path          string `json:"path"`
+	Title         string `json:"title"`
+	OverdueDays   int    `json:"overdue_days"`
+	NeverReviewed bool   `json:"never_reviewed,omitempty"`
+	Owner         string `json:"owner,omitempty"`
+}
+
 // Escalation represents an active escalation
 type Escalation struct {
 	ID        int64     `json:"id"`
@@ -114,10 +137,16 @@ func (s *Service) GenerateBriefing() (*Briefing, error) {
 		ProjectName: filepath.Base(s.projectRoot),
 	}
 
-	// Recent sessions (last 5)
-	sessions, err := sessionSvc.ListDetailed(false, 5)
+	// Recent sessions (last 5, 샌드박스 세션 제외)
+	sessions, err := sessionSvc.ListDetailed(false, 20)
 	if err == nil {
 		for _, sess := range sessions {
+			if len(briefing.RecentSessions) == 5 {
+				break
+			}
+			if sessionSvc.IsSandbox(sess.ID) {
+				continue
+			}
 			title := ""
 			if sess.Title.Valid {
 				title = sess.Title.String
@@ -136,6 +165,9 @@ func (s *Service) GenerateBriefing() (*Briefing, error) {
 	runningPorts, err := portSvc.List("running", 20)
 	if err == nil {
 		for _, p := range runningPorts {
+			if p.SessionID.Valid && sessionSvc.IsSandbox(p.SessionID.String) {
+				continue
+			}
 			title := p.ID
 			if p.Title.Valid {
 				title = p.Title.String
@@ -157,6 +189,9 @@ func (s *Service) GenerateBriefing() (*Briefing, error) {
 	pendingPorts, err := portSvc.List("pending", 10)
 	if err == nil {
 		for _, p := range pendingPorts {
+			if p.SessionID.Valid && sessionSvc.IsSandbox(p.SessionID.String) {
+				continue
+			}
 			title := p.ID
 			if p.Title.Valid {
 				title = p.Title.String
@@ -169,12 +204,39 @@ func (s *Service) GenerateBriefing() (*Briefing, error) {
 		}
 	}
 
+	// Externally blocked ports
+	if blocked, err := portSvc.ActiveBlockers(); err == nil {
+		for _, blockers := range blocked {
+			p, err := portSvc.Get(blockers[0].PortID)
+			if err != nil || p.Status == "complete" {
+				continue
+			}
+			title := p.ID
+			if p.Title.Valid {
+				title = p.Title.String
+			}
+			bp := BlockedPort{ID: p.ID, Title: title, Status: p.Status, Blockers: blockers}
+			for _, b := range blockers {
+				if b.Overdue(briefing.GeneratedAt) {
+					bp.Overdue = true
+				}
+			}
+			briefing.BlockedPorts = append(briefing.BlockedPorts, bp)
+		}
+		sort.Slice(briefing.BlockedPorts, func(i, j int) bool {
+			return briefing.BlockedPorts[i].ID < briefing.BlockedPorts[j].ID
+		})
+	}
+
 	// Load escalations from database
 	escalations, err := s.getActiveEscalations()
 	if err == nil {
 		briefing.Escalations = escalations
 	}
 
+	// Critical KB documents past their review cadence
+	briefing.OverdueDocs = s.overdueCriticalDocs(briefing.GeneratedAt)
+
 	// Generate summary and recommendations
 	briefing.Summary = s.generateBriefingSummary(briefing)
 	briefing.Recommendations = s.generateRecommendations(briefing)
@@ -319,7 +381,8 @@ func (s *Service) WriteBriefing(b *Briefing) error {
 	}
 
 	briefingPath := filepath.Join(contextDir, "session-briefing.md")
-	content := s.formatBriefingMarkdown(b)
+	// 데몬이 멈춘 뒤 오래된 브리핑이 재사용되지 않도록 만료 헤더 포함
+	content := rules.WithHeaderTTL(s.formatBriefingMarkdown(b), nil, rules.ProjectTTL(s.projectRoot))
 
 	return os.WriteFile(briefingPath, []byte(content), 0644)
 }
@@ -338,6 +401,11 @@ func (s *Service) WriteSummary(sum *Summary) error {
 	return os.WriteFile(summaryPath, []byte(content), 0644)
 }
 
+// FormatBriefing renders a briefing as Markdown
+func (s *Service) FormatBriefing(b *Briefing) string {
+	return s.formatBriefingMarkdown(b)
+}
+
 // GetBriefingPath returns the path to the briefing file
 func (s *Service) GetBriefingPath() string {
 	return filepath.Join(s.projectRoot, ".pal", "context", "session-briefing.md")
@@ -347,7 +415,7 @@ func (s *Service) GetBriefingPath() string {
 
 func (s *Service) getActiveEscalations() ([]Escalation, error) {
 	rows, err := s.db.Query(`
-		SELECT id, port_id, escalation_type, message, created_at
+		SELECT id, from_port, COALESCE(type, 'general'), issue, created_at
 		FROM escalations
 		WHERE status = 'open'
 		ORDER BY created_at DESC
@@ -374,6 +442,34 @@ func (s *Service) getActiveEscalations() ([]Escalation, error) {
 	return escalations, nil
 }
 
+// overdueCriticalDocs lists critical KB documents whose review is overdue (KB 색인이 없으면 nil)
+func (s *Service) overdueCriticalDocs(now time.Time) []OverdueDoc {
+	if _, err := os.Stat(filepath.Join(s.projectRoot, kb.MetaDir, "index.db")); err != nil {
+		return nil
+	}
+	indexSvc := kb.NewIndexService(s.projectRoot)
+	if err := indexSvc.Open(); err != nil {
+		return nil
+	}
+	defer indexSvc.Close()
+
+	due, err := indexSvc.ReviewDue(kb.ReviewOptions{Now: now, CriticalOnly: true})
+	if err != nil {
+		return nil
+	}
+	var docs []OverdueDoc
+	for _, r := range due {
+		docs = append(docs, OverdueDoc{
+			Path:          r.Document.Path,
+			Title:         r.Document.Title,
+			OverdueDays:   r.OverdueDays,
+			NeverReviewed: r.NeverReviewed,
+			Owner:         r.Owner,
+		})
+	}
+	return docs
+}
+
 func (s *Service) generateBriefingSummary(b *Briefing) string {
 	var parts []string
 
@@ -385,10 +481,18 @@ func (s *Service) generateBriefingSummary(b *Briefing) string {
 		parts = append(parts, fmt.Sprintf("%d pending port(s)", len(b.PendingPorts)))
 	}
 
+	if len(b.BlockedPorts) > 0 {
+		parts = append(parts, fmt.Sprintf("%d blocked port(s)", len(b.BlockedPorts)))
+	}
+
 	if len(b.Escalations) > 0 {
 		parts = append(parts, fmt.Sprintf("%d active escalation(s)", len(b.Escalations)))
 	}
 
+	if len(b.OverdueDocs) > 0 {
+		parts = append(parts, fmt.Sprintf("%d overdue critical doc(s)", len(b.OverdueDocs)))
+	}
+
 	if len(parts) == 0 {
 		return "No active work items."
 	}
@@ -411,10 +515,33 @@ func (s *Service) generateRecommendations(b *Briefing) []string {
 			"Address pending escalations before starting new work")
 	}
 
-	// Recommend pending ports if nothing is running
-	if len(b.RunningPorts) == 0 && len(b.PendingPorts) > 0 {
+	// Recommend following up on blockers past their expected date
+	for _, bp := range b.BlockedPorts {
+		if bp.Overdue {
+			recommendations = append(recommendations,
+				fmt.Sprintf("Follow up on overdue blocker: %s", bp.ID))
+		}
+	}
+
+	// Recommend reviewing the most overdue critical document
+	if len(b.OverdueDocs) > 0 {
 		recommendations = append(recommendations,
-			fmt.Sprintf("Start pending port: %s", b.PendingPorts[0].ID))
+			fmt.Sprintf("Review overdue critical doc: %s", b.OverdueDocs[0].Path))
+	}
+
+	// Recommend pending ports if nothing is running (외부 차단된 포트 제외)
+	if len(b.RunningPorts) == 0 {
+		blocked := make(map[string]bool)
+		for _, bp := range b.BlockedPorts {
+			blocked[bp.ID] = true
+		}
+		for _, p := range b.PendingPorts {
+			if !blocked[p.ID] {
+				recommendations = append(recommendations,
+					fmt.Sprintf("Start pending port: %s", p.ID))
+				break
+			}
+		}
 	}
 
 	return recommendations
@@ -446,6 +573,26 @@ func (s *Service) formatBriefingMarkdown(b *Briefing) string {
 		sb.WriteString("\n")
 	}
 
+	// Blocked ports
+	if len(b.BlockedPorts) > 0 {
+		sb.WriteString("## Blocked Ports\n\n")
+		for _, p := range b.BlockedPorts {
+			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", p.ID, p.Title))
+			for _, bl := range p.Blockers {
+				line := fmt.Sprintf("  - %s", bl.Reason)
+				if bl.ExpectedAt != nil {
+					line += fmt.Sprintf(" (expected %s", bl.ExpectedAt.Format("2006-01-02"))
+					if bl.Overdue(b.GeneratedAt) {
+						line += ", overdue"
+					}
+					line += ")"
+				}
+				sb.WriteString(line + "\n")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
 	// Escalations
 	if len(b.Escalations) > 0 {
 		sb.WriteString("## Active Escalations\n\n")
@@ -455,6 +602,24 @@ func (s *Service) formatBriefingMarkdown(b *Briefing) string {
 		sb.WriteString("\n")
 	}
 
+	// Overdue critical docs
+	if len(b.OverdueDocs) > 0 {
+		sb.WriteString("## Overdue Critical Docs\n\n")
+		for _, d := range b.OverdueDocs {
+			line := fmt.Sprintf("- **%s**: %s", d.Path, d.Title)
+			if d.NeverReviewed {
+				line += " (never reviewed"
+			} else {
+				line += fmt.Sprintf(" (%d day(s) overdue", d.OverdueDays)
+			}
+			if d.Owner != "" {
+				line += ", owner " + d.Owner
+			}
+			sb.WriteString(line + ")\n")
+		}
+		sb.WriteString("\n")
+	}
+
 	// Recommendations
 	if len(b.Recommendations) > 0 {
 		sb.WriteString("## Recommendations\n\n")
@@ -0,0 +1,61 @@
+package operator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/kb"
+)
+
+func TestBriefingOverdueCriticalDocs(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	root := t.TempDir()
+	if err := kb.NewService(root).Init(); err != nil {
+		t.Fatal(err)
+	}
+	docs := map[string]string{
+		"00-System/runbook.md": "---\ntitle: 장애 대응\nreview_every: 30d\nreviewed: 2020-01-01\ncritical: true\nowner: oncall\n---\n# 장애 대응\n",
+		"00-System/notes.md":   "---\ntitle: 메모\nreview_every: 30d\nreviewed: 2020-01-01\n---\n# 메모\n",
+	}
+	for rel, content := range docs {
+		path := filepath.Join(root, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc := NewService(database, root)
+	b, _ := svc.GenerateBriefing()
+	if len(b.OverdueDocs) != 0 {
+		t.Errorf("KB 색인 전 overdue docs = %+v", b.OverdueDocs)
+	}
+
+	idx := kb.NewIndexService(root)
+	if err := idx.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := idx.BuildIndex(); err != nil {
+		t.Fatal(err)
+	}
+	idx.Close()
+
+	b, err := svc.GenerateBriefing()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.OverdueDocs) != 1 || b.OverdueDocs[0].Path != "00-System/runbook.md" || b.OverdueDocs[0].Owner != "oncall" {
+		t.Fatalf("overdue docs = %+v", b.OverdueDocs)
+	}
+	if !strings.Contains(b.Summary, "1 overdue critical doc(s)") {
+		t.Errorf("summary = %q", b.Summary)
+	}
+	md := svc.FormatBriefing(b)
+	if !strings.Contains(md, "## Overdue Critical Docs") || !strings.Contains(md, "Review overdue critical doc: 00-System/runbook.md") {
+		t.Errorf("briefing:\n%s", md)
+	}
+}
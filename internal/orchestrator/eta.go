@@ -0,0 +1,243 @@
+package orchestrator
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/port"
+)
+
+// DefaultPortHours is the duration assumed for ports without an estimate
+const DefaultPortHours = 1.0
+
+// slackEpsilon treats float slack below this (hours) as zero
+const slackEpsilon = 1e-6
+
+// PortSchedule is the projected schedule of a single port.
+// 시간 값은 지금부터의 시간(hour) 단위이며, 병렬 워커 수 제한은 고려하지 않습니다.
+type PortSchedule struct {
+	PortID          string     `json:"port_id"`
+	Status          string     `json:"status"`
+	DependsOn       []string   `json:"depends_on,omitempty"`
+	EstimateHours   float64    `json:"estimate_hours"`
+	Estimated       bool       `json:"estimated"` // false면 추정치가 없어 기본값 사용
+	RemainingHours  float64    `json:"remaining_hours"`
+	Overrun         bool       `json:"overrun,omitempty"`       // 실행 시간이 추정치를 넘음
+	Blocked         bool       `json:"blocked,omitempty"`       // 외부 차단 요인이 남아 있음
+	BlockedUntil    *time.Time `json:"blocked_until,omitempty"` // 차단 해소 예정일 (모두 예정일이 있을 때)
+	EarliestStart   float64    `json:"earliest_start_hours"`
+	EarliestFinish  float64    `json:"earliest_finish_hours"`
+	SlackHours      float64    `json:"slack_hours"`
+	Critical        bool       `json:"critical"`
+	ProjectedStart  time.Time  `json:"projected_start"`
+	ProjectedFinish time.Time  `json:"projected_finish"`
+}
+
+// ExecutionPlan is the critical path and ETA of an orchestration
+type ExecutionPlan struct {
+	OrchestrationID     string              `json:"orchestration_id"`
+	Title               string              `json:"title"`
+	Status              OrchestrationStatus `json:"status"`
+	CriticalPath        []string            `json:"critical_path"`
+	Blocking            []string            `json:"blocking,omitempty"`           // 지금 진행 중이거나 시작 가능한 크리티컬 포트
+	ExternallyBlocked   []string            `json:"externally_blocked,omitempty"` // 외부 차단 요인으로 시작할 수 없는 포트
+	RemainingHours      float64             `json:"remaining_hours"`
+	ProjectedCompletion time.Time           `json:"projected_completion"`
+	MissingEstimates    int                 `json:"missing_estimates"`
+	Ports               []PortSchedule      `json:"ports"`
+	GeneratedAt         time.Time           `json:"generated_at"`
+}
+
+// ComputePlan computes the critical path, projected completion and per-port slack of an orchestration.
+// 포트 추정치(pal port estimate --hours)와 의존성 그래프로 계산하며, 실행 중인 포트는 경과 시간을 뺀 나머지만 남은 것으로 봅니다.
+func (s *Service) ComputePlan(id string) (*ExecutionPlan, error) {
+	op, err := s.GetOrchestration(id)
+	if err != nil {
+		return nil, errcode.Wrap(errcode.KindNotFound, err)
+	}
+
+	portSvc := port.NewService(s.db)
+	estimates := make(map[string]float64)
+	started := make(map[string]time.Time)
+	blocked, err := portSvc.ActiveBlockers()
+	if err != nil {
+		return nil, err
+	}
+	for _, ap := range op.AtomicPorts {
+		if est, err := portSvc.GetEstimate(ap.PortID); err == nil && est.Hours > 0 {
+			estimates[ap.PortID] = est.Hours
+		}
+		if p, err := portSvc.Get(ap.PortID); err == nil && p.StartedAt.Valid {
+			started[ap.PortID] = p.StartedAt.Time
+		}
+	}
+
+	return buildExecutionPlan(op, estimates, started, blocked, time.Now())
+}
+
+// buildExecutionPlan schedules the ports of op.
+// 외부 차단 요인이 있는 포트는 해소 예정일 이후에 시작하는 것으로 보며, 예정일을 모르면 지금 시작 가능한 포트(Blocking)에서 제외합니다.
+func buildExecutionPlan(op *OrchestrationPort, estimates map[string]float64, started map[string]time.Time, blocked map[string][]port.Blocker, now time.Time) (*ExecutionPlan, error) {
+	levels, err := NewDependencyGraph(op.AtomicPorts).TopologicalLevels()
+	if err != nil {
+		return nil, errcode.Wrap(errcode.KindValidation, err)
+	}
+
+	plan := &ExecutionPlan{
+		OrchestrationID: op.ID,
+		Title:           op.Title,
+		Status:          op.Status,
+		CriticalPath:    []string{},
+		GeneratedAt:     now,
+	}
+
+	byID := make(map[string]*PortSchedule)
+	atomic := make(map[string]AtomicPort)
+	for _, ap := range op.AtomicPorts {
+		atomic[ap.PortID] = ap
+		ps := &PortSchedule{
+			PortID:    ap.PortID,
+			Status:    ap.Status,
+			DependsOn: ap.DependsOn,
+		}
+		if ps.Status == "" {
+			ps.Status = "pending"
+		}
+
+		ps.EstimateHours, ps.Estimated = estimates[ap.PortID]
+		if !ps.Estimated {
+			ps.EstimateHours = DefaultPortHours
+			plan.MissingEstimates++
+		}
+
+		switch ps.Status {
+		case "complete", string(StatusCancelled):
+			ps.RemainingHours = 0
+		case "running":
+			ps.RemainingHours = ps.EstimateHours
+			if t, ok := started[ap.PortID]; ok {
+				ps.RemainingHours -= now.Sub(t).Hours()
+			}
+			if ps.RemainingHours < 0 {
+				ps.RemainingHours = 0
+				ps.Overrun = true
+			}
+		default:
+			ps.RemainingHours = ps.EstimateHours
+		}
+		if ps.RemainingHours > 0 && len(blocked[ap.PortID]) > 0 {
+			ps.Blocked = true
+			if at, known := port.UnblockAt(blocked[ap.PortID]); known {
+				ps.BlockedUntil = &at
+			}
+		}
+		byID[ap.PortID] = ps
+	}
+
+	// 전진 계산: 가장 이른 시작/종료
+	var order []string
+	end := 0.0
+	for _, level := range levels {
+		for _, id := range level {
+			order = append(order, id)
+			ps := byID[id]
+			for _, dep := range ps.DependsOn {
+				if d, ok := byID[dep]; ok && d.EarliestFinish > ps.EarliestStart {
+					ps.EarliestStart = d.EarliestFinish
+				}
+			}
+			// 재시도 대기 중인 포트는 backoff가 끝난 뒤 시작
+			if ap := atomic[id]; ap.RetryAt != nil && ps.RemainingHours > 0 {
+				if wait := ap.RetryAt.Sub(now).Hours(); wait > ps.EarliestStart {
+					ps.EarliestStart = wait
+				}
+			}
+			// 외부 차단 요인은 해소 예정일 이후 시작 (실행 중인 포트는 이미 시작됨)
+			if ps.BlockedUntil != nil && ps.Status != "running" {
+				if wait := ps.BlockedUntil.Sub(now).Hours(); wait > ps.EarliestStart {
+					ps.EarliestStart = wait
+				}
+			}
+			ps.EarliestFinish = ps.EarliestStart + ps.RemainingHours
+			end = math.Max(end, ps.EarliestFinish)
+		}
+	}
+
+	// 후진 계산: 늦어도 되는 종료 시각과 여유(slack)
+	dependents := make(map[string][]string)
+	for _, id := range order {
+		for _, dep := range byID[id].DependsOn {
+			dependents[dep] = append(dependents[dep], id)
+		}
+	}
+	latestFinish := make(map[string]float64)
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		lf := end
+		for _, child := range dependents[id] {
+			c := byID[child]
+			if ls := latestFinish[child] - c.RemainingHours; ls < lf {
+				lf = ls
+			}
+		}
+		latestFinish[id] = lf
+		ps := byID[id]
+		ps.SlackHours = lf - ps.EarliestFinish
+		if ps.SlackHours < slackEpsilon {
+			ps.SlackHours = 0
+		}
+		ps.Critical = ps.SlackHours == 0 && ps.RemainingHours > 0
+	}
+
+	plan.RemainingHours = end
+	plan.ProjectedCompletion = now.Add(hoursToDuration(end))
+	if op.Status == StatusComplete && op.CompletedAt != nil {
+		plan.ProjectedCompletion = *op.CompletedAt
+	}
+
+	// 크리티컬 패스: 가장 늦게 끝나는 크리티컬 포트에서 의존성을 거슬러 올라감
+	var last *PortSchedule
+	for _, id := range order {
+		ps := byID[id]
+		if ps.Critical && math.Abs(ps.EarliestFinish-end) < slackEpsilon {
+			last = ps
+			break
+		}
+	}
+	for last != nil {
+		plan.CriticalPath = append([]string{last.PortID}, plan.CriticalPath...)
+		var prev *PortSchedule
+		for _, dep := range last.DependsOn {
+			d, ok := byID[dep]
+			if ok && d.Critical && math.Abs(d.EarliestFinish-last.EarliestStart) < slackEpsilon {
+				prev = d
+				break
+			}
+		}
+		last = prev
+	}
+
+	for _, id := range order {
+		ps := byID[id]
+		ps.ProjectedStart = now.Add(hoursToDuration(ps.EarliestStart))
+		ps.ProjectedFinish = now.Add(hoursToDuration(ps.EarliestFinish))
+		if ps.Blocked {
+			plan.ExternallyBlocked = append(plan.ExternallyBlocked, id)
+		}
+		if ps.Critical && (ps.Status == "running" || (ps.Status == "pending" && !ps.Blocked && ps.EarliestStart < slackEpsilon)) {
+			plan.Blocking = append(plan.Blocking, id)
+		}
+		plan.Ports = append(plan.Ports, *ps)
+	}
+	sort.SliceStable(plan.Ports, func(i, j int) bool {
+		return plan.Ports[i].EarliestStart < plan.Ports[j].EarliestStart
+	})
+
+	return plan, nil
+}
+
+func hoursToDuration(h float64) time.Duration {
+	return time.Duration(h * float64(time.Hour))
+}
@@ -0,0 +1,39 @@
+package orchestrator
+
+import (
+	"github.com/n0roo/pal-kit/internal/server/events"
+)
+
+// publishPortEvent publishes an orchestration SSE event for a port, followed by a progress event.
+// 대시보드(pal serve)에 연결된 클라이언트가 없으면 아무 일도 하지 않습니다.
+func (s *Service) publishPortEvent(eventType events.EventType, op *OrchestrationPort, portID string, data events.OrchestrationProgressData) {
+	data.OrchestrationID = op.ID
+	data.PortID = portID
+	data.Status = string(op.Status)
+	data.TotalPorts = len(op.AtomicPorts)
+	for _, ap := range op.AtomicPorts {
+		if ap.PortID == portID && data.PortStatus == "" {
+			data.PortStatus = ap.Status
+		}
+		if ap.Status == "complete" {
+			data.CompletedPorts++
+		}
+	}
+	if data.TotalPorts > 0 {
+		data.ProgressPercent = data.CompletedPorts * 100 / data.TotalPorts
+	}
+
+	events.GetPublisher().PublishOrchestrationEvent(eventType, data)
+}
+
+// portEventType maps a port status change to its SSE event type
+func portEventType(status string) events.EventType {
+	switch status {
+	case "running":
+		return events.EventOrchestrationPortStarted
+	case "complete":
+		return events.EventOrchestrationPortCompleted
+	default:
+		return events.EventOrchestrationProgress
+	}
+}
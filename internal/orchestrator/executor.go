@@ -3,9 +3,11 @@ package orchestrator
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/message"
+	"github.com/n0roo/pal-kit/internal/port"
 )
 
 // ExecutionState represents the state of an orchestration execution
@@ -22,6 +24,8 @@ type ExecutionState struct {
 	LastUpdateAt      time.Time           `json:"last_update_at"`
 	Graph             *DependencyGraph    `json:"-"` // 의존성 그래프
 	MaxParallelism    int                 `json:"max_parallelism"`
+	AgentPins         []AgentPin          `json:"agent_pins,omitempty"` // 재실행 시 고정한 에이전트 버전
+	ProjectRoot       string              `json:"project_root,omitempty"`
 }
 
 // ExecutorConfig holds executor configuration
@@ -31,6 +35,10 @@ type ExecutorConfig struct {
 	WorkerTimeout   time.Duration `json:"worker_timeout"`
 	DefaultAgentIDs AgentIDs      `json:"default_agent_ids"`
 	MaxParallelism  int           `json:"max_parallelism"` // 최대 병렬 워커 수
+
+	// PortEnv resolves the env var names a port requires before its worker is spawned.
+	// 필수 값이 없으면 오류를 반환하여 워커 생성을 막습니다. nil이면 Service.PortEnvNames를 사용합니다.
+	PortEnv func(portID, projectRoot string) ([]string, error) `json:"-"`
 }
 
 // AgentIDs holds default agent IDs for different worker types
@@ -58,10 +66,14 @@ type Executor struct {
 	service *Service
 	config  ExecutorConfig
 	states  map[string]*ExecutionState
+	mu      sync.Mutex // 재시도 타이머가 별도 goroutine에서 배정하므로 실행 상태를 보호
 }
 
 // NewExecutor creates a new executor
 func NewExecutor(service *Service, config ExecutorConfig) *Executor {
+	if config.PortEnv == nil {
+		config.PortEnv = service.PortEnvNames
+	}
 	return &Executor{
 		service: service,
 		config:  config,
@@ -71,6 +83,9 @@ func NewExecutor(service *Service, config ExecutorConfig) *Executor {
 
 // Start starts an orchestration execution
 func (e *Executor) Start(orchestrationID, operatorSessionID, projectRoot string) (*ExecutionState, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	op, err := e.service.GetOrchestration(orchestrationID)
 	if err != nil {
 		return nil, err
@@ -104,6 +119,10 @@ func (e *Executor) Start(orchestrationID, operatorSessionID, projectRoot string)
 		LastUpdateAt:      time.Now(),
 		Graph:             graph,
 		MaxParallelism:    maxParallel,
+		ProjectRoot:       projectRoot,
+	}
+	if replay, err := e.service.ReplayForOrchestration(orchestrationID); err == nil && replay != nil {
+		state.AgentPins = replay.AgentVersions
 	}
 
 	e.states[orchestrationID] = state
@@ -118,6 +137,11 @@ func (e *Executor) Start(orchestrationID, operatorSessionID, projectRoot string)
 
 // processReadyPorts processes all ports that are ready (dependencies complete)
 func (e *Executor) processReadyPorts(state *ExecutionState, projectRoot string) error {
+	// 일시정지/취소된 Orchestration에는 새 포트를 배정하지 않음
+	if !e.service.Dispatchable(state.OrchestrationID) {
+		return nil
+	}
+
 	if state.Graph == nil {
 		// Fallback to legacy processing
 		op, err := e.service.GetOrchestration(state.OrchestrationID)
@@ -130,6 +154,23 @@ func (e *Executor) processReadyPorts(state *ExecutionState, projectRoot string)
 	// Get ready ports from dependency graph
 	readyPorts := state.Graph.GetReadyPorts()
 
+	// 재시도 backoff가 끝나지 않은 포트는 건너뜀
+	waiting := make(map[string]bool)
+	if op, err := e.service.GetOrchestration(state.OrchestrationID); err == nil {
+		now := time.Now()
+		for i := range op.AtomicPorts {
+			if !op.AtomicPorts[i].RetryReady(now) {
+				waiting[op.AtomicPorts[i].PortID] = true
+			}
+		}
+	}
+	// 외부 차단 요인(API 키 대기 등)이 남은 포트도 건너뜀
+	if blocked, err := port.NewService(e.service.db).ActiveBlockers(); err == nil {
+		for portID := range blocked {
+			waiting[portID] = true
+		}
+	}
+
 	// Limit by max parallelism
 	availableSlots := state.MaxParallelism - len(state.ActiveWorkers)
 	if availableSlots <= 0 {
@@ -141,6 +182,9 @@ func (e *Executor) processReadyPorts(state *ExecutionState, projectRoot string)
 		if spawnCount >= availableSlots {
 			break
 		}
+		if waiting[portID] {
+			continue
+		}
 
 		// Check if already active
 		alreadyActive := false
@@ -187,6 +231,10 @@ func (e *Executor) processReadyPorts(state *ExecutionState, projectRoot string)
 
 // processNextPorts processes the next available ports (legacy fallback)
 func (e *Executor) processNextPorts(state *ExecutionState, op *OrchestrationPort, projectRoot string) error {
+	if !e.service.Dispatchable(state.OrchestrationID) {
+		return nil
+	}
+
 	for {
 		nextPort, err := e.service.GetNextPort(state.OrchestrationID)
 		if err != nil {
@@ -211,6 +259,11 @@ func (e *Executor) processNextPorts(state *ExecutionState, op *OrchestrationPort
 			break
 		}
 
+		// 외부 차단 요인이 해소될 때까지 순차 진행을 멈춤
+		if blockers, err := port.NewService(e.service.db).Blockers(nextPort.PortID, false); err == nil && len(blockers) > 0 {
+			break
+		}
+
 		// Spawn worker for this port
 		ws, err := e.spawnWorkerForPort(state, nextPort, projectRoot)
 		if err != nil {
@@ -234,22 +287,38 @@ func (e *Executor) spawnWorkerForPort(state *ExecutionState, port *AtomicPort, p
 	// Get port spec (simplified - in real implementation, load from file)
 	portSpec := fmt.Sprintf("Port ID: %s", port.PortID)
 
-	// Spawn worker pair
-	return e.service.SpawnWorkerPair(WorkerPairOptions{
+	var envNames []string
+	if e.config.PortEnv != nil {
+		names, err := e.config.PortEnv(port.PortID, projectRoot)
+		if err != nil {
+			return nil, fmt.Errorf("포트 '%s' 환경 변수 준비 실패: %w", port.PortID, err)
+		}
+		envNames = names
+	}
+
+	// 명세에 pair: true가 있으면 Impl/Test 쌍, 아니면 단일 워커
+	return e.service.SpawnPortWorker(WorkerPairOptions{
 		OrchestrationID:   state.OrchestrationID,
 		OperatorSessionID: state.OperatorSessionID,
 		PortID:            port.PortID,
 		PortTitle:         port.PortID,
 		PortSpec:          portSpec,
-		ImplAgentID:       e.config.DefaultAgentIDs.ImplWorker,
-		TestAgentID:       e.config.DefaultAgentIDs.TestWorker,
+		ImplAgentID:       PinnedAgentID(state.AgentPins, e.config.DefaultAgentIDs.ImplWorker),
+		TestAgentID:       PinnedAgentID(state.AgentPins, e.config.DefaultAgentIDs.TestWorker),
 		TokenBudget:       15000,
 		ProjectRoot:       projectRoot,
+		EnvNames:          envNames,
 	})
 }
 
 // HandleWorkerComplete handles worker completion
 func (e *Executor) HandleWorkerComplete(workerSessionID string, result WorkerPairResult) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.handleWorkerComplete(workerSessionID, result)
+}
+
+func (e *Executor) handleWorkerComplete(workerSessionID string, result WorkerPairResult) error {
 	ws, err := e.service.GetWorkerSession(workerSessionID)
 	if err != nil {
 		return err
@@ -283,19 +352,23 @@ func (e *Executor) HandleWorkerComplete(workerSessionID string, result WorkerPai
 			state.Graph.MarkComplete(ws.PortID)
 		}
 	} else {
-		// Check retry count
-		state.RetryCount[ws.PortID]++
-		if state.RetryCount[ws.PortID] >= e.config.MaxRetries {
-			e.service.UpdatePortStatus(state.OrchestrationID, ws.PortID, "failed")
-			state.FailedPorts = append(state.FailedPorts, ws.PortID)
+		// Orchestration 재시도 정책 적용 (backoff 후 재배정 또는 실패 + 에스컬레이션)
+		outcome, err := e.service.HandleWorkerFailure(workerSessionID, result.ErrorMessage)
+		if err != nil {
+			return err
+		}
+		state.RetryCount[ws.PortID] = outcome.Attempt
+		if outcome.Retrying {
 			if state.Graph != nil {
-				state.Graph.MarkFailed(ws.PortID)
+				state.Graph.Nodes[ws.PortID].Status = "pending"
+			}
+			if outcome.RetryAt != nil {
+				e.scheduleRetry(state.OrchestrationID, *outcome.RetryAt)
 			}
 		} else {
-			// Reset to pending for retry
-			e.service.UpdatePortStatus(state.OrchestrationID, ws.PortID, "pending")
+			state.FailedPorts = append(state.FailedPorts, ws.PortID)
 			if state.Graph != nil {
-				state.Graph.Nodes[ws.PortID].Status = "pending"
+				state.Graph.MarkFailed(ws.PortID)
 			}
 		}
 	}
@@ -306,11 +379,37 @@ func (e *Executor) HandleWorkerComplete(workerSessionID string, result WorkerPai
 	}
 
 	// Process next ready ports using graph
-	return e.processReadyPorts(state, "")
+	return e.processReadyPorts(state, state.ProjectRoot)
+}
+
+// scheduleRetry wakes the executor once a retry backoff has elapsed.
+// 재시도 대기 중인 포트는 다른 워커가 끝나지 않으면 배정되지 않으므로 타이머로 다시 배정합니다.
+func (e *Executor) scheduleRetry(orchestrationID string, retryAt time.Time) {
+	time.AfterFunc(time.Until(retryAt), func() {
+		e.Tick(orchestrationID, "")
+	})
+}
+
+// Tick dispatches ports whose retry backoff has elapsed (projectRoot가 비어 있으면 Start 시의 경로)
+func (e *Executor) Tick(orchestrationID, projectRoot string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, err := e.getState(orchestrationID)
+	if err != nil {
+		return err
+	}
+	if projectRoot == "" {
+		projectRoot = state.ProjectRoot
+	}
+	return e.processReadyPorts(state, projectRoot)
 }
 
 // HandleMessage handles incoming messages for orchestration
 func (e *Executor) HandleMessage(msg *message.Message) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	switch msg.Subtype {
 	case message.SubtypeTaskComplete:
 		return e.handleTaskComplete(msg)
@@ -332,6 +431,11 @@ func (e *Executor) handleTaskComplete(msg *message.Message) error {
 		return err
 	}
 
+	// 단일 워커는 테스트 단계 없이 바로 완료
+	if ws.WorkerType == WorkerTypeSingle {
+		return e.handleWorkerComplete(ws.ID, WorkerPairResult{Success: true})
+	}
+
 	// If this is impl complete, wait for test
 	if msg.FromSession == ws.ImplSessionID {
 		e.service.UpdateWorkerStatus(ws.ID, "running", "testing")
@@ -380,7 +484,7 @@ func (e *Executor) handleTaskFailed(msg *message.Message) error {
 		}
 	}
 
-	return e.HandleWorkerComplete(ws.ID, result)
+	return e.handleWorkerComplete(ws.ID, result)
 }
 
 func (e *Executor) handleTestPass(msg *message.Message) error {
@@ -397,7 +501,7 @@ func (e *Executor) handleTestPass(msg *message.Message) error {
 		result.TestResult = payloadData
 	}
 
-	return e.HandleWorkerComplete(ws.ID, result)
+	return e.handleWorkerComplete(ws.ID, result)
 }
 
 func (e *Executor) handleTestFail(msg *message.Message) error {
@@ -424,16 +528,26 @@ func (e *Executor) handleTestFail(msg *message.Message) error {
 		return fmt.Errorf("실행 상태를 찾을 수 없습니다")
 	}
 
+	// Impl/Test 피드백 루프가 있으면 그 한도(재시도 정책)를, 없으면 실행기 설정을 따름
 	retryKey := ws.PortID + ":test"
 	state.RetryCount[retryKey]++
+	exceeded := state.RetryCount[retryKey] >= e.config.MaxRetries
+
+	fb := NewFeedbackService(e.service.db)
+	if loop, _ := fb.GetActiveLoopForPort(ws.PortID); loop != nil {
+		fb.IncrementRetry(loop.ID)
+		loop.CurrentRetry++
+		state.RetryCount[retryKey] = loop.CurrentRetry
+		exceeded = fb.ShouldEscalate(loop)
+	}
 
-	if state.RetryCount[retryKey] >= e.config.MaxRetries {
+	if exceeded {
 		// Max retries exceeded - escalate
 		result := WorkerPairResult{
 			Success:      false,
 			ErrorMessage: fmt.Sprintf("테스트 실패 %d회 - 사용자 개입 필요", state.RetryCount[retryKey]),
 		}
-		return e.HandleWorkerComplete(ws.ID, result)
+		return e.handleWorkerComplete(ws.ID, result)
 	}
 
 	// Send fix request to impl worker
@@ -487,14 +601,20 @@ func (e *Executor) completeOrchestration(state *ExecutionState) error {
 	state.Status = status
 	_, err := e.service.db.Exec(`
 		UPDATE orchestration_ports SET status = ?, completed_at = CURRENT_TIMESTAMP
-		WHERE id = ?
-	`, status, state.OrchestrationID)
+		WHERE id = ? AND status != ?
+	`, status, state.OrchestrationID, StatusCancelled)
 
 	return err
 }
 
 // GetState returns the execution state for an orchestration
 func (e *Executor) GetState(orchestrationID string) (*ExecutionState, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.getState(orchestrationID)
+}
+
+func (e *Executor) getState(orchestrationID string) (*ExecutionState, error) {
 	state, ok := e.states[orchestrationID]
 	if !ok {
 		return nil, fmt.Errorf("실행 상태를 찾을 수 없습니다: %s", orchestrationID)
@@ -502,71 +622,67 @@ func (e *Executor) GetState(orchestrationID string) (*ExecutionState, error) {
 	return state, nil
 }
 
-// Pause pauses an orchestration
+// Pause pauses an orchestration (see Service.PauseOrchestration)
 func (e *Executor) Pause(orchestrationID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	state, ok := e.states[orchestrationID]
 	if !ok {
 		return fmt.Errorf("실행 상태를 찾을 수 없습니다: %s", orchestrationID)
 	}
 
+	if _, err := e.service.PauseOrchestration(orchestrationID, ""); err != nil {
+		return err
+	}
 	state.Status = StatusPaused
-	_, err := e.service.db.Exec(`UPDATE orchestration_ports SET status = ? WHERE id = ?`,
-		StatusPaused, orchestrationID)
-	return err
+	return nil
 }
 
-// Resume resumes a paused orchestration
+// Resume resumes a paused orchestration and dispatches ready ports
 func (e *Executor) Resume(orchestrationID, projectRoot string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	state, ok := e.states[orchestrationID]
 	if !ok {
 		return fmt.Errorf("실행 상태를 찾을 수 없습니다: %s", orchestrationID)
 	}
 
-	state.Status = StatusRunning
-	_, err := e.service.db.Exec(`UPDATE orchestration_ports SET status = ? WHERE id = ?`,
-		StatusRunning, orchestrationID)
-	if err != nil {
-		return err
-	}
-
-	op, err := e.service.GetOrchestration(orchestrationID)
-	if err != nil {
+	if _, err := e.service.ResumeOrchestration(orchestrationID, ""); err != nil {
 		return err
 	}
+	state.Status = StatusRunning
 
-	return e.processNextPorts(state, op, projectRoot)
+	return e.processReadyPorts(state, projectRoot)
 }
 
-// Cancel cancels an orchestration
+// Cancel cancels an orchestration (see Service.CancelOrchestration)
 func (e *Executor) Cancel(orchestrationID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	state, ok := e.states[orchestrationID]
 	if !ok {
 		return fmt.Errorf("실행 상태를 찾을 수 없습니다: %s", orchestrationID)
 	}
 
-	// End all active workers
-	for _, wsID := range state.ActiveWorkers {
-		e.service.CompleteWorkerSession(wsID, WorkerPairResult{
-			Success:      false,
-			ErrorMessage: "Orchestration cancelled",
-		})
+	if _, err := e.service.CancelOrchestration(orchestrationID, ""); err != nil {
+		return err
 	}
 
 	state.Status = StatusCancelled
 	state.ActiveWorkers = nil
-
-	_, err := e.service.db.Exec(`
-		UPDATE orchestration_ports SET status = ?, completed_at = CURRENT_TIMESTAMP
-		WHERE id = ?
-	`, StatusCancelled, orchestrationID)
-
 	delete(e.states, orchestrationID)
-	return err
+	return nil
 }
 
 // ExportState exports the execution state as JSON
 func (e *Executor) ExportState(orchestrationID string) (string, error) {
-	state, err := e.GetState(orchestrationID)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, err := e.getState(orchestrationID)
 	if err != nil {
 		return "", err
 	}
@@ -581,7 +697,10 @@ func (e *Executor) ExportState(orchestrationID string) (string, error) {
 
 // GetGraphStats returns dependency graph statistics
 func (e *Executor) GetGraphStats(orchestrationID string) (*GraphStats, error) {
-	state, err := e.GetState(orchestrationID)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, err := e.getState(orchestrationID)
 	if err != nil {
 		return nil, err
 	}
@@ -595,7 +714,10 @@ func (e *Executor) GetGraphStats(orchestrationID string) (*GraphStats, error) {
 
 // GetCriticalPath returns the critical path for an orchestration
 func (e *Executor) GetCriticalPath(orchestrationID string) ([]string, error) {
-	state, err := e.GetState(orchestrationID)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, err := e.getState(orchestrationID)
 	if err != nil {
 		return nil, err
 	}
@@ -609,7 +731,10 @@ func (e *Executor) GetCriticalPath(orchestrationID string) ([]string, error) {
 
 // GetExecutionLevels returns the topological levels for parallel execution
 func (e *Executor) GetExecutionLevels(orchestrationID string) ([][]string, error) {
-	state, err := e.GetState(orchestrationID)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, err := e.getState(orchestrationID)
 	if err != nil {
 		return nil, err
 	}
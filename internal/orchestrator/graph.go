@@ -30,21 +30,25 @@ func NewDependencyGraph(ports []AtomicPort) *DependencyGraph {
 
 	// Initialize nodes
 	for _, p := range ports {
+		status := p.Status
+		if status == "" {
+			status = "pending" // 아직 배정되지 않은 포트 (GetNextPort와 동일)
+		}
 		g.Nodes[p.PortID] = &PortNode{
 			PortID:    p.PortID,
 			Order:     p.Order,
-			Status:    p.Status,
+			Status:    status,
 			DependsOn: p.DependsOn,
 		}
 		g.Edges[p.PortID] = p.DependsOn
 		g.InDegree[p.PortID] = 0
 	}
 
-	// Calculate in-degrees
-	for _, deps := range g.Edges {
+	// Calculate in-degrees (그래프 안의 의존 포트 수)
+	for portID, deps := range g.Edges {
 		for _, dep := range deps {
 			if _, exists := g.Nodes[dep]; exists {
-				g.InDegree[dep]++
+				g.InDegree[portID]++
 			}
 		}
 	}
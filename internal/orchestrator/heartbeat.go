@@ -0,0 +1,156 @@
+package orchestrator
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/message"
+)
+
+// SubstatusStalled marks a running worker that has been silent beyond the stall window
+const SubstatusStalled = "stalled"
+
+// DefaultStallWindow is the silence window after which a worker is considered stalled
+const DefaultStallWindow = 10 * time.Minute
+
+// WorkerHeartbeat is the liveness signal of a running worker
+type WorkerHeartbeat struct {
+	WorkerID       string    `json:"worker_id"`
+	PortID         string    `json:"port_id"`
+	LastActivity   time.Time `json:"last_activity"`
+	SilenceSeconds int64     `json:"silence_seconds"`
+	RecentEvents   int       `json:"recent_events"` // stall window 내 이벤트/메시지 수
+	Stalled        bool      `json:"stalled"`
+}
+
+// WorkerStalledPayload is the payload of a worker_stalled report
+type WorkerStalledPayload struct {
+	WorkerID       string `json:"worker_id"`
+	PortID         string `json:"port_id"`
+	SilenceSeconds int64  `json:"silence_seconds"`
+}
+
+// workerSessionIDs returns the underlying session IDs of a worker
+func workerSessionIDs(ws *WorkerSession) []string {
+	var ids []string
+	if ws.ImplSessionID != "" {
+		ids = append(ids, ws.ImplSessionID)
+	}
+	if ws.TestSessionID != "" {
+		ids = append(ids, ws.TestSessionID)
+	}
+	return ids
+}
+
+// lastActivity returns the latest activity of a worker and the activity count since `since`.
+// 워커 세션의 이벤트, 워커가 보낸 메시지, 워커 상태 갱신 중 가장 최근 시각을 사용합니다.
+func (s *Service) lastActivity(ws *WorkerSession, since time.Time) (time.Time, int) {
+	last := ws.UpdatedAt
+	if ws.CreatedAt.After(last) {
+		last = ws.CreatedAt
+	}
+	count := 0
+
+	for _, id := range workerSessionIDs(ws) {
+		var eventAt time.Time
+		if err := s.db.QueryRow(`
+			SELECT created_at FROM session_events WHERE session_id = ? ORDER BY id DESC LIMIT 1
+		`, id).Scan(&eventAt); err == nil && eventAt.After(last) {
+			last = eventAt
+		}
+
+		var msgAt time.Time
+		if err := s.db.QueryRow(`
+			SELECT created_at FROM messages WHERE from_session = ? ORDER BY created_at DESC LIMIT 1
+		`, id).Scan(&msgAt); err == nil && msgAt.After(last) {
+			last = msgAt
+		}
+
+		var n int
+		s.db.QueryRow(`
+			SELECT (SELECT COUNT(*) FROM session_events WHERE session_id = ? AND created_at >= ?)
+			     + (SELECT COUNT(*) FROM messages WHERE from_session = ? AND created_at >= ?)
+		`, id, since.UTC().Format("2006-01-02 15:04:05"), id, since).Scan(&n)
+		count += n
+	}
+
+	return last, count
+}
+
+// CheckWorkerHeartbeats derives liveness of running workers from their event/message cadence.
+// window 동안 활동이 없으면 substatus를 stalled로 표시하고 Operator 세션에 보고하며,
+// 활동이 재개되면 stalled 표시를 해제합니다. window가 0 이하면 DefaultStallWindow를 사용합니다.
+func (s *Service) CheckWorkerHeartbeats(orchestrationID string, window time.Duration) ([]*WorkerHeartbeat, error) {
+	if window <= 0 {
+		window = DefaultStallWindow
+	}
+
+	workers, err := s.ListWorkerSessions(orchestrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var beats []*WorkerHeartbeat
+	for _, ws := range workers {
+		if ws.Status != "running" {
+			continue
+		}
+
+		last, recent := s.lastActivity(ws, now.Add(-window))
+		silence := now.Sub(last)
+		hb := &WorkerHeartbeat{
+			WorkerID:       ws.ID,
+			PortID:         ws.PortID,
+			LastActivity:   last,
+			SilenceSeconds: int64(silence.Seconds()),
+			RecentEvents:   recent,
+			Stalled:        silence > window,
+		}
+		beats = append(beats, hb)
+
+		switch {
+		case hb.Stalled && ws.Substatus != SubstatusStalled:
+			s.markStalled(ws, hb)
+		case !hb.Stalled && ws.Substatus == SubstatusStalled:
+			s.UpdateWorkerStatus(ws.ID, "running", "")
+		}
+	}
+
+	return beats, nil
+}
+
+// markStalled flags a worker as stalled and reports it to the operator session.
+// updated_at은 heartbeat 계산에 쓰이므로 갱신하지 않습니다.
+func (s *Service) markStalled(ws *WorkerSession, hb *WorkerHeartbeat) {
+	s.db.Exec(`UPDATE worker_sessions SET substatus = ? WHERE id = ?`, SubstatusStalled, ws.ID)
+
+	if s.messageStore == nil {
+		return
+	}
+	ids := workerSessionIDs(ws)
+	if len(ids) == 0 {
+		return
+	}
+
+	var operator sql.NullString
+	s.db.QueryRow(`SELECT COALESCE(parent_id, parent_session) FROM sessions WHERE id = ?`, ids[0]).Scan(&operator)
+	if !operator.Valid || operator.String == "" {
+		return
+	}
+
+	s.messageStore.Send(&message.Message{
+		ConversationID: ws.PortID,
+		FromSession:    "system", // 워커 세션 명의로 보내면 heartbeat로 집계됨
+		ToSession:      operator.String,
+		Type:           message.TypeReport,
+		Subtype:        message.SubtypeWorkerStalled,
+		Payload: WorkerStalledPayload{
+			WorkerID:       ws.ID,
+			PortID:         ws.PortID,
+			SilenceSeconds: hb.SilenceSeconds,
+		},
+		PortID:   ws.PortID,
+		Priority: 2,
+	})
+}
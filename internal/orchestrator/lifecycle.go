@@ -0,0 +1,339 @@
+package orchestrator
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/server/events"
+)
+
+// Lifecycle actions
+const (
+	ActionPause  = "pause"
+	ActionResume = "resume"
+	ActionCancel = "cancel"
+)
+
+// Worker statuses set by lifecycle controls
+const (
+	WorkerStatusPaused    = "paused"
+	WorkerStatusCancelled = "cancelled"
+)
+
+// inFlightWorkerStatuses are worker statuses that still hold a port
+var inFlightWorkerStatuses = []string{"pending", "running", "blocked"}
+
+// LifecycleEvent records a pause, resume or cancel of an orchestration
+type LifecycleEvent struct {
+	ID              int64               `json:"id"`
+	OrchestrationID string              `json:"orchestration_id"`
+	Action          string              `json:"action"`
+	FromStatus      OrchestrationStatus `json:"from_status"`
+	ToStatus        OrchestrationStatus `json:"to_status"`
+	Reason          string              `json:"reason,omitempty"`
+	Workers         []string            `json:"workers,omitempty"`        // 표시한 워커 세션
+	ReleasedLocks   []string            `json:"released_locks,omitempty"` // 해제한 Lock 리소스
+	CreatedAt       time.Time           `json:"created_at"`
+}
+
+// Dispatchable reports whether new ports may be dispatched for an orchestration.
+// 실행 중(running)일 때만 새 포트를 배정하며, 일시정지/취소는 다른 프로세스(CLI, API)에서도 반영됩니다.
+func (s *Service) Dispatchable(id string) bool {
+	var status string
+	if err := s.db.QueryRow(`SELECT status FROM orchestration_ports WHERE id = ?`, id).Scan(&status); err != nil {
+		return false
+	}
+	return OrchestrationStatus(status) == StatusRunning
+}
+
+// PauseOrchestration stops dispatching new ports.
+// 진행 중인 워커는 paused로 표시하고, 워커 세션이 잡고 있던 Lock을 해제합니다.
+func (s *Service) PauseOrchestration(id, reason string) (*LifecycleEvent, error) {
+	return s.transition(id, ActionPause, reason)
+}
+
+// ResumeOrchestration resumes a paused orchestration and its paused workers
+func (s *Service) ResumeOrchestration(id, reason string) (*LifecycleEvent, error) {
+	return s.transition(id, ActionResume, reason)
+}
+
+// CancelOrchestration cancels an orchestration.
+// 진행 중이거나 일시정지된 워커는 cancelled로 종료하고, 실행 중이던 포트는 cancelled로 표시하며 Lock을 해제합니다.
+func (s *Service) CancelOrchestration(id, reason string) (*LifecycleEvent, error) {
+	return s.transition(id, ActionCancel, reason)
+}
+
+// lifecycleTarget returns the target status of an action, or an error if the action is not allowed from `from`
+func lifecycleTarget(action string, from OrchestrationStatus) (OrchestrationStatus, error) {
+	switch action {
+	case ActionPause:
+		if from == StatusRunning || from == StatusPending {
+			return StatusPaused, nil
+		}
+	case ActionResume:
+		if from == StatusPaused {
+			return StatusRunning, nil
+		}
+	case ActionCancel:
+		if from == StatusRunning || from == StatusPending || from == StatusPaused {
+			return StatusCancelled, nil
+		}
+	default:
+		return "", errcode.New(errcode.KindValidation, "알 수 없는 동작입니다: %s", action)
+	}
+	return "", errcode.New(errcode.KindConflict, "%s 상태의 Orchestration은 %s할 수 없습니다", from, actionLabels[action])
+}
+
+var actionLabels = map[string]string{
+	ActionPause:  "일시정지",
+	ActionResume: "재개",
+	ActionCancel: "취소",
+}
+
+// lifecycleHook runs between reading the orchestration and applying a transition (테스트에서 동시 전환을 재현할 때 사용)
+var lifecycleHook func(action string)
+
+func (s *Service) transition(id, action, reason string) (*LifecycleEvent, error) {
+	op, err := s.GetOrchestration(id)
+	if err != nil {
+		return nil, errcode.Wrap(errcode.KindNotFound, err)
+	}
+	to, err := lifecycleTarget(action, op.Status)
+	if err != nil {
+		return nil, err
+	}
+	if action == ActionResume {
+		// 시작 전에 일시정지했다면 대기 상태로 되돌림
+		var before string
+		s.db.QueryRow(`
+			SELECT from_status FROM orchestration_lifecycle
+			WHERE orchestration_id = ? AND action = ? ORDER BY id DESC LIMIT 1
+		`, id, ActionPause).Scan(&before)
+		if OrchestrationStatus(before) == StatusPending {
+			to = StatusPending
+		}
+	}
+
+	if lifecycleHook != nil {
+		lifecycleHook(action)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 읽은 상태 그대로일 때만 전환: 동시에 들어온 일시정지와 취소 중 하나만 적용
+	res, err := tx.Exec(`UPDATE orchestration_ports SET status = ? WHERE id = ? AND status = ?`, to, id, op.Status)
+	if err != nil {
+		return nil, fmt.Errorf("Orchestration 상태 갱신 실패: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, errcode.New(errcode.KindConflict, "Orchestration 상태가 그 사이 변경되어 %s할 수 없습니다: %s", actionLabels[action], id)
+	}
+
+	event := &LifecycleEvent{
+		OrchestrationID: id,
+		Action:          action,
+		FromStatus:      op.Status,
+		ToStatus:        to,
+		Reason:          reason,
+	}
+
+	// 대상 워커: 일시정지는 진행 중인 워커, 재개는 일시정지된 워커, 취소는 둘 다
+	var workerStatuses []string
+	switch action {
+	case ActionPause:
+		workerStatuses = inFlightWorkerStatuses
+	case ActionResume:
+		workerStatuses = []string{WorkerStatusPaused}
+	case ActionCancel:
+		workerStatuses = append(append([]string{}, inFlightWorkerStatuses...), WorkerStatusPaused)
+	}
+	workers, err := workersWithStatus(tx, id, workerStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, ws := range workers {
+		event.Workers = append(event.Workers, ws.ID)
+		switch action {
+		case ActionPause:
+			// 일시정지 전 상태는 paused_from에 보관 (substatus는 그대로 유지)
+			_, err = tx.Exec(`UPDATE worker_sessions SET status = ?, paused_from = ?, updated_at = ? WHERE id = ?`,
+				WorkerStatusPaused, ws.Status, now, ws.ID)
+		case ActionResume:
+			prev := ws.pausedFrom
+			if prev == "" {
+				prev = "running"
+			}
+			_, err = tx.Exec(`UPDATE worker_sessions SET status = ?, paused_from = NULL, updated_at = ? WHERE id = ?`,
+				prev, now, ws.ID)
+		case ActionCancel:
+			result, _ := json.Marshal(WorkerPairResult{
+				Success:      false,
+				ErrorMessage: cancelMessage(reason),
+				EnvNames:     envNamesOf(ws.Result),
+			})
+			_, err = tx.Exec(`UPDATE worker_sessions SET status = ?, result = ?, updated_at = ? WHERE id = ?`,
+				WorkerStatusCancelled, string(result), now, ws.ID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("워커 상태 갱신 실패: %w", err)
+		}
+
+		if action != ActionResume {
+			released, err := releaseSessionLocks(tx, workerSessionIDs(ws))
+			if err != nil {
+				return nil, err
+			}
+			event.ReleasedLocks = append(event.ReleasedLocks, released...)
+		}
+		if action == ActionCancel {
+			for _, sid := range workerSessionIDs(ws) {
+				if _, err := tx.Exec(`
+					UPDATE sessions SET status = ?, ended_at = CURRENT_TIMESTAMP
+					WHERE id = ? AND status IN ('running', 'paused')
+				`, WorkerStatusCancelled, sid); err != nil {
+					return nil, fmt.Errorf("워커 세션 종료 실패: %w", err)
+				}
+			}
+		}
+	}
+
+	if action == ActionCancel {
+		// 실행 중이던 포트는 cancelled, 나머지(대기/완료/실패)는 그대로 둠 (트랜잭션 안에서 다시 읽음)
+		var portsJSON string
+		if err := tx.QueryRow(`SELECT COALESCE(atomic_ports, '[]') FROM orchestration_ports WHERE id = ?`, id).Scan(&portsJSON); err != nil {
+			return nil, fmt.Errorf("Orchestration 조회 실패: %w", err)
+		}
+		json.Unmarshal([]byte(portsJSON), &op.AtomicPorts)
+		for i := range op.AtomicPorts {
+			if op.AtomicPorts[i].Status == "running" {
+				op.AtomicPorts[i].Status = string(StatusCancelled)
+			}
+		}
+		updated, _ := json.Marshal(op.AtomicPorts)
+		if _, err := tx.Exec(`
+			UPDATE orchestration_ports SET atomic_ports = ?, completed_at = CURRENT_TIMESTAMP
+			WHERE id = ?
+		`, string(updated), id); err != nil {
+			return nil, fmt.Errorf("Orchestration 상태 갱신 실패: %w", err)
+		}
+	}
+
+	workersJSON, _ := json.Marshal(event.Workers)
+	locksJSON, _ := json.Marshal(event.ReleasedLocks)
+	res, err = tx.Exec(`
+		INSERT INTO orchestration_lifecycle (orchestration_id, action, from_status, to_status, reason, workers, released_locks)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, id, action, event.FromStatus, event.ToStatus, nullableString(reason), string(workersJSON), string(locksJSON))
+	if err != nil {
+		return nil, fmt.Errorf("수명주기 기록 실패: %w", err)
+	}
+	event.ID, _ = res.LastInsertId()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("Orchestration %s 실패: %w", actionLabels[action], err)
+	}
+	event.CreatedAt = now
+
+	op.Status = to
+	s.publishPortEvent(events.EventOrchestrationProgress, op, "", events.OrchestrationProgressData{})
+	return event, nil
+}
+
+// LifecycleHistory returns the pause/resume/cancel records of an orchestration, oldest first
+func (s *Service) LifecycleHistory(id string) ([]*LifecycleEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, orchestration_id, action, from_status, to_status, COALESCE(reason, ''),
+		       COALESCE(workers, '[]'), COALESCE(released_locks, '[]'), created_at
+		FROM orchestration_lifecycle WHERE orchestration_id = ?
+		ORDER BY id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("수명주기 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*LifecycleEvent
+	for rows.Next() {
+		var e LifecycleEvent
+		var workersJSON, locksJSON string
+		if err := rows.Scan(&e.ID, &e.OrchestrationID, &e.Action, &e.FromStatus, &e.ToStatus,
+			&e.Reason, &workersJSON, &locksJSON, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		json.Unmarshal([]byte(workersJSON), &e.Workers)
+		json.Unmarshal([]byte(locksJSON), &e.ReleasedLocks)
+		events = append(events, &e)
+	}
+	return events, nil
+}
+
+func workersWithStatus(tx *sql.Tx, orchestrationID string, statuses []string) ([]*WorkerSession, error) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(statuses)), ",")
+	args := []interface{}{orchestrationID}
+	for _, st := range statuses {
+		args = append(args, st)
+	}
+
+	rows, err := tx.Query(`
+		SELECT id, port_id, COALESCE(impl_session_id, ''), COALESCE(test_session_id, ''),
+		       status, COALESCE(substatus, ''), COALESCE(result, ''), COALESCE(paused_from, '')
+		FROM worker_sessions
+		WHERE orchestration_id = ? AND status IN (`+placeholders+`)
+		ORDER BY created_at
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("워커 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var workers []*WorkerSession
+	for rows.Next() {
+		ws := &WorkerSession{OrchestrationID: orchestrationID}
+		if err := rows.Scan(&ws.ID, &ws.PortID, &ws.ImplSessionID, &ws.TestSessionID,
+			&ws.Status, &ws.Substatus, &ws.Result, &ws.pausedFrom); err != nil {
+			return nil, err
+		}
+		workers = append(workers, ws)
+	}
+	return workers, nil
+}
+
+// releaseSessionLocks releases all locks held by the given sessions and returns the resources
+func releaseSessionLocks(tx *sql.Tx, sessionIDs []string) ([]string, error) {
+	var released []string
+	for _, sid := range sessionIDs {
+		rows, err := tx.Query(`SELECT resource FROM locks WHERE session_id = ? ORDER BY resource`, sid)
+		if err != nil {
+			return nil, fmt.Errorf("Lock 조회 실패: %w", err)
+		}
+		for rows.Next() {
+			var r string
+			if rows.Scan(&r) == nil {
+				released = append(released, r)
+			}
+		}
+		rows.Close()
+
+		if _, err := tx.Exec(`DELETE FROM locks WHERE session_id = ?`, sid); err != nil {
+			return nil, fmt.Errorf("Lock 해제 실패: %w", err)
+		}
+	}
+	return released, nil
+}
+
+func cancelMessage(reason string) string {
+	if reason == "" {
+		return "Orchestration cancelled"
+	}
+	return "Orchestration cancelled: " + reason
+}
@@ -8,7 +8,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
 	"github.com/n0roo/pal-kit/internal/message"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/server/events"
 	"github.com/n0roo/pal-kit/internal/session"
 )
 
@@ -40,6 +43,11 @@ type AtomicPort struct {
 	Order     int      `json:"order"`
 	DependsOn []string `json:"depends_on,omitempty"`
 	Status    string   `json:"status,omitempty"`
+
+	// 재시도 상태 (RetryPolicy 참고)
+	Attempts  int        `json:"attempts,omitempty"`   // 실패한 시도 수
+	RetryAt   *time.Time `json:"retry_at,omitempty"`   // 이 시각 이후에 재배정
+	LastError string     `json:"last_error,omitempty"` // 마지막 실패 사유
 }
 
 // OrchestrationPort represents an orchestration port definition
@@ -54,6 +62,7 @@ type OrchestrationPort struct {
 	CreatedAt       time.Time     `json:"created_at"`
 	StartedAt       *time.Time    `json:"started_at,omitempty"`
 	CompletedAt     *time.Time    `json:"completed_at,omitempty"`
+	RetryPolicy     RetryPolicy   `json:"retry_policy"`
 }
 
 // WorkerSession represents a worker session
@@ -67,8 +76,12 @@ type WorkerSession struct {
 	Status          string     `json:"status"`
 	Substatus       string     `json:"substatus,omitempty"`
 	Result          string     `json:"result,omitempty"` // JSON
+	EnvNames        []string   `json:"env_names,omitempty"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
+	FeedbackLoopID  string     `json:"feedback_loop_id,omitempty"` // Impl/Test 쌍 생성 시에만 설정
+
+	pausedFrom string // Orchestration 일시정지 전 상태 (수명주기 전환에서만 읽음)
 }
 
 // WorkerPairResult holds the result of a worker pair execution
@@ -77,6 +90,8 @@ type WorkerPairResult struct {
 	TestResult   interface{} `json:"test_result,omitempty"`
 	Success      bool        `json:"success"`
 	ErrorMessage string      `json:"error_message,omitempty"`
+	// 워커 프로세스에 주입된 환경 변수 이름 (재현용, 값은 기록하지 않음)
+	EnvNames []string `json:"env_names,omitempty"`
 }
 
 // Service handles orchestration operations
@@ -97,7 +112,82 @@ func NewService(database *db.DB, sessionSvc *session.Service, msgStore *message.
 
 // CreateOrchestration creates a new orchestration port
 func (s *Service) CreateOrchestration(title, description string, atomicPorts []AtomicPort) (*OrchestrationPort, error) {
+	return insertOrchestration(s.db, uuid.New().String(), title, description, atomicPorts, nil)
+}
+
+// CreateOrchestrationTx creates an orchestration port within the caller's transaction
+func (s *Service) CreateOrchestrationTx(tx *sql.Tx, title, description string, atomicPorts []AtomicPort) (*OrchestrationPort, error) {
+	return insertOrchestration(tx, uuid.New().String(), title, description, atomicPorts, nil)
+}
+
+// CreateOrchestrationIdempotent creates an orchestration once per idempotency key.
+// 같은 키로 재요청하면 새로 생성하지 않고 기존 Orchestration을 반환합니다 (created=false).
+func (s *Service) CreateOrchestrationIdempotent(key, title, description string, atomicPorts []AtomicPort) (*OrchestrationPort, bool, error) {
+	return s.CreateOrchestrationWithPolicy(key, title, description, atomicPorts, nil)
+}
+
+// CreateOrchestrationWithPolicy is CreateOrchestrationIdempotent with a retry policy stored in the same insert.
+// policy가 nil이면 기본 정책을 사용하고, 같은 키의 재요청이 다른 정책을 지정하면 충돌로 거부합니다.
+func (s *Service) CreateOrchestrationWithPolicy(key, title, description string, atomicPorts []AtomicPort, policy *RetryPolicy) (*OrchestrationPort, bool, error) {
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return nil, false, err
+		}
+	}
+	if key == "" {
+		op, err := insertOrchestration(s.db, uuid.New().String(), title, description, atomicPorts, policy)
+		return op, err == nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, false, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 키를 먼저 선점하여 동시 재시도도 하나의 Orchestration으로 수렴
 	id := uuid.New().String()
+	if _, err := tx.Exec(`
+		INSERT OR IGNORE INTO orchestration_idempotency_keys (key, orchestration_id) VALUES (?, ?)
+	`, key, id); err != nil {
+		return nil, false, fmt.Errorf("멱등성 키 저장 실패: %w", err)
+	}
+
+	var ownerID string
+	if err := tx.QueryRow(`
+		SELECT orchestration_id FROM orchestration_idempotency_keys WHERE key = ?
+	`, key).Scan(&ownerID); err != nil {
+		return nil, false, fmt.Errorf("멱등성 키 조회 실패: %w", err)
+	}
+
+	if ownerID != id {
+		tx.Rollback()
+		op, err := s.GetOrchestration(ownerID)
+		if err != nil {
+			return nil, false, err
+		}
+		if policy != nil && op.RetryPolicy != *policy {
+			return nil, false, errcode.New(errcode.KindConflict, "같은 멱등성 키의 Orchestration이 다른 재시도 정책으로 이미 생성되었습니다: %s", op.ID)
+		}
+		return op, false, nil
+	}
+
+	op, err := insertOrchestration(tx, id, title, description, atomicPorts, policy)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("Orchestration 생성 실패: %w", err)
+	}
+	return op, true, nil
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertOrchestration(ex execer, id, title, description string, atomicPorts []AtomicPort, policy *RetryPolicy) (*OrchestrationPort, error) {
 	now := time.Now()
 
 	portsJSON, err := json.Marshal(atomicPorts)
@@ -105,11 +195,19 @@ func (s *Service) CreateOrchestration(title, description string, atomicPorts []A
 		return nil, fmt.Errorf("포트 직렬화 실패: %w", err)
 	}
 
-	_, err = s.db.Exec(`
+	retryPolicy := DefaultRetryPolicy()
+	var policyJSON interface{}
+	if policy != nil {
+		retryPolicy = *policy
+		data, _ := json.Marshal(policy)
+		policyJSON = string(data)
+	}
+
+	_, err = ex.Exec(`
 		INSERT INTO orchestration_ports (
-			id, title, description, atomic_ports, status, progress_percent, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?)
-	`, id, title, description, string(portsJSON), StatusPending, 0, now)
+			id, title, description, atomic_ports, status, progress_percent, created_at, retry_policy
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, id, title, description, string(portsJSON), StatusPending, 0, now, policyJSON)
 
 	if err != nil {
 		return nil, fmt.Errorf("Orchestration 생성 실패: %w", err)
@@ -123,22 +221,37 @@ func (s *Service) CreateOrchestration(title, description string, atomicPorts []A
 		Status:          StatusPending,
 		ProgressPercent: 0,
 		CreatedAt:       now,
+		RetryPolicy:     retryPolicy,
 	}, nil
 }
 
+// PortEnvNames checks that the env vars declared by a port's spec are available and returns their names.
+// 값은 워커 프로세스를 'pal port run'으로 시작할 때 주입되며, 여기서는 필수 값 누락으로 워커가 실패하기 전에 배정을 막습니다.
+func (s *Service) PortEnvNames(portID, projectRoot string) ([]string, error) {
+	portSvc := port.NewService(s.db)
+	vars, err := portSvc.EnvFor(portID, projectRoot)
+	if err != nil || len(vars) == 0 {
+		return nil, err
+	}
+	if _, err := port.ResolveEnv(vars, projectRoot); err != nil {
+		return nil, err
+	}
+	return port.EnvNames(vars), nil
+}
+
 // GetOrchestration retrieves an orchestration by ID
 func (s *Service) GetOrchestration(id string) (*OrchestrationPort, error) {
 	var op OrchestrationPort
 	var atomicPortsJSON string
-	var description, currentPortID sql.NullString
+	var description, currentPortID, retryPolicy sql.NullString
 	var startedAt, completedAt sql.NullTime
 
 	err := s.db.QueryRow(`
 		SELECT id, title, description, atomic_ports, status, current_port_id,
-		       progress_percent, created_at, started_at, completed_at
+		       progress_percent, created_at, started_at, completed_at, retry_policy
 		FROM orchestration_ports WHERE id = ?
 	`, id).Scan(&op.ID, &op.Title, &description, &atomicPortsJSON, &op.Status,
-		&currentPortID, &op.ProgressPercent, &op.CreatedAt, &startedAt, &completedAt)
+		&currentPortID, &op.ProgressPercent, &op.CreatedAt, &startedAt, &completedAt, &retryPolicy)
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("Orchestration '%s'을(를) 찾을 수 없습니다", id)
@@ -163,6 +276,7 @@ func (s *Service) GetOrchestration(id string) (*OrchestrationPort, error) {
 	if err := json.Unmarshal([]byte(atomicPortsJSON), &op.AtomicPorts); err != nil {
 		return nil, fmt.Errorf("포트 역직렬화 실패: %w", err)
 	}
+	op.RetryPolicy = parseRetryPolicy(retryPolicy.String)
 
 	return &op, nil
 }
@@ -196,11 +310,15 @@ func (s *Service) GetNextPort(orchestrationID string) (*AtomicPort, error) {
 	}
 
 	// Find first pending port with satisfied dependencies
+	now := time.Now()
 	for i := range op.AtomicPorts {
 		port := &op.AtomicPorts[i]
 		if port.Status != "" && port.Status != "pending" {
 			continue
 		}
+		if !port.RetryReady(now) {
+			continue // 재시도 대기 중
+		}
 
 		// Check dependencies
 		allSatisfied := true
@@ -268,8 +386,13 @@ func (s *Service) UpdatePortStatus(orchestrationID, portID, status string) error
 		    completed_at = CASE WHEN ? = 'complete' THEN CURRENT_TIMESTAMP ELSE completed_at END
 		WHERE id = ?
 	`, string(portsJSON), portID, progress, orchStatus, orchStatus, orchestrationID)
+	if err != nil {
+		return err
+	}
 
-	return err
+	op.Status = orchStatus
+	s.publishPortEvent(portEventType(status), op, portID, events.OrchestrationProgressData{})
+	return nil
 }
 
 // SpawnWorkerPair creates a Worker + Test session pair
@@ -319,6 +442,15 @@ func (s *Service) SpawnWorkerPair(opts WorkerPairOptions) (*WorkerSession, error
 	if err != nil {
 		return nil, fmt.Errorf("Worker 세션 기록 실패: %w", err)
 	}
+	if err := s.RecordWorkerEnv(wsID, opts.EnvNames); err != nil {
+		return nil, err
+	}
+
+	// Impl/Test 직접 채널과 피드백 루프 (재시도 한도는 Orchestration 정책을 따름)
+	loop, err := s.startFeedbackLoop(opts.OrchestrationID, opts.PortID, implSession.ID, testSession.ID)
+	if err != nil {
+		return nil, err
+	}
 
 	ws := &WorkerSession{
 		ID:              wsID,
@@ -329,7 +461,9 @@ func (s *Service) SpawnWorkerPair(opts WorkerPairOptions) (*WorkerSession, error
 		TestSessionID:   testSession.ID,
 		Status:          "running",
 		CreatedAt:       now,
+		EnvNames:        opts.EnvNames,
 		UpdatedAt:       now,
+		FeedbackLoopID:  loop.ID,
 	}
 
 	// Send task assignment to impl worker
@@ -342,6 +476,7 @@ func (s *Service) SpawnWorkerPair(opts WorkerPairOptions) (*WorkerSession, error
 				PortID:      opts.PortID,
 				PortSpec:    opts.PortSpec,
 				Conventions: opts.Conventions,
+				ChannelID:   loop.ChannelID,
 			},
 		)
 	}
@@ -361,6 +496,7 @@ type WorkerPairOptions struct {
 	TestAgentID       string
 	TokenBudget       int
 	ProjectRoot       string
+	EnvNames          []string // 포트가 선언한 환경 변수 이름
 }
 
 // SpawnSingleWorker creates a single worker session
@@ -409,6 +545,9 @@ func (s *Service) SpawnSingleWorker(opts SingleWorkerOptions) (*WorkerSession, e
 	if err != nil {
 		return nil, fmt.Errorf("Worker 세션 기록 실패: %w", err)
 	}
+	if err := s.RecordWorkerEnv(wsID, opts.EnvNames); err != nil {
+		return nil, err
+	}
 
 	ws := &WorkerSession{
 		ID:              wsID,
@@ -419,6 +558,7 @@ func (s *Service) SpawnSingleWorker(opts SingleWorkerOptions) (*WorkerSession, e
 		TestSessionID:   testSessionID,
 		Status:          "running",
 		CreatedAt:       now,
+		EnvNames:        opts.EnvNames,
 		UpdatedAt:       now,
 	}
 
@@ -455,6 +595,7 @@ type SingleWorkerOptions struct {
 	AgentID           string
 	TokenBudget       int
 	ProjectRoot       string
+	EnvNames          []string // 포트가 선언한 환경 변수 이름
 }
 
 // GetWorkerSession retrieves a worker session by ID
@@ -491,6 +632,7 @@ func (s *Service) GetWorkerSession(id string) (*WorkerSession, error) {
 	}
 	if result.Valid {
 		ws.Result = result.String
+		ws.EnvNames = envNamesOf(result.String)
 	}
 
 	return &ws, nil
@@ -524,6 +666,11 @@ func (s *Service) UpdateWorkerStatus(id, status, substatus string) error {
 
 // CompleteWorkerSession completes a worker session with result
 func (s *Service) CompleteWorkerSession(id string, result WorkerPairResult) error {
+	if len(result.EnvNames) == 0 {
+		var prev sql.NullString
+		s.db.QueryRow(`SELECT result FROM worker_sessions WHERE id = ?`, id).Scan(&prev)
+		result.EnvNames = envNamesOf(prev.String)
+	}
 	resultJSON, _ := json.Marshal(result)
 	status := "complete"
 	if !result.Success {
@@ -544,6 +691,9 @@ func (s *Service) CompleteWorkerSession(id string, result WorkerPairResult) erro
 	if err != nil {
 		return nil // Worker session already updated
 	}
+	if result.Success {
+		s.closeFeedbackLoop(ws, FeedbackStatusSuccess)
+	}
 
 	if ws.ImplSessionID != "" {
 		s.sessionService.EndWithSummary(ws.ImplSessionID, status, result.ImplResult)
@@ -555,6 +705,47 @@ func (s *Service) CompleteWorkerSession(id string, result WorkerPairResult) erro
 	return nil
 }
 
+// RecordWorkerEnv records the env var names injected into a worker (값은 저장하지 않음).
+// 완료 전에는 result에 env_names만 기록되며, CompleteWorkerSession이 이를 유지합니다.
+func (s *Service) RecordWorkerEnv(id string, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	var prev sql.NullString
+	if err := s.db.QueryRow(`SELECT result FROM worker_sessions WHERE id = ?`, id).Scan(&prev); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("Worker 세션 '%s'을(를) 찾을 수 없습니다", id)
+		}
+		return err
+	}
+
+	result := map[string]interface{}{}
+	if prev.String != "" {
+		json.Unmarshal([]byte(prev.String), &result)
+	}
+	result["env_names"] = names
+	resultJSON, _ := json.Marshal(result)
+
+	_, err := s.db.Exec(`UPDATE worker_sessions SET result = ?, updated_at = ? WHERE id = ?`, string(resultJSON), time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("Worker 환경 변수 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// envNamesOf extracts env_names from a worker result JSON
+func envNamesOf(resultJSON string) []string {
+	if resultJSON == "" {
+		return nil
+	}
+	var r struct {
+		EnvNames []string `json:"env_names"`
+	}
+	json.Unmarshal([]byte(resultJSON), &r)
+	return r.EnvNames
+}
+
 // ListWorkerSessions lists worker sessions for an orchestration
 func (s *Service) ListWorkerSessions(orchestrationID string) ([]*WorkerSession, error) {
 	rows, err := s.db.Query(`
@@ -595,6 +786,7 @@ func (s *Service) ListWorkerSessions(orchestrationID string) ([]*WorkerSession,
 		}
 		if result.Valid {
 			ws.Result = result.String
+			ws.EnvNames = envNamesOf(result.String)
 		}
 
 		sessions = append(sessions, &ws)
@@ -607,7 +799,7 @@ func (s *Service) ListWorkerSessions(orchestrationID string) ([]*WorkerSession,
 func (s *Service) ListOrchestrations(status OrchestrationStatus, limit int) ([]*OrchestrationPort, error) {
 	query := `
 		SELECT id, title, description, atomic_ports, status, current_port_id,
-		       progress_percent, created_at, started_at, completed_at
+		       progress_percent, created_at, started_at, completed_at, retry_policy
 		FROM orchestration_ports
 	`
 	args := []interface{}{}
@@ -632,11 +824,11 @@ func (s *Service) ListOrchestrations(status OrchestrationStatus, limit int) ([]*
 	for rows.Next() {
 		var op OrchestrationPort
 		var atomicPortsJSON string
-		var description, currentPortID sql.NullString
+		var description, currentPortID, retryPolicy sql.NullString
 		var startedAt, completedAt sql.NullTime
 
 		err := rows.Scan(&op.ID, &op.Title, &description, &atomicPortsJSON, &op.Status,
-			&currentPortID, &op.ProgressPercent, &op.CreatedAt, &startedAt, &completedAt)
+			&currentPortID, &op.ProgressPercent, &op.CreatedAt, &startedAt, &completedAt, &retryPolicy)
 		if err != nil {
 			continue
 		}
@@ -655,6 +847,7 @@ func (s *Service) ListOrchestrations(status OrchestrationStatus, limit int) ([]*
 		}
 
 		json.Unmarshal([]byte(atomicPortsJSON), &op.AtomicPorts)
+		op.RetryPolicy = parseRetryPolicy(retryPolicy.String)
 		orchestrations = append(orchestrations, &op)
 	}
 
@@ -695,6 +888,10 @@ func (s *Service) GetOrchestrationStats(id string) (*OrchestrationStats, error)
 		stats.TotalWorkers++
 		if w.Status == "running" {
 			stats.ActiveWorkers++
+			if w.Substatus == SubstatusStalled {
+				stats.StalledWorkers++
+				stats.StalledPorts = append(stats.StalledPorts, w.PortID)
+			}
 		}
 	}
 
@@ -711,6 +908,9 @@ type OrchestrationStats struct {
 	ProgressPercent int `json:"progress_percent"`
 	TotalWorkers    int `json:"total_workers"`
 	ActiveWorkers   int `json:"active_workers"`
+	StalledWorkers  int `json:"stalled_workers"`
+
+	StalledPorts []string `json:"stalled_ports,omitempty"` // heartbeat이 끊긴 워커의 포트
 }
 
 func nullableString(s string) sql.NullString {
@@ -1,11 +1,19 @@
 package orchestrator
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
 	"github.com/n0roo/pal-kit/internal/message"
+	"github.com/n0roo/pal-kit/internal/pipeline"
+	"github.com/n0roo/pal-kit/internal/port"
 	"github.com/n0roo/pal-kit/internal/session"
 )
 
@@ -207,3 +215,731 @@ func TestOrchestrationStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateOrchestrationIdempotent(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database, nil, nil)
+	ports := []AtomicPort{{PortID: "port-001", Order: 1}}
+
+	first, created, err := svc.CreateOrchestrationIdempotent("retry-key-1", "Nightly", "", ports)
+	if err != nil {
+		t.Fatalf("Failed to create orchestration: %v", err)
+	}
+	if !created {
+		t.Error("First request should create a new orchestration")
+	}
+
+	second, created, err := svc.CreateOrchestrationIdempotent("retry-key-1", "Nightly", "", ports)
+	if err != nil {
+		t.Fatalf("Failed to replay orchestration: %v", err)
+	}
+	if created {
+		t.Error("Replayed request should not create a new orchestration")
+	}
+	if second.ID != first.ID {
+		t.Errorf("Expected existing orchestration %s, got %s", first.ID, second.ID)
+	}
+
+	other, created, err := svc.CreateOrchestrationIdempotent("retry-key-2", "Nightly", "", ports)
+	if err != nil {
+		t.Fatalf("Failed to create orchestration: %v", err)
+	}
+	if !created || other.ID == first.ID {
+		t.Error("Different key should create a new orchestration")
+	}
+
+	list, err := svc.ListOrchestrations("", 10)
+	if err != nil {
+		t.Fatalf("Failed to list orchestrations: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("Expected 2 orchestrations, got %d", len(list))
+	}
+}
+
+func TestCheckWorkerHeartbeats(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	sessionSvc := session.NewService(database)
+	msgStore := message.NewStore(database.DB)
+	svc := NewService(database, sessionSvc, msgStore)
+
+	orch, err := svc.CreateOrchestration("Heartbeat", "", []AtomicPort{{PortID: "port-001", Order: 1}})
+	if err != nil {
+		t.Fatalf("Failed to create orchestration: %v", err)
+	}
+	operator, err := sessionSvc.StartHierarchical(session.HierarchyStartOptions{Title: "operator", Type: session.TypeOperator})
+	if err != nil {
+		t.Fatalf("Failed to start operator: %v", err)
+	}
+	ws, err := svc.SpawnSingleWorker(SingleWorkerOptions{
+		OrchestrationID:   orch.ID,
+		OperatorSessionID: operator.ID,
+		PortID:            "port-001",
+		Title:             "worker",
+		WorkerType:        WorkerTypeImpl,
+	})
+	if err != nil {
+		t.Fatalf("Failed to spawn worker: %v", err)
+	}
+
+	// 1시간 전 활동으로 되돌림
+	old := time.Now().Add(-time.Hour)
+	database.Exec(`UPDATE worker_sessions SET created_at = ?, updated_at = ? WHERE id = ?`, old, old, ws.ID)
+	database.Exec(`UPDATE session_events SET created_at = ? WHERE session_id = ?`, old.UTC().Format("2006-01-02 15:04:05"), ws.ImplSessionID)
+
+	beats, err := svc.CheckWorkerHeartbeats(orch.ID, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("CheckWorkerHeartbeats failed: %v", err)
+	}
+	if len(beats) != 1 || !beats[0].Stalled {
+		t.Fatalf("Expected 1 stalled worker, got %+v", beats)
+	}
+
+	stats, _ := svc.GetOrchestrationStats(orch.ID)
+	if stats.StalledWorkers != 1 || len(stats.StalledPorts) != 1 || stats.StalledPorts[0] != "port-001" {
+		t.Errorf("Expected stalled port-001 in stats, got %+v", stats)
+	}
+
+	msgs, _ := msgStore.Receive(operator.ID, 10)
+	found := false
+	for _, m := range msgs {
+		if m.Subtype == message.SubtypeWorkerStalled {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected worker_stalled report to operator")
+	}
+
+	// 활동 재개 시 stalled 해제
+	sessionSvc.LogEvent(ws.ImplSessionID, session.EventFileEdit, `{}`)
+	beats, _ = svc.CheckWorkerHeartbeats(orch.ID, 10*time.Minute)
+	if len(beats) != 1 || beats[0].Stalled || beats[0].RecentEvents == 0 {
+		t.Errorf("Expected worker to recover, got %+v", beats)
+	}
+	if stats, _ := svc.GetOrchestrationStats(orch.ID); stats.StalledWorkers != 0 {
+		t.Errorf("Expected no stalled workers after recovery, got %d", stats.StalledWorkers)
+	}
+}
+
+func TestWorkerEnvNames(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	sessionSvc := session.NewService(database)
+	svc := NewService(database, sessionSvc, nil)
+
+	orch, err := svc.CreateOrchestration("Env", "", []AtomicPort{{PortID: "port-001", Order: 1}})
+	if err != nil {
+		t.Fatalf("Failed to create orchestration: %v", err)
+	}
+	ws, err := svc.SpawnSingleWorker(SingleWorkerOptions{
+		OrchestrationID: orch.ID,
+		PortID:          "port-001",
+		Title:           "worker",
+		WorkerType:      WorkerTypeImpl,
+		EnvNames:        []string{"DATABASE_URL", "STRIPE_KEY"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to spawn worker: %v", err)
+	}
+
+	got, _ := svc.GetWorkerSession(ws.ID)
+	if len(got.EnvNames) != 2 || got.EnvNames[0] != "DATABASE_URL" {
+		t.Errorf("Expected env names recorded at spawn, got %v", got.EnvNames)
+	}
+
+	if err := svc.CompleteWorkerSession(ws.ID, WorkerPairResult{Success: true}); err != nil {
+		t.Fatalf("Failed to complete worker: %v", err)
+	}
+	got, _ = svc.GetWorkerSession(ws.ID)
+	if len(got.EnvNames) != 2 || !strings.Contains(got.Result, `"env_names"`) {
+		t.Errorf("Expected env names kept in result, got %s", got.Result)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if _, err := ParseAgentPin("builder"); err == nil {
+		t.Error("ParseAgentPin should reject missing version")
+	}
+	pin, err := ParseAgentPin("builder@5")
+	if err != nil || pin.Agent != "builder" || pin.Version != 5 {
+		t.Fatalf("ParseAgentPin = %+v, %v", pin, err)
+	}
+	if got := PinnedAgentID([]AgentPin{pin}, "builder"); got != "builder@5" {
+		t.Errorf("PinnedAgentID = %s, want builder@5", got)
+	}
+	if got := PinnedAgentID([]AgentPin{pin}, "tester"); got != "tester" {
+		t.Errorf("PinnedAgentID = %s, want tester", got)
+	}
+
+	svc := NewService(database, session.NewService(database), nil)
+	source, err := svc.CreateOrchestration("Auth", "", []AtomicPort{
+		{PortID: "p1", Order: 1},
+		{PortID: "p2", Order: 2},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrchestration failed: %v", err)
+	}
+	svc.UpdatePortStatus(source.ID, "p1", "complete")
+	svc.UpdatePortStatus(source.ID, "p2", "failed")
+
+	replay, err := svc.CreateReplay(ReplayOptions{
+		SourceOrchestrationID: source.ID,
+		AgentVersions:         []AgentPin{pin},
+		Branch:                "pal/replay-test",
+	})
+	if err != nil {
+		t.Fatalf("CreateReplay failed: %v", err)
+	}
+
+	orch, _ := svc.GetOrchestration(replay.OrchestrationID)
+	if orch.Status != StatusRunning || len(orch.AtomicPorts) != 2 || orch.AtomicPorts[0].Status != "" {
+		t.Errorf("replay orchestration not reset: %+v", orch)
+	}
+
+	found, err := svc.ReplayForOrchestration(replay.OrchestrationID)
+	if err != nil || found == nil || len(found.AgentVersions) != 1 || found.Branch != "pal/replay-test" {
+		t.Fatalf("ReplayForOrchestration = %+v, %v", found, err)
+	}
+
+	svc.UpdatePortStatus(replay.OrchestrationID, "p1", "complete")
+	svc.UpdatePortStatus(replay.OrchestrationID, "p2", "complete")
+
+	cmp, err := svc.CompareReplay(replay.ID)
+	if err != nil {
+		t.Fatalf("CompareReplay failed: %v", err)
+	}
+	if cmp.Source.Stats.FailedPorts != 1 || cmp.Candidate.Stats.CompletedPorts != 2 {
+		t.Errorf("unexpected outcomes: source=%+v candidate=%+v", cmp.Source.Stats, cmp.Candidate.Stats)
+	}
+	if len(cmp.PortDiffs) != 1 || cmp.PortDiffs[0].PortID != "p2" || cmp.PortDiffs[0].Replay != "complete" {
+		t.Errorf("PortDiffs = %+v", cmp.PortDiffs)
+	}
+
+	if err := svc.MarkReplayCleaned(replay.ID); err != nil {
+		t.Fatalf("MarkReplayCleaned failed: %v", err)
+	}
+	if r, _ := svc.GetReplay(replay.ID); r.Status != ReplayCleaned || r.CleanedAt == nil {
+		t.Errorf("replay not cleaned: %+v", r)
+	}
+}
+
+func TestOrchestrationLifecycle(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	sessionSvc := session.NewService(database)
+	svc := NewService(database, sessionSvc, nil)
+
+	orch, err := svc.CreateOrchestration("Lifecycle", "", []AtomicPort{
+		{PortID: "port-001", Order: 1},
+		{PortID: "port-002", Order: 2},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create orchestration: %v", err)
+	}
+	if _, err := svc.ResumeOrchestration(orch.ID, ""); err == nil {
+		t.Error("Pending orchestration should not be resumable")
+	}
+
+	svc.StartOrchestration(orch.ID, "")
+	operator, _ := sessionSvc.StartHierarchical(session.HierarchyStartOptions{Title: "operator", Type: session.TypeOperator})
+	ws, err := svc.SpawnSingleWorker(SingleWorkerOptions{
+		OrchestrationID:   orch.ID,
+		OperatorSessionID: operator.ID,
+		PortID:            "port-001",
+		Title:             "worker",
+		WorkerType:        WorkerTypeImpl,
+	})
+	if err != nil {
+		t.Fatalf("Failed to spawn worker: %v", err)
+	}
+	svc.UpdateWorkerStatus(ws.ID, "running", "coding")
+	svc.UpdatePortStatus(orch.ID, "port-001", "running")
+	database.Exec(`INSERT INTO locks (resource, session_id) VALUES ('entity', ?)`, ws.ImplSessionID)
+
+	// 일시정지: 배정 중단, 워커 표시, Lock 해제, 사유 기록
+	paused, err := svc.PauseOrchestration(orch.ID, "리뷰 대기")
+	if err != nil {
+		t.Fatalf("Pause failed: %v", err)
+	}
+	if len(paused.Workers) != 1 || len(paused.ReleasedLocks) != 1 || paused.ReleasedLocks[0] != "entity" {
+		t.Errorf("Unexpected pause event: %+v", paused)
+	}
+	if svc.Dispatchable(orch.ID) {
+		t.Error("Paused orchestration should not dispatch ports")
+	}
+	if got, _ := svc.GetWorkerSession(ws.ID); got.Status != WorkerStatusPaused {
+		t.Errorf("Expected paused worker, got %s", got.Status)
+	}
+
+	if _, err := svc.ResumeOrchestration(orch.ID, ""); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if got, _ := svc.GetWorkerSession(ws.ID); got.Status != "running" || got.Substatus != "coding" {
+		t.Errorf("Expected running worker with its substatus after resume, got %s/%s", got.Status, got.Substatus)
+	}
+	if !svc.Dispatchable(orch.ID) {
+		t.Error("Resumed orchestration should dispatch ports")
+	}
+
+	// 취소: 워커 종료, 실행 중이던 포트 cancelled
+	if _, err := svc.CancelOrchestration(orch.ID, "요구사항 변경"); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	got, _ := svc.GetOrchestration(orch.ID)
+	if got.Status != StatusCancelled || got.AtomicPorts[0].Status != "cancelled" || got.AtomicPorts[1].Status != "" {
+		t.Errorf("Unexpected cancelled orchestration: %+v", got)
+	}
+	if w, _ := svc.GetWorkerSession(ws.ID); w.Status != WorkerStatusCancelled || !strings.Contains(w.Result, "요구사항 변경") {
+		t.Errorf("Expected cancelled worker with reason, got %+v", w)
+	}
+	if _, err := svc.PauseOrchestration(orch.ID, ""); err == nil {
+		t.Error("Cancelled orchestration should not be pausable")
+	}
+
+	history, _ := svc.LifecycleHistory(orch.ID)
+	if len(history) != 3 || history[0].Reason != "리뷰 대기" || history[2].Action != ActionCancel {
+		t.Errorf("Unexpected lifecycle history: %+v", history)
+	}
+}
+
+func TestOrchestrationLifecycleConcurrent(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	sessionSvc := session.NewService(database)
+	svc := NewService(database, sessionSvc, nil)
+
+	orch, _ := svc.CreateOrchestration("Race", "", []AtomicPort{{PortID: "port-001", Order: 1}})
+	svc.StartOrchestration(orch.ID, "")
+
+	// 일시정지가 running을 읽은 직후 다른 프로세스가 취소
+	lifecycleHook = func(action string) {
+		if action == ActionPause {
+			lifecycleHook = nil
+			if _, err := svc.CancelOrchestration(orch.ID, ""); err != nil {
+				t.Errorf("Cancel failed: %v", err)
+			}
+		}
+	}
+	defer func() { lifecycleHook = nil }()
+
+	_, err := svc.PauseOrchestration(orch.ID, "")
+	if !errcode.Is(err, errcode.KindConflict) {
+		t.Fatalf("Expected conflict for a stale pause, got %v", err)
+	}
+	if got, _ := svc.GetOrchestration(orch.ID); got.Status != StatusCancelled {
+		t.Errorf("Pause should not override the cancel, got %s", got.Status)
+	}
+	if history, _ := svc.LifecycleHistory(orch.ID); len(history) != 1 || history[0].Action != ActionCancel {
+		t.Errorf("Expected only the cancel to be recorded, got %+v", history)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	sessionSvc := session.NewService(database)
+	svc := NewService(database, sessionSvc, nil)
+
+	orch, err := svc.CreateOrchestration("Retry", "", []AtomicPort{{PortID: "port-001", Order: 1}})
+	if err != nil {
+		t.Fatalf("Failed to create orchestration: %v", err)
+	}
+	if orch.RetryPolicy != DefaultRetryPolicy() {
+		t.Errorf("Expected default policy, got %+v", orch.RetryPolicy)
+	}
+	if err := svc.SetRetryPolicy(orch.ID, RetryPolicy{MaxRetries: 1, Backoff: "soon"}); err == nil {
+		t.Error("Invalid backoff should be rejected")
+	}
+	policy := RetryPolicy{MaxRetries: 1, Backoff: "1m"}
+	if err := svc.SetRetryPolicy(orch.ID, policy); err != nil {
+		t.Fatalf("SetRetryPolicy failed: %v", err)
+	}
+	if got, _ := svc.GetOrchestration(orch.ID); got.RetryPolicy != policy {
+		t.Errorf("Expected stored policy, got %+v", got.RetryPolicy)
+	}
+	if d := policy.BackoffFor(3); d != 4*time.Minute {
+		t.Errorf("Expected doubled backoff 4m, got %s", d)
+	}
+
+	svc.StartOrchestration(orch.ID, "")
+	operator, _ := sessionSvc.StartHierarchical(session.HierarchyStartOptions{Title: "operator", Type: session.TypeOperator})
+	spawn := func() *WorkerSession {
+		ws, err := svc.SpawnWorkerPair(WorkerPairOptions{
+			OrchestrationID:   orch.ID,
+			OperatorSessionID: operator.ID,
+			PortID:            "port-001",
+			PortTitle:         "port-001",
+		})
+		if err != nil {
+			t.Fatalf("Failed to spawn worker pair: %v", err)
+		}
+		svc.UpdatePortStatus(orch.ID, "port-001", "running")
+		return ws
+	}
+	loopStatus := func(id string) FeedbackLoopStatus {
+		loop, _ := NewFeedbackService(database).GetFeedbackLoop(id)
+		return loop.Status
+	}
+
+	// 첫 실패: backoff 후 재시도
+	ws := spawn()
+	if ws.FeedbackLoopID == "" {
+		t.Fatal("Worker pair should get a feedback loop")
+	}
+	svc.CompleteWorkerSession(ws.ID, WorkerPairResult{Success: false, ErrorMessage: "build failed"})
+	outcome, err := svc.HandleWorkerFailure(ws.ID, "build failed")
+	if err != nil {
+		t.Fatalf("HandleWorkerFailure failed: %v", err)
+	}
+	if !outcome.Retrying || outcome.Exhausted || outcome.EscalationID != 0 || outcome.RetryAt.Before(time.Now().Add(50*time.Second)) {
+		t.Errorf("Unexpected first outcome: %+v", outcome)
+	}
+	if next, _ := svc.GetNextPort(orch.ID); next != nil {
+		t.Errorf("Port in backoff should not be dispatched: %+v", next)
+	}
+	if st := loopStatus(ws.FeedbackLoopID); st != FeedbackStatusFailed {
+		t.Errorf("Expected failed feedback loop, got %s", st)
+	}
+
+	// 두 번째 실패: 재시도 소진 → 포트 failed + 에스컬레이션
+	ws = spawn()
+	svc.CompleteWorkerSession(ws.ID, WorkerPairResult{Success: false, ErrorMessage: "tests failed"})
+	outcome, err = svc.HandleWorkerFailure(ws.ID, "tests failed")
+	if err != nil {
+		t.Fatalf("HandleWorkerFailure failed: %v", err)
+	}
+	if outcome.Retrying || !outcome.Exhausted || outcome.Attempt != 2 || outcome.EscalationID == 0 {
+		t.Errorf("Unexpected exhausted outcome: %+v", outcome)
+	}
+	got, _ := svc.GetOrchestration(orch.ID)
+	if p := got.AtomicPorts[0]; p.Status != "failed" || p.Attempts != 2 || p.LastError != "tests failed" {
+		t.Errorf("Unexpected failed port: %+v", p)
+	}
+	if st := loopStatus(ws.FeedbackLoopID); st != FeedbackStatusEscalated {
+		t.Errorf("Expected escalated feedback loop, got %s", st)
+	}
+
+	var issue, severity string
+	database.QueryRow(`SELECT issue, severity FROM escalations WHERE id = ?`, outcome.EscalationID).Scan(&issue, &severity)
+	if !strings.Contains(issue, "port-001") || severity != "high" {
+		t.Errorf("Unexpected escalation: %q (%s)", issue, severity)
+	}
+}
+
+func TestBuildExecutionPlan(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	op := &OrchestrationPort{
+		ID:     "orch-1",
+		Status: StatusRunning,
+		AtomicPorts: []AtomicPort{
+			{PortID: "a", Order: 1, Status: "complete"},
+			{PortID: "b", Order: 2, Status: "running", DependsOn: []string{"a"}},
+			{PortID: "c", Order: 3, DependsOn: []string{"a"}},
+			{PortID: "d", Order: 4, DependsOn: []string{"b", "c"}},
+		},
+	}
+	estimates := map[string]float64{"a": 2, "b": 3, "c": 1}
+	started := map[string]time.Time{"b": now.Add(-time.Hour)}
+
+	plan, err := buildExecutionPlan(op, estimates, started, nil, now)
+	if err != nil {
+		t.Fatalf("buildExecutionPlan failed: %v", err)
+	}
+
+	if strings.Join(plan.CriticalPath, ",") != "b,d" {
+		t.Errorf("Expected critical path b,d, got %v", plan.CriticalPath)
+	}
+	if plan.RemainingHours != 3 || !plan.ProjectedCompletion.Equal(now.Add(3*time.Hour)) {
+		t.Errorf("Unexpected ETA: %.2fh, %s", plan.RemainingHours, plan.ProjectedCompletion)
+	}
+	if len(plan.Blocking) != 1 || plan.Blocking[0] != "b" || plan.MissingEstimates != 1 {
+		t.Errorf("Unexpected blocking/missing: %v, %d", plan.Blocking, plan.MissingEstimates)
+	}
+
+	ports := map[string]PortSchedule{}
+	for _, p := range plan.Ports {
+		ports[p.PortID] = p
+	}
+	if ports["c"].SlackHours != 1 || ports["c"].Critical {
+		t.Errorf("Expected 1h slack on c, got %+v", ports["c"])
+	}
+	if ports["b"].RemainingHours != 2 || ports["d"].EarliestStart != 2 || ports["d"].Estimated {
+		t.Errorf("Unexpected schedule: b=%+v d=%+v", ports["b"], ports["d"])
+	}
+	if ports["a"].Critical || ports["a"].RemainingHours != 0 {
+		t.Errorf("Completed port should not be critical: %+v", ports["a"])
+	}
+}
+
+func TestBuildExecutionPlanExternalBlockers(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	op := &OrchestrationPort{
+		ID:     "orch-1",
+		Status: StatusRunning,
+		AtomicPorts: []AtomicPort{
+			{PortID: "a", Order: 1},
+			{PortID: "b", Order: 2, DependsOn: []string{"a"}},
+			{PortID: "c", Order: 3},
+		},
+	}
+	estimates := map[string]float64{"a": 1, "b": 1, "c": 1}
+	until := now.Add(24 * time.Hour)
+	blocked := map[string][]port.Blocker{
+		"a": {{PortID: "a", Reason: "API 키 대기", ExpectedAt: &until}},
+		"c": {{PortID: "c", Reason: "디자인 승인 대기"}},
+	}
+
+	plan, err := buildExecutionPlan(op, estimates, nil, blocked, now)
+	if err != nil {
+		t.Fatalf("buildExecutionPlan failed: %v", err)
+	}
+
+	ports := map[string]PortSchedule{}
+	for _, p := range plan.Ports {
+		ports[p.PortID] = p
+	}
+	if !ports["a"].Blocked || ports["a"].BlockedUntil == nil || ports["a"].EarliestStart != 24 {
+		t.Errorf("Blocked port should start after its unblock date: %+v", ports["a"])
+	}
+	if ports["b"].Blocked || ports["b"].EarliestStart != 25 {
+		t.Errorf("Dependent should be pushed by the blocker: %+v", ports["b"])
+	}
+	if !ports["c"].Blocked || ports["c"].BlockedUntil != nil {
+		t.Errorf("Blocker without a date should have no unblock time: %+v", ports["c"])
+	}
+	if len(plan.Blocking) != 0 {
+		t.Errorf("Externally blocked ports cannot be startable now: %v", plan.Blocking)
+	}
+	if strings.Join(plan.ExternallyBlocked, ",") != "a,c" {
+		t.Errorf("Unexpected externally blocked ports: %v", plan.ExternallyBlocked)
+	}
+}
+
+func TestSpawnPortWorker(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "pair.md"), []byte("---\nport: pair-port\npair: true\n---\n# Pair\n"), 0644)
+	os.WriteFile(filepath.Join(root, "solo.md"), []byte("---\nport: solo-port\n---\n# Solo\n"), 0644)
+	portSvc := port.NewService(database)
+	portSvc.Create("pair-port", "pair", "pair.md")
+	portSvc.Create("solo-port", "solo", "solo.md")
+
+	msgStore := message.NewStore(database.DB)
+	svc := NewService(database, session.NewService(database), msgStore)
+	orch, _ := svc.CreateOrchestration("Pair", "", []AtomicPort{
+		{PortID: "pair-port", Order: 1},
+		{PortID: "solo-port", Order: 2},
+	})
+
+	pair, err := svc.SpawnPortWorker(WorkerPairOptions{OrchestrationID: orch.ID, PortID: "pair-port", PortTitle: "pair", ProjectRoot: root})
+	if err != nil {
+		t.Fatalf("Failed to spawn pair: %v", err)
+	}
+	if pair.WorkerType != WorkerTypePair || pair.TestSessionID == "" || pair.FeedbackLoopID == "" {
+		t.Fatalf("Expected impl/test pair with feedback loop, got %+v", pair)
+	}
+	loop, _ := NewFeedbackService(database).GetFeedbackLoop(pair.FeedbackLoopID)
+	if loop.ImplSessionID != pair.ImplSessionID || loop.TestSessionID != pair.TestSessionID || loop.ChannelID == "" {
+		t.Errorf("Feedback loop should link the pair: %+v", loop)
+	}
+	msgs, _ := msgStore.Receive(pair.ImplSessionID, 10)
+	if len(msgs) != 1 || msgs[0].Payload.(map[string]interface{})["channel_id"] != loop.ChannelID {
+		t.Errorf("Task assignment should carry the pair channel: %+v", msgs)
+	}
+
+	solo, err := svc.SpawnPortWorker(WorkerPairOptions{OrchestrationID: orch.ID, PortID: "solo-port", PortTitle: "solo", ProjectRoot: root})
+	if err != nil {
+		t.Fatalf("Failed to spawn single worker: %v", err)
+	}
+	if solo.WorkerType != WorkerTypeSingle || solo.ImplSessionID == "" || solo.TestSessionID != "" || solo.FeedbackLoopID != "" {
+		t.Errorf("Expected single impl worker, got %+v", solo)
+	}
+}
+
+func TestMigratePipeline(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	plSvc := pipeline.NewService(database)
+	plSvc.Create("legacy", "Legacy build", "")
+	plSvc.AddPort("legacy", "pl-a", 0)
+	plSvc.AddPort("legacy", "pl-b", 1)
+	plSvc.AddPort("legacy", "pl-c", 1)
+	plSvc.AddDependency("pl-b", "pl-a")
+	plSvc.AddDependency("pl-c", "outside") // 파이프라인 밖 의존성은 제외
+	plSvc.UpdatePortStatus("legacy", "pl-a", pipeline.StatusComplete)
+	plSvc.UpdatePortStatus("legacy", "pl-c", pipeline.StatusSkipped)
+	plSvc.UpdateStatus("legacy", pipeline.StatusRunning)
+	plSvc.Create("other", "Other", "")
+
+	svc := NewService(database, nil, nil)
+	svc.CreateOrchestration("Native", "", []AtomicPort{{PortID: "n-1", Order: 1}})
+
+	executions, err := svc.ListExecutions("", 0)
+	if err != nil {
+		t.Fatalf("ListExecutions failed: %v", err)
+	}
+	kinds := map[string]string{}
+	for _, e := range executions {
+		kinds[e.ID] = e.Kind
+	}
+	if len(executions) != 3 || kinds["legacy"] != ExecutionPipeline || kinds["other"] != ExecutionPipeline {
+		t.Fatalf("Expected orchestration and two pipelines, got %+v", executions)
+	}
+
+	m, err := svc.MigratePipeline("legacy")
+	if err != nil {
+		t.Fatalf("MigratePipeline failed: %v", err)
+	}
+	if !m.Created || m.Ports != 3 {
+		t.Errorf("Unexpected migration: %+v", m)
+	}
+
+	op, err := svc.GetOrchestration(m.OrchestrationID)
+	if err != nil {
+		t.Fatalf("Migrated orchestration not found: %v", err)
+	}
+	if op.Title != "Legacy build" || op.Status != StatusRunning || op.ProgressPercent != 33 {
+		t.Errorf("Unexpected orchestration: %+v", op)
+	}
+	byID := map[string]AtomicPort{}
+	for _, ap := range op.AtomicPorts {
+		byID[ap.PortID] = ap
+	}
+	if a := byID["pl-a"]; a.Order != 1 || a.Status != "complete" {
+		t.Errorf("Unexpected pl-a: %+v", a)
+	}
+	if b := byID["pl-b"]; b.Order != 2 || len(b.DependsOn) != 1 || b.DependsOn[0] != "pl-a" {
+		t.Errorf("Unexpected pl-b: %+v", b)
+	}
+	if c := byID["pl-c"]; len(c.DependsOn) != 0 || c.Status != string(StatusCancelled) {
+		t.Errorf("Unexpected pl-c: %+v", c)
+	}
+
+	// 재이관은 기존 Orchestration 반환
+	again, err := svc.MigratePipeline("legacy")
+	if err != nil || again.Created || again.OrchestrationID != m.OrchestrationID {
+		t.Errorf("Re-migration should return the existing orchestration: %+v, %v", again, err)
+	}
+	if id, err := svc.ResolveExecutionID("legacy"); err != nil || id != m.OrchestrationID {
+		t.Errorf("Pipeline ID should resolve to orchestration: %s, %v", id, err)
+	}
+	if _, err := svc.ResolveExecutionID("other"); err == nil {
+		t.Error("Unmigrated pipeline should not resolve")
+	}
+
+	migrated, err := svc.MigrateAllPipelines()
+	if err != nil || len(migrated) != 1 || migrated[0].PipelineID != "other" {
+		t.Errorf("MigrateAllPipelines should migrate only the remaining pipeline: %+v, %v", migrated, err)
+	}
+	executions, _ = svc.ListExecutions("", 0)
+	for _, e := range executions {
+		if e.Kind != ExecutionOrchestration {
+			t.Errorf("Migrated pipelines should be listed as orchestrations: %+v", e)
+		}
+	}
+	if len(executions) != 3 {
+		t.Errorf("Expected 3 executions, got %d", len(executions))
+	}
+}
+
+func TestExecutorRetryAfterBackoff(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "solo.md"), []byte("---\nport: solo-port\n---\n# Solo\n"), 0644)
+	port.NewService(database).Create("solo-port", "solo", "solo.md")
+
+	sessionSvc := session.NewService(database)
+	svc := NewService(database, sessionSvc, message.NewStore(database.DB))
+	orch, _ := svc.CreateOrchestration("Retry", "", []AtomicPort{{PortID: "solo-port", Order: 1}})
+	if err := svc.SetRetryPolicy(orch.ID, RetryPolicy{MaxRetries: 1, Backoff: "100ms"}); err != nil {
+		t.Fatalf("SetRetryPolicy failed: %v", err)
+	}
+	operator, _ := sessionSvc.StartHierarchical(session.HierarchyStartOptions{Title: "operator", Type: session.TypeOperator})
+
+	executor := NewExecutor(svc, DefaultExecutorConfig())
+	state, err := executor.Start(orch.ID, operator.ID, root)
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if len(state.ActiveWorkers) != 1 {
+		t.Fatalf("Expected 1 active worker, got %v", state.ActiveWorkers)
+	}
+	first := state.ActiveWorkers[0]
+
+	// 유일한 포트가 실패해도 backoff가 끝나면 다른 이벤트 없이 재배정되어야 함
+	if err := executor.HandleWorkerComplete(first, WorkerPairResult{Success: false, ErrorMessage: "build failed"}); err != nil {
+		t.Fatalf("HandleWorkerComplete failed: %v", err)
+	}
+	if state, _ := executor.GetState(orch.ID); len(state.ActiveWorkers) != 0 {
+		t.Fatalf("Port in backoff should not be dispatched yet: %v", state.ActiveWorkers)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		var export ExecutionState
+		data, _ := executor.ExportState(orch.ID)
+		json.Unmarshal([]byte(data), &export)
+		if len(export.ActiveWorkers) == 1 && export.ActiveWorkers[0] != first {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Port was not re-dispatched after backoff: %s", data)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	got, _ := svc.GetOrchestration(orch.ID)
+	if p := got.AtomicPorts[0]; p.Status != "running" || p.Attempts != 1 {
+		t.Errorf("Expected re-dispatched running port, got %+v", p)
+	}
+}
+
+func TestExecutorPortEnv(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	root := t.TempDir()
+	spec := "---\nport: %s\n---\n# Env\n\n## 환경 변수\n\n```yaml\nenv:\n  - name: %s\n```\n"
+	os.WriteFile(filepath.Join(root, "ready.md"), []byte(fmt.Sprintf(spec, "ready-port", "PAL_TEST_READY_KEY")), 0644)
+	os.WriteFile(filepath.Join(root, "missing.md"), []byte(fmt.Sprintf(spec, "missing-port", "PAL_TEST_MISSING_KEY")), 0644)
+	portSvc := port.NewService(database)
+	portSvc.Create("ready-port", "ready", "ready.md")
+	portSvc.Create("missing-port", "missing", "missing.md")
+	t.Setenv("PAL_TEST_READY_KEY", "secret")
+
+	sessionSvc := session.NewService(database)
+	svc := NewService(database, sessionSvc, nil)
+	orch, _ := svc.CreateOrchestration("Env", "", []AtomicPort{
+		{PortID: "ready-port", Order: 1},
+		{PortID: "missing-port", Order: 2},
+	})
+	operator, _ := sessionSvc.StartHierarchical(session.HierarchyStartOptions{Title: "operator", Type: session.TypeOperator})
+
+	state, err := NewExecutor(svc, DefaultExecutorConfig()).Start(orch.ID, operator.ID, root)
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if len(state.ActiveWorkers) != 1 || len(state.FailedPorts) != 1 || state.FailedPorts[0] != "missing-port" {
+		t.Fatalf("Expected ready-port dispatched and missing-port failed, got %+v", state)
+	}
+	ws, _ := svc.GetWorkerSession(state.ActiveWorkers[0])
+	if ws.PortID != "ready-port" || len(ws.EnvNames) != 1 || ws.EnvNames[0] != "PAL_TEST_READY_KEY" {
+		t.Errorf("Expected env names recorded for the spawned worker, got %+v", ws)
+	}
+}
@@ -0,0 +1,38 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/n0roo/pal-kit/internal/port"
+)
+
+// PairRequested reports whether a port spec asks for an impl/test worker pair (frontmatter `pair: true`).
+// 명세가 없거나 읽을 수 없으면 단일 워커로 봅니다.
+func (s *Service) PairRequested(portID, projectRoot string) bool {
+	meta, err := port.NewService(s.db).SpecMetaFor(portID, projectRoot)
+	return err == nil && meta.Pair
+}
+
+// SpawnPortWorker spawns the worker of a port according to its spec.
+// `pair: true`인 포트는 직접 채널과 피드백 루프를 공유하는 Impl/Test 쌍으로, 나머지는 단일 Impl 워커로 생성합니다.
+func (s *Service) SpawnPortWorker(opts WorkerPairOptions) (*WorkerSession, error) {
+	if s.sessionService == nil {
+		return nil, fmt.Errorf("세션 서비스 없이 워커를 생성할 수 없습니다")
+	}
+	if s.PairRequested(opts.PortID, opts.ProjectRoot) {
+		return s.SpawnWorkerPair(opts)
+	}
+	return s.SpawnSingleWorker(SingleWorkerOptions{
+		OrchestrationID:   opts.OrchestrationID,
+		OperatorSessionID: opts.OperatorSessionID,
+		PortID:            opts.PortID,
+		Title:             fmt.Sprintf("[Impl] %s", opts.PortTitle),
+		PortSpec:          opts.PortSpec,
+		Conventions:       opts.Conventions,
+		WorkerType:        WorkerTypeImpl,
+		AgentID:           opts.ImplAgentID,
+		TokenBudget:       opts.TokenBudget,
+		ProjectRoot:       opts.ProjectRoot,
+		EnvNames:          opts.EnvNames,
+	})
+}
@@ -0,0 +1,284 @@
+package orchestrator
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/pipeline"
+)
+
+// PipelineMigration is the result of converting a legacy pipeline into an orchestration
+type PipelineMigration struct {
+	PipelineID      string `json:"pipeline_id"`
+	OrchestrationID string `json:"orchestration_id"`
+	Title           string `json:"title"`
+	Ports           int    `json:"ports"`
+	Created         bool   `json:"created"` // false면 이미 이관되어 기존 Orchestration을 반환
+}
+
+// pipelinePortStatus maps a pipeline port status to an atomic port status.
+// skipped는 실행하지 않은 포트이므로 cancelled로 옮깁니다.
+func pipelinePortStatus(status string) string {
+	switch status {
+	case pipeline.StatusRunning, pipeline.StatusComplete, pipeline.StatusFailed, pipeline.StatusCancelled:
+		return status
+	case pipeline.StatusSkipped:
+		return string(StatusCancelled)
+	default:
+		return "pending"
+	}
+}
+
+func pipelineStatus(status string) OrchestrationStatus {
+	switch status {
+	case pipeline.StatusRunning:
+		return StatusRunning
+	case pipeline.StatusComplete:
+		return StatusComplete
+	case pipeline.StatusFailed:
+		return StatusFailed
+	case pipeline.StatusCancelled:
+		return StatusCancelled
+	default:
+		return StatusPending
+	}
+}
+
+// MigratePipeline converts a legacy pipeline (pipelines, v2) into an orchestration.
+// 실행 그룹은 포트 순서로, 파이프라인 안의 포트 의존성은 depends_on으로 옮기며 상태와 시각도 유지합니다.
+// 이미 이관된 파이프라인은 다시 만들지 않고 기존 Orchestration을 반환합니다.
+func (s *Service) MigratePipeline(pipelineID string) (*PipelineMigration, error) {
+	plSvc := pipeline.NewService(s.db)
+	pl, err := plSvc.Get(pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	if pl.MigratedTo.Valid && pl.MigratedTo.String != "" {
+		if op, err := s.GetOrchestration(pl.MigratedTo.String); err == nil {
+			return &PipelineMigration{
+				PipelineID:      pl.ID,
+				OrchestrationID: op.ID,
+				Title:           op.Title,
+				Ports:           len(op.AtomicPorts),
+			}, nil
+		}
+	}
+
+	ports, err := plSvc.GetPorts(pipelineID)
+	if err != nil {
+		return nil, fmt.Errorf("파이프라인 포트 조회 실패: %w", err)
+	}
+	inPipeline := make(map[string]bool, len(ports))
+	for _, pp := range ports {
+		inPipeline[pp.PortID] = true
+	}
+
+	atomicPorts := make([]AtomicPort, 0, len(ports))
+	complete := 0
+	for _, pp := range ports {
+		deps, err := plSvc.GetDependencies(pp.PortID)
+		if err != nil {
+			return nil, fmt.Errorf("포트 의존성 조회 실패: %w", err)
+		}
+		// 파이프라인 밖 포트에 대한 의존성은 Orchestration 그래프에 넣지 않음
+		var dependsOn []string
+		for _, dep := range deps {
+			if inPipeline[dep] {
+				dependsOn = append(dependsOn, dep)
+			}
+		}
+		sort.Strings(dependsOn)
+
+		ap := AtomicPort{
+			PortID:    pp.PortID,
+			Order:     pp.GroupOrder + 1,
+			DependsOn: dependsOn,
+			Status:    pipelinePortStatus(pp.Status),
+		}
+		if ap.Status == "complete" {
+			complete++
+		}
+		atomicPorts = append(atomicPorts, ap)
+	}
+
+	progress := 0
+	if len(atomicPorts) > 0 {
+		progress = complete * 100 / len(atomicPorts)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	id := uuid.New().String()
+	op, err := insertOrchestration(tx, id, pl.Name, fmt.Sprintf("파이프라인 %s에서 이관", pl.ID), atomicPorts, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE orchestration_ports
+		SET status = ?, progress_percent = ?, created_at = ?, started_at = ?, completed_at = ?
+		WHERE id = ?
+	`, pipelineStatus(pl.Status), progress, pl.CreatedAt, nullTime(pl.StartedAt), nullTime(pl.CompletedAt), id); err != nil {
+		return nil, fmt.Errorf("Orchestration 상태 이관 실패: %w", err)
+	}
+	if _, err := tx.Exec(`UPDATE pipelines SET migrated_to = ? WHERE id = ?`, id, pl.ID); err != nil {
+		return nil, fmt.Errorf("이관 기록 실패: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("파이프라인 이관 실패: %w", err)
+	}
+
+	return &PipelineMigration{
+		PipelineID:      pl.ID,
+		OrchestrationID: op.ID,
+		Title:           op.Title,
+		Ports:           len(atomicPorts),
+		Created:         true,
+	}, nil
+}
+
+// MigrateAllPipelines migrates every pipeline that has not been migrated yet
+func (s *Service) MigrateAllPipelines() ([]*PipelineMigration, error) {
+	pipelines, err := pipeline.NewService(s.db).List("", 0)
+	if err != nil {
+		return nil, fmt.Errorf("파이프라인 목록 조회 실패: %w", err)
+	}
+
+	var results []*PipelineMigration
+	for _, pl := range pipelines {
+		if pl.MigratedTo.Valid && pl.MigratedTo.String != "" {
+			continue
+		}
+		m, err := s.MigratePipeline(pl.ID)
+		if err != nil {
+			return results, fmt.Errorf("%s: %w", pl.ID, err)
+		}
+		results = append(results, m)
+	}
+	return results, nil
+}
+
+// ResolveExecutionID returns the orchestration ID for an orchestration or a migrated pipeline ID
+func (s *Service) ResolveExecutionID(id string) (string, error) {
+	var exists int
+	if err := s.db.QueryRow(`SELECT 1 FROM orchestration_ports WHERE id = ?`, id).Scan(&exists); err == nil {
+		return id, nil
+	}
+
+	var migratedTo sql.NullString
+	err := s.db.QueryRow(`SELECT migrated_to FROM pipelines WHERE id = ?`, id).Scan(&migratedTo)
+	if err == sql.ErrNoRows {
+		return "", errcode.New(errcode.KindNotFound, "Orchestration '%s'을(를) 찾을 수 없습니다", id)
+	}
+	if err != nil {
+		return "", fmt.Errorf("파이프라인 조회 실패: %w", err)
+	}
+	if !migratedTo.Valid || migratedTo.String == "" {
+		return "", errcode.New(errcode.KindNotFound, "'%s'은(는) 이관되지 않은 파이프라인입니다 (pal pipeline migrate %s)", id, id)
+	}
+	return migratedTo.String, nil
+}
+
+func nullTime(t sql.NullTime) interface{} {
+	if !t.Valid {
+		return nil
+	}
+	return t.Time
+}
+
+// Execution is the unified view of an orchestration or a not yet migrated legacy pipeline.
+// 대시보드, CLI, MCP가 두 실행 모델을 하나의 목록으로 보여줄 때 사용합니다.
+type Execution struct {
+	ID              string     `json:"id"`
+	Kind            string     `json:"kind"` // orchestration, pipeline
+	Title           string     `json:"title"`
+	Status          string     `json:"status"`
+	Ports           int        `json:"ports"`
+	CompletedPorts  int        `json:"completed_ports"`
+	ProgressPercent int        `json:"progress_percent"`
+	CreatedAt       time.Time  `json:"created_at"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+}
+
+// Execution kinds
+const (
+	ExecutionOrchestration = "orchestration"
+	ExecutionPipeline      = "pipeline"
+)
+
+// ListExecutions lists orchestrations and not yet migrated pipelines, newest first.
+// 이관된 파이프라인은 해당 Orchestration으로만 나타납니다.
+func (s *Service) ListExecutions(status string, limit int) ([]Execution, error) {
+	orchestrations, err := s.ListOrchestrations(OrchestrationStatus(status), limit)
+	if err != nil {
+		return nil, fmt.Errorf("Orchestration 목록 조회 실패: %w", err)
+	}
+
+	executions := []Execution{}
+	for _, op := range orchestrations {
+		e := Execution{
+			ID:              op.ID,
+			Kind:            ExecutionOrchestration,
+			Title:           op.Title,
+			Status:          string(op.Status),
+			Ports:           len(op.AtomicPorts),
+			ProgressPercent: op.ProgressPercent,
+			CreatedAt:       op.CreatedAt,
+			StartedAt:       op.StartedAt,
+			CompletedAt:     op.CompletedAt,
+		}
+		for _, ap := range op.AtomicPorts {
+			if ap.Status == "complete" {
+				e.CompletedPorts++
+			}
+		}
+		executions = append(executions, e)
+	}
+
+	plSvc := pipeline.NewService(s.db)
+	pipelines, err := plSvc.List(status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("파이프라인 목록 조회 실패: %w", err)
+	}
+	for _, pl := range pipelines {
+		if pl.MigratedTo.Valid && pl.MigratedTo.String != "" {
+			continue
+		}
+		e := Execution{
+			ID:        pl.ID,
+			Kind:      ExecutionPipeline,
+			Title:     pl.Name,
+			Status:    pl.Status,
+			CreatedAt: pl.CreatedAt,
+		}
+		if pl.StartedAt.Valid {
+			e.StartedAt = &pl.StartedAt.Time
+		}
+		if pl.CompletedAt.Valid {
+			e.CompletedAt = &pl.CompletedAt.Time
+		}
+		if completed, total, err := plSvc.GetProgress(pl.ID); err == nil {
+			e.CompletedPorts, e.Ports = completed, total
+			if total > 0 {
+				e.ProgressPercent = completed * 100 / total
+			}
+		}
+		executions = append(executions, e)
+	}
+
+	sort.SliceStable(executions, func(i, j int) bool {
+		return executions[i].CreatedAt.After(executions[j].CreatedAt)
+	})
+	if limit > 0 && len(executions) > limit {
+		executions = executions[:limit]
+	}
+	return executions, nil
+}
@@ -0,0 +1,330 @@
+package orchestrator
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Replay statuses
+const (
+	ReplayActive  = "active"
+	ReplayCleaned = "cleaned"
+)
+
+// AgentPin pins an agent to a specific spec version for a replay (builder@5)
+type AgentPin struct {
+	Agent   string `json:"agent"`
+	Version int    `json:"version"`
+}
+
+// String returns the pin in name@version form
+func (p AgentPin) String() string {
+	return fmt.Sprintf("%s@%d", p.Agent, p.Version)
+}
+
+// ParseAgentPin parses "name@version"
+func ParseAgentPin(s string) (AgentPin, error) {
+	name, ver, found := strings.Cut(strings.TrimSpace(s), "@")
+	if !found || name == "" {
+		return AgentPin{}, fmt.Errorf("에이전트 버전 형식이 잘못되었습니다 (name@version): %s", s)
+	}
+	v, err := strconv.Atoi(ver)
+	if err != nil || v <= 0 {
+		return AgentPin{}, fmt.Errorf("에이전트 버전은 양의 정수여야 합니다: %s", s)
+	}
+	return AgentPin{Agent: name, Version: v}, nil
+}
+
+// PinnedAgentID returns "agent@version" when the agent is pinned, otherwise the agent ID as-is
+func PinnedAgentID(pins []AgentPin, agentID string) string {
+	for _, p := range pins {
+		if p.Agent == agentID {
+			return p.String()
+		}
+	}
+	return agentID
+}
+
+// Replay is a re-execution of a past orchestration with pinned agent versions
+type Replay struct {
+	ID                    string     `json:"id"`
+	SourceOrchestrationID string     `json:"source_orchestration_id"`
+	OrchestrationID       string     `json:"orchestration_id"`
+	AgentVersions         []AgentPin `json:"agent_versions,omitempty"`
+	Branch                string     `json:"branch,omitempty"`
+	Worktree              string     `json:"worktree,omitempty"`
+	Status                string     `json:"status"`
+	CreatedAt             time.Time  `json:"created_at"`
+	CleanedAt             *time.Time `json:"cleaned_at,omitempty"`
+}
+
+// ReplayOptions contains options for replaying an orchestration
+type ReplayOptions struct {
+	ID                    string // 비어 있으면 생성 (worktree 경로를 먼저 만들 때 지정)
+	SourceOrchestrationID string
+	AgentVersions         []AgentPin
+	Branch                string
+	Worktree              string
+}
+
+// CreateReplay clones the source orchestration's plan into a new orchestration and starts it.
+// 포트 구성은 그대로 두고 상태만 초기화하며, 고정한 에이전트 버전과 작업 브랜치를 함께 기록합니다.
+func (s *Service) CreateReplay(opts ReplayOptions) (*Replay, error) {
+	source, err := s.GetOrchestration(opts.SourceOrchestrationID)
+	if err != nil {
+		return nil, fmt.Errorf("원본 Orchestration 조회 실패: %w", err)
+	}
+
+	ports := make([]AtomicPort, len(source.AtomicPorts))
+	for i, p := range source.AtomicPorts {
+		p.Status = ""
+		p.Attempts, p.RetryAt, p.LastError = 0, nil, ""
+		ports[i] = p
+	}
+
+	pins := make([]string, len(opts.AgentVersions))
+	for i, p := range opts.AgentVersions {
+		pins[i] = p.String()
+	}
+	title := fmt.Sprintf("%s (replay %s)", source.Title, strings.Join(pins, ", "))
+
+	orch, err := s.CreateOrchestration(title, source.Description, ports)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.SetRetryPolicy(orch.ID, source.RetryPolicy); err != nil {
+		return nil, err
+	}
+	if err := s.StartOrchestration(orch.ID, ""); err != nil {
+		return nil, err
+	}
+
+	replay := &Replay{
+		ID:                    opts.ID,
+		SourceOrchestrationID: source.ID,
+		OrchestrationID:       orch.ID,
+		AgentVersions:         opts.AgentVersions,
+		Branch:                opts.Branch,
+		Worktree:              opts.Worktree,
+		Status:                ReplayActive,
+		CreatedAt:             time.Now(),
+	}
+	if replay.ID == "" {
+		replay.ID = uuid.New().String()
+	}
+
+	pinsJSON, _ := json.Marshal(replay.AgentVersions)
+	_, err = s.db.Exec(`
+		INSERT INTO orchestration_replays (
+			id, source_orchestration_id, orchestration_id, agent_versions, branch, worktree, status, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, replay.ID, replay.SourceOrchestrationID, replay.OrchestrationID, string(pinsJSON),
+		nullableString(replay.Branch), nullableString(replay.Worktree), replay.Status, replay.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("재실행 기록 실패: %w", err)
+	}
+
+	return replay, nil
+}
+
+const replayColumns = `id, source_orchestration_id, orchestration_id, agent_versions, branch, worktree,
+		       status, created_at, cleaned_at`
+
+// GetReplay retrieves a replay by ID
+func (s *Service) GetReplay(id string) (*Replay, error) {
+	row := s.db.QueryRow(`SELECT `+replayColumns+` FROM orchestration_replays WHERE id = ?`, id)
+	replay, err := scanReplay(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("재실행을 찾을 수 없습니다: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("재실행 조회 실패: %w", err)
+	}
+	return replay, nil
+}
+
+// ReplayForOrchestration returns the replay that created the orchestration (재실행이 아니면 nil)
+func (s *Service) ReplayForOrchestration(orchestrationID string) (*Replay, error) {
+	row := s.db.QueryRow(`SELECT `+replayColumns+` FROM orchestration_replays WHERE orchestration_id = ?`, orchestrationID)
+	replay, err := scanReplay(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("재실행 조회 실패: %w", err)
+	}
+	return replay, nil
+}
+
+// ListReplays lists replays, optionally filtered by source orchestration
+func (s *Service) ListReplays(sourceID string, limit int) ([]*Replay, error) {
+	query := `SELECT ` + replayColumns + ` FROM orchestration_replays`
+	args := []interface{}{}
+	if sourceID != "" {
+		query += " WHERE source_orchestration_id = ?"
+		args = append(args, sourceID)
+	}
+	query += " ORDER BY created_at DESC"
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("재실행 목록 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var replays []*Replay
+	for rows.Next() {
+		replay, err := scanReplay(rows)
+		if err != nil {
+			continue
+		}
+		replays = append(replays, replay)
+	}
+	return replays, nil
+}
+
+// MarkReplayCleaned records that the replay's worktree and branch were removed
+func (s *Service) MarkReplayCleaned(id string) error {
+	result, err := s.db.Exec(`
+		UPDATE orchestration_replays SET status = ?, cleaned_at = ? WHERE id = ?
+	`, ReplayCleaned, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("재실행 정리 기록 실패: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("재실행을 찾을 수 없습니다: %s", id)
+	}
+	return nil
+}
+
+// RunOutcome summarizes the result of an orchestration run
+type RunOutcome struct {
+	OrchestrationID string              `json:"orchestration_id"`
+	Status          OrchestrationStatus `json:"status"`
+	Stats           *OrchestrationStats `json:"stats"`
+	PortStatus      map[string]string   `json:"port_status"`
+	TokensUsed      int64               `json:"tokens_used"`
+	CostUSD         float64             `json:"cost_usd"`
+	DurationSeconds int64               `json:"duration_seconds,omitempty"` // 완료된 경우만
+}
+
+// PortOutcomeDiff is a port whose result differs between source and replay
+type PortOutcomeDiff struct {
+	PortID string `json:"port_id"`
+	Source string `json:"source"`
+	Replay string `json:"replay"`
+}
+
+// ReplayComparison compares the outcome of a replay against its source run
+type ReplayComparison struct {
+	Replay    *Replay           `json:"replay"`
+	Source    *RunOutcome       `json:"source"`
+	Candidate *RunOutcome       `json:"candidate"`
+	PortDiffs []PortOutcomeDiff `json:"port_diffs,omitempty"`
+}
+
+// Outcome collects port results and worker session usage for an orchestration
+func (s *Service) Outcome(orchestrationID string) (*RunOutcome, error) {
+	op, err := s.GetOrchestration(orchestrationID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.GetOrchestrationStats(orchestrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := &RunOutcome{
+		OrchestrationID: op.ID,
+		Status:          op.Status,
+		Stats:           stats,
+		PortStatus:      make(map[string]string, len(op.AtomicPorts)),
+	}
+	for _, p := range op.AtomicPorts {
+		status := p.Status
+		if status == "" {
+			status = "pending"
+		}
+		outcome.PortStatus[p.PortID] = status
+	}
+	if op.StartedAt != nil && op.CompletedAt != nil {
+		outcome.DurationSeconds = int64(op.CompletedAt.Sub(*op.StartedAt).Seconds())
+	}
+
+	// Worker(Impl/Test) 세션 사용량 합계
+	err = s.db.QueryRow(`
+		SELECT COALESCE(SUM(input_tokens + output_tokens + cache_read_tokens + cache_create_tokens), 0),
+		       COALESCE(SUM(cost_usd), 0)
+		FROM sessions
+		WHERE id IN (
+			SELECT impl_session_id FROM worker_sessions WHERE orchestration_id = ?
+			UNION
+			SELECT test_session_id FROM worker_sessions WHERE orchestration_id = ?
+		)
+	`, orchestrationID, orchestrationID).Scan(&outcome.TokensUsed, &outcome.CostUSD)
+	if err != nil {
+		return nil, fmt.Errorf("Worker 사용량 조회 실패: %w", err)
+	}
+
+	return outcome, nil
+}
+
+// CompareReplay compares a replay's outcome with the source orchestration
+func (s *Service) CompareReplay(id string) (*ReplayComparison, error) {
+	replay, err := s.GetReplay(id)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.Outcome(replay.SourceOrchestrationID)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := s.Outcome(replay.OrchestrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := &ReplayComparison{Replay: replay, Source: source, Candidate: candidate}
+	for portID, before := range source.PortStatus {
+		if after := candidate.PortStatus[portID]; after != before {
+			cmp.PortDiffs = append(cmp.PortDiffs, PortOutcomeDiff{PortID: portID, Source: before, Replay: after})
+		}
+	}
+	sort.Slice(cmp.PortDiffs, func(i, j int) bool { return cmp.PortDiffs[i].PortID < cmp.PortDiffs[j].PortID })
+
+	return cmp, nil
+}
+
+type replayScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReplay(row replayScanner) (*Replay, error) {
+	var replay Replay
+	var pins, branch, worktree sql.NullString
+	var cleanedAt sql.NullTime
+
+	if err := row.Scan(&replay.ID, &replay.SourceOrchestrationID, &replay.OrchestrationID, &pins,
+		&branch, &worktree, &replay.Status, &replay.CreatedAt, &cleanedAt); err != nil {
+		return nil, err
+	}
+	if pins.Valid {
+		json.Unmarshal([]byte(pins.String), &replay.AgentVersions)
+	}
+	replay.Branch = branch.String
+	replay.Worktree = worktree.String
+	if cleanedAt.Valid {
+		replay.CleanedAt = &cleanedAt.Time
+	}
+	return &replay, nil
+}
@@ -0,0 +1,269 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/message"
+	"github.com/n0roo/pal-kit/internal/server/events"
+)
+
+// RetryPolicy controls how an orchestration reacts to failed workers.
+// Orchestration마다 저장되며(orchestration_ports.retry_policy), 없으면 DefaultRetryPolicy를 사용합니다.
+type RetryPolicy struct {
+	MaxRetries    int    `json:"max_retries"`              // 포트당 재시도 횟수 (0이면 재시도 없음)
+	Backoff       string `json:"backoff,omitempty"`        // 첫 재시도 대기 시간, 재시도마다 2배 (예: "30s")
+	EscalateAfter int    `json:"escalate_after,omitempty"` // 이 횟수만큼 실패하면 재시도 중에도 에스컬레이션 (0이면 소진 시에만)
+}
+
+// maxBackoff caps the exponential retry backoff
+const maxBackoff = time.Hour
+
+// DefaultRetryPolicy returns the policy used when an orchestration has none stored
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxRetries: 3, Backoff: "5s"}
+}
+
+// Validate checks the policy values
+func (p RetryPolicy) Validate() error {
+	if p.MaxRetries < 0 {
+		return errcode.New(errcode.KindValidation, "max_retries는 0 이상이어야 합니다: %d", p.MaxRetries)
+	}
+	if p.EscalateAfter < 0 {
+		return errcode.New(errcode.KindValidation, "escalate_after는 0 이상이어야 합니다: %d", p.EscalateAfter)
+	}
+	if p.Backoff != "" {
+		d, err := time.ParseDuration(p.Backoff)
+		if err != nil || d < 0 {
+			return errcode.New(errcode.KindValidation, "잘못된 backoff 값입니다: %s", p.Backoff)
+		}
+	}
+	return nil
+}
+
+// BackoffFor returns the wait before retrying after the given failed attempt (1부터)
+func (p RetryPolicy) BackoffFor(attempt int) time.Duration {
+	base, err := time.ParseDuration(p.Backoff)
+	if err != nil || base <= 0 || attempt <= 0 {
+		return 0
+	}
+	d := base
+	for i := 1; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+func parseRetryPolicy(raw string) RetryPolicy {
+	if raw == "" {
+		return DefaultRetryPolicy()
+	}
+	var p RetryPolicy
+	if err := json.Unmarshal([]byte(raw), &p); err != nil {
+		return DefaultRetryPolicy()
+	}
+	return p
+}
+
+// RetryReady reports whether a pending port has finished its retry backoff
+func (p *AtomicPort) RetryReady(now time.Time) bool {
+	return p.RetryAt == nil || !now.Before(*p.RetryAt)
+}
+
+// SetRetryPolicy stores the retry policy of an orchestration
+func (s *Service) SetRetryPolicy(id string, policy RetryPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.GetOrchestration(id); err != nil {
+		return errcode.Wrap(errcode.KindNotFound, err)
+	}
+
+	data, _ := json.Marshal(policy)
+	if _, err := s.db.Exec(`UPDATE orchestration_ports SET retry_policy = ? WHERE id = ?`, string(data), id); err != nil {
+		return fmt.Errorf("재시도 정책 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// FailureOutcome describes what happened after a worker failure
+type FailureOutcome struct {
+	OrchestrationID string     `json:"orchestration_id"`
+	WorkerID        string     `json:"worker_id"`
+	PortID          string     `json:"port_id"`
+	Attempt         int        `json:"attempt"` // 이 포트의 누적 실패 횟수
+	MaxRetries      int        `json:"max_retries"`
+	Retrying        bool       `json:"retrying"`
+	RetryAt         *time.Time `json:"retry_at,omitempty"`
+	Exhausted       bool       `json:"exhausted"`
+	EscalationID    int64      `json:"escalation_id,omitempty"`
+}
+
+// HandleWorkerFailure applies the orchestration's retry policy to a failed worker.
+// 워커가 failed로 완료된 뒤 호출합니다. 재시도가 남아 있으면 포트를 backoff 후 대기(pending)로 되돌리고,
+// 소진되면 포트를 failed로 표시하고 에스컬레이션을 기록합니다. Impl/Test 피드백 루프도 함께 종료됩니다.
+func (s *Service) HandleWorkerFailure(workerID, errMsg string) (*FailureOutcome, error) {
+	ws, err := s.GetWorkerSession(workerID)
+	if err != nil {
+		return nil, errcode.Wrap(errcode.KindNotFound, err)
+	}
+	if ws.OrchestrationID == "" {
+		return nil, errcode.New(errcode.KindValidation, "Orchestration에 속하지 않은 워커입니다: %s", workerID)
+	}
+	op, err := s.GetOrchestration(ws.OrchestrationID)
+	if err != nil {
+		return nil, errcode.Wrap(errcode.KindNotFound, err)
+	}
+
+	var port *AtomicPort
+	for i := range op.AtomicPorts {
+		if op.AtomicPorts[i].PortID == ws.PortID {
+			port = &op.AtomicPorts[i]
+			break
+		}
+	}
+	if port == nil {
+		return nil, errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", ws.PortID)
+	}
+
+	policy := op.RetryPolicy
+	port.Attempts++
+	port.LastError = errMsg
+	outcome := &FailureOutcome{
+		OrchestrationID: op.ID,
+		WorkerID:        ws.ID,
+		PortID:          port.PortID,
+		Attempt:         port.Attempts,
+		MaxRetries:      policy.MaxRetries,
+	}
+
+	// 취소된 Orchestration은 재시도하지 않음
+	if port.Attempts <= policy.MaxRetries && op.Status != StatusCancelled {
+		retryAt := time.Now().Add(policy.BackoffFor(port.Attempts))
+		port.Status = "pending"
+		port.RetryAt = &retryAt
+		outcome.Retrying = true
+		outcome.RetryAt = &retryAt
+	} else {
+		port.Status = "failed"
+		port.RetryAt = nil
+		outcome.Exhausted = true
+	}
+
+	portsJSON, _ := json.Marshal(op.AtomicPorts)
+	if _, err := s.db.Exec(`
+		UPDATE orchestration_ports SET atomic_ports = ?, current_port_id = ? WHERE id = ?
+	`, string(portsJSON), port.PortID, op.ID); err != nil {
+		return nil, fmt.Errorf("포트 재시도 상태 저장 실패: %w", err)
+	}
+	s.publishPortEvent(events.EventOrchestrationWorkerFailed, op, port.PortID, events.OrchestrationProgressData{
+		WorkerID: ws.ID,
+		Attempt:  outcome.Attempt,
+		Retrying: outcome.Retrying,
+		Error:    errMsg,
+	})
+
+	loopStatus := FeedbackStatusFailed
+	if outcome.Exhausted {
+		loopStatus = FeedbackStatusEscalated
+	}
+	s.closeFeedbackLoop(ws, loopStatus)
+
+	if outcome.Exhausted || (policy.EscalateAfter > 0 && port.Attempts == policy.EscalateAfter) {
+		id, err := s.escalateFailure(ws, port, outcome)
+		if err != nil {
+			return outcome, err
+		}
+		outcome.EscalationID = id
+	}
+
+	return outcome, nil
+}
+
+func (s *Service) escalateFailure(ws *WorkerSession, port *AtomicPort, outcome *FailureOutcome) (int64, error) {
+	fromSession := ws.ImplSessionID
+	if fromSession == "" {
+		fromSession = ws.TestSessionID
+	}
+
+	issue := fmt.Sprintf("포트 %s 실패 %d회 - 재시도 진행 중 (%d/%d)", port.PortID, outcome.Attempt, outcome.Attempt, outcome.MaxRetries)
+	severity := escalation.SeverityMedium
+	if outcome.Exhausted {
+		issue = fmt.Sprintf("포트 %s 실패 %d회 - 재시도 소진, 사용자 개입 필요", port.PortID, outcome.Attempt)
+		severity = escalation.SeverityHigh
+	}
+	if port.LastError != "" {
+		issue += ": " + port.LastError
+	}
+
+	return escalation.NewService(s.db).CreateRecord(escalation.EnhancedEscalationOptions{
+		FromSession: fromSession,
+		FromPort:    port.PortID,
+		Type:        escalation.TypeManualReview,
+		Severity:    severity,
+		Issue:       issue,
+		Context: map[string]interface{}{
+			"orchestration_id": outcome.OrchestrationID,
+			"worker_id":        ws.ID,
+			"attempts":         outcome.Attempt,
+			"max_retries":      outcome.MaxRetries,
+			"last_error":       port.LastError,
+		},
+		Suggestion: fmt.Sprintf("pal orch show %s 로 실패 사유를 확인한 뒤 포트를 수정하고 재시도 정책을 조정하세요", outcome.OrchestrationID),
+	})
+}
+
+// startFeedbackLoop opens the direct channel and feedback loop of an impl/test pair
+func (s *Service) startFeedbackLoop(orchestrationID, portID, implSessionID, testSessionID string) (*FeedbackLoop, error) {
+	policy := DefaultRetryPolicy()
+	if orchestrationID != "" {
+		if op, err := s.GetOrchestration(orchestrationID); err == nil {
+			policy = op.RetryPolicy
+		}
+	}
+
+	channel, err := message.NewDirectStore(s.db.DB).CreateChannel(implSessionID, testSessionID, portID, orchestrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 피드백 루프는 최소 한 번의 테스트 결과를 주고받음
+	maxRetries := policy.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 1
+	}
+	return NewFeedbackService(s.db).CreateFeedbackLoop(channel.ID, implSessionID, testSessionID, portID, maxRetries)
+}
+
+// closeFeedbackLoop finishes the running feedback loop of a worker pair and closes its channel
+func (s *Service) closeFeedbackLoop(ws *WorkerSession, status FeedbackLoopStatus) {
+	if ws.ImplSessionID == "" || ws.TestSessionID == "" {
+		return
+	}
+
+	var loopID, channelID string
+	if err := s.db.QueryRow(`
+		SELECT id, channel_id FROM feedback_loops
+		WHERE impl_session = ? AND test_session = ? AND status = ?
+		ORDER BY created_at DESC LIMIT 1
+	`, ws.ImplSessionID, ws.TestSessionID, FeedbackStatusRunning).Scan(&loopID, &channelID); err != nil {
+		return
+	}
+
+	fb := NewFeedbackService(s.db)
+	switch status {
+	case FeedbackStatusSuccess:
+		fb.MarkSuccess(loopID)
+	case FeedbackStatusEscalated:
+		fb.MarkEscalated(loopID)
+	default:
+		fb.MarkFailed(loopID)
+	}
+	fb.directStore.CloseChannel(channelID)
+}
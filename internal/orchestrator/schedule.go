@@ -0,0 +1,331 @@
+package orchestrator
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schedule represents a recurring orchestration schedule
+type Schedule struct {
+	ID        string     `json:"id"`
+	PlanID    string     `json:"plan_id"`
+	Cron      string     `json:"cron"`
+	Enabled   bool       `json:"enabled"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ScheduleRun records a single execution of a schedule
+type ScheduleRun struct {
+	ID              int64     `json:"id"`
+	ScheduleID      string    `json:"schedule_id"`
+	OrchestrationID string    `json:"orchestration_id,omitempty"`
+	Status          string    `json:"status"` // started, failed
+	Error           string    `json:"error,omitempty"`
+	ScheduledAt     time.Time `json:"scheduled_at"`
+	StartedAt       time.Time `json:"started_at"`
+}
+
+// Schedule run statuses
+const (
+	ScheduleRunStarted = "started"
+	ScheduleRunFailed  = "failed"
+)
+
+// CreateSchedule registers a cron schedule for a plan orchestration.
+// 각 실행마다 plan의 포트 구성을 복제한 새 Orchestration이 생성됩니다.
+func (s *Service) CreateSchedule(planID, cronExpr string) (*Schedule, error) {
+	expr, err := ParseCron(cronExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetOrchestration(planID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	next := expr.Next(now)
+	if next.IsZero() {
+		return nil, fmt.Errorf("다음 실행 시각을 계산할 수 없습니다: %q", cronExpr)
+	}
+	sched := &Schedule{
+		ID:        uuid.New().String(),
+		PlanID:    planID,
+		Cron:      expr.String(),
+		Enabled:   true,
+		NextRunAt: &next,
+		CreatedAt: now,
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO orchestration_schedules (id, plan_id, cron, enabled, next_run_at, created_at)
+		VALUES (?, ?, ?, 1, ?, ?)
+	`, sched.ID, sched.PlanID, sched.Cron, next, now)
+	if err != nil {
+		return nil, fmt.Errorf("스케줄 생성 실패: %w", err)
+	}
+
+	return sched, nil
+}
+
+// GetSchedule retrieves a schedule by ID
+func (s *Service) GetSchedule(id string) (*Schedule, error) {
+	row := s.db.QueryRow(`
+		SELECT id, plan_id, cron, enabled, last_run_at, next_run_at, created_at
+		FROM orchestration_schedules WHERE id = ?
+	`, id)
+
+	sched, err := scanSchedule(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("스케줄 '%s'을(를) 찾을 수 없습니다", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sched, nil
+}
+
+// ListSchedules lists all schedules
+func (s *Service) ListSchedules() ([]*Schedule, error) {
+	rows, err := s.db.Query(`
+		SELECT id, plan_id, cron, enabled, last_run_at, next_run_at, created_at
+		FROM orchestration_schedules
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("스케줄 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []*Schedule
+	for rows.Next() {
+		sched, err := scanSchedule(rows)
+		if err != nil {
+			continue
+		}
+		schedules = append(schedules, sched)
+	}
+
+	return schedules, nil
+}
+
+// SetScheduleEnabled enables or disables a schedule
+func (s *Service) SetScheduleEnabled(id string, enabled bool) error {
+	sched, err := s.GetSchedule(id)
+	if err != nil {
+		return err
+	}
+
+	// 재활성화 시 다음 실행 시각을 현재 기준으로 다시 계산
+	var next interface{}
+	if enabled {
+		expr, err := ParseCron(sched.Cron)
+		if err != nil {
+			return err
+		}
+		nextAt := expr.Next(time.Now())
+		if nextAt.IsZero() {
+			return fmt.Errorf("다음 실행 시각을 계산할 수 없습니다: %q", sched.Cron)
+		}
+		next = nextAt
+	}
+
+	_, err = s.db.Exec(`
+		UPDATE orchestration_schedules SET enabled = ?, next_run_at = ? WHERE id = ?
+	`, enabled, next, id)
+	return err
+}
+
+// DeleteSchedule removes a schedule and its run history
+func (s *Service) DeleteSchedule(id string) error {
+	if _, err := s.db.Exec(`DELETE FROM orchestration_schedule_runs WHERE schedule_id = ?`, id); err != nil {
+		return fmt.Errorf("스케줄 이력 삭제 실패: %w", err)
+	}
+
+	result, err := s.db.Exec(`DELETE FROM orchestration_schedules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("스케줄 삭제 실패: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("스케줄 '%s'을(를) 찾을 수 없습니다", id)
+	}
+	return nil
+}
+
+// ListScheduleRuns returns run history of a schedule (newest first)
+func (s *Service) ListScheduleRuns(scheduleID string, limit int) ([]*ScheduleRun, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, schedule_id, COALESCE(orchestration_id, ''), status,
+		       COALESCE(error, ''), scheduled_at, started_at
+		FROM orchestration_schedule_runs
+		WHERE schedule_id = ?
+		ORDER BY started_at DESC, id DESC
+		LIMIT ?
+	`, scheduleID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("스케줄 이력 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*ScheduleRun
+	for rows.Next() {
+		var run ScheduleRun
+		var scheduledAt sql.NullTime
+		if err := rows.Scan(&run.ID, &run.ScheduleID, &run.OrchestrationID, &run.Status,
+			&run.Error, &scheduledAt, &run.StartedAt); err != nil {
+			continue
+		}
+		if scheduledAt.Valid {
+			run.ScheduledAt = scheduledAt.Time
+		}
+		runs = append(runs, &run)
+	}
+
+	return runs, nil
+}
+
+// RunDueSchedules executes every enabled schedule whose next run time has passed.
+// 놓친 실행은 한 번만 수행하고 다음 실행 시각을 now 기준으로 갱신합니다.
+func (s *Service) RunDueSchedules(now time.Time) ([]*ScheduleRun, error) {
+	rows, err := s.db.Query(`
+		SELECT id, plan_id, cron, enabled, last_run_at, next_run_at, created_at
+		FROM orchestration_schedules
+		WHERE enabled = 1 AND next_run_at IS NOT NULL AND next_run_at <= ?
+	`, now)
+	if err != nil {
+		return nil, fmt.Errorf("실행 대상 스케줄 조회 실패: %w", err)
+	}
+
+	var due []*Schedule
+	for rows.Next() {
+		sched, err := scanSchedule(rows)
+		if err != nil {
+			continue
+		}
+		due = append(due, sched)
+	}
+	rows.Close()
+
+	var runs []*ScheduleRun
+	for _, sched := range due {
+		runs = append(runs, s.runSchedule(sched, now))
+	}
+
+	return runs, nil
+}
+
+// RunScheduleNow executes a schedule immediately without changing its next run time
+func (s *Service) RunScheduleNow(id string) (*ScheduleRun, error) {
+	sched, err := s.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.recordScheduleRun(sched, time.Now()), nil
+}
+
+func (s *Service) runSchedule(sched *Schedule, now time.Time) *ScheduleRun {
+	scheduledAt := now
+	if sched.NextRunAt != nil {
+		scheduledAt = *sched.NextRunAt
+	}
+
+	// 다음 실행 시각이 없으면 (zero time은 매 tick마다 due로 잡힘) NULL로 두고 비활성화
+	var next interface{}
+	enabled := false
+	if expr, err := ParseCron(sched.Cron); err == nil {
+		if nextAt := expr.Next(now); !nextAt.IsZero() {
+			next, enabled = nextAt, true
+		}
+	}
+	s.db.Exec(`
+		UPDATE orchestration_schedules SET last_run_at = ?, next_run_at = ?, enabled = ? WHERE id = ?
+	`, now, next, enabled, sched.ID)
+
+	return s.recordScheduleRun(sched, scheduledAt)
+}
+
+func (s *Service) recordScheduleRun(sched *Schedule, scheduledAt time.Time) *ScheduleRun {
+	run := &ScheduleRun{
+		ScheduleID:  sched.ID,
+		ScheduledAt: scheduledAt,
+		StartedAt:   time.Now(),
+	}
+
+	orch, err := s.launchPlan(sched.PlanID, scheduledAt)
+	if orch != nil {
+		run.OrchestrationID = orch.ID
+	}
+	if err != nil {
+		run.Status = ScheduleRunFailed
+		run.Error = err.Error()
+	} else {
+		run.Status = ScheduleRunStarted
+	}
+
+	result, err := s.db.Exec(`
+		INSERT INTO orchestration_schedule_runs
+			(schedule_id, orchestration_id, status, error, scheduled_at, started_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, run.ScheduleID, nullableString(run.OrchestrationID), run.Status,
+		nullableString(run.Error), run.ScheduledAt, run.StartedAt)
+	if err == nil {
+		run.ID, _ = result.LastInsertId()
+	}
+
+	return run
+}
+
+// launchPlan clones the plan orchestration and starts the copy
+func (s *Service) launchPlan(planID string, scheduledAt time.Time) (*OrchestrationPort, error) {
+	plan, err := s.GetOrchestration(planID)
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make([]AtomicPort, len(plan.AtomicPorts))
+	for i, p := range plan.AtomicPorts {
+		p.Status = ""
+		ports[i] = p
+	}
+
+	title := fmt.Sprintf("%s (scheduled %s)", plan.Title, scheduledAt.Format("2006-01-02 15:04"))
+	orch, err := s.CreateOrchestration(title, plan.Description, ports)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.StartOrchestration(orch.ID, ""); err != nil {
+		return orch, err
+	}
+	return orch, nil
+}
+
+type scheduleScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSchedule(row scheduleScanner) (*Schedule, error) {
+	var sched Schedule
+	var lastRunAt, nextRunAt sql.NullTime
+
+	if err := row.Scan(&sched.ID, &sched.PlanID, &sched.Cron, &sched.Enabled,
+		&lastRunAt, &nextRunAt, &sched.CreatedAt); err != nil {
+		return nil, err
+	}
+	if lastRunAt.Valid {
+		sched.LastRunAt = &lastRunAt.Time
+	}
+	if nextRunAt.Valid {
+		sched.NextRunAt = &nextRunAt.Time
+	}
+	return &sched, nil
+}
@@ -0,0 +1,162 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{"0 2 * * *", false},
+		{"*/15 * * * 1-5", false},
+		{"0 0 1,15 * *", false},
+		{"0 0 * * 7", false},
+		{"0 2 * *", true},
+		{"60 * * * *", true},
+		{"*/0 * * * *", true},
+		{"5-1 * * * *", true},
+		{"0 0 30 2 *", true},
+		{"0 0 31 4,6,9,11 *", true},
+		{"0 0 29 2 *", false},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseCron(tt.expr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCron(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2026, 3, 10, 14, 7, 30, 0, time.UTC) // Tuesday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 2 * * *", time.Date(2026, 3, 11, 2, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 10, 14, 15, 0, 0, time.UTC)},
+		{"30 9 * * 1", time.Date(2026, 3, 16, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) failed: %v", tt.expr, err)
+		}
+		if got := expr.Next(base); !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestRunDueSchedules(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database, nil, nil)
+	plan, err := svc.CreateOrchestration("Dependency bump", "", []AtomicPort{
+		{PortID: "bump-deps", Order: 1},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create plan: %v", err)
+	}
+
+	if _, err := svc.CreateSchedule(plan.ID, "bad cron"); err == nil {
+		t.Error("Expected error for invalid cron")
+	}
+
+	sched, err := svc.CreateSchedule(plan.ID, "0 2 * * *")
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+
+	// 아직 실행 시각이 아님
+	runs, err := svc.RunDueSchedules(time.Now())
+	if err != nil {
+		t.Fatalf("Failed to run schedules: %v", err)
+	}
+	if len(runs) != 0 {
+		t.Errorf("Expected no due runs, got %d", len(runs))
+	}
+
+	runs, err = svc.RunDueSchedules(sched.NextRunAt.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Failed to run schedules: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Status != ScheduleRunStarted {
+		t.Fatalf("Expected 1 started run, got %+v", runs)
+	}
+
+	orch, err := svc.GetOrchestration(runs[0].OrchestrationID)
+	if err != nil {
+		t.Fatalf("Failed to get scheduled orchestration: %v", err)
+	}
+	if orch.Status != StatusRunning || len(orch.AtomicPorts) != 1 {
+		t.Errorf("Unexpected scheduled orchestration: %+v", orch)
+	}
+
+	updated, _ := svc.GetSchedule(sched.ID)
+	if updated.LastRunAt == nil || !updated.NextRunAt.After(*sched.NextRunAt) {
+		t.Errorf("Schedule times not advanced: %+v", updated)
+	}
+
+	history, _ := svc.ListScheduleRuns(sched.ID, 10)
+	if len(history) != 1 {
+		t.Errorf("Expected 1 history entry, got %d", len(history))
+	}
+}
+
+func TestRunDueSchedulesImpossibleCron(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database, nil, nil)
+	plan, err := svc.CreateOrchestration("Impossible", "", []AtomicPort{
+		{PortID: "never", Order: 1},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create plan: %v", err)
+	}
+
+	if _, err := svc.CreateSchedule(plan.ID, "0 0 30 2 *"); err == nil {
+		t.Error("Expected error for a cron expression that never matches")
+	}
+
+	// 검증 이전에 저장된 스케줄: 한 번 실행된 뒤 비활성화되어야 함
+	past := time.Now().Add(-time.Hour)
+	if _, err := database.Exec(`
+		INSERT INTO orchestration_schedules (id, plan_id, cron, enabled, next_run_at, created_at)
+		VALUES ('legacy', ?, '0 0 30 2 *', 1, ?, ?)
+	`, plan.ID, past, past); err != nil {
+		t.Fatalf("Failed to insert schedule: %v", err)
+	}
+
+	runs, err := svc.RunDueSchedules(time.Now())
+	if err != nil {
+		t.Fatalf("Failed to run schedules: %v", err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("Expected 1 run, got %d", len(runs))
+	}
+
+	for i := 0; i < 2; i++ {
+		runs, err = svc.RunDueSchedules(time.Now().Add(time.Duration(i+1) * time.Minute))
+		if err != nil {
+			t.Fatalf("Failed to run schedules: %v", err)
+		}
+		if len(runs) != 0 {
+			t.Errorf("tick %d: expected no runs, got %d", i, len(runs))
+		}
+	}
+
+	sched, _ := svc.GetSchedule("legacy")
+	if sched.Enabled || sched.NextRunAt != nil {
+		t.Errorf("Expected disabled schedule without next run, got %+v", sched)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
 )
 
 // Pipeline represents a port execution pipeline
@@ -17,6 +18,7 @@ type Pipeline struct {
 	CreatedAt   time.Time
 	StartedAt   sql.NullTime
 	CompletedAt sql.NullTime
+	MigratedTo  sql.NullString // 이관된 Orchestration ID (pal pipeline migrate)
 }
 
 // PipelinePort represents a port in a pipeline
@@ -101,12 +103,12 @@ func (s *Service) AddDependency(portID, dependsOn string) error {
 func (s *Service) Get(id string) (*Pipeline, error) {
 	var p Pipeline
 	err := s.db.QueryRow(`
-		SELECT id, name, session_id, status, created_at, started_at, completed_at
+		SELECT id, name, session_id, status, created_at, started_at, completed_at, migrated_to
 		FROM pipelines WHERE id = ?
-	`, id).Scan(&p.ID, &p.Name, &p.SessionID, &p.Status, &p.CreatedAt, &p.StartedAt, &p.CompletedAt)
+	`, id).Scan(&p.ID, &p.Name, &p.SessionID, &p.Status, &p.CreatedAt, &p.StartedAt, &p.CompletedAt, &p.MigratedTo)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("파이프라인 '%s'을(를) 찾을 수 없습니다", id)
+		return nil, errcode.New(errcode.KindNotFound, "파이프라인 '%s'을(를) 찾을 수 없습니다", id)
 	}
 	if err != nil {
 		return nil, err
@@ -116,7 +118,7 @@ func (s *Service) Get(id string) (*Pipeline, error) {
 
 // List returns all pipelines
 func (s *Service) List(status string, limit int) ([]Pipeline, error) {
-	query := `SELECT id, name, session_id, status, created_at, started_at, completed_at FROM pipelines`
+	query := `SELECT id, name, session_id, status, created_at, started_at, completed_at, migrated_to FROM pipelines`
 	
 	var args []interface{}
 	if status != "" {
@@ -139,7 +141,7 @@ func (s *Service) List(status string, limit int) ([]Pipeline, error) {
 	var pipelines []Pipeline
 	for rows.Next() {
 		var p Pipeline
-		if err := rows.Scan(&p.ID, &p.Name, &p.SessionID, &p.Status, &p.CreatedAt, &p.StartedAt, &p.CompletedAt); err != nil {
+		if err := rows.Scan(&p.ID, &p.Name, &p.SessionID, &p.Status, &p.CreatedAt, &p.StartedAt, &p.CompletedAt, &p.MigratedTo); err != nil {
 			return nil, err
 		}
 		pipelines = append(pipelines, p)
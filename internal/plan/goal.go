@@ -0,0 +1,137 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+)
+
+// maxGoalTitle is the longest plan title taken from the first line of a goal (runes)
+const maxGoalTitle = 60
+
+// Constraints limits the size of a plan (0 = 제한 없음)
+type Constraints struct {
+	MaxPorts      int     `json:"max_ports,omitempty"`
+	MaxPortTokens int64   `json:"max_port_tokens,omitempty"` // 포트당 추정 토큰 상한
+	MaxPortHours  float64 `json:"max_port_hours,omitempty"`  // 포트당 추정 시간 상한
+	MaxHours      float64 `json:"max_hours,omitempty"`       // 전체 추정 시간 상한
+}
+
+// FromGoal proposes a plan from a free-text goal.
+// 마크다운 섹션이 있으면 ParseSpec과 같이 분해하고, 없으면 목록 항목을 포트로, 목록도 없으면 목표 전체를 포트 하나로 만듭니다.
+func FromGoal(goal string, opts Options) (*Plan, error) {
+	goal = strings.TrimSpace(goal)
+	if goal == "" {
+		return nil, errcode.New(errcode.KindValidation, "목표가 비어 있습니다")
+	}
+
+	p := ParseSpec(goal, opts)
+	if len(p.Ports) > 0 {
+		return p, nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(goal, "\r\n", "\n"), "\n")
+	p.Title = goalTitle(lines[0])
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = slugify(p.Title)
+	}
+	if prefix == "" {
+		prefix = "port"
+	}
+
+	var titles []string
+	var description []string
+	for _, line := range lines {
+		if m := bulletPattern.FindStringSubmatch(line); m != nil && leadingSpaces(line) == 0 {
+			titles = append(titles, strings.TrimSpace(m[1]))
+			continue
+		}
+		if len(titles) == 0 && strings.TrimSpace(line) != "" {
+			description = append(description, strings.TrimSpace(line))
+		}
+	}
+	if len(titles) == 0 {
+		p.Ports = []PlannedPort{{ID: prefix + "-01", Title: p.Title, Description: strings.Join(description, " ")}}
+		return p, nil
+	}
+
+	layers := make([]int, len(titles))
+	for i, title := range titles {
+		p.Ports = append(p.Ports, PlannedPort{ID: fmt.Sprintf("%s-%02d", prefix, i+1), Title: title})
+		layers[i] = detectLayer(title)
+	}
+	for i := range p.Ports {
+		p.Ports[i].DependsOn = layerDependencies(p.Ports, layers, i)
+	}
+	return p, nil
+}
+
+// FillEstimates sets def as the estimate of ports without one; 채운 포트 수를 반환합니다
+func (p *Plan) FillEstimates(def Estimate) int {
+	if def.Tokens <= 0 && def.Hours <= 0 {
+		return 0
+	}
+	filled := 0
+	for i := range p.Ports {
+		if e := p.Ports[i].Estimate; e != nil && (e.Tokens > 0 || e.Hours > 0) {
+			continue
+		}
+		est := def
+		p.Ports[i].Estimate = &est
+		filled++
+	}
+	return filled
+}
+
+// TotalEstimate sums the estimates of all ports
+func (p *Plan) TotalEstimate() Estimate {
+	var total Estimate
+	for _, port := range p.Ports {
+		if port.Estimate != nil {
+			total.Tokens += port.Estimate.Tokens
+			total.Hours += port.Estimate.Hours
+		}
+	}
+	return total
+}
+
+// CheckConstraints reports the first constraint the plan violates
+func (p *Plan) CheckConstraints(c Constraints) error {
+	if c.MaxPorts > 0 && len(p.Ports) > c.MaxPorts {
+		return errcode.New(errcode.KindValidation, "포트가 %d개로 최대 %d개를 넘습니다", len(p.Ports), c.MaxPorts)
+	}
+	for _, port := range p.Ports {
+		e := port.Estimate
+		if e == nil {
+			continue
+		}
+		if c.MaxPortTokens > 0 && e.Tokens > c.MaxPortTokens {
+			return errcode.New(errcode.KindValidation, "포트 '%s'의 추정 토큰 %d이(가) 상한 %d을(를) 넘습니다 (더 작게 분해하세요)", port.ID, e.Tokens, c.MaxPortTokens)
+		}
+		if c.MaxPortHours > 0 && e.Hours > c.MaxPortHours {
+			return errcode.New(errcode.KindValidation, "포트 '%s'의 추정 시간 %.1fh이(가) 상한 %.1fh을(를) 넘습니다 (더 작게 분해하세요)", port.ID, e.Hours, c.MaxPortHours)
+		}
+	}
+	if total := p.TotalEstimate(); c.MaxHours > 0 && total.Hours > c.MaxHours {
+		return errcode.New(errcode.KindValidation, "전체 추정 시간 %.1fh이(가) 상한 %.1fh을(를) 넘습니다", total.Hours, c.MaxHours)
+	}
+	return nil
+}
+
+func goalTitle(line string) string {
+	title := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+	if m := bulletPattern.FindStringSubmatch(title); m != nil {
+		title = strings.TrimSpace(m[1])
+	}
+	if utf8.RuneCountInString(title) > maxGoalTitle {
+		title = string([]rune(title)[:maxGoalTitle])
+	}
+	return title
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
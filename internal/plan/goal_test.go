@@ -0,0 +1,79 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFromGoal(t *testing.T) {
+	goal := `Add team billing
+카드 결제 지원
+
+- Billing schema
+- Billing API
+  - 세부 항목은 포트가 아님
+- Billing page`
+
+	p, err := FromGoal(goal, Options{})
+	if err != nil {
+		t.Fatalf("FromGoal 실패: %v", err)
+	}
+	if p.Title != "Add team billing" || len(p.Ports) != 3 {
+		t.Fatalf("Unexpected plan: %+v", p)
+	}
+	if p.Ports[0].ID != "add-team-billing-01" || p.Ports[1].Title != "Billing API" {
+		t.Errorf("Unexpected ports: %+v", p.Ports)
+	}
+	if !reflect.DeepEqual(p.Ports[1].DependsOn, []string{"add-team-billing-01"}) ||
+		!reflect.DeepEqual(p.Ports[2].DependsOn, []string{"add-team-billing-02"}) {
+		t.Errorf("Expected layered dependencies: %+v", p.Ports)
+	}
+
+	single, err := FromGoal("로그인 오류 메시지 개선\n잘못된 비밀번호일 때 안내 문구 표시", Options{Prefix: "login"})
+	if err != nil {
+		t.Fatalf("FromGoal 실패: %v", err)
+	}
+	if len(single.Ports) != 1 || single.Ports[0].ID != "login-01" || single.Ports[0].Description == "" {
+		t.Errorf("Expected single port: %+v", single.Ports)
+	}
+
+	// 섹션이 있으면 스펙과 같이 분해
+	spec, _ := FromGoal(sampleSpec, Options{})
+	if len(spec.Ports) != 4 {
+		t.Errorf("Expected spec decomposition, got %d ports", len(spec.Ports))
+	}
+
+	if _, err := FromGoal("  ", Options{}); err == nil {
+		t.Error("Expected empty goal error")
+	}
+}
+
+func TestCheckConstraints(t *testing.T) {
+	p := &Plan{Ports: []PlannedPort{
+		{ID: "a", Estimate: &Estimate{Tokens: 30000, Hours: 2}},
+		{ID: "b"},
+	}}
+	if n := p.FillEstimates(Estimate{Hours: 1}); n != 1 || p.Ports[1].Estimate.Hours != 1 {
+		t.Fatalf("FillEstimates = %d, %+v", n, p.Ports[1].Estimate)
+	}
+	if total := p.TotalEstimate(); total.Hours != 3 || total.Tokens != 30000 {
+		t.Errorf("TotalEstimate = %+v", total)
+	}
+
+	tests := []struct {
+		c    Constraints
+		fail bool
+	}{
+		{Constraints{}, false},
+		{Constraints{MaxPorts: 1}, true},
+		{Constraints{MaxPortTokens: 20000}, true},
+		{Constraints{MaxPortHours: 2}, false},
+		{Constraints{MaxPortHours: 1.5}, true},
+		{Constraints{MaxHours: 2.5}, true},
+	}
+	for _, tt := range tests {
+		if err := p.CheckConstraints(tt.c); (err != nil) != tt.fail {
+			t.Errorf("CheckConstraints(%+v) = %v", tt.c, err)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package plan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/handoff"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+)
+
+// ImportOptions controls how a plan is imported
+type ImportOptions struct {
+	Handoffs      *handoff.Store // 프로젝트 handoff 예산이 적용된 저장소 (nil = 기본 예산)
+	Orchestration bool           // 가져온 포트로 Orchestration 생성
+}
+
+// ImportResult is what a plan import created
+type ImportResult struct {
+	Ports         []string                        `json:"ports"`
+	Dependencies  int                             `json:"dependencies"`
+	Estimates     int                             `json:"estimates"`
+	Handoffs      []*handoff.Handoff              `json:"handoffs,omitempty"`
+	Orchestration *orchestrator.OrchestrationPort `json:"orchestration,omitempty"`
+}
+
+// Load reads a plan from a YAML file, or from a planning markdown file.
+// 마크다운은 YAML frontmatter 또는 첫 번째 ```yaml 코드 블록을 계획으로 사용합니다.
+func Load(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("계획 파일 읽기 실패: %w", err)
+	}
+
+	content := string(data)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		block, ok := markdownYAML(content)
+		if !ok {
+			return nil, errcode.New(errcode.KindValidation, "계획 마크다운에서 YAML frontmatter나 ```yaml 블록을 찾을 수 없습니다: %s", path)
+		}
+		content = block
+	}
+
+	var p Plan
+	if err := yaml.Unmarshal([]byte(content), &p); err != nil {
+		return nil, errcode.New(errcode.KindValidation, "계획 파싱 실패: %w", err)
+	}
+	if p.Title == "" {
+		p.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	p.Normalize()
+	return &p, nil
+}
+
+// markdownYAML extracts the plan YAML from a planning markdown document
+func markdownYAML(content string) (string, bool) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if strings.HasPrefix(content, "---\n") {
+		if end := strings.Index(content[4:], "\n---"); end >= 0 {
+			return content[4 : 4+end], true
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		fence := strings.TrimSpace(line)
+		if fence != "```yaml" && fence != "```yml" {
+			continue
+		}
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "```" {
+				return strings.Join(lines[i+1:j], "\n"), true
+			}
+		}
+	}
+	return "", false
+}
+
+// Normalize trims port IDs and fills the default spec path and handoff type
+func (p *Plan) Normalize() {
+	for i := range p.Ports {
+		port := &p.Ports[i]
+		port.ID = strings.TrimSpace(port.ID)
+		if port.Spec == "" && port.ID != "" {
+			port.Spec = filepath.ToSlash(filepath.Join("ports", port.ID+".md"))
+		}
+		for j := range port.Handoffs {
+			if port.Handoffs[j].Type == "" {
+				port.Handoffs[j].Type = string(handoff.TypeCustom)
+			}
+		}
+	}
+}
+
+// Validate checks the plan before anything is created.
+// exists는 이미 등록된 포트인지 확인하며, 선행 포트와 handoff 대상은 계획 밖의 기존 포트여도 됩니다.
+func (p *Plan) Validate(exists func(id string) bool) error {
+	if len(p.Ports) == 0 {
+		return errcode.New(errcode.KindValidation, "계획에 포트가 없습니다")
+	}
+
+	planned := make(map[string]bool)
+	for _, port := range p.Ports {
+		if port.ID == "" {
+			return errcode.New(errcode.KindValidation, "ID가 없는 포트가 있습니다")
+		}
+		if planned[port.ID] {
+			return errcode.New(errcode.KindValidation, "포트 ID가 중복되었습니다: %s", port.ID)
+		}
+		if exists(port.ID) {
+			return errcode.New(errcode.KindConflict, "포트 '%s'이(가) 이미 존재합니다", port.ID)
+		}
+		planned[port.ID] = true
+	}
+
+	known := func(id string) bool { return planned[id] || exists(id) }
+	for _, port := range p.Ports {
+		for _, dep := range port.DependsOn {
+			if dep == port.ID {
+				return errcode.New(errcode.KindValidation, "포트 '%s'이(가) 자기 자신에 의존합니다", port.ID)
+			}
+			if !known(dep) {
+				return errcode.New(errcode.KindValidation, "포트 '%s'의 선행 포트 '%s'을(를) 찾을 수 없습니다", port.ID, dep)
+			}
+		}
+		if e := port.Estimate; e != nil && (e.Tokens < 0 || e.Hours < 0) {
+			return errcode.New(errcode.KindValidation, "포트 '%s'의 추정치는 0 이상이어야 합니다", port.ID)
+		}
+		for _, h := range port.Handoffs {
+			if !known(h.To) {
+				return errcode.New(errcode.KindValidation, "포트 '%s'의 handoff 대상 '%s'을(를) 찾을 수 없습니다", port.ID, h.To)
+			}
+			if _, ok := handoff.DefaultBudgets[handoff.HandoffType(h.Type)]; !ok {
+				return errcode.New(errcode.KindValidation, "알 수 없는 handoff 타입: %s", h.Type)
+			}
+			if len(h.Content) == 0 {
+				return errcode.New(errcode.KindValidation, "포트 '%s' → '%s' handoff 내용이 비어 있습니다", port.ID, h.To)
+			}
+		}
+	}
+
+	_, err := p.Order()
+	return err
+}
+
+// Order returns the planned ports in dependency order, keeping the file order among independent ports
+func (p *Plan) Order() ([]PlannedPort, error) {
+	inPlan := make(map[string]bool)
+	for _, port := range p.Ports {
+		inPlan[port.ID] = true
+	}
+
+	done := make(map[string]bool)
+	var ordered []PlannedPort
+	for len(ordered) < len(p.Ports) {
+		progressed := false
+		for _, port := range p.Ports {
+			if done[port.ID] {
+				continue
+			}
+			ready := true
+			for _, dep := range port.DependsOn {
+				if inPlan[dep] && !done[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				done[port.ID] = true
+				ordered = append(ordered, port)
+				progressed = true
+			}
+		}
+		if !progressed {
+			var cycle []string
+			for _, port := range p.Ports {
+				if !done[port.ID] {
+					cycle = append(cycle, port.ID)
+				}
+			}
+			return nil, errcode.New(errcode.KindValidation, "포트 의존성에 순환이 있습니다: %s", strings.Join(cycle, ", "))
+		}
+	}
+	return ordered, nil
+}
+
+// Import creates the ports, dependencies, estimates and handoffs of a plan in one transaction,
+// and optionally an orchestration running the ports in dependency order.
+// 하나라도 실패하면 아무것도 생성되지 않습니다. 명세 파일은 만들지 않습니다 (SpecDocument 참고).
+func Import(database *db.DB, p *Plan, opts ImportOptions) (*ImportResult, error) {
+	exists := func(id string) bool {
+		var n int
+		database.QueryRow(`SELECT COUNT(*) FROM ports WHERE id = ?`, id).Scan(&n)
+		return n > 0
+	}
+	if err := p.Validate(exists); err != nil {
+		return nil, err
+	}
+	ordered, err := p.Order()
+	if err != nil {
+		return nil, err
+	}
+
+	hoStore := opts.Handoffs
+	if hoStore == nil {
+		hoStore = handoff.NewStore(database)
+	}
+
+	tx, err := database.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &ImportResult{}
+	for _, port := range ordered {
+		var tokens, hours interface{}
+		if e := port.Estimate; e != nil {
+			if e.Tokens > 0 {
+				tokens = e.Tokens
+			}
+			if e.Hours > 0 {
+				hours = e.Hours
+			}
+			if tokens != nil || hours != nil {
+				result.Estimates++
+			}
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO ports (id, title, file_path, status, estimate_tokens, estimate_hours)
+			VALUES (?, ?, ?, 'pending', ?, ?)
+		`, port.ID, nullIfEmpty(port.Title), nullIfEmpty(port.Spec), tokens, hours); err != nil {
+			return nil, fmt.Errorf("포트 '%s' 생성 실패: %w", port.ID, err)
+		}
+		result.Ports = append(result.Ports, port.ID)
+
+		for _, dep := range port.DependsOn {
+			if _, err := tx.Exec(`
+				INSERT OR IGNORE INTO port_dependencies (port_id, depends_on) VALUES (?, ?)
+			`, port.ID, dep); err != nil {
+				return nil, fmt.Errorf("의존성 추가 실패: %w", err)
+			}
+			result.Dependencies++
+		}
+	}
+
+	for _, port := range ordered {
+		for _, h := range port.Handoffs {
+			created, err := hoStore.CreateTx(tx, port.ID, h.To, handoff.HandoffType(h.Type), h.Content)
+			if err != nil {
+				return nil, fmt.Errorf("handoff %s → %s: %w", port.ID, h.To, err)
+			}
+			result.Handoffs = append(result.Handoffs, created)
+		}
+	}
+
+	if opts.Orchestration {
+		var atomicPorts []orchestrator.AtomicPort
+		for i, port := range ordered {
+			atomicPorts = append(atomicPorts, orchestrator.AtomicPort{
+				PortID:    port.ID,
+				Order:     i + 1,
+				DependsOn: port.DependsOn,
+			})
+		}
+		orch, err := orchestrator.NewService(database, nil, nil).CreateOrchestrationTx(tx, p.Title, p.Source, atomicPorts)
+		if err != nil {
+			return nil, err
+		}
+		result.Orchestration = orch
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("계획 가져오기 실패: %w", err)
+	}
+	return result, nil
+}
+
+// SpecDocument renders a minimal spec for a planned port with its acceptance criteria
+func SpecDocument(port PlannedPort) string {
+	title := port.Title
+	if title == "" {
+		title = port.ID
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s\n\n## 컨텍스트\n\n", title))
+	if port.Description != "" {
+		sb.WriteString(port.Description + "\n")
+	} else {
+		sb.WriteString("- 작업 목적: \n")
+	}
+	if len(port.DependsOn) > 0 {
+		sb.WriteString("\n## 입력\n\n")
+		for _, dep := range port.DependsOn {
+			sb.WriteString(fmt.Sprintf("- 선행 포트: %s\n", dep))
+		}
+	}
+	sb.WriteString("\n## 완료 기준\n\n")
+	if len(port.Acceptance) == 0 {
+		sb.WriteString("- [ ] \n")
+	}
+	for _, a := range port.Acceptance {
+		sb.WriteString(fmt.Sprintf("- [ ] %s\n", a))
+	}
+	return sb.String()
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,115 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+const samplePlanMarkdown = "# 프로필 계획\n\n플래너 메모\n\n```yaml\n" + `title: Profile
+ports:
+  - id: profile-api
+    depends_on: [profile-schema]
+  - id: profile-schema
+    estimate: {tokens: 40000, hours: 1.5}
+    handoffs:
+      - to: profile-api
+        type: schema
+        content: {table_name: profiles}
+` + "```\n"
+
+func TestLoadAndImport(t *testing.T) {
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "plan.md")
+	os.WriteFile(planPath, []byte(samplePlanMarkdown), 0644)
+
+	p, err := Load(planPath)
+	if err != nil {
+		t.Fatalf("Load 실패: %v", err)
+	}
+	if p.Title != "Profile" || len(p.Ports) != 2 || p.Ports[0].Spec != "ports/profile-api.md" {
+		t.Fatalf("Unexpected plan: %+v", p)
+	}
+	ordered, err := p.Order()
+	if err != nil || ordered[0].ID != "profile-schema" {
+		t.Fatalf("Expected profile-schema first, got %+v (%v)", ordered, err)
+	}
+
+	database, err := db.Open(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	defer database.Close()
+	if err := database.Init(); err != nil {
+		t.Fatalf("DB 초기화 실패: %v", err)
+	}
+
+	result, err := Import(database, p, ImportOptions{Orchestration: true})
+	if err != nil {
+		t.Fatalf("Import 실패: %v", err)
+	}
+	if len(result.Ports) != 2 || result.Dependencies != 1 || result.Estimates != 1 || len(result.Handoffs) != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if result.Orchestration == nil || result.Orchestration.AtomicPorts[0].PortID != "profile-schema" {
+		t.Errorf("Orchestration should run ports in dependency order: %+v", result.Orchestration)
+	}
+
+	var tokens int64
+	database.QueryRow(`SELECT estimate_tokens FROM ports WHERE id = 'profile-schema'`).Scan(&tokens)
+	if tokens != 40000 {
+		t.Errorf("Expected estimate 40000, got %d", tokens)
+	}
+
+	// 이미 존재하는 포트는 거부, 아무것도 생성되지 않음
+	if _, err := Import(database, p, ImportOptions{}); err == nil {
+		t.Error("Expected conflict for existing ports")
+	}
+
+	// handoff 예산 초과 시 트랜잭션 전체 롤백
+	big := &Plan{Title: "big", Ports: []PlannedPort{
+		{ID: "big-a", Handoffs: []PlannedHandoff{{To: "big-b", Type: "config", Content: map[string]interface{}{"x": strings.Repeat("a", 8000)}}}},
+		{ID: "big-b"},
+	}}
+	big.Normalize()
+	if _, err := Import(database, big, ImportOptions{}); err == nil {
+		t.Fatal("Expected budget error")
+	}
+	var count int
+	database.QueryRow(`SELECT COUNT(*) FROM ports WHERE id LIKE 'big-%'`).Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected rollback, found %d ports", count)
+	}
+}
+
+func TestValidatePlan(t *testing.T) {
+	none := func(string) bool { return false }
+
+	cyclic := &Plan{Ports: []PlannedPort{
+		{ID: "a", DependsOn: []string{"b"}},
+		{ID: "b", DependsOn: []string{"a"}},
+	}}
+	if err := cyclic.Validate(none); err == nil {
+		t.Error("Expected cycle error")
+	}
+
+	unknown := &Plan{Ports: []PlannedPort{{ID: "a", DependsOn: []string{"missing"}}}}
+	if err := unknown.Validate(none); err == nil {
+		t.Error("Expected unknown dependency error")
+	}
+	// 계획 밖의 기존 포트에는 의존 가능
+	if err := unknown.Validate(func(id string) bool { return id == "missing" }); err != nil {
+		t.Errorf("Existing dependency should be allowed: %v", err)
+	}
+
+	badType := &Plan{Ports: []PlannedPort{
+		{ID: "a", Handoffs: []PlannedHandoff{{To: "b", Type: "unknown", Content: map[string]interface{}{"k": "v"}}}},
+		{ID: "b"},
+	}}
+	if err := badType.Validate(none); err == nil {
+		t.Error("Expected unknown handoff type error")
+	}
+}
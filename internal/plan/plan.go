@@ -0,0 +1,407 @@
+package plan
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Plan is a proposed set of atomic ports derived from a spec.
+// 'pal port import'의 입력 포맷으로 사용됩니다.
+type Plan struct {
+	Title      string        `yaml:"title" json:"title"`
+	Source     string        `yaml:"source,omitempty" json:"source,omitempty"`
+	Acceptance []string      `yaml:"acceptance,omitempty" json:"acceptance,omitempty"`
+	Ports      []PlannedPort `yaml:"ports" json:"ports"`
+}
+
+// PlannedPort is a single proposed port
+type PlannedPort struct {
+	ID          string           `yaml:"id" json:"id"`
+	Title       string           `yaml:"title" json:"title"`
+	Description string           `yaml:"description,omitempty" json:"description,omitempty"`
+	Acceptance  []string         `yaml:"acceptance,omitempty" json:"acceptance,omitempty"`
+	DependsOn   []string         `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+	Spec        string           `yaml:"spec,omitempty" json:"spec,omitempty"`         // 명세 경로 (기본: ports/<id>.md)
+	Template    string           `yaml:"template,omitempty" json:"template,omitempty"` // 명세 생성에 사용할 포트 템플릿
+	Estimate    *Estimate        `yaml:"estimate,omitempty" json:"estimate,omitempty"`
+	Handoffs    []PlannedHandoff `yaml:"handoffs,omitempty" json:"handoffs,omitempty"`
+}
+
+// Estimate is the expected size of a planned port
+type Estimate struct {
+	Tokens int64   `yaml:"tokens,omitempty" json:"tokens,omitempty"`
+	Hours  float64 `yaml:"hours,omitempty" json:"hours,omitempty"`
+}
+
+// PlannedHandoff is a handoff from a planned port to a port that consumes its output
+type PlannedHandoff struct {
+	To      string                 `yaml:"to" json:"to"`
+	Type    string                 `yaml:"type,omitempty" json:"type,omitempty"` // 기본: custom
+	Content map[string]interface{} `yaml:"content" json:"content"`
+}
+
+// Options controls spec decomposition
+type Options struct {
+	Prefix string // 포트 ID 접두사 (기본: 제목에서 생성)
+}
+
+// 구현 레이어 (의존성 추론용, 낮을수록 먼저)
+const (
+	layerData = iota
+	layerLogic
+	layerUI
+	layerVerify
+	layerUnknown
+)
+
+var (
+	headingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	bulletPattern    = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?(.+)$`)
+	checkboxPattern  = regexp.MustCompile(`^\s*[-*+]\s+\[[ xX]\]\s+(.+)$`)
+	dependsPattern   = regexp.MustCompile(`(?i)^\s*(?:[-*+]\s+)?(?:\*\*)?(?:depends on|dependencies|requires|after|의존|선행)(?:\*\*)?\s*[:：]\s*(.+)$`)
+	slugPattern      = regexp.MustCompile(`[^a-z0-9]+`)
+	acceptanceTitles = []string{"acceptance", "criteria", "definition of done", "dod", "수용 기준", "인수 조건", "완료 조건", "검수"}
+	contextTitles    = []string{"overview", "background", "summary", "goal", "non-goal", "out of scope", "motivation",
+		"reference", "open question", "glossary", "개요", "배경", "목표", "요약", "범위 외", "참고", "용어", "미결"}
+	layerKeywords = map[int][]string{
+		layerData:   {"schema", "model", "entity", "migration", "database", "db", "storage", "스키마", "모델", "엔티티", "마이그레이션", "데이터"},
+		layerLogic:  {"service", "logic", "domain", "api", "endpoint", "handler", "backend", "서비스", "로직", "도메인", "백엔드"},
+		layerUI:     {"ui", "frontend", "view", "page", "screen", "component", "client", "화면", "프론트", "컴포넌트"},
+		layerVerify: {"test", "e2e", "qa", "docs", "documentation", "rollout", "테스트", "문서", "배포"},
+	}
+)
+
+// section is a heading with its body lines
+type section struct {
+	level    int
+	title    string
+	lines    []string
+	children []*section
+}
+
+// FromSpec reads a markdown spec file and proposes a plan
+func FromSpec(path string, opts Options) (*Plan, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("스펙 파일 읽기 실패: %w", err)
+	}
+
+	p := ParseSpec(string(content), opts)
+	p.Source = path
+	if p.Title == "" {
+		p.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if len(p.Ports) == 0 {
+		return nil, fmt.Errorf("스펙에서 포트 후보를 찾을 수 없습니다 (## 제목 섹션 필요): %s", path)
+	}
+	return p, nil
+}
+
+// ParseSpec decomposes markdown spec content using heading/acceptance heuristics
+func ParseSpec(content string, opts Options) *Plan {
+	root := parseSections(content)
+	p := &Plan{}
+
+	var top []*section
+	for _, s := range root.children {
+		if s.level == 1 && p.Title == "" {
+			p.Title = s.title
+			top = append(top, s.children...)
+			continue
+		}
+		top = append(top, s)
+	}
+
+	type candidate struct {
+		title      string
+		body       []string
+		acceptance []string
+	}
+	var candidates []candidate
+
+	for _, s := range top {
+		switch {
+		case isAcceptanceTitle(s.title):
+			p.Acceptance = append(p.Acceptance, collectBullets(s)...)
+			continue
+		case isContextTitle(s.title):
+			continue
+		}
+
+		var workChildren []*section
+		for _, c := range s.children {
+			if !isAcceptanceTitle(c.title) && !isContextTitle(c.title) {
+				workChildren = append(workChildren, c)
+			}
+		}
+
+		if len(workChildren) > 0 && !hasText(s.lines) {
+			for _, c := range workChildren {
+				candidates = append(candidates, candidate{
+					title:      fmt.Sprintf("%s: %s", s.title, c.title),
+					body:       c.lines,
+					acceptance: sectionAcceptance(c),
+				})
+			}
+			continue
+		}
+
+		candidates = append(candidates, candidate{
+			title:      s.title,
+			body:       s.lines,
+			acceptance: sectionAcceptance(s),
+		})
+	}
+
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = slugify(p.Title)
+	}
+	if prefix == "" {
+		prefix = "port"
+	}
+
+	layers := make([]int, len(candidates))
+	explicit := make([][]string, len(candidates))
+	for i, c := range candidates {
+		p.Ports = append(p.Ports, PlannedPort{
+			ID:          fmt.Sprintf("%s-%02d", prefix, i+1),
+			Title:       c.title,
+			Description: firstParagraph(c.body),
+			Acceptance:  c.acceptance,
+		})
+		layers[i] = detectLayer(c.title)
+		explicit[i] = explicitDependencies(c.body)
+	}
+
+	for i := range p.Ports {
+		if len(explicit[i]) > 0 {
+			p.Ports[i].DependsOn = resolveDependencies(p.Ports, i, explicit[i])
+			continue
+		}
+		p.Ports[i].DependsOn = layerDependencies(p.Ports, layers, i)
+	}
+
+	return p
+}
+
+// YAML renders the plan as YAML
+func (p *Plan) YAML() ([]byte, error) {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("YAML 변환 실패: %w", err)
+	}
+	return data, nil
+}
+
+func parseSections(content string) *section {
+	root := &section{level: 0}
+	stack := []*section{root}
+	inFence := false
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+
+		if !inFence {
+			if m := headingPattern.FindStringSubmatch(line); m != nil {
+				s := &section{level: len(m[1]), title: strings.TrimSpace(m[2])}
+				for len(stack) > 1 && stack[len(stack)-1].level >= s.level {
+					stack = stack[:len(stack)-1]
+				}
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, s)
+				stack = append(stack, s)
+				continue
+			}
+		}
+
+		current := stack[len(stack)-1]
+		current.lines = append(current.lines, line)
+	}
+
+	return root
+}
+
+// sectionAcceptance collects acceptance criteria of a section:
+// 수용 기준 하위 섹션의 항목 + 본문의 체크박스 항목
+func sectionAcceptance(s *section) []string {
+	var items []string
+	for _, line := range s.lines {
+		if m := checkboxPattern.FindStringSubmatch(line); m != nil {
+			items = append(items, strings.TrimSpace(m[1]))
+		}
+	}
+	for _, c := range s.children {
+		if isAcceptanceTitle(c.title) {
+			items = append(items, collectBullets(c)...)
+		}
+	}
+	return items
+}
+
+func collectBullets(s *section) []string {
+	var items []string
+	for _, line := range s.lines {
+		if m := bulletPattern.FindStringSubmatch(line); m != nil {
+			items = append(items, strings.TrimSpace(m[1]))
+		}
+	}
+	for _, c := range s.children {
+		items = append(items, collectBullets(c)...)
+	}
+	return items
+}
+
+func firstParagraph(lines []string) string {
+	var para []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			if len(para) > 0 {
+				break
+			}
+			continue
+		}
+		if bulletPattern.MatchString(line) || dependsPattern.MatchString(line) || strings.HasPrefix(trimmed, "```") {
+			if len(para) > 0 {
+				break
+			}
+			continue
+		}
+		para = append(para, trimmed)
+	}
+	return strings.Join(para, " ")
+}
+
+func hasText(lines []string) bool {
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func explicitDependencies(lines []string) []string {
+	var refs []string
+	for _, line := range lines {
+		m := dependsPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for _, ref := range strings.Split(m[1], ",") {
+			ref = strings.Trim(strings.TrimSpace(ref), "`*\"'")
+			if ref != "" {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+// resolveDependencies matches explicit references against other port IDs or titles
+func resolveDependencies(ports []PlannedPort, self int, refs []string) []string {
+	var deps []string
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		lower := strings.ToLower(ref)
+		for j, other := range ports {
+			if j == self || seen[other.ID] {
+				continue
+			}
+			title := strings.ToLower(other.Title)
+			if other.ID == ref || title == lower || strings.Contains(title, lower) {
+				deps = append(deps, other.ID)
+				seen[other.ID] = true
+				break
+			}
+		}
+	}
+	return deps
+}
+
+// layerDependencies makes a port depend on the ports of the nearest lower layer
+func layerDependencies(ports []PlannedPort, layers []int, self int) []string {
+	if layers[self] == layerUnknown || layers[self] == layerData {
+		return nil
+	}
+
+	for layer := layers[self] - 1; layer >= layerData; layer-- {
+		var deps []string
+		for j := range ports {
+			if j != self && layers[j] == layer {
+				deps = append(deps, ports[j].ID)
+			}
+		}
+		if len(deps) > 0 {
+			return deps
+		}
+	}
+	return nil
+}
+
+func detectLayer(title string) int {
+	words := strings.Fields(strings.ToLower(slugPattern.ReplaceAllString(strings.ToLower(title), " ")))
+	lowerTitle := strings.ToLower(title)
+
+	for layer := layerData; layer < layerUnknown; layer++ {
+		for _, kw := range layerKeywords[layer] {
+			// 영문 키워드는 단어 단위, 한글 키워드는 부분 문자열로 매칭
+			if isASCII(kw) {
+				for _, w := range words {
+					if w == kw {
+						return layer
+					}
+				}
+			} else if strings.Contains(lowerTitle, kw) {
+				return layer
+			}
+		}
+	}
+	return layerUnknown
+}
+
+func isAcceptanceTitle(title string) bool {
+	return containsAny(strings.ToLower(title), acceptanceTitles)
+}
+
+func isContextTitle(title string) bool {
+	return containsAny(strings.ToLower(title), contextTitles)
+}
+
+func containsAny(s string, keywords []string) bool {
+	for _, kw := range keywords {
+		if strings.Contains(s, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func slugify(s string) string {
+	slug := strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	words := strings.Split(slug, "-")
+	if len(words) > 4 {
+		words = words[:4]
+	}
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,124 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sampleSpec = `# User Profile
+
+## Overview
+
+사용자 프로필 기능을 추가한다.
+
+## Profile schema
+
+프로필 테이블과 모델을 정의한다.
+
+- [ ] profiles 테이블 생성
+- [ ] 마이그레이션 추가
+
+## Profile API
+
+프로필 조회/수정 엔드포인트.
+
+### Acceptance Criteria
+- GET /profile returns 200
+- PUT /profile validates input
+
+## Profile page
+
+프로필 화면.
+
+## Avatar upload
+
+Depends on: Profile API
+
+아바타 업로드 처리.
+
+## Acceptance Criteria
+
+- 모든 엔드포인트에 인증 필요
+`
+
+func TestParseSpec(t *testing.T) {
+	p := ParseSpec(sampleSpec, Options{})
+
+	if p.Title != "User Profile" {
+		t.Errorf("Expected title 'User Profile', got %q", p.Title)
+	}
+	if len(p.Ports) != 4 {
+		t.Fatalf("Expected 4 ports, got %d: %+v", len(p.Ports), p.Ports)
+	}
+	if p.Ports[0].ID != "user-profile-01" {
+		t.Errorf("Unexpected port ID: %s", p.Ports[0].ID)
+	}
+
+	schema, api, page, avatar := p.Ports[0], p.Ports[1], p.Ports[2], p.Ports[3]
+
+	if len(schema.Acceptance) != 2 || len(schema.DependsOn) != 0 {
+		t.Errorf("Unexpected schema port: %+v", schema)
+	}
+	if len(api.Acceptance) != 2 {
+		t.Errorf("Expected 2 acceptance criteria for API, got %v", api.Acceptance)
+	}
+	if !reflect.DeepEqual(api.DependsOn, []string{schema.ID}) {
+		t.Errorf("API should depend on schema, got %v", api.DependsOn)
+	}
+	if !reflect.DeepEqual(page.DependsOn, []string{api.ID}) {
+		t.Errorf("Page should depend on API, got %v", page.DependsOn)
+	}
+	if !reflect.DeepEqual(avatar.DependsOn, []string{api.ID}) {
+		t.Errorf("Avatar should explicitly depend on API, got %v", avatar.DependsOn)
+	}
+	if avatar.Description != "아바타 업로드 처리." {
+		t.Errorf("Unexpected description: %q", avatar.Description)
+	}
+	if len(p.Acceptance) != 1 {
+		t.Errorf("Expected 1 plan-level acceptance, got %v", p.Acceptance)
+	}
+}
+
+func TestParseSpec_NestedSections(t *testing.T) {
+	spec := "# Billing\n\n## Backend\n\n### Invoice model\n\n### Invoice service\n\n## Frontend\n\n청구서 화면\n"
+	p := ParseSpec(spec, Options{Prefix: "bill"})
+
+	if len(p.Ports) != 3 {
+		t.Fatalf("Expected 3 ports, got %d: %+v", len(p.Ports), p.Ports)
+	}
+	if p.Ports[0].Title != "Backend: Invoice model" || p.Ports[2].ID != "bill-03" {
+		t.Errorf("Unexpected ports: %+v", p.Ports)
+	}
+}
+
+func TestFromSpec(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "pal-plan-test-*")
+	if err != nil {
+		t.Fatalf("임시 디렉토리 생성 실패: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	specPath := filepath.Join(tmpDir, "feature.md")
+	os.WriteFile(specPath, []byte(sampleSpec), 0644)
+
+	p, err := FromSpec(specPath, Options{})
+	if err != nil {
+		t.Fatalf("FromSpec 실패: %v", err)
+	}
+	if p.Source != specPath {
+		t.Errorf("Expected source %s, got %s", specPath, p.Source)
+	}
+
+	data, err := p.YAML()
+	if err != nil || len(data) == 0 {
+		t.Errorf("YAML 변환 실패: %v", err)
+	}
+
+	emptyPath := filepath.Join(tmpDir, "empty.md")
+	os.WriteFile(emptyPath, []byte("no headings"), 0644)
+	if _, err := FromSpec(emptyPath, Options{}); err == nil {
+		t.Error("Expected error for spec without sections")
+	}
+}
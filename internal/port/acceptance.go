@@ -0,0 +1,122 @@
+package port
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Criterion is an acceptance criterion (definition-of-done item) of a port spec
+type Criterion struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// acceptanceHeadings are the spec sections whose checkboxes are acceptance criteria
+var acceptanceHeadings = []string{
+	"완료 기준", "완료 조건", "완료 체크리스트", "인수 조건",
+	"acceptance criteria", "definition of done",
+}
+
+// ParseAcceptanceCriteria extracts the checkbox items under the acceptance sections of a spec.
+// 섹션은 같은 수준 이상의 다음 헤딩에서 끝나며, 해당 섹션이 없으면 nil을 반환합니다.
+func ParseAcceptanceCriteria(spec string) []Criterion {
+	var criteria []Criterion
+	level := 0 // 현재 수집 중인 섹션의 헤딩 수준 (0 = 섹션 밖)
+	inFence := false
+
+	for _, raw := range strings.Split(spec, "\n") {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if h := headingLevel(line); h > 0 {
+			if level > 0 && h <= level {
+				level = 0
+			}
+			if level == 0 && isAcceptanceHeading(line[h:]) {
+				level = h
+			}
+			continue
+		}
+		if level == 0 {
+			continue
+		}
+
+		if len(line) < 2 || !strings.ContainsRune("-*+", rune(line[0])) || line[1] != ' ' {
+			continue
+		}
+		item := strings.TrimSpace(line[2:])
+		if len(item) < 3 || item[0] != '[' || item[2] != ']' {
+			continue
+		}
+		text := strings.TrimSpace(item[3:])
+		if text == "" {
+			continue
+		}
+		criteria = append(criteria, Criterion{Text: text, Done: item[1] == 'x' || item[1] == 'X'})
+	}
+	return criteria
+}
+
+func headingLevel(line string) int {
+	n := 0
+	for n < len(line) && line[n] == '#' {
+		n++
+	}
+	if n == 0 || n >= len(line) || line[n] != ' ' {
+		return 0
+	}
+	return n
+}
+
+func isAcceptanceHeading(title string) bool {
+	title = strings.ToLower(strings.TrimSpace(title))
+	for _, h := range acceptanceHeadings {
+		if strings.Contains(title, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// Remaining returns the criteria that are not checked off
+func Remaining(criteria []Criterion) []Criterion {
+	var open []Criterion
+	for _, c := range criteria {
+		if !c.Done {
+			open = append(open, c)
+		}
+	}
+	return open
+}
+
+// AcceptanceCriteria reads the port spec and returns its acceptance criteria.
+// 명세 파일이 없는 포트는 기준이 없는 것으로 간주합니다.
+func (s *Service) AcceptanceCriteria(id, projectRoot string) ([]Criterion, error) {
+	p, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !p.FilePath.Valid || p.FilePath.String == "" {
+		return nil, nil
+	}
+	specPath := p.FilePath.String
+	if !filepath.IsAbs(specPath) {
+		specPath = filepath.Join(projectRoot, specPath)
+	}
+	content, err := os.ReadFile(specPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("포트 명세 읽기 실패: %w", err)
+	}
+	return ParseAcceptanceCriteria(string(content)), nil
+}
@@ -0,0 +1,282 @@
+package port
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+)
+
+// StatusArchived marks a port whose spec and rules were moved to the archive.
+// UpdateStatus로는 설정할 수 없고 Archive/RestoreArchive로만 전환됩니다.
+const StatusArchived = "archived"
+
+// Archive reasons
+const (
+	ArchiveReasonManual    = "manual"
+	ArchiveReasonRetention = "retention"
+)
+
+// ArchiveDir is the directory archived port files are moved to (<root>/.pal/archive/ports/<id>/)
+var ArchiveDir = filepath.Join(".pal", "archive", "ports")
+
+// ArchivedFile is a file moved into the archive (프로젝트 루트 기준 경로)
+type ArchivedFile struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Archive records an archived port
+type Archive struct {
+	PortID     string         `json:"port_id"`
+	PrevStatus string         `json:"prev_status"`
+	SpecPath   string         `json:"spec_path,omitempty"`
+	ArchiveDir string         `json:"archive_dir"`
+	Files      []ArchivedFile `json:"files,omitempty"`
+	Reason     string         `json:"reason"`
+	ArchivedAt time.Time      `json:"archived_at"`
+}
+
+// Archive moves the spec and rules files of a finished port into .pal/archive/ports/<id>/
+// and keeps only the slim port row with status archived.
+func (s *Service) Archive(id, projectRoot, reason string) (*Archive, error) {
+	p, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	switch p.Status {
+	case StatusArchived:
+		return nil, errcode.New(errcode.KindConflict, "포트 '%s'은(는) 이미 아카이브되었습니다", id)
+	case StatusComplete, StatusFailed:
+	default:
+		return nil, errcode.New(errcode.KindValidation, "완료 또는 실패한 포트만 아카이브할 수 있습니다: %s (%s)", id, p.Status)
+	}
+	if reason == "" {
+		reason = ArchiveReasonManual
+	}
+
+	a := &Archive{
+		PortID:     id,
+		PrevStatus: p.Status,
+		ArchiveDir: filepath.ToSlash(filepath.Join(ArchiveDir, id)),
+		Reason:     reason,
+		ArchivedAt: time.Now(),
+	}
+
+	var sources []string
+	if p.FilePath.Valid && p.FilePath.String != "" {
+		a.SpecPath = p.FilePath.String
+		if !filepath.IsAbs(a.SpecPath) {
+			sources = append(sources, a.SpecPath)
+		}
+	}
+	sources = append(sources, filepath.ToSlash(filepath.Join(".claude", "rules", id+".md")))
+
+	archivedSpec := ""
+	for _, rel := range sources {
+		src := filepath.Join(projectRoot, rel)
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		to := filepath.ToSlash(filepath.Join(a.ArchiveDir, rel))
+		if err := moveFile(src, filepath.Join(projectRoot, to)); err != nil {
+			restoreFiles(projectRoot, a.Files)
+			return nil, fmt.Errorf("포트 파일 아카이브 실패: %w", err)
+		}
+		a.Files = append(a.Files, ArchivedFile{From: rel, To: to})
+		if rel == a.SpecPath {
+			archivedSpec = to
+		}
+	}
+
+	filesJSON, _ := json.Marshal(a.Files)
+	tx, err := s.db.Begin()
+	if err != nil {
+		restoreFiles(projectRoot, a.Files)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT INTO port_archives (port_id, prev_status, spec_path, archive_dir, files, reason, archived_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, id, a.PrevStatus, nullString(a.SpecPath), a.ArchiveDir, string(filesJSON), reason,
+		a.ArchivedAt.UTC().Format("2006-01-02 15:04:05")); err != nil {
+		restoreFiles(projectRoot, a.Files)
+		return nil, fmt.Errorf("아카이브 기록 실패: %w", err)
+	}
+	// 명세는 아카이브 위치를 가리키도록 유지 (pal port show에서 계속 조회 가능)
+	if _, err := tx.Exec(`UPDATE ports SET status = ?, file_path = COALESCE(?, file_path) WHERE id = ?`,
+		StatusArchived, nullString(archivedSpec), id); err != nil {
+		restoreFiles(projectRoot, a.Files)
+		return nil, fmt.Errorf("포트 상태 업데이트 실패: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		restoreFiles(projectRoot, a.Files)
+		return nil, err
+	}
+	return a, nil
+}
+
+// RestoreArchive moves the archived files back and restores the previous port status
+func (s *Service) RestoreArchive(id, projectRoot string) (*Archive, error) {
+	a, err := s.GetArchive(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range a.Files {
+		if _, err := os.Stat(filepath.Join(projectRoot, f.From)); err == nil {
+			return nil, errcode.New(errcode.KindConflict, "원래 위치에 파일이 이미 존재합니다: %s", f.From)
+		}
+	}
+
+	var restored []ArchivedFile
+	for _, f := range a.Files {
+		if err := moveFile(filepath.Join(projectRoot, f.To), filepath.Join(projectRoot, f.From)); err != nil {
+			// 이미 복원한 파일은 다시 아카이브로
+			for _, r := range restored {
+				moveFile(filepath.Join(projectRoot, r.From), filepath.Join(projectRoot, r.To))
+			}
+			return nil, fmt.Errorf("포트 파일 복원 실패: %w", err)
+		}
+		restored = append(restored, f)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`UPDATE ports SET status = ?, file_path = ? WHERE id = ?`,
+		a.PrevStatus, nullString(a.SpecPath), id); err != nil {
+		return nil, fmt.Errorf("포트 상태 복원 실패: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM port_archives WHERE port_id = ?`, id); err != nil {
+		return nil, fmt.Errorf("아카이브 기록 삭제 실패: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	os.RemoveAll(filepath.Join(projectRoot, a.ArchiveDir))
+	return a, nil
+}
+
+// GetArchive returns the archive record of a port
+func (s *Service) GetArchive(id string) (*Archive, error) {
+	row := s.db.QueryRow(`
+		SELECT port_id, prev_status, spec_path, archive_dir, files, reason, archived_at
+		FROM port_archives WHERE port_id = ?
+	`, id)
+	a, err := scanArchive(row)
+	if err == sql.ErrNoRows {
+		return nil, errcode.New(errcode.KindNotFound, "포트 '%s'은(는) 아카이브되지 않았습니다", id)
+	}
+	return a, err
+}
+
+// ListArchives returns archived ports, newest first
+func (s *Service) ListArchives() ([]Archive, error) {
+	rows, err := s.db.Query(`
+		SELECT port_id, prev_status, spec_path, archive_dir, files, reason, archived_at
+		FROM port_archives ORDER BY archived_at DESC, port_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("아카이브 목록 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var archives []Archive
+	for rows.Next() {
+		a, err := scanArchive(rows)
+		if err != nil {
+			return nil, err
+		}
+		archives = append(archives, *a)
+	}
+	return archives, rows.Err()
+}
+
+// ArchiveExpired archives ports completed more than olderThan ago.
+// 명세 파일이 이 프로젝트에 없는 포트(다른 프로젝트의 포트)는 건너뜁니다.
+func (s *Service) ArchiveExpired(projectRoot string, olderThan time.Duration, now time.Time) ([]Archive, error) {
+	cutoff := now.Add(-olderThan).UTC().Format("2006-01-02 15:04:05")
+	rows, err := s.db.Query(`
+		SELECT id, COALESCE(file_path, '') FROM ports
+		WHERE status = ? AND completed_at IS NOT NULL AND completed_at < ?
+		ORDER BY completed_at
+	`, StatusComplete, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("보관 대상 포트 조회 실패: %w", err)
+	}
+	type candidate struct{ id, path string }
+	var candidates []candidate
+	for rows.Next() {
+		var c candidate
+		if err := rows.Scan(&c.id, &c.path); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	rows.Close()
+
+	var archived []Archive
+	var firstErr error
+	for _, c := range candidates {
+		if c.path != "" {
+			path := c.path
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(projectRoot, path)
+			}
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+		}
+		a, err := s.Archive(c.id, projectRoot, ArchiveReasonRetention)
+		if err != nil {
+			// 한 포트의 실패가 나머지 보관을 막지 않도록 계속 진행
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		archived = append(archived, *a)
+	}
+	return archived, firstErr
+}
+
+func scanArchive(row interface{ Scan(...interface{}) error }) (*Archive, error) {
+	var a Archive
+	var specPath, files sql.NullString
+	if err := row.Scan(&a.PortID, &a.PrevStatus, &specPath, &a.ArchiveDir, &files, &a.Reason, &a.ArchivedAt); err != nil {
+		return nil, err
+	}
+	a.SpecPath = specPath.String
+	if files.Valid && files.String != "" {
+		json.Unmarshal([]byte(files.String), &a.Files)
+	}
+	return &a, nil
+}
+
+func moveFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return os.Rename(src, dst)
+}
+
+// restoreFiles rolls back files moved by a failed Archive
+func restoreFiles(projectRoot string, files []ArchivedFile) {
+	for _, f := range files {
+		moveFile(filepath.Join(projectRoot, f.To), filepath.Join(projectRoot, f.From))
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
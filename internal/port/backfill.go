@@ -0,0 +1,154 @@
+package port
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BackfillEdit is an untracked file edit to be attributed to a retroactive port
+type BackfillEdit struct {
+	FilePath string
+	Tool     string
+	EditedAt time.Time
+}
+
+// BackfillGroup is a set of edited files under the same directory
+type BackfillGroup struct {
+	Dir   string   `json:"dir"`
+	Files []string `json:"files"`
+}
+
+// BackfillOptions holds options for creating a retroactive port
+type BackfillOptions struct {
+	PortID    string
+	Title     string
+	FilePath  string // 포트 명세 문서 경로
+	SessionID string
+	Edits     []BackfillEdit
+}
+
+// GroupEditedFiles groups edited files by their directory (중복 제거, 정렬)
+func GroupEditedFiles(edits []BackfillEdit) []BackfillGroup {
+	byDir := make(map[string]map[string]bool)
+	for _, e := range edits {
+		if e.FilePath == "" {
+			continue
+		}
+		dir := path.Dir(e.FilePath)
+		if byDir[dir] == nil {
+			byDir[dir] = make(map[string]bool)
+		}
+		byDir[dir][e.FilePath] = true
+	}
+
+	groups := make([]BackfillGroup, 0, len(byDir))
+	for dir, files := range byDir {
+		g := BackfillGroup{Dir: dir}
+		for f := range files {
+			g.Files = append(g.Files, f)
+		}
+		sort.Strings(g.Files)
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Dir < groups[j].Dir })
+	return groups
+}
+
+// editRange returns the first and last edit time
+func editRange(edits []BackfillEdit) (first, last time.Time) {
+	for _, e := range edits {
+		if first.IsZero() || e.EditedAt.Before(first) {
+			first = e.EditedAt
+		}
+		if e.EditedAt.After(last) {
+			last = e.EditedAt
+		}
+	}
+	return first, last
+}
+
+// DraftBackfillSpec drafts a port spec from grouped untracked edits
+func DraftBackfillSpec(opts BackfillOptions) string {
+	title := opts.Title
+	if title == "" {
+		title = opts.PortID
+	}
+	groups := GroupEditedFiles(opts.Edits)
+	first, last := editRange(opts.Edits)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s\n\n", title))
+	sb.WriteString("> 포트 없이 진행된 작업을 기반으로 자동 생성된 회고 포트입니다.\n")
+	sb.WriteString(fmt.Sprintf("> Session: %s\n", opts.SessionID))
+	if !first.IsZero() {
+		sb.WriteString(fmt.Sprintf("> 작업 기간: %s ~ %s\n",
+			first.Format("2006-01-02 15:04"), last.Format("2006-01-02 15:04")))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## 컨텍스트\n\n")
+	sb.WriteString("- 상위 요구사항: \n")
+	sb.WriteString("- 작업 목적: \n\n")
+
+	sb.WriteString("## 작업 범위 (배타적 소유권)\n\n")
+	sb.WriteString("### 생성/수정한 파일\n")
+	for _, g := range groups {
+		sb.WriteString(fmt.Sprintf("\n**%s/**\n", g.Dir))
+		for _, f := range g.Files {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", f))
+		}
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## 완료 기준\n\n")
+	sb.WriteString(fmt.Sprintf("- [x] 파일 %d개 수정 (편집 %d회)\n", countFiles(groups), len(opts.Edits)))
+	sb.WriteString("- [ ] 변경 내용 검토\n")
+
+	return sb.String()
+}
+
+func countFiles(groups []BackfillGroup) int {
+	n := 0
+	for _, g := range groups {
+		n += len(g.Files)
+	}
+	return n
+}
+
+// Backfill creates a completed port for untracked edits and attributes the edits to it
+func (s *Service) Backfill(opts BackfillOptions) error {
+	if len(opts.Edits) == 0 {
+		return fmt.Errorf("추적되지 않은 파일 수정이 없습니다")
+	}
+	first, last := editRange(opts.Edits)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO ports (id, title, status, session_id, file_path, started_at, completed_at, duration_secs)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, opts.PortID, opts.Title, StatusComplete, opts.SessionID, opts.FilePath,
+		first, last, int64(last.Sub(first).Seconds()))
+	if err != nil {
+		return fmt.Errorf("포트 생성 실패: %w", err)
+	}
+
+	for _, e := range opts.Edits {
+		_, err := tx.Exec(`
+			INSERT INTO port_file_changes (port_id, session_id, file_path, tool, changed_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, opts.PortID, opts.SessionID, e.FilePath, e.Tool, e.EditedAt)
+		if err != nil {
+			return fmt.Errorf("파일 변경 기록 실패: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,165 @@
+package port
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+)
+
+// Blocker is an external reason a port cannot proceed (API 키 대기, 디자인 승인 등).
+// 빌드 의존성(port_dependencies)과 달리 다른 포트가 아니라 외부 상황에 막힌 것이며, 해소될 때까지 스케줄링에서 제외됩니다.
+type Blocker struct {
+	ID         int64      `json:"id"`
+	PortID     string     `json:"port_id"`
+	Reason     string     `json:"reason"`
+	ExpectedAt *time.Time `json:"expected_at,omitempty"` // 해소 예정일
+	Resolution string     `json:"resolution,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// Active reports whether the blocker is unresolved
+func (b Blocker) Active() bool {
+	return b.ResolvedAt == nil
+}
+
+// Overdue reports whether an active blocker is past its expected unblock date
+func (b Blocker) Overdue(now time.Time) bool {
+	return b.Active() && b.ExpectedAt != nil && now.After(*b.ExpectedAt)
+}
+
+// AddBlocker records an external blocker on a port
+func (s *Service) AddBlocker(portID, reason string, expectedAt *time.Time) (*Blocker, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errcode.New(errcode.KindValidation, "차단 사유가 비어 있습니다")
+	}
+	if _, err := s.Get(portID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var expected sql.NullTime
+	if expectedAt != nil {
+		expected = sql.NullTime{Time: *expectedAt, Valid: true}
+	}
+	res, err := s.db.Exec(`
+		INSERT INTO port_blockers (port_id, reason, expected_at, created_at)
+		VALUES (?, ?, ?, ?)
+	`, portID, reason, expected, now)
+	if err != nil {
+		return nil, fmt.Errorf("차단 요인 기록 실패: %w", err)
+	}
+	id, _ := res.LastInsertId()
+
+	return &Blocker{ID: id, PortID: portID, Reason: reason, ExpectedAt: expectedAt, CreatedAt: now}, nil
+}
+
+// ResolveBlockers resolves the active blockers of a port.
+// blockerID가 0이면 포트의 모든 차단 요인을 해소합니다.
+func (s *Service) ResolveBlockers(portID string, blockerID int64, resolution string) ([]Blocker, error) {
+	active, err := s.Blockers(portID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var resolved []Blocker
+	for _, b := range active {
+		if blockerID != 0 && b.ID != blockerID {
+			continue
+		}
+		if _, err := s.db.Exec(`
+			UPDATE port_blockers SET resolved_at = ?, resolution = ? WHERE id = ?
+		`, now, strings.TrimSpace(resolution), b.ID); err != nil {
+			return nil, fmt.Errorf("차단 요인 해소 실패: %w", err)
+		}
+		b.ResolvedAt = &now
+		b.Resolution = strings.TrimSpace(resolution)
+		resolved = append(resolved, b)
+	}
+
+	if len(resolved) == 0 {
+		if blockerID != 0 {
+			return nil, errcode.New(errcode.KindNotFound, "포트 %s에 차단 요인 #%d이 없습니다", portID, blockerID)
+		}
+		return nil, errcode.New(errcode.KindNotFound, "포트 %s에 해소할 차단 요인이 없습니다", portID)
+	}
+	return resolved, nil
+}
+
+// Blockers returns the blockers of a port, oldest first.
+// portID가 비어 있으면 모든 포트, includeResolved가 false면 차단 중인 것만 반환합니다.
+func (s *Service) Blockers(portID string, includeResolved bool) ([]Blocker, error) {
+	query := `
+		SELECT id, port_id, reason, expected_at, COALESCE(resolution, ''), created_at, resolved_at
+		FROM port_blockers WHERE 1=1
+	`
+	var args []interface{}
+	if portID != "" {
+		query += ` AND port_id = ?`
+		args = append(args, portID)
+	}
+	if !includeResolved {
+		query += ` AND resolved_at IS NULL`
+	}
+	query += ` ORDER BY id`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("차단 요인 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	blockers := []Blocker{}
+	for rows.Next() {
+		var b Blocker
+		var expectedAt, resolvedAt sql.NullTime
+		if err := rows.Scan(&b.ID, &b.PortID, &b.Reason, &expectedAt, &b.Resolution, &b.CreatedAt, &resolvedAt); err != nil {
+			return nil, err
+		}
+		if expectedAt.Valid {
+			b.ExpectedAt = &expectedAt.Time
+		}
+		if resolvedAt.Valid {
+			b.ResolvedAt = &resolvedAt.Time
+		}
+		blockers = append(blockers, b)
+	}
+	return blockers, rows.Err()
+}
+
+// ActiveBlockers returns the active blockers of all ports, keyed by port ID
+func (s *Service) ActiveBlockers() (map[string][]Blocker, error) {
+	blockers, err := s.Blockers("", false)
+	if err != nil {
+		return nil, err
+	}
+	byPort := make(map[string][]Blocker)
+	for _, b := range blockers {
+		byPort[b.PortID] = append(byPort[b.PortID], b)
+	}
+	return byPort, nil
+}
+
+// UnblockAt returns when the blockers are expected to be resolved: the latest expected date.
+// 예정일이 없는 차단 요인이 하나라도 있으면 known은 false입니다.
+func UnblockAt(blockers []Blocker) (at time.Time, known bool) {
+	known = true
+	for _, b := range blockers {
+		if !b.Active() {
+			continue
+		}
+		if b.ExpectedAt == nil {
+			known = false
+			continue
+		}
+		if b.ExpectedAt.After(at) {
+			at = *b.ExpectedAt
+		}
+	}
+	return at, known
+}
@@ -0,0 +1,257 @@
+package port
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Env sources (EnvVar.From 접두사)
+const (
+	EnvSourceProcess  = "env"      // env:NAME (기본: 선언한 이름 그대로)
+	EnvSourceFile     = "env_file" // env_file:<path>[#KEY]
+	EnvSourceKeychain = "keychain" // keychain:<service>[/<account>]
+)
+
+// envSectionHeadings are the port spec headings that hold the env declaration
+var envSectionHeadings = []string{"## 환경 변수", "## Environment"}
+
+// EnvVar is an environment variable or secret required by a port.
+// 포트 명세의 "## 환경 변수" 섹션에 yaml 블록으로 선언합니다:
+//
+//	env:
+//	  - name: DATABASE_URL
+//	    from: env_file:.env.test
+//	  - name: STRIPE_KEY
+//	    from: keychain:stripe/test
+//	  - name: AWS_PROFILE
+type EnvVar struct {
+	Name     string `yaml:"name" json:"name"`
+	From     string `yaml:"from,omitempty" json:"from,omitempty"`
+	Optional bool   `yaml:"optional,omitempty" json:"optional,omitempty"`
+}
+
+// Source returns the source kind and reference of the variable
+func (v EnvVar) Source() (kind, ref string) {
+	if v.From == "" {
+		return EnvSourceProcess, v.Name
+	}
+	kind, ref, found := strings.Cut(v.From, ":")
+	if !found {
+		return EnvSourceProcess, v.From
+	}
+	return kind, ref
+}
+
+// ParseEnvSpec extracts the env declaration from port spec markdown.
+// 환경 변수 섹션이 없으면 nil을 반환합니다.
+func ParseEnvSpec(content string) ([]EnvVar, error) {
+	lines := strings.Split(content, "\n")
+
+	start := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		for _, h := range envSectionHeadings {
+			if strings.EqualFold(trimmed, h) {
+				start = i + 1
+			}
+		}
+		if start >= 0 {
+			break
+		}
+	}
+	if start < 0 {
+		return nil, nil
+	}
+
+	var block []string
+	inBlock := false
+	for _, line := range lines[start:] {
+		trimmed := strings.TrimSpace(line)
+		if !inBlock && strings.HasPrefix(trimmed, "## ") {
+			break
+		}
+		if strings.HasPrefix(trimmed, "```") {
+			if inBlock {
+				break
+			}
+			inBlock = true
+			continue
+		}
+		if inBlock {
+			block = append(block, line)
+		}
+	}
+	if len(block) == 0 {
+		return nil, nil
+	}
+
+	var spec struct {
+		Env []EnvVar `yaml:"env"`
+	}
+	if err := yaml.Unmarshal([]byte(strings.Join(block, "\n")), &spec); err != nil {
+		return nil, fmt.Errorf("환경 변수 선언 파싱 실패: %w", err)
+	}
+	for _, v := range spec.Env {
+		if v.Name == "" {
+			return nil, fmt.Errorf("환경 변수 선언에 name이 없습니다")
+		}
+	}
+	return spec.Env, nil
+}
+
+// LoadEnvSpec reads the env declaration from a port spec file
+func LoadEnvSpec(specPath string) ([]EnvVar, error) {
+	content, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("포트 명세 읽기 실패: %w", err)
+	}
+	return ParseEnvSpec(string(content))
+}
+
+// EnvFor returns the env declaration of a port (명세 파일이 없으면 nil)
+func (s *Service) EnvFor(portID, projectRoot string) ([]EnvVar, error) {
+	specPath, err := s.specPath(portID, projectRoot)
+	if err != nil || specPath == "" {
+		return nil, err
+	}
+	return LoadEnvSpec(specPath)
+}
+
+// EnvNames returns the declared variable names (값은 포함하지 않음)
+func EnvNames(vars []EnvVar) []string {
+	names := make([]string, 0, len(vars))
+	for _, v := range vars {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+// ResolveEnv resolves declared variables to values.
+// 필수 변수가 하나라도 없으면 누락된 이름을 모두 담아 오류를 반환합니다.
+func ResolveEnv(vars []EnvVar, projectRoot string) (map[string]string, error) {
+	values := make(map[string]string, len(vars))
+	dotenvCache := map[string]map[string]string{}
+	var missing []string
+
+	for _, v := range vars {
+		value, ok, err := resolveEnvVar(v, projectRoot, dotenvCache)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", v.Name, err)
+		}
+		if !ok {
+			if !v.Optional {
+				missing = append(missing, v.Name)
+			}
+			continue
+		}
+		values[v.Name] = value
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return values, fmt.Errorf("필수 환경 변수를 찾을 수 없습니다: %s", strings.Join(missing, ", "))
+	}
+	return values, nil
+}
+
+func resolveEnvVar(v EnvVar, projectRoot string, dotenvCache map[string]map[string]string) (string, bool, error) {
+	kind, ref := v.Source()
+	switch kind {
+	case EnvSourceProcess:
+		value, ok := os.LookupEnv(ref)
+		return value, ok, nil
+
+	case EnvSourceFile:
+		path, key, _ := strings.Cut(ref, "#")
+		if key == "" {
+			key = v.Name
+		}
+		if !filepath.IsAbs(path) && projectRoot != "" {
+			path = filepath.Join(projectRoot, path)
+		}
+		values, ok := dotenvCache[path]
+		if !ok {
+			var err error
+			if values, err = readDotenv(path); err != nil {
+				return "", false, err
+			}
+			dotenvCache[path] = values
+		}
+		value, ok := values[key]
+		return value, ok, nil
+
+	case EnvSourceKeychain:
+		service, account, _ := strings.Cut(ref, "/")
+		return readKeychain(service, account)
+
+	default:
+		return "", false, fmt.Errorf("알 수 없는 환경 변수 소스: %s", kind)
+	}
+}
+
+// readDotenv parses KEY=VALUE lines (export 접두사, 따옴표, # 주석 지원)
+func readDotenv(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf(".env 파일 열기 실패: %w", err)
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values, scanner.Err()
+}
+
+// readKeychain reads a secret from the OS keychain (macOS: security, Linux: secret-tool)
+func readKeychain(service, account string) (string, bool, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		args := []string{"find-generic-password", "-s", service, "-w"}
+		if account != "" {
+			args = append(args, "-a", account)
+		}
+		cmd = exec.Command("security", args...)
+	case "linux":
+		args := []string{"lookup", "service", service}
+		if account != "" {
+			args = append(args, "account", account)
+		}
+		cmd = exec.Command("secret-tool", args...)
+	default:
+		return "", false, fmt.Errorf("keychain을 지원하지 않는 OS: %s", runtime.GOOS)
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("keychain 조회 실패: %w", err)
+	}
+	return strings.TrimRight(string(out), "\r\n"), true, nil
+}
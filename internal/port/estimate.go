@@ -0,0 +1,190 @@
+package port
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+)
+
+// estimateHitRange is how far actuals may deviate from the estimate and still count as accurate (±25%)
+const estimateHitRange = 0.25
+
+// Estimate is the expected size of a port (0 = 추정하지 않음)
+type Estimate struct {
+	Tokens int64   `json:"tokens,omitempty"`
+	Hours  float64 `json:"hours,omitempty"`
+}
+
+// IsZero reports whether nothing was estimated
+func (e Estimate) IsZero() bool {
+	return e.Tokens == 0 && e.Hours == 0
+}
+
+// EstimateResult compares the estimate of a completed port with its actuals
+type EstimateResult struct {
+	PortID       string    `json:"port_id"`
+	AgentID      string    `json:"agent_id,omitempty"`
+	Estimate     Estimate  `json:"estimate"`
+	ActualTokens int64     `json:"actual_tokens"`
+	ActualHours  float64   `json:"actual_hours"`
+	CompletedAt  time.Time `json:"completed_at"`
+}
+
+// TokenRatio returns actual/estimated tokens (0 if tokens were not estimated)
+func (r EstimateResult) TokenRatio() float64 {
+	if r.Estimate.Tokens <= 0 {
+		return 0
+	}
+	return float64(r.ActualTokens) / float64(r.Estimate.Tokens)
+}
+
+// HourRatio returns actual/estimated hours (0 if hours were not estimated)
+func (r EstimateResult) HourRatio() float64 {
+	if r.Estimate.Hours <= 0 {
+		return 0
+	}
+	return r.ActualHours / r.Estimate.Hours
+}
+
+// EstimateAccuracy aggregates estimate results of a group (agent, week, ...)
+type EstimateAccuracy struct {
+	Key        string  `json:"key"`
+	Ports      int     `json:"ports"`
+	TokenRatio float64 `json:"token_ratio,omitempty"` // 실제/추정 평균 (1.0 = 정확, >1 = 과소 추정)
+	TokenHits  int     `json:"token_hits"`            // ±25% 이내로 맞춘 포트 수
+	TokenPorts int     `json:"token_ports"`
+	HourRatio  float64 `json:"hour_ratio,omitempty"`
+	HourHits   int     `json:"hour_hits"`
+	HourPorts  int     `json:"hour_ports"`
+}
+
+// SetEstimate records the expected tokens and hours of a port
+func (s *Service) SetEstimate(id string, e Estimate) error {
+	if e.Tokens < 0 || e.Hours < 0 {
+		return errcode.New(errcode.KindValidation, "추정치는 0 이상이어야 합니다")
+	}
+	result, err := s.db.Exec(`UPDATE ports SET estimate_tokens = ?, estimate_hours = ? WHERE id = ?`,
+		e.Tokens, e.Hours, id)
+	if err != nil {
+		return fmt.Errorf("추정치 저장 실패: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", id)
+	}
+	return nil
+}
+
+// GetEstimate returns the estimate of a port (zero if none)
+func (s *Service) GetEstimate(id string) (Estimate, error) {
+	var e Estimate
+	err := s.db.QueryRow(`SELECT COALESCE(estimate_tokens, 0), COALESCE(estimate_hours, 0) FROM ports WHERE id = ?`, id).
+		Scan(&e.Tokens, &e.Hours)
+	if err == sql.ErrNoRows {
+		return e, errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", id)
+	}
+	if err != nil {
+		return e, fmt.Errorf("추정치 조회 실패: %w", err)
+	}
+	return e, nil
+}
+
+// EstimateResults returns the completed ports with an estimate, oldest first.
+// since가 zero면 전체 기간입니다.
+func (s *Service) EstimateResults(since time.Time) ([]EstimateResult, error) {
+	query := `
+		SELECT id, COALESCE(agent_id, ''), COALESCE(estimate_tokens, 0), COALESCE(estimate_hours, 0),
+		       input_tokens + output_tokens, duration_secs, completed_at
+		FROM ports
+		WHERE status IN (?, ?) AND completed_at IS NOT NULL
+		  AND (COALESCE(estimate_tokens, 0) > 0 OR COALESCE(estimate_hours, 0) > 0)
+	`
+	args := []interface{}{StatusComplete, StatusArchived}
+	if !since.IsZero() {
+		query += ` AND completed_at >= ?`
+		args = append(args, since.UTC().Format("2006-01-02 15:04:05"))
+	}
+	query += ` ORDER BY completed_at, id`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("추정 결과 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var results []EstimateResult
+	for rows.Next() {
+		var r EstimateResult
+		var durationSecs int64
+		if err := rows.Scan(&r.PortID, &r.AgentID, &r.Estimate.Tokens, &r.Estimate.Hours,
+			&r.ActualTokens, &durationSecs, &r.CompletedAt); err != nil {
+			return nil, err
+		}
+		r.ActualHours = float64(durationSecs) / 3600
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// AggregateEstimates groups estimate results by key, sorted by key
+func AggregateEstimates(results []EstimateResult, key func(EstimateResult) string) []EstimateAccuracy {
+	byKey := make(map[string]*EstimateAccuracy)
+	var keys []string
+	for _, r := range results {
+		k := key(r)
+		a, ok := byKey[k]
+		if !ok {
+			a = &EstimateAccuracy{Key: k}
+			byKey[k] = a
+			keys = append(keys, k)
+		}
+		a.Ports++
+		if r.Estimate.Tokens > 0 {
+			ratio := r.TokenRatio()
+			a.TokenPorts++
+			a.TokenRatio += ratio
+			if math.Abs(ratio-1) <= estimateHitRange {
+				a.TokenHits++
+			}
+		}
+		if r.Estimate.Hours > 0 {
+			ratio := r.HourRatio()
+			a.HourPorts++
+			a.HourRatio += ratio
+			if math.Abs(ratio-1) <= estimateHitRange {
+				a.HourHits++
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	accuracy := make([]EstimateAccuracy, 0, len(keys))
+	for _, k := range keys {
+		a := byKey[k]
+		if a.TokenPorts > 0 {
+			a.TokenRatio /= float64(a.TokenPorts)
+		}
+		if a.HourPorts > 0 {
+			a.HourRatio /= float64(a.HourPorts)
+		}
+		accuracy = append(accuracy, *a)
+	}
+	return accuracy
+}
+
+// ByAgent groups estimate results by the agent/worker that ran the port
+func ByAgent(r EstimateResult) string {
+	if r.AgentID == "" {
+		return "-"
+	}
+	return r.AgentID
+}
+
+// ByWeek groups estimate results by the ISO week of completion (예: 2026-W03)
+func ByWeek(r EstimateResult) string {
+	year, week := r.CompletedAt.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
@@ -0,0 +1,106 @@
+package port
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FileChange is a single file edit recorded under a port
+type FileChange struct {
+	PortID    string
+	SessionID string
+	FilePath  string
+	Tool      string
+	Additions int
+	Deletions int
+}
+
+// FileChangeSummary aggregates all edits of a file under a port
+type FileChangeSummary struct {
+	FilePath    string    `json:"file_path"`
+	Edits       int       `json:"edits"`
+	Additions   int       `json:"additions"`
+	Deletions   int       `json:"deletions"`
+	LastChanged time.Time `json:"last_changed"`
+}
+
+// RecordFileChange records a file edit under a port
+func (s *Service) RecordFileChange(fc FileChange) error {
+	if fc.PortID == "" || fc.FilePath == "" {
+		return nil
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO port_file_changes (port_id, session_id, file_path, tool, additions, deletions)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, fc.PortID, fc.SessionID, fc.FilePath, fc.Tool, fc.Additions, fc.Deletions)
+	if err != nil {
+		return fmt.Errorf("파일 변경 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// GetFileChanges returns files touched under a port with line deltas
+func (s *Service) GetFileChanges(portID string) ([]FileChangeSummary, error) {
+	rows, err := s.db.Query(`
+		SELECT file_path, COUNT(*), COALESCE(SUM(additions), 0), COALESCE(SUM(deletions), 0), MAX(changed_at)
+		FROM port_file_changes
+		WHERE port_id = ?
+		GROUP BY file_path
+		ORDER BY MAX(changed_at) DESC, file_path
+	`, portID)
+	if err != nil {
+		return nil, fmt.Errorf("파일 변경 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []FileChangeSummary
+	for rows.Next() {
+		var c FileChangeSummary
+		var lastChanged string
+		if err := rows.Scan(&c.FilePath, &c.Edits, &c.Additions, &c.Deletions, &lastChanged); err != nil {
+			return nil, err
+		}
+		c.LastChanged = parseSQLiteTime(lastChanged)
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+// DiffStat returns a lightweight line delta between two texts.
+// 줄 단위 multiset 비교로, 순서 변경은 추가/삭제로 세지 않습니다.
+func DiffStat(oldText, newText string) (additions, deletions int) {
+	counts := make(map[string]int)
+	for _, line := range splitLines(oldText) {
+		counts[line]++
+	}
+	for _, line := range splitLines(newText) {
+		if counts[line] > 0 {
+			counts[line]--
+		} else {
+			additions++
+		}
+	}
+	for _, n := range counts {
+		deletions += n
+	}
+	return additions, deletions
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// parseSQLiteTime parses MAX(changed_at), which SQLite returns as text
+func parseSQLiteTime(v string) time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
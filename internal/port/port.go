@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
 )
 
 // Port represents a work unit specification
@@ -20,7 +21,10 @@ type Port struct {
 	CompletedAt  sql.NullTime
 	InputTokens  int64
 	OutputTokens int64
-	CostUSD      float64
+	// 캐시 토큰 (transcript 시간 구간 기반 귀속)
+	CacheReadTokens   int64
+	CacheCreateTokens int64
+	CostUSD           float64
 	DurationSecs int64
 	AgentID      sql.NullString
 }
@@ -32,10 +36,13 @@ const (
 	StatusComplete = "complete"
 	StatusFailed   = "failed"
 	StatusBlocked  = "blocked"
+
+	// 완료 기준이 남은 채 port-end된 포트 (acceptance_mode: review)
+	StatusNeedsReview = "needs-review"
 )
 
 // ValidStatuses lists all valid port statuses
-var ValidStatuses = []string{StatusPending, StatusRunning, StatusComplete, StatusFailed, StatusBlocked}
+var ValidStatuses = []string{StatusPending, StatusRunning, StatusComplete, StatusFailed, StatusBlocked, StatusNeedsReview}
 
 // Service handles port operations
 type Service struct {
@@ -80,7 +87,7 @@ func (s *Service) UpdateStatus(id, status string) error {
 		}
 	}
 	if !valid {
-		return fmt.Errorf("유효하지 않은 상태: %s (가능: %v)", status, ValidStatuses)
+		return errcode.New(errcode.KindValidation, "유효하지 않은 상태: %s (가능: %v)", status, ValidStatuses)
 	}
 
 	// 상태에 따른 추가 필드 업데이트
@@ -101,7 +108,27 @@ func (s *Service) UpdateStatus(id, status string) error {
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("포트 '%s'을(를) 찾을 수 없습니다", id)
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", id)
+	}
+
+	return nil
+}
+
+// UpdateTitle updates the port title
+func (s *Service) UpdateTitle(id, title string) error {
+	var titleNull sql.NullString
+	if title != "" {
+		titleNull = sql.NullString{String: title, Valid: true}
+	}
+
+	result, err := s.db.Exec(`UPDATE ports SET title = ? WHERE id = ?`, titleNull, id)
+	if err != nil {
+		return fmt.Errorf("제목 업데이트 실패: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", id)
 	}
 
 	return nil
@@ -119,7 +146,7 @@ func (s *Service) AssignSession(portID, sessionID string) error {
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("포트 '%s'을(를) 찾을 수 없습니다", portID)
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", portID)
 	}
 
 	return nil
@@ -130,16 +157,17 @@ func (s *Service) Get(id string) (*Port, error) {
 	var p Port
 	err := s.db.QueryRow(`
 		SELECT id, title, status, session_id, file_path, created_at, started_at, completed_at,
-		       input_tokens, output_tokens, cost_usd, duration_secs, agent_id
+		       input_tokens, output_tokens, COALESCE(cache_read_tokens, 0), COALESCE(cache_create_tokens, 0),
+		       cost_usd, duration_secs, agent_id
 		FROM ports WHERE id = ?
 	`, id).Scan(
 		&p.ID, &p.Title, &p.Status, &p.SessionID, &p.FilePath,
 		&p.CreatedAt, &p.StartedAt, &p.CompletedAt,
-		&p.InputTokens, &p.OutputTokens, &p.CostUSD, &p.DurationSecs, &p.AgentID,
+		&p.InputTokens, &p.OutputTokens, &p.CacheReadTokens, &p.CacheCreateTokens, &p.CostUSD, &p.DurationSecs, &p.AgentID,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("포트 '%s'을(를) 찾을 수 없습니다", id)
+		return nil, errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", id)
 	}
 	if err != nil {
 		return nil, err
@@ -148,11 +176,12 @@ func (s *Service) Get(id string) (*Port, error) {
 	return &p, nil
 }
 
-// List returns ports with optional filters
+// List returns ports with optional filters (status가 비어 있으면 아카이브된 포트 제외)
 func (s *Service) List(status string, limit int) ([]Port, error) {
 	query := `
 		SELECT id, title, status, session_id, file_path, created_at, started_at, completed_at,
-		       input_tokens, output_tokens, cost_usd, duration_secs, agent_id
+		       input_tokens, output_tokens, COALESCE(cache_read_tokens, 0), COALESCE(cache_create_tokens, 0),
+		       cost_usd, duration_secs, agent_id
 		FROM ports
 	`
 
@@ -160,6 +189,10 @@ func (s *Service) List(status string, limit int) ([]Port, error) {
 	if status != "" {
 		query += ` WHERE status = ?`
 		args = append(args, status)
+	} else {
+		// 아카이브된 포트는 상태를 명시할 때만 조회
+		query += ` WHERE status != ?`
+		args = append(args, StatusArchived)
 	}
 
 	query += ` ORDER BY created_at DESC`
@@ -180,7 +213,7 @@ func (s *Service) List(status string, limit int) ([]Port, error) {
 		if err := rows.Scan(
 			&p.ID, &p.Title, &p.Status, &p.SessionID, &p.FilePath,
 			&p.CreatedAt, &p.StartedAt, &p.CompletedAt,
-			&p.InputTokens, &p.OutputTokens, &p.CostUSD, &p.DurationSecs, &p.AgentID,
+			&p.InputTokens, &p.OutputTokens, &p.CacheReadTokens, &p.CacheCreateTokens, &p.CostUSD, &p.DurationSecs, &p.AgentID,
 		); err != nil {
 			return nil, err
 		}
@@ -194,7 +227,8 @@ func (s *Service) List(status string, limit int) ([]Port, error) {
 func (s *Service) ListBySession(sessionID string) ([]Port, error) {
 	rows, err := s.db.Query(`
 		SELECT id, title, status, session_id, file_path, created_at, started_at, completed_at,
-		       input_tokens, output_tokens, cost_usd, duration_secs, agent_id
+		       input_tokens, output_tokens, COALESCE(cache_read_tokens, 0), COALESCE(cache_create_tokens, 0),
+		       cost_usd, duration_secs, agent_id
 		FROM ports
 		WHERE session_id = ?
 		ORDER BY created_at DESC
@@ -210,7 +244,7 @@ func (s *Service) ListBySession(sessionID string) ([]Port, error) {
 		if err := rows.Scan(
 			&p.ID, &p.Title, &p.Status, &p.SessionID, &p.FilePath,
 			&p.CreatedAt, &p.StartedAt, &p.CompletedAt,
-			&p.InputTokens, &p.OutputTokens, &p.CostUSD, &p.DurationSecs, &p.AgentID,
+			&p.InputTokens, &p.OutputTokens, &p.CacheReadTokens, &p.CacheCreateTokens, &p.CostUSD, &p.DurationSecs, &p.AgentID,
 		); err != nil {
 			return nil, err
 		}
@@ -229,8 +263,9 @@ func (s *Service) Delete(id string) error {
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("포트 '%s'을(를) 찾을 수 없습니다", id)
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", id)
 	}
+	s.db.Exec(`DELETE FROM port_blockers WHERE port_id = ?`, id)
 
 	return nil
 }
@@ -251,7 +286,7 @@ func (s *Service) UpdateUsage(id string, inputTokens, outputTokens int64, cost f
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("포트 '%s'을(를) 찾을 수 없습니다", id)
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", id)
 	}
 
 	return nil
@@ -274,7 +309,7 @@ func (s *Service) SetUsage(id string, inputTokens, outputTokens int64, cost floa
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("포트 '%s'을(를) 찾을 수 없습니다", id)
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", id)
 	}
 
 	return nil
@@ -289,7 +324,7 @@ func (s *Service) SetAgentID(id, agentID string) error {
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("포트 '%s'을(를) 찾을 수 없습니다", id)
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", id)
 	}
 
 	return nil
@@ -325,7 +360,7 @@ func (s *Service) SetDuration(id string, durationSecs int64) error {
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("포트 '%s'을(를) 찾을 수 없습니다", id)
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", id)
 	}
 
 	return nil
@@ -357,7 +392,7 @@ func (s *Service) RecordStart(portID, sessionID, agentID string) error {
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("포트 '%s'을(를) 찾을 수 없습니다", portID)
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", portID)
 	}
 
 	return nil
@@ -393,17 +428,31 @@ func (s *Service) RecordCompletion(portID string, inputTokens, outputTokens int6
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("포트 '%s'을(를) 찾을 수 없습니다", portID)
+		return errcode.New(errcode.KindNotFound, "포트 '%s'을(를) 찾을 수 없습니다", portID)
 	}
 
 	return nil
 }
 
+// SetCacheUsage records cache token usage attributed to a port
+func (s *Service) SetCacheUsage(id string, cacheReadTokens, cacheCreateTokens int64) error {
+	_, err := s.db.Exec(`
+		UPDATE ports
+		SET cache_read_tokens = ?, cache_create_tokens = ?
+		WHERE id = ?
+	`, cacheReadTokens, cacheCreateTokens, id)
+	if err != nil {
+		return fmt.Errorf("포트 캐시 사용량 기록 실패: %w", err)
+	}
+	return nil
+}
+
 // GetBySession returns ports associated with a session
 func (s *Service) GetBySession(sessionID string) ([]Port, error) {
 	rows, err := s.db.Query(`
 		SELECT id, title, status, session_id, file_path, created_at, started_at, completed_at,
-		       input_tokens, output_tokens, cost_usd, duration_secs, agent_id
+		       input_tokens, output_tokens, COALESCE(cache_read_tokens, 0), COALESCE(cache_create_tokens, 0),
+		       cost_usd, duration_secs, agent_id
 		FROM ports
 		WHERE session_id = ?
 		ORDER BY started_at DESC
@@ -419,7 +468,7 @@ func (s *Service) GetBySession(sessionID string) ([]Port, error) {
 		if err := rows.Scan(
 			&p.ID, &p.Title, &p.Status, &p.SessionID, &p.FilePath,
 			&p.CreatedAt, &p.StartedAt, &p.CompletedAt,
-			&p.InputTokens, &p.OutputTokens, &p.CostUSD, &p.DurationSecs, &p.AgentID,
+			&p.InputTokens, &p.OutputTokens, &p.CacheReadTokens, &p.CacheCreateTokens, &p.CostUSD, &p.DurationSecs, &p.AgentID,
 		); err != nil {
 			return nil, err
 		}
@@ -495,7 +544,8 @@ func (s *Service) GetStats() (*PortStats, error) {
 func (s *Service) GetRecentCompleted(limit int) ([]Port, error) {
 	query := `
 		SELECT id, title, status, session_id, file_path, created_at, started_at, completed_at,
-		       input_tokens, output_tokens, cost_usd, duration_secs, agent_id
+		       input_tokens, output_tokens, COALESCE(cache_read_tokens, 0), COALESCE(cache_create_tokens, 0),
+		       cost_usd, duration_secs, agent_id
 		FROM ports
 		WHERE status = 'complete'
 		ORDER BY completed_at DESC
@@ -516,7 +566,7 @@ func (s *Service) GetRecentCompleted(limit int) ([]Port, error) {
 		if err := rows.Scan(
 			&p.ID, &p.Title, &p.Status, &p.SessionID, &p.FilePath,
 			&p.CreatedAt, &p.StartedAt, &p.CompletedAt,
-			&p.InputTokens, &p.OutputTokens, &p.CostUSD, &p.DurationSecs, &p.AgentID,
+			&p.InputTokens, &p.OutputTokens, &p.CacheReadTokens, &p.CacheCreateTokens, &p.CostUSD, &p.DurationSecs, &p.AgentID,
 		); err != nil {
 			return nil, err
 		}
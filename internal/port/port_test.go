@@ -1,9 +1,12 @@
 package port
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
 )
@@ -215,3 +218,482 @@ func TestList_WithLimit(t *testing.T) {
 		t.Errorf("제한 수 = %d, want 5", len(limited))
 	}
 }
+
+func TestFileChanges(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	svc.Create("port-001", "Test", "")
+
+	add, del := DiffStat("a\nb\nc\n", "a\nB\nc\nd\n")
+	if add != 2 || del != 1 {
+		t.Errorf("DiffStat = +%d -%d, want +2 -1", add, del)
+	}
+
+	svc.RecordFileChange(FileChange{PortID: "port-001", FilePath: "src/a.go", Tool: "Edit", Additions: 2, Deletions: 1})
+	svc.RecordFileChange(FileChange{PortID: "port-001", FilePath: "src/a.go", Tool: "Edit", Additions: 3})
+	svc.RecordFileChange(FileChange{PortID: "port-001", FilePath: "src/b.go", Tool: "Write", Additions: 10})
+	svc.RecordFileChange(FileChange{PortID: "port-002", FilePath: "src/c.go", Tool: "Write", Additions: 1})
+
+	changes, err := svc.GetFileChanges("port-001")
+	if err != nil {
+		t.Fatalf("GetFileChanges 실패: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("파일 2개를 기대함, got %d", len(changes))
+	}
+	for _, c := range changes {
+		if c.FilePath == "src/a.go" && (c.Edits != 2 || c.Additions != 5 || c.Deletions != 1) {
+			t.Errorf("src/a.go 집계 불일치: %+v", c)
+		}
+		if c.LastChanged.IsZero() {
+			t.Errorf("LastChanged가 비어있음: %+v", c)
+		}
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	base := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	edits := []BackfillEdit{
+		{FilePath: "src/auth/login.go", Tool: "Edit", EditedAt: base},
+		{FilePath: "src/auth/login.go", Tool: "Edit", EditedAt: base.Add(5 * time.Minute)},
+		{FilePath: "src/auth/token.go", Tool: "Write", EditedAt: base.Add(10 * time.Minute)},
+		{FilePath: "docs/auth.md", Tool: "Write", EditedAt: base.Add(30 * time.Minute)},
+	}
+
+	groups := GroupEditedFiles(edits)
+	if len(groups) != 2 || groups[0].Dir != "docs" || len(groups[1].Files) != 2 {
+		t.Errorf("그룹 결과 불일치: %+v", groups)
+	}
+
+	opts := BackfillOptions{PortID: "backfill-s1", Title: "인증", SessionID: "s1", Edits: edits}
+	spec := DraftBackfillSpec(opts)
+	if !strings.Contains(spec, "`src/auth/token.go`") || !strings.Contains(spec, "파일 3개 수정 (편집 4회)") {
+		t.Errorf("명세 초안 내용 불일치:\n%s", spec)
+	}
+
+	if err := svc.Backfill(opts); err != nil {
+		t.Fatalf("Backfill 실패: %v", err)
+	}
+	p, err := svc.Get("backfill-s1")
+	if err != nil {
+		t.Fatalf("포트 조회 실패: %v", err)
+	}
+	if p.Status != StatusComplete || p.DurationSecs != 1800 {
+		t.Errorf("회고 포트 상태 불일치: status=%s duration=%d", p.Status, p.DurationSecs)
+	}
+	changes, _ := svc.GetFileChanges("backfill-s1")
+	if len(changes) != 3 {
+		t.Errorf("파일 변경 3개를 기대함, got %d", len(changes))
+	}
+
+	if err := svc.Backfill(BackfillOptions{PortID: "empty"}); err == nil {
+		t.Error("수정이 없으면 에러가 발생해야 함")
+	}
+}
+
+func TestEnvSpec(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, ".env.test"), []byte("# comment\nexport DATABASE_URL=\"postgres://test\"\nOTHER=1\n"), 0644)
+	spec := "# Port\n\n## 환경 변수\n\n```yaml\nenv:\n  - name: DATABASE_URL\n    from: env_file:.env.test\n  - name: LEGACY\n    from: env_file:.env.test#OTHER\n  - name: PAL_TEST_PORT_TOKEN\n  - name: PAL_TEST_MISSING\n    optional: true\n```\n\n## 검증\n"
+	os.WriteFile(filepath.Join(root, "port.md"), []byte(spec), 0644)
+
+	svc := NewService(database)
+	if err := svc.Create("env-port", "env", "port.md"); err != nil {
+		t.Fatalf("Create 실패: %v", err)
+	}
+
+	vars, err := svc.EnvFor("env-port", root)
+	if err != nil {
+		t.Fatalf("EnvFor 실패: %v", err)
+	}
+	if got := strings.Join(EnvNames(vars), ","); got != "DATABASE_URL,LEGACY,PAL_TEST_PORT_TOKEN,PAL_TEST_MISSING" {
+		t.Fatalf("names = %s", got)
+	}
+
+	if _, err := ResolveEnv(vars, root); err == nil || !strings.Contains(err.Error(), "PAL_TEST_PORT_TOKEN") {
+		t.Errorf("missing required var should fail, got %v", err)
+	}
+
+	t.Setenv("PAL_TEST_PORT_TOKEN", "secret")
+	values, err := ResolveEnv(vars, root)
+	if err != nil {
+		t.Fatalf("ResolveEnv 실패: %v", err)
+	}
+	if values["DATABASE_URL"] != "postgres://test" || values["LEGACY"] != "1" || values["PAL_TEST_PORT_TOKEN"] != "secret" {
+		t.Errorf("values = %v", values)
+	}
+	if _, ok := values["PAL_TEST_MISSING"]; ok {
+		t.Error("optional missing var should be skipped")
+	}
+
+	if vars, err := ParseEnvSpec("# Port\n\n## 검증\n"); err != nil || vars != nil {
+		t.Errorf("spec without env section = %v, %v", vars, err)
+	}
+}
+
+func TestParseAcceptanceCriteria(t *testing.T) {
+	spec := `# 주문 API
+
+## 작업 범위
+
+- [ ] 범위 항목은 기준이 아님
+
+## 완료 기준
+
+- [x] 주문 생성 API
+- [ ] 에러 응답 코드 정의
+  - [X] 404 정의
+
+### 검증 명령
+` + "```" + `
+- [ ] 코드 블록 안은 무시
+` + "```" + `
+
+## Acceptance Criteria
+* [ ] Load test passes
+
+## 참고
+- [ ] 참고 항목은 기준이 아님
+`
+	criteria := ParseAcceptanceCriteria(spec)
+	var got []string
+	for _, c := range criteria {
+		mark := " "
+		if c.Done {
+			mark = "x"
+		}
+		got = append(got, mark+c.Text)
+	}
+	want := "x주문 생성 API| 에러 응답 코드 정의|x404 정의| Load test passes"
+	if strings.Join(got, "|") != want {
+		t.Errorf("criteria = %q, want %q", strings.Join(got, "|"), want)
+	}
+	if remaining := Remaining(criteria); len(remaining) != 2 {
+		t.Errorf("remaining = %v", remaining)
+	}
+
+	if ParseAcceptanceCriteria("# 명세\n\n- [ ] 섹션 밖 항목\n") != nil {
+		t.Error("완료 기준 섹션이 없으면 nil이어야 함")
+	}
+}
+
+func TestAcceptanceCriteria(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "p1.md"), []byte("## 완료 체크리스트\n\n- [x] 컴파일 성공\n- [ ] 테스트 통과\n"), 0644)
+
+	svc := NewService(database)
+	svc.Create("p1", "포트1", "p1.md")
+	svc.Create("p2", "명세 없음", "")
+
+	criteria, err := svc.AcceptanceCriteria("p1", root)
+	if err != nil || len(criteria) != 2 || len(Remaining(criteria)) != 1 {
+		t.Fatalf("AcceptanceCriteria = %v, %v", criteria, err)
+	}
+	if criteria, err := svc.AcceptanceCriteria("p2", root); err != nil || criteria != nil {
+		t.Errorf("명세 없는 포트는 기준이 없어야 함: %v, %v", criteria, err)
+	}
+	if err := svc.UpdateStatus("p1", StatusNeedsReview); err != nil {
+		t.Errorf("needs-review 상태 변경 실패: %v", err)
+	}
+}
+
+func TestSpecMeta(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "pair.md"), []byte("---\nport: pair-port\ntype: port\npair: true\n---\n\n# Pair\n"), 0644)
+
+	svc := NewService(database)
+	svc.Create("pair-port", "pair", "pair.md")
+	svc.Create("missing-port", "missing", "missing.md")
+
+	if meta, err := svc.SpecMetaFor("pair-port", root); err != nil || !meta.Pair {
+		t.Errorf("pair spec = %+v, %v", meta, err)
+	}
+	if meta, err := svc.SpecMetaFor("missing-port", root); err != nil || meta.Pair {
+		t.Errorf("missing spec = %+v, %v", meta, err)
+	}
+	if meta, err := ParseSpecMeta("# Port\n\npair: true\n"); err != nil || meta.Pair {
+		t.Errorf("body without frontmatter = %+v, %v", meta, err)
+	}
+	if _, err := ParseSpecMeta("---\npair: [\n---\n"); err == nil {
+		t.Error("invalid frontmatter should fail")
+	}
+}
+
+func TestScratchpad(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	svc.Create("scratch-port", "scratch", "")
+
+	if _, err := svc.AppendScratch("scratch-port", "", "  "); err == nil {
+		t.Error("empty note should be rejected")
+	}
+	if _, err := svc.AppendScratch("no-port", "", "note"); err == nil {
+		t.Error("note on unknown port should fail")
+	}
+
+	svc.AppendScratch("scratch-port", "session-aaaaaaaaaa", "테스트 DB는 매번 migrate 필요")
+	svc.AppendScratch("scratch-port", "", "fixture 순서 주의\n두 번째 줄")
+
+	notes, err := svc.Scratchpad("scratch-port")
+	if err != nil {
+		t.Fatalf("Scratchpad 실패: %v", err)
+	}
+	if len(notes) != 2 || notes[0].SessionID != "session-aaaaaaaaaa" || notes[1].SessionID != "" {
+		t.Fatalf("notes = %+v", notes)
+	}
+
+	doc := RenderScratchpad("scratch-port", notes)
+	if !strings.Contains(doc, "(session-) 테스트 DB") || !strings.Contains(doc, "(user) fixture 순서 주의\n  두 번째 줄") {
+		t.Errorf("document = %s", doc)
+	}
+}
+
+func TestBlockers(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	svc.Create("blocked-port", "blocked", "")
+
+	if _, err := svc.AddBlocker("blocked-port", " ", nil); err == nil {
+		t.Error("empty reason should be rejected")
+	}
+	if _, err := svc.AddBlocker("no-port", "API 키 대기", nil); err == nil {
+		t.Error("blocker on unknown port should fail")
+	}
+
+	due := time.Now().Add(-time.Hour)
+	keys, _ := svc.AddBlocker("blocked-port", "API 키 대기", &due)
+	design, _ := svc.AddBlocker("blocked-port", "디자인 승인", nil)
+
+	active, err := svc.ActiveBlockers()
+	if err != nil {
+		t.Fatalf("ActiveBlockers 실패: %v", err)
+	}
+	if len(active["blocked-port"]) != 2 {
+		t.Fatalf("active = %+v", active)
+	}
+	if !active["blocked-port"][0].Overdue(time.Now()) || active["blocked-port"][1].Overdue(time.Now()) {
+		t.Error("only the blocker past its expected date should be overdue")
+	}
+	if _, known := UnblockAt(active["blocked-port"]); known {
+		t.Error("unblock date should be unknown while a blocker has no expected date")
+	}
+
+	resolved, err := svc.ResolveBlockers("blocked-port", design.ID, "승인됨")
+	if err != nil || len(resolved) != 1 || resolved[0].Resolution != "승인됨" {
+		t.Fatalf("ResolveBlockers = %+v, %v", resolved, err)
+	}
+	remaining, _ := svc.Blockers("blocked-port", false)
+	if len(remaining) != 1 || remaining[0].ID != keys.ID {
+		t.Fatalf("remaining = %+v", remaining)
+	}
+	if at, known := UnblockAt(remaining); !known || !at.Equal(due) {
+		t.Errorf("UnblockAt = %v, %v; want %v", at, known, due)
+	}
+
+	if _, err := svc.ResolveBlockers("blocked-port", 0, ""); err != nil {
+		t.Fatalf("resolve all 실패: %v", err)
+	}
+	if _, err := svc.ResolveBlockers("blocked-port", 0, ""); err == nil {
+		t.Error("resolving without active blockers should fail")
+	}
+	all, _ := svc.Blockers("blocked-port", true)
+	if len(all) != 2 {
+		t.Errorf("history = %d, want 2", len(all))
+	}
+}
+
+func TestArchiveAndRestore(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "ports"), 0755)
+	os.MkdirAll(filepath.Join(root, ".claude", "rules"), 0755)
+	os.WriteFile(filepath.Join(root, "ports", "old.md"), []byte("# old"), 0644)
+	os.WriteFile(filepath.Join(root, ".claude", "rules", "old.md"), []byte("rule"), 0644)
+	os.WriteFile(filepath.Join(root, "ports", "new.md"), []byte("# new"), 0644)
+
+	svc := NewService(database)
+	svc.Create("old", "오래된 포트", "ports/old.md")
+	svc.Create("new", "최근 포트", "ports/new.md")
+	svc.Create("wip", "진행 중", "")
+	svc.UpdateStatus("old", StatusComplete)
+	svc.UpdateStatus("new", StatusComplete)
+	database.Exec(`UPDATE ports SET completed_at = datetime('now', '-40 days') WHERE id = 'old'`)
+
+	if _, err := svc.Archive("wip", root, ""); err == nil {
+		t.Error("진행 중인 포트는 아카이브할 수 없어야 함")
+	}
+
+	archived, err := svc.ArchiveExpired(root, 30*24*time.Hour, time.Now())
+	if err != nil || len(archived) != 1 || archived[0].PortID != "old" {
+		t.Fatalf("ArchiveExpired = %+v, %v", archived, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "ports", "old.md")); !os.IsNotExist(err) {
+		t.Error("명세 파일이 아카이브로 이동되어야 함")
+	}
+	if _, err := os.Stat(filepath.Join(root, ".pal", "archive", "ports", "old", ".claude", "rules", "old.md")); err != nil {
+		t.Errorf("rules 파일이 아카이브되어야 함: %v", err)
+	}
+
+	p, _ := svc.Get("old")
+	if p.Status != StatusArchived || p.FilePath.String != ".pal/archive/ports/old/ports/old.md" {
+		t.Errorf("포트 행 불일치: status=%s file=%s", p.Status, p.FilePath.String)
+	}
+	if ports, _ := svc.List("", 0); len(ports) != 2 {
+		t.Errorf("기본 목록에서 아카이브된 포트는 제외되어야 함: %d", len(ports))
+	}
+
+	a, err := svc.RestoreArchive("old", root)
+	if err != nil || a.PrevStatus != StatusComplete {
+		t.Fatalf("RestoreArchive = %+v, %v", a, err)
+	}
+	p, _ = svc.Get("old")
+	if p.Status != StatusComplete || p.FilePath.String != "ports/old.md" {
+		t.Errorf("복원 후 포트 행 불일치: status=%s file=%s", p.Status, p.FilePath.String)
+	}
+	if content, err := os.ReadFile(filepath.Join(root, "ports", "old.md")); err != nil || string(content) != "# old" {
+		t.Errorf("명세 파일이 복원되어야 함: %v", err)
+	}
+	if _, err := svc.GetArchive("old"); err == nil {
+		t.Error("복원 후 아카이브 기록이 삭제되어야 함")
+	}
+}
+
+func TestCompletionSummary(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "ports"), 0755)
+	os.WriteFile(filepath.Join(root, "ports", "auth.md"), []byte(`# 인증
+
+## API
+
+- POST /login → 토큰 발급
+
+## 주의 사항
+
+- 토큰 만료는 15분 고정
+
+## 완료 기준
+
+- [x] 로그인 구현
+- [ ] 리프레시 토큰
+`), 0644)
+
+	svc := NewService(database)
+	svc.Create("auth", "인증", "ports/auth.md")
+	svc.Create("profile", "프로필", "")
+	svc.RecordFileChange(FileChange{PortID: "auth", FilePath: "internal/auth/login.go", Tool: "Write", Additions: 120})
+	database.Exec(`INSERT INTO port_dependencies (port_id, depends_on) VALUES ('profile', 'auth')`)
+
+	sum, err := svc.BuildCompletionSummary("auth", root)
+	if err != nil {
+		t.Fatalf("BuildCompletionSummary 실패: %v", err)
+	}
+	text := sum.Text()
+	for _, want := range []string{"internal/auth/login.go (+120)", "POST /login", "토큰 만료는 15분 고정", "미완료: 리프레시 토큰"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("요약에 %q가 없음:\n%s", want, text)
+		}
+	}
+
+	// 예산을 넘으면 파일 목록부터 줄임
+	for i := 0; i < 60; i++ {
+		sum.Files = append(sum.Files, fmt.Sprintf("internal/generated/file_%02d.go (+10)", i))
+	}
+	if long := sum.Text(); len(long)/4 > SummaryTokenBudget || !strings.Contains(long, "외 ") {
+		t.Errorf("요약이 예산을 넘음 (%d 토큰):\n%s", len(long)/4, long)
+	}
+
+	if deps, _ := svc.DependencySummaries("profile"); len(deps) != 0 {
+		t.Errorf("요약 저장 전에는 비어 있어야 함: %+v", deps)
+	}
+	svc.SetSummary("auth", text)
+	deps, err := svc.DependencySummaries("profile")
+	if err != nil || len(deps) != 1 || deps[0].PortID != "auth" || deps[0].Summary != text {
+		t.Fatalf("DependencySummaries = %+v, %v", deps, err)
+	}
+	if md := DependencySummariesMarkdown(deps); !strings.Contains(md, "### auth (인증)") {
+		t.Errorf("Markdown 헤딩 불일치:\n%s", md)
+	}
+}
+
+func TestEstimateAccuracy(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	for _, id := range []string{"a", "b", "c", "none"} {
+		svc.Create(id, id, "")
+	}
+	if err := svc.SetEstimate("missing", Estimate{Tokens: 1}); err == nil {
+		t.Error("없는 포트의 추정치 기록은 실패해야 함")
+	}
+	svc.SetEstimate("a", Estimate{Tokens: 1000, Hours: 1})
+	svc.SetEstimate("b", Estimate{Tokens: 1000})
+	svc.SetEstimate("c", Estimate{Hours: 2})
+
+	// a: 정확, b: 2배 과소 추정, c: 시간 절반
+	database.Exec(`UPDATE ports SET status = 'complete', completed_at = '2026-01-05 10:00:00', agent_id = 'worker-go',
+		input_tokens = 900, output_tokens = 100, duration_secs = 3600 WHERE id = 'a'`)
+	database.Exec(`UPDATE ports SET status = 'complete', completed_at = '2026-01-06 10:00:00', agent_id = 'worker-go',
+		input_tokens = 1500, output_tokens = 500 WHERE id = 'b'`)
+	database.Exec(`UPDATE ports SET status = 'complete', completed_at = '2026-01-14 10:00:00',
+		duration_secs = 3600 WHERE id = 'c'`)
+	database.Exec(`UPDATE ports SET status = 'complete', completed_at = '2026-01-14 10:00:00' WHERE id = 'none'`)
+
+	if est, _ := svc.GetEstimate("a"); est.Tokens != 1000 || est.Hours != 1 {
+		t.Errorf("GetEstimate = %+v", est)
+	}
+
+	results, err := svc.EstimateResults(time.Time{})
+	if err != nil || len(results) != 3 {
+		t.Fatalf("EstimateResults = %d개, %v (추정치 없는 포트 제외)", len(results), err)
+	}
+
+	byAgent := AggregateEstimates(results, ByAgent)
+	if len(byAgent) != 2 || byAgent[1].Key != "worker-go" {
+		t.Fatalf("ByAgent = %+v", byAgent)
+	}
+	goAcc := byAgent[1]
+	if goAcc.Ports != 2 || goAcc.TokenPorts != 2 || goAcc.TokenHits != 1 || goAcc.TokenRatio != 1.5 {
+		t.Errorf("worker-go 토큰 정확도 = %+v", goAcc)
+	}
+	if goAcc.HourPorts != 1 || goAcc.HourHits != 1 {
+		t.Errorf("worker-go 시간 정확도 = %+v", goAcc)
+	}
+	if byAgent[0].Key != "-" || byAgent[0].HourRatio != 0.5 || byAgent[0].HourHits != 0 {
+		t.Errorf("에이전트 없는 포트 정확도 = %+v", byAgent[0])
+	}
+
+	byWeek := AggregateEstimates(results, ByWeek)
+	if len(byWeek) != 2 || byWeek[0].Key != "2026-W02" || byWeek[0].Ports != 2 {
+		t.Errorf("ByWeek = %+v", byWeek)
+	}
+
+	recent, _ := svc.EstimateResults(time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC))
+	if len(recent) != 1 || recent[0].PortID != "c" {
+		t.Errorf("since 필터 결과 = %+v", recent)
+	}
+}
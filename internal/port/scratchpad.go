@@ -0,0 +1,96 @@
+package port
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+)
+
+// ScratchNote is an informal note left on a port's shared scratchpad.
+// 핸드오프와 달리 형식이 없고 추가만 가능하며, 포트를 작업하는 모든 세션이 읽고 남길 수 있습니다.
+type ScratchNote struct {
+	ID        int64     `json:"id"`
+	PortID    string    `json:"port_id"`
+	SessionID string    `json:"session_id,omitempty"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AppendScratch appends a note to the port's scratchpad
+func (s *Service) AppendScratch(portID, sessionID, content string) (*ScratchNote, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, errcode.New(errcode.KindValidation, "메모 내용이 비어 있습니다")
+	}
+	if _, err := s.Get(portID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var sessionNull sql.NullString
+	if sessionID != "" {
+		sessionNull = sql.NullString{String: sessionID, Valid: true}
+	}
+	res, err := s.db.Exec(`
+		INSERT INTO port_scratchpad (port_id, session_id, content, created_at)
+		VALUES (?, ?, ?, ?)
+	`, portID, sessionNull, content, now)
+	if err != nil {
+		return nil, fmt.Errorf("스크래치패드 기록 실패: %w", err)
+	}
+	id, _ := res.LastInsertId()
+
+	return &ScratchNote{ID: id, PortID: portID, SessionID: sessionID, Content: content, CreatedAt: now}, nil
+}
+
+// Scratchpad returns the notes of a port, oldest first
+func (s *Service) Scratchpad(portID string) ([]ScratchNote, error) {
+	rows, err := s.db.Query(`
+		SELECT id, port_id, COALESCE(session_id, ''), content, created_at
+		FROM port_scratchpad WHERE port_id = ?
+		ORDER BY id
+	`, portID)
+	if err != nil {
+		return nil, fmt.Errorf("스크래치패드 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	notes := []ScratchNote{}
+	for rows.Next() {
+		var n ScratchNote
+		if err := rows.Scan(&n.ID, &n.PortID, &n.SessionID, &n.Content, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+	return notes, nil
+}
+
+// RenderScratchpad renders the notes as a markdown document
+func RenderScratchpad(portID string, notes []ScratchNote) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Scratchpad: %s\n", portID)
+	if len(notes) == 0 {
+		b.WriteString("\n(메모 없음)\n")
+		return b.String()
+	}
+	for _, n := range notes {
+		author := "user"
+		if n.SessionID != "" {
+			author = shortID(n.SessionID)
+		}
+		fmt.Fprintf(&b, "\n- **%s** (%s) %s\n", n.CreatedAt.Local().Format("2006-01-02 15:04"), author,
+			strings.ReplaceAll(n.Content, "\n", "\n  "))
+	}
+	return b.String()
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,70 @@
+package port
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpecMeta holds the orchestration flags declared in a port spec frontmatter.
+//
+//	---
+//	port: auth-login
+//	pair: true
+//	---
+type SpecMeta struct {
+	Pair bool `yaml:"pair" json:"pair"` // Impl/Test 워커 쌍으로 실행
+}
+
+// ParseSpecMeta reads the orchestration flags from port spec markdown.
+// frontmatter가 없으면 기본값을 반환합니다.
+func ParseSpecMeta(content string) (SpecMeta, error) {
+	var meta SpecMeta
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return meta, nil
+	}
+	end := strings.Index(content[4:], "\n---")
+	if end < 0 {
+		return meta, nil
+	}
+	if err := yaml.Unmarshal([]byte(content[4:4+end]), &meta); err != nil {
+		return meta, fmt.Errorf("포트 명세 frontmatter 파싱 실패: %w", err)
+	}
+	return meta, nil
+}
+
+// SpecMetaFor returns the orchestration flags of a port (명세 파일이 없으면 기본값)
+func (s *Service) SpecMetaFor(portID, projectRoot string) (SpecMeta, error) {
+	specPath, err := s.specPath(portID, projectRoot)
+	if err != nil || specPath == "" {
+		return SpecMeta{}, err
+	}
+	content, err := os.ReadFile(specPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return SpecMeta{}, nil
+		}
+		return SpecMeta{}, fmt.Errorf("포트 명세 읽기 실패: %w", err)
+	}
+	return ParseSpecMeta(string(content))
+}
+
+// specPath resolves the spec file of a port against the project root ("" if none is recorded)
+func (s *Service) specPath(portID, projectRoot string) (string, error) {
+	p, err := s.Get(portID)
+	if err != nil {
+		return "", err
+	}
+	if !p.FilePath.Valid || p.FilePath.String == "" {
+		return "", nil
+	}
+	path := p.FilePath.String
+	if !filepath.IsAbs(path) && projectRoot != "" {
+		path = filepath.Join(projectRoot, path)
+	}
+	return path, nil
+}
@@ -0,0 +1,243 @@
+package port
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SummaryTokenBudget is the token budget of a completed port summary (~4 chars per token)
+const SummaryTokenBudget = 200
+
+// summarySections are the spec sections whose bullets are carried into the summary
+var (
+	apiHeadings    = []string{"api", "인터페이스", "interface", "엔드포인트", "endpoint", "공개 함수"}
+	caveatHeadings = []string{"주의", "caveat", "제약", "알려진 이슈", "known issue", "트레이드오프", "tradeoff"}
+)
+
+// CompletionSummary is a short "what changed" note of a completed port for its dependents
+type CompletionSummary struct {
+	PortID  string   `json:"port_id"`
+	Title   string   `json:"title,omitempty"`
+	Files   []string `json:"files,omitempty"`
+	APIs    []string `json:"apis,omitempty"`
+	Caveats []string `json:"caveats,omitempty"`
+}
+
+// DependencySummary is the stored summary of a port another port depends on
+type DependencySummary struct {
+	PortID  string `json:"port_id"`
+	Title   string `json:"title,omitempty"`
+	Summary string `json:"summary"`
+}
+
+// BuildCompletionSummary collects the files changed under a port, the APIs and caveats
+// listed in its spec, and the acceptance criteria left unchecked.
+func (s *Service) BuildCompletionSummary(id, projectRoot string) (*CompletionSummary, error) {
+	p, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	sum := &CompletionSummary{PortID: id}
+	if p.Title.Valid {
+		sum.Title = p.Title.String
+	}
+
+	changes, err := s.GetFileChanges(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range changes {
+		delta := fmt.Sprintf("+%d", c.Additions)
+		if c.Deletions > 0 {
+			delta += fmt.Sprintf("/-%d", c.Deletions)
+		}
+		sum.Files = append(sum.Files, fmt.Sprintf("%s (%s)", c.FilePath, delta))
+	}
+
+	if p.FilePath.Valid && p.FilePath.String != "" && projectRoot != "" {
+		specPath := p.FilePath.String
+		if !filepath.IsAbs(specPath) {
+			specPath = filepath.Join(projectRoot, specPath)
+		}
+		if content, err := os.ReadFile(specPath); err == nil {
+			spec := string(content)
+			sum.APIs = sectionBullets(spec, apiHeadings)
+			sum.Caveats = sectionBullets(spec, caveatHeadings)
+			for _, c := range Remaining(ParseAcceptanceCriteria(spec)) {
+				sum.Caveats = append(sum.Caveats, "미완료: "+c.Text)
+			}
+		}
+	}
+	return sum, nil
+}
+
+// IsEmpty reports whether there is nothing to tell dependent ports
+func (c *CompletionSummary) IsEmpty() bool {
+	return len(c.Files) == 0 && len(c.APIs) == 0 && len(c.Caveats) == 0
+}
+
+// Text renders the summary within SummaryTokenBudget, dropping the least recent items first
+func (c *CompletionSummary) Text() string {
+	files, apis, caveats := c.Files, c.APIs, c.Caveats
+	for {
+		text := renderSummary(c.Files, files, apis, caveats)
+		if len(text)/4 <= SummaryTokenBudget {
+			return text
+		}
+		// 가장 긴 목록부터 줄임 (주의 사항은 마지막까지 유지)
+		switch {
+		case len(files) > 1 && len(files) >= len(apis):
+			files = files[:len(files)-1]
+		case len(apis) > 1:
+			apis = apis[:len(apis)-1]
+		case len(caveats) > 1:
+			caveats = caveats[:len(caveats)-1]
+		default:
+			limit := SummaryTokenBudget*4 - len("…")
+			for limit > 0 && !isRuneStart(text[limit]) {
+				limit--
+			}
+			return text[:limit] + "…"
+		}
+	}
+}
+
+func renderSummary(all, files, apis, caveats []string) string {
+	var lines []string
+	if len(files) > 0 {
+		line := "- 파일: " + strings.Join(files, ", ")
+		if rest := len(all) - len(files); rest > 0 {
+			line += fmt.Sprintf(" 외 %d개", rest)
+		}
+		lines = append(lines, line)
+	}
+	if len(apis) > 0 {
+		lines = append(lines, "- API: "+strings.Join(apis, "; "))
+	}
+	for _, c := range caveats {
+		lines = append(lines, "- 주의: "+c)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// sectionBullets returns the list items under the spec sections matching headings
+func sectionBullets(spec string, headings []string) []string {
+	var items []string
+	level := 0
+	inFence := false
+	for _, raw := range strings.Split(spec, "\n") {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if h := headingLevel(line); h > 0 {
+			if level > 0 && h <= level {
+				level = 0
+			}
+			if level == 0 && headingMatches(line[h:], headings) {
+				level = h
+			}
+			continue
+		}
+		if level == 0 || len(line) < 2 || !strings.ContainsRune("-*+", rune(line[0])) || line[1] != ' ' {
+			continue
+		}
+		item := strings.TrimSpace(line[2:])
+		// 체크박스 표기 제거
+		if len(item) >= 3 && item[0] == '[' && item[2] == ']' {
+			item = strings.TrimSpace(item[3:])
+		}
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func headingMatches(title string, headings []string) bool {
+	title = strings.ToLower(strings.TrimSpace(title))
+	for _, h := range headings {
+		if strings.Contains(title, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetSummary stores the completion summary of a port
+func (s *Service) SetSummary(id, summary string) error {
+	if _, err := s.db.Exec(`UPDATE ports SET summary = ? WHERE id = ?`, nullString(summary), id); err != nil {
+		return fmt.Errorf("포트 요약 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// GetSummary returns the stored completion summary of a port ("" if none)
+func (s *Service) GetSummary(id string) (string, error) {
+	var summary sql.NullString
+	err := s.db.QueryRow(`SELECT summary FROM ports WHERE id = ?`, id).Scan(&summary)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("포트 요약 조회 실패: %w", err)
+	}
+	return summary.String, nil
+}
+
+// DependencySummaries returns the stored summaries of the ports id depends on
+func (s *Service) DependencySummaries(id string) ([]DependencySummary, error) {
+	rows, err := s.db.Query(`
+		SELECT p.id, COALESCE(p.title, ''), p.summary
+		FROM port_dependencies d
+		JOIN ports p ON p.id = d.depends_on
+		WHERE d.port_id = ? AND p.summary IS NOT NULL AND p.summary != ''
+		ORDER BY p.completed_at, p.id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("선행 포트 요약 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []DependencySummary
+	for rows.Next() {
+		var d DependencySummary
+		if err := rows.Scan(&d.PortID, &d.Title, &d.Summary); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, d)
+	}
+	return summaries, rows.Err()
+}
+
+// DependencySummariesMarkdown renders dependency summaries as a rules section ("" if none)
+func DependencySummariesMarkdown(summaries []DependencySummary) string {
+	if len(summaries) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n---\n\n")
+	sb.WriteString("## 선행 포트 변경 요약\n\n")
+	sb.WriteString("> 이 포트가 의존하는 포트가 완료되며 남긴 요약입니다. 핸드오프(pal handoff)와 함께 참고하세요.\n\n")
+	for _, d := range summaries {
+		if d.Title != "" && d.Title != d.PortID {
+			sb.WriteString(fmt.Sprintf("### %s (%s)\n\n", d.PortID, d.Title))
+		} else {
+			sb.WriteString(fmt.Sprintf("### %s\n\n", d.PortID))
+		}
+		sb.WriteString(d.Summary)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,345 @@
+// Package primer builds and caches a compact project primer for cold session starts.
+// 데몬이 주기적으로 갱신하고, SessionStart Hook은 캐시를 그대로 주입하여
+// 브리핑/문서 조회를 다시 계산하지 않습니다.
+package primer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/convention"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/document"
+	"github.com/n0roo/pal-kit/internal/operator"
+)
+
+// Primer limits
+const (
+	maxSections    = 12
+	maxOverview    = 400
+	maxLayout      = 15
+	maxConventions = 5
+	maxDomains     = 5
+)
+
+// architectureSources are the files the architecture summary is read from, in order
+var architectureSources = []string{
+	"ARCHITECTURE.md",
+	filepath.Join("docs", "architecture.md"),
+	filepath.Join("docs", "ARCHITECTURE.md"),
+	"CLAUDE.md",
+	"README.md",
+}
+
+// skippedDirs are top-level directories left out of the layout
+var skippedDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "dist": true, "build": true, "target": true, "bin": true,
+}
+
+// Primer is a compressed project overview injected at session start
+type Primer struct {
+	GeneratedAt  time.Time        `json:"generated_at"`
+	ProjectName  string           `json:"project_name"`
+	Architecture Architecture     `json:"architecture"`
+	Layout       []string         `json:"layout,omitempty"`
+	Conventions  []ConventionNote `json:"conventions,omitempty"`
+	Domains      []DomainCount    `json:"domains,omitempty"`
+	Briefing     string           `json:"briefing,omitempty"`
+	RunningPorts []string         `json:"running_ports,omitempty"`
+}
+
+// Architecture is the outline of the project's architecture document
+type Architecture struct {
+	Source   string   `json:"source,omitempty"`
+	Overview string   `json:"overview,omitempty"`
+	Sections []string `json:"sections,omitempty"`
+}
+
+// ConventionNote is a key convention included in the primer
+type ConventionNote struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// DomainCount is a document domain and its document count
+type DomainCount struct {
+	Domain string `json:"domain"`
+	Docs   int    `json:"docs"`
+}
+
+// Service generates and caches primers for a project
+type Service struct {
+	db          *db.DB
+	projectRoot string
+}
+
+// NewService creates a new primer service
+func NewService(database *db.DB, projectRoot string) *Service {
+	return &Service{db: database, projectRoot: projectRoot}
+}
+
+// CachePath returns the cached primer path (.pal/context/primer.json)
+func (s *Service) CachePath() string {
+	return filepath.Join(s.projectRoot, ".pal", "context", "primer.json")
+}
+
+// Generate builds a primer from the architecture docs, conventions, document index and briefing
+func (s *Service) Generate() (*Primer, error) {
+	p := &Primer{
+		GeneratedAt: time.Now(),
+		ProjectName: filepath.Base(s.projectRoot),
+	}
+
+	p.Architecture = s.architecture()
+	p.Layout = s.layout()
+
+	convSvc := convention.NewService(s.projectRoot)
+	if err := convSvc.Load(); err == nil {
+		if convs, err := convSvc.ListEnabled(); err == nil {
+			sort.SliceStable(convs, func(i, j int) bool { return convs[i].Priority > convs[j].Priority })
+			for _, c := range convs {
+				if len(p.Conventions) >= maxConventions {
+					break
+				}
+				p.Conventions = append(p.Conventions, ConventionNote{Name: c.Name, Description: firstLine(c.Description)})
+			}
+		}
+	}
+
+	if stats, err := document.NewService(s.db, s.projectRoot).GetStats(); err == nil {
+		for domain, n := range stats.ByDomain {
+			p.Domains = append(p.Domains, DomainCount{Domain: domain, Docs: n})
+		}
+		sort.Slice(p.Domains, func(i, j int) bool {
+			if p.Domains[i].Docs != p.Domains[j].Docs {
+				return p.Domains[i].Docs > p.Domains[j].Docs
+			}
+			return p.Domains[i].Domain < p.Domains[j].Domain
+		})
+		if len(p.Domains) > maxDomains {
+			p.Domains = p.Domains[:maxDomains]
+		}
+	}
+
+	briefing, err := operator.NewService(s.db, s.projectRoot).GenerateBriefing()
+	if err != nil {
+		return nil, fmt.Errorf("브리핑 생성 실패: %w", err)
+	}
+	if briefing.Summary != "No active work items." {
+		p.Briefing = briefing.Summary
+	}
+	for _, port := range briefing.RunningPorts {
+		p.RunningPorts = append(p.RunningPorts, port.ID)
+	}
+
+	return p, nil
+}
+
+// Save writes the primer to the cache
+func (s *Service) Save(p *Primer) error {
+	path := s.CachePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("primer 디렉토리 생성 실패: %w", err)
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("primer 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// Load reads the cached primer (캐시가 없으면 nil)
+func (s *Service) Load() (*Primer, error) {
+	data, err := os.ReadFile(s.CachePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("primer 읽기 실패: %w", err)
+	}
+	var p Primer
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("primer 파싱 실패: %w", err)
+	}
+	return &p, nil
+}
+
+// LoadFresh returns the cached primer if it is younger than maxAge, otherwise nil
+func (s *Service) LoadFresh(maxAge time.Duration, now time.Time) *Primer {
+	if maxAge <= 0 {
+		return nil
+	}
+	p, err := s.Load()
+	if err != nil || p == nil || now.Sub(p.GeneratedAt) > maxAge {
+		return nil
+	}
+	return p
+}
+
+// Refresh regenerates the cache when it is older than interval.
+// 갱신했으면 true를 반환합니다.
+func (s *Service) Refresh(interval time.Duration, now time.Time) (bool, error) {
+	if p, err := s.Load(); err == nil && p != nil && now.Sub(p.GeneratedAt) < interval {
+		return false, nil
+	}
+	p, err := s.Generate()
+	if err != nil {
+		return false, err
+	}
+	return true, s.Save(p)
+}
+
+// Markdown renders the primer for injection into the session
+func (p *Primer) Markdown() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🧭 [PAL Kit] 프로젝트 프라이머: %s (%s 기준)\n", p.ProjectName, p.GeneratedAt.Format("01/02 15:04")))
+
+	if p.Architecture.Overview != "" || len(p.Architecture.Sections) > 0 {
+		sb.WriteString(fmt.Sprintf("\n## 아키텍처 (%s)\n", p.Architecture.Source))
+		if p.Architecture.Overview != "" {
+			sb.WriteString(p.Architecture.Overview + "\n")
+		}
+		if len(p.Architecture.Sections) > 0 {
+			sb.WriteString("섹션: " + strings.Join(p.Architecture.Sections, " · ") + "\n")
+		}
+	}
+
+	if len(p.Layout) > 0 {
+		sb.WriteString("\n## 구조\n")
+		sb.WriteString(strings.Join(p.Layout, "  ") + "\n")
+	}
+
+	if len(p.Conventions) > 0 {
+		sb.WriteString("\n## 주요 컨벤션\n")
+		for _, c := range p.Conventions {
+			if c.Description != "" {
+				sb.WriteString(fmt.Sprintf("- %s: %s\n", c.Name, c.Description))
+			} else {
+				sb.WriteString(fmt.Sprintf("- %s\n", c.Name))
+			}
+		}
+	}
+
+	if len(p.Domains) > 0 {
+		sb.WriteString("\n## 주요 도메인\n")
+		parts := make([]string, len(p.Domains))
+		for i, d := range p.Domains {
+			parts[i] = fmt.Sprintf("%s(%d)", d.Domain, d.Docs)
+		}
+		sb.WriteString(strings.Join(parts, ", ") + "\n")
+	}
+
+	if p.Briefing != "" {
+		sb.WriteString("\n## 현재 상태\n")
+		sb.WriteString(p.Briefing + "\n")
+	}
+
+	return sb.String()
+}
+
+// architecture extracts an overview paragraph and section headings from the first architecture source
+func (s *Service) architecture() Architecture {
+	for _, rel := range architectureSources {
+		content, err := os.ReadFile(filepath.Join(s.projectRoot, rel))
+		if err != nil {
+			continue
+		}
+		arch := outline(string(content))
+		if arch.Overview == "" && len(arch.Sections) == 0 {
+			continue
+		}
+		arch.Source = rel
+		return arch
+	}
+	return Architecture{}
+}
+
+// outline returns the first paragraph and the ## headings of a markdown document.
+// 코드 블록과 PAL이 주입한 섹션(<!-- pal:...:start --> ~ end)은 건너뜁니다.
+func outline(content string) Architecture {
+	var arch Architecture
+	var paragraph []string
+	inCode, inInjected := false, false
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "<!-- pal:") && strings.Contains(trimmed, ":start"):
+			inInjected = true
+			continue
+		case strings.HasPrefix(trimmed, "<!-- pal:") && strings.Contains(trimmed, ":end"):
+			inInjected = false
+			continue
+		case strings.HasPrefix(trimmed, "```"):
+			inCode = !inCode
+			continue
+		}
+		if inCode || inInjected {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "## ") {
+			if len(arch.Sections) < maxSections {
+				arch.Sections = append(arch.Sections, strings.TrimSpace(strings.TrimPrefix(trimmed, "## ")))
+			}
+			continue
+		}
+		if arch.Overview != "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "<!--") {
+			continue
+		}
+		if trimmed == "" {
+			if len(paragraph) > 0 {
+				arch.Overview = truncate(strings.Join(paragraph, " "), maxOverview)
+			}
+			paragraph = nil
+			continue
+		}
+		paragraph = append(paragraph, trimmed)
+	}
+	if arch.Overview == "" && len(paragraph) > 0 {
+		arch.Overview = truncate(strings.Join(paragraph, " "), maxOverview)
+	}
+	return arch
+}
+
+// layout lists the visible top-level directories
+func (s *Service) layout() []string {
+	entries, err := os.ReadDir(s.projectRoot)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() || strings.HasPrefix(name, ".") || skippedDirs[name] {
+			continue
+		}
+		dirs = append(dirs, name+"/")
+		if len(dirs) >= maxLayout {
+			break
+		}
+	}
+	return dirs
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return truncate(line, 120)
+}
+
+func truncate(s string, maxLen int) string {
+	r := []rune(s)
+	if len(r) <= maxLen {
+		return s
+	}
+	return string(r[:maxLen-3]) + "..."
+}
@@ -0,0 +1,100 @@
+package primer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func setupTestDB(t *testing.T) *db.DB {
+	t.Helper()
+
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func TestOutline(t *testing.T) {
+	content := `# Project
+
+<!-- pal:context:start -->
+주입된 컨텍스트
+<!-- pal:context:end -->
+
+CLI와 데몬으로 구성된
+세션 관리 도구입니다.
+
+## Packages
+
+` + "```go\n## not a heading\n```" + `
+
+## Hooks
+`
+	arch := outline(content)
+	if arch.Overview != "CLI와 데몬으로 구성된 세션 관리 도구입니다." {
+		t.Errorf("Overview = %q", arch.Overview)
+	}
+	if strings.Join(arch.Sections, ",") != "Packages,Hooks" {
+		t.Errorf("Sections = %v", arch.Sections)
+	}
+}
+
+func TestGenerateAndCache(t *testing.T) {
+	database := setupTestDB(t)
+	root := t.TempDir()
+
+	os.WriteFile(filepath.Join(root, "ARCHITECTURE.md"), []byte("# Arch\n\n레이어드 구조.\n\n## Domain\n"), 0644)
+	os.MkdirAll(filepath.Join(root, "internal"), 0755)
+	os.MkdirAll(filepath.Join(root, "node_modules"), 0755)
+	os.MkdirAll(filepath.Join(root, "conventions"), 0755)
+	os.WriteFile(filepath.Join(root, "conventions", "naming.yaml"), []byte(
+		"id: naming\nname: Naming\ntype: naming\ndescription: 카멜 케이스 사용\nenabled: true\npriority: 5\n"), 0644)
+
+	svc := NewService(database, root)
+	now := time.Now()
+
+	if p := svc.LoadFresh(time.Hour, now); p != nil {
+		t.Fatal("캐시가 없으면 nil이어야 함")
+	}
+
+	refreshed, err := svc.Refresh(15*time.Minute, now)
+	if err != nil || !refreshed {
+		t.Fatalf("Refresh = %v, %v", refreshed, err)
+	}
+
+	p := svc.LoadFresh(time.Hour, now)
+	if p == nil {
+		t.Fatal("갱신 직후 캐시는 최신이어야 함")
+	}
+	if p.Architecture.Source != "ARCHITECTURE.md" || p.Architecture.Overview != "레이어드 구조." {
+		t.Errorf("Architecture = %+v", p.Architecture)
+	}
+	if strings.Join(p.Layout, ",") != "conventions/,internal/" {
+		t.Errorf("Layout = %v", p.Layout)
+	}
+	if len(p.Conventions) != 1 || p.Conventions[0].Name != "Naming" {
+		t.Errorf("Conventions = %+v", p.Conventions)
+	}
+	if md := p.Markdown(); !strings.Contains(md, "## 아키텍처 (ARCHITECTURE.md)") || !strings.Contains(md, "- Naming: 카멜 케이스 사용") {
+		t.Errorf("Markdown:\n%s", md)
+	}
+
+	// 주기 안에서는 다시 생성하지 않음
+	if refreshed, _ := svc.Refresh(15*time.Minute, now.Add(time.Minute)); refreshed {
+		t.Error("주기 내 재생성은 생략되어야 함")
+	}
+	if refreshed, _ := svc.Refresh(15*time.Minute, now.Add(20*time.Minute)); !refreshed {
+		t.Error("주기가 지나면 재생성해야 함")
+	}
+
+	if p := svc.LoadFresh(time.Hour, now.Add(2*time.Hour)); p != nil {
+		t.Error("오래된 캐시는 사용하지 않아야 함")
+	}
+}
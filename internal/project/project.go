@@ -0,0 +1,191 @@
+package project
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
+)
+
+// NotArchived is a SQL condition on the sessions table excluding archived projects.
+// 대시보드 집계 쿼리에서 보관된 프로젝트의 세션을 제외할 때 사용합니다.
+const NotArchived = "COALESCE(project_root, '') NOT IN (SELECT root FROM project_states WHERE archived = 1)"
+
+// Project is a registered project with its dashboard state
+type Project struct {
+	Root         string       `json:"root"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description,omitempty"`
+	LastActive   sql.NullTime `json:"-"`
+	SessionCount int          `json:"session_count"`
+	TotalTokens  int64        `json:"total_tokens"`
+	TotalCost    float64      `json:"total_cost"`
+	CreatedAt    sql.NullTime `json:"-"`
+	Archived     bool         `json:"archived"`
+	Hidden       bool         `json:"hidden"`
+	ArchivedAt   sql.NullTime `json:"-"`
+}
+
+// ListOptions controls which projects are listed
+type ListOptions struct {
+	IncludeArchived bool
+	IncludeHidden   bool
+	OnlyArchived    bool
+}
+
+// Service manages registered projects
+type Service struct {
+	db *db.DB
+}
+
+// NewService creates a new project service
+func NewService(database *db.DB) *Service {
+	return &Service{db: database}
+}
+
+// Register registers a project root (이미 있으면 이름만 갱신)
+func (s *Service) Register(root, name string) error {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return errcode.New(errcode.KindValidation, "경로 확인 실패: %w", err)
+	}
+	if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+		return errcode.New(errcode.KindValidation, "디렉토리가 아닙니다: %s", abs)
+	}
+	if name == "" {
+		name = filepath.Base(abs)
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO projects (root, name, last_active, created_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT(root) DO UPDATE SET
+			name = excluded.name,
+			last_active = CURRENT_TIMESTAMP
+	`, abs, name)
+	if err != nil {
+		return fmt.Errorf("프로젝트 등록 실패: %w", err)
+	}
+	return nil
+}
+
+// Rename changes the display name of a project
+func (s *Service) Rename(root, name string) error {
+	if name == "" {
+		return errcode.New(errcode.KindValidation, "프로젝트 이름이 비어 있습니다")
+	}
+	result, err := s.db.Exec(`UPDATE projects SET name = ? WHERE root = ?`, name, root)
+	if err != nil {
+		return fmt.Errorf("프로젝트 이름 변경 실패: %w", err)
+	}
+	return requireAffected(result, root)
+}
+
+// SetArchived archives or restores a project
+func (s *Service) SetArchived(root string, archived bool) error {
+	if err := s.requireProject(root); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO project_states (root, archived, archived_at, updated_at)
+		VALUES (?, ?, CASE WHEN ? THEN CURRENT_TIMESTAMP END, CURRENT_TIMESTAMP)
+		ON CONFLICT(root) DO UPDATE SET
+			archived = excluded.archived,
+			archived_at = excluded.archived_at,
+			updated_at = CURRENT_TIMESTAMP
+	`, root, archived, archived)
+	if err != nil {
+		return fmt.Errorf("프로젝트 보관 상태 변경 실패: %w", err)
+	}
+	return nil
+}
+
+// SetHidden hides or shows a project in default lists
+func (s *Service) SetHidden(root string, hidden bool) error {
+	if err := s.requireProject(root); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO project_states (root, hidden, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(root) DO UPDATE SET
+			hidden = excluded.hidden,
+			updated_at = CURRENT_TIMESTAMP
+	`, root, hidden)
+	if err != nil {
+		return fmt.Errorf("프로젝트 숨김 상태 변경 실패: %w", err)
+	}
+	return nil
+}
+
+// Get returns a project by root
+func (s *Service) Get(root string) (*Project, error) {
+	projects, err := s.query(`WHERE p.root = ?`, root)
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return nil, errcode.New(errcode.KindNotFound, "프로젝트 '%s'을(를) 찾을 수 없습니다", root)
+	}
+	return &projects[0], nil
+}
+
+// List returns projects; 보관/숨김 프로젝트는 기본적으로 제외됩니다.
+func (s *Service) List(opts ListOptions) ([]Project, error) {
+	where := "WHERE 1 = 1"
+	if opts.OnlyArchived {
+		where += " AND COALESCE(st.archived, 0) = 1"
+	} else if !opts.IncludeArchived {
+		where += " AND COALESCE(st.archived, 0) = 0"
+	}
+	if !opts.IncludeHidden && !opts.OnlyArchived {
+		where += " AND COALESCE(st.hidden, 0) = 0"
+	}
+	return s.query(where)
+}
+
+func (s *Service) query(where string, args ...interface{}) ([]Project, error) {
+	rows, err := s.db.Query(`
+		SELECT p.root, COALESCE(p.name, ''), COALESCE(p.description, ''), p.last_active,
+		       COALESCE(p.session_count, 0), COALESCE(p.total_tokens, 0), COALESCE(p.total_cost, 0),
+		       p.created_at, COALESCE(st.archived, 0), COALESCE(st.hidden, 0), st.archived_at
+		FROM projects p
+		LEFT JOIN project_states st ON st.root = p.root
+		`+where+`
+		ORDER BY p.last_active DESC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("프로젝트 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []Project
+	for rows.Next() {
+		var p Project
+		if err := rows.Scan(&p.Root, &p.Name, &p.Description, &p.LastActive,
+			&p.SessionCount, &p.TotalTokens, &p.TotalCost,
+			&p.CreatedAt, &p.Archived, &p.Hidden, &p.ArchivedAt); err != nil {
+			return nil, err
+		}
+		projects = append(projects, p)
+	}
+	return projects, rows.Err()
+}
+
+func (s *Service) requireProject(root string) error {
+	var exists int
+	if err := s.db.QueryRow(`SELECT 1 FROM projects WHERE root = ?`, root).Scan(&exists); err != nil {
+		return errcode.New(errcode.KindNotFound, "프로젝트 '%s'을(를) 찾을 수 없습니다", root)
+	}
+	return nil
+}
+
+func requireAffected(result sql.Result, root string) error {
+	if n, _ := result.RowsAffected(); n == 0 {
+		return errcode.New(errcode.KindNotFound, "프로젝트 '%s'을(를) 찾을 수 없습니다", root)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
+)
+
+func setupTestDB(t *testing.T) (*db.DB, func()) {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "pal-test-*")
+	if err != nil {
+		t.Fatalf("임시 디렉토리 생성 실패: %v", err)
+	}
+
+	database, err := db.Open(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+
+	cleanup := func() {
+		database.Close()
+		os.RemoveAll(tmpDir)
+	}
+
+	return database, cleanup
+}
+
+func TestArchiveAndHide(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	a, b, c := t.TempDir(), t.TempDir(), t.TempDir()
+	for _, root := range []string{a, b, c} {
+		if err := svc.Register(root, ""); err != nil {
+			t.Fatalf("Register 실패: %v", err)
+		}
+	}
+
+	if err := svc.Rename(a, "alpha"); err != nil {
+		t.Fatalf("Rename 실패: %v", err)
+	}
+	if err := svc.SetArchived(b, true); err != nil {
+		t.Fatalf("SetArchived 실패: %v", err)
+	}
+	if err := svc.SetHidden(c, true); err != nil {
+		t.Fatalf("SetHidden 실패: %v", err)
+	}
+
+	list, _ := svc.List(ListOptions{})
+	if len(list) != 1 || list[0].Name != "alpha" {
+		t.Errorf("기본 목록은 보관/숨김 제외: %+v", list)
+	}
+
+	all, _ := svc.List(ListOptions{IncludeArchived: true, IncludeHidden: true})
+	if len(all) != 3 {
+		t.Errorf("전체 목록 = %d, want 3", len(all))
+	}
+
+	archived, _ := svc.List(ListOptions{OnlyArchived: true})
+	if len(archived) != 1 || archived[0].Root != b || !archived[0].ArchivedAt.Valid {
+		t.Errorf("보관 목록 불일치: %+v", archived)
+	}
+
+	// 복원
+	svc.SetArchived(b, false)
+	if p, _ := svc.Get(b); p.Archived {
+		t.Error("복원 후 archived=false여야 함")
+	}
+
+	if err := svc.SetArchived("/nope", true); !errcode.Is(err, errcode.KindNotFound) {
+		t.Errorf("없는 프로젝트는 not_found: %v", err)
+	}
+}
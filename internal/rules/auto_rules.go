@@ -6,7 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/n0roo/pal-kit/internal/config"
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/i18n"
 	"github.com/n0roo/pal-kit/internal/port"
 )
 
@@ -26,6 +28,7 @@ func ActivatePortRules(database *db.DB, projectRoot, portID string) error {
 
 	// Load port spec
 	portSpec := ""
+	var sources []string
 	if p.FilePath.Valid && p.FilePath.String != "" {
 		specPath := p.FilePath.String
 		if !filepath.IsAbs(specPath) {
@@ -33,6 +36,7 @@ func ActivatePortRules(database *db.DB, projectRoot, portID string) error {
 		}
 		if content, err := os.ReadFile(specPath); err == nil {
 			portSpec = string(content)
+			sources = append(sources, p.FilePath.String)
 		}
 	}
 
@@ -46,11 +50,11 @@ func ActivatePortRules(database *db.DB, projectRoot, portID string) error {
 	}
 
 	// Generate rules content
-	content := generatePortRules(portID, portTitle, portSpec, checklist)
+	content := generatePortRules(config.ProjectLanguage(projectRoot), portID, portTitle, portSpec, checklist)
 
 	// Write rules file
 	rulePath := filepath.Join(rulesDir, portID+".md")
-	if err := os.WriteFile(rulePath, []byte(content), 0644); err != nil {
+	if err := WriteFile(rulePath, content, sources); err != nil {
 		return fmt.Errorf("rules 파일 생성 실패: %w", err)
 	}
 
@@ -73,8 +77,8 @@ func DeactivatePortRules(projectRoot, portID string) error {
 	return nil
 }
 
-// generatePortRules generates rules content for port
-func generatePortRules(portID, portTitle, portSpec string, checklist []string) string {
+// generatePortRules generates rules content for port in the given language
+func generatePortRules(lang, portID, portTitle, portSpec string, checklist []string) string {
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf(`---
@@ -84,15 +88,15 @@ globs:
 alwaysApply: true
 ---
 
-# 포트: %s
+%s
 
 %s
 
-`, portID, portTitle, portTitle, getPortObjective(portSpec)))
+`, portID, portTitle, i18n.T(lang, i18n.KeyRulePortTitle, portTitle), getPortObjective(portSpec)))
 
 	// Add checklist if available
 	if len(checklist) > 0 {
-		sb.WriteString("## 완료 체크리스트\n\n")
+		sb.WriteString(i18n.T(lang, i18n.KeyRuleChecklist) + "\n\n")
 		for _, item := range checklist {
 			sb.WriteString(fmt.Sprintf("- [ ] %s\n", item))
 		}
@@ -100,12 +104,7 @@ alwaysApply: true
 	}
 
 	// Add conventions reminder
-	sb.WriteString(`## 주의사항
-
-- 작업 완료 시 ` + "`pal_port_end`" + ` 호출
-- 빌드/테스트 실패 시 자동으로 블록 처리됨
-- 문제 발생 시 ` + "`pal_escalate`" + ` 사용
-`)
+	sb.WriteString(i18n.T(lang, i18n.KeyRuleNotes) + "\n")
 
 	return sb.String()
 }
@@ -117,7 +116,7 @@ func getPortObjective(portSpec string) string {
 	var objective strings.Builder
 
 	for _, line := range lines {
-		if strings.HasPrefix(line, "## 목표") {
+		if strings.HasPrefix(line, "## 목표") || strings.HasPrefix(line, "## Objective") || strings.HasPrefix(line, "## Goal") {
 			inObjective = true
 			continue
 		}
@@ -150,7 +149,8 @@ func extractChecklistFromSpec(spec string) []string {
 	inChecklist := false
 
 	for _, line := range lines {
-		if strings.Contains(line, "체크리스트") || strings.Contains(line, "완료 기준") {
+		if strings.Contains(line, "체크리스트") || strings.Contains(line, "완료 기준") ||
+			strings.Contains(line, "Checklist") || strings.Contains(line, "Acceptance") {
 			inChecklist = true
 			continue
 		}
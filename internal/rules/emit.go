@@ -0,0 +1,127 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Emit formats for non-Claude coding assistants
+const (
+	FormatCursor   = "cursor"
+	FormatContinue = "continue"
+	FormatPlain    = "plain"
+)
+
+// EmitFormats lists supported emit formats
+var EmitFormats = []string{FormatCursor, FormatContinue, FormatPlain}
+
+// Section is a single rule file rendered without Claude-specific metadata
+type Section struct {
+	Name  string   `json:"name"`
+	Paths []string `json:"paths,omitempty"`
+	Body  string   `json:"body"`
+}
+
+// Sections reads all rule files (workflow.md 포함) as emit sections.
+// frontmatter와 pal-rules 헤더는 제거되고, paths는 Section.Paths로 옮겨집니다.
+func (s *Service) Sections() ([]Section, error) {
+	entries, err := os.ReadDir(s.rulesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("rules 디렉토리 읽기 실패: %w", err)
+	}
+
+	var sections []Section
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.rulesDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		content := StripHeader(string(data))
+		section := Section{Name: strings.TrimSuffix(entry.Name(), ".md")}
+		if end := frontmatterEnd(content); end > 0 {
+			var fm struct {
+				Paths []string `yaml:"paths"`
+			}
+			yaml.Unmarshal([]byte(content[4:end-len("---\n")]), &fm)
+			section.Paths = fm.Paths
+			content = content[end:]
+		}
+		section.Body = strings.TrimSpace(content)
+		if section.Body != "" {
+			sections = append(sections, section)
+		}
+	}
+
+	// workflow 규칙을 맨 앞에
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Name == "workflow" && sections[j].Name != "workflow"
+	})
+	return sections, nil
+}
+
+// Render renders briefing and rule sections for another assistant.
+//   - cursor:   .cursor/rules/*.mdc (frontmatter: description, globs, alwaysApply)
+//   - continue: .continue/rules/*.md (frontmatter: name, alwaysApply)
+//   - plain:    frontmatter 없는 Markdown
+func Render(format, briefing string, sections []Section) (string, error) {
+	var sb strings.Builder
+
+	switch format {
+	case FormatCursor:
+		sb.WriteString("---\n")
+		sb.WriteString("description: PAL Kit workflow rules and session briefing\n")
+		sb.WriteString("globs:\n")
+		sb.WriteString("alwaysApply: true\n")
+		sb.WriteString("---\n\n")
+	case FormatContinue:
+		sb.WriteString("---\n")
+		sb.WriteString("name: PAL Kit workflow\n")
+		sb.WriteString("alwaysApply: true\n")
+		sb.WriteString("---\n\n")
+	case FormatPlain:
+	default:
+		return "", fmt.Errorf("알 수 없는 형식: %s (%s)", format, strings.Join(EmitFormats, ", "))
+	}
+
+	sb.WriteString("<!-- Generated by PAL Kit (pal context emit). Do not edit manually. -->\n\n")
+
+	if b := strings.TrimSpace(briefing); b != "" {
+		sb.WriteString(b)
+		sb.WriteString("\n\n")
+	}
+
+	for _, sec := range sections {
+		sb.WriteString("---\n\n")
+		if len(sec.Paths) > 0 {
+			sb.WriteString(fmt.Sprintf("> Applies to: %s\n\n", strings.Join(sec.Paths, ", ")))
+		}
+		sb.WriteString(sec.Body)
+		sb.WriteString("\n\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n", nil
+}
+
+// EmitPath returns the conventional output path of a format ("" for plain)
+func EmitPath(projectRoot, format string) string {
+	switch format {
+	case FormatCursor:
+		return filepath.Join(projectRoot, ".cursor", "rules", "pal-kit.mdc")
+	case FormatContinue:
+		return filepath.Join(projectRoot, ".continue", "rules", "pal-kit.md")
+	default:
+		return ""
+	}
+}
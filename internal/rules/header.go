@@ -0,0 +1,240 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/n0roo/pal-kit/internal/config"
+)
+
+// headerPrefix marks the machine-readable metadata line of generated rule files.
+// 예: <!-- pal-rules: {"tokens":420,"generated_at":"...","sources":["ports/a.md"]} -->
+const (
+	headerPrefix = "<!-- pal-rules: "
+	headerSuffix = " -->"
+)
+
+// Header is the metadata embedded in each generated rule file
+type Header struct {
+	Tokens      int       `json:"tokens"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Sources     []string  `json:"sources,omitempty"`
+	// 이 시각 이후에는 재사용하지 않고 재생성 (nil = 만료 없음)
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired reports whether the header's expiry has passed
+func (h *Header) Expired(now time.Time) bool {
+	return h.ExpiresAt != nil && !now.Before(*h.ExpiresAt)
+}
+
+// IsExpired reports whether generated content carries an expired header.
+// 헤더가 없는 파일은 직접 작성한 것으로 보고 만료되지 않습니다.
+func IsExpired(content string, now time.Time) bool {
+	h, ok := ParseHeader(content)
+	return ok && h.Expired(now)
+}
+
+// ProjectTTL returns the lifetime of generated context files of a project (0 = 만료 없음)
+func ProjectTTL(projectRoot string) time.Duration {
+	cfg, err := config.LoadProjectConfig(projectRoot)
+	if err != nil {
+		return config.DefaultContextTTL
+	}
+	return cfg.Settings.ContextExpiry()
+}
+
+// RuleBudget is the context weight of a single rule file
+type RuleBudget struct {
+	Name        string     `json:"name"`
+	Tokens      int        `json:"tokens"`
+	GeneratedAt *time.Time `json:"generated_at,omitempty"`
+	Sources     []string   `json:"sources,omitempty"`
+	Managed     bool       `json:"managed"` // PAL Kit 헤더 포함 여부
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Expired     bool       `json:"expired,omitempty"`
+}
+
+// Budget sums the context weight of all active rule files
+type Budget struct {
+	Rules       []RuleBudget `json:"rules"`
+	TotalTokens int          `json:"total_tokens"`
+}
+
+// EstimateTokens approximates the token count of text.
+// context.ApproximateCounter와 같은 비율 (영문 ~4자, 한글 ~2자, 기호 ~3자)을 사용합니다.
+func EstimateTokens(text string) int {
+	var tokens float64
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Hangul, r):
+			tokens += 1.0 / 2.0
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			tokens += 1.0 / 4.0
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			tokens += 1.0 / 3.0
+		case r == '\n':
+			tokens += 0.5
+		default:
+			tokens += 1.0 / 4.0
+		}
+	}
+	return int(tokens)
+}
+
+// WithHeader returns content with a fresh pal-rules header using the default TTL.
+// 헤더는 frontmatter 바로 뒤에 위치하며, 기존 헤더는 교체됩니다.
+func WithHeader(content string, sources []string) string {
+	return WithHeaderTTL(content, sources, config.DefaultContextTTL)
+}
+
+// WithHeaderTTL returns content with a fresh pal-rules header expiring after ttl (0 = 만료 없음)
+func WithHeaderTTL(content string, sources []string, ttl time.Duration) string {
+	body := StripHeader(content)
+
+	header := Header{
+		Tokens:      EstimateTokens(body),
+		GeneratedAt: time.Now().Truncate(time.Second),
+		Sources:     sources,
+	}
+	if ttl > 0 {
+		expiresAt := header.GeneratedAt.Add(ttl)
+		header.ExpiresAt = &expiresAt
+	}
+	data, _ := json.Marshal(header)
+	line := headerPrefix + string(data) + headerSuffix + "\n"
+
+	if end := frontmatterEnd(body); end > 0 {
+		return body[:end] + line + body[end:]
+	}
+	return line + body
+}
+
+// ParseHeader extracts the pal-rules header from content
+func ParseHeader(content string) (*Header, bool) {
+	for _, line := range strings.SplitN(content, "\n", 64) {
+		if !strings.HasPrefix(line, headerPrefix) {
+			continue
+		}
+		raw := strings.TrimSuffix(strings.TrimPrefix(line, headerPrefix), headerSuffix)
+		var h Header
+		if err := json.Unmarshal([]byte(raw), &h); err != nil {
+			return nil, false
+		}
+		return &h, true
+	}
+	return nil, false
+}
+
+// StripHeader removes the pal-rules header line from content
+func StripHeader(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, headerPrefix) {
+			return strings.Join(append(lines[:i:i], lines[i+1:]...), "\n")
+		}
+	}
+	return content
+}
+
+// WriteFile writes a rule file with a pal-rules header.
+// .claude/rules 아래 파일이면 해당 프로젝트의 context_ttl을 따릅니다.
+func WriteFile(path, content string, sources []string) error {
+	ttl := config.DefaultContextTTL
+	rulesDir := filepath.Dir(path)
+	if filepath.Base(rulesDir) == "rules" && filepath.Base(filepath.Dir(rulesDir)) == ".claude" {
+		ttl = ProjectTTL(filepath.Dir(filepath.Dir(rulesDir)))
+	}
+	return os.WriteFile(path, []byte(WithHeaderTTL(content, sources, ttl)), 0644)
+}
+
+// frontmatterEnd returns the offset right after the closing '---' line, or 0
+func frontmatterEnd(content string) int {
+	if !strings.HasPrefix(content, "---\n") {
+		return 0
+	}
+	if strings.HasPrefix(content[4:], "---\n") {
+		return 8 // 빈 frontmatter
+	}
+	idx := strings.Index(content[4:], "\n---\n")
+	if idx < 0 {
+		return 0
+	}
+	return 4 + idx + len("\n---\n")
+}
+
+// Budget returns the token weight of every rule file in .claude/rules
+func (s *Service) Budget() (*Budget, error) {
+	entries, err := os.ReadDir(s.rulesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Budget{}, nil
+		}
+		return nil, fmt.Errorf("rules 디렉토리 읽기 실패: %w", err)
+	}
+
+	budget := &Budget{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(s.rulesDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		rb := RuleBudget{
+			Name:   strings.TrimSuffix(entry.Name(), ".md"),
+			Tokens: EstimateTokens(StripHeader(string(content))),
+		}
+		if h, ok := ParseHeader(string(content)); ok {
+			rb.Managed = true
+			rb.GeneratedAt = &h.GeneratedAt
+			rb.Sources = h.Sources
+			rb.ExpiresAt = h.ExpiresAt
+			rb.Expired = h.Expired(time.Now())
+		}
+
+		budget.Rules = append(budget.Rules, rb)
+		budget.TotalTokens += rb.Tokens
+	}
+
+	sort.Slice(budget.Rules, func(i, j int) bool {
+		return budget.Rules[i].Tokens > budget.Rules[j].Tokens
+	})
+
+	return budget, nil
+}
+
+// Expired returns the names of generated rule files whose expiry has passed
+func (s *Service) Expired(now time.Time) ([]string, error) {
+	entries, err := os.ReadDir(s.rulesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("rules 디렉토리 읽기 실패: %w", err)
+	}
+
+	var expired []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(s.rulesDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		if IsExpired(string(content), now) {
+			expired = append(expired, strings.TrimSuffix(entry.Name(), ".md"))
+		}
+	}
+	return expired, nil
+}
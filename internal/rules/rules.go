@@ -6,17 +6,22 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/i18n"
 )
 
 // Service handles .claude/rules/ management
 type Service struct {
 	rulesDir string
+	lang     string // 생성 안내문 언어 (.pal/config.yaml project.language)
 }
 
 // NewService creates a new rules service
 func NewService(projectRoot string) *Service {
 	return &Service{
 		rulesDir: filepath.Join(projectRoot, ".claude", "rules"),
+		lang:     config.ProjectLanguage(projectRoot),
 	}
 }
 
@@ -46,7 +51,11 @@ func (s *Service) ActivatePort(portID, title, filePath string, filePatterns []st
 
 	content := s.generateRuleContent(portID, title, paths)
 
-	if err := os.WriteFile(rulePath, []byte(content), 0644); err != nil {
+	var sources []string
+	if filePath != "" {
+		sources = []string{filePath}
+	}
+	if err := WriteFile(rulePath, content, sources); err != nil {
 		return fmt.Errorf("규칙 파일 생성 실패: %w", err)
 	}
 
@@ -133,18 +142,14 @@ func (s *Service) generateRuleContent(portID, title string, paths []string) stri
 	sb.WriteString(fmt.Sprintf("> Activated: %s\n\n", time.Now().Format("2006-01-02 15:04:05")))
 
 	// Instructions
-	sb.WriteString("## 작업 지침\n\n")
-	sb.WriteString("이 포트에서 작업 시 다음 사항을 준수하세요:\n\n")
-	sb.WriteString("1. 포트 명세에 정의된 파일만 수정\n")
-	sb.WriteString("2. 작업 시작 전 Lock 획득 확인\n")
-	sb.WriteString("3. 완료 시 검증 명령 실행\n\n")
+	sb.WriteString(i18n.T(s.lang, i18n.KeyRuleInstructions) + "\n\n")
 
 	// Commands
-	sb.WriteString("## 실행 명령\n\n")
+	sb.WriteString(i18n.T(s.lang, i18n.KeyRuleCommands) + "\n\n")
 	sb.WriteString("```bash\n")
-	sb.WriteString("# 상태 확인\n")
+	sb.WriteString(i18n.T(s.lang, i18n.KeyRuleCmdStatus) + "\n")
 	sb.WriteString(fmt.Sprintf("pal port show %s\n\n", portID))
-	sb.WriteString("# 완료 처리\n")
+	sb.WriteString(i18n.T(s.lang, i18n.KeyRuleCmdComplete) + "\n")
 	sb.WriteString(fmt.Sprintf("pal port status %s complete\n", portID))
 	sb.WriteString("```\n")
 
@@ -169,7 +174,11 @@ func (s *Service) ActivatePortWithSpec(portID, title, specPath string, filePatte
 
 	content := s.generateRuleContentWithSpec(portID, title, filePatterns, specContent)
 
-	if err := os.WriteFile(rulePath, []byte(content), 0644); err != nil {
+	var sources []string
+	if specContent != "" {
+		sources = []string{specPath}
+	}
+	if err := WriteFile(rulePath, content, sources); err != nil {
 		return fmt.Errorf("규칙 파일 생성 실패: %w", err)
 	}
 
@@ -211,11 +220,11 @@ func (s *Service) generateRuleContentWithSpec(portID, title string, paths []stri
 
 	// 실행 명령
 	sb.WriteString("\n---\n\n")
-	sb.WriteString("## PAL 명령\n\n")
+	sb.WriteString(i18n.T(s.lang, i18n.KeyRulePalCommands) + "\n\n")
 	sb.WriteString("```bash\n")
-	sb.WriteString("# 포트 상태 확인\n")
+	sb.WriteString(i18n.T(s.lang, i18n.KeyRuleCmdPortStatus) + "\n")
 	sb.WriteString(fmt.Sprintf("pal port show %s\n\n", portID))
-	sb.WriteString("# 작업 완료\n")
+	sb.WriteString(i18n.T(s.lang, i18n.KeyRuleCmdWorkComplete) + "\n")
 	sb.WriteString(fmt.Sprintf("pal port status %s complete\n", portID))
 	sb.WriteString("```\n")
 
@@ -232,10 +241,14 @@ func (s *Service) AppendToRule(portID, content string) error {
 		return fmt.Errorf("규칙 파일 읽기 실패: %w", err)
 	}
 
-	// 새 내용 추가
+	// 새 내용 추가 (헤더의 토큰 수 갱신, 출처 유지)
 	newContent := string(existingContent) + "\n" + content
 
-	if err := os.WriteFile(rulePath, []byte(newContent), 0644); err != nil {
+	var sources []string
+	if h, ok := ParseHeader(string(existingContent)); ok {
+		sources = h.Sources
+	}
+	if err := WriteFile(rulePath, newContent, sources); err != nil {
 		return fmt.Errorf("규칙 파일 업데이트 실패: %w", err)
 	}
 
@@ -269,7 +282,7 @@ func (s *Service) LoadConvention(conventionPath string) error {
 	sb.WriteString("---\n\n")
 	sb.WriteString(string(content))
 
-	if err := os.WriteFile(rulePath, []byte(sb.String()), 0644); err != nil {
+	if err := WriteFile(rulePath, sb.String(), []string{conventionPath}); err != nil {
 		return fmt.Errorf("convention rule 파일 생성 실패: %w", err)
 	}
 
@@ -302,15 +315,11 @@ func (s *Service) GenerateDependencySummary(dependencies []string) string {
 		return ""
 	}
 
-	var sb strings.Builder
-	sb.WriteString("## 의존성\n\n")
-	sb.WriteString("이 포트는 다음 포트에 의존합니다:\n\n")
+	var list strings.Builder
 	for _, dep := range dependencies {
-		sb.WriteString(fmt.Sprintf("- `%s`\n", dep))
+		list.WriteString(fmt.Sprintf("- `%s`\n", dep))
 	}
-	sb.WriteString("\n의존 포트가 완료된 후에 작업을 진행하세요.\n")
-
-	return sb.String()
+	return i18n.T(s.lang, i18n.KeyRuleDependencies, list.String()) + "\n"
 }
 
 // v11: WriteDependencyRule creates a dependencies.md rule file
@@ -326,5 +335,5 @@ func (s *Service) WriteDependencyRule(dependencies []string) error {
 	rulePath := filepath.Join(s.rulesDir, "dependencies.md")
 	content := s.GenerateDependencySummary(dependencies)
 
-	return os.WriteFile(rulePath, []byte(content), 0644)
+	return WriteFile(rulePath, content, nil)
 }
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func setupTestProject(t *testing.T) (string, func()) {
@@ -238,3 +239,179 @@ func TestActivatePort_WithFilePatterns(t *testing.T) {
 		t.Error("파일 패턴이 규칙에 포함되지 않음")
 	}
 }
+
+func TestRuleHeaderAndBudget(t *testing.T) {
+	projectRoot, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	svc := NewService(projectRoot)
+
+	specPath := filepath.Join(projectRoot, "ports", "port-001.md")
+	os.MkdirAll(filepath.Dir(specPath), 0755)
+	os.WriteFile(specPath, []byte("# Port 001\n\n## 목표\n사용자 API 구현\n"), 0644)
+
+	if err := svc.ActivatePortWithSpec("port-001", "Port 001", specPath, []string{"src/**"}); err != nil {
+		t.Fatalf("포트 활성화 실패: %v", err)
+	}
+
+	content, _ := os.ReadFile(svc.GetRulePath("port-001"))
+	if !strings.HasPrefix(string(content), "---\npaths:") {
+		t.Error("frontmatter should stay at the top of the rule file")
+	}
+
+	h, ok := ParseHeader(string(content))
+	if !ok {
+		t.Fatal("rules 헤더가 없음")
+	}
+	if h.Tokens <= 0 || len(h.Sources) != 1 || h.Sources[0] != specPath {
+		t.Errorf("Unexpected header: %+v", h)
+	}
+
+	// append 시 토큰 수 갱신, 헤더는 하나만 유지
+	if err := svc.AppendToRule("port-001", strings.Repeat("추가 지침\n", 50)); err != nil {
+		t.Fatalf("규칙 추가 실패: %v", err)
+	}
+	content, _ = os.ReadFile(svc.GetRulePath("port-001"))
+	updated, _ := ParseHeader(string(content))
+	if updated.Tokens <= h.Tokens {
+		t.Errorf("Expected token count to grow: %d -> %d", h.Tokens, updated.Tokens)
+	}
+	if strings.Count(string(content), headerPrefix) != 1 {
+		t.Error("rule file should contain exactly one header")
+	}
+
+	// 외부 파일도 합산
+	os.WriteFile(filepath.Join(projectRoot, ".claude", "rules", "custom.md"), []byte("custom rule text"), 0644)
+
+	budget, err := svc.Budget()
+	if err != nil {
+		t.Fatalf("budget 조회 실패: %v", err)
+	}
+	if len(budget.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(budget.Rules))
+	}
+	if budget.TotalTokens != budget.Rules[0].Tokens+budget.Rules[1].Tokens {
+		t.Error("total should be the sum of rule tokens")
+	}
+	for _, r := range budget.Rules {
+		if r.Name == "custom" && r.Managed {
+			t.Error("custom rule should not be marked as managed")
+		}
+	}
+}
+
+func TestSectionsAndRender(t *testing.T) {
+	projectRoot, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	svc := NewService(projectRoot)
+	if err := svc.ActivatePort("port-001", "Order Entity", "", []string{"src/order/**"}); err != nil {
+		t.Fatalf("포트 활성화 실패: %v", err)
+	}
+	os.WriteFile(filepath.Join(projectRoot, ".claude", "rules", "workflow.md"), []byte("# Workflow\n\n단일 작업"), 0644)
+
+	sections, err := svc.Sections()
+	if err != nil {
+		t.Fatalf("Sections 실패: %v", err)
+	}
+	if len(sections) != 2 || sections[0].Name != "workflow" {
+		t.Fatalf("섹션 순서 불일치: %+v", sections)
+	}
+	order := sections[1]
+	if len(order.Paths) != 1 || order.Paths[0] != "src/order/**" {
+		t.Errorf("paths 파싱 실패: %v", order.Paths)
+	}
+	if strings.Contains(order.Body, headerPrefix) || strings.HasPrefix(order.Body, "---") {
+		t.Errorf("헤더/frontmatter가 제거되어야 함:\n%s", order.Body)
+	}
+
+	cursor, err := Render(FormatCursor, "# Session Briefing", sections)
+	if err != nil {
+		t.Fatalf("Render 실패: %v", err)
+	}
+	if !strings.HasPrefix(cursor, "---\ndescription:") || !strings.Contains(cursor, "alwaysApply: true") {
+		t.Errorf("cursor frontmatter 누락:\n%s", cursor)
+	}
+	if !strings.Contains(cursor, "# Session Briefing") || !strings.Contains(cursor, "> Applies to: src/order/**") {
+		t.Errorf("브리핑/규칙 내용 누락:\n%s", cursor)
+	}
+
+	plain, _ := Render(FormatPlain, "", sections)
+	if strings.Contains(plain, "alwaysApply") {
+		t.Error("plain 형식에는 frontmatter가 없어야 함")
+	}
+
+	if _, err := Render("copilot", "", sections); err == nil {
+		t.Error("알 수 없는 형식에 에러가 발생해야 함")
+	}
+}
+
+func TestRuleExpiry(t *testing.T) {
+	projectRoot, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	svc := NewService(projectRoot)
+	if err := svc.ActivatePort("port-001", "Port 001", "", nil); err != nil {
+		t.Fatalf("포트 활성화 실패: %v", err)
+	}
+	os.WriteFile(filepath.Join(projectRoot, ".claude", "rules", "custom.md"), []byte("custom rule text"), 0644)
+
+	content, _ := os.ReadFile(svc.GetRulePath("port-001"))
+	h, _ := ParseHeader(string(content))
+	if h.ExpiresAt == nil || !h.ExpiresAt.After(h.GeneratedAt) {
+		t.Fatalf("만료 시각이 없음: %+v", h)
+	}
+
+	now := time.Now()
+	if expired, _ := svc.Expired(now); len(expired) != 0 {
+		t.Errorf("방금 생성한 rules가 만료됨: %v", expired)
+	}
+	expired, err := svc.Expired(h.ExpiresAt.Add(time.Second))
+	if err != nil {
+		t.Fatalf("만료 조회 실패: %v", err)
+	}
+	// 헤더 없는 파일은 만료 대상이 아님
+	if len(expired) != 1 || expired[0] != "port-001" {
+		t.Errorf("Expected [port-001], got %v", expired)
+	}
+
+	if IsExpired(WithHeaderTTL("briefing", nil, 0), now.Add(365*24*time.Hour)) {
+		t.Error("TTL 0은 만료되지 않아야 합니다")
+	}
+	if !IsExpired(WithHeaderTTL("briefing", nil, time.Hour), now.Add(2*time.Hour)) {
+		t.Error("TTL이 지난 내용은 만료되어야 합니다")
+	}
+}
+
+func TestRuleLanguage(t *testing.T) {
+	projectRoot, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	os.MkdirAll(filepath.Join(projectRoot, ".pal"), 0755)
+	cfg := "project:\n  name: demo\n  language: en\n"
+	if err := os.WriteFile(filepath.Join(projectRoot, ".pal", "config.yaml"), []byte(cfg), 0644); err != nil {
+		t.Fatalf("설정 파일 생성 실패: %v", err)
+	}
+
+	svc := NewService(projectRoot)
+	if err := svc.ActivatePort("port-001", "Login", "", nil); err != nil {
+		t.Fatalf("ActivatePort 실패: %v", err)
+	}
+	content, _ := os.ReadFile(svc.GetRulePath("port-001"))
+	if !strings.Contains(string(content), "## Guidelines") || strings.Contains(string(content), "작업 지침") {
+		t.Errorf("영어 규칙이 생성되지 않음:\n%s", content)
+	}
+
+	deps := svc.GenerateDependencySummary([]string{"port-000"})
+	if !strings.Contains(deps, "## Dependencies") || !strings.Contains(deps, "- `port-000`") {
+		t.Errorf("의존성 요약 = %s", deps)
+	}
+
+	rules := generatePortRules("", "port-002", "결제", "## Goal\nPay\n", []string{"테스트"})
+	if !strings.Contains(rules, "# 포트: 결제") || !strings.Contains(rules, "## 완료 체크리스트") {
+		t.Errorf("기본 언어는 한국어여야 함:\n%s", rules)
+	}
+	if !strings.Contains(rules, "Pay") {
+		t.Errorf("영어 목표 섹션을 읽지 못함:\n%s", rules)
+	}
+}
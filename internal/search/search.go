@@ -0,0 +1,389 @@
+// Package search provides a federated search across PAL data
+// (ports, sessions, events, documents, KB notes, decisions, escalations).
+package search
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/kb"
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+// Hit kinds
+const (
+	KindPort       = "port"
+	KindSession    = "session"
+	KindEvent      = "event"
+	KindDocument   = "document"
+	KindKB         = "kb"
+	KindDecision   = "decision"
+	KindEscalation = "escalation"
+)
+
+// AllKinds lists searchable kinds in display order
+var AllKinds = []string{KindPort, KindSession, KindEvent, KindDocument, KindKB, KindDecision, KindEscalation}
+
+// DecisionsDir is the directory of decision records (ADR) under the project root
+var DecisionsDir = filepath.Join(".pal", "decisions")
+
+// snippetRadius is the number of runes kept around a match
+const snippetRadius = 40
+
+// Hit is a typed search result
+type Hit struct {
+	Kind      string `json:"kind"`
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Snippet   string `json:"snippet,omitempty"`
+	Status    string `json:"status,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	Link      string `json:"link"` // 상세 조회 명령 (예: pal port show <id>)
+}
+
+// Options filters a search
+type Options struct {
+	Kinds     []string // 비어 있으면 전체
+	Limit     int      // kind별 최대 결과 수 (기본 10)
+	VaultPath string   // KB vault 경로 (비어 있으면 KB 검색 생략)
+}
+
+// Service runs federated searches
+type Service struct {
+	db          *db.DB
+	projectRoot string
+}
+
+// NewService creates a new search service
+func NewService(database *db.DB, projectRoot string) *Service {
+	return &Service{db: database, projectRoot: projectRoot}
+}
+
+// Search searches all PAL data for the query.
+// 대소문자를 구분하지 않는 부분 일치로 검색하며, 결과는 kind 순서(AllKinds)로 정렬됩니다.
+func (s *Service) Search(query string, opts Options) ([]Hit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("검색어를 입력하세요")
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 10
+	}
+
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = AllKinds
+	}
+	want := make(map[string]bool)
+	for _, k := range kinds {
+		want[k] = true
+	}
+
+	searchers := []struct {
+		kind string
+		fn   func(string, Options) ([]Hit, error)
+	}{
+		{KindPort, s.searchPorts},
+		{KindSession, s.searchSessions},
+		{KindEvent, s.searchEvents},
+		{KindDocument, s.searchDocuments},
+		{KindKB, s.searchKB},
+		{KindDecision, s.searchDecisions},
+		{KindEscalation, s.searchEscalations},
+	}
+
+	var hits []Hit
+	for _, sr := range searchers {
+		if !want[sr.kind] {
+			continue
+		}
+		found, err := sr.fn(query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s 검색 실패: %w", sr.kind, err)
+		}
+		hits = append(hits, found...)
+	}
+	return hits, nil
+}
+
+func likePattern(query string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + r.Replace(query) + "%"
+}
+
+// snippet returns the text around the first match of query
+func snippet(text, query string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	idx := strings.Index(strings.ToLower(text), strings.ToLower(query))
+	if idx < 0 {
+		if len(runes) > snippetRadius*2 {
+			return string(runes[:snippetRadius*2]) + "..."
+		}
+		return text
+	}
+
+	start := len([]rune(text[:idx])) - snippetRadius
+	end := len([]rune(text[:idx])) + len([]rune(query)) + snippetRadius
+	prefix, suffix := "...", "..."
+	if start <= 0 {
+		start, prefix = 0, ""
+	}
+	if end >= len(runes) {
+		end, suffix = len(runes), ""
+	}
+	return prefix + string(runes[start:end]) + suffix
+}
+
+func (s *Service) searchPorts(query string, opts Options) ([]Hit, error) {
+	rows, err := s.db.Query(`
+		SELECT id, COALESCE(title, ''), COALESCE(status, ''), COALESCE(CAST(created_at AS TEXT), '')
+		FROM ports
+		WHERE id LIKE ? ESCAPE '\' OR title LIKE ? ESCAPE '\'
+		ORDER BY created_at DESC
+		LIMIT ?
+	`, likePattern(query), likePattern(query), opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []Hit
+	for rows.Next() {
+		var h Hit
+		if err := rows.Scan(&h.ID, &h.Title, &h.Status, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		h.Kind = KindPort
+		h.Link = "pal port show " + h.ID
+		if h.Title == "" {
+			h.Title = h.ID
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+func (s *Service) searchSessions(query string, opts Options) ([]Hit, error) {
+	rows, err := s.db.Query(`
+		SELECT id, COALESCE(title, ''), COALESCE(status, ''), COALESCE(CAST(started_at AS TEXT), ''),
+		       COALESCE(project_name, '')
+		FROM sessions
+		WHERE id LIKE ? ESCAPE '\' OR title LIKE ? ESCAPE '\'
+		ORDER BY started_at DESC
+		LIMIT ?
+	`, likePattern(query), likePattern(query), opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []Hit
+	for rows.Next() {
+		var h Hit
+		var project string
+		if err := rows.Scan(&h.ID, &h.Title, &h.Status, &h.CreatedAt, &project); err != nil {
+			return nil, err
+		}
+		h.Kind = KindSession
+		h.Link = "pal session show " + h.ID
+		if h.Title == "" {
+			h.Title = h.ID
+		}
+		if project != "" {
+			h.Snippet = "project: " + project
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+// searchEvents searches session event payloads (decision 이벤트는 decision으로 분류)
+func (s *Service) searchEvents(query string, opts Options) ([]Hit, error) {
+	return s.queryEvents(query, opts.Limit, "event_type != ?", KindEvent)
+}
+
+func (s *Service) queryEvents(query string, limit int, typeCond, kind string) ([]Hit, error) {
+	rows, err := s.db.Query(`
+		SELECT id, session_id, event_type, COALESCE(event_data, ''), COALESCE(CAST(created_at AS TEXT), '')
+		FROM session_events
+		WHERE `+typeCond+` AND event_data LIKE ? ESCAPE '\'
+		ORDER BY id DESC
+		LIMIT ?
+	`, session.EventDecision, likePattern(query), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []Hit
+	for rows.Next() {
+		var id int64
+		var sessionID, eventType, data string
+		var h Hit
+		if err := rows.Scan(&id, &sessionID, &eventType, &data, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		h.Kind = kind
+		h.ID = fmt.Sprintf("%d", id)
+		h.Title = fmt.Sprintf("%s (session %s)", eventType, sessionID)
+		h.Snippet = snippet(data, query)
+		h.Link = "pal session show " + sessionID
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+func (s *Service) searchDocuments(query string, opts Options) ([]Hit, error) {
+	rows, err := s.db.Query(`
+		SELECT id, path, COALESCE(type, ''), COALESCE(status, ''), COALESCE(summary, ''),
+		       COALESCE(CAST(updated_at AS TEXT), '')
+		FROM documents
+		WHERE id LIKE ? ESCAPE '\' OR path LIKE ? ESCAPE '\' OR summary LIKE ? ESCAPE '\'
+		ORDER BY updated_at DESC
+		LIMIT ?
+	`, likePattern(query), likePattern(query), likePattern(query), opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []Hit
+	for rows.Next() {
+		var h Hit
+		var docType, summary string
+		if err := rows.Scan(&h.ID, &h.Title, &docType, &h.Status, &summary, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		h.Kind = KindDocument
+		h.Link = "pal docs get " + h.ID
+		h.Snippet = snippet(summary, query)
+		if docType != "" {
+			h.Title = fmt.Sprintf("%s [%s]", h.Title, docType)
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+// searchKB searches the KB vault index (vault가 초기화되지 않았으면 생략)
+func (s *Service) searchKB(query string, opts Options) ([]Hit, error) {
+	if opts.VaultPath == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(filepath.Join(opts.VaultPath, kb.MetaDir, "index.db")); err != nil {
+		return nil, nil
+	}
+
+	indexSvc := kb.NewIndexService(opts.VaultPath)
+	if err := indexSvc.Open(); err != nil {
+		return nil, err
+	}
+	defer indexSvc.Close()
+
+	results, err := indexSvc.Search(query, &kb.SearchOptions{Limit: opts.Limit})
+	if err != nil {
+		// FTS 문법에 맞지 않는 검색어는 KB 결과 없이 진행
+		return nil, nil
+	}
+
+	var hits []Hit
+	for _, r := range results {
+		doc := r.Document
+		hits = append(hits, Hit{
+			Kind:      KindKB,
+			ID:        doc.Path,
+			Title:     doc.Title,
+			Snippet:   snippet(doc.Summary, query),
+			Status:    doc.Status,
+			CreatedAt: doc.UpdatedAt,
+			Link:      "pal kb search " + fmt.Sprintf("%q", doc.Title) + " " + opts.VaultPath,
+		})
+	}
+	return hits, nil
+}
+
+// searchDecisions searches decision events and decision records under .pal/decisions
+func (s *Service) searchDecisions(query string, opts Options) ([]Hit, error) {
+	hits, err := s.queryEvents(query, opts.Limit, "event_type = ?", KindDecision)
+	if err != nil {
+		return nil, err
+	}
+	if s.projectRoot == "" {
+		return hits, nil
+	}
+
+	dir := filepath.Join(s.projectRoot, DecisionsDir)
+	lower := strings.ToLower(query)
+	files := 0
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || files >= opts.Limit || !strings.HasSuffix(path, ".md") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		content := string(data)
+		if !strings.Contains(strings.ToLower(content), lower) && !strings.Contains(strings.ToLower(info.Name()), lower) {
+			return nil
+		}
+		rel, _ := filepath.Rel(s.projectRoot, path)
+		files++
+		hits = append(hits, Hit{
+			Kind:      KindDecision,
+			ID:        rel,
+			Title:     markdownTitle(content, info.Name()),
+			Snippet:   snippet(content, query),
+			CreatedAt: info.ModTime().Format("2006-01-02 15:04:05"),
+			Link:      "pal docs show " + rel,
+		})
+		return nil
+	})
+	return hits, nil
+}
+
+// markdownTitle returns the first heading of a markdown document
+func markdownTitle(content, fallback string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+	}
+	return fallback
+}
+
+func (s *Service) searchEscalations(query string, opts Options) ([]Hit, error) {
+	rows, err := s.db.Query(`
+		SELECT id, issue, COALESCE(status, ''), COALESCE(context, ''), COALESCE(CAST(created_at AS TEXT), '')
+		FROM escalations
+		WHERE issue LIKE ? ESCAPE '\' OR context LIKE ? ESCAPE '\' OR resolution LIKE ? ESCAPE '\'
+		ORDER BY id DESC
+		LIMIT ?
+	`, likePattern(query), likePattern(query), likePattern(query), opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []Hit
+	for rows.Next() {
+		var id int64
+		var context string
+		var h Hit
+		if err := rows.Scan(&id, &h.Title, &h.Status, &context, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		h.Kind = KindEscalation
+		h.ID = fmt.Sprintf("%d", id)
+		h.Link = "pal escalation show " + h.ID
+		if context != "" {
+			h.Snippet = snippet(context, query)
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
@@ -0,0 +1,88 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func setupTestDB(t *testing.T) *db.DB {
+	t.Helper()
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func TestSearch(t *testing.T) {
+	database := setupTestDB(t)
+	root := t.TempDir()
+
+	database.Exec(`INSERT INTO ports (id, title, status) VALUES ('payment-api', '결제 API 구현', 'running')`)
+	database.Exec(`INSERT INTO ports (id, title, status) VALUES ('auth', '인증', 'pending')`)
+	database.Exec(`INSERT INTO sessions (id, title, status) VALUES ('s1', '결제 리팩토링', 'complete')`)
+	database.Exec(`INSERT INTO session_events (session_id, event_type, event_data) VALUES ('s1', 'decision', '{"message":"결제는 PG사 webhook으로 확정"}')`)
+	database.Exec(`INSERT INTO session_events (session_id, event_type, event_data) VALUES ('s1', 'user_request', '{"message":"결제 오류 수정해줘"}')`)
+	database.Exec(`INSERT INTO documents (id, path, type, summary) VALUES ('ports-payment', 'ports/payment.md', 'port', '결제 흐름 명세')`)
+	database.Exec(`INSERT INTO escalations (issue, status) VALUES ('결제 테스트 실패', 'open')`)
+
+	decisions := filepath.Join(root, DecisionsDir)
+	os.MkdirAll(decisions, 0755)
+	os.WriteFile(filepath.Join(decisions, "001-pg.md"), []byte("# PG사 선정\n\n결제 대행사는 A사로 결정"), 0644)
+
+	svc := NewService(database, root)
+	hits, err := svc.Search("결제", Options{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, h := range hits {
+		counts[h.Kind]++
+		if h.Link == "" {
+			t.Errorf("Hit should have a link: %+v", h)
+		}
+	}
+	want := map[string]int{
+		KindPort: 1, KindSession: 1, KindEvent: 1, KindDocument: 1, KindDecision: 2, KindEscalation: 1,
+	}
+	for kind, n := range want {
+		if counts[kind] != n {
+			t.Errorf("Expected %d %s hits, got %d (%v)", n, kind, counts[kind], counts)
+		}
+	}
+	if hits[0].Kind != KindPort || hits[0].Link != "pal port show payment-api" {
+		t.Errorf("Expected port hit first, got %+v", hits[0])
+	}
+
+	// kind 필터
+	hits, _ = svc.Search("결제", Options{Kinds: []string{KindEscalation}})
+	if len(hits) != 1 || hits[0].Link != "pal escalation show 1" {
+		t.Errorf("Expected only escalation hit, got %+v", hits)
+	}
+
+	// LIKE 와일드카드는 문자 그대로 검색
+	hits, _ = svc.Search("%", Options{Kinds: []string{KindPort}})
+	if len(hits) != 0 {
+		t.Errorf("Wildcard should be escaped, got %+v", hits)
+	}
+
+	if _, err := svc.Search("  ", Options{}); err == nil {
+		t.Error("Empty query should fail")
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	long := "앞부분 가나다라마바사아자차카타파하가나다라마바사아자차카타파하가나다라마바사아자차카타파하 결제 뒷부분"
+	s := snippet(long, "결제")
+	if s == "" || s[:3] != "..." {
+		t.Errorf("Expected leading ellipsis, got %q", s)
+	}
+	if got := snippet("짧은 결제 문장", "결제"); got != "짧은 결제 문장" {
+		t.Errorf("Unexpected snippet: %q", got)
+	}
+}
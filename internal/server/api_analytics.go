@@ -0,0 +1,61 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/usage"
+)
+
+// maxAnalyticsDays bounds the cost analytics period
+const maxAnalyticsDays = 366
+
+// handleCostAnalytics returns token/USD spend by day, week, project, session type, agent and port
+func (s *Server) handleCostAnalytics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	days, err := intParam(q.Get("days"), 30)
+	if err != nil || days < 1 || days > maxAnalyticsDays {
+		s.errorResponse(w, 400, "days must be between 1 and 366")
+		return
+	}
+	window, err := intParam(q.Get("window"), usage.DefaultCostWindow)
+	if err != nil || window < 1 {
+		s.errorResponse(w, 400, "window must be a positive integer")
+		return
+	}
+	window = min(window, days)
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	now := time.Now()
+	report, err := usage.NewService(database).GetCosts(usage.CostOptions{
+		Since:   now.AddDate(0, 0, -(days - 1)),
+		Until:   now,
+		Project: requestedProject(r),
+		Window:  window,
+	})
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	s.jsonResponse(w, report)
+}
+
+// intParam parses an optional integer query parameter
+func intParam(v string, def int) (int, error) {
+	if v == "" {
+		return def, nil
+	}
+	return strconv.Atoi(v)
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func TestCostAnalyticsEndpoint(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	database.Exec(`INSERT INTO sessions (id, started_at, input_tokens, output_tokens, cost_usd, project_name) VALUES ('s1', ?, 100, 50, 0.25, 'app')`, time.Now().UTC())
+	database.Close()
+
+	s := NewServer(Config{DBPath: dbPath})
+	t.Cleanup(func() { s.Close() })
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/analytics/costs", s.withCORS(s.handleCostAnalytics))
+
+	report := doJSON(t, mux, "GET", "/api/v2/analytics/costs?days=7&window=3", "", 200)
+	total, _ := report["total"].(map[string]interface{})
+	if total["tokens"] != float64(150) || total["cost_usd"] != 0.25 {
+		t.Errorf("합계 = %v", total)
+	}
+	if daily, _ := report["daily"].([]interface{}); len(daily) != 7 {
+		t.Errorf("일별 항목 수 = %d", len(daily))
+	}
+	if report["window"] != float64(3) {
+		t.Errorf("window = %v", report["window"])
+	}
+
+	doJSON(t, mux, "GET", "/api/v2/analytics/costs?days=0", "", 400)
+	doJSON(t, mux, "GET", "/api/v2/analytics/costs?window=x", "", 400)
+	if clamped := doJSON(t, mux, "GET", "/api/v2/analytics/costs?days=2", "", 200); clamped["window"] != float64(2) {
+		t.Errorf("기간보다 긴 window = %v", clamped["window"])
+	}
+	doJSON(t, mux, "POST", "/api/v2/analytics/costs", "", 405)
+}
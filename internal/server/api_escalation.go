@@ -0,0 +1,58 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/server/events"
+)
+
+// handleEscalationAction handles /api/escalations/{id}/{assign|comments|activity}
+func (s *Server) handleEscalationAction(w http.ResponseWriter, r *http.Request, svc *escalation.Service, e *escalation.Escalation, action string) {
+	switch {
+	case action == "activity" && r.Method == "GET":
+		activities, err := svc.Activity(e.ID)
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		writeList(s, w, r, activities, 100)
+
+	case action == "assign" && r.Method == "POST":
+		var req escalationAssignRequest
+		if !s.decodeBody(w, r, &req) {
+			return
+		}
+		activity, err := svc.Assign(e.ID, req.Assignee, req.Actor)
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		s.publishEscalationActivity(e, false, activity)
+		s.jsonResponse(w, activity)
+
+	case action == "comments" && r.Method == "POST":
+		var req escalationCommentRequest
+		if !s.decodeBody(w, r, &req) {
+			return
+		}
+		activity, err := svc.Comment(e.ID, req.Actor, req.Body, req.ParentID)
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		s.publishEscalationActivity(e, false, activity)
+		s.createdResponse(w, activity)
+
+	case action == "activity" || action == "assign" || action == "comments":
+		s.errorResponse(w, 405, "Method not allowed")
+
+	default:
+		s.errorResponse(w, 404, "unknown escalation action: "+action)
+	}
+}
+
+// publishEscalationActivity notifies SSE clients subscribed to the escalation topic
+func (s *Server) publishEscalationActivity(e *escalation.Escalation, resolved bool, data interface{}) {
+	events.GetPublisher().PublishEscalationActivity(e.FromSession.String, e.FromPort.String, resolved, data)
+}
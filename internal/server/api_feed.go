@@ -0,0 +1,102 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/feed"
+)
+
+// maxFeedWindow bounds how far back the activity feed can look
+const maxFeedWindow = 7 * 24 * time.Hour
+
+// handleFeed returns session events, messages, escalations and lock activity as one stream (newest first).
+// 첫 페이지에서 until을 현재 시각으로 고정해 다음 페이지 링크에 붙이므로,
+// 조회 중에 새 활동이 쌓여도 커서가 밀리지 않습니다.
+func (s *Server) handleFeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	until := time.Now().UTC().Truncate(time.Second)
+	if v := q.Get("until"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			s.errorResponse(w, 400, "until must be RFC3339")
+			return
+		}
+		until = t
+	}
+	since, err := feedSince(q.Get("since"), until)
+	if err != nil {
+		s.errorResponse(w, 400, err.Error())
+		return
+	}
+
+	var kinds []feed.Kind
+	if v := q.Get("kind"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			kind, err := feed.ParseKind(strings.TrimSpace(name))
+			if err != nil {
+				s.errorResponse(w, 400, err.Error())
+				return
+			}
+			kinds = append(kinds, kind)
+		}
+	}
+
+	root, err := s.scopedProject(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	items, err := feed.NewService(database).List(feed.Filter{
+		Since:   since,
+		Until:   until,
+		Project: root,
+		Kinds:   kinds,
+		Limit:   maxPageScan,
+	})
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	if q.Get("until") == "" {
+		q.Set("until", until.Format(time.RFC3339))
+		r.URL.RawQuery = q.Encode()
+	}
+	writeList(s, w, r, items, 50)
+}
+
+// feedSince parses ?since= as a duration before until (1h, 30m) or an RFC3339 time
+func feedSince(v string, until time.Time) (time.Time, error) {
+	if v == "" {
+		return until.Add(-feed.DefaultWindow), nil
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		if d <= 0 || d > maxFeedWindow {
+			return time.Time{}, fmt.Errorf("since must be within %s", maxFeedWindow)
+		}
+		return until.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("since must be a duration (1h, 30m) or RFC3339")
+	}
+	if until.Sub(t) > maxFeedWindow {
+		return time.Time{}, fmt.Errorf("since must be within %s", maxFeedWindow)
+	}
+	return t, nil
+}
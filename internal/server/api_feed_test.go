@@ -0,0 +1,60 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func TestFeedEndpoint(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	now := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		database.Exec(`INSERT INTO session_events (session_id, event_type, created_at) VALUES ('s1', 'tool_use', ?)`,
+			now.Add(-time.Duration(i+1)*time.Minute).Format("2006-01-02 15:04:05"))
+	}
+	database.Exec(`INSERT INTO escalations (from_session, issue, created_at) VALUES ('s1', '막힘', ?)`,
+		now.Add(-30*time.Second).Format("2006-01-02 15:04:05"))
+	database.Exec(`INSERT INTO session_events (session_id, event_type, created_at) VALUES ('s1', 'old', ?)`,
+		now.Add(-2*time.Hour).Format("2006-01-02 15:04:05"))
+	database.Close()
+
+	s := NewServer(Config{DBPath: dbPath})
+	t.Cleanup(func() { s.Close() })
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/feed", s.withCORS(s.handleFeed))
+
+	page := doJSON(t, mux, "GET", "/api/v2/feed?envelope=true", "", 200)
+	items, _ := page["items"].([]interface{})
+	if len(items) != 4 {
+		t.Fatalf("기본 1시간 피드 항목 수 = %d, want 4", len(items))
+	}
+	if first, _ := items[0].(map[string]interface{}); first["kind"] != "escalation" {
+		t.Errorf("최신 항목 = %v", first)
+	}
+
+	if page = doJSON(t, mux, "GET", "/api/v2/feed?envelope=true&since=3h&kind=event", "", 200); len(page["items"].([]interface{})) != 4 {
+		t.Errorf("since=3h kind=event 항목 = %v", page["items"])
+	}
+
+	req := httptest.NewRequest("GET", "/api/v2/feed?limit=2", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if link := rec.Header().Get("Link"); !strings.Contains(link, "cursor=") || !strings.Contains(link, "until=") {
+		t.Errorf("다음 페이지 링크에 cursor/until이 고정되어야 함: %q", link)
+	}
+
+	doJSON(t, mux, "GET", "/api/v2/feed?since=nope", "", 400)
+	doJSON(t, mux, "GET", "/api/v2/feed?since=720h", "", 400)
+	doJSON(t, mux, "GET", "/api/v2/feed?kind=bogus", "", 400)
+	doJSON(t, mux, "POST", "/api/v2/feed", "", 405)
+}
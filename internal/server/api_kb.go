@@ -1,8 +1,10 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"io"
+	"log"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -10,6 +12,8 @@ import (
 	"strings"
 
 	"github.com/n0roo/pal-kit/internal/kb"
+	"github.com/n0roo/pal-kit/internal/server/events"
+	"github.com/n0roo/pal-kit/internal/trash"
 )
 
 // RegisterKBRoutes registers Knowledge Base API routes
@@ -32,6 +36,9 @@ func (s *Server) RegisterKBRoutes(mux *http.ServeMux) {
 	// Tags
 	mux.HandleFunc("/api/v2/kb/tags", s.withCORS(s.handleKBTags))
 
+	// Link graph
+	mux.HandleFunc("/api/v2/kb/graph", s.withCORS(s.handleKBGraph))
+
 	// Sections
 	mux.HandleFunc("/api/v2/kb/sections", s.withCORS(s.handleKBSections))
 
@@ -50,6 +57,31 @@ func (s *Server) getVaultPath() string {
 	return filepath.Join(home, "mcp-docs")
 }
 
+// runKBWatch keeps the vault index up to date and publishes kb:updated events until stop is closed.
+// read-only 모드에서는 색인만 갱신하고 TOC 파일은 쓰지 않습니다.
+func (s *Server) runKBWatch(stop <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	vaultPath := s.getVaultPath()
+	if status, err := kb.NewService(vaultPath).Status(); err != nil || !status.Initialized {
+		log.Printf("KB watch disabled: vault not initialized (%s)", vaultPath)
+		return
+	}
+
+	watcher := kb.NewWatcher(vaultPath, kb.WatchOptions{NoTOC: s.config.ReadOnly})
+	err := watcher.Run(ctx, func(event *kb.WatchEvent) {
+		events.GetPublisher().Publish(events.NewEvent(events.EventKBUpdated, event).WithProject(s.config.ProjectRoot))
+	})
+	if err != nil {
+		log.Printf("KB watch stopped: %v", err)
+	}
+}
+
 // ========================================
 // KB Status & Init Handlers
 // ========================================
@@ -303,14 +335,8 @@ func (s *Server) handleKBDocuments(w http.ResponseWriter, r *http.Request) {
 		domain := r.URL.Query().Get("domain")
 		status := r.URL.Query().Get("status")
 		tag := r.URL.Query().Get("tag")
-		limitStr := r.URL.Query().Get("limit")
 		tokenBudgetStr := r.URL.Query().Get("token_budget")
 
-		limit := 50
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
-
 		tokenBudget := 0
 		if tb, err := strconv.Atoi(tokenBudgetStr); err == nil && tb > 0 {
 			tokenBudget = tb
@@ -327,8 +353,9 @@ func (s *Server) handleKBDocuments(w http.ResponseWriter, r *http.Request) {
 			Type:        docType,
 			Domain:      domain,
 			Status:      status,
-			Limit:       limit,
+			Limit:       maxPageScan,
 			TokenBudget: tokenBudget,
+			Content:     r.URL.Query().Get("content") == "true",
 		}
 		if tag != "" {
 			opts.Tags = []string{tag}
@@ -340,7 +367,7 @@ func (s *Server) handleKBDocuments(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		s.jsonResponse(w, results)
+		writeList(s, w, r, results, 50)
 
 	case "POST":
 		// Create document
@@ -476,14 +503,25 @@ func (s *Server) handleKBDocumentDetail(w http.ResponseWriter, r *http.Request)
 		s.jsonResponse(w, map[string]string{"status": "updated"})
 
 	case "DELETE":
-		// Delete document
-		fullPath := filepath.Join(vaultPath, path)
-		if err := os.Remove(fullPath); err != nil {
+		// Soft-delete: 휴지통으로 이동 (30일 후 영구 삭제)
+		database, err := s.getDB()
+		if err != nil {
+			s.errorResponse(w, 500, err.Error())
+			return
+		}
+
+		item, err := trash.NewService(database).Move(trash.KindKB, vaultPath, path, nil)
+		if err != nil {
+			s.errorResponse(w, 500, err.Error())
+			return
+		}
+		// 검색 결과에 휴지통 노트가 남지 않도록 색인에서도 제거
+		if err := kb.RefreshVaultDocument(vaultPath, path); err != nil {
 			s.errorResponse(w, 500, err.Error())
 			return
 		}
 
-		s.jsonResponse(w, map[string]string{"status": "deleted"})
+		s.jsonResponse(w, map[string]string{"status": "trashed", "trash_id": item.ID})
 
 	default:
 		s.errorResponse(w, 405, "Method not allowed")
@@ -498,16 +536,26 @@ func (s *Server) handleKBDocumentContent(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	render, err := wantsHTML(r)
+	if err != nil {
+		s.errorResponse(w, 400, err.Error())
+		return
+	}
+
 	content, err := os.ReadFile(fullPath)
 	if err != nil {
 		s.errorResponse(w, 404, "Document not found")
 		return
 	}
 
-	s.jsonResponse(w, map[string]string{
+	resp := map[string]string{
 		"path":    docPath,
 		"content": string(content),
-	})
+	}
+	if render {
+		resp["html"] = renderKBHTML(vaultPath, docPath, string(content))
+	}
+	s.jsonResponse(w, resp)
 }
 
 func (s *Server) handleKBDocumentMove(w http.ResponseWriter, r *http.Request, vaultPath, docPath string) {
@@ -524,25 +572,18 @@ func (s *Server) handleKBDocumentMove(w http.ResponseWriter, r *http.Request, va
 		return
 	}
 
-	oldPath := filepath.Join(vaultPath, docPath)
-	newPath := filepath.Join(vaultPath, req.NewPath)
-
-	// Ensure target directory exists
-	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
-		s.errorResponse(w, 500, err.Error())
-		return
-	}
-
-	// Move file
-	if err := os.Rename(oldPath, newPath); err != nil {
+	// 이 문서를 가리키던 위키링크와 색인 경로도 함께 갱신
+	result, err := kb.NewLinkService(vaultPath).MoveDocument(docPath, req.NewPath, false)
+	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
 
-	s.jsonResponse(w, map[string]string{
+	s.jsonResponse(w, map[string]interface{}{
 		"status":   "moved",
 		"old_path": docPath,
 		"new_path": req.NewPath,
+		"relinked": len(result.Relinked),
 	})
 }
 
@@ -683,6 +724,55 @@ func (s *Server) handleKBTags(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, tags)
 }
 
+// ========================================
+// Graph Handler
+// ========================================
+
+// graphContentTypes are the response types of non-JSON graph formats
+var graphContentTypes = map[string]string{
+	kb.GraphFormatGraphML: "application/graphml+xml; charset=utf-8",
+	kb.GraphFormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	kb.GraphFormatMermaid: "text/plain; charset=utf-8",
+}
+
+func (s *Server) handleKBGraph(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := kb.GraphFilter{Domain: q.Get("domain"), Tag: q.Get("tag")}
+	if v := q.Get("min_degree"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			s.errorResponse(w, 400, "min_degree는 0 이상의 정수여야 합니다")
+			return
+		}
+		filter.MinDegree = n
+	}
+	format := strings.ToLower(q.Get("format"))
+	contentType, ok := graphContentTypes[format]
+	if !ok && format != "" && format != kb.GraphFormatJSON {
+		s.errorResponse(w, 400, "지원하지 않는 그래프 형식: "+format)
+		return
+	}
+
+	graph, err := kb.NewLinkService(s.getVaultPath()).BuildLinkGraph()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+	graph = graph.Filter(filter)
+
+	if !ok {
+		s.jsonResponse(w, graph)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	kb.WriteGraph(w, graph, format)
+}
+
 // ========================================
 // Sections Handler
 // ========================================
@@ -762,8 +852,14 @@ func (s *Server) handleKBRegister(w http.ResponseWriter, r *http.Request) {
 		req.TargetSection = kb.ProjectsDir
 	}
 
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
 	vaultPath := s.getVaultPath()
-	registerSvc := kb.NewRegisterService(vaultPath, s.config.ProjectRoot)
+	registerSvc := kb.NewRegisterService(vaultPath, root)
 
 	result, err := registerSvc.RegisterFromProject(req.SourcePath, req.TargetSection, req.TargetPath)
 	if err != nil {
@@ -804,8 +900,14 @@ func (s *Server) handleKBRegisterExternal(w http.ResponseWriter, r *http.Request
 		req.TargetSection = kb.ReferencesDir
 	}
 
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
 	vaultPath := s.getVaultPath()
-	registerSvc := kb.NewRegisterService(vaultPath, s.config.ProjectRoot)
+	registerSvc := kb.NewRegisterService(vaultPath, root)
 
 	result, err := registerSvc.RegisterExternal(req.Title, req.Content, req.TargetSection, req.Type, req.Tags)
 	if err != nil {
@@ -5,11 +5,15 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/config"
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/health"
+	"github.com/n0roo/pal-kit/internal/project"
 )
 
 // Project represents a PAL Kit project
@@ -24,6 +28,9 @@ type Project struct {
 	TotalTokens  int64     `json:"total_tokens"`
 	CreatedAt    time.Time `json:"created_at"`
 	Initialized  bool      `json:"initialized"`
+	Archived     bool      `json:"archived"`
+	Hidden       bool      `json:"hidden"`
+	Current      bool      `json:"current"` // 서버 시작 프로젝트 (X-PAL-Project 미지정 시 기본값)
 }
 
 // RegisterProjectRoutes registers project management routes
@@ -49,45 +56,24 @@ func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	// Query projects from database
-	rows, err := database.Query(`
-		SELECT
-			root, name, description, last_active,
-			session_count, total_tokens, created_at
-		FROM projects
-		ORDER BY last_active DESC
-	`)
+	// 보관/숨김 프로젝트는 기본 제외 (?archived=include|only, ?hidden=include)
+	q := r.URL.Query()
+	opts := project.ListOptions{
+		IncludeArchived: q.Get("archived") == "include",
+		OnlyArchived:    q.Get("archived") == "only",
+		IncludeHidden:   q.Get("hidden") == "include",
+	}
+	list, err := project.NewService(database).List(opts)
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer rows.Close()
-
-	projects := make([]Project, 0)
-	for rows.Next() {
-		var p Project
-		var lastActive, createdAt *string
-		var description *string
-
-		err := rows.Scan(
-			&p.Root, &p.Name, &description, &lastActive,
-			&p.SessionCount, &p.TotalTokens, &createdAt,
-		)
-		if err != nil {
-			continue
-		}
 
-		if description != nil {
-			p.Description = *description
-		}
-		if lastActive != nil {
-			p.LastActive, _ = time.Parse(time.RFC3339, *lastActive)
-		}
-		if createdAt != nil {
-			p.CreatedAt, _ = time.Parse(time.RFC3339, *createdAt)
-		}
+	projects := make([]Project, 0, len(list))
+	for _, item := range list {
+		p := toProjectDTO(item)
+		p.Current = p.Root == s.config.ProjectRoot
 
 		// Check if project is initialized (has .pal folder)
 		p.Initialized = isProjectInitialized(p.Root)
@@ -100,7 +86,26 @@ func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
 		projects = append(projects, p)
 	}
 
-	s.jsonResponse(w, projects)
+	writeList(s, w, r, projects, 0)
+}
+
+func toProjectDTO(item project.Project) Project {
+	p := Project{
+		Root:         item.Root,
+		Name:         item.Name,
+		Description:  item.Description,
+		SessionCount: item.SessionCount,
+		TotalTokens:  item.TotalTokens,
+		Archived:     item.Archived,
+		Hidden:       item.Hidden,
+	}
+	if item.LastActive.Valid {
+		p.LastActive = item.LastActive.Time
+	}
+	if item.CreatedAt.Valid {
+		p.CreatedAt = item.CreatedAt.Time
+	}
+	return p
 }
 
 type portCounts struct {
@@ -148,9 +153,20 @@ func (s *Server) handleProjectDetailV2(w http.ResponseWriter, r *http.Request) {
 	// URL decode the path
 	root := path
 
+	if strings.HasSuffix(path, "/health") {
+		if r.Method != "GET" {
+			s.errorResponse(w, 405, "Method not allowed")
+			return
+		}
+		s.getProjectHealth(w, r, strings.TrimSuffix(path, "/health"))
+		return
+	}
+
 	switch r.Method {
 	case "GET":
 		s.getProject(w, r, root)
+	case "PATCH":
+		s.updateProject(w, r, root)
 	case "DELETE":
 		s.removeProject(w, r, root)
 	default:
@@ -164,7 +180,6 @@ func (s *Server) getProject(w http.ResponseWriter, r *http.Request, root string)
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	var p Project
 	var lastActive, createdAt *string
@@ -181,6 +196,7 @@ func (s *Server) getProject(w http.ResponseWriter, r *http.Request, root string)
 		s.errorResponse(w, 404, "Project not found")
 		return
 	}
+	p.Current = p.Root == s.config.ProjectRoot
 
 	if description != nil {
 		p.Description = *description
@@ -196,13 +212,95 @@ func (s *Server) getProject(w http.ResponseWriter, r *http.Request, root string)
 	s.jsonResponse(w, p)
 }
 
+// getProjectHealth returns the composite health score and its contributing factors (?days=14)
+func (s *Server) getProjectHealth(w http.ResponseWriter, r *http.Request, root string) {
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	var exists int
+	database.QueryRow(`SELECT COUNT(*) FROM projects WHERE root = ?`, root).Scan(&exists)
+	if exists == 0 {
+		s.errorResponse(w, 404, "Project not found")
+		return
+	}
+
+	window := health.DefaultWindow
+	if v := r.URL.Query().Get("days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days <= 0 {
+			s.errorResponse(w, 400, "days must be a positive integer")
+			return
+		}
+		window = time.Duration(days) * 24 * time.Hour
+	}
+
+	report, err := health.NewService(database).Compute(root, window, time.Now())
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.jsonResponse(w, report)
+}
+
+// updateProject renames, archives or hides a project
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, root string) {
+	var req struct {
+		Name     *string `json:"name"`
+		Archived *bool   `json:"archived"`
+		Hidden   *bool   `json:"hidden"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.errorResponse(w, 400, "Invalid request body")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	svc := project.NewService(database)
+	if req.Name != nil {
+		err = svc.Rename(root, *req.Name)
+	}
+	if err == nil && req.Archived != nil {
+		err = svc.SetArchived(root, *req.Archived)
+	}
+	if err == nil && req.Hidden != nil {
+		err = svc.SetHidden(root, *req.Hidden)
+	}
+	if err != nil {
+		status := 500
+		switch errcode.KindOf(err) {
+		case errcode.KindNotFound:
+			status = 404
+		case errcode.KindValidation:
+			status = 400
+		}
+		s.errorResponse(w, status, err.Error())
+		return
+	}
+
+	item, err := svc.Get(root)
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+	p := toProjectDTO(*item)
+	p.Initialized = isProjectInitialized(p.Root)
+	s.jsonResponse(w, p)
+}
+
 func (s *Server) removeProject(w http.ResponseWriter, r *http.Request, root string) {
 	database, err := s.getDB()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	_, err = database.Exec("DELETE FROM projects WHERE root = ?", root)
 	if err != nil {
@@ -249,7 +347,6 @@ func (s *Server) handleProjectImport(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	// Insert or update project
 	_, err = database.Exec(`
@@ -312,7 +409,6 @@ func (s *Server) handleProjectInit(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	// Insert or update project
 	_, err = database.Exec(`
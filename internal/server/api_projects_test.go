@@ -0,0 +1,113 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func TestProjectHealthEndpoint(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	root := t.TempDir()
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	database.Exec(`INSERT INTO projects (root, name) VALUES (?, 'demo')`, root)
+	database.Exec(`INSERT INTO sessions (id, project_root) VALUES ('s1', ?)`, root)
+	database.Exec(`INSERT INTO escalations (from_session, issue) VALUES ('s1', '리뷰 필요')`)
+	database.Close()
+
+	s := NewServer(Config{DBPath: dbPath})
+	t.Cleanup(func() { s.Close() })
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/projects/", s.withCORS(s.handleProjectDetailV2))
+
+	report := doJSON(t, mux, "GET", "/api/v2/projects/"+url.PathEscape(root)+"/health?days=7", "", 200)
+	if report["score"] == nil || report["window"] != "7d" {
+		t.Fatalf("건강 보고서 = %v", report)
+	}
+	factors, _ := report["factors"].([]interface{})
+	if len(factors) != 5 {
+		t.Fatalf("요인 수 = %d", len(factors))
+	}
+	esc := factors[1].(map[string]interface{})
+	if esc["name"] != "escalation_backlog" || esc["score"] != float64(85) {
+		t.Errorf("에스컬레이션 요인 = %v", esc)
+	}
+
+	doJSON(t, mux, "GET", "/api/v2/projects/"+url.PathEscape(root)+"/health?days=0", "", 400)
+	doJSON(t, mux, "GET", "/api/v2/projects/missing/health", "", 404)
+	doJSON(t, mux, "POST", "/api/v2/projects/"+url.PathEscape(root)+"/health", "", 405)
+}
+
+func TestProjectScopedLists(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	home, other := t.TempDir(), t.TempDir()
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	database.Exec(`INSERT INTO projects (root, name) VALUES (?, 'home'), (?, 'other')`, home, other)
+	database.Exec(`INSERT INTO sessions (id, project_root) VALUES ('s-home', ?), ('s-other', ?)`, home, other)
+	database.Exec(`INSERT INTO ports (id, session_id) VALUES ('p-home', 's-home'), ('p-other', 's-other')`)
+	database.Close()
+
+	s := NewServer(Config{DBPath: dbPath, ProjectRoot: home})
+	t.Cleanup(func() { s.Close() })
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/sessions", s.withCORS(s.handleSessions))
+	mux.HandleFunc("/api/ports", s.withCORS(s.handlePorts))
+	mux.HandleFunc("/api/status", s.withCORS(s.handleStatus))
+	mux.HandleFunc("/api/v2/projects", s.withCORS(s.handleProjectsV2))
+
+	ids := func(body map[string]interface{}) []string {
+		var out []string
+		items, _ := body["items"].([]interface{})
+		for _, it := range items {
+			out = append(out, it.(map[string]interface{})["id"].(string))
+		}
+		return out
+	}
+
+	// 지정하지 않으면 전체
+	if got := ids(doJSON(t, mux, "GET", "/api/sessions?envelope=true", "", 200)); len(got) != 2 {
+		t.Errorf("전체 세션 = %v", got)
+	}
+
+	// 헤더로 프로젝트 전환
+	req := httptest.NewRequest("GET", "/api/ports?envelope=true", nil)
+	req.Header.Set(projectHeader, other)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	var body map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if got := ids(body); rec.Code != 200 || len(got) != 1 || got[0] != "p-other" {
+		t.Errorf("other 포트 = %d %v", rec.Code, got)
+	}
+
+	if got := ids(doJSON(t, mux, "GET", "/api/sessions?envelope=true&project="+url.QueryEscape(home), "", 200)); len(got) != 1 || got[0] != "s-home" {
+		t.Errorf("home 세션 = %v", got)
+	}
+	status := doJSON(t, mux, "GET", "/api/status?project="+url.QueryEscape(other), "", 200)
+	if status["project_root"] != other {
+		t.Errorf("status project_root = %v", status["project_root"])
+	}
+
+	// 등록되지 않은 프로젝트는 404
+	doJSON(t, mux, "GET", "/api/sessions?project=/nowhere", "", 404)
+
+	// 전환기용 목록: 서버 시작 프로젝트 표시
+	list := doJSON(t, mux, "GET", "/api/v2/projects?envelope=true", "", 200)
+	for _, it := range list["items"].([]interface{}) {
+		p := it.(map[string]interface{})
+		if (p["root"] == home) != (p["current"] == true) {
+			t.Errorf("current 표시 = %v", p)
+		}
+	}
+}
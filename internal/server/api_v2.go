@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -11,12 +12,17 @@ import (
 	"github.com/n0roo/pal-kit/internal/agent"
 	"github.com/n0roo/pal-kit/internal/agentv2"
 	"github.com/n0roo/pal-kit/internal/attention"
+	"github.com/n0roo/pal-kit/internal/compact"
 	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/db"
 	"github.com/n0roo/pal-kit/internal/document"
+	"github.com/n0roo/pal-kit/internal/errcode"
 	"github.com/n0roo/pal-kit/internal/handoff"
+	"github.com/n0roo/pal-kit/internal/kb"
 	"github.com/n0roo/pal-kit/internal/message"
 	"github.com/n0roo/pal-kit/internal/orchestrator"
 	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/n0roo/pal-kit/internal/trash"
 )
 
 // RegisterV2Routes registers v2 API routes
@@ -25,6 +31,10 @@ func (s *Server) RegisterV2Routes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/v2/orchestrations", s.withCORS(s.handleOrchestrations))
 	mux.HandleFunc("/api/v2/orchestrations/", s.withCORS(s.handleOrchestrationDetail))
 
+	// Execution API (Orchestration + 이관 전 파이프라인 통합 목록)
+	mux.HandleFunc("/api/v2/executions", s.withCORS(s.handleExecutions))
+	mux.HandleFunc("/api/v2/executions/migrate", s.withCORS(s.handleExecutionMigrate))
+
 	// Session Hierarchy API
 	mux.HandleFunc("/api/v2/sessions/hierarchy", s.withCORS(s.handleSessionHierarchy))
 	mux.HandleFunc("/api/v2/sessions/hierarchy/", s.withCORS(s.handleSessionHierarchyDetail))
@@ -56,8 +66,19 @@ func (s *Server) RegisterV2Routes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/v2/documents/stats", s.withCORS(s.handleDocumentStats))
 	mux.HandleFunc("/api/v2/documents/index", s.withCORS(s.handleDocumentIndex))
 	mux.HandleFunc("/api/v2/documents/tree", s.withCORS(s.handleDocumentTree))
+	mux.HandleFunc("/api/v2/documents/types", s.withCORS(s.handleDocumentTypes))
 	mux.HandleFunc("/api/v2/documents/", s.withCORS(s.handleDocumentDetail))
 	mux.HandleFunc("/api/v2/documents", s.withCORS(s.handleDocumentsV2))
+
+	// Trash API (soft-deleted documents / KB notes)
+	mux.HandleFunc("/api/v2/trash", s.withCORS(s.handleTrash))
+	mux.HandleFunc("/api/v2/trash/", s.withCORS(s.handleTrashDetail))
+
+	// Analytics API
+	mux.HandleFunc("/api/v2/analytics/costs", s.withCORS(s.handleCostAnalytics))
+
+	// Activity feed (events, messages, escalations, locks)
+	mux.HandleFunc("/api/v2/feed", s.withCORS(s.handleFeed))
 }
 
 // ========================================
@@ -70,7 +91,6 @@ func (s *Server) handleOrchestrations(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	sessionSvc := session.NewService(database)
 	msgStore := message.NewStore(database.DB)
@@ -79,34 +99,47 @@ func (s *Server) handleOrchestrations(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case "GET":
 		status := r.URL.Query().Get("status")
-		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-		if limit <= 0 {
-			limit = 20
-		}
-
-		orchestrations, err := orchSvc.ListOrchestrations(orchestrator.OrchestrationStatus(status), limit)
+		orchestrations, err := orchSvc.ListOrchestrations(orchestrator.OrchestrationStatus(status), maxPageScan)
 		if err != nil {
 			s.errorResponse(w, 500, err.Error())
 			return
 		}
-		s.jsonResponse(w, orchestrations)
+		writeList(s, w, r, orchestrations, 20)
 
 	case "POST":
 		var req struct {
 			Title       string                       `json:"title"`
 			Description string                       `json:"description"`
 			Ports       []orchestrator.AtomicPort    `json:"ports"`
+			IdempotencyKey string                    `json:"idempotency_key"`
+			RetryPolicy *orchestrator.RetryPolicy    `json:"retry_policy"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			s.errorResponse(w, 400, "Invalid request body")
 			return
 		}
+		if req.RetryPolicy != nil {
+			if err := req.RetryPolicy.Validate(); err != nil {
+				s.writeError(w, err)
+				return
+			}
+		}
+
+		// Idempotency-Key 헤더 우선, 없으면 body의 idempotency_key 사용
+		key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+		if key == "" {
+			key = strings.TrimSpace(req.IdempotencyKey)
+		}
 
-		orch, err := orchSvc.CreateOrchestration(req.Title, req.Description, req.Ports)
+		// 재시도 정책은 같은 INSERT로 저장 (생성 후 갱신이 실패해 기본 정책으로 남지 않도록)
+		orch, created, err := orchSvc.CreateOrchestrationWithPolicy(key, req.Title, req.Description, req.Ports, req.RetryPolicy)
 		if err != nil {
-			s.errorResponse(w, 500, err.Error())
+			s.writeError(w, err)
 			return
 		}
+		if !created {
+			w.Header().Set("Idempotent-Replayed", "true")
+		}
 		s.jsonResponse(w, orch)
 
 	default:
@@ -134,12 +167,16 @@ func (s *Server) handleOrchestrationDetail(w http.ResponseWriter, r *http.Reques
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	sessionSvc := session.NewService(database)
 	msgStore := message.NewStore(database.DB)
 	orchSvc := orchestrator.NewService(database, sessionSvc, msgStore)
 
+	// 이관된 파이프라인 ID로도 조회 가능
+	if resolved, err := orchSvc.ResolveExecutionID(id); err == nil {
+		id = resolved
+	}
+
 	switch subResource {
 	case "stats":
 		stats, err := orchSvc.GetOrchestrationStats(id)
@@ -173,6 +210,92 @@ func (s *Server) handleOrchestrationDetail(w http.ResponseWriter, r *http.Reques
 		}
 		s.jsonResponse(w, map[string]string{"status": "started"})
 
+	case orchestrator.ActionPause, orchestrator.ActionResume, orchestrator.ActionCancel:
+		if r.Method != "POST" {
+			s.errorResponse(w, 405, "Method not allowed")
+			return
+		}
+		var req struct {
+			Reason string `json:"reason"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var event *orchestrator.LifecycleEvent
+		switch subResource {
+		case orchestrator.ActionPause:
+			event, err = orchSvc.PauseOrchestration(id, req.Reason)
+		case orchestrator.ActionResume:
+			event, err = orchSvc.ResumeOrchestration(id, req.Reason)
+		default:
+			event, err = orchSvc.CancelOrchestration(id, req.Reason)
+		}
+		if err != nil {
+			status := 500
+			switch errcode.KindOf(err) {
+			case errcode.KindNotFound:
+				status = 404
+			case errcode.KindConflict:
+				status = 409
+			}
+			s.errorResponse(w, status, err.Error())
+			return
+		}
+		s.jsonResponse(w, event)
+
+	case "plan":
+		plan, err := orchSvc.ComputePlan(id)
+		if err != nil {
+			status := 500
+			switch errcode.KindOf(err) {
+			case errcode.KindNotFound:
+				status = 404
+			case errcode.KindValidation:
+				status = 400
+			}
+			s.errorResponse(w, status, err.Error())
+			return
+		}
+		s.jsonResponse(w, plan)
+
+	case "retry-policy":
+		orch, err := orchSvc.GetOrchestration(id)
+		if err != nil {
+			s.errorResponse(w, 404, err.Error())
+			return
+		}
+		switch r.Method {
+		case "GET":
+			s.jsonResponse(w, orch.RetryPolicy)
+		case "PUT":
+			policy := orch.RetryPolicy
+			if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+				s.errorResponse(w, 400, "Invalid request body")
+				return
+			}
+			if err := orchSvc.SetRetryPolicy(id, policy); err != nil {
+				status := 500
+				if errcode.KindOf(err) == errcode.KindValidation {
+					status = 400
+				}
+				s.errorResponse(w, status, err.Error())
+				return
+			}
+			s.jsonResponse(w, policy)
+		default:
+			s.errorResponse(w, 405, "Method not allowed")
+		}
+
+	case "lifecycle":
+		events, err := orchSvc.LifecycleHistory(id)
+		if err != nil {
+			s.errorResponse(w, 500, err.Error())
+			return
+		}
+		if events == nil {
+			events = []*orchestrator.LifecycleEvent{}
+		}
+		s.jsonResponse(w, events)
+
 	default:
 		orch, err := orchSvc.GetOrchestration(id)
 		if err != nil {
@@ -193,7 +316,6 @@ func (s *Server) handleSessionHierarchy(w http.ResponseWriter, r *http.Request)
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := session.NewService(database)
 
@@ -226,7 +348,6 @@ func (s *Server) handleSessionHierarchyDetail(w http.ResponseWriter, r *http.Req
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := session.NewService(database)
 
@@ -271,22 +392,16 @@ func (s *Server) handleBuildSessions(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := session.NewService(database)
 	activeOnly := r.URL.Query().Get("active") == "true"
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-	if limit <= 0 {
-		limit = 10
-	}
-
-	builds, err := svc.GetBuildSessions(activeOnly, limit)
+	builds, err := svc.GetBuildSessions(activeOnly, maxPageScan)
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
 
-	s.jsonResponse(w, builds)
+	writeList(s, w, r, builds, 10)
 }
 
 // ========================================
@@ -317,7 +432,6 @@ func (s *Server) handleAttention(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	store := attention.NewStore(database.DB)
 
@@ -335,7 +449,7 @@ func (s *Server) handleAttention(w http.ResponseWriter, r *http.Request) {
 		if limit <= 0 {
 			limit = 10
 		}
-		events, err := store.GetCompactHistory(sessionID, limit)
+		events, err := compact.NewService(database).History(sessionID, limit)
 		if err != nil {
 			s.errorResponse(w, 500, err.Error())
 			return
@@ -380,15 +494,31 @@ func (s *Server) handleAttention(w http.ResponseWriter, r *http.Request) {
 // Handoff Handlers
 // ========================================
 
+// handoffStore creates a handoff store with the project's token budgets applied
+func (s *Server) handoffStore(database *db.DB, root string) *handoff.Store {
+	store := handoff.NewStore(database)
+	if root != "" {
+		if projectCfg, err := config.LoadProjectConfig(root); err == nil {
+			store.SetBudgets(projectCfg.Settings.HandoffBudgets)
+		}
+	}
+	return store
+}
+
 func (s *Server) handleHandoffs(w http.ResponseWriter, r *http.Request) {
 	database, err := s.getDB()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	store := handoff.NewStore(database)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	store := s.handoffStore(database, root)
 
 	switch r.Method {
 	case "GET":
@@ -416,7 +546,7 @@ func (s *Server) handleHandoffs(w http.ResponseWriter, r *http.Request) {
 			s.errorResponse(w, 500, err.Error())
 			return
 		}
-		s.jsonResponse(w, handoffs)
+		writeList(s, w, r, handoffs, 0)
 
 	case "POST":
 		var req struct {
@@ -432,6 +562,16 @@ func (s *Server) handleHandoffs(w http.ResponseWriter, r *http.Request) {
 
 		ho, err := store.Create(req.FromPortID, req.ToPortID, handoff.HandoffType(req.Type), req.Content)
 		if err != nil {
+			var budgetErr *handoff.BudgetError
+			if errors.As(err, &budgetErr) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(400)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"error":       err.Error(),
+					"over_budget": budgetErr,
+				})
+				return
+			}
 			s.errorResponse(w, 500, err.Error())
 			return
 		}
@@ -454,13 +594,19 @@ func (s *Server) handleHandoffDetail(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	store := handoff.NewStore(database)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	store := s.handoffStore(database, root)
 
 	// Check for estimate endpoint
 	if id == "estimate" {
 		var req struct {
+			Type    string      `json:"type"`
 			Content interface{} `json:"content"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -474,11 +620,22 @@ func (s *Server) handleHandoffDetail(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		budget := handoff.MaxTokenBudget
+		if req.Type != "" {
+			budget = store.BudgetFor(handoff.HandoffType(req.Type))
+		}
+		var suggestions []string
+		if tokens > budget {
+			suggestions = handoff.TrimSuggestions(req.Content, tokens-budget)
+		}
+
 		s.jsonResponse(w, map[string]interface{}{
-			"tokens":  tokens,
-			"budget":  handoff.MaxTokenBudget,
-			"percent": float64(tokens) / float64(handoff.MaxTokenBudget) * 100,
-			"valid":   tokens <= handoff.MaxTokenBudget,
+			"type":        req.Type,
+			"tokens":      tokens,
+			"budget":      budget,
+			"percent":     float64(tokens) / float64(budget) * 100,
+			"valid":       tokens <= budget,
+			"suggestions": suggestions,
 		})
 		return
 	}
@@ -501,7 +658,6 @@ func (s *Server) handleAgentsV2(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	store := agentv2.NewStore(database.DB)
 
@@ -523,7 +679,7 @@ func (s *Server) handleAgentsV2(w http.ResponseWriter, r *http.Request) {
 			agents = append(agents, systemAgents...)
 		}
 
-		s.jsonResponse(w, agents)
+		writeList(s, w, r, agents, 0)
 
 	case "POST":
 		var req struct {
@@ -645,7 +801,6 @@ func (s *Server) handleAgentV2Detail(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	store := agentv2.NewStore(database.DB)
 
@@ -757,7 +912,6 @@ func (s *Server) handleMessages(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	store := message.NewStore(database.DB)
 
@@ -829,7 +983,6 @@ func (s *Server) handleMessageDetail(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	store := message.NewStore(database.DB)
 
@@ -861,6 +1014,78 @@ func (s *Server) handleMessageDetail(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ========================================
+// Execution Handlers
+// ========================================
+
+func (s *Server) handleExecutions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	executions, err := orchestrator.NewService(database, nil, nil).ListExecutions(r.URL.Query().Get("status"), maxPageScan)
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+	writeList(s, w, r, executions, 20)
+}
+
+func (s *Server) handleExecutionMigrate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
+	var req struct {
+		PipelineID string `json:"pipeline_id"` // 비우면 이관되지 않은 모든 파이프라인
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.errorResponse(w, 400, "Invalid request body")
+			return
+		}
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	orchSvc := orchestrator.NewService(database, nil, nil)
+	if req.PipelineID != "" {
+		migration, err := orchSvc.MigratePipeline(req.PipelineID)
+		if err != nil {
+			status := 500
+			if errcode.KindOf(err) == errcode.KindNotFound {
+				status = 404
+			}
+			s.errorResponse(w, status, err.Error())
+			return
+		}
+		s.jsonResponse(w, []*orchestrator.PipelineMigration{migration})
+		return
+	}
+
+	migrations, err := orchSvc.MigrateAllPipelines()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+	if migrations == nil {
+		migrations = []*orchestrator.PipelineMigration{}
+	}
+	s.jsonResponse(w, migrations)
+}
+
 // ========================================
 // Worker Session Handlers
 // ========================================
@@ -871,7 +1096,6 @@ func (s *Server) handleWorkerSessions(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	sessionSvc := session.NewService(database)
 	msgStore := message.NewStore(database.DB)
@@ -889,7 +1113,7 @@ func (s *Server) handleWorkerSessions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.jsonResponse(w, workers)
+	writeList(s, w, r, workers, 0)
 }
 
 func (s *Server) handleWorkerSessionDetail(w http.ResponseWriter, r *http.Request) {
@@ -904,7 +1128,6 @@ func (s *Server) handleWorkerSessionDetail(w http.ResponseWriter, r *http.Reques
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	sessionSvc := session.NewService(database)
 	msgStore := message.NewStore(database.DB)
@@ -929,7 +1152,6 @@ func (s *Server) handleV2Status(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	status := map[string]interface{}{}
 
@@ -986,9 +1208,14 @@ func (s *Server) handleDocumentsV2(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	docSvc := document.NewService(database, s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	docSvc := document.NewService(database, root)
 
 	switch r.Method {
 	case "POST":
@@ -1018,21 +1245,13 @@ func (s *Server) handleDocumentsV2(w http.ResponseWriter, r *http.Request) {
 		domain := r.URL.Query().Get("domain")
 		status := r.URL.Query().Get("status")
 		tag := r.URL.Query().Get("tag")
-		limitStr := r.URL.Query().Get("limit")
-
-		limit := 100
-		if limitStr != "" {
-			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-				limit = l
-			}
-		}
 
 		filters := document.SearchFilters{
 			Type:   docType,
 			Domain: domain,
 			Status: status,
 			Tag:    tag,
-			Limit:  limit,
+			Limit:  maxPageScan,
 		}
 
 		docs, err := docSvc.Search(query, filters)
@@ -1061,7 +1280,7 @@ func (s *Server) handleDocumentsV2(w http.ResponseWriter, r *http.Request) {
 			result = append(result, item)
 		}
 
-		s.jsonResponse(w, result)
+		writeList(s, w, r, result, 100)
 	}
 }
 
@@ -1071,9 +1290,14 @@ func (s *Server) handleDocumentStats(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	docSvc := document.NewService(database, s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	docSvc := document.NewService(database, root)
 	stats, err := docSvc.GetStats()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
@@ -1094,9 +1318,14 @@ func (s *Server) handleDocumentIndex(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	docSvc := document.NewService(database, s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	docSvc := document.NewService(database, root)
 	result, err := docSvc.Index()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
@@ -1123,6 +1352,10 @@ func (s *Server) handleDocumentDetail(w http.ResponseWriter, r *http.Request) {
 		s.handleDocumentTree(w, r)
 		return
 	}
+	if id == "types" {
+		s.handleDocumentTypes(w, r)
+		return
+	}
 
 	// Check for content sub-resource
 	if strings.HasSuffix(id, "/content") {
@@ -1143,9 +1376,14 @@ func (s *Server) handleDocumentDetail(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	docSvc := document.NewService(database, s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	docSvc := document.NewService(database, root)
 
 	switch r.Method {
 	case "PUT":
@@ -1164,12 +1402,13 @@ func (s *Server) handleDocumentDetail(w http.ResponseWriter, r *http.Request) {
 		s.jsonResponse(w, map[string]string{"status": "updated", "id": id})
 
 	case "DELETE":
-		// Delete document
-		if err := docSvc.DeleteDocument(id); err != nil {
+		// Soft-delete: 휴지통으로 이동
+		item, err := docSvc.TrashDocument(id)
+		if err != nil {
 			s.errorResponse(w, 500, err.Error())
 			return
 		}
-		s.jsonResponse(w, map[string]string{"status": "deleted", "id": id})
+		s.jsonResponse(w, map[string]string{"status": "trashed", "id": id, "trash_id": item.ID})
 
 	default:
 		// GET: document detail
@@ -1211,19 +1450,44 @@ func (s *Server) handleDocumentContent(w http.ResponseWriter, r *http.Request, i
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	docSvc := document.NewService(database, s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	render, err := wantsHTML(r)
+	if err != nil {
+		s.errorResponse(w, 400, err.Error())
+		return
+	}
+
+	docSvc := document.NewService(database, root)
+	doc, err := docSvc.Get(id)
+	if err != nil {
+		s.errorResponse(w, 404, err.Error())
+		return
+	}
 	content, err := docSvc.GetContent(id)
 	if err != nil {
 		s.errorResponse(w, 404, err.Error())
 		return
 	}
 
-	s.jsonResponse(w, map[string]string{
+	resp := map[string]string{
 		"id":      id,
 		"content": content,
-	})
+	}
+	if render {
+		rendered, err := renderDocumentHTML(docSvc, doc.Path, content)
+		if err != nil {
+			s.errorResponse(w, 500, err.Error())
+			return
+		}
+		resp["html"] = rendered
+	}
+	s.jsonResponse(w, resp)
 }
 
 func (s *Server) handleDocumentMove(w http.ResponseWriter, r *http.Request, id string) {
@@ -1249,9 +1513,14 @@ func (s *Server) handleDocumentMove(w http.ResponseWriter, r *http.Request, id s
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	docSvc := document.NewService(database, s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	docSvc := document.NewService(database, root)
 	if err := docSvc.MoveDocument(id, body.NewPath); err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
@@ -1266,9 +1535,26 @@ type DocumentTreeNode struct {
 	Path     string              `json:"path"`
 	Type     string              `json:"type"` // "file" or "directory"
 	DocType  string              `json:"doc_type,omitempty"`
+	TypeName string              `json:"doc_type_name,omitempty"` // 문서 타입 레지스트리의 표시 이름
 	Children []*DocumentTreeNode `json:"children,omitempty"`
 }
 
+// handleDocumentTypes returns the document type registry (builtin + .pal/doc-types.yaml)
+func (s *Server) handleDocumentTypes(w http.ResponseWriter, r *http.Request) {
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	types, err := document.LoadTypeRegistry(root)
+	if err != nil {
+		s.errorResponse(w, 400, err.Error())
+		return
+	}
+	s.jsonResponse(w, types.Types())
+}
+
 func (s *Server) handleDocumentTree(w http.ResponseWriter, r *http.Request) {
 	depthStr := r.URL.Query().Get("depth")
 	maxDepth := 4
@@ -1278,30 +1564,33 @@ func (s *Server) handleDocumentTree(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Only scan managed directories
-	managedDirs := []struct {
-		Dir  string
-		Name string
-	}{
-		{"ports", "ports"},
-		{"conventions", "conventions"},
-		{"agents", "agents"},
-		{"docs", "docs"},
-		{filepath.Join(".pal", "sessions"), "sessions"},
-		{filepath.Join(".pal", "decisions"), "decisions"},
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	// Only scan directories of registered document types, in type order
+	types, err := document.LoadTypeRegistry(root)
+	if err != nil {
+		s.errorResponse(w, 400, err.Error())
+		return
 	}
 
 	rootNode := &DocumentTreeNode{
-		Name:     filepath.Base(s.config.ProjectRoot),
+		Name:     filepath.Base(root),
 		Path:     ".",
 		Type:     "directory",
 		Children: make([]*DocumentTreeNode, 0),
 	}
 
-	for _, md := range managedDirs {
-		absPath := filepath.Join(s.config.ProjectRoot, md.Dir)
+	for _, dir := range types.ScanRoots() {
+		if dir == "." {
+			continue
+		}
+		absPath := filepath.Join(root, dir)
 		if info, err := os.Stat(absPath); err == nil && info.IsDir() {
-			child := s.buildDocumentTree(absPath, md.Dir, 0, maxDepth)
+			child := s.buildDocumentTree(types, absPath, dir, 0, maxDepth)
 			if child != nil {
 				rootNode.Children = append(rootNode.Children, child)
 			}
@@ -1311,7 +1600,7 @@ func (s *Server) handleDocumentTree(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, rootNode)
 }
 
-func (s *Server) buildDocumentTree(absPath, relPath string, depth, maxDepth int) *DocumentTreeNode {
+func (s *Server) buildDocumentTree(types *document.TypeRegistry, absPath, relPath string, depth, maxDepth int) *DocumentTreeNode {
 	info, err := os.Stat(absPath)
 	if err != nil {
 		return nil
@@ -1345,7 +1634,7 @@ func (s *Server) buildDocumentTree(absPath, relPath string, depth, maxDepth int)
 						}
 					}
 
-					child := s.buildDocumentTree(childPath, childRelPath, depth+1, maxDepth)
+					child := s.buildDocumentTree(types, childPath, childRelPath, depth+1, maxDepth)
 					if child != nil {
 						node.Children = append(node.Children, child)
 					}
@@ -1355,7 +1644,12 @@ func (s *Server) buildDocumentTree(absPath, relPath string, depth, maxDepth int)
 	} else {
 		node.Type = "file"
 
-		// Determine doc type based on extension and path
+		// Registered types first, then fall back to extension and path
+		if t, ok := types.Match(relPath); ok {
+			node.DocType = t.ID
+			node.TypeName = t.Name
+			return node
+		}
 		ext := strings.ToLower(filepath.Ext(absPath))
 		switch ext {
 		case ".md":
@@ -1441,7 +1735,12 @@ func (s *Server) handleGlobalAgents(w http.ResponseWriter, r *http.Request) {
 			}
 
 			if req.ProjectRoot == "" {
-				req.ProjectRoot = s.config.ProjectRoot
+				root, err := s.projectRoot(r)
+				if err != nil {
+					s.writeError(w, err)
+					return
+				}
+				req.ProjectRoot = root
 			}
 
 			count, err := store.SyncToProject(req.ProjectRoot, req.ForceOverwrite)
@@ -1638,3 +1937,78 @@ func (s *Server) handleSystemAgentDetail(w http.ResponseWriter, r *http.Request,
 		})
 	}
 }
+
+// ========================================
+// Trash Handlers
+// ========================================
+
+func (s *Server) handleTrash(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	items, err := trash.NewService(database).List(trash.Kind(r.URL.Query().Get("kind")))
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+	writeList(s, w, r, items, 0)
+}
+
+func (s *Server) handleTrashDetail(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v2/trash/"), "/")
+	id := parts[0]
+	if id == "" {
+		s.errorResponse(w, 400, "Trash item ID required")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	trashSvc := trash.NewService(database)
+
+	switch {
+	case len(parts) > 1 && parts[1] == "restore" && r.Method == "POST":
+		item, err := trashSvc.Restore(id)
+		if err != nil {
+			s.errorResponse(w, 400, err.Error())
+			return
+		}
+		switch item.Kind {
+		case trash.KindDocument:
+			document.NewService(database, item.Root).RefreshDocument(item.Path)
+		case trash.KindKB:
+			kb.RefreshVaultDocument(item.Root, item.Path)
+		}
+		s.jsonResponse(w, map[string]string{"status": "restored", "id": id, "path": item.Path})
+
+	case len(parts) == 1 && r.Method == "DELETE":
+		if err := trashSvc.Purge(id); err != nil {
+			s.errorResponse(w, 404, err.Error())
+			return
+		}
+		s.jsonResponse(w, map[string]string{"status": "purged", "id": id})
+
+	case len(parts) == 1 && r.Method == "GET":
+		item, err := trashSvc.Get(id)
+		if err != nil {
+			s.errorResponse(w, 404, err.Error())
+			return
+		}
+		s.jsonResponse(w, item)
+
+	default:
+		s.errorResponse(w, 405, "Method not allowed")
+	}
+}
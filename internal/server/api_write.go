@@ -0,0 +1,595 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/lock"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/server/events"
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+// Write-path request bodies (웹 UI/원격 도구가 CLI 없이 워크플로우를 진행하기 위한 CRUD)
+type (
+	portCreateRequest struct {
+		ID       string `json:"id"`
+		Title    string `json:"title,omitempty"`
+		FilePath string `json:"file_path,omitempty"`
+	}
+	portUpdateRequest struct {
+		Title     *string `json:"title,omitempty"`
+		Status    *string `json:"status,omitempty"` // running/complete는 pal hook port-start/port-end로만 (409)
+		SessionID *string `json:"session_id,omitempty"`
+	}
+	sessionCreateRequest struct {
+		ID            string `json:"id,omitempty"` // 비우면 생성
+		Title         string `json:"title,omitempty"`
+		PortID        string `json:"port_id,omitempty"`
+		Type          string `json:"type,omitempty"`
+		ParentSession string `json:"parent_session,omitempty"`
+	}
+	sessionUpdateRequest struct {
+		Title  *string `json:"title,omitempty"`
+		Status *string `json:"status,omitempty"` // running, paused, complete(종료)
+		Reason string  `json:"reason,omitempty"` // 종료 사유
+	}
+	lockAcquireRequest struct {
+		Resource  string `json:"resource"`
+		SessionID string `json:"session_id"`
+	}
+	escalationCreateRequest struct {
+		Issue     string `json:"issue"`
+		SessionID string `json:"session_id,omitempty"`
+		PortID    string `json:"port_id,omitempty"`
+	}
+	escalationUpdateRequest struct {
+		Status   string `json:"status,omitempty"`   // resolved, dismissed
+		Severity string `json:"severity,omitempty"` // low, medium, high, critical
+		Summary  string `json:"summary,omitempty"`  // 해결 요약 (dismissed면 사유)
+		Actor    string `json:"actor,omitempty"`
+	}
+	escalationAssignRequest struct {
+		Assignee string `json:"assignee"` // 빈 값이면 담당 해제
+		Actor    string `json:"actor,omitempty"`
+	}
+	escalationCommentRequest struct {
+		Body     string `json:"body"`
+		Actor    string `json:"actor,omitempty"`
+		ParentID int64  `json:"parent_id,omitempty"` // 답글 대상 댓글 ID
+	}
+)
+
+// errorStatus maps an error category to its HTTP status
+func errorStatus(err error) int {
+	switch errcode.KindOf(err) {
+	case errcode.KindNotFound:
+		return http.StatusNotFound
+	case errcode.KindValidation:
+		return http.StatusBadRequest
+	case errcode.KindConflict:
+		return http.StatusConflict
+	case errcode.KindDBBusy:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// writeError responds with the status of the error's category
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	s.errorResponse(w, errorStatus(err), err.Error())
+}
+
+// createdResponse writes a 201 JSON response
+func (s *Server) createdResponse(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(data)
+}
+
+// decodeBody decodes a JSON request body, responding 400 on failure
+func (s *Server) decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		s.errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return false
+	}
+	return true
+}
+
+// pathID returns the path segment after prefix (예: /api/ports/{id})
+func pathID(r *http.Request, prefix string) string {
+	return strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+}
+
+// ========================================
+// Ports
+// ========================================
+
+func (s *Server) createPort(w http.ResponseWriter, r *http.Request) {
+	var req portCreateRequest
+	if !s.decodeBody(w, r, &req) {
+		return
+	}
+	req.ID = strings.TrimSpace(req.ID)
+	if req.ID == "" || strings.ContainsAny(req.ID, "/ \t") {
+		s.errorResponse(w, http.StatusBadRequest, "id is required and must not contain '/' or spaces")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	svc := port.NewService(database)
+	if _, err := svc.Get(req.ID); err == nil {
+		s.errorResponse(w, http.StatusConflict, "port already exists: "+req.ID)
+		return
+	}
+	if err := svc.Create(req.ID, req.Title, req.FilePath); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	p, err := svc.Get(req.ID)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.createdResponse(w, toPortDTO(*p))
+}
+
+// handlePortDetail handles GET/PATCH/DELETE /api/ports/{id}
+func (s *Server) handlePortDetail(w http.ResponseWriter, r *http.Request) {
+	id := pathID(r, "/api/ports/")
+	if id == "" || strings.Contains(id, "/") {
+		s.errorResponse(w, 400, "port ID required")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	svc := port.NewService(database)
+
+	switch r.Method {
+	case "GET":
+	case "PATCH":
+		var req portUpdateRequest
+		if !s.decodeBody(w, r, &req) {
+			return
+		}
+		if req.Title == nil && req.Status == nil && req.SessionID == nil {
+			s.errorResponse(w, 400, "nothing to update: title, status or session_id required")
+			return
+		}
+		before, err := svc.Get(id)
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		// 시작/완료는 Lock, DoD 검사, 요약, 추정치 비교, 완료 기록을 함께 처리하는 hook 경로로만 전이
+		if req.Status != nil && *req.Status != before.Status &&
+			(*req.Status == port.StatusRunning || *req.Status == port.StatusComplete) {
+			s.writeError(w, errcode.New(errcode.KindConflict,
+				"포트를 %s 상태로 바꾸려면 'pal hook port-%s %s'를 사용하세요", *req.Status, portHookAction(*req.Status), id))
+			return
+		}
+		if req.Title != nil {
+			if err := svc.UpdateTitle(id, strings.TrimSpace(*req.Title)); err != nil {
+				s.writeError(w, err)
+				return
+			}
+		}
+		if req.SessionID != nil {
+			if err := svc.AssignSession(id, *req.SessionID); err != nil {
+				s.writeError(w, err)
+				return
+			}
+		}
+		if req.Status != nil && *req.Status != before.Status {
+			if err := svc.UpdateStatus(id, *req.Status); err != nil {
+				s.writeError(w, err)
+				return
+			}
+			publishPortStatus(before, *req.Status)
+		}
+	case "DELETE":
+		if err := svc.Delete(id); err != nil {
+			s.writeError(w, err)
+			return
+		}
+		s.jsonResponse(w, map[string]string{"status": "deleted", "id": id})
+		return
+	default:
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
+	p, err := svc.Get(id)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.jsonResponse(w, toPortDTO(*p))
+}
+
+// portHookAction returns the hook that performs a port transition (running → start, complete → end)
+func portHookAction(status string) string {
+	if status == port.StatusRunning {
+		return "start"
+	}
+	return "end"
+}
+
+// publishPortStatus publishes the SSE event of a port status change
+func publishPortStatus(before *port.Port, status string) {
+	sessionID := ""
+	if before.SessionID.Valid {
+		sessionID = before.SessionID.String
+	}
+	publisher := events.GetPublisher()
+	switch status {
+	case port.StatusRunning:
+		title := before.ID
+		if before.Title.Valid {
+			title = before.Title.String
+		}
+		publisher.PublishPortStart(sessionID, before.ID, title, nil)
+	case port.StatusComplete, port.StatusFailed:
+		publisher.PublishPortEnd(sessionID, before.ID, status, before.DurationSecs)
+	case port.StatusBlocked:
+		publisher.PublishPortBlocked(sessionID, before.ID, "")
+	}
+}
+
+// ========================================
+// Sessions
+// ========================================
+
+func (s *Server) createSession(w http.ResponseWriter, r *http.Request) {
+	var req sessionCreateRequest
+	if !s.decodeBody(w, r, &req) {
+		return
+	}
+	if req.ID == "" {
+		req.ID = uuid.New().String()[:8]
+	}
+	if req.Type == "" {
+		req.Type = session.TypeSingle
+	}
+	switch req.Type {
+	case session.TypeSingle, session.TypeMain, session.TypeSub, session.TypeMulti, session.TypeBuilder:
+	default:
+		s.errorResponse(w, 400, "invalid session type: "+req.Type)
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	svc := session.NewService(database)
+	if _, err := svc.Get(req.ID); err == nil {
+		s.errorResponse(w, http.StatusConflict, "session already exists: "+req.ID)
+		return
+	}
+	if req.ParentSession != "" {
+		if _, err := svc.Get(req.ParentSession); err != nil {
+			s.errorResponse(w, 400, "parent session not found: "+req.ParentSession)
+			return
+		}
+	}
+	if err := svc.StartWithFullOptions(session.StartOptions{
+		ID:            req.ID,
+		PortID:        req.PortID,
+		Title:         req.Title,
+		SessionType:   req.Type,
+		ParentSession: req.ParentSession,
+		ProjectRoot:   root,
+	}); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	events.GetPublisher().PublishSessionStart(req.ID, req.Title, req.Type, root)
+
+	sess, err := svc.Get(req.ID)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.createdResponse(w, toSessionDTO(*sess))
+}
+
+// updateSession handles PATCH/DELETE /api/sessions/{id}. DELETE는 세션을 종료합니다(기록은 남김).
+func (s *Server) updateSession(w http.ResponseWriter, r *http.Request, id string) {
+	req := sessionUpdateRequest{Reason: r.URL.Query().Get("reason")}
+	if r.Method == "DELETE" {
+		complete := "complete"
+		req.Status = &complete
+	} else if !s.decodeBody(w, r, &req) {
+		return
+	}
+	if req.Title == nil && req.Status == nil {
+		s.errorResponse(w, 400, "nothing to update: title or status required")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	svc := session.NewService(database)
+	sess, err := svc.Get(id)
+	if err != nil {
+		s.errorResponse(w, 404, err.Error())
+		return
+	}
+
+	if req.Title != nil {
+		if err := svc.UpdateTitle(id, strings.TrimSpace(*req.Title)); err != nil {
+			s.writeError(w, err)
+			return
+		}
+	}
+	if req.Status != nil && *req.Status != sess.Status {
+		switch *req.Status {
+		case "complete":
+			if sess.Status != "running" && sess.Status != session.StatusPaused {
+				s.errorResponse(w, http.StatusConflict, "session already ended: "+sess.Status)
+				return
+			}
+			if err := svc.EndWithReason(id, req.Reason); err != nil {
+				s.writeError(w, err)
+				return
+			}
+			events.GetPublisher().PublishSessionEnd(id, req.Reason, "complete")
+		case "running", session.StatusPaused:
+			if sess.Status != "running" && sess.Status != session.StatusPaused {
+				s.errorResponse(w, http.StatusConflict, "ended session cannot be reopened")
+				return
+			}
+			if err := svc.UpdateStatus(id, *req.Status); err != nil {
+				s.writeError(w, err)
+				return
+			}
+			events.GetPublisher().PublishSessionUpdate(id, map[string]string{"status": *req.Status})
+		default:
+			s.errorResponse(w, 400, "invalid status: "+*req.Status+" (running, paused, complete)")
+			return
+		}
+	}
+
+	updated, err := svc.Get(id)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.jsonResponse(w, toSessionDTO(*updated))
+}
+
+// ========================================
+// Locks
+// ========================================
+
+func (s *Server) acquireLock(w http.ResponseWriter, r *http.Request) {
+	var req lockAcquireRequest
+	if !s.decodeBody(w, r, &req) {
+		return
+	}
+	req.Resource = strings.TrimSpace(req.Resource)
+	if req.Resource == "" || req.SessionID == "" {
+		s.errorResponse(w, 400, "resource and session_id are required")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	if _, err := session.NewService(database).Get(req.SessionID); err != nil {
+		s.errorResponse(w, 400, "session not found: "+req.SessionID)
+		return
+	}
+	svc := lock.NewService(database)
+	if err := svc.Acquire(req.Resource, req.SessionID); err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	locks, _ := svc.List()
+	for _, l := range locks {
+		if l.Resource == req.Resource {
+			s.createdResponse(w, toLockDTO(l))
+			return
+		}
+	}
+	s.createdResponse(w, LockDTO{Resource: req.Resource, SessionID: req.SessionID})
+}
+
+// handleLockDetail handles DELETE /api/locks/{resource} (리소스 이름에 '/'가 있어도 됨)
+func (s *Server) handleLockDetail(w http.ResponseWriter, r *http.Request) {
+	resource := strings.TrimPrefix(r.URL.Path, "/api/locks/")
+	if resource == "" {
+		s.errorResponse(w, 400, "resource required")
+		return
+	}
+	if r.Method != "DELETE" {
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	if err := lock.NewService(database).Release(resource); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.jsonResponse(w, map[string]string{"status": "released", "resource": resource})
+}
+
+// ========================================
+// Escalations
+// ========================================
+
+func (s *Server) createEscalation(w http.ResponseWriter, r *http.Request) {
+	var req escalationCreateRequest
+	if !s.decodeBody(w, r, &req) {
+		return
+	}
+	req.Issue = strings.TrimSpace(req.Issue)
+	if req.Issue == "" {
+		s.errorResponse(w, 400, "issue is required")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	if req.PortID != "" {
+		if _, err := port.NewService(database).Get(req.PortID); err != nil {
+			s.errorResponse(w, 400, "port not found: "+req.PortID)
+			return
+		}
+	}
+
+	svc := escalation.NewService(database)
+	id, err := svc.Create(req.Issue, req.SessionID, req.PortID)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	events.GetPublisher().PublishEscalationCreated(req.SessionID, req.PortID, strconv.FormatInt(id, 10), "", req.Issue, "")
+
+	e, err := svc.Get(id)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.createdResponse(w, toEscalationDTO(*e))
+}
+
+// handleEscalationDetail handles GET/PATCH/DELETE /api/escalations/{id}. DELETE는 dismiss입니다.
+// 하위 경로(assign, comments, activity)는 handleEscalationAction이 처리합니다.
+func (s *Server) handleEscalationDetail(w http.ResponseWriter, r *http.Request) {
+	idPart, action, _ := strings.Cut(pathID(r, "/api/escalations/"), "/")
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil {
+		s.errorResponse(w, 400, "invalid escalation ID")
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	svc := escalation.NewService(database)
+	e, err := svc.Get(id)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	if action != "" {
+		s.handleEscalationAction(w, r, svc, e, action)
+		return
+	}
+
+	var req escalationUpdateRequest
+	switch r.Method {
+	case "GET":
+		s.jsonResponse(w, toEscalationDTO(*e))
+		return
+	case "PATCH":
+		if !s.decodeBody(w, r, &req) {
+			return
+		}
+	case "DELETE":
+		req.Status = "dismissed"
+	default:
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
+	if req.Status == "" && req.Severity == "" {
+		s.errorResponse(w, 400, "status or severity is required")
+		return
+	}
+	if req.Status != "" && req.Status != "resolved" && req.Status != "dismissed" {
+		s.errorResponse(w, 400, "invalid status: "+req.Status+" (resolved, dismissed)")
+		return
+	}
+	if e.Status != "open" {
+		s.errorResponse(w, http.StatusConflict, "escalation already "+e.Status)
+		return
+	}
+
+	if req.Severity != "" {
+		severity, err := escalation.ParseSeverity(req.Severity)
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		activity, err := svc.SetSeverity(id, severity, req.Actor)
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		s.publishEscalationActivity(e, false, activity)
+	}
+
+	switch req.Status {
+	case "resolved":
+		err = svc.ResolveWithSteps(id, nil, escalation.ResolutionRecord{Summary: req.Summary, By: req.Actor})
+	case "dismissed":
+		err = svc.DismissBy(id, req.Actor, req.Summary)
+	}
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	if req.Status != "" {
+		s.publishEscalationActivity(e, true, map[string]interface{}{
+			"escalation_id": id,
+			"status":        req.Status,
+			"summary":       req.Summary,
+			"actor":         req.Actor,
+		})
+	}
+
+	e, err = svc.Get(id)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.jsonResponse(w, toEscalationDTO(*e))
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newWriteTestServer(t *testing.T) http.Handler {
+	t.Helper()
+
+	s := NewServer(Config{DBPath: filepath.Join(t.TempDir(), "test.db"), ProjectRoot: t.TempDir()})
+	t.Cleanup(func() { s.Close() })
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/sessions", s.withCORS(s.handleSessions))
+	mux.HandleFunc("/api/sessions/", s.withCORS(s.handleSessionDetail))
+	mux.HandleFunc("/api/ports", s.withCORS(s.handlePorts))
+	mux.HandleFunc("/api/ports/", s.withCORS(s.handlePortDetail))
+	mux.HandleFunc("/api/locks", s.withCORS(s.handleLocks))
+	mux.HandleFunc("/api/locks/", s.withCORS(s.handleLockDetail))
+	mux.HandleFunc("/api/escalations", s.withCORS(s.handleEscalations))
+	mux.HandleFunc("/api/escalations/", s.withCORS(s.handleEscalationDetail))
+	return mux
+}
+
+func doJSON(t *testing.T, h http.Handler, method, path, body string, wantStatus int) map[string]interface{} {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != wantStatus {
+		t.Fatalf("%s %s = %d, want %d: %s", method, path, rec.Code, wantStatus, rec.Body.String())
+	}
+	var out map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &out)
+	return out
+}
+
+func TestWritePathPortsAndSessions(t *testing.T) {
+	h := newWriteTestServer(t)
+
+	doJSON(t, h, "POST", "/api/ports", `{"title":"no id"}`, 400)
+	p := doJSON(t, h, "POST", "/api/ports", `{"id":"p-web","title":"웹에서 생성"}`, 201)
+	if p["id"] != "p-web" || p["status"] != "pending" {
+		t.Errorf("생성된 포트 = %v", p)
+	}
+	doJSON(t, h, "POST", "/api/ports", `{"id":"p-web"}`, 409)
+
+	p = doJSON(t, h, "PATCH", "/api/ports/p-web", `{"status":"blocked","title":"이름 변경"}`, 200)
+	if p["status"] != "blocked" || p["title"] != "이름 변경" {
+		t.Errorf("수정된 포트 = %v", p)
+	}
+	// 시작/완료는 hook 경로로만 (DoD 검사, Lock 해제, 완료 기록을 건너뛰지 않도록)
+	doJSON(t, h, "PATCH", "/api/ports/p-web", `{"status":"running","title":"무시됨"}`, 409)
+	p = doJSON(t, h, "PATCH", "/api/ports/p-web", `{"status":"complete"}`, 409)
+	if !strings.Contains(p["error"].(string), "port-end") {
+		t.Errorf("완료 거부 응답 = %v", p)
+	}
+	if p = doJSON(t, h, "GET", "/api/ports/p-web", "", 200); p["status"] != "blocked" || p["title"] != "이름 변경" {
+		t.Errorf("거부 후 포트 = %v", p)
+	}
+	doJSON(t, h, "PATCH", "/api/ports/p-web", `{"status":"bogus"}`, 400)
+	doJSON(t, h, "PATCH", "/api/ports/missing", `{"title":"x"}`, 404)
+
+	sess := doJSON(t, h, "POST", "/api/sessions", `{"id":"s-web","title":"웹 세션"}`, 201)
+	if sess["id"] != "s-web" || sess["status"] != "running" {
+		t.Errorf("생성된 세션 = %v", sess)
+	}
+	doJSON(t, h, "POST", "/api/sessions", `{"type":"weird"}`, 400)
+	doJSON(t, h, "PATCH", "/api/sessions/s-web", `{"status":"paused"}`, 200)
+	sess = doJSON(t, h, "DELETE", "/api/sessions/s-web?reason=done", "", 200)
+	if sess["status"] != "complete" {
+		t.Errorf("종료된 세션 = %v", sess)
+	}
+	doJSON(t, h, "DELETE", "/api/sessions/s-web", "", 200) // 이미 종료된 세션은 그대로
+	doJSON(t, h, "PATCH", "/api/sessions/s-web", `{"status":"running"}`, 409)
+
+	doJSON(t, h, "DELETE", "/api/ports/p-web", "", 200)
+	doJSON(t, h, "GET", "/api/ports/p-web", "", 404)
+}
+
+func TestWritePathLocksAndEscalations(t *testing.T) {
+	h := newWriteTestServer(t)
+
+	doJSON(t, h, "POST", "/api/sessions", `{"id":"s-lock"}`, 201)
+	doJSON(t, h, "POST", "/api/locks", `{"resource":"src/api"}`, 400)
+	l := doJSON(t, h, "POST", "/api/locks", `{"resource":"src/api","session_id":"s-lock"}`, 201)
+	if l["resource"] != "src/api" {
+		t.Errorf("획득한 Lock = %v", l)
+	}
+	doJSON(t, h, "POST", "/api/locks", `{"resource":"src/api","session_id":"s-lock"}`, 409)
+	doJSON(t, h, "DELETE", "/api/locks/src/api", "", 200)
+	doJSON(t, h, "DELETE", "/api/locks/src/api", "", 404)
+
+	doJSON(t, h, "POST", "/api/escalations", `{"issue":" "}`, 400)
+	doJSON(t, h, "POST", "/api/escalations", `{"issue":"x","port_id":"missing"}`, 400)
+	e := doJSON(t, h, "POST", "/api/escalations", `{"issue":"빌드 실패","session_id":"s-lock"}`, 201)
+	id := "/api/escalations/" + jsonNumber(e["id"])
+	doJSON(t, h, "PATCH", id, `{"status":"reopened"}`, 400)
+	e = doJSON(t, h, "PATCH", id, `{"status":"resolved"}`, 200)
+	if e["status"] != "resolved" {
+		t.Errorf("처리된 에스컬레이션 = %v", e)
+	}
+	doJSON(t, h, "DELETE", id, "", 409)
+	doJSON(t, h, "GET", "/api/escalations/999", "", 404)
+}
+
+func TestEscalationWorkflow(t *testing.T) {
+	h := newWriteTestServer(t)
+
+	e := doJSON(t, h, "POST", "/api/escalations", `{"issue":"스키마 승인 필요","session_id":"s-w"}`, 201)
+	id := "/api/escalations/" + jsonNumber(e["id"])
+
+	a := doJSON(t, h, "POST", id+"/assign", `{"assignee":"alice","actor":"op"}`, 200)
+	if a["kind"] != "assign" || a["new_value"] != "alice" {
+		t.Errorf("assign = %v", a)
+	}
+	c := doJSON(t, h, "POST", id+"/comments", `{"body":"검토 중","actor":"op"}`, 201)
+	doJSON(t, h, "POST", id+"/comments", `{"body":"확인","actor":"s-w","parent_id":`+jsonNumber(c["id"])+`}`, 201)
+	doJSON(t, h, "POST", id+"/comments", `{"body":""}`, 400)
+	doJSON(t, h, "GET", id+"/comments", "", 405)
+	doJSON(t, h, "GET", id+"/unknown", "", 404)
+	doJSON(t, h, "PATCH", id, `{"severity":"urgent"}`, 400)
+
+	e = doJSON(t, h, "PATCH", id, `{"severity":"critical","status":"resolved","summary":"승인됨","actor":"op"}`, 200)
+	if e["status"] != "resolved" || e["severity"] != "critical" || e["assignee"] != "alice" || e["resolution"] != "승인됨" {
+		t.Errorf("처리된 에스컬레이션 = %v", e)
+	}
+	doJSON(t, h, "POST", id+"/assign", `{"assignee":"bob"}`, 409)
+
+	page := doJSON(t, h, "GET", id+"/activity?envelope=true", "", 200)
+	if items, _ := page["items"].([]interface{}); len(items) != 5 {
+		t.Errorf("처리 기록 = %v", page["items"])
+	}
+}
+
+func jsonNumber(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func TestCreateOrchestrationRetryPolicy(t *testing.T) {
+	s := NewServer(Config{DBPath: filepath.Join(t.TempDir(), "test.db"), ProjectRoot: t.TempDir()})
+	t.Cleanup(func() { s.Close() })
+	h := http.HandlerFunc(s.withCORS(s.handleOrchestrations))
+
+	// 잘못된 정책은 저장 전에 거부
+	doJSON(t, h, "POST", "/api/v2/orchestrations", `{"title":"bad","idempotency_key":"k1","retry_policy":{"max_retries":-1}}`, http.StatusBadRequest)
+	doJSON(t, h, "POST", "/api/v2/orchestrations", `{"title":"bad","idempotency_key":"k1","retry_policy":{"max_retries":1,"backoff":"soon"}}`, http.StatusBadRequest)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v2/orchestrations", nil))
+	if total := rec.Header().Get(HeaderTotalCount); total != "0" {
+		t.Errorf("invalid policy should not store an orchestration: total=%s", total)
+	}
+
+	body := `{"title":"ok","idempotency_key":"k1","retry_policy":{"max_retries":1,"backoff":"1m"}}`
+	created := doJSON(t, h, "POST", "/api/v2/orchestrations", body, http.StatusOK)
+	if policy, _ := created["retry_policy"].(map[string]interface{}); policy["backoff"] != "1m" {
+		t.Errorf("retry_policy = %v", created["retry_policy"])
+	}
+	doJSON(t, h, "POST", "/api/v2/orchestrations", body, http.StatusOK)
+	doJSON(t, h, "POST", "/api/v2/orchestrations", `{"title":"ok","idempotency_key":"k1","retry_policy":{"max_retries":2}}`, http.StatusConflict)
+}
@@ -62,6 +62,7 @@ type SessionDetailDTO struct {
 	Title         string  `json:"title,omitempty"`
 	Status        string  `json:"status"`
 	SessionType   string  `json:"session_type,omitempty"`
+	ProjectRoot   string  `json:"project_root,omitempty"`
 	Parent        string  `json:"parent,omitempty"`
 	StartedAt     string  `json:"started_at,omitempty"`
 	EndedAt       string  `json:"ended_at,omitempty"`
@@ -100,6 +101,7 @@ func toSessionDetailDTO(d session.SessionDetail) SessionDetailDTO {
 	if d.ParentSession.Valid {
 		dto.Parent = d.ParentSession.String
 	}
+	dto.ProjectRoot = d.ProjectRoot.String
 	dto.StartedAt = d.StartedAt.Format(time.RFC3339)
 	if d.EndedAt.Valid {
 		dto.EndedAt = d.EndedAt.Time.Format(time.RFC3339)
@@ -218,6 +220,7 @@ type PipelineDTO struct {
 	CreatedAt   string `json:"created_at,omitempty"`
 	StartedAt   string `json:"started_at,omitempty"`
 	CompletedAt string `json:"completed_at,omitempty"`
+	MigratedTo  string `json:"migrated_to,omitempty"` // 이관된 Orchestration ID
 }
 
 func toPipelineDTO(p pipeline.Pipeline) PipelineDTO {
@@ -236,6 +239,9 @@ func toPipelineDTO(p pipeline.Pipeline) PipelineDTO {
 	if p.CompletedAt.Valid {
 		dto.CompletedAt = p.CompletedAt.Time.Format(time.RFC3339)
 	}
+	if p.MigratedTo.Valid {
+		dto.MigratedTo = p.MigratedTo.String
+	}
 	return dto
 }
 
@@ -254,6 +260,9 @@ type EscalationDTO struct {
 	FromPort    string `json:"from_port,omitempty"`
 	Issue       string `json:"issue"`
 	Status      string `json:"status"`
+	Severity    string `json:"severity,omitempty"`
+	Assignee    string `json:"assignee,omitempty"`
+	Resolution  string `json:"resolution,omitempty"` // 해결 요약 또는 메모
 	CreatedAt   string `json:"created_at,omitempty"`
 	ResolvedAt  string `json:"resolved_at,omitempty"`
 }
@@ -270,6 +279,14 @@ func toEscalationDTO(e escalation.Escalation) EscalationDTO {
 	if e.FromPort.Valid {
 		dto.FromPort = e.FromPort.String
 	}
+	dto.Severity = e.Severity.String
+	dto.Assignee = e.Assignee.String
+	if record := e.ResolutionRecord(); record != nil {
+		dto.Resolution = record.Summary
+		if dto.Resolution == "" {
+			dto.Resolution = record.Note
+		}
+	}
 	dto.CreatedAt = e.CreatedAt.Format(time.RFC3339)
 	if e.ResolvedAt.Valid {
 		dto.ResolvedAt = e.ResolvedAt.Time.Format(time.RFC3339)
@@ -290,14 +307,19 @@ type LockDTO struct {
 	Resource   string `json:"resource"`
 	SessionID  string `json:"session_id"`
 	AcquiredAt string `json:"acquired_at,omitempty"`
+	ExpiresAt  string `json:"expires_at,omitempty"`
 }
 
 func toLockDTO(l lock.Lock) LockDTO {
-	return LockDTO{
+	dto := LockDTO{
 		Resource:   l.Resource,
 		SessionID:  l.SessionID,
 		AcquiredAt: l.AcquiredAt.Format(time.RFC3339),
 	}
+	if l.ExpiresAt != nil {
+		dto.ExpiresAt = l.ExpiresAt.Format(time.RFC3339)
+	}
+	return dto
 }
 
 func toLockDTOs(locks []lock.Lock) []LockDTO {
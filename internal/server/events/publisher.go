@@ -7,6 +7,9 @@ import (
 // Publisher publishes events to SSE clients
 type Publisher struct {
 	sse *SSEServer
+
+	projectMu sync.RWMutex
+	projectOf func(sessionID string) string // 세션의 프로젝트 루트 (SetProjectResolver)
 }
 
 var (
@@ -35,8 +38,24 @@ func (p *Publisher) GetSSEServer() *SSEServer {
 	return p.sse
 }
 
+// SetProjectResolver sets how the project root of a session is looked up.
+// 프로젝트가 지정되지 않은 세션 이벤트에 프로젝트를 기록해 ?project= 필터가 적용되도록 합니다.
+func (p *Publisher) SetProjectResolver(resolve func(sessionID string) string) {
+	p.projectMu.Lock()
+	p.projectOf = resolve
+	p.projectMu.Unlock()
+}
+
 // Publish publishes an event
 func (p *Publisher) Publish(event *Event) {
+	if event.Project == "" && event.SessionID != "" {
+		p.projectMu.RLock()
+		resolve := p.projectOf
+		p.projectMu.RUnlock()
+		if resolve != nil {
+			event.Project = resolve(event.SessionID)
+		}
+	}
 	if p.sse != nil {
 		p.sse.Broadcast(event)
 	}
@@ -49,7 +68,7 @@ func (p *Publisher) PublishSessionStart(sessionID, title, sessionType, projectRo
 		Title:       title,
 		Type:        sessionType,
 		ProjectRoot: projectRoot,
-	}).WithSession(sessionID)
+	}).WithSession(sessionID).WithProject(projectRoot)
 
 	p.Publish(event)
 }
@@ -150,6 +169,23 @@ func (p *Publisher) PublishPortBlocked(sessionID, portID, reason string) {
 	p.Publish(event)
 }
 
+// PublishOrchestrationEvent publishes an orchestration port/worker event followed by a progress event
+func (p *Publisher) PublishOrchestrationEvent(eventType EventType, data OrchestrationProgressData) {
+	p.Publish(NewEvent(eventType, data).WithPort(data.PortID))
+	if eventType == EventOrchestrationProgress {
+		return
+	}
+
+	progress := data
+	progress.PortID = ""
+	progress.WorkerID = ""
+	progress.Attempt = 0
+	progress.Retrying = false
+	progress.PortStatus = ""
+	progress.Error = ""
+	p.Publish(NewEvent(EventOrchestrationProgress, progress))
+}
+
 // PublishChecklistResult publishes checklist result event
 func (p *Publisher) PublishChecklistResult(sessionID, portID string, passed bool, passedCount, failedCount int, items []CheckItem, blockedBy []string) {
 	eventType := EventChecklistPassed
@@ -180,6 +216,15 @@ func (p *Publisher) PublishEscalationCreated(sessionID, portID, escalationID, es
 	p.Publish(event)
 }
 
+// PublishEscalationActivity publishes an escalation audit trail entry (assign/comment/severity → updated, resolve/dismiss → resolved)
+func (p *Publisher) PublishEscalationActivity(sessionID, portID string, resolved bool, activity interface{}) {
+	eventType := EventEscalationUpdated
+	if resolved {
+		eventType = EventEscalationResolved
+	}
+	p.Publish(NewEvent(eventType, activity).WithSession(sessionID).WithPort(portID))
+}
+
 // PublishBuildFailed publishes build failed event
 func (p *Publisher) PublishBuildFailed(sessionID, portID string, exitCode int, errorMsg string) {
 	event := NewEvent(EventBuildFailed, BuildFailedData{
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -16,15 +19,19 @@ type SSEServer struct {
 	unregister chan string
 	broadcast  chan *Event
 	mu         sync.RWMutex
-	running    bool
+	running    atomic.Bool
+	quit       chan struct{} // Stop 시 닫힘 (run 루프와 연결 핸들러 종료)
+	stopOnce   sync.Once
 }
 
 // SSEClient represents a connected client
 type SSEClient struct {
 	ID        string
 	Events    chan *Event
-	Filters   []EventType  // Event types to receive (empty = all)
-	SessionID string       // Only receive events for this session (empty = all)
+	Filters   []EventType     // Event types to receive (empty = all)
+	Topics    map[string]bool // Event topics to receive (empty = all)
+	SessionID string          // Only receive events for this session (empty = all)
+	Project   string          // Only receive events for this project (empty = all)
 	done      chan struct{}
 }
 
@@ -35,30 +42,38 @@ func NewSSEServer() *SSEServer {
 		register:   make(chan *SSEClient),
 		unregister: make(chan string),
 		broadcast:  make(chan *Event, 100),
+		quit:       make(chan struct{}),
 	}
 	return s
 }
 
 // Start starts the SSE server event loop
 func (s *SSEServer) Start() {
-	s.running = true
+	s.running.Store(true)
 	go s.run()
 }
 
-// Stop stops the SSE server
+// Stop stops the SSE server and ends every open stream (서버 종료 시 드레이닝을 막지 않도록)
 func (s *SSEServer) Stop() {
-	s.running = false
-	// Close all client connections
-	s.mu.Lock()
-	for _, client := range s.clients {
-		close(client.done)
-	}
-	s.mu.Unlock()
+	s.stopOnce.Do(func() {
+		s.running.Store(false)
+		close(s.quit)
+		// Close all client connections
+		s.mu.Lock()
+		for _, client := range s.clients {
+			close(client.done)
+		}
+		s.clients = make(map[string]*SSEClient)
+		s.mu.Unlock()
+	})
 }
 
 func (s *SSEServer) run() {
-	for s.running {
+	for s.running.Load() {
 		select {
+		case <-s.quit:
+			return
+
 		case client := <-s.register:
 			s.mu.Lock()
 			s.clients[client.ID] = client
@@ -95,6 +110,16 @@ func (s *SSEServer) shouldSend(client *SSEClient, event *Event) bool {
 		return false
 	}
 
+	// Project filter (프로젝트 정보가 없는 이벤트는 어느 프로젝트 것인지 알 수 없으므로 제외)
+	if client.Project != "" && (event.Project == "" || !matchProject(client.Project, event.Project)) {
+		return false
+	}
+
+	// Topic filter
+	if len(client.Topics) > 0 && !client.Topics[event.Type.Topic()] {
+		return false
+	}
+
 	// Event type filter
 	if len(client.Filters) > 0 {
 		for _, f := range client.Filters {
@@ -110,7 +135,7 @@ func (s *SSEServer) shouldSend(client *SSEClient, event *Event) bool {
 
 // Broadcast sends an event to all connected clients
 func (s *SSEServer) Broadcast(event *Event) {
-	if !s.running {
+	if !s.running.Load() {
 		return
 	}
 	select {
@@ -159,6 +184,18 @@ func (s *SSEServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	sessionID := r.URL.Query().Get("session_id")
+	project := r.URL.Query().Get("project")
+
+	// Parse topics (?topics=ports,escalations)
+	var topics map[string]bool
+	if topicParam := r.URL.Query().Get("topics"); topicParam != "" {
+		topics = make(map[string]bool)
+		for _, t := range strings.Split(topicParam, ",") {
+			if t = NormalizeTopic(t); t != "" {
+				topics[t] = true
+			}
+		}
+	}
 
 	// Create client
 	clientID := fmt.Sprintf("client-%d", time.Now().UnixNano())
@@ -166,16 +203,26 @@ func (s *SSEServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ID:        clientID,
 		Events:    make(chan *Event, 50),
 		Filters:   filters,
+		Topics:    topics,
 		SessionID: sessionID,
+		Project:   project,
 		done:      make(chan struct{}),
 	}
 
 	// Register client
-	s.register <- client
+	select {
+	case s.register <- client:
+	case <-s.quit:
+		http.Error(w, "Server shutting down", http.StatusServiceUnavailable)
+		return
+	}
 
 	// Ensure cleanup on disconnect
 	defer func() {
-		s.unregister <- clientID
+		select {
+		case s.unregister <- clientID:
+		case <-s.quit:
+		}
 	}()
 
 	// Get flusher
@@ -192,6 +239,8 @@ func (s *SSEServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Data: map[string]interface{}{
 			"client_id": clientID,
 			"filters":   filters,
+			"topics":    topicList(topics),
+			"project":   project,
 		},
 	}
 	s.sendEvent(w, flusher, connectEvent)
@@ -209,6 +258,9 @@ func (s *SSEServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case <-client.done:
 			return
 
+		case <-s.quit:
+			return
+
 		case event := <-client.Events:
 			s.sendEvent(w, flusher, event)
 
@@ -230,3 +282,21 @@ func (s *SSEServer) sendEvent(w http.ResponseWriter, flusher http.Flusher, event
 	fmt.Fprintf(w, "data: %s\n\n", data)
 	flusher.Flush()
 }
+
+// matchProject matches a project filter against an event project root.
+// 전체 경로 또는 디렉토리 이름으로 매칭합니다.
+func matchProject(filter, projectRoot string) bool {
+	if filter == projectRoot {
+		return true
+	}
+	return filepath.Base(filepath.Clean(projectRoot)) == filter
+}
+
+func topicList(topics map[string]bool) []string {
+	list := make([]string, 0, len(topics))
+	for t := range topics {
+		list = append(list, t)
+	}
+	sort.Strings(list)
+	return list
+}
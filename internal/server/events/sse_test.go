@@ -0,0 +1,116 @@
+package events
+
+import "testing"
+
+func TestEventTypeTopic(t *testing.T) {
+	tests := map[EventType]string{
+		EventPortStart:         "port",
+		EventEscalationCreated: "escalation",
+		EventAttentionCritical: "attention",
+		EventType("custom"):    "custom",
+	}
+	for eventType, want := range tests {
+		if got := eventType.Topic(); got != want {
+			t.Errorf("%s.Topic() = %s, want %s", eventType, got, want)
+		}
+	}
+
+	if NormalizeTopic(" Ports ") != "port" || NormalizeTopic("escalations") != "escalation" {
+		t.Error("plural topics should be normalized")
+	}
+}
+
+func TestShouldSend(t *testing.T) {
+	s := NewSSEServer()
+
+	client := &SSEClient{
+		Topics:  map[string]bool{"port": true, "escalation": true},
+		Project: "pal-kit",
+	}
+
+	tests := []struct {
+		name  string
+		event *Event
+		want  bool
+	}{
+		{"topic match", NewEvent(EventPortStart, nil).WithProject("/home/u/pal-kit"), true},
+		{"topic mismatch", NewEvent(EventSessionStart, nil).WithProject("/home/u/pal-kit"), false},
+		{"other project", NewEvent(EventEscalationCreated, nil).WithProject("/home/u/other"), false},
+		{"no project info", NewEvent(EventEscalationCreated, nil), false},
+		{"port event from other project", NewEvent(EventPortStart, nil).WithSession("s-other").WithProject("/home/u/other"), false},
+	}
+
+	for _, tt := range tests {
+		if got := s.shouldSend(client, tt.event); got != tt.want {
+			t.Errorf("%s: shouldSend = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPublishOrchestrationEvent(t *testing.T) {
+	s := NewSSEServer()
+	s.running.Store(true)
+	p := &Publisher{sse: s}
+
+	p.PublishOrchestrationEvent(EventOrchestrationWorkerFailed, OrchestrationProgressData{
+		OrchestrationID: "orch-1",
+		PortID:          "port-a",
+		WorkerID:        "w-1",
+		PortStatus:      "pending",
+		Status:          "running",
+		CompletedPorts:  1,
+		TotalPorts:      4,
+		ProgressPercent: 25,
+		Attempt:         1,
+		Retrying:        true,
+		Error:           "boom",
+	})
+
+	first := <-s.broadcast
+	if first.Type != EventOrchestrationWorkerFailed || first.PortID != "port-a" {
+		t.Fatalf("first event = %s (%s)", first.Type, first.PortID)
+	}
+	if first.Type.Topic() != "orchestration" {
+		t.Errorf("topic = %s, want orchestration", first.Type.Topic())
+	}
+
+	second := <-s.broadcast
+	if second.Type != EventOrchestrationProgress {
+		t.Fatalf("second event = %s, want %s", second.Type, EventOrchestrationProgress)
+	}
+	data := second.Data.(OrchestrationProgressData)
+	if data.PortID != "" || data.Error != "" || data.Retrying {
+		t.Errorf("progress event should not carry port details: %+v", data)
+	}
+	if data.ProgressPercent != 25 || data.TotalPorts != 4 || data.Status != "running" {
+		t.Errorf("progress = %+v", data)
+	}
+
+	p.PublishOrchestrationEvent(EventOrchestrationProgress, OrchestrationProgressData{OrchestrationID: "orch-1"})
+	<-s.broadcast
+	select {
+	case e := <-s.broadcast:
+		t.Errorf("progress event should not be duplicated, got %s", e.Type)
+	default:
+	}
+}
+
+func TestPublishResolvesProject(t *testing.T) {
+	s := NewSSEServer()
+	s.running.Store(true)
+	p := &Publisher{sse: s}
+	p.SetProjectResolver(func(sessionID string) string {
+		return map[string]string{"s-mine": "/home/u/pal-kit", "s-other": "/home/u/other"}[sessionID]
+	})
+
+	client := &SSEClient{Project: "pal-kit"}
+	p.PublishPortStart("s-other", "port-a", "A", nil)
+	p.PublishPortStart("s-mine", "port-b", "B", nil)
+
+	if other := <-s.broadcast; other.Project != "/home/u/other" || s.shouldSend(client, other) {
+		t.Errorf("port event from another project should be filtered: %+v", other)
+	}
+	if mine := <-s.broadcast; mine.Project != "/home/u/pal-kit" || !s.shouldSend(client, mine) {
+		t.Errorf("port event from the filtered project should be sent: %+v", mine)
+	}
+}
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"strings"
 	"time"
 )
 
@@ -24,6 +25,12 @@ const (
 	EventPortEnd     EventType = "port:end"
 	EventPortBlocked EventType = "port:blocked"
 
+	// Orchestration events
+	EventOrchestrationPortStarted   EventType = "orchestration:port_started"
+	EventOrchestrationPortCompleted EventType = "orchestration:port_completed"
+	EventOrchestrationWorkerFailed  EventType = "orchestration:worker_failed"
+	EventOrchestrationProgress      EventType = "orchestration:progress"
+
 	// Checklist events
 	EventChecklistFailed EventType = "checklist:failed"
 	EventChecklistPassed EventType = "checklist:passed"
@@ -31,21 +38,45 @@ const (
 	// Escalation events
 	EventEscalationCreated  EventType = "escalation:created"
 	EventEscalationResolved EventType = "escalation:resolved"
+	EventEscalationUpdated  EventType = "escalation:updated" // 담당 지정, 댓글, 심각도 변경
 
 	// Message events
 	EventMessageReceived EventType = "message:received"
 
+	// Knowledge Base events
+	EventKBUpdated EventType = "kb:updated" // 감시 모드가 재색인/TOC 갱신/링크 검사한 결과
+
 	// Build events
 	EventBuildFailed EventType = "build:failed"
 	EventTestFailed  EventType = "test:failed"
 )
 
+// Topic returns the topic of the event type (prefix before ':').
+// 예: "port:start" → "port"
+func (t EventType) Topic() string {
+	if idx := strings.Index(string(t), ":"); idx >= 0 {
+		return string(t)[:idx]
+	}
+	return string(t)
+}
+
+// NormalizeTopic converts a client supplied topic to its canonical form.
+// 복수형도 허용합니다 (ports → port, escalations → escalation).
+func NormalizeTopic(topic string) string {
+	topic = strings.ToLower(strings.TrimSpace(topic))
+	if len(topic) > 1 && strings.HasSuffix(topic, "s") {
+		topic = strings.TrimSuffix(topic, "s")
+	}
+	return topic
+}
+
 // Event represents a real-time event
 type Event struct {
 	Type      EventType   `json:"type"`
 	Timestamp time.Time   `json:"timestamp"`
 	SessionID string      `json:"session_id,omitempty"`
 	PortID    string      `json:"port_id,omitempty"`
+	Project   string      `json:"project,omitempty"` // 프로젝트 루트 경로
 	Data      interface{} `json:"data,omitempty"`
 }
 
@@ -70,6 +101,12 @@ func (e *Event) WithPort(portID string) *Event {
 	return e
 }
 
+// WithProject sets project root
+func (e *Event) WithProject(projectRoot string) *Event {
+	e.Project = projectRoot
+	return e
+}
+
 // SessionStartData represents session start event data
 type SessionStartData struct {
 	ID          string `json:"id"`
@@ -122,6 +159,22 @@ type PortEndData struct {
 	Duration int64  `json:"duration_secs,omitempty"`
 }
 
+// OrchestrationProgressData represents orchestration progress event data.
+// 대시보드가 폴링 없이 진행률 바를 그릴 수 있도록 모든 오케스트레이션 이벤트에 진행 현황을 함께 담습니다.
+type OrchestrationProgressData struct {
+	OrchestrationID string `json:"orchestration_id"`
+	PortID          string `json:"port_id,omitempty"`
+	WorkerID        string `json:"worker_id,omitempty"`
+	PortStatus      string `json:"port_status,omitempty"`
+	Status          string `json:"status"` // Orchestration 상태
+	CompletedPorts  int    `json:"completed_ports"`
+	TotalPorts      int    `json:"total_ports"`
+	ProgressPercent int    `json:"progress_percent"`
+	Attempt         int    `json:"attempt,omitempty"`
+	Retrying        bool   `json:"retrying,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
 // ChecklistResultData represents checklist result event data
 type ChecklistResultData struct {
 	Passed      bool          `json:"passed"`
@@ -0,0 +1,22 @@
+//go:build ignore
+
+// gen_openapi writes openapi.json from the endpoint registry.
+// 사용법: go generate ./internal/server
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/n0roo/pal-kit/internal/server"
+)
+
+func main() {
+	data, err := server.BuildOpenAPISpec()
+	if err != nil {
+		log.Fatalf("OpenAPI 문서 생성 실패: %v", err)
+	}
+	if err := os.WriteFile("openapi.json", data, 0644); err != nil {
+		log.Fatalf("openapi.json 저장 실패: %v", err)
+	}
+}
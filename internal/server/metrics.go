@@ -0,0 +1,123 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/metrics"
+)
+
+const (
+	metricDBQuery        = "pal_db_query_duration_seconds"
+	metricHookCalls      = "pal_hook_invocations_total"
+	metricHookErrors     = "pal_hook_errors_total"
+	metricHookDuration   = "pal_hook_duration_seconds"
+	metricSessionsActive = "pal_sessions_active"
+	metricPortsRunning   = "pal_ports_running"
+	metricProjectTokens  = "pal_project_tokens_total"
+	metricProjectCost    = "pal_project_cost_usd_total"
+	metricSSEClients     = "pal_sse_clients"
+)
+
+// newMetricsRegistry declares the exported families so Grafana sees them before the first sample
+func newMetricsRegistry() *metrics.Registry {
+	reg := metrics.NewRegistry()
+	reg.Describe(metricDBQuery, "Latency of DB queries issued by the server", "histogram")
+	reg.Describe(metricHookCalls, "Hook invocations by hook type", "counter")
+	reg.Describe(metricHookErrors, "Failed hook invocations by hook type", "counter")
+	reg.Describe(metricHookDuration, "Hook execution time by hook type", "histogram")
+	reg.Describe(metricSessionsActive, "Sessions currently running", "gauge")
+	reg.Describe(metricPortsRunning, "Ports currently running", "gauge")
+	reg.Describe(metricProjectTokens, "Tokens spent per project", "counter")
+	reg.Describe(metricProjectCost, "Cost in USD spent per project", "counter")
+	reg.Describe(metricSSEClients, "Connected SSE clients", "gauge")
+	return reg
+}
+
+// observeQuery feeds the DB query latency histogram (db.SetQueryObserver)
+func (s *Server) observeQuery(op string, elapsed time.Duration) {
+	s.metrics.Observe(metricDBQuery, "", metrics.Labels{"op": op}, elapsed.Seconds())
+}
+
+// handleMetrics serves Prometheus metrics (text exposition format).
+// DB에 저장된 값(hook 집계, 세션/포트 상태, 프로젝트별 토큰)은 스크레이프 시점에 다시 읽습니다.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	if err := s.collectMetrics(database); err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	s.metrics.WriteText(w)
+}
+
+func (s *Server) collectMetrics(database *db.DB) error {
+	reg := s.metrics
+
+	hooks, err := metrics.HookStats(database)
+	if err != nil {
+		return err
+	}
+	for _, h := range hooks {
+		labels := metrics.Labels{"hook": h.Hook}
+		reg.SetCounter(metricHookCalls, "", labels, float64(h.Invocations))
+		reg.SetCounter(metricHookErrors, "", labels, float64(h.Errors))
+		reg.SetHistogram(metricHookDuration, "", labels, h.Buckets, h.DurationSum)
+	}
+
+	var activeSessions, runningPorts int
+	database.QueryRow(`SELECT COUNT(*) FROM sessions WHERE status = 'running'`).Scan(&activeSessions)
+	database.QueryRow(`SELECT COUNT(*) FROM ports WHERE status = 'running'`).Scan(&runningPorts)
+	reg.Set(metricSessionsActive, "", nil, float64(activeSessions))
+	reg.Set(metricPortsRunning, "", nil, float64(runningPorts))
+
+	rows, err := database.Query(`
+		SELECT COALESCE(project_root, ''),
+			COALESCE(SUM(input_tokens), 0), COALESCE(SUM(output_tokens), 0),
+			COALESCE(SUM(cache_read_tokens), 0), COALESCE(SUM(cache_create_tokens), 0),
+			COALESCE(SUM(cost_usd), 0)
+		FROM sessions GROUP BY project_root
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	reg.Reset(metricProjectTokens)
+	reg.Reset(metricProjectCost)
+	for rows.Next() {
+		var root string
+		var input, output, cacheRead, cacheCreate int64
+		var cost float64
+		if err := rows.Scan(&root, &input, &output, &cacheRead, &cacheCreate, &cost); err != nil {
+			return err
+		}
+		if root == "" {
+			root = "unknown"
+		}
+		for typ, n := range map[string]int64{"input": input, "output": output, "cache_read": cacheRead, "cache_create": cacheCreate} {
+			reg.SetCounter(metricProjectTokens, "", metrics.Labels{"project": root, "type": typ}, float64(n))
+		}
+		if !s.config.HideCost {
+			reg.SetCounter(metricProjectCost, "", metrics.Labels{"project": root}, cost)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	s.lifecycleMu.Lock()
+	sse := s.sse
+	s.lifecycleMu.Unlock()
+	if sse != nil {
+		reg.Set(metricSSEClients, "", nil, float64(sse.ClientCount()))
+	}
+	return nil
+}
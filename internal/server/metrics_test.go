@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/metrics"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	database.Exec(`INSERT INTO sessions (id, status, project_root, input_tokens, output_tokens, cost_usd) VALUES ('s1', 'running', '/p', 100, 20, 0.5)`)
+	database.Exec(`INSERT INTO ports (id, status) VALUES ('p1', 'running'), ('p2', 'pending')`)
+	metrics.RecordHook(database, "session-start", 5*time.Millisecond, false)
+	database.Close()
+
+	s := NewServer(Config{DBPath: dbPath, HideCost: true})
+	t.Cleanup(func() { s.Close() })
+	db.SetQueryObserver(s.observeQuery)
+	defer db.SetQueryObserver(nil)
+
+	rec := httptest.NewRecorder()
+	s.handleMetrics(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != 200 || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("응답 = %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	out := rec.Body.String()
+	for _, want := range []string{
+		`pal_hook_invocations_total{hook="session-start"} 1`,
+		"pal_sessions_active 1\n",
+		"pal_ports_running 1\n",
+		`pal_project_tokens_total{project="/p",type="input"} 100`,
+		`pal_db_query_duration_seconds_count{op="query"}`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("출력에 %q 없음:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "pal_project_cost_usd_total{") {
+		t.Error("HideCost인데 비용 메트릭이 노출됨")
+	}
+}
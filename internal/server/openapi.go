@@ -0,0 +1,411 @@
+package server
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+//go:generate go run gen_openapi.go
+
+// openAPISpec is the generated OpenAPI document (go generate ./internal/server로 갱신)
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// apiParam is a query parameter of an operation
+type apiParam struct {
+	Name        string
+	Description string
+}
+
+// apiOperation documents one method of an endpoint
+type apiOperation struct {
+	Method      string
+	Summary     string
+	Query       []apiParam
+	RequestBody interface{} // 요청 본문 타입 (nil이면 본문 없음)
+	Response    interface{} // 응답 타입 (nil이면 임의 객체)
+	List        bool        // 목록 API 공통 규약(limit/offset/cursor/sort/filter) 적용
+	Stream      bool        // text/event-stream 응답
+}
+
+// apiEndpoint documents a path served by a registered mux pattern.
+// 하나의 패턴(예: /api/v2/orchestrations/)이 여러 하위 경로를 처리하면 경로마다 항목을 둡니다.
+type apiEndpoint struct {
+	Pattern    string // mux에 등록한 패턴
+	Path       string // OpenAPI 경로 ({id} 등 경로 파라미터 표기)
+	Tag        string
+	Operations []apiOperation
+}
+
+func opGet(summary string, response interface{}, query ...apiParam) apiOperation {
+	return apiOperation{Method: http.MethodGet, Summary: summary, Response: response, Query: query}
+}
+
+func opList(summary string, item interface{}, query ...apiParam) apiOperation {
+	op := opGet(summary, item, query...)
+	op.List = true
+	return op
+}
+
+func opBody(method, summary string, body, response interface{}, query ...apiParam) apiOperation {
+	return apiOperation{Method: method, Summary: summary, RequestBody: body, Response: response, Query: query}
+}
+
+func qp(name, description string) apiParam {
+	return apiParam{Name: name, Description: description}
+}
+
+// Common untyped payloads
+type (
+	apiObject = map[string]interface{}
+	apiStatus = map[string]string
+)
+
+// Request bodies decoded by handlers into anonymous structs
+type (
+	reasonRequest struct {
+		Reason string `json:"reason,omitempty"`
+	}
+	projectPathRequest struct {
+		Path string `json:"path"`
+		Name string `json:"name,omitempty"`
+	}
+	documentWriteRequest struct {
+		Path    string `json:"path,omitempty"`
+		Content string `json:"content"`
+	}
+	documentMoveRequest struct {
+		NewPath string `json:"new_path"`
+	}
+	pipelineMigrateRequest struct {
+		PipelineID string `json:"pipeline_id,omitempty"`
+	}
+	handoffEstimateRequest struct {
+		Type    string      `json:"type,omitempty"`
+		Content interface{} `json:"content"`
+	}
+	orchestrationStartRequest struct {
+		OperatorSessionID string `json:"operator_session_id,omitempty"`
+	}
+)
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// openAPIVersion is the version of the documented API surface
+const openAPIVersion = "2.0"
+
+// BuildOpenAPISpec builds the OpenAPI 3 document from the endpoint registry and DTO types
+func BuildOpenAPISpec() ([]byte, error) {
+	b := &schemaBuilder{schemas: make(map[string]interface{})}
+	paths := make(map[string]interface{})
+	tagSet := make(map[string]bool)
+
+	for _, ep := range apiEndpoints() {
+		item, ok := paths[ep.Path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[ep.Path] = item
+		}
+		tagSet[ep.Tag] = true
+		for _, op := range ep.Operations {
+			item[strings.ToLower(op.Method)] = b.operation(ep, op)
+		}
+	}
+
+	tags := make([]map[string]string, 0, len(tagSet))
+	for _, name := range sortedKeys(tagSet) {
+		tags = append(tags, map[string]string{"name": name})
+	}
+
+	b.schemaOf(reflect.TypeOf(ErrorResponse{}))
+	b.schemaOf(reflect.TypeOf(PageInfo{}))
+
+	spec := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "PAL Kit API",
+			"version":     openAPIVersion,
+			"description": "pal serve가 제공하는 v1/v2 HTTP API. 이 문서는 엔드포인트 레지스트리(internal/server/openapi_routes.go)에서 생성됩니다. 프로젝트 단위 API는 X-PAL-Project 헤더(또는 ?project=)로 등록된 다른 프로젝트를 조회할 수 있습니다.",
+		},
+		"tags":  tags,
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": b.schemas,
+			"responses": map[string]interface{}{
+				"Error": map[string]interface{}{
+					"description": "오류",
+					"content":     jsonContent(schemaRef("ErrorResponse")),
+				},
+			},
+			"parameters": listParameters(),
+		},
+	}
+
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// handleOpenAPI serves the generated OpenAPI document
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
+
+// handleSwaggerUI serves the embedded Swagger UI page
+func (s *Server) handleSwaggerUI(w http.ResponseWriter, r *http.Request) {
+	page, err := staticFiles.ReadFile("static/swagger.html")
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(page)
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+func (b *schemaBuilder) operation(ep apiEndpoint, op apiOperation) map[string]interface{} {
+	result := map[string]interface{}{
+		"tags":        []string{ep.Tag},
+		"summary":     op.Summary,
+		"operationId": operationID(op.Method, ep.Path),
+	}
+
+	var params []interface{}
+	for _, m := range pathParamPattern.FindAllStringSubmatch(ep.Path, -1) {
+		params = append(params, map[string]interface{}{
+			"name": m[1], "in": "path", "required": true,
+			"schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	for _, p := range op.Query {
+		params = append(params, map[string]interface{}{
+			"name": p.Name, "in": "query", "description": p.Description,
+			"schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	if op.List {
+		for _, name := range []string{"limit", "offset", "cursor", "sort", "order", "filter", "envelope"} {
+			params = append(params, map[string]interface{}{"$ref": "#/components/parameters/" + name})
+		}
+	}
+	if len(params) > 0 {
+		result["parameters"] = params
+	}
+
+	if op.RequestBody != nil {
+		result["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  jsonContent(b.schemaOf(reflect.TypeOf(op.RequestBody))),
+		}
+	}
+
+	ok := map[string]interface{}{"description": "OK"}
+	switch {
+	case op.Stream:
+		ok["content"] = map[string]interface{}{
+			"text/event-stream": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+		}
+	case op.List:
+		item := b.schemaOf(reflect.TypeOf(op.Response))
+		array := map[string]interface{}{"type": "array", "items": item}
+		ok["content"] = jsonContent(map[string]interface{}{
+			"oneOf": []interface{}{
+				array,
+				map[string]interface{}{
+					"type":        "object",
+					"description": "envelope=true",
+					"properties":  map[string]interface{}{"items": array, "page": schemaRef("PageInfo")},
+				},
+			},
+		})
+		ok["headers"] = map[string]interface{}{
+			HeaderTotalCount: map[string]interface{}{"schema": map[string]interface{}{"type": "integer"}, "description": "필터 적용 후 전체 항목 수"},
+			HeaderNextCursor: map[string]interface{}{"schema": map[string]interface{}{"type": "string"}, "description": "다음 페이지 커서 (마지막 페이지면 없음)"},
+			"Link":           map[string]interface{}{"schema": map[string]interface{}{"type": "string"}, "description": "rel=\"next\" 링크"},
+		}
+	default:
+		schema := map[string]interface{}{"type": "object"}
+		if op.Response != nil {
+			schema = b.schemaOf(reflect.TypeOf(op.Response))
+		}
+		ok["content"] = jsonContent(schema)
+	}
+
+	result["responses"] = map[string]interface{}{
+		"200":     ok,
+		"default": map[string]interface{}{"$ref": "#/components/responses/Error"},
+	}
+	return result
+}
+
+func listParameters() map[string]interface{} {
+	param := func(name, description string, schema map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"name": name, "in": "query", "description": description, "schema": schema}
+	}
+	str := map[string]interface{}{"type": "string"}
+	filter := param("filter", "JSON 필드 값 일치 필터 (filter[status]=running, 여러 개는 AND)",
+		map[string]interface{}{"type": "object", "additionalProperties": str})
+	filter["style"] = "deepObject"
+	filter["explode"] = true
+
+	return map[string]interface{}{
+		"limit":    param("limit", "페이지 크기 (엔드포인트별 기본값, 최대 500)", map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxPageLimit}),
+		"offset":   param("offset", "건너뛸 항목 수", map[string]interface{}{"type": "integer", "minimum": 0}),
+		"cursor":   param("cursor", "이전 응답의 X-Next-Cursor (offset보다 우선)", str),
+		"sort":     param("sort", "JSON 필드 기준 정렬, '-' 접두사는 내림차순", str),
+		"order":    param("order", "asc 또는 desc (sort의 '-' 접두사보다 우선)", map[string]interface{}{"type": "string", "enum": []string{"asc", "desc"}}),
+		"filter":   filter,
+		"envelope": param("envelope", "true면 배열 대신 {items, page} 형태로 응답", map[string]interface{}{"type": "boolean"}),
+	}
+}
+
+// operationID derives a stable camelCase operation ID (예: GET /api/v2/orchestrations/{id}/plan → getApiV2OrchestrationsIdPlan)
+func operationID(method, p string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(method))
+	for _, word := range strings.FieldsFunc(p, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	return sb.String()
+}
+
+func jsonContent(schema interface{}) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+}
+
+func schemaRef(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// schemaBuilder converts Go types to JSON schemas, collecting named structs as components
+type schemaBuilder struct {
+	schemas map[string]interface{}
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+func (b *schemaBuilder) schemaOf(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": b.schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		name := schemaName(t)
+		if _, ok := b.schemas[name]; !ok {
+			b.schemas[name] = map[string]interface{}{} // 재귀 타입 대비 선등록
+			b.schemas[name] = b.structSchema(t)
+		}
+		return schemaRef(name)
+	}
+	return map[string]interface{}{}
+}
+
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	props := make(map[string]interface{})
+	b.addFields(t, props)
+	return map[string]interface{}{"type": "object", "properties": props}
+}
+
+// addFields adds the JSON-visible fields of t, flattening untagged embedded structs like encoding/json.
+// 바깥 필드가 임베드된 필드보다 우선합니다.
+func (b *schemaBuilder) addFields(t reflect.Type, props map[string]interface{}) {
+	embedded := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				b.addFields(ft, embedded)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = b.schemaOf(f.Type)
+	}
+	for name, schema := range embedded {
+		if _, ok := props[name]; !ok {
+			props[name] = schema
+		}
+	}
+}
+
+// schemaName names a component after its Go type (다른 패키지 타입은 pkg.Type)
+func schemaName(t reflect.Type) string {
+	if pkg := path.Base(t.PkgPath()); pkg != "server" && pkg != "." {
+		return pkg + "." + t.Name()
+	}
+	return t.Name()
+}
@@ -0,0 +1,521 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/n0roo/pal-kit/internal/agent"
+	"github.com/n0roo/pal-kit/internal/agentv2"
+	"github.com/n0roo/pal-kit/internal/attention"
+	"github.com/n0roo/pal-kit/internal/compact"
+	"github.com/n0roo/pal-kit/internal/convention"
+	"github.com/n0roo/pal-kit/internal/docs"
+	"github.com/n0roo/pal-kit/internal/document"
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/feed"
+	"github.com/n0roo/pal-kit/internal/handoff"
+	"github.com/n0roo/pal-kit/internal/health"
+	"github.com/n0roo/pal-kit/internal/history"
+	"github.com/n0roo/pal-kit/internal/kb"
+	"github.com/n0roo/pal-kit/internal/manifest"
+	"github.com/n0roo/pal-kit/internal/message"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/n0roo/pal-kit/internal/trash"
+	"github.com/n0roo/pal-kit/internal/usage"
+)
+
+// Request bodies of create endpoints (핸들러가 디코딩하는 필드와 같게 유지)
+type (
+	orchestrationCreateRequest struct {
+		Title          string                    `json:"title"`
+		Description    string                    `json:"description,omitempty"`
+		Ports          []orchestrator.AtomicPort `json:"ports"`
+		IdempotencyKey string                    `json:"idempotency_key,omitempty"`
+		RetryPolicy    *orchestrator.RetryPolicy `json:"retry_policy,omitempty"`
+	}
+	attentionInitRequest struct {
+		PortID      string `json:"port_id"`
+		TokenBudget int    `json:"token_budget"`
+	}
+	handoffCreateRequest struct {
+		FromPortID string      `json:"from_port_id"`
+		ToPortID   string      `json:"to_port_id"`
+		Type       string      `json:"type"`
+		Content    interface{} `json:"content"`
+	}
+	agentCreateRequest struct {
+		Name         string   `json:"name"`
+		Type         string   `json:"type"`
+		Description  string   `json:"description,omitempty"`
+		Capabilities []string `json:"capabilities,omitempty"`
+	}
+	agentVersionRequest struct {
+		SpecContent   string `json:"spec_content"`
+		ChangeSummary string `json:"change_summary,omitempty"`
+		ChangeReason  string `json:"change_reason,omitempty"`
+	}
+	globalSyncRequest struct {
+		ProjectRoot    string `json:"project_root"`
+		ForceOverwrite bool   `json:"force_overwrite,omitempty"`
+	}
+	contentRequest struct {
+		Content string `json:"content"`
+	}
+	kbInitRequest struct {
+		VaultPath string `json:"vault_path,omitempty"`
+	}
+	kbTocGenerateRequest struct {
+		Depth  int    `json:"depth,omitempty"`
+		SortBy string `json:"sort_by,omitempty"`
+	}
+	kbDocumentCreateRequest struct {
+		Path    string   `json:"path"`
+		Title   string   `json:"title"`
+		Type    string   `json:"type,omitempty"`
+		Domain  string   `json:"domain,omitempty"`
+		Status  string   `json:"status,omitempty"`
+		Tags    []string `json:"tags,omitempty"`
+		Content string   `json:"content,omitempty"`
+	}
+	kbRegisterRequest struct {
+		SourcePath    string `json:"source_path"`
+		TargetSection string `json:"target_section"`
+		TargetPath    string `json:"target_path,omitempty"`
+	}
+	kbRegisterExternalRequest struct {
+		Title         string   `json:"title"`
+		Content       string   `json:"content"`
+		TargetSection string   `json:"target_section"`
+		Type          string   `json:"type,omitempty"`
+		Tags          []string `json:"tags,omitempty"`
+	}
+	projectUpdateRequest struct {
+		Name     *string `json:"name,omitempty"`
+		Archived *bool   `json:"archived,omitempty"`
+		Hidden   *bool   `json:"hidden,omitempty"`
+	}
+)
+
+// apiEndpoints is the registry of every documented HTTP endpoint.
+// 라우트를 추가하면 여기에도 추가하고 go generate ./internal/server로 openapi.json을 갱신합니다 (테스트가 누락을 검사).
+func apiEndpoints() []apiEndpoint {
+	historyFilters := []apiParam{
+		qp("session_id", "세션 ID"), qp("event_type", "이벤트 타입"), qp("project", "프로젝트 루트"),
+		qp("search", "이벤트 데이터 검색어"), qp("start_date", "시작일 (YYYY-MM-DD)"), qp("end_date", "종료일 (YYYY-MM-DD)"),
+	}
+
+	return []apiEndpoint{
+		// Dashboard (v1)
+		{Pattern: "/api/status", Path: "/api/status", Tag: "status", Operations: []apiOperation{
+			opGet("전체 상태 요약", apiObject{}),
+		}},
+		{Pattern: "/api/v2/status", Path: "/api/v2/status", Tag: "status", Operations: []apiOperation{
+			opGet("v2 상태 요약 (orchestration, 빌드 세션, 에이전트)", apiObject{}),
+		}},
+		{Pattern: "/api/share/status", Path: "/api/share/status", Tag: "status", Operations: []apiOperation{
+			opGet("관계자용 진행률 (--share 모드 전용)", apiObject{}, qp("orch", "Orchestration ID")),
+		}},
+		{Pattern: "/api/projects", Path: "/api/projects", Tag: "projects", Operations: []apiOperation{
+			opList("세션 기록이 있는 프로젝트 목록", apiObject{}),
+		}},
+		{Pattern: "/api/projects/detail", Path: "/api/projects/detail", Tag: "projects", Operations: []apiOperation{
+			opGet("프로젝트 상세 (세션, 포트)", apiObject{}, qp("root", "프로젝트 루트")),
+		}},
+		{Pattern: "/api/sessions", Path: "/api/sessions", Tag: "sessions", Operations: []apiOperation{
+			opList("세션 목록", SessionDetailDTO{}, qp("project", "프로젝트 루트 (X-PAL-Project 헤더와 동일, 비우면 전체)")),
+			opBody(http.MethodPost, "세션 시작 (id가 비면 생성)", sessionCreateRequest{}, SessionDTO{}),
+		}},
+		{Pattern: "/api/sessions/stats", Path: "/api/sessions/stats", Tag: "sessions", Operations: []apiOperation{
+			opGet("세션 통계", session.SessionStats{}),
+		}},
+		{Pattern: "/api/sessions/history", Path: "/api/sessions/history", Tag: "sessions", Operations: []apiOperation{
+			opGet("일별 세션 이력", []apiObject{}, qp("days", "조회 일수")),
+		}},
+		{Pattern: "/api/sessions/tree", Path: "/api/sessions/tree", Tag: "sessions", Operations: []apiOperation{
+			opGet("세션 트리", apiObject{}),
+		}},
+		{Pattern: "/api/sessions/", Path: "/api/sessions/{id}", Tag: "sessions", Operations: []apiOperation{
+			opGet("세션 상세", apiObject{}),
+			opBody(http.MethodPatch, "세션 제목/상태 변경 (status=complete는 종료)", sessionUpdateRequest{}, SessionDTO{}),
+			opBody(http.MethodDelete, "세션 종료 (기록은 유지)", nil, SessionDTO{}, qp("reason", "종료 사유")),
+		}},
+		{Pattern: "/api/sessions/", Path: "/api/sessions/{id}/events", Tag: "sessions", Operations: []apiOperation{
+			opGet("세션 이벤트", []SessionEventDTO{}, qp("limit", "최대 개수"), qp("type", "이벤트 타입")),
+		}},
+		{Pattern: "/api/ports", Path: "/api/ports", Tag: "ports", Operations: []apiOperation{
+			opList("포트 목록", PortDTO{}, qp("project", "프로젝트 루트 (X-PAL-Project 헤더와 동일, 비우면 전체)")),
+			opBody(http.MethodPost, "포트 생성", portCreateRequest{}, PortDTO{}),
+		}},
+		{Pattern: "/api/ports/", Path: "/api/ports/{id}", Tag: "ports", Operations: []apiOperation{
+			opGet("포트 상세", PortDTO{}),
+			opBody(http.MethodPatch, "포트 제목/상태/세션 변경 (시작/완료는 hook 경로, 409)", portUpdateRequest{}, PortDTO{}),
+			opBody(http.MethodDelete, "포트 삭제", nil, apiStatus{}),
+		}},
+		{Pattern: "/api/ports/flow", Path: "/api/ports/flow", Tag: "ports", Operations: []apiOperation{
+			opGet("포트 흐름 그래프", PortFlowDTO{}, qp("session", "세션 ID")),
+		}},
+		{Pattern: "/api/ports/progress", Path: "/api/ports/progress", Tag: "ports", Operations: []apiOperation{
+			opGet("포트 진행률", PortProgressDTO{}),
+		}},
+		{Pattern: "/api/pipelines", Path: "/api/pipelines", Tag: "pipelines", Operations: []apiOperation{
+			opList("파이프라인 목록", PipelineDTO{}),
+		}},
+		{Pattern: "/api/agents", Path: "/api/agents", Tag: "agents", Operations: []apiOperation{
+			opList("프로젝트 에이전트 목록", agent.Agent{}),
+		}},
+		{Pattern: "/api/docs", Path: "/api/docs", Tag: "docs", Operations: []apiOperation{
+			opList("프로젝트 문서 목록", docs.Document{}),
+		}},
+		{Pattern: "/api/docs/content", Path: "/api/docs/content", Tag: "docs", Operations: []apiOperation{
+			opGet("문서 내용", apiObject{}, qp("path", "문서 경로")),
+		}},
+		{Pattern: "/api/conventions", Path: "/api/conventions", Tag: "conventions", Operations: []apiOperation{
+			opList("컨벤션 목록", convention.Convention{}),
+		}},
+		{Pattern: "/api/locks", Path: "/api/locks", Tag: "locks", Operations: []apiOperation{
+			opList("Lock 목록", LockDTO{}),
+			opBody(http.MethodPost, "Lock 획득 (이미 잠겨 있으면 409)", lockAcquireRequest{}, LockDTO{}),
+		}},
+		{Pattern: "/api/locks/", Path: "/api/locks/{resource}", Tag: "locks", Operations: []apiOperation{
+			opBody(http.MethodDelete, "Lock 해제", nil, apiStatus{}),
+		}},
+		{Pattern: "/api/escalations", Path: "/api/escalations", Tag: "escalations", Operations: []apiOperation{
+			opList("에스컬레이션 목록", EscalationDTO{}),
+			opBody(http.MethodPost, "에스컬레이션 생성", escalationCreateRequest{}, EscalationDTO{}),
+		}},
+		{Pattern: "/api/escalations/", Path: "/api/escalations/{id}", Tag: "escalations", Operations: []apiOperation{
+			opGet("에스컬레이션 상세", EscalationDTO{}),
+			opBody(http.MethodPatch, "에스컬레이션 처리 (status: resolved/dismissed, severity 변경, 해결 요약)", escalationUpdateRequest{}, EscalationDTO{}),
+			opBody(http.MethodDelete, "에스컬레이션 무시 (dismissed)", nil, EscalationDTO{}),
+		}},
+		{Pattern: "/api/escalations/", Path: "/api/escalations/{id}/assign", Tag: "escalations", Operations: []apiOperation{
+			opBody(http.MethodPost, "담당자 지정 (assignee 비우면 해제)", escalationAssignRequest{}, escalation.Activity{}),
+		}},
+		{Pattern: "/api/escalations/", Path: "/api/escalations/{id}/comments", Tag: "escalations", Operations: []apiOperation{
+			opBody(http.MethodPost, "댓글 작성 (parent_id로 답글)", escalationCommentRequest{}, escalation.Activity{}),
+		}},
+		{Pattern: "/api/escalations/", Path: "/api/escalations/{id}/activity", Tag: "escalations", Operations: []apiOperation{
+			opList("처리 기록 (담당, 댓글, 심각도, 해결/무시)", escalation.Activity{}),
+		}},
+		{Pattern: "/api/manifest", Path: "/api/manifest", Tag: "manifest", Operations: []apiOperation{
+			opGet("매니페스트 추적 파일 상태", apiObject{}),
+		}},
+		{Pattern: "/api/manifest/changes", Path: "/api/manifest/changes", Tag: "manifest", Operations: []apiOperation{
+			opList("매니페스트 변경 이력", manifest.ChangeRecord{}),
+		}},
+
+		// History
+		{Pattern: "/api/history/events", Path: "/api/history/events", Tag: "history", Operations: []apiOperation{
+			opGet("이벤트 로그 ({events, total})", apiObject{}, append(historyFilters, qp("limit", "최대 개수"), qp("offset", "건너뛸 개수"))...),
+		}},
+		{Pattern: "/api/history/types", Path: "/api/history/types", Tag: "history", Operations: []apiOperation{
+			opGet("이벤트 타입 목록", []string{}),
+		}},
+		{Pattern: "/api/history/projects", Path: "/api/history/projects", Tag: "history", Operations: []apiOperation{
+			opGet("이벤트가 있는 프로젝트 목록", []string{}),
+		}},
+		{Pattern: "/api/history/stats", Path: "/api/history/stats", Tag: "history", Operations: []apiOperation{
+			opGet("이벤트 통계", apiObject{}),
+		}},
+		{Pattern: "/api/history/export", Path: "/api/history/export", Tag: "history", Operations: []apiOperation{
+			opGet("이벤트 내보내기 (CSV/JSON 첨부 파일)", []history.EventDetail{}, append(historyFilters, qp("format", "csv 또는 json"))...),
+		}},
+		{Pattern: "/api/history/state", Path: "/api/history/state", Tag: "history", Operations: []apiOperation{
+			opGet("특정 시점의 프로젝트 상태", history.Snapshot{}, qp("as_of", "시점 (2025-06-01, 2025-06-01 18:00, RFC3339)"), qp("project", "프로젝트 루트 (비우면 전체)")),
+		}},
+		{Pattern: "/api/stats/heatmap", Path: "/api/stats/heatmap", Tag: "history", Operations: []apiOperation{
+			opGet("사용량 히트맵", usage.Heatmap{}, qp("days", "조회 일수"), qp("by", "집계 단위"), qp("project", "프로젝트 루트")),
+		}},
+		{Pattern: "/api/v2/analytics/costs", Path: "/api/v2/analytics/costs", Tag: "history", Operations: []apiOperation{
+			opGet("일/주/프로젝트/세션 타입/에이전트/포트별 토큰·비용 (이동 평균 포함)", usage.CostReport{},
+				qp("days", "조회 일수 (기본 30, 최대 366)"), qp("window", "이동 평균 일수 (기본 7)"), qp("project", "프로젝트 이름 또는 루트")),
+		}},
+		{Pattern: "/api/v2/feed", Path: "/api/v2/feed", Tag: "history", Operations: []apiOperation{
+			opList("세션 이벤트/메시지/에스컬레이션/Lock 활동 통합 피드 (최신순)", feed.Item{},
+				qp("since", "시작 시점 (1h, 30m 또는 RFC3339, 기본 1h, 최대 7일)"), qp("until", "끝 시점 (RFC3339, 첫 페이지에서 고정됨)"),
+				qp("kind", "event, message, escalation, lock (쉼표 구분)"), qp("project", "프로젝트 루트")),
+		}},
+
+		// Orchestrations
+		{Pattern: "/api/v2/orchestrations", Path: "/api/v2/orchestrations", Tag: "orchestrations", Operations: []apiOperation{
+			opList("Orchestration 목록", orchestrator.OrchestrationPort{}, qp("status", "상태")),
+			opBody(http.MethodPost, "Orchestration 생성 (Idempotency-Key 헤더 지원)", orchestrationCreateRequest{}, orchestrator.OrchestrationPort{}),
+		}},
+		{Pattern: "/api/v2/orchestrations/", Path: "/api/v2/orchestrations/{id}", Tag: "orchestrations", Operations: []apiOperation{
+			opGet("Orchestration 상세", orchestrator.OrchestrationPort{}),
+		}},
+		{Pattern: "/api/v2/orchestrations/", Path: "/api/v2/orchestrations/{id}/stats", Tag: "orchestrations", Operations: []apiOperation{
+			opGet("Orchestration 통계", orchestrator.OrchestrationStats{}),
+		}},
+		{Pattern: "/api/v2/orchestrations/", Path: "/api/v2/orchestrations/{id}/workers", Tag: "orchestrations", Operations: []apiOperation{
+			opGet("Orchestration 워커 세션", []orchestrator.WorkerSession{}),
+		}},
+		{Pattern: "/api/v2/orchestrations/", Path: "/api/v2/orchestrations/{id}/start", Tag: "orchestrations", Operations: []apiOperation{
+			opBody(http.MethodPost, "Orchestration 시작", orchestrationStartRequest{}, apiStatus{}),
+		}},
+		{Pattern: "/api/v2/orchestrations/", Path: "/api/v2/orchestrations/{id}/pause", Tag: "orchestrations", Operations: []apiOperation{
+			opBody(http.MethodPost, "Orchestration 일시 중지", reasonRequest{}, orchestrator.LifecycleEvent{}),
+		}},
+		{Pattern: "/api/v2/orchestrations/", Path: "/api/v2/orchestrations/{id}/resume", Tag: "orchestrations", Operations: []apiOperation{
+			opBody(http.MethodPost, "Orchestration 재개", reasonRequest{}, orchestrator.LifecycleEvent{}),
+		}},
+		{Pattern: "/api/v2/orchestrations/", Path: "/api/v2/orchestrations/{id}/cancel", Tag: "orchestrations", Operations: []apiOperation{
+			opBody(http.MethodPost, "Orchestration 취소", reasonRequest{}, orchestrator.LifecycleEvent{}),
+		}},
+		{Pattern: "/api/v2/orchestrations/", Path: "/api/v2/orchestrations/{id}/plan", Tag: "orchestrations", Operations: []apiOperation{
+			opGet("크리티컬 패스와 예상 완료 시각", orchestrator.ExecutionPlan{}),
+		}},
+		{Pattern: "/api/v2/orchestrations/", Path: "/api/v2/orchestrations/{id}/retry-policy", Tag: "orchestrations", Operations: []apiOperation{
+			opGet("재시도 정책", orchestrator.RetryPolicy{}),
+			opBody(http.MethodPut, "재시도 정책 변경", orchestrator.RetryPolicy{}, orchestrator.RetryPolicy{}),
+		}},
+		{Pattern: "/api/v2/orchestrations/", Path: "/api/v2/orchestrations/{id}/lifecycle", Tag: "orchestrations", Operations: []apiOperation{
+			opGet("상태 전이 이력", []orchestrator.LifecycleEvent{}),
+		}},
+		{Pattern: "/api/v2/executions", Path: "/api/v2/executions", Tag: "orchestrations", Operations: []apiOperation{
+			opList("파이프라인과 Orchestration 통합 실행 목록", orchestrator.Execution{}, qp("status", "상태")),
+		}},
+		{Pattern: "/api/v2/executions/migrate", Path: "/api/v2/executions/migrate", Tag: "orchestrations", Operations: []apiOperation{
+			opBody(http.MethodPost, "파이프라인을 Orchestration으로 이관 (pipeline_id가 비면 전체)", pipelineMigrateRequest{}, []orchestrator.PipelineMigration{}),
+		}},
+		{Pattern: "/api/v2/workers", Path: "/api/v2/workers", Tag: "orchestrations", Operations: []apiOperation{
+			opList("워커 세션 목록", orchestrator.WorkerSession{}, qp("orchestration", "Orchestration ID")),
+		}},
+		{Pattern: "/api/v2/workers/", Path: "/api/v2/workers/{id}", Tag: "orchestrations", Operations: []apiOperation{
+			opGet("워커 세션 상세", orchestrator.WorkerSession{}),
+		}},
+
+		// Session hierarchy
+		{Pattern: "/api/v2/sessions/hierarchy", Path: "/api/v2/sessions/hierarchy", Tag: "sessions", Operations: []apiOperation{
+			opGet("루트 세션 목록", []session.HierarchicalSession{}),
+		}},
+		{Pattern: "/api/v2/sessions/hierarchy/", Path: "/api/v2/sessions/hierarchy/{id}", Tag: "sessions", Operations: []apiOperation{
+			opGet("계층 세션 상세", session.HierarchicalSession{}),
+		}},
+		{Pattern: "/api/v2/sessions/hierarchy/", Path: "/api/v2/sessions/hierarchy/{id}/tree", Tag: "sessions", Operations: []apiOperation{
+			opGet("세션 계층 트리", session.SessionHierarchyNode{}),
+		}},
+		{Pattern: "/api/v2/sessions/hierarchy/", Path: "/api/v2/sessions/hierarchy/{id}/list", Tag: "sessions", Operations: []apiOperation{
+			opGet("루트 아래 세션 목록", []session.HierarchicalSession{}),
+		}},
+		{Pattern: "/api/v2/sessions/hierarchy/", Path: "/api/v2/sessions/hierarchy/{id}/stats", Tag: "sessions", Operations: []apiOperation{
+			opGet("계층 통계", session.HierarchyStats{}),
+		}},
+		{Pattern: "/api/v2/sessions/builds", Path: "/api/v2/sessions/builds", Tag: "sessions", Operations: []apiOperation{
+			opList("빌드 세션 목록", session.HierarchicalSession{}, qp("active", "true면 진행 중만")),
+		}},
+
+		// Attention
+		{Pattern: "/api/v2/attention", Path: "/api/v2/attention", Tag: "attention", Operations: []apiOperation{
+			opGet("세션 ID 필요 (항상 400)", ErrorResponse{}),
+		}},
+		{Pattern: "/api/v2/attention/", Path: "/api/v2/attention/{session_id}", Tag: "attention", Operations: []apiOperation{
+			opGet("세션 Attention 상태", apiObject{}),
+		}},
+		{Pattern: "/api/v2/attention/", Path: "/api/v2/attention/{session_id}/report", Tag: "attention", Operations: []apiOperation{
+			opGet("Attention 리포트", attention.AttentionReport{}),
+		}},
+		{Pattern: "/api/v2/attention/", Path: "/api/v2/attention/{session_id}/history", Tag: "attention", Operations: []apiOperation{
+			opGet("컴팩션 이력", []compact.Event{}, qp("limit", "최대 개수")),
+		}},
+		{Pattern: "/api/v2/attention/", Path: "/api/v2/attention/{session_id}/init", Tag: "attention", Operations: []apiOperation{
+			opBody(http.MethodPost, "Attention 추적 초기화", attentionInitRequest{}, apiStatus{}),
+		}},
+
+		// Handoffs
+		{Pattern: "/api/v2/handoffs", Path: "/api/v2/handoffs", Tag: "handoffs", Operations: []apiOperation{
+			opList("포트 핸드오프 목록", handoff.Handoff{}, qp("port", "포트 ID"), qp("direction", "from, to 또는 비움(양방향)")),
+			opBody(http.MethodPost, "핸드오프 생성", handoffCreateRequest{}, handoff.Handoff{}),
+		}},
+		{Pattern: "/api/v2/handoffs/", Path: "/api/v2/handoffs/estimate", Tag: "handoffs", Operations: []apiOperation{
+			opBody(http.MethodPost, "핸드오프 토큰 추정", handoffEstimateRequest{}, apiObject{}),
+		}},
+		{Pattern: "/api/v2/handoffs/", Path: "/api/v2/handoffs/{id}", Tag: "handoffs", Operations: []apiOperation{
+			opGet("핸드오프 상세", handoff.Handoff{}),
+		}},
+
+		// Agents (v2)
+		{Pattern: "/api/v2/agents", Path: "/api/v2/agents", Tag: "agents", Operations: []apiOperation{
+			opList("에이전트 목록", agentv2.Agent{}, qp("type", "에이전트 타입"), qp("include_system", "true면 시스템 에이전트 포함")),
+			opBody(http.MethodPost, "에이전트 생성", agentCreateRequest{}, agentv2.Agent{}),
+		}},
+		{Pattern: "/api/v2/agents/", Path: "/api/v2/agents/{id}", Tag: "agents", Operations: []apiOperation{
+			opGet("에이전트 상세", agentv2.Agent{}),
+		}},
+		{Pattern: "/api/v2/agents/", Path: "/api/v2/agents/{id}/spec", Tag: "agents", Operations: []apiOperation{
+			opGet("현재 버전 스펙", apiObject{}),
+		}},
+		{Pattern: "/api/v2/agents/", Path: "/api/v2/agents/{id}/versions", Tag: "agents", Operations: []apiOperation{
+			opGet("버전 목록", []agentv2.AgentVersion{}),
+			opBody(http.MethodPost, "새 버전 생성", agentVersionRequest{}, agentv2.AgentVersion{}),
+		}},
+		{Pattern: "/api/v2/agents/", Path: "/api/v2/agents/{id}/stats", Tag: "agents", Operations: []apiOperation{
+			opGet("버전 통계", agentv2.VersionStats{}, qp("version", "버전 (비우면 현재 버전)")),
+		}},
+		{Pattern: "/api/v2/agents/", Path: "/api/v2/agents/{id}/compare", Tag: "agents", Operations: []apiOperation{
+			opGet("버전 비교", agentv2.VersionComparison{}, qp("v1", "기준 버전"), qp("v2", "비교 버전")),
+		}},
+		{Pattern: "/api/v2/agents/global", Path: "/api/v2/agents/global", Tag: "agents", Operations: []apiOperation{
+			opGet("전역 에이전트/스킬/컨벤션 목록", apiObject{}, qp("type", "agents, skills 또는 conventions")),
+			opBody(http.MethodPost, "전역 에이전트 init, sync, reset", globalSyncRequest{}, apiObject{}, qp("action", "init, sync 또는 reset"), qp("force", "init 시 true면 덮어쓰기")),
+		}},
+		{Pattern: "/api/v2/agents/global/", Path: "/api/v2/agents/global/manifest", Tag: "agents", Operations: []apiOperation{
+			opGet("전역 매니페스트", apiObject{}),
+		}},
+		{Pattern: "/api/v2/agents/global/", Path: "/api/v2/agents/global/path", Tag: "agents", Operations: []apiOperation{
+			opGet("전역 디렉토리 경로", apiStatus{}),
+		}},
+		{Pattern: "/api/v2/agents/global/", Path: "/api/v2/agents/global/{path}", Tag: "agents", Operations: []apiOperation{
+			opGet("전역 파일 내용", apiObject{}),
+			opBody(http.MethodPut, "전역 파일 수정", contentRequest{}, apiStatus{}),
+			opBody(http.MethodDelete, "전역 파일 삭제", nil, apiStatus{}),
+		}},
+
+		// Messages
+		{Pattern: "/api/v2/messages", Path: "/api/v2/messages", Tag: "messages", Operations: []apiOperation{
+			opGet("대화 또는 세션 수신 메시지", []message.Message{}, qp("conversation", "대화 ID"), qp("session", "수신 세션 ID"), qp("limit", "최대 개수")),
+			opBody(http.MethodPost, "메시지 전송", message.Message{}, message.Message{}),
+		}},
+		{Pattern: "/api/v2/messages/", Path: "/api/v2/messages/{id}/delivered", Tag: "messages", Operations: []apiOperation{
+			opBody(http.MethodPost, "전달 완료 표시", nil, apiStatus{}),
+		}},
+		{Pattern: "/api/v2/messages/", Path: "/api/v2/messages/{id}/processed", Tag: "messages", Operations: []apiOperation{
+			opBody(http.MethodPost, "처리 완료 표시", nil, apiStatus{}),
+		}},
+
+		// Documents
+		{Pattern: "/api/v2/documents", Path: "/api/v2/documents", Tag: "documents", Operations: []apiOperation{
+			opList("문서 검색", apiObject{}, qp("q", "검색어"), qp("type", "문서 타입"), qp("domain", "도메인"), qp("status", "상태"), qp("tag", "태그")),
+			opBody(http.MethodPost, "문서 생성", documentWriteRequest{}, apiStatus{}),
+		}},
+		{Pattern: "/api/v2/documents/stats", Path: "/api/v2/documents/stats", Tag: "documents", Operations: []apiOperation{
+			opGet("문서 통계", document.DocStats{}),
+		}},
+		{Pattern: "/api/v2/documents/index", Path: "/api/v2/documents/index", Tag: "documents", Operations: []apiOperation{
+			opBody(http.MethodPost, "문서 재인덱싱", nil, apiObject{}),
+		}},
+		{Pattern: "/api/v2/documents/tree", Path: "/api/v2/documents/tree", Tag: "documents", Operations: []apiOperation{
+			opGet("문서 디렉토리 트리", DocumentTreeNode{}, qp("depth", "최대 깊이")),
+		}},
+		{Pattern: "/api/v2/documents/types", Path: "/api/v2/documents/types", Tag: "documents", Operations: []apiOperation{
+			opGet("문서 타입 레지스트리", []document.TypeDef{}),
+		}},
+		{Pattern: "/api/v2/documents/", Path: "/api/v2/documents/{id}", Tag: "documents", Operations: []apiOperation{
+			opGet("문서 상세 (링크 포함)", apiObject{}),
+			opBody(http.MethodPut, "문서 수정", documentWriteRequest{}, apiStatus{}),
+			opBody(http.MethodDelete, "문서 휴지통으로 이동", nil, apiStatus{}),
+		}},
+		{Pattern: "/api/v2/documents/", Path: "/api/v2/documents/{id}/content", Tag: "documents", Operations: []apiOperation{
+			opGet("문서 내용 (render=html이면 html 필드 추가)", apiStatus{}, qp("render", "html: 위키링크/구문 강조를 적용한 HTML 포함")),
+		}},
+		{Pattern: "/api/v2/documents/", Path: "/api/v2/documents/{id}/move", Tag: "documents", Operations: []apiOperation{
+			opBody(http.MethodPost, "문서 이동", documentMoveRequest{}, apiStatus{}),
+		}},
+
+		// Trash
+		{Pattern: "/api/v2/trash", Path: "/api/v2/trash", Tag: "trash", Operations: []apiOperation{
+			opList("휴지통 목록", trash.Item{}, qp("kind", "항목 종류")),
+		}},
+		{Pattern: "/api/v2/trash/", Path: "/api/v2/trash/{id}", Tag: "trash", Operations: []apiOperation{
+			opGet("휴지통 항목", trash.Item{}),
+			opBody(http.MethodDelete, "영구 삭제", nil, apiStatus{}),
+		}},
+		{Pattern: "/api/v2/trash/", Path: "/api/v2/trash/{id}/restore", Tag: "trash", Operations: []apiOperation{
+			opBody(http.MethodPost, "복원", nil, apiStatus{}),
+		}},
+
+		// Projects (v2)
+		{Pattern: "/api/v2/projects", Path: "/api/v2/projects", Tag: "projects", Operations: []apiOperation{
+			opList("등록된 프로젝트 목록", Project{}, qp("archived", "include 또는 only"), qp("hidden", "include")),
+		}},
+		{Pattern: "/api/v2/projects/import", Path: "/api/v2/projects/import", Tag: "projects", Operations: []apiOperation{
+			opBody(http.MethodPost, "기존 프로젝트 가져오기", projectPathRequest{}, apiObject{}),
+		}},
+		{Pattern: "/api/v2/projects/init", Path: "/api/v2/projects/init", Tag: "projects", Operations: []apiOperation{
+			opBody(http.MethodPost, "프로젝트 초기화", projectPathRequest{}, apiObject{}),
+		}},
+		{Pattern: "/api/v2/projects/", Path: "/api/v2/projects/{root}", Tag: "projects", Operations: []apiOperation{
+			opGet("프로젝트 상세", Project{}),
+			opBody(http.MethodPatch, "이름 변경, 보관, 숨김", projectUpdateRequest{}, Project{}),
+			opBody(http.MethodDelete, "프로젝트 등록 해제", nil, apiStatus{}),
+		}},
+		{Pattern: "/api/v2/projects/", Path: "/api/v2/projects/{root}/health", Tag: "projects", Operations: []apiOperation{
+			opGet("프로젝트 건강 점수와 요인별 원인", health.Report{}, qp("days", "이벤트 기반 지표의 조회 기간 (기본 14)")),
+		}},
+
+		// Knowledge Base
+		{Pattern: "/api/v2/kb/status", Path: "/api/v2/kb/status", Tag: "kb", Operations: []apiOperation{
+			opGet("KB 상태", apiObject{}),
+		}},
+		{Pattern: "/api/v2/kb/init", Path: "/api/v2/kb/init", Tag: "kb", Operations: []apiOperation{
+			opBody(http.MethodPost, "KB 초기화", kbInitRequest{}, apiStatus{}),
+		}},
+		{Pattern: "/api/v2/kb/toc", Path: "/api/v2/kb/toc", Tag: "kb", Operations: []apiOperation{
+			opGet("섹션별 목차 상태", []apiObject{}),
+		}},
+		{Pattern: "/api/v2/kb/toc/", Path: "/api/v2/kb/toc/{section}", Tag: "kb", Operations: []apiOperation{
+			opGet("섹션 목차", apiObject{}),
+		}},
+		{Pattern: "/api/v2/kb/toc/", Path: "/api/v2/kb/toc/{section}/generate", Tag: "kb", Operations: []apiOperation{
+			opBody(http.MethodPost, "목차 생성", kbTocGenerateRequest{}, kb.TOCStats{}),
+		}},
+		{Pattern: "/api/v2/kb/toc/", Path: "/api/v2/kb/toc/{section}/check", Tag: "kb", Operations: []apiOperation{
+			opGet("목차 검사", kb.TOCCheckResult{}),
+		}},
+		{Pattern: "/api/v2/kb/documents", Path: "/api/v2/kb/documents", Tag: "kb", Operations: []apiOperation{
+			opList("KB 문서 검색", kb.SearchResult{}, qp("q", "검색어"), qp("type", "문서 타입"), qp("domain", "도메인"), qp("status", "상태"), qp("tag", "태그"), qp("token_budget", "토큰 예산"), qp("content", "true면 본문까지 검색 (snippet 포함)")),
+			opBody(http.MethodPost, "KB 문서 생성", kbDocumentCreateRequest{}, apiStatus{}),
+		}},
+		{Pattern: "/api/v2/kb/documents/", Path: "/api/v2/kb/documents/{path}", Tag: "kb", Operations: []apiOperation{
+			opGet("KB 문서 메타데이터", apiObject{}),
+			opBody(http.MethodPut, "KB 문서 수정", contentRequest{}, apiStatus{}),
+			opBody(http.MethodDelete, "KB 문서 휴지통으로 이동", nil, apiStatus{}),
+		}},
+		{Pattern: "/api/v2/kb/documents/", Path: "/api/v2/kb/documents/{path}/content", Tag: "kb", Operations: []apiOperation{
+			opGet("KB 문서 내용 (render=html이면 html 필드 추가)", apiStatus{}, qp("render", "html: 위키링크/구문 강조를 적용한 HTML 포함")),
+		}},
+		{Pattern: "/api/v2/kb/documents/", Path: "/api/v2/kb/documents/{path}/move", Tag: "kb", Operations: []apiOperation{
+			opBody(http.MethodPost, "KB 문서 이동", documentMoveRequest{}, apiStatus{}),
+		}},
+		{Pattern: "/api/v2/kb/index", Path: "/api/v2/kb/index", Tag: "kb", Operations: []apiOperation{
+			opGet("KB 인덱스 통계", kb.IndexStats{}),
+			opBody(http.MethodPost, "KB 인덱스 재구축", nil, apiObject{}, qp("action", "update면 증분 갱신")),
+		}},
+		{Pattern: "/api/v2/kb/tags", Path: "/api/v2/kb/tags", Tag: "kb", Operations: []apiOperation{
+			opGet("태그별 문서 수", map[string]int{}),
+		}},
+		{Pattern: "/api/v2/kb/graph", Path: "/api/v2/kb/graph", Tag: "kb", Operations: []apiOperation{
+			opGet("KB 링크 그래프 (format=graphml/dot/mermaid면 텍스트)", kb.LinkGraph{},
+				qp("domain", "도메인 필터"), qp("tag", "태그 필터"), qp("min_degree", "최소 연결 수 (in + out)"),
+				qp("format", "json, graphml, dot, mermaid")),
+		}},
+		{Pattern: "/api/v2/kb/sections", Path: "/api/v2/kb/sections", Tag: "kb", Operations: []apiOperation{
+			opGet("KB 섹션 목록", []apiObject{}),
+		}},
+		{Pattern: "/api/v2/kb/register", Path: "/api/v2/kb/register", Tag: "kb", Operations: []apiOperation{
+			opBody(http.MethodPost, "프로젝트 문서를 KB에 등록", kbRegisterRequest{}, kb.RegisterResult{}),
+		}},
+		{Pattern: "/api/v2/kb/register/external", Path: "/api/v2/kb/register/external", Tag: "kb", Operations: []apiOperation{
+			opBody(http.MethodPost, "외부 문서를 KB에 등록", kbRegisterExternalRequest{}, kb.RegisterResult{}),
+		}},
+
+		// Events (SSE)
+		{Pattern: "/api/v2/events", Path: "/api/v2/events", Tag: "events", Operations: []apiOperation{
+			{Method: http.MethodGet, Summary: "실시간 이벤트 스트림 (SSE)", Stream: true},
+		}},
+		{Pattern: "/api/v2/events/stream", Path: "/api/v2/events/stream", Tag: "events", Operations: []apiOperation{
+			{Method: http.MethodGet, Summary: "실시간 이벤트 스트림 (SSE, /api/v2/events 별칭)", Stream: true},
+		}},
+		{Pattern: "/api/v2/events/status", Path: "/api/v2/events/status", Tag: "events", Operations: []apiOperation{
+			opGet("SSE 연결 상태", apiObject{}),
+		}},
+		{Pattern: "/api/v2/events/test", Path: "/api/v2/events/test", Tag: "events", Operations: []apiOperation{
+			opBody(http.MethodPost, "테스트 이벤트 발행", nil, apiStatus{}),
+		}},
+
+		// API documentation
+		{Pattern: "/api/openapi.json", Path: "/api/openapi.json", Tag: "meta", Operations: []apiOperation{
+			opGet("이 OpenAPI 문서", apiObject{}),
+		}},
+	}
+}
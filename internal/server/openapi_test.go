@@ -0,0 +1,111 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestOpenAPISpecUpToDate fails when openapi.json was not regenerated after a registry or DTO change
+func TestOpenAPISpecUpToDate(t *testing.T) {
+	data, err := BuildOpenAPISpec()
+	if err != nil {
+		t.Fatalf("BuildOpenAPISpec 실패: %v", err)
+	}
+	if !bytes.Equal(data, openAPISpec) {
+		t.Fatal("openapi.json이 최신이 아닙니다: go generate ./internal/server 로 갱신하세요")
+	}
+
+	var spec struct {
+		OpenAPI string                            `json:"openapi"`
+		Paths   map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("생성된 문서가 JSON이 아님: %v", err)
+	}
+	if spec.OpenAPI == "" || len(spec.Paths) == 0 {
+		t.Fatal("openapi 버전 또는 paths가 비어 있음")
+	}
+}
+
+// TestOpenAPICoversRoutes checks that every /api/ route registered on the mux is documented and vice versa
+func TestOpenAPICoversRoutes(t *testing.T) {
+	registered := registeredPatterns(t)
+
+	documented := make(map[string]bool)
+	paths := make(map[string]bool)
+	for _, ep := range apiEndpoints() {
+		documented[ep.Pattern] = true
+		if !strings.HasPrefix(ep.Path, strings.TrimSuffix(ep.Pattern, "/")) {
+			t.Errorf("%s: 경로가 패턴 %s 아래에 있지 않음", ep.Path, ep.Pattern)
+		}
+		if paths[ep.Path] {
+			t.Errorf("%s: 경로가 중복 등록됨", ep.Path)
+		}
+		paths[ep.Path] = true
+		if len(ep.Operations) == 0 {
+			t.Errorf("%s: 오퍼레이션이 없음", ep.Path)
+		}
+	}
+
+	for pattern := range registered {
+		if !documented[pattern] {
+			t.Errorf("라우트 %s가 apiEndpoints에 없음", pattern)
+		}
+	}
+	for pattern := range documented {
+		if !registered[pattern] {
+			t.Errorf("apiEndpoints의 %s가 등록된 라우트가 아님", pattern)
+		}
+	}
+}
+
+// registeredPatterns collects the string literal patterns passed to mux.Handle/HandleFunc in this package
+func registeredPatterns(t *testing.T) map[string]bool {
+	t.Helper()
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patterns := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatalf("%s 파싱 실패: %v", file, err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "HandleFunc" && sel.Sel.Name != "Handle") {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			if pattern, err := strconv.Unquote(lit.Value); err == nil && strings.HasPrefix(pattern, "/api/") {
+				patterns[pattern] = true
+			}
+			return true
+		})
+	}
+	if len(patterns) == 0 {
+		t.Fatal("등록된 라우트를 찾지 못함")
+	}
+	return patterns
+}
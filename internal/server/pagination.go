@@ -0,0 +1,349 @@
+package server
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Pagination limits shared by all list endpoints
+const (
+	maxPageLimit = 500  // limit 상한
+	maxPageScan  = 5000 // 한 번에 정렬/필터링하는 최대 항목 수
+)
+
+// List response headers
+const (
+	HeaderTotalCount = "X-Total-Count"
+	HeaderNextCursor = "X-Next-Cursor"
+)
+
+// PageInfo describes a page of a list response
+type PageInfo struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Sort       string `json:"sort,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PageDTO wraps a list page when envelope=true is requested
+type PageDTO struct {
+	Items interface{} `json:"items"`
+	Page  PageInfo    `json:"page"`
+}
+
+// listQuery is the pagination, sorting and filtering contract of list endpoints.
+//
+//	limit=50          페이지 크기 (엔드포인트별 기본값, 최대 500)
+//	offset=100        건너뛸 항목 수
+//	cursor=...        이전 응답의 X-Next-Cursor (offset보다 우선)
+//	sort=-created_at  JSON 필드 기준 정렬, '-' 접두사는 내림차순
+//	filter[status]=running  JSON 필드 값 일치 필터 (여러 개는 AND)
+//	envelope=true     배열 대신 {items, page} 형태로 응답
+type listQuery struct {
+	Limit    int
+	Offset   int
+	Sort     string
+	Desc     bool
+	Filters  map[string]string
+	Envelope bool
+}
+
+// parseListQuery parses the list contract from the request query
+func parseListQuery(r *http.Request, defaultLimit int) (listQuery, error) {
+	values := r.URL.Query()
+	q := listQuery{Limit: defaultLimit, Filters: make(map[string]string)}
+
+	if v := values.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return q, fmt.Errorf("잘못된 limit 값입니다: %s", v)
+		}
+		q.Limit = n
+	}
+	if q.Limit > maxPageLimit {
+		q.Limit = maxPageLimit
+	}
+
+	if v := values.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return q, fmt.Errorf("잘못된 offset 값입니다: %s", v)
+		}
+		q.Offset = n
+	}
+	if v := values.Get("cursor"); v != "" {
+		n, err := decodeCursor(v)
+		if err != nil {
+			return q, err
+		}
+		q.Offset = n
+	}
+
+	if v := strings.TrimSpace(values.Get("sort")); v != "" {
+		q.Desc = strings.HasPrefix(v, "-")
+		q.Sort = strings.TrimLeft(v, "-+")
+		if order := values.Get("order"); order != "" {
+			q.Desc = strings.EqualFold(order, "desc")
+		}
+	}
+
+	for key, vals := range values {
+		if strings.HasPrefix(key, "filter[") && strings.HasSuffix(key, "]") && len(vals) > 0 {
+			field := strings.TrimSuffix(strings.TrimPrefix(key, "filter["), "]")
+			if field != "" {
+				q.Filters[field] = vals[0]
+			}
+		}
+	}
+
+	q.Envelope, _ = strconv.ParseBool(values.Get("envelope"))
+	return q, nil
+}
+
+// Cursors are opaque to clients; currently they encode the next offset
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("o:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(raw), "o:") {
+		if n, err := strconv.Atoi(strings.TrimPrefix(string(raw), "o:")); err == nil && n >= 0 {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("잘못된 cursor 값입니다: %s", cursor)
+}
+
+// paginate filters, sorts and slices items according to q
+func paginate[T any](items []T, q listQuery) ([]T, PageInfo, error) {
+	filtered := items
+	if len(q.Filters) > 0 {
+		filtered = make([]T, 0, len(items))
+		for _, item := range items {
+			ok, err := matchFilters(reflect.ValueOf(item), q.Filters)
+			if err != nil {
+				return nil, PageInfo{}, err
+			}
+			if ok {
+				filtered = append(filtered, item)
+			}
+		}
+	}
+
+	if q.Sort != "" && len(filtered) > 0 {
+		if _, ok := jsonField(reflect.ValueOf(filtered[0]), q.Sort); !ok {
+			return nil, PageInfo{}, fmt.Errorf("정렬할 수 없는 필드입니다: %s", q.Sort)
+		}
+		sorted := append([]T{}, filtered...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a, _ := jsonField(reflect.ValueOf(sorted[i]), q.Sort)
+			b, _ := jsonField(reflect.ValueOf(sorted[j]), q.Sort)
+			if q.Desc {
+				return compareValues(b, a) < 0
+			}
+			return compareValues(a, b) < 0
+		})
+		filtered = sorted
+	}
+
+	page := PageInfo{Total: len(filtered), Limit: q.Limit, Offset: q.Offset}
+	if q.Sort != "" {
+		page.Sort = q.Sort
+		if q.Desc {
+			page.Sort = "-" + q.Sort
+		}
+	}
+
+	start := q.Offset
+	if start > len(filtered) {
+		start = len(filtered)
+	}
+	end := len(filtered)
+	if q.Limit > 0 && start+q.Limit < end {
+		end = start + q.Limit
+		page.NextCursor = encodeCursor(end)
+	}
+	return filtered[start:end], page, nil
+}
+
+// writeList writes a paginated list response.
+// 본문은 기존과 같은 배열이며, 전체 개수와 다음 커서는 헤더(X-Total-Count, X-Next-Cursor, Link)로 전달합니다.
+func writeList[T any](s *Server, w http.ResponseWriter, r *http.Request, items []T, defaultLimit int) {
+	q, err := parseListQuery(r, defaultLimit)
+	if err != nil {
+		s.errorResponse(w, 400, err.Error())
+		return
+	}
+	pageItems, page, err := paginate(items, q)
+	if err != nil {
+		s.errorResponse(w, 400, err.Error())
+		return
+	}
+	if pageItems == nil {
+		pageItems = []T{}
+	}
+
+	w.Header().Set(HeaderTotalCount, strconv.Itoa(page.Total))
+	if page.NextCursor != "" {
+		w.Header().Set(HeaderNextCursor, page.NextCursor)
+		next := *r.URL
+		values := next.Query()
+		values.Del("offset")
+		values.Set("cursor", page.NextCursor)
+		next.RawQuery = values.Encode()
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.RequestURI()))
+	}
+	w.Header().Set("Access-Control-Expose-Headers", strings.Join([]string{HeaderTotalCount, HeaderNextCursor, "Link"}, ", "))
+
+	if q.Envelope {
+		s.jsonResponse(w, PageDTO{Items: pageItems, Page: page})
+		return
+	}
+	s.jsonResponse(w, pageItems)
+}
+
+func matchFilters(v reflect.Value, filters map[string]string) (bool, error) {
+	for field, want := range filters {
+		got, ok := jsonField(v, field)
+		if !ok {
+			return false, fmt.Errorf("필터할 수 없는 필드입니다: %s", field)
+		}
+		if !strings.EqualFold(valueString(got), want) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// jsonField returns the value of a struct field (by JSON name) or map key
+func jsonField(v reflect.Value, name string) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		mv := v.MapIndex(reflect.ValueOf(name))
+		if !mv.IsValid() {
+			// 맵 항목은 키가 없을 수 있으므로 빈 값으로 취급
+			return reflect.ValueOf(""), true
+		}
+		return mv, true
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tag := strings.Split(f.Tag.Get("json"), ",")[0]
+			if tag == "-" {
+				continue
+			}
+			if tag == name || (tag == "" && strings.EqualFold(f.Name, name)) {
+				return sqlValue(v.Field(i)), true
+			}
+			if f.Anonymous && tag == "" {
+				if fv, ok := jsonField(v.Field(i), name); ok {
+					return fv, true
+				}
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// sqlValue unwraps sql.Null* fields so they filter and sort by their value
+func sqlValue(v reflect.Value) reflect.Value {
+	if v.CanInterface() {
+		if valuer, ok := v.Interface().(driver.Valuer); ok {
+			if val, err := valuer.Value(); err == nil {
+				if val == nil {
+					return reflect.ValueOf("")
+				}
+				return reflect.ValueOf(val)
+			}
+		}
+	}
+	return v
+}
+
+func valueString(v reflect.Value) string {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// compareValues orders two field values (숫자, 시간, 불리언, 문자열 순으로 비교)
+func compareValues(a, b reflect.Value) int {
+	for a.IsValid() && (a.Kind() == reflect.Ptr || a.Kind() == reflect.Interface) && !a.IsNil() {
+		a = a.Elem()
+	}
+	for b.IsValid() && (b.Kind() == reflect.Ptr || b.Kind() == reflect.Interface) && !b.IsNil() {
+		b = b.Elem()
+	}
+	if fa, ok := numeric(a); ok {
+		if fb, ok := numeric(b); ok {
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			}
+			return 0
+		}
+	}
+	if a.IsValid() && b.IsValid() && a.CanInterface() && b.CanInterface() {
+		ta, okA := a.Interface().(time.Time)
+		tb, okB := b.Interface().(time.Time)
+		if okA && okB {
+			return ta.Compare(tb)
+		}
+	}
+	return strings.Compare(valueString(a), valueString(b))
+}
+
+func numeric(v reflect.Value) (float64, bool) {
+	if !v.IsValid() {
+		return 0, false
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Bool:
+		if v.Bool() {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// getList requests a list endpoint and returns the response with its decoded body
+func getList(t *testing.T, h http.Handler, path string, out interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if rec.Code == http.StatusOK && out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s: %v (%s)", path, err, rec.Body.String())
+		}
+	}
+	return rec
+}
+
+func TestListPaginationPorts(t *testing.T) {
+	h := newWriteTestServer(t)
+	for i := 1; i <= 5; i++ {
+		doJSON(t, h, "POST", "/api/ports", fmt.Sprintf(`{"id":"p-%d","title":"포트 %d"}`, i, i), 201)
+	}
+
+	// 첫 페이지: 전체 개수와 다음 커서
+	var page []PortDTO
+	rec := getList(t, h, "/api/ports?sort=id&limit=2", &page)
+	if len(page) != 2 || page[0].ID != "p-1" || page[1].ID != "p-2" {
+		t.Fatalf("page 1 = %+v", page)
+	}
+	if rec.Header().Get(HeaderTotalCount) != "5" {
+		t.Errorf("total = %q", rec.Header().Get(HeaderTotalCount))
+	}
+	cursor := rec.Header().Get(HeaderNextCursor)
+	if cursor != encodeCursor(2) || rec.Header().Get("Link") == "" {
+		t.Errorf("next cursor = %q, link = %q", cursor, rec.Header().Get("Link"))
+	}
+
+	// 커서는 offset보다 우선
+	rec = getList(t, h, "/api/ports?sort=id&limit=2&offset=0&cursor="+cursor, &page)
+	if len(page) != 2 || page[0].ID != "p-3" || rec.Header().Get(HeaderNextCursor) != encodeCursor(4) {
+		t.Errorf("page 2 = %+v, next = %q", page, rec.Header().Get(HeaderNextCursor))
+	}
+
+	// 마지막 페이지에는 다음 커서가 없음 (남은 항목이 limit과 정확히 같은 경우 포함)
+	for _, path := range []string{"/api/ports?sort=id&limit=2&cursor=" + encodeCursor(4), "/api/ports?sort=id&limit=5"} {
+		rec = getList(t, h, path, &page)
+		if rec.Header().Get(HeaderNextCursor) != "" || rec.Header().Get("Link") != "" {
+			t.Errorf("%s: 마지막 페이지에 다음 커서 %q", path, rec.Header().Get(HeaderNextCursor))
+		}
+	}
+	if len(page) != 5 {
+		t.Errorf("limit=5 page = %d items", len(page))
+	}
+
+	// 범위를 벗어난 offset은 빈 배열
+	rec = getList(t, h, "/api/ports?offset=99", &page)
+	if rec.Code != http.StatusOK || len(page) != 0 || rec.Body.String() == "null\n" {
+		t.Errorf("offset beyond total = %d %s", rec.Code, rec.Body.String())
+	}
+
+	// envelope
+	var env struct {
+		Items []PortDTO `json:"items"`
+		Page  PageInfo  `json:"page"`
+	}
+	getList(t, h, "/api/ports?sort=-id&limit=3&envelope=true", &env)
+	if len(env.Items) != 3 || env.Items[0].ID != "p-5" || env.Page.Total != 5 || env.Page.Sort != "-id" || env.Page.NextCursor != encodeCursor(3) {
+		t.Errorf("envelope = %+v", env)
+	}
+
+	// 잘못된 파라미터는 400
+	for _, query := range []string{
+		"limit=0", "limit=-1", "limit=abc", "offset=-1", "offset=x",
+		"cursor=!!!", "cursor=" + "bm9wZQ", // base64("nope")
+		"sort=no_such_field", "filter[no_such_field]=x",
+	} {
+		if rec := getList(t, h, "/api/ports?"+query, nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s = %d, want 400", query, rec.Code)
+		}
+	}
+}
+
+func TestListLimitBounds(t *testing.T) {
+	s := NewServer(Config{DBPath: filepath.Join(t.TempDir(), "test.db")})
+	t.Cleanup(func() { s.Close() })
+	items := make([]int, maxPageLimit+20)
+	for i := range items {
+		items[i] = i
+	}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { writeList(s, w, r, items, 30) })
+
+	var page []int
+	getList(t, h, "/list", &page)
+	if len(page) != 30 {
+		t.Errorf("default limit = %d", len(page))
+	}
+	rec := getList(t, h, "/list?limit=100000", &page)
+	if len(page) != maxPageLimit || rec.Header().Get(HeaderNextCursor) != encodeCursor(maxPageLimit) {
+		t.Errorf("limit above max = %d items, next = %q", len(page), rec.Header().Get(HeaderNextCursor))
+	}
+	rec = getList(t, h, "/list?limit=100000&cursor="+encodeCursor(maxPageLimit), &page)
+	if len(page) != 20 || page[0] != maxPageLimit || rec.Header().Get(HeaderNextCursor) != "" {
+		t.Errorf("last page = %d items from %v, next = %q", len(page), page, rec.Header().Get(HeaderNextCursor))
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/project"
+)
+
+// projectHeader scopes a request to one registered project (same as ?project=).
+// 대시보드의 프로젝트 전환기는 선택한 루트를 이 헤더로 모든 요청에 붙입니다.
+const projectHeader = "X-PAL-Project"
+
+// requestedProject returns the project root the client asked for, or "" when the request is unscoped
+func requestedProject(r *http.Request) string {
+	root := r.Header.Get(projectHeader)
+	if root == "" {
+		root = r.URL.Query().Get("project")
+	}
+	if root == "" {
+		return ""
+	}
+	return filepath.Clean(root)
+}
+
+// scopedProject validates the requested project; "" means the request is not scoped.
+// 서버 시작 프로젝트 외에는 projects 테이블에 등록된 프로젝트만 허용합니다 (없으면 404).
+func (s *Server) scopedProject(r *http.Request) (string, error) {
+	root := requestedProject(r)
+	if root == "" || root == filepath.Clean(s.config.ProjectRoot) {
+		return root, nil
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		return "", err
+	}
+	if _, err := project.NewService(database).Get(root); err != nil {
+		return "", err
+	}
+	return root, nil
+}
+
+// projectRoot resolves the project a request operates on, defaulting to the server's startup project
+func (s *Server) projectRoot(r *http.Request) (string, error) {
+	root, err := s.scopedProject(r)
+	if err != nil {
+		return "", err
+	}
+	if root == "" {
+		return s.config.ProjectRoot, nil
+	}
+	return root, nil
+}
+
+// projectSessionIDs returns the IDs of sessions recorded under root (포트는 세션을 통해 프로젝트에 속함)
+func projectSessionIDs(database *db.DB, root string) (map[string]bool, error) {
+	rows, err := database.Query(`SELECT id FROM sessions WHERE project_root = ?`, root)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids[id] = true
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/escalation"
+	"github.com/n0roo/pal-kit/internal/message"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+// readOnlyMiddleware rejects every mutating request (POST, PUT, PATCH, DELETE)
+func (s *Server) readOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+		default:
+			s.errorResponse(w, http.StatusForbidden, "read-only mode: mutating requests are disabled")
+		}
+	})
+}
+
+// hideCostMiddleware strips cost fields (키 이름에 "cost" 포함) from JSON responses.
+// SSE 등 JSON이 아닌 응답은 그대로 전달합니다.
+func (s *Server) hideCostMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cw := &costFilterWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(cw, r)
+		cw.finish()
+	})
+}
+
+// costFilterWriter buffers JSON responses so cost fields can be removed before writing
+type costFilterWriter struct {
+	http.ResponseWriter
+	status      int
+	decided     bool
+	passthrough bool
+	buf         bytes.Buffer
+}
+
+func (w *costFilterWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	w.passthrough = !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")
+	if w.passthrough {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+}
+
+func (w *costFilterWriter) WriteHeader(status int) {
+	w.status = status
+	w.decide()
+}
+
+func (w *costFilterWriter) Write(b []byte) (int, error) {
+	w.decide()
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buf.Write(b)
+}
+
+// Flush keeps streaming responses (SSE) working through the filter
+func (w *costFilterWriter) Flush() {
+	w.decide()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok && w.passthrough {
+		f.Flush()
+	}
+}
+
+func (w *costFilterWriter) finish() {
+	w.decide()
+	if w.passthrough {
+		return
+	}
+
+	out := w.buf.Bytes()
+	var data interface{}
+	if err := json.Unmarshal(out, &data); err == nil {
+		if filtered, err := json.Marshal(stripCost(data)); err == nil {
+			out = append(filtered, '\n')
+		}
+	}
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(out)
+}
+
+// stripCost removes cost fields recursively
+func stripCost(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if strings.Contains(strings.ToLower(k), "cost") {
+				delete(t, k)
+				continue
+			}
+			t[k] = stripCost(child)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = stripCost(child)
+		}
+	}
+	return v
+}
+
+// ShareOrchestration is an orchestration summary on the share page
+type ShareOrchestration struct {
+	ID              string                           `json:"id"`
+	Title           string                           `json:"title"`
+	Status          orchestrator.OrchestrationStatus `json:"status"`
+	ProgressPercent int                              `json:"progress_percent"`
+	CurrentPortID   string                           `json:"current_port_id,omitempty"`
+	Ports           []orchestrator.AtomicPort        `json:"ports"`
+	Stats           *orchestrator.OrchestrationStats `json:"stats,omitempty"`
+	StartedAt       *time.Time                       `json:"started_at,omitempty"`
+}
+
+// handleShareStatus returns the stakeholder status summary (?orch=<id>로 특정 Orchestration만 조회)
+func (s *Server) handleShareStatus(w http.ResponseWriter, r *http.Request) {
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	sessionSvc := session.NewService(database)
+	orchSvc := orchestrator.NewService(database, sessionSvc, message.NewStore(database.DB))
+
+	var orchs []*orchestrator.OrchestrationPort
+	if id := r.URL.Query().Get("orch"); id != "" {
+		orch, err := orchSvc.GetOrchestration(id)
+		if err != nil {
+			s.errorResponse(w, 404, err.Error())
+			return
+		}
+		orchs = append(orchs, orch)
+	} else {
+		orchs, err = orchSvc.ListOrchestrations("", 10)
+		if err != nil {
+			s.errorResponse(w, 500, err.Error())
+			return
+		}
+	}
+
+	items := make([]ShareOrchestration, 0, len(orchs))
+	for _, o := range orchs {
+		item := ShareOrchestration{
+			ID:              o.ID,
+			Title:           o.Title,
+			Status:          o.Status,
+			ProgressPercent: o.ProgressPercent,
+			CurrentPortID:   o.CurrentPortID,
+			Ports:           o.AtomicPorts,
+			StartedAt:       o.StartedAt,
+		}
+		if stats, err := orchSvc.GetOrchestrationStats(o.ID); err == nil {
+			item.Stats = stats
+		}
+		items = append(items, item)
+	}
+
+	openEscalations := 0
+	if list, err := escalation.NewService(database).List("open", 100); err == nil {
+		openEscalations = len(list)
+	}
+
+	s.jsonResponse(w, map[string]interface{}{
+		"generated_at":     time.Now(),
+		"orchestrations":   items,
+		"open_escalations": openEscalations,
+	})
+}
+
+// handleSharePage serves the simplified stakeholder status page
+func (s *Server) handleSharePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" && r.URL.Path != "/share" {
+		http.NotFound(w, r)
+		return
+	}
+	page, err := staticFiles.ReadFile("static/share.html")
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(page)
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	s := NewServer(Config{DBPath: filepath.Join(t.TempDir(), "test.db"), ReadOnly: true})
+	t.Cleanup(func() { s.Close() })
+
+	called := 0
+	h := s.wrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, "/api/ports/p-1", strings.NewReader(`{"status":"failed"}`)))
+		if rec.Code != http.StatusForbidden && rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s = %d, want 403/405", method, rec.Code)
+		}
+	}
+	if called != 0 {
+		t.Errorf("변경 요청이 핸들러까지 전달됨 (%d회)", called)
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, "/api/ports", nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s = %d, want 200", method, rec.Code)
+		}
+	}
+}
+
+func TestHideCostMiddleware(t *testing.T) {
+	s := NewServer(Config{DBPath: filepath.Join(t.TempDir(), "test.db"), HideCost: true})
+	t.Cleanup(func() { s.Close() })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"p-1","cost_usd":1.5,"port":{"title":"cart","TotalCost":2,` +
+			`"sessions":[{"id":"s-1","costUSD":3,"tokens":10}]},"costs":[1,2]}`))
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"cost_usd": 1.5`))
+	})
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("cost_usd: 1.5\n"))
+	})
+	h := s.wrapHandler(mux)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/json", nil))
+	if rec.Code != http.StatusCreated {
+		t.Errorf("status = %d, want 201", rec.Code)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("응답이 JSON이 아님: %v (%s)", err, rec.Body.String())
+	}
+	want := map[string]interface{}{
+		"id": "p-1",
+		"port": map[string]interface{}{
+			"title":    "cart",
+			"sessions": []interface{}{map[string]interface{}{"id": "s-1", "tokens": float64(10)}},
+		},
+	}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("body = %s, want %s", gotJSON, wantJSON)
+	}
+
+	// 파싱할 수 없는 JSON과 JSON이 아닌 응답은 그대로
+	for path, body := range map[string]string{"/broken": `{"cost_usd": 1.5`, "/text": "cost_usd: 1.5\n"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Body.String() != body {
+			t.Errorf("%s body = %q, want %q", path, rec.Body.String(), body)
+		}
+	}
+}
+
+func TestShareDoesNotStartScheduler(t *testing.T) {
+	started := make(chan struct{}, 1)
+	schedulerHook = func() { started <- struct{}{} }
+	defer func() { schedulerHook = nil }()
+
+	run := func(cfg Config) bool {
+		cfg.DBPath = filepath.Join(t.TempDir(), "test.db")
+		s := NewServer(cfg)
+		done := make(chan error, 1)
+		go func() { done <- s.Start() }()
+		time.Sleep(200 * time.Millisecond)
+		if err := s.Stop(); err != nil {
+			t.Fatal(err)
+		}
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-started:
+			return true
+		default:
+			return false
+		}
+	}
+
+	if run(Config{Share: true}) {
+		t.Error("share 페이지가 스케줄러를 시작함")
+	}
+	if !run(Config{}) {
+		t.Error("일반 모드에서 스케줄러가 시작되지 않음 (hook 확인)")
+	}
+}
+
+func TestShareStatus(t *testing.T) {
+	s := NewServer(Config{DBPath: filepath.Join(t.TempDir(), "test.db"), Share: true, ReadOnly: true, HideCost: true})
+	t.Cleanup(func() { s.Close() })
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/share/status", s.handleShareStatus)
+	h := s.wrapHandler(mux)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/share/status", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"orchestrations"`) {
+		t.Errorf("share status = %d %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/share/status", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("POST share status = %d, want 403", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/share/status?orch=missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown orchestration = %d, want 404", rec.Code)
+	}
+}
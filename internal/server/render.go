@@ -0,0 +1,59 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/n0roo/pal-kit/internal/document"
+	"github.com/n0roo/pal-kit/internal/kb"
+	"github.com/n0roo/pal-kit/internal/markdown"
+)
+
+// wantsHTML reports whether the client asked for server-rendered markdown (?render=html)
+func wantsHTML(r *http.Request) (bool, error) {
+	switch v := r.URL.Query().Get("render"); v {
+	case "", "raw":
+		return false, nil
+	case "html":
+		return true, nil
+	default:
+		return false, fmt.Errorf("render must be html or raw: %s", v)
+	}
+}
+
+// renderDocumentHTML renders a project document, linking [[wikilinks]] to indexed documents
+func renderDocumentHTML(docSvc *document.Service, fromPath, content string) (string, error) {
+	idx, err := docSvc.LinkIndex()
+	if err != nil {
+		return "", err
+	}
+	return markdown.Render(content, markdown.Options{
+		ResolveLink: func(target string) (string, bool) {
+			id, ok := idx.Resolve(target, fromPath)
+			if !ok {
+				return "", false
+			}
+			return "/api/v2/documents/" + url.PathEscape(id), true
+		},
+	}), nil
+}
+
+// renderKBHTML renders a KB note, linking [[wikilinks]] to notes in the vault
+func renderKBHTML(vaultPath, docPath, content string) string {
+	resolve := kb.NewLinkService(vaultPath).Resolver(docPath)
+	return markdown.Render(content, markdown.Options{
+		ResolveLink: func(target string) (string, bool) {
+			path, ok := resolve(target)
+			if !ok {
+				return "", false
+			}
+			segments := strings.Split(path, "/")
+			for i := range segments {
+				segments[i] = url.PathEscape(segments[i])
+			}
+			return "/api/v2/kb/documents/" + strings.Join(segments, "/"), true
+		},
+	})
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func TestDocumentContentRenderHTML(t *testing.T) {
+	root := t.TempDir()
+	vault := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	files := map[string]string{
+		filepath.Join(root, "ports", "auth.md"):        "# Auth\n\n[[login]] [[nope]]\n\n```go\nreturn nil\n```\n",
+		filepath.Join(root, "ports", "login.md"):       "# Login\n",
+		filepath.Join(vault, "10-Domains", "auth.md"):  "# KB\n\n[[token]]\n",
+		filepath.Join(vault, "10-Domains", "token.md"): "# Token\n",
+	}
+	for path, content := range files {
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	database, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("DB 열기 실패: %v", err)
+	}
+	database.Exec(`INSERT INTO documents (id, path, type, domain, status, priority, content_hash) VALUES ('ports-auth', 'ports/auth.md', 'port', '', 'active', 'medium', 'h1'), ('ports-login', 'ports/login.md', 'port', '', 'active', 'medium', 'h2')`)
+	database.Close()
+
+	s := NewServer(Config{DBPath: dbPath, ProjectRoot: root, VaultPath: vault})
+	t.Cleanup(func() { s.Close() })
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/documents/", s.withCORS(s.handleDocumentDetail))
+	mux.HandleFunc("/api/v2/kb/documents/", s.withCORS(s.handleKBDocumentDetail))
+
+	raw := doJSON(t, mux, "GET", "/api/v2/documents/ports-auth/content", "", 200)
+	if _, ok := raw["html"]; ok {
+		t.Error("render 없이 html이 포함됨")
+	}
+
+	out := doJSON(t, mux, "GET", "/api/v2/documents/ports-auth/content?render=html", "", 200)
+	html, _ := out["html"].(string)
+	for _, want := range []string{
+		`<a class="wikilink" href="/api/v2/documents/ports-login">login</a>`,
+		`<span class="wikilink broken" title="nope">nope</span>`,
+		`<code class="language-go"><span class="hl-kw">return</span> <span class="hl-kw">nil</span>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("문서 html에 %q 없음\n%s", want, html)
+		}
+	}
+
+	kbOut := doJSON(t, mux, "GET", "/api/v2/kb/documents/10-Domains/auth.md/content?render=html", "", 200)
+	if kbHTML, _ := kbOut["html"].(string); !strings.Contains(kbHTML, `href="/api/v2/kb/documents/10-Domains/token.md"`) {
+		t.Errorf("KB 위키링크가 해석되지 않음\n%s", kbHTML)
+	}
+
+	doJSON(t, mux, "GET", "/api/v2/documents/ports-auth/content?render=pdf", "", 400)
+}
@@ -0,0 +1,253 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/digest"
+	"github.com/n0roo/pal-kit/internal/lock"
+	"github.com/n0roo/pal-kit/internal/notify"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
+	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/primer"
+	"github.com/n0roo/pal-kit/internal/project"
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+// scheduleTickInterval is how often the daemon checks for due schedules
+const scheduleTickInterval = 30 * time.Second
+
+// schedulerHook is called when the scheduler starts (테스트에서 시작 여부를 관찰할 때 교체)
+var schedulerHook func()
+
+// runScheduler executes due orchestration schedules while the server is running
+func (s *Server) runScheduler(stop <-chan struct{}) {
+	if schedulerHook != nil {
+		schedulerHook()
+	}
+	ticker := time.NewTicker(scheduleTickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.runDueSchedules(now)
+			s.pauseIdleSessions()
+			s.sendDueDigests(now)
+			s.refreshPrimers(now)
+			s.escalateLockContention(now)
+			s.archiveExpiredPorts(now)
+		}
+	}
+}
+
+func (s *Server) runDueSchedules(now time.Time) {
+	database, err := s.getDB()
+	if err != nil {
+		log.Printf("⚠️  스케줄러 DB 열기 실패: %v", err)
+		return
+	}
+
+	svc := orchestrator.NewService(database, nil, nil)
+	runs, err := svc.RunDueSchedules(now)
+	if err != nil {
+		log.Printf("⚠️  스케줄 실행 실패: %v", err)
+		return
+	}
+
+	for _, run := range runs {
+		if run.Status == orchestrator.ScheduleRunFailed {
+			log.Printf("⏰ 스케줄 %s 실행 실패: %s", run.ScheduleID, run.Error)
+			continue
+		}
+		log.Printf("⏰ 스케줄 %s 실행: orchestration %s", run.ScheduleID, run.OrchestrationID)
+	}
+}
+
+// pauseIdleSessions pauses idle sessions per project (프로젝트별 idle_pause_after 적용)
+func (s *Server) pauseIdleSessions() {
+	database, err := s.getDB()
+	if err != nil {
+		return
+	}
+
+	svc := session.NewService(database)
+	roots, err := svc.RunningProjectRoots()
+	if err != nil {
+		log.Printf("⚠️  유휴 세션 확인 실패: %v", err)
+		return
+	}
+
+	for _, root := range roots {
+		window := config.DefaultIdlePauseAfter
+		if projectCfg, err := config.LoadProjectConfig(root); err == nil {
+			window = projectCfg.Settings.IdlePauseWindow()
+		}
+		paused, err := svc.PauseIdleSessions(root, window)
+		if err != nil {
+			log.Printf("⚠️  유휴 세션 일시정지 실패 (%s): %v", root, err)
+			continue
+		}
+		for _, p := range paused {
+			log.Printf("💤 세션 %s 일시정지 (%d분 유휴, Lock %d개 해제)", p.ID, p.IdleMinutes, len(p.ReleasedLocks))
+		}
+	}
+}
+
+// sendDueDigests emails the weekly report for projects whose digest schedule is due
+func (s *Server) sendDueDigests(now time.Time) {
+	database, err := s.getDB()
+	if err != nil {
+		return
+	}
+
+	svc := digest.NewService(database)
+	for _, root := range s.knownProjectRoots(database) {
+		projectCfg, err := config.LoadProjectConfig(root)
+		if err != nil || !projectCfg.Notifications.Digest.Enabled() {
+			continue
+		}
+		run, err := svc.RunDue(projectCfg.Notifications.Digest, root, now)
+		if err != nil {
+			log.Printf("⚠️  다이제스트 발송 실패 (%s): %v", root, err)
+			continue
+		}
+		if run != nil {
+			log.Printf("📧 주간 리포트 발송 (%s): %d명", root, len(run.Recipients))
+		}
+	}
+}
+
+// refreshPrimers regenerates stale project primers so SessionStart can inject them without recomputing
+func (s *Server) refreshPrimers(now time.Time) {
+	database, err := s.getDB()
+	if err != nil {
+		return
+	}
+
+	for _, root := range s.knownProjectRoots(database) {
+		if _, err := os.Stat(filepath.Join(root, ".pal")); err != nil {
+			continue
+		}
+		interval := config.DefaultPrimerRefresh
+		if projectCfg, err := config.LoadProjectConfig(root); err == nil {
+			interval = projectCfg.Settings.PrimerRefreshInterval()
+		}
+		if interval == 0 {
+			continue
+		}
+		if _, err := primer.NewService(database, root).Refresh(interval, now); err != nil {
+			log.Printf("⚠️  프라이머 갱신 실패 (%s): %v", root, err)
+		}
+	}
+}
+
+// escalateLockContention escalates locks waited on past the holder project's lock_escalation thresholds.
+// 대기 세션이 재시도하지 않아도 오래 기다린 경합이 조용히 방치되지 않도록 데몬이 주기적으로 확인합니다.
+func (s *Server) escalateLockContention(now time.Time) {
+	database, err := s.getDB()
+	if err != nil {
+		return
+	}
+
+	configs := map[string]*config.ProjectConfig{}
+	loadConfig := func(root string) *config.ProjectConfig {
+		if cfg, ok := configs[root]; ok {
+			return cfg
+		}
+		cfg, err := config.LoadProjectConfig(root)
+		if err != nil {
+			cfg = nil
+		}
+		configs[root] = cfg
+		return cfg
+	}
+
+	escalated, err := lock.NewService(database).EscalateExceeded(func(c *lock.Contention) (lock.EscalationPolicy, bool) {
+		var settings config.LockEscalationSettings
+		if c.Project != "" {
+			if cfg := loadConfig(c.Project); cfg != nil {
+				settings = cfg.Settings.LockEscalation
+			}
+		}
+		return lock.PolicyFromSettings(settings)
+	}, now)
+	if err != nil {
+		log.Printf("⚠️  Lock 경합 에스컬레이션 실패: %v", err)
+	}
+
+	for _, c := range escalated {
+		log.Printf("🔒 Lock 경합 에스컬레이션 #%d: %s", c.EscalationID, c.Issue(now))
+		if c.Project == "" {
+			continue
+		}
+		cfg := loadConfig(c.Project)
+		if cfg == nil || len(cfg.Notifications.Routes) == 0 {
+			continue
+		}
+		errs := notify.NewRouter(cfg.Notifications, c.Project).Dispatch(notify.Notification{
+			Type:        notify.TypeLockContention,
+			Message:     fmt.Sprintf("#%d %s", c.EscalationID, c.Issue(now)),
+			SessionID:   c.Holder,
+			ProjectRoot: c.Project,
+		})
+		for _, e := range errs {
+			log.Printf("⚠️  알림 전달 실패 (%s): %v", c.Project, e)
+		}
+	}
+}
+
+// archiveExpiredPorts applies each project's port retention policy (settings.port_archive_days)
+func (s *Server) archiveExpiredPorts(now time.Time) {
+	database, err := s.getDB()
+	if err != nil {
+		return
+	}
+
+	svc := port.NewService(database)
+	for _, root := range s.knownProjectRoots(database) {
+		if _, err := os.Stat(filepath.Join(root, ".pal")); err != nil {
+			continue
+		}
+		olderThan := time.Duration(config.DefaultPortArchiveDays) * 24 * time.Hour
+		if projectCfg, err := config.LoadProjectConfig(root); err == nil {
+			olderThan = projectCfg.Settings.PortArchiveAfter()
+		}
+		if olderThan == 0 {
+			continue
+		}
+		archived, err := svc.ArchiveExpired(root, olderThan, now)
+		if err != nil {
+			log.Printf("⚠️  포트 아카이브 실패 (%s): %v", root, err)
+		}
+		if len(archived) > 0 {
+			log.Printf("📦 포트 %d개 아카이브 (%s)", len(archived), root)
+		}
+	}
+}
+
+// knownProjectRoots returns the server's project and all registered projects
+func (s *Server) knownProjectRoots(database *db.DB) []string {
+	seen := map[string]bool{}
+	var roots []string
+	add := func(root string) {
+		if root != "" && !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+	add(s.config.ProjectRoot)
+	if projects, err := project.NewService(database).List(project.ListOptions{}); err == nil {
+		for _, p := range projects {
+			add(p.Root)
+		}
+	}
+	return roots
+}
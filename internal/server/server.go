@@ -2,17 +2,21 @@ package server
 
 import (
 	"context"
+	"database/sql"
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/agent"
+	"github.com/n0roo/pal-kit/internal/compact"
 	"github.com/n0roo/pal-kit/internal/config"
 	"github.com/n0roo/pal-kit/internal/convention"
 	"github.com/n0roo/pal-kit/internal/db"
@@ -21,9 +25,13 @@ import (
 	"github.com/n0roo/pal-kit/internal/history"
 	"github.com/n0roo/pal-kit/internal/lock"
 	"github.com/n0roo/pal-kit/internal/manifest"
+	"github.com/n0roo/pal-kit/internal/metrics"
+	"github.com/n0roo/pal-kit/internal/orchestrator"
 	"github.com/n0roo/pal-kit/internal/pipeline"
 	"github.com/n0roo/pal-kit/internal/port"
+	"github.com/n0roo/pal-kit/internal/server/events"
 	"github.com/n0roo/pal-kit/internal/session"
+	"github.com/n0roo/pal-kit/internal/usage"
 )
 
 //go:embed static/*
@@ -35,23 +43,48 @@ type Config struct {
 	ProjectRoot string
 	DBPath      string
 	VaultPath   string // Knowledge Base vault path
+
+	ReadOnly bool // 모든 변경 요청(POST/PUT/PATCH/DELETE) 차단, 스케줄러 비활성화
+	Share    bool // 관계자용 간이 상태 페이지만 제공 (ReadOnly 포함)
+	HideCost bool // JSON 응답에서 비용 필드 제거
+	KBWatch  bool // KB vault 감시 (변경 시 재색인 후 kb:updated 이벤트)
+
+	DB db.PoolOptions // 공유 DB 연결 풀 설정 (0이면 기본값)
 }
 
 // Server represents the web server
 type Server struct {
-	config Config
-	srv    *http.Server
+	config  Config
+	srv     *http.Server
+	stop    chan struct{}
+	metrics *metrics.Registry
+	sse     *SSEHub
+
+	dbMu sync.Mutex
+	db   *db.DB // 모든 핸들러가 공유하는 연결 풀 (첫 사용 시 열고 Stop에서 닫음)
+
+	lifecycleMu sync.Mutex     // srv, sse (Start/Stop이 다른 goroutine에서 호출됨)
+	background  sync.WaitGroup // 스케줄러 등 백그라운드 작업
+	stopOnce    sync.Once
+	stopped     chan struct{} // Stop의 드레이닝이 끝나면 닫힘
 }
 
 // NewServer creates a new server
 func NewServer(config Config) *Server {
 	return &Server{
-		config: config,
+		config:  config,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		metrics: newMetricsRegistry(),
 	}
 }
 
 // Start starts the server
 func (s *Server) Start() error {
+	if s.config.Share {
+		return s.startShare()
+	}
+
 	mux := http.NewServeMux()
 
 	// API routes
@@ -63,13 +96,16 @@ func (s *Server) Start() error {
 	mux.HandleFunc("/api/sessions/history", s.withCORS(s.handleSessionHistory))
 	mux.HandleFunc("/api/sessions/", s.withCORS(s.handleSessionDetail))
 	mux.HandleFunc("/api/ports", s.withCORS(s.handlePorts))
+	mux.HandleFunc("/api/ports/", s.withCORS(s.handlePortDetail))
 	mux.HandleFunc("/api/pipelines", s.withCORS(s.handlePipelines))
 	mux.HandleFunc("/api/agents", s.withCORS(s.handleAgents))
 	mux.HandleFunc("/api/docs", s.withCORS(s.handleDocs))
 	mux.HandleFunc("/api/docs/content", s.withCORS(s.handleDocContent))
 	mux.HandleFunc("/api/conventions", s.withCORS(s.handleConventions))
 	mux.HandleFunc("/api/locks", s.withCORS(s.handleLocks))
+	mux.HandleFunc("/api/locks/", s.withCORS(s.handleLockDetail))
 	mux.HandleFunc("/api/escalations", s.withCORS(s.handleEscalations))
+	mux.HandleFunc("/api/escalations/", s.withCORS(s.handleEscalationDetail))
 	mux.HandleFunc("/api/manifest", s.withCORS(s.handleManifest))
 	mux.HandleFunc("/api/manifest/changes", s.withCORS(s.handleManifestChanges))
 
@@ -79,6 +115,10 @@ func (s *Server) Start() error {
 	mux.HandleFunc("/api/history/projects", s.withCORS(s.handleHistoryProjects))
 	mux.HandleFunc("/api/history/stats", s.withCORS(s.handleHistoryStats))
 	mux.HandleFunc("/api/history/export", s.withCORS(s.handleHistoryExport))
+	mux.HandleFunc("/api/history/state", s.withCORS(s.handleHistoryState))
+
+	// Usage analytics
+	mux.HandleFunc("/api/stats/heatmap", s.withCORS(s.handleStatsHeatmap))
 
 	// Session visualization API
 	mux.HandleFunc("/api/sessions/tree", s.withCORS(s.handleSessionTree))
@@ -94,14 +134,46 @@ func (s *Server) Start() error {
 	// KB API routes
 	s.RegisterKBRoutes(mux)
 
+	// 세션 이벤트에 프로젝트를 기록하여 SSE ?project= 필터가 적용되도록 함
+	events.GetPublisher().SetProjectResolver(s.sessionProject)
+
 	// SSE (Server-Sent Events) for real-time updates
 	sseHub := NewSSEHub()
 	go sseHub.Run()
 	s.RegisterSSERoutes(mux, sseHub)
+	s.lifecycleMu.Lock()
+	s.sse = sseHub
+	s.lifecycleMu.Unlock()
+
+	// Prometheus metrics
+	db.SetQueryObserver(s.observeQuery)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+
+	// Orchestration scheduler (cron) - read-only 모드에서는 상태를 바꾸지 않도록 비활성화
+	if !s.config.ReadOnly {
+		s.background.Add(1)
+		go func() {
+			defer s.background.Done()
+			s.runScheduler(s.stop)
+		}()
+	}
+
+	// KB watch mode: 문서 변경을 재색인하고 SSE로 알림
+	if s.config.KBWatch {
+		s.background.Add(1)
+		go func() {
+			defer s.background.Done()
+			s.runKBWatch(s.stop)
+		}()
+	}
 
 	// v2 Status endpoint
 	mux.HandleFunc("/api/v2/status", s.withCORS(s.handleV2Status))
 
+	// API documentation (OpenAPI 3 + Swagger UI)
+	mux.HandleFunc("/api/openapi.json", s.withCORS(s.handleOpenAPI))
+	mux.HandleFunc("/swagger", s.handleSwaggerUI)
+
 	// Static files
 	staticFS, err := fs.Sub(staticFiles, "static")
 	if err != nil {
@@ -109,12 +181,9 @@ func (s *Server) Start() error {
 	}
 	mux.Handle("/", http.FileServer(http.FS(staticFS)))
 
-	// Wrap entire mux with CORS middleware
-	corsHandler := s.corsMiddleware(mux)
-
-	s.srv = &http.Server{
+	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", s.config.Port),
-		Handler:      corsHandler,
+		Handler:      s.wrapHandler(mux),
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second, // Increased for SSE
 	}
@@ -124,14 +193,109 @@ func (s *Server) Start() error {
 	log.Printf("📁 Projects API available at /api/v2/projects/*")
 	log.Printf("📚 KB API available at /api/v2/kb/*")
 	log.Printf("🔔 SSE events at /api/v2/events")
-	return s.srv.ListenAndServe()
+	log.Printf("📖 API docs at /swagger (spec: /api/openapi.json)")
+	log.Printf("📈 Prometheus metrics at /metrics")
+	if s.config.KBWatch {
+		log.Printf("👁️  Watching KB vault %s (kb:updated events)", s.getVaultPath())
+	}
+	if s.config.ReadOnly {
+		log.Printf("🔒 Read-only mode: mutating requests and scheduler disabled")
+	} else {
+		log.Printf("⏰ Orchestration scheduler enabled")
+	}
+	return s.serve(srv)
+}
+
+// startShare serves only the simplified stakeholder status page
+func (s *Server) startShare() error {
+	s.config.ReadOnly = true
+
+	staticFS, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		return fmt.Errorf("static files: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/share/status", s.withCORS(s.handleShareStatus))
+	mux.Handle("/style.css", http.FileServer(http.FS(staticFS)))
+	mux.HandleFunc("/", s.handleSharePage)
+
+	srv := &http.Server{
+		Addr:         fmt.Sprintf(":%d", s.config.Port),
+		Handler:      s.wrapHandler(mux),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	log.Printf("👀 PAL Kit share page running at http://localhost:%d (read-only)", s.config.Port)
+	if s.config.HideCost {
+		log.Printf("💸 Cost data hidden")
+	}
+	return s.serve(srv)
 }
 
-// Stop gracefully stops the server
+// wrapHandler applies CORS and the read-only/hide-cost middlewares
+func (s *Server) wrapHandler(mux http.Handler) http.Handler {
+	var h http.Handler = mux
+	if s.config.HideCost {
+		h = s.hideCostMiddleware(h)
+	}
+	if s.config.ReadOnly {
+		h = s.readOnlyMiddleware(h)
+	}
+	// Wrap entire mux with CORS middleware
+	return s.corsMiddleware(h)
+}
+
+// serve runs the listener and, after Stop, waits until draining has finished
+func (s *Server) serve(srv *http.Server) error {
+	s.lifecycleMu.Lock()
+	select {
+	case <-s.stop:
+		s.lifecycleMu.Unlock()
+		return nil
+	default:
+	}
+	s.srv = srv
+	s.lifecycleMu.Unlock()
+
+	err := srv.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		<-s.stopped
+		return nil
+	}
+	return err
+}
+
+// shutdownTimeout bounds how long Stop waits for in-flight requests
+const shutdownTimeout = 10 * time.Second
+
+// Stop gracefully stops the server: 새 연결을 받지 않고 SSE 스트림을 끊은 뒤,
+// 처리 중인 요청과 스케줄러 작업이 끝나기를 기다리고 공유 DB를 닫습니다.
 func (s *Server) Stop() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	return s.srv.Shutdown(ctx)
+	var err error
+	s.stopOnce.Do(func() {
+		defer close(s.stopped)
+
+		s.lifecycleMu.Lock()
+		close(s.stop)
+		srv, sse := s.srv, s.sse
+		s.lifecycleMu.Unlock()
+
+		if sse != nil {
+			sse.Stop()
+		}
+		if srv != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			err = srv.Shutdown(ctx)
+		}
+		s.background.Wait()
+		if closeErr := s.Close(); err == nil {
+			err = closeErr
+		}
+	})
+	return err
 }
 
 // corsMiddleware wraps a handler with CORS headers for all requests
@@ -139,8 +303,8 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Set CORS headers for all requests
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, Idempotency-Key, "+projectHeader)
 		w.Header().Set("Access-Control-Max-Age", "86400")
 
 		// Handle preflight requests
@@ -180,9 +344,33 @@ func (s *Server) errorResponse(w http.ResponseWriter, status int, message string
 	json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
 
-// Database helper
+// getDB returns the shared connection pool, opening it on first use.
+// 핸들러는 반환된 DB를 닫지 않습니다 (Stop/Close에서 닫힘).
 func (s *Server) getDB() (*db.DB, error) {
-	return db.Open(s.config.DBPath)
+	s.dbMu.Lock()
+	defer s.dbMu.Unlock()
+
+	if s.db == nil {
+		database, err := db.OpenPool(s.config.DBPath, s.config.DB)
+		if err != nil {
+			return nil, err
+		}
+		s.db = database
+	}
+	return s.db, nil
+}
+
+// Close closes the shared DB pool (Stop 이후 또는 테스트 정리용)
+func (s *Server) Close() error {
+	s.dbMu.Lock()
+	defer s.dbMu.Unlock()
+
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db = nil
+	return err
 }
 
 // handleStatus returns overall status
@@ -192,10 +380,14 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
 
 	status := map[string]interface{}{
-		"project_root": s.config.ProjectRoot,
+		"project_root": root,
 		"timestamp":    time.Now().Format(time.RFC3339),
 	}
 
@@ -237,8 +429,22 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Executions (Orchestration + 이관 전 파이프라인)
+	if executions, err := orchestrator.NewService(database, nil, nil).ListExecutions("", 100); err == nil {
+		running := 0
+		for _, e := range executions {
+			if e.Status == "running" {
+				running++
+			}
+		}
+		status["executions"] = map[string]int{
+			"running": running,
+			"total":   len(executions),
+		}
+	}
+
 	// Docs
-	docsSvc := docs.NewService(s.config.ProjectRoot)
+	docsSvc := docs.NewService(root)
 	if documents, err := docsSvc.List(); err == nil {
 		status["docs"] = map[string]int{
 			"total": len(documents),
@@ -246,7 +452,7 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Conventions
-	convSvc := convention.NewService(s.config.ProjectRoot)
+	convSvc := convention.NewService(root)
 	if conventions, err := convSvc.List(); err == nil {
 		enabled := 0
 		for _, c := range conventions {
@@ -279,25 +485,51 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, status)
 }
 
-// handleSessions returns session list
+// handleSessions returns session list (POST: 세션 시작)
 func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST":
+		s.createSession(w, r)
+		return
+	default:
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
 	database, err := s.getDB()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
+
+	root, err := s.scopedProject(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
 
 	svc := session.NewService(database)
 	
 	// Use detailed list for richer info
-	details, err := svc.ListDetailed(false, 50)
+	details, err := svc.ListDetailed(false, maxPageScan)
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
 
-	s.jsonResponse(w, toSessionDetailDTOs(details))
+	// 프로젝트가 지정되면 해당 프로젝트 세션만
+	if root != "" {
+		scoped := details[:0]
+		for _, d := range details {
+			if d.ProjectRoot.String == root {
+				scoped = append(scoped, d)
+			}
+		}
+		details = scoped
+	}
+
+	writeList(s, w, r, toSessionDetailDTOs(details), 50)
 }
 
 // handleSessionStats returns session statistics
@@ -307,7 +539,6 @@ func (s *Server) handleSessionStats(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := session.NewService(database)
 	stats, err := svc.GetStats()
@@ -326,7 +557,6 @@ func (s *Server) handleSessionHistory(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	// Default to 30 days
 	days := 30
@@ -361,12 +591,25 @@ func (s *Server) handleSessionDetail(w http.ResponseWriter, r *http.Request) {
 	id := parts[0]
 	isEventsRequest := len(parts) > 1 && parts[1] == "events"
 
+	switch r.Method {
+	case "GET":
+	case "PATCH", "DELETE":
+		if len(parts) > 1 {
+			s.errorResponse(w, 405, "Method not allowed")
+			return
+		}
+		s.updateSession(w, r, id)
+		return
+	default:
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
 	database, err := s.getDB()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := session.NewService(database)
 
@@ -406,27 +649,64 @@ func (s *Server) handleSessionDetail(w http.ResponseWriter, r *http.Request) {
 		"session":  toSessionDetailDTO(*detail),
 		"children": toSessionDTOs(children),
 	}
+	if env, err := svc.GetEnvSnapshot(id); err == nil && env != nil {
+		response["env"] = env
+	}
+	if compactions, err := compact.NewService(database).History(id, 20); err == nil {
+		response["compactions"] = compactions
+	}
 
 	s.jsonResponse(w, response)
 }
 
-// handlePorts returns port list
+// handlePorts returns port list (POST: 포트 생성)
 func (s *Server) handlePorts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST":
+		s.createPort(w, r)
+		return
+	default:
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
 	database, err := s.getDB()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
+
+	root, err := s.scopedProject(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
 
 	svc := port.NewService(database)
-	ports, err := svc.List("", 50)
+	ports, err := svc.List("", maxPageScan)
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
 
-	s.jsonResponse(w, toPortDTOs(ports))
+	// 프로젝트가 지정되면 해당 프로젝트 세션에 연결된 포트만
+	if root != "" {
+		ids, err := projectSessionIDs(database, root)
+		if err != nil {
+			s.errorResponse(w, 500, err.Error())
+			return
+		}
+		scoped := ports[:0]
+		for _, p := range ports {
+			if ids[p.SessionID.String] {
+				scoped = append(scoped, p)
+			}
+		}
+		ports = scoped
+	}
+
+	writeList(s, w, r, toPortDTOs(ports), 50)
 }
 
 // handlePipelines returns pipeline list
@@ -436,21 +716,25 @@ func (s *Server) handlePipelines(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := pipeline.NewService(database)
-	pipelines, err := svc.List("", 50)
+	pipelines, err := svc.List("", maxPageScan)
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
 
-	s.jsonResponse(w, toPipelineDTOs(pipelines))
+	writeList(s, w, r, toPipelineDTOs(pipelines), 50)
 }
 
 // handleAgents returns agent list
 func (s *Server) handleAgents(w http.ResponseWriter, r *http.Request) {
-	svc := agent.NewService(s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	svc := agent.NewService(root)
 
 	agents, err := svc.List()
 	if err != nil {
@@ -458,19 +742,24 @@ func (s *Server) handleAgents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.jsonResponse(w, agents)
+	writeList(s, w, r, agents, 0)
 }
 
 // handleDocs returns document list
 func (s *Server) handleDocs(w http.ResponseWriter, r *http.Request) {
-	svc := docs.NewService(s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	svc := docs.NewService(root)
 	documents, err := svc.List()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
 
-	s.jsonResponse(w, documents)
+	writeList(s, w, r, documents, 0)
 }
 
 // handleDocContent returns the content of a specific document
@@ -481,7 +770,13 @@ func (s *Server) handleDocContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	svc := docs.NewService(s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	svc := docs.NewService(root)
 	content, err := svc.GetContent(path)
 	if err != nil {
 		s.errorResponse(w, 404, err.Error())
@@ -504,24 +799,38 @@ func (s *Server) handleDocContent(w http.ResponseWriter, r *http.Request) {
 
 // handleConventions returns convention list
 func (s *Server) handleConventions(w http.ResponseWriter, r *http.Request) {
-	svc := convention.NewService(s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	svc := convention.NewService(root)
 	conventions, err := svc.List()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
 
-	s.jsonResponse(w, conventions)
+	writeList(s, w, r, conventions, 0)
 }
 
-// handleLocks returns lock list
+// handleLocks returns lock list (POST: Lock 획득)
 func (s *Server) handleLocks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST":
+		s.acquireLock(w, r)
+		return
+	default:
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
 	database, err := s.getDB()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := lock.NewService(database)
 	locks, err := svc.List()
@@ -530,7 +839,7 @@ func (s *Server) handleLocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.jsonResponse(w, toLockDTOs(locks))
+	writeList(s, w, r, toLockDTOs(locks), 0)
 }
 
 // handleProjects returns registered projects list
@@ -540,11 +849,11 @@ func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	rows, err := database.Query(`
 		SELECT root, name, description, last_active, session_count, total_tokens, total_cost, created_at
 		FROM projects
+		WHERE root NOT IN (SELECT root FROM project_states WHERE archived = 1 OR hidden = 1)
 		ORDER BY last_active DESC
 	`)
 	if err != nil {
@@ -573,15 +882,12 @@ func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
 			"total_tokens":  totalTokens,
 			"total_cost":    totalCost,
 			"created_at":    createdAt,
+			"current":       root == s.config.ProjectRoot,
 		}
 		projects = append(projects, project)
 	}
 
-	if projects == nil {
-		projects = []map[string]interface{}{}
-	}
-
-	s.jsonResponse(w, projects)
+	writeList(s, w, r, projects, 0)
 }
 
 // handleProjectDetail returns detailed project information
@@ -597,7 +903,6 @@ func (s *Server) handleProjectDetail(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	// Get project from DB
 	var name string
@@ -705,23 +1010,32 @@ func (s *Server) handleProjectDetail(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, result)
 }
 
-// handleEscalations returns escalation list
+// handleEscalations returns escalation list (POST: 에스컬레이션 생성)
 func (s *Server) handleEscalations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST":
+		s.createEscalation(w, r)
+		return
+	default:
+		s.errorResponse(w, 405, "Method not allowed")
+		return
+	}
+
 	database, err := s.getDB()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := escalation.NewService(database)
-	escalations, err := svc.List("", 50)
+	escalations, err := svc.List("", maxPageScan)
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
 
-	s.jsonResponse(w, toEscalationDTOs(escalations))
+	writeList(s, w, r, toEscalationDTOs(escalations), 50)
 }
 
 // Run starts the server (convenience function)
@@ -759,9 +1073,14 @@ func (s *Server) handleManifest(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	manifestSvc := manifest.NewService(database, s.config.ProjectRoot)
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	manifestSvc := manifest.NewService(database, root)
 	statuses, err := manifestSvc.Status()
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
@@ -770,7 +1089,7 @@ func (s *Server) handleManifest(w http.ResponseWriter, r *http.Request) {
 
 	// 상태별 분류
 	result := map[string]interface{}{
-		"project_root": s.config.ProjectRoot,
+		"project_root": root,
 		"files":        statuses,
 		"summary": map[string]int{
 			"total":    len(statuses),
@@ -791,23 +1110,53 @@ func (s *Server) handleManifestChanges(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
-	limit := 50
-	if l := r.URL.Query().Get("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
-			limit = parsed
-		}
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
 	}
 
-	manifestSvc := manifest.NewService(database, s.config.ProjectRoot)
-	changes, err := manifestSvc.GetChanges(limit)
+	manifestSvc := manifest.NewService(database, root)
+	changes, err := manifestSvc.GetChanges(maxPageScan)
 	if err != nil {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
 
-	s.jsonResponse(w, changes)
+	writeList(s, w, r, changes, 50)
+}
+
+// handleStatsHeatmap returns session usage by weekday/hour and agent or project
+func (s *Server) handleStatsHeatmap(w http.ResponseWriter, r *http.Request) {
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	opts := usage.HeatmapOptions{
+		Project: requestedProject(r),
+		GroupBy: q.Get("by"),
+	}
+	days := 30
+	if d := q.Get("days"); d != "" {
+		if parsed, err := strconv.Atoi(d); err == nil && parsed >= 0 {
+			days = parsed
+		}
+	}
+	if days > 0 {
+		opts.Since = time.Now().AddDate(0, 0, -days)
+	}
+
+	heatmap, err := usage.NewService(database).GetHeatmap(opts)
+	if err != nil {
+		s.errorResponse(w, 400, err.Error())
+		return
+	}
+
+	s.jsonResponse(w, heatmap)
 }
 
 // countByStatus counts files by status
@@ -828,7 +1177,6 @@ func (s *Server) handleHistoryEvents(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	// Parse filter parameters
 	filter := history.Filter{}
@@ -839,7 +1187,7 @@ func (s *Server) handleHistoryEvents(w http.ResponseWriter, r *http.Request) {
 	if v := r.URL.Query().Get("event_type"); v != "" {
 		filter.EventType = v
 	}
-	if v := r.URL.Query().Get("project"); v != "" {
+	if v := requestedProject(r); v != "" {
 		filter.ProjectRoot = v
 	}
 	if v := r.URL.Query().Get("search"); v != "" {
@@ -888,7 +1236,6 @@ func (s *Server) handleHistoryTypes(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := history.NewService(database)
 	types, err := svc.GetEventTypes()
@@ -907,7 +1254,6 @@ func (s *Server) handleHistoryProjects(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := history.NewService(database)
 	projects, err := svc.GetProjects()
@@ -919,6 +1265,36 @@ func (s *Server) handleHistoryProjects(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, projects)
 }
 
+// handleHistoryState returns the project state as of a point in time
+// GET /api/history/state?as_of=2025-06-01&project=/path/to/project
+func (s *Server) handleHistoryState(w http.ResponseWriter, r *http.Request) {
+	asOf := r.URL.Query().Get("as_of")
+	if asOf == "" {
+		s.errorResponse(w, 400, "as_of is required")
+		return
+	}
+	at, err := history.ParseAsOf(asOf)
+	if err != nil {
+		s.errorResponse(w, 400, err.Error())
+		return
+	}
+
+	database, err := s.getDB()
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	svc := history.NewService(database)
+	snap, err := svc.StateAsOf(at, requestedProject(r))
+	if err != nil {
+		s.errorResponse(w, 500, err.Error())
+		return
+	}
+
+	s.jsonResponse(w, snap)
+}
+
 // handleHistoryStats returns history statistics
 func (s *Server) handleHistoryStats(w http.ResponseWriter, r *http.Request) {
 	database, err := s.getDB()
@@ -926,7 +1302,6 @@ func (s *Server) handleHistoryStats(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := history.NewService(database)
 	stats, err := svc.GetStats()
@@ -945,7 +1320,6 @@ func (s *Server) handleHistoryExport(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	// Parse filter (same as handleHistoryEvents)
 	filter := history.Filter{}
@@ -955,7 +1329,7 @@ func (s *Server) handleHistoryExport(w http.ResponseWriter, r *http.Request) {
 	if v := r.URL.Query().Get("event_type"); v != "" {
 		filter.EventType = v
 	}
-	if v := r.URL.Query().Get("project"); v != "" {
+	if v := requestedProject(r); v != "" {
 		filter.ProjectRoot = v
 	}
 	if v := r.URL.Query().Get("search"); v != "" {
@@ -1006,7 +1380,6 @@ func (s *Server) handleSessionTree(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	svc := session.NewService(database)
 
@@ -1043,12 +1416,17 @@ func (s *Server) handlePortFlow(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	sessionID := r.URL.Query().Get("session")
 
+	root, err := s.projectRoot(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
 	portSvc := port.NewService(database)
-	docsSvc := docs.NewService(s.config.ProjectRoot)
+	docsSvc := docs.NewService(root)
 
 	// Get ports
 	var ports []port.Port
@@ -1142,7 +1520,6 @@ func (s *Server) handlePortProgress(w http.ResponseWriter, r *http.Request) {
 		s.errorResponse(w, 500, err.Error())
 		return
 	}
-	defer database.Close()
 
 	portSvc := port.NewService(database)
 	ports, err := portSvc.List("", 100)
@@ -1181,3 +1558,14 @@ func (s *Server) handlePortProgress(w http.ResponseWriter, r *http.Request) {
 
 	s.jsonResponse(w, result)
 }
+
+// sessionProject returns the project root of a session (없으면 빈 문자열)
+func (s *Server) sessionProject(sessionID string) string {
+	database, err := s.getDB()
+	if err != nil {
+		return ""
+	}
+	var root sql.NullString
+	database.QueryRow(`SELECT project_root FROM sessions WHERE id = ?`, sessionID).Scan(&root)
+	return root.String
+}
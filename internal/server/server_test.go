@@ -0,0 +1,43 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStopDrainsAndClosesDB(t *testing.T) {
+	s := NewServer(Config{Port: 0, DBPath: filepath.Join(t.TempDir(), "test.db"), ReadOnly: true})
+
+	started := make(chan error, 1)
+	go func() { started <- s.Start() }()
+
+	// 첫 요청 전에도 풀은 한 번만 열리고 재사용되어야 함
+	first, err := s.getDB()
+	if err != nil {
+		t.Fatalf("getDB 실패: %v", err)
+	}
+	if second, _ := s.getDB(); second != first {
+		t.Error("getDB가 매번 새 연결을 열었음")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if err := s.Stop(); err != nil {
+		t.Fatalf("Stop 실패: %v", err)
+	}
+	select {
+	case err := <-started:
+		if err != nil {
+			t.Errorf("Start 반환값 = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop 후 Start가 반환되지 않음")
+	}
+
+	if err := first.Ping(); err == nil {
+		t.Error("Stop 후에도 공유 DB가 열려 있음")
+	}
+	if err := s.Stop(); err != nil {
+		t.Errorf("두 번째 Stop = %v", err)
+	}
+}
@@ -772,7 +772,8 @@ async function loadAllData() {
         loadWorkflows(),
         loadDocs(),
         loadConventions(),
-        loadAgents()
+        loadAgents(),
+        loadManifest()
     ]);
     updateLastRefresh();
 }
@@ -1397,6 +1398,39 @@ async function loadAgents() {
     `).join('');
 }
 
+// Manifest
+async function loadManifest() {
+    const data = await fetchAPI('manifest');
+    const tbody = document.getElementById('manifest-table');
+    const files = data && Array.isArray(data.files) ? data.files : [];
+
+    if (files.length === 0) {
+        tbody.innerHTML = '<tr><td colspan="6" class="empty-state">No tracked files</td></tr>';
+        return;
+    }
+
+    files.sort((a, b) => (a.path || '').localeCompare(b.path || ''));
+    tbody.innerHTML = files.map(f => {
+        const p = f.provenance || {};
+        return `
+        <tr>
+            <td>${statusBadge(f.status)}</td>
+            <td class="text-sm">${escapeHtml(f.path)}</td>
+            <td>${escapeHtml(f.type)}</td>
+            <td class="text-sm">${p.created_at ? provenanceLabel(p.created_session, p.created_port, p.created_at) : escapeHtml(f.managed_by)}</td>
+            <td class="text-sm">${p.modified_at ? provenanceLabel(p.modified_session, p.modified_port, p.modified_at) : '-'}</td>
+            <td>${p.edit_count ?? 0}</td>
+        </tr>
+    `;
+    }).join('');
+}
+
+function provenanceLabel(sessionId, portId, at) {
+    const session = sessionId ? escapeHtml(sessionId.substring(0, 8)) : '-';
+    const port = portId ? ` / ${escapeHtml(portId)}` : '';
+    return `${session}${port}<br><span class="muted">${formatTimeAgo(at)}</span>`;
+}
+
 // Helpers
 function escapeHtml(text) {
     if (text === null || text === undefined) return '-';
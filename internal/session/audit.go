@@ -0,0 +1,38 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/audit"
+	"github.com/n0roo/pal-kit/internal/config"
+)
+
+// auditEvent appends an event to the project's JSONL audit log.
+// 감사 로그는 보조 기록이므로 실패해도 이벤트 기록을 막지 않습니다.
+// 샘플링된 이벤트는 개별로 기록되므로 events_collapsed 요약은 남기지 않습니다.
+func (s *Service) auditEvent(sessionID, eventType, eventData string) {
+	if eventType == EventCollapsed {
+		return
+	}
+	var projectRoot string
+	err := s.db.QueryRow(`SELECT COALESCE(project_root, '') FROM sessions WHERE id = ?`, sessionID).Scan(&projectRoot)
+	if err != nil || projectRoot == "" {
+		return
+	}
+	if _, err := os.Stat(filepath.Join(projectRoot, ".pal")); err != nil {
+		return
+	}
+
+	var settings config.AuditSettings
+	if cfg, err := config.LoadProjectConfig(projectRoot); err == nil {
+		settings = cfg.Settings.Audit
+	}
+	if settings.Disabled {
+		return
+	}
+
+	log := audit.NewLog(projectRoot, audit.Options{MaxSize: settings.MaxSizeBytes(), Retention: settings.Retention()})
+	log.Append(audit.NewRecord(sessionID, eventType, eventData, time.Now()))
+}
@@ -0,0 +1,187 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventBudgetWarning is logged when usage crosses a budget threshold
+const EventBudgetWarning = "budget_warning"
+
+// BudgetThresholds are the usage percentages that raise a budget warning
+var BudgetThresholds = []int{50, 80, 100}
+
+// Budget scopes and metrics
+const (
+	BudgetScopeSession = "session"
+	BudgetScopeDaily   = "daily"
+
+	BudgetMetricUSD    = "usd"
+	BudgetMetricTokens = "tokens"
+)
+
+// BudgetLimits holds per-session and per-day budgets (0 = 제한 없음)
+type BudgetLimits struct {
+	SessionUSD    float64
+	SessionTokens int64
+	DailyUSD      float64
+	DailyTokens   int64
+}
+
+// Enabled reports whether any budget is set
+func (l BudgetLimits) Enabled() bool {
+	return l.SessionUSD > 0 || l.SessionTokens > 0 || l.DailyUSD > 0 || l.DailyTokens > 0
+}
+
+// BudgetAlarm is a budget threshold crossed by a session
+type BudgetAlarm struct {
+	Key       string  `json:"key"`
+	Scope     string  `json:"scope"`  // session, daily
+	Metric    string  `json:"metric"` // usd, tokens
+	Threshold int     `json:"threshold"`
+	Percent   int     `json:"percent"`
+	Used      float64 `json:"used"`
+	Limit     float64 `json:"limit"`
+}
+
+// Message returns a human readable warning for the alarm
+func (a BudgetAlarm) Message() string {
+	scope := "세션"
+	if a.Scope == BudgetScopeDaily {
+		scope = "오늘"
+	}
+	if a.Metric == BudgetMetricUSD {
+		return fmt.Sprintf("%s 예산 %d%% 도달: $%.2f / $%.2f", scope, a.Percent, a.Used, a.Limit)
+	}
+	return fmt.Sprintf("%s 토큰 예산 %d%% 도달: %.0f / %.0f", scope, a.Percent, a.Used, a.Limit)
+}
+
+// BudgetStatus is the usage of a session against its budgets
+type BudgetStatus struct {
+	Usage    []BudgetAlarm `json:"usage"`            // 설정된 예산별 현재 사용률 (Threshold 없음)
+	Alarms   []BudgetAlarm `json:"alarms,omitempty"` // 이번 검사에서 새로 넘은 임계치
+	Exceeded bool          `json:"exceeded"`         // 하나 이상의 예산이 100% 이상
+}
+
+// BudgetUsage computes a session's usage against the limits without logging.
+// daily 범위는 같은 프로젝트에서 오늘(로컬 기준) 시작된 세션 합계입니다.
+func (s *Service) BudgetUsage(sessionID string, limits BudgetLimits) (*BudgetStatus, error) {
+	status := &BudgetStatus{}
+	if !limits.Enabled() {
+		return status, nil
+	}
+
+	var projectRoot string
+	var sessionTokens int64
+	var sessionCost float64
+	err := s.db.QueryRow(`
+		SELECT COALESCE(project_root, ''),
+		       input_tokens + output_tokens + cache_read_tokens + cache_create_tokens, cost_usd
+		FROM sessions WHERE id = ?
+	`, sessionID).Scan(&projectRoot, &sessionTokens, &sessionCost)
+	if err != nil {
+		return nil, fmt.Errorf("세션 사용량 조회 실패: %w", err)
+	}
+
+	add := func(scope, metric string, used, limit float64) {
+		if limit <= 0 {
+			return
+		}
+		percent := int(used * 100 / limit)
+		status.Usage = append(status.Usage, BudgetAlarm{Scope: scope, Metric: metric, Percent: percent, Used: used, Limit: limit})
+		if percent >= 100 {
+			status.Exceeded = true
+		}
+	}
+
+	add(BudgetScopeSession, BudgetMetricUSD, sessionCost, limits.SessionUSD)
+	add(BudgetScopeSession, BudgetMetricTokens, float64(sessionTokens), float64(limits.SessionTokens))
+
+	if limits.DailyUSD > 0 || limits.DailyTokens > 0 {
+		var dailyTokens int64
+		var dailyCost float64
+		err := s.db.QueryRow(`
+			SELECT COALESCE(SUM(input_tokens + output_tokens + cache_read_tokens + cache_create_tokens), 0),
+			       COALESCE(SUM(cost_usd), 0)
+			FROM sessions
+			WHERE COALESCE(project_root, '') = ? AND COALESCE(parent_session, '') = ''
+			  AND started_at >= ?
+		`, projectRoot, startOfToday()).Scan(&dailyTokens, &dailyCost)
+		if err != nil {
+			return nil, fmt.Errorf("일일 사용량 조회 실패: %w", err)
+		}
+		add(BudgetScopeDaily, BudgetMetricUSD, dailyCost, limits.DailyUSD)
+		add(BudgetScopeDaily, BudgetMetricTokens, float64(dailyTokens), float64(limits.DailyTokens))
+	}
+
+	return status, nil
+}
+
+// CheckBudget evaluates the thresholds after a usage update and logs a budget_warning
+// event for each newly crossed threshold. 같은 임계치는 세션(daily는 하루)당 한 번만 기록됩니다.
+func (s *Service) CheckBudget(sessionID string, limits BudgetLimits) (*BudgetStatus, error) {
+	status, err := s.BudgetUsage(sessionID, limits)
+	if err != nil || !limits.Enabled() {
+		return status, err
+	}
+
+	today := time.Now().Format("2006-01-02")
+	for _, u := range status.Usage {
+		// 가장 높은 임계치 하나만 경고 (50%와 80%를 한 번에 넘으면 80%만)
+		crossed := 0
+		for _, th := range BudgetThresholds {
+			if u.Percent >= th {
+				crossed = th
+			}
+		}
+		if crossed == 0 {
+			continue
+		}
+
+		alarm := u
+		alarm.Threshold = crossed
+		alarm.Key = fmt.Sprintf("%s:%s:%d", u.Scope, u.Metric, crossed)
+		if u.Scope == BudgetScopeDaily {
+			alarm.Key += ":" + today
+		}
+		if s.budgetWarned(sessionID, alarm) {
+			continue
+		}
+
+		data, _ := json.Marshal(alarm)
+		s.LogEvent(sessionID, EventBudgetWarning, string(data))
+		status.Alarms = append(status.Alarms, alarm)
+	}
+
+	return status, nil
+}
+
+// budgetWarned reports whether an alarm with the same key was already logged.
+// daily 경고는 같은 프로젝트의 다른 세션에서 기록된 것도 포함합니다.
+func (s *Service) budgetWarned(sessionID string, alarm BudgetAlarm) bool {
+	pattern := fmt.Sprintf(`%%"key":"%s"%%`, alarm.Key)
+
+	var count int
+	if alarm.Scope == BudgetScopeDaily {
+		s.db.QueryRow(`
+			SELECT COUNT(*) FROM session_events e
+			JOIN sessions s ON s.id = e.session_id
+			WHERE e.event_type = ? AND e.event_data LIKE ?
+			  AND COALESCE(s.project_root, '') = (SELECT COALESCE(project_root, '') FROM sessions WHERE id = ?)
+		`, EventBudgetWarning, pattern, sessionID).Scan(&count)
+	} else {
+		s.db.QueryRow(`
+			SELECT COUNT(*) FROM session_events
+			WHERE session_id = ? AND event_type = ? AND event_data LIKE ?
+		`, sessionID, EventBudgetWarning, pattern).Scan(&count)
+	}
+	return count > 0
+}
+
+// startOfToday returns local midnight as a UTC timestamp string (sessions.started_at 형식)
+func startOfToday() string {
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return midnight.UTC().Format("2006-01-02 15:04:05")
+}
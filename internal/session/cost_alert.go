@@ -0,0 +1,125 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EventCostAlert is logged when a session's cost crosses a cost alert threshold
+const EventCostAlert = "cost_alert"
+
+// CostAlert is a cost threshold (USD) crossed by a session
+type CostAlert struct {
+	Threshold float64 `json:"threshold"`
+	CostUSD   float64 `json:"cost_usd"`
+	Delivered bool    `json:"delivered"` // 다음 훅 출력으로 전달했는지
+}
+
+// Message returns the one-line note for the alert
+func (a CostAlert) Message() string {
+	return fmt.Sprintf("세션 비용이 $%s를 넘었습니다 (현재 $%.2f)", formatUSD(a.Threshold), a.CostUSD)
+}
+
+func formatUSD(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	return strings.TrimSuffix(s, ".00")
+}
+
+// CheckCostAlerts logs a cost_alert event when the session cost crosses a threshold.
+// 한 번에 여러 임계치를 넘으면 가장 높은 것만 기록하며, 같은 임계치는 세션당 한 번만 기록됩니다.
+func (s *Service) CheckCostAlerts(sessionID string, thresholds []float64) (*CostAlert, error) {
+	if len(thresholds) == 0 {
+		return nil, nil
+	}
+
+	var cost float64
+	if err := s.db.QueryRow(`SELECT cost_usd FROM sessions WHERE id = ?`, sessionID).Scan(&cost); err != nil {
+		return nil, fmt.Errorf("세션 비용 조회 실패: %w", err)
+	}
+
+	sorted := append([]float64{}, thresholds...)
+	sort.Float64s(sorted)
+	crossed := 0.0
+	for _, th := range sorted {
+		if th > 0 && cost >= th {
+			crossed = th
+		}
+	}
+	if crossed == 0 {
+		return nil, nil
+	}
+
+	alerted, err := s.alertedThresholds(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, th := range alerted {
+		if th >= crossed {
+			return nil, nil
+		}
+	}
+
+	alert := &CostAlert{Threshold: crossed, CostUSD: cost}
+	data, _ := json.Marshal(alert)
+	if err := s.LogEvent(sessionID, EventCostAlert, string(data)); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+func (s *Service) alertedThresholds(sessionID string) ([]float64, error) {
+	rows, err := s.db.Query(`
+		SELECT event_data FROM session_events WHERE session_id = ? AND event_type = ?
+	`, sessionID, EventCostAlert)
+	if err != nil {
+		return nil, fmt.Errorf("비용 알림 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var thresholds []float64
+	for rows.Next() {
+		var data string
+		var alert CostAlert
+		if rows.Scan(&data) == nil && json.Unmarshal([]byte(data), &alert) == nil {
+			thresholds = append(thresholds, alert.Threshold)
+		}
+	}
+	return thresholds, nil
+}
+
+// TakeCostAlerts returns the undelivered cost alerts of a session and marks them delivered
+func (s *Service) TakeCostAlerts(sessionID string) ([]CostAlert, error) {
+	rows, err := s.db.Query(`
+		SELECT id, event_data FROM session_events
+		WHERE session_id = ? AND event_type = ? AND event_data LIKE '%"delivered":false%'
+		ORDER BY id
+	`, sessionID, EventCostAlert)
+	if err != nil {
+		return nil, fmt.Errorf("비용 알림 조회 실패: %w", err)
+	}
+
+	var ids []int64
+	var alerts []CostAlert
+	for rows.Next() {
+		var id int64
+		var data string
+		var alert CostAlert
+		if rows.Scan(&id, &data) != nil || json.Unmarshal([]byte(data), &alert) != nil {
+			continue
+		}
+		ids = append(ids, id)
+		alerts = append(alerts, alert)
+	}
+	rows.Close()
+
+	for i, id := range ids {
+		alerts[i].Delivered = true
+		data, _ := json.Marshal(alerts[i])
+		if _, err := s.db.Exec(`UPDATE session_events SET event_data = ? WHERE id = ?`, string(data), id); err != nil {
+			return nil, fmt.Errorf("비용 알림 갱신 실패: %w", err)
+		}
+	}
+	return alerts, nil
+}
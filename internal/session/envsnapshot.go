@@ -0,0 +1,214 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// envCommandTimeout bounds each version probe so session start stays fast
+const envCommandTimeout = 2 * time.Second
+
+// EnvSnapshot is the developer environment captured at session start.
+// 한 개발자 환경에서만 실패하는 포트를 조사할 때 세션 간 차이를 비교하는 데 사용합니다.
+type EnvSnapshot struct {
+	CapturedAt time.Time         `json:"captured_at"`
+	OS         string            `json:"os"`
+	Arch       string            `json:"arch"`
+	Kernel     string            `json:"kernel,omitempty"`
+	Hostname   string            `json:"hostname,omitempty"`
+	Tools      map[string]string `json:"tools,omitempty"` // go, node, git ...
+	GitSHA     string            `json:"git_sha,omitempty"`
+	GitBranch  string            `json:"git_branch,omitempty"`
+	GitDirty   bool              `json:"git_dirty,omitempty"`
+}
+
+// envProbes are the commands run to capture tool versions
+var envProbes = map[string][]string{
+	"go":     {"go", "env", "GOVERSION"},
+	"node":   {"node", "--version"},
+	"git":    {"git", "--version"},
+	"python": {"python3", "--version"},
+}
+
+// CaptureEnv captures OS, tool versions and the git revision of dir.
+// 설치되지 않은 도구는 건너뜁니다.
+func CaptureEnv(dir string) *EnvSnapshot {
+	snap := &EnvSnapshot{
+		CapturedAt: time.Now().UTC(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Tools:      make(map[string]string),
+	}
+	snap.Hostname, _ = os.Hostname()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	run := func(set func(string), name string, args ...string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if out, ok := probe(dir, name, args...); ok {
+				mu.Lock()
+				set(out)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for tool, cmd := range envProbes {
+		tool := tool
+		run(func(v string) { snap.Tools[tool] = trimVersion(tool, v) }, cmd[0], cmd[1:]...)
+	}
+	if runtime.GOOS != "windows" {
+		run(func(v string) { snap.Kernel = v }, "uname", "-sr")
+	}
+	run(func(v string) { snap.GitSHA = v }, "git", "rev-parse", "HEAD")
+	run(func(v string) { snap.GitBranch = v }, "git", "rev-parse", "--abbrev-ref", "HEAD")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), envCommandTimeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, "git", "status", "--porcelain", "--untracked-files=no")
+		cmd.Dir = dir
+		if out, err := cmd.Output(); err == nil {
+			mu.Lock()
+			snap.GitDirty = strings.TrimSpace(string(out)) != ""
+			mu.Unlock()
+		}
+	}()
+
+	wg.Wait()
+	return snap
+}
+
+func probe(dir, name string, args ...string) (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), envCommandTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+	v := strings.TrimSpace(string(out))
+	return v, v != ""
+}
+
+// trimVersion strips tool names from version output ("git version 2.43.0" → "2.43.0")
+func trimVersion(tool, v string) string {
+	v, _, _ = strings.Cut(v, "\n")
+	switch tool {
+	case "go":
+		return strings.TrimPrefix(v, "go")
+	case "node":
+		return strings.TrimPrefix(v, "v")
+	case "git":
+		v = strings.TrimPrefix(v, "git version ")
+	case "python":
+		v = strings.TrimPrefix(v, "Python ")
+	}
+	return v
+}
+
+// SetEnvSnapshot stores the environment snapshot of a session
+func (s *Service) SetEnvSnapshot(id string, snap *EnvSnapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.Exec(`UPDATE sessions SET env_snapshot = ? WHERE id = ?`, string(data), id); err != nil {
+		return fmt.Errorf("환경 스냅샷 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// GetEnvSnapshot returns the environment snapshot of a session (없으면 nil)
+func (s *Service) GetEnvSnapshot(id string) (*EnvSnapshot, error) {
+	var data sql.NullString
+	err := s.db.QueryRow(`SELECT env_snapshot FROM sessions WHERE id = ?`, id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("세션 '%s'을(를) 찾을 수 없습니다", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !data.Valid || data.String == "" {
+		return nil, nil
+	}
+	var snap EnvSnapshot
+	if err := json.Unmarshal([]byte(data.String), &snap); err != nil {
+		return nil, fmt.Errorf("환경 스냅샷 파싱 실패: %w", err)
+	}
+	return &snap, nil
+}
+
+// EnvDiff is a field that differs between two snapshots
+type EnvDiff struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// Fields returns the snapshot as ordered name/value pairs for display and comparison
+func (e *EnvSnapshot) Fields() [][2]string {
+	fields := [][2]string{
+		{"os", e.OS + "/" + e.Arch},
+		{"kernel", e.Kernel},
+		{"hostname", e.Hostname},
+	}
+	tools := make([]string, 0, len(e.Tools))
+	for name := range e.Tools {
+		tools = append(tools, name)
+	}
+	sort.Strings(tools)
+	for _, name := range tools {
+		fields = append(fields, [2]string{name, e.Tools[name]})
+	}
+	sha := e.GitSHA
+	if e.GitDirty && sha != "" {
+		sha += " (dirty)"
+	}
+	fields = append(fields, [2]string{"git_sha", sha}, [2]string{"git_branch", e.GitBranch})
+	return fields
+}
+
+// Diff returns the fields that differ between two snapshots
+func (e *EnvSnapshot) Diff(other *EnvSnapshot) []EnvDiff {
+	a, b := fieldMap(e), fieldMap(other)
+	var names []string
+	seen := make(map[string]bool)
+	for _, snap := range []*EnvSnapshot{e, other} {
+		for _, f := range snap.Fields() {
+			if !seen[f[0]] {
+				seen[f[0]] = true
+				names = append(names, f[0])
+			}
+		}
+	}
+
+	var diffs []EnvDiff
+	for _, name := range names {
+		if a[name] != b[name] {
+			diffs = append(diffs, EnvDiff{Field: name, A: a[name], B: b[name]})
+		}
+	}
+	return diffs
+}
+
+func fieldMap(e *EnvSnapshot) map[string]string {
+	m := make(map[string]string)
+	for _, f := range e.Fields() {
+		m[f[0]] = f[1]
+	}
+	return m
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/n0roo/pal-kit/internal/errcode"
 )
 
 // v10 Session Types (계층적 구조)
@@ -151,7 +152,7 @@ func (s *Service) GetHierarchical(id string) (*HierarchicalSession, error) {
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("세션 '%s'을(를) 찾을 수 없습니다", id)
+		return nil, errcode.New(errcode.KindNotFound, "세션 '%s'을(를) 찾을 수 없습니다", id)
 	}
 	if err != nil {
 		return nil, err
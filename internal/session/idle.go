@@ -0,0 +1,184 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// StatusPaused marks a session paused after a period of inactivity
+const StatusPaused = "paused"
+
+// Idle pause events
+const (
+	EventSessionPaused   = "session_paused"
+	EventSessionUnpaused = "session_unpaused"
+)
+
+// IdleSession is a running session without recent activity
+type IdleSession struct {
+	ID            string   `json:"id"`
+	ProjectRoot   string   `json:"project_root,omitempty"`
+	LastActivity  string   `json:"last_activity"`
+	IdleMinutes   int      `json:"idle_minutes"`
+	ReleasedLocks []string `json:"released_locks,omitempty"`
+}
+
+// FindIdleSessions returns running sessions whose last event is older than idle.
+// projectRoot가 비어 있으면 전체 프로젝트를 대상으로 합니다.
+func (s *Service) FindIdleSessions(projectRoot string, idle time.Duration) ([]IdleSession, error) {
+	cutoff := time.Now().Add(-idle).UTC().Format("2006-01-02 15:04:05")
+
+	rows, err := s.db.Query(`
+		SELECT id, project_root, last_activity FROM (
+			SELECT s.id, COALESCE(s.project_root, '') AS project_root,
+			       COALESCE((SELECT MAX(e.created_at) FROM session_events e WHERE e.session_id = s.id),
+			                s.started_at) AS last_activity
+			FROM sessions s
+			WHERE s.status = 'running' AND (? = '' OR s.project_root = ?)
+		)
+		WHERE last_activity < ?
+		ORDER BY last_activity
+	`, projectRoot, projectRoot, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("유휴 세션 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var idles []IdleSession
+	for rows.Next() {
+		var is IdleSession
+		if err := rows.Scan(&is.ID, &is.ProjectRoot, &is.LastActivity); err != nil {
+			return nil, err
+		}
+		if last, err := time.Parse("2006-01-02 15:04:05", is.LastActivity); err == nil {
+			is.IdleMinutes = int(time.Since(last).Minutes())
+		}
+		idles = append(idles, is)
+	}
+	return idles, rows.Err()
+}
+
+// PauseIdleSessions marks idle running sessions as paused and releases their locks.
+// 다음 Hook 이벤트에서 ResumeIdle로 다시 running이 됩니다. exceptClaudeSessions의 세션은
+// 지금 돌아온 세션이므로 일시정지하지 않습니다 (Lock 유지).
+func (s *Service) PauseIdleSessions(projectRoot string, idle time.Duration, exceptClaudeSessions ...string) ([]IdleSession, error) {
+	if idle <= 0 {
+		return nil, nil
+	}
+
+	found, err := s.FindIdleSessions(projectRoot, idle)
+	if err != nil {
+		return nil, err
+	}
+
+	var idles []IdleSession
+	for _, is := range found {
+		if !s.ownedByClaudeSession(is.ID, exceptClaudeSessions) {
+			idles = append(idles, is)
+		}
+	}
+
+	for i := range idles {
+		is := &idles[i]
+		result, err := s.db.Exec(`UPDATE sessions SET status = ? WHERE id = ? AND status = 'running'`, StatusPaused, is.ID)
+		if err != nil {
+			return nil, fmt.Errorf("세션 일시정지 실패: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			continue
+		}
+
+		is.ReleasedLocks = s.releaseLocks(is.ID)
+
+		data, _ := json.Marshal(map[string]interface{}{
+			"idle_minutes":   is.IdleMinutes,
+			"last_activity":  is.LastActivity,
+			"released_locks": is.ReleasedLocks,
+		})
+		s.LogEvent(is.ID, EventSessionPaused, string(data))
+	}
+
+	return idles, nil
+}
+
+// ownedByClaudeSession reports whether a PAL session belongs to one of the Claude session IDs
+func (s *Service) ownedByClaudeSession(id string, claudeSessionIDs []string) bool {
+	var claudeSessionID string
+	s.db.QueryRow(`SELECT COALESCE(claude_session_id, '') FROM sessions WHERE id = ?`, id).Scan(&claudeSessionID)
+	for _, c := range claudeSessionIDs {
+		if c != "" && (c == claudeSessionID || c == id) {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseLocks releases all locks held by a session and returns the resources
+func (s *Service) releaseLocks(sessionID string) []string {
+	rows, err := s.db.Query(`SELECT resource FROM locks WHERE session_id = ?`, sessionID)
+	if err != nil {
+		return nil
+	}
+	var resources []string
+	for rows.Next() {
+		var r string
+		if rows.Scan(&r) == nil {
+			resources = append(resources, r)
+		}
+	}
+	rows.Close()
+
+	if len(resources) > 0 {
+		s.db.Exec(`DELETE FROM locks WHERE session_id = ?`, sessionID)
+	}
+	return resources
+}
+
+// ResumeIdle resumes the paused session of a Claude session ID.
+// 재개된 세션 ID를 반환하며, 일시정지된 세션이 없으면 빈 문자열을 반환합니다.
+func (s *Service) ResumeIdle(claudeSessionID string) string {
+	if claudeSessionID == "" {
+		return ""
+	}
+
+	var id string
+	if err := s.db.QueryRow(`
+		SELECT id FROM sessions WHERE claude_session_id = ? AND status = ?
+		ORDER BY started_at DESC LIMIT 1
+	`, claudeSessionID, StatusPaused).Scan(&id); err != nil {
+		return ""
+	}
+
+	result, err := s.db.Exec(`UPDATE sessions SET status = 'running' WHERE id = ? AND status = ?`, id, StatusPaused)
+	if err != nil {
+		return ""
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ""
+	}
+	s.LogEvent(id, EventSessionUnpaused, `{}`)
+	return id
+}
+
+// RunningProjectRoots returns the distinct project roots of running sessions
+func (s *Service) RunningProjectRoots() ([]string, error) {
+	rows, err := s.db.Query(`
+		SELECT DISTINCT project_root FROM sessions
+		WHERE status = 'running' AND COALESCE(project_root, '') != ''
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("프로젝트 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var roots []string
+	for rows.Next() {
+		var root string
+		if err := rows.Scan(&root); err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+	return roots, rows.Err()
+}
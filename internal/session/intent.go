@@ -0,0 +1,65 @@
+package session
+
+import (
+	"strings"
+)
+
+// Intent constants for user prompt classification
+const (
+	IntentBugfix   = "bugfix"
+	IntentFeature  = "feature"
+	IntentQuestion = "question"
+	IntentOther    = "other"
+)
+
+var intentKeywords = []struct {
+	intent   string
+	keywords []string
+}{
+	{IntentBugfix, []string{
+		"bug", "fix", "error", "broken", "crash", "fail", "panic", "regression", "doesn't work", "not working",
+		"버그", "수정해", "고쳐", "에러", "오류", "실패", "안 돼", "안돼", "깨짐", "깨져",
+	}},
+	{IntentFeature, []string{
+		"add", "implement", "create", "support", "feature", "build", "introduce", "refactor",
+		"추가", "구현", "만들어", "지원", "기능", "개선", "리팩토링",
+	}},
+}
+
+var questionPrefixes = []string{
+	"what", "why", "how", "where", "when", "which", "who", "is ", "are ", "can ", "could ", "does ", "do ", "should ",
+}
+
+var questionSuffixes = []string{
+	"?", "까", "나요", "가요", "인가", "뭐야", "어때",
+}
+
+// ClassifyIntent performs lightweight keyword-based intent classification of a user prompt.
+// 버그 수정 > 기능 요청 > 질문 순으로 판단하며, 해당 없으면 other를 반환합니다.
+func ClassifyIntent(prompt string) string {
+	text := strings.ToLower(strings.TrimSpace(prompt))
+	if text == "" {
+		return IntentOther
+	}
+
+	for _, group := range intentKeywords {
+		for _, kw := range group.keywords {
+			if strings.Contains(text, kw) {
+				return group.intent
+			}
+		}
+	}
+
+	for _, prefix := range questionPrefixes {
+		if strings.HasPrefix(text, prefix) {
+			return IntentQuestion
+		}
+	}
+	for _, suffix := range questionSuffixes {
+		if strings.HasSuffix(text, suffix) {
+			return IntentQuestion
+		}
+	}
+
+	return IntentOther
+}
@@ -0,0 +1,156 @@
+package session
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Peer is a running session in the same project with the ports and locks it holds
+type Peer struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title,omitempty"`
+	Type         string    `json:"type"`
+	Cwd          string    `json:"cwd,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	LastActivity string    `json:"last_activity"` // UTC "2006-01-02 15:04:05"
+	Ports        []string  `json:"ports,omitempty"`
+	Locks        []string  `json:"locks,omitempty"`
+}
+
+// Peers returns the running sessions of a project except selfID and its sub-sessions.
+// selfID가 비어 있으면 프로젝트의 모든 running 세션을 반환합니다.
+func (s *Service) Peers(projectRoot, selfID string) ([]Peer, error) {
+	rows, err := s.db.Query(`
+		SELECT s.id, COALESCE(s.title, ''), COALESCE(s.session_type, 'single'), COALESCE(s.cwd, ''), s.started_at,
+		       COALESCE((SELECT MAX(e.created_at) FROM session_events e WHERE e.session_id = s.id), s.started_at)
+		FROM sessions s
+		WHERE s.status = 'running' AND s.project_root = ?
+		  AND (? = '' OR (s.id != ? AND COALESCE(s.parent_session, '') != ?))
+		ORDER BY s.started_at, s.id
+	`, projectRoot, selfID, selfID, selfID)
+	if err != nil {
+		return nil, fmt.Errorf("동시 실행 세션 조회 실패: %w", err)
+	}
+
+	var peers []Peer
+	for rows.Next() {
+		var p Peer
+		var lastActivity interface{}
+		if err := rows.Scan(&p.ID, &p.Title, &p.Type, &p.Cwd, &p.StartedAt, &lastActivity); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		p.LastActivity = formatActivity(lastActivity)
+		peers = append(peers, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range peers {
+		p := &peers[i]
+		p.Ports = s.queryStrings(`
+			SELECT port_id FROM sessions WHERE id = ? AND COALESCE(port_id, '') != ''
+			UNION
+			SELECT id FROM ports WHERE session_id = ? AND status = 'running'
+		`, p.ID, p.ID)
+		p.Locks = s.queryStrings(`SELECT resource FROM locks WHERE session_id = ? ORDER BY resource`, p.ID)
+	}
+	return peers, nil
+}
+
+// PeersMarkdown renders the peers as a context file for every Claude instance of the project
+func PeersMarkdown(peers []Peer, now time.Time) string {
+	var sb strings.Builder
+
+	sb.WriteString("# 동시 실행 세션\n\n")
+	sb.WriteString(fmt.Sprintf("> PAL Kit이 Hook 실행 시 갱신합니다 (%s). 다른 세션이 작업 중인 포트와 Lock 대상 파일은 수정하지 마세요.\n\n",
+		now.Format("2006-01-02 15:04:05")))
+
+	if len(peers) == 0 {
+		sb.WriteString("실행 중인 세션이 없습니다.\n")
+		return sb.String()
+	}
+
+	for _, p := range peers {
+		title := p.Title
+		if title == "" || title == "-" {
+			title = "(제목 없음)"
+		}
+		sb.WriteString(fmt.Sprintf("## %s — %s\n\n", p.ID, title))
+		sb.WriteString(fmt.Sprintf("- 유형: %s, 시작: %s, 마지막 활동: %s\n",
+			p.Type, p.StartedAt.Local().Format("01/02 15:04"), activityLocal(p.LastActivity)))
+		if p.Cwd != "" {
+			sb.WriteString(fmt.Sprintf("- 작업 디렉토리: %s\n", p.Cwd))
+		}
+		sb.WriteString(fmt.Sprintf("- 포트: %s\n", listOrNone(p.Ports)))
+		sb.WriteString(fmt.Sprintf("- Lock: %s\n\n", listOrNone(p.Locks)))
+	}
+	return sb.String()
+}
+
+// Banner returns a short multi-line summary of the peers for hook output
+func (p Peer) Banner(projectRoot string) string {
+	line := fmt.Sprintf("   • %s", p.ID)
+	if p.Title != "" && p.Title != "-" {
+		line += fmt.Sprintf(" (%s)", p.Title)
+	}
+	if p.Cwd != "" && p.Cwd != projectRoot {
+		if rel, err := filepath.Rel(projectRoot, p.Cwd); err == nil && !strings.HasPrefix(rel, "..") {
+			line += " [" + rel + "]"
+		}
+	}
+	line += fmt.Sprintf(" 포트: %s, Lock: %s", listOrNone(p.Ports), listOrNone(p.Locks))
+	return line
+}
+
+func (s *Service) queryStrings(query string, args ...interface{}) []string {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v string
+		if rows.Scan(&v) == nil {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// formatActivity normalizes MAX(created_at), which SQLite may return as text or time
+func formatActivity(v interface{}) string {
+	switch t := v.(type) {
+	case time.Time:
+		return t.UTC().Format("2006-01-02 15:04:05")
+	case string:
+		if parsed, err := time.Parse(time.RFC3339Nano, t); err == nil {
+			return parsed.UTC().Format("2006-01-02 15:04:05")
+		}
+		return t
+	case []byte:
+		return formatActivity(string(t))
+	}
+	return ""
+}
+
+func activityLocal(utc string) string {
+	t, err := time.Parse("2006-01-02 15:04:05", utc)
+	if err != nil {
+		return utc
+	}
+	return t.Local().Format("01/02 15:04")
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "없음"
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,136 @@
+package session
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventSessionResumed is logged when a prior session is reopened on session-start
+const EventSessionResumed = "session_resumed"
+
+// DefaultResumeWindow is how long an ended session stays resumable
+const DefaultResumeWindow = 7 * 24 * time.Hour
+
+// resumeFingerprintCandidates limits how many ended sessions are fingerprinted
+const resumeFingerprintCandidates = 20
+
+// Resume match strategies
+const (
+	ResumeByTranscript  = "transcript_path"
+	ResumeByClaudeID    = "claude_session_id"
+	ResumeByFingerprint = "fingerprint"
+)
+
+// ResumeQuery describes the Claude session being started
+type ResumeQuery struct {
+	ClaudeSessionID string
+	TranscriptPath  string
+	ProjectRoot     string
+	// Fingerprint is the transcript fingerprint of the new session.
+	// FingerprintOf는 이전 세션의 transcript에서 같은 방식으로 fingerprint를 계산합니다.
+	Fingerprint   string
+	FingerprintOf func(transcriptPath string) string
+	Window        time.Duration
+}
+
+// FindResumable finds a prior session that the starting Claude session continues.
+// transcript_path → claude_session_id → transcript fingerprint 순으로 매칭하며,
+// 서브에이전트(자식) 세션과 window보다 오래전에 종료된 세션은 제외합니다.
+func (s *Service) FindResumable(q ResumeQuery) (*Session, string, error) {
+	window := q.Window
+	if window <= 0 {
+		window = DefaultResumeWindow
+	}
+	since := time.Now().Add(-window).UTC().Format("2006-01-02 15:04:05")
+
+	base := `
+		SELECT id FROM sessions
+		WHERE COALESCE(parent_session, '') = ''
+		  AND (ended_at IS NULL OR ended_at >= ?)
+		  AND (? = '' OR project_root = ?)
+	`
+	args := []interface{}{since, q.ProjectRoot, q.ProjectRoot}
+
+	find := func(cond string, value string) (string, error) {
+		var id string
+		err := s.db.QueryRow(base+" AND "+cond+" ORDER BY started_at DESC, rowid DESC LIMIT 1",
+			append(args, value)...).Scan(&id)
+		return id, err
+	}
+
+	if q.TranscriptPath != "" {
+		if id, err := find("transcript_path = ?", q.TranscriptPath); err == nil {
+			sess, err := s.Get(id)
+			return sess, ResumeByTranscript, err
+		} else if err != sql.ErrNoRows {
+			return nil, "", fmt.Errorf("재개 세션 조회 실패: %w", err)
+		}
+	}
+
+	if q.ClaudeSessionID != "" {
+		if id, err := find("claude_session_id = ?", q.ClaudeSessionID); err == nil {
+			sess, err := s.Get(id)
+			return sess, ResumeByClaudeID, err
+		} else if err != sql.ErrNoRows {
+			return nil, "", fmt.Errorf("재개 세션 조회 실패: %w", err)
+		}
+	}
+
+	if q.Fingerprint != "" && q.FingerprintOf != nil {
+		// 실행 중인 세션은 다른 터미널에서 이어가는 중일 수 있으므로 종료된 세션만 비교
+		rows, err := s.db.Query(base+` AND status != 'running' AND COALESCE(transcript_path, '') != ''
+			ORDER BY ended_at DESC, rowid DESC LIMIT ?`, append(args, resumeFingerprintCandidates)...)
+		if err != nil {
+			return nil, "", fmt.Errorf("재개 세션 조회 실패: %w", err)
+		}
+		var ids []string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err == nil {
+				ids = append(ids, id)
+			}
+		}
+		rows.Close()
+
+		for _, id := range ids {
+			var path string
+			s.db.QueryRow(`SELECT transcript_path FROM sessions WHERE id = ?`, id).Scan(&path)
+			if path != q.TranscriptPath && q.FingerprintOf(path) == q.Fingerprint {
+				sess, err := s.Get(id)
+				return sess, ResumeByFingerprint, err
+			}
+		}
+	}
+
+	return nil, "", nil
+}
+
+// Reopen resumes a prior session for a (re)started Claude session.
+// 세션을 running으로 되돌리고 Claude 세션 ID/transcript 경로를 갱신한 뒤 session_resumed 이벤트를 남깁니다.
+func (s *Service) Reopen(id, claudeSessionID, transcriptPath, matchedBy string) error {
+	sess, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		UPDATE sessions
+		SET status = 'running', ended_at = NULL,
+		    claude_session_id = COALESCE(NULLIF(?, ''), claude_session_id),
+		    transcript_path = COALESCE(NULLIF(?, ''), transcript_path)
+		WHERE id = ?
+	`, claudeSessionID, transcriptPath, id)
+	if err != nil {
+		return fmt.Errorf("세션 재개 실패: %w", err)
+	}
+
+	data, _ := json.Marshal(map[string]string{
+		"claude_session_id": claudeSessionID,
+		"transcript_path":   transcriptPath,
+		"matched_by":        matchedBy,
+		"previous_status":   sess.Status,
+	})
+	return s.LogEvent(id, EventSessionResumed, string(data))
+}
@@ -0,0 +1,109 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventCollapsed is a summary event replacing a burst of same-type events
+const EventCollapsed = "events_collapsed"
+
+// sqliteTimeLayout is the format of CURRENT_TIMESTAMP values
+const sqliteTimeLayout = "2006-01-02 15:04:05"
+
+// CollapsedEvents is the event_data of an events_collapsed event
+type CollapsedEvents struct {
+	EventType string          `json:"event_type"`
+	Count     int             `json:"count"`
+	FirstAt   string          `json:"first_at"`
+	LastAt    string          `json:"last_at"`
+	Last      json.RawMessage `json:"last,omitempty"` // 마지막으로 합쳐진 이벤트 데이터
+}
+
+// LogEventSampled logs an event, collapsing bursts above maxPerMinute.
+// 최근 1분간 같은 타입의 개별 이벤트가 maxPerMinute개 이상이면 개별 기록 대신
+// 진행 중인 events_collapsed 요약 이벤트의 count를 올립니다. maxPerMinute <= 0이면 LogEvent와 같습니다.
+func (s *Service) LogEventSampled(sessionID, eventType, eventData string, maxPerMinute int) error {
+	if maxPerMinute <= 0 {
+		return s.LogEvent(sessionID, eventType, eventData)
+	}
+
+	var recent int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM session_events
+		WHERE session_id = ? AND event_type = ? AND created_at >= datetime('now', '-1 minute')
+	`, sessionID, eventType).Scan(&recent)
+	if err != nil {
+		return fmt.Errorf("이벤트 빈도 조회 실패: %w", err)
+	}
+	if recent < maxPerMinute {
+		return s.LogEvent(sessionID, eventType, eventData)
+	}
+
+	// DB에는 요약만 남기지만 감사 로그에는 개별 이벤트를 모두 기록
+	s.auditEvent(sessionID, eventType, eventData)
+
+	now := time.Now().UTC().Format(sqliteTimeLayout)
+	var last json.RawMessage
+	if json.Valid([]byte(eventData)) {
+		last = json.RawMessage(eventData)
+	}
+
+	id, summary, err := s.findOpenCollapse(sessionID, eventType)
+	if err != nil {
+		return err
+	}
+	if summary == nil {
+		summary = &CollapsedEvents{EventType: eventType, FirstAt: now}
+	}
+	summary.Count++
+	summary.LastAt = now
+	summary.Last = last
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	if id == 0 {
+		return s.LogEvent(sessionID, EventCollapsed, string(data))
+	}
+	_, err = s.db.Exec(`UPDATE session_events SET event_data = ? WHERE id = ?`, string(data), id)
+	if err != nil {
+		return fmt.Errorf("요약 이벤트 갱신 실패: %w", err)
+	}
+	return nil
+}
+
+// findOpenCollapse returns the collapsed summary of an event type still within the burst window
+func (s *Service) findOpenCollapse(sessionID, eventType string) (int64, *CollapsedEvents, error) {
+	rows, err := s.db.Query(`
+		SELECT id, COALESCE(event_data, '') FROM session_events
+		WHERE session_id = ? AND event_type = ?
+		ORDER BY id DESC
+		LIMIT 20
+	`, sessionID, EventCollapsed)
+	if err != nil {
+		return 0, nil, fmt.Errorf("요약 이벤트 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	cutoff := time.Now().UTC().Add(-time.Minute)
+	for rows.Next() {
+		var id int64
+		var data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return 0, nil, err
+		}
+		var summary CollapsedEvents
+		if json.Unmarshal([]byte(data), &summary) != nil || summary.EventType != eventType {
+			continue
+		}
+		lastAt, err := time.Parse(sqliteTimeLayout, summary.LastAt)
+		if err != nil || lastAt.Before(cutoff) {
+			return 0, nil, nil
+		}
+		return id, &summary, nil
+	}
+	return 0, nil, rows.Err()
+}
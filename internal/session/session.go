@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/errcode"
+	"github.com/n0roo/pal-kit/internal/project"
 )
 
 // Session type constants
@@ -43,6 +45,7 @@ const (
 	// 시스템 이벤트
 	EventCompact       = "compact"        // 컨텍스트 컴팩트
 	EventZombieCleanup = "zombie_cleanup" // 좀비 세션 정리
+	EventNotification  = "notification"   // Claude Code 알림
 
 	// v11: 컨텍스트 이벤트
 	EventContextLoaded   = "context_loaded"   // 컨텍스트 로드 완료
@@ -62,6 +65,10 @@ const (
 	EventCheckpointCreated = "checkpoint_created" // 체크포인트 생성
 )
 
+// NotSandbox is a SQL condition on the sessions table excluding sandbox sessions.
+// 통계, 브리핑 등 프로젝트 이력 집계 쿼리에서 사용합니다.
+const NotSandbox = "COALESCE(sandbox, 0) = 0"
+
 // Session represents a work session
 type Session struct {
 	ID                string
@@ -137,6 +144,10 @@ type StartOptions struct {
 	// v11 필드 - 세션 식별 강화
 	TTY       string
 	ParentPID int
+	// 샌드박스 세션: 기록은 하되 통계/브리핑/요약에서 제외
+	Sandbox bool
+	// 모노레포 워크스페이스 루트 (ProjectRoot를 감싸는 최상위 루트)
+	WorkspaceRoot string
 }
 
 // SessionIdentifier contains fields for unique session identification
@@ -200,7 +211,7 @@ func (s *Service) StartWithFullOptions(opts StartOptions) error {
 		var exists int
 		err := s.db.QueryRow(`SELECT 1 FROM sessions WHERE id = ?`, opts.ParentSession).Scan(&exists)
 		if err != nil {
-			return fmt.Errorf("상위 세션 '%s'을(를) 찾을 수 없습니다", opts.ParentSession)
+			return errcode.New(errcode.KindNotFound, "상위 세션 '%s'을(를) 찾을 수 없습니다", opts.ParentSession)
 		}
 		parentNull = sql.NullString{String: opts.ParentSession, Valid: true}
 	}
@@ -233,14 +244,22 @@ func (s *Service) StartWithFullOptions(opts StartOptions) error {
 	fingerprint := GenerateFingerprint(opts.Cwd, opts.TTY, opts.ParentPID, time.Now())
 	fingerprintNull = sql.NullString{String: fingerprint, Valid: true}
 
+	var workspaceNull sql.NullString
+	if opts.WorkspaceRoot != "" {
+		workspaceNull = sql.NullString{String: opts.WorkspaceRoot, Valid: true}
+	}
+
+	// 상위 세션이 샌드박스면 하위 세션도 샌드박스
+	sandbox := opts.Sandbox || (opts.ParentSession != "" && s.IsSandbox(opts.ParentSession))
+
 	_, err := s.db.Exec(`
 		INSERT INTO sessions (id, port_id, title, status, session_type, parent_session,
 			claude_session_id, project_root, project_name, transcript_path, cwd,
-			tty, parent_pid, fingerprint)
-		VALUES (?, ?, ?, 'running', ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			tty, parent_pid, fingerprint, sandbox, workspace_root)
+		VALUES (?, ?, ?, 'running', ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, opts.ID, portIDNull, titleNull, opts.SessionType, parentNull,
 		claudeIDNull, projRootNull, projNameNull, transcriptNull, cwdNull,
-		ttyNull, parentPIDNull, fingerprintNull)
+		ttyNull, parentPIDNull, fingerprintNull, sandbox, workspaceNull)
 
 	if err != nil {
 		return fmt.Errorf("세션 생성 실패: %w", err)
@@ -253,6 +272,13 @@ func (s *Service) StartWithFullOptions(opts StartOptions) error {
 	return nil
 }
 
+// IsSandbox reports whether a session is a sandbox session
+func (s *Service) IsSandbox(id string) bool {
+	var sandbox bool
+	err := s.db.QueryRow(`SELECT COALESCE(sandbox, 0) FROM sessions WHERE id = ?`, id).Scan(&sandbox)
+	return err == nil && sandbox
+}
+
 // End marks a session as ended
 func (s *Service) End(id string) error {
 	return s.EndWithReason(id, "")
@@ -263,7 +289,7 @@ func (s *Service) EndWithReason(id, reason string) error {
 	result, err := s.db.Exec(`
 		UPDATE sessions 
 		SET status = 'complete', ended_at = CURRENT_TIMESTAMP
-		WHERE id = ? AND status = 'running'
+		WHERE id = ? AND status IN ('running', 'paused')
 	`, id)
 
 	if err != nil {
@@ -286,7 +312,7 @@ func (s *Service) EndAllByClaudeSession(claudeSessionID, reason string) (int, er
 	result, err := s.db.Exec(`
 		UPDATE sessions
 		SET status = 'complete', ended_at = CURRENT_TIMESTAMP
-		WHERE claude_session_id = ? AND status = 'running'
+		WHERE claude_session_id = ? AND status IN ('running', 'paused')
 	`, claudeSessionID)
 
 	if err != nil {
@@ -302,7 +328,7 @@ func (s *Service) CleanupZombieSessions(maxAgeHours int) (int, error) {
 	result, err := s.db.Exec(`
 		UPDATE sessions
 		SET status = 'complete', ended_at = CURRENT_TIMESTAMP
-		WHERE status = 'running'
+		WHERE status IN ('running', 'paused')
 		AND started_at < datetime('now', ? || ' hours')
 	`, -maxAgeHours)
 
@@ -320,7 +346,11 @@ func (s *Service) LogEvent(sessionID, eventType, eventData string) error {
 		INSERT INTO session_events (session_id, event_type, event_data)
 		VALUES (?, ?, ?)
 	`, sessionID, eventType, eventData)
-	return err
+	if err != nil {
+		return err
+	}
+	s.auditEvent(sessionID, eventType, eventData)
+	return nil
 }
 
 // GetEvents returns events for a session with optional type filter
@@ -360,6 +390,18 @@ func (s *Service) GetEvents(sessionID string, eventType string, limit int) ([]Se
 	return events, nil
 }
 
+// RetagEvents changes the type of a session's events (예: untracked_edit → file_edit)
+func (s *Service) RetagEvents(sessionID, fromType, toType string) (int64, error) {
+	result, err := s.db.Exec(`
+		UPDATE session_events SET event_type = ?
+		WHERE session_id = ? AND event_type = ?
+	`, toType, sessionID, fromType)
+	if err != nil {
+		return 0, fmt.Errorf("이벤트 변경 실패: %w", err)
+	}
+	return result.RowsAffected()
+}
+
 // GetRecentEvents returns recent events across all sessions
 func (s *Service) GetRecentEvents(limit int) ([]SessionEvent, error) {
 	query := `
@@ -412,7 +454,7 @@ func (s *Service) FindByClaudeSessionID(claudeSessionID string) (*Session, error
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("세션을 찾을 수 없습니다")
+		return nil, errcode.New(errcode.KindNotFound, "세션을 찾을 수 없습니다")
 	}
 	if err != nil {
 		return nil, err
@@ -433,6 +475,9 @@ func (s *Service) FindByClaudeSessionID(claudeSessionID string) (*Session, error
 // 2. Try by cwd + project_root (if provided)
 // 3. Fall back to most recent running session
 func (s *Service) FindActiveSession(claudeSessionID, cwd, projectRoot string) (*Session, error) {
+	// 유휴 상태로 일시정지된 세션은 다음 Hook 이벤트에서 재개
+	s.ResumeIdle(claudeSessionID)
+
 	// Strategy 1: Claude session ID (가장 정확)
 	if claudeSessionID != "" {
 		sess, err := s.FindByClaudeSessionID(claudeSessionID)
@@ -455,11 +500,13 @@ func (s *Service) FindActiveSession(claudeSessionID, cwd, projectRoot string) (*
 		return sess, nil
 	}
 
-	return nil, fmt.Errorf("활성 세션을 찾을 수 없습니다")
+	return nil, errcode.New(errcode.KindNotFound, "활성 세션을 찾을 수 없습니다")
 }
 
 // FindActiveSessionWithIdentifier finds a session using SessionIdentifier for more accurate matching
 func (s *Service) FindActiveSessionWithIdentifier(claudeSessionID string, identifier *SessionIdentifier, projectRoot string) (*Session, error) {
+	s.ResumeIdle(claudeSessionID)
+
 	// Strategy 1: Claude session ID
 	if claudeSessionID != "" {
 		sess, err := s.FindByClaudeSessionID(claudeSessionID)
@@ -494,7 +541,7 @@ func (s *Service) FindActiveSessionWithIdentifier(claudeSessionID string, identi
 		return sess, nil
 	}
 
-	return nil, fmt.Errorf("활성 세션을 찾을 수 없습니다")
+	return nil, errcode.New(errcode.KindNotFound, "활성 세션을 찾을 수 없습니다")
 }
 
 // FindByFingerprint finds a running session by fingerprint
@@ -562,7 +609,7 @@ func (s *Service) findByLocation(cwd, projectRoot string) (*Session, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("해당 위치의 세션을 찾을 수 없습니다")
+	return nil, errcode.New(errcode.KindNotFound, "해당 위치의 세션을 찾을 수 없습니다")
 }
 
 // findByCwd finds a running session with exact cwd match
@@ -717,7 +764,7 @@ func (s *Service) UpdateStatus(id, status string) error {
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("세션 '%s'을(를) 찾을 수 없습니다", id)
+		return errcode.New(errcode.KindNotFound, "세션 '%s'을(를) 찾을 수 없습니다", id)
 	}
 
 	return nil
@@ -735,7 +782,7 @@ func (s *Service) UpdateTitle(id, title string) error {
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("세션 '%s'을(를) 찾을 수 없습니다", id)
+		return errcode.New(errcode.KindNotFound, "세션 '%s'을(를) 찾을 수 없습니다", id)
 	}
 
 	return nil
@@ -762,7 +809,7 @@ func (s *Service) Get(id string) (*Session, error) {
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("세션 '%s'을(를) 찾을 수 없습니다", id)
+		return nil, errcode.New(errcode.KindNotFound, "세션 '%s'을(를) 찾을 수 없습니다", id)
 	}
 	if err != nil {
 		return nil, err
@@ -786,7 +833,7 @@ func (s *Service) List(activeOnly bool, limit int) ([]Session, error) {
 		       COALESCE(session_type, 'single'), parent_session,
 		       started_at, ended_at, jsonl_path,
 		       input_tokens, output_tokens, cache_read_tokens, cache_create_tokens,
-		       cost_usd, compact_count, last_compact_at
+		       cost_usd, compact_count, last_compact_at, project_root
 		FROM sessions
 	`
 
@@ -815,7 +862,7 @@ func (s *Service) List(activeOnly bool, limit int) ([]Session, error) {
 			&sessionType, &parentSession,
 			&sess.StartedAt, &sess.EndedAt,
 			&sess.JSONLPath, &sess.InputTokens, &sess.OutputTokens, &sess.CacheReadTokens,
-			&sess.CacheCreateTokens, &sess.CostUSD, &sess.CompactCount, &sess.LastCompactAt,
+			&sess.CacheCreateTokens, &sess.CostUSD, &sess.CompactCount, &sess.LastCompactAt, &sess.ProjectRoot,
 		); err != nil {
 			return nil, err
 		}
@@ -1000,6 +1047,7 @@ func (s *Service) GetStats() (*SessionStats, error) {
 			SUM(CASE WHEN status = 'running' THEN 1 ELSE 0 END) as active,
 			SUM(CASE WHEN status = 'complete' THEN 1 ELSE 0 END) as completed
 		FROM sessions
+		WHERE `+NotSandbox+` AND `+project.NotArchived+`
 	`).Scan(&stats.TotalSessions, &stats.ActiveSessions, &stats.CompletedSessions)
 	if err != nil {
 		return nil, err
@@ -1014,6 +1062,7 @@ func (s *Service) GetStats() (*SessionStats, error) {
 			COALESCE(SUM(cache_create_tokens), 0),
 			COALESCE(SUM(cost_usd), 0)
 		FROM sessions
+		WHERE `+NotSandbox+` AND `+project.NotArchived+`
 	`).Scan(&stats.TotalInputTokens, &stats.TotalOutputTokens, 
 		&stats.TotalCacheRead, &stats.TotalCacheCreate, &stats.TotalCostUSD)
 	if err != nil {
@@ -1026,7 +1075,7 @@ func (s *Service) GetStats() (*SessionStats, error) {
 			COALESCE(SUM(CAST((julianday(ended_at) - julianday(started_at)) * 86400 AS INTEGER)), 0),
 			COALESCE(AVG(CAST((julianday(ended_at) - julianday(started_at)) * 86400 AS REAL)), 0)
 		FROM sessions
-		WHERE ended_at IS NOT NULL
+		WHERE ended_at IS NOT NULL AND `+NotSandbox+` AND `+project.NotArchived+`
 	`).Scan(&stats.TotalDurationSecs, &stats.AvgDurationSecs)
 	if err != nil {
 		return nil, err
@@ -1076,6 +1125,22 @@ func (s *Service) ListDetailed(activeOnly bool, limit int) ([]SessionDetail, err
 		return nil, err
 	}
 
+	// 하위 세션 수는 한 번에 집계 (목록 API가 페이지네이션을 위해 많은 세션을 읽음)
+	children := make(map[string]int)
+	if rows, err := s.db.Query(`
+		SELECT parent_session, COUNT(*) FROM sessions
+		WHERE parent_session IS NOT NULL GROUP BY parent_session
+	`); err == nil {
+		for rows.Next() {
+			var parent string
+			var count int
+			if rows.Scan(&parent, &count) == nil {
+				children[parent] = count
+			}
+		}
+		rows.Close()
+	}
+
 	details := make([]SessionDetail, len(sessions))
 	for i, sess := range sessions {
 		details[i] = SessionDetail{Session: sess}
@@ -1088,8 +1153,7 @@ func (s *Service) ListDetailed(activeOnly bool, limit int) ([]SessionDetail, err
 		}
 		details[i].DurationStr = formatDuration(details[i].DurationSecs)
 
-		// Count children
-		s.db.QueryRow(`SELECT COUNT(*) FROM sessions WHERE parent_session = ?`, sess.ID).Scan(&details[i].ChildrenCount)
+		details[i].ChildrenCount = children[sess.ID]
 	}
 
 	return details, nil
@@ -1,9 +1,13 @@
 package session
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
 )
@@ -274,3 +278,465 @@ func TestIncrementCompact(t *testing.T) {
 		t.Errorf("CompactCount = %d, want 2", sess.CompactCount)
 	}
 }
+
+func TestSandboxSession(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	svc.Start("real", "", "실제 작업")
+	if err := svc.StartWithFullOptions(StartOptions{ID: "demo", Title: "데모", Sandbox: true}); err != nil {
+		t.Fatalf("샌드박스 세션 생성 실패: %v", err)
+	}
+	svc.StartWithOptions("demo-child", "", "하위", TypeSub, "demo")
+
+	if svc.IsSandbox("real") || !svc.IsSandbox("demo") {
+		t.Error("IsSandbox 결과 불일치")
+	}
+	if !svc.IsSandbox("demo-child") {
+		t.Error("샌드박스 세션의 하위 세션도 샌드박스여야 함")
+	}
+
+	// 이벤트는 기록됨
+	events, _ := svc.GetEvents("demo", "", 10)
+	if len(events) == 0 {
+		t.Error("샌드박스 세션도 이벤트는 기록되어야 함")
+	}
+
+	stats, err := svc.GetStats()
+	if err != nil {
+		t.Fatalf("GetStats 실패: %v", err)
+	}
+	if stats.TotalSessions != 1 {
+		t.Errorf("통계에서 샌드박스 세션이 제외되어야 함: total=%d", stats.TotalSessions)
+	}
+}
+
+func TestClassifyIntent(t *testing.T) {
+	tests := []struct {
+		prompt string
+		want   string
+	}{
+		{"로그인 시 에러가 나요, 고쳐주세요", IntentBugfix},
+		{"Fix the crash in the parser", IntentBugfix},
+		{"포트 목록에 필터 기능을 추가해줘", IntentFeature},
+		{"Implement pagination for the API", IntentFeature},
+		{"How does the lock service work?", IntentQuestion},
+		{"이 함수는 왜 두 번 호출되나요", IntentQuestion},
+		{"계속", IntentOther},
+		{"", IntentOther},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyIntent(tt.prompt); got != tt.want {
+			t.Errorf("ClassifyIntent(%q) = %s, want %s", tt.prompt, got, tt.want)
+		}
+	}
+}
+
+func TestLogEventSampled(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+
+	for i := 0; i < 5; i++ {
+		data := fmt.Sprintf(`{"file":"f%d.go"}`, i)
+		if err := svc.LogEventSampled("s1", EventFileEdit, data, 3); err != nil {
+			t.Fatalf("LogEventSampled failed: %v", err)
+		}
+	}
+
+	edits, _ := svc.GetEvents("s1", EventFileEdit, 0)
+	if len(edits) != 3 {
+		t.Errorf("individual events = %d, want 3", len(edits))
+	}
+
+	collapsed, _ := svc.GetEvents("s1", EventCollapsed, 0)
+	if len(collapsed) != 1 {
+		t.Fatalf("collapsed events = %d, want 1", len(collapsed))
+	}
+	var summary CollapsedEvents
+	if err := json.Unmarshal([]byte(collapsed[0].EventData), &summary); err != nil {
+		t.Fatalf("invalid summary: %v", err)
+	}
+	if summary.EventType != EventFileEdit || summary.Count != 2 {
+		t.Errorf("summary = %+v, want file_edit x2", summary)
+	}
+	if string(summary.Last) != `{"file":"f4.go"}` {
+		t.Errorf("summary.Last = %s", summary.Last)
+	}
+
+	// 제한 없음
+	for i := 0; i < 5; i++ {
+		svc.LogEventSampled("s2", EventFileEdit, `{}`, 0)
+	}
+	if edits, _ := svc.GetEvents("s2", EventFileEdit, 0); len(edits) != 5 {
+		t.Errorf("unlimited events = %d, want 5", len(edits))
+	}
+}
+
+func TestSubagentUsageRollup(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	if err := svc.Start("parent", "", ""); err != nil {
+		t.Fatalf("세션 시작 실패: %v", err)
+	}
+
+	childID := SubagentSessionID("parent", "agent-1")
+	if childID != SubagentSessionID("parent", "agent-1") {
+		t.Error("SubagentSessionID should be deterministic")
+	}
+	if err := svc.StartWithFullOptions(StartOptions{ID: childID, SessionType: TypeSub, ParentSession: "parent"}); err != nil {
+		t.Fatalf("자식 세션 시작 실패: %v", err)
+	}
+
+	// agent_id 조회 + agent_id 없는 경우 최근 자식 세션
+	for _, agentID := range []string{"agent-1", ""} {
+		child, err := svc.FindSubagentSession("parent", agentID)
+		if err != nil || child.ID != childID {
+			t.Errorf("FindSubagentSession(%q) = %v, %v", agentID, child, err)
+		}
+	}
+
+	usage := TokenUsage{InputTokens: 100, OutputTokens: 10, CostUSD: 0.5}
+	if err := svc.AddUsage("parent", usage); err != nil {
+		t.Fatalf("AddUsage failed: %v", err)
+	}
+	svc.EndWithSummary(childID, "complete", map[string]interface{}{"usage": usage})
+
+	parent, _ := svc.Get("parent")
+	if parent.InputTokens != 100 || parent.CostUSD != 0.5 {
+		t.Errorf("parent usage = %d/%f, want 100/0.5", parent.InputTokens, parent.CostUSD)
+	}
+	child, _ := svc.Get(childID)
+	if child.InputTokens != 0 {
+		t.Errorf("child token columns should stay empty, got %d", child.InputTokens)
+	}
+
+	total, err := svc.ChildUsage("parent")
+	if err != nil {
+		t.Fatalf("ChildUsage failed: %v", err)
+	}
+	if total.InputTokens != 100 || total.OutputTokens != 10 {
+		t.Errorf("ChildUsage = %+v", total)
+	}
+
+	if _, err := svc.FindSubagentSession("parent", ""); err == nil {
+		t.Error("ended child should not be found without agent_id")
+	}
+}
+
+func TestFindResumableAndReopen(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	svc.StartWithFullOptions(StartOptions{
+		ID: "prev", SessionType: TypeMain, ClaudeSessionID: "claude-1",
+		ProjectRoot: "/proj", TranscriptPath: "/t/a.jsonl",
+	})
+	svc.EndWithReason("prev", "exit")
+
+	// transcript_path 매칭
+	sess, by, err := svc.FindResumable(ResumeQuery{TranscriptPath: "/t/a.jsonl", ProjectRoot: "/proj"})
+	if err != nil || sess == nil || sess.ID != "prev" || by != ResumeByTranscript {
+		t.Fatalf("Expected transcript match, got %v %q %v", sess, by, err)
+	}
+
+	// 다른 프로젝트는 매칭하지 않음
+	if sess, _, _ := svc.FindResumable(ResumeQuery{TranscriptPath: "/t/a.jsonl", ProjectRoot: "/other"}); sess != nil {
+		t.Errorf("Should not match another project, got %s", sess.ID)
+	}
+
+	// fingerprint 매칭 (새 transcript 파일)
+	fingerprints := map[string]string{"/t/a.jsonl": "fp-1", "/t/b.jsonl": "fp-1"}
+	sess, by, _ = svc.FindResumable(ResumeQuery{
+		ClaudeSessionID: "claude-2", TranscriptPath: "/t/b.jsonl", ProjectRoot: "/proj",
+		Fingerprint: "fp-1", FingerprintOf: func(p string) string { return fingerprints[p] },
+	})
+	if sess == nil || sess.ID != "prev" || by != ResumeByFingerprint {
+		t.Fatalf("Expected fingerprint match, got %v %q", sess, by)
+	}
+
+	if err := svc.Reopen("prev", "claude-2", "/t/b.jsonl", by); err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	reopened, err := svc.FindByClaudeSessionID("claude-2")
+	if err != nil || reopened.ID != "prev" || reopened.TranscriptPath.String != "/t/b.jsonl" {
+		t.Fatalf("Reopened session should be running with new ids, got %v %v", reopened, err)
+	}
+
+	events, _ := svc.GetEvents("prev", EventSessionResumed, 10)
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 session_resumed event, got %d", len(events))
+	}
+	var data map[string]string
+	json.Unmarshal([]byte(events[0].EventData), &data)
+	if data["matched_by"] != ResumeByFingerprint || data["previous_status"] != "complete" {
+		t.Errorf("Unexpected event data: %v", data)
+	}
+}
+
+func TestPauseIdleSessions(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	svc.StartWithFullOptions(StartOptions{ID: "idle", ClaudeSessionID: "c-idle", ProjectRoot: "/proj"})
+	svc.StartWithFullOptions(StartOptions{ID: "busy", ClaudeSessionID: "c-busy", ProjectRoot: "/proj"})
+	svc.StartWithFullOptions(StartOptions{ID: "other", ClaudeSessionID: "c-other", ProjectRoot: "/other"})
+
+	// idle/other 세션의 활동을 1시간 전으로 이동
+	for _, id := range []string{"idle", "other"} {
+		database.Exec(`UPDATE sessions SET started_at = datetime('now', '-1 hours') WHERE id = ?`, id)
+		database.Exec(`UPDATE session_events SET created_at = datetime('now', '-1 hours') WHERE session_id = ?`, id)
+	}
+	database.Exec(`INSERT INTO locks (resource, session_id) VALUES ('src/**', 'idle')`)
+
+	paused, err := svc.PauseIdleSessions("/proj", 30*time.Minute)
+	if err != nil {
+		t.Fatalf("PauseIdleSessions failed: %v", err)
+	}
+	if len(paused) != 1 || paused[0].ID != "idle" {
+		t.Fatalf("Expected only 'idle' to be paused, got %+v", paused)
+	}
+	if len(paused[0].ReleasedLocks) != 1 || paused[0].IdleMinutes < 59 {
+		t.Errorf("Unexpected pause result: %+v", paused[0])
+	}
+
+	sess, _ := svc.Get("idle")
+	if sess.Status != StatusPaused {
+		t.Errorf("Expected paused status, got %s", sess.Status)
+	}
+	var locks int
+	database.QueryRow(`SELECT COUNT(*) FROM locks WHERE session_id = 'idle'`).Scan(&locks)
+	if locks != 0 {
+		t.Errorf("Locks should be released, got %d", locks)
+	}
+	if other, _ := svc.Get("other"); other.Status != "running" {
+		t.Errorf("Other project session should not be paused, got %s", other.Status)
+	}
+
+	// 다음 Hook 이벤트에서 재개
+	found, err := svc.FindActiveSession("c-idle", "", "")
+	if err != nil || found.ID != "idle" {
+		t.Fatalf("Expected paused session to be resumed, got %v %v", found, err)
+	}
+	events, _ := svc.GetEvents("idle", EventSessionUnpaused, 10)
+	if len(events) != 1 {
+		t.Errorf("Expected 1 session_unpaused event, got %d", len(events))
+	}
+
+	// 돌아온 Claude 세션은 일시정지하지 않고 Lock을 유지
+	database.Exec(`UPDATE session_events SET created_at = datetime('now', '-1 hours') WHERE session_id = 'idle'`)
+	database.Exec(`INSERT INTO locks (resource, session_id) VALUES ('docs/**', 'idle')`)
+	if paused, _ := svc.PauseIdleSessions("/proj", 30*time.Minute, "c-idle"); len(paused) != 0 {
+		t.Errorf("Returning Claude session should not be paused, got %+v", paused)
+	}
+	database.QueryRow(`SELECT COUNT(*) FROM locks WHERE session_id = 'idle'`).Scan(&locks)
+	if sess, _ := svc.Get("idle"); sess.Status != "running" || locks != 1 {
+		t.Errorf("Returning session should keep running with its lock, got %s with %d lock(s)", sess.Status, locks)
+	}
+
+	// 비활성화
+	if paused, _ := svc.PauseIdleSessions("", 0); len(paused) != 0 {
+		t.Errorf("Zero window should disable pausing, got %+v", paused)
+	}
+}
+
+func TestCheckBudget(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	svc.StartWithFullOptions(StartOptions{ID: "b1", ClaudeSessionID: "c-b1", ProjectRoot: "/proj"})
+	svc.StartWithFullOptions(StartOptions{ID: "b2", ClaudeSessionID: "c-b2", ProjectRoot: "/proj"})
+	limits := BudgetLimits{SessionUSD: 10, DailyUSD: 20}
+
+	svc.UpdateUsage("b1", 0, 0, 0, 0, 5.5)
+	status, err := svc.CheckBudget("b1", limits)
+	if err != nil {
+		t.Fatalf("CheckBudget failed: %v", err)
+	}
+	if len(status.Alarms) != 1 || status.Alarms[0].Threshold != 50 || status.Alarms[0].Scope != BudgetScopeSession {
+		t.Fatalf("Expected session 50%% alarm, got %+v", status.Alarms)
+	}
+
+	// 같은 임계치는 다시 경고하지 않음
+	if status, _ := svc.CheckBudget("b1", limits); len(status.Alarms) != 0 {
+		t.Errorf("Expected no repeated alarm, got %+v", status.Alarms)
+	}
+
+	// 한 번에 80%를 넘으면 80%만 경고, 일일 합계 (5.5+11=16.5/20)도 80%
+	svc.UpdateUsage("b2", 0, 0, 0, 0, 11)
+	status, _ = svc.CheckBudget("b2", limits)
+	if !status.Exceeded {
+		t.Error("Expected exceeded for b2 session budget")
+	}
+	got := map[string]int{}
+	for _, a := range status.Alarms {
+		got[a.Scope] = a.Threshold
+	}
+	if got[BudgetScopeSession] != 100 || got[BudgetScopeDaily] != 80 {
+		t.Errorf("Unexpected alarms: %+v", status.Alarms)
+	}
+
+	// 일일 경고는 같은 프로젝트의 다른 세션에서 반복되지 않음
+	svc.UpdateUsage("b1", 0, 0, 0, 0, 6)
+	status, _ = svc.CheckBudget("b1", limits)
+	for _, a := range status.Alarms {
+		if a.Scope == BudgetScopeDaily {
+			t.Errorf("Daily alarm should be logged once per day: %+v", a)
+		}
+	}
+
+	events, _ := svc.GetEvents("b2", EventBudgetWarning, 0)
+	if len(events) != 2 {
+		t.Errorf("Expected 2 budget_warning events for b2, got %d", len(events))
+	}
+}
+
+func TestPeers(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database.Exec(`INSERT INTO ports (id, status) VALUES ('api', 'running')`)
+
+	svc := NewService(database)
+	svc.StartWithFullOptions(StartOptions{ID: "me", Title: "나", ProjectRoot: "/proj", Cwd: "/proj"})
+	svc.StartWithFullOptions(StartOptions{ID: "peer", Title: "API 작업", ProjectRoot: "/proj", Cwd: "/proj/api", PortID: "api"})
+	svc.StartWithFullOptions(StartOptions{ID: "child", ProjectRoot: "/proj", ParentSession: "me"})
+	svc.StartWithFullOptions(StartOptions{ID: "other", ProjectRoot: "/other"})
+	database.Exec(`INSERT INTO ports (id, status, session_id) VALUES ('db-migrate', 'running', 'peer')`)
+	database.Exec(`INSERT INTO locks (resource, session_id) VALUES ('api/**', 'peer')`)
+
+	peers, err := svc.Peers("/proj", "me")
+	if err != nil {
+		t.Fatalf("Peers failed: %v", err)
+	}
+	if len(peers) != 1 || peers[0].ID != "peer" {
+		t.Fatalf("Expected only 'peer', got %+v", peers)
+	}
+	p := peers[0]
+	if fmt.Sprint(p.Ports) != "[api db-migrate]" || fmt.Sprint(p.Locks) != "[api/**]" {
+		t.Errorf("Unexpected ports/locks: %v %v", p.Ports, p.Locks)
+	}
+	if p.LastActivity == "" {
+		t.Error("LastActivity should be set")
+	}
+	if banner := p.Banner("/proj"); banner != "   • peer (API 작업) [api] 포트: api, db-migrate, Lock: api/**" {
+		t.Errorf("Unexpected banner: %q", banner)
+	}
+
+	all, _ := svc.Peers("/proj", "")
+	if len(all) != 3 {
+		t.Errorf("Expected all 3 running sessions of the project, got %d", len(all))
+	}
+	md := PeersMarkdown(all, time.Now())
+	for _, want := range []string{"## peer — API 작업", "- Lock: api/**", "- 포트: 없음"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("peers.md missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestLogEventAudit(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, ".pal"), 0755)
+
+	svc := NewService(database)
+	svc.StartWithFullOptions(StartOptions{ID: "s1", ProjectRoot: root})
+	for i := 0; i < 5; i++ {
+		svc.LogEventSampled("s1", EventFileEdit, fmt.Sprintf(`{"file":"f%d.go"}`, i), 2)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, ".pal", "audit", time.Now().UTC().Format("2006-01-02")+".jsonl"))
+	if err != nil {
+		t.Fatalf("audit file not written: %v", err)
+	}
+	// 샘플링으로 DB에는 요약되지만 감사 로그에는 모든 file_edit이 남음
+	if n := strings.Count(string(data), `"event_type":"file_edit"`); n != 5 {
+		t.Errorf("Expected 5 file_edit records in audit log, got %d:\n%s", n, data)
+	}
+}
+
+func TestEnvSnapshot(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	svc.StartWithFullOptions(StartOptions{ID: "a"})
+	svc.StartWithFullOptions(StartOptions{ID: "b"})
+
+	if env, err := svc.GetEnvSnapshot("a"); err != nil || env != nil {
+		t.Fatalf("Expected no snapshot before capture, got %+v, %v", env, err)
+	}
+
+	snap := CaptureEnv(t.TempDir())
+	if snap.OS == "" || snap.Arch == "" {
+		t.Fatalf("CaptureEnv should record OS/arch: %+v", snap)
+	}
+	if err := svc.SetEnvSnapshot("a", snap); err != nil {
+		t.Fatalf("SetEnvSnapshot failed: %v", err)
+	}
+	got, err := svc.GetEnvSnapshot("a")
+	if err != nil || got == nil || got.OS != snap.OS {
+		t.Fatalf("GetEnvSnapshot = %+v, %v", got, err)
+	}
+
+	other := *got
+	other.Tools = map[string]string{"go": "1.21.0", "node": "20.1.0"}
+	other.GitSHA = "abc"
+	other.GitDirty = true
+	got.Tools = map[string]string{"go": "1.24.1", "node": "20.1.0"}
+	got.GitSHA = "abc"
+	svc.SetEnvSnapshot("b", &other)
+
+	diffs := got.Diff(&other)
+	if len(diffs) != 2 || diffs[0].Field != "go" || diffs[1].Field != "git_sha" || diffs[1].B != "abc (dirty)" {
+		t.Errorf("Unexpected diff: %+v", diffs)
+	}
+}
+
+func TestCostAlerts(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	svc := NewService(database)
+	svc.StartWithFullOptions(StartOptions{ID: "c1", ClaudeSessionID: "c-c1", ProjectRoot: "/proj"})
+	thresholds := []float64{5, 2}
+
+	svc.UpdateUsage("c1", 0, 0, 0, 0, 1.5)
+	if alert, _ := svc.CheckCostAlerts("c1", thresholds); alert != nil {
+		t.Fatalf("Expected no alert below thresholds, got %+v", alert)
+	}
+
+	svc.UpdateUsage("c1", 0, 0, 0, 0, 2.4)
+	alert, err := svc.CheckCostAlerts("c1", thresholds)
+	if err != nil || alert == nil || alert.Threshold != 2 {
+		t.Fatalf("Expected $2 alert, got %+v (%v)", alert, err)
+	}
+	if again, _ := svc.CheckCostAlerts("c1", thresholds); again != nil {
+		t.Errorf("Same threshold should alert once, got %+v", again)
+	}
+
+	pending, _ := svc.TakeCostAlerts("c1")
+	if len(pending) != 1 || !pending[0].Delivered || pending[0].Message() != "세션 비용이 $2를 넘었습니다 (현재 $2.40)" {
+		t.Errorf("Unexpected pending alerts: %+v", pending)
+	}
+	if pending, _ := svc.TakeCostAlerts("c1"); len(pending) != 0 {
+		t.Errorf("Delivered alerts should not be returned again: %+v", pending)
+	}
+
+	// 다음 임계치는 새로 알림, 낮은 임계치는 반복하지 않음
+	svc.UpdateUsage("c1", 0, 0, 0, 0, 7)
+	if alert, _ := svc.CheckCostAlerts("c1", thresholds); alert == nil || alert.Threshold != 5 {
+		t.Errorf("Expected $5 alert, got %+v", alert)
+	}
+}
@@ -0,0 +1,101 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/n0roo/pal-kit/internal/errcode"
+)
+
+// TokenUsage is a token/cost total of a session
+type TokenUsage struct {
+	InputTokens       int64   `json:"input_tokens"`
+	OutputTokens      int64   `json:"output_tokens"`
+	CacheReadTokens   int64   `json:"cache_read_tokens"`
+	CacheCreateTokens int64   `json:"cache_create_tokens"`
+	CostUSD           float64 `json:"cost_usd"`
+}
+
+// SubagentSessionID derives the child session ID of a subagent.
+// SubagentStart/SubagentStop이 같은 agent_id로 같은 자식 세션을 찾을 수 있도록 결정적으로 생성합니다.
+func SubagentSessionID(parentID, agentID string) string {
+	sum := sha256.Sum256([]byte(parentID + ":" + agentID))
+	return "sa-" + hex.EncodeToString(sum[:])[:8]
+}
+
+// FindSubagentSession finds the running child session of a subagent.
+// agent_id가 없으면(구버전 Claude Code) 가장 최근에 시작된 실행 중 자식 세션을 반환합니다.
+func (s *Service) FindSubagentSession(parentID, agentID string) (*Session, error) {
+	if agentID != "" {
+		sess, err := s.Get(SubagentSessionID(parentID, agentID))
+		if err != nil {
+			return nil, errcode.New(errcode.KindNotFound, "서브에이전트 세션을 찾을 수 없습니다: %s", agentID)
+		}
+		return sess, nil
+	}
+
+	var id string
+	err := s.db.QueryRow(`
+		SELECT id FROM sessions
+		WHERE parent_session = ? AND session_type = ? AND status = 'running'
+		ORDER BY started_at DESC, rowid DESC
+		LIMIT 1
+	`, parentID, TypeSub).Scan(&id)
+	if err != nil {
+		return nil, errcode.New(errcode.KindNotFound, "실행 중인 서브에이전트 세션이 없습니다")
+	}
+	return s.Get(id)
+}
+
+// AddUsage adds token usage to a session (자식 세션 사용량을 부모로 합산)
+func (s *Service) AddUsage(id string, u TokenUsage) error {
+	_, err := s.db.Exec(`
+		UPDATE sessions
+		SET input_tokens = COALESCE(input_tokens, 0) + ?,
+		    output_tokens = COALESCE(output_tokens, 0) + ?,
+		    cache_read_tokens = COALESCE(cache_read_tokens, 0) + ?,
+		    cache_create_tokens = COALESCE(cache_create_tokens, 0) + ?,
+		    cost_usd = COALESCE(cost_usd, 0) + ?
+		WHERE id = ?
+	`, u.InputTokens, u.OutputTokens, u.CacheReadTokens, u.CacheCreateTokens, u.CostUSD, id)
+	if err != nil {
+		return fmt.Errorf("사용량 합산 실패: %w", err)
+	}
+	return nil
+}
+
+// ChildUsage returns the total usage of a session's subagent children.
+// 자식 세션 사용량은 집계 쿼리의 중복 합산을 막기 위해 토큰 컬럼이 아닌
+// output_summary의 usage에 기록되므로 여기서 합산합니다.
+func (s *Service) ChildUsage(parentID string) (*TokenUsage, error) {
+	rows, err := s.db.Query(`
+		SELECT COALESCE(output_summary, '') FROM sessions
+		WHERE parent_session = ? AND session_type = ?
+	`, parentID, TypeSub)
+	if err != nil {
+		return nil, fmt.Errorf("자식 세션 사용량 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	total := &TokenUsage{}
+	for rows.Next() {
+		var raw string
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		var summary struct {
+			Usage TokenUsage `json:"usage"`
+		}
+		if raw == "" || json.Unmarshal([]byte(raw), &summary) != nil {
+			continue
+		}
+		total.InputTokens += summary.Usage.InputTokens
+		total.OutputTokens += summary.Usage.OutputTokens
+		total.CacheReadTokens += summary.Usage.CacheReadTokens
+		total.CacheCreateTokens += summary.Usage.CacheCreateTokens
+		total.CostUSD += summary.Usage.CostUSD
+	}
+	return total, rows.Err()
+}
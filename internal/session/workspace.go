@@ -0,0 +1,75 @@
+package session
+
+import (
+	"fmt"
+)
+
+// ProjectStats aggregates sessions and ports of a single project root
+type ProjectStats struct {
+	ProjectRoot    string  `json:"project_root"`
+	TotalSessions  int     `json:"total_sessions"`
+	ActiveSessions int     `json:"active_sessions"`
+	TotalPorts     int     `json:"total_ports"`
+	RunningPorts   int     `json:"running_ports"`
+	InputTokens    int64   `json:"input_tokens"`
+	OutputTokens   int64   `json:"output_tokens"`
+	CostUSD        float64 `json:"cost_usd"`
+}
+
+// GetProjectStats returns session/port statistics of a project root (샌드박스 제외)
+func (s *Service) GetProjectStats(projectRoot string) (*ProjectStats, error) {
+	stats := &ProjectStats{ProjectRoot: projectRoot}
+
+	err := s.db.QueryRow(`
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN status = 'running' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(input_tokens), 0),
+			COALESCE(SUM(output_tokens), 0),
+			COALESCE(SUM(cost_usd), 0)
+		FROM sessions
+		WHERE project_root = ? AND `+NotSandbox+`
+	`, projectRoot).Scan(&stats.TotalSessions, &stats.ActiveSessions,
+		&stats.InputTokens, &stats.OutputTokens, &stats.CostUSD)
+	if err != nil {
+		return nil, fmt.Errorf("프로젝트 통계 조회 실패: %w", err)
+	}
+
+	err = s.db.QueryRow(`
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN p.status = 'running' THEN 1 ELSE 0 END), 0)
+		FROM ports p
+		JOIN sessions s ON p.session_id = s.id
+		WHERE s.project_root = ? AND COALESCE(s.sandbox, 0) = 0
+	`, projectRoot).Scan(&stats.TotalPorts, &stats.RunningPorts)
+	if err != nil {
+		return nil, fmt.Errorf("프로젝트 포트 통계 조회 실패: %w", err)
+	}
+
+	return stats, nil
+}
+
+// ListWorkspaceProjects returns project roots of sessions recorded under a workspace root
+func (s *Service) ListWorkspaceProjects(workspaceRoot string) ([]string, error) {
+	rows, err := s.db.Query(`
+		SELECT DISTINCT project_root
+		FROM sessions
+		WHERE workspace_root = ? AND project_root IS NOT NULL
+		ORDER BY project_root
+	`, workspaceRoot)
+	if err != nil {
+		return nil, fmt.Errorf("워크스페이스 프로젝트 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var roots []string
+	for rows.Next() {
+		var root string
+		if err := rows.Scan(&root); err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+	return roots, rows.Err()
+}
@@ -0,0 +1,246 @@
+// Package telemetry collects opt-in, anonymized usage statistics.
+// 기본은 꺼져 있으며(pal telemetry enable), 명령 이름별 실행 횟수, DB 크기 구간, 오류 유형만 집계합니다.
+// 명령 인자, 경로, 프로젝트/세션 내용은 기록하지 않습니다.
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileName is the telemetry state file in the global PAL directory
+const FileName = "telemetry.json"
+
+// EnvDoNotTrack disables telemetry regardless of the stored setting (DO_NOT_TRACK=1)
+const EnvDoNotTrack = "DO_NOT_TRACK"
+
+// EnvEndpoint overrides the report endpoint
+const EnvEndpoint = "PAL_TELEMETRY_ENDPOINT"
+
+// DefaultEndpoint is the report endpoint of release builds (ldflags로 설정, 비어 있으면 전송하지 않음)
+var DefaultEndpoint = ""
+
+// SendInterval is how often collected counts are reported
+const SendInterval = 24 * time.Hour
+
+// sendTimeout keeps reporting from delaying the command that triggered it
+const sendTimeout = 2 * time.Second
+
+// State is the stored telemetry setting and the counts collected since the last report
+type State struct {
+	Enabled   bool           `json:"enabled"`
+	InstallID string         `json:"install_id,omitempty"` // 무작위 설치 ID (사용자/머신 정보와 무관)
+	Endpoint  string         `json:"endpoint,omitempty"`
+	Since     time.Time      `json:"since,omitempty"` // 현재 집계 시작 시각
+	LastSent  *time.Time     `json:"last_sent,omitempty"`
+	Commands  map[string]int `json:"commands,omitempty"`
+	Errors    map[string]int `json:"errors,omitempty"` // 오류 유형(errcode Kind)별 횟수
+}
+
+// Report is exactly what is sent to the endpoint
+type Report struct {
+	InstallID   string         `json:"install_id"`
+	Version     string         `json:"version"`
+	OS          string         `json:"os"`
+	Arch        string         `json:"arch"`
+	PeriodStart time.Time      `json:"period_start"`
+	PeriodEnd   time.Time      `json:"period_end"`
+	Commands    map[string]int `json:"commands"`
+	Errors      map[string]int `json:"errors"`
+	DBSize      string         `json:"db_size"` // 크기 구간 (정확한 크기는 보내지 않음)
+}
+
+// Store reads and writes the telemetry state file
+type Store struct {
+	path string
+}
+
+// NewStore creates a store in the given directory (보통 ~/.pal)
+func NewStore(dir string) *Store {
+	return &Store{path: filepath.Join(dir, FileName)}
+}
+
+// Path returns the state file path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the state (파일이 없으면 비활성 상태)
+func (s *Store) Load() (*State, error) {
+	st := &State{}
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("텔레메트리 설정 읽기 실패: %w", err)
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("텔레메트리 설정 파싱 실패: %w", err)
+	}
+	return st, nil
+}
+
+// Save writes the state
+func (s *Store) Save(st *State) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %w", err)
+	}
+	data, _ := json.MarshalIndent(st, "", "  ")
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("텔레메트리 설정 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// Enable turns telemetry on with a fresh install ID and empty counts
+func (s *Store) Enable(endpoint string, now time.Time) (*State, error) {
+	st, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	if !st.Enabled || st.InstallID == "" {
+		st = &State{InstallID: uuid.New().String(), Since: now}
+	}
+	st.Enabled = true
+	if endpoint != "" {
+		st.Endpoint = endpoint
+	}
+	return st, s.Save(st)
+}
+
+// Disable turns telemetry off and discards the install ID and unsent counts
+func (s *Store) Disable() error {
+	st, err := s.Load()
+	if err != nil {
+		return err
+	}
+	return s.Save(&State{Endpoint: st.Endpoint})
+}
+
+// Active reports whether counts should be recorded
+func (st *State) Active() bool {
+	return st.Enabled && os.Getenv(EnvDoNotTrack) != "1"
+}
+
+// ReportEndpoint returns the endpoint reports are sent to ("" = 전송하지 않음)
+func (st *State) ReportEndpoint() string {
+	if v := os.Getenv(EnvEndpoint); v != "" {
+		return v
+	}
+	if st.Endpoint != "" {
+		return st.Endpoint
+	}
+	return DefaultEndpoint
+}
+
+// Record counts one command run and, if it failed, its error kind.
+// 비활성 상태면 아무것도 기록하지 않습니다.
+func (s *Store) Record(command, errKind string) error {
+	st, err := s.Load()
+	if err != nil || !st.Active() {
+		return err
+	}
+	if st.Commands == nil {
+		st.Commands = map[string]int{}
+	}
+	st.Commands[command]++
+	if errKind != "" {
+		if st.Errors == nil {
+			st.Errors = map[string]int{}
+		}
+		st.Errors[errKind]++
+	}
+	return s.Save(st)
+}
+
+// BuildReport builds the report for the collected counts
+func BuildReport(st *State, version, dbPath string, now time.Time) Report {
+	r := Report{
+		InstallID:   st.InstallID,
+		Version:     version,
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		PeriodStart: st.Since,
+		PeriodEnd:   now,
+		Commands:    map[string]int{},
+		Errors:      map[string]int{},
+		DBSize:      DBSizeBucket(fileSize(dbPath)),
+	}
+	for k, v := range st.Commands {
+		r.Commands[k] = v
+	}
+	for k, v := range st.Errors {
+		r.Errors[k] = v
+	}
+	return r
+}
+
+// DBSizeBucket returns the coarse size bucket of a DB file
+func DBSizeBucket(size int64) string {
+	const mb = 1 << 20
+	switch {
+	case size <= 0:
+		return "none"
+	case size < mb:
+		return "0-1MB"
+	case size < 10*mb:
+		return "1-10MB"
+	case size < 100*mb:
+		return "10-100MB"
+	case size < 1024*mb:
+		return "100MB-1GB"
+	default:
+		return "1GB+"
+	}
+}
+
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// Due reports whether a report should be sent now
+func (st *State) Due(now time.Time) bool {
+	if !st.Active() || st.ReportEndpoint() == "" || len(st.Commands) == 0 {
+		return false
+	}
+	return st.LastSent == nil || now.Sub(*st.LastSent) >= SendInterval
+}
+
+// SendIfDue sends the collected counts once per SendInterval and resets them on success
+func (s *Store) SendIfDue(version, dbPath string, now time.Time) (bool, error) {
+	st, err := s.Load()
+	if err != nil || !st.Due(now) {
+		return false, err
+	}
+
+	report := BuildReport(st, version, dbPath, now)
+	body, _ := json.Marshal(report)
+	client := &http.Client{Timeout: sendTimeout}
+	resp, err := client.Post(st.ReportEndpoint(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("텔레메트리 전송 실패: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return false, fmt.Errorf("텔레메트리 전송 실패: HTTP %d", resp.StatusCode)
+	}
+
+	st.LastSent = &now
+	st.Since = now
+	st.Commands = nil
+	st.Errors = nil
+	return true, s.Save(st)
+}
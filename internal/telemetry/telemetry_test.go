@@ -0,0 +1,101 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordRequiresOptIn(t *testing.T) {
+	t.Setenv(EnvDoNotTrack, "")
+	store := NewStore(t.TempDir())
+
+	if err := store.Record("port start", ""); err != nil {
+		t.Fatalf("Record 실패: %v", err)
+	}
+	if _, err := os.Stat(store.Path()); !os.IsNotExist(err) {
+		t.Error("disabled telemetry should not write anything")
+	}
+
+	now := time.Now()
+	if _, err := store.Enable("", now); err != nil {
+		t.Fatalf("Enable 실패: %v", err)
+	}
+	store.Record("port start", "")
+	store.Record("port start", "not_found")
+
+	t.Setenv(EnvDoNotTrack, "1")
+	store.Record("port list", "")
+	t.Setenv(EnvDoNotTrack, "")
+
+	st, _ := store.Load()
+	if st.InstallID == "" || st.Commands["port start"] != 2 || st.Commands["port list"] != 0 || st.Errors["not_found"] != 1 {
+		t.Errorf("state = %+v", st)
+	}
+
+	if err := store.Disable(); err != nil {
+		t.Fatalf("Disable 실패: %v", err)
+	}
+	st, _ = store.Load()
+	if st.Enabled || st.InstallID != "" || len(st.Commands) != 0 {
+		t.Errorf("disable should discard counts: %+v", st)
+	}
+}
+
+func TestSendIfDue(t *testing.T) {
+	t.Setenv(EnvDoNotTrack, "")
+	t.Setenv(EnvEndpoint, "")
+
+	var received Report
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "pal.db")
+	os.WriteFile(dbPath, make([]byte, 2<<20), 0644)
+
+	store := NewStore(dir)
+	now := time.Now()
+	store.Enable(srv.URL, now)
+
+	if sent, _ := store.SendIfDue("1.0.0", dbPath, now); sent {
+		t.Error("nothing recorded, nothing to send")
+	}
+	store.Record("orch list", "")
+
+	preview := BuildReport(mustLoad(t, store), "1.0.0", dbPath, now)
+	sent, err := store.SendIfDue("1.0.0", dbPath, now)
+	if err != nil || !sent {
+		t.Fatalf("SendIfDue = %v, %v", sent, err)
+	}
+	if received.InstallID != preview.InstallID || received.Commands["orch list"] != 1 || received.DBSize != "1-10MB" {
+		t.Errorf("received = %+v", received)
+	}
+
+	st := mustLoad(t, store)
+	if len(st.Commands) != 0 || st.LastSent == nil {
+		t.Errorf("counts should reset after sending: %+v", st)
+	}
+	store.Record("orch list", "")
+	if sent, _ := store.SendIfDue("1.0.0", dbPath, now.Add(time.Hour)); sent {
+		t.Error("should send at most once per interval")
+	}
+	if sent, _ := store.SendIfDue("1.0.0", dbPath, now.Add(SendInterval)); !sent {
+		t.Error("should send after the interval")
+	}
+}
+
+func mustLoad(t *testing.T, store *Store) *State {
+	t.Helper()
+	st, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load 실패: %v", err)
+	}
+	return st
+}
@@ -0,0 +1,316 @@
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PortTemplate scaffolds a port spec: frontmatter, body, checklist and the conventions to pre-link.
+//
+// 프로젝트 템플릿은 .claude/templates/ports/<name>.yaml에 정의하며 같은 이름의 기본 템플릿을 덮어씁니다.
+//
+//	description: gRPC 서비스 메서드 추가
+//	conventions: [naming, error-handling]   # 컨벤션 ID 또는 타입
+//	checklist:
+//	  - proto 정의 리뷰
+//	body: |
+//	  ## 컨텍스트
+//	  ...
+type PortTemplate struct {
+	Name        string   `yaml:"-" json:"name"`
+	Description string   `yaml:"description" json:"description"`
+	Conventions []string `yaml:"conventions,omitempty" json:"conventions,omitempty"`
+	Checklist   []string `yaml:"checklist,omitempty" json:"checklist,omitempty"`
+	Body        string   `yaml:"body" json:"-"`
+	Source      string   `yaml:"-" json:"source"` // builtin 또는 파일 경로
+}
+
+// PortConvention is a convention pre-linked to a scaffolded port
+type PortConvention struct {
+	ID          string
+	Name        string
+	Description string
+}
+
+// PortData holds data for rendering a port template
+type PortData struct {
+	TemplateData
+	Template    string
+	Conventions []PortConvention
+	DependsOn   []string
+	Checklist   []string
+}
+
+// builtinPortTemplates are the port templates shipped with PAL Kit
+var builtinPortTemplates = []PortTemplate{
+	{
+		Name:        "default",
+		Description: "기본 작업 단위 명세",
+		Checklist:   []string{"컴파일 성공", "테스트 통과", "컨벤션 준수"},
+		Body:        scaffoldBody,
+	},
+	{
+		Name:        "api-endpoint",
+		Description: "API 엔드포인트 추가 (요청/응답, 에러, 인증, 테스트)",
+		Conventions: []string{"naming", "error-handling", "testing"},
+		Checklist: []string{
+			"요청/응답 스키마 정의",
+			"입력 검증 및 에러 응답 코드 정의",
+			"인증/권한 확인",
+			"핸들러 단위 테스트",
+			"통합 테스트 (정상/에러 경로)",
+			"API 문서 갱신",
+		},
+		Body: apiEndpointBody,
+	},
+	{
+		Name:        "bugfix",
+		Description: "버그 수정 (재현, 원인, 회귀 테스트)",
+		Conventions: []string{"testing"},
+		Checklist:   []string{"원인 확인", "최소 범위 수정", "회귀 테스트 추가", "기존 테스트 통과"},
+		Body:        bugfixScaffoldBody,
+	},
+}
+
+// PortTemplates returns the builtin and project port templates sorted by name
+func (s *Service) PortTemplates() ([]PortTemplate, error) {
+	byName := make(map[string]PortTemplate)
+	for _, t := range builtinPortTemplates {
+		t.Source = "builtin"
+		byName[t.Name] = t
+	}
+
+	dir := filepath.Join(s.templatesDir, "ports")
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("포트 템플릿 디렉토리 읽기 실패: %w", err)
+	}
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("포트 템플릿 읽기 실패: %w", err)
+		}
+		var t PortTemplate
+		if err := yaml.Unmarshal(data, &t); err != nil {
+			return nil, fmt.Errorf("포트 템플릿 파싱 실패 (%s): %w", e.Name(), err)
+		}
+		t.Name = strings.TrimSuffix(e.Name(), ext)
+		t.Source = path
+		byName[t.Name] = t
+	}
+
+	templates := make([]PortTemplate, 0, len(byName))
+	for _, t := range byName {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// PortTemplate returns a port template by name
+func (s *Service) PortTemplate(name string) (*PortTemplate, error) {
+	templates, err := s.PortTemplates()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for i := range templates {
+		if templates[i].Name == name {
+			return &templates[i], nil
+		}
+		names = append(names, templates[i].Name)
+	}
+	return nil, fmt.Errorf("알 수 없는 포트 템플릿: %s (가능: %s)", name, strings.Join(names, ", "))
+}
+
+// Render renders the port spec with a frontmatter, the template body and the checklist
+func (t *PortTemplate) Render(data PortData) (string, error) {
+	if data.Date == "" {
+		data.Date = time.Now().Format("2006-01-02")
+	}
+	if data.Timestamp == "" {
+		data.Timestamp = time.Now().Format("2006-01-02 15:04:05")
+	}
+	if data.Title == "" {
+		data.Title = data.ID
+	}
+	data.Template = t.Name
+	if len(data.Checklist) == 0 {
+		data.Checklist = t.Checklist
+	}
+
+	tmpl, err := template.New(t.Name).Parse(t.Body)
+	if err != nil {
+		return "", fmt.Errorf("포트 템플릿 파싱 실패: %w", err)
+	}
+	var body strings.Builder
+	if err := tmpl.Execute(&body, data); err != nil {
+		return "", fmt.Errorf("포트 템플릿 렌더링 실패: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	sb.WriteString(fmt.Sprintf("port: %s\n", data.ID))
+	sb.WriteString(fmt.Sprintf("title: %q\n", data.Title))
+	sb.WriteString("type: port\n")
+	sb.WriteString("status: pending\n")
+	sb.WriteString(fmt.Sprintf("template: %s\n", t.Name))
+	sb.WriteString(fmt.Sprintf("conventions: [%s]\n", strings.Join(conventionIDs(data.Conventions), ", ")))
+	sb.WriteString(fmt.Sprintf("depends_on: [%s]\n", strings.Join(data.DependsOn, ", ")))
+	sb.WriteString(fmt.Sprintf("created: %s\n", data.Date))
+	sb.WriteString("---\n\n")
+	sb.WriteString(fmt.Sprintf("# %s\n\n", data.Title))
+	sb.WriteString(strings.TrimSpace(body.String()))
+	sb.WriteString("\n")
+
+	// 본문이 체크리스트를 배치하지 않으면 끝에 추가
+	if !strings.Contains(t.Body, ".Checklist") && len(data.Checklist) > 0 {
+		sb.WriteString("\n## 완료 체크리스트\n\n")
+		for _, item := range data.Checklist {
+			sb.WriteString(fmt.Sprintf("- [ ] %s\n", item))
+		}
+	}
+	return sb.String(), nil
+}
+
+func conventionIDs(convs []PortConvention) []string {
+	ids := make([]string, len(convs))
+	for i, c := range convs {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+// Shared sections of the builtin port templates
+
+const dependencySection = `## 의존성
+
+{{if .DependsOn}}| 선행 포트 | 전달받을 산출물 |
+|-----------|-----------------|
+{{range .DependsOn}}| {{.}} |  |
+{{end}}{{else}}선행 포트 없음
+{{end}}`
+
+const conventionSection = `## 컨벤션
+
+{{if .Conventions}}{{range .Conventions}}- **{{.Name}}** ({{.ID}}){{if .Description}}: {{.Description}}{{end}}
+{{end}}{{else}}-
+{{end}}`
+
+const checklistSection = `## 완료 체크리스트
+
+{{range .Checklist}}- [ ] {{.}}
+{{end}}`
+
+var scaffoldBody = `## 컨텍스트
+
+- 상위 요구사항: {{.Description}}
+- 작업 목적:
+
+` + dependencySection + `
+## 작업 범위 (배타적 소유권)
+
+### 생성/수정할 파일
+-
+
+### 구현할 기능
+-
+
+` + conventionSection + `
+## 검증
+
+### 컴파일/테스트 명령
+` + "```bash" + `
+# 빌드/테스트 명령
+` + "```" + `
+
+` + checklistSection
+
+var apiEndpointBody = `## 컨텍스트
+
+- 상위 요구사항: {{.Description}}
+- 작업 목적:
+
+## 엔드포인트
+
+| 항목 | 값 |
+|------|----|
+| Method | |
+| Path | |
+| 인증 | |
+
+### 요청
+` + "```json" + `
+{}
+` + "```" + `
+
+### 응답
+` + "```json" + `
+{}
+` + "```" + `
+
+### 에러
+| 코드 | 조건 |
+|------|------|
+| 400 | 입력 검증 실패 |
+| 401 | 인증 실패 |
+| 404 | 대상 없음 |
+
+` + dependencySection + `
+## 작업 범위 (배타적 소유권)
+
+### 생성/수정할 파일
+- 핸들러:
+- 서비스:
+- 테스트:
+
+` + conventionSection + `
+## 검증
+
+### 컴파일/테스트 명령
+` + "```bash" + `
+# 빌드/테스트 명령
+` + "```" + `
+
+` + checklistSection
+
+var bugfixScaffoldBody = `## 버그 설명
+
+{{if .Description}}{{.Description}}{{else}}- {{end}}
+
+## 재현
+
+### 재현 절차
+1.
+
+### 기대 동작
+-
+
+### 실제 동작
+-
+
+## 원인 분석
+
+- 가설:
+- 확인된 원인:
+
+` + dependencySection + `
+## 작업 범위 (배타적 소유권)
+
+### 생성/수정할 파일
+-
+
+` + conventionSection + `
+` + checklistSection
@@ -0,0 +1,78 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPortTemplateRender(t *testing.T) {
+	svc := NewService(t.TempDir())
+	tmpl, err := svc.PortTemplate("api-endpoint")
+	if err != nil {
+		t.Fatalf("PortTemplate failed: %v", err)
+	}
+
+	content, err := tmpl.Render(PortData{
+		TemplateData: TemplateData{ID: "users-api", Title: "사용자 API", Date: "2026-01-02"},
+		Conventions:  []PortConvention{{ID: "naming", Name: "Naming", Description: "카멜 케이스"}},
+		DependsOn:    []string{"db-schema"},
+	})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"---\nport: users-api\n",
+		"template: api-endpoint\n",
+		"conventions: [naming]\n",
+		"depends_on: [db-schema]\n",
+		"# 사용자 API\n",
+		"| db-schema |  |",
+		"- **Naming** (naming): 카멜 케이스",
+		"- [ ] 요청/응답 스키마 정의",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("rendered spec missing %q:\n%s", want, content)
+		}
+	}
+
+	if _, err := svc.PortTemplate("nope"); err == nil {
+		t.Error("unknown template should fail")
+	}
+}
+
+func TestProjectPortTemplateOverride(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, ".claude", "templates", "ports")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "bugfix.yaml"), []byte(`description: 팀 버그 수정
+checklist: [핫픽스 브랜치]
+body: |
+  ## 버그
+  {{.Description}}
+`), 0644)
+
+	svc := NewService(root)
+	templates, err := svc.PortTemplates()
+	if err != nil {
+		t.Fatalf("PortTemplates failed: %v", err)
+	}
+	if len(templates) != len(builtinPortTemplates) {
+		t.Errorf("project template should replace the builtin one, got %d templates", len(templates))
+	}
+
+	tmpl, _ := svc.PortTemplate("bugfix")
+	if tmpl.Description != "팀 버그 수정" {
+		t.Fatalf("expected project template, got %+v", tmpl)
+	}
+	content, err := tmpl.Render(PortData{TemplateData: TemplateData{ID: "fix-1", Description: "로그인 실패"}})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(content, "## 버그\n로그인 실패") || !strings.Contains(content, "# fix-1\n") ||
+		!strings.Contains(content, "## 완료 체크리스트\n\n- [ ] 핫픽스 브랜치") {
+		t.Errorf("unexpected render:\n%s", content)
+	}
+}
@@ -17,17 +17,19 @@ const (
 	TypeAgent   TemplateType = "agent"
 	TypeSession TemplateType = "session"
 	TypeHook    TemplateType = "hook"
+	TypeBugfix  TemplateType = "bugfix"
 )
 
 // ValidTypes lists all valid template types
-var ValidTypes = []TemplateType{TypePort, TypeAgent, TypeSession, TypeHook}
+var ValidTypes = []TemplateType{TypePort, TypeAgent, TypeSession, TypeHook, TypeBugfix}
 
 // TemplateData holds data for template rendering
 type TemplateData struct {
-	ID        string
-	Title     string
-	Date      string
-	Timestamp string
+	ID          string
+	Title       string
+	Description string
+	Date        string
+	Timestamp   string
 }
 
 // Service handles template operations
@@ -99,6 +101,8 @@ func (s *Service) GetTemplateContent(templateType TemplateType) (string, error)
 		return sessionTemplate, nil
 	case TypeHook:
 		return hookTemplate, nil
+	case TypeBugfix:
+		return bugfixTemplate, nil
 	default:
 		return "", fmt.Errorf("알 수 없는 템플릿 타입: %s", templateType)
 	}
@@ -125,6 +129,8 @@ func GetTypeDescription(t TemplateType) string {
 		return "세션 기록"
 	case TypeHook:
 		return "Hook 스크립트"
+	case TypeBugfix:
+		return "버그 수정 포트 명세서"
 	default:
 		return ""
 	}
@@ -208,6 +214,72 @@ var portTemplate = `# {{.Title}}
 - 
 `
 
+var bugfixTemplate = `# {{.Title}}
+
+> Port ID: {{.ID}}
+> Type: bugfix
+> Created: {{.Date}}
+
+---
+
+## 버그 설명
+
+{{if .Description}}{{.Description}}{{else}}- {{end}}
+
+---
+
+## 재현
+
+### 재현 절차
+1. 
+
+### 기대 동작
+- 
+
+### 실제 동작
+- 
+
+---
+
+## 원인 분석
+
+### 가설
+- 
+
+### 확인된 원인
+- 
+
+---
+
+## 작업 범위 (배타적 소유권)
+
+### 생성/수정할 파일
+` + "```" + `
+# 수정이 필요한 최소한의 파일만 나열
+` + "```" + `
+
+---
+
+## 검증
+
+### 회귀 테스트
+- [ ] 버그를 재현하는 실패 테스트 작성
+- [ ] 수정 후 테스트 통과
+
+### 완료 체크리스트
+- [ ] 원인 확인
+- [ ] 최소 범위 수정
+- [ ] 회귀 테스트 추가
+- [ ] 기존 테스트 통과
+
+---
+
+## 출력
+
+### 수정 요약
+- 
+`
+
 var agentTemplate = `---
 name: {{.ID}}
 description: {{.Title}}
@@ -319,7 +391,7 @@ exit 0
 // GetDefaultOutputPath returns the default output path for a template type
 func GetDefaultOutputPath(templateType TemplateType, id string) string {
 	switch templateType {
-	case TypePort:
+	case TypePort, TypeBugfix:
 		return fmt.Sprintf("ports/%s.md", id)
 	case TypeAgent:
 		return fmt.Sprintf(".claude/agents/%s.md", id)
@@ -0,0 +1,118 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// CompactMarker is a compaction boundary found in a transcript
+type CompactMarker struct {
+	UUID      string    `json:"uuid"`
+	Timestamp time.Time `json:"timestamp"`
+	Trigger   string    `json:"trigger"` // auto, manual
+	PreTokens int64     `json:"pre_tokens"`
+	Summary   string    `json:"summary,omitempty"`
+}
+
+// maxMarkerSummary limits the summary kept from the compaction summary message
+const maxMarkerSummary = 2000
+
+// compactEntry is the subset of a transcript entry needed to find compactions
+type compactEntry struct {
+	Type             string `json:"type"`
+	Subtype          string `json:"subtype"`
+	UUID             string `json:"uuid"`
+	Timestamp        string `json:"timestamp"`
+	IsCompactSummary bool   `json:"isCompactSummary"`
+	CompactMetadata  *struct {
+		Trigger   string `json:"trigger"`
+		PreTokens int64  `json:"preTokens"`
+	} `json:"compactMetadata"`
+	Message *struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// ParseCompactions returns the compaction boundaries of a transcript in file order.
+// compact_boundary 시스템 엔트리를 경계로 보고, 바로 뒤따르는 요약 메시지(isCompactSummary)를 Summary로 붙입니다.
+func ParseCompactions(path string) ([]CompactMarker, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 10*1024*1024)
+
+	var markers []CompactMarker
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry compactEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+
+		if entry.Type == "system" && entry.Subtype == "compact_boundary" {
+			ts, err := time.Parse(time.RFC3339Nano, entry.Timestamp)
+			if err != nil {
+				continue
+			}
+			marker := CompactMarker{UUID: entry.UUID, Timestamp: ts, Trigger: "auto"}
+			if m := entry.CompactMetadata; m != nil {
+				if m.Trigger != "" {
+					marker.Trigger = m.Trigger
+				}
+				marker.PreTokens = m.PreTokens
+			}
+			markers = append(markers, marker)
+			continue
+		}
+
+		if entry.IsCompactSummary && len(markers) > 0 && entry.Message != nil {
+			last := &markers[len(markers)-1]
+			if last.Summary == "" {
+				last.Summary = truncateSummary(messageText(entry.Message.Content))
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("파일 읽기 실패: %w", err)
+	}
+
+	return markers, nil
+}
+
+// messageText returns the text of a message content that is either a string or content blocks
+func messageText(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var blocks []ContentBlock
+	if err := json.Unmarshal(raw, &blocks); err == nil {
+		for _, block := range blocks {
+			if block.Type == "text" && block.Text != "" {
+				return block.Text
+			}
+		}
+	}
+	return ""
+}
+
+func truncateSummary(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxMarkerSummary {
+		return s
+	}
+	return string(runes[:maxMarkerSummary]) + "..."
+}
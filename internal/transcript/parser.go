@@ -2,9 +2,12 @@ package transcript
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 )
 
 // Usage represents aggregated token usage from a transcript
@@ -21,6 +24,7 @@ type Usage struct {
 type TranscriptEntry struct {
 	Type      string         `json:"type"`
 	SessionID string         `json:"sessionId"`
+	Timestamp string         `json:"timestamp,omitempty"`
 	Message   *MessageDetail `json:"message,omitempty"`
 }
 
@@ -84,6 +88,27 @@ var defaultPricing = ModelPricing{
 
 // ParseFile parses a JSONL transcript file and returns aggregated usage
 func ParseFile(path string) (*Usage, error) {
+	return parseUsage(path, nil)
+}
+
+// ParseFileRange aggregates usage of assistant messages within [from, to].
+// 포트 작업 구간(port-start ~ port-end)의 사용량 귀속에 사용합니다.
+// 타임스탬프가 없는 메시지는 제외되며, to가 zero면 상한 없이 집계합니다.
+func ParseFileRange(path string, from, to time.Time) (*Usage, error) {
+	return parseUsage(path, func(entry *TranscriptEntry) bool {
+		ts, err := time.Parse(time.RFC3339Nano, entry.Timestamp)
+		if err != nil {
+			return false
+		}
+		if ts.Before(from) {
+			return false
+		}
+		return to.IsZero() || !ts.After(to)
+	})
+}
+
+// parseUsage aggregates usage of assistant messages accepted by include
+func parseUsage(path string, include func(*TranscriptEntry) bool) (*Usage, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("파일 열기 실패: %w", err)
@@ -115,6 +140,9 @@ func ParseFile(path string) (*Usage, error) {
 		if entry.Message == nil || entry.Message.Usage == nil {
 			continue
 		}
+		if include != nil && !include(&entry) {
+			continue
+		}
 
 		u := entry.Message.Usage
 		usage.InputTokens += u.InputTokens
@@ -174,6 +202,7 @@ type HumanEntry struct {
 type ContentBlock struct {
 	Type string `json:"type"`
 	Text string `json:"text,omitempty"`
+	Name string `json:"name,omitempty"` // tool_use 블록의 도구 이름
 }
 
 // GetFirstUserMessage extracts the first user message from transcript
@@ -288,3 +317,52 @@ func GetUserMessages(path string, limit int) ([]UserMessage, error) {
 
 	return messages, nil
 }
+
+// CountToolUses counts tool_use blocks per tool name in assistant messages.
+// 서브에이전트 transcript에서 사용한 도구 집계에 사용합니다.
+func CountToolUses(path string) (map[string]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 10*1024*1024)
+
+	counts := make(map[string]int)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry HumanEntry
+		if err := json.Unmarshal(line, &entry); err != nil || entry.Type != "assistant" {
+			continue
+		}
+		for _, block := range entry.Message.Content {
+			if block.Type == "tool_use" && block.Name != "" {
+				counts[block.Name]++
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("파일 읽기 실패: %w", err)
+	}
+	return counts, nil
+}
+
+// Fingerprint identifies a conversation by its first user message.
+// --resume/--continue로 새 transcript 파일이 만들어져도 기존 대화 내용이 복사되므로 같은 값을 가집니다.
+// 사용자 메시지가 없으면 빈 문자열을 반환합니다.
+func Fingerprint(path string) string {
+	msg, err := GetFirstUserMessage(path)
+	if err != nil || msg == nil {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(msg.Timestamp + "\n" + msg.Content))
+	return hex.EncodeToString(hash[:])[:16]
+}
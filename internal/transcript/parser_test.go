@@ -1,7 +1,10 @@
 package transcript
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestParseFile(t *testing.T) {
@@ -30,3 +33,109 @@ func TestParseFile(t *testing.T) {
 	t.Logf("Cost: $%.4f", usage.CostUSD)
 	t.Logf("Messages: %d", usage.MessageCount)
 }
+
+func TestParseFileRange(t *testing.T) {
+	lines := `{"type":"assistant","timestamp":"2026-01-05T10:00:00.000Z","message":{"model":"claude-sonnet-4-20250514","usage":{"input_tokens":100,"output_tokens":10}}}
+{"type":"user","timestamp":"2026-01-05T10:05:00.000Z"}
+{"type":"assistant","timestamp":"2026-01-05T10:10:00.000Z","message":{"model":"claude-sonnet-4-20250514","usage":{"input_tokens":200,"output_tokens":20,"cache_read_input_tokens":1000}}}
+{"type":"assistant","timestamp":"2026-01-05T10:30:00.000Z","message":{"model":"claude-sonnet-4-20250514","usage":{"input_tokens":400,"output_tokens":40}}}
+{"type":"assistant","message":{"model":"claude-sonnet-4-20250514","usage":{"input_tokens":800,"output_tokens":80}}}
+`
+	path := filepath.Join(t.TempDir(), "transcript.jsonl")
+	if err := os.WriteFile(path, []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2026, 1, 5, 10, 5, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 5, 10, 30, 0, 0, time.UTC)
+	usage, err := ParseFileRange(path, from, to)
+	if err != nil {
+		t.Fatalf("ParseFileRange failed: %v", err)
+	}
+	if usage.InputTokens != 600 || usage.OutputTokens != 60 || usage.CacheReadTokens != 1000 || usage.MessageCount != 2 {
+		t.Errorf("Unexpected usage in range: %+v", usage)
+	}
+	if usage.CostUSD <= 0 {
+		t.Error("Expected non-zero cost")
+	}
+
+	all, _ := ParseFile(path)
+	if all.InputTokens != 1500 {
+		t.Errorf("ParseFile should include all messages, got %d", all.InputTokens)
+	}
+}
+
+func TestCountToolUses(t *testing.T) {
+	lines := `{"type":"assistant","message":{"content":[{"type":"text","text":"읽겠습니다"},{"type":"tool_use","name":"Read"}]}}
+{"type":"user","message":{"content":[{"type":"tool_result"}]}}
+{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Read"},{"type":"tool_use","name":"Grep"}]}}
+not json
+`
+	path := filepath.Join(t.TempDir(), "agent.jsonl")
+	if err := os.WriteFile(path, []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := CountToolUses(path)
+	if err != nil {
+		t.Fatalf("CountToolUses failed: %v", err)
+	}
+	if counts["Read"] != 2 || counts["Grep"] != 1 || len(counts) != 2 {
+		t.Errorf("Unexpected counts: %v", counts)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	first := `{"type":"human","message":{"text":"주문 API 만들어줘"},"timestamp":"2025-01-01T00:00:00Z"}
+`
+	original := filepath.Join(dir, "a.jsonl")
+	resumed := filepath.Join(dir, "b.jsonl")
+	other := filepath.Join(dir, "c.jsonl")
+	os.WriteFile(original, []byte(first), 0644)
+	os.WriteFile(resumed, []byte(first+`{"type":"human","message":{"text":"계속"}}
+`), 0644)
+	os.WriteFile(other, []byte(`{"type":"human","message":{"text":"다른 작업"}}
+`), 0644)
+
+	fp := Fingerprint(original)
+	if fp == "" {
+		t.Fatal("Fingerprint should not be empty")
+	}
+	if Fingerprint(resumed) != fp {
+		t.Error("Resumed transcript should share the fingerprint")
+	}
+	if Fingerprint(other) == fp {
+		t.Error("Different conversation should have a different fingerprint")
+	}
+	if Fingerprint(filepath.Join(dir, "missing.jsonl")) != "" {
+		t.Error("Missing transcript should have an empty fingerprint")
+	}
+}
+
+func TestParseCompactions(t *testing.T) {
+	lines := `{"type":"user","timestamp":"2026-01-05T10:00:00.000Z","message":{"content":"작업 시작"}}
+{"type":"system","subtype":"compact_boundary","uuid":"b-1","timestamp":"2026-01-05T11:00:00.000Z","compactMetadata":{"trigger":"auto","preTokens":155000}}
+{"type":"user","isCompactSummary":true,"timestamp":"2026-01-05T11:00:01.000Z","message":{"content":"이전 대화 요약"}}
+{"type":"system","subtype":"compact_boundary","uuid":"b-2","timestamp":"2026-01-05T12:00:00.000Z","compactMetadata":{"trigger":"manual","preTokens":90000}}
+{"type":"user","isCompactSummary":true,"message":{"content":[{"type":"text","text":"두 번째 요약"}]}}
+`
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	if err := os.WriteFile(path, []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	markers, err := ParseCompactions(path)
+	if err != nil {
+		t.Fatalf("ParseCompactions failed: %v", err)
+	}
+	if len(markers) != 2 {
+		t.Fatalf("Expected 2 markers, got %d", len(markers))
+	}
+	if markers[0].UUID != "b-1" || markers[0].Trigger != "auto" || markers[0].PreTokens != 155000 || markers[0].Summary != "이전 대화 요약" {
+		t.Errorf("Unexpected first marker: %+v", markers[0])
+	}
+	if markers[1].Trigger != "manual" || markers[1].Summary != "두 번째 요약" {
+		t.Errorf("Unexpected second marker: %+v", markers[1])
+	}
+}
@@ -0,0 +1,236 @@
+package trash
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+// Kind defines the origin of a trashed file
+type Kind string
+
+const (
+	KindDocument Kind = "document" // 프로젝트 문서 (document 인덱스)
+	KindKB       Kind = "kb"       // KB vault 노트
+)
+
+// RetentionPeriod is how long trashed files are kept before purge
+const RetentionPeriod = 30 * 24 * time.Hour
+
+// dirName is the trash directory created under each root (<root>/.pal/trash)
+var dirName = filepath.Join(".pal", "trash")
+
+// Item represents a soft-deleted file
+type Item struct {
+	ID        string            `json:"id"`
+	Kind      Kind              `json:"kind"`
+	Root      string            `json:"root"`       // 프로젝트 루트 또는 vault 경로
+	Path      string            `json:"path"`       // root 기준 원래 경로
+	TrashPath string            `json:"trash_path"` // 보관 중인 파일 절대 경로
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	DeletedAt time.Time         `json:"deleted_at"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// Service handles trash operations
+type Service struct {
+	db *db.DB
+}
+
+// NewService creates a new trash service
+func NewService(database *db.DB) *Service {
+	return &Service{db: database}
+}
+
+// Move soft-deletes root/relPath by moving it into <root>/.pal/trash/<id>/.
+// 만료된 항목은 이 시점에 함께 정리됩니다.
+func (s *Service) Move(kind Kind, root, relPath string, metadata map[string]string) (*Item, error) {
+	src := filepath.Join(root, relPath)
+	if _, err := os.Stat(src); err != nil {
+		return nil, fmt.Errorf("파일을 찾을 수 없습니다: %s", relPath)
+	}
+
+	id := uuid.New().String()[:8]
+	dst := filepath.Join(root, dirName, id, relPath)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return nil, fmt.Errorf("휴지통 디렉토리 생성 실패: %w", err)
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return nil, fmt.Errorf("휴지통 이동 실패: %w", err)
+	}
+
+	metaJSON, _ := json.Marshal(metadata)
+	now := time.Now()
+	_, err := s.db.Exec(`
+		INSERT INTO trash_items (id, kind, root, path, trash_path, metadata, deleted_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, id, kind, root, relPath, dst, string(metaJSON), now)
+	if err != nil {
+		// 기록 실패 시 파일 원복
+		os.Rename(dst, src)
+		return nil, fmt.Errorf("휴지통 기록 실패: %w", err)
+	}
+
+	s.PurgeExpired(now)
+
+	return &Item{
+		ID:        id,
+		Kind:      kind,
+		Root:      root,
+		Path:      relPath,
+		TrashPath: dst,
+		Metadata:  metadata,
+		DeletedAt: now,
+		ExpiresAt: now.Add(RetentionPeriod),
+	}, nil
+}
+
+// Get retrieves a trashed item by ID
+func (s *Service) Get(id string) (*Item, error) {
+	row := s.db.QueryRow(`
+		SELECT id, kind, root, path, trash_path, metadata, deleted_at
+		FROM trash_items WHERE id = ?
+	`, id)
+	item, err := scanItem(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("휴지통 항목 '%s'을(를) 찾을 수 없습니다", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// List returns trashed items, newest first. kind가 비어있으면 전체를 반환합니다.
+func (s *Service) List(kind Kind) ([]*Item, error) {
+	query := `SELECT id, kind, root, path, trash_path, metadata, deleted_at FROM trash_items`
+	var args []interface{}
+	if kind != "" {
+		query += ` WHERE kind = ?`
+		args = append(args, kind)
+	}
+	query += ` ORDER BY deleted_at DESC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("휴지통 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Restore moves a trashed file back to its original path
+func (s *Service) Restore(id string) (*Item, error) {
+	item, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := filepath.Join(item.Root, item.Path)
+	if _, err := os.Stat(dst); err == nil {
+		return nil, fmt.Errorf("원래 위치에 파일이 이미 존재합니다: %s", item.Path)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return nil, fmt.Errorf("디렉토리 생성 실패: %w", err)
+	}
+	if err := os.Rename(item.TrashPath, dst); err != nil {
+		return nil, fmt.Errorf("복원 실패: %w", err)
+	}
+
+	if _, err := s.db.Exec(`DELETE FROM trash_items WHERE id = ?`, id); err != nil {
+		return nil, fmt.Errorf("휴지통 기록 삭제 실패: %w", err)
+	}
+	s.removeItemDir(item)
+
+	return item, nil
+}
+
+// Purge permanently deletes a trashed item
+func (s *Service) Purge(id string) error {
+	item, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	return s.purge(item)
+}
+
+// PurgeExpired permanently deletes items older than RetentionPeriod
+func (s *Service) PurgeExpired(now time.Time) (int, error) {
+	rows, err := s.db.Query(`
+		SELECT id, kind, root, path, trash_path, metadata, deleted_at
+		FROM trash_items WHERE deleted_at < ?
+	`, now.Add(-RetentionPeriod))
+	if err != nil {
+		return 0, fmt.Errorf("휴지통 조회 실패: %w", err)
+	}
+
+	var expired []*Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		expired = append(expired, item)
+	}
+	rows.Close()
+
+	purged := 0
+	for _, item := range expired {
+		if err := s.purge(item); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+func (s *Service) purge(item *Item) error {
+	if err := os.Remove(item.TrashPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("파일 삭제 실패: %w", err)
+	}
+	s.removeItemDir(item)
+
+	if _, err := s.db.Exec(`DELETE FROM trash_items WHERE id = ?`, item.ID); err != nil {
+		return fmt.Errorf("휴지통 기록 삭제 실패: %w", err)
+	}
+	return nil
+}
+
+// removeItemDir cleans up the <root>/.pal/trash/<id> directory
+func (s *Service) removeItemDir(item *Item) {
+	os.RemoveAll(filepath.Join(item.Root, dirName, item.ID))
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanItem(row scanner) (*Item, error) {
+	var item Item
+	var metadata sql.NullString
+	if err := row.Scan(&item.ID, &item.Kind, &item.Root, &item.Path, &item.TrashPath,
+		&metadata, &item.DeletedAt); err != nil {
+		return nil, err
+	}
+	if metadata.Valid && metadata.String != "" {
+		json.Unmarshal([]byte(metadata.String), &item.Metadata)
+	}
+	item.ExpiresAt = item.DeletedAt.Add(RetentionPeriod)
+	return &item, nil
+}
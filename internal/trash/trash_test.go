@@ -0,0 +1,112 @@
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func setupTest(t *testing.T) (*Service, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	database, err := db.Open(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	root := filepath.Join(dir, "project")
+	if err := os.MkdirAll(filepath.Join(root, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return NewService(database), root
+}
+
+func TestMoveAndRestore(t *testing.T) {
+	svc, root := setupTest(t)
+
+	original := filepath.Join(root, "docs", "guide.md")
+	os.WriteFile(original, []byte("# Guide"), 0644)
+
+	item, err := svc.Move(KindDocument, root, "docs/guide.md", map[string]string{"type": "docs"})
+	if err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	if _, err := os.Stat(original); !os.IsNotExist(err) {
+		t.Error("Original file should be moved out")
+	}
+	if _, err := os.Stat(item.TrashPath); err != nil {
+		t.Errorf("Trashed file should exist: %v", err)
+	}
+
+	items, err := svc.List(KindDocument)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Metadata["type"] != "docs" {
+		t.Fatalf("Unexpected trash list: %+v", items)
+	}
+	if kbItems, _ := svc.List(KindKB); len(kbItems) != 0 {
+		t.Errorf("Expected no kb items, got %d", len(kbItems))
+	}
+
+	// 원래 위치에 새 파일이 있으면 복원 거부
+	os.WriteFile(original, []byte("# New"), 0644)
+	if _, err := svc.Restore(item.ID); err == nil {
+		t.Error("Restore should fail when the original path is occupied")
+	}
+	os.Remove(original)
+
+	if _, err := svc.Restore(item.ID); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	content, err := os.ReadFile(original)
+	if err != nil || string(content) != "# Guide" {
+		t.Errorf("Restored content mismatch: %q, %v", content, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, dirName, item.ID)); !os.IsNotExist(err) {
+		t.Error("Trash item directory should be cleaned up")
+	}
+	if _, err := svc.Get(item.ID); err == nil {
+		t.Error("Restored item should be removed from trash")
+	}
+}
+
+func TestPurgeExpired(t *testing.T) {
+	svc, root := setupTest(t)
+
+	os.WriteFile(filepath.Join(root, "docs", "old.md"), []byte("old"), 0644)
+	os.WriteFile(filepath.Join(root, "docs", "new.md"), []byte("new"), 0644)
+
+	old, err := svc.Move(KindKB, root, "docs/old.md", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Move(KindKB, root, "docs/new.md", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// old 항목을 보관 기간 이전으로 되돌림
+	svc.db.Exec(`UPDATE trash_items SET deleted_at = ? WHERE id = ?`,
+		time.Now().Add(-RetentionPeriod-time.Hour), old.ID)
+
+	purged, err := svc.PurgeExpired(time.Now())
+	if err != nil {
+		t.Fatalf("PurgeExpired failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("Expected 1 purged item, got %d", purged)
+	}
+	if _, err := os.Stat(old.TrashPath); !os.IsNotExist(err) {
+		t.Error("Expired file should be deleted")
+	}
+
+	items, _ := svc.List("")
+	if len(items) != 1 || items[0].Path != "docs/new.md" {
+		t.Errorf("Expected only new.md to remain, got %+v", items)
+	}
+}
@@ -0,0 +1,273 @@
+package usage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+// DefaultCostWindow is the moving average window (days) of the cost report
+const DefaultCostWindow = 7
+
+// CostBucket is the token and USD spend of one group (day, week, project, agent...)
+type CostBucket struct {
+	Key               string  `json:"key"`
+	Sessions          int     `json:"sessions"`
+	InputTokens       int64   `json:"input_tokens"`
+	OutputTokens      int64   `json:"output_tokens"`
+	CacheReadTokens   int64   `json:"cache_read_tokens"`
+	CacheCreateTokens int64   `json:"cache_create_tokens"`
+	Tokens            int64   `json:"tokens"` // input + output
+	CostUSD           float64 `json:"cost_usd"`
+}
+
+func (b *CostBucket) add(o CostBucket) {
+	b.Sessions += o.Sessions
+	b.InputTokens += o.InputTokens
+	b.OutputTokens += o.OutputTokens
+	b.CacheReadTokens += o.CacheReadTokens
+	b.CacheCreateTokens += o.CacheCreateTokens
+	b.Tokens += o.Tokens
+	b.CostUSD += o.CostUSD
+}
+
+// CostDay is one day of spend with trailing moving averages
+type CostDay struct {
+	CostBucket
+	TokensMA float64 `json:"tokens_ma"`
+	CostMA   float64 `json:"cost_ma"`
+}
+
+// CostReport aggregates session spend by day, week, project, session type, agent and port.
+// 포트별 집계는 port-end에서 포트 작업 구간에 귀속한 사용량(ports 테이블)을 사용하므로
+// 세션 합계와 일치하지 않을 수 있습니다.
+type CostReport struct {
+	Since         time.Time    `json:"since"`
+	Until         time.Time    `json:"until"`
+	Timezone      string       `json:"timezone"`
+	Window        int          `json:"window"` // 이동 평균 기간 (일)
+	Total         CostBucket   `json:"total"`
+	Daily         []CostDay    `json:"daily"`  // 기간 내 모든 날짜 (사용이 없는 날 포함)
+	Weekly        []CostBucket `json:"weekly"` // key = 주 시작일 (월요일)
+	ByProject     []CostBucket `json:"by_project"`
+	BySessionType []CostBucket `json:"by_session_type"`
+	ByAgent       []CostBucket `json:"by_agent"`
+	ByPort        []CostBucket `json:"by_port"`
+}
+
+// CostOptions filters cost aggregation
+type CostOptions struct {
+	Since    time.Time // 필수
+	Until    time.Time // 기본: 현재
+	Project  string    // project_name 또는 project_root
+	Window   int       // 이동 평균 기간 (기본 DefaultCostWindow)
+	Location *time.Location
+}
+
+// GetCosts aggregates token and USD spend of non-sandbox sessions started in [Since, Until].
+// Since는 일별 집계가 맞도록 해당 날짜의 0시(Location 기준)로 내립니다.
+func (s *Service) GetCosts(opts CostOptions) (*CostReport, error) {
+	if opts.Since.IsZero() {
+		return nil, fmt.Errorf("조회 시작 시점이 필요합니다")
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	until := opts.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	window := opts.Window
+	if window <= 0 {
+		window = DefaultCostWindow
+	}
+	since := startOfDay(opts.Since.In(loc))
+
+	query := `
+		SELECT started_at, COALESCE(project_name, ''), COALESCE(session_type, 'single'), COALESCE(agent_id, ''),
+		       COALESCE(input_tokens, 0), COALESCE(output_tokens, 0),
+		       COALESCE(cache_read_tokens, 0), COALESCE(cache_create_tokens, 0), COALESCE(cost_usd, 0)
+		FROM sessions
+		WHERE started_at >= ? AND started_at <= ? AND ` + session.NotSandbox
+	args := []interface{}{since.UTC(), until.UTC()}
+	if opts.Project != "" {
+		query += ` AND (project_name = ? OR project_root = ?)`
+		args = append(args, opts.Project, opts.Project)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("세션 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	report := &CostReport{
+		Since:    since,
+		Until:    until,
+		Timezone: loc.String(),
+		Window:   window,
+	}
+	daily := make(map[string]*CostBucket)
+	weekly := make(map[string]*CostBucket)
+	projects := make(map[string]*CostBucket)
+	types := make(map[string]*CostBucket)
+	agents := make(map[string]*CostBucket)
+
+	for rows.Next() {
+		var startedAt time.Time
+		var projectName, sessionType, agentID string
+		b := CostBucket{Sessions: 1}
+		if err := rows.Scan(&startedAt, &projectName, &sessionType, &agentID,
+			&b.InputTokens, &b.OutputTokens, &b.CacheReadTokens, &b.CacheCreateTokens, &b.CostUSD); err != nil {
+			return nil, err
+		}
+		b.Tokens = b.InputTokens + b.OutputTokens
+
+		t := startedAt.In(loc)
+		report.Total.add(b)
+		addCost(daily, t.Format("2006-01-02"), b)
+		addCost(weekly, weekStart(t).Format("2006-01-02"), b)
+		addCost(projects, orUnknown(projectName, "(unknown)"), b)
+		addCost(types, sessionType, b)
+		addCost(agents, orUnknown(agentID, "(none)"), b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	report.Daily = dailyWithAverages(daily, since, until.In(loc), window)
+	report.Weekly = sortedByKey(weekly)
+	report.ByProject = sortedByCost(projects)
+	report.BySessionType = sortedByCost(types)
+	report.ByAgent = sortedByCost(agents)
+
+	ports, err := s.portCosts(since, until, opts.Project)
+	if err != nil {
+		return nil, err
+	}
+	report.ByPort = ports
+
+	return report, nil
+}
+
+// portCosts aggregates usage attributed to ports completed (or started) in the period
+func (s *Service) portCosts(since, until time.Time, project string) ([]CostBucket, error) {
+	query := `
+		SELECT p.id, COALESCE(p.input_tokens, 0), COALESCE(p.output_tokens, 0),
+		       COALESCE(p.cache_read_tokens, 0), COALESCE(p.cache_create_tokens, 0), COALESCE(p.cost_usd, 0)
+		FROM ports p
+		LEFT JOIN sessions s ON s.id = p.session_id
+		WHERE COALESCE(p.completed_at, p.started_at) >= ? AND COALESCE(p.completed_at, p.started_at) <= ?
+		  AND (COALESCE(p.input_tokens, 0) + COALESCE(p.output_tokens, 0) > 0 OR COALESCE(p.cost_usd, 0) > 0)
+	`
+	args := []interface{}{since.UTC(), until.UTC()}
+	if project != "" {
+		query += ` AND (s.project_name = ? OR s.project_root = ?)`
+		args = append(args, project, project)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("포트 사용량 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	ports := make(map[string]*CostBucket)
+	for rows.Next() {
+		var id string
+		var b CostBucket
+		if err := rows.Scan(&id, &b.InputTokens, &b.OutputTokens, &b.CacheReadTokens, &b.CacheCreateTokens, &b.CostUSD); err != nil {
+			return nil, err
+		}
+		b.Tokens = b.InputTokens + b.OutputTokens
+		addCost(ports, id, b)
+	}
+	return sortedByCost(ports), rows.Err()
+}
+
+func addCost(m map[string]*CostBucket, key string, b CostBucket) {
+	bucket, ok := m[key]
+	if !ok {
+		bucket = &CostBucket{Key: key}
+		m[key] = bucket
+	}
+	bucket.add(b)
+}
+
+func orUnknown(v, fallback string) string {
+	if v == "" {
+		return fallback
+	}
+	return v
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// weekStart returns the Monday of t's week (local date)
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
+
+// dailyWithAverages fills every day in [since, until] (since = 0시) and computes trailing moving averages
+func dailyWithAverages(daily map[string]*CostBucket, since, until time.Time, window int) []CostDay {
+	var days []CostDay
+	for d := since; !d.After(until); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		day := CostDay{CostBucket: CostBucket{Key: key}}
+		if b, ok := daily[key]; ok {
+			day.CostBucket = *b
+		}
+		days = append(days, day)
+	}
+
+	// 앞쪽 날짜는 가능한 일수만으로 평균
+	var tokens int64
+	var cost float64
+	for i := range days {
+		tokens += days[i].Tokens
+		cost += days[i].CostUSD
+		if i >= window {
+			tokens -= days[i-window].Tokens
+			cost -= days[i-window].CostUSD
+		}
+		n := window
+		if i+1 < window {
+			n = i + 1
+		}
+		days[i].TokensMA = float64(tokens) / float64(n)
+		days[i].CostMA = cost / float64(n)
+	}
+	return days
+}
+
+func sortedByKey(m map[string]*CostBucket) []CostBucket {
+	out := make([]CostBucket, 0, len(m))
+	for _, b := range m {
+		out = append(out, *b)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+func sortedByCost(m map[string]*CostBucket) []CostBucket {
+	out := make([]CostBucket, 0, len(m))
+	for _, b := range m {
+		out = append(out, *b)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CostUSD != out[j].CostUSD {
+			return out[i].CostUSD > out[j].CostUSD
+		}
+		if out[i].Tokens != out[j].Tokens {
+			return out[i].Tokens > out[j].Tokens
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
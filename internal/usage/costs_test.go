@@ -0,0 +1,93 @@
+package usage
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func TestGetCosts(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer database.Close()
+
+	// 2026-01-05 = 월요일
+	day := func(d, h int) time.Time { return time.Date(2026, 1, d, h, 0, 0, 0, time.UTC) }
+	sessions := []struct {
+		id      string
+		started time.Time
+		tokens  int64
+		cost    float64
+		agent   string
+		project string
+		typ     string
+		sandbox int
+	}{
+		{"s1", day(5, 10), 1000, 1.0, "worker", "app", "single", 0},
+		{"s2", day(5, 15), 3000, 2.0, "worker", "app", "sub", 0},
+		{"s3", day(7, 9), 500, 0.5, "", "lib", "single", 0},
+		{"s4", day(12, 9), 800, 1.5, "planner", "app", "single", 0},
+		{"s5", day(7, 9), 9999, 9.0, "worker", "app", "single", 1}, // 샌드박스 제외
+		{"s6", day(1, 9), 7777, 7.0, "worker", "app", "single", 0}, // 기간 밖
+	}
+	for _, s := range sessions {
+		_, err := database.Exec(`
+			INSERT INTO sessions (id, started_at, input_tokens, output_tokens, cost_usd, agent_id, project_name, session_type, sandbox)
+			VALUES (?, ?, ?, 0, ?, ?, ?, ?, ?)
+		`, s.id, s.started, s.tokens, s.cost, s.agent, s.project, s.typ, s.sandbox)
+		if err != nil {
+			t.Fatalf("Failed to insert session: %v", err)
+		}
+	}
+	database.Exec(`INSERT INTO ports (id, session_id, completed_at, input_tokens, output_tokens, cost_usd) VALUES ('p1', 's1', ?, 600, 100, 0.7)`, day(5, 12))
+
+	svc := NewService(database)
+	report, err := svc.GetCosts(CostOptions{Since: day(5, 8), Until: day(12, 23), Window: 3, Location: time.UTC})
+	if err != nil {
+		t.Fatalf("GetCosts failed: %v", err)
+	}
+
+	if report.Total.Sessions != 4 || report.Total.Tokens != 5300 || math.Abs(report.Total.CostUSD-5.0) > 1e-9 {
+		t.Errorf("Unexpected total: %+v", report.Total)
+	}
+	if len(report.Daily) != 8 || report.Daily[0].Key != "2026-01-05" || report.Daily[0].CostUSD != 3.0 {
+		t.Fatalf("Unexpected daily: %+v", report.Daily)
+	}
+	// 1/7 이동 평균 = (3.0 + 0 + 0.5) / 3
+	if got := report.Daily[2].CostMA; math.Abs(got-3.5/3) > 1e-9 {
+		t.Errorf("Unexpected moving average on 01-07: %v", got)
+	}
+	// 첫날은 가능한 일수(1일)로 평균
+	if report.Daily[0].TokensMA != 4000 {
+		t.Errorf("Unexpected first-day average: %v", report.Daily[0].TokensMA)
+	}
+
+	if len(report.Weekly) != 2 || report.Weekly[0].Key != "2026-01-05" || report.Weekly[1].Key != "2026-01-12" {
+		t.Errorf("Unexpected weekly: %+v", report.Weekly)
+	}
+	if report.ByProject[0].Key != "app" || report.ByProject[0].Sessions != 3 {
+		t.Errorf("Unexpected projects: %+v", report.ByProject)
+	}
+	if len(report.BySessionType) != 2 || report.BySessionType[1].Key != "sub" {
+		t.Errorf("Unexpected session types: %+v", report.BySessionType)
+	}
+	if len(report.ByAgent) != 3 || report.ByAgent[0].Key != "worker" {
+		t.Errorf("Unexpected agents: %+v", report.ByAgent)
+	}
+	if len(report.ByPort) != 1 || report.ByPort[0].Key != "p1" || report.ByPort[0].Tokens != 700 {
+		t.Errorf("Unexpected ports: %+v", report.ByPort)
+	}
+
+	filtered, err := svc.GetCosts(CostOptions{Since: day(5, 8), Until: day(12, 23), Project: "lib", Location: time.UTC})
+	if err != nil {
+		t.Fatalf("GetCosts with project failed: %v", err)
+	}
+	if filtered.Total.Sessions != 1 || len(filtered.ByPort) != 0 {
+		t.Errorf("Unexpected project filter result: %+v", filtered.Total)
+	}
+}
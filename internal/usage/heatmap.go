@@ -0,0 +1,178 @@
+package usage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/session"
+)
+
+// Heatmap grouping
+const (
+	GroupByAgent   = "agent"
+	GroupByProject = "project"
+)
+
+// HeatCell aggregates sessions started within a time bucket
+type HeatCell struct {
+	Sessions int     `json:"sessions"`
+	Tokens   int64   `json:"tokens"`
+	CostUSD  float64 `json:"cost_usd"`
+}
+
+func (c *HeatCell) add(tokens int64, cost float64) {
+	c.Sessions++
+	c.Tokens += tokens
+	c.CostUSD += cost
+}
+
+// HeatGroup is the hourly usage of a single agent or project
+type HeatGroup struct {
+	Name  string       `json:"name"`
+	Hours [24]HeatCell `json:"hours"`
+	Total HeatCell     `json:"total"`
+}
+
+// Heatmap shows when sessions run and what they consume, by weekday × hour.
+// 요일은 time.Weekday 순서 (0 = 일요일) 입니다.
+type Heatmap struct {
+	Since      time.Time       `json:"since"`
+	Timezone   string          `json:"timezone"`
+	GroupBy    string          `json:"group_by"`
+	Cells      [7][24]HeatCell `json:"cells"`
+	Hours      [24]HeatCell    `json:"hours"`
+	Groups     []HeatGroup     `json:"groups"`
+	Total      HeatCell        `json:"total"`
+	PeakHours  []int           `json:"peak_hours"`  // 토큰 사용이 가장 많은 시간대
+	QuietHours []int           `json:"quiet_hours"` // 토큰 사용이 가장 적은 시간대 (오프피크 후보)
+}
+
+// HeatmapOptions filters heatmap aggregation
+type HeatmapOptions struct {
+	Since    time.Time
+	Project  string // project_name 또는 project_root
+	GroupBy  string // agent (기본) | project
+	Location *time.Location
+}
+
+// GetHeatmap aggregates session usage by weekday/hour and by agent or project
+func (s *Service) GetHeatmap(opts HeatmapOptions) (*Heatmap, error) {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	groupBy := opts.GroupBy
+	if groupBy == "" {
+		groupBy = GroupByAgent
+	}
+	if groupBy != GroupByAgent && groupBy != GroupByProject {
+		return nil, fmt.Errorf("알 수 없는 그룹 기준: %s (agent, project)", groupBy)
+	}
+
+	query := `
+		SELECT started_at, COALESCE(input_tokens, 0) + COALESCE(output_tokens, 0),
+		       COALESCE(cost_usd, 0), agent_id, project_name
+		FROM sessions
+		WHERE started_at IS NOT NULL AND ` + session.NotSandbox + `
+	`
+	var args []interface{}
+	if !opts.Since.IsZero() {
+		query += ` AND started_at >= ?`
+		args = append(args, opts.Since.UTC())
+	}
+	if opts.Project != "" {
+		query += ` AND (project_name = ? OR project_root = ?)`
+		args = append(args, opts.Project, opts.Project)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("세션 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	hm := &Heatmap{
+		Since:    opts.Since,
+		Timezone: loc.String(),
+		GroupBy:  groupBy,
+	}
+	groups := make(map[string]*HeatGroup)
+
+	for rows.Next() {
+		var startedAt time.Time
+		var tokens int64
+		var cost float64
+		var agentID, projectName sql.NullString
+		if err := rows.Scan(&startedAt, &tokens, &cost, &agentID, &projectName); err != nil {
+			continue
+		}
+
+		t := startedAt.In(loc)
+		day, hour := int(t.Weekday()), t.Hour()
+		hm.Cells[day][hour].add(tokens, cost)
+		hm.Hours[hour].add(tokens, cost)
+		hm.Total.add(tokens, cost)
+
+		name := "(none)"
+		if groupBy == GroupByProject {
+			name = "(unknown)"
+			if projectName.Valid && projectName.String != "" {
+				name = projectName.String
+			}
+		} else if agentID.Valid && agentID.String != "" {
+			name = agentID.String
+		}
+
+		g, ok := groups[name]
+		if !ok {
+			g = &HeatGroup{Name: name}
+			groups[name] = g
+		}
+		g.Hours[hour].add(tokens, cost)
+		g.Total.add(tokens, cost)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, g := range groups {
+		hm.Groups = append(hm.Groups, *g)
+	}
+	sort.Slice(hm.Groups, func(i, j int) bool {
+		if hm.Groups[i].Total.Tokens != hm.Groups[j].Total.Tokens {
+			return hm.Groups[i].Total.Tokens > hm.Groups[j].Total.Tokens
+		}
+		return hm.Groups[i].Name < hm.Groups[j].Name
+	})
+
+	hm.PeakHours, hm.QuietHours = rankHours(hm.Hours, 3)
+	return hm, nil
+}
+
+// rankHours returns the n busiest and n quietest hours by token usage
+func rankHours(hours [24]HeatCell, n int) (peak, quiet []int) {
+	order := make([]int, 24)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return hours[order[i]].Tokens > hours[order[j]].Tokens
+	})
+
+	for _, h := range order {
+		if len(peak) == n || hours[h].Tokens == 0 {
+			break
+		}
+		peak = append(peak, h)
+	}
+	if len(peak) == 0 {
+		return nil, nil
+	}
+	for i := len(order) - 1; i >= 0 && len(quiet) < n; i-- {
+		quiet = append(quiet, order[i])
+	}
+	sort.Ints(quiet)
+	return peak, quiet
+}
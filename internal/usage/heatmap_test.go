@@ -0,0 +1,73 @@
+package usage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/n0roo/pal-kit/internal/db"
+)
+
+func TestGetHeatmap(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer database.Close()
+
+	// 2026-01-05 = 월요일
+	monday10 := time.Date(2026, 1, 5, 10, 15, 0, 0, time.UTC)
+	sessions := []struct {
+		id      string
+		started time.Time
+		tokens  int64
+		cost    float64
+		agent   string
+		project string
+	}{
+		{"s1", monday10, 1000, 0.5, "worker", "app"},
+		{"s2", monday10.Add(20 * time.Minute), 3000, 1.5, "worker", "app"},
+		{"s3", monday10.Add(5 * time.Hour), 500, 0.2, "", "lib"},
+	}
+	for _, s := range sessions {
+		_, err := database.Exec(`
+			INSERT INTO sessions (id, started_at, input_tokens, output_tokens, cost_usd, agent_id, project_name)
+			VALUES (?, ?, ?, 0, ?, ?, ?)
+		`, s.id, s.started, s.tokens, s.cost, s.agent, s.project)
+		if err != nil {
+			t.Fatalf("Failed to insert session: %v", err)
+		}
+	}
+
+	svc := NewService(database)
+	hm, err := svc.GetHeatmap(HeatmapOptions{Location: time.UTC})
+	if err != nil {
+		t.Fatalf("GetHeatmap failed: %v", err)
+	}
+
+	if hm.Total.Sessions != 3 || hm.Total.Tokens != 4500 {
+		t.Errorf("Unexpected total: %+v", hm.Total)
+	}
+	cell := hm.Cells[time.Monday][10]
+	if cell.Sessions != 2 || cell.Tokens != 4000 {
+		t.Errorf("Unexpected Monday 10h cell: %+v", cell)
+	}
+	if len(hm.PeakHours) == 0 || hm.PeakHours[0] != 10 {
+		t.Errorf("Expected peak hour 10, got %v", hm.PeakHours)
+	}
+	if len(hm.Groups) != 2 || hm.Groups[0].Name != "worker" || hm.Groups[1].Name != "(none)" {
+		t.Errorf("Unexpected agent groups: %+v", hm.Groups)
+	}
+
+	byProject, err := svc.GetHeatmap(HeatmapOptions{Location: time.UTC, GroupBy: GroupByProject, Project: "lib"})
+	if err != nil {
+		t.Fatalf("GetHeatmap by project failed: %v", err)
+	}
+	if byProject.Total.Sessions != 1 || len(byProject.Groups) != 1 || byProject.Groups[0].Name != "lib" {
+		t.Errorf("Unexpected project heatmap: %+v", byProject.Groups)
+	}
+
+	if _, err := svc.GetHeatmap(HeatmapOptions{GroupBy: "model"}); err == nil {
+		t.Error("Expected error for unknown group")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/n0roo/pal-kit/internal/db"
+	"github.com/n0roo/pal-kit/internal/session"
 )
 
 // JSONLMessage represents a message from Claude Code JSONL file
@@ -185,11 +186,12 @@ func (s *Service) GetSummary(since time.Time) (*SessionUsage, error) {
 		       COALESCE(SUM(cache_read_tokens), 0), COALESCE(SUM(cache_create_tokens), 0),
 		       COALESCE(SUM(cost_usd), 0), COUNT(*)
 		FROM sessions
+		WHERE `+session.NotSandbox+`
 	`
 
 	var args []interface{}
 	if !since.IsZero() {
-		query += ` WHERE started_at >= ?`
+		query += ` AND started_at >= ?`
 		args = append(args, since)
 	}
 
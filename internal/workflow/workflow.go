@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/n0roo/pal-kit/internal/config"
+	"github.com/n0roo/pal-kit/internal/rules"
 	"gopkg.in/yaml.v3"
 )
 
@@ -299,7 +300,7 @@ func (s *Service) WriteRulesFile(ctx *Context) error {
 	content := s.GenerateRulesContent(ctx)
 	rulesPath := filepath.Join(rulesDir, "workflow.md")
 
-	if err := os.WriteFile(rulesPath, []byte(content), 0644); err != nil {
+	if err := rules.WriteFile(rulesPath, content, nil); err != nil {
 		return fmt.Errorf("rules 파일 작성 실패: %w", err)
 	}
 